@@ -38,6 +38,9 @@ const (
 	getStatePath             = "/eth/v2/debug/beacon/states"
 	getNodeVersionPath       = "/eth/v1/node/version"
 	changeBLStoExecutionPath = "/eth/v1/beacon/pool/bls_to_execution_changes"
+	getForkChoicePath        = "/prysm/v1/debug/fork_choice"
+	getExecutionClientPath   = "/prysm/v1/node/execution_client_version"
+	replayGossipPath         = "/prysm/v1/debug/gossip/replay"
 )
 
 // StateOrBlockId represents the block_id / state_id parameters that several of the Eth Beacon API methods accept.
@@ -146,6 +149,12 @@ func withSSZEncoding() reqOption {
 	}
 }
 
+func withDOTEncoding() reqOption {
+	return func(req *http.Request) {
+		req.Header.Set("Accept", "text/vnd.graphviz")
+	}
+}
+
 // get is a generic, opinionated GET function to reduce boilerplate amongst the getters in this package.
 func (c *Client) get(ctx context.Context, path string, opts ...reqOption) ([]byte, error) {
 	u := c.baseURL.ResolveReference(&url.URL{Path: path})
@@ -307,6 +316,30 @@ func (c *Client) GetNodeVersion(ctx context.Context) (*NodeVersion, error) {
 	return parseNodeVersion(d.Data.Version)
 }
 
+// ClientVersion identifies a client implementation by its two letter code, name, version and commit.
+type ClientVersion struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+}
+
+// GetExecutionClientVersion requests the versions of the client implementations run by the execution node
+// connected to the beacon node, as reported by the engine_getClientVersionV1 engine API method.
+func (c *Client) GetExecutionClientVersion(ctx context.Context) ([]*ClientVersion, error) {
+	b, err := c.get(ctx, getExecutionClientPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting execution client version")
+	}
+	d := struct {
+		Data []*ClientVersion `json:"data"`
+	}{}
+	if err := json.Unmarshal(b, &d); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling response body: %s", string(b))
+	}
+	return d.Data, nil
+}
+
 func renderGetStatePath(id StateOrBlockId) string {
 	return path.Join(getStatePath, string(id))
 }
@@ -413,6 +446,63 @@ func (c *Client) GetBLStoExecutionChanges(ctx context.Context) (*apimiddleware.B
 	return poolResponse, nil
 }
 
+// GetForkChoiceDiagnostics retrieves a detailed snapshot of the fork choice store of the beacon node.
+// The snapshot is a Graphviz DOT document when dot is true, and a JSON document otherwise.
+// The beacon node only serves this endpoint when its debug endpoints are enabled.
+func (c *Client) GetForkChoiceDiagnostics(ctx context.Context, dot bool) ([]byte, error) {
+	var opts []reqOption
+	if dot {
+		opts = append(opts, withDOTEncoding())
+	}
+	b, err := c.get(ctx, getForkChoicePath, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting fork choice diagnostics")
+	}
+	return b, nil
+}
+
+// GossipReplayResult is the result of the validation of a replayed gossip message: accept, reject or ignore.
+// Error explains why the message was not accepted, when known.
+type GossipReplayResult struct {
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ReplayGossipMessage runs the snappy compressed gossip message through the gossip validation pipeline of
+// the beacon node, as if it was received from the given peer on the topic. The peer ID may be empty.
+// The beacon node only serves this endpoint when its debug endpoints are enabled.
+func (c *Client) ReplayGossipMessage(ctx context.Context, topic, peerID string, data []byte) (*GossipReplayResult, error) {
+	u := c.baseURL.ResolveReference(&url.URL{Path: replayGossipPath})
+	body, err := json.Marshal(struct {
+		Topic  string `json:"topic"`
+		PeerId string `json:"peer_id"`
+		Data   string `json:"data"`
+	}{Topic: topic, PeerId: peerID, Data: hexutil.Encode(data)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal JSON")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid format, failed to create new POST request object")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, non200Err(resp)
+	}
+	result := &GossipReplayResult{}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, errors.Wrap(err, "error decoding gossip replay response")
+	}
+	return result, nil
+}
+
 func non200Err(response *http.Response) error {
 	bodyBytes, err := io.ReadAll(response.Body)
 	var body string
@@ -14,8 +14,10 @@ import (
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/api/gateway/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
 	"github.com/prysmaticlabs/prysm/v4/runtime"
 	"github.com/rs/cors"
+	"go.opencensus.io/plugin/ocgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
@@ -123,7 +125,7 @@ func (g *Gateway) Start() {
 
 	g.server = &http.Server{
 		Addr:              g.cfg.gatewayAddr,
-		Handler:           corsMux,
+		Handler:           tracing.NewHandler(corsMux),
 		ReadHeaderTimeout: time.Second,
 	}
 
@@ -222,6 +224,7 @@ func (g *Gateway) dialTCP(ctx context.Context, addr string) (*grpc.ClientConn, e
 	opts := []grpc.DialOption{
 		security,
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(int(g.cfg.maxCallRecvMsgSize))),
+		grpc.WithStatsHandler(&ocgrpc.ClientHandler{}),
 	}
 
 	return grpc.DialContext(ctx, addr, opts...)
@@ -243,6 +246,7 @@ func (g *Gateway) dialUnix(ctx context.Context, addr string) (*grpc.ClientConn,
 		grpc.WithInsecure(),
 		grpc.WithContextDialer(f),
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(int(g.cfg.maxCallRecvMsgSize))),
+		grpc.WithStatsHandler(&ocgrpc.ClientHandler{}),
 	}
 	return grpc.DialContext(ctx, addr, opts...)
 }
@@ -0,0 +1,187 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"go.opencensus.io/trace"
+)
+
+// ErrBlobsNotAvailable is returned when the blob sidecars committed to by a block could not
+// be retrieved before the block is imported.
+var ErrBlobsNotAvailable = errors.New("blob sidecars are not available")
+
+// blobNotifierMap keeps, per block root, a channel receiving the indices of the
+// blob sidecars saved for that block.
+type blobNotifierMap struct {
+	sync.Mutex
+	notifiers map[[32]byte]chan uint64
+}
+
+// forRoot returns the channel notified of the blob sidecars of the given block root,
+// creating it if needed.
+func (bn *blobNotifierMap) forRoot(root [32]byte) chan uint64 {
+	bn.Lock()
+	defer bn.Unlock()
+	if bn.notifiers == nil {
+		bn.notifiers = make(map[[32]byte]chan uint64)
+	}
+	c, ok := bn.notifiers[root]
+	if !ok {
+		c = make(chan uint64, fieldparams.MaxBlobsPerBlock)
+		bn.notifiers[root] = c
+	}
+	return c
+}
+
+// notify sends the index of a newly saved blob sidecar to the channel of its block root,
+// if anyone is waiting for it.
+func (bn *blobNotifierMap) notify(root [32]byte, idx uint64) {
+	bn.Lock()
+	defer bn.Unlock()
+	c, ok := bn.notifiers[root]
+	if !ok {
+		return
+	}
+	select {
+	case c <- idx:
+	default:
+	}
+}
+
+func (bn *blobNotifierMap) delete(root [32]byte) {
+	bn.Lock()
+	defer bn.Unlock()
+	delete(bn.notifiers, root)
+}
+
+// isDataAvailable blocks until all the blob sidecars committed to by the given block are available
+// in the database, or the deadline to import the block is reached. Blocks prior to Deneb, without
+// commitments or older than the blob retention window are always available.
+func (s *Service) isDataAvailable(ctx context.Context, root [32]byte, signed interfaces.ReadOnlySignedBeaconBlock) error {
+	ctx, span := trace.StartSpan(ctx, "blockChain.isDataAvailable")
+	defer span.End()
+	commitments, err := s.expectedBlobCommitments(signed)
+	if err != nil || len(commitments) == 0 {
+		return err
+	}
+	// Subscribe before looking up the database so that no sidecar saved in between is missed.
+	nc := s.blobNotifiers.forRoot(root)
+	defer s.blobNotifiers.delete(root)
+	missing, err := s.missingBlobIndices(ctx, root, commitments)
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+
+	// Wait for the missing sidecars up to the end of the slot following the block's slot.
+	deadline, err := slots.ToTime(uint64(s.genesisTime.Unix()), signed.Block().Slot()+2)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+	for {
+		select {
+		case idx := <-nc:
+			delete(missing, idx)
+			if len(missing) == 0 {
+				// Verify the commitments of the sidecars received in the meantime.
+				missing, err = s.missingBlobIndices(ctx, root, commitments)
+				if err != nil || len(missing) == 0 {
+					return err
+				}
+			}
+		case <-ctx.Done():
+			return errors.Wrapf(ErrBlobsNotAvailable, "missing blob indices %v for block %#x", sortedIndices(missing), root)
+		}
+	}
+}
+
+// areDataAvailable checks, without waiting, that the blob sidecars of each of the given blocks
+// are available in the database. It is used when importing batches of blocks during sync, where
+// sidecars are fetched along with the blocks.
+func (s *Service) areDataAvailable(ctx context.Context, blks []interfaces.ReadOnlySignedBeaconBlock, roots [][32]byte) error {
+	for i, b := range blks {
+		commitments, err := s.expectedBlobCommitments(b)
+		if err != nil {
+			return err
+		}
+		if len(commitments) == 0 {
+			continue
+		}
+		missing, err := s.missingBlobIndices(ctx, roots[i], commitments)
+		if err != nil {
+			return err
+		}
+		if len(missing) != 0 {
+			return errors.Wrapf(ErrBlobsNotAvailable, "missing blob indices %v for block %#x", sortedIndices(missing), roots[i])
+		}
+	}
+	return nil
+}
+
+// expectedBlobCommitments returns the KZG commitments of the block whose sidecars must be
+// available for the block to be imported.
+func (s *Service) expectedBlobCommitments(signed interfaces.ReadOnlySignedBeaconBlock) ([][]byte, error) {
+	if signed.Version() < version.Deneb {
+		return nil, nil
+	}
+	if !withinBlobRetention(signed.Block().Slot(), s.CurrentSlot()) {
+		return nil, nil
+	}
+	return signed.Block().Body().BlobKzgCommitments()
+}
+
+// missingBlobIndices returns the indices of the given commitments for which no sidecar is saved.
+// A saved sidecar with a commitment that does not match the block is an error.
+func (s *Service) missingBlobIndices(ctx context.Context, root [32]byte, commitments [][]byte) (map[uint64]bool, error) {
+	missing := make(map[uint64]bool, len(commitments))
+	for i := range commitments {
+		missing[uint64(i)] = true
+	}
+	sidecars, err := s.cfg.BeaconDB.BlobSidecarsByRoot(ctx, root)
+	if errors.Is(err, kv.ErrNotFoundBlobSidecars) {
+		return missing, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get blob sidecars")
+	}
+	for _, sc := range sidecars {
+		if sc.Index >= uint64(len(commitments)) {
+			continue
+		}
+		if !bytes.Equal(sc.KzgCommitment, commitments[sc.Index]) {
+			return nil, fmt.Errorf("blob sidecar %d of block %#x does not match the block kzg commitment", sc.Index, root)
+		}
+		delete(missing, sc.Index)
+	}
+	return missing, nil
+}
+
+// withinBlobRetention returns true if blob sidecars of the given slot are required to be
+// available, i.e. if it is within MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS of the current slot.
+func withinBlobRetention(slot, current primitives.Slot) bool {
+	currentEpoch := slots.ToEpoch(current)
+	minEpochs := params.BeaconNetworkConfig().MinEpochsForBlobsSidecarsRequest
+	return currentEpoch <= minEpochs || slots.ToEpoch(slot) >= currentEpoch-minEpochs
+}
+
+func sortedIndices(m map[uint64]bool) []uint64 {
+	indices := make([]uint64, 0, len(m))
+	for i := uint64(0); i < fieldparams.MaxBlobsPerBlock; i++ {
+		if m[i] {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
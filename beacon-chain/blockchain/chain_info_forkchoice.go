@@ -3,6 +3,7 @@ package blockchain
 import (
 	"context"
 
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
@@ -57,6 +58,13 @@ func (s *Service) ForkChoiceDump(ctx context.Context) (*ethpbv1.ForkChoiceDump,
 	return s.cfg.ForkChoiceStore.ForkChoiceDump(ctx)
 }
 
+// ForkChoiceDiagnostics returns the corresponding value from forkchoice
+func (s *Service) ForkChoiceDiagnostics(ctx context.Context) (*forkchoicetypes.Diagnostics, error) {
+	s.cfg.ForkChoiceStore.RLock()
+	defer s.cfg.ForkChoiceStore.RUnlock()
+	return s.cfg.ForkChoiceStore.Diagnostics(ctx)
+}
+
 // NewSlot returns the corresponding value from forkchoice
 func (s *Service) NewSlot(ctx context.Context, slot primitives.Slot) error {
 	s.cfg.ForkChoiceStore.Lock()
@@ -30,6 +30,10 @@ var (
 	// ErrNotCheckpoint is returned when a given checkpoint is not a
 	// checkpoint in any chain known to forkchoice
 	ErrNotCheckpoint = errors.New("not a checkpoint in forkchoice")
+	// ErrLightClientBootstrapUnavailable is returned when a light client bootstrap cannot be served for a block root.
+	ErrLightClientBootstrapUnavailable = errors.New("light client bootstrap not available for block root")
+	// errTooFewSyncParticipants is returned when a sync aggregate has too few participants to build a light client update.
+	errTooFewSyncParticipants = errors.New("not enough sync committee participants for light client update")
 )
 
 // An invalid block is the block that fails state transition based on the core protocol rules.
@@ -0,0 +1,99 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/sirupsen/logrus"
+)
+
+// saveForkchoiceSnapshot serializes the fork choice store and saves it to the database.
+func (s *Service) saveForkchoiceSnapshot(ctx context.Context) error {
+	s.cfg.ForkChoiceStore.RLock()
+	snapshot, err := s.cfg.ForkChoiceStore.Snapshot()
+	s.cfg.ForkChoiceStore.RUnlock()
+	if err != nil {
+		return errors.Wrap(err, "could not take fork choice snapshot")
+	}
+	if err := s.cfg.BeaconDB.SaveForkchoiceSnapshot(ctx, snapshot); err != nil {
+		return errors.Wrap(err, "could not save fork choice snapshot")
+	}
+	log.WithField("size", len(snapshot)).Debug("Saved fork choice snapshot")
+	return nil
+}
+
+// runForkchoiceSnapshots saves a fork choice snapshot to the database once per epoch.
+func (s *Service) runForkchoiceSnapshots() {
+	interval := time.Duration(uint64(params.BeaconConfig().SlotsPerEpoch)*params.BeaconConfig().SecondsPerSlot) * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.saveForkchoiceSnapshot(s.ctx); err != nil {
+				log.WithError(err).Error("Could not save fork choice snapshot")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting routine")
+			return
+		}
+	}
+}
+
+// restoreForkchoiceSnapshot restores the non-finalized part of fork choice from the snapshot saved
+// in the database, on top of a store that only contains the finalized block. Only the blocks that are
+// in the database are restored. When the restored head differs from the finalized block, the head is
+// updated so that the node does not need to relearn it from gossip. The caller must hold the fork choice lock.
+// Failures are logged and leave fork choice with only the finalized block.
+func (s *Service) restoreForkchoiceSnapshot(ctx context.Context, finalizedRoot [32]byte) {
+	snapshot, err := s.cfg.BeaconDB.ForkchoiceSnapshot(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get fork choice snapshot from db")
+		return
+	}
+	if len(snapshot) == 0 {
+		return
+	}
+	restored, err := s.cfg.ForkChoiceStore.RestoreSnapshot(ctx, snapshot, s.cfg.BeaconDB.HasBlock)
+	if err != nil {
+		log.WithError(err).Error("Could not restore fork choice snapshot")
+		return
+	}
+	if restored == 0 {
+		return
+	}
+
+	headRoot, err := s.cfg.ForkChoiceStore.Head(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not compute head from restored fork choice")
+		return
+	}
+	logFields := logrus.Fields{
+		"restoredNodes": restored,
+		"headRoot":      fmt.Sprintf("%#x", bytesutil.Trunc(headRoot[:])),
+	}
+	if headRoot == finalizedRoot {
+		log.WithFields(logFields).Info("Restored fork choice snapshot")
+		return
+	}
+	headBlock, err := s.getBlock(ctx, headRoot)
+	if err != nil {
+		log.WithError(err).Error("Could not get restored head block")
+		return
+	}
+	headState, err := s.cfg.StateGen.StateByRoot(ctx, headRoot)
+	if err != nil {
+		log.WithError(err).Error("Could not get restored head state")
+		return
+	}
+	if err := s.setHead(headRoot, headBlock, headState); err != nil {
+		log.WithError(err).Error("Could not set restored head")
+		return
+	}
+	logFields["headSlot"] = headBlock.Block().Slot()
+	log.WithFields(logFields).Info("Restored fork choice snapshot")
+}
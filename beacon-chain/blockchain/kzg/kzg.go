@@ -0,0 +1,97 @@
+// Package kzg wraps the KZG polynomial commitment library used to verify
+// EIP-4844 blob sidecars against the commitments included in a beacon block.
+package kzg
+
+import (
+	"crypto/sha256"
+	"sync"
+
+	GoKZG "github.com/crate-crypto/go-kzg-4844"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+// blobCommitmentVersionKZG is the version byte prepended to the hash of a KZG commitment.
+const blobCommitmentVersionKZG uint8 = 0x01
+
+var (
+	kzgContext     *GoKZG.Context
+	kzgContextErr  error
+	kzgContextOnce sync.Once
+
+	errNilSidecar        = errors.New("nil blob sidecar")
+	errInvalidBlobLength = errors.New("invalid blob length")
+)
+
+// context lazily loads the trusted setup, which takes a noticeable amount of
+// time and memory and is not needed by nodes that never see a Deneb block.
+func context() (*GoKZG.Context, error) {
+	kzgContextOnce.Do(func() {
+		kzgContext, kzgContextErr = GoKZG.NewContext4096Secure()
+	})
+	return kzgContext, kzgContextErr
+}
+
+// Verify performs a batched KZG proof verification over the blobs of the given
+// sidecars against their commitments and proofs.
+func Verify(sidecars ...*ethpb.BlobSidecar) error {
+	if len(sidecars) == 0 {
+		return nil
+	}
+	ctx, err := context()
+	if err != nil {
+		return errors.Wrap(err, "could not load kzg trusted setup")
+	}
+	blobs := make([]GoKZG.Blob, len(sidecars))
+	commitments := make([]GoKZG.KZGCommitment, len(sidecars))
+	proofs := make([]GoKZG.KZGProof, len(sidecars))
+	for i, sc := range sidecars {
+		if sc == nil {
+			return errNilSidecar
+		}
+		if len(sc.Blob) != fieldparams.BlobLength {
+			return errInvalidBlobLength
+		}
+		copy(blobs[i][:], sc.Blob)
+		copy(commitments[i][:], sc.KzgCommitment)
+		copy(proofs[i][:], sc.KzgProof)
+	}
+	return ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+}
+
+// ComputeCommitmentAndProof computes the KZG commitment of the given blob and the
+// proof that the commitment opens to it.
+func ComputeCommitmentAndProof(blob []byte) (commitment []byte, proof []byte, err error) {
+	if len(blob) != fieldparams.BlobLength {
+		return nil, nil, errInvalidBlobLength
+	}
+	ctx, err := context()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not load kzg trusted setup")
+	}
+	var b GoKZG.Blob
+	copy(b[:], blob)
+	c, err := ctx.BlobToKZGCommitment(b, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	p, err := ctx.ComputeBlobKZGProof(b, c, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c[:], p[:], nil
+}
+
+// KzgCommitmentToVersionedHash computes the versioned hash of a KZG commitment as
+// defined in the Deneb consensus specs.
+//
+// Spec code:
+// def kzg_commitment_to_versioned_hash(kzg_commitment: KZGCommitment) -> VersionedHash:
+//
+//	return VERSIONED_HASH_VERSION_KZG + hash(kzg_commitment)[1:]
+func KzgCommitmentToVersionedHash(commitment []byte) [32]byte {
+	versionedHash := sha256.Sum256(commitment)
+	versionedHash[0] = blobCommitmentVersionKZG
+	return versionedHash
+}
@@ -0,0 +1,34 @@
+package kzg
+
+import (
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestVerify(t *testing.T) {
+	blob := make([]byte, fieldparams.BlobLength)
+	blob[31] = 1
+	commitment, proof, err := ComputeCommitmentAndProof(blob)
+	require.NoError(t, err)
+	sc := &ethpb.BlobSidecar{Blob: blob, KzgCommitment: commitment, KzgProof: proof}
+	require.NoError(t, Verify(sc))
+
+	sc.KzgProof = commitment
+	require.NotNil(t, Verify(sc))
+}
+
+func TestVerify_NoSidecars(t *testing.T) {
+	require.NoError(t, Verify())
+}
+
+func TestVerify_InvalidBlobLength(t *testing.T) {
+	require.ErrorIs(t, Verify(&ethpb.BlobSidecar{Blob: []byte{1}}), errInvalidBlobLength)
+}
+
+func TestKzgCommitmentToVersionedHash(t *testing.T) {
+	h := KzgCommitmentToVersionedHash(make([]byte, 48))
+	require.Equal(t, blobCommitmentVersionKZG, h[0])
+}
@@ -0,0 +1,484 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	lightclient "github.com/prysmaticlabs/prysm/v4/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+const (
+	// MaxRequestLightClientUpdates is the maximum number of light client updates served in a single request.
+	MaxRequestLightClientUpdates = 128
+	// syncCommitteeBranchDepth is the depth of the Merkle branch of a sync committee within the beacon state.
+	syncCommitteeBranchDepth = 5
+	// finalityBranchDepth is the depth of the Merkle branch of the finalized root within the beacon state.
+	finalityBranchDepth = 6
+	// lightClientQueueSize is the number of blocks that can wait for their light client updates to be built.
+	// Further blocks are skipped until the queue drains.
+	lightClientQueueSize = 64
+)
+
+// lightClientBlock is a processed block queued for building light client updates.
+type lightClientBlock struct {
+	block  interfaces.ReadOnlySignedBeaconBlock
+	isHead bool
+}
+
+// LightClientFetcher defines a common interface for methods in blockchain service which
+// return data served to light clients.
+type LightClientFetcher interface {
+	LightClientBootstrap(ctx context.Context, blockRoot [32]byte) (interfaces.LightClientBootstrap, error)
+	LightClientUpdatesByRange(ctx context.Context, startPeriod, count uint64) ([]interfaces.LightClientUpdate, error)
+	LightClientFinalityUpdate() interfaces.LightClientFinalityUpdate
+	LightClientOptimisticUpdate() interfaces.LightClientOptimisticUpdate
+}
+
+// LightClientBootstrap builds the light client bootstrap for the given block root. Bootstraps are only served
+// for Altair or later blocks that are either finalized or known to fork choice, using the light client types
+// of the fork of the block.
+func (s *Service) LightClientBootstrap(ctx context.Context, blockRoot [32]byte) (interfaces.LightClientBootstrap, error) {
+	ctx, span := trace.StartSpan(ctx, "blockChain.LightClientBootstrap")
+	defer span.End()
+
+	if !s.cfg.BeaconDB.IsFinalizedBlock(ctx, blockRoot) && !s.cfg.ForkChoiceStore.HasNode(blockRoot) {
+		return nil, ErrLightClientBootstrapUnavailable
+	}
+	blk, err := s.getBlock(ctx, blockRoot)
+	if err != nil {
+		if errors.Is(err, errBlockNotFoundInCacheOrDB) {
+			return nil, ErrLightClientBootstrapUnavailable
+		}
+		return nil, err
+	}
+	if blk.Version() < version.Altair {
+		return nil, ErrLightClientBootstrapUnavailable
+	}
+	st, err := s.cfg.StateGen.StateByRoot(ctx, blockRoot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get state for block root %#x", blockRoot)
+	}
+	header, err := lightclient.HeaderFromBlock(blk, lightclient.HeaderVersion(blk.Version()))
+	if err != nil {
+		return nil, err
+	}
+	committee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current sync committee")
+	}
+	branch, err := st.CurrentSyncCommitteeProof(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current sync committee proof")
+	}
+	return lightclient.NewBootstrap(header, committee, branch)
+}
+
+// LightClientUpdatesByRange returns the best known light client update for each sync committee period in
+// [startPeriod, startPeriod+count). The result stops at the first period for which no update is known.
+func (s *Service) LightClientUpdatesByRange(ctx context.Context, startPeriod, count uint64) ([]interfaces.LightClientUpdate, error) {
+	ctx, span := trace.StartSpan(ctx, "blockChain.LightClientUpdatesByRange")
+	defer span.End()
+
+	if count == 0 {
+		return []interfaces.LightClientUpdate{}, nil
+	}
+	if count > MaxRequestLightClientUpdates {
+		count = MaxRequestLightClientUpdates
+	}
+	return s.cfg.BeaconDB.LightClientUpdates(ctx, startPeriod, startPeriod+count-1)
+}
+
+// LightClientFinalityUpdate returns the latest finality update built from the canonical chain, or nil if there is none.
+func (s *Service) LightClientFinalityUpdate() interfaces.LightClientFinalityUpdate {
+	s.lightClientLock.RLock()
+	defer s.lightClientLock.RUnlock()
+	return s.lcFinalityUpdate
+}
+
+// LightClientOptimisticUpdate returns the latest optimistic update built from the canonical chain, or nil if there is none.
+func (s *Service) LightClientOptimisticUpdate() interfaces.LightClientOptimisticUpdate {
+	s.lightClientLock.RLock()
+	defer s.lightClientLock.RUnlock()
+	return s.lcOptimisticUpdate
+}
+
+// queueLightClientBlock queues a processed block for building its light client updates in the background.
+// The block is skipped if the queue is full, so that a slow worker cannot hold up block processing.
+func (s *Service) queueLightClientBlock(blk interfaces.ReadOnlySignedBeaconBlock, isHead bool) {
+	select {
+	case s.lightClientBlocks <- lightClientBlock{block: blk, isHead: isHead}:
+	default:
+		log.WithField("slot", blk.Block().Slot()).Debug("Light client queue is full, skipping block")
+	}
+}
+
+// runLightClientUpdates builds the light client updates of the queued blocks one at a time.
+func (s *Service) runLightClientUpdates() {
+	for {
+		select {
+		case b := <-s.lightClientBlocks:
+			ctx, cancel := context.WithTimeout(s.ctx, slotDeadline)
+			if err := s.processLightClientUpdates(ctx, b.block, b.isHead); err != nil {
+				log.WithError(err).Debug("Could not process light client updates")
+			}
+			cancel()
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting routine")
+			return
+		}
+	}
+}
+
+// processLightClientUpdates builds the light client update for the sync aggregate contained in the given block,
+// keeps it as the best update of its sync committee period if it is better than the stored one and, if the block
+// is the new head, updates the latest finality and optimistic updates. New latest updates are broadcast once a
+// third of their signature slot has passed, as peers ignore them before.
+func (s *Service) processLightClientUpdates(ctx context.Context, signed interfaces.ReadOnlySignedBeaconBlock, isHead bool) error {
+	ctx, span := trace.StartSpan(ctx, "blockChain.processLightClientUpdates")
+	defer span.End()
+
+	update, err := s.createLightClientUpdate(ctx, signed)
+	if err != nil {
+		if errors.Is(err, errTooFewSyncParticipants) {
+			return nil
+		}
+		return err
+	}
+
+	if err := s.saveBestLightClientUpdate(ctx, update); err != nil {
+		return err
+	}
+	if !isHead {
+		return nil
+	}
+
+	optimistic, err := lightclient.NewOptimisticUpdate(update)
+	if err != nil {
+		return err
+	}
+	var finality interfaces.LightClientFinalityUpdate
+	if isFinalityUpdate(update) {
+		finality, err = lightclient.NewFinalityUpdate(update)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.lightClientLock.Lock()
+	newOptimistic := s.lcOptimisticUpdate == nil ||
+		optimistic.AttestedHeader().Beacon().Slot > s.lcOptimisticUpdate.AttestedHeader().Beacon().Slot
+	if newOptimistic {
+		s.lcOptimisticUpdate = optimistic
+	}
+	newFinality := finality != nil && isNewerFinalityUpdate(finality, s.lcFinalityUpdate)
+	if newFinality {
+		s.lcFinalityUpdate = finality
+	}
+	s.lightClientLock.Unlock()
+
+	if !newOptimistic {
+		optimistic = nil
+	}
+	if !newFinality {
+		finality = nil
+	}
+	if optimistic == nil && finality == nil {
+		return nil
+	}
+	broadcastTime := slots.StartTime(uint64(s.genesisTime.Unix()), update.SignatureSlot()).
+		Add(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second / 3)
+	delay := time.Until(broadcastTime)
+	if delay <= 0 {
+		s.broadcastLightClientUpdates(s.ctx, optimistic, finality)
+		return nil
+	}
+	go func() {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			s.broadcastLightClientUpdates(s.ctx, optimistic, finality)
+		case <-s.ctx.Done():
+		}
+	}()
+	return nil
+}
+
+// saveBestLightClientUpdate saves the update as the best update of its sync committee period if it is better
+// than the stored one. The lock makes the comparison and the write atomic for concurrently processed blocks.
+func (s *Service) saveBestLightClientUpdate(ctx context.Context, update interfaces.LightClientUpdate) error {
+	s.lightClientBestUpdateLock.Lock()
+	defer s.lightClientBestUpdateLock.Unlock()
+
+	period := slotPeriod(update.AttestedHeader().Beacon().Slot)
+	best, err := s.cfg.BeaconDB.LightClientUpdate(ctx, period)
+	if err != nil {
+		return errors.Wrap(err, "could not get best light client update")
+	}
+	if best != nil && !isBetterLightClientUpdate(update, best) {
+		return nil
+	}
+	if err := s.cfg.BeaconDB.SaveLightClientUpdate(ctx, period, update); err != nil {
+		return errors.Wrap(err, "could not save light client update")
+	}
+	return nil
+}
+
+// broadcastLightClientUpdates broadcasts the given optimistic and finality updates, either of which may be nil.
+// Updates which were replaced by newer ones in the meantime are not broadcast.
+func (s *Service) broadcastLightClientUpdates(ctx context.Context, optimistic interfaces.LightClientOptimisticUpdate, finality interfaces.LightClientFinalityUpdate) {
+	s.lightClientLock.RLock()
+	if optimistic != s.lcOptimisticUpdate {
+		optimistic = nil
+	}
+	if finality != s.lcFinalityUpdate {
+		finality = nil
+	}
+	s.lightClientLock.RUnlock()
+
+	if optimistic != nil {
+		if err := s.cfg.P2p.Broadcast(ctx, optimistic.Proto()); err != nil {
+			log.WithError(err).Debug("Could not broadcast light client optimistic update")
+		}
+	}
+	if finality != nil {
+		if err := s.cfg.P2p.Broadcast(ctx, finality.Proto()); err != nil {
+			log.WithError(err).Debug("Could not broadcast light client finality update")
+		}
+		log.WithFields(logrus.Fields{
+			"attestedSlot":  finality.AttestedHeader().Beacon().Slot,
+			"finalizedSlot": finality.FinalizedHeader().Beacon().Slot,
+			"signatureSlot": finality.SignatureSlot(),
+			"version":       version.String(finality.Version()),
+		}).Debug("New light client finality update")
+	}
+}
+
+// createLightClientUpdate builds a light client update for the sync aggregate contained in the given block,
+// following create_light_client_update from the light client specs. The sync aggregate attests to the
+// parent block, whose post state provides the next sync committee and the finality branches. The update
+// uses the light client types of the fork of the attested block.
+func (s *Service) createLightClientUpdate(ctx context.Context, signed interfaces.ReadOnlySignedBeaconBlock) (interfaces.LightClientUpdate, error) {
+	blk := signed.Block()
+	if blk.Version() < version.Altair {
+		return nil, errors.Errorf("light client updates are not supported for block version %s", version.String(blk.Version()))
+	}
+	syncAggregate, err := blk.Body().SyncAggregate()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync aggregate")
+	}
+	if syncAggregate.SyncCommitteeBits.Count() < params.BeaconConfig().MinSyncCommitteeParticipants {
+		return nil, errTooFewSyncParticipants
+	}
+
+	attestedRoot := blk.ParentRoot()
+	attestedBlock, err := s.getBlock(ctx, attestedRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attested block")
+	}
+	if attestedBlock.Version() < version.Altair {
+		return nil, errors.New("attested block is older than the Altair fork")
+	}
+	attestedState, err := s.cfg.StateGen.StateByRoot(ctx, attestedRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attested state")
+	}
+	lcVersion := lightclient.HeaderVersion(attestedBlock.Version())
+	attestedHeader, err := lightclient.HeaderFromBlock(attestedBlock, lcVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	nextSyncCommittee := emptySyncCommittee()
+	nextSyncCommitteeBranch := emptyBranch(syncCommitteeBranchDepth)
+	attestedPeriod := slots.SyncCommitteePeriod(slots.ToEpoch(attestedBlock.Block().Slot()))
+	signaturePeriod := slots.SyncCommitteePeriod(slots.ToEpoch(blk.Slot()))
+	if attestedPeriod == signaturePeriod {
+		committee, err := attestedState.NextSyncCommittee()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get next sync committee")
+		}
+		branch, err := attestedState.NextSyncCommitteeProof(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get next sync committee proof")
+		}
+		nextSyncCommittee = committee
+		nextSyncCommitteeBranch = branch
+	}
+
+	finalizedHeader, finalityBranch, err := s.lightClientFinalizedHeader(ctx, attestedState, lcVersion)
+	if err != nil {
+		return nil, err
+	}
+	return lightclient.NewUpdate(
+		attestedHeader,
+		nextSyncCommittee,
+		nextSyncCommitteeBranch,
+		finalizedHeader,
+		finalityBranch,
+		syncAggregate,
+		blk.Slot(),
+	)
+}
+
+// lightClientFinalizedHeader returns the finalized header of the given light client version and the finality
+// branch of the attested state. Both are left empty when the attested state has not finalized any block yet.
+func (s *Service) lightClientFinalizedHeader(
+	ctx context.Context,
+	attestedState state.BeaconState,
+	lcVersion int,
+) (interfaces.LightClientHeader, [][]byte, error) {
+	emptyHeader, err := lightclient.EmptyHeader(lcVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	emptyFinalityBranch := emptyBranch(finalityBranchDepth)
+	cp := attestedState.FinalizedCheckpoint()
+	if cp == nil {
+		return nil, nil, errNilFinalizedCheckpoint
+	}
+	finalizedRoot := bytesutil.ToBytes32(cp.Root)
+	if finalizedRoot == params.BeaconConfig().ZeroHash {
+		return emptyHeader, emptyFinalityBranch, nil
+	}
+	finalizedBlock, err := s.getBlock(ctx, finalizedRoot)
+	if err != nil {
+		// The finalized block may be missing for checkpoint synced nodes until it has been backfilled.
+		log.WithError(err).WithField("root", fmt.Sprintf("%#x", finalizedRoot)).Debug("Could not get finalized block for light client update")
+		return emptyHeader, emptyFinalityBranch, nil
+	}
+	header := emptyHeader
+	if finalizedBlock.Block().Slot() != params.BeaconConfig().GenesisSlot {
+		header, err = lightclient.HeaderFromBlock(finalizedBlock, lcVersion)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	branch, err := attestedState.FinalizedRootProof(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get finalized root proof")
+	}
+	return header, branch, nil
+}
+
+// isBetterLightClientUpdate reports whether newUpdate is a better update than oldUpdate for the same sync
+// committee period, following is_better_update from the Altair light client spec.
+func isBetterLightClientUpdate(newUpdate, oldUpdate interfaces.LightClientUpdate) bool {
+	maxActiveParticipants := newUpdate.SyncAggregate().SyncCommitteeBits.Len()
+	newNumActiveParticipants := newUpdate.SyncAggregate().SyncCommitteeBits.Count()
+	oldNumActiveParticipants := oldUpdate.SyncAggregate().SyncCommitteeBits.Count()
+	newHasSupermajority := newNumActiveParticipants*3 >= maxActiveParticipants*2
+	oldHasSupermajority := oldNumActiveParticipants*3 >= maxActiveParticipants*2
+	if newHasSupermajority != oldHasSupermajority {
+		return newHasSupermajority
+	}
+	if !newHasSupermajority && newNumActiveParticipants != oldNumActiveParticipants {
+		return newNumActiveParticipants > oldNumActiveParticipants
+	}
+
+	// Compare presence of relevant sync committee.
+	newHasRelevantSyncCommittee := isSyncCommitteeUpdate(newUpdate) &&
+		slotPeriod(newUpdate.AttestedHeader().Beacon().Slot) == slotPeriod(newUpdate.SignatureSlot())
+	oldHasRelevantSyncCommittee := isSyncCommitteeUpdate(oldUpdate) &&
+		slotPeriod(oldUpdate.AttestedHeader().Beacon().Slot) == slotPeriod(oldUpdate.SignatureSlot())
+	if newHasRelevantSyncCommittee != oldHasRelevantSyncCommittee {
+		return newHasRelevantSyncCommittee
+	}
+
+	// Compare indication of any finality.
+	newHasFinality := isFinalityUpdate(newUpdate)
+	oldHasFinality := isFinalityUpdate(oldUpdate)
+	if newHasFinality != oldHasFinality {
+		return newHasFinality
+	}
+
+	// Compare sync committee finality.
+	if newHasFinality {
+		newHasSyncCommitteeFinality := slotPeriod(newUpdate.FinalizedHeader().Beacon().Slot) == slotPeriod(newUpdate.AttestedHeader().Beacon().Slot)
+		oldHasSyncCommitteeFinality := slotPeriod(oldUpdate.FinalizedHeader().Beacon().Slot) == slotPeriod(oldUpdate.AttestedHeader().Beacon().Slot)
+		if newHasSyncCommitteeFinality != oldHasSyncCommitteeFinality {
+			return newHasSyncCommitteeFinality
+		}
+	}
+
+	// Tiebreaker 1: Sync committee participation beyond supermajority.
+	if newNumActiveParticipants != oldNumActiveParticipants {
+		return newNumActiveParticipants > oldNumActiveParticipants
+	}
+
+	// Tiebreaker 2: Prefer older data (fewer changes to best).
+	if newUpdate.AttestedHeader().Beacon().Slot != oldUpdate.AttestedHeader().Beacon().Slot {
+		return newUpdate.AttestedHeader().Beacon().Slot < oldUpdate.AttestedHeader().Beacon().Slot
+	}
+	return newUpdate.SignatureSlot() < oldUpdate.SignatureSlot()
+}
+
+// isNewerFinalityUpdate reports whether the finality update should replace the current one, following the
+// forwarding rules of the light_client_finality_update gossip topic.
+func isNewerFinalityUpdate(update, current interfaces.LightClientFinalityUpdate) bool {
+	if current == nil {
+		return true
+	}
+	newSlot := update.FinalizedHeader().Beacon().Slot
+	currentSlot := current.FinalizedHeader().Beacon().Slot
+	if newSlot != currentSlot {
+		return newSlot > currentSlot
+	}
+	return hasSupermajority(update.SyncAggregate()) && !hasSupermajority(current.SyncAggregate())
+}
+
+func hasSupermajority(agg *ethpb.SyncAggregate) bool {
+	return agg.SyncCommitteeBits.Count()*3 >= agg.SyncCommitteeBits.Len()*2
+}
+
+func isSyncCommitteeUpdate(update interfaces.LightClientUpdate) bool {
+	return !isEmptyBranch(update.NextSyncCommitteeBranch())
+}
+
+func isFinalityUpdate(update interfaces.LightClientUpdate) bool {
+	return !isEmptyBranch(update.FinalityBranch())
+}
+
+func isEmptyBranch(branch [][]byte) bool {
+	for _, b := range branch {
+		if bytesutil.ToBytes32(b) != params.BeaconConfig().ZeroHash {
+			return false
+		}
+	}
+	return true
+}
+
+func slotPeriod(slot primitives.Slot) uint64 {
+	return slots.SyncCommitteePeriod(slots.ToEpoch(slot))
+}
+
+func emptySyncCommittee() *ethpb.SyncCommittee {
+	pubkeys := make([][]byte, params.BeaconConfig().SyncCommitteeSize)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, 48)
+	}
+	return &ethpb.SyncCommittee{
+		Pubkeys:         pubkeys,
+		AggregatePubkey: make([]byte, 48),
+	}
+}
+
+func emptyBranch(depth int) [][]byte {
+	branch := make([][]byte, depth)
+	for i := range branch {
+		branch[i] = make([]byte, 32)
+	}
+	return branch
+}
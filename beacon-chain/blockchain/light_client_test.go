@@ -0,0 +1,207 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	testDB "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	lightclient "github.com/prysmaticlabs/prysm/v4/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func testSyncAggregate(participants uint64) *ethpb.SyncAggregate {
+	bits := bitfield.NewBitvector512()
+	for i := uint64(0); i < participants; i++ {
+		bits.SetBitAt(i, true)
+	}
+	return &ethpb.SyncAggregate{SyncCommitteeBits: bits, SyncCommitteeSignature: make([]byte, 96)}
+}
+
+func testLightClientHeader(t *testing.T, slot primitives.Slot) interfaces.LightClientHeader {
+	header, err := lightclient.EmptyHeader(version.Altair)
+	require.NoError(t, err)
+	header.Beacon().Slot = slot
+	return header
+}
+
+func testLightClientUpdateWithBranches(
+	t *testing.T,
+	attestedSlot primitives.Slot,
+	participants uint64,
+	nextSyncCommitteeBranch, finalityBranch [][]byte,
+) interfaces.LightClientUpdate {
+	update, err := lightclient.NewUpdate(
+		testLightClientHeader(t, attestedSlot),
+		emptySyncCommittee(),
+		nextSyncCommitteeBranch,
+		testLightClientHeader(t, 0),
+		finalityBranch,
+		testSyncAggregate(participants),
+		attestedSlot+1,
+	)
+	require.NoError(t, err)
+	return update
+}
+
+func testLightClientUpdate(t *testing.T, attestedSlot primitives.Slot, participants uint64) interfaces.LightClientUpdate {
+	return testLightClientUpdateWithBranches(t, attestedSlot, participants, emptyBranch(syncCommitteeBranchDepth), emptyBranch(finalityBranchDepth))
+}
+
+func TestIsBetterLightClientUpdate(t *testing.T) {
+	nonEmptyBranch := func(depth int) [][]byte {
+		branch := emptyBranch(depth)
+		branch[0] = bytesutil.PadTo([]byte{1}, 32)
+		return branch
+	}
+
+	t.Run("supermajority wins", func(t *testing.T) {
+		newUpdate := testLightClientUpdate(t, 100, 400)
+		oldUpdate := testLightClientUpdate(t, 100, 300)
+		assert.Equal(t, true, isBetterLightClientUpdate(newUpdate, oldUpdate))
+		assert.Equal(t, false, isBetterLightClientUpdate(oldUpdate, newUpdate))
+	})
+	t.Run("more participants without supermajority", func(t *testing.T) {
+		newUpdate := testLightClientUpdate(t, 100, 200)
+		oldUpdate := testLightClientUpdate(t, 100, 100)
+		assert.Equal(t, true, isBetterLightClientUpdate(newUpdate, oldUpdate))
+	})
+	t.Run("relevant sync committee", func(t *testing.T) {
+		newUpdate := testLightClientUpdateWithBranches(t, 100, 400, nonEmptyBranch(syncCommitteeBranchDepth), emptyBranch(finalityBranchDepth))
+		oldUpdate := testLightClientUpdate(t, 100, 500)
+		assert.Equal(t, true, isBetterLightClientUpdate(newUpdate, oldUpdate))
+	})
+	t.Run("finality", func(t *testing.T) {
+		newUpdate := testLightClientUpdateWithBranches(t, 100, 400, emptyBranch(syncCommitteeBranchDepth), nonEmptyBranch(finalityBranchDepth))
+		oldUpdate := testLightClientUpdate(t, 100, 500)
+		assert.Equal(t, true, isBetterLightClientUpdate(newUpdate, oldUpdate))
+	})
+	t.Run("more participants beyond supermajority", func(t *testing.T) {
+		newUpdate := testLightClientUpdate(t, 100, 500)
+		oldUpdate := testLightClientUpdate(t, 100, 400)
+		assert.Equal(t, true, isBetterLightClientUpdate(newUpdate, oldUpdate))
+	})
+	t.Run("older data preferred", func(t *testing.T) {
+		newUpdate := testLightClientUpdate(t, 90, 400)
+		oldUpdate := testLightClientUpdate(t, 100, 400)
+		assert.Equal(t, true, isBetterLightClientUpdate(newUpdate, oldUpdate))
+		assert.Equal(t, false, isBetterLightClientUpdate(oldUpdate, newUpdate))
+		assert.Equal(t, false, isBetterLightClientUpdate(oldUpdate, oldUpdate))
+	})
+}
+
+func TestIsNewerFinalityUpdate(t *testing.T) {
+	update := func(finalizedSlot primitives.Slot, participants uint64) interfaces.LightClientFinalityUpdate {
+		u, err := lightclient.NewWrappedFinalityUpdate(&ethpb.LightClientFinalityUpdate{
+			AttestedHeader:  testLightClientHeader(t, 0).Proto().(*ethpb.LightClientHeader),
+			FinalizedHeader: testLightClientHeader(t, finalizedSlot).Proto().(*ethpb.LightClientHeader),
+			FinalityBranch:  emptyBranch(finalityBranchDepth),
+			SyncAggregate:   testSyncAggregate(participants),
+		})
+		require.NoError(t, err)
+		return u
+	}
+
+	assert.Equal(t, true, isNewerFinalityUpdate(update(32, 1), nil))
+	assert.Equal(t, true, isNewerFinalityUpdate(update(64, 1), update(32, 512)))
+	assert.Equal(t, false, isNewerFinalityUpdate(update(32, 512), update(64, 1)))
+	assert.Equal(t, true, isNewerFinalityUpdate(update(64, 400), update(64, 100)))
+	assert.Equal(t, false, isNewerFinalityUpdate(update(64, 400), update(64, 500)))
+}
+
+func TestSlotPeriod(t *testing.T) {
+	period := primitives.Slot(params.BeaconConfig().EpochsPerSyncCommitteePeriod) * params.BeaconConfig().SlotsPerEpoch
+	assert.Equal(t, uint64(0), slotPeriod(period-1))
+	assert.Equal(t, uint64(1), slotPeriod(period))
+}
+
+func TestSaveBestLightClientUpdate(t *testing.T) {
+	ctx := context.Background()
+	s := &Service{cfg: &config{BeaconDB: testDB.SetupDB(t)}}
+
+	better := testLightClientUpdate(t, 10, 400)
+	require.NoError(t, s.saveBestLightClientUpdate(ctx, better))
+	require.NoError(t, s.saveBestLightClientUpdate(ctx, testLightClientUpdate(t, 11, 300)))
+
+	best, err := s.cfg.BeaconDB.LightClientUpdate(ctx, 0)
+	require.NoError(t, err)
+	assert.DeepEqual(t, better.Proto(), best.Proto())
+}
+
+func TestBroadcastLightClientUpdates_SkipsReplacedUpdates(t *testing.T) {
+	ctx := context.Background()
+	broadcaster := &mockBroadcaster{}
+	s := &Service{cfg: &config{P2p: broadcaster}}
+
+	optimistic := func() interfaces.LightClientOptimisticUpdate {
+		u, err := lightclient.NewOptimisticUpdate(testLightClientUpdate(t, 10, 400))
+		require.NoError(t, err)
+		return u
+	}
+	s.lcOptimisticUpdate = optimistic()
+	s.broadcastLightClientUpdates(ctx, optimistic(), nil)
+	assert.Equal(t, false, broadcaster.broadcastCalled)
+
+	s.broadcastLightClientUpdates(ctx, s.lcOptimisticUpdate, nil)
+	assert.Equal(t, true, broadcaster.broadcastCalled)
+}
+
+func TestLightClientBootstrap_UsesForkTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		block   interface{}
+		version int
+	}{
+		{name: "bellatrix", block: util.NewBeaconBlockBellatrix(), version: version.Altair},
+		{name: "capella", block: util.NewBeaconBlockCapella(), version: version.Capella},
+		{name: "deneb", block: util.NewBeaconBlockDeneb(), version: version.Deneb},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, tr := minimalTestService(t)
+			ctx := tr.ctx
+			st, _ := util.DeterministicGenesisStateAltair(t, 64)
+			blk := util.SaveBlock(t, ctx, service.cfg.BeaconDB, tt.block)
+			root, err := blk.Block().HashTreeRoot()
+			require.NoError(t, err)
+			require.NoError(t, service.cfg.BeaconDB.SaveState(ctx, st, root))
+			require.NoError(t, service.cfg.BeaconDB.SaveGenesisBlockRoot(ctx, root))
+			require.NoError(t, service.cfg.BeaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Root: root[:]}))
+
+			bootstrap, err := service.LightClientBootstrap(ctx, root)
+			require.NoError(t, err)
+			assert.Equal(t, tt.version, bootstrap.Version())
+			assert.Equal(t, tt.version, bootstrap.Header().Version())
+			if tt.version >= version.Capella {
+				execution, err := bootstrap.Header().Execution()
+				require.NoError(t, err)
+				payload, err := blk.Block().Body().Execution()
+				require.NoError(t, err)
+				executionRoot, err := execution.HashTreeRoot()
+				require.NoError(t, err)
+				payloadRoot, err := payload.HashTreeRoot()
+				require.NoError(t, err)
+				assert.Equal(t, payloadRoot, executionRoot)
+			}
+		})
+	}
+}
+
+func TestQueueLightClientBlock_SkipsWhenFull(t *testing.T) {
+	s := &Service{lightClientBlocks: make(chan lightClientBlock, 1)}
+	blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockAltair())
+	require.NoError(t, err)
+	s.queueLightClientBlock(blk, true)
+	s.queueLightClientBlock(blk, false)
+	require.Equal(t, 1, len(s.lightClientBlocks))
+	assert.Equal(t, true, (<-s.lightClientBlocks).isHead)
+}
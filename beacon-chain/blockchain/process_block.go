@@ -223,6 +223,12 @@ func (s *Service) onBlock(ctx context.Context, signed interfaces.ReadOnlySignedB
 	}
 	newBlockHeadElapsedTime.Observe(float64(time.Since(start).Milliseconds()))
 
+	if features.Get().EnableLightClient && signed.Version() >= version.Altair {
+		// Light client updates are built in the background, as they require the attested state and Merkle
+		// proofs which are not needed on the critical block processing path.
+		s.queueLightClientBlock(signed, blockRoot == headRoot)
+	}
+
 	// verify conditions for FCU, notifies FCU, and saves the new head.
 	// This function also prunes attestations, other similar operations happen in prunePostBlockOperationPools.
 	if _, err := s.forkchoiceUpdateWithExecution(ctx, headRoot, s.CurrentSlot()+1); err != nil {
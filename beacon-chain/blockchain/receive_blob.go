@@ -0,0 +1,31 @@
+package blockchain
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+// BlobReceiver interface defines the methods of chain service for receiving new
+// blob sidecars.
+type BlobReceiver interface {
+	ReceiveBlob(ctx context.Context, sidecar *ethpb.BlobSidecar) error
+}
+
+// ReceiveBlob saves a validated blob sidecar to the database and notifies any block
+// import waiting on the data availability of its block.
+func (s *Service) ReceiveBlob(ctx context.Context, sidecar *ethpb.BlobSidecar) error {
+	if sidecar == nil || sidecar.SignedBlockHeader == nil || sidecar.SignedBlockHeader.Header == nil {
+		return errors.New("nil blob sidecar")
+	}
+	if err := s.cfg.BeaconDB.SaveBlobSidecars(ctx, []*ethpb.BlobSidecar{sidecar}); err != nil {
+		return errors.Wrap(err, "could not save blob sidecar")
+	}
+	root, err := sidecar.SignedBlockHeader.Header.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	s.blobNotifiers.notify(root, sidecar.Index)
+	return nil
+}
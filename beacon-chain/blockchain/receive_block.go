@@ -47,6 +47,14 @@ func (s *Service) ReceiveBlock(ctx context.Context, block interfaces.ReadOnlySig
 		return err
 	}
 
+	// Wait for the blob sidecars of the block before importing it. This is done before
+	// acquiring the fork choice lock as it may block until the sidecars are received.
+	if err := s.isDataAvailable(ctx, blockRoot, blockCopy); err != nil {
+		err := errors.Wrap(err, "could not validate blob data availability")
+		tracing.AnnotateError(span, err)
+		return err
+	}
+
 	s.cfg.ForkChoiceStore.Lock()
 	defer s.cfg.ForkChoiceStore.Unlock()
 	// Apply state transition on the new block.
@@ -96,6 +104,12 @@ func (s *Service) ReceiveBlockBatch(ctx context.Context, blocks []interfaces.Rea
 	ctx, span := trace.StartSpan(ctx, "blockChain.ReceiveBlockBatch")
 	defer span.End()
 
+	if err := s.areDataAvailable(ctx, blocks, blkRoots); err != nil {
+		err := errors.Wrap(err, "could not validate blob data availability")
+		tracing.AnnotateError(span, err)
+		return err
+	}
+
 	s.cfg.ForkChoiceStore.Lock()
 	defer s.cfg.ForkChoiceStore.Unlock()
 
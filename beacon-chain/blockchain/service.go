@@ -45,21 +45,27 @@ import (
 // Service represents a service that handles the internal
 // logic of managing the full PoS beacon chain.
 type Service struct {
-	cfg                   *config
-	ctx                   context.Context
-	cancel                context.CancelFunc
-	genesisTime           time.Time
-	head                  *head
-	headLock              sync.RWMutex
-	originBlockRoot       [32]byte // genesis root, or weak subjectivity checkpoint root, depending on how the node is initialized
-	nextEpochBoundarySlot primitives.Slot
-	boundaryRoots         [][32]byte
-	checkpointStateCache  *cache.CheckpointStateCache
-	initSyncBlocks        map[[32]byte]interfaces.ReadOnlySignedBeaconBlock
-	initSyncBlocksLock    sync.RWMutex
-	wsVerifier            *WeakSubjectivityVerifier
-	clockSetter           startup.ClockSetter
-	clockWaiter           startup.ClockWaiter
+	cfg                       *config
+	ctx                       context.Context
+	cancel                    context.CancelFunc
+	genesisTime               time.Time
+	head                      *head
+	headLock                  sync.RWMutex
+	originBlockRoot           [32]byte // genesis root, or weak subjectivity checkpoint root, depending on how the node is initialized
+	nextEpochBoundarySlot     primitives.Slot
+	boundaryRoots             [][32]byte
+	checkpointStateCache      *cache.CheckpointStateCache
+	initSyncBlocks            map[[32]byte]interfaces.ReadOnlySignedBeaconBlock
+	initSyncBlocksLock        sync.RWMutex
+	wsVerifier                *WeakSubjectivityVerifier
+	clockSetter               startup.ClockSetter
+	clockWaiter               startup.ClockWaiter
+	lightClientLock           sync.RWMutex
+	lightClientBestUpdateLock sync.Mutex
+	lcFinalityUpdate          interfaces.LightClientFinalityUpdate
+	lcOptimisticUpdate        interfaces.LightClientOptimisticUpdate
+	lightClientBlocks         chan lightClientBlock
+	blobNotifiers             blobNotifierMap
 }
 
 // config options for the service.
@@ -99,6 +105,7 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 		checkpointStateCache: cache.NewCheckpointStateCache(),
 		initSyncBlocks:       make(map[[32]byte]interfaces.ReadOnlySignedBeaconBlock),
 		cfg:                  &config{ProposerSlotIndexCache: cache.NewProposerPayloadIDsCache()},
+		lightClientBlocks:    make(chan lightClientBlock, lightClientQueueSize),
 	}
 	for _, opt := range opts {
 		if err := opt(srv); err != nil {
@@ -131,6 +138,12 @@ func (s *Service) Start() {
 	}
 	s.spawnProcessAttestationsRoutine()
 	go s.runLateBlockTasks()
+	if features.Get().EnableLightClient {
+		go s.runLightClientUpdates()
+	}
+	if features.Get().EnableForkChoicePersistence {
+		go s.runForkchoiceSnapshots()
+	}
 }
 
 // Stop the blockchain service's main event loop and associated goroutines.
@@ -150,7 +163,14 @@ func (s *Service) Stop() error {
 		s.headLock.RUnlock()
 	}
 	// Save initial sync cached blocks to the DB before stop.
-	return s.cfg.BeaconDB.SaveBlocks(s.ctx, s.getInitSyncBlocks())
+	if err := s.cfg.BeaconDB.SaveBlocks(s.ctx, s.getInitSyncBlocks()); err != nil {
+		return err
+	}
+	// Save fork choice so that the non-finalized blocks and their votes survive the restart.
+	if features.Get().EnableForkChoicePersistence {
+		return s.saveForkchoiceSnapshot(s.ctx)
+	}
+	return nil
 }
 
 // Status always returns nil unless there is an error condition that causes
@@ -237,6 +257,9 @@ func (s *Service) StartFromSavedState(saved state.BeaconState) error {
 			}
 		}
 	}
+	if features.Get().EnableForkChoicePersistence {
+		s.restoreForkchoiceSnapshot(s.ctx, fRoot)
+	}
 	// not attempting to save initial sync blocks here, because there shouldn't be any until
 	// after the statefeed.Initialized event is fired (below)
 	if err := s.wsVerifier.VerifyWeakSubjectivity(s.ctx, finalized.Epoch); err != nil {
@@ -518,3 +518,91 @@ func (s *MockClockSetter) SetClock(g *startup.Clock) error {
 	s.G = g
 	return s.Err
 }
+
+func TestChainService_InitializeChainInfo_RestoreForkchoice(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnableForkChoicePersistence: true,
+	})
+	defer resetCfg()
+
+	genesis := util.NewBeaconBlock()
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	finalizedSlot := params.BeaconConfig().SlotsPerEpoch*2 + 1
+	finalizedBlock := util.NewBeaconBlock()
+	finalizedBlock.Block.Slot = finalizedSlot
+	finalizedBlock.Block.ParentRoot = bytesutil.PadTo(genesisRoot[:], 32)
+	finalizedState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, finalizedState.SetSlot(finalizedSlot))
+	require.NoError(t, finalizedState.SetGenesisValidatorsRoot(params.BeaconConfig().ZeroHash[:]))
+	finalizedRoot, err := finalizedBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	headBlock := util.NewBeaconBlock()
+	headBlock.Block.Slot = finalizedSlot + 1
+	headBlock.Block.ParentRoot = bytesutil.PadTo(finalizedRoot[:], 32)
+	headState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, headState.SetSlot(finalizedSlot+1))
+	require.NoError(t, headState.SetGenesisValidatorsRoot(params.BeaconConfig().ZeroHash[:]))
+	require.NoError(t, headState.SetLatestBlockHeader(util.HydrateBeaconHeader(&ethpb.BeaconBlockHeader{Slot: finalizedSlot + 1, ParentRoot: finalizedRoot[:]})))
+	headRoot, err := headBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	c, tr := minimalTestService(t, WithFinalizedStateAtStartUp(finalizedState))
+	ctx, beaconDB, stateGen := tr.ctx, tr.db, tr.sg
+
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	require.NoError(t, beaconDB.SaveState(ctx, finalizedState, finalizedRoot))
+	require.NoError(t, beaconDB.SaveState(ctx, finalizedState, genesisRoot))
+	util.SaveBlock(t, ctx, beaconDB, finalizedBlock)
+	util.SaveBlock(t, ctx, beaconDB, headBlock)
+	require.NoError(t, beaconDB.SaveState(ctx, headState, headRoot))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: slots.ToEpoch(finalizedSlot), Root: finalizedRoot[:]}))
+	require.NoError(t, stateGen.SaveState(ctx, finalizedRoot, finalizedState))
+
+	// Snapshot the fork choice store of the previous run, which knew about the head block.
+	previous := doublylinkedtree.New()
+	require.NoError(t, previous.InsertNode(ctx, finalizedState, finalizedRoot))
+	require.NoError(t, previous.InsertNode(ctx, headState, headRoot))
+	snapshot, err := previous.Snapshot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveForkchoiceSnapshot(ctx, snapshot))
+
+	require.NoError(t, c.StartFromSavedState(finalizedState))
+	assert.Equal(t, true, c.cfg.ForkChoiceStore.HasNode(headRoot))
+	r, err := c.HeadRoot(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, headRoot[:], r)
+	assert.Equal(t, headBlock.Block.Slot, c.HeadSlot())
+}
+
+func TestServiceStop_SaveForkchoiceSnapshot(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnableForkChoicePersistence: true,
+	})
+	defer resetCfg()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	beaconDB := testDB.SetupDB(t)
+	fcs := doublylinkedtree.New()
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, fcs.InsertNode(ctx, st, [32]byte{'a'}))
+	s := &Service{
+		cfg:            &config{BeaconDB: beaconDB, StateGen: stategen.New(beaconDB, fcs), ForkChoiceStore: fcs},
+		ctx:            ctx,
+		cancel:         cancel,
+		initSyncBlocks: make(map[[32]byte]interfaces.ReadOnlySignedBeaconBlock),
+	}
+	require.NoError(t, s.Stop())
+	snapshot, err := beaconDB.ForkchoiceSnapshot(context.Background())
+	require.NoError(t, err)
+	restored := doublylinkedtree.New()
+	require.NoError(t, restored.InsertNode(context.Background(), st, [32]byte{'a'}))
+	_, err = restored.RestoreSnapshot(context.Background(), snapshot, nil)
+	require.NoError(t, err)
+}
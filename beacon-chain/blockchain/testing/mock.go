@@ -18,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	state_native "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
@@ -69,6 +70,11 @@ type ChainService struct {
 	OptimisticCheckRootReceived [32]byte
 	FinalizedRoots              map[[32]byte]bool
 	OptimisticRoots             map[[32]byte]bool
+	LCBootstraps                map[[32]byte]interfaces.LightClientBootstrap
+	LCUpdates                   map[uint64]interfaces.LightClientUpdate
+	LCFinalityUpdate            interfaces.LightClientFinalityUpdate
+	LCOptimisticUpdate          interfaces.LightClientOptimisticUpdate
+	BlobsReceived               []*ethpb.BlobSidecar
 }
 
 func (s *ChainService) Ancestor(ctx context.Context, root []byte, slot primitives.Slot) ([]byte, error) {
@@ -263,6 +269,17 @@ func (s *ChainService) ReceiveBlock(ctx context.Context, block interfaces.ReadOn
 	return nil
 }
 
+// ReceiveBlob mocks ReceiveBlob method in chain service.
+func (s *ChainService) ReceiveBlob(ctx context.Context, sidecar *ethpb.BlobSidecar) error {
+	if s.DB != nil {
+		if err := s.DB.SaveBlobSidecars(ctx, []*ethpb.BlobSidecar{sidecar}); err != nil {
+			return err
+		}
+	}
+	s.BlobsReceived = append(s.BlobsReceived, sidecar)
+	return nil
+}
+
 // HeadSlot mocks HeadSlot method in chain service.
 func (s *ChainService) HeadSlot() primitives.Slot {
 	if s.State == nil {
@@ -572,6 +589,14 @@ func (s *ChainService) ForkChoiceDump(ctx context.Context) (*ethpbv1.ForkChoiceD
 	return nil, nil
 }
 
+// ForkChoiceDiagnostics mocks the same method in the chain service
+func (s *ChainService) ForkChoiceDiagnostics(ctx context.Context) (*forkchoicetypes.Diagnostics, error) {
+	if s.ForkChoiceStore != nil {
+		return s.ForkChoiceStore.Diagnostics(ctx)
+	}
+	return nil, nil
+}
+
 // NewSlot mocks the same method in the chain service
 func (s *ChainService) NewSlot(ctx context.Context, slot primitives.Slot) error {
 	if s.ForkChoiceStore != nil {
@@ -597,3 +622,31 @@ func (s *ChainService) FinalizedBlockHash() [32]byte {
 func (s *ChainService) UnrealizedJustifiedPayloadBlockHash() [32]byte {
 	return [32]byte{}
 }
+
+// LightClientBootstrap mocks the same method in the chain service.
+func (s *ChainService) LightClientBootstrap(_ context.Context, blockRoot [32]byte) (interfaces.LightClientBootstrap, error) {
+	return s.LCBootstraps[blockRoot], nil
+}
+
+// LightClientUpdatesByRange mocks the same method in the chain service.
+func (s *ChainService) LightClientUpdatesByRange(_ context.Context, startPeriod, count uint64) ([]interfaces.LightClientUpdate, error) {
+	updates := make([]interfaces.LightClientUpdate, 0)
+	for period := startPeriod; period < startPeriod+count; period++ {
+		update, ok := s.LCUpdates[period]
+		if !ok {
+			break
+		}
+		updates = append(updates, update)
+	}
+	return updates, nil
+}
+
+// LightClientFinalityUpdate mocks the same method in the chain service.
+func (s *ChainService) LightClientFinalityUpdate() interfaces.LightClientFinalityUpdate {
+	return s.LCFinalityUpdate
+}
+
+// LightClientOptimisticUpdate mocks the same method in the chain service.
+func (s *ChainService) LightClientOptimisticUpdate() interfaces.LightClientOptimisticUpdate {
+	return s.LCOptimisticUpdate
+}
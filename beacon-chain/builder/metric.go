@@ -27,4 +27,26 @@ var (
 			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
 		},
 	)
+	relayGetHeaderLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "relay_get_header_latency_milliseconds",
+			Help:    "Captures RPC latency for get header per relay in milliseconds",
+			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
+		},
+		[]string{"relay"},
+	)
+	relayRequestFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "relay_request_failures_total",
+			Help: "Number of failed builder API requests per relay and method",
+		},
+		[]string{"relay", "method"},
+	)
+	relayBidsWon = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "relay_bids_won_total",
+			Help: "Number of times the bid of a relay was selected as the highest bid",
+		},
+		[]string{"relay"},
+	)
 )
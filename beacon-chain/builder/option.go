@@ -1,6 +1,10 @@
 package builder
 
 import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/api/client/builder"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
@@ -13,21 +17,36 @@ type Option func(s *Service) error
 
 // FlagOptions for builder service flag configurations.
 func FlagOptions(c *cli.Context) ([]Option, error) {
-	endpoint := c.String(flags.MevRelayEndpoint.Name)
-	var client *builder.Client
-	if endpoint != "" {
-		var err error
-		client, err = builder.NewClient(endpoint)
+	endpoints := relayEndpoints(c.String(flags.MevRelayEndpoint.Name))
+	if len(endpoints) == 0 {
+		return []Option{WithBuilderClient(nil)}, nil
+	}
+	clients := make([]builder.BuilderClient, len(endpoints))
+	for i, e := range endpoints {
+		client, err := builder.NewClient(e)
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrapf(err, "could not create builder client for relay %s", e)
 		}
+		clients[i] = client
 	}
 	opts := []Option{
-		WithBuilderClient(client),
+		WithRelayClients(clients, c.Duration(flags.MevRelayGetHeaderTimeout.Name)),
 	}
 	return opts, nil
 }
 
+// relayEndpoints splits the comma separated list of relay endpoints.
+func relayEndpoints(flagValue string) []string {
+	endpoints := make([]string, 0)
+	for _, e := range strings.Split(flagValue, ",") {
+		e = strings.TrimSpace(e)
+		if e != "" {
+			endpoints = append(endpoints, e)
+		}
+	}
+	return endpoints
+}
+
 // WithBuilderClient sets the builder client for the beacon chain builder service.
 func WithBuilderClient(client builder.BuilderClient) Option {
 	return func(s *Service) error {
@@ -36,6 +55,15 @@ func WithBuilderClient(client builder.BuilderClient) Option {
 	}
 }
 
+// WithRelayClients configures the builder service to request bids from the given relays at once, giving each relay
+// the given timeout to respond to GetHeader requests. The highest valid bid is selected.
+func WithRelayClients(clients []builder.BuilderClient, getHeaderTimeout time.Duration) Option {
+	return func(s *Service) error {
+		s.cfg.builderClient = newMultiRelayClient(clients, getHeaderTimeout)
+		return nil
+	}
+}
+
 // WithHeadFetcher gets the head info from chain service.
 func WithHeadFetcher(svc blockchain.HeadFetcher) Option {
 	return func(s *Service) error {
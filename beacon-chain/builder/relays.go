@@ -0,0 +1,306 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	log "github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// DefaultGetHeaderTimeout is the time each relay is given to respond to a GetHeader request.
+const DefaultGetHeaderTimeout = time.Second
+
+var (
+	errNoRelays          = errors.New("no relays configured")
+	errNoBids            = errors.New("no relay returned a valid bid")
+	errAllRelays         = errors.New("request failed for all relays")
+	errNilPayload        = errors.New("nil execution payload header in blinded block")
+	errUnknownRelay      = errors.New("no relay provided a bid for the payload of the blinded block")
+	errWrongParentHash   = errors.New("bid is not built on the requested parent hash")
+	errWrongBuilder      = errors.New("bid is not signed by the builder public key of the relay")
+	errInvalidBuilderSig = errors.New("invalid builder signature")
+)
+
+// relayBid tracks the relay that provided the winning bid for a given payload, so that the blinded block
+// built on that payload can be submitted back to the same relay.
+type relayBid struct {
+	relay builder.BuilderClient
+	slot  primitives.Slot
+}
+
+// multiRelayClient implements builder.BuilderClient on top of one or more relays. GetHeader requests are sent to all
+// relays concurrently, each with its own timeout, invalid bids are dropped and the valid bid with the highest value
+// wins. SubmitBlindedBlock is only sent to the relay which provided the winning bid. Validator registrations are sent
+// to all relays.
+type multiRelayClient struct {
+	relays []builder.BuilderClient
+	// pubkeys holds the builder public key given in the URL of each relay, if any.
+	pubkeys [][]byte
+	timeout time.Duration
+	sync.Mutex
+	winners map[[32]byte]relayBid
+}
+
+var _ builder.BuilderClient = (*multiRelayClient)(nil)
+
+func newMultiRelayClient(relays []builder.BuilderClient, timeout time.Duration) *multiRelayClient {
+	if timeout <= 0 {
+		timeout = DefaultGetHeaderTimeout
+	}
+	pubkeys := make([][]byte, len(relays))
+	for i, r := range relays {
+		pubkeys[i] = relayPubkey(r.NodeURL())
+	}
+	return &multiRelayClient{
+		relays:  relays,
+		pubkeys: pubkeys,
+		timeout: timeout,
+		winners: make(map[[32]byte]relayBid),
+	}
+}
+
+// relayPubkey returns the builder public key given as the user of a relay URL, as in
+// https://0xpubkey@relay.example.com, or nil if the URL does not contain one.
+func relayPubkey(relayURL string) []byte {
+	u, err := url.Parse(relayURL)
+	if err != nil || u.User == nil {
+		return nil
+	}
+	pubkey, err := hexutil.Decode(u.User.Username())
+	if err != nil || len(pubkey) != fieldparams.BLSPubkeyLength {
+		return nil
+	}
+	return pubkey
+}
+
+// NodeURL returns the comma separated URLs of all relays.
+func (m *multiRelayClient) NodeURL() string {
+	urls := make([]string, len(m.relays))
+	for i, r := range m.relays {
+		urls[i] = r.NodeURL()
+	}
+	return strings.Join(urls, ",")
+}
+
+type relayResult struct {
+	relay builder.BuilderClient
+	bid   builder.SignedBid
+	value *big.Int
+	hash  [32]byte
+	err   error
+}
+
+// GetHeader requests a bid from every relay and returns the valid bid with the highest value.
+func (m *multiRelayClient) GetHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubkey [48]byte) (builder.SignedBid, error) {
+	ctx, span := trace.StartSpan(ctx, "builder.multiRelayClient.GetHeader")
+	defer span.End()
+	if len(m.relays) == 0 {
+		return nil, errNoRelays
+	}
+
+	results := make(chan relayResult, len(m.relays))
+	for i, r := range m.relays {
+		go func(r builder.BuilderClient, relayPubkey []byte) {
+			results <- m.getRelayHeader(ctx, r, relayPubkey, slot, parentHash, pubkey)
+		}(r, m.pubkeys[i])
+	}
+
+	var best *relayResult
+	for i := 0; i < len(m.relays); i++ {
+		res := <-results
+		if res.err != nil {
+			log.WithError(res.err).WithField("relay", res.relay.NodeURL()).Warn("Could not get header from relay")
+			continue
+		}
+		if res.bid == nil {
+			continue
+		}
+		if best == nil || res.value.Cmp(best.value) > 0 {
+			r := res
+			best = &r
+		}
+	}
+	if best == nil {
+		return nil, errNoBids
+	}
+	relayBidsWon.WithLabelValues(best.relay.NodeURL()).Inc()
+	log.WithFields(log.Fields{
+		"relay": best.relay.NodeURL(),
+		"value": best.value.String(),
+		"slot":  slot,
+	}).Debug("Selected best relay bid")
+
+	m.Lock()
+	defer m.Unlock()
+	m.winners[best.hash] = relayBid{relay: best.relay, slot: slot}
+	m.pruneWinners(slot)
+	return best.bid, nil
+}
+
+// getRelayHeader requests a header from a single relay, bounded by the relay timeout, and validates the bid.
+func (m *multiRelayClient) getRelayHeader(
+	ctx context.Context, r builder.BuilderClient, relayPubkey []byte, slot primitives.Slot, parentHash [32]byte, pubkey [48]byte,
+) relayResult {
+	res := relayResult{relay: r}
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+	start := time.Now()
+	bid, err := r.GetHeader(ctx, slot, parentHash, pubkey)
+	relayGetHeaderLatency.WithLabelValues(r.NodeURL()).Observe(float64(time.Since(start).Milliseconds()))
+	if err != nil {
+		relayRequestFailures.WithLabelValues(r.NodeURL(), "get_header").Inc()
+		res.err = err
+		return res
+	}
+	if bid == nil || bid.IsNil() {
+		return res
+	}
+	msg, err := bid.Message()
+	if err != nil {
+		relayRequestFailures.WithLabelValues(r.NodeURL(), "get_header").Inc()
+		res.err = errors.Wrap(err, "could not get bid message")
+		return res
+	}
+	header, err := msg.Header()
+	if err != nil {
+		relayRequestFailures.WithLabelValues(r.NodeURL(), "get_header").Inc()
+		res.err = errors.Wrap(err, "could not get bid header")
+		return res
+	}
+	if err := validateBid(bid, header, relayPubkey, parentHash); err != nil {
+		relayRequestFailures.WithLabelValues(r.NodeURL(), "get_header").Inc()
+		res.err = errors.Wrap(err, "invalid bid")
+		return res
+	}
+	res.bid = bid
+	res.value = bytesutil.LittleEndianBytesToBigInt(msg.Value())
+	res.hash = bytesutil.ToBytes32(header.BlockHash())
+	return res
+}
+
+// validateBid checks that the bid builds on the requested parent hash, that it is signed by the builder of the relay
+// when the relay URL includes its public key, and that the builder signature is valid.
+func validateBid(bid builder.SignedBid, header interfaces.ExecutionData, relayPubkey []byte, parentHash [32]byte) error {
+	if !bytes.Equal(header.ParentHash(), parentHash[:]) {
+		return errors.Wrapf(errWrongParentHash, "got %#x, want %#x", header.ParentHash(), parentHash)
+	}
+	msg, err := bid.Message()
+	if err != nil {
+		return errors.Wrap(err, "could not get bid message")
+	}
+	if relayPubkey != nil && !bytes.Equal(msg.Pubkey(), relayPubkey) {
+		return errors.Wrapf(errWrongBuilder, "got %#x, want %#x", msg.Pubkey(), relayPubkey)
+	}
+	d, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder, nil /* fork version */, nil /* genesis val root */)
+	if err != nil {
+		return err
+	}
+	if err := signing.VerifySigningRoot(msg, msg.Pubkey(), bid.Signature(), d); err != nil {
+		return errors.Wrap(errInvalidBuilderSig, err.Error())
+	}
+	return nil
+}
+
+// pruneWinners drops winning bids that are too old to be submitted. The caller must hold the lock.
+func (m *multiRelayClient) pruneWinners(slot primitives.Slot) {
+	for h, w := range m.winners {
+		if w.slot+params.BeaconConfig().SlotsPerEpoch < slot {
+			delete(m.winners, h)
+		}
+	}
+}
+
+// RegisterValidator sends the registrations to all relays. It succeeds if at least one relay accepted them.
+func (m *multiRelayClient) RegisterValidator(ctx context.Context, svr []*ethpb.SignedValidatorRegistrationV1) error {
+	ctx, span := trace.StartSpan(ctx, "builder.multiRelayClient.RegisterValidator")
+	defer span.End()
+	if len(m.relays) == 0 {
+		return errNoRelays
+	}
+	errs := make(chan error, len(m.relays))
+	for _, r := range m.relays {
+		go func(r builder.BuilderClient) {
+			err := r.RegisterValidator(ctx, svr)
+			if err != nil {
+				relayRequestFailures.WithLabelValues(r.NodeURL(), "register_validator").Inc()
+				log.WithError(err).WithField("relay", r.NodeURL()).Warn("Could not register validators with relay")
+			}
+			errs <- err
+		}(r)
+	}
+	var lastErr error
+	succeeded := false
+	for i := 0; i < len(m.relays); i++ {
+		if err := <-errs; err != nil {
+			lastErr = err
+		} else {
+			succeeded = true
+		}
+	}
+	if !succeeded {
+		return errors.Wrap(lastErr, errAllRelays.Error())
+	}
+	return nil
+}
+
+// SubmitBlindedBlock submits the blinded block to the relay that provided the winning bid for its payload. It fails
+// if that relay is not known, for example after a restart, as other relays cannot reveal the payload.
+func (m *multiRelayClient) SubmitBlindedBlock(ctx context.Context, sb interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, error) {
+	ctx, span := trace.StartSpan(ctx, "builder.multiRelayClient.SubmitBlindedBlock")
+	defer span.End()
+	if len(m.relays) == 0 {
+		return nil, errNoRelays
+	}
+	header, err := sb.Block().Body().Execution()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get execution payload header")
+	}
+	if header == nil || header.IsNil() {
+		return nil, errNilPayload
+	}
+	m.Lock()
+	w, ok := m.winners[bytesutil.ToBytes32(header.BlockHash())]
+	m.Unlock()
+	if !ok {
+		return nil, errors.Wrapf(errUnknownRelay, "block hash %#x", header.BlockHash())
+	}
+	payload, err := w.relay.SubmitBlindedBlock(ctx, sb)
+	if err != nil {
+		relayRequestFailures.WithLabelValues(w.relay.NodeURL(), "submit_blinded_block").Inc()
+	}
+	return payload, err
+}
+
+// Status succeeds if at least one relay is available.
+func (m *multiRelayClient) Status(ctx context.Context) error {
+	if len(m.relays) == 0 {
+		return errNoRelays
+	}
+	var lastErr error
+	for _, r := range m.relays {
+		if err := r.Status(ctx); err != nil {
+			relayRequestFailures.WithLabelValues(r.NodeURL(), "status").Inc()
+			log.WithError(err).WithField("relay", r.NodeURL()).Warn("Relay status check failed")
+			lastErr = err
+			continue
+		}
+		return nil
+	}
+	return lastErr
+}
@@ -0,0 +1,231 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	v1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+type mockRelay struct {
+	url       string
+	bid       builder.SignedBid
+	err       error
+	delay     time.Duration
+	submitted int
+}
+
+func (m *mockRelay) NodeURL() string {
+	return m.url
+}
+
+func (m *mockRelay) GetHeader(ctx context.Context, _ primitives.Slot, _ [32]byte, _ [48]byte) (builder.SignedBid, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(m.delay):
+	}
+	return m.bid, m.err
+}
+
+func (m *mockRelay) RegisterValidator(_ context.Context, _ []*ethpb.SignedValidatorRegistrationV1) error {
+	return m.err
+}
+
+func (m *mockRelay) SubmitBlindedBlock(_ context.Context, _ interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, error) {
+	m.submitted++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return blocks.WrappedExecutionPayloadCapella(&v1.ExecutionPayloadCapella{
+		ParentHash:    make([]byte, 32),
+		FeeRecipient:  make([]byte, 20),
+		StateRoot:     make([]byte, 32),
+		ReceiptsRoot:  make([]byte, 32),
+		LogsBloom:     make([]byte, 256),
+		PrevRandao:    make([]byte, 32),
+		BaseFeePerGas: make([]byte, 32),
+		BlockHash:     make([]byte, 32),
+	}, big.NewInt(0))
+}
+
+func (m *mockRelay) Status(_ context.Context) error {
+	return m.err
+}
+
+func testBid(t *testing.T, value uint64, blockHash byte) builder.SignedBid {
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	return testSignedBid(t, sk, value, blockHash, [32]byte{})
+}
+
+func testSignedBid(t *testing.T, sk bls.SecretKey, value uint64, blockHash byte, parentHash [32]byte) builder.SignedBid {
+	header := util.NewBlindedBeaconBlockCapella().Block.Body.ExecutionPayloadHeader
+	header.BlockHash = bytesutil.PadTo([]byte{blockHash}, 32)
+	header.ParentHash = parentHash[:]
+	msg := &ethpb.BuilderBidCapella{
+		Header: header,
+		Value:  bytesutil.PadTo(bytesutil.Uint64ToBytesLittleEndian(value), 32),
+		Pubkey: sk.PublicKey().Marshal(),
+	}
+	d, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder, nil, nil)
+	require.NoError(t, err)
+	sr, err := signing.ComputeSigningRoot(msg, d)
+	require.NoError(t, err)
+	bid, err := builder.WrappedSignedBuilderBidCapella(&ethpb.SignedBuilderBidCapella{
+		Message:   msg,
+		Signature: sk.Sign(sr[:]).Marshal(),
+	})
+	require.NoError(t, err)
+	return bid
+}
+
+func testBlindedBlock(t *testing.T, blockHash byte) interfaces.ReadOnlySignedBeaconBlock {
+	b := util.NewBlindedBeaconBlockCapella()
+	b.Block.Body.ExecutionPayloadHeader.BlockHash = bytesutil.PadTo([]byte{blockHash}, 32)
+	sb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	return sb
+}
+
+func TestMultiRelayClient_GetHeader_HighestBid(t *testing.T) {
+	ctx := context.Background()
+	low := &mockRelay{url: "low", bid: testBid(t, 1, 0x01)}
+	high := &mockRelay{url: "high", bid: testBid(t, 10, 0x02)}
+	failing := &mockRelay{url: "failing", err: errors.New("relay down")}
+	empty := &mockRelay{url: "empty"}
+	m := newMultiRelayClient([]builder.BuilderClient{low, high, failing, empty}, time.Second)
+
+	bid, err := m.GetHeader(ctx, 1, [32]byte{}, [48]byte{})
+	require.NoError(t, err)
+	msg, err := bid.Message()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), bytesutil.LittleEndianBytesToBigInt(msg.Value()).Uint64())
+
+	// The blinded block built on the winning bid is only submitted to the winning relay.
+	_, err = m.SubmitBlindedBlock(ctx, testBlindedBlock(t, 0x02))
+	require.NoError(t, err)
+	assert.Equal(t, 1, high.submitted)
+	assert.Equal(t, 0, low.submitted)
+	assert.Equal(t, 0, failing.submitted)
+}
+
+func TestMultiRelayClient_GetHeader_DropsInvalidBids(t *testing.T) {
+	ctx := context.Background()
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	valid := &mockRelay{url: "valid", bid: testSignedBid(t, sk, 1, 0x01, [32]byte{})}
+
+	forged := util.NewBlindedBeaconBlockCapella().Block.Body.ExecutionPayloadHeader
+	forged.BlockHash = bytesutil.PadTo([]byte{0x02}, 32)
+	forgedBid, err := builder.WrappedSignedBuilderBidCapella(&ethpb.SignedBuilderBidCapella{
+		Message: &ethpb.BuilderBidCapella{
+			Header: forged,
+			Value:  bytesutil.PadTo(bytesutil.Uint64ToBytesLittleEndian(100), 32),
+			Pubkey: sk.PublicKey().Marshal(),
+		},
+		Signature: make([]byte, 96),
+	})
+	require.NoError(t, err)
+	badSignature := &mockRelay{url: "bad-signature", bid: forgedBid}
+	wrongParent := &mockRelay{url: "wrong-parent", bid: testSignedBid(t, sk, 100, 0x03, [32]byte{0x01})}
+	other, err := bls.RandKey()
+	require.NoError(t, err)
+	wrongBuilder := &mockRelay{
+		url: fmt.Sprintf("https://%#x@wrong-builder", sk.PublicKey().Marshal()),
+		bid: testSignedBid(t, other, 100, 0x04, [32]byte{}),
+	}
+	m := newMultiRelayClient([]builder.BuilderClient{valid, badSignature, wrongParent, wrongBuilder}, time.Second)
+
+	bid, err := m.GetHeader(ctx, 1, [32]byte{}, [48]byte{})
+	require.NoError(t, err)
+	msg, err := bid.Message()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), bytesutil.LittleEndianBytesToBigInt(msg.Value()).Uint64())
+
+	m = newMultiRelayClient([]builder.BuilderClient{badSignature, wrongParent, wrongBuilder}, time.Second)
+	_, err = m.GetHeader(ctx, 1, [32]byte{}, [48]byte{})
+	require.ErrorIs(t, err, errNoBids)
+}
+
+func TestMultiRelayClient_GetHeader_Timeout(t *testing.T) {
+	ctx := context.Background()
+	slow := &mockRelay{url: "slow", bid: testBid(t, 100, 0x01), delay: time.Second}
+	fast := &mockRelay{url: "fast", bid: testBid(t, 1, 0x02)}
+	m := newMultiRelayClient([]builder.BuilderClient{slow, fast}, 50*time.Millisecond)
+
+	bid, err := m.GetHeader(ctx, 1, [32]byte{}, [48]byte{})
+	require.NoError(t, err)
+	msg, err := bid.Message()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), bytesutil.LittleEndianBytesToBigInt(msg.Value()).Uint64())
+}
+
+func TestMultiRelayClient_GetHeader_NoBids(t *testing.T) {
+	failing := &mockRelay{url: "failing", err: errors.New("relay down")}
+	empty := &mockRelay{url: "empty"}
+	m := newMultiRelayClient([]builder.BuilderClient{failing, empty}, time.Second)
+	_, err := m.GetHeader(context.Background(), 1, [32]byte{}, [48]byte{})
+	require.ErrorIs(t, err, errNoBids)
+}
+
+func TestMultiRelayClient_SubmitBlindedBlock_UnknownRelay(t *testing.T) {
+	first := &mockRelay{url: "first"}
+	second := &mockRelay{url: "second"}
+	m := newMultiRelayClient([]builder.BuilderClient{first, second}, time.Second)
+	_, err := m.SubmitBlindedBlock(context.Background(), testBlindedBlock(t, 0x03))
+	require.ErrorIs(t, err, errUnknownRelay)
+	assert.Equal(t, 0, first.submitted)
+	assert.Equal(t, 0, second.submitted)
+}
+
+func TestMultiRelayClient_RegisterValidator(t *testing.T) {
+	failing := &mockRelay{url: "failing", err: errors.New("relay down")}
+	ok := &mockRelay{url: "ok"}
+	m := newMultiRelayClient([]builder.BuilderClient{failing, ok}, time.Second)
+	require.NoError(t, m.RegisterValidator(context.Background(), []*ethpb.SignedValidatorRegistrationV1{{}}))
+
+	m = newMultiRelayClient([]builder.BuilderClient{failing}, time.Second)
+	require.ErrorContains(t, errAllRelays.Error(), m.RegisterValidator(context.Background(), []*ethpb.SignedValidatorRegistrationV1{{}}))
+}
+
+func TestMultiRelayClient_PruneWinners(t *testing.T) {
+	m := newMultiRelayClient([]builder.BuilderClient{&mockRelay{url: "relay", bid: testBid(t, 1, 0x01)}}, time.Second)
+	_, err := m.GetHeader(context.Background(), 1, [32]byte{}, [48]byte{})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(m.winners))
+	m.Lock()
+	m.pruneWinners(1000)
+	m.Unlock()
+	require.Equal(t, 0, len(m.winners))
+}
+
+func TestRelayPubkey(t *testing.T) {
+	pubkey := bytes.Repeat([]byte{0xab}, 48)
+	assert.DeepEqual(t, pubkey, relayPubkey(fmt.Sprintf("https://%#x@relay.example.com", pubkey)))
+	assert.DeepEqual(t, []byte(nil), relayPubkey("https://relay.example.com"))
+	assert.DeepEqual(t, []byte(nil), relayPubkey("https://0x1234@relay.example.com"))
+}
+
+func TestRelayEndpoints(t *testing.T) {
+	assert.DeepEqual(t, []string{}, relayEndpoints(""))
+	assert.DeepEqual(t, []string{"http://a:1"}, relayEndpoints("http://a:1"))
+	assert.DeepEqual(t, []string{"http://a:1", "http://b:2"}, relayEndpoints("http://a:1, http://b:2,"))
+}
@@ -2,16 +2,19 @@ package builder
 
 import (
 	"context"
+	"flag"
 	"testing"
 	"time"
 
 	buildertesting "github.com/prysmaticlabs/prysm/v4/api/client/builder/testing"
 	blockchainTesting "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
 	dbtesting "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/urfave/cli/v2"
 )
 
 func Test_NewServiceWithBuilder(t *testing.T) {
@@ -20,6 +23,20 @@ func Test_NewServiceWithBuilder(t *testing.T) {
 	assert.Equal(t, true, s.Configured())
 }
 
+func TestFlagOptions_SingleRelay(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(flags.MevRelayEndpoint.Name, "http://relay:1", "")
+	set.Duration(flags.MevRelayGetHeaderTimeout.Name, time.Second, "")
+	opts, err := FlagOptions(cli.NewContext(&app, set, nil))
+	require.NoError(t, err)
+	s, err := NewService(context.Background(), opts...)
+	require.NoError(t, err)
+	// A single relay goes through the same client as several relays, with the same timeout and metrics.
+	_, ok := s.c.(*multiRelayClient)
+	assert.Equal(t, true, ok)
+}
+
 func Test_NewServiceWithoutBuilder(t *testing.T) {
 	s, err := NewService(context.Background())
 	require.NoError(t, err)
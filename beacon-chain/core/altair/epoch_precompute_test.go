@@ -268,6 +268,51 @@ func TestAttestationsDeltaBellatrix(t *testing.T) {
 	require.DeepEqual(t, want, penalties)
 }
 
+func TestAttDeltas(t *testing.T) {
+	s, err := testState()
+	require.NoError(t, err)
+	validators, balance, err := InitializePrecomputeValidators(context.Background(), s)
+	require.NoError(t, err)
+	validators, balance, err = ProcessEpochParticipation(context.Background(), s, balance, validators)
+	require.NoError(t, err)
+	deltas, err := AttDeltas(s, balance, validators)
+	require.NoError(t, err)
+	rewards, penalties, err := AttestationsDelta(s, balance, validators)
+	require.NoError(t, err)
+
+	require.Equal(t, len(validators), len(deltas))
+	for i, d := range deltas {
+		assert.Equal(t, rewards[i], d.HeadReward+d.SourceReward+d.TargetReward)
+		assert.Equal(t, penalties[i], d.SourcePenalty+d.TargetPenalty+d.InactivityPenalty)
+	}
+
+	// The first validator did not vote at all.
+	assert.Equal(t, uint64(0), deltas[0].HeadReward)
+	assert.Equal(t, uint64(0), deltas[0].SourceReward)
+	assert.Equal(t, uint64(0), deltas[0].TargetReward)
+	assert.Equal(t, true, deltas[0].SourcePenalty > 0)
+	assert.Equal(t, true, deltas[0].TargetPenalty > 0)
+	// The last validator voted correctly for the source, the target and the head.
+	last := deltas[len(deltas)-1]
+	assert.Equal(t, true, last.HeadReward > 0)
+	assert.Equal(t, true, last.SourceReward > 0)
+	assert.Equal(t, true, last.TargetReward > 0)
+	assert.Equal(t, uint64(0), last.SourcePenalty+last.TargetPenalty+last.InactivityPenalty)
+
+	// Validators which are not part of the state can be used as well.
+	ideal := &precompute.Validator{
+		IsActivePrevEpoch:            true,
+		IsPrevEpochSourceAttester:    true,
+		IsPrevEpochTargetAttester:    true,
+		IsPrevEpochHeadAttester:      true,
+		CurrentEpochEffectiveBalance: params.BeaconConfig().MaxEffectiveBalance,
+	}
+	deltas, err = AttDeltas(s, balance, []*precompute.Validator{ideal})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(deltas))
+	assert.DeepEqual(t, last, deltas[0])
+}
+
 func TestProcessRewardsAndPenaltiesPrecompute_Ok(t *testing.T) {
 	s, err := testState()
 	require.NoError(t, err)
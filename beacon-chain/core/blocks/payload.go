@@ -2,11 +2,13 @@ package blocks
 
 import (
 	"bytes"
+	"fmt"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	consensus_types "github.com/prysmaticlabs/prysm/v4/consensus-types"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
@@ -220,6 +222,27 @@ func ProcessPayload(st state.BeaconState, payload interfaces.ExecutionData) (sta
 	return st, nil
 }
 
+// VerifyBlobCommitmentCount verifies that the number of blob KZG commitments in a Deneb block does not exceed
+// the maximum number of blobs per block.
+//
+// Spec code:
+//
+//	# [New in Deneb:EIP4844] Verify commitments are under limit
+//	assert len(body.blob_kzg_commitments) <= MAX_BLOBS_PER_BLOCK
+func VerifyBlobCommitmentCount(blk interfaces.ReadOnlyBeaconBlock) error {
+	if blk.Version() < version.Deneb {
+		return nil
+	}
+	kzgs, err := blk.Body().BlobKzgCommitments()
+	if err != nil {
+		return err
+	}
+	if len(kzgs) > fieldparams.MaxBlobsPerBlock {
+		return fmt.Errorf("too many kzg commitments in block: %d > %d", len(kzgs), fieldparams.MaxBlobsPerBlock)
+	}
+	return nil
+}
+
 // ValidatePayloadHeaderWhenMergeCompletes validates the payload header when the merge completes.
 func ValidatePayloadHeaderWhenMergeCompletes(st state.BeaconState, header interfaces.ExecutionData) error {
 	// Skip validation if the state is not merge compatible.
@@ -1,6 +1,7 @@
 package blocks_test
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
@@ -609,7 +610,7 @@ func Test_ProcessPayloadCapella(t *testing.T) {
 	random, err := helpers.RandaoMix(st, time.CurrentEpoch(st))
 	require.NoError(t, err)
 	payload.PrevRandao = random
-	wrapped, err := consensusblocks.WrappedExecutionPayloadCapella(payload, 0)
+	wrapped, err := consensusblocks.WrappedExecutionPayloadCapella(payload, big.NewInt(0))
 	require.NoError(t, err)
 	_, err = blocks.ProcessPayload(st, wrapped)
 	require.NoError(t, err)
@@ -873,7 +874,7 @@ func emptyPayloadHeaderCapella() (interfaces.ExecutionData, error) {
 		TransactionsRoot: make([]byte, fieldparams.RootLength),
 		WithdrawalsRoot:  make([]byte, fieldparams.RootLength),
 		ExtraData:        make([]byte, 0),
-	}, 0)
+	}, big.NewInt(0))
 }
 
 func emptyPayload() *enginev1.ExecutionPayload {
@@ -906,3 +907,16 @@ func emptyPayloadCapella() *enginev1.ExecutionPayloadCapella {
 		ExtraData:     make([]byte, 0),
 	}
 }
+
+func Test_VerifyBlobCommitmentCount(t *testing.T) {
+	b := util.NewBeaconBlockDeneb()
+	b.Block.Body.BlobKzgCommitments = make([][]byte, fieldparams.MaxBlobsPerBlock)
+	wb, err := consensusblocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	require.NoError(t, blocks.VerifyBlobCommitmentCount(wb.Block()))
+
+	b.Block.Body.BlobKzgCommitments = make([][]byte, fieldparams.MaxBlobsPerBlock+1)
+	wb, err = consensusblocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	require.ErrorContains(t, "too many kzg commitments in block", blocks.VerifyBlobCommitmentCount(wb.Block()))
+}
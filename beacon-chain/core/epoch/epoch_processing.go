@@ -134,7 +134,12 @@ func ProcessRegistryUpdates(ctx context.Context, state state.BeaconState) (state
 		return nil, errors.Wrap(err, "could not get active validator count")
 	}
 
-	churnLimit, err := helpers.ValidatorChurnLimit(activeValidatorCount)
+	var churnLimit uint64
+	if state.Version() >= version.Deneb {
+		churnLimit, err = helpers.ValidatorActivationChurnLimitDeneb(activeValidatorCount)
+	} else {
+		churnLimit, err = helpers.ValidatorChurnLimit(activeValidatorCount)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get churn limit")
 	}
@@ -224,6 +224,27 @@ func ValidatorChurnLimit(activeValidatorCount uint64) (uint64, error) {
 	return churnLimit, nil
 }
 
+// ValidatorActivationChurnLimitDeneb returns the maximum number of validators that can be activated in a given epoch
+// post Deneb. The activation churn is capped at MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT (EIP-7514).
+//
+// Spec pseudocode definition:
+//
+//	def get_validator_activation_churn_limit(state: BeaconState) -> uint64:
+//	 """
+//	 Return the validator activation churn limit for the current epoch.
+//	 """
+//	 return min(MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT, get_validator_churn_limit(state))
+func ValidatorActivationChurnLimitDeneb(activeValidatorCount uint64) (uint64, error) {
+	limit, err := ValidatorChurnLimit(activeValidatorCount)
+	if err != nil {
+		return 0, err
+	}
+	if limit > params.BeaconConfig().MaxPerEpochActivationChurnLimit {
+		return params.BeaconConfig().MaxPerEpochActivationChurnLimit, nil
+	}
+	return limit, nil
+}
+
 // BeaconProposerIndex returns proposer index of a current slot.
 //
 // Spec pseudocode definition:
@@ -0,0 +1,79 @@
+package era
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+)
+
+// headerSize is the size of an e2store record header: a 2 byte type, a 4 byte little endian data length and
+// 2 reserved bytes which must be zero.
+const headerSize = 8
+
+// recordType identifies the content of an e2store record.
+type recordType [2]byte
+
+var (
+	typeVersion         = recordType{0x65, 0x32}
+	typeCompressedBlock = recordType{0x01, 0x00}
+	typeCompressedState = recordType{0x02, 0x00}
+	typeSlotIndex       = recordType{0x69, 0x32}
+)
+
+var errReservedBytes = errors.New("e2store record header has non-zero reserved bytes")
+
+// writeRecord writes an e2store record with the given type and data, returning the number of bytes written.
+func writeRecord(w io.Writer, typ recordType, data []byte) (int64, error) {
+	if uint64(len(data)) > uint64(^uint32(0)) {
+		return 0, fmt.Errorf("e2store record of %d bytes is too large", len(data))
+	}
+	header := make([]byte, headerSize)
+	copy(header[:2], typ[:])
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(data)))
+	n, err := w.Write(header)
+	if err != nil {
+		return int64(n), err
+	}
+	m, err := w.Write(data)
+	return int64(n + m), err
+}
+
+// readRecord reads the e2store record starting at the given offset.
+func readRecord(r io.ReaderAt, offset int64) (recordType, []byte, error) {
+	header := make([]byte, headerSize)
+	if _, err := r.ReadAt(header, offset); err != nil {
+		return recordType{}, nil, errors.Wrapf(err, "could not read e2store record header at offset %d", offset)
+	}
+	if header[6] != 0 || header[7] != 0 {
+		return recordType{}, nil, errReservedBytes
+	}
+	var typ recordType
+	copy(typ[:], header[:2])
+	data := make([]byte, binary.LittleEndian.Uint32(header[2:6]))
+	if _, err := r.ReadAt(data, offset+headerSize); err != nil {
+		return recordType{}, nil, errors.Wrapf(err, "could not read e2store record data at offset %d", offset)
+	}
+	return typ, data, nil
+}
+
+// compress encodes SSZ data with the snappy framing format, as used by compressed e2store records.
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := snappy.NewBufferedWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompress decodes snappy framed data.
+func decompress(data []byte) ([]byte, error) {
+	return io.ReadAll(snappy.NewReader(bytes.NewReader(data)))
+}
@@ -0,0 +1,354 @@
+// Package era reads and writes era files, which store the finalized blocks and the matching state of one
+// SLOTS_PER_HISTORICAL_ROOT period in snappy-compressed SSZ, along with a slot index.
+//
+// An era file is an e2store file with the following layout:
+//
+//	era := version | block* | state | block-index | state-index
+//
+// Era N holds the canonical blocks from slot (N-1)*SLOTS_PER_HISTORICAL_ROOT up to, but excluding, slot
+// N*SLOTS_PER_HISTORICAL_ROOT, and the state at slot N*SLOTS_PER_HISTORICAL_ROOT. Era 0 only holds the
+// genesis state and has no block index. The state's historical roots commit to the blocks of the era, so
+// that an era file can be verified on its own.
+package era
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+var (
+	errStateWritten    = errors.New("era state was already written")
+	errStateNotWritten = errors.New("era state must be written before closing the era file")
+	errUnexpectedSlot  = errors.New("slot is outside of the era")
+	errInvalidIndex    = errors.New("invalid era slot index")
+	errUnexpectedType  = errors.New("unexpected e2store record type")
+)
+
+// StateSlot returns the slot of the state stored in the given era.
+func StateSlot(era uint64) primitives.Slot {
+	return primitives.Slot(era).Mul(uint64(params.BeaconConfig().SlotsPerHistoricalRoot))
+}
+
+// StartSlot returns the slot of the first block stored in the given era.
+func StartSlot(era uint64) primitives.Slot {
+	if era == 0 {
+		return 0
+	}
+	return StateSlot(era - 1)
+}
+
+// Filename returns the conventional name of an era file, made of the network name, the era number and
+// the first 4 bytes of the era root.
+func Filename(network string, era uint64, root [32]byte) string {
+	return fmt.Sprintf("%s-%05d-%x.era", network, era, root[:4])
+}
+
+// Root returns the root identifying the given era: the genesis validators root for era 0, and otherwise the
+// historical root or historical summary root committing to the blocks and states of the era, as found in
+// the era state.
+func Root(st state.ReadOnlyBeaconState, era uint64) ([32]byte, error) {
+	if era == 0 {
+		return bytesutil.ToBytes32(st.GenesisValidatorsRoot()), nil
+	}
+	historicalRoots, err := st.HistoricalRoots()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if era <= uint64(len(historicalRoots)) {
+		return bytesutil.ToBytes32(historicalRoots[era-1]), nil
+	}
+	summaries, err := st.HistoricalSummaries()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	i := era - 1 - uint64(len(historicalRoots))
+	if i >= uint64(len(summaries)) {
+		return [32]byte{}, fmt.Errorf("state at slot %d has no historical root for era %d", st.Slot(), era)
+	}
+	return summaries[i].HashTreeRoot()
+}
+
+// Writer writes the blocks and the state of an era to an era file. Blocks must be written in increasing slot
+// order, followed by the state, before the Writer is closed.
+type Writer struct {
+	w            io.Writer
+	era          uint64
+	offset       int64
+	blockOffsets []int64
+	stateOffset  int64
+	lastSlot     primitives.Slot
+	hasBlock     bool
+}
+
+// NewWriter starts writing the given era to w.
+func NewWriter(w io.Writer, era uint64) (*Writer, error) {
+	ew := &Writer{w: w, era: era, stateOffset: -1}
+	if era > 0 {
+		ew.blockOffsets = make([]int64, params.BeaconConfig().SlotsPerHistoricalRoot)
+	}
+	if err := ew.write(typeVersion, nil); err != nil {
+		return nil, err
+	}
+	return ew, nil
+}
+
+// WriteBlock appends a compressed block to the era file.
+func (w *Writer) WriteBlock(b interfaces.ReadOnlySignedBeaconBlock) error {
+	if w.stateOffset >= 0 {
+		return errStateWritten
+	}
+	slot := b.Block().Slot()
+	start := StartSlot(w.era)
+	if w.era == 0 || slot < start || slot >= StateSlot(w.era) {
+		return errors.Wrapf(errUnexpectedSlot, "block at slot %d does not belong to era %d", slot, w.era)
+	}
+	if w.hasBlock && slot <= w.lastSlot {
+		return fmt.Errorf("block at slot %d written after block at slot %d", slot, w.lastSlot)
+	}
+	enc, err := b.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	data, err := compress(enc)
+	if err != nil {
+		return err
+	}
+	w.blockOffsets[slot-start] = w.offset
+	w.lastSlot, w.hasBlock = slot, true
+	return w.write(typeCompressedBlock, data)
+}
+
+// WriteState appends the compressed era state to the era file.
+func (w *Writer) WriteState(st state.ReadOnlyBeaconState) error {
+	if w.stateOffset >= 0 {
+		return errStateWritten
+	}
+	if st.Slot() != StateSlot(w.era) {
+		return errors.Wrapf(errUnexpectedSlot, "state at slot %d is not the state of era %d", st.Slot(), w.era)
+	}
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	data, err := compress(enc)
+	if err != nil {
+		return err
+	}
+	w.stateOffset = w.offset
+	return w.write(typeCompressedState, data)
+}
+
+// Close writes the slot indices which end the era file. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.stateOffset < 0 {
+		return errStateNotWritten
+	}
+	if w.era > 0 {
+		if err := w.writeIndex(StartSlot(w.era), w.blockOffsets); err != nil {
+			return err
+		}
+	}
+	return w.writeIndex(StateSlot(w.era), []int64{w.stateOffset})
+}
+
+// writeIndex writes a slot index record, whose offsets are relative to the start of the record. Missing
+// entries keep a zero offset.
+func (w *Writer) writeIndex(start primitives.Slot, offsets []int64) error {
+	data := make([]byte, 8*(len(offsets)+2))
+	binary.LittleEndian.PutUint64(data, uint64(start))
+	for i, o := range offsets {
+		if o == 0 {
+			continue
+		}
+		binary.LittleEndian.PutUint64(data[8*(i+1):], uint64(o-w.offset))
+	}
+	binary.LittleEndian.PutUint64(data[len(data)-8:], uint64(len(offsets)))
+	return w.write(typeSlotIndex, data)
+}
+
+func (w *Writer) write(typ recordType, data []byte) error {
+	n, err := writeRecord(w.w, typ, data)
+	w.offset += n
+	return err
+}
+
+// Reader reads the blocks and the state of an era file.
+type Reader struct {
+	r            io.ReaderAt
+	closer       io.Closer
+	era          uint64
+	blockOffsets []int64
+	stateOffset  int64
+}
+
+// Open opens the era file at the given path. The Reader must be closed once done.
+func Open(path string) (*Reader, error) {
+	f, err := os.Open(path) // #nosec G304 -- the path is provided by the user.
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err == nil {
+		var r *Reader
+		r, err = NewReader(f, info.Size())
+		if err == nil {
+			r.closer = f
+			return r, nil
+		}
+	}
+	if cerr := f.Close(); cerr != nil {
+		log.WithError(cerr).Debug("Could not close era file")
+	}
+	return nil, errors.Wrapf(err, "could not read era file %s", path)
+}
+
+// NewReader reads the slot indices of an era file of the given size.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	stateStart, stateSlot, stateOffsets, err := readIndex(r, size)
+	if err != nil {
+		return nil, err
+	}
+	if len(stateOffsets) != 1 || stateOffsets[0] == 0 {
+		return nil, errors.Wrap(errInvalidIndex, "era file must index exactly one state")
+	}
+	sphr := uint64(params.BeaconConfig().SlotsPerHistoricalRoot)
+	if uint64(stateSlot)%sphr != 0 {
+		return nil, errors.Wrapf(errInvalidIndex, "state at slot %d is not at an era boundary", stateSlot)
+	}
+	er := &Reader{
+		r:           r,
+		era:         uint64(stateSlot) / sphr,
+		stateOffset: stateStart + stateOffsets[0],
+	}
+	if er.era == 0 {
+		return er, nil
+	}
+	blockStart, startSlot, blockOffsets, err := readIndex(r, stateStart)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read block index")
+	}
+	if startSlot != StartSlot(er.era) || uint64(len(blockOffsets)) != sphr {
+		return nil, errors.Wrapf(errInvalidIndex, "block index does not cover era %d", er.era)
+	}
+	er.blockOffsets = make([]int64, len(blockOffsets))
+	for i, o := range blockOffsets {
+		if o != 0 {
+			er.blockOffsets[i] = blockStart + o
+		}
+	}
+	return er, nil
+}
+
+// readIndex reads the slot index record ending at the given offset.
+func readIndex(r io.ReaderAt, end int64) (int64, primitives.Slot, []int64, error) {
+	if end < headerSize+16 {
+		return 0, 0, nil, errInvalidIndex
+	}
+	enc := make([]byte, 8)
+	if _, err := r.ReadAt(enc, end-8); err != nil {
+		return 0, 0, nil, err
+	}
+	count := binary.LittleEndian.Uint64(enc)
+	if count > uint64(end-headerSize-16)/8 {
+		return 0, 0, nil, errors.Wrapf(errInvalidIndex, "index of %d entries does not fit in the file", count)
+	}
+	start := end - headerSize - 8*int64(count+2)
+	typ, data, err := readRecord(r, start)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	if typ != typeSlotIndex || uint64(len(data)) != 8*(count+2) {
+		return 0, 0, nil, errInvalidIndex
+	}
+	offsets := make([]int64, count)
+	for i := range offsets {
+		offsets[i] = int64(binary.LittleEndian.Uint64(data[8*(i+1):]))
+	}
+	return start, primitives.Slot(binary.LittleEndian.Uint64(data)), offsets, nil
+}
+
+// Close closes the era file opened by Open.
+func (r *Reader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// Era returns the era number of the file.
+func (r *Reader) Era() uint64 {
+	return r.era
+}
+
+// BlockSlots returns the slots of the blocks stored in the era file, in increasing order.
+func (r *Reader) BlockSlots() []primitives.Slot {
+	start := StartSlot(r.era)
+	s := make([]primitives.Slot, 0)
+	for i, o := range r.blockOffsets {
+		if o != 0 {
+			s = append(s, start+primitives.Slot(i))
+		}
+	}
+	return s
+}
+
+// Block returns the block stored for the given slot, or nil when the era file has no block at that slot.
+func (r *Reader) Block(slot primitives.Slot) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	start := StartSlot(r.era)
+	if r.era == 0 || slot < start || slot >= StateSlot(r.era) {
+		return nil, errors.Wrapf(errUnexpectedSlot, "slot %d is not in era %d", slot, r.era)
+	}
+	offset := r.blockOffsets[slot-start]
+	if offset == 0 {
+		return nil, nil
+	}
+	enc, err := r.read(offset, typeCompressedBlock)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read block at slot %d", slot)
+	}
+	fork, err := forks.Fork(slots.ToEpoch(slot))
+	if err != nil {
+		return nil, err
+	}
+	cf, err := detect.FromForkVersion(bytesutil.ToBytes4(fork.CurrentVersion))
+	if err != nil {
+		return nil, err
+	}
+	return cf.UnmarshalBeaconBlock(enc)
+}
+
+// State returns the era state.
+func (r *Reader) State() (state.BeaconState, error) {
+	enc, err := r.read(r.stateOffset, typeCompressedState)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read era state")
+	}
+	cf, err := detect.FromState(enc)
+	if err != nil {
+		return nil, err
+	}
+	return cf.UnmarshalBeaconState(enc)
+}
+
+func (r *Reader) read(offset int64, want recordType) ([]byte, error) {
+	typ, data, err := readRecord(r.r, offset)
+	if err != nil {
+		return nil, err
+	}
+	if typ != want {
+		return nil, errors.Wrapf(errUnexpectedType, "got %#x, wanted %#x", typ, want)
+	}
+	return decompress(data)
+}
@@ -0,0 +1,91 @@
+package era
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestWriterReader(t *testing.T) {
+	sphr := primitives.Slot(params.BeaconConfig().SlotsPerHistoricalRoot)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(2*sphr))
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, 2)
+	require.NoError(t, err)
+	for _, slot := range []primitives.Slot{sphr, sphr + 5, 2*sphr - 1} {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, w.WriteBlock(wsb))
+	}
+	require.ErrorIs(t, w.Close(), errStateNotWritten)
+	require.NoError(t, w.WriteState(st))
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), r.Era())
+	assert.DeepEqual(t, []primitives.Slot{sphr, sphr + 5, 2*sphr - 1}, r.BlockSlots())
+	b, err := r.Block(sphr + 5)
+	require.NoError(t, err)
+	assert.Equal(t, sphr+5, b.Block().Slot())
+	b, err = r.Block(sphr + 6)
+	require.NoError(t, err)
+	assert.Equal(t, true, b == nil)
+	_, err = r.Block(2 * sphr)
+	require.ErrorIs(t, err, errUnexpectedSlot)
+
+	got, err := r.State()
+	require.NoError(t, err)
+	want, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	gotRoot, err := got.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, want, gotRoot)
+}
+
+func TestWriter_InvalidSlots(t *testing.T) {
+	sphr := primitives.Slot(params.BeaconConfig().SlotsPerHistoricalRoot)
+	w, err := NewWriter(&bytes.Buffer{}, 1)
+	require.NoError(t, err)
+
+	b := util.NewBeaconBlock()
+	b.Block.Slot = sphr
+	wsb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	require.ErrorIs(t, w.WriteBlock(wsb), errUnexpectedSlot)
+
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.ErrorIs(t, w.WriteState(st), errUnexpectedSlot)
+}
+
+func TestReader_Genesis(t *testing.T) {
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, 0)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteState(st))
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), r.Era())
+	assert.Equal(t, 0, len(r.BlockSlots()))
+
+	// A truncated file is rejected.
+	_, err = NewReader(bytes.NewReader(buf.Bytes()[:buf.Len()-1]), int64(buf.Len()-1))
+	require.NotNil(t, err)
+}
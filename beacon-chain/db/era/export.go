@@ -0,0 +1,194 @@
+package era
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var errNoFinalizedEra = errors.New("no finalized era in the requested range")
+
+// finalizedHistory treats the finalized blocks as canonical, which is all an offline export needs.
+type finalizedHistory struct {
+	db   db.ReadOnlyDatabase
+	slot primitives.Slot
+}
+
+// IsCanonical reports whether the block is part of the finalized chain.
+func (h *finalizedHistory) IsCanonical(ctx context.Context, blockRoot [32]byte) (bool, error) {
+	return h.db.IsFinalizedBlock(ctx, blockRoot), nil
+}
+
+// CurrentSlot returns the first slot of the finalized epoch, the highest slot which can be exported.
+func (h *finalizedHistory) CurrentSlot() primitives.Slot {
+	return h.slot
+}
+
+// LastFinalizedEra returns the most recent era whose blocks and state are all finalized in the database.
+func LastFinalizedEra(ctx context.Context, d db.ReadOnlyDatabase) (uint64, error) {
+	f, err := d.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, err
+	}
+	finalizedSlot, err := slots.EpochStart(f.Epoch)
+	if err != nil {
+		return 0, err
+	}
+	if finalizedSlot == 0 {
+		return 0, nil
+	}
+	return uint64(finalizedSlot-1) / uint64(params.BeaconConfig().SlotsPerHistoricalRoot), nil
+}
+
+// Export writes one era file per finalized era in the [from, to] range to the given directory, and returns
+// the paths of the written files. The range is capped at the last finalized era.
+func Export(ctx context.Context, d db.ReadOnlyDatabase, dir string, from, to uint64) ([]string, error) {
+	last, err := LastFinalizedEra(ctx, d)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not determine the last finalized era")
+	}
+	if to > last {
+		to = last
+	}
+	if from > to {
+		return nil, errors.Wrapf(errNoFinalizedEra, "eras %d to %d were requested, the last finalized era is %d", from, to, last)
+	}
+	if err := os.MkdirAll(dir, params.BeaconIoConfig().ReadWriteExecutePermissions); err != nil {
+		return nil, err
+	}
+	history := stategen.NewCanonicalHistory(d, &finalizedHistory{db: d, slot: StateSlot(last)}, &finalizedHistory{db: d, slot: StateSlot(last)},
+		stategen.WithRetainedHistory(d))
+
+	paths := make([]string, 0, to-from+1)
+	for era := from; era <= to; era++ {
+		if ctx.Err() != nil {
+			return paths, ctx.Err()
+		}
+		var st state.BeaconState
+		if era == 0 {
+			st, err = genesisState(ctx, d)
+		} else {
+			st, err = history.ReplayerForSlot(StateSlot(era)).ReplayBlocks(ctx)
+		}
+		if err != nil {
+			return paths, errors.Wrapf(err, "could not get the state of era %d", era)
+		}
+		path, err := exportEra(ctx, d, dir, era, st)
+		if err != nil {
+			return paths, errors.Wrapf(err, "could not export era %d", era)
+		}
+		log.WithFields(logrus.Fields{
+			"era":  era,
+			"path": path,
+		}).Info("Exported era file")
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// genesisState returns the genesis state saved in the database, rather than the one embedded for known networks.
+func genesisState(ctx context.Context, d db.ReadOnlyDatabase) (state.BeaconState, error) {
+	root, err := d.GenesisBlockRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return d.StateOrError(ctx, root)
+}
+
+// exportEra writes the era file of the given era state, along with the canonical blocks referenced by its
+// block roots.
+func exportEra(ctx context.Context, d db.ReadOnlyDatabase, dir string, era uint64, st state.BeaconState) (string, error) {
+	blks, err := eraBlocks(ctx, d, era, st)
+	if err != nil {
+		return "", err
+	}
+	root, err := Root(st, era)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, Filename(params.BeaconConfig().ConfigName, era, root))
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp) // #nosec G304 -- the directory is provided by the user.
+	if err != nil {
+		return "", err
+	}
+	if err := writeEra(f, era, blks, st); err != nil {
+		if cerr := f.Close(); cerr != nil {
+			log.WithError(cerr).Debug("Could not close era file")
+		}
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	return path, os.Rename(tmp, path)
+}
+
+func writeEra(f *os.File, era uint64, blks []interfaces.ReadOnlySignedBeaconBlock, st state.BeaconState) error {
+	w, err := NewWriter(f, era)
+	if err != nil {
+		return err
+	}
+	for _, b := range blks {
+		if err := w.WriteBlock(b); err != nil {
+			return err
+		}
+	}
+	if err := w.WriteState(st); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// eraBlocks returns the blocks of the given era, using the block roots of the era state to select them.
+// The genesis block is not exported, as it can be derived from the genesis state in era 0.
+func eraBlocks(ctx context.Context, d db.ReadOnlyDatabase, era uint64, st state.BeaconState) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	if era == 0 {
+		return nil, nil
+	}
+	start := StartSlot(era)
+	roots := st.BlockRoots()
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, 0)
+	var previous [32]byte
+	for slot := start; slot < StateSlot(era); slot++ {
+		root := bytesutil.ToBytes32(roots[uint64(slot)%uint64(len(roots))])
+		if root == previous || slot == 0 {
+			previous = root
+			continue
+		}
+		previous = root
+		b, err := d.Block(ctx, root)
+		if err != nil {
+			return nil, err
+		}
+		if b == nil || b.IsNil() {
+			return nil, fmt.Errorf("block %#x is missing from the database", root)
+		}
+		// The first root of the era may be the last block of the previous era.
+		if b.Block().Slot() < start {
+			continue
+		}
+		if b.IsBlinded() {
+			return nil, fmt.Errorf("block at slot %d is stored without its execution payload, "+
+				"only nodes running with --%s can export it", b.Block().Slot(), features.SaveFullExecutionPayloads.Name)
+		}
+		blks = append(blks, b)
+	}
+	return blks, nil
+}
@@ -0,0 +1,178 @@
+package era
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	sphr := primitives.Slot(params.BeaconConfig().SlotsPerHistoricalRoot)
+	source, err := kv.NewKVStore(ctx, t.TempDir())
+	require.NoError(t, err)
+	st, keys := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, source.SaveGenesisData(ctx, st))
+
+	// The block at the slot of the era 1 state is the first block of era 2, while the era 2 state follows
+	// skipped slots.
+	blockSlots := []primitives.Slot{1, 2, sphr - 1, sphr, 2*sphr - 4, 2*sphr + 16}
+	roots := make(map[primitives.Slot][32]byte)
+	for _, slot := range blockSlots {
+		advanced, err := transition.ProcessSlots(ctx, st.Copy(), slot)
+		require.NoError(t, err)
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ProposerIndex, err = helpers.BeaconProposerIndex(ctx, advanced)
+		require.NoError(t, err)
+		parentRoot, err := advanced.LatestBlockHeader().HashTreeRoot()
+		require.NoError(t, err)
+		b.Block.ParentRoot = parentRoot[:]
+		b.Block.Body.RandaoReveal, err = util.RandaoReveal(advanced, slots.ToEpoch(slot), keys)
+		require.NoError(t, err)
+		sig, err := util.BlockSignature(st, b.Block, keys)
+		require.NoError(t, err)
+		b.Signature = sig.Marshal()
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		root, err := wsb.Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, source.SaveBlock(ctx, wsb))
+		require.NoError(t, source.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: root[:]}))
+		require.NoError(t, source.SaveState(ctx, st, root))
+		roots[slot] = root
+	}
+	head := roots[2*sphr+16]
+	require.NoError(t, source.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: primitives.Epoch(2*sphr/params.BeaconConfig().SlotsPerEpoch) + 1, Root: head[:]}))
+
+	dir := t.TempDir()
+	paths, err := Export(ctx, source, dir, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(paths))
+	_, err = Export(ctx, source, dir, 3, 10)
+	require.ErrorIs(t, err, errNoFinalizedEra)
+
+	r, err := Open(paths[1])
+	require.NoError(t, err)
+	assert.DeepEqual(t, []primitives.Slot{1, 2, sphr - 1}, r.BlockSlots())
+	eraState, err := r.State()
+	require.NoError(t, err)
+	root, err := Root(eraState, 1)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, Filename(params.BeaconConfig().ConfigName, 1, root)), paths[1])
+	require.NoError(t, r.Close())
+	// Only one database can be open at a time, as each one registers the same metrics.
+	require.NoError(t, source.Close())
+
+	genesisRoot := roots[0]
+	anchor := roots[2*sphr-4]
+	trust := Trust{
+		GenesisValidatorsRoot: bytesutil.ToBytes32(eraState.GenesisValidatorsRoot()),
+		Checkpoint:            &ethpb.Checkpoint{Epoch: primitives.Epoch(2*sphr/params.BeaconConfig().SlotsPerEpoch), Root: anchor[:]},
+	}
+	// An era 1 file whose block at slot 2 carries the signature of the block at slot 1.
+	badSig := filepath.Join(t.TempDir(), "bad.era")
+	writeTamperedEra(t, paths[1], badSig, 2, 1)
+	for name, tt := range map[string]struct {
+		paths []string
+		trust Trust
+		err   error
+	}{
+		"no trusted checkpoint": {paths: paths, trust: Trust{GenesisValidatorsRoot: trust.GenesisValidatorsRoot}, err: errNoTrustedCheckpoint},
+		"other network":         {paths: paths, trust: Trust{Checkpoint: trust.Checkpoint}, err: errWrongNetwork},
+		"invalid signature":     {paths: []string{paths[0], badSig, paths[2]}, trust: trust, err: errBadSignature},
+		"untrusted checkpoint": {
+			paths: paths,
+			trust: Trust{GenesisValidatorsRoot: trust.GenesisValidatorsRoot, Checkpoint: &ethpb.Checkpoint{Epoch: trust.Checkpoint.Epoch, Root: genesisRoot[:]}},
+			err:   errUntrustedCheckpoint,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d, err := kv.NewKVStore(ctx, t.TempDir())
+			require.NoError(t, err)
+			_, err = Import(ctx, d, tt.paths, tt.trust)
+			require.ErrorIs(t, err, tt.err)
+			// Fork choice checkpoints are only written once the import is trusted.
+			cp, err := d.FinalizedCheckpoint(ctx)
+			require.NoError(t, err)
+			assert.DeepEqual(t, params.BeaconConfig().ZeroHash[:], cp.Root)
+			require.NoError(t, d.Close())
+		})
+	}
+
+	target := dbtest.SetupDB(t)
+	_, err = Import(ctx, target, paths[1:], trust)
+	require.ErrorIs(t, err, errNotConsecutive)
+	cp, err := Import(ctx, target, paths, trust)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Epoch(2*sphr/params.BeaconConfig().SlotsPerEpoch), cp.Epoch)
+	assert.DeepEqual(t, anchor[:], cp.Root)
+	for _, slot := range blockSlots[:len(blockSlots)-1] {
+		assert.Equal(t, true, target.HasBlock(ctx, roots[slot]), "Block at slot %d was not imported", slot)
+		assert.Equal(t, true, target.IsFinalizedBlock(ctx, roots[slot]), "Block at slot %d is not finalized", slot)
+	}
+	// The era 1 state is saved once the block at its slot is imported from era 2.
+	assert.Equal(t, true, target.HasState(ctx, roots[sphr]))
+	assert.Equal(t, true, target.HasState(ctx, roots[2*sphr-4]))
+	headBlock, err := target.HeadBlock(ctx)
+	require.NoError(t, err)
+	headRoot, err := headBlock.Block().HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, anchor, headRoot)
+
+	_, err = Import(ctx, target, paths, trust)
+	require.ErrorIs(t, err, errDatabaseNotEmpty)
+}
+
+// writeTamperedEra copies the era file at src to dst, replacing the signature of the block at the given slot
+// with the signature of the block at another slot.
+func writeTamperedEra(t *testing.T, src, dst string, slot, sigSlot primitives.Slot) {
+	r, err := Open(src)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, r.Close())
+	}()
+	f, err := os.Create(dst)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	w, err := NewWriter(f, r.Era())
+	require.NoError(t, err)
+	for _, s := range r.BlockSlots() {
+		b, err := r.Block(s)
+		require.NoError(t, err)
+		if s == slot {
+			other, err := r.Block(sigSlot)
+			require.NoError(t, err)
+			pb, err := b.Proto()
+			require.NoError(t, err)
+			sig := other.Signature()
+			pb.(*ethpb.SignedBeaconBlock).Signature = sig[:]
+			b, err = blocks.NewSignedBeaconBlock(pb)
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.WriteBlock(b))
+	}
+	st, err := r.State()
+	require.NoError(t, err)
+	require.NoError(t, w.WriteState(st))
+	require.NoError(t, w.Close())
+}
@@ -0,0 +1,271 @@
+package era
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	errDatabaseNotEmpty    = errors.New("era files can only be imported into an empty database")
+	errNotConsecutive      = errors.New("era files must hold consecutive eras starting from era 0")
+	errUnlinkedBlock       = errors.New("block does not match the block roots of the era state")
+	errWrongNetwork        = errors.New("era state does not have the trusted genesis validators root")
+	errBadSignature        = errors.New("block proposer signature is invalid")
+	errUntrustedCheckpoint = errors.New("imported history does not end at the trusted checkpoint")
+	errNoTrustedCheckpoint = errors.New("a trusted checkpoint is required to import era files")
+)
+
+// Trust holds the trusted values which imported era files are verified against.
+type Trust struct {
+	// GenesisValidatorsRoot is the genesis validators root of the network the era files must belong to.
+	GenesisValidatorsRoot [32]byte
+	// Checkpoint is a checkpoint obtained from a trusted source, such as a weak subjectivity checkpoint.
+	// The checkpoint of the last imported era state must match it.
+	Checkpoint *ethpb.Checkpoint
+}
+
+// importer tracks the chain imported so far.
+type importer struct {
+	db         db.HeadAccessDatabase
+	validators [32]byte
+	head       [32]byte
+	// pending is an era state whose latest block is the first block of the next era.
+	pending     state.BeaconState
+	pendingRoot [32]byte
+	checkpoint  *ethpb.Checkpoint
+}
+
+// Import seeds an empty database with the history stored in the given era files, which must hold consecutive
+// eras starting from era 0. Every era state must have the trusted genesis validators root, and blocks are
+// checked against the block roots of their era state and the signature of their proposer. Every era state
+// is saved as an archived state. The most recent imported era state becomes the finalized checkpoint, which
+// is returned, once it is found to match the trusted checkpoint. Otherwise, no checkpoint is saved and the
+// partially imported database must be discarded.
+func Import(ctx context.Context, d db.HeadAccessDatabase, paths []string, trust Trust) (*ethpb.Checkpoint, error) {
+	if trust.Checkpoint == nil {
+		return nil, errNoTrustedCheckpoint
+	}
+	_, err := d.GenesisBlockRoot(ctx)
+	if err == nil {
+		return nil, errDatabaseNotEmpty
+	}
+	if !errors.Is(err, kv.ErrNotFoundGenesisBlockRoot) {
+		return nil, err
+	}
+	readers := make([]*Reader, 0, len(paths))
+	defer func() {
+		for _, r := range readers {
+			if err := r.Close(); err != nil {
+				log.WithError(err).Debug("Could not close era file")
+			}
+		}
+	}()
+	for _, p := range paths {
+		r, err := Open(p)
+		if err != nil {
+			return nil, err
+		}
+		readers = append(readers, r)
+	}
+	sort.Slice(readers, func(i, j int) bool {
+		return readers[i].Era() < readers[j].Era()
+	})
+	for i, r := range readers {
+		if r.Era() != uint64(i) {
+			return nil, errors.Wrapf(errNotConsecutive, "expected era %d, found era %d", i, r.Era())
+		}
+	}
+	if len(readers) == 0 {
+		return nil, errors.Wrap(errNotConsecutive, "no era files provided")
+	}
+
+	im := &importer{db: d, validators: trust.GenesisValidatorsRoot}
+	for _, r := range readers {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err := im.importEra(ctx, r); err != nil {
+			return nil, errors.Wrapf(err, "could not import era %d", r.Era())
+		}
+	}
+	if im.pending != nil {
+		log.WithField("slot", im.pending.Slot()).Warn("The last era state was not imported, " +
+			"as its latest block is stored in the next era file")
+	}
+	cp := im.checkpoint
+	if cp.Epoch != trust.Checkpoint.Epoch || !bytes.Equal(cp.Root, trust.Checkpoint.Root) {
+		return nil, errors.Wrapf(errUntrustedCheckpoint, "last imported checkpoint is epoch %d root %#x, trusted checkpoint is epoch %d root %#x",
+			cp.Epoch, cp.Root, trust.Checkpoint.Epoch, trust.Checkpoint.Root)
+	}
+	if err := im.saveFinalized(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not save finalized checkpoint")
+	}
+	return cp, nil
+}
+
+func (im *importer) importEra(ctx context.Context, r *Reader) error {
+	st, err := r.State()
+	if err != nil {
+		return err
+	}
+	if r.Era() == 0 {
+		return im.importGenesis(ctx, st)
+	}
+	if bytesutil.ToBytes32(st.GenesisValidatorsRoot()) != im.validators {
+		return errors.Wrapf(errWrongNetwork, "era state has genesis validators root %#x, expected %#x", st.GenesisValidatorsRoot(), im.validators)
+	}
+
+	roots := st.BlockRoots()
+	sigs := bls.NewSet()
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, 0)
+	summaries := make([]*ethpb.StateSummary, 0)
+	for slot := StartSlot(r.Era()); slot < StateSlot(r.Era()); slot++ {
+		expected := bytesutil.ToBytes32(roots[uint64(slot)%uint64(len(roots))])
+		b, err := r.Block(slot)
+		if err != nil {
+			return err
+		}
+		if b == nil {
+			if expected != im.head {
+				return errors.Wrapf(errUnlinkedBlock, "era file has no block at slot %d", slot)
+			}
+			continue
+		}
+		root, err := b.Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		if root != expected || b.Block().ParentRoot() != im.head {
+			return errors.Wrapf(errUnlinkedBlock, "block %#x at slot %d", root, slot)
+		}
+		set, err := im.proposerSignature(st, b)
+		if err != nil {
+			return errors.Wrapf(err, "could not get proposer signature of block at slot %d", slot)
+		}
+		sigs.Join(set)
+		blks = append(blks, b)
+		summaries = append(summaries, &ethpb.StateSummary{Slot: slot, Root: root[:]})
+		im.head = root
+	}
+	if len(sigs.Signatures) > 0 {
+		valid, err := sigs.Verify()
+		if err != nil {
+			return errors.Wrap(err, "could not verify proposer signatures")
+		}
+		if !valid {
+			return errBadSignature
+		}
+	}
+	if err := im.db.SaveBlocks(ctx, blks); err != nil {
+		return err
+	}
+	if err := im.db.SaveStateSummaries(ctx, summaries); err != nil {
+		return err
+	}
+	if im.pending != nil && im.db.HasBlock(ctx, im.pendingRoot) {
+		if err := im.saveCheckpoint(ctx, im.pending, im.pendingRoot); err != nil {
+			return err
+		}
+	}
+	im.pending = nil
+
+	// The era state includes the block at its slot, which is the first block of the next era.
+	header := ethpb.CopyBeaconBlockHeader(st.LatestBlockHeader())
+	if bytesutil.ToBytes32(header.StateRoot) == [32]byte{} {
+		stateRoot, err := st.HashTreeRoot(ctx)
+		if err != nil {
+			return err
+		}
+		header.StateRoot = stateRoot[:]
+	}
+	anchor, err := header.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	if anchor != im.head {
+		im.pending, im.pendingRoot = st, anchor
+		return nil
+	}
+	return im.saveCheckpoint(ctx, st, anchor)
+}
+
+func (im *importer) importGenesis(ctx context.Context, st state.BeaconState) error {
+	if bytesutil.ToBytes32(st.GenesisValidatorsRoot()) != im.validators {
+		return errors.Wrapf(errWrongNetwork, "genesis state has genesis validators root %#x, expected %#x", st.GenesisValidatorsRoot(), im.validators)
+	}
+	if err := im.db.SaveGenesisData(ctx, st); err != nil {
+		return err
+	}
+	root, err := im.db.GenesisBlockRoot(ctx)
+	if err != nil {
+		return err
+	}
+	im.head = root
+	im.checkpoint = &ethpb.Checkpoint{Root: root[:]}
+	return nil
+}
+
+// proposerSignature returns the proposer signature of a block for batch verification. The proposer is looked up
+// in the era state, whose validator registry holds every validator which could propose a block in the era.
+func (im *importer) proposerSignature(st state.ReadOnlyBeaconState, b interfaces.ReadOnlySignedBeaconBlock) (*bls.SignatureBatch, error) {
+	epoch := slots.ToEpoch(b.Block().Slot())
+	fork, err := forks.Fork(epoch)
+	if err != nil {
+		return nil, err
+	}
+	domain, err := signing.Domain(fork, epoch, params.BeaconConfig().DomainBeaconProposer, im.validators[:])
+	if err != nil {
+		return nil, err
+	}
+	proposer, err := st.ValidatorAtIndexReadOnly(b.Block().ProposerIndex())
+	if err != nil {
+		return nil, err
+	}
+	pub := proposer.PublicKey()
+	sig := b.Signature()
+	return signing.BlockSignatureBatch(pub[:], sig[:], domain, b.Block().HashTreeRoot)
+}
+
+// saveCheckpoint saves the era state as an archived state, and records its latest block as the checkpoint
+// reached so far.
+func (im *importer) saveCheckpoint(ctx context.Context, st state.BeaconState, root [32]byte) error {
+	if err := im.db.SaveState(ctx, st, root); err != nil {
+		return err
+	}
+	cp := &ethpb.Checkpoint{Epoch: slots.ToEpoch(st.Slot()), Root: root[:]}
+	im.checkpoint = cp
+	log.WithFields(logrus.Fields{
+		"slot":  st.Slot(),
+		"epoch": cp.Epoch,
+		"root":  fmt.Sprintf("%#x", root),
+	}).Info("Imported era state")
+	return nil
+}
+
+// saveFinalized marks the checkpoint of the last imported era state as justified and finalized, and as the
+// head of the chain.
+func (im *importer) saveFinalized(ctx context.Context) error {
+	if err := im.db.SaveJustifiedCheckpoint(ctx, im.checkpoint); err != nil {
+		return err
+	}
+	if err := im.db.SaveFinalizedCheckpoint(ctx, im.checkpoint); err != nil {
+		return err
+	}
+	return im.db.SaveHeadBlockRoot(ctx, bytesutil.ToBytes32(im.checkpoint.Root))
+}
@@ -19,3 +19,6 @@ var ErrNotFoundBackfillBlockRoot = kv.ErrNotFoundBackfillBlockRoot
 
 // ErrNotFoundGenesisBlockRoot means no genesis block root was found, indicating the db was not initialized with genesis
 var ErrNotFoundGenesisBlockRoot = kv.ErrNotFoundGenesisBlockRoot
+
+// ErrNotFoundBlobSidecars wraps ErrNotFound for an error specific to blob sidecars not being found in the database.
+var ErrNotFoundBlobSidecars = kv.ErrNotFoundBlobSidecars
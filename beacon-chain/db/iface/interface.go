@@ -57,6 +57,15 @@ type ReadOnlyDatabase interface {
 	// origin checkpoint sync support
 	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
 	BackfillBlockRoot(ctx context.Context) ([32]byte, error)
+	// History retention.
+	LowestRetainedSlot(ctx context.Context) (primitives.Slot, error)
+	// Light client operations.
+	LightClientUpdate(ctx context.Context, period uint64) (interfaces.LightClientUpdate, error)
+	LightClientUpdates(ctx context.Context, startPeriod, endPeriod uint64) ([]interfaces.LightClientUpdate, error)
+	// Blob sidecar operations.
+	BlobSidecarsByRoot(ctx context.Context, root [32]byte, indices ...uint64) ([]*ethpb.BlobSidecar, error)
+	// Fork choice persistence.
+	ForkchoiceSnapshot(ctx context.Context) ([]byte, error)
 }
 
 // NoHeadAccessDatabase defines a struct without access to chain head data.
@@ -88,8 +97,17 @@ type NoHeadAccessDatabase interface {
 	// Fee recipients operations.
 	SaveFeeRecipientsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, addrs []common.Address) error
 	SaveRegistrationsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, regs []*ethpb.ValidatorRegistrationV1) error
+	// Light client operations.
+	SaveLightClientUpdate(ctx context.Context, period uint64, update interfaces.LightClientUpdate) error
+	// Blob sidecar operations.
+	SaveBlobSidecars(ctx context.Context, sidecars []*ethpb.BlobSidecar) error
+	DeleteBlobSidecars(ctx context.Context, root [32]byte) error
+	PruneBlobSidecars(ctx context.Context, beforeSlot primitives.Slot) (int, error)
+	// Fork choice persistence.
+	SaveForkchoiceSnapshot(ctx context.Context, snapshot []byte) error
 
 	CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint primitives.Slot) error
+	PruneHistory(ctx context.Context, beforeSlot primitives.Slot, keepRoots ...[32]byte) (int, error)
 }
 
 // HeadAccessDatabase defines a struct with access to reading chain head data.
@@ -108,6 +126,7 @@ type HeadAccessDatabase interface {
 	// initialization method needed for origin checkpoint sync
 	SaveOrigin(ctx context.Context, serState, serBlock []byte) error
 	SaveBackfillBlockRoot(ctx context.Context, blockRoot [32]byte) error
+	SaveBackfillBlocks(ctx context.Context, blks []interfaces.ReadOnlySignedBeaconBlock, childRoot [32]byte) error
 }
 
 // SlasherDatabase interface for persisting data related to detecting slashable offenses on Ethereum.
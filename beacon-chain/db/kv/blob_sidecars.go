@@ -0,0 +1,197 @@
+package kv
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+var errMixedBlobSidecars = errors.New("blob sidecars do not belong to the same block")
+
+// SaveBlobSidecars saves the blob sidecars of a single block. Sidecars already stored for the block
+// are kept, and replaced when a sidecar with the same index is saved again.
+// Sidecars are keyed by slot and block root, so that they can be pruned by slot, and the slot of each
+// block root is indexed so that the sidecars of a block are looked up with a single seek.
+func (s *Store) SaveBlobSidecars(ctx context.Context, sidecars []*ethpb.BlobSidecar) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveBlobSidecars")
+	defer span.End()
+	if len(sidecars) == 0 {
+		return nil
+	}
+	slot, root, err := blobSidecarsBlock(sidecars)
+	if err != nil {
+		return err
+	}
+	key := blobSidecarKey(slot, root)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(blobSidecarsBucket)
+		byIndex := make(map[uint64]*ethpb.BlobSidecar)
+		if enc := bkt.Get(key); enc != nil {
+			stored := &ethpb.BlobSidecars{}
+			if err := decode(ctx, enc, stored); err != nil {
+				return err
+			}
+			for _, sc := range stored.Sidecars {
+				byIndex[sc.Index] = sc
+			}
+		}
+		for _, sc := range sidecars {
+			byIndex[sc.Index] = sc
+		}
+		merged := make([]*ethpb.BlobSidecar, 0, len(byIndex))
+		for _, sc := range byIndex {
+			merged = append(merged, sc)
+		}
+		sort.Slice(merged, func(i, j int) bool {
+			return merged[i].Index < merged[j].Index
+		})
+		enc, err := encode(ctx, &ethpb.BlobSidecars{Sidecars: merged})
+		if err != nil {
+			return err
+		}
+		if err := bkt.Put(key, enc); err != nil {
+			return err
+		}
+		return tx.Bucket(blobSidecarRootIndicesBucket).Put(root[:], bytesutil.SlotToBytesBigEndian(slot))
+	})
+}
+
+// BlobSidecarsByRoot retrieves the blob sidecars of the block with the given root, in ascending index order.
+// When indices are given, only the sidecars with those indices are returned.
+// It returns ErrNotFoundBlobSidecars if no requested sidecar is stored.
+func (s *Store) BlobSidecarsByRoot(ctx context.Context, root [32]byte, indices ...uint64) ([]*ethpb.BlobSidecar, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.BlobSidecarsByRoot")
+	defer span.End()
+	var stored *ethpb.BlobSidecars
+	err := s.db.View(func(tx *bolt.Tx) error {
+		key := blobSidecarKeyByRoot(tx, root)
+		if key == nil {
+			return nil
+		}
+		enc := tx.Bucket(blobSidecarsBucket).Get(key)
+		if enc == nil {
+			return nil
+		}
+		stored = &ethpb.BlobSidecars{}
+		return decode(ctx, enc, stored)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, ErrNotFoundBlobSidecars
+	}
+	sidecars := filterBlobSidecars(stored.Sidecars, indices)
+	if len(sidecars) == 0 {
+		return nil, ErrNotFoundBlobSidecars
+	}
+	return sidecars, nil
+}
+
+// DeleteBlobSidecars deletes the blob sidecars of the block with the given root.
+func (s *Store) DeleteBlobSidecars(ctx context.Context, root [32]byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DeleteBlobSidecars")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		key := blobSidecarKeyByRoot(tx, root)
+		if key == nil {
+			return nil
+		}
+		if err := tx.Bucket(blobSidecarsBucket).Delete(key); err != nil {
+			return err
+		}
+		return tx.Bucket(blobSidecarRootIndicesBucket).Delete(root[:])
+	})
+}
+
+// PruneBlobSidecars deletes the blob sidecars of all the blocks with a slot lower than the given slot.
+// It returns the number of blocks whose sidecars were deleted.
+func (s *Store) PruneBlobSidecars(ctx context.Context, beforeSlot primitives.Slot) (int, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.PruneBlobSidecars")
+	defer span.End()
+	var pruned int
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(blobSidecarsBucket)
+		c := bkt.Cursor()
+		var keys [][]byte
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			if bytesutil.BytesToSlotBigEndian(k[:8]) >= beforeSlot {
+				break
+			}
+			keys = append(keys, bytesutil.SafeCopyBytes(k))
+		}
+		indices := tx.Bucket(blobSidecarRootIndicesBucket)
+		for _, k := range keys {
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+			if err := indices.Delete(k[8:]); err != nil {
+				return err
+			}
+		}
+		pruned = len(keys)
+		return nil
+	})
+	return pruned, err
+}
+
+// blobSidecarsBlock returns the slot and root of the block the given sidecars belong to.
+func blobSidecarsBlock(sidecars []*ethpb.BlobSidecar) (primitives.Slot, [32]byte, error) {
+	var slot primitives.Slot
+	var root [32]byte
+	for i, sc := range sidecars {
+		if sc == nil || sc.SignedBlockHeader == nil || sc.SignedBlockHeader.Header == nil {
+			return 0, [32]byte{}, errors.New("nil blob sidecar")
+		}
+		r, err := sc.SignedBlockHeader.Header.HashTreeRoot()
+		if err != nil {
+			return 0, [32]byte{}, err
+		}
+		if i == 0 {
+			slot, root = sc.SignedBlockHeader.Header.Slot, r
+			continue
+		}
+		if r != root {
+			return 0, [32]byte{}, errMixedBlobSidecars
+		}
+	}
+	return slot, root, nil
+}
+
+func filterBlobSidecars(sidecars []*ethpb.BlobSidecar, indices []uint64) []*ethpb.BlobSidecar {
+	if len(indices) == 0 {
+		return sidecars
+	}
+	wanted := make(map[uint64]bool, len(indices))
+	for _, i := range indices {
+		wanted[i] = true
+	}
+	filtered := make([]*ethpb.BlobSidecar, 0, len(indices))
+	for _, sc := range sidecars {
+		if wanted[sc.Index] {
+			filtered = append(filtered, sc)
+		}
+	}
+	return filtered
+}
+
+// blobSidecarKeyByRoot returns the key of the blob sidecars of the block with the given root,
+// or nil if no sidecars are stored for the block.
+func blobSidecarKeyByRoot(tx *bolt.Tx, root [32]byte) []byte {
+	enc := tx.Bucket(blobSidecarRootIndicesBucket).Get(root[:])
+	if enc == nil {
+		return nil
+	}
+	return blobSidecarKey(bytesutil.BytesToSlotBigEndian(enc), root)
+}
+
+// blobSidecarKey is the slot in big endian followed by the block root, so that keys are ordered by slot.
+func blobSidecarKey(slot primitives.Slot, root [32]byte) []byte {
+	return append(bytesutil.SlotToBytesBigEndian(slot), root[:]...)
+}
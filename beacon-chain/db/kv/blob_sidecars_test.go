@@ -0,0 +1,114 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+func testBlobSidecars(t *testing.T, slot primitives.Slot, indices ...uint64) ([32]byte, []*ethpb.BlobSidecar) {
+	header := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{
+			Slot:       slot,
+			ParentRoot: make([]byte, fieldparams.RootLength),
+			StateRoot:  make([]byte, fieldparams.RootLength),
+			BodyRoot:   make([]byte, fieldparams.RootLength),
+		},
+		Signature: make([]byte, fieldparams.BLSSignatureLength),
+	}
+	root, err := header.Header.HashTreeRoot()
+	require.NoError(t, err)
+	sidecars := make([]*ethpb.BlobSidecar, len(indices))
+	for i, idx := range indices {
+		proof := make([][]byte, fieldparams.KzgCommitmentInclusionProofDepth)
+		for j := range proof {
+			proof[j] = make([]byte, 32)
+		}
+		sidecars[i] = &ethpb.BlobSidecar{
+			Index:                    idx,
+			Blob:                     make([]byte, fieldparams.BlobLength),
+			KzgCommitment:            make([]byte, 48),
+			KzgProof:                 make([]byte, 48),
+			SignedBlockHeader:        header,
+			CommitmentInclusionProof: proof,
+		}
+	}
+	return root, sidecars
+}
+
+func TestStore_BlobSidecars_CanSaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	root, sidecars := testBlobSidecars(t, 10, 0, 1, 2)
+	_, err := db.BlobSidecarsByRoot(ctx, root)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// Sidecars received one at a time are merged.
+	require.NoError(t, db.SaveBlobSidecars(ctx, sidecars[2:]))
+	require.NoError(t, db.SaveBlobSidecars(ctx, sidecars[:2]))
+	got, err := db.BlobSidecarsByRoot(ctx, root)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(got))
+	for i := range got {
+		assert.Equal(t, true, proto.Equal(sidecars[i], got[i]))
+	}
+
+	got, err = db.BlobSidecarsByRoot(ctx, root, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(got))
+	assert.Equal(t, uint64(1), got[0].Index)
+
+	_, err = db.BlobSidecarsByRoot(ctx, root, 5)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, db.DeleteBlobSidecars(ctx, root))
+	_, err = db.BlobSidecarsByRoot(ctx, root)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		assert.Equal(t, true, tx.Bucket(blobSidecarRootIndicesBucket).Get(root[:]) == nil)
+		return nil
+	}))
+}
+
+func TestStore_SaveBlobSidecars_MixedBlocks(t *testing.T) {
+	db := setupDB(t)
+	_, a := testBlobSidecars(t, 10, 0)
+	_, b := testBlobSidecars(t, 11, 1)
+	require.ErrorIs(t, db.SaveBlobSidecars(context.Background(), append(a, b...)), errMixedBlobSidecars)
+}
+
+func TestStore_PruneBlobSidecars(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	roots := make([][32]byte, 0)
+	for slot := primitives.Slot(1); slot <= 5; slot++ {
+		root, sidecars := testBlobSidecars(t, slot, 0)
+		require.NoError(t, db.SaveBlobSidecars(ctx, sidecars))
+		roots = append(roots, root)
+	}
+	pruned, err := db.PruneBlobSidecars(ctx, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 3, pruned)
+	for i, root := range roots {
+		_, err := db.BlobSidecarsByRoot(ctx, root)
+		if i < 3 {
+			require.ErrorIs(t, err, ErrNotFound)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+	// The root index of the pruned blocks is pruned along with their sidecars.
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		assert.Equal(t, 2, tx.Bucket(blobSidecarRootIndicesBucket).Stats().KeyN)
+		return nil
+	}))
+}
@@ -70,7 +70,8 @@ func (s *Store) OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
 	return root, err
 }
 
-// BackfillBlockRoot keeps track of the highest block available before the OriginCheckpointBlockRoot
+// BackfillBlockRoot keeps track of the lowest block backfilled below the OriginCheckpointBlockRoot.
+// Checkpoint sync initializes this value to the genesis block root, before any blocks have been backfilled.
 func (s *Store) BackfillBlockRoot(ctx context.Context) ([32]byte, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.BackfillBlockRoot")
 	defer span.End()
@@ -294,53 +295,117 @@ func (s *Store) SaveBlocks(ctx context.Context, blks []interfaces.ReadOnlySigned
 
 	// Performing marshaling, hashing, and indexing outside the bolt transaction
 	// to minimize the time we hold the DB lock.
-	blockRoots := make([][]byte, len(blks))
-	encodedBlocks := make([][]byte, len(blks))
-	indicesForBlocks := make([]map[string][]byte, len(blks))
+	prepared, err := s.prepareBlocks(ctx, blks)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return s.saveBlocks(ctx, tx, prepared)
+	})
+}
+
+// SaveBackfillBlocks saves a batch of backfilled blocks, ordered by increasing slot and each linking to the
+// previous one, and adds them to the finalized block roots index in the same transaction. childRoot is the root
+// of the already backfilled block that the highest block of the batch is the parent of.
+func (s *Store) SaveBackfillBlocks(ctx context.Context, blks []interfaces.ReadOnlySignedBeaconBlock, childRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveBackfillBlocks")
+	defer span.End()
+
+	prepared, err := s.prepareBlocks(ctx, blks)
+	if err != nil {
+		return err
+	}
+	containers := make([][]byte, len(blks))
 	for i, blk := range blks {
-		blockRoot, err := blk.Block().HashTreeRoot()
+		parentRoot := blk.Block().ParentRoot()
+		child := childRoot[:]
+		if i+1 < len(blks) {
+			child = prepared.roots[i+1]
+		}
+		containers[i], err = encode(ctx, &ethpb.FinalizedBlockRootContainer{
+			ParentRoot: parentRoot[:],
+			ChildRoot:  child,
+		})
 		if err != nil {
 			return err
 		}
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := s.saveBlocks(ctx, tx, prepared); err != nil {
+			return err
+		}
+		bkt := tx.Bucket(finalizedBlockRootsIndexBucket)
+		for i := range blks {
+			if err := bkt.Put(prepared.roots[i], containers[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// preparedBlocks holds the roots, encodings and indices of blocks about to be saved.
+type preparedBlocks struct {
+	blks        []interfaces.ReadOnlySignedBeaconBlock
+	roots       [][]byte
+	encoded     [][]byte
+	indices     []map[string][]byte
+	saveBlinded bool
+}
+
+func (s *Store) prepareBlocks(ctx context.Context, blks []interfaces.ReadOnlySignedBeaconBlock) (*preparedBlocks, error) {
+	p := &preparedBlocks{
+		blks:    blks,
+		roots:   make([][]byte, len(blks)),
+		encoded: make([][]byte, len(blks)),
+		indices: make([]map[string][]byte, len(blks)),
+	}
+	for i, blk := range blks {
+		blockRoot, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			return nil, err
+		}
 		enc, err := s.marshalBlock(ctx, blk)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		blockRoots[i] = blockRoot[:]
-		encodedBlocks[i] = enc
-		indicesByBucket := createBlockIndicesFromBlock(ctx, blk.Block())
-		indicesForBlocks[i] = indicesByBucket
+		p.roots[i] = blockRoot[:]
+		p.encoded[i] = enc
+		p.indices[i] = createBlockIndicesFromBlock(ctx, blk.Block())
 	}
 	saveBlinded, err := s.shouldSaveBlinded(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return s.db.Update(func(tx *bolt.Tx) error {
-		bkt := tx.Bucket(blocksBucket)
-		for i, blk := range blks {
-			if existingBlock := bkt.Get(blockRoots[i]); existingBlock != nil {
-				continue
-			}
-			if err := updateValueForIndices(ctx, indicesForBlocks[i], blockRoots[i], tx); err != nil {
-				return errors.Wrap(err, "could not update DB indices")
-			}
-			if saveBlinded {
-				blindedBlock, err := blk.ToBlinded()
-				if err != nil {
-					if !errors.Is(err, blocks.ErrUnsupportedVersion) {
-						return err
-					}
-				} else {
-					blk = blindedBlock
+	p.saveBlinded = saveBlinded
+	return p, nil
+}
+
+func (s *Store) saveBlocks(ctx context.Context, tx *bolt.Tx, p *preparedBlocks) error {
+	bkt := tx.Bucket(blocksBucket)
+	for i, blk := range p.blks {
+		if existingBlock := bkt.Get(p.roots[i]); existingBlock != nil {
+			continue
+		}
+		if err := updateValueForIndices(ctx, p.indices[i], p.roots[i], tx); err != nil {
+			return errors.Wrap(err, "could not update DB indices")
+		}
+		if p.saveBlinded {
+			blindedBlock, err := blk.ToBlinded()
+			if err != nil {
+				if !errors.Is(err, blocks.ErrUnsupportedVersion) {
+					return err
 				}
-			}
-			s.blockCache.Set(string(blockRoots[i]), blk, int64(len(encodedBlocks[i])))
-			if err := bkt.Put(blockRoots[i], encodedBlocks[i]); err != nil {
-				return err
+			} else {
+				blk = blindedBlock
 			}
 		}
-		return nil
-	})
+		s.blockCache.Set(string(p.roots[i]), blk, int64(len(p.encoded[i])))
+		if err := bkt.Put(p.roots[i], p.encoded[i]); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // SaveHeadBlockRoot to the db.
@@ -418,7 +483,8 @@ func (s *Store) SaveOriginCheckpointBlockRoot(ctx context.Context, blockRoot [32
 }
 
 // SaveBackfillBlockRoot is used to keep track of the most recently backfilled block root when
-// the node was initialized via checkpoint sync.
+// the node was initialized via checkpoint sync. Backfill proceeds backwards from the origin block,
+// so this is the lowest block that links back to the origin block.
 func (s *Store) SaveBackfillBlockRoot(ctx context.Context, blockRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveBackfillBlockRoot")
 	defer span.End()
@@ -818,6 +884,16 @@ func unmarshalBlock(_ context.Context, enc []byte) (interfaces.ReadOnlySignedBea
 		if err := rawBlock.UnmarshalSSZ(enc[len(capellaBlindKey):]); err != nil {
 			return nil, errors.Wrap(err, "could not unmarshal blinded Capella block")
 		}
+	case hasDenebKey(enc):
+		rawBlock = &ethpb.SignedBeaconBlockDeneb{}
+		if err := rawBlock.UnmarshalSSZ(enc[len(denebKey):]); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal Deneb block")
+		}
+	case hasDenebBlindKey(enc):
+		rawBlock = &ethpb.SignedBlindedBeaconBlockDeneb{}
+		if err := rawBlock.UnmarshalSSZ(enc[len(denebBlindKey):]); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal blinded Deneb block")
+		}
 	default:
 		// Marshal block bytes to phase 0 beacon block.
 		rawBlock = &ethpb.SignedBeaconBlock{}
@@ -854,6 +930,8 @@ func marshalBlockFull(
 		return nil, err
 	}
 	switch blk.Version() {
+	case version.Deneb:
+		return snappy.Encode(nil, append(denebKey, encodedBlock...)), nil
 	case version.Capella:
 		return snappy.Encode(nil, append(capellaKey, encodedBlock...)), nil
 	case version.Bellatrix:
@@ -888,6 +966,8 @@ func marshalBlockBlinded(
 		return nil, errors.Wrap(err, "could not marshal blinded block")
 	}
 	switch blk.Version() {
+	case version.Deneb:
+		return snappy.Encode(nil, append(denebBlindKey, encodedBlock...)), nil
 	case version.Capella:
 		return snappy.Encode(nil, append(capellaBlindKey, encodedBlock...)), nil
 	case version.Bellatrix:
@@ -109,6 +109,48 @@ func TestStore_SaveBackfillBlockRoot(t *testing.T) {
 
 }
 
+func TestStore_SaveBackfillBlocks(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	var parent [32]byte
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, 3)
+	roots := make([][32]byte, 3)
+	for i := range blks {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = primitives.Slot(i + 1)
+		b.Block.ParentRoot = parent[:]
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		blks[i] = wsb
+		roots[i], err = wsb.Block().HashTreeRoot()
+		require.NoError(t, err)
+		parent = roots[i]
+	}
+	child := util.NewBeaconBlock()
+	child.Block.Slot = 4
+	child.Block.ParentRoot = parent[:]
+	util.SaveBlock(t, ctx, db, child)
+	childRoot, err := child.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	require.NoError(t, db.SaveBackfillBlocks(ctx, blks, childRoot))
+	for i, r := range roots {
+		require.Equal(t, true, db.HasBlock(ctx, r))
+		require.Equal(t, true, db.IsFinalizedBlock(ctx, r))
+		if i+1 < len(roots) {
+			next, err := db.FinalizedChildBlock(ctx, r)
+			require.NoError(t, err)
+			nextRoot, err := next.Block().HashTreeRoot()
+			require.NoError(t, err)
+			require.Equal(t, roots[i+1], nextRoot)
+		}
+	}
+	next, err := db.FinalizedChildBlock(ctx, roots[2])
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(4), next.Block().Slot())
+}
+
 func TestStore_SaveBlock_NoDuplicates(t *testing.T) {
 	BlockCacheSize = 1
 	slot := primitives.Slot(20)
@@ -21,3 +21,6 @@ var ErrNotFoundBackfillBlockRoot = errors.Wrap(ErrNotFound, "BackfillBlockRoot")
 
 // ErrNotFoundFeeRecipient is a not found error specifically for the fee recipient getter
 var ErrNotFoundFeeRecipient = errors.Wrap(ErrNotFound, "fee recipient")
+
+// ErrNotFoundBlobSidecars is a not found error specifically for the blob sidecars getter
+var ErrNotFoundBlobSidecars = errors.Wrap(ErrNotFound, "blob sidecars")
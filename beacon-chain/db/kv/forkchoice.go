@@ -0,0 +1,40 @@
+package kv
+
+import (
+	"context"
+
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveForkchoiceSnapshot persists the given serialized fork choice store, replacing
+// any previously saved snapshot. The encoding of the snapshot is owned by the fork choice package.
+func (s *Store) SaveForkchoiceSnapshot(ctx context.Context, snapshot []byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveForkchoiceSnapshot")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(chainMetadataBucket)
+		if len(snapshot) == 0 {
+			return bkt.Delete(forkchoiceSnapshotKey)
+		}
+		return bkt.Put(forkchoiceSnapshotKey, snapshot)
+	})
+}
+
+// ForkchoiceSnapshot retrieves the last saved fork choice snapshot. It returns nil if no
+// snapshot has been saved.
+func (s *Store) ForkchoiceSnapshot(ctx context.Context) ([]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ForkchoiceSnapshot")
+	defer span.End()
+	var snapshot []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(forkchoiceSnapshotKey)
+		if enc == nil {
+			return nil
+		}
+		snapshot = make([]byte, len(enc))
+		copy(snapshot, enc)
+		return nil
+	})
+	return snapshot, err
+}
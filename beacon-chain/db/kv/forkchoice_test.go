@@ -0,0 +1,33 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_ForkchoiceSnapshot(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	snapshot, err := db.ForkchoiceSnapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(snapshot))
+
+	require.NoError(t, db.SaveForkchoiceSnapshot(ctx, []byte{1, 2, 3}))
+	snapshot, err = db.ForkchoiceSnapshot(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{1, 2, 3}, snapshot)
+
+	require.NoError(t, db.SaveForkchoiceSnapshot(ctx, []byte{4}))
+	snapshot, err = db.ForkchoiceSnapshot(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{4}, snapshot)
+
+	require.NoError(t, db.SaveForkchoiceSnapshot(ctx, nil))
+	snapshot, err = db.ForkchoiceSnapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(snapshot))
+}
@@ -122,6 +122,7 @@ var Buckets = [][]byte{
 	blockParentRootIndicesBucket,
 	finalizedBlockRootsIndexBucket,
 	blockRootValidatorHashesBucket,
+	blobSidecarRootIndicesBucket,
 	// State management service bucket.
 	newStateServiceCompatibleBucket,
 	// Migrations
@@ -129,6 +130,8 @@ var Buckets = [][]byte{
 
 	feeRecipientBucket,
 	registrationBucket,
+	lightClientUpdatesBucket,
+	blobSidecarsBucket,
 }
 
 // NewKVStore initializes a new boltDB key-value store at the directory
@@ -0,0 +1,129 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	lightclient "github.com/prysmaticlabs/prysm/v4/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// SaveLightClientUpdate saves the best light client update known for the given sync committee period,
+// replacing any update previously stored for that period.
+func (s *Store) SaveLightClientUpdate(ctx context.Context, period uint64, update interfaces.LightClientUpdate) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveLightClientUpdate")
+	defer span.End()
+	if update == nil {
+		return errors.New("nil light client update")
+	}
+	enc, err := encodeLightClientUpdate(ctx, update)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(lightClientUpdatesBucket)
+		return bkt.Put(bytesutil.Uint64ToBytesBigEndian(period), enc)
+	})
+}
+
+// LightClientUpdate retrieves the light client update stored for the given sync committee period.
+// It returns nil if there is no update for the period.
+func (s *Store) LightClientUpdate(ctx context.Context, period uint64) (interfaces.LightClientUpdate, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LightClientUpdate")
+	defer span.End()
+	var update interfaces.LightClientUpdate
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(lightClientUpdatesBucket).Get(bytesutil.Uint64ToBytesBigEndian(period))
+		if enc == nil {
+			return nil
+		}
+		var err error
+		update, err = decodeLightClientUpdate(ctx, enc)
+		return err
+	})
+	return update, err
+}
+
+// LightClientUpdates retrieves the light client updates stored for the sync committee periods in the
+// inclusive range [startPeriod, endPeriod], in ascending period order. The result stops at the first
+// period without an update, so that the returned updates are always for consecutive periods.
+func (s *Store) LightClientUpdates(ctx context.Context, startPeriod, endPeriod uint64) ([]interfaces.LightClientUpdate, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LightClientUpdates")
+	defer span.End()
+	if startPeriod > endPeriod {
+		return nil, errors.Errorf("start period %d is greater than end period %d", startPeriod, endPeriod)
+	}
+	updates := make([]interfaces.LightClientUpdate, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(lightClientUpdatesBucket).Cursor()
+		expected := startPeriod
+		for k, v := c.Seek(bytesutil.Uint64ToBytesBigEndian(startPeriod)); k != nil; k, v = c.Next() {
+			period := bytesutil.BytesToUint64BigEndian(k)
+			if period > endPeriod || period != expected {
+				return nil
+			}
+			update, err := decodeLightClientUpdate(ctx, v)
+			if err != nil {
+				return err
+			}
+			updates = append(updates, update)
+			expected++
+		}
+		return nil
+	})
+	return updates, err
+}
+
+// encodeLightClientUpdate encodes the update, prefixing Capella and later updates with the key of their
+// fork so that they can be decoded into the right type. Altair updates are stored without a prefix.
+func encodeLightClientUpdate(ctx context.Context, update interfaces.LightClientUpdate) ([]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.encodeLightClientUpdate")
+	defer span.End()
+
+	enc, err := proto.Marshal(update.Proto())
+	if err != nil {
+		return nil, err
+	}
+	switch update.Version() {
+	case version.Altair:
+	case version.Capella:
+		enc = append(capellaKey, enc...)
+	case version.Deneb:
+		enc = append(denebKey, enc...)
+	default:
+		return nil, errors.Errorf("unsupported light client update version %s", version.String(update.Version()))
+	}
+	return snappy.Encode(nil, enc), nil
+}
+
+func decodeLightClientUpdate(ctx context.Context, enc []byte) (interfaces.LightClientUpdate, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.decodeLightClientUpdate")
+	defer span.End()
+
+	enc, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not snappy decode light client update")
+	}
+	var update proto.Message
+	switch {
+	case hasCapellaKey(enc):
+		enc = enc[len(capellaKey):]
+		update = &ethpb.LightClientUpdateCapella{}
+	case hasDenebKey(enc):
+		enc = enc[len(denebKey):]
+		update = &ethpb.LightClientUpdateDeneb{}
+	default:
+		update = &ethpb.LightClientUpdate{}
+	}
+	if err := proto.Unmarshal(enc, update); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal light client update")
+	}
+	return lightclient.NewWrappedUpdate(update)
+}
@@ -0,0 +1,81 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	lightclient "github.com/prysmaticlabs/prysm/v4/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func testLightClientUpdate(t *testing.T, v int, slot primitives.Slot) interfaces.LightClientUpdate {
+	header, err := lightclient.EmptyHeader(v)
+	require.NoError(t, err)
+	header.Beacon().Slot = slot
+	update, err := lightclient.NewUpdate(header, &ethpb.SyncCommittee{}, nil, header, nil, &ethpb.SyncAggregate{}, slot+1)
+	require.NoError(t, err)
+	return update
+}
+
+func TestStore_LightClientUpdate_CanSaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	update, err := db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, nil, update)
+
+	want := testLightClientUpdate(t, version.Altair, 100)
+	require.NoError(t, db.SaveLightClientUpdate(ctx, 1, want))
+	update, err = db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(want.Proto(), update.Proto()), "Wanted %v, received %v", want, update)
+
+	// A better update for the same period replaces the stored one.
+	want = testLightClientUpdate(t, version.Altair, 200)
+	require.NoError(t, db.SaveLightClientUpdate(ctx, 1, want))
+	update, err = db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, true, proto.Equal(want.Proto(), update.Proto()), "Wanted %v, received %v", want, update)
+}
+
+func TestStore_LightClientUpdate_Versions(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	for i, v := range []int{version.Altair, version.Capella, version.Deneb} {
+		want := testLightClientUpdate(t, v, primitives.Slot(i))
+		require.NoError(t, db.SaveLightClientUpdate(ctx, uint64(i), want))
+		update, err := db.LightClientUpdate(ctx, uint64(i))
+		require.NoError(t, err)
+		assert.Equal(t, v, update.Version())
+		assert.Equal(t, true, proto.Equal(want.Proto(), update.Proto()), "Wanted %v, received %v", want, update)
+	}
+}
+
+func TestStore_LightClientUpdates(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	for _, period := range []uint64{1, 2, 3, 5} {
+		require.NoError(t, db.SaveLightClientUpdate(ctx, period, testLightClientUpdate(t, version.Altair, primitives.Slot(period))))
+	}
+
+	updates, err := db.LightClientUpdates(ctx, 2, 10)
+	require.NoError(t, err)
+	// The range stops at the gap in period 4.
+	require.Equal(t, 2, len(updates))
+	assert.Equal(t, primitives.Slot(2), updates[0].AttestedHeader().Beacon().Slot)
+	assert.Equal(t, primitives.Slot(3), updates[1].AttestedHeader().Beacon().Slot)
+
+	updates, err = db.LightClientUpdates(ctx, 4, 5)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(updates))
+
+	_, err = db.LightClientUpdates(ctx, 3, 2)
+	require.ErrorContains(t, "start period 3 is greater than end period 2", err)
+}
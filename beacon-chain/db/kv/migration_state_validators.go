@@ -137,6 +137,8 @@ func performValidatorStateMigration(ctx context.Context, bar *progressbar.Progre
 				item = item[len(bellatrixKey):]
 			case hasCapellaKey(enc):
 				item = item[len(capellaKey):]
+			case hasDenebKey(enc):
+				item = item[len(denebKey):]
 			}
 
 			detector, err := detect.FromState(item)
@@ -178,6 +180,8 @@ func performValidatorStateMigration(ctx context.Context, bar *progressbar.Progre
 				stateBytes = snappy.Encode(nil, append(bellatrixKey, rawObj...))
 			case hasCapellaKey(enc):
 				stateBytes = snappy.Encode(nil, append(capellaKey, rawObj...))
+			case hasDenebKey(enc):
+				stateBytes = snappy.Encode(nil, append(denebKey, rawObj...))
 			default:
 				stateBytes = snappy.Encode(nil, rawObj)
 			}
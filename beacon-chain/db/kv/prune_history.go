@@ -0,0 +1,277 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// pruneBatchSize is the number of slots worth of blocks deleted in a single database transaction,
+// so that pruning a long history does not hold a huge write transaction.
+const pruneBatchSize = primitives.Slot(256)
+
+// LowestRetainedSlot returns the slot below which the block and state history was pruned. Blocks and states
+// below this slot are not available, except for the genesis and origin checkpoint ones. It returns 0 when
+// the history was never pruned.
+func (s *Store) LowestRetainedSlot(ctx context.Context) (primitives.Slot, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.LowestRetainedSlot")
+	defer span.End()
+
+	var slot primitives.Slot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(lowestRetainedSlotKey)
+		if len(enc) == 0 {
+			return nil
+		}
+		slot = bytesutil.BytesToSlotBigEndian(enc)
+		return nil
+	})
+	return slot, err
+}
+
+// PruneHistory deletes the finalized blocks and states with a slot lower than the given slot, along with their
+// state summaries and database indices. The most recent state saved at or before the given slot is kept along with
+// its block, so that every state from that point on can still be regenerated by replaying blocks. The genesis,
+// origin checkpoint, justified and finalized blocks and states are never deleted, nor are the blocks and states
+// of the additional roots to keep. It returns the number of deleted blocks.
+func (s *Store) PruneHistory(ctx context.Context, beforeSlot primitives.Slot, keepRoots ...[32]byte) (int, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PruneHistory")
+	defer span.End()
+
+	f, err := s.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, err
+	}
+	finalizedSummary, err := s.StateSummary(ctx, bytesutil.ToBytes32(f.Root))
+	if err != nil {
+		return 0, err
+	}
+	// Only finalized history can be pruned.
+	if finalizedSummary != nil && beforeSlot > finalizedSummary.Slot {
+		beforeSlot = finalizedSummary.Slot
+	}
+
+	keep, err := s.retainedRoots(ctx)
+	if err != nil {
+		return 0, err
+	}
+	anchorRoot, anchorSlot, err := s.pruningAnchor(ctx, beforeSlot)
+	if err != nil {
+		return 0, err
+	}
+	keep[anchorRoot] = true
+	for _, r := range keepRoots {
+		keep[r] = true
+	}
+	lowest, err := s.LowestRetainedSlot(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if anchorSlot <= lowest {
+		return 0, nil
+	}
+
+	// States are deleted first, as deleting a state relies on the state summary or the block to find its slot.
+	if err := s.pruneStates(ctx, anchorSlot, keep); err != nil {
+		return 0, errors.Wrap(err, "could not prune states")
+	}
+	var pruned int
+	for start := lowest; start < anchorSlot; start += pruneBatchSize {
+		if ctx.Err() != nil {
+			return pruned, ctx.Err()
+		}
+		end := start + pruneBatchSize
+		if end > anchorSlot {
+			end = anchorSlot
+		}
+		n, err := s.pruneBlocks(ctx, start, end, keep)
+		if err != nil {
+			return pruned, errors.Wrapf(err, "could not prune blocks between slots %d and %d", start, end)
+		}
+		pruned += n
+	}
+
+	return pruned, s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(chainMetadataBucket).Put(lowestRetainedSlotKey, bytesutil.SlotToBytesBigEndian(anchorSlot))
+	})
+}
+
+// retainedRoots returns the roots of the blocks and states which must survive pruning.
+func (s *Store) retainedRoots(ctx context.Context) (map[[32]byte]bool, error) {
+	keep := make(map[[32]byte]bool)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(blocksBucket)
+		for _, key := range [][]byte{genesisBlockRootKey, originCheckpointBlockRootKey, backfillBlockRootKey, headBlockRootKey} {
+			if root := bkt.Get(key); len(root) == 32 {
+				keep[bytesutil.ToBytes32(root)] = true
+			}
+		}
+		bkt = tx.Bucket(checkpointBucket)
+		for _, key := range [][]byte{justifiedCheckpointKey, finalizedCheckpointKey} {
+			enc := bkt.Get(key)
+			if enc == nil {
+				continue
+			}
+			cp := &ethpb.Checkpoint{}
+			if err := decode(ctx, enc, cp); err != nil {
+				return err
+			}
+			keep[bytesutil.ToBytes32(cp.Root)] = true
+		}
+		return nil
+	})
+	return keep, err
+}
+
+// pruningAnchor returns the root and the block slot of the most recent state saved at or before the given slot.
+// History is only pruned below this block, so that the states after it can be regenerated.
+func (s *Store) pruningAnchor(ctx context.Context, beforeSlot primitives.Slot) ([32]byte, primitives.Slot, error) {
+	var root [32]byte
+	var slot primitives.Slot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(stateSlotIndicesBucket).Cursor()
+		k, v := c.Seek(bytesutil.SlotToBytesBigEndian(beforeSlot))
+		if k == nil {
+			k, v = c.Last()
+		} else if bytesutil.BytesToSlotBigEndian(k) > beforeSlot {
+			k, v = c.Prev()
+		}
+		if k == nil || len(v) < 32 {
+			return nil
+		}
+		root = bytesutil.ToBytes32(v[:32])
+		var err error
+		slot, err = s.slotByBlockRoot(ctx, tx, root[:])
+		return err
+	})
+	return root, slot, err
+}
+
+// pruneStates deletes the states with a slot lower than the given one, unless their root is kept.
+func (s *Store) pruneStates(ctx context.Context, beforeSlot primitives.Slot, keep map[[32]byte]bool) error {
+	var roots [][32]byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(stateSlotIndicesBucket).Cursor()
+		for k, v := c.First(); k != nil && bytesutil.BytesToSlotBigEndian(k) < beforeSlot; k, v = c.Next() {
+			for i := 0; i+32 <= len(v); i += 32 {
+				if root := bytesutil.ToBytes32(v[i : i+32]); !keep[root] {
+					roots = append(roots, root)
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(roots) == 0 {
+		return nil
+	}
+	if err := s.DeleteStates(ctx, roots); err != nil {
+		return err
+	}
+
+	// A state saved at a skipped slot is indexed by the slot of the state, while it is removed from the index
+	// using the slot of its block. Clean up such leftover index entries.
+	return s.db.Update(func(tx *bolt.Tx) error {
+		stateBkt := tx.Bucket(stateBucket)
+		idxBkt := tx.Bucket(stateSlotIndicesBucket)
+		c := idxBkt.Cursor()
+		keys := make([][]byte, 0)
+		values := make([][]byte, 0)
+		for k, v := c.First(); k != nil && bytesutil.BytesToSlotBigEndian(k) < beforeSlot; k, v = c.Next() {
+			remaining := make([]byte, 0, len(v))
+			for i := 0; i+32 <= len(v); i += 32 {
+				if stateBkt.Get(v[i:i+32]) != nil {
+					remaining = append(remaining, v[i:i+32]...)
+				}
+			}
+			if !bytes.Equal(remaining, v) {
+				keys = append(keys, bytesutil.SafeCopyBytes(k))
+				values = append(values, remaining)
+			}
+		}
+		return rewriteIndex(idxBkt, keys, values)
+	})
+}
+
+// pruneBlocks deletes the blocks in the [start, end) slot range, along with their state summaries and
+// indices, unless their root is kept. It returns the number of deleted blocks.
+func (s *Store) pruneBlocks(ctx context.Context, start, end primitives.Slot, keep map[[32]byte]bool) (int, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.pruneBlocks")
+	defer span.End()
+
+	var pruned int
+	prunedRoots := make([][32]byte, 0)
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		idxBkt := tx.Bucket(blockSlotIndicesBucket)
+		keys := make([][]byte, 0)
+		values := make([][]byte, 0)
+		c := idxBkt.Cursor()
+		for k, v := c.Seek(bytesutil.SlotToBytesBigEndian(start)); k != nil && bytesutil.BytesToSlotBigEndian(k) < end; k, v = c.Next() {
+			remaining := make([]byte, 0)
+			for i := 0; i+32 <= len(v); i += 32 {
+				root := bytesutil.ToBytes32(v[i : i+32])
+				if keep[root] {
+					remaining = append(remaining, root[:]...)
+					continue
+				}
+				prunedRoots = append(prunedRoots, root)
+			}
+			if len(remaining) != len(v) {
+				keys = append(keys, bytesutil.SafeCopyBytes(k))
+				values = append(values, remaining)
+			}
+		}
+		if err := rewriteIndex(idxBkt, keys, values); err != nil {
+			return err
+		}
+
+		for _, root := range prunedRoots {
+			if err := tx.Bucket(blocksBucket).Delete(root[:]); err != nil {
+				return err
+			}
+			// The children of a pruned block are indexed by its root.
+			if err := tx.Bucket(blockParentRootIndicesBucket).Delete(root[:]); err != nil {
+				return err
+			}
+			if err := tx.Bucket(finalizedBlockRootsIndexBucket).Delete(root[:]); err != nil {
+				return err
+			}
+			if err := tx.Bucket(stateSummaryBucket).Delete(root[:]); err != nil {
+				return err
+			}
+			pruned++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, root := range prunedRoots {
+		s.blockCache.Del(string(root[:]))
+		s.stateSummaryCache.delete(root)
+	}
+	return pruned, nil
+}
+
+// rewriteIndex replaces the values of the given index keys, deleting the keys left without any value.
+func rewriteIndex(bkt *bolt.Bucket, keys, values [][]byte) error {
+	for i, k := range keys {
+		if len(values[i]) == 0 {
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := bkt.Put(k, values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+// saveChain saves a chain of blocks from genesis up to the given slot, with a state every stateInterval slots.
+func saveChain(t *testing.T, db *Store, headSlot, stateInterval primitives.Slot) [][32]byte {
+	ctx := context.Background()
+	roots := make([][32]byte, 0, headSlot+1)
+	var parentRoot [32]byte
+	for slot := primitives.Slot(0); slot <= headSlot; slot++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = parentRoot[:]
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		root, err := wsb.Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, db.SaveBlock(ctx, wsb))
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: root[:]}))
+		if slot%stateInterval == 0 {
+			st, err := util.NewBeaconState()
+			require.NoError(t, err)
+			require.NoError(t, st.SetSlot(slot))
+			require.NoError(t, db.SaveState(ctx, st, root))
+		}
+		roots = append(roots, root)
+		parentRoot = root
+	}
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, roots[0]))
+	return roots
+}
+
+func TestStore_PruneHistory(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	roots := saveChain(t, db, 127, 32)
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: roots[96][:]}))
+
+	lowest, err := db.LowestRetainedSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(0), lowest)
+
+	// History is kept from the state saved at slot 64, the last one before slot 80.
+	pruned, err := db.PruneHistory(ctx, 80)
+	require.NoError(t, err)
+	assert.Equal(t, 63, pruned)
+	lowest, err = db.LowestRetainedSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(64), lowest)
+
+	assert.Equal(t, true, db.HasBlock(ctx, roots[0]), "Genesis block was pruned")
+	assert.Equal(t, true, db.HasState(ctx, roots[0]), "Genesis state was pruned")
+	for _, slot := range []primitives.Slot{1, 32, 63} {
+		assert.Equal(t, false, db.HasBlock(ctx, roots[slot]), "Block at slot %d was not pruned", slot)
+		assert.Equal(t, false, db.HasStateSummary(ctx, roots[slot]), "State summary at slot %d was not pruned", slot)
+	}
+	assert.Equal(t, false, db.HasState(ctx, roots[32]), "State at slot 32 was not pruned")
+	for _, slot := range []primitives.Slot{64, 80, 127} {
+		assert.Equal(t, true, db.HasBlock(ctx, roots[slot]), "Block at slot %d was pruned", slot)
+	}
+	assert.Equal(t, true, db.HasState(ctx, roots[64]), "State at slot 64 was pruned")
+
+	// Block indices do not reference pruned blocks anymore.
+	_, blockRoots, err := db.BlockRootsBySlot(ctx, 32)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(blockRoots))
+	_, blockRoots, err = db.BlockRootsBySlot(ctx, 64)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(blockRoots))
+
+	// Pruning again up to the same point is a no-op.
+	pruned, err = db.PruneHistory(ctx, 70)
+	require.NoError(t, err)
+	assert.Equal(t, 0, pruned)
+
+	// History cannot be pruned beyond the finalized checkpoint.
+	pruned, err = db.PruneHistory(ctx, 127)
+	require.NoError(t, err)
+	assert.Equal(t, 32, pruned)
+	lowest, err = db.LowestRetainedSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(96), lowest)
+	assert.Equal(t, false, db.HasState(ctx, roots[64]), "State at slot 64 was not pruned")
+	assert.Equal(t, true, db.HasState(ctx, roots[96]), "Finalized state was pruned")
+	assert.Equal(t, true, db.HasBlock(ctx, roots[0]), "Genesis block was pruned")
+}
+
+func TestStore_PruneHistory_KeepsOriginCheckpoint(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	roots := saveChain(t, db, 127, 32)
+	require.NoError(t, db.SaveOriginCheckpointBlockRoot(ctx, roots[32]))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: roots[96][:]}))
+
+	pruned, err := db.PruneHistory(ctx, 96)
+	require.NoError(t, err)
+	assert.Equal(t, 94, pruned)
+	assert.Equal(t, true, db.HasBlock(ctx, roots[32]), "Origin checkpoint block was pruned")
+	assert.Equal(t, true, db.HasState(ctx, roots[32]), "Origin checkpoint state was pruned")
+	assert.Equal(t, false, db.HasBlock(ctx, roots[64]), "Block at slot 64 was not pruned")
+}
+
+func TestStore_PruneHistory_KeepsRoots(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	roots := saveChain(t, db, 127, 32)
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: roots[96][:]}))
+
+	pruned, err := db.PruneHistory(ctx, 96, roots[40])
+	require.NoError(t, err)
+	assert.Equal(t, 94, pruned)
+	assert.Equal(t, true, db.HasBlock(ctx, roots[40]), "Kept block was pruned")
+	assert.Equal(t, false, db.HasBlock(ctx, roots[41]), "Block at slot 41 was not pruned")
+}
@@ -7,19 +7,21 @@ package kv
 // it easy to scan for keys that have a certain shard number as a prefix and return those
 // corresponding attestations.
 var (
-	attestationsBucket      = []byte("attestations")
-	blocksBucket            = []byte("blocks")
-	stateBucket             = []byte("state")
-	stateSummaryBucket      = []byte("state-summary")
-	proposerSlashingsBucket = []byte("proposer-slashings")
-	attesterSlashingsBucket = []byte("attester-slashings")
-	voluntaryExitsBucket    = []byte("voluntary-exits")
-	chainMetadataBucket     = []byte("chain-metadata")
-	checkpointBucket        = []byte("check-point")
-	powchainBucket          = []byte("powchain")
-	stateValidatorsBucket   = []byte("state-validators")
-	feeRecipientBucket      = []byte("fee-recipient")
-	registrationBucket      = []byte("registration")
+	attestationsBucket       = []byte("attestations")
+	blocksBucket             = []byte("blocks")
+	stateBucket              = []byte("state")
+	stateSummaryBucket       = []byte("state-summary")
+	proposerSlashingsBucket  = []byte("proposer-slashings")
+	attesterSlashingsBucket  = []byte("attester-slashings")
+	voluntaryExitsBucket     = []byte("voluntary-exits")
+	chainMetadataBucket      = []byte("chain-metadata")
+	checkpointBucket         = []byte("check-point")
+	powchainBucket           = []byte("powchain")
+	stateValidatorsBucket    = []byte("state-validators")
+	feeRecipientBucket       = []byte("fee-recipient")
+	registrationBucket       = []byte("registration")
+	lightClientUpdatesBucket = []byte("light-client-updates")
+	blobSidecarsBucket       = []byte("blob-sidecars")
 
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
@@ -37,6 +39,7 @@ var (
 	attestationTargetEpochIndicesBucket = []byte("attestation-target-epoch-indices")
 	finalizedBlockRootsIndexBucket      = []byte("finalized-block-roots-index")
 	blockRootValidatorHashesBucket      = []byte("block-root-validator-hashes")
+	blobSidecarRootIndicesBucket        = []byte("blob-sidecar-root-indices")
 
 	// Specific item keys.
 	headBlockRootKey           = []byte("head-root")
@@ -46,6 +49,8 @@ var (
 	finalizedCheckpointKey     = []byte("finalized-checkpoint")
 	powchainDataKey            = []byte("powchain-data")
 	lastValidatedCheckpointKey = []byte("last-validated-checkpoint")
+	lowestRetainedSlotKey      = []byte("lowest-retained-slot")
+	forkchoiceSnapshotKey      = []byte("forkchoice-snapshot")
 
 	// Below keys are used to identify objects are to be fork compatible.
 	// Objects that are only compatible with specific forks should be prefixed with such keys.
@@ -54,6 +59,8 @@ var (
 	bellatrixBlindKey          = []byte("blind-bellatrix")
 	capellaKey                 = []byte("capella")
 	capellaBlindKey            = []byte("blind-capella")
+	denebKey                   = []byte("deneb")
+	denebBlindKey              = []byte("blind-deneb")
 	saveBlindedBeaconBlocksKey = []byte("save-blinded-beacon-blocks")
 	// block root included in the beacon state used by weak subjectivity initial sync
 	originCheckpointBlockRootKey = []byte("origin-checkpoint-block-root")
@@ -0,0 +1,34 @@
+package pruner
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	prunedBlocksCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "db_pruned_blocks_total",
+			Help: "Number of finalized blocks deleted from the database by the history pruner.",
+		},
+	)
+	prunedBlobSidecarsCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "db_pruned_blob_sidecars_blocks_total",
+			Help: "Number of blocks whose blob sidecars were deleted from the database by the pruner.",
+		},
+	)
+	lowestRetainedSlot = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "db_lowest_retained_slot",
+			Help: "Slot of the lowest block retained in the database after pruning.",
+		},
+	)
+	pruneLatency = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "db_prune_history_latency_milliseconds",
+			Help:    "Time taken to prune the block and state history.",
+			Buckets: []float64{10, 100, 1000, 10000, 60000, 300000},
+		},
+	)
+)
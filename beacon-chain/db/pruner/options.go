@@ -0,0 +1,71 @@
+package pruner
+
+import (
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+// ServiceOption represents a functional option for the pruner service constructor.
+type ServiceOption func(*Service) error
+
+// WithMinimalHistory toggles whether the service prunes the block and state history older than the retention period.
+func WithMinimalHistory(enabled bool) ServiceOption {
+	return func(s *Service) error {
+		s.enabled = enabled
+		return nil
+	}
+}
+
+// WithRetentionEpochs sets the number of epochs of blocks and states kept before the current epoch. It cannot be
+// lower than MIN_EPOCHS_FOR_BLOCK_REQUESTS, the number of epochs of blocks a node must be able to serve to its peers.
+func WithRetentionEpochs(e primitives.Epoch) ServiceOption {
+	return func(s *Service) error {
+		if e < params.BeaconNetworkConfig().MinEpochsForBlockRequests {
+			return fmt.Errorf("history retention epochs %d is lower than the minimum of %d epochs", e, params.BeaconNetworkConfig().MinEpochsForBlockRequests)
+		}
+		s.retentionEpochs = e
+		return nil
+	}
+}
+
+// WithBlobRetentionEpochs sets the number of epochs of blob sidecars kept before the current epoch.
+func WithBlobRetentionEpochs(e primitives.Epoch) ServiceOption {
+	return func(s *Service) error {
+		if e < params.BeaconNetworkConfig().MinEpochsForBlobsSidecarsRequest {
+			return fmt.Errorf("blob retention epochs %d is lower than the minimum of %d epochs", e, params.BeaconNetworkConfig().MinEpochsForBlobsSidecarsRequest)
+		}
+		s.blobRetentionEpochs = e
+		return nil
+	}
+}
+
+// WithWeakSubjectivityCheckpoint sets the weak subjectivity checkpoint, whose block and state are never pruned.
+func WithWeakSubjectivityCheckpoint(c *ethpb.Checkpoint) ServiceOption {
+	return func(s *Service) error {
+		if c != nil && len(c.Root) == 32 {
+			s.keepRoots = append(s.keepRoots, bytesutil.ToBytes32(c.Root))
+		}
+		return nil
+	}
+}
+
+// WithDatabase sets the database to prune.
+func WithDatabase(db Database) ServiceOption {
+	return func(s *Service) error {
+		s.db = db
+		return nil
+	}
+}
+
+// WithClockWaiter sets the startup.ClockWaiter, used to wait until the genesis data is known.
+func WithClockWaiter(cw startup.ClockWaiter) ServiceOption {
+	return func(s *Service) error {
+		s.clockWaiter = cw
+		return nil
+	}
+}
@@ -0,0 +1,184 @@
+package pruner
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/runtime"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var _ runtime.Service = (*Service)(nil)
+
+var (
+	errNilDatabase    = errors.New("pruner service requires a Database")
+	errNilClockWaiter = errors.New("pruner service requires a ClockWaiter")
+)
+
+// Database describes the set of DB methods that the pruner Service needs to function.
+type Database interface {
+	PruneHistory(ctx context.Context, beforeSlot primitives.Slot, keepRoots ...[32]byte) (int, error)
+	LowestRetainedSlot(ctx context.Context) (primitives.Slot, error)
+	PruneBlobSidecars(ctx context.Context, beforeSlot primitives.Slot) (int, error)
+}
+
+// Service deletes the finalized blocks and states older than the retention period, once per epoch. The database
+// always keeps the genesis, origin checkpoint and weak subjectivity checkpoint blocks and states, as well as the
+// archived state preceding the retention period, so that every retained slot can still be regenerated.
+// Independently of the history retention, blob sidecars older than the blob retention period are always deleted.
+type Service struct {
+	ctx                 context.Context
+	cancel              context.CancelFunc
+	enabled             bool
+	retentionEpochs     primitives.Epoch
+	blobRetentionEpochs primitives.Epoch
+	keepRoots           [][32]byte
+	db                  Database
+	clockWaiter         startup.ClockWaiter
+}
+
+// NewService initializes a pruner Service with the given options.
+func NewService(ctx context.Context, opts ...ServiceOption) (*Service, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Service{
+		ctx:                 ctx,
+		cancel:              cancel,
+		retentionEpochs:     params.BeaconNetworkConfig().MinEpochsForBlockRequests,
+		blobRetentionEpochs: params.BeaconNetworkConfig().MinEpochsForBlobsSidecarsRequest,
+	}
+	for _, o := range opts {
+		if err := o(s); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	if !s.enabled {
+		return s, nil
+	}
+	if s.db == nil {
+		cancel()
+		return nil, errNilDatabase
+	}
+	if s.clockWaiter == nil {
+		cancel()
+		return nil, errNilClockWaiter
+	}
+	return s, nil
+}
+
+// Start runs the pruning loop in the background.
+func (s *Service) Start() {
+	if !s.enabled {
+		log.Debug("History pruning not enabled")
+	}
+	if s.db == nil || s.clockWaiter == nil {
+		return
+	}
+	go s.run()
+}
+
+// Stop the pruner service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the pruner service.
+func (s *Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	clock, err := s.clockWaiter.WaitForClock(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Pruner service failed to receive startup event")
+		return
+	}
+	if s.enabled {
+		log.WithField("retentionEpochs", s.retentionEpochs).Info("Pruning block and state history older than the retention period")
+	}
+	s.prune(slots.ToEpoch(clock.CurrentSlot()))
+	s.pruneBlobSidecars(slots.ToEpoch(clock.CurrentSlot()))
+
+	ticker := slots.NewSlotTicker(clock.GenesisTime(), params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	for {
+		select {
+		case slot := <-ticker.C():
+			if slots.IsEpochStart(slot) {
+				s.prune(slots.ToEpoch(slot))
+				s.pruneBlobSidecars(slots.ToEpoch(slot))
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// prune deletes the history older than the retention period, as seen from the given epoch.
+func (s *Service) prune(current primitives.Epoch) {
+	if !s.enabled || current <= s.retentionEpochs {
+		return
+	}
+	cutoff, err := slots.EpochStart(current - s.retentionEpochs)
+	if err != nil {
+		log.WithError(err).Error("Could not compute the history pruning cutoff")
+		return
+	}
+	start := time.Now()
+	pruned, err := s.db.PruneHistory(s.ctx, cutoff, s.keepRoots...)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			log.WithError(err).Error("Could not prune history")
+		}
+		return
+	}
+	lowest, err := s.db.LowestRetainedSlot(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not read the lowest retained slot")
+		return
+	}
+	lowestRetainedSlot.Set(float64(lowest))
+	if pruned == 0 {
+		return
+	}
+	prunedBlocksCount.Add(float64(pruned))
+	pruneLatency.Observe(float64(time.Since(start).Milliseconds()))
+	log.WithFields(logrus.Fields{
+		"prunedBlocks":       pruned,
+		"lowestRetainedSlot": lowest,
+		"duration":           time.Since(start),
+	}).Info("Pruned block and state history")
+}
+
+// pruneBlobSidecars deletes the blob sidecars older than the blob retention period, as seen from the given epoch.
+func (s *Service) pruneBlobSidecars(current primitives.Epoch) {
+	if current <= s.blobRetentionEpochs {
+		return
+	}
+	cutoff, err := slots.EpochStart(current - s.blobRetentionEpochs)
+	if err != nil {
+		log.WithError(err).Error("Could not compute the blob sidecars pruning cutoff")
+		return
+	}
+	pruned, err := s.db.PruneBlobSidecars(s.ctx, cutoff)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			log.WithError(err).Error("Could not prune blob sidecars")
+		}
+		return
+	}
+	if pruned == 0 {
+		return
+	}
+	prunedBlobSidecarsCount.Add(float64(pruned))
+	log.WithFields(logrus.Fields{
+		"prunedBlocks": pruned,
+		"cutoffSlot":   cutoff,
+	}).Debug("Pruned blob sidecars")
+}
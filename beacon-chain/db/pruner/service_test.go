@@ -0,0 +1,96 @@
+package pruner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+type mockDatabase struct {
+	beforeSlots     []primitives.Slot
+	keepRoots       [][32]byte
+	lowest          primitives.Slot
+	blobBeforeSlots []primitives.Slot
+}
+
+func (m *mockDatabase) PruneHistory(_ context.Context, beforeSlot primitives.Slot, keepRoots ...[32]byte) (int, error) {
+	m.beforeSlots = append(m.beforeSlots, beforeSlot)
+	m.keepRoots = keepRoots
+	m.lowest = beforeSlot
+	return 1, nil
+}
+
+func (m *mockDatabase) LowestRetainedSlot(context.Context) (primitives.Slot, error) {
+	return m.lowest, nil
+}
+
+func (m *mockDatabase) PruneBlobSidecars(_ context.Context, beforeSlot primitives.Slot) (int, error) {
+	m.blobBeforeSlots = append(m.blobBeforeSlots, beforeSlot)
+	return 1, nil
+}
+
+func TestNewService(t *testing.T) {
+	_, err := NewService(context.Background())
+	require.NoError(t, err)
+	_, err = NewService(context.Background(), WithMinimalHistory(true), WithClockWaiter(startup.NewClockSynchronizer()))
+	require.ErrorIs(t, err, errNilDatabase)
+	_, err = NewService(context.Background(), WithMinimalHistory(true), WithDatabase(&mockDatabase{}))
+	require.ErrorIs(t, err, errNilClockWaiter)
+}
+
+func TestService_Prune(t *testing.T) {
+	minEpochs := params.BeaconNetworkConfig().MinEpochsForBlockRequests
+	_, err := NewService(context.Background(), WithRetentionEpochs(minEpochs-1))
+	require.ErrorContains(t, "lower than the minimum", err)
+
+	db := &mockDatabase{}
+	wsRoot := [32]byte{'a'}
+	s, err := NewService(context.Background(),
+		WithMinimalHistory(true),
+		WithDatabase(db),
+		WithClockWaiter(startup.NewClockSynchronizer()),
+		WithRetentionEpochs(minEpochs+10),
+		WithWeakSubjectivityCheckpoint(&ethpb.Checkpoint{Epoch: 2, Root: wsRoot[:]}),
+	)
+	require.NoError(t, err)
+
+	// Nothing is pruned before the retention period has elapsed.
+	s.prune(minEpochs + 10)
+	assert.Equal(t, 0, len(db.beforeSlots))
+
+	s.prune(minEpochs + 15)
+	require.Equal(t, 1, len(db.beforeSlots))
+	assert.Equal(t, primitives.Slot(5)*params.BeaconConfig().SlotsPerEpoch, db.beforeSlots[0])
+	require.Equal(t, 1, len(db.keepRoots))
+	assert.Equal(t, wsRoot, db.keepRoots[0])
+}
+
+func TestService_PruneBlobSidecars(t *testing.T) {
+	minEpochs := params.BeaconNetworkConfig().MinEpochsForBlobsSidecarsRequest
+	_, err := NewService(context.Background(), WithBlobRetentionEpochs(minEpochs-1))
+	require.ErrorContains(t, "lower than the minimum", err)
+
+	db := &mockDatabase{}
+	s, err := NewService(context.Background(),
+		WithDatabase(db),
+		WithClockWaiter(startup.NewClockSynchronizer()),
+		WithBlobRetentionEpochs(minEpochs+10),
+	)
+	require.NoError(t, err)
+
+	// History pruning is not enabled, blob sidecars are pruned regardless.
+	s.prune(minEpochs + 20)
+	assert.Equal(t, 0, len(db.beforeSlots))
+
+	s.pruneBlobSidecars(minEpochs + 10)
+	assert.Equal(t, 0, len(db.blobBeforeSlots))
+	s.pruneBlobSidecars(minEpochs + 20)
+	require.Equal(t, 1, len(db.blobBeforeSlots))
+	assert.Equal(t, primitives.Slot(10)*params.BeaconConfig().SlotsPerEpoch, db.blobBeforeSlots[0])
+}
@@ -66,3 +66,12 @@ func (h *HeaderInfo) UnmarshalJSON(data []byte) error {
 	h.Hash = *dec.Hash
 	return nil
 }
+
+// ClientVersionV1 identifies a client implementation, as exchanged between the
+// consensus and the execution clients over engine_getClientVersionV1.
+type ClientVersionV1 struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+}
@@ -0,0 +1,121 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// tallyVoteSinceHead accounts the vote of the given validator for the given
+// root in the tally of votes received since the last head change.
+func (f *ForkChoice) tallyVoteSinceHead(index uint64, root [fieldparams.RootLength]byte) {
+	if f.votesSinceHead == nil {
+		f.votesSinceHead = make(map[[fieldparams.RootLength]byte]*forkchoicetypes.VoteTally)
+	}
+	tally, ok := f.votesSinceHead[root]
+	if !ok {
+		tally = &forkchoicetypes.VoteTally{Root: root}
+		f.votesSinceHead[root] = tally
+	}
+	tally.Count++
+	if index < uint64(len(f.justifiedBalances)) {
+		tally.Balance += f.justifiedBalances[index]
+	}
+}
+
+// resetVotesSinceHead clears the tally of votes and records the slot at which
+// the head changed.
+func (f *ForkChoice) resetVotesSinceHead() {
+	f.votesSinceHead = make(map[[fieldparams.RootLength]byte]*forkchoicetypes.VoteTally)
+	f.headChangedAt = slots.SinceGenesis(time.Unix(int64(f.store.genesisTime), 0))
+}
+
+// Diagnostics returns a detailed snapshot of the fork choice store.
+func (f *ForkChoice) Diagnostics(ctx context.Context) (*forkchoicetypes.Diagnostics, error) {
+	d := &forkchoicetypes.Diagnostics{
+		JustifiedCheckpoint:           copyCheckpoint(f.store.justifiedCheckpoint),
+		UnrealizedJustifiedCheckpoint: copyCheckpoint(f.store.unrealizedJustifiedCheckpoint),
+		PreviousJustifiedCheckpoint:   copyCheckpoint(f.store.prevJustifiedCheckpoint),
+		FinalizedCheckpoint:           copyCheckpoint(f.store.finalizedCheckpoint),
+		UnrealizedFinalizedCheckpoint: copyCheckpoint(f.store.unrealizedFinalizedCheckpoint),
+		ProposerBoostRoot:             f.store.proposerBoostRoot,
+		PreviousProposerBoostRoot:     f.store.previousProposerBoostRoot,
+		PreviousProposerBoostScore:    f.store.previousProposerBoostScore,
+		CommitteeWeight:               f.store.committeeWeight,
+		GenesisTime:                   f.store.genesisTime,
+		HeadChangedAt:                 f.headChangedAt,
+		Nodes:                         make([]*forkchoicetypes.NodeDiagnostics, 0, f.NodeCount()),
+		VotesSinceHeadChange:          make([]*forkchoicetypes.VoteTally, 0, len(f.votesSinceHead)),
+	}
+	if f.store.headNode != nil {
+		d.HeadRoot = f.store.headNode.root
+	}
+	if f.store.treeRootNode != nil {
+		var err error
+		d.Nodes, err = f.nodeDiagnostics(ctx, f.store.treeRootNode, d.Nodes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, tally := range f.votesSinceHead {
+		d.VotesSinceHeadChange = append(d.VotesSinceHeadChange, &forkchoicetypes.VoteTally{
+			Root:    tally.Root,
+			Count:   tally.Count,
+			Balance: tally.Balance,
+		})
+	}
+	sort.Slice(d.VotesSinceHeadChange, func(i, j int) bool {
+		return d.VotesSinceHeadChange[i].Balance > d.VotesSinceHeadChange[j].Balance
+	})
+	return d, nil
+}
+
+// nodeDiagnostics appends to the given list the diagnostics of the given node
+// and of all the nodes descending from it.
+func (f *ForkChoice) nodeDiagnostics(ctx context.Context, n *Node, nodes []*forkchoicetypes.NodeDiagnostics) ([]*forkchoicetypes.NodeDiagnostics, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	thisNode := &forkchoicetypes.NodeDiagnostics{
+		Slot:                     n.slot,
+		Root:                     n.root,
+		PayloadHash:              n.payloadHash,
+		JustifiedEpoch:           n.justifiedEpoch,
+		FinalizedEpoch:           n.finalizedEpoch,
+		UnrealizedJustifiedEpoch: n.unrealizedJustifiedEpoch,
+		UnrealizedFinalizedEpoch: n.unrealizedFinalizedEpoch,
+		Balance:                  n.balance,
+		Weight:                   n.weight,
+		Optimistic:               n.optimistic,
+		Timestamp:                n.timestamp,
+	}
+	if n.parent != nil {
+		thisNode.ParentRoot = n.parent.root
+	}
+	if n.bestDescendant != nil {
+		thisNode.BestDescendant = n.bestDescendant.root
+	}
+	if f.store.headNode != nil {
+		thisNode.Canonical = f.IsCanonical(n.root)
+	}
+	nodes = append(nodes, thisNode)
+	var err error
+	for _, child := range n.children {
+		nodes, err = f.nodeDiagnostics(ctx, child, nodes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func copyCheckpoint(cp *forkchoicetypes.Checkpoint) *forkchoicetypes.Checkpoint {
+	if cp == nil {
+		return nil
+	}
+	return &forkchoicetypes.Checkpoint{Epoch: cp.Epoch, Root: cp.Root}
+}
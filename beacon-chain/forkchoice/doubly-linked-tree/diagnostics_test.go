@@ -0,0 +1,69 @@
+package doublylinkedtree
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestForkChoice_Diagnostics(t *testing.T) {
+	f := setup(0, 0)
+	ctx := context.Background()
+	st, blkRoot, err := prepareForkchoiceState(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, params.BeaconConfig().ZeroHash, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, blkRoot))
+	st, blkRoot, err = prepareForkchoiceState(ctx, 2, indexToHash(2), indexToHash(1), params.BeaconConfig().ZeroHash, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, blkRoot))
+	st, blkRoot, err = prepareForkchoiceState(ctx, 3, indexToHash(3), indexToHash(1), params.BeaconConfig().ZeroHash, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, blkRoot))
+
+	f.justifiedBalances = []uint64{10, 20, 30}
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(3), 0)
+	head, err := f.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(3), head)
+
+	f.ProcessAttestation(ctx, []uint64{1, 2}, indexToHash(2), 0)
+	d, err := f.Diagnostics(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(3), d.HeadRoot)
+	require.Equal(t, 4, len(d.Nodes))
+	require.Equal(t, 1, len(d.VotesSinceHeadChange))
+	assert.Equal(t, indexToHash(2), d.VotesSinceHeadChange[0].Root)
+	assert.Equal(t, uint64(2), d.VotesSinceHeadChange[0].Count)
+	assert.Equal(t, uint64(50), d.VotesSinceHeadChange[0].Balance)
+	for _, n := range d.Nodes {
+		switch n.Root {
+		case indexToHash(2):
+			assert.Equal(t, false, n.Canonical)
+			assert.Equal(t, indexToHash(1), n.ParentRoot)
+		case indexToHash(3):
+			assert.Equal(t, true, n.Canonical)
+			assert.Equal(t, uint64(10), n.Weight)
+		}
+	}
+
+	// The head moves to the block that received the new votes, which resets the tally.
+	head, err = f.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(2), head)
+	d, err = f.Diagnostics(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(2), d.HeadRoot)
+	assert.Equal(t, 0, len(d.VotesSinceHeadChange))
+
+	var buf bytes.Buffer
+	require.NoError(t, d.WriteDOT(&buf))
+	dot := buf.String()
+	assert.StringContains(t, "digraph forkchoice {", dot)
+	assert.StringContains(t, fmt.Sprintf("\"%#x\" -> \"%#x\";", indexToHash(1), indexToHash(2)), dot)
+	assert.StringContains(t, fmt.Sprintf("\"%#x\" -> \"%#x\";", indexToHash(1), indexToHash(3)), dot)
+	assert.StringContains(t, "color=green", dot)
+}
@@ -39,7 +39,12 @@ func New() *ForkChoice {
 
 	b := make([]uint64, 0)
 	v := make([]Vote, 0)
-	return &ForkChoice{store: s, balances: b, votes: v}
+	return &ForkChoice{
+		store:          s,
+		balances:       b,
+		votes:          v,
+		votesSinceHead: make(map[[fieldparams.RootLength]byte]*forkchoicetypes.VoteTally),
+	}
 }
 
 // NodeCount returns the current number of nodes in the Store.
@@ -75,7 +80,15 @@ func (f *ForkChoice) Head(
 	if err := f.store.treeRootNode.updateBestDescendant(ctx, jc.Epoch, fc.Epoch, currentEpoch); err != nil {
 		return [32]byte{}, errors.Wrap(err, "could not update best descendant")
 	}
-	return f.store.head(ctx)
+	previousHead := f.store.headNode
+	head, err := f.store.head(ctx)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if f.store.headNode != previousHead {
+		f.resetVotesSinceHead()
+	}
+	return head, nil
 }
 
 // ProcessAttestation processes attestation for vote accounting, it iterates around validator indices
@@ -98,6 +111,7 @@ func (f *ForkChoice) ProcessAttestation(ctx context.Context, validatorIndices []
 		if newVote || targetEpoch > f.votes[index].nextEpoch {
 			f.votes[index].nextEpoch = targetEpoch
 			f.votes[index].nextRoot = blockRoot
+			f.tallyVoteSinceHead(index, blockRoot)
 		}
 	}
 
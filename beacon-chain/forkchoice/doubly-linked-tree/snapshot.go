@@ -0,0 +1,282 @@
+package doublylinkedtree
+
+import (
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"google.golang.org/protobuf/proto"
+)
+
+var errSnapshotGenesisMismatch = errors.New("fork choice snapshot genesis time does not match the store")
+
+// snapshotNode is the persisted representation of a Node.
+type snapshotNode struct {
+	slot                     primitives.Slot
+	root                     [fieldparams.RootLength]byte
+	parentRoot               [fieldparams.RootLength]byte
+	payloadHash              [fieldparams.RootLength]byte
+	justifiedEpoch           primitives.Epoch
+	unrealizedJustifiedEpoch primitives.Epoch
+	finalizedEpoch           primitives.Epoch
+	unrealizedFinalizedEpoch primitives.Epoch
+	optimistic               bool
+	timestamp                uint64
+}
+
+// snapshot is the decoded content of a fork choice snapshot.
+type snapshot struct {
+	genesisTime                   uint64
+	justifiedCheckpoint           *forkchoicetypes.Checkpoint
+	prevJustifiedCheckpoint       *forkchoicetypes.Checkpoint
+	unrealizedJustifiedCheckpoint *forkchoicetypes.Checkpoint
+	finalizedCheckpoint           *forkchoicetypes.Checkpoint
+	unrealizedFinalizedCheckpoint *forkchoicetypes.Checkpoint
+	slashedIndices                []primitives.ValidatorIndex
+	nodes                         []*snapshotNode
+	votes                         []Vote
+}
+
+// Snapshot serializes the nodes, the checkpoints and the latest validator
+// votes of the store so that fork choice can be restored after a restart.
+// Nodes are written parents first. Votes reference their roots through a
+// table of the distinct roots, which keeps the snapshot small.
+func (f *ForkChoice) Snapshot() ([]byte, error) {
+	s := &ethpb.ForkChoiceSnapshot{
+		GenesisTime:                   f.store.genesisTime,
+		JustifiedCheckpoint:           snapshotCheckpoint(f.store.justifiedCheckpoint),
+		PreviousJustifiedCheckpoint:   snapshotCheckpoint(f.store.prevJustifiedCheckpoint),
+		UnrealizedJustifiedCheckpoint: snapshotCheckpoint(f.store.unrealizedJustifiedCheckpoint),
+		FinalizedCheckpoint:           snapshotCheckpoint(f.store.finalizedCheckpoint),
+		UnrealizedFinalizedCheckpoint: snapshotCheckpoint(f.store.unrealizedFinalizedCheckpoint),
+		SlashedIndices:                make([]primitives.ValidatorIndex, 0, len(f.store.slashedIndices)),
+		Votes:                         make([]*ethpb.ForkChoiceSnapshotVote, len(f.votes)),
+	}
+	for index := range f.store.slashedIndices {
+		s.SlashedIndices = append(s.SlashedIndices, index)
+	}
+
+	nodes := make([]*Node, 0, len(f.store.nodeByRoot))
+	if f.store.treeRootNode != nil {
+		nodes = f.store.treeRootNode.appendDescendants(nodes)
+	}
+	s.Nodes = make([]*ethpb.ForkChoiceSnapshotNode, len(nodes))
+	for i, n := range nodes {
+		s.Nodes[i] = n.snapshot()
+	}
+
+	rootIndices := make(map[[fieldparams.RootLength]byte]uint32)
+	for i, v := range f.votes {
+		idx, ok := rootIndices[v.nextRoot]
+		if !ok {
+			idx = uint32(len(s.VoteRoots))
+			rootIndices[v.nextRoot] = idx
+			root := v.nextRoot
+			s.VoteRoots = append(s.VoteRoots, root[:])
+		}
+		s.Votes[i] = &ethpb.ForkChoiceSnapshotVote{RootIndex: idx, NextEpoch: v.nextEpoch}
+	}
+	enc, err := proto.Marshal(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode fork choice snapshot")
+	}
+	return snappy.Encode(nil, enc), nil
+}
+
+// appendDescendants appends this node and all the nodes descending from it,
+// parents first.
+func (n *Node) appendDescendants(nodes []*Node) []*Node {
+	nodes = append(nodes, n)
+	for _, child := range n.children {
+		nodes = child.appendDescendants(nodes)
+	}
+	return nodes
+}
+
+// snapshot returns the persisted fields of this node.
+func (n *Node) snapshot() *ethpb.ForkChoiceSnapshotNode {
+	var parentRoot [fieldparams.RootLength]byte
+	if n.parent != nil {
+		parentRoot = n.parent.root
+	}
+	root, payloadHash := n.root, n.payloadHash
+	return &ethpb.ForkChoiceSnapshotNode{
+		Slot:                     n.slot,
+		Root:                     root[:],
+		ParentRoot:               parentRoot[:],
+		PayloadHash:              payloadHash[:],
+		JustifiedEpoch:           n.justifiedEpoch,
+		UnrealizedJustifiedEpoch: n.unrealizedJustifiedEpoch,
+		FinalizedEpoch:           n.finalizedEpoch,
+		UnrealizedFinalizedEpoch: n.unrealizedFinalizedEpoch,
+		Optimistic:               n.optimistic,
+		Timestamp:                n.timestamp,
+	}
+}
+
+func snapshotCheckpoint(cp *forkchoicetypes.Checkpoint) *ethpb.Checkpoint {
+	if cp == nil {
+		cp = &forkchoicetypes.Checkpoint{}
+	}
+	root := cp.Root
+	return &ethpb.Checkpoint{Epoch: cp.Epoch, Root: root[:]}
+}
+
+// RestoreSnapshot restores a snapshot taken by Snapshot on top of a store
+// that has been initialized with the finalized checkpoint. Nodes whose parent
+// is not in the store, or whose block is rejected by hasBlock, are skipped
+// together with their descendants. A node is only restored as fully validated
+// if its parent is validated as well. The restored votes are accounted in the
+// node weights on the next head computation. It returns the number of
+// restored nodes.
+func (f *ForkChoice) RestoreSnapshot(ctx context.Context, enc []byte, hasBlock forkchoice.BlockChecker) (int, error) {
+	s, err := decodeSnapshot(enc)
+	if err != nil {
+		return 0, err
+	}
+	if f.store.genesisTime != 0 && s.genesisTime != f.store.genesisTime {
+		return 0, errSnapshotGenesisMismatch
+	}
+
+	// Restored blocks arrived in a previous run, they should never be boosted.
+	proposerBoostRoot := f.store.proposerBoostRoot
+	restored := 0
+	for _, sn := range s.nodes {
+		if ctx.Err() != nil {
+			return restored, ctx.Err()
+		}
+		if _, ok := f.store.nodeByRoot[sn.root]; ok {
+			continue
+		}
+		parent, ok := f.store.nodeByRoot[sn.parentRoot]
+		if !ok || parent == nil {
+			continue
+		}
+		if hasBlock != nil && !hasBlock(ctx, sn.root) {
+			continue
+		}
+		n, err := f.store.insert(ctx, sn.slot, sn.root, sn.parentRoot, sn.payloadHash, sn.justifiedEpoch, sn.finalizedEpoch)
+		if err != nil {
+			return restored, errors.Wrapf(err, "could not restore node %#x", sn.root)
+		}
+		n.unrealizedJustifiedEpoch = sn.unrealizedJustifiedEpoch
+		n.unrealizedFinalizedEpoch = sn.unrealizedFinalizedEpoch
+		n.optimistic = sn.optimistic || parent.optimistic
+		n.timestamp = sn.timestamp
+		restored++
+	}
+	f.store.proposerBoostRoot = proposerBoostRoot
+
+	if s.unrealizedJustifiedCheckpoint.Epoch > f.store.unrealizedJustifiedCheckpoint.Epoch && f.HasNode(s.unrealizedJustifiedCheckpoint.Root) {
+		f.store.unrealizedJustifiedCheckpoint = s.unrealizedJustifiedCheckpoint
+	}
+	if s.unrealizedFinalizedCheckpoint.Epoch > f.store.unrealizedFinalizedCheckpoint.Epoch && f.HasNode(s.unrealizedFinalizedCheckpoint.Root) {
+		f.store.unrealizedFinalizedCheckpoint = s.unrealizedFinalizedCheckpoint
+	}
+	if s.justifiedCheckpoint.Epoch > f.store.justifiedCheckpoint.Epoch && f.HasNode(s.justifiedCheckpoint.Root) {
+		if err := f.UpdateJustifiedCheckpoint(ctx, s.justifiedCheckpoint); err != nil {
+			return restored, errors.Wrap(err, "could not restore justified checkpoint")
+		}
+		f.store.prevJustifiedCheckpoint = s.prevJustifiedCheckpoint
+	}
+
+	for _, index := range s.slashedIndices {
+		f.store.slashedIndices[index] = true
+	}
+
+	// The restored votes have not been accounted in any node yet, the next
+	// call to updateBalances adds them to the nodes they point to.
+	f.votes = s.votes
+	f.balances = make([]uint64, 0)
+	return restored, nil
+}
+
+func decodeSnapshot(enc []byte) (*snapshot, error) {
+	dec, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress fork choice snapshot")
+	}
+	pb := &ethpb.ForkChoiceSnapshot{}
+	if err := proto.Unmarshal(dec, pb); err != nil {
+		return nil, errors.Wrap(err, "could not decode fork choice snapshot")
+	}
+	s := &snapshot{
+		genesisTime:    pb.GenesisTime,
+		slashedIndices: pb.SlashedIndices,
+		nodes:          make([]*snapshotNode, len(pb.Nodes)),
+		votes:          make([]Vote, len(pb.Votes)),
+	}
+	for _, cp := range []struct {
+		dst **forkchoicetypes.Checkpoint
+		src *ethpb.Checkpoint
+	}{
+		{&s.justifiedCheckpoint, pb.JustifiedCheckpoint},
+		{&s.prevJustifiedCheckpoint, pb.PreviousJustifiedCheckpoint},
+		{&s.unrealizedJustifiedCheckpoint, pb.UnrealizedJustifiedCheckpoint},
+		{&s.finalizedCheckpoint, pb.FinalizedCheckpoint},
+		{&s.unrealizedFinalizedCheckpoint, pb.UnrealizedFinalizedCheckpoint},
+	} {
+		root, err := snapshotRoot(cp.src.GetRoot())
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid fork choice snapshot checkpoint")
+		}
+		*cp.dst = &forkchoicetypes.Checkpoint{Epoch: cp.src.GetEpoch(), Root: root}
+	}
+
+	for i, n := range pb.Nodes {
+		sn := &snapshotNode{
+			slot:                     n.Slot,
+			justifiedEpoch:           n.JustifiedEpoch,
+			unrealizedJustifiedEpoch: n.UnrealizedJustifiedEpoch,
+			finalizedEpoch:           n.FinalizedEpoch,
+			unrealizedFinalizedEpoch: n.UnrealizedFinalizedEpoch,
+			optimistic:               n.Optimistic,
+			timestamp:                n.Timestamp,
+		}
+		if sn.root, err = snapshotRoot(n.Root); err != nil {
+			return nil, errors.Wrapf(err, "invalid fork choice snapshot node %d", i)
+		}
+		if sn.parentRoot, err = snapshotRoot(n.ParentRoot); err != nil {
+			return nil, errors.Wrapf(err, "invalid fork choice snapshot node %d", i)
+		}
+		if sn.payloadHash, err = snapshotRoot(n.PayloadHash); err != nil {
+			return nil, errors.Wrapf(err, "invalid fork choice snapshot node %d", i)
+		}
+		s.nodes[i] = sn
+	}
+
+	roots := make([][fieldparams.RootLength]byte, len(pb.VoteRoots))
+	for i, r := range pb.VoteRoots {
+		if roots[i], err = snapshotRoot(r); err != nil {
+			return nil, errors.Wrapf(err, "invalid fork choice snapshot vote root %d", i)
+		}
+	}
+	for i, v := range pb.Votes {
+		if int(v.RootIndex) >= len(roots) {
+			return nil, errors.Errorf("fork choice snapshot vote %d references unknown root %d", i, v.RootIndex)
+		}
+		s.votes[i] = Vote{
+			currentRoot: params.BeaconConfig().ZeroHash,
+			nextRoot:    roots[v.RootIndex],
+			nextEpoch:   v.NextEpoch,
+		}
+	}
+	return s, nil
+}
+
+// snapshotRoot converts a persisted root into a fixed size root, rejecting
+// roots of any other length.
+func snapshotRoot(b []byte) ([fieldparams.RootLength]byte, error) {
+	var root [fieldparams.RootLength]byte
+	if len(b) != fieldparams.RootLength {
+		return root, errors.Errorf("root has length %d, want %d", len(b), fieldparams.RootLength)
+	}
+	copy(root[:], b)
+	return root, nil
+}
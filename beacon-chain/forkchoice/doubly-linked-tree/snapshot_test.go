@@ -0,0 +1,158 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/snappy"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// snapshotTestStore returns a store with the tree
+//
+//	0 <- 1 <- 2
+//	      \-- 3 <- 4
+//
+// where block 4 has been fully validated and validators vote for blocks 2 and 4.
+func snapshotTestStore(t *testing.T) *ForkChoice {
+	f := setup(0, 0)
+	ctx := context.Background()
+	for _, b := range []struct{ slot, parent uint64 }{{1, 0}, {2, 1}, {3, 1}, {4, 3}} {
+		parentRoot := params.BeaconConfig().ZeroHash
+		if b.parent != 0 {
+			parentRoot = indexToHash(b.parent)
+		}
+		st, root, err := prepareForkchoiceState(ctx, primitives.Slot(b.slot), indexToHash(b.slot), parentRoot, indexToHash(100+b.slot), 0, 0)
+		require.NoError(t, err)
+		require.NoError(t, f.InsertNode(ctx, st, root))
+	}
+	require.NoError(t, f.SetOptimisticToValid(ctx, indexToHash(4)))
+	f.store.nodeByRoot[indexToHash(4)].unrealizedJustifiedEpoch = 1
+	f.justifiedBalances = []uint64{10, 20, 30}
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(2), 1)
+	f.ProcessAttestation(ctx, []uint64{1, 2}, indexToHash(4), 1)
+	f.InsertSlashedIndex(ctx, 5)
+	return f
+}
+
+func TestForkChoice_SnapshotRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	f := snapshotTestStore(t)
+	head, err := f.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(4), head)
+
+	enc, err := f.Snapshot()
+	require.NoError(t, err)
+
+	restoredStore := setup(0, 0)
+	restoredStore.justifiedBalances = []uint64{10, 20, 30}
+	require.NoError(t, restoredStore.SetOptimisticToValid(ctx, params.BeaconConfig().ZeroHash))
+	restored, err := restoredStore.RestoreSnapshot(ctx, enc, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 4, restored)
+	assert.Equal(t, f.NodeCount(), restoredStore.NodeCount())
+	assert.Equal(t, true, restoredStore.store.slashedIndices[5])
+
+	restoredHead, err := restoredStore.Head(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, head, restoredHead)
+	for root, n := range f.store.nodeByRoot {
+		rn, ok := restoredStore.store.nodeByRoot[root]
+		require.Equal(t, true, ok)
+		assert.Equal(t, n.slot, rn.slot)
+		assert.Equal(t, n.payloadHash, rn.payloadHash)
+		assert.Equal(t, n.optimistic, rn.optimistic)
+		assert.Equal(t, n.unrealizedJustifiedEpoch, rn.unrealizedJustifiedEpoch)
+		assert.Equal(t, n.timestamp, rn.timestamp)
+		assert.Equal(t, n.weight, rn.weight)
+	}
+	require.Equal(t, len(f.votes), len(restoredStore.votes))
+	for i := range f.votes {
+		assert.Equal(t, f.votes[i].nextRoot, restoredStore.votes[i].nextRoot)
+		assert.Equal(t, f.votes[i].nextEpoch, restoredStore.votes[i].nextEpoch)
+	}
+}
+
+func TestForkChoice_RestoreSnapshot_SkipsUnknownBlocks(t *testing.T) {
+	ctx := context.Background()
+	f := snapshotTestStore(t)
+	enc, err := f.Snapshot()
+	require.NoError(t, err)
+
+	restoredStore := setup(0, 0)
+	restoredStore.justifiedBalances = []uint64{10, 20, 30}
+	hasBlock := func(_ context.Context, root [32]byte) bool {
+		return root != indexToHash(3)
+	}
+	restored, err := restoredStore.RestoreSnapshot(ctx, enc, hasBlock)
+	require.NoError(t, err)
+	// Block 4 descends from the missing block 3.
+	assert.Equal(t, 2, restored)
+	assert.Equal(t, true, restoredStore.HasNode(indexToHash(2)))
+	assert.Equal(t, false, restoredStore.HasNode(indexToHash(3)))
+	assert.Equal(t, false, restoredStore.HasNode(indexToHash(4)))
+
+	head, err := restoredStore.Head(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(2), head)
+}
+
+func TestForkChoice_RestoreSnapshot_OptimisticParent(t *testing.T) {
+	ctx := context.Background()
+	f := snapshotTestStore(t)
+	enc, err := f.Snapshot()
+	require.NoError(t, err)
+
+	restoredStore := setup(0, 0)
+	restoredStore.store.treeRootNode.optimistic = true
+	_, err = restoredStore.RestoreSnapshot(ctx, enc, nil)
+	require.NoError(t, err)
+	optimistic, err := restoredStore.IsOptimistic(indexToHash(4))
+	require.NoError(t, err)
+	assert.Equal(t, true, optimistic)
+}
+
+func TestForkChoice_RestoreSnapshot_Invalid(t *testing.T) {
+	ctx := context.Background()
+	f := snapshotTestStore(t)
+	f.SetGenesisTime(100)
+	enc, err := f.Snapshot()
+	require.NoError(t, err)
+
+	restoredStore := setup(0, 0)
+	restoredStore.SetGenesisTime(200)
+	_, err = restoredStore.RestoreSnapshot(ctx, enc, nil)
+	require.ErrorIs(t, err, errSnapshotGenesisMismatch)
+
+	_, err = restoredStore.RestoreSnapshot(ctx, []byte("not a snapshot"), nil)
+	require.ErrorContains(t, "could not decompress fork choice snapshot", err)
+
+	dec, err := decodeSnapshot(enc)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(dec.nodes))
+	assert.DeepEqual(t, &forkchoicetypes.Checkpoint{}, dec.finalizedCheckpoint)
+
+	pb := &ethpb.ForkChoiceSnapshot{}
+	raw, err := snappy.Decode(nil, enc)
+	require.NoError(t, err)
+	require.NoError(t, proto.Unmarshal(raw, pb))
+	pb.Votes[0].RootIndex = uint32(len(pb.VoteRoots))
+	corrupted, err := proto.Marshal(pb)
+	require.NoError(t, err)
+	_, err = decodeSnapshot(snappy.Encode(nil, corrupted))
+	require.ErrorContains(t, "references unknown root", err)
+
+	pb.Votes[0].RootIndex = 0
+	pb.Nodes[1].ParentRoot = []byte{'a'}
+	corrupted, err = proto.Marshal(pb)
+	require.NoError(t, err)
+	_, err = decodeSnapshot(snappy.Encode(nil, corrupted))
+	require.ErrorContains(t, "invalid fork choice snapshot node 1", err)
+}
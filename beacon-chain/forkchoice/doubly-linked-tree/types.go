@@ -13,11 +13,13 @@ import (
 type ForkChoice struct {
 	sync.RWMutex
 	store               *Store
-	votes               []Vote                      // tracks individual validator's last vote.
-	balances            []uint64                    // tracks individual validator's balances last accounted in votes.
-	justifiedBalances   []uint64                    // tracks individual validator's last justified balances.
-	numActiveValidators uint64                      // tracks the total number of active validators.
-	balancesByRoot      forkchoice.BalancesByRooter // handler to obtain balances for the state with a given root
+	votes               []Vote                                                      // tracks individual validator's last vote.
+	balances            []uint64                                                    // tracks individual validator's balances last accounted in votes.
+	justifiedBalances   []uint64                                                    // tracks individual validator's last justified balances.
+	numActiveValidators uint64                                                      // tracks the total number of active validators.
+	balancesByRoot      forkchoice.BalancesByRooter                                 // handler to obtain balances for the state with a given root
+	votesSinceHead      map[[fieldparams.RootLength]byte]*forkchoicetypes.VoteTally // tracks the votes received since the head last changed.
+	headChangedAt       primitives.Slot                                             // the slot at which the head last changed.
 }
 
 // Store defines the fork choice store which includes block nodes and the last view of checkpoint information.
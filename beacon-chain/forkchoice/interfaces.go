@@ -14,6 +14,10 @@ import (
 // with the given block root
 type BalancesByRooter func(context.Context, [32]byte) ([]uint64, error)
 
+// BlockChecker is a handler to check whether the block with the given root
+// is available to the node.
+type BlockChecker func(context.Context, [32]byte) bool
+
 // ForkChoicer represents the full fork choice interface composed of all the sub-interfaces.
 type ForkChoicer interface {
 	Lock()
@@ -25,6 +29,7 @@ type ForkChoicer interface {
 	AttestationProcessor // to track new attestation for fork choice.
 	Getter               // to retrieve fork choice information.
 	Setter               // to set fork choice information.
+	Snapshotter          // to persist fork choice across restarts.
 }
 
 // HeadRetriever retrieves head root and optimistic info of the current chain.
@@ -63,6 +68,7 @@ type Getter interface {
 	HighestReceivedBlockSlot() primitives.Slot
 	ReceivedBlocksLastEpoch() (uint64, error)
 	ForkChoiceDump(context.Context) (*v1.ForkChoiceDump, error)
+	Diagnostics(context.Context) (*forkchoicetypes.Diagnostics, error)
 	Weight(root [32]byte) (uint64, error)
 	Tips() ([][32]byte, []primitives.Slot)
 	IsOptimistic(root [32]byte) (bool, error)
@@ -70,6 +76,12 @@ type Getter interface {
 	Slot([32]byte) (primitives.Slot, error)
 }
 
+// Snapshotter serializes fork choice and restores it from a serialized snapshot.
+type Snapshotter interface {
+	Snapshot() ([]byte, error)
+	RestoreSnapshot(context.Context, []byte, BlockChecker) (int, error)
+}
+
 // Setter allows to set forkchoice information
 type Setter interface {
 	SetOptimisticToValid(context.Context, [fieldparams.RootLength]byte) error
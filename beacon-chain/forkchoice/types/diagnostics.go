@@ -0,0 +1,108 @@
+package types
+
+import (
+	"fmt"
+	"io"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+)
+
+// Diagnostics is a detailed snapshot of the fork choice store. Unlike the
+// flat dump served by the standard debug API, it carries the information
+// needed to diagnose reorgs: proposer boost, the best descendant of every
+// node and the votes received since the head last changed.
+type Diagnostics struct {
+	JustifiedCheckpoint           *Checkpoint
+	UnrealizedJustifiedCheckpoint *Checkpoint
+	PreviousJustifiedCheckpoint   *Checkpoint
+	FinalizedCheckpoint           *Checkpoint
+	UnrealizedFinalizedCheckpoint *Checkpoint
+	HeadRoot                      [fieldparams.RootLength]byte
+	ProposerBoostRoot             [fieldparams.RootLength]byte
+	PreviousProposerBoostRoot     [fieldparams.RootLength]byte
+	PreviousProposerBoostScore    uint64
+	CommitteeWeight               uint64
+	GenesisTime                   uint64
+	HeadChangedAt                 primitives.Slot
+	Nodes                         []*NodeDiagnostics
+	VotesSinceHeadChange          []*VoteTally
+}
+
+// NodeDiagnostics describes a single node of the fork choice tree.
+type NodeDiagnostics struct {
+	Slot                     primitives.Slot
+	Root                     [fieldparams.RootLength]byte
+	ParentRoot               [fieldparams.RootLength]byte
+	PayloadHash              [fieldparams.RootLength]byte
+	BestDescendant           [fieldparams.RootLength]byte
+	JustifiedEpoch           primitives.Epoch
+	FinalizedEpoch           primitives.Epoch
+	UnrealizedJustifiedEpoch primitives.Epoch
+	UnrealizedFinalizedEpoch primitives.Epoch
+	Balance                  uint64
+	Weight                   uint64
+	Optimistic               bool
+	Canonical                bool
+	Timestamp                uint64
+}
+
+// VoteTally aggregates the votes that moved to a block root since the
+// fork choice head last changed.
+type VoteTally struct {
+	Root    [fieldparams.RootLength]byte
+	Count   uint64
+	Balance uint64
+}
+
+// WriteDOT renders the fork choice tree as a Graphviz DOT document. Canonical
+// nodes are drawn with a bold outline, optimistic nodes are dashed and the
+// head and the proposer boosted node are highlighted.
+func (d *Diagnostics) WriteDOT(w io.Writer) error {
+	votes := make(map[[fieldparams.RootLength]byte]*VoteTally, len(d.VotesSinceHeadChange))
+	for _, v := range d.VotesSinceHeadChange {
+		votes[v.Root] = v
+	}
+	if _, err := fmt.Fprintln(w, "digraph forkchoice {"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "\trankdir=LR;\n\tnode [shape=box, fontname=monospace];"); err != nil {
+		return err
+	}
+	known := make(map[[fieldparams.RootLength]byte]bool, len(d.Nodes))
+	for _, n := range d.Nodes {
+		known[n.Root] = true
+	}
+	for _, n := range d.Nodes {
+		label := fmt.Sprintf("slot %d\\nroot %#x\\nweight %d\\nbalance %d\\njustified %d (unrealized %d)\\nfinalized %d (unrealized %d)",
+			n.Slot, n.Root[:4], n.Weight, n.Balance, n.JustifiedEpoch, n.UnrealizedJustifiedEpoch, n.FinalizedEpoch, n.UnrealizedFinalizedEpoch)
+		if v, ok := votes[n.Root]; ok {
+			label += fmt.Sprintf("\\nnew votes %d (balance %d)", v.Count, v.Balance)
+		}
+		style := "solid"
+		if n.Optimistic {
+			style = "dashed"
+		}
+		if n.Canonical {
+			style += ",bold"
+		}
+		color := "black"
+		switch n.Root {
+		case d.HeadRoot:
+			color = "green"
+		case d.ProposerBoostRoot:
+			color = "orange"
+		}
+		if _, err := fmt.Fprintf(w, "\t\"%#x\" [label=\"%s\", style=\"%s\", color=%s];\n", n.Root, label, style, color); err != nil {
+			return err
+		}
+		if !known[n.ParentRoot] {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "\t\"%#x\" -> \"%#x\";\n", n.ParentRoot, n.Root); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
@@ -27,7 +27,7 @@ func (s *Service) canUpdateAttestedValidator(idx primitives.ValidatorIndex, slot
 	}
 
 	if lp, ok := s.latestPerformance[idx]; ok {
-		return lp.attestedSlot != slot
+		return lp.AttestedSlot != slot
 	}
 	return false
 }
@@ -81,17 +81,17 @@ func (s *Service) processIncludedAttestation(ctx context.Context, state state.Be
 			}
 
 			aggregatedPerf := s.aggregatedPerformance[primitives.ValidatorIndex(idx)]
-			aggregatedPerf.totalAttestedCount++
-			aggregatedPerf.totalRequestedCount++
+			aggregatedPerf.TotalAttestedCount++
+			aggregatedPerf.TotalRequestedCount++
 
 			latestPerf := s.latestPerformance[primitives.ValidatorIndex(idx)]
-			balanceChg := int64(balance - latestPerf.balance)
-			latestPerf.balanceChange = balanceChg
-			latestPerf.balance = balance
-			latestPerf.attestedSlot = att.Data.Slot
-			latestPerf.inclusionSlot = state.Slot()
-			inclusionSlotGauge.WithLabelValues(fmt.Sprintf("%d", idx)).Set(float64(latestPerf.inclusionSlot))
-			aggregatedPerf.totalDistance += uint64(latestPerf.inclusionSlot - latestPerf.attestedSlot)
+			balanceChg := int64(balance - latestPerf.Balance)
+			latestPerf.BalanceChange = balanceChg
+			latestPerf.Balance = balance
+			latestPerf.AttestedSlot = att.Data.Slot
+			latestPerf.InclusionSlot = state.Slot()
+			inclusionSlotGauge.WithLabelValues(fmt.Sprintf("%d", idx)).Set(float64(latestPerf.InclusionSlot))
+			aggregatedPerf.TotalDistance += uint64(latestPerf.InclusionSlot - latestPerf.AttestedSlot)
 
 			if state.Version() == version.Altair {
 				targetIdx := params.BeaconConfig().TimelyTargetFlagIndex
@@ -99,8 +99,8 @@ func (s *Service) processIncludedAttestation(ctx context.Context, state state.Be
 				headIdx := params.BeaconConfig().TimelyHeadFlagIndex
 
 				var participation []byte
-				if slots.ToEpoch(latestPerf.inclusionSlot) ==
-					slots.ToEpoch(latestPerf.attestedSlot) {
+				if slots.ToEpoch(latestPerf.InclusionSlot) ==
+					slots.ToEpoch(latestPerf.AttestedSlot) {
 					participation, err = state.CurrentEpochParticipation()
 					if err != nil {
 						log.WithError(err).Error("Could not get current epoch participation")
@@ -119,37 +119,37 @@ func (s *Service) processIncludedAttestation(ctx context.Context, state state.Be
 					log.WithError(err).Error("Could not get timely Source flag")
 					return
 				}
-				latestPerf.timelySource = hasFlag
+				latestPerf.TimelySource = hasFlag
 				hasFlag, err = altair.HasValidatorFlag(flags, headIdx)
 				if err != nil {
 					log.WithError(err).Error("Could not get timely Head flag")
 					return
 				}
-				latestPerf.timelyHead = hasFlag
+				latestPerf.TimelyHead = hasFlag
 				hasFlag, err = altair.HasValidatorFlag(flags, targetIdx)
 				if err != nil {
 					log.WithError(err).Error("Could not get timely Target flag")
 					return
 				}
-				latestPerf.timelyTarget = hasFlag
+				latestPerf.TimelyTarget = hasFlag
 
-				if latestPerf.timelySource {
+				if latestPerf.TimelySource {
 					timelySourceCounter.WithLabelValues(fmt.Sprintf("%d", idx)).Inc()
-					aggregatedPerf.totalCorrectSource++
+					aggregatedPerf.TotalCorrectSource++
 				}
-				if latestPerf.timelyHead {
+				if latestPerf.TimelyHead {
 					timelyHeadCounter.WithLabelValues(fmt.Sprintf("%d", idx)).Inc()
-					aggregatedPerf.totalCorrectHead++
+					aggregatedPerf.TotalCorrectHead++
 				}
-				if latestPerf.timelyTarget {
+				if latestPerf.TimelyTarget {
 					timelyTargetCounter.WithLabelValues(fmt.Sprintf("%d", idx)).Inc()
-					aggregatedPerf.totalCorrectTarget++
+					aggregatedPerf.TotalCorrectTarget++
 				}
 			}
-			logFields["CorrectHead"] = latestPerf.timelyHead
-			logFields["CorrectSource"] = latestPerf.timelySource
-			logFields["CorrectTarget"] = latestPerf.timelyTarget
-			logFields["InclusionSlot"] = latestPerf.inclusionSlot
+			logFields["CorrectHead"] = latestPerf.TimelyHead
+			logFields["CorrectSource"] = latestPerf.TimelySource
+			logFields["CorrectTarget"] = latestPerf.TimelyTarget
+			logFields["InclusionSlot"] = latestPerf.InclusionSlot
 			logFields["NewBalance"] = balance
 			logFields["BalanceChange"] = balanceChg
 
@@ -200,7 +200,7 @@ func (s *Service) processAggregatedAttestation(ctx context.Context, att *ethpb.A
 				att.Aggregate.Data.Target.Root)),
 		}).Info("Processed attestation aggregation")
 		aggregatedPerf := s.aggregatedPerformance[att.AggregatorIndex]
-		aggregatedPerf.totalAggregations++
+		aggregatedPerf.TotalAggregations++
 		s.aggregatedPerformance[att.AggregatorIndex] = aggregatedPerf
 		aggregationCounter.WithLabelValues(fmt.Sprintf("%d", att.AggregatorIndex)).Inc()
 	}
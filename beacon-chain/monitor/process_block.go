@@ -79,13 +79,13 @@ func (s *Service) processProposedBlock(state state.BeaconState, root [32]byte, b
 		}
 
 		latestPerf := s.latestPerformance[blk.ProposerIndex()]
-		balanceChg := int64(balance - latestPerf.balance)
-		latestPerf.balanceChange = balanceChg
-		latestPerf.balance = balance
+		balanceChg := int64(balance - latestPerf.Balance)
+		latestPerf.BalanceChange = balanceChg
+		latestPerf.Balance = balance
 		s.latestPerformance[blk.ProposerIndex()] = latestPerf
 
 		aggPerf := s.aggregatedPerformance[blk.ProposerIndex()]
-		aggPerf.totalProposedCount++
+		aggPerf.TotalProposedCount++
 		s.aggregatedPerformance[blk.ProposerIndex()] = aggPerf
 
 		parentRoot := blk.ParentRoot()
@@ -144,34 +144,34 @@ func (s *Service) logAggregatedPerformance() {
 	defer s.RUnlock()
 
 	for idx, p := range s.aggregatedPerformance {
-		if p.totalAttestedCount == 0 || p.totalRequestedCount == 0 || p.startBalance == 0 {
-			break
+		if p.TotalAttestedCount == 0 || p.TotalRequestedCount == 0 || p.StartBalance == 0 {
+			continue
 		}
 		l, ok := s.latestPerformance[idx]
 		if !ok {
-			break
+			continue
 		}
-		percentAtt := float64(p.totalAttestedCount) / float64(p.totalRequestedCount)
-		percentBal := float64(l.balance-p.startBalance) / float64(p.startBalance)
-		percentDistance := float64(p.totalDistance) / float64(p.totalAttestedCount)
-		percentCorrectSource := float64(p.totalCorrectSource) / float64(p.totalAttestedCount)
-		percentCorrectHead := float64(p.totalCorrectHead) / float64(p.totalAttestedCount)
-		percentCorrectTarget := float64(p.totalCorrectTarget) / float64(p.totalAttestedCount)
+		percentAtt := float64(p.TotalAttestedCount) / float64(p.TotalRequestedCount)
+		percentBal := float64(l.Balance-p.StartBalance) / float64(p.StartBalance)
+		percentDistance := float64(p.TotalDistance) / float64(p.TotalAttestedCount)
+		percentCorrectSource := float64(p.TotalCorrectSource) / float64(p.TotalAttestedCount)
+		percentCorrectHead := float64(p.TotalCorrectHead) / float64(p.TotalAttestedCount)
+		percentCorrectTarget := float64(p.TotalCorrectTarget) / float64(p.TotalAttestedCount)
 
 		log.WithFields(logrus.Fields{
 			"ValidatorIndex":           idx,
-			"StartEpoch":               p.startEpoch,
-			"StartBalance":             p.startBalance,
-			"TotalRequested":           p.totalRequestedCount,
+			"StartEpoch":               p.StartEpoch,
+			"StartBalance":             p.StartBalance,
+			"TotalRequested":           p.TotalRequestedCount,
 			"AttestationInclusion":     fmt.Sprintf("%.2f%%", percentAtt*100),
 			"BalanceChangePct":         fmt.Sprintf("%.2f%%", percentBal*100),
 			"CorrectlyVotedSourcePct":  fmt.Sprintf("%.2f%%", percentCorrectSource*100),
 			"CorrectlyVotedTargetPct":  fmt.Sprintf("%.2f%%", percentCorrectTarget*100),
 			"CorrectlyVotedHeadPct":    fmt.Sprintf("%.2f%%", percentCorrectHead*100),
 			"AverageInclusionDistance": fmt.Sprintf("%.1f", percentDistance),
-			"TotalProposedBlocks":      p.totalProposedCount,
-			"TotalAggregations":        p.totalAggregations,
-			"TotalSyncContributions":   p.totalSyncCommitteeContributions,
+			"TotalProposedBlocks":      p.TotalProposedCount,
+			"TotalAggregations":        p.TotalAggregations,
+			"TotalSyncContributions":   p.TotalSyncCommitteeContributions,
 		}).Info("Aggregated performance since launch")
 	}
 }
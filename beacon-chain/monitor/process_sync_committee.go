@@ -17,7 +17,7 @@ func (s *Service) processSyncCommitteeContribution(contribution *ethpb.SignedCon
 	defer s.Unlock()
 	if s.trackedIndex(idx) {
 		aggPerf := s.aggregatedPerformance[idx]
-		aggPerf.totalSyncCommitteeAggregations++
+		aggPerf.TotalSyncCommitteeAggregations++
 		s.aggregatedPerformance[idx] = aggPerf
 
 		log.WithField("ValidatorIndex", contribution.Message.AggregatorIndex).Info("Sync committee aggregation processed")
@@ -52,13 +52,13 @@ func (s *Service) processSyncAggregate(state state.BeaconState, blk interfaces.R
 			}
 
 			latestPerf := s.latestPerformance[validatorIdx]
-			balanceChg := int64(balance - latestPerf.balance)
-			latestPerf.balanceChange = balanceChg
-			latestPerf.balance = balance
+			balanceChg := int64(balance - latestPerf.Balance)
+			latestPerf.BalanceChange = balanceChg
+			latestPerf.Balance = balance
 			s.latestPerformance[validatorIdx] = latestPerf
 
 			aggPerf := s.aggregatedPerformance[validatorIdx]
-			aggPerf.totalSyncCommitteeContributions += uint64(contrib)
+			aggPerf.TotalSyncCommitteeContributions += uint64(contrib)
 			s.aggregatedPerformance[validatorIdx] = aggPerf
 
 			syncCommitteeContributionCounter.WithLabelValues(
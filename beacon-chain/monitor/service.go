@@ -24,41 +24,42 @@ var errContextClosedWhileWaiting = errors.New("context closed while waiting for
 
 // ValidatorLatestPerformance keeps track of the latest participation of the validator
 type ValidatorLatestPerformance struct {
-	attestedSlot  primitives.Slot
-	inclusionSlot primitives.Slot
-	timelySource  bool
-	timelyTarget  bool
-	timelyHead    bool
-	balance       uint64
-	balanceChange int64
+	AttestedSlot  primitives.Slot
+	InclusionSlot primitives.Slot
+	TimelySource  bool
+	TimelyTarget  bool
+	TimelyHead    bool
+	Balance       uint64
+	BalanceChange int64
 }
 
 // ValidatorAggregatedPerformance keeps track of the accumulated performance of
 // the tracked validator since start of monitor service.
 type ValidatorAggregatedPerformance struct {
-	startEpoch                      primitives.Epoch
-	startBalance                    uint64
-	totalAttestedCount              uint64
-	totalRequestedCount             uint64
-	totalDistance                   uint64
-	totalCorrectSource              uint64
-	totalCorrectTarget              uint64
-	totalCorrectHead                uint64
-	totalProposedCount              uint64
-	totalAggregations               uint64
-	totalSyncCommitteeContributions uint64
-	totalSyncCommitteeAggregations  uint64
+	StartEpoch                      primitives.Epoch
+	StartBalance                    uint64
+	TotalAttestedCount              uint64
+	TotalRequestedCount             uint64
+	TotalDistance                   uint64
+	TotalCorrectSource              uint64
+	TotalCorrectTarget              uint64
+	TotalCorrectHead                uint64
+	TotalProposedCount              uint64
+	TotalAggregations               uint64
+	TotalSyncCommitteeContributions uint64
+	TotalSyncCommitteeAggregations  uint64
 }
 
 // ValidatorMonitorConfig contains the list of validator indices that the
 // monitor service tracks, and the event feed notifier that the
 // monitor needs to subscribe.
 type ValidatorMonitorConfig struct {
-	StateNotifier       statefeed.Notifier
-	AttestationNotifier operation.Notifier
-	HeadFetcher         blockchain.HeadFetcher
-	StateGen            stategen.StateManager
-	InitialSyncComplete chan struct{}
+	StateNotifier             statefeed.Notifier
+	AttestationNotifier       operation.Notifier
+	HeadFetcher               blockchain.HeadFetcher
+	StateGen                  stategen.StateManager
+	InitialSyncComplete       chan struct{}
+	TrackProposerPreparations bool
 }
 
 // Service is the main structure that tracks validators and reports logs and
@@ -136,16 +137,14 @@ func (s *Service) run() {
 	epoch := slots.ToEpoch(st.Slot())
 	log.WithField("Epoch", epoch).Info("Synced to head epoch, starting reporting performance")
 
+	// Validators tracked at runtime are either initialized here or, once logging, by TrackValidators.
 	s.Lock()
 	s.initializePerformanceStructures(st, epoch)
+	s.isLogging = true
 	s.Unlock()
 
 	s.updateSyncCommitteeTrackedVals(st)
 
-	s.Lock()
-	s.isLogging = true
-	s.Unlock()
-
 	stateChannel := make(chan *feed.Event, 1)
 	stateSub := s.config.StateNotifier.StateFeed().Subscribe(stateChannel)
 	s.monitorRoutine(stateChannel, stateSub)
@@ -162,11 +161,11 @@ func (s *Service) initializePerformanceStructures(state state.BeaconState, epoch
 			balance = 0
 		}
 		s.aggregatedPerformance[idx] = ValidatorAggregatedPerformance{
-			startEpoch:   epoch,
-			startBalance: balance,
+			StartEpoch:   epoch,
+			StartBalance: balance,
 		}
 		s.latestPerformance[idx] = ValidatorLatestPerformance{
-			balance: balance,
+			Balance: balance,
 		}
 	}
 }
@@ -53,31 +53,31 @@ func setupService(t *testing.T) *Service {
 	}
 	latestPerformance := map[primitives.ValidatorIndex]ValidatorLatestPerformance{
 		1: {
-			balance: 32000000000,
+			Balance: 32000000000,
 		},
 		2: {
-			balance: 32000000000,
+			Balance: 32000000000,
 		},
 		12: {
-			balance: 31900000000,
+			Balance: 31900000000,
 		},
 		15: {
-			balance: 31900000000,
+			Balance: 31900000000,
 		},
 	}
 	aggregatedPerformance := map[primitives.ValidatorIndex]ValidatorAggregatedPerformance{
 		1: {
-			startEpoch:                      0,
-			startBalance:                    31700000000,
-			totalAttestedCount:              12,
-			totalRequestedCount:             15,
-			totalDistance:                   14,
-			totalCorrectHead:                8,
-			totalCorrectSource:              11,
-			totalCorrectTarget:              12,
-			totalProposedCount:              1,
-			totalSyncCommitteeContributions: 0,
-			totalSyncCommitteeAggregations:  0,
+			StartEpoch:                      0,
+			StartBalance:                    31700000000,
+			TotalAttestedCount:              12,
+			TotalRequestedCount:             15,
+			TotalDistance:                   14,
+			TotalCorrectHead:                8,
+			TotalCorrectSource:              11,
+			TotalCorrectTarget:              12,
+			TotalProposedCount:              1,
+			TotalSyncCommitteeContributions: 0,
+			TotalSyncCommitteeAggregations:  0,
 		},
 		2:  {},
 		12: {},
@@ -165,30 +165,30 @@ func TestInitializePerformanceStructures(t *testing.T) {
 	require.LogsDoNotContain(t, hook, "Could not fetch starting balance")
 	latestPerformance := map[primitives.ValidatorIndex]ValidatorLatestPerformance{
 		1: {
-			balance: 32000000000,
+			Balance: 32000000000,
 		},
 		2: {
-			balance: 32000000000,
+			Balance: 32000000000,
 		},
 		12: {
-			balance: 32000000000,
+			Balance: 32000000000,
 		},
 		15: {
-			balance: 32000000000,
+			Balance: 32000000000,
 		},
 	}
 	aggregatedPerformance := map[primitives.ValidatorIndex]ValidatorAggregatedPerformance{
 		1: {
-			startBalance: 32000000000,
+			StartBalance: 32000000000,
 		},
 		2: {
-			startBalance: 32000000000,
+			StartBalance: 32000000000,
 		},
 		12: {
-			startBalance: 32000000000,
+			StartBalance: 32000000000,
 		},
 		15: {
-			startBalance: 32000000000,
+			StartBalance: 32000000000,
 		},
 	}
 
@@ -0,0 +1,169 @@
+package monitor
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrValidatorNotTracked is returned when requesting the performance of a validator that is not monitored.
+var ErrValidatorNotTracked = errors.New("validator is not tracked by the monitor")
+
+// TrackedValidatorsManager allows to change the set of monitored validators at runtime
+// and to query their performance.
+type TrackedValidatorsManager interface {
+	TrackValidators(ctx context.Context, indices []primitives.ValidatorIndex) error
+	UntrackValidators(indices []primitives.ValidatorIndex)
+	TrackProposerPreparations(ctx context.Context, indices []primitives.ValidatorIndex) error
+	TrackedIndices() []primitives.ValidatorIndex
+	Performance(idx primitives.ValidatorIndex) (ValidatorLatestPerformance, ValidatorAggregatedPerformance, error)
+}
+
+var _ TrackedValidatorsManager = (*Service)(nil)
+
+// TrackValidators adds the given validator indices to the tracked set. Validators added once the
+// monitor is already reporting have their performance initialized from the current head state,
+// indices which are not in that state are skipped.
+func (s *Service) TrackValidators(ctx context.Context, indices []primitives.ValidatorIndex) error {
+	s.RLock()
+	isLogging := s.isLogging
+	s.RUnlock()
+
+	var st state.BeaconState
+	if isLogging {
+		var err error
+		if st, err = s.headState(ctx); err != nil {
+			return err
+		}
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	if !s.isLogging {
+		// The performance structures of every tracked validator are initialized once the node is synced.
+		added := make([]primitives.ValidatorIndex, 0, len(indices))
+		for _, idx := range indices {
+			if s.trackedIndex(idx) {
+				continue
+			}
+			s.TrackedValidators[idx] = true
+			added = append(added, idx)
+		}
+		if len(added) > 0 {
+			log.WithField("ValidatorIndices", added).Info("Started tracking validators")
+		}
+		return nil
+	}
+	// The monitor started reporting after the check above.
+	if st == nil {
+		var err error
+		if st, err = s.headState(ctx); err != nil {
+			return err
+		}
+	}
+
+	epoch := slots.ToEpoch(st.Slot())
+	added := make([]primitives.ValidatorIndex, 0, len(indices))
+	for _, idx := range indices {
+		if s.trackedIndex(idx) {
+			continue
+		}
+		balance, err := st.BalanceAtIndex(idx)
+		if err != nil {
+			log.WithError(err).WithField("ValidatorIndex", idx).Warn("Could not get validator balance, not tracking validator")
+			continue
+		}
+		s.TrackedValidators[idx] = true
+		s.aggregatedPerformance[idx] = ValidatorAggregatedPerformance{
+			StartEpoch:   epoch,
+			StartBalance: balance,
+		}
+		s.latestPerformance[idx] = ValidatorLatestPerformance{
+			Balance: balance,
+		}
+		syncIdx, err := helpers.CurrentPeriodSyncSubcommitteeIndices(st, idx)
+		if err == nil && len(syncIdx) > 0 {
+			s.trackedSyncCommitteeIndices[idx] = syncIdx
+		}
+		added = append(added, idx)
+	}
+	if len(added) > 0 {
+		log.WithFields(logrus.Fields{
+			"ValidatorIndices": added,
+			"Epoch":            epoch,
+		}).Info("Started tracking validators")
+	}
+	return nil
+}
+
+// headState returns the current head state, used to initialize the performance of validators
+// tracked at runtime.
+func (s *Service) headState(ctx context.Context) (state.BeaconState, error) {
+	st, err := s.config.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if st == nil || st.IsNil() {
+		return nil, errors.New("head state is nil")
+	}
+	return st, nil
+}
+
+// UntrackValidators removes the given validator indices from the tracked set, dropping their
+// performance history and metrics.
+func (s *Service) UntrackValidators(indices []primitives.ValidatorIndex) {
+	s.Lock()
+	defer s.Unlock()
+	removed := make([]primitives.ValidatorIndex, 0, len(indices))
+	for _, idx := range indices {
+		if !s.trackedIndex(idx) {
+			continue
+		}
+		delete(s.TrackedValidators, idx)
+		delete(s.latestPerformance, idx)
+		delete(s.aggregatedPerformance, idx)
+		delete(s.trackedSyncCommitteeIndices, idx)
+		deleteValidatorMetrics(idx)
+		removed = append(removed, idx)
+	}
+	if len(removed) > 0 {
+		log.WithField("ValidatorIndices", removed).Info("Stopped tracking validators")
+	}
+}
+
+// TrackProposerPreparations tracks the validators that submitted a proposer preparation,
+// when the monitor is configured to do so.
+func (s *Service) TrackProposerPreparations(ctx context.Context, indices []primitives.ValidatorIndex) error {
+	if !s.config.TrackProposerPreparations {
+		return nil
+	}
+	return s.TrackValidators(ctx, indices)
+}
+
+// TrackedIndices returns the sorted indices of the tracked validators.
+func (s *Service) TrackedIndices() []primitives.ValidatorIndex {
+	s.RLock()
+	defer s.RUnlock()
+	tracked := make([]primitives.ValidatorIndex, 0, len(s.TrackedValidators))
+	for idx := range s.TrackedValidators {
+		tracked = append(tracked, idx)
+	}
+	sort.Slice(tracked, func(i, j int) bool { return tracked[i] < tracked[j] })
+	return tracked
+}
+
+// Performance returns the latest and the aggregated performance of a tracked validator.
+func (s *Service) Performance(idx primitives.ValidatorIndex) (ValidatorLatestPerformance, ValidatorAggregatedPerformance, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if !s.trackedIndex(idx) {
+		return ValidatorLatestPerformance{}, ValidatorAggregatedPerformance{}, ErrValidatorNotTracked
+	}
+	return s.latestPerformance[idx], s.aggregatedPerformance[idx], nil
+}
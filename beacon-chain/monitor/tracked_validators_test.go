@@ -0,0 +1,72 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestTrackValidators_NotLogging(t *testing.T) {
+	s := setupService(t)
+
+	require.NoError(t, s.TrackValidators(context.Background(), []primitives.ValidatorIndex{2, 3}))
+	require.DeepEqual(t, []primitives.ValidatorIndex{1, 2, 3, 12, 15}, s.TrackedIndices())
+	// Performance is initialized for every tracked validator once the node is synced.
+	_, ok := s.latestPerformance[3]
+	require.Equal(t, false, ok)
+}
+
+func TestTrackValidators_Logging(t *testing.T) {
+	s := setupService(t)
+	s.isLogging = true
+
+	require.NoError(t, s.TrackValidators(context.Background(), []primitives.ValidatorIndex{1, 3}))
+	require.DeepEqual(t, []primitives.ValidatorIndex{1, 2, 3, 12, 15}, s.TrackedIndices())
+
+	latest, aggregated, err := s.Performance(3)
+	require.NoError(t, err)
+	require.DeepEqual(t, ValidatorLatestPerformance{Balance: 32000000000}, latest)
+	require.DeepEqual(t, ValidatorAggregatedPerformance{StartBalance: 32000000000}, aggregated)
+	// Already tracked validators keep their performance history.
+	_, aggregated, err = s.Performance(1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), aggregated.TotalAttestedCount)
+	_, ok := s.trackedSyncCommitteeIndices[3]
+	require.Equal(t, false, ok)
+}
+
+func TestTrackValidators_UnknownValidator(t *testing.T) {
+	s := setupService(t)
+	s.isLogging = true
+
+	// Unknown validators are skipped without preventing the others from being tracked.
+	require.NoError(t, s.TrackValidators(context.Background(), []primitives.ValidatorIndex{3, 100000}))
+	require.Equal(t, true, s.trackedIndex(3))
+	require.Equal(t, false, s.trackedIndex(100000))
+}
+
+func TestUntrackValidators(t *testing.T) {
+	s := setupService(t)
+
+	s.UntrackValidators([]primitives.ValidatorIndex{1, 3})
+	require.DeepEqual(t, []primitives.ValidatorIndex{2, 12, 15}, s.TrackedIndices())
+	_, ok := s.aggregatedPerformance[1]
+	require.Equal(t, false, ok)
+	_, ok = s.trackedSyncCommitteeIndices[1]
+	require.Equal(t, false, ok)
+	_, _, err := s.Performance(1)
+	require.ErrorIs(t, err, ErrValidatorNotTracked)
+}
+
+func TestTrackProposerPreparations(t *testing.T) {
+	s := setupService(t)
+
+	require.NoError(t, s.TrackProposerPreparations(context.Background(), []primitives.ValidatorIndex{3}))
+	require.Equal(t, false, s.trackedIndex(3))
+
+	s.config.TrackProposerPreparations = true
+	require.NoError(t, s.TrackProposerPreparations(context.Background(), []primitives.ValidatorIndex{3}))
+	require.Equal(t, true, s.trackedIndex(3))
+}
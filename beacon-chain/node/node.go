@@ -16,6 +16,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	apigateway "github.com/prysmaticlabs/prysm/v4/api/gateway"
 	"github.com/prysmaticlabs/prysm/v4/async/event"
@@ -25,6 +26,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositcache"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/pruner"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/slasherkv"
 	interopcoldstart "github.com/prysmaticlabs/prysm/v4/beacon-chain/deterministic-genesis"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
@@ -54,10 +56,13 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/container/slice"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/monitoring/prometheus"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/runtime"
 	"github.com/prysmaticlabs/prysm/v4/runtime/debug"
 	"github.com/prysmaticlabs/prysm/v4/runtime/prereqs"
@@ -75,6 +80,8 @@ type serviceFlagOpts struct {
 	blockchainFlagOpts     []blockchain.Option
 	executionChainFlagOpts []execution.Option
 	builderOpts            []builder.Option
+	backfillOpts           []backfill.ServiceOption
+	prunerOpts             []pruner.ServiceOption
 }
 
 // BeaconNode defines a struct that handles the services running a random beacon chain
@@ -110,6 +117,7 @@ type BeaconNode struct {
 	forkChoicer             forkchoice.ForkChoicer
 	clockWaiter             startup.ClockWaiter
 	initialSyncComplete     chan struct{}
+	backfillStatus          *backfill.Status
 }
 
 // New creates a new node instance, sets up configuration options, and registers
@@ -205,13 +213,13 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
-	bfs := backfill.NewStatus(beacon.db)
-	if err := bfs.Reload(ctx); err != nil {
+	beacon.backfillStatus = backfill.NewStatus(beacon.db)
+	if err := beacon.backfillStatus.Reload(ctx); err != nil {
 		return nil, errors.Wrap(err, "backfill status initialization error")
 	}
 
 	log.Debugln("Starting State Gen")
-	if err := beacon.startStateGen(ctx, bfs, beacon.forkChoicer); err != nil {
+	if err := beacon.startStateGen(ctx, beacon.backfillStatus, beacon.forkChoicer); err != nil {
 		return nil, err
 	}
 
@@ -250,6 +258,16 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	log.Debugln("Registering Backfill Service")
+	if err := beacon.registerBackfillService(); err != nil {
+		return nil, err
+	}
+
+	log.Debugln("Registering Pruner Service")
+	if err := beacon.registerPrunerService(); err != nil {
+		return nil, err
+	}
+
 	log.Debugln("Registering Slasher Service")
 	if err := beacon.registerSlasherService(); err != nil {
 		return nil, err
@@ -260,6 +278,11 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	log.Debugln("Registering Validator Monitoring Service")
+	if err := beacon.registerValidatorMonitorService(beacon.initialSyncComplete); err != nil {
+		return nil, err
+	}
+
 	log.Debugln("Registering RPC Service")
 	router := mux.NewRouter()
 	if err := beacon.registerRPCService(router); err != nil {
@@ -271,11 +294,6 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
-	log.Debugln("Registering Validator Monitoring Service")
-	if err := beacon.registerValidatorMonitorService(beacon.initialSyncComplete); err != nil {
-		return nil, err
-	}
-
 	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
 		log.Debugln("Registering Prometheus Service")
 		if err := beacon.registerPrometheusService(cliCtx); err != nil {
@@ -355,6 +373,9 @@ func (b *BeaconNode) Close() {
 		log.WithError(err).Error("Failed to close database")
 	}
 	b.collector.unregister()
+	if err := tracing.Shutdown(b.ctx); err != nil {
+		log.WithError(err).Error("Failed to flush traces")
+	}
 	b.cancel()
 	close(b.stop)
 }
@@ -535,9 +556,24 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		return err
 	}
 
+	peerGroups, err := p2p.ParsePeerGroups(cliCtx.StringSlice(cmd.PeerGroups.Name))
+	if err != nil {
+		return err
+	}
+
+	var gossipCapture *p2p.GossipCaptureConfig
+	if dir := cliCtx.String(flags.GossipCaptureDir.Name); dir != "" {
+		gossipCapture = &p2p.GossipCaptureConfig{
+			Dir:         dir,
+			MaxFileSize: cliCtx.Uint64(flags.GossipCaptureMaxFileSize.Name) * 1024 * 1024,
+			MaxFiles:    cliCtx.Int(flags.GossipCaptureMaxFiles.Name),
+		}
+	}
+
 	svc, err := p2p.NewService(b.ctx, &p2p.Config{
 		NoDiscovery:       cliCtx.Bool(cmd.NoDiscovery.Name),
 		StaticPeers:       slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.StaticPeers.Name)),
+		PeerGroups:        peerGroups,
 		BootstrapNodeAddr: bootstrapNodeAddrs,
 		RelayNodeAddr:     cliCtx.String(cmd.RelayNode.Name),
 		DataDir:           dataDir,
@@ -548,6 +584,7 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		StaticPeerID:      cliCtx.Bool(cmd.P2PStaticID.Name),
 		MetaDataDir:       cliCtx.String(cmd.P2PMetadata.Name),
 		TCPPort:           cliCtx.Uint(cmd.P2PTCPPort.Name),
+		QUICPort:          cliCtx.Uint(cmd.P2PQUICPort.Name),
 		UDPPort:           cliCtx.Uint(cmd.P2PUDPPort.Name),
 		MaxPeers:          cliCtx.Uint(cmd.P2PMaxPeers.Name),
 		AllowListCIDR:     cliCtx.String(cmd.P2PAllowList.Name),
@@ -556,6 +593,7 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		StateNotifier:     b,
 		DB:                b.db,
 		ClockWaiter:       b.clockWaiter,
+		GossipCapture:     gossipCapture,
 	})
 	if err != nil {
 		return err
@@ -678,8 +716,12 @@ func (b *BeaconNode) registerSyncService(initialSyncComplete chan struct{}) erro
 		return err
 	}
 
-	rs := regularsync.NewService(
-		b.ctx,
+	trustedPeers, err := b.rateLimitTrustedPeers()
+	if err != nil {
+		return err
+	}
+
+	opts := []regularsync.Option{
 		regularsync.WithDatabase(b.db),
 		regularsync.WithP2P(b.fetchP2P()),
 		regularsync.WithChainService(chainService),
@@ -698,10 +740,65 @@ func (b *BeaconNode) registerSyncService(initialSyncComplete chan struct{}) erro
 		regularsync.WithExecutionPayloadReconstructor(web3Service),
 		regularsync.WithClockWaiter(b.clockWaiter),
 		regularsync.WithInitialSyncComplete(initialSyncComplete),
-	)
+		regularsync.WithTrustedPeers(trustedPeers),
+	}
+	if b.cliCtx.Bool(flags.EnableGossipReplay.Name) {
+		log.Warn("Gossip replay is enabled, replayed messages alter the state of the node which must not be used for validating")
+		opts = append(opts, regularsync.WithGossipReplay())
+	}
+	rs := regularsync.NewService(b.ctx, opts...)
 	return b.services.RegisterService(rs)
 }
 
+// rateLimitTrustedPeers returns the allow-listed peers, which are granted the req/resp rate limiting
+// quotas of trusted peers. Members of peer groups, such as the static peers, are trusted as well.
+func (b *BeaconNode) rateLimitTrustedPeers() ([]peer.ID, error) {
+	var pids []peer.ID
+	for _, id := range slice.SplitCommaSeparated(b.cliCtx.StringSlice(flags.RateLimitAllowlist.Name)) {
+		pid, err := peer.Decode(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode allow-listed peer ID %s", id)
+		}
+		pids = append(pids, pid)
+	}
+	return pids, nil
+}
+
+func (b *BeaconNode) registerBackfillService() error {
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+
+	p2pService := b.fetchP2P()
+	requester := func(ctx context.Context, pid peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+		return regularsync.SendBeaconBlocksByRangeRequest(ctx, chainService, p2pService, pid, req, nil)
+	}
+	opts := append(b.serviceFlagOpts.backfillOpts,
+		backfill.WithStore(b.db),
+		backfill.WithP2P(p2pService),
+		backfill.WithClockWaiter(b.clockWaiter),
+		backfill.WithBlockRequester(requester),
+	)
+	bf, err := backfill.NewService(b.ctx, b.backfillStatus, opts...)
+	if err != nil {
+		return errors.Wrap(err, "could not initialize backfill service")
+	}
+	return b.services.RegisterService(bf)
+}
+
+func (b *BeaconNode) registerPrunerService() error {
+	opts := append(b.serviceFlagOpts.prunerOpts,
+		pruner.WithDatabase(b.db),
+		pruner.WithClockWaiter(b.clockWaiter),
+	)
+	p, err := pruner.NewService(b.ctx, opts...)
+	if err != nil {
+		return errors.Wrap(err, "could not initialize pruner service")
+	}
+	return b.services.RegisterService(p)
+}
+
 func (b *BeaconNode) registerInitialSyncService(complete chan struct{}) error {
 	var chainService *blockchain.Service
 	if err := b.services.FetchService(&chainService); err != nil {
@@ -767,6 +864,16 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		return err
 	}
 
+	var regularSyncService *regularsync.Service
+	if err := b.services.FetchService(&regularSyncService); err != nil {
+		return err
+	}
+
+	var peerGroupManager *p2p.Service
+	if err := b.services.FetchService(&peerGroupManager); err != nil {
+		return err
+	}
+
 	var slasherService *slasher.Service
 	if features.Get().EnableSlasher {
 		if err := b.services.FetchService(&slasherService); err != nil {
@@ -774,6 +881,15 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		}
 	}
 
+	var validatorMonitor monitor.TrackedValidatorsManager
+	if b.validatorMonitorEnabled() {
+		var monitorService *monitor.Service
+		if err := b.services.FetchService(&monitorService); err != nil {
+			return err
+		}
+		validatorMonitor = monitorService
+	}
+
 	genesisValidators := b.cliCtx.Uint64(flags.InteropNumValidatorsFlag.Name)
 	var depositFetcher depositcache.DepositFetcher
 	var chainStartFetcher execution.ChainStartFetcher
@@ -814,6 +930,7 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		Broadcaster:                   p2pService,
 		PeersFetcher:                  p2pService,
 		PeerManager:                   p2pService,
+		PeerGroupManager:              peerGroupManager,
 		MetadataProvider:              p2pService,
 		ChainInfoFetcher:              chainService,
 		HeadFetcher:                   chainService,
@@ -826,17 +943,22 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		GenesisTimeFetcher:            chainService,
 		GenesisFetcher:                chainService,
 		OptimisticModeFetcher:         chainService,
+		LightClientFetcher:            chainService,
 		AttestationsPool:              b.attestationPool,
 		ExitPool:                      b.exitPool,
 		SlashingsPool:                 b.slashingsPool,
 		BLSChangesPool:                b.blsToExecPool,
 		SlashingChecker:               slasherService,
+		SlashingNotifier:              slasherService,
+		ValidatorMonitor:              validatorMonitor,
 		SyncCommitteeObjectPool:       b.syncCommitteePool,
 		ExecutionChainService:         web3Service,
 		ExecutionChainInfoFetcher:     web3Service,
 		ChainStartFetcher:             chainStartFetcher,
 		MockEth1Votes:                 mockEth1DataVotes,
 		SyncService:                   syncService,
+		RateLimitManager:              regularSyncService,
+		GossipReplayer:                regularSyncService,
 		DepositFetcher:                depositFetcher,
 		PendingDepositFetcher:         b.depositCache,
 		BlockNotifier:                 b,
@@ -949,11 +1071,19 @@ func (b *BeaconNode) registerDeterminsticGenesisService() error {
 	return nil
 }
 
+// validatorMonitorEnabled returns true if the validator monitor should run, either because validators
+// are tracked from startup or because they can be tracked at runtime.
+func (b *BeaconNode) validatorMonitorEnabled() bool {
+	return b.cliCtx.IntSlice(cmd.ValidatorMonitorIndicesFlag.Name) != nil ||
+		b.cliCtx.Bool(cmd.EnableValidatorMonitorFlag.Name) ||
+		b.cliCtx.Bool(cmd.ValidatorMonitorProposerPreparationsFlag.Name)
+}
+
 func (b *BeaconNode) registerValidatorMonitorService(initialSyncComplete chan struct{}) error {
-	cliSlice := b.cliCtx.IntSlice(cmd.ValidatorMonitorIndicesFlag.Name)
-	if cliSlice == nil {
+	if !b.validatorMonitorEnabled() {
 		return nil
 	}
+	cliSlice := b.cliCtx.IntSlice(cmd.ValidatorMonitorIndicesFlag.Name)
 	tracked := make([]primitives.ValidatorIndex, len(cliSlice))
 	for i := range tracked {
 		tracked[i] = primitives.ValidatorIndex(cliSlice[i])
@@ -964,11 +1094,12 @@ func (b *BeaconNode) registerValidatorMonitorService(initialSyncComplete chan st
 		return err
 	}
 	monitorConfig := &monitor.ValidatorMonitorConfig{
-		StateNotifier:       b,
-		AttestationNotifier: b,
-		StateGen:            b.stateGen,
-		HeadFetcher:         chainService,
-		InitialSyncComplete: initialSyncComplete,
+		StateNotifier:             b,
+		AttestationNotifier:       b,
+		StateGen:                  b.stateGen,
+		HeadFetcher:               chainService,
+		InitialSyncComplete:       initialSyncComplete,
+		TrackProposerPreparations: b.cliCtx.Bool(cmd.ValidatorMonitorProposerPreparationsFlag.Name),
 	}
 	svc, err := monitor.NewService(b.ctx, monitorConfig, tracked)
 	if err != nil {
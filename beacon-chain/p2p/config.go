@@ -13,6 +13,7 @@ type Config struct {
 	EnableUPnP          bool
 	StaticPeerID        bool
 	StaticPeers         []string
+	PeerGroups          map[string][]string
 	BootstrapNodeAddr   []string
 	Discv5BootStrapAddr []string
 	RelayNodeAddr       string
@@ -23,6 +24,7 @@ type Config struct {
 	DataDir             string
 	MetaDataDir         string
 	TCPPort             uint
+	QUICPort            uint
 	UDPPort             uint
 	MaxPeers            uint
 	AllowListCIDR       string
@@ -30,4 +32,5 @@ type Config struct {
 	StateNotifier       statefeed.Notifier
 	DB                  db.ReadOnlyDatabase
 	ClockWaiter         startup.ClockWaiter
+	GossipCapture       *GossipCaptureConfig
 }
@@ -53,7 +53,8 @@ func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
 			"reason": "exceeded dial limit"}).Trace("Not accepting inbound dial from ip address")
 		return false
 	}
-	if s.isPeerAtLimit(true /* inbound */) {
+	// Members of peer groups have reserved slots, their identity is checked once the connection is secured.
+	if s.isPeerAtLimit(true /* inbound */) && !s.isPeerGroupAddr(n.RemoteMultiaddr()) {
 		log.WithFields(logrus.Fields{"peer": n.RemoteMultiaddr(),
 			"reason": "at peer limit"}).Trace("Not accepting inbound dial")
 		return false
@@ -63,7 +64,13 @@ func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
 
 // InterceptSecured tests whether a given connection, now authenticated,
 // is allowed.
-func (_ *Service) InterceptSecured(_ network.Direction, _ peer.ID, _ network.ConnMultiaddrs) (allow bool) {
+func (s *Service) InterceptSecured(direction network.Direction, pid peer.ID, n network.ConnMultiaddrs) (allow bool) {
+	// Only members of peer groups may connect beyond the inbound limit.
+	if direction == network.DirInbound && !s.peers.IsProtected(pid) && s.isPeerAtLimit(true /* inbound */) {
+		log.WithFields(logrus.Fields{"peer": pid, "address": n.RemoteMultiaddr(),
+			"reason": "at peer limit"}).Trace("Not accepting inbound connection")
+		return false
+	}
 	return true
 }
 
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
@@ -167,6 +168,46 @@ func TestService_RejectInboundPeersBeyondLimit(t *testing.T) {
 	}
 }
 
+func TestService_AcceptPeerGroupMembersBeyondLimit(t *testing.T) {
+	limit := 20
+	s := &Service{
+		ipLimiter: leakybucket.NewCollector(ipLimit, ipBurst, 1*time.Second, false),
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    limit,
+			ScorerParams: &scorers.Config{},
+		}),
+		host:       mockp2p.NewTestP2P(t).BHost,
+		cfg:        &Config{MaxPeers: uint(limit)},
+		peerGroups: peerGroups{groups: make(map[string]map[peer.ID]*peerGroupMember)},
+	}
+	var err error
+	s.addrFilter, err = configureFilter(&Config{})
+	require.NoError(t, err)
+	s.started = true
+
+	memberAddr, err := ma.NewMultiaddr("/ip4/212.67.10.122/tcp/3000")
+	require.NoError(t, err)
+	otherAddr, err := ma.NewMultiaddr("/ip4/212.67.10.123/tcp/3000")
+	require.NoError(t, err)
+	member := addPeer(t, s.peers, peerdata.PeerConnectionState(ethpb.ConnectionState_DISCONNECTED))
+	other := addPeer(t, s.peers, peerdata.PeerConnectionState(ethpb.ConnectionState_DISCONNECTED))
+	s.peerGroups.groups["trusted"] = map[peer.ID]*peerGroupMember{
+		member: {info: peer.AddrInfo{ID: member, Addrs: []ma.Multiaddr{memberAddr}}},
+	}
+	s.peers.Protect(member, "trusted")
+
+	inboundLimit := float64(limit)*peers.InboundRatio + highWatermarkBuffer + 1
+	for i := 0; i < int(inboundLimit); i++ {
+		addPeer(t, s.peers, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+	}
+
+	assert.Equal(t, false, s.InterceptAccept(&maEndpoints{raddr: otherAddr}))
+	assert.Equal(t, true, s.InterceptAccept(&maEndpoints{raddr: memberAddr}))
+	assert.Equal(t, true, s.InterceptSecured(network.DirInbound, member, &maEndpoints{raddr: memberAddr}))
+	assert.Equal(t, false, s.InterceptSecured(network.DirInbound, other, &maEndpoints{raddr: memberAddr}))
+	assert.Equal(t, true, s.InterceptSecured(network.DirOutbound, other, &maEndpoints{raddr: otherAddr}))
+}
+
 func TestPeer_BelowMaxLimit(t *testing.T) {
 	// create host and remote peer
 	ipAddr, pkey := createAddrAndPrivKey(t)
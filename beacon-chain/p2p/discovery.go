@@ -15,6 +15,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	ecdsaprysm "github.com/prysmaticlabs/prysm/v4/crypto/ecdsa"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
@@ -34,6 +35,13 @@ type Listener interface {
 	LocalNode() *enode.LocalNode
 }
 
+// quicProtocol is the "quic" entry of the ENR, which holds the UDP port on which the node accepts
+// libp2p connections over QUIC.
+type quicProtocol uint16
+
+// ENRKey returns the key of the QUIC port entry in the ENR.
+func (quicProtocol) ENRKey() string { return "quic" }
+
 // RefreshENR uses an epoch to refresh the enr entry for our node
 // with the tracked committee ids for the epoch, allowing our node
 // to be dynamically discoverable by others given our tracked committee ids.
@@ -167,6 +175,7 @@ func (s *Service) createListener(
 		ipAddr,
 		int(s.cfg.UDPPort),
 		int(s.cfg.TCPPort),
+		int(s.cfg.QUICPort),
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create local node")
@@ -215,7 +224,7 @@ func (s *Service) createListener(
 func (s *Service) createLocalNode(
 	privKey *ecdsa.PrivateKey,
 	ipAddr net.IP,
-	udpPort, tcpPort int,
+	udpPort, tcpPort, quicPort int,
 ) (*enode.LocalNode, error) {
 	db, err := enode.OpenDB("")
 	if err != nil {
@@ -229,6 +238,9 @@ func (s *Service) createLocalNode(
 	localNode.Set(ipEntry)
 	localNode.Set(udpEntry)
 	localNode.Set(tcpEntry)
+	if features.Get().EnableQUIC {
+		localNode.Set(quicProtocol(quicPort))
+	}
 	localNode.SetFallbackIP(ipAddr)
 	localNode.SetFallbackUDP(udpPort)
 
@@ -258,7 +270,7 @@ func (s *Service) startDiscoveryV5(
 // Validity Conditions:
 //  1. The local node is still actively looking for peers to
 //     connect to.
-//  2. Peer has a valid IP and TCP port set in their enr.
+//  2. Peer has a valid IP and TCP port, or QUIC port if QUIC is enabled, set in their enr.
 //  3. Peer hasn't been marked as 'bad'
 //  4. Peer is not currently active or connected.
 //  5. Peer is ready to receive incoming connections.
@@ -273,11 +285,8 @@ func (s *Service) filterPeer(node *enode.Node) bool {
 	if node.IP() == nil {
 		return false
 	}
-	// do not dial nodes with their tcp ports not set
-	if err := node.Record().Load(enr.WithEntry("tcp", new(enr.TCP))); err != nil {
-		if !enr.IsNotFound(err) {
-			log.WithError(err).Debug("Could not retrieve tcp port")
-		}
+	// do not dial nodes with neither their tcp nor their quic ports set
+	if !hasDialablePort(node) {
 		return false
 	}
 	peerData, multiAddr, err := convertToAddrInfo(node)
@@ -315,20 +324,21 @@ func (s *Service) filterPeer(node *enode.Node) bool {
 // determines whether our currently connected and
 // active peers are above our set max peer limit.
 func (s *Service) isPeerAtLimit(inbound bool) bool {
-	numOfConns := len(s.host.Network().Peers())
+	// Members of peer groups have reserved slots, and do not count toward the limits.
+	numOfConns := len(s.peers.Unprotected(s.host.Network().Peers()))
 	maxPeers := int(s.cfg.MaxPeers)
 	// If we are measuring the limit for inbound peers
 	// we apply the high watermark buffer.
 	if inbound {
 		maxPeers += highWatermarkBuffer
 		maxInbound := s.peers.InboundLimit() + highWatermarkBuffer
-		currInbound := len(s.peers.InboundConnected())
+		currInbound := len(s.peers.Unprotected(s.peers.InboundConnected()))
 		// Exit early if we are at the inbound limit.
 		if currInbound >= maxInbound {
 			return true
 		}
 	}
-	activePeers := len(s.Peers().Active())
+	activePeers := len(s.peers.Unprotected(s.Peers().Active()))
 	return activePeers >= maxPeers || numOfConns >= maxPeers
 }
 
@@ -348,11 +358,11 @@ func PeersFromStringAddrs(addrs []string) ([]ma.Multiaddr, error) {
 		if err != nil {
 			return nil, errors.Wrapf(err, "Could not get enode from string")
 		}
-		addr, err := convertToSingleMultiAddr(enodeAddr)
+		addrs, err := convertToMultiAddrs(enodeAddr)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Could not get multiaddr")
 		}
-		allAddrs = append(allAddrs, addr)
+		allAddrs = append(allAddrs, addrs...)
 	}
 	return allAddrs, nil
 }
@@ -393,39 +403,101 @@ func convertToMultiAddr(nodes []*enode.Node) []ma.Multiaddr {
 		if node.IP() == nil {
 			continue
 		}
-		multiAddr, err := convertToSingleMultiAddr(node)
+		addrs, err := convertToMultiAddrs(node)
 		if err != nil {
 			log.WithError(err).Error("Could not convert to multiAddr")
 			continue
 		}
-		multiAddrs = append(multiAddrs, multiAddr)
+		multiAddrs = append(multiAddrs, addrs...)
 	}
 	return multiAddrs
 }
 
+// convertToAddrInfo returns the address info used to dial the node, along with its preferred address.
 func convertToAddrInfo(node *enode.Node) (*peer.AddrInfo, ma.Multiaddr, error) {
-	multiAddr, err := convertToSingleMultiAddr(node)
+	multiAddrs, err := convertToMultiAddrs(node)
 	if err != nil {
 		return nil, nil, err
 	}
-	info, err := peer.AddrInfoFromP2pAddr(multiAddr)
+	infos, err := peer.AddrInfosFromP2pAddrs(multiAddrs...)
 	if err != nil {
 		return nil, nil, err
 	}
-	return info, multiAddr, nil
+	if len(infos) != 1 {
+		return nil, nil, errors.Errorf("expected 1 peer for the addresses of the node, got %d", len(infos))
+	}
+	return &infos[0], multiAddrs[0], nil
+}
+
+// convertToMultiAddrs returns the multiaddresses at which the node accepts libp2p connections. When QUIC
+// is enabled and the node advertises a QUIC port, the QUIC address comes first as the preferred one.
+// Libp2p dials UDP addresses before TCP ones and falls back to TCP if the QUIC dial fails.
+func convertToMultiAddrs(node *enode.Node) ([]ma.Multiaddr, error) {
+	id, err := peerIdFromNode(node)
+	if err != nil {
+		return nil, err
+	}
+	var multiAddrs []ma.Multiaddr
+	var quicPort quicProtocol
+	if features.Get().EnableQUIC && node.Load(&quicPort) == nil {
+		addr, err := quicMultiAddressBuilderWithID(node.IP().String(), uint(quicPort), id)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not build QUIC address")
+		}
+		multiAddrs = append(multiAddrs, addr)
+	}
+	var tcpPort enr.TCP
+	if node.Load(&tcpPort) == nil {
+		addr, err := multiAddressBuilderWithID(node.IP().String(), "tcp", uint(tcpPort), id)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not build TCP address")
+		}
+		multiAddrs = append(multiAddrs, addr)
+	}
+	if len(multiAddrs) == 0 {
+		return nil, errors.New("node has no tcp or quic port set")
+	}
+	return multiAddrs, nil
 }
 
 func convertToSingleMultiAddr(node *enode.Node) (ma.Multiaddr, error) {
+	id, err := peerIdFromNode(node)
+	if err != nil {
+		return nil, err
+	}
+	return multiAddressBuilderWithID(node.IP().String(), "tcp", uint(node.TCP()), id)
+}
+
+func peerIdFromNode(node *enode.Node) (peer.ID, error) {
 	pubkey := node.Pubkey()
 	assertedKey, err := ecdsaprysm.ConvertToInterfacePubkey(pubkey)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not get pubkey")
+		return "", errors.Wrap(err, "could not get pubkey")
 	}
 	id, err := peer.IDFromPublicKey(assertedKey)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not get peer id")
+		return "", errors.Wrap(err, "could not get peer id")
 	}
-	return multiAddressBuilderWithID(node.IP().String(), "tcp", uint(node.TCP()), id)
+	return id, nil
+}
+
+// hasDialablePort returns whether the node advertises a TCP port, or a QUIC port when QUIC is enabled.
+func hasDialablePort(node *enode.Node) bool {
+	if err := node.Record().Load(enr.WithEntry("tcp", new(enr.TCP))); err == nil {
+		return true
+	} else if !enr.IsNotFound(err) {
+		log.WithError(err).Debug("Could not retrieve tcp port")
+	}
+	if !features.Get().EnableQUIC {
+		return false
+	}
+	if err := node.Record().Load(new(quicProtocol)); err != nil {
+		if !enr.IsNotFound(err) {
+			log.WithError(err).Debug("Could not retrieve quic port")
+		}
+		return false
+	}
+	return true
 }
 
 func convertToUdpMultiAddr(node *enode.Node) ([]ma.Multiaddr, error) {
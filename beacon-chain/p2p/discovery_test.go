@@ -27,6 +27,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers/scorers"
 	testp2p "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/wrapper"
 	leakybucket "github.com/prysmaticlabs/prysm/v4/container/leaky-bucket"
@@ -140,7 +141,7 @@ func TestMultiAddrsConversion_InvalidIPAddr(t *testing.T) {
 		genesisTime:           time.Now(),
 		genesisValidatorsRoot: bytesutil.PadTo([]byte{'A'}, 32),
 	}
-	node, err := s.createLocalNode(pkey, addr, 0, 0)
+	node, err := s.createLocalNode(pkey, addr, 0, 0, 0)
 	require.NoError(t, err)
 	multiAddr := convertToMultiAddr([]*enode.Node{node.Node()})
 	assert.Equal(t, 0, len(multiAddr), "Invalid ip address converted successfully")
@@ -167,6 +168,58 @@ func TestMultiAddrConversion_OK(t *testing.T) {
 	require.LogsDoNotContain(t, hook, "Could not get multiaddr")
 }
 
+func TestMultiAddrsConversion_QUIC(t *testing.T) {
+	ipAddr, pkey := createAddrAndPrivKey(t)
+	s := &Service{
+		genesisTime:           time.Now(),
+		genesisValidatorsRoot: bytesutil.PadTo([]byte{'A'}, 32),
+	}
+
+	node, err := s.createLocalNode(pkey, ipAddr, 2000, 3000, 3001)
+	require.NoError(t, err)
+	var quicPort quicProtocol
+	assert.NotNil(t, node.Node().Load(&quicPort), "QUIC port set in the ENR without QUIC enabled")
+	addrs, err := convertToMultiAddrs(node.Node())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(addrs))
+	assert.Equal(t, true, strings.Contains(addrs[0].String(), "/tcp/3000/p2p/"))
+
+	resetCfg := features.InitWithReset(&features.Flags{EnableQUIC: true})
+	defer resetCfg()
+	node, err = s.createLocalNode(pkey, ipAddr, 2000, 3000, 3001)
+	require.NoError(t, err)
+	require.NoError(t, node.Node().Load(&quicPort))
+	assert.Equal(t, quicProtocol(3001), quicPort)
+	addrs, err = convertToMultiAddrs(node.Node())
+	require.NoError(t, err)
+	require.Equal(t, 2, len(addrs))
+	assert.Equal(t, true, strings.Contains(addrs[0].String(), "/udp/3001/quic-v1/p2p/"), "QUIC address is not preferred")
+	assert.Equal(t, true, strings.Contains(addrs[1].String(), "/tcp/3000/p2p/"))
+
+	info, preferred, err := convertToAddrInfo(node.Node())
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(info.Addrs))
+	assert.Equal(t, addrs[0].String(), preferred.String())
+}
+
+func TestHasDialablePort(t *testing.T) {
+	_, pkey := createAddrAndPrivKey(t)
+	db, err := enode.OpenDB("")
+	require.NoError(t, err)
+	localNode := enode.NewLocalNode(db, pkey)
+	localNode.Set(enr.IP(net.ParseIP("192.168.0.1")))
+	assert.Equal(t, false, hasDialablePort(localNode.Node()))
+
+	localNode.Set(quicProtocol(3001))
+	assert.Equal(t, false, hasDialablePort(localNode.Node()))
+	resetCfg := features.InitWithReset(&features.Flags{EnableQUIC: true})
+	assert.Equal(t, true, hasDialablePort(localNode.Node()))
+	resetCfg()
+
+	localNode.Set(enr.TCP(3000))
+	assert.Equal(t, true, hasDialablePort(localNode.Node()))
+}
+
 func TestStaticPeering_PeersAreAdded(t *testing.T) {
 	cs := startup.NewClockSynchronizer()
 	cfg := &Config{
@@ -0,0 +1,284 @@
+package p2p
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	lru "github.com/hashicorp/golang-lru"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+)
+
+// Validation results of captured gossip messages.
+const (
+	GossipResultAccept    = "accept"
+	GossipResultReject    = "reject"
+	GossipResultIgnore    = "ignore"
+	GossipResultDuplicate = "duplicate"
+)
+
+const (
+	gossipCaptureFilePrefix = "gossip-"
+	gossipCaptureFileSuffix = ".jsonl"
+	// gossipCaptureQueueSize is the number of records waiting to be written, beyond which records are dropped.
+	gossipCaptureQueueSize = 4096
+	// gossipCaptureArrivalsSize is the number of messages being validated whose arrival time is tracked.
+	gossipCaptureArrivalsSize = 8192
+)
+
+// GossipCaptureRecord is a gossip message received or published by the node, as written to the gossip capture.
+// The data of the message is the snappy compressed SSZ payload, as sent on the wire. Duplicate messages are
+// recorded without their data.
+type GossipCaptureRecord struct {
+	Topic     string        `json:"topic"`
+	Peer      string        `json:"peer"`
+	MessageID string        `json:"message_id"`
+	Arrival   time.Time     `json:"arrival"`
+	Published bool          `json:"published"`
+	Result    string        `json:"result"`
+	Data      hexutil.Bytes `json:"data,omitempty"`
+}
+
+// GossipCaptureConfig specifies where the gossip capture is written, and how its files are rotated.
+type GossipCaptureConfig struct {
+	Dir string
+	// MaxFileSize is the size in bytes beyond which a new capture file is started.
+	MaxFileSize uint64
+	// MaxFiles is the number of capture files to keep. Older files are deleted.
+	MaxFiles int
+}
+
+// gossipCapture writes the gossip messages observed by the gossip tracer to rotating files. Records are
+// written asynchronously, so that the capture never blocks the validation of messages.
+type gossipCapture struct {
+	cfg      GossipCaptureConfig
+	records  chan *GossipCaptureRecord
+	arrivals *lru.Cache
+	done     chan struct{}
+	stopped  bool
+	lock     sync.RWMutex
+	file     *os.File
+	writer   *bufio.Writer
+	size     uint64
+}
+
+func newGossipCapture(cfg GossipCaptureConfig) (*gossipCapture, error) {
+	if cfg.MaxFileSize == 0 {
+		return nil, errors.New("maximum size of gossip capture files must be positive")
+	}
+	if cfg.MaxFiles <= 0 {
+		return nil, errors.New("maximum number of gossip capture files must be positive")
+	}
+	if err := file.MkdirAll(cfg.Dir); err != nil {
+		return nil, errors.Wrap(err, "could not create gossip capture directory")
+	}
+	arrivals, err := lru.New(gossipCaptureArrivalsSize)
+	if err != nil {
+		return nil, err
+	}
+	c := &gossipCapture{
+		cfg:      cfg,
+		records:  make(chan *GossipCaptureRecord, gossipCaptureQueueSize),
+		arrivals: arrivals,
+		done:     make(chan struct{}),
+	}
+	if err := c.rotate(); err != nil {
+		return nil, err
+	}
+	go c.run()
+	return c, nil
+}
+
+// arrived records the arrival time of a message, once it enters validation.
+func (c *gossipCapture) arrived(msg *pubsub.Message) {
+	c.arrivals.Add(msg.ID, time.Now())
+}
+
+// capture queues the record of a message which completed validation.
+func (c *gossipCapture) capture(msg *pubsub.Message, published bool, result string) {
+	arrival := time.Now()
+	if t, ok := c.arrivals.Get(msg.ID); ok {
+		arrival = t.(time.Time)
+		c.arrivals.Remove(msg.ID)
+	}
+	r := &GossipCaptureRecord{
+		Topic:     msg.GetTopic(),
+		Peer:      msg.ReceivedFrom.String(),
+		MessageID: hex.EncodeToString([]byte(msg.ID)),
+		Arrival:   arrival.UTC(),
+		Published: published,
+		Result:    result,
+	}
+	if result != GossipResultDuplicate {
+		r.Data = msg.Data
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if c.stopped {
+		return
+	}
+	select {
+	case c.records <- r:
+	default:
+		gossipCaptureDroppedCount.Inc()
+	}
+}
+
+// stop flushes the queued records and closes the current capture file.
+func (c *gossipCapture) stop() {
+	c.lock.Lock()
+	if c.stopped {
+		c.lock.Unlock()
+		return
+	}
+	c.stopped = true
+	close(c.records)
+	c.lock.Unlock()
+	<-c.done
+}
+
+func (c *gossipCapture) run() {
+	defer close(c.done)
+	defer c.closeFile()
+	for r := range c.records {
+		if err := c.write(r); err != nil {
+			log.WithError(err).Error("Could not write gossip capture record")
+		}
+		// Flush once the queue is drained, so that the capture is readable while the node runs.
+		if len(c.records) == 0 {
+			if err := c.writer.Flush(); err != nil {
+				log.WithError(err).Error("Could not flush gossip capture file")
+			}
+		}
+	}
+}
+
+func (c *gossipCapture) write(r *GossipCaptureRecord) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	if c.size > 0 && c.size+uint64(len(b)) > c.cfg.MaxFileSize {
+		if err := c.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := c.writer.Write(b)
+	c.size += uint64(n)
+	return err
+}
+
+// rotate closes the current capture file, starts a new one and deletes the oldest files beyond the
+// maximum number of files.
+func (c *gossipCapture) rotate() error {
+	c.closeFile()
+	name := fmt.Sprintf("%s%s%s", gossipCaptureFilePrefix, time.Now().UTC().Format("20060102T150405.000000000"), gossipCaptureFileSuffix)
+	f, err := os.OpenFile(filepath.Join(c.cfg.Dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, params.BeaconIoConfig().ReadWritePermissions) // #nosec G304
+	if err != nil {
+		return errors.Wrap(err, "could not create gossip capture file")
+	}
+	c.file = f
+	c.writer = bufio.NewWriter(f)
+	c.size = 0
+
+	files, err := GossipCaptureFiles(c.cfg.Dir)
+	if err != nil {
+		return err
+	}
+	for len(files) > c.cfg.MaxFiles {
+		if err := os.Remove(files[0]); err != nil {
+			return errors.Wrap(err, "could not delete gossip capture file")
+		}
+		files = files[1:]
+	}
+	return nil
+}
+
+func (c *gossipCapture) closeFile() {
+	if c.file == nil {
+		return
+	}
+	if err := c.writer.Flush(); err != nil {
+		log.WithError(err).Error("Could not flush gossip capture file")
+	}
+	if err := c.file.Close(); err != nil {
+		log.WithError(err).Error("Could not close gossip capture file")
+	}
+	c.file = nil
+}
+
+// GossipCaptureFiles returns the gossip capture files of the directory, from the oldest to the newest.
+func GossipCaptureFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read gossip capture directory")
+	}
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), gossipCaptureFilePrefix) || !strings.HasSuffix(e.Name(), gossipCaptureFileSuffix) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, e.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ReadGossipCapture calls fn with each record of a gossip capture file, in order, until fn returns an error.
+func ReadGossipCapture(r io.Reader, fn func(*GossipCaptureRecord) error) error {
+	scanner := bufio.NewScanner(r)
+	// Records hold whole blocks, which exceed the default maximum line size of the scanner.
+	scanner.Buffer(make([]byte, 0, 64*1024), 2*int(params.BeaconNetworkConfig().GossipMaxSizeBellatrix)+64*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := &GossipCaptureRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return errors.Wrapf(err, "could not decode gossip capture record on line %d", line)
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// gossipResult returns the validation result of a message rejected by pubsub for the given reason.
+func gossipResult(reason string) string {
+	switch reason {
+	case pubsub.RejectValidationFailed, pubsub.RejectInvalidSignature, pubsub.RejectUnexpectedSignature, pubsub.RejectMissingSignature:
+		return GossipResultReject
+	case pubsub.RejectValidationIgnored:
+		return GossipResultIgnore
+	default:
+		return reason
+	}
+}
+
+// GossipValidationResult returns the capture result of a validation result.
+func GossipValidationResult(res pubsub.ValidationResult) string {
+	switch res {
+	case pubsub.ValidationAccept:
+		return GossipResultAccept
+	case pubsub.ValidationReject:
+		return GossipResultReject
+	default:
+		return GossipResultIgnore
+	}
+}
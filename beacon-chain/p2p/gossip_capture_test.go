@@ -0,0 +1,116 @@
+package p2p
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func captureMessage(id, topic string, from peer.ID, data []byte) *pubsub.Message {
+	return &pubsub.Message{
+		Message:      &pubsubpb.Message{Data: data, Topic: &topic},
+		ID:           id,
+		ReceivedFrom: from,
+	}
+}
+
+func readCapture(t *testing.T, dir string) []*GossipCaptureRecord {
+	files, err := GossipCaptureFiles(dir)
+	require.NoError(t, err)
+	var records []*GossipCaptureRecord
+	for _, name := range files {
+		f, err := os.Open(name)
+		require.NoError(t, err)
+		require.NoError(t, ReadGossipCapture(f, func(r *GossipCaptureRecord) error {
+			records = append(records, r)
+			return nil
+		}))
+		require.NoError(t, f.Close())
+	}
+	return records
+}
+
+func TestGossipTracer_Capture(t *testing.T) {
+	h, _, _ := createHost(t, 34596)
+	defer func() {
+		require.NoError(t, h.Close())
+	}()
+	dir := filepath.Join(t.TempDir(), "capture")
+	capture, err := newGossipCapture(GossipCaptureConfig{Dir: dir, MaxFileSize: 1 << 20, MaxFiles: 2})
+	require.NoError(t, err)
+	tracer := gossipTracer{host: h, capture: capture}
+
+	remote := peer.ID("remote")
+	accepted := captureMessage("a", "/eth2/00000000/beacon_block/ssz_snappy", remote, []byte{1, 2})
+	tracer.ValidateMessage(accepted)
+	tracer.DeliverMessage(accepted)
+	rejected := captureMessage("b", "/eth2/00000000/beacon_attestation_3/ssz_snappy", remote, []byte{3})
+	tracer.ValidateMessage(rejected)
+	tracer.RejectMessage(rejected, pubsub.RejectValidationFailed)
+	ignored := captureMessage("c", "/eth2/00000000/beacon_attestation_3/ssz_snappy", remote, []byte{4})
+	tracer.RejectMessage(ignored, pubsub.RejectValidationIgnored)
+	tracer.DuplicateMessage(accepted)
+	published := captureMessage("d", "/eth2/00000000/voluntary_exit/ssz_snappy", h.ID(), []byte{5})
+	tracer.DeliverMessage(published)
+	capture.stop()
+	// Messages traced after the capture stopped are not recorded.
+	tracer.DeliverMessage(accepted)
+
+	records := readCapture(t, dir)
+	require.Equal(t, 5, len(records))
+	assert.Equal(t, "/eth2/00000000/beacon_block/ssz_snappy", records[0].Topic)
+	assert.Equal(t, remote.String(), records[0].Peer)
+	assert.Equal(t, "61", records[0].MessageID)
+	assert.Equal(t, GossipResultAccept, records[0].Result)
+	assert.Equal(t, false, records[0].Published)
+	assert.DeepEqual(t, []byte{1, 2}, []byte(records[0].Data))
+	assert.Equal(t, false, records[0].Arrival.IsZero())
+	assert.Equal(t, GossipResultReject, records[1].Result)
+	assert.Equal(t, GossipResultIgnore, records[2].Result)
+	assert.Equal(t, GossipResultDuplicate, records[3].Result)
+	assert.Equal(t, 0, len(records[3].Data))
+	assert.Equal(t, GossipResultAccept, records[4].Result)
+	assert.Equal(t, true, records[4].Published)
+}
+
+func TestGossipCapture_Rotate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "capture")
+	require.NoError(t, os.Mkdir(dir, 0700))
+	// Non capture files are left untouched.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0600))
+	capture, err := newGossipCapture(GossipCaptureConfig{Dir: dir, MaxFileSize: 300, MaxFiles: 2})
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		msg := captureMessage(string(rune('a'+i)), "/eth2/00000000/beacon_block/ssz_snappy", "remote", make([]byte, 32))
+		capture.capture(msg, false, GossipResultAccept)
+	}
+	capture.stop()
+
+	files, err := GossipCaptureFiles(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(files))
+	for _, name := range files {
+		info, err := os.Stat(name)
+		require.NoError(t, err)
+		assert.Equal(t, true, info.Size() <= 300, "capture file %s is too large", name)
+	}
+	// The newest records are kept.
+	records := readCapture(t, dir)
+	require.Equal(t, true, len(records) > 0)
+	assert.Equal(t, "74", records[len(records)-1].MessageID)
+	_, err = os.Stat(filepath.Join(dir, "notes.txt"))
+	require.NoError(t, err)
+}
+
+func TestGossipValidationResult(t *testing.T) {
+	assert.Equal(t, GossipResultAccept, GossipValidationResult(pubsub.ValidationAccept))
+	assert.Equal(t, GossipResultReject, GossipValidationResult(pubsub.ValidationReject))
+	assert.Equal(t, GossipResultIgnore, GossipValidationResult(pubsub.ValidationIgnore))
+	assert.Equal(t, pubsub.RejectValidationThrottled, gossipResult(pubsub.RejectValidationThrottled))
+}
@@ -57,3 +57,36 @@ func TestGossipTopicMappings_CorrectBlockType(t *testing.T) {
 	_, ok = pMessage.(*ethpb.SignedBeaconBlockCapella)
 	assert.Equal(t, true, ok)
 }
+
+func TestGossipTopicMappings_CorrectLightClientType(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	bCfg := params.BeaconConfig().Copy()
+	capellaForkEpoch := primitives.Epoch(300)
+	denebForkEpoch := primitives.Epoch(400)
+	bCfg.CapellaForkEpoch = capellaForkEpoch
+	bCfg.DenebForkEpoch = denebForkEpoch
+	params.OverrideBeaconConfig(bCfg)
+
+	pMessage := GossipTopicMappings(LightClientFinalityUpdateTopicFormat, 0)
+	_, ok := pMessage.(*ethpb.LightClientFinalityUpdate)
+	assert.Equal(t, true, ok)
+	pMessage = GossipTopicMappings(LightClientFinalityUpdateTopicFormat, capellaForkEpoch)
+	_, ok = pMessage.(*ethpb.LightClientFinalityUpdateCapella)
+	assert.Equal(t, true, ok)
+	pMessage = GossipTopicMappings(LightClientFinalityUpdateTopicFormat, denebForkEpoch)
+	_, ok = pMessage.(*ethpb.LightClientFinalityUpdateDeneb)
+	assert.Equal(t, true, ok)
+
+	pMessage = GossipTopicMappings(LightClientOptimisticUpdateTopicFormat, 0)
+	_, ok = pMessage.(*ethpb.LightClientOptimisticUpdate)
+	assert.Equal(t, true, ok)
+	pMessage = GossipTopicMappings(LightClientOptimisticUpdateTopicFormat, capellaForkEpoch)
+	_, ok = pMessage.(*ethpb.LightClientOptimisticUpdateCapella)
+	assert.Equal(t, true, ok)
+	pMessage = GossipTopicMappings(LightClientOptimisticUpdateTopicFormat, denebForkEpoch)
+	_, ok = pMessage.(*ethpb.LightClientOptimisticUpdateDeneb)
+	assert.Equal(t, true, ok)
+
+	assert.Equal(t, LightClientFinalityUpdateTopicFormat, GossipTypeMapping[reflect.TypeOf(&ethpb.LightClientFinalityUpdateDeneb{})])
+	assert.Equal(t, LightClientOptimisticUpdateTopicFormat, GossipTypeMapping[reflect.TypeOf(&ethpb.LightClientOptimisticUpdateCapella{})])
+}
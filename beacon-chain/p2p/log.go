@@ -1,7 +1,6 @@
 package p2p
 
 import (
-	"strconv"
 	"strings"
 
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -11,43 +10,38 @@ import (
 
 var log = logrus.WithField("prefix", "p2p")
 
+// logIPAddr logs the first IP listen address of every transport.
 func logIPAddr(id peer.ID, addrs ...ma.Multiaddr) {
-	var correctAddr ma.Multiaddr
+	logged := make(map[string]bool)
 	for _, addr := range addrs {
-		if strings.Contains(addr.String(), "/ip4/") || strings.Contains(addr.String(), "/ip6/") {
-			correctAddr = addr
-			break
+		if !strings.Contains(addr.String(), "/ip4/") && !strings.Contains(addr.String(), "/ip6/") {
+			continue
 		}
-	}
-	if correctAddr != nil {
+		transport := transportFromMultiAddr(addr)
+		if logged[transport] {
+			continue
+		}
+		logged[transport] = true
 		log.WithField(
 			"multiAddr",
-			correctAddr.String()+"/p2p/"+id.String(),
+			addr.String()+"/p2p/"+id.String(),
 		).Info("Node started p2p server")
 	}
 }
 
-func logExternalIPAddr(id peer.ID, addr string, port uint) {
-	if addr != "" {
-		multiAddr, err := MultiAddressBuilder(addr, port)
-		if err != nil {
-			log.WithError(err).Error("Could not create multiaddress")
-			return
-		}
-		log.WithField(
-			"multiAddr",
-			multiAddr.String()+"/p2p/"+id.String(),
-		).Info("Node started external p2p server")
+func logExternalAddrs(id peer.ID, host string, cfg *Config, builder func(string, *Config) ([]ma.Multiaddr, error)) {
+	if host == "" {
+		return
 	}
-}
-
-func logExternalDNSAddr(id peer.ID, addr string, port uint) {
-	if addr != "" {
-		p := strconv.FormatUint(uint64(port), 10)
-
+	multiAddrs, err := builder(host, cfg)
+	if err != nil {
+		log.WithError(err).Error("Could not create multiaddress")
+		return
+	}
+	for _, multiAddr := range multiAddrs {
 		log.WithField(
 			"multiAddr",
-			"/dns4/"+addr+"/tcp/"+p+"/p2p/"+id.String(),
+			multiAddr.String()+"/p2p/"+id.String(),
 		).Info("Node started external p2p server")
 	}
 }
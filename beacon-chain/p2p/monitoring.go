@@ -3,12 +3,20 @@ package p2p
 import (
 	"strings"
 
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
+const (
+	transportTCP   = "tcp"
+	transportQUIC  = "quic"
+	transportOther = "other"
+)
+
 var (
 	knownAgentVersions = []string{
 		"lighthouse",
@@ -24,6 +32,11 @@ var (
 		Help: "The number of peers in a given state.",
 	},
 		[]string{"state"})
+	p2pConnectionCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_connection_count",
+		Help: "The number of open libp2p connections by transport and direction.",
+	},
+		[]string{"transport", "direction"})
 	connectedPeersCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "connected_libp2p_peers",
 		Help: "Tracks the total number of connected libp2p peers by agent string",
@@ -129,6 +142,10 @@ var (
 		Name: "p2p_pubsub_rpc_drop_sub_total",
 		Help: "The number of subscription messages dropped via rpc",
 	})
+	gossipCaptureDroppedCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "p2p_gossip_capture_dropped_total",
+		Help: "The number of gossip messages which were not written to the gossip capture because its queue was full",
+	})
 	pubsubRPCSent = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "p2p_pubsub_rpc_sent_total",
 		Help: "The number of messages sent via rpc for a particular topic",
@@ -147,6 +164,7 @@ func (s *Service) updateMetrics() {
 	p2pPeerCount.WithLabelValues("Connecting").Set(float64(len(s.peers.Connecting())))
 	p2pPeerCount.WithLabelValues("Disconnecting").Set(float64(len(s.peers.Disconnecting())))
 	p2pPeerCount.WithLabelValues("Bad").Set(float64(len(s.peers.Bad())))
+	s.updateConnectionMetrics()
 
 	store := s.Host().Peerstore()
 	numConnectedPeersByClient := make(map[string]float64)
@@ -175,6 +193,41 @@ func (s *Service) updateMetrics() {
 	}
 }
 
+// updateConnectionMetrics counts the open connections of the host by transport and direction.
+func (s *Service) updateConnectionMetrics() {
+	counts := make(map[string]map[network.Direction]float64)
+	for _, transport := range []string{transportTCP, transportQUIC, transportOther} {
+		counts[transport] = map[network.Direction]float64{network.DirInbound: 0, network.DirOutbound: 0}
+	}
+	for _, conn := range s.Host().Network().Conns() {
+		counts[transportFromMultiAddr(conn.RemoteMultiaddr())][conn.Stat().Direction]++
+	}
+	for transport, byDirection := range counts {
+		for direction, count := range byDirection {
+			if direction == network.DirUnknown {
+				continue
+			}
+			p2pConnectionCount.WithLabelValues(transport, strings.ToLower(direction.String())).Set(count)
+		}
+	}
+}
+
+// transportFromMultiAddr returns the name of the libp2p transport of the multiaddress.
+func transportFromMultiAddr(addr ma.Multiaddr) string {
+	if addr == nil {
+		return transportOther
+	}
+	for _, p := range addr.Protocols() {
+		switch p.Code {
+		case ma.P_QUIC, ma.P_QUIC_V1:
+			return transportQUIC
+		case ma.P_TCP:
+			return transportTCP
+		}
+	}
+	return transportOther
+}
+
 func average(xs []float64) float64 {
 	if len(xs) == 0 {
 		return 0
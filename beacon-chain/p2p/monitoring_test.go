@@ -0,0 +1,28 @@
+package p2p
+
+import (
+	"testing"
+
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestTransportFromMultiAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want string
+	}{
+		{addr: "/ip4/127.0.0.1/tcp/13000", want: transportTCP},
+		{addr: "/ip6/::1/tcp/13000/p2p/16Uiu2HAkum7hhuMpWqFj3yNLcmQBGmThmqw2ohaCRThXQuKU9ohs", want: transportTCP},
+		{addr: "/ip4/127.0.0.1/udp/13000/quic-v1", want: transportQUIC},
+		{addr: "/ip4/127.0.0.1/udp/13000/quic", want: transportQUIC},
+		{addr: "/ip4/127.0.0.1/udp/12000", want: transportOther},
+	}
+	for _, tt := range tests {
+		addr, err := ma.NewMultiaddr(tt.addr)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, transportFromMultiAddr(addr), tt.addr)
+	}
+	assert.Equal(t, transportOther, transportFromMultiAddr(nil))
+}
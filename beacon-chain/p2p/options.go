@@ -10,6 +10,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/muxer/mplex"
 	"github.com/libp2p/go-libp2p/p2p/security/noise"
+	libp2pquic "github.com/libp2p/go-libp2p/p2p/transport/quic"
 	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
@@ -30,10 +31,57 @@ func MultiAddressBuilder(ipAddr string, port uint) (ma.Multiaddr, error) {
 	return ma.NewMultiaddr(fmt.Sprintf("/ip6/%s/tcp/%d", ipAddr, port))
 }
 
+// QUICMultiAddressBuilder takes in an ip address string and UDP port to produce the go multiaddr
+// format of the QUIC transport.
+func QUICMultiAddressBuilder(ipAddr string, port uint) (ma.Multiaddr, error) {
+	parsedIP := net.ParseIP(ipAddr)
+	if parsedIP.To4() == nil && parsedIP.To16() == nil {
+		return nil, errors.Errorf("invalid ip address provided: %s", ipAddr)
+	}
+	if parsedIP.To4() != nil {
+		return ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/udp/%d/quic-v1", ipAddr, port))
+	}
+	return ma.NewMultiaddr(fmt.Sprintf("/ip6/%s/udp/%d/quic-v1", ipAddr, port))
+}
+
+// hostMultiAddrs returns the multiaddresses of the libp2p host at the given ip address, which are
+// the TCP address followed by the QUIC address when QUIC is enabled.
+func hostMultiAddrs(ipAddr string, cfg *Config) ([]ma.Multiaddr, error) {
+	tcpAddr, err := MultiAddressBuilder(ipAddr, cfg.TCPPort)
+	if err != nil {
+		return nil, err
+	}
+	if !features.Get().EnableQUIC {
+		return []ma.Multiaddr{tcpAddr}, nil
+	}
+	quicAddr, err := QUICMultiAddressBuilder(ipAddr, cfg.QUICPort)
+	if err != nil {
+		return nil, err
+	}
+	return []ma.Multiaddr{tcpAddr, quicAddr}, nil
+}
+
+// hostDNSMultiAddrs returns the multiaddresses of the libp2p host at the given DNS name, which are
+// the TCP address followed by the QUIC address when QUIC is enabled.
+func hostDNSMultiAddrs(host string, cfg *Config) ([]ma.Multiaddr, error) {
+	tcpAddr, err := ma.NewMultiaddr(fmt.Sprintf("/dns4/%s/tcp/%d", host, cfg.TCPPort))
+	if err != nil {
+		return nil, err
+	}
+	if !features.Get().EnableQUIC {
+		return []ma.Multiaddr{tcpAddr}, nil
+	}
+	quicAddr, err := ma.NewMultiaddr(fmt.Sprintf("/dns4/%s/udp/%d/quic-v1", host, cfg.QUICPort))
+	if err != nil {
+		return nil, err
+	}
+	return []ma.Multiaddr{tcpAddr, quicAddr}, nil
+}
+
 // buildOptions for the libp2p host.
 func (s *Service) buildOptions(ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Option {
 	cfg := s.cfg
-	listen, err := MultiAddressBuilder(ip.String(), cfg.TCPPort)
+	listen, err := hostMultiAddrs(ip.String(), cfg)
 	if err != nil {
 		log.WithError(err).Fatal("Failed to p2p listen")
 	}
@@ -41,7 +89,7 @@ func (s *Service) buildOptions(ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 		if net.ParseIP(cfg.LocalIP) == nil {
 			log.Fatalf("Invalid local ip provided: %s", cfg.LocalIP)
 		}
-		listen, err = MultiAddressBuilder(cfg.LocalIP, cfg.TCPPort)
+		listen, err = hostMultiAddrs(cfg.LocalIP, cfg)
 		if err != nil {
 			log.WithError(err).Fatal("Failed to p2p listen")
 		}
@@ -58,7 +106,7 @@ func (s *Service) buildOptions(ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 
 	options := []libp2p.Option{
 		privKeyOption(priKey),
-		libp2p.ListenAddrs(listen),
+		libp2p.ListenAddrs(listen...),
 		libp2p.UserAgent(version.BuildData()),
 		libp2p.ConnectionGater(s),
 		libp2p.Transport(tcp.NewTCPTransport),
@@ -66,6 +114,11 @@ func (s *Service) buildOptions(ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 		libp2p.DefaultMuxers,
 	}
 
+	if features.Get().EnableQUIC {
+		// QUIC provides its own encryption and stream multiplexing.
+		options = append(options, libp2p.Transport(libp2pquic.NewTransport))
+	}
+
 	options = append(options, libp2p.Security(noise.ID, noise.New))
 
 	if cfg.EnableUPnP {
@@ -79,22 +132,22 @@ func (s *Service) buildOptions(ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 	}
 	if cfg.HostAddress != "" {
 		options = append(options, libp2p.AddrsFactory(func(addrs []ma.Multiaddr) []ma.Multiaddr {
-			external, err := MultiAddressBuilder(cfg.HostAddress, cfg.TCPPort)
+			external, err := hostMultiAddrs(cfg.HostAddress, cfg)
 			if err != nil {
 				log.WithError(err).Error("Unable to create external multiaddress")
 			} else {
-				addrs = append(addrs, external)
+				addrs = append(addrs, external...)
 			}
 			return addrs
 		}))
 	}
 	if cfg.HostDNS != "" {
 		options = append(options, libp2p.AddrsFactory(func(addrs []ma.Multiaddr) []ma.Multiaddr {
-			external, err := ma.NewMultiaddr(fmt.Sprintf("/dns4/%s/tcp/%d", cfg.HostDNS, cfg.TCPPort))
+			external, err := hostDNSMultiAddrs(cfg.HostDNS, cfg)
 			if err != nil {
 				log.WithError(err).Error("Unable to create external multiaddress")
 			} else {
-				addrs = append(addrs, external)
+				addrs = append(addrs, external...)
 			}
 			return addrs
 		}))
@@ -121,6 +174,21 @@ func multiAddressBuilderWithID(ipAddr, protocol string, port uint, id peer.ID) (
 	return ma.NewMultiaddr(fmt.Sprintf("/ip6/%s/%s/%d/p2p/%s", ipAddr, protocol, port, id.String()))
 }
 
+func quicMultiAddressBuilderWithID(ipAddr string, port uint, id peer.ID) (ma.Multiaddr, error) {
+	if id.String() == "" {
+		return nil, errors.New("empty peer id given")
+	}
+	addr, err := QUICMultiAddressBuilder(ipAddr, port)
+	if err != nil {
+		return nil, err
+	}
+	p2pAddr, err := ma.NewMultiaddr("/p2p/" + id.String())
+	if err != nil {
+		return nil, err
+	}
+	return addr.Encapsulate(p2pAddr), nil
+}
+
 // Adds a private key to the libp2p option if the option was provided.
 // If the private key file is missing or cannot be read, or if the
 // private key contents cannot be marshaled, an exception is thrown.
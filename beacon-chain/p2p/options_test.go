@@ -1,6 +1,7 @@
 package p2p
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"net"
@@ -13,8 +14,12 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	ma "github.com/multiformats/go-multiaddr"
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	ecdsaprysm "github.com/prysmaticlabs/prysm/v4/crypto/ecdsa"
 	"github.com/prysmaticlabs/prysm/v4/network"
@@ -127,3 +132,88 @@ func TestDefaultMultiplexers(t *testing.T) {
 	assert.Equal(t, protocol.ID("/yamux/1.0.0"), cfg.Muxers[1].ID)
 
 }
+
+func TestQUICMultiAddressBuilder(t *testing.T) {
+	addr, err := QUICMultiAddressBuilder("192.168.0.1", 13000)
+	require.NoError(t, err)
+	assert.Equal(t, "/ip4/192.168.0.1/udp/13000/quic-v1", addr.String())
+
+	addr, err = QUICMultiAddressBuilder("::1", 13000)
+	require.NoError(t, err)
+	assert.Equal(t, "/ip6/::1/udp/13000/quic-v1", addr.String())
+
+	_, err = QUICMultiAddressBuilder("invalid", 13000)
+	require.ErrorContains(t, "invalid ip address provided", err)
+}
+
+func TestHostMultiAddrs(t *testing.T) {
+	cfg := &Config{TCPPort: 13000, QUICPort: 13001}
+
+	addrs, err := hostMultiAddrs("192.168.0.1", cfg)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(addrs))
+	assert.Equal(t, "/ip4/192.168.0.1/tcp/13000", addrs[0].String())
+
+	resetCfg := features.InitWithReset(&features.Flags{EnableQUIC: true})
+	defer resetCfg()
+	addrs, err = hostMultiAddrs("192.168.0.1", cfg)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(addrs))
+	assert.Equal(t, "/ip4/192.168.0.1/tcp/13000", addrs[0].String())
+	assert.Equal(t, "/ip4/192.168.0.1/udp/13001/quic-v1", addrs[1].String())
+
+	addrs, err = hostDNSMultiAddrs("node.example.com", cfg)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(addrs))
+	assert.Equal(t, "/dns4/node.example.com/tcp/13000", addrs[0].String())
+	assert.Equal(t, "/dns4/node.example.com/udp/13001/quic-v1", addrs[1].String())
+}
+
+func TestQUICTransport(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableQUIC: true})
+	defer resetCfg()
+	p2pCfg := &Config{
+		TCPPort:       2000,
+		UDPPort:       2000,
+		QUICPort:      2001,
+		StateNotifier: &mock.MockStateNotifier{},
+	}
+	svc := &Service{cfg: p2pCfg}
+	var err error
+	svc.privKey, err = privKey(svc.cfg)
+	require.NoError(t, err)
+	var cfg libp2p.Config
+	opts := svc.buildOptions(net.ParseIP("127.0.0.1"), svc.privKey)
+	require.NoError(t, cfg.Apply(append(opts, libp2p.FallbackDefaults)...))
+
+	assert.Equal(t, 2, len(cfg.Transports))
+	require.Equal(t, 2, len(cfg.ListenAddrs))
+	assert.Equal(t, "/ip4/127.0.0.1/tcp/2000", cfg.ListenAddrs[0].String())
+	assert.Equal(t, "/ip4/127.0.0.1/udp/2001/quic-v1", cfg.ListenAddrs[1].String())
+}
+
+func TestQUICTransport_Connect(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableQUIC: true})
+	defer resetCfg()
+	newHost := func() host.Host {
+		s, err := NewService(context.Background(), &Config{StateNotifier: &mock.MockStateNotifier{}})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, s.Stop())
+		})
+		return s.host
+	}
+	h1, h2 := newHost(), newHost()
+
+	var quicAddrs []ma.Multiaddr
+	for _, addr := range h1.Addrs() {
+		if transportFromMultiAddr(addr) == transportQUIC {
+			quicAddrs = append(quicAddrs, addr)
+		}
+	}
+	require.NotEqual(t, 0, len(quicAddrs), "host does not listen over QUIC")
+	require.NoError(t, h2.Connect(context.Background(), peer.AddrInfo{ID: h1.ID(), Addrs: quicAddrs}))
+	conns := h2.Network().ConnsToPeer(h1.ID())
+	require.Equal(t, 1, len(conns))
+	assert.Equal(t, transportQUIC, transportFromMultiAddr(conns[0].RemoteMultiaddr()))
+}
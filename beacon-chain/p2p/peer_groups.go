@@ -0,0 +1,278 @@
+package p2p
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// StaticPeerGroup is the name of the peer group of the static peers.
+const StaticPeerGroup = "static"
+
+const (
+	// peerGroupReconnectInterval is the interval at which disconnected members of peer groups are redialed.
+	peerGroupReconnectInterval = 5 * time.Second
+	// peerGroupMaxBackoff is the maximum delay between two dials of a member of a peer group.
+	peerGroupMaxBackoff = 5 * time.Minute
+)
+
+var (
+	// ErrUnknownPeerGroup is returned when the peer group does not exist.
+	ErrUnknownPeerGroup = errors.New("unknown peer group")
+	// ErrInvalidPeerGroupName is returned when the name of the peer group is empty or contains spaces.
+	ErrInvalidPeerGroupName = errors.New("invalid peer group name")
+)
+
+// PeerGroupManager manages named groups of peers, to which the node stays connected. Members of
+// peer groups are redialed with backoff when disconnected, and are protected: they are never
+// pruned and do not take the connection slots of other peers.
+type PeerGroupManager interface {
+	PeerGroups() []*PeerGroup
+	AddPeerGroupMembers(group string, addrs []string) error
+	RemovePeerGroupMembers(group string, pids []peer.ID) error
+}
+
+// PeerGroup is a named group of peers.
+type PeerGroup struct {
+	Name    string
+	Members []*PeerGroupMember
+}
+
+// PeerGroupMember is the connection status of a member of a peer group.
+type PeerGroupMember struct {
+	AddrInfo  peer.AddrInfo
+	Connected bool
+	// Failures is the number of consecutive failed dials of the member.
+	Failures int
+	// NextDial is the earliest time of the next dial of the member, if it is disconnected.
+	NextDial time.Time
+}
+
+type peerGroupMember struct {
+	info     peer.AddrInfo
+	failures int
+	nextDial time.Time
+	dialing  bool
+}
+
+type peerGroups struct {
+	groups map[string]map[peer.ID]*peerGroupMember
+	sync.Mutex
+}
+
+// ParsePeerGroups parses peer groups given as "name=address" values, where the address is a
+// multiaddr or an ENR of the peer.
+func ParsePeerGroups(values []string) (map[string][]string, error) {
+	groups := make(map[string][]string)
+	for _, v := range values {
+		name, addr, ok := strings.Cut(v, "=")
+		if !ok || addr == "" {
+			return nil, errors.Errorf("peer group %q is not of the form name=address", v)
+		}
+		if err := validatePeerGroupName(name); err != nil {
+			return nil, err
+		}
+		groups[name] = append(groups[name], addr)
+	}
+	return groups, nil
+}
+
+func validatePeerGroupName(name string) error {
+	if name == "" || strings.ContainsAny(name, " \t/=") {
+		return errors.Wrapf(ErrInvalidPeerGroupName, "name %q", name)
+	}
+	return nil
+}
+
+// PeerGroups returns the peer groups and the connection status of their members.
+func (s *Service) PeerGroups() []*PeerGroup {
+	s.peerGroups.Lock()
+	defer s.peerGroups.Unlock()
+
+	groups := make([]*PeerGroup, 0, len(s.peerGroups.groups))
+	for name, members := range s.peerGroups.groups {
+		g := &PeerGroup{Name: name, Members: make([]*PeerGroupMember, 0, len(members))}
+		for pid, m := range members {
+			g.Members = append(g.Members, &PeerGroupMember{
+				AddrInfo:  m.info,
+				Connected: s.host.Network().Connectedness(pid) == network.Connected,
+				Failures:  m.failures,
+				NextDial:  m.nextDial,
+			})
+		}
+		sort.Slice(g.Members, func(i, j int) bool {
+			return g.Members[i].AddrInfo.ID < g.Members[j].AddrInfo.ID
+		})
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+// AddPeerGroupMembers adds the peers with the given multiaddrs or ENRs to the peer group, which
+// is created if it does not exist. The new members are dialed right away.
+func (s *Service) AddPeerGroupMembers(group string, addrs []string) error {
+	if err := validatePeerGroupName(group); err != nil {
+		return err
+	}
+	multiAddrs, err := PeersFromStringAddrs(addrs)
+	if err != nil {
+		return errors.Wrap(err, "could not parse peer addresses")
+	}
+	infos, err := peer.AddrInfosFromP2pAddrs(multiAddrs...)
+	if err != nil {
+		return errors.Wrap(err, "could not get peer address infos")
+	}
+
+	s.peerGroups.Lock()
+	members, ok := s.peerGroups.groups[group]
+	if !ok {
+		members = make(map[peer.ID]*peerGroupMember)
+		s.peerGroups.groups[group] = members
+	}
+	for _, info := range infos {
+		if m, ok := members[info.ID]; ok {
+			m.info.Addrs = info.Addrs
+			m.failures = 0
+			m.nextDial = time.Time{}
+			continue
+		}
+		members[info.ID] = &peerGroupMember{info: info}
+		s.peers.Protect(info.ID, group)
+	}
+	s.peerGroups.Unlock()
+
+	log.WithFields(logrus.Fields{
+		"group": group,
+		"peers": len(infos),
+	}).Info("Added peers to peer group")
+	s.reconnectPeerGroups()
+	return nil
+}
+
+// RemovePeerGroupMembers removes the given peers from the peer group, which is deleted once it has
+// no members left. The removed peers stay connected, but are no longer protected.
+func (s *Service) RemovePeerGroupMembers(group string, pids []peer.ID) error {
+	s.peerGroups.Lock()
+	defer s.peerGroups.Unlock()
+
+	members, ok := s.peerGroups.groups[group]
+	if !ok {
+		return errors.Wrapf(ErrUnknownPeerGroup, "group %s", group)
+	}
+	for _, pid := range pids {
+		if _, ok := members[pid]; !ok {
+			continue
+		}
+		delete(members, pid)
+		s.peers.Unprotect(pid, group)
+	}
+	if len(members) == 0 {
+		delete(s.peerGroups.groups, group)
+	}
+	log.WithFields(logrus.Fields{
+		"group": group,
+		"peers": len(pids),
+	}).Info("Removed peers from peer group")
+	return nil
+}
+
+// addConfiguredPeerGroups adds the static peers and the peer groups of the configuration.
+func (s *Service) addConfiguredPeerGroups() {
+	groups := make(map[string][]string, len(s.cfg.PeerGroups)+1)
+	for name, addrs := range s.cfg.PeerGroups {
+		groups[name] = addrs
+	}
+	if len(s.cfg.StaticPeers) > 0 {
+		groups[StaticPeerGroup] = append(groups[StaticPeerGroup], s.cfg.StaticPeers...)
+	}
+	for name, addrs := range groups {
+		if err := s.AddPeerGroupMembers(name, addrs); err != nil {
+			log.WithError(err).WithField("group", name).Error("Could not add peers to peer group")
+		}
+	}
+}
+
+// isPeerGroupAddr states if the address has the IP of a member of a peer group.
+func (s *Service) isPeerGroupAddr(addr multiaddr.Multiaddr) bool {
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return false
+	}
+
+	s.peerGroups.Lock()
+	defer s.peerGroups.Unlock()
+	for _, members := range s.peerGroups.groups {
+		for _, m := range members {
+			for _, memberAddr := range m.info.Addrs {
+				memberIP, err := manet.ToIP(memberAddr)
+				if err == nil && memberIP.Equal(ip) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// reconnectPeerGroups dials the disconnected members of the peer groups whose backoff has elapsed.
+// The delay between two dials of a member doubles after each failure, up to peerGroupMaxBackoff.
+func (s *Service) reconnectPeerGroups() {
+	s.peerGroups.Lock()
+	defer s.peerGroups.Unlock()
+
+	now := time.Now()
+	for name, members := range s.peerGroups.groups {
+		for pid, m := range members {
+			if pid == s.host.ID() || m.dialing {
+				continue
+			}
+			if s.host.Network().Connectedness(pid) == network.Connected {
+				m.failures = 0
+				m.nextDial = time.Time{}
+				continue
+			}
+			if now.Before(m.nextDial) {
+				continue
+			}
+			m.dialing = true
+			go s.dialPeerGroupMember(name, m, m.info)
+		}
+	}
+}
+
+func (s *Service) dialPeerGroupMember(group string, m *peerGroupMember, info peer.AddrInfo) {
+	err := connectWithTimeout(s.ctx, s.host, &info)
+
+	s.peerGroups.Lock()
+	defer s.peerGroups.Unlock()
+
+	m.dialing = false
+	if err == nil {
+		m.failures = 0
+		m.nextDial = time.Time{}
+		return
+	}
+	m.failures++
+	backoff := peerGroupMaxBackoff
+	if m.failures < 16 && peerGroupReconnectInterval<<(m.failures-1) < peerGroupMaxBackoff {
+		backoff = peerGroupReconnectInterval << (m.failures - 1)
+	}
+	m.nextDial = time.Now().Add(backoff)
+	log.WithError(err).WithFields(logrus.Fields{
+		"group":    group,
+		"peer":     info.ID,
+		"failures": m.failures,
+		"backoff":  backoff,
+	}).Debug("Could not connect to member of peer group")
+}
@@ -0,0 +1,137 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestParsePeerGroups(t *testing.T) {
+	groups, err := ParsePeerGroups([]string{
+		"sentries=/ip4/127.0.0.1/tcp/13000/p2p/16Uiu2HAkum7hhuMpWqFj3yNLcmQBGmThmqw2ohaCRThXQuKU9ohs",
+		"sentries=/ip4/127.0.0.2/tcp/13000/p2p/16Uiu2HAm2CMhKpbTbhVmGoNxj3zZP1ymPtcYhhAZLHtsLbVBv2Kv",
+		"partners=/ip4/127.0.0.3/tcp/13000/p2p/16Uiu2HAkydtMbRyXdqr5TrRNsgyWB8kMvUJfLCmWWtWqVEsmv4vH",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(groups["sentries"]))
+	assert.Equal(t, 1, len(groups["partners"]))
+
+	_, err = ParsePeerGroups([]string{"/ip4/127.0.0.1/tcp/13000"})
+	assert.ErrorContains(t, "is not of the form name=address", err)
+	_, err = ParsePeerGroups([]string{"=/ip4/127.0.0.1/tcp/13000"})
+	require.ErrorIs(t, err, ErrInvalidPeerGroupName)
+	_, err = ParsePeerGroups([]string{"sentries="})
+	assert.ErrorContains(t, "is not of the form name=address", err)
+}
+
+func TestService_PeerGroups(t *testing.T) {
+	h, _, _ := createHost(t, 34590)
+	defer func() {
+		require.NoError(t, h.Close())
+	}()
+	member, _, _ := createHost(t, 34591)
+	defer func() {
+		require.NoError(t, member.Close())
+	}()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &Service{
+		ctx:  ctx,
+		host: h,
+		peers: peers.NewStatus(ctx, &peers.StatusConfig{
+			PeerLimit:    30,
+			ScorerParams: &scorers.Config{},
+		}),
+		peerGroups: peerGroups{groups: make(map[string]map[peer.ID]*peerGroupMember)},
+	}
+
+	addr := fmt.Sprintf("%s/p2p/%s", member.Addrs()[0], member.ID())
+	require.NoError(t, s.AddPeerGroupMembers("sentries", []string{addr}))
+	assert.Equal(t, true, s.peers.IsProtected(member.ID()))
+
+	// The new member is dialed right away.
+	waitForCondition(t, func() bool {
+		return h.Network().Connectedness(member.ID()) == network.Connected
+	})
+	groups := s.PeerGroups()
+	require.Equal(t, 1, len(groups))
+	assert.Equal(t, "sentries", groups[0].Name)
+	require.Equal(t, 1, len(groups[0].Members))
+	assert.Equal(t, member.ID(), groups[0].Members[0].AddrInfo.ID)
+	assert.Equal(t, true, groups[0].Members[0].Connected)
+
+	// The member is reconnected once disconnected.
+	require.NoError(t, h.Network().ClosePeer(member.ID()))
+	waitForCondition(t, func() bool {
+		s.reconnectPeerGroups()
+		return h.Network().Connectedness(member.ID()) == network.Connected
+	})
+
+	err := s.RemovePeerGroupMembers("partners", []peer.ID{member.ID()})
+	require.ErrorIs(t, err, ErrUnknownPeerGroup)
+	require.NoError(t, s.RemovePeerGroupMembers("sentries", []peer.ID{member.ID()}))
+	assert.Equal(t, false, s.peers.IsProtected(member.ID()))
+	assert.Equal(t, 0, len(s.PeerGroups()))
+
+	err = s.AddPeerGroupMembers("bad name", []string{addr})
+	require.ErrorIs(t, err, ErrInvalidPeerGroupName)
+}
+
+func TestService_PeerGroups_Backoff(t *testing.T) {
+	h, _, _ := createHost(t, 34592)
+	defer func() {
+		require.NoError(t, h.Close())
+	}()
+	unreachable, _, _ := createHost(t, 34593)
+	addr := fmt.Sprintf("%s/p2p/%s", unreachable.Addrs()[0], unreachable.ID())
+	require.NoError(t, unreachable.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &Service{
+		ctx:  ctx,
+		host: h,
+		peers: peers.NewStatus(ctx, &peers.StatusConfig{
+			PeerLimit:    30,
+			ScorerParams: &scorers.Config{},
+		}),
+		peerGroups: peerGroups{groups: make(map[string]map[peer.ID]*peerGroupMember)},
+	}
+	require.NoError(t, s.AddPeerGroupMembers("sentries", []string{addr}))
+
+	memberFailures := func() int {
+		groups := s.PeerGroups()
+		require.Equal(t, 1, len(groups))
+		require.Equal(t, 1, len(groups[0].Members))
+		return groups[0].Members[0].Failures
+	}
+	waitForCondition(t, func() bool {
+		return memberFailures() == 1
+	})
+	m := s.PeerGroups()[0].Members[0]
+	assert.Equal(t, false, m.Connected)
+	assert.Equal(t, true, m.NextDial.After(time.Now()))
+
+	// The member is not redialed before its backoff has elapsed.
+	s.reconnectPeerGroups()
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, memberFailures())
+}
+
+func waitForCondition(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("Condition not met before timeout")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
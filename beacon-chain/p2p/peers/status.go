@@ -82,6 +82,7 @@ type Status struct {
 	scorers   *scorers.Service
 	store     *peerdata.Store
 	ipTracker map[string]uint64
+	protected map[peer.ID]map[string]bool
 	rand      *rand.Rand
 }
 
@@ -103,6 +104,7 @@ func NewStatus(ctx context.Context, config *StatusConfig) *Status {
 		store:     store,
 		scorers:   scorers.NewService(ctx, store, config.ScorerParams),
 		ipTracker: map[string]uint64{},
+		protected: map[peer.ID]map[string]bool{},
 		// Random generator used to calculate dial backoff period.
 		// It is ok to use deterministic generator, no need for true entropy.
 		rand: rand.NewDeterministicGenerator(),
@@ -335,9 +337,56 @@ func (p *Status) IsBad(pid peer.ID) bool {
 
 // isBad is the lock-free version of IsBad.
 func (p *Status) isBad(pid peer.ID) bool {
+	if len(p.protected[pid]) > 0 {
+		return false
+	}
 	return p.isfromBadIP(pid) || p.scorers.IsBadPeerNoLock(pid)
 }
 
+// Protect marks the peer as a member of the given peer group. Protected peers are never considered
+// bad nor pruned, and do not count toward the connected peer limits.
+func (p *Status) Protect(pid peer.ID, group string) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	if p.protected[pid] == nil {
+		p.protected[pid] = make(map[string]bool)
+	}
+	p.protected[pid][group] = true
+}
+
+// Unprotect removes the peer from the given peer group. The peer stays protected
+// as long as it is a member of another group.
+func (p *Status) Unprotect(pid peer.ID, group string) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	delete(p.protected[pid], group)
+	if len(p.protected[pid]) == 0 {
+		delete(p.protected, pid)
+	}
+}
+
+// IsProtected states if the peer is a member of a peer group.
+func (p *Status) IsProtected(pid peer.ID) bool {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return len(p.protected[pid]) > 0
+}
+
+// Unprotected returns the provided peers which are not protected.
+func (p *Status) Unprotected(pids []peer.ID) []peer.ID {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	peers := make([]peer.ID, 0, len(pids))
+	for _, pid := range pids {
+		if len(p.protected[pid]) == 0 {
+			peers = append(peers, pid)
+		}
+	}
+	return peers
+}
+
 // NextValidTime gets the earliest possible time it is to contact/dial
 // a peer again. This is used to back-off from peers in the event
 // they are 'full' or have banned us.
@@ -563,7 +612,7 @@ func (p *Status) Prune() {
 	peersToPrune := make([]*peerResp, 0)
 	// Select disconnected peers with a smaller bad response count.
 	for pid, peerData := range p.store.Peers() {
-		if peerData.ConnState == PeerDisconnected && notBadPeer(pid) {
+		if peerData.ConnState == PeerDisconnected && notBadPeer(pid) && len(p.protected[pid]) == 0 {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:   pid,
 				score: p.Scorers().ScoreNoLock(pid),
@@ -611,7 +660,7 @@ func (p *Status) deprecatedPrune() {
 	peersToPrune := make([]*peerResp, 0)
 	// Select disconnected peers with a smaller bad response count.
 	for pid, peerData := range p.store.Peers() {
-		if peerData.ConnState == PeerDisconnected && notBadPeer(peerData) {
+		if peerData.ConnState == PeerDisconnected && notBadPeer(peerData) && len(p.protected[pid]) == 0 {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:     pid,
 				badResp: peerData.BadResponses,
@@ -751,8 +800,9 @@ func (p *Status) PeersToPrune() []peer.ID {
 	}
 	connLimit := p.ConnectedPeerLimit()
 	inBoundLimit := uint64(p.InboundLimit())
-	activePeers := p.Active()
-	numInboundPeers := uint64(len(p.InboundConnected()))
+	// Protected peers do not count toward the limits.
+	activePeers := p.Unprotected(p.Active())
+	numInboundPeers := uint64(len(p.Unprotected(p.InboundConnected())))
 	// Exit early if we are still below our max
 	// limit.
 	if uint64(len(activePeers)) <= connLimit {
@@ -769,7 +819,8 @@ func (p *Status) PeersToPrune() []peer.ID {
 	// Select connected and inbound peers to prune.
 	for pid, peerData := range p.store.Peers() {
 		if peerData.ConnState == PeerConnected &&
-			peerData.Direction == network.DirInbound {
+			peerData.Direction == network.DirInbound &&
+			len(p.protected[pid]) == 0 {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:   pid,
 				score: p.scorers.ScoreNoLock(pid),
@@ -817,8 +868,9 @@ func (p *Status) PeersToPrune() []peer.ID {
 func (p *Status) deprecatedPeersToPrune() []peer.ID {
 	connLimit := p.ConnectedPeerLimit()
 	inBoundLimit := p.InboundLimit()
-	activePeers := p.Active()
-	numInboundPeers := len(p.InboundConnected())
+	// Protected peers do not count toward the limits.
+	activePeers := p.Unprotected(p.Active())
+	numInboundPeers := len(p.Unprotected(p.InboundConnected()))
 	// Exit early if we are still below our max
 	// limit.
 	if uint64(len(activePeers)) <= connLimit {
@@ -835,7 +887,8 @@ func (p *Status) deprecatedPeersToPrune() []peer.ID {
 	// Select connected and inbound peers to prune.
 	for pid, peerData := range p.store.Peers() {
 		if peerData.ConnState == PeerConnected &&
-			peerData.Direction == network.DirInbound {
+			peerData.Direction == network.DirInbound &&
+			len(p.protected[pid]) == 0 {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:     pid,
 				badResp: peerData.BadResponses,
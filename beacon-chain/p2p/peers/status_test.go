@@ -755,6 +755,79 @@ func TestPrunePeers(t *testing.T) {
 	}
 }
 
+func TestStatus_ProtectedPeers(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold: 1,
+			},
+		},
+	})
+	for i := 0; i < 15; i++ {
+		createPeer(t, p, nil, network.DirOutbound, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+	}
+	var protected []peer.ID
+	for i := 0; i < 18; i++ {
+		pid := createPeer(t, p, nil, network.DirInbound, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+		if i < 5 {
+			p.Protect(pid, "static")
+			protected = append(protected, pid)
+		}
+	}
+	assert.Equal(t, 28, len(p.Unprotected(p.Active())))
+
+	// Protected peers do not count toward the limits.
+	assert.Equal(t, 0, len(p.PeersToPrune()))
+
+	for i := 0; i < 5; i++ {
+		createPeer(t, p, nil, network.DirInbound, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+	}
+	peersToPrune := p.PeersToPrune()
+	assert.Equal(t, 3, len(peersToPrune))
+	for _, pid := range peersToPrune {
+		assert.Equal(t, false, p.IsProtected(pid))
+	}
+
+	// Protected peers are never bad.
+	p.Scorers().BadResponsesScorer().Increment(protected[0])
+	assert.Equal(t, false, p.IsBad(protected[0]))
+
+	// Peers stay protected while they are a member of a group.
+	p.Protect(protected[0], "trusted")
+	p.Unprotect(protected[0], "static")
+	assert.Equal(t, true, p.IsProtected(protected[0]))
+	p.Unprotect(protected[0], "trusted")
+	assert.Equal(t, false, p.IsProtected(protected[0]))
+	assert.Equal(t, true, p.IsBad(protected[0]))
+}
+
+func TestPrune_ProtectedPeers(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold: 2,
+			},
+		},
+	})
+	for i := 0; i < p.MaxPeerLimit()+100; i++ {
+		if i%7 == 0 {
+			_ = addPeer(t, p, peers.PeerDisconnected)
+		}
+		_ = addPeer(t, p, peers.PeerConnected)
+	}
+	disPeers := p.Disconnected()
+	p.Protect(disPeers[0], "static")
+
+	p.Prune()
+
+	_, err := p.Scorers().BadResponsesScorer().Count(disPeers[0])
+	assert.NoError(t, err, "protected peer was pruned")
+	_, err = p.Scorers().BadResponsesScorer().Count(disPeers[1])
+	assert.ErrorContains(t, "peer unknown", err)
+}
+
 func TestStatus_BestPeer(t *testing.T) {
 	type peerConfig struct {
 		headSlot       primitives.Slot
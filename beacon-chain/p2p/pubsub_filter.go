@@ -18,6 +18,7 @@ var _ pubsub.SubscriptionFilter = (*Service)(nil)
 // of double topic subscriptions at fork boundaries.
 // -> 64 Attestation Subnets * 2.
 // -> 4 Sync Committee Subnets * 2.
+// -> 6 Blob Sidecar Subnets * 2.
 // -> Block,Aggregate,ProposerSlashing,AttesterSlashing,Exits,SyncContribution * 2.
 const pubsubSubscriptionRequestLimit = 200
 
@@ -57,12 +58,18 @@ func (s *Service) CanSubscribe(topic string) bool {
 		log.WithError(err).Error("Could not determine Capella fork digest")
 		return false
 	}
+	denebForkDigest, err := forks.ForkDigestFromEpoch(params.BeaconConfig().DenebForkEpoch, s.genesisValidatorsRoot)
+	if err != nil {
+		log.WithError(err).Error("Could not determine Deneb fork digest")
+		return false
+	}
 
 	switch parts[2] {
 	case fmt.Sprintf("%x", phase0ForkDigest):
 	case fmt.Sprintf("%x", altairForkDigest):
 	case fmt.Sprintf("%x", bellatrixForkDigest):
 	case fmt.Sprintf("%x", capellaForkDigest):
+	case fmt.Sprintf("%x", denebForkDigest):
 	default:
 		return false
 	}
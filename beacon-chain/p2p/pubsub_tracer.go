@@ -11,9 +11,11 @@ import (
 var _ = pubsub.RawTracer(gossipTracer{})
 
 // This tracer is used to implement metrics collection for messages received
-// and broadcasted through gossipsub.
+// and broadcasted through gossipsub. When the gossip capture is enabled, it
+// also records every message along with its validation result.
 type gossipTracer struct {
-	host host.Host
+	host    host.Host
+	capture *gossipCapture
 }
 
 // AddPeer .
@@ -49,21 +51,27 @@ func (g gossipTracer) Prune(p peer.ID, topic string) {
 // ValidateMessage .
 func (g gossipTracer) ValidateMessage(msg *pubsub.Message) {
 	pubsubMessageValidate.WithLabelValues(*msg.Topic).Inc()
+	if g.capture != nil {
+		g.capture.arrived(msg)
+	}
 }
 
 // DeliverMessage .
 func (g gossipTracer) DeliverMessage(msg *pubsub.Message) {
 	pubsubMessageDeliver.WithLabelValues(*msg.Topic).Inc()
+	g.captureMessage(msg, GossipResultAccept)
 }
 
 // RejectMessage .
 func (g gossipTracer) RejectMessage(msg *pubsub.Message, reason string) {
 	pubsubMessageReject.WithLabelValues(*msg.Topic).Inc()
+	g.captureMessage(msg, gossipResult(reason))
 }
 
 // DuplicateMessage .
 func (g gossipTracer) DuplicateMessage(msg *pubsub.Message) {
 	pubsubMessageDuplicate.WithLabelValues(*msg.Topic).Inc()
+	g.captureMessage(msg, GossipResultDuplicate)
 }
 
 // UndeliverableMessage .
@@ -92,6 +100,13 @@ func (g gossipTracer) DropRPC(rpc *pubsub.RPC, p peer.ID) {
 	setMetricFromRPC(pubsubRPCSubDrop, pubsubRPCDrop, rpc)
 }
 
+func (g gossipTracer) captureMessage(msg *pubsub.Message, result string) {
+	if g.capture == nil {
+		return
+	}
+	g.capture.capture(msg, msg.ReceivedFrom == g.host.ID(), result)
+}
+
 func setMetricFromRPC(ctr prometheus.Counter, gauge *prometheus.CounterVec, rpc *pubsub.RPC) {
 	ctr.Add(float64(len(rpc.Subscriptions)))
 	if rpc.Control != nil {
@@ -31,12 +31,30 @@ const BeaconBlocksByRangeMessageName = "/beacon_blocks_by_range"
 // BeaconBlocksByRootsMessageName specifies the name for the beacon blocks by root message topic.
 const BeaconBlocksByRootsMessageName = "/beacon_blocks_by_root"
 
+// BlobSidecarsByRangeName is the name for the BlobSidecarsByRange v1 message topic.
+const BlobSidecarsByRangeName = "/blob_sidecars_by_range"
+
+// BlobSidecarsByRootName is the name for the BlobSidecarsByRoot v1 message topic.
+const BlobSidecarsByRootName = "/blob_sidecars_by_root"
+
 // PingMessageName Specifies the name for the ping message topic.
 const PingMessageName = "/ping"
 
 // MetadataMessageName specifies the name for the metadata message topic.
 const MetadataMessageName = "/metadata"
 
+// LightClientBootstrapMessageName specifies the name for the light client bootstrap message topic.
+const LightClientBootstrapMessageName = "/light_client_bootstrap"
+
+// LightClientUpdatesByRangeMessageName specifies the name for the light client updates by range message topic.
+const LightClientUpdatesByRangeMessageName = "/light_client_updates_by_range"
+
+// LightClientFinalityUpdateMessageName specifies the name for the light client finality update message topic.
+const LightClientFinalityUpdateMessageName = "/light_client_finality_update"
+
+// LightClientOptimisticUpdateMessageName specifies the name for the light client optimistic update message topic.
+const LightClientOptimisticUpdateMessageName = "/light_client_optimistic_update"
+
 const (
 	// V1 RPC Topics
 	// RPCStatusTopicV1 defines the v1 topic for the status rpc method.
@@ -47,10 +65,23 @@ const (
 	RPCBlocksByRangeTopicV1 = protocolPrefix + BeaconBlocksByRangeMessageName + SchemaVersionV1
 	// RPCBlocksByRootTopicV1 defines the v1 topic for the blocks by root rpc method.
 	RPCBlocksByRootTopicV1 = protocolPrefix + BeaconBlocksByRootsMessageName + SchemaVersionV1
+	// RPCBlobSidecarsByRangeTopicV1 is a topic for requesting blob sidecars
+	// in the slot range [start_slot, start_slot + count), leading up to the current head block as selected by fork choice.
+	RPCBlobSidecarsByRangeTopicV1 = protocolPrefix + BlobSidecarsByRangeName + SchemaVersionV1
+	// RPCBlobSidecarsByRootTopicV1 is a topic for requesting blob sidecars by their block root.
+	RPCBlobSidecarsByRootTopicV1 = protocolPrefix + BlobSidecarsByRootName + SchemaVersionV1
 	// RPCPingTopicV1 defines the v1 topic for the ping rpc method.
 	RPCPingTopicV1 = protocolPrefix + PingMessageName + SchemaVersionV1
 	// RPCMetaDataTopicV1 defines the v1 topic for the metadata rpc method.
 	RPCMetaDataTopicV1 = protocolPrefix + MetadataMessageName + SchemaVersionV1
+	// RPCLightClientBootstrapTopicV1 defines the v1 topic for the light client bootstrap rpc method.
+	RPCLightClientBootstrapTopicV1 = protocolPrefix + LightClientBootstrapMessageName + SchemaVersionV1
+	// RPCLightClientUpdatesByRangeTopicV1 defines the v1 topic for the light client updates by range rpc method.
+	RPCLightClientUpdatesByRangeTopicV1 = protocolPrefix + LightClientUpdatesByRangeMessageName + SchemaVersionV1
+	// RPCLightClientFinalityUpdateTopicV1 defines the v1 topic for the light client finality update rpc method.
+	RPCLightClientFinalityUpdateTopicV1 = protocolPrefix + LightClientFinalityUpdateMessageName + SchemaVersionV1
+	// RPCLightClientOptimisticUpdateTopicV1 defines the v1 topic for the light client optimistic update rpc method.
+	RPCLightClientOptimisticUpdateTopicV1 = protocolPrefix + LightClientOptimisticUpdateMessageName + SchemaVersionV1
 
 	// V2 RPC Topics
 	// RPCBlocksByRangeTopicV2 defines v2 the topic for the blocks by range rpc method.
@@ -78,11 +109,19 @@ var RPCTopicMappings = map[string]interface{}{
 	// RPC Block By Root Message
 	RPCBlocksByRootTopicV1: new(p2ptypes.BeaconBlockByRootsReq),
 	RPCBlocksByRootTopicV2: new(p2ptypes.BeaconBlockByRootsReq),
+	// RPC Blob Sidecar Messages
+	RPCBlobSidecarsByRangeTopicV1: new(pb.BlobSidecarsByRangeRequest),
+	RPCBlobSidecarsByRootTopicV1:  new(p2ptypes.BlobSidecarsByRootReq),
 	// RPC Ping Message
 	RPCPingTopicV1: new(primitives.SSZUint64),
 	// RPC Metadata Message
 	RPCMetaDataTopicV1: new(interface{}),
 	RPCMetaDataTopicV2: new(interface{}),
+	// RPC Light Client Messages
+	RPCLightClientBootstrapTopicV1:        new(pb.LightClientBootstrapRequest),
+	RPCLightClientUpdatesByRangeTopicV1:   new(pb.LightClientUpdatesByRangeRequest),
+	RPCLightClientFinalityUpdateTopicV1:   new(interface{}),
+	RPCLightClientOptimisticUpdateTopicV1: new(interface{}),
 }
 
 // Maps all registered protocol prefixes.
@@ -93,12 +132,18 @@ var protocolMapping = map[string]bool{
 // Maps all the protocol message names for the different rpc
 // topics.
 var messageMapping = map[string]bool{
-	StatusMessageName:              true,
-	GoodbyeMessageName:             true,
-	BeaconBlocksByRangeMessageName: true,
-	BeaconBlocksByRootsMessageName: true,
-	PingMessageName:                true,
-	MetadataMessageName:            true,
+	StatusMessageName:                      true,
+	GoodbyeMessageName:                     true,
+	BeaconBlocksByRangeMessageName:         true,
+	BeaconBlocksByRootsMessageName:         true,
+	BlobSidecarsByRangeName:                true,
+	BlobSidecarsByRootName:                 true,
+	PingMessageName:                        true,
+	MetadataMessageName:                    true,
+	LightClientBootstrapMessageName:        true,
+	LightClientUpdatesByRangeMessageName:   true,
+	LightClientFinalityUpdateMessageName:   true,
+	LightClientOptimisticUpdateMessageName: true,
 }
 
 // Maps all the RPC messages which are to updated in altair.
@@ -80,6 +80,8 @@ type Service struct {
 	genesisTime           time.Time
 	genesisValidatorsRoot []byte
 	activeValidatorCount  uint64
+	peerGroups            peerGroups
+	gossipCapture         *gossipCapture
 }
 
 // NewService initializes a new p2p service compatible with shared.Service interface. No
@@ -96,6 +98,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		isPreGenesis: true,
 		joinedTopics: make(map[string]*pubsub.Topic, len(gossipTopicMappings)),
 		subnetsLock:  make(map[uint64]*sync.RWMutex),
+		peerGroups:   peerGroups{groups: make(map[string]map[peer.ID]*peerGroupMember)},
 	}
 
 	dv5Nodes := parseBootStrapAddrs(s.cfg.BootstrapNodeAddr)
@@ -128,6 +131,14 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 	}
 
 	s.host = h
+	if s.cfg.GossipCapture != nil {
+		s.gossipCapture, err = newGossipCapture(*s.cfg.GossipCapture)
+		if err != nil {
+			log.WithError(err).Error("Failed to start gossip capture")
+			return nil, err
+		}
+		log.WithField("dir", s.cfg.GossipCapture.Dir).Info("Capturing gossip messages")
+	}
 	// Gossipsub registration is done before we add in any new peers
 	// due to libp2p's gossipsub implementation not taking into
 	// account previously added peers when creating the gossipsub
@@ -205,13 +216,7 @@ func (s *Service) Start() {
 
 	s.started = true
 
-	if len(s.cfg.StaticPeers) > 0 {
-		addrs, err := PeersFromStringAddrs(s.cfg.StaticPeers)
-		if err != nil {
-			log.WithError(err).Error("Could not connect to static peer")
-		}
-		s.connectWithAllPeers(addrs)
-	}
+	s.addConfiguredPeerGroups()
 	// Initialize metadata according to the
 	// current epoch.
 	s.RefreshENR()
@@ -225,6 +230,7 @@ func (s *Service) Start() {
 		ensurePeerConnections(s.ctx, s.host, peersToWatch...)
 	})
 	async.RunEvery(s.ctx, 30*time.Minute, s.Peers().Prune)
+	async.RunEvery(s.ctx, peerGroupReconnectInterval, s.reconnectPeerGroups)
 	async.RunEvery(s.ctx, params.BeaconNetworkConfig().RespTimeout, s.updateMetrics)
 	async.RunEvery(s.ctx, refreshRate, s.RefreshENR)
 	async.RunEvery(s.ctx, 1*time.Minute, func() {
@@ -242,13 +248,13 @@ func (s *Service) Start() {
 	p2pTCPPort := s.cfg.TCPPort
 
 	if p2pHostAddress != "" {
-		logExternalIPAddr(s.host.ID(), p2pHostAddress, p2pTCPPort)
+		logExternalAddrs(s.host.ID(), p2pHostAddress, s.cfg, hostMultiAddrs)
 		verifyConnectivity(p2pHostAddress, p2pTCPPort, "tcp")
 	}
 
 	p2pHostDNS := s.cfg.HostDNS
 	if p2pHostDNS != "" {
-		logExternalDNSAddr(s.host.ID(), p2pHostDNS, p2pTCPPort)
+		logExternalAddrs(s.host.ID(), p2pHostDNS, s.cfg, hostDNSMultiAddrs)
 	}
 	go s.forkWatcher()
 }
@@ -260,6 +266,9 @@ func (s *Service) Stop() error {
 	if s.dv5Listener != nil {
 		s.dv5Listener.Close()
 	}
+	if s.gossipCapture != nil {
+		s.gossipCapture.stop()
+	}
 	return nil
 }
 
@@ -437,11 +446,8 @@ func (s *Service) connectToBootnodes() error {
 		if err != nil {
 			return err
 		}
-		// do not dial bootnodes with their tcp ports not set
-		if err := bootNode.Record().Load(enr.WithEntry("tcp", new(enr.TCP))); err != nil {
-			if !enr.IsNotFound(err) {
-				log.WithError(err).Error("Could not retrieve tcp port")
-			}
+		// do not dial bootnodes with neither their tcp nor their quic ports set
+		if !hasDialablePort(bootNode) {
 			continue
 		}
 		nodes = append(nodes, bootNode)
@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 	ssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 )
 
 const rootLength = 32
@@ -80,6 +81,70 @@ func (r *BeaconBlockByRootsReq) UnmarshalSSZ(buf []byte) error {
 	return nil
 }
 
+// BlobIdentifierSize is the SSZ encoded size of a blob identifier: a block root
+// followed by a uint64 blob index.
+const BlobIdentifierSize = rootLength + 8
+
+// BlobSidecarsByRootReq specifies the blob sidecars by root request type.
+type BlobSidecarsByRootReq []*eth.BlobIdentifier
+
+// MarshalSSZTo marshals the blob sidecars by root request with the provided byte slice.
+func (r *BlobSidecarsByRootReq) MarshalSSZTo(dst []byte) ([]byte, error) {
+	marshalledObj, err := r.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	return append(dst, marshalledObj...), nil
+}
+
+// MarshalSSZ Marshals the blob sidecars by root request type into the serialized object.
+func (r *BlobSidecarsByRootReq) MarshalSSZ() ([]byte, error) {
+	if len(*r) > int(params.BeaconNetworkConfig().MaxRequestBlobSidecars) {
+		return nil, errors.Errorf("blob sidecars by root request exceeds max size: %d > %d", len(*r), params.BeaconNetworkConfig().MaxRequestBlobSidecars)
+	}
+	buf := make([]byte, 0, r.SizeSSZ())
+	for _, id := range *r {
+		if id == nil {
+			return nil, errors.New("nil blob identifier in request")
+		}
+		var err error
+		buf, err = id.MarshalSSZTo(buf)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// SizeSSZ returns the size of the serialized representation.
+func (r *BlobSidecarsByRootReq) SizeSSZ() int {
+	return len(*r) * BlobIdentifierSize
+}
+
+// UnmarshalSSZ unmarshals the provided bytes buffer into the
+// blob sidecars by root request object.
+func (r *BlobSidecarsByRootReq) UnmarshalSSZ(buf []byte) error {
+	bufLen := len(buf)
+	maxLength := int(params.BeaconNetworkConfig().MaxRequestBlobSidecars) * BlobIdentifierSize
+	if bufLen > maxLength {
+		return errors.Errorf("expected buffer with length of upto %d but received length %d", maxLength, bufLen)
+	}
+	if bufLen%BlobIdentifierSize != 0 {
+		return ssz.ErrIncorrectByteSize
+	}
+	count := bufLen / BlobIdentifierSize
+	ids := make([]*eth.BlobIdentifier, count)
+	for i := 0; i < count; i++ {
+		id := &eth.BlobIdentifier{}
+		if err := id.UnmarshalSSZ(buf[i*BlobIdentifierSize : (i+1)*BlobIdentifierSize]); err != nil {
+			return err
+		}
+		ids[i] = id
+	}
+	*r = ids
+	return nil
+}
+
 // ErrorMessage describes the error message type.
 type ErrorMessage []byte
 
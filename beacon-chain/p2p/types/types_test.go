@@ -4,8 +4,11 @@ import (
 	"encoding/hex"
 	"testing"
 
+	ssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 )
@@ -41,9 +44,30 @@ func TestErrorResponse_Limit(t *testing.T) {
 
 func TestRoundTripSerialization(t *testing.T) {
 	roundTripTestBlocksByRootReq(t)
+	roundTripTestBlobSidecarsByRootReq(t)
 	roundTripTestErrorMessage(t)
 }
 
+func TestBlobSidecarsByRootReq_Limit(t *testing.T) {
+	ids := make([]*eth.BlobIdentifier, 0)
+	for i := uint64(0); i < params.BeaconNetworkConfig().MaxRequestBlobSidecars+1; i++ {
+		ids = append(ids, &eth.BlobIdentifier{BlockRoot: bytesutil.PadTo([]byte{byte(i)}, 32), Index: i % 6})
+	}
+	req := BlobSidecarsByRootReq(ids)
+
+	_, err := req.MarshalSSZ()
+	require.ErrorContains(t, "blob sidecars by root request exceeds max size", err)
+
+	buf := make([]byte, 0)
+	for _, id := range ids {
+		buf, err = id.MarshalSSZTo(buf)
+		require.NoError(t, err)
+	}
+	req2 := BlobSidecarsByRootReq(nil)
+	require.ErrorContains(t, "expected buffer with length of upto", req2.UnmarshalSSZ(buf))
+	require.ErrorIs(t, req2.UnmarshalSSZ(buf[:BlobIdentifierSize+1]), ssz.ErrIncorrectByteSize)
+}
+
 func roundTripTestBlocksByRootReq(t *testing.T) {
 	fixedRoots := make([][32]byte, 0)
 	for i := 0; i < 200; i++ {
@@ -59,6 +83,21 @@ func roundTripTestBlocksByRootReq(t *testing.T) {
 	assert.DeepEqual(t, [][32]byte(newVal), fixedRoots)
 }
 
+func roundTripTestBlobSidecarsByRootReq(t *testing.T) {
+	ids := make([]*eth.BlobIdentifier, 0)
+	for i := 0; i < 20; i++ {
+		ids = append(ids, &eth.BlobIdentifier{BlockRoot: bytesutil.PadTo([]byte{byte(i)}, 32), Index: uint64(i % 6)})
+	}
+	req := BlobSidecarsByRootReq(ids)
+
+	marshalledObj, err := req.MarshalSSZ()
+	require.NoError(t, err)
+	newVal := BlobSidecarsByRootReq(nil)
+
+	require.NoError(t, newVal.UnmarshalSSZ(marshalledObj))
+	assert.DeepEqual(t, []*eth.BlobIdentifier(newVal), ids)
+}
+
 func roundTripTestErrorMessage(t *testing.T) {
 	errMsg := []byte{'e', 'r', 'r', 'o', 'r'}
 	sszErr := make(ErrorMessage, len(errMsg))
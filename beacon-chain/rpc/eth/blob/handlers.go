@@ -0,0 +1,137 @@
+package blob
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+)
+
+// Blobs is an HTTP handler for Beacon API getBlobSidecars.
+// Blocks without blob commitments, and blocks whose blobs are outside the retention window, yield an empty list.
+func (s *Server) Blobs(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(r.URL.Path, "/")
+	blockId := segments[len(segments)-1]
+
+	indices, errJson := parseIndices(r)
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+
+	blk, err := s.Blocker.Block(r.Context(), []byte(blockId))
+	if errors.Is(err, lookup.BlockIdParseError{}) {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "invalid block ID").Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get block from block ID").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if err := blocks.BeaconBlockIsNil(blk); err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not find requested block").Error(),
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	if blk.Version() < version.Deneb {
+		network.WriteJson(w, &SidecarsResponse{Data: []*Sidecar{}})
+		return
+	}
+
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not hash block").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	sidecars, err := s.BeaconDB.BlobSidecarsByRoot(r.Context(), root, indices...)
+	if errors.Is(err, db.ErrNotFoundBlobSidecars) {
+		network.WriteJson(w, &SidecarsResponse{Data: []*Sidecar{}})
+		return
+	}
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get blob sidecars").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	network.WriteJson(w, &SidecarsResponse{Data: sidecarsFromConsensus(sidecars)})
+}
+
+// parseIndices reads the optional, repeatable "indices" query parameter.
+// Comma separated values are accepted as well.
+func parseIndices(r *http.Request) ([]uint64, *network.DefaultErrorJson) {
+	var indices []uint64
+	for _, raw := range r.URL.Query()["indices"] {
+		for _, v := range strings.Split(raw, ",") {
+			if v == "" {
+				continue
+			}
+			ix, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return nil, &network.DefaultErrorJson{
+					Message: "invalid blob index: " + v,
+					Code:    http.StatusBadRequest,
+				}
+			}
+			if ix >= fieldparams.MaxBlobsPerBlock {
+				return nil, &network.DefaultErrorJson{
+					Message: "blob index out of range: " + v,
+					Code:    http.StatusBadRequest,
+				}
+			}
+			indices = append(indices, ix)
+		}
+	}
+	return indices, nil
+}
+
+func sidecarsFromConsensus(sidecars []*ethpb.BlobSidecar) []*Sidecar {
+	result := make([]*Sidecar, len(sidecars))
+	for i, sc := range sidecars {
+		proof := make([]string, len(sc.CommitmentInclusionProof))
+		for j, p := range sc.CommitmentInclusionProof {
+			proof[j] = hexutil.Encode(p)
+		}
+		h := sc.SignedBlockHeader
+		result[i] = &Sidecar{
+			Index:         strconv.FormatUint(sc.Index, 10),
+			Blob:          hexutil.Encode(sc.Blob),
+			KzgCommitment: hexutil.Encode(sc.KzgCommitment),
+			KzgProof:      hexutil.Encode(sc.KzgProof),
+			SignedBlockHeader: &SignedBlockHeader{
+				Message: &BeaconBlockHeader{
+					Slot:          strconv.FormatUint(uint64(h.Header.Slot), 10),
+					ProposerIndex: strconv.FormatUint(uint64(h.Header.ProposerIndex), 10),
+					ParentRoot:    hexutil.Encode(h.Header.ParentRoot),
+					StateRoot:     hexutil.Encode(h.Header.StateRoot),
+					BodyRoot:      hexutil.Encode(h.Header.BodyRoot),
+				},
+				Signature: hexutil.Encode(h.Signature),
+			},
+			CommitmentInclusionProof: proof,
+		}
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestBlobs(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.SetupDB(t)
+
+	b := util.NewBeaconBlockDeneb()
+	b.Block.Slot = 123
+	b.Block.Body.BlobKzgCommitments = [][]byte{bytes.Repeat([]byte{1}, 48), bytes.Repeat([]byte{2}, 48)}
+	denebBlock, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	blobs := [][]byte{make([]byte, fieldparams.BlobLength), make([]byte, fieldparams.BlobLength)}
+	proofs := [][]byte{bytes.Repeat([]byte{3}, 48), bytes.Repeat([]byte{4}, 48)}
+	sidecars, err := blocks.BuildBlobSidecars(denebBlock, blobs, proofs)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlobSidecars(ctx, sidecars))
+
+	emptyBlock, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockDeneb())
+	require.NoError(t, err)
+	phase0Block, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+
+	query := func(t *testing.T, blk interfaces.ReadOnlySignedBeaconBlock, url string) *httptest.ResponseRecorder {
+		s := &Server{
+			Blocker:  &testutil.MockBlocker{BlockToReturn: blk},
+			BeaconDB: db,
+		}
+		request := httptest.NewRequest(http.MethodGet, url, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.Blobs(writer, request)
+		return writer
+	}
+
+	t.Run("all sidecars", func(t *testing.T) {
+		writer := query(t, denebBlock, "http://foo.example/eth/v1/beacon/blob_sidecars/head")
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &SidecarsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "0", resp.Data[0].Index)
+		assert.Equal(t, "1", resp.Data[1].Index)
+		assert.Equal(t, "123", resp.Data[0].SignedBlockHeader.Message.Slot)
+		assert.Equal(t, fieldparams.KzgCommitmentInclusionProofDepth, len(resp.Data[0].CommitmentInclusionProof))
+	})
+	t.Run("requested indices", func(t *testing.T) {
+		writer := query(t, denebBlock, "http://foo.example/eth/v1/beacon/blob_sidecars/head?indices=1")
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &SidecarsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "1", resp.Data[0].Index)
+	})
+	t.Run("no stored sidecars", func(t *testing.T) {
+		writer := query(t, emptyBlock, "http://foo.example/eth/v1/beacon/blob_sidecars/head")
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &SidecarsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, 0, len(resp.Data))
+	})
+	t.Run("pre-deneb block", func(t *testing.T) {
+		writer := query(t, phase0Block, "http://foo.example/eth/v1/beacon/blob_sidecars/head")
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &SidecarsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, 0, len(resp.Data))
+	})
+	t.Run("invalid index", func(t *testing.T) {
+		writer := query(t, denebBlock, "http://foo.example/eth/v1/beacon/blob_sidecars/head?indices=6")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "blob index out of range", e.Message)
+	})
+}
@@ -0,0 +1,11 @@
+package blob
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
+)
+
+type Server struct {
+	Blocker  lookup.Blocker
+	BeaconDB db.ReadOnlyDatabase
+}
@@ -0,0 +1,27 @@
+package blob
+
+type SidecarsResponse struct {
+	Data []*Sidecar `json:"data"`
+}
+
+type Sidecar struct {
+	Index                    string             `json:"index"`
+	Blob                     string             `json:"blob"`
+	KzgCommitment            string             `json:"kzg_commitment"`
+	KzgProof                 string             `json:"kzg_proof"`
+	SignedBlockHeader        *SignedBlockHeader `json:"signed_block_header"`
+	CommitmentInclusionProof []string           `json:"kzg_commitment_inclusion_proof"`
+}
+
+type SignedBlockHeader struct {
+	Message   *BeaconBlockHeader `json:"message"`
+	Signature string             `json:"signature"`
+}
+
+type BeaconBlockHeader struct {
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
+}
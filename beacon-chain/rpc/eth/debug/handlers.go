@@ -0,0 +1,524 @@
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stateutil"
+	chainSync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	"github.com/prysmaticlabs/prysm/v4/network"
+)
+
+const (
+	dotMediaType = "text/vnd.graphviz"
+	// blockHeaderFieldCount is the number of fields of a block header, and of a block.
+	blockHeaderFieldCount = 5
+	// blockHeaderStateRootIndex is the index of the state root among the fields of a block header.
+	blockHeaderStateRootIndex = 3
+)
+
+// GetForkChoiceDiagnostics is an HTTP handler serving a detailed snapshot of the fork choice store,
+// including proposer boost, unrealized checkpoints and the votes received since the head last changed.
+// The snapshot is rendered as a Graphviz DOT document when the request accepts "text/vnd.graphviz",
+// and as JSON otherwise.
+func (ds *Server) GetForkChoiceDiagnostics(w http.ResponseWriter, r *http.Request) {
+	d, err := ds.ForkchoiceFetcher.ForkChoiceDiagnostics(r.Context())
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get fork choice diagnostics").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	if d == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Fork choice store is not available",
+			Code:    http.StatusServiceUnavailable,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), dotMediaType) {
+		var buf bytes.Buffer
+		if err := d.WriteDOT(&buf); err != nil {
+			errJson := &network.DefaultErrorJson{
+				Message: errors.Wrap(err, "could not render fork choice diagnostics").Error(),
+				Code:    http.StatusInternalServerError,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		w.Header().Set("Content-Type", dotMediaType)
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			log.WithError(err).Error("Could not write response message")
+		}
+		return
+	}
+
+	resp := &ForkChoiceDiagnosticsResponse{
+		JustifiedCheckpoint:           checkpointJson(d.JustifiedCheckpoint),
+		UnrealizedJustifiedCheckpoint: checkpointJson(d.UnrealizedJustifiedCheckpoint),
+		PreviousJustifiedCheckpoint:   checkpointJson(d.PreviousJustifiedCheckpoint),
+		FinalizedCheckpoint:           checkpointJson(d.FinalizedCheckpoint),
+		UnrealizedFinalizedCheckpoint: checkpointJson(d.UnrealizedFinalizedCheckpoint),
+		HeadRoot:                      hexutil.Encode(d.HeadRoot[:]),
+		HeadChangedAtSlot:             fmt.Sprintf("%d", d.HeadChangedAt),
+		ProposerBoostRoot:             hexutil.Encode(d.ProposerBoostRoot[:]),
+		PreviousProposerBoostRoot:     hexutil.Encode(d.PreviousProposerBoostRoot[:]),
+		PreviousProposerBoostScore:    fmt.Sprintf("%d", d.PreviousProposerBoostScore),
+		CommitteeWeight:               fmt.Sprintf("%d", d.CommitteeWeight),
+		GenesisTime:                   fmt.Sprintf("%d", d.GenesisTime),
+		Nodes:                         make([]*ForkChoiceNodeDiagnostics, len(d.Nodes)),
+		VotesSinceHeadChange:          make([]*VoteTally, len(d.VotesSinceHeadChange)),
+	}
+	for i, n := range d.Nodes {
+		resp.Nodes[i] = &ForkChoiceNodeDiagnostics{
+			Slot:                     fmt.Sprintf("%d", n.Slot),
+			BlockRoot:                hexutil.Encode(n.Root[:]),
+			ParentRoot:               hexutil.Encode(n.ParentRoot[:]),
+			ExecutionBlockHash:       hexutil.Encode(n.PayloadHash[:]),
+			BestDescendant:           hexutil.Encode(n.BestDescendant[:]),
+			JustifiedEpoch:           fmt.Sprintf("%d", n.JustifiedEpoch),
+			FinalizedEpoch:           fmt.Sprintf("%d", n.FinalizedEpoch),
+			UnrealizedJustifiedEpoch: fmt.Sprintf("%d", n.UnrealizedJustifiedEpoch),
+			UnrealizedFinalizedEpoch: fmt.Sprintf("%d", n.UnrealizedFinalizedEpoch),
+			Balance:                  fmt.Sprintf("%d", n.Balance),
+			Weight:                   fmt.Sprintf("%d", n.Weight),
+			ExecutionOptimistic:      n.Optimistic,
+			Canonical:                n.Canonical,
+			Timestamp:                fmt.Sprintf("%d", n.Timestamp),
+		}
+	}
+	for i, v := range d.VotesSinceHeadChange {
+		resp.VotesSinceHeadChange[i] = &VoteTally{
+			BlockRoot: hexutil.Encode(v.Root[:]),
+			Count:     fmt.Sprintf("%d", v.Count),
+			Balance:   fmt.Sprintf("%d", v.Balance),
+		}
+	}
+	network.WriteJson(w, resp)
+}
+
+func checkpointJson(cp *forkchoicetypes.Checkpoint) *Checkpoint {
+	if cp == nil {
+		return nil
+	}
+	return &Checkpoint{
+		Epoch: fmt.Sprintf("%d", cp.Epoch),
+		Root:  hexutil.Encode(cp.Root[:]),
+	}
+}
+
+// GetStateFieldProof is an HTTP handler serving a Merkle proof of the chunk of the requested state at
+// the field path given by the "path" query parameter, such as "validators.5.effective_balance" or
+// "balances.5". The proof verifies against the hash tree root of the state.
+func (ds *Server) GetStateFieldProof(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(r.URL.Path, "/")
+	stateId := segments[len(segments)-2]
+	path := r.URL.Query().Get("path")
+	if path == "" {
+		errJson := &network.DefaultErrorJson{
+			Message: "path is required in URL params",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	id, err := decodeId(stateId)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "invalid state ID").Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	st, err := ds.Stater.State(r.Context(), id)
+	if err != nil {
+		network.WriteError(w, handleGetStateError(err))
+		return
+	}
+	root, err := st.HashTreeRoot(r.Context())
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not compute state root").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	proof, err := st.FieldProof(r.Context(), path)
+	if err != nil {
+		network.WriteError(w, handleFieldProofError(err))
+		return
+	}
+	network.WriteJson(w, fieldProofResponse(path, root, proof))
+}
+
+// GetBlockFieldProof is an HTTP handler serving a Merkle proof of the chunk of the post-state of the
+// requested block at the field path given by the "path" query parameter. The proof verifies against
+// the root of the block, which is the parent_beacon_block_root of the next execution payload.
+func (ds *Server) GetBlockFieldProof(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(r.URL.Path, "/")
+	blockId := segments[len(segments)-2]
+	path := r.URL.Query().Get("path")
+	if path == "" {
+		errJson := &network.DefaultErrorJson{
+			Message: "path is required in URL params",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	id, err := decodeId(blockId)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "invalid block ID").Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	blk, err := ds.Blocker.Block(r.Context(), id)
+	if errJson := handleGetBlockError(blk, err); errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	header, err := blk.Header()
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get block header").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	stateRoot := bytesutil.ToBytes32(header.Header.StateRoot)
+	st, err := ds.Stater.State(r.Context(), stateRoot[:])
+	if err != nil {
+		network.WriteError(w, handleGetStateError(err))
+		return
+	}
+	proof, err := st.FieldProof(r.Context(), path)
+	if err != nil {
+		network.WriteError(w, handleFieldProofError(err))
+		return
+	}
+	// The root of the block is the root of its header, in which the state root is the fourth field.
+	stateRootProof, err := ssz.VectorProof(stateutil.BlockHeaderFieldRoots(header.Header), blockHeaderFieldCount, blockHeaderStateRootIndex)
+	if err == nil {
+		proof, err = proof.Nest(stateRootProof.GeneralizedIndex, stateRootProof.Branch)
+	}
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not prove state root within block").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not compute block root").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	if !proof.Verify(root) {
+		errJson := &network.DefaultErrorJson{
+			Message: "state of the block does not match its state root",
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	network.WriteJson(w, fieldProofResponse(path, root, proof))
+}
+
+func fieldProofResponse(path string, root [32]byte, proof *ssz.Proof) *FieldProofResponse {
+	resp := &FieldProofResponse{
+		Path:             path,
+		Root:             hexutil.Encode(root[:]),
+		GeneralizedIndex: fmt.Sprintf("%d", proof.GeneralizedIndex),
+		Leaf:             hexutil.Encode(proof.Leaf[:]),
+		Branch:           make([]string, len(proof.Branch)),
+	}
+	for i, node := range proof.Branch {
+		resp.Branch[i] = hexutil.Encode(node[:])
+	}
+	return resp
+}
+
+// decodeId decodes a state or block ID, which is a 0x-prefixed hex encoded root or a named or numeric ID.
+func decodeId(id string) ([]byte, error) {
+	if strings.HasPrefix(id, "0x") {
+		return hexutil.Decode(id)
+	}
+	return []byte(id), nil
+}
+
+func handleGetStateError(err error) *network.DefaultErrorJson {
+	if errors.Is(err, stategen.ErrNoDataForSlot) {
+		return &network.DefaultErrorJson{
+			Message: "lacking historical data needed to fulfill request",
+			Code:    http.StatusNotFound,
+		}
+	}
+	var notFoundErr *lookup.StateNotFoundError
+	if errors.As(err, &notFoundErr) {
+		return &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "state not found").Error(),
+			Code:    http.StatusNotFound,
+		}
+	}
+	var parseErr *lookup.StateIdParseError
+	if errors.As(err, &parseErr) {
+		return &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "invalid state ID").Error(),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return &network.DefaultErrorJson{
+		Message: errors.Wrap(err, "could not get state").Error(),
+		Code:    http.StatusInternalServerError,
+	}
+}
+
+func handleGetBlockError(blk interfaces.ReadOnlySignedBeaconBlock, err error) *network.DefaultErrorJson {
+	var parseErr *lookup.BlockIdParseError
+	if errors.As(err, &parseErr) {
+		return &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "invalid block ID").Error(),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if err != nil {
+		return &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get block from block ID").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+	}
+	if err := blocks.BeaconBlockIsNil(blk); err != nil {
+		return &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not find requested block").Error(),
+			Code:    http.StatusNotFound,
+		}
+	}
+	return nil
+}
+
+func handleFieldProofError(err error) *network.DefaultErrorJson {
+	if errors.Is(err, state.ErrInvalidProofPath) {
+		return &network.DefaultErrorJson{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return &network.DefaultErrorJson{
+		Message: errors.Wrap(err, "could not compute proof").Error(),
+		Code:    http.StatusInternalServerError,
+	}
+}
+
+// GetRateLimits is an HTTP handler serving the req/resp rate limiting quotas of each protocol and tier
+// of peers, along with the fill levels of the buckets of the peers and the number of their requests
+// which were rejected.
+func (ds *Server) GetRateLimits(w http.ResponseWriter, _ *http.Request) {
+	if ds.RateLimitManager == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Rate limiter is not available",
+			Code:    http.StatusServiceUnavailable,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	network.WriteJson(w, ds.rateLimitsResponse())
+}
+
+// SetRateLimitQuota is an HTTP handler changing the req/resp rate limiting quota of the protocol and
+// tier of peers given in the request body. The tier defaults to the tier of peers which are not
+// trusted. It returns the updated rate limits.
+func (ds *Server) SetRateLimitQuota(w http.ResponseWriter, r *http.Request) {
+	if ds.RateLimitManager == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Rate limiter is not available",
+			Code:    http.StatusServiceUnavailable,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	var req SetRateLimitQuotaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Could not decode request body: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	rate, err := strconv.ParseFloat(req.Rate, 64)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "invalid rate").Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	burst, err := strconv.ParseInt(req.Burst, 10, 64)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "invalid burst").Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	tier := chainSync.RateLimitTier(req.Tier)
+	if tier == "" {
+		tier = chainSync.RateLimitTierDefault
+	}
+	if err := ds.RateLimitManager.SetRateLimitQuota(req.Protocol, tier, rate, burst); err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not set rate limit quota").Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	network.WriteJson(w, ds.rateLimitsResponse())
+}
+
+func (ds *Server) rateLimitsResponse() *RateLimitsResponse {
+	policies := ds.RateLimitManager.RateLimitPolicies()
+	resp := &RateLimitsResponse{
+		Policies: make([]*RateLimitPolicy, 0, len(policies)),
+		Peers:    []*PeerRateLimits{},
+	}
+	for _, p := range policies {
+		policy := &RateLimitPolicy{Protocol: p.Protocol, SharedWith: p.SharedWith, Quotas: []*RateLimitQuota{}}
+		if policy.SharedWith == nil {
+			policy.SharedWith = []string{}
+		}
+		for _, tier := range []chainSync.RateLimitTier{chainSync.RateLimitTierDefault, chainSync.RateLimitTierTrusted} {
+			q, ok := p.Quotas[tier]
+			if !ok {
+				continue
+			}
+			policy.Quotas = append(policy.Quotas, &RateLimitQuota{
+				Tier:          string(tier),
+				Rate:          strconv.FormatFloat(q.Rate, 'f', -1, 64),
+				Burst:         strconv.FormatInt(q.Burst, 10),
+				PeriodSeconds: strconv.FormatFloat(q.Period.Seconds(), 'f', -1, 64),
+			})
+		}
+		resp.Policies = append(resp.Policies, policy)
+	}
+	for _, p := range ds.RateLimitManager.PeerRateLimits() {
+		peerLimits := &PeerRateLimits{
+			PeerId:    p.PeerID.String(),
+			Tier:      string(p.Tier),
+			Protocols: make([]*ProtocolRateLimit, 0, len(p.Protocols)),
+		}
+		for _, pl := range p.Protocols {
+			peerLimits.Protocols = append(peerLimits.Protocols, &ProtocolRateLimit{
+				Protocol:   pl.Protocol,
+				Count:      strconv.FormatInt(pl.Count, 10),
+				Capacity:   strconv.FormatInt(pl.Capacity, 10),
+				Rejections: strconv.FormatUint(pl.Rejections, 10),
+			})
+		}
+		resp.Peers = append(resp.Peers, peerLimits)
+	}
+	return resp
+}
+
+// ReplayGossipMessage is an HTTP handler running the gossip message given in the request body, such as a
+// message of a gossip capture, through the gossip validation pipeline of the node. The message is neither
+// processed further nor forwarded to peers. It returns the validation result, and the error explaining it.
+// The node must run with --enable-gossip-replay, as replayed messages alter its state like gossip does.
+func (ds *Server) ReplayGossipMessage(w http.ResponseWriter, r *http.Request) {
+	if ds.GossipReplayer == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Gossip replay is not available",
+			Code:    http.StatusServiceUnavailable,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	var req ReplayGossipMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Could not decode request body: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	data, err := hexutil.Decode(req.Data)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "invalid data").Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	var pid peer.ID
+	if req.PeerId != "" {
+		pid, err = peer.Decode(req.PeerId)
+		if err != nil {
+			errJson := &network.DefaultErrorJson{
+				Message: errors.Wrap(err, "invalid peer ID").Error(),
+				Code:    http.StatusBadRequest,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+	}
+	res, err := ds.GossipReplayer.ReplayGossipMessage(r.Context(), req.Topic, data, pid)
+	if errors.Is(err, chainSync.ErrGossipReplayDisabled) {
+		errJson := &network.DefaultErrorJson{
+			Message: "Gossip replay is disabled, run the node with --enable-gossip-replay",
+			Code:    http.StatusServiceUnavailable,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	if errors.Is(err, chainSync.ErrUnknownGossipTopic) || errors.Is(err, chainSync.ErrChainNotStarted) {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not replay gossip message").Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	resp := &ReplayGossipMessageResponse{Result: p2p.GossipValidationResult(res)}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	network.WriteJson(w, resp)
+}
@@ -0,0 +1,402 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	blockchainmock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/doubly-linked-tree"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
+	chainSync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestGetForkChoiceDiagnostics(t *testing.T) {
+	store := doublylinkedtree.New()
+	fRoot := [32]byte{'a'}
+	require.NoError(t, store.UpdateFinalizedCheckpoint(&forkchoicetypes.Checkpoint{Epoch: 2, Root: fRoot}))
+	s := &Server{ForkchoiceFetcher: &blockchainmock.ChainService{ForkChoiceStore: store}}
+
+	t.Run("json", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/fork_choice", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetForkChoiceDiagnostics(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ForkChoiceDiagnosticsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.NotNil(t, resp.FinalizedCheckpoint)
+		assert.Equal(t, "2", resp.FinalizedCheckpoint.Epoch)
+		assert.Equal(t, "0x6100000000000000000000000000000000000000000000000000000000000000", resp.FinalizedCheckpoint.Root)
+		assert.Equal(t, 0, len(resp.Nodes))
+	})
+	t.Run("dot", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/fork_choice", nil)
+		request.Header.Set("Accept", "text/vnd.graphviz")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetForkChoiceDiagnostics(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "text/vnd.graphviz", writer.Header().Get("Content-Type"))
+		assert.StringContains(t, "digraph forkchoice {", writer.Body.String())
+	})
+	t.Run("no fork choice store", func(t *testing.T) {
+		s := &Server{ForkchoiceFetcher: &blockchainmock.ChainService{}}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/fork_choice", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetForkChoiceDiagnostics(writer, request)
+		require.Equal(t, http.StatusServiceUnavailable, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusServiceUnavailable, e.Code)
+	})
+}
+
+func TestGetStateFieldProof(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateDeneb(t, 16)
+	stateRoot, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	s := &Server{Stater: &testutil.MockStater{BeaconState: st}}
+
+	t.Run("ok", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/beacon/states/head/proof?path=balances.5", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetStateFieldProof(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &FieldProofResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "balances.5", resp.Path)
+		assert.Equal(t, hexutil.Encode(stateRoot[:]), resp.Root)
+		verifyFieldProof(t, stateRoot, resp)
+	})
+	t.Run("no path", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/beacon/states/head/proof", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetStateFieldProof(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("invalid path", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/beacon/states/head/proof?path=validators.100", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetStateFieldProof(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "out of range", e.Message)
+	})
+}
+
+func TestGetBlockFieldProof(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateDeneb(t, 16)
+	stateRoot, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	b := util.NewBeaconBlockDeneb()
+	b.Block.Slot = 1
+	b.Block.StateRoot = stateRoot[:]
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	blockRoot, err := blk.Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	t.Run("ok", func(t *testing.T) {
+		s := &Server{Stater: &testutil.MockStater{BeaconState: st}, Blocker: &testutil.MockBlocker{BlockToReturn: blk}}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/beacon/blocks/head/proof?path=validators.3.effective_balance", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBlockFieldProof(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &FieldProofResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, hexutil.Encode(blockRoot[:]), resp.Root)
+		verifyFieldProof(t, blockRoot, resp)
+	})
+	t.Run("state mismatch", func(t *testing.T) {
+		other, _ := util.DeterministicGenesisStateDeneb(t, 8)
+		s := &Server{Stater: &testutil.MockStater{BeaconState: other}, Blocker: &testutil.MockBlocker{BlockToReturn: blk}}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/beacon/blocks/head/proof?path=slot", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBlockFieldProof(writer, request)
+		require.Equal(t, http.StatusInternalServerError, writer.Code)
+	})
+	t.Run("block not found", func(t *testing.T) {
+		s := &Server{Stater: &testutil.MockStater{BeaconState: st}, Blocker: &testutil.MockBlocker{}}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/beacon/blocks/head/proof?path=slot", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBlockFieldProof(writer, request)
+		require.Equal(t, http.StatusNotFound, writer.Code)
+	})
+}
+
+func verifyFieldProof(t *testing.T, root [32]byte, resp *FieldProofResponse) {
+	gindex, err := strconv.ParseUint(resp.GeneralizedIndex, 10, 64)
+	require.NoError(t, err)
+	leaf, err := hexutil.Decode(resp.Leaf)
+	require.NoError(t, err)
+	branch := make([][32]byte, len(resp.Branch))
+	for i, node := range resp.Branch {
+		b, err := hexutil.Decode(node)
+		require.NoError(t, err)
+		branch[i] = bytesutil.ToBytes32(b)
+	}
+	assert.Equal(t, true, ssz.VerifyMerkleBranch(root, bytesutil.ToBytes32(leaf), gindex, branch))
+}
+
+type mockRateLimitManager struct {
+	policies []*chainSync.RateLimitPolicy
+	peers    []*chainSync.PeerRateLimits
+}
+
+func (m *mockRateLimitManager) RateLimitPolicies() []*chainSync.RateLimitPolicy {
+	return m.policies
+}
+
+func (m *mockRateLimitManager) SetRateLimitQuota(protocol string, tier chainSync.RateLimitTier, rate float64, burst int64) error {
+	if tier != chainSync.RateLimitTierDefault && tier != chainSync.RateLimitTierTrusted {
+		return chainSync.ErrUnknownRateLimitTier
+	}
+	for _, p := range m.policies {
+		if p.Protocol == protocol {
+			q := p.Quotas[tier]
+			q.Rate, q.Burst = rate, burst
+			p.Quotas[tier] = q
+			return nil
+		}
+	}
+	return chainSync.ErrUnknownRateLimitProtocol
+}
+
+func (m *mockRateLimitManager) PeerRateLimits() []*chainSync.PeerRateLimits {
+	return m.peers
+}
+
+func newMockRateLimitManager(t *testing.T) *mockRateLimitManager {
+	pid, err := peer.Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	require.NoError(t, err)
+	return &mockRateLimitManager{
+		policies: []*chainSync.RateLimitPolicy{{
+			Protocol:   "/eth2/beacon_chain/req/ping/1/ssz_snappy",
+			SharedWith: nil,
+			Quotas: map[chainSync.RateLimitTier]chainSync.RateLimitQuota{
+				chainSync.RateLimitTierDefault: {Rate: 1, Burst: 5, Period: time.Second},
+				chainSync.RateLimitTierTrusted: {Rate: 4, Burst: 20, Period: time.Second},
+			},
+		}},
+		peers: []*chainSync.PeerRateLimits{{
+			PeerID: pid,
+			Tier:   chainSync.RateLimitTierTrusted,
+			Protocols: []*chainSync.ProtocolRateLimit{{
+				Protocol:   "/eth2/beacon_chain/req/ping/1/ssz_snappy",
+				Count:      3,
+				Capacity:   20,
+				Rejections: 2,
+			}},
+		}},
+	}
+}
+
+func TestGetRateLimits(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		s := &Server{RateLimitManager: newMockRateLimitManager(t)}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/rate_limits", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetRateLimits(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &RateLimitsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Policies))
+		assert.Equal(t, "/eth2/beacon_chain/req/ping/1/ssz_snappy", resp.Policies[0].Protocol)
+		assert.DeepEqual(t, []*RateLimitQuota{
+			{Tier: "default", Rate: "1", Burst: "5", PeriodSeconds: "1"},
+			{Tier: "trusted", Rate: "4", Burst: "20", PeriodSeconds: "1"},
+		}, resp.Policies[0].Quotas)
+		require.Equal(t, 1, len(resp.Peers))
+		assert.Equal(t, "16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR", resp.Peers[0].PeerId)
+		assert.Equal(t, "trusted", resp.Peers[0].Tier)
+		assert.DeepEqual(t, []*ProtocolRateLimit{{
+			Protocol:   "/eth2/beacon_chain/req/ping/1/ssz_snappy",
+			Count:      "3",
+			Capacity:   "20",
+			Rejections: "2",
+		}}, resp.Peers[0].Protocols)
+	})
+	t.Run("not available", func(t *testing.T) {
+		s := &Server{}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/rate_limits", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetRateLimits(writer, request)
+		require.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	})
+}
+
+func TestSetRateLimitQuota(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		s := &Server{RateLimitManager: newMockRateLimitManager(t)}
+		body := `{"protocol":"/eth2/beacon_chain/req/ping/1/ssz_snappy","tier":"trusted","rate":"2.5","burst":"10"}`
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/debug/rate_limits/quotas", strings.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SetRateLimitQuota(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &RateLimitsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Policies))
+		assert.DeepEqual(t, &RateLimitQuota{Tier: "trusted", Rate: "2.5", Burst: "10", PeriodSeconds: "1"}, resp.Policies[0].Quotas[1])
+	})
+	t.Run("default tier", func(t *testing.T) {
+		s := &Server{RateLimitManager: newMockRateLimitManager(t)}
+		body := `{"protocol":"/eth2/beacon_chain/req/ping/1/ssz_snappy","rate":"3","burst":"6"}`
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/debug/rate_limits/quotas", strings.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SetRateLimitQuota(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &RateLimitsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.DeepEqual(t, &RateLimitQuota{Tier: "default", Rate: "3", Burst: "6", PeriodSeconds: "1"}, resp.Policies[0].Quotas[0])
+	})
+	t.Run("unknown protocol", func(t *testing.T) {
+		s := &Server{RateLimitManager: newMockRateLimitManager(t)}
+		body := `{"protocol":"/unknown","rate":"3","burst":"6"}`
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/debug/rate_limits/quotas", strings.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SetRateLimitQuota(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "unknown rate limit protocol", e.Message)
+	})
+	t.Run("invalid burst", func(t *testing.T) {
+		s := &Server{RateLimitManager: newMockRateLimitManager(t)}
+		body := `{"protocol":"/eth2/beacon_chain/req/ping/1/ssz_snappy","rate":"3","burst":"foo"}`
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/debug/rate_limits/quotas", strings.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SetRateLimitQuota(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
+type mockGossipReplayer struct {
+	disabled bool
+	topic    string
+	data     []byte
+	pid      peer.ID
+}
+
+func (m *mockGossipReplayer) ReplayGossipMessage(_ context.Context, topic string, data []byte, pid peer.ID) (pubsub.ValidationResult, error) {
+	if m.disabled {
+		return pubsub.ValidationIgnore, chainSync.ErrGossipReplayDisabled
+	}
+	if topic != "/eth2/01020304/beacon_block/ssz_snappy" {
+		return pubsub.ValidationIgnore, chainSync.ErrUnknownGossipTopic
+	}
+	m.topic, m.data, m.pid = topic, data, pid
+	return pubsub.ValidationReject, errors.New("invalid signature")
+}
+
+func TestReplayGossipMessage(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		replayer := &mockGossipReplayer{}
+		s := &Server{GossipReplayer: replayer}
+		body := `{"topic":"/eth2/01020304/beacon_block/ssz_snappy","peer_id":"16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR","data":"0x0102"}`
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/debug/gossip/replay", strings.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.ReplayGossipMessage(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ReplayGossipMessageResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "reject", resp.Result)
+		assert.Equal(t, "invalid signature", resp.Error)
+		assert.DeepEqual(t, []byte{1, 2}, replayer.data)
+		assert.Equal(t, "16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR", replayer.pid.String())
+	})
+	t.Run("unknown topic", func(t *testing.T) {
+		s := &Server{GossipReplayer: &mockGossipReplayer{}}
+		body := `{"topic":"/eth2/01020304/unknown/ssz_snappy","data":"0x0102"}`
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/debug/gossip/replay", strings.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.ReplayGossipMessage(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "no validator registered for gossip topic", e.Message)
+	})
+	t.Run("invalid data", func(t *testing.T) {
+		s := &Server{GossipReplayer: &mockGossipReplayer{}}
+		body := `{"topic":"/eth2/01020304/beacon_block/ssz_snappy","data":"foo"}`
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/debug/gossip/replay", strings.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.ReplayGossipMessage(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("not available", func(t *testing.T) {
+		s := &Server{}
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/debug/gossip/replay", strings.NewReader("{}"))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.ReplayGossipMessage(writer, request)
+		require.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		s := &Server{GossipReplayer: &mockGossipReplayer{disabled: true}}
+		body := `{"topic":"/eth2/01020304/beacon_block/ssz_snappy","data":"0x0102"}`
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/debug/gossip/replay", strings.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.ReplayGossipMessage(writer, request)
+		require.Equal(t, http.StatusServiceUnavailable, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "--enable-gossip-replay", e.Message)
+	})
+}
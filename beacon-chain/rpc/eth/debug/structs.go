@@ -0,0 +1,103 @@
+package debug
+
+type ForkChoiceDiagnosticsResponse struct {
+	JustifiedCheckpoint           *Checkpoint                  `json:"justified_checkpoint"`
+	UnrealizedJustifiedCheckpoint *Checkpoint                  `json:"unrealized_justified_checkpoint"`
+	PreviousJustifiedCheckpoint   *Checkpoint                  `json:"previous_justified_checkpoint"`
+	FinalizedCheckpoint           *Checkpoint                  `json:"finalized_checkpoint"`
+	UnrealizedFinalizedCheckpoint *Checkpoint                  `json:"unrealized_finalized_checkpoint"`
+	HeadRoot                      string                       `json:"head_root"`
+	HeadChangedAtSlot             string                       `json:"head_changed_at_slot"`
+	ProposerBoostRoot             string                       `json:"proposer_boost_root"`
+	PreviousProposerBoostRoot     string                       `json:"previous_proposer_boost_root"`
+	PreviousProposerBoostScore    string                       `json:"previous_proposer_boost_score"`
+	CommitteeWeight               string                       `json:"committee_weight"`
+	GenesisTime                   string                       `json:"genesis_time"`
+	Nodes                         []*ForkChoiceNodeDiagnostics `json:"nodes"`
+	VotesSinceHeadChange          []*VoteTally                 `json:"votes_since_head_change"`
+}
+
+type Checkpoint struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+type ForkChoiceNodeDiagnostics struct {
+	Slot                     string `json:"slot"`
+	BlockRoot                string `json:"block_root"`
+	ParentRoot               string `json:"parent_root"`
+	ExecutionBlockHash       string `json:"execution_block_hash"`
+	BestDescendant           string `json:"best_descendant"`
+	JustifiedEpoch           string `json:"justified_epoch"`
+	FinalizedEpoch           string `json:"finalized_epoch"`
+	UnrealizedJustifiedEpoch string `json:"unrealized_justified_epoch"`
+	UnrealizedFinalizedEpoch string `json:"unrealized_finalized_epoch"`
+	Balance                  string `json:"balance"`
+	Weight                   string `json:"weight"`
+	ExecutionOptimistic      bool   `json:"execution_optimistic"`
+	Canonical                bool   `json:"canonical"`
+	Timestamp                string `json:"timestamp"`
+}
+
+type VoteTally struct {
+	BlockRoot string `json:"block_root"`
+	Count     string `json:"count"`
+	Balance   string `json:"balance"`
+}
+
+type FieldProofResponse struct {
+	Path             string   `json:"path"`
+	Root             string   `json:"root"`
+	GeneralizedIndex string   `json:"gindex"`
+	Leaf             string   `json:"leaf"`
+	Branch           []string `json:"branch"`
+}
+
+type RateLimitsResponse struct {
+	Policies []*RateLimitPolicy `json:"policies"`
+	Peers    []*PeerRateLimits  `json:"peers"`
+}
+
+type RateLimitPolicy struct {
+	Protocol   string            `json:"protocol"`
+	SharedWith []string          `json:"shared_with"`
+	Quotas     []*RateLimitQuota `json:"quotas"`
+}
+
+type RateLimitQuota struct {
+	Tier          string `json:"tier"`
+	Rate          string `json:"rate"`
+	Burst         string `json:"burst"`
+	PeriodSeconds string `json:"period_seconds"`
+}
+
+type PeerRateLimits struct {
+	PeerId    string               `json:"peer_id"`
+	Tier      string               `json:"tier"`
+	Protocols []*ProtocolRateLimit `json:"protocols"`
+}
+
+type ProtocolRateLimit struct {
+	Protocol   string `json:"protocol"`
+	Count      string `json:"count"`
+	Capacity   string `json:"capacity"`
+	Rejections string `json:"rejections"`
+}
+
+type SetRateLimitQuotaRequest struct {
+	Protocol string `json:"protocol"`
+	Tier     string `json:"tier"`
+	Rate     string `json:"rate"`
+	Burst    string `json:"burst"`
+}
+
+type ReplayGossipMessageRequest struct {
+	Topic  string `json:"topic"`
+	PeerId string `json:"peer_id"`
+	Data   string `json:"data"`
+}
+
+type ReplayGossipMessageResponse struct {
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
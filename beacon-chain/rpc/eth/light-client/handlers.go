@@ -0,0 +1,304 @@
+package lightclient
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// GetLightClientBootstrap is an HTTP handler for Beacon API getLightClientBootstrap.
+func (s *Server) GetLightClientBootstrap(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(r.URL.Path, "/")
+	rawRoot := segments[len(segments)-1]
+	blockRoot, err := hexutil.Decode(rawRoot)
+	if err != nil || len(blockRoot) != fieldparams.RootLength {
+		errJson := &network.DefaultErrorJson{
+			Message: "invalid block root: " + rawRoot,
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	bootstrap, err := s.LightClientFetcher.LightClientBootstrap(r.Context(), bytesutil.ToBytes32(blockRoot))
+	if err != nil && !errors.Is(err, blockchain.ErrLightClientBootstrapUnavailable) {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get light client bootstrap").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	if bootstrap == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "light client bootstrap is not available for block root " + rawRoot,
+			Code:    http.StatusNotFound,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	network.WriteJson(w, &LightClientBootstrapResponse{
+		Version: versionAtSlot(bootstrap.Header().Beacon().Slot),
+		Data: &LightClientBootstrap{
+			Header:                     headerFromConsensus(bootstrap.Header()),
+			CurrentSyncCommittee:       syncCommitteeFromConsensus(bootstrap.CurrentSyncCommittee()),
+			CurrentSyncCommitteeBranch: branchFromConsensus(bootstrap.CurrentSyncCommitteeBranch()),
+		},
+	})
+}
+
+// GetLightClientUpdatesByRange is an HTTP handler for Beacon API getLightClientUpdatesByRange.
+// Requests for more than blockchain.MaxRequestLightClientUpdates updates are served up to that limit.
+func (s *Server) GetLightClientUpdatesByRange(w http.ResponseWriter, r *http.Request) {
+	startPeriod, ok := uintQueryParam(w, r, "start_period")
+	if !ok {
+		return
+	}
+	count, ok := uintQueryParam(w, r, "count")
+	if !ok {
+		return
+	}
+	if count == 0 {
+		errJson := &network.DefaultErrorJson{
+			Message: "count must be greater than 0",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	if count > blockchain.MaxRequestLightClientUpdates {
+		count = blockchain.MaxRequestLightClientUpdates
+	}
+
+	updates, err := s.LightClientFetcher.LightClientUpdatesByRange(r.Context(), startPeriod, count)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get light client updates").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	resp := make([]*LightClientUpdateResponse, len(updates))
+	for i, u := range updates {
+		resp[i] = &LightClientUpdateResponse{
+			Version: versionAtSlot(u.AttestedHeader().Beacon().Slot),
+			Data: &LightClientUpdate{
+				AttestedHeader:          headerFromConsensus(u.AttestedHeader()),
+				NextSyncCommittee:       syncCommitteeFromConsensus(u.NextSyncCommittee()),
+				NextSyncCommitteeBranch: branchFromConsensus(u.NextSyncCommitteeBranch()),
+				FinalizedHeader:         headerFromConsensus(u.FinalizedHeader()),
+				FinalityBranch:          branchFromConsensus(u.FinalityBranch()),
+				SyncAggregate:           syncAggregateFromConsensus(u.SyncAggregate()),
+				SignatureSlot:           strconv.FormatUint(uint64(u.SignatureSlot()), 10),
+			},
+		}
+	}
+	network.WriteJson(w, resp)
+}
+
+// GetLightClientFinalityUpdate is an HTTP handler for Beacon API getLightClientFinalityUpdate.
+func (s *Server) GetLightClientFinalityUpdate(w http.ResponseWriter, _ *http.Request) {
+	update := s.LightClientFetcher.LightClientFinalityUpdate()
+	if update == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "no light client finality update is available",
+			Code:    http.StatusNotFound,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	network.WriteJson(w, &LightClientFinalityUpdateResponse{
+		Version: versionAtSlot(update.AttestedHeader().Beacon().Slot),
+		Data: &LightClientFinalityUpdate{
+			AttestedHeader:  headerFromConsensus(update.AttestedHeader()),
+			FinalizedHeader: headerFromConsensus(update.FinalizedHeader()),
+			FinalityBranch:  branchFromConsensus(update.FinalityBranch()),
+			SyncAggregate:   syncAggregateFromConsensus(update.SyncAggregate()),
+			SignatureSlot:   strconv.FormatUint(uint64(update.SignatureSlot()), 10),
+		},
+	})
+}
+
+// GetLightClientOptimisticUpdate is an HTTP handler for Beacon API getLightClientOptimisticUpdate.
+func (s *Server) GetLightClientOptimisticUpdate(w http.ResponseWriter, _ *http.Request) {
+	update := s.LightClientFetcher.LightClientOptimisticUpdate()
+	if update == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "no light client optimistic update is available",
+			Code:    http.StatusNotFound,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	network.WriteJson(w, &LightClientOptimisticUpdateResponse{
+		Version: versionAtSlot(update.AttestedHeader().Beacon().Slot),
+		Data: &LightClientOptimisticUpdate{
+			AttestedHeader: headerFromConsensus(update.AttestedHeader()),
+			SyncAggregate:  syncAggregateFromConsensus(update.SyncAggregate()),
+			SignatureSlot:  strconv.FormatUint(uint64(update.SignatureSlot()), 10),
+		},
+	})
+}
+
+func uintQueryParam(w http.ResponseWriter, r *http.Request, name string) (uint64, bool) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		errJson := &network.DefaultErrorJson{
+			Message: name + " is required",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return 0, false
+	}
+	v, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "invalid " + name + ": " + raw,
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return 0, false
+	}
+	return v, true
+}
+
+// versionAtSlot returns the name of the fork active at the given slot.
+func versionAtSlot(slot primitives.Slot) string {
+	epoch := slots.ToEpoch(slot)
+	cfg := params.BeaconConfig()
+	switch {
+	case epoch >= cfg.DenebForkEpoch:
+		return version.String(version.Deneb)
+	case epoch >= cfg.CapellaForkEpoch:
+		return version.String(version.Capella)
+	case epoch >= cfg.BellatrixForkEpoch:
+		return version.String(version.Bellatrix)
+	case epoch >= cfg.AltairForkEpoch:
+		return version.String(version.Altair)
+	default:
+		return version.String(version.Phase0)
+	}
+}
+
+// headerFromConsensus converts a light client header, including the execution payload header and its branch
+// for Capella and later headers.
+func headerFromConsensus(h interfaces.LightClientHeader) *LightClientHeader {
+	if h == nil || h.Beacon() == nil {
+		return nil
+	}
+	beacon := h.Beacon()
+	header := &LightClientHeader{
+		Beacon: &BeaconBlockHeader{
+			Slot:          strconv.FormatUint(uint64(beacon.Slot), 10),
+			ProposerIndex: strconv.FormatUint(uint64(beacon.ProposerIndex), 10),
+			ParentRoot:    hexutil.Encode(beacon.ParentRoot),
+			StateRoot:     hexutil.Encode(beacon.StateRoot),
+			BodyRoot:      hexutil.Encode(beacon.BodyRoot),
+		},
+	}
+	if h.Version() < version.Capella {
+		return header
+	}
+	execution, err := h.Execution()
+	if err != nil {
+		return header
+	}
+	branch, err := h.ExecutionBranch()
+	if err != nil {
+		return header
+	}
+	header.Execution = executionHeaderFromConsensus(execution, h.Version())
+	header.ExecutionBranch = branchFromConsensus(branch)
+	return header
+}
+
+func executionHeaderFromConsensus(e interfaces.ExecutionData, v int) *ExecutionPayloadHeader {
+	txRoot, err := e.TransactionsRoot()
+	if err != nil {
+		return nil
+	}
+	withdrawalsRoot, err := e.WithdrawalsRoot()
+	if err != nil {
+		return nil
+	}
+	header := &ExecutionPayloadHeader{
+		ParentHash:       hexutil.Encode(e.ParentHash()),
+		FeeRecipient:     hexutil.Encode(e.FeeRecipient()),
+		StateRoot:        hexutil.Encode(e.StateRoot()),
+		ReceiptsRoot:     hexutil.Encode(e.ReceiptsRoot()),
+		LogsBloom:        hexutil.Encode(e.LogsBloom()),
+		PrevRandao:       hexutil.Encode(e.PrevRandao()),
+		BlockNumber:      strconv.FormatUint(e.BlockNumber(), 10),
+		GasLimit:         strconv.FormatUint(e.GasLimit(), 10),
+		GasUsed:          strconv.FormatUint(e.GasUsed(), 10),
+		Timestamp:        strconv.FormatUint(e.Timestamp(), 10),
+		ExtraData:        hexutil.Encode(e.ExtraData()),
+		BaseFeePerGas:    bytesutil.LittleEndianBytesToBigInt(e.BaseFeePerGas()).String(),
+		BlockHash:        hexutil.Encode(e.BlockHash()),
+		TransactionsRoot: hexutil.Encode(txRoot),
+		WithdrawalsRoot:  hexutil.Encode(withdrawalsRoot),
+	}
+	if v >= version.Deneb {
+		blobGasUsed, err := e.BlobGasUsed()
+		if err != nil {
+			return nil
+		}
+		excessBlobGas, err := e.ExcessBlobGas()
+		if err != nil {
+			return nil
+		}
+		header.BlobGasUsed = strconv.FormatUint(blobGasUsed, 10)
+		header.ExcessBlobGas = strconv.FormatUint(excessBlobGas, 10)
+	}
+	return header
+}
+
+func syncCommitteeFromConsensus(c *ethpb.SyncCommittee) *SyncCommittee {
+	if c == nil {
+		return nil
+	}
+	pubkeys := make([]string, len(c.Pubkeys))
+	for i, pk := range c.Pubkeys {
+		pubkeys[i] = hexutil.Encode(pk)
+	}
+	return &SyncCommittee{
+		Pubkeys:         pubkeys,
+		AggregatePubkey: hexutil.Encode(c.AggregatePubkey),
+	}
+}
+
+func syncAggregateFromConsensus(a *ethpb.SyncAggregate) *SyncAggregate {
+	if a == nil {
+		return nil
+	}
+	return &SyncAggregate{
+		SyncCommitteeBits:      hexutil.Encode(a.SyncCommitteeBits),
+		SyncCommitteeSignature: hexutil.Encode(a.SyncCommitteeSignature),
+	}
+}
+
+func branchFromConsensus(branch [][]byte) []string {
+	b := make([]string, len(branch))
+	for i, node := range branch {
+		b[i] = hexutil.Encode(node)
+	}
+	return b
+}
@@ -0,0 +1,249 @@
+package lightclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	consensuslc "github.com/prysmaticlabs/prysm/v4/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"google.golang.org/protobuf/proto"
+)
+
+func testHeader(slot uint64) *ethpb.LightClientHeader {
+	return &ethpb.LightClientHeader{
+		Beacon: &ethpb.BeaconBlockHeader{
+			Slot:       params.BeaconConfig().SlotsPerEpoch.Mul(slot),
+			ParentRoot: make([]byte, fieldparams.RootLength),
+			StateRoot:  make([]byte, fieldparams.RootLength),
+			BodyRoot:   make([]byte, fieldparams.RootLength),
+		},
+	}
+}
+
+func wrap[T any](t *testing.T, wrapper func(proto.Message) (T, error), m proto.Message) T {
+	w, err := wrapper(m)
+	require.NoError(t, err)
+	return w
+}
+
+func testSyncAggregate() *ethpb.SyncAggregate {
+	return &ethpb.SyncAggregate{
+		SyncCommitteeBits:      make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength),
+		SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
+	}
+}
+
+func TestGetLightClientBootstrap(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 10
+	cfg.CapellaForkEpoch = 20
+	params.OverrideBeaconConfig(cfg)
+
+	root := bytesutil.PadTo([]byte("root"), 32)
+	s := &Server{LightClientFetcher: &mock.ChainService{
+		LCBootstraps: map[[32]byte]interfaces.LightClientBootstrap{
+			bytesutil.ToBytes32(root): wrap(t, consensuslc.NewWrappedBootstrap, &ethpb.LightClientBootstrap{
+				Header: testHeader(12),
+				CurrentSyncCommittee: &ethpb.SyncCommittee{
+					Pubkeys:         [][]byte{make([]byte, fieldparams.BLSPubkeyLength)},
+					AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+				},
+				CurrentSyncCommitteeBranch: [][]byte{root},
+			}),
+		},
+	}}
+
+	t.Run("ok", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/eth/v1/beacon/light_client/bootstrap/"+hexutil.Encode(root), nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetLightClientBootstrap(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &LightClientBootstrapResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "bellatrix", resp.Version)
+		assert.Equal(t, "384", resp.Data.Header.Beacon.Slot)
+		require.Equal(t, 1, len(resp.Data.CurrentSyncCommittee.Pubkeys))
+		require.Equal(t, 1, len(resp.Data.CurrentSyncCommitteeBranch))
+		assert.Equal(t, hexutil.Encode(root), resp.Data.CurrentSyncCommitteeBranch[0])
+	})
+	t.Run("unknown root", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/eth/v1/beacon/light_client/bootstrap/"+hexutil.Encode(make([]byte, 32)), nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetLightClientBootstrap(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("invalid root", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/eth/v1/beacon/light_client/bootstrap/0x1234", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetLightClientBootstrap(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, "invalid block root: 0x1234", e.Message)
+	})
+}
+
+func TestGetLightClientUpdatesByRange(t *testing.T) {
+	update := func(slot uint64) interfaces.LightClientUpdate {
+		return wrap(t, consensuslc.NewWrappedUpdate, &ethpb.LightClientUpdate{
+			AttestedHeader:  testHeader(slot),
+			FinalizedHeader: testHeader(slot - 1),
+			SyncAggregate:   testSyncAggregate(),
+			SignatureSlot:   params.BeaconConfig().SlotsPerEpoch.Mul(slot) + 1,
+		})
+	}
+	s := &Server{LightClientFetcher: &mock.ChainService{
+		LCUpdates: map[uint64]interfaces.LightClientUpdate{
+			1: update(300),
+			2: update(600),
+			4: update(1200),
+		},
+	}}
+
+	t.Run("ok", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/eth/v1/beacon/light_client/updates?start_period=1&count=5", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetLightClientUpdatesByRange(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		var resp []*LightClientUpdateResponse
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &resp))
+		require.Equal(t, 2, len(resp))
+		assert.Equal(t, "9601", resp[0].Data.SignatureSlot)
+		assert.Equal(t, "19201", resp[1].Data.SignatureSlot)
+	})
+	t.Run("zero count", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/eth/v1/beacon/light_client/updates?start_period=1&count=0", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetLightClientUpdatesByRange(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("missing start period", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/eth/v1/beacon/light_client/updates?count=1", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetLightClientUpdatesByRange(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, "start_period is required", e.Message)
+	})
+}
+
+func TestGetLightClientFinalityUpdate(t *testing.T) {
+	chain := &mock.ChainService{}
+	s := &Server{LightClientFetcher: chain}
+
+	request := httptest.NewRequest("GET", "http://foo.example/eth/v1/beacon/light_client/finality_update", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetLightClientFinalityUpdate(writer, request)
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+
+	chain.LCFinalityUpdate = wrap(t, consensuslc.NewWrappedFinalityUpdate, &ethpb.LightClientFinalityUpdate{
+		AttestedHeader:  testHeader(3),
+		FinalizedHeader: testHeader(1),
+		FinalityBranch:  [][]byte{make([]byte, fieldparams.RootLength)},
+		SyncAggregate:   testSyncAggregate(),
+		SignatureSlot:   97,
+	})
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetLightClientFinalityUpdate(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp := &LightClientFinalityUpdateResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, "96", resp.Data.AttestedHeader.Beacon.Slot)
+	assert.Equal(t, "32", resp.Data.FinalizedHeader.Beacon.Slot)
+	assert.Equal(t, "97", resp.Data.SignatureSlot)
+}
+
+func TestGetLightClientOptimisticUpdate(t *testing.T) {
+	chain := &mock.ChainService{}
+	s := &Server{LightClientFetcher: chain}
+
+	request := httptest.NewRequest("GET", "http://foo.example/eth/v1/beacon/light_client/optimistic_update", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetLightClientOptimisticUpdate(writer, request)
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+
+	chain.LCOptimisticUpdate = wrap(t, consensuslc.NewWrappedOptimisticUpdate, &ethpb.LightClientOptimisticUpdate{
+		AttestedHeader: testHeader(3),
+		SyncAggregate:  testSyncAggregate(),
+		SignatureSlot:  97,
+	})
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetLightClientOptimisticUpdate(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp := &LightClientOptimisticUpdateResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, "96", resp.Data.AttestedHeader.Beacon.Slot)
+	assert.Equal(t, "97", resp.Data.SignatureSlot)
+}
+
+func TestGetLightClientOptimisticUpdate_Capella(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 0
+	cfg.CapellaForkEpoch = 0
+	cfg.DenebForkEpoch = 10
+	params.OverrideBeaconConfig(cfg)
+
+	pb := util.NewBeaconBlockCapella()
+	pb.Block.Slot = 96
+	pb.Block.Body.ExecutionPayload.BlockNumber = 10
+	pb.Block.Body.ExecutionPayload.BaseFeePerGas = bytesutil.PadTo([]byte{1, 1}, fieldparams.RootLength)
+	blk, err := blocks.NewSignedBeaconBlock(pb)
+	require.NoError(t, err)
+	header, err := consensuslc.HeaderFromBlock(blk, version.Capella)
+	require.NoError(t, err)
+	update, err := consensuslc.NewUpdate(header, nil, nil, header, nil, testSyncAggregate(), 97)
+	require.NoError(t, err)
+	optimistic, err := consensuslc.NewOptimisticUpdate(update)
+	require.NoError(t, err)
+	s := &Server{LightClientFetcher: &mock.ChainService{LCOptimisticUpdate: optimistic}}
+
+	request := httptest.NewRequest("GET", "http://foo.example/eth/v1/beacon/light_client/optimistic_update", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetLightClientOptimisticUpdate(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp := &LightClientOptimisticUpdateResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, "capella", resp.Version)
+	require.NotNil(t, resp.Data.AttestedHeader.Execution)
+	assert.Equal(t, "10", resp.Data.AttestedHeader.Execution.BlockNumber)
+	assert.Equal(t, "257", resp.Data.AttestedHeader.Execution.BaseFeePerGas)
+	assert.Equal(t, "", resp.Data.AttestedHeader.Execution.BlobGasUsed)
+	assert.Equal(t, consensuslc.ExecutionBranchDepth, len(resp.Data.AttestedHeader.ExecutionBranch))
+}
@@ -0,0 +1,9 @@
+package lightclient
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+)
+
+type Server struct {
+	LightClientFetcher blockchain.LightClientFetcher
+}
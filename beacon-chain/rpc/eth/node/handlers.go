@@ -0,0 +1,172 @@
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v4/network"
+)
+
+// GetExecutionClientVersion is an HTTP handler returning the versions of the client implementations
+// run by the execution node connected to this beacon node, as reported by engine_getClientVersionV1.
+func (s *Server) GetExecutionClientVersion(w http.ResponseWriter, r *http.Request) {
+	if s.ExecutionEngineCaller == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Execution engine is not configured",
+			Code:    http.StatusServiceUnavailable,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	versions, err := s.ExecutionEngineCaller.GetClientVersion(r.Context())
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get execution client version").Error(),
+			Code:    http.StatusServiceUnavailable,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	resp := &ExecutionClientVersionResponse{Data: make([]*ClientVersion, len(versions))}
+	for i, v := range versions {
+		resp.Data[i] = &ClientVersion{
+			Code:    v.Code,
+			Name:    v.Name,
+			Version: v.Version,
+			Commit:  v.Commit,
+		}
+	}
+	network.WriteJson(w, resp)
+}
+
+// GetPeerGroups is an HTTP handler serving the peer groups of the node and the connection status
+// of their members.
+func (s *Server) GetPeerGroups(w http.ResponseWriter, _ *http.Request) {
+	if s.PeerGroupManager == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Peer groups are not available",
+			Code:    http.StatusServiceUnavailable,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	network.WriteJson(w, s.peerGroupsResponse())
+}
+
+// AddPeerGroupMembers is an HTTP handler adding the peers with the multiaddrs or ENRs given in the
+// request body to a peer group, which is created if it does not exist. It returns the updated peer groups.
+func (s *Server) AddPeerGroupMembers(w http.ResponseWriter, r *http.Request) {
+	if s.PeerGroupManager == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Peer groups are not available",
+			Code:    http.StatusServiceUnavailable,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	var req AddPeerGroupMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Could not decode request body: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	if len(req.Addresses) == 0 {
+		errJson := &network.DefaultErrorJson{
+			Message: "No addresses provided",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	if err := s.PeerGroupManager.AddPeerGroupMembers(req.Group, req.Addresses); err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not add peers to peer group").Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	network.WriteJson(w, s.peerGroupsResponse())
+}
+
+// RemovePeerGroupMembers is an HTTP handler removing the peers with the IDs given in the request body
+// from a peer group. The peers stay connected, but are no longer protected. It returns the updated
+// peer groups.
+func (s *Server) RemovePeerGroupMembers(w http.ResponseWriter, r *http.Request) {
+	if s.PeerGroupManager == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Peer groups are not available",
+			Code:    http.StatusServiceUnavailable,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	var req RemovePeerGroupMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Could not decode request body: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	pids := make([]peer.ID, len(req.PeerIds))
+	for i, id := range req.PeerIds {
+		pid, err := peer.Decode(id)
+		if err != nil {
+			errJson := &network.DefaultErrorJson{
+				Message: errors.Wrapf(err, "invalid peer ID %s", id).Error(),
+				Code:    http.StatusBadRequest,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		pids[i] = pid
+	}
+	if err := s.PeerGroupManager.RemovePeerGroupMembers(req.Group, pids); err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, p2p.ErrUnknownPeerGroup) {
+			code = http.StatusNotFound
+		}
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not remove peers from peer group").Error(),
+			Code:    code,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	network.WriteJson(w, s.peerGroupsResponse())
+}
+
+func (s *Server) peerGroupsResponse() *PeerGroupsResponse {
+	groups := s.PeerGroupManager.PeerGroups()
+	resp := &PeerGroupsResponse{Data: make([]*PeerGroup, len(groups))}
+	for i, g := range groups {
+		group := &PeerGroup{Name: g.Name, Members: make([]*PeerGroupMember, len(g.Members))}
+		for j, m := range g.Members {
+			member := &PeerGroupMember{
+				PeerId:    m.AddrInfo.ID.String(),
+				Addresses: make([]string, len(m.AddrInfo.Addrs)),
+				Connected: m.Connected,
+				Failures:  strconv.Itoa(m.Failures),
+			}
+			for k, addr := range m.AddrInfo.Addrs {
+				member.Addresses[k] = addr.String()
+			}
+			if !m.Connected && !m.NextDial.IsZero() {
+				member.NextDial = m.NextDial.UTC().Format(time.RFC3339)
+			}
+			group.Members[j] = member
+		}
+		resp.Data[i] = group
+	}
+	return resp
+}
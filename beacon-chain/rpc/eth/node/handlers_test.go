@@ -0,0 +1,209 @@
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	mockExecution "github.com/prysmaticlabs/prysm/v4/beacon-chain/execution/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution/types"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestGetExecutionClientVersion(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		s := &Server{ExecutionEngineCaller: &mockExecution.EngineClient{
+			ClientVersions: []*types.ClientVersionV1{{Code: "GE", Name: "Geth", Version: "1.13.0", Commit: "0xfa4c7b5b"}},
+		}}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/execution_client_version", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetExecutionClientVersion(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ExecutionClientVersionResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.DeepEqual(t, &ClientVersion{Code: "GE", Name: "Geth", Version: "1.13.0", Commit: "0xfa4c7b5b"}, resp.Data[0])
+	})
+	t.Run("execution client error", func(t *testing.T) {
+		s := &Server{ExecutionEngineCaller: &mockExecution.EngineClient{
+			ErrGetClientVersion: errors.New("method not found"),
+		}}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/execution_client_version", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetExecutionClientVersion(writer, request)
+		require.Equal(t, http.StatusServiceUnavailable, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "could not get execution client version: method not found", e.Message)
+	})
+}
+
+type mockPeerGroupManager struct {
+	groups map[string][]*p2p.PeerGroupMember
+}
+
+func (m *mockPeerGroupManager) PeerGroups() []*p2p.PeerGroup {
+	var groups []*p2p.PeerGroup
+	for name, members := range m.groups {
+		groups = append(groups, &p2p.PeerGroup{Name: name, Members: members})
+	}
+	return groups
+}
+
+func (m *mockPeerGroupManager) AddPeerGroupMembers(group string, addrs []string) error {
+	for _, a := range addrs {
+		addr, err := multiaddr.NewMultiaddr(a)
+		if err != nil {
+			return err
+		}
+		info, err := peer.AddrInfoFromP2pAddr(addr)
+		if err != nil {
+			return err
+		}
+		m.groups[group] = append(m.groups[group], &p2p.PeerGroupMember{AddrInfo: *info})
+	}
+	return nil
+}
+
+func (m *mockPeerGroupManager) RemovePeerGroupMembers(group string, _ []peer.ID) error {
+	if _, ok := m.groups[group]; !ok {
+		return p2p.ErrUnknownPeerGroup
+	}
+	delete(m.groups, group)
+	return nil
+}
+
+func TestGetPeerGroups(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		pid, err := peer.Decode("16Uiu2HAkum7hhuMpWqFj3yNLcmQBGmThmqw2ohaCRThXQuKU9ohs")
+		require.NoError(t, err)
+		addr, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/13000")
+		require.NoError(t, err)
+		nextDial := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+		s := &Server{PeerGroupManager: &mockPeerGroupManager{groups: map[string][]*p2p.PeerGroupMember{
+			"static": {{AddrInfo: peer.AddrInfo{ID: pid, Addrs: []multiaddr.Multiaddr{addr}}, Failures: 2, NextDial: nextDial}},
+		}}}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/peer_groups", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPeerGroups(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &PeerGroupsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "static", resp.Data[0].Name)
+		require.Equal(t, 1, len(resp.Data[0].Members))
+		assert.DeepEqual(t, &PeerGroupMember{
+			PeerId:    pid.String(),
+			Addresses: []string{"/ip4/127.0.0.1/tcp/13000"},
+			Connected: false,
+			Failures:  "2",
+			NextDial:  "2023-10-01T12:00:00Z",
+		}, resp.Data[0].Members[0])
+	})
+	t.Run("not available", func(t *testing.T) {
+		s := &Server{}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/peer_groups", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPeerGroups(writer, request)
+		require.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	})
+}
+
+func TestAddPeerGroupMembers(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		s := &Server{PeerGroupManager: &mockPeerGroupManager{groups: map[string][]*p2p.PeerGroupMember{}}}
+		body := `{"group":"sentries","addresses":["/ip4/127.0.0.1/tcp/13000/p2p/16Uiu2HAkum7hhuMpWqFj3yNLcmQBGmThmqw2ohaCRThXQuKU9ohs"]}`
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/node/peer_groups/add", strings.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AddPeerGroupMembers(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &PeerGroupsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "sentries", resp.Data[0].Name)
+		require.Equal(t, 1, len(resp.Data[0].Members))
+		assert.Equal(t, "16Uiu2HAkum7hhuMpWqFj3yNLcmQBGmThmqw2ohaCRThXQuKU9ohs", resp.Data[0].Members[0].PeerId)
+	})
+	t.Run("no addresses", func(t *testing.T) {
+		s := &Server{PeerGroupManager: &mockPeerGroupManager{groups: map[string][]*p2p.PeerGroupMember{}}}
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/node/peer_groups/add", strings.NewReader(`{"group":"sentries"}`))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AddPeerGroupMembers(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "No addresses provided", e.Message)
+	})
+	t.Run("invalid address", func(t *testing.T) {
+		s := &Server{PeerGroupManager: &mockPeerGroupManager{groups: map[string][]*p2p.PeerGroupMember{}}}
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/node/peer_groups/add", strings.NewReader(`{"group":"sentries","addresses":["foo"]}`))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AddPeerGroupMembers(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "could not add peers to peer group", e.Message)
+	})
+}
+
+func TestRemovePeerGroupMembers(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		s := &Server{PeerGroupManager: &mockPeerGroupManager{groups: map[string][]*p2p.PeerGroupMember{"sentries": {}}}}
+		body := `{"group":"sentries","peer_ids":["16Uiu2HAkum7hhuMpWqFj3yNLcmQBGmThmqw2ohaCRThXQuKU9ohs"]}`
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/node/peer_groups/remove", strings.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.RemovePeerGroupMembers(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &PeerGroupsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, 0, len(resp.Data))
+	})
+	t.Run("unknown group", func(t *testing.T) {
+		s := &Server{PeerGroupManager: &mockPeerGroupManager{groups: map[string][]*p2p.PeerGroupMember{}}}
+		body := `{"group":"sentries","peer_ids":["16Uiu2HAkum7hhuMpWqFj3yNLcmQBGmThmqw2ohaCRThXQuKU9ohs"]}`
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/node/peer_groups/remove", strings.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.RemovePeerGroupMembers(writer, request)
+		require.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("invalid peer ID", func(t *testing.T) {
+		s := &Server{PeerGroupManager: &mockPeerGroupManager{groups: map[string][]*p2p.PeerGroupMember{}}}
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/node/peer_groups/remove", strings.NewReader(`{"group":"sentries","peer_ids":["foo"]}`))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.RemovePeerGroupMembers(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "invalid peer ID foo", e.Message)
+	})
+}
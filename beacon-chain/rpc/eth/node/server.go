@@ -22,8 +22,10 @@ type Server struct {
 	BeaconDB                  db.ReadOnlyDatabase
 	PeersFetcher              p2p.PeersProvider
 	PeerManager               p2p.PeerManager
+	PeerGroupManager          p2p.PeerGroupManager
 	MetadataProvider          p2p.MetadataProvider
 	GenesisTimeFetcher        blockchain.TimeFetcher
 	HeadFetcher               blockchain.HeadFetcher
 	ExecutionChainInfoFetcher execution.ChainInfoFetcher
+	ExecutionEngineCaller     execution.EngineCaller
 }
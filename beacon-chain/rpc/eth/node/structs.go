@@ -0,0 +1,39 @@
+package node
+
+type ExecutionClientVersionResponse struct {
+	Data []*ClientVersion `json:"data"`
+}
+
+type ClientVersion struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+}
+
+type PeerGroupsResponse struct {
+	Data []*PeerGroup `json:"data"`
+}
+
+type PeerGroup struct {
+	Name    string             `json:"name"`
+	Members []*PeerGroupMember `json:"members"`
+}
+
+type PeerGroupMember struct {
+	PeerId    string   `json:"peer_id"`
+	Addresses []string `json:"addresses"`
+	Connected bool     `json:"connected"`
+	Failures  string   `json:"failures"`
+	NextDial  string   `json:"next_dial"`
+}
+
+type AddPeerGroupMembersRequest struct {
+	Group     string   `json:"group"`
+	Addresses []string `json:"addresses"`
+}
+
+type RemovePeerGroupMembersRequest struct {
+	Group   string   `json:"group"`
+	PeerIds []string `json:"peer_ids"`
+}
@@ -1,19 +1,33 @@
 package rewards
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
 	coreblocks "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/validators"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/network"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
 )
 
 // BlockRewards is an HTTP handler for Beacon API getBlockRewards.
@@ -166,6 +180,486 @@ func (s *Server) BlockRewards(w http.ResponseWriter, r *http.Request) {
 	network.WriteJson(w, response)
 }
 
+// AttestationRewards retrieves attestation reward info for validators specified by array of public keys or validator index.
+// If no array is provided, return reward info for every validator.
+func (s *Server) AttestationRewards(w http.ResponseWriter, r *http.Request) {
+	st, ok := s.attRewardsState(w, r)
+	if !ok {
+		return
+	}
+	bal, vals, valIndices, ok := attRewardsBalancesAndVals(w, r, st)
+	if !ok {
+		return
+	}
+	totalRewards, ok := totalAttRewards(w, st, bal, vals, valIndices)
+	if !ok {
+		return
+	}
+	idealRewards, ok := idealAttRewards(w, st, bal, vals, valIndices)
+	if !ok {
+		return
+	}
+
+	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(r.Context())
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get optimistic mode info").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	// The state root of the latest block header is only filled in when processing the next slot.
+	header := st.LatestBlockHeader()
+	if bytes.Equal(header.StateRoot, params.BeaconConfig().ZeroHash[:]) {
+		stRoot, err := st.HashTreeRoot(r.Context())
+		if err != nil {
+			errJson := &network.DefaultErrorJson{
+				Message: errors.Wrapf(err, "could not get state root").Error(),
+				Code:    http.StatusInternalServerError,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		header.StateRoot = stRoot[:]
+	}
+	blkRoot, err := header.HashTreeRoot()
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get block root").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	resp := &AttestationRewardsResponse{
+		Data: AttestationRewards{
+			IdealRewards: idealRewards,
+			TotalRewards: totalRewards,
+		},
+		ExecutionOptimistic: optimistic,
+		Finalized:           s.FinalizationFetcher.IsFinalized(r.Context(), blkRoot),
+	}
+	network.WriteJson(w, resp)
+}
+
+// SyncCommitteeRewards retrieves rewards info for sync committee members specified by array of public keys or validator index.
+// If no array is provided, return reward info for every committee member.
+func (s *Server) SyncCommitteeRewards(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(r.URL.Path, "/")
+	blockId := segments[len(segments)-1]
+
+	blk, err := s.Blocker.Block(r.Context(), []byte(blockId))
+	if errJson := handleGetBlockError(blk, err); errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	if blk.Version() == version.Phase0 {
+		errJson := &network.DefaultErrorJson{
+			Message: "sync committee rewards are not supported for Phase 0",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	// We replay the state up to the block's slot, but before processing the block,
+	// so that the difference in balances after processing the sync aggregate gives us the rewards.
+	st, err := s.ReplayerBuilder.ReplayerForSlot(blk.Block().Slot()-1).ReplayToSlot(r.Context(), blk.Block().Slot())
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get state").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	sa, err := blk.Block().Body().SyncAggregate()
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get sync aggregate").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	valIndices, ok := syncRewardsVals(w, r, st)
+	if !ok {
+		return
+	}
+	preProcessBals := make([]uint64, len(valIndices))
+	for i, valIdx := range valIndices {
+		preProcessBals[i], err = st.BalanceAtIndex(valIdx)
+		if err != nil {
+			errJson := &network.DefaultErrorJson{
+				Message: errors.Wrapf(err, "could not get validator's balance").Error(),
+				Code:    http.StatusInternalServerError,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+	}
+
+	_, proposerReward, err := altair.ProcessSyncAggregate(r.Context(), st, sa)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get sync aggregate rewards").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	rewards := make([]SyncCommitteeReward, len(preProcessBals))
+	for i, valIdx := range valIndices {
+		bal, err := st.BalanceAtIndex(valIdx)
+		if err != nil {
+			errJson := &network.DefaultErrorJson{
+				Message: errors.Wrapf(err, "could not get validator's balance").Error(),
+				Code:    http.StatusInternalServerError,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		reward := int(bal) - int(preProcessBals[i])
+		// The proposer's balance also includes the reward for including the sync aggregate.
+		if valIdx == blk.Block().ProposerIndex() {
+			reward -= int(proposerReward)
+		}
+		rewards[i] = SyncCommitteeReward{
+			ValidatorIndex: strconv.FormatUint(uint64(valIdx), 10),
+			Reward:         strconv.Itoa(reward),
+		}
+	}
+
+	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(r.Context())
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get optimistic mode info").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	blkRoot, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get block root").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	response := &SyncCommitteeRewardsResponse{
+		Data:                rewards,
+		ExecutionOptimistic: optimistic,
+		Finalized:           s.FinalizationFetcher.IsFinalized(r.Context(), blkRoot),
+	}
+	network.WriteJson(w, response)
+}
+
+// attRewardsState returns the state at the end of the epoch following the requested one.
+// Attestations of an epoch are rewarded in the epoch transition at the end of the next epoch,
+// so this is the state to which the rewards are applied.
+func (s *Server) attRewardsState(w http.ResponseWriter, r *http.Request) (state.BeaconState, bool) {
+	segments := strings.Split(r.URL.Path, "/")
+	requestedEpoch, err := strconv.ParseUint(segments[len(segments)-1], 10, 64)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Could not decode epoch: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return nil, false
+	}
+	if primitives.Epoch(requestedEpoch) < params.BeaconConfig().AltairForkEpoch {
+		errJson := &network.DefaultErrorJson{
+			Message: "Attestation rewards are not supported for Phase 0",
+			Code:    http.StatusNotFound,
+		}
+		network.WriteError(w, errJson)
+		return nil, false
+	}
+	currentEpoch := slots.ToEpoch(s.TimeFetcher.CurrentSlot())
+	if primitives.Epoch(requestedEpoch+1) >= currentEpoch {
+		errJson := &network.DefaultErrorJson{
+			Message: "Attestation rewards are available after two epoch transitions to ensure all attestations have a chance of inclusion",
+			Code:    http.StatusNotFound,
+		}
+		network.WriteError(w, errJson)
+		return nil, false
+	}
+	nextEpochEnd, err := slots.EpochEnd(primitives.Epoch(requestedEpoch + 1))
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get next epoch's ending slot").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return nil, false
+	}
+	st, err := s.ReplayerBuilder.ReplayerForSlot(nextEpochEnd).ReplayToSlot(r.Context(), nextEpochEnd)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get state").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return nil, false
+	}
+	return st, true
+}
+
+// attRewardsBalancesAndVals runs the parts of the epoch transition that precede the processing of rewards and penalties,
+// and returns the precomputed balances and validators along with the indices of the requested validators.
+func attRewardsBalancesAndVals(
+	w http.ResponseWriter,
+	r *http.Request,
+	st state.BeaconState,
+) (*precompute.Balance, []*precompute.Validator, []primitives.ValidatorIndex, bool) {
+	allVals, bal, err := altair.InitializePrecomputeValidators(r.Context(), st)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not initialize precompute validators").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return nil, nil, nil, false
+	}
+	allVals, bal, err = altair.ProcessEpochParticipation(r.Context(), st, bal, allVals)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not process epoch participation").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return nil, nil, nil, false
+	}
+	st, err = precompute.ProcessJustificationAndFinalizationPreCompute(st, bal)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not process justification and finalization").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return nil, nil, nil, false
+	}
+	_, allVals, err = altair.ProcessInactivityScores(r.Context(), st, allVals)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not process inactivity scores").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return nil, nil, nil, false
+	}
+	valIndices, ok := requestedValIndices(w, r, st)
+	if !ok {
+		return nil, nil, nil, false
+	}
+	return bal, allVals, valIndices, true
+}
+
+// requestedValIndices decodes the validator indices or public keys of the request body.
+// An empty body means that every validator is requested.
+func requestedValIndices(
+	w http.ResponseWriter,
+	r *http.Request,
+	st state.BeaconState,
+) ([]primitives.ValidatorIndex, bool) {
+	numVals := st.NumValidators()
+	var rawValIds []string
+	if r.Body != http.NoBody && r.Body != nil {
+		if err := json.NewDecoder(r.Body).Decode(&rawValIds); err != nil && err != io.EOF {
+			errJson := &network.DefaultErrorJson{
+				Message: "Could not decode validators: " + err.Error(),
+				Code:    http.StatusBadRequest,
+			}
+			network.WriteError(w, errJson)
+			return nil, false
+		}
+	}
+	valIndices := make([]primitives.ValidatorIndex, len(rawValIds))
+	for i, v := range rawValIds {
+		index, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			pubkey, err := hexutil.Decode(v)
+			if err != nil || len(pubkey) != fieldparams.BLSPubkeyLength {
+				errJson := &network.DefaultErrorJson{
+					Message: fmt.Sprintf("%s is not a validator index or pubkey", v),
+					Code:    http.StatusBadRequest,
+				}
+				network.WriteError(w, errJson)
+				return nil, false
+			}
+			var ok bool
+			valIndices[i], ok = st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey))
+			if !ok {
+				errJson := &network.DefaultErrorJson{
+					Message: fmt.Sprintf("No validator index found for pubkey %#x", pubkey),
+					Code:    http.StatusBadRequest,
+				}
+				network.WriteError(w, errJson)
+				return nil, false
+			}
+		} else {
+			if index >= uint64(numVals) {
+				errJson := &network.DefaultErrorJson{
+					Message: fmt.Sprintf("Validator index %d is too large. Maximum allowed index is %d", index, numVals-1),
+					Code:    http.StatusBadRequest,
+				}
+				network.WriteError(w, errJson)
+				return nil, false
+			}
+			valIndices[i] = primitives.ValidatorIndex(index)
+		}
+	}
+	if len(valIndices) == 0 {
+		valIndices = make([]primitives.ValidatorIndex, numVals)
+		for i := 0; i < numVals; i++ {
+			valIndices[i] = primitives.ValidatorIndex(i)
+		}
+	}
+	return valIndices, true
+}
+
+// idealAttRewards computes the rewards of a perfectly performing validator
+// for every distinct effective balance of the requested validators.
+func idealAttRewards(
+	w http.ResponseWriter,
+	st state.BeaconState,
+	bal *precompute.Balance,
+	vals []*precompute.Validator,
+	valIndices []primitives.ValidatorIndex,
+) ([]IdealAttestationReward, bool) {
+	increment := params.BeaconConfig().EffectiveBalanceIncrement
+	effectiveBalances := make([]uint64, 0)
+	seen := make(map[uint64]bool)
+	for _, valIdx := range valIndices {
+		effectiveBalance := vals[valIdx].CurrentEpochEffectiveBalance
+		if effectiveBalance < increment || seen[effectiveBalance] {
+			continue
+		}
+		seen[effectiveBalance] = true
+		effectiveBalances = append(effectiveBalances, effectiveBalance)
+	}
+	sort.Slice(effectiveBalances, func(i, j int) bool {
+		return effectiveBalances[i] < effectiveBalances[j]
+	})
+
+	idealVals := make([]*precompute.Validator, len(effectiveBalances))
+	for i, effectiveBalance := range effectiveBalances {
+		idealVals[i] = &precompute.Validator{
+			IsActivePrevEpoch:            true,
+			IsPrevEpochSourceAttester:    true,
+			IsPrevEpochTargetAttester:    true,
+			IsPrevEpochHeadAttester:      true,
+			CurrentEpochEffectiveBalance: effectiveBalance,
+		}
+	}
+	deltas, err := altair.AttDeltas(st, bal, idealVals)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get attestations delta").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return nil, false
+	}
+
+	idealRewards := make([]IdealAttestationReward, len(deltas))
+	for i, d := range deltas {
+		idealRewards[i] = IdealAttestationReward{
+			EffectiveBalance: strconv.FormatUint(effectiveBalances[i], 10),
+			Head:             strconv.FormatUint(d.HeadReward, 10),
+			Target:           strconv.FormatUint(d.TargetReward, 10),
+			Source:           strconv.FormatUint(d.SourceReward, 10),
+			Inactivity:       "0",
+		}
+	}
+	return idealRewards, true
+}
+
+// totalAttRewards computes the actual rewards of the requested validators. Penalties are reported as negative rewards.
+func totalAttRewards(
+	w http.ResponseWriter,
+	st state.BeaconState,
+	bal *precompute.Balance,
+	vals []*precompute.Validator,
+	valIndices []primitives.ValidatorIndex,
+) ([]TotalAttestationReward, bool) {
+	requestedVals := make([]*precompute.Validator, len(valIndices))
+	for i, valIdx := range valIndices {
+		requestedVals[i] = vals[valIdx]
+	}
+	deltas, err := altair.AttDeltas(st, bal, requestedVals)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get attestations delta").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return nil, false
+	}
+
+	totalRewards := make([]TotalAttestationReward, len(deltas))
+	for i, d := range deltas {
+		totalRewards[i] = TotalAttestationReward{
+			ValidatorIndex: strconv.FormatUint(uint64(valIndices[i]), 10),
+			Head:           strconv.FormatUint(d.HeadReward, 10),
+			Target:         strconv.Itoa(int(d.TargetReward) - int(d.TargetPenalty)),
+			Source:         strconv.Itoa(int(d.SourceReward) - int(d.SourcePenalty)),
+			Inactivity:     strconv.Itoa(-int(d.InactivityPenalty)),
+		}
+	}
+	return totalRewards, true
+}
+
+// syncRewardsVals returns the indices of the requested members of the current sync committee.
+// A validator appearing several times in the committee is returned once, as its balance accounts for all its seats.
+func syncRewardsVals(w http.ResponseWriter, r *http.Request, st state.BeaconState) ([]primitives.ValidatorIndex, bool) {
+	requested, ok := requestedValIndices(w, r, st)
+	if !ok {
+		return nil, false
+	}
+	requestedSet := make(map[primitives.ValidatorIndex]bool, len(requested))
+	for _, valIdx := range requested {
+		requestedSet[valIdx] = true
+	}
+
+	sc, err := st.CurrentSyncCommittee()
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get current sync committee").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return nil, false
+	}
+	scIndices := make([]primitives.ValidatorIndex, 0, len(sc.Pubkeys))
+	for _, pk := range sc.Pubkeys {
+		valIdx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pk))
+		if !ok {
+			errJson := &network.DefaultErrorJson{
+				Message: fmt.Sprintf("No validator index found for pubkey %#x", pk),
+				Code:    http.StatusInternalServerError,
+			}
+			network.WriteError(w, errJson)
+			return nil, false
+		}
+		if !requestedSet[valIdx] {
+			continue
+		}
+		// Don't report a validator twice.
+		delete(requestedSet, valIdx)
+		scIndices = append(scIndices, valIdx)
+	}
+	return scIndices, true
+}
+
 func handleGetBlockError(blk interfaces.ReadOnlySignedBeaconBlock, err error) *network.DefaultErrorJson {
 	if errors.Is(err, lookup.BlockIdParseError{}) {
 		return &network.DefaultErrorJson{
@@ -4,13 +4,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/prysmaticlabs/go-bitfield"
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
 	mockstategen "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen/mock"
@@ -196,3 +199,370 @@ func TestBlockRewards(t *testing.T) {
 		assert.Equal(t, "block rewards are not supported for Phase 0 blocks", e.Message)
 	})
 }
+
+func TestAttestationRewards(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	valCount := 64
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerEpoch*3-1))
+	validators := make([]*eth.Validator, 0, valCount)
+	balances := make([]uint64, 0, valCount)
+	for i := 0; i < valCount; i++ {
+		blsKey, err := bls.RandKey()
+		require.NoError(t, err)
+		effectiveBalance := params.BeaconConfig().MaxEffectiveBalance
+		// The last validator has a lower effective balance.
+		if i == valCount-1 {
+			effectiveBalance = params.BeaconConfig().MaxEffectiveBalance / 2
+		}
+		validators = append(validators, &eth.Validator{
+			PublicKey:         blsKey.PublicKey().Marshal(),
+			ExitEpoch:         params.BeaconConfig().FarFutureEpoch,
+			WithdrawableEpoch: params.BeaconConfig().FarFutureEpoch,
+			EffectiveBalance:  effectiveBalance,
+		})
+		balances = append(balances, effectiveBalance)
+	}
+	require.NoError(t, st.SetValidators(validators))
+	require.NoError(t, st.SetBalances(balances))
+	require.NoError(t, st.SetCurrentParticipationBits(make([]byte, valCount)))
+	// Validators with an even index voted correctly for the source, the target and the head, the others did not vote.
+	bits := make([]byte, valCount)
+	scores := make([]uint64, valCount)
+	for i := range bits {
+		if i%2 == 0 {
+			bits[i] = 0b111
+		} else {
+			scores[i] = 32
+		}
+	}
+	require.NoError(t, st.SetPreviousParticipationBits(bits))
+	require.NoError(t, st.SetInactivityScores(scores))
+
+	currentSlot := params.BeaconConfig().SlotsPerEpoch * 3
+	mockChainService := &mock.ChainService{Optimistic: true, Slot: &currentSlot}
+	s := &Server{
+		OptimisticModeFetcher: mockChainService,
+		FinalizationFetcher:   mockChainService,
+		ReplayerBuilder:       mockstategen.NewMockReplayerBuilder(mockstategen.WithMockState(st.Copy())),
+		TimeFetcher:           mockChainService,
+	}
+
+	t.Run("ok - ideal rewards", func(t *testing.T) {
+		url := "http://only.the.epoch.number.at.the.end.is.important/1"
+		request := httptest.NewRequest("POST", url, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &AttestationRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data.IdealRewards))
+		assert.Equal(t, "16000000000", resp.Data.IdealRewards[0].EffectiveBalance)
+		assert.Equal(t, "32000000000", resp.Data.IdealRewards[1].EffectiveBalance)
+		assert.Equal(t, "", resp.Data.IdealRewards[1].InclusionDelay)
+		assert.Equal(t, "0", resp.Data.IdealRewards[1].Inactivity)
+		require.Equal(t, valCount, len(resp.Data.TotalRewards))
+		assert.Equal(t, true, resp.ExecutionOptimistic)
+		assert.Equal(t, false, resp.Finalized)
+
+		// A validator with a perfect vote gets the ideal rewards.
+		ideal := resp.Data.IdealRewards[1]
+		perfect := resp.Data.TotalRewards[0]
+		assert.Equal(t, "0", perfect.ValidatorIndex)
+		assert.Equal(t, ideal.Head, perfect.Head)
+		assert.Equal(t, ideal.Target, perfect.Target)
+		assert.Equal(t, ideal.Source, perfect.Source)
+		assert.Equal(t, "0", perfect.Inactivity)
+	})
+	t.Run("ok - filtered vals", func(t *testing.T) {
+		// Computing rewards modifies the state, so we need a fresh one.
+		s.ReplayerBuilder = mockstategen.NewMockReplayerBuilder(mockstategen.WithMockState(st.Copy()))
+		url := "http://only.the.epoch.number.at.the.end.is.important/1"
+		pubkey := fmt.Sprintf("%#x", validators[63].PublicKey)
+		valIds, err := json.Marshal([]string{"1", pubkey})
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", url, bytes.NewReader(valIds))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &AttestationRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data.TotalRewards))
+		assert.DeepEqual(t, TotalAttestationReward{
+			ValidatorIndex: "1",
+			Head:           "0",
+			Target:         "-583661",
+			Source:         "-314279",
+			Inactivity:     "-3178",
+		}, resp.Data.TotalRewards[0])
+		assert.DeepEqual(t, TotalAttestationReward{
+			ValidatorIndex: "63",
+			Head:           "0",
+			Target:         "-291830",
+			Source:         "-157139",
+			Inactivity:     "-1589",
+		}, resp.Data.TotalRewards[1])
+		// Ideal rewards only cover the effective balances of the requested validators.
+		require.Equal(t, 2, len(resp.Data.IdealRewards))
+	})
+	t.Run("invalid validator index", func(t *testing.T) {
+		url := "http://only.the.epoch.number.at.the.end.is.important/1"
+		valIds, err := json.Marshal([]string{"64"})
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", url, bytes.NewReader(valIds))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusBadRequest, e.Code)
+		assert.Equal(t, "Validator index 64 is too large. Maximum allowed index is 63", e.Message)
+	})
+	t.Run("unknown pubkey", func(t *testing.T) {
+		url := "http://only.the.epoch.number.at.the.end.is.important/1"
+		pubkey := fmt.Sprintf("%#x", bytesutil.PadTo([]byte{1}, fieldparams.BLSPubkeyLength))
+		valIds, err := json.Marshal([]string{pubkey})
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", url, bytes.NewReader(valIds))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusBadRequest, e.Code)
+		assert.Equal(t, "No validator index found for pubkey "+pubkey, e.Message)
+	})
+	t.Run("invalid validator id", func(t *testing.T) {
+		url := "http://only.the.epoch.number.at.the.end.is.important/1"
+		valIds, err := json.Marshal([]string{"foo"})
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", url, bytes.NewReader(valIds))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusBadRequest, e.Code)
+		assert.Equal(t, "foo is not a validator index or pubkey", e.Message)
+	})
+	t.Run("invalid epoch", func(t *testing.T) {
+		url := "http://only.the.epoch.number.at.the.end.is.important/foo"
+		request := httptest.NewRequest("POST", url, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusBadRequest, e.Code)
+		assert.StringContains(t, "Could not decode epoch", e.Message)
+	})
+	t.Run("phase 0", func(t *testing.T) {
+		url := "http://only.the.epoch.number.at.the.end.is.important/0"
+		request := httptest.NewRequest("POST", url, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusNotFound, e.Code)
+		assert.Equal(t, "Attestation rewards are not supported for Phase 0", e.Message)
+	})
+	t.Run("epoch too recent", func(t *testing.T) {
+		url := "http://only.the.epoch.number.at.the.end.is.important/2"
+		request := httptest.NewRequest("POST", url, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusNotFound, e.Code)
+		assert.Equal(t, "Attestation rewards are available after two epoch transitions to ensure all attestations have a chance of inclusion", e.Message)
+	})
+}
+
+func TestSyncCommitteeRewards(t *testing.T) {
+	helpers.ClearCache()
+	valCount := 1024
+
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(1))
+	validators := make([]*eth.Validator, 0, valCount)
+	balances := make([]uint64, 0, valCount)
+	secretKeys := make(map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey, valCount)
+	for i := 0; i < valCount; i++ {
+		blsKey, err := bls.RandKey()
+		require.NoError(t, err)
+		secretKeys[bytesutil.ToBytes48(blsKey.PublicKey().Marshal())] = blsKey
+		validators = append(validators, &eth.Validator{
+			PublicKey:         blsKey.PublicKey().Marshal(),
+			ExitEpoch:         params.BeaconConfig().FarFutureEpoch,
+			WithdrawableEpoch: params.BeaconConfig().FarFutureEpoch,
+			EffectiveBalance:  params.BeaconConfig().MaxEffectiveBalance,
+		})
+		balances = append(balances, params.BeaconConfig().MaxEffectiveBalance)
+	}
+	require.NoError(t, st.SetValidators(validators))
+	require.NoError(t, st.SetBalances(balances))
+	require.NoError(t, st.SetCurrentParticipationBits(make([]byte, valCount)))
+	syncCommittee, err := altair.NextSyncCommittee(context.Background(), st)
+	require.NoError(t, err)
+	require.NoError(t, st.SetCurrentSyncCommittee(syncCommittee))
+	slot0bRoot := bytesutil.PadTo([]byte("slot0root"), 32)
+	bRoots := make([][]byte, fieldparams.BlockRootsLength)
+	bRoots[0] = slot0bRoot
+	require.NoError(t, st.SetBlockRoots(bRoots))
+	proposerIndex, err := helpers.BeaconProposerIndex(context.Background(), st)
+	require.NoError(t, err)
+
+	// Every member of the sync committee participates, except the first one.
+	absent := syncCommittee.Pubkeys[0]
+	domain, err := signing.Domain(st.Fork(), 0, params.BeaconConfig().DomainSyncCommittee, st.GenesisValidatorsRoot())
+	require.NoError(t, err)
+	sszBytes := primitives.SSZBytes(slot0bRoot)
+	r, err := signing.ComputeSigningRoot(&sszBytes, domain)
+	require.NoError(t, err)
+	scBits := bitfield.NewBitvector512()
+	sigs := make([]bls.Signature, 0, len(syncCommittee.Pubkeys))
+	for i, pk := range syncCommittee.Pubkeys {
+		if bytes.Equal(pk, absent) {
+			continue
+		}
+		scBits.SetBitAt(uint64(i), true)
+		sigs = append(sigs, secretKeys[bytesutil.ToBytes48(pk)].Sign(r[:]))
+	}
+	b := util.HydrateSignedBeaconBlockAltair(util.NewBeaconBlockAltair())
+	b.Block.Slot = 2
+	b.Block.ProposerIndex = proposerIndex
+	b.Block.Body.SyncAggregate = &eth.SyncAggregate{
+		SyncCommitteeBits:      scBits,
+		SyncCommitteeSignature: bls.AggregateSignatures(sigs).Marshal(),
+	}
+	sbb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	phase0block, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+
+	absentIndex, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(absent))
+	require.Equal(t, true, ok)
+	var presentIndex primitives.ValidatorIndex
+	for _, pk := range syncCommittee.Pubkeys {
+		if !bytes.Equal(pk, absent) {
+			presentIndex, ok = st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pk))
+			require.Equal(t, true, ok)
+			break
+		}
+	}
+
+	mockChainService := &mock.ChainService{Optimistic: true}
+	s := &Server{
+		Blocker: &testutil.MockBlocker{SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
+			0: phase0block,
+			2: sbb,
+		}},
+		OptimisticModeFetcher: mockChainService,
+		FinalizationFetcher:   mockChainService,
+	}
+
+	t.Run("ok - all committee members", func(t *testing.T) {
+		// Computing rewards modifies the state, so we need a fresh one.
+		s.ReplayerBuilder = mockstategen.NewMockReplayerBuilder(mockstategen.WithMockState(st.Copy()))
+		url := "http://only.the.slot.number.at.the.end.is.important/2"
+		request := httptest.NewRequest("POST", url, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SyncCommitteeRewards(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &SyncCommitteeRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.ExecutionOptimistic)
+		assert.Equal(t, false, resp.Finalized)
+		members := make(map[string]bool)
+		for _, pk := range syncCommittee.Pubkeys {
+			idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pk))
+			require.Equal(t, true, ok)
+			members[strconv.FormatUint(uint64(idx), 10)] = true
+		}
+		require.Equal(t, len(members), len(resp.Data))
+		for _, reward := range resp.Data {
+			assert.Equal(t, true, members[reward.ValidatorIndex])
+		}
+	})
+	t.Run("ok - filtered vals", func(t *testing.T) {
+		s.ReplayerBuilder = mockstategen.NewMockReplayerBuilder(mockstategen.WithMockState(st.Copy()))
+		url := "http://only.the.slot.number.at.the.end.is.important/2"
+		valIds, err := json.Marshal([]string{
+			strconv.FormatUint(uint64(presentIndex), 10),
+			fmt.Sprintf("%#x", absent),
+		})
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", url, bytes.NewReader(valIds))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SyncCommitteeRewards(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &SyncCommitteeRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		rewards := make(map[string]string)
+		for _, reward := range resp.Data {
+			rewards[reward.ValidatorIndex] = reward.Reward
+		}
+		assert.Equal(t, "-698", rewards[strconv.FormatUint(uint64(absentIndex), 10)])
+		assert.Equal(t, "698", rewards[strconv.FormatUint(uint64(presentIndex), 10)])
+	})
+	t.Run("ok - proposer does not get the sync aggregate inclusion reward", func(t *testing.T) {
+		s.ReplayerBuilder = mockstategen.NewMockReplayerBuilder(mockstategen.WithMockState(st.Copy()))
+		url := "http://only.the.slot.number.at.the.end.is.important/2"
+		valIds, err := json.Marshal([]string{strconv.FormatUint(uint64(proposerIndex), 10)})
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", url, bytes.NewReader(valIds))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SyncCommitteeRewards(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &SyncCommitteeRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		// The proposer is a member of the committee in this setup.
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, strconv.FormatUint(uint64(proposerIndex), 10), resp.Data[0].ValidatorIndex)
+		assert.Equal(t, "698", resp.Data[0].Reward)
+	})
+	t.Run("phase 0", func(t *testing.T) {
+		url := "http://only.the.slot.number.at.the.end.is.important/0"
+		request := httptest.NewRequest("POST", url, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SyncCommitteeRewards(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusBadRequest, e.Code)
+		assert.Equal(t, "sync committee rewards are not supported for Phase 0", e.Message)
+	})
+}
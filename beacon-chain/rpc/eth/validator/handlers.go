@@ -0,0 +1,438 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	gatewaymiddleware "github.com/prysmaticlabs/prysm/v4/api/gateway/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+	rpchelpers "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/helpers"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v4/proto/migration"
+	ethpbalpha "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	versionHeader                 = "Eth-Consensus-Version"
+	executionPayloadBlindedHeader = "Eth-Execution-Payload-Blinded"
+	executionPayloadValueHeader   = "Eth-Execution-Payload-Value"
+	consensusBlockValueHeader     = "Eth-Consensus-Block-Value"
+)
+
+// Liveness is an HTTP handler for Beacon API getLiveness.
+// It indicates whether the requested validators have been observed to be live in the given epoch.
+// Liveness is derived from epoch participation, so a validator is live if any of its attestations was included on chain.
+func (vs *Server) Liveness(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(r.URL.Path, "/")
+	requestedEpoch, err := strconv.ParseUint(segments[len(segments)-1], 10, 64)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Could not decode epoch: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	var rawIndices []string
+	if err := json.NewDecoder(r.Body).Decode(&rawIndices); err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Could not decode validator indices: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	if len(rawIndices) == 0 {
+		errJson := &network.DefaultErrorJson{
+			Message: "No validator indices provided",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	participation, err := vs.epochParticipation(r.Context(), primitives.Epoch(requestedEpoch))
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, errFutureEpoch) {
+			code = http.StatusBadRequest
+		}
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get liveness").Error(),
+			Code:    code,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	resp := &GetLivenessResponse{
+		Data: make([]*Liveness, len(rawIndices)),
+	}
+	for i, rawIndex := range rawIndices {
+		index, err := strconv.ParseUint(rawIndex, 10, 64)
+		if err != nil {
+			errJson := &network.DefaultErrorJson{
+				Message: fmt.Sprintf("Could not decode validator index %s: %v", rawIndex, err),
+				Code:    http.StatusBadRequest,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		if index >= uint64(len(participation)) {
+			errJson := &network.DefaultErrorJson{
+				Message: fmt.Sprintf("Validator index %d is invalid", index),
+				Code:    http.StatusBadRequest,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		resp.Data[i] = &Liveness{
+			Index:  rawIndex,
+			IsLive: participation[index] != 0,
+		}
+	}
+	network.WriteJson(w, resp)
+}
+
+// ProduceBlockV3 is an HTTP handler for Beacon API produceBlockV3.
+// It returns either a full or a blinded block, depending on whether the local or the builder execution payload
+// was chosen. The builder payload is chosen when its value, multiplied by the builder_boost_factor percentage,
+// is higher than the value of the local payload.
+func (vs *Server) ProduceBlockV3(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.ProduceBlockV3")
+	defer span.End()
+
+	segments := strings.Split(r.URL.Path, "/")
+	slot, err := strconv.ParseUint(segments[len(segments)-1], 10, 64)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Could not decode slot: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	query := r.URL.Query()
+	randaoReveal, err := hexutil.Decode(query.Get("randao_reveal"))
+	if err != nil || len(randaoReveal) != fieldparams.BLSSignatureLength {
+		errJson := &network.DefaultErrorJson{
+			Message: "Invalid randao reveal: " + query.Get("randao_reveal"),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	var graffiti []byte
+	if rawGraffiti := query.Get("graffiti"); rawGraffiti != "" {
+		graffiti, err = hexutil.Decode(rawGraffiti)
+		if err != nil || len(graffiti) != fieldparams.RootLength {
+			errJson := &network.DefaultErrorJson{
+				Message: "Invalid graffiti: " + rawGraffiti,
+				Code:    http.StatusBadRequest,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+	}
+	builderBoostFactor := params.BeaconConfig().BuilderBoostFactor
+	if rawFactor := query.Get("builder_boost_factor"); rawFactor != "" {
+		builderBoostFactor, err = strconv.ParseUint(rawFactor, 10, 64)
+		if err != nil {
+			errJson := &network.DefaultErrorJson{
+				Message: "Could not decode builder boost factor: " + err.Error(),
+				Code:    http.StatusBadRequest,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+	}
+
+	if !vs.checkSync(ctx, w) {
+		return
+	}
+
+	produced, err := vs.BlockProducer.ProduceBeaconBlock(ctx, &ethpbalpha.BlockRequest{
+		Slot:         primitives.Slot(slot),
+		RandaoReveal: randaoReveal,
+		Graffiti:     graffiti,
+	}, builderBoostFactor, true)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if status.Code(err) == codes.Unavailable {
+			code = http.StatusServiceUnavailable
+		}
+		errJson := &network.DefaultErrorJson{
+			Message: "Could not produce block: " + status.Convert(err).Message(),
+			Code:    code,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	ver, blinded, data, err := blockJson(produced.Block)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Could not prepare block response: " + err.Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	payloadValue := "0"
+	if produced.ExecutionPayloadValue != nil {
+		payloadValue = produced.ExecutionPayloadValue.String()
+	}
+	consensusValue := gweiToWei(produced.ConsensusBlockValue)
+
+	w.Header().Set(versionHeader, ver)
+	w.Header().Set(executionPayloadBlindedHeader, strconv.FormatBool(blinded))
+	w.Header().Set(executionPayloadValueHeader, payloadValue)
+	w.Header().Set(consensusBlockValueHeader, consensusValue)
+	network.WriteJson(w, &ProduceBlockV3Response{
+		Version:                 ver,
+		ExecutionPayloadBlinded: blinded,
+		ExecutionPayloadValue:   payloadValue,
+		ConsensusBlockValue:     consensusValue,
+		Data:                    data,
+	})
+}
+
+func (vs *Server) checkSync(ctx context.Context, w http.ResponseWriter) bool {
+	isSyncing, syncDetails, err := rpchelpers.ValidateSyncHTTP(ctx, vs.SyncChecker, vs.HeadFetcher, vs.TimeFetcher, vs.OptimisticModeFetcher)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Could not check if node is syncing: " + err.Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return false
+	}
+	if isSyncing {
+		msg := "Beacon node is currently syncing and not serving request on that endpoint"
+		details, err := json.Marshal(syncDetails)
+		if err == nil {
+			msg += " Details: " + string(details)
+		}
+		errJson := &network.DefaultErrorJson{
+			Message: msg,
+			Code:    http.StatusServiceUnavailable,
+		}
+		network.WriteError(w, errJson)
+		return false
+	}
+	return true
+}
+
+// blockJson converts a produced block to the JSON representation of its fork, as served by the other block
+// production endpoints. It returns the fork version of the block and whether the block is blinded.
+func blockJson(blk *ethpbalpha.GenericBeaconBlock) (string, bool, json.RawMessage, error) {
+	var (
+		ver       int
+		blinded   bool
+		converted proto.Message
+		container interface{}
+		err       error
+	)
+	switch b := blk.Block.(type) {
+	case *ethpbalpha.GenericBeaconBlock_Phase0:
+		ver, container = version.Phase0, &apimiddleware.BeaconBlockJson{}
+		converted, err = migration.V1Alpha1ToV1Block(b.Phase0)
+	case *ethpbalpha.GenericBeaconBlock_Altair:
+		ver, container = version.Altair, &apimiddleware.BeaconBlockAltairJson{}
+		converted, err = migration.V1Alpha1BeaconBlockAltairToV2(b.Altair)
+	case *ethpbalpha.GenericBeaconBlock_Bellatrix:
+		ver, container = version.Bellatrix, &apimiddleware.BeaconBlockBellatrixJson{}
+		converted, err = migration.V1Alpha1BeaconBlockBellatrixToV2(b.Bellatrix)
+	case *ethpbalpha.GenericBeaconBlock_BlindedBellatrix:
+		ver, blinded, container = version.Bellatrix, true, &apimiddleware.BlindedBeaconBlockBellatrixJson{}
+		converted, err = migration.V1Alpha1BeaconBlockBlindedBellatrixToV2Blinded(b.BlindedBellatrix)
+	case *ethpbalpha.GenericBeaconBlock_Capella:
+		ver, container = version.Capella, &apimiddleware.BeaconBlockCapellaJson{}
+		converted, err = migration.V1Alpha1BeaconBlockCapellaToV2(b.Capella)
+	case *ethpbalpha.GenericBeaconBlock_BlindedCapella:
+		ver, blinded, container = version.Capella, true, &apimiddleware.BlindedBeaconBlockCapellaJson{}
+		converted, err = migration.V1Alpha1BeaconBlockBlindedCapellaToV2Blinded(b.BlindedCapella)
+	case *ethpbalpha.GenericBeaconBlock_Deneb:
+		ver = version.Deneb
+		container, err = blockContentsDenebJson(b.Deneb)
+	case *ethpbalpha.GenericBeaconBlock_BlindedDeneb:
+		ver, blinded = version.Deneb, true
+		container, err = blindedBlockDenebJson(b.BlindedDeneb)
+	default:
+		return "", false, nil, fmt.Errorf("unsupported block type %T", blk.Block)
+	}
+	if err != nil {
+		return "", false, nil, errors.Wrap(err, "could not convert block")
+	}
+	// Deneb blocks are built from already processed Capella JSON.
+	if converted != nil {
+		if err := middlewareJson(converted, container); err != nil {
+			return "", false, nil, err
+		}
+	}
+	data, err := json.Marshal(container)
+	if err != nil {
+		return "", false, nil, errors.Wrap(err, "could not marshal block")
+	}
+	return version.String(ver), blinded, data, nil
+}
+
+// middlewareJson fills the container with the JSON of the message, going through the same JSON processing as the
+// blocks served by the API middleware.
+func middlewareJson(msg proto.Message, container interface{}) error {
+	protoJson, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal block")
+	}
+	if err := json.Unmarshal(protoJson, container); err != nil {
+		return errors.Wrap(err, "could not unmarshal block")
+	}
+	if errJson := gatewaymiddleware.ProcessMiddlewareResponseFields(container); errJson != nil {
+		return errors.New(errJson.Msg())
+	}
+	return nil
+}
+
+// capellaJson converts a Deneb block to the JSON of a Capella block. Deneb only appends fields to the Capella block
+// body and execution payload, so the Capella v2 block decodes the Deneb wire format and drops the new fields.
+func capellaJson(denebBlk proto.Message, capellaBlk proto.Message, container interface{}) error {
+	marshaled, err := proto.Marshal(denebBlk)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal block")
+	}
+	if err := proto.Unmarshal(marshaled, capellaBlk); err != nil {
+		return errors.Wrap(err, "could not unmarshal block")
+	}
+	return middlewareJson(capellaBlk, container)
+}
+
+func blockContentsDenebJson(contents *ethpbalpha.BeaconBlockContentsDeneb) (*apimiddleware.BeaconBlockContentsDenebJson, error) {
+	blk := contents.Block
+	if blk == nil || blk.Body == nil || blk.Body.ExecutionPayload == nil {
+		return nil, errors.New("block is incomplete")
+	}
+	c := &apimiddleware.BeaconBlockCapellaJson{}
+	if err := capellaJson(blk, &ethpbv2.BeaconBlockCapella{}, c); err != nil {
+		return nil, err
+	}
+	payload := c.Body.ExecutionPayload
+	return &apimiddleware.BeaconBlockContentsDenebJson{
+		Block: &apimiddleware.BeaconBlockDenebJson{
+			Slot:          c.Slot,
+			ProposerIndex: c.ProposerIndex,
+			ParentRoot:    c.ParentRoot,
+			StateRoot:     c.StateRoot,
+			Body: &apimiddleware.BeaconBlockBodyDenebJson{
+				RandaoReveal:      c.Body.RandaoReveal,
+				Eth1Data:          c.Body.Eth1Data,
+				Graffiti:          c.Body.Graffiti,
+				ProposerSlashings: c.Body.ProposerSlashings,
+				AttesterSlashings: c.Body.AttesterSlashings,
+				Attestations:      c.Body.Attestations,
+				Deposits:          c.Body.Deposits,
+				VoluntaryExits:    c.Body.VoluntaryExits,
+				SyncAggregate:     c.Body.SyncAggregate,
+				ExecutionPayload: &apimiddleware.ExecutionPayloadDenebJson{
+					ParentHash:    payload.ParentHash,
+					FeeRecipient:  payload.FeeRecipient,
+					StateRoot:     payload.StateRoot,
+					ReceiptsRoot:  payload.ReceiptsRoot,
+					LogsBloom:     payload.LogsBloom,
+					PrevRandao:    payload.PrevRandao,
+					BlockNumber:   payload.BlockNumber,
+					GasLimit:      payload.GasLimit,
+					GasUsed:       payload.GasUsed,
+					TimeStamp:     payload.TimeStamp,
+					ExtraData:     payload.ExtraData,
+					BaseFeePerGas: payload.BaseFeePerGas,
+					BlockHash:     payload.BlockHash,
+					Transactions:  payload.Transactions,
+					Withdrawals:   payload.Withdrawals,
+					BlobGasUsed:   strconv.FormatUint(blk.Body.ExecutionPayload.BlobGasUsed, 10),
+					ExcessBlobGas: strconv.FormatUint(blk.Body.ExecutionPayload.ExcessBlobGas, 10),
+				},
+				BLSToExecutionChanges: c.Body.BLSToExecutionChanges,
+				BlobKzgCommitments:    hexSlice(blk.Body.BlobKzgCommitments),
+			},
+		},
+		KzgProofs: hexSlice(contents.KzgProofs),
+		Blobs:     hexSlice(contents.Blobs),
+	}, nil
+}
+
+func blindedBlockDenebJson(blk *ethpbalpha.BlindedBeaconBlockDeneb) (*apimiddleware.BlindedBeaconBlockDenebJson, error) {
+	if blk.Body == nil || blk.Body.ExecutionPayloadHeader == nil {
+		return nil, errors.New("block is incomplete")
+	}
+	c := &apimiddleware.BlindedBeaconBlockCapellaJson{}
+	if err := capellaJson(blk, &ethpbv2.BlindedBeaconBlockCapella{}, c); err != nil {
+		return nil, err
+	}
+	header := c.Body.ExecutionPayloadHeader
+	return &apimiddleware.BlindedBeaconBlockDenebJson{
+		Slot:          c.Slot,
+		ProposerIndex: c.ProposerIndex,
+		ParentRoot:    c.ParentRoot,
+		StateRoot:     c.StateRoot,
+		Body: &apimiddleware.BlindedBeaconBlockBodyDenebJson{
+			RandaoReveal:      c.Body.RandaoReveal,
+			Eth1Data:          c.Body.Eth1Data,
+			Graffiti:          c.Body.Graffiti,
+			ProposerSlashings: c.Body.ProposerSlashings,
+			AttesterSlashings: c.Body.AttesterSlashings,
+			Attestations:      c.Body.Attestations,
+			Deposits:          c.Body.Deposits,
+			VoluntaryExits:    c.Body.VoluntaryExits,
+			SyncAggregate:     c.Body.SyncAggregate,
+			ExecutionPayloadHeader: &apimiddleware.ExecutionPayloadHeaderDenebJson{
+				ParentHash:       header.ParentHash,
+				FeeRecipient:     header.FeeRecipient,
+				StateRoot:        header.StateRoot,
+				ReceiptsRoot:     header.ReceiptsRoot,
+				LogsBloom:        header.LogsBloom,
+				PrevRandao:       header.PrevRandao,
+				BlockNumber:      header.BlockNumber,
+				GasLimit:         header.GasLimit,
+				GasUsed:          header.GasUsed,
+				TimeStamp:        header.TimeStamp,
+				ExtraData:        header.ExtraData,
+				BaseFeePerGas:    header.BaseFeePerGas,
+				BlockHash:        header.BlockHash,
+				TransactionsRoot: header.TransactionsRoot,
+				WithdrawalsRoot:  header.WithdrawalsRoot,
+				BlobGasUsed:      strconv.FormatUint(blk.Body.ExecutionPayloadHeader.BlobGasUsed, 10),
+				ExcessBlobGas:    strconv.FormatUint(blk.Body.ExecutionPayloadHeader.ExcessBlobGas, 10),
+			},
+			BLSToExecutionChanges: c.Body.BLSToExecutionChanges,
+			BlobKzgCommitments:    hexSlice(blk.Body.BlobKzgCommitments),
+		},
+	}, nil
+}
+
+func hexSlice(items [][]byte) []string {
+	encoded := make([]string, len(items))
+	for i, item := range items {
+		encoded[i] = hexutil.Encode(item)
+	}
+	return encoded
+}
+
+func gweiToWei(gwei uint64) string {
+	return new(big.Int).Mul(new(big.Int).SetUint64(gwei), big.NewInt(1e9)).String()
+}
@@ -0,0 +1,258 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	mockChain "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+	v1alpha1validator "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/validator"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	mockSync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/initial-sync/testing"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	ethpbalpha "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestLiveness(t *testing.T) {
+	// Setup:
+	// Epoch 0 - both validators not live
+	// Epoch 1 - validator with index 1 is live
+	// Epoch 2 - validator with index 0 is live
+	oldSt, err := util.NewBeaconStateBellatrix()
+	require.NoError(t, err)
+	require.NoError(t, oldSt.AppendCurrentParticipationBits(0))
+	require.NoError(t, oldSt.AppendCurrentParticipationBits(0))
+	headSt, err := util.NewBeaconStateBellatrix()
+	require.NoError(t, err)
+	require.NoError(t, headSt.SetSlot(params.BeaconConfig().SlotsPerEpoch*2))
+	require.NoError(t, headSt.AppendPreviousParticipationBits(0))
+	require.NoError(t, headSt.AppendPreviousParticipationBits(1))
+	require.NoError(t, headSt.AppendCurrentParticipationBits(1))
+	require.NoError(t, headSt.AppendCurrentParticipationBits(0))
+
+	server := &Server{
+		HeadFetcher: &mockChain.ChainService{State: headSt},
+		Stater: &testutil.MockStater{
+			// We configure states for last slots of an epoch
+			StatesBySlot: map[primitives.Slot]state.BeaconState{
+				params.BeaconConfig().SlotsPerEpoch - 1:   oldSt,
+				params.BeaconConfig().SlotsPerEpoch*3 - 1: headSt,
+			},
+		},
+	}
+
+	liveness := func(t *testing.T, epoch string, body string) *httptest.ResponseRecorder {
+		url := "http://example.com/eth/v1/validator/liveness/" + epoch
+		request := httptest.NewRequest("POST", url, bytes.NewReader([]byte(body)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.Liveness(writer, request)
+		return writer
+	}
+
+	t.Run("old epoch", func(t *testing.T) {
+		writer := liveness(t, "0", `["0","1"]`)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetLivenessResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.DeepEqual(t, &Liveness{Index: "0", IsLive: false}, resp.Data[0])
+		assert.DeepEqual(t, &Liveness{Index: "1", IsLive: false}, resp.Data[1])
+	})
+	t.Run("previous epoch", func(t *testing.T) {
+		writer := liveness(t, "1", `["0","1"]`)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetLivenessResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.DeepEqual(t, &Liveness{Index: "0", IsLive: false}, resp.Data[0])
+		assert.DeepEqual(t, &Liveness{Index: "1", IsLive: true}, resp.Data[1])
+	})
+	t.Run("current epoch", func(t *testing.T) {
+		writer := liveness(t, "2", `["0","1"]`)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetLivenessResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.DeepEqual(t, &Liveness{Index: "0", IsLive: true}, resp.Data[0])
+		assert.DeepEqual(t, &Liveness{Index: "1", IsLive: false}, resp.Data[1])
+	})
+	t.Run("future epoch", func(t *testing.T) {
+		writer := liveness(t, "3", `["0","1"]`)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusBadRequest, e.Code)
+		assert.StringContains(t, "requested epoch cannot be in the future", e.Message)
+	})
+	t.Run("invalid epoch", func(t *testing.T) {
+		writer := liveness(t, "foo", `["0","1"]`)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Could not decode epoch", e.Message)
+	})
+	t.Run("no indices", func(t *testing.T) {
+		writer := liveness(t, "0", `[]`)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "No validator indices provided", e.Message)
+	})
+	t.Run("unknown validator index", func(t *testing.T) {
+		writer := liveness(t, "0", `["0","1","2"]`)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Validator index 2 is invalid", e.Message)
+	})
+}
+
+type mockBlockProducer struct {
+	block              *ethpbalpha.GenericBeaconBlock
+	builderBoostFactor uint64
+	withConsensusValue bool
+}
+
+func (m *mockBlockProducer) ProduceBeaconBlock(_ context.Context, req *ethpbalpha.BlockRequest, builderBoostFactor uint64, withConsensusValue bool) (*v1alpha1validator.ProducedBlock, error) {
+	m.builderBoostFactor = builderBoostFactor
+	m.withConsensusValue = withConsensusValue
+	return &v1alpha1validator.ProducedBlock{
+		Block:                 m.block,
+		// The payload value is not a whole number of Gwei, and must not be rounded.
+		ExecutionPayloadValue: big.NewInt(2000000001),
+		ConsensusBlockValue:   3,
+	}, nil
+}
+
+func TestProduceBlockV3(t *testing.T) {
+	randaoReveal := hexutil.Encode(make([]byte, fieldparams.BLSSignatureLength))
+	blindedBlock := util.NewBlindedBeaconBlockCapella().Block
+	blindedBlock.Slot = 5
+	producer := &mockBlockProducer{
+		block: &ethpbalpha.GenericBeaconBlock{Block: &ethpbalpha.GenericBeaconBlock_BlindedCapella{BlindedCapella: blindedBlock}},
+	}
+	server := &Server{
+		SyncChecker:           &mockSync.Sync{IsSyncing: false},
+		HeadFetcher:           &mockChain.ChainService{},
+		TimeFetcher:           &mockChain.ChainService{},
+		OptimisticModeFetcher: &mockChain.ChainService{},
+		BlockProducer:         producer,
+	}
+
+	produce := func(t *testing.T, query string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v3/validator/blocks/5?"+query, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.ProduceBlockV3(writer, request)
+		return writer
+	}
+
+	t.Run("blinded block", func(t *testing.T) {
+		writer := produce(t, "randao_reveal="+randaoReveal+"&builder_boost_factor=150")
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, uint64(150), producer.builderBoostFactor)
+		assert.Equal(t, true, producer.withConsensusValue)
+		assert.Equal(t, "capella", writer.Header().Get(versionHeader))
+		assert.Equal(t, "true", writer.Header().Get(executionPayloadBlindedHeader))
+		assert.Equal(t, "2000000001", writer.Header().Get(executionPayloadValueHeader))
+		assert.Equal(t, "3000000000", writer.Header().Get(consensusBlockValueHeader))
+		resp := &ProduceBlockV3Response{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "capella", resp.Version)
+		assert.Equal(t, true, resp.ExecutionPayloadBlinded)
+		assert.Equal(t, "2000000001", resp.ExecutionPayloadValue)
+		assert.Equal(t, "3000000000", resp.ConsensusBlockValue)
+		blk := &apimiddleware.BlindedBeaconBlockCapellaJson{}
+		require.NoError(t, json.Unmarshal(resp.Data, blk))
+		assert.Equal(t, "5", blk.Slot)
+		assert.Equal(t, hexutil.Encode(blindedBlock.ParentRoot), blk.ParentRoot)
+		assert.Equal(t, hexutil.Encode(blindedBlock.Body.ExecutionPayloadHeader.BlockHash), blk.Body.ExecutionPayloadHeader.BlockHash)
+	})
+	t.Run("default builder boost factor", func(t *testing.T) {
+		fullBlock := util.NewBeaconBlockCapella().Block
+		producer.block = &ethpbalpha.GenericBeaconBlock{Block: &ethpbalpha.GenericBeaconBlock_Capella{Capella: fullBlock}}
+		writer := produce(t, "randao_reveal="+randaoReveal)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, params.BeaconConfig().BuilderBoostFactor, producer.builderBoostFactor)
+		resp := &ProduceBlockV3Response{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, false, resp.ExecutionPayloadBlinded)
+		blk := &apimiddleware.BeaconBlockCapellaJson{}
+		require.NoError(t, json.Unmarshal(resp.Data, blk))
+		assert.Equal(t, 0, len(blk.Body.ExecutionPayload.Transactions))
+	})
+	t.Run("deneb block", func(t *testing.T) {
+		fullBlock := util.NewBeaconBlockDeneb().Block
+		fullBlock.Body.ExecutionPayload.BlobGasUsed = 6
+		fullBlock.Body.BlobKzgCommitments = [][]byte{bytesutil.PadTo([]byte{7}, 48)}
+		producer.block = &ethpbalpha.GenericBeaconBlock{Block: &ethpbalpha.GenericBeaconBlock_Deneb{Deneb: &ethpbalpha.BeaconBlockContentsDeneb{
+			Block:     fullBlock,
+			KzgProofs: [][]byte{bytesutil.PadTo([]byte{8}, 48)},
+			Blobs:     [][]byte{{9}},
+		}}}
+		writer := produce(t, "randao_reveal="+randaoReveal)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "deneb", writer.Header().Get(versionHeader))
+		assert.Equal(t, "false", writer.Header().Get(executionPayloadBlindedHeader))
+		resp := &ProduceBlockV3Response{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "deneb", resp.Version)
+		contents := &apimiddleware.BeaconBlockContentsDenebJson{}
+		require.NoError(t, json.Unmarshal(resp.Data, contents))
+		assert.Equal(t, hexutil.Encode(fullBlock.ParentRoot), contents.Block.ParentRoot)
+		assert.Equal(t, hexutil.Encode(fullBlock.Body.ExecutionPayload.BlockHash), contents.Block.Body.ExecutionPayload.BlockHash)
+		assert.Equal(t, "6", contents.Block.Body.ExecutionPayload.BlobGasUsed)
+		assert.Equal(t, "0", contents.Block.Body.ExecutionPayload.ExcessBlobGas)
+		assert.DeepEqual(t, []string{hexutil.Encode(fullBlock.Body.BlobKzgCommitments[0])}, contents.Block.Body.BlobKzgCommitments)
+		assert.DeepEqual(t, []string{hexutil.Encode(bytesutil.PadTo([]byte{8}, 48))}, contents.KzgProofs)
+		assert.DeepEqual(t, []string{"0x09"}, contents.Blobs)
+	})
+	t.Run("blinded deneb block", func(t *testing.T) {
+		blindedBlock := util.NewBlindedBeaconBlockDeneb().Block
+		blindedBlock.Body.ExecutionPayloadHeader.ExcessBlobGas = 10
+		producer.block = &ethpbalpha.GenericBeaconBlock{Block: &ethpbalpha.GenericBeaconBlock_BlindedDeneb{BlindedDeneb: blindedBlock}}
+		writer := produce(t, "randao_reveal="+randaoReveal)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "deneb", writer.Header().Get(versionHeader))
+		assert.Equal(t, "true", writer.Header().Get(executionPayloadBlindedHeader))
+		resp := &ProduceBlockV3Response{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		blk := &apimiddleware.BlindedBeaconBlockDenebJson{}
+		require.NoError(t, json.Unmarshal(resp.Data, blk))
+		assert.Equal(t, hexutil.Encode(blindedBlock.Body.ExecutionPayloadHeader.WithdrawalsRoot), blk.Body.ExecutionPayloadHeader.WithdrawalsRoot)
+		assert.Equal(t, "10", blk.Body.ExecutionPayloadHeader.ExcessBlobGas)
+		assert.Equal(t, 0, len(blk.Body.BlobKzgCommitments))
+	})
+	t.Run("invalid randao reveal", func(t *testing.T) {
+		writer := produce(t, "randao_reveal=0x01")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Invalid randao reveal", e.Message)
+	})
+	t.Run("invalid builder boost factor", func(t *testing.T) {
+		writer := produce(t, "randao_reveal="+randaoReveal+"&builder_boost_factor=-1")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("syncing", func(t *testing.T) {
+		server.SyncChecker = &mockSync.Sync{IsSyncing: true}
+		defer func() { server.SyncChecker = &mockSync.Sync{IsSyncing: false} }()
+		writer := produce(t, "randao_reveal="+randaoReveal)
+		assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	})
+}
@@ -0,0 +1,20 @@
+package validator
+
+import "encoding/json"
+
+type GetLivenessResponse struct {
+	Data []*Liveness `json:"data"`
+}
+
+type Liveness struct {
+	Index  string `json:"index"`
+	IsLive bool   `json:"is_live"`
+}
+
+type ProduceBlockV3Response struct {
+	Version                 string          `json:"version"`
+	ExecutionPayloadBlinded bool            `json:"execution_payload_blinded"`
+	ExecutionPayloadValue   string          `json:"execution_payload_value"`
+	ConsensusBlockValue     string          `json:"consensus_block_value"`
+	Data                    json.RawMessage `json:"data"`
+}
@@ -36,7 +36,7 @@ import (
 )
 
 var errInvalidValIndex = errors.New("invalid validator index")
-var errParticipation = status.Error(codes.Internal, "Could not obtain epoch participation")
+var errFutureEpoch = errors.New("requested epoch cannot be in the future")
 
 // GetAttesterDuties requests the beacon node to provide a set of attestation duties,
 // which should be performed by validators, for a particular epoch.
@@ -716,6 +716,15 @@ func (vs *Server) PrepareBeaconProposer(
 	defer span.End()
 	var feeRecipients []common.Address
 	var validatorIndices []primitives.ValidatorIndex
+	if vs.ValidatorMonitor != nil {
+		preparedIndices := make([]primitives.ValidatorIndex, len(request.Recipients))
+		for i, r := range request.Recipients {
+			preparedIndices[i] = r.ValidatorIndex
+		}
+		if err := vs.ValidatorMonitor.TrackProposerPreparations(ctx, preparedIndices); err != nil {
+			log.WithError(err).Warn("Could not track prepared proposers in the validator monitor")
+		}
+	}
 	newRecipients := make([]*ethpbv1.PrepareBeaconProposerRequest_FeeRecipientContainer, 0, len(request.Recipients))
 	for _, r := range request.Recipients {
 		f, err := vs.BeaconDB.FeeRecipientByValidatorID(ctx, r.ValidatorIndex)
@@ -1117,47 +1126,12 @@ func (vs *Server) GetLiveness(ctx context.Context, req *ethpbv2.GetLivenessReque
 	ctx, span := trace.StartSpan(ctx, "validator.GetLiveness")
 	defer span.End()
 
-	var participation []byte
-
-	// First we check if the requested epoch is the current epoch.
-	// If it is, then we won't be able to fetch the state at the end of the epoch.
-	// In that case we get participation info from the head state.
-	// We can also use the head state to get participation info for the previous epoch.
-	headSt, err := vs.HeadFetcher.HeadState(ctx)
+	participation, err := vs.epochParticipation(ctx, req.Epoch)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "Could not get head state")
-	}
-	currEpoch := slots.ToEpoch(headSt.Slot())
-	if req.Epoch > currEpoch {
-		return nil, status.Error(codes.InvalidArgument, "Requested epoch cannot be in the future")
-	}
-
-	var st state.BeaconState
-	if req.Epoch == currEpoch {
-		st = headSt
-		participation, err = st.CurrentEpochParticipation()
-		if err != nil {
-			return nil, errParticipation
-		}
-	} else if req.Epoch == currEpoch-1 {
-		st = headSt
-		participation, err = st.PreviousEpochParticipation()
-		if err != nil {
-			return nil, errParticipation
-		}
-	} else {
-		epochEnd, err := slots.EpochEnd(req.Epoch)
-		if err != nil {
-			return nil, status.Error(codes.Internal, "Could not get requested epoch's end slot")
-		}
-		st, err = vs.Stater.StateBySlot(ctx, epochEnd)
-		if err != nil {
-			return nil, status.Error(codes.Internal, "Could not get slot for requested epoch")
-		}
-		participation, err = st.CurrentEpochParticipation()
-		if err != nil {
-			return nil, errParticipation
+		if errors.Is(err, errFutureEpoch) {
+			return nil, status.Error(codes.InvalidArgument, "Requested epoch cannot be in the future")
 		}
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	resp := &ethpbv2.GetLivenessResponse{
@@ -1176,6 +1150,43 @@ func (vs *Server) GetLiveness(ctx context.Context, req *ethpbv2.GetLivenessReque
 	return resp, nil
 }
 
+// epochParticipation returns the participation flags of all validators in the given epoch.
+func (vs *Server) epochParticipation(ctx context.Context, epoch primitives.Epoch) ([]byte, error) {
+	// The current epoch has not ended yet, so we won't be able to fetch the state at the end of the epoch.
+	// In that case we get participation info from the head state.
+	// We can also use the head state to get participation info for the previous epoch.
+	headSt, err := vs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head state")
+	}
+	currEpoch := slots.ToEpoch(headSt.Slot())
+	if epoch > currEpoch {
+		return nil, errFutureEpoch
+	}
+
+	st := headSt
+	if epoch+1 < currEpoch {
+		epochEnd, err := slots.EpochEnd(epoch)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get requested epoch's end slot")
+		}
+		st, err = vs.Stater.StateBySlot(ctx, epochEnd)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get state for requested epoch")
+		}
+	}
+	var participation []byte
+	if epoch+1 == currEpoch {
+		participation, err = st.PreviousEpochParticipation()
+	} else {
+		participation, err = st.CurrentEpochParticipation()
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not obtain epoch participation")
+	}
+	return participation, nil
+}
+
 // attestationDependentRoot is get_block_root_at_slot(state, compute_start_slot_at_epoch(epoch - 1) - 1)
 // or the genesis block root in the case of underflow.
 func attestationDependentRoot(s state.BeaconState, epoch primitives.Epoch) ([]byte, error) {
@@ -6,10 +6,15 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
 	dbtesting "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/doubly-linked-tree"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpbalpha "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
@@ -142,3 +147,51 @@ func TestGetBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBlock_BackfilledSlot(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtesting.SetupDB(t)
+
+	// Build a chain from genesis to the origin checkpoint block at slot 10, with slot 4 skipped.
+	chain := make([]interfaces.ReadOnlySignedBeaconBlock, 0)
+	var parentRoot [32]byte
+	for slot := primitives.Slot(0); slot <= 10; slot++ {
+		if slot == 4 {
+			continue
+		}
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = parentRoot[:]
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		parentRoot, err = wsb.Block().HashTreeRoot()
+		require.NoError(t, err)
+		chain = append(chain, wsb)
+	}
+	genesisRoot, err := chain[0].Block().HashTreeRoot()
+	require.NoError(t, err)
+	origin := chain[len(chain)-1]
+	require.NoError(t, beaconDB.SaveBlocks(ctx, []interfaces.ReadOnlySignedBeaconBlock{chain[0], origin}))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+	// Backfill saves the blocks between genesis and the origin block.
+	require.NoError(t, beaconDB.SaveBackfillBlocks(ctx, chain[1:len(chain)-1], parentRoot))
+
+	chainService, err := blockchain.NewService(ctx,
+		blockchain.WithDatabase(beaconDB),
+		blockchain.WithForkChoiceStore(doublylinkedtree.New()),
+		blockchain.WithClockSynchronizer(startup.NewClockSynchronizer()),
+	)
+	require.NoError(t, err)
+	fetcher := &BeaconDbBlocker{
+		BeaconDB:         beaconDB,
+		ChainInfoFetcher: chainService,
+	}
+
+	blk, err := fetcher.Block(ctx, []byte("5"))
+	require.NoError(t, err)
+	require.NotNil(t, blk)
+	assert.Equal(t, primitives.Slot(5), blk.Block().Slot())
+	blk, err = fetcher.Block(ctx, []byte("4"))
+	require.NoError(t, err)
+	assert.Equal(t, nil, blk)
+}
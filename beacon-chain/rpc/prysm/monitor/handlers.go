@@ -0,0 +1,212 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	monitorservice "github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network"
+)
+
+// TrackedValidators is an HTTP handler returning the indices of the validators tracked by the validator monitor.
+func (s *Server) TrackedValidators(w http.ResponseWriter, _ *http.Request) {
+	network.WriteJson(w, &TrackedValidatorsResponse{Data: indicesToStrings(s.ValidatorMonitor.TrackedIndices())})
+}
+
+// TrackValidators is an HTTP handler adding the validators given by index or public key
+// in the request body to the validator monitor. It returns the updated list of tracked validators.
+func (s *Server) TrackValidators(w http.ResponseWriter, r *http.Request) {
+	indices, ok := s.requestedIndices(w, r)
+	if !ok {
+		return
+	}
+	if err := s.ValidatorMonitor.TrackValidators(r.Context(), indices); err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not track validators").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	network.WriteJson(w, &TrackedValidatorsResponse{Data: indicesToStrings(s.ValidatorMonitor.TrackedIndices())})
+}
+
+// UntrackValidators is an HTTP handler removing the validators given by index or public key
+// in the request body from the validator monitor. It returns the updated list of tracked validators.
+func (s *Server) UntrackValidators(w http.ResponseWriter, r *http.Request) {
+	indices, ok := s.requestedIndices(w, r)
+	if !ok {
+		return
+	}
+	s.ValidatorMonitor.UntrackValidators(indices)
+	network.WriteJson(w, &TrackedValidatorsResponse{Data: indicesToStrings(s.ValidatorMonitor.TrackedIndices())})
+}
+
+// Performance is an HTTP handler returning the latest and the aggregated performance of tracked validators.
+// The optional, repeatable "id" query parameter restricts the response to the given validator indices or
+// public keys. Without it, every tracked validator is reported.
+func (s *Server) Performance(w http.ResponseWriter, r *http.Request) {
+	var rawIds []string
+	for _, rawId := range r.URL.Query()["id"] {
+		for _, id := range strings.Split(rawId, ",") {
+			if id != "" {
+				rawIds = append(rawIds, id)
+			}
+		}
+	}
+	indices := s.ValidatorMonitor.TrackedIndices()
+	if len(rawIds) > 0 {
+		var ok bool
+		indices, ok = s.indicesFromIds(w, r, rawIds)
+		if !ok {
+			return
+		}
+	}
+
+	resp := &PerformanceResponse{Data: make([]*ValidatorPerformance, 0, len(indices))}
+	for _, idx := range indices {
+		latest, aggregated, err := s.ValidatorMonitor.Performance(idx)
+		if errors.Is(err, monitorservice.ErrValidatorNotTracked) {
+			errJson := &network.DefaultErrorJson{
+				Message: fmt.Sprintf("Validator %d is not tracked", idx),
+				Code:    http.StatusNotFound,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		if err != nil {
+			errJson := &network.DefaultErrorJson{
+				Message: errors.Wrapf(err, "could not get performance of validator %d", idx).Error(),
+				Code:    http.StatusInternalServerError,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		resp.Data = append(resp.Data, performanceFromMonitor(idx, latest, aggregated))
+	}
+	network.WriteJson(w, resp)
+}
+
+// requestedIndices decodes the JSON list of validator indices or public keys in the request body.
+func (s *Server) requestedIndices(w http.ResponseWriter, r *http.Request) ([]primitives.ValidatorIndex, bool) {
+	var rawIds []string
+	if r.Body != http.NoBody && r.Body != nil {
+		if err := json.NewDecoder(r.Body).Decode(&rawIds); err != nil && err != io.EOF {
+			errJson := &network.DefaultErrorJson{
+				Message: "Could not decode validators: " + err.Error(),
+				Code:    http.StatusBadRequest,
+			}
+			network.WriteError(w, errJson)
+			return nil, false
+		}
+	}
+	if len(rawIds) == 0 {
+		errJson := &network.DefaultErrorJson{
+			Message: "No validators provided",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return nil, false
+	}
+	return s.indicesFromIds(w, r, rawIds)
+}
+
+// indicesFromIds resolves validator indices or public keys to indices of validators known by the head state.
+func (s *Server) indicesFromIds(w http.ResponseWriter, r *http.Request, rawIds []string) ([]primitives.ValidatorIndex, bool) {
+	st, err := s.HeadFetcher.HeadStateReadOnly(r.Context())
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get head state").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return nil, false
+	}
+	numVals := uint64(st.NumValidators())
+	indices := make([]primitives.ValidatorIndex, len(rawIds))
+	for i, v := range rawIds {
+		index, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			pubkey, err := hexutil.Decode(v)
+			if err != nil || len(pubkey) != fieldparams.BLSPubkeyLength {
+				errJson := &network.DefaultErrorJson{
+					Message: fmt.Sprintf("%s is not a validator index or pubkey", v),
+					Code:    http.StatusBadRequest,
+				}
+				network.WriteError(w, errJson)
+				return nil, false
+			}
+			var ok bool
+			indices[i], ok = st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey))
+			if !ok {
+				errJson := &network.DefaultErrorJson{
+					Message: fmt.Sprintf("No validator index found for pubkey %#x", pubkey),
+					Code:    http.StatusBadRequest,
+				}
+				network.WriteError(w, errJson)
+				return nil, false
+			}
+			continue
+		}
+		if index >= numVals {
+			errJson := &network.DefaultErrorJson{
+				Message: fmt.Sprintf("Validator index %d is too large. Maximum allowed index is %d", index, numVals-1),
+				Code:    http.StatusBadRequest,
+			}
+			network.WriteError(w, errJson)
+			return nil, false
+		}
+		indices[i] = primitives.ValidatorIndex(index)
+	}
+	return indices, true
+}
+
+func indicesToStrings(indices []primitives.ValidatorIndex) []string {
+	result := make([]string, len(indices))
+	for i, idx := range indices {
+		result[i] = strconv.FormatUint(uint64(idx), 10)
+	}
+	return result
+}
+
+func performanceFromMonitor(
+	idx primitives.ValidatorIndex,
+	latest monitorservice.ValidatorLatestPerformance,
+	aggregated monitorservice.ValidatorAggregatedPerformance,
+) *ValidatorPerformance {
+	return &ValidatorPerformance{
+		ValidatorIndex: strconv.FormatUint(uint64(idx), 10),
+		Latest: &LatestPerformance{
+			AttestedSlot:  strconv.FormatUint(uint64(latest.AttestedSlot), 10),
+			InclusionSlot: strconv.FormatUint(uint64(latest.InclusionSlot), 10),
+			TimelySource:  latest.TimelySource,
+			TimelyTarget:  latest.TimelyTarget,
+			TimelyHead:    latest.TimelyHead,
+			Balance:       strconv.FormatUint(latest.Balance, 10),
+			BalanceChange: strconv.FormatInt(latest.BalanceChange, 10),
+		},
+		Aggregated: &AggregatedPerformance{
+			StartEpoch:                      strconv.FormatUint(uint64(aggregated.StartEpoch), 10),
+			StartBalance:                    strconv.FormatUint(aggregated.StartBalance, 10),
+			TotalAttestedCount:              strconv.FormatUint(aggregated.TotalAttestedCount, 10),
+			TotalRequestedCount:             strconv.FormatUint(aggregated.TotalRequestedCount, 10),
+			TotalDistance:                   strconv.FormatUint(aggregated.TotalDistance, 10),
+			TotalCorrectSource:              strconv.FormatUint(aggregated.TotalCorrectSource, 10),
+			TotalCorrectTarget:              strconv.FormatUint(aggregated.TotalCorrectTarget, 10),
+			TotalCorrectHead:                strconv.FormatUint(aggregated.TotalCorrectHead, 10),
+			TotalProposedCount:              strconv.FormatUint(aggregated.TotalProposedCount, 10),
+			TotalAggregations:               strconv.FormatUint(aggregated.TotalAggregations, 10),
+			TotalSyncCommitteeContributions: strconv.FormatUint(aggregated.TotalSyncCommitteeContributions, 10),
+			TotalSyncCommitteeAggregations:  strconv.FormatUint(aggregated.TotalSyncCommitteeAggregations, 10),
+		},
+	}
+}
@@ -0,0 +1,158 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	monitorservice "github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func setupServer(t *testing.T, tracked []primitives.ValidatorIndex) *Server {
+	st, _ := util.DeterministicGenesisState(t, 64)
+	chainService := &mock.ChainService{State: st}
+	m, err := monitorservice.NewService(context.Background(), &monitorservice.ValidatorMonitorConfig{HeadFetcher: chainService}, tracked)
+	require.NoError(t, err)
+	return &Server{ValidatorMonitor: m, HeadFetcher: chainService}
+}
+
+func TestTrackedValidators(t *testing.T) {
+	s := setupServer(t, []primitives.ValidatorIndex{5, 1})
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validator_monitor/validators", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.TrackedValidators(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &TrackedValidatorsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.DeepEqual(t, []string{"1", "5"}, resp.Data)
+}
+
+func TestTrackValidators(t *testing.T) {
+	t.Run("by index and pubkey", func(t *testing.T) {
+		s := setupServer(t, []primitives.ValidatorIndex{1})
+		st, err := s.HeadFetcher.HeadStateReadOnly(context.Background())
+		require.NoError(t, err)
+		pubkey := st.PubkeyAtIndex(7)
+
+		body, err := json.Marshal([]string{"3", hexutil.Encode(pubkey[:])})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validator_monitor/validators/track", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.TrackValidators(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &TrackedValidatorsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.DeepEqual(t, []string{"1", "3", "7"}, resp.Data)
+	})
+	t.Run("no validators", func(t *testing.T) {
+		s := setupServer(t, nil)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validator_monitor/validators/track", strings.NewReader("[]"))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.TrackValidators(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusBadRequest, e.Code)
+		assert.StringContains(t, "No validators provided", e.Message)
+	})
+	t.Run("index too large", func(t *testing.T) {
+		s := setupServer(t, nil)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validator_monitor/validators/track", strings.NewReader(`["64"]`))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.TrackValidators(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Validator index 64 is too large", e.Message)
+	})
+	t.Run("invalid id", func(t *testing.T) {
+		s := setupServer(t, nil)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validator_monitor/validators/track", strings.NewReader(`["foo"]`))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.TrackValidators(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "foo is not a validator index or pubkey", e.Message)
+	})
+}
+
+func TestUntrackValidators(t *testing.T) {
+	s := setupServer(t, []primitives.ValidatorIndex{1, 2, 3})
+
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validator_monitor/validators/untrack", strings.NewReader(`["2"]`))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.UntrackValidators(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &TrackedValidatorsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.DeepEqual(t, []string{"1", "3"}, resp.Data)
+}
+
+func TestPerformance(t *testing.T) {
+	t.Run("all tracked validators", func(t *testing.T) {
+		s := setupServer(t, []primitives.ValidatorIndex{1, 2})
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validator_monitor/performance", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.Performance(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &PerformanceResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "1", resp.Data[0].ValidatorIndex)
+		assert.Equal(t, "2", resp.Data[1].ValidatorIndex)
+		assert.Equal(t, "0", resp.Data[0].Aggregated.TotalAttestedCount)
+	})
+	t.Run("selected validators", func(t *testing.T) {
+		s := setupServer(t, []primitives.ValidatorIndex{1, 2, 3})
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validator_monitor/performance?id=3,1", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.Performance(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &PerformanceResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "3", resp.Data[0].ValidatorIndex)
+		assert.Equal(t, "1", resp.Data[1].ValidatorIndex)
+	})
+	t.Run("validator not tracked", func(t *testing.T) {
+		s := setupServer(t, []primitives.ValidatorIndex{1})
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validator_monitor/performance?id=4", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.Performance(writer, request)
+		require.Equal(t, http.StatusNotFound, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Validator 4 is not tracked", e.Message)
+	})
+}
@@ -0,0 +1,13 @@
+// Package monitor defines the HTTP endpoints controlling which validators are tracked
+// by the beacon node's validator monitor and reporting their performance.
+package monitor
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+	monitorservice "github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor"
+)
+
+type Server struct {
+	ValidatorMonitor monitorservice.TrackedValidatorsManager
+	HeadFetcher      blockchain.HeadFetcher
+}
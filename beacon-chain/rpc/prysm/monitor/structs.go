@@ -0,0 +1,40 @@
+package monitor
+
+type TrackedValidatorsResponse struct {
+	Data []string `json:"data"`
+}
+
+type PerformanceResponse struct {
+	Data []*ValidatorPerformance `json:"data"`
+}
+
+type ValidatorPerformance struct {
+	ValidatorIndex string                 `json:"validator_index"`
+	Latest         *LatestPerformance     `json:"latest"`
+	Aggregated     *AggregatedPerformance `json:"aggregated"`
+}
+
+type LatestPerformance struct {
+	AttestedSlot  string `json:"attested_slot"`
+	InclusionSlot string `json:"inclusion_slot"`
+	TimelySource  bool   `json:"timely_source"`
+	TimelyTarget  bool   `json:"timely_target"`
+	TimelyHead    bool   `json:"timely_head"`
+	Balance       string `json:"balance"`
+	BalanceChange string `json:"balance_change"`
+}
+
+type AggregatedPerformance struct {
+	StartEpoch                      string `json:"start_epoch"`
+	StartBalance                    string `json:"start_balance"`
+	TotalAttestedCount              string `json:"total_attested_count"`
+	TotalRequestedCount             string `json:"total_requested_count"`
+	TotalDistance                   string `json:"total_distance"`
+	TotalCorrectSource              string `json:"total_correct_source"`
+	TotalCorrectTarget              string `json:"total_correct_target"`
+	TotalCorrectHead                string `json:"total_correct_head"`
+	TotalProposedCount              string `json:"total_proposed_count"`
+	TotalAggregations               string `json:"total_aggregations"`
+	TotalSyncCommitteeContributions string `json:"total_sync_committee_contributions"`
+	TotalSyncCommitteeAggregations  string `json:"total_sync_committee_aggregations"`
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"sync"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/api/pagination"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
@@ -13,12 +15,15 @@ import (
 	coreTime "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/validators"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/cmd"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/attestation"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	"google.golang.org/grpc/codes"
@@ -750,6 +755,16 @@ func (bs *Server) GetValidatorPerformance(
 		return validatorIndices[i] < validatorIndices[j]
 	})
 
+	// Since Altair, the beacon state only keeps the participation flags of the validators, so the inclusion
+	// of their attestations is looked up in the blocks, along with their proposals and sync committee messages.
+	var inclusions *epochInclusions
+	if headState.Version() >= version.Altair {
+		inclusions, err = bs.prevEpochAttestationInclusions(ctx, headState)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get attestation inclusions: %v", err)
+		}
+	}
+
 	currentEpoch := coreTime.CurrentEpoch(headState)
 	responseCap = len(validatorIndices)
 	pubKeys := make([][]byte, 0, responseCap)
@@ -760,6 +775,10 @@ func (bs *Server) GetValidatorPerformance(
 	correctlyVotedTarget := make([]bool, 0, responseCap)
 	correctlyVotedHead := make([]bool, 0, responseCap)
 	inactivityScores := make([]uint64, 0, responseCap)
+	inclusionSlots := make([]primitives.Slot, 0, responseCap)
+	inclusionDistances := make([]primitives.Slot, 0, responseCap)
+	proposedBlocks := make([]uint64, 0, responseCap)
+	includedSyncCommitteeMessages := make([]uint64, 0, responseCap)
 	// Append performance summaries.
 	// Also track missing validators using public keys.
 	for _, idx := range validatorIndices {
@@ -789,9 +808,19 @@ func (bs *Server) GetValidatorPerformance(
 
 		if headState.Version() == version.Phase0 {
 			correctlyVotedSource = append(correctlyVotedSource, summary.IsPrevEpochAttester)
+			inclusionSlots = append(inclusionSlots, summary.InclusionSlot)
+			inclusionDistances = append(inclusionDistances, summary.InclusionDistance)
 		} else {
 			correctlyVotedSource = append(correctlyVotedSource, summary.IsPrevEpochSourceAttester)
 			inactivityScores = append(inactivityScores, summary.InactivityScore)
+			proposedBlocks = append(proposedBlocks, inclusions.proposals[idx])
+			includedSyncCommitteeMessages = append(includedSyncCommitteeMessages, inclusions.syncMessages[idx])
+			inclusion, ok := inclusions.attestation(idx)
+			if !ok {
+				inclusion = attestationInclusion{slot: params.BeaconConfig().FarFutureSlot, distance: params.BeaconConfig().FarFutureSlot}
+			}
+			inclusionSlots = append(inclusionSlots, inclusion.slot)
+			inclusionDistances = append(inclusionDistances, inclusion.distance)
 		}
 	}
 
@@ -805,9 +834,204 @@ func (bs *Server) GetValidatorPerformance(
 		BalancesAfterEpochTransition:  afterTransitionBalances,
 		MissingValidators:             missingValidators,
 		InactivityScores:              inactivityScores, // Only populated in Altair
+		InclusionSlots:                inclusionSlots,
+		InclusionDistances:            inclusionDistances,
+		ProposedBlocks:                proposedBlocks,                // Only populated in Altair
+		IncludedSyncCommitteeMessages: includedSyncCommitteeMessages, // Only populated in Altair
 	}, nil
 }
 
+// attestationInclusion is the earliest inclusion of the attestation of a validator in a canonical block.
+type attestationInclusion struct {
+	slot     primitives.Slot
+	distance primitives.Slot
+}
+
+// epochInclusions is the participation of every validator in the canonical blocks of an epoch.
+type epochInclusions struct {
+	epoch    primitives.Epoch
+	headRoot [32]byte
+	// attestations is indexed by validator index. A zero slot means that the attestation of the
+	// validator was not included, as no attestation can be included at the genesis slot.
+	attestations []attestationInclusion
+	proposals    map[primitives.ValidatorIndex]uint64
+	syncMessages map[primitives.ValidatorIndex]uint64
+}
+
+// attestation returns the earliest inclusion of the attestation of the validator, if any.
+func (e *epochInclusions) attestation(idx primitives.ValidatorIndex) (attestationInclusion, bool) {
+	if uint64(idx) >= uint64(len(e.attestations)) || e.attestations[idx].slot == 0 {
+		return attestationInclusion{}, false
+	}
+	return e.attestations[idx], true
+}
+
+// epochInclusionsCache keeps the inclusions of the last epoch looked up, as every validator client
+// asks for the performance of its validators in the same epoch.
+type epochInclusionsCache struct {
+	sync.Mutex
+	inclusions *epochInclusions
+}
+
+// prevEpochAttestationInclusions returns the inclusions in canonical blocks of the attestations, blocks
+// and sync committee messages of the validators for the previous epoch of the state. The inclusions
+// are cached until the epoch or the head of the chain changes.
+func (bs *Server) prevEpochAttestationInclusions(ctx context.Context, st state.ReadOnlyBeaconState) (*epochInclusions, error) {
+	headRoot, err := bs.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head root")
+	}
+	prevEpoch := coreTime.PrevEpoch(st)
+
+	bs.inclusionsCache.Lock()
+	defer bs.inclusionsCache.Unlock()
+	if cached := bs.inclusionsCache.inclusions; cached != nil && cached.epoch == prevEpoch && cached.headRoot == bytesutil.ToBytes32(headRoot) {
+		return cached, nil
+	}
+	inclusions, err := bs.lookupEpochInclusions(ctx, st, prevEpoch)
+	if err != nil {
+		return nil, err
+	}
+	inclusions.headRoot = bytesutil.ToBytes32(headRoot)
+	bs.inclusionsCache.inclusions = inclusions
+	return inclusions, nil
+}
+
+// lookupEpochInclusions scans the canonical blocks from the start of the epoch up to the slot of the state.
+// The proposals of the epoch are the canonical blocks of its slots, and its sync committee messages are
+// included by the blocks of the following slots, as they sign the block root of the previous slot.
+func (bs *Server) lookupEpochInclusions(ctx context.Context, st state.ReadOnlyBeaconState, epoch primitives.Epoch) (*epochInclusions, error) {
+	inclusions := &epochInclusions{
+		epoch:        epoch,
+		attestations: make([]attestationInclusion, st.NumValidators()),
+		proposals:    make(map[primitives.ValidatorIndex]uint64),
+		syncMessages: make(map[primitives.ValidatorIndex]uint64),
+	}
+	if epoch == coreTime.CurrentEpoch(st) {
+		return inclusions, nil
+	}
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, err
+	}
+	endSlot, err := slots.EpochEnd(epoch)
+	if err != nil {
+		return nil, err
+	}
+	blks, roots, err := bs.BeaconDB.Blocks(ctx, filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(st.Slot()))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get blocks")
+	}
+	syncCommittees := make(map[uint64][]primitives.ValidatorIndex)
+	for i, blk := range blks {
+		canonical, err := bs.CanonicalFetcher.IsCanonical(ctx, roots[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "could not determine if block is canonical")
+		}
+		if !canonical {
+			continue
+		}
+		blkSlot := blk.Block().Slot()
+		if blkSlot <= endSlot {
+			inclusions.proposals[blk.Block().ProposerIndex()]++
+		}
+		if blkSlot > startSlot && blkSlot <= endSlot+1 && blk.Version() >= version.Altair {
+			if err := bs.countSyncCommitteeMessages(ctx, st, blk, roots[i], syncCommittees, inclusions.syncMessages); err != nil {
+				return nil, err
+			}
+		}
+		if blkSlot == startSlot {
+			continue
+		}
+		for _, att := range blk.Block().Body().Attestations() {
+			if att.Data.Target.Epoch != epoch {
+				continue
+			}
+			committee, err := helpers.BeaconCommitteeFromState(ctx, st, att.Data.Slot, att.Data.CommitteeIndex)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not get committee")
+			}
+			attestingIndices, err := attestation.AttestingIndices(att.AggregationBits, committee)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not get attesting indices")
+			}
+			for _, idx := range attestingIndices {
+				if idx >= uint64(len(inclusions.attestations)) {
+					continue
+				}
+				if inclusion := inclusions.attestations[idx]; inclusion.slot != 0 && inclusion.slot <= blkSlot {
+					continue
+				}
+				inclusions.attestations[idx] = attestationInclusion{slot: blkSlot, distance: blkSlot - att.Data.Slot}
+			}
+		}
+	}
+	return inclusions, nil
+}
+
+// countSyncCommitteeMessages counts the sync committee messages included by the sync aggregate of the block.
+// The sync aggregate of a block is signed by the sync committee of the period of the block, so the sync
+// committees are kept by period. Only a period which is over needs the state of the block to be loaded.
+func (bs *Server) countSyncCommitteeMessages(
+	ctx context.Context,
+	st state.ReadOnlyBeaconState,
+	blk interfaces.ReadOnlySignedBeaconBlock,
+	root [32]byte,
+	committees map[uint64][]primitives.ValidatorIndex,
+	counts map[primitives.ValidatorIndex]uint64,
+) error {
+	aggregate, err := blk.Block().Body().SyncAggregate()
+	if err != nil {
+		return errors.Wrap(err, "could not get sync aggregate")
+	}
+	positions := aggregate.SyncCommitteeBits.BitIndices()
+	if len(positions) == 0 {
+		return nil
+	}
+	period := slots.SyncCommitteePeriod(slots.ToEpoch(blk.Block().Slot()))
+	committee, ok := committees[period]
+	if !ok {
+		committeeState := st
+		if period != slots.SyncCommitteePeriod(coreTime.CurrentEpoch(st)) {
+			if committeeState, err = bs.StateGen.StateByRoot(ctx, root); err != nil {
+				return errors.Wrap(err, "could not get state of previous sync committee period")
+			}
+		}
+		if committee, err = syncCommitteeIndices(committeeState); err != nil {
+			return err
+		}
+		committees[period] = committee
+	}
+	// A validator can appear several times in the sync committee, but sends a single message per slot.
+	included := make(map[primitives.ValidatorIndex]bool)
+	for _, position := range positions {
+		if position < len(committee) {
+			included[committee[position]] = true
+		}
+	}
+	for idx := range included {
+		counts[idx]++
+	}
+	return nil
+}
+
+// syncCommitteeIndices returns the validator indices of the current sync committee of the state, by position in the committee.
+func syncCommitteeIndices(st state.ReadOnlyBeaconState) ([]primitives.ValidatorIndex, error) {
+	committee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current sync committee")
+	}
+	indices := make([]primitives.ValidatorIndex, len(committee.Pubkeys))
+	for i, pubKey := range committee.Pubkeys {
+		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubKey))
+		if !ok {
+			return nil, fmt.Errorf("sync committee member %#x is not in the state", pubKey)
+		}
+		indices[i] = idx
+	}
+	return indices, nil
+}
+
 // GetIndividualVotes retrieves individual voting status of validators.
 func (bs *Server) GetIndividualVotes(
 	ctx context.Context,
@@ -1872,6 +1872,8 @@ func TestGetValidatorPerformance_OK(t *testing.T) {
 		BalancesBeforeEpochTransition: []uint64{101, 102},
 		BalancesAfterEpochTransition:  []uint64{0, 0},
 		MissingValidators:             [][]byte{publicKey1[:]},
+		InclusionSlots:                []primitives.Slot{params.BeaconConfig().FarFutureSlot, params.BeaconConfig().FarFutureSlot},
+		InclusionDistances:            []primitives.Slot{params.BeaconConfig().FarFutureSlot, params.BeaconConfig().FarFutureSlot},
 	}
 
 	res, err := bs.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{
@@ -1941,6 +1943,8 @@ func TestGetValidatorPerformance_Indices(t *testing.T) {
 		BalancesBeforeEpochTransition: []uint64{extraBal, extraBal + params.BeaconConfig().GweiPerEth},
 		BalancesAfterEpochTransition:  []uint64{vp[1].AfterEpochTransitionBalance, vp[2].AfterEpochTransitionBalance},
 		MissingValidators:             [][]byte{publicKey1[:]},
+		InclusionSlots:                []primitives.Slot{params.BeaconConfig().FarFutureSlot, params.BeaconConfig().FarFutureSlot},
+		InclusionDistances:            []primitives.Slot{params.BeaconConfig().FarFutureSlot, params.BeaconConfig().FarFutureSlot},
 	}
 
 	res, err := bs.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{
@@ -2011,6 +2015,8 @@ func TestGetValidatorPerformance_IndicesPubkeys(t *testing.T) {
 		BalancesBeforeEpochTransition: []uint64{extraBal, extraBal + params.BeaconConfig().GweiPerEth},
 		BalancesAfterEpochTransition:  []uint64{vp[1].AfterEpochTransitionBalance, vp[2].AfterEpochTransitionBalance},
 		MissingValidators:             [][]byte{publicKey1[:]},
+		InclusionSlots:                []primitives.Slot{params.BeaconConfig().FarFutureSlot, params.BeaconConfig().FarFutureSlot},
+		InclusionDistances:            []primitives.Slot{params.BeaconConfig().FarFutureSlot, params.BeaconConfig().FarFutureSlot},
 	}
 	// Index 2 and publicKey3 points to the same validator.
 	// Should not return duplicates.
@@ -2064,9 +2070,11 @@ func TestGetValidatorPerformanceAltair_OK(t *testing.T) {
 	require.NoError(t, headState.SetBalances([]uint64{100, 101, 102}))
 	offset := int64(headState.Slot().Mul(params.BeaconConfig().SecondsPerSlot))
 	bs := &Server{
+		BeaconDB: dbTest.SetupDB(t),
 		HeadFetcher: &mock.ChainService{
 			State: headState,
 		},
+		CanonicalFetcher:   &mock.ChainService{},
 		GenesisTimeFetcher: &mock.ChainService{Genesis: time.Now().Add(time.Duration(-1*offset) * time.Second)},
 		SyncChecker:        &mockSync.Sync{IsSyncing: false},
 	}
@@ -2080,6 +2088,10 @@ func TestGetValidatorPerformanceAltair_OK(t *testing.T) {
 		BalancesAfterEpochTransition:  []uint64{0, 0},
 		MissingValidators:             [][]byte{publicKey1[:]},
 		InactivityScores:              []uint64{0, 0},
+		ProposedBlocks:                []uint64{0, 0},
+		IncludedSyncCommitteeMessages: []uint64{0, 0},
+		InclusionSlots:                []primitives.Slot{params.BeaconConfig().FarFutureSlot, params.BeaconConfig().FarFutureSlot},
+		InclusionDistances:            []primitives.Slot{params.BeaconConfig().FarFutureSlot, params.BeaconConfig().FarFutureSlot},
 	}
 
 	res, err := bs.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{
@@ -2132,9 +2144,11 @@ func TestGetValidatorPerformanceBellatrix_OK(t *testing.T) {
 	require.NoError(t, headState.SetBalances([]uint64{100, 101, 102}))
 	offset := int64(headState.Slot().Mul(params.BeaconConfig().SecondsPerSlot))
 	bs := &Server{
+		BeaconDB: dbTest.SetupDB(t),
 		HeadFetcher: &mock.ChainService{
 			State: headState,
 		},
+		CanonicalFetcher:   &mock.ChainService{},
 		GenesisTimeFetcher: &mock.ChainService{Genesis: time.Now().Add(time.Duration(-1*offset) * time.Second)},
 		SyncChecker:        &mockSync.Sync{IsSyncing: false},
 	}
@@ -2148,6 +2162,10 @@ func TestGetValidatorPerformanceBellatrix_OK(t *testing.T) {
 		BalancesAfterEpochTransition:  []uint64{0, 0},
 		MissingValidators:             [][]byte{publicKey1[:]},
 		InactivityScores:              []uint64{0, 0},
+		ProposedBlocks:                []uint64{0, 0},
+		IncludedSyncCommitteeMessages: []uint64{0, 0},
+		InclusionSlots:                []primitives.Slot{params.BeaconConfig().FarFutureSlot, params.BeaconConfig().FarFutureSlot},
+		InclusionDistances:            []primitives.Slot{params.BeaconConfig().FarFutureSlot, params.BeaconConfig().FarFutureSlot},
 	}
 
 	res, err := bs.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{
@@ -2200,9 +2218,11 @@ func TestGetValidatorPerformanceCapella_OK(t *testing.T) {
 	require.NoError(t, headState.SetBalances([]uint64{100, 101, 102}))
 	offset := int64(headState.Slot().Mul(params.BeaconConfig().SecondsPerSlot))
 	bs := &Server{
+		BeaconDB: dbTest.SetupDB(t),
 		HeadFetcher: &mock.ChainService{
 			State: headState,
 		},
+		CanonicalFetcher:   &mock.ChainService{},
 		GenesisTimeFetcher: &mock.ChainService{Genesis: time.Now().Add(time.Duration(-1*offset) * time.Second)},
 		SyncChecker:        &mockSync.Sync{IsSyncing: false},
 	}
@@ -2216,6 +2236,10 @@ func TestGetValidatorPerformanceCapella_OK(t *testing.T) {
 		BalancesAfterEpochTransition:  []uint64{0, 0},
 		MissingValidators:             [][]byte{publicKey1[:]},
 		InactivityScores:              []uint64{0, 0},
+		ProposedBlocks:                []uint64{0, 0},
+		IncludedSyncCommitteeMessages: []uint64{0, 0},
+		InclusionSlots:                []primitives.Slot{params.BeaconConfig().FarFutureSlot, params.BeaconConfig().FarFutureSlot},
+		InclusionDistances:            []primitives.Slot{params.BeaconConfig().FarFutureSlot, params.BeaconConfig().FarFutureSlot},
 	}
 
 	res, err := bs.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{
@@ -2227,6 +2251,156 @@ func TestGetValidatorPerformanceCapella_OK(t *testing.T) {
 	}
 }
 
+func TestGetValidatorPerformanceAltair_AttestationInclusions(t *testing.T) {
+	helpers.ClearCache()
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MinimalSpecConfig())
+
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	headState, _ := util.DeterministicGenesisStateAltair(t, 32)
+	require.NoError(t, headState.SetSlot(params.BeaconConfig().SlotsPerEpoch.Mul(2)))
+
+	attSlot := params.BeaconConfig().SlotsPerEpoch + 1
+	committee, err := helpers.BeaconCommitteeFromState(ctx, headState, attSlot, 0)
+	require.NoError(t, err)
+	require.Equal(t, true, len(committee) > 2)
+	attestation := func(bit uint64) *ethpb.Attestation {
+		bits := bitfield.NewBitlist(uint64(len(committee)))
+		bits.SetBitAt(bit, true)
+		return util.HydrateAttestation(&ethpb.Attestation{
+			AggregationBits: bits,
+			Data: &ethpb.AttestationData{
+				Slot:   attSlot,
+				Target: &ethpb.Checkpoint{Epoch: 1},
+			},
+		})
+	}
+	saveBlock := func(slot primitives.Slot, atts ...*ethpb.Attestation) [32]byte {
+		blk := util.NewBeaconBlockAltair()
+		blk.Block.Slot = slot
+		blk.Block.Body.Attestations = atts
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, beaconDB, blk)
+		return root
+	}
+	// The first validator of the committee is included twice, the second one only in a block which is not canonical.
+	canonical := map[[32]byte]bool{
+		saveBlock(attSlot+2, attestation(0)): true,
+		saveBlock(attSlot+3, attestation(0)): true,
+	}
+	saveBlock(attSlot+1, attestation(1))
+
+	offset := int64(headState.Slot().Mul(params.BeaconConfig().SecondsPerSlot))
+	bs := &Server{
+		BeaconDB:           beaconDB,
+		HeadFetcher:        &mock.ChainService{State: headState},
+		CanonicalFetcher:   &mock.ChainService{CanonicalRoots: canonical},
+		GenesisTimeFetcher: &mock.ChainService{Genesis: time.Now().Add(time.Duration(-1*offset) * time.Second)},
+		SyncChecker:        &mockSync.Sync{IsSyncing: false},
+	}
+	res, err := bs.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{
+		Indices: []primitives.ValidatorIndex{committee[0], committee[1]},
+	})
+	require.NoError(t, err)
+	want := map[primitives.ValidatorIndex][2]primitives.Slot{
+		committee[0]: {attSlot + 2, 2},
+		committee[1]: {params.BeaconConfig().FarFutureSlot, params.BeaconConfig().FarFutureSlot},
+	}
+	require.Equal(t, 2, len(res.PublicKeys))
+	for i, pubKey := range res.PublicKeys {
+		idx, ok := headState.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubKey))
+		require.Equal(t, true, ok)
+		assert.Equal(t, want[idx][0], res.InclusionSlots[i])
+		assert.Equal(t, want[idx][1], res.InclusionDistances[i])
+	}
+}
+
+func TestGetValidatorPerformanceAltair_ProposalsAndSyncCommitteeMessages(t *testing.T) {
+	helpers.ClearCache()
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MinimalSpecConfig())
+
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	headState, _ := util.DeterministicGenesisStateAltair(t, 32)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	require.NoError(t, headState.SetSlot(slotsPerEpoch.Mul(2)))
+	syncCommittee, err := altair.NextSyncCommittee(ctx, headState)
+	require.NoError(t, err)
+	require.NoError(t, headState.SetCurrentSyncCommittee(syncCommittee))
+	members, err := syncCommitteeIndices(headState)
+	require.NoError(t, err)
+
+	wantProposals := make(map[primitives.ValidatorIndex]uint64)
+	wantSyncMessages := make(map[primitives.ValidatorIndex]uint64)
+	canonical := make(map[[32]byte]bool)
+	saveBlock := func(slot primitives.Slot, proposer primitives.ValidatorIndex, isCanonical, countProposal, countSync bool, positions ...uint64) {
+		blk := util.NewBeaconBlockAltair()
+		blk.Block.Slot = slot
+		blk.Block.ProposerIndex = proposer
+		included := make(map[primitives.ValidatorIndex]bool)
+		for _, position := range positions {
+			blk.Block.Body.SyncAggregate.SyncCommitteeBits.SetBitAt(position, true)
+			included[members[position]] = true
+		}
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, beaconDB, blk)
+		if isCanonical {
+			canonical[root] = true
+		}
+		if countProposal {
+			wantProposals[proposer]++
+		}
+		if countSync {
+			for idx := range included {
+				wantSyncMessages[idx]++
+			}
+		}
+	}
+	// The sync aggregate of the first block of the epoch covers the last slot of the epoch before.
+	saveBlock(slotsPerEpoch, 1, true, true, false, 0)
+	saveBlock(slotsPerEpoch+1, 1, true, true, true, 0, 1)
+	saveBlock(slotsPerEpoch+2, 2, false, false, false, 0, 1)
+	// The first block of the next epoch includes the sync committee messages of the last slot of the epoch.
+	saveBlock(slotsPerEpoch.Mul(2), 2, true, false, true, 0)
+
+	offset := int64(headState.Slot().Mul(params.BeaconConfig().SecondsPerSlot))
+	headFetcher := &mock.ChainService{State: headState, Root: bytesutil.PadTo([]byte("head"), 32)}
+	bs := &Server{
+		BeaconDB:           beaconDB,
+		HeadFetcher:        headFetcher,
+		CanonicalFetcher:   &mock.ChainService{CanonicalRoots: canonical},
+		GenesisTimeFetcher: &mock.ChainService{Genesis: time.Now().Add(time.Duration(-1*offset) * time.Second)},
+		SyncChecker:        &mockSync.Sync{IsSyncing: false},
+	}
+	req := &ethpb.ValidatorPerformanceRequest{
+		Indices: []primitives.ValidatorIndex{1, 2, members[0], members[1]},
+	}
+	check := func() {
+		res, err := bs.GetValidatorPerformance(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, len(res.PublicKeys), len(res.ProposedBlocks))
+		require.Equal(t, len(res.PublicKeys), len(res.IncludedSyncCommitteeMessages))
+		for i, pubKey := range res.PublicKeys {
+			idx, ok := headState.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubKey))
+			require.Equal(t, true, ok)
+			assert.Equal(t, wantProposals[idx], res.ProposedBlocks[i], "proposals of validator %d", idx)
+			assert.Equal(t, wantSyncMessages[idx], res.IncludedSyncCommitteeMessages[i], "sync committee messages of validator %d", idx)
+		}
+	}
+	check()
+
+	// The inclusions are cached until the head changes.
+	saveBlock(slotsPerEpoch+3, 2, true, false, false)
+	check()
+	headFetcher.Root = bytesutil.PadTo([]byte("new head"), 32)
+	wantProposals[2]++
+	check()
+}
+
 func BenchmarkListValidatorBalances(b *testing.B) {
 	b.StopTimer()
 	beaconDB := dbTest.SetupDB(b)
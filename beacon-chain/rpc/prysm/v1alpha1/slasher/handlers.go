@@ -0,0 +1,187 @@
+package slasher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	slasherservice "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/v4/network"
+)
+
+const (
+	// AttesterSlashingTopic represents a newly detected attester slashing event topic.
+	AttesterSlashingTopic = "attester_slashing"
+	// ProposerSlashingTopic represents a newly detected proposer slashing event topic.
+	ProposerSlashingTopic = "proposer_slashing"
+)
+
+var topicsHandled = map[string]bool{
+	AttesterSlashingTopic: true,
+	ProposerSlashingTopic: true,
+}
+
+// SlashableAttestation is an HTTP handler returning the attester slashings caused by the
+// indexed attestation in the request body, if it were to be signed and broadcast.
+func (s *Server) SlashableAttestation(w http.ResponseWriter, r *http.Request) {
+	var req IndexedAttestation
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Could not decode request body: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	att, err := req.ToConsensus()
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Invalid attestation: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	attesterSlashings, err := s.SlashingChecker.IsSlashableAttestation(r.Context(), att)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not determine if attestation is slashable").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	resp := &SlashableAttestationResponse{Data: make([]*AttesterSlashing, len(attesterSlashings))}
+	for i, sl := range attesterSlashings {
+		resp.Data[i] = attesterSlashingFromConsensus(sl)
+	}
+	network.WriteJson(w, resp)
+}
+
+// SlashableBlock is an HTTP handler returning the proposer slashing caused by the
+// signed block header in the request body, if it were to be broadcast.
+func (s *Server) SlashableBlock(w http.ResponseWriter, r *http.Request) {
+	var req SignedBeaconBlockHeader
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Could not decode request body: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	header, err := req.ToConsensus()
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "Invalid block header: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	proposerSlashing, err := s.SlashingChecker.IsSlashableBlock(r.Context(), header)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not determine if block is slashable").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	resp := &SlashableBlockResponse{Data: []*ProposerSlashing{}}
+	if proposerSlashing != nil {
+		resp.Data = append(resp.Data, proposerSlashingFromConsensus(proposerSlashing))
+	}
+	network.WriteJson(w, resp)
+}
+
+// StreamSlashings is an HTTP handler streaming the slashings detected by the slasher as server-sent events.
+// The optional "topics" query parameter restricts the stream to the given comma-separated topics.
+func (s *Server) StreamSlashings(w http.ResponseWriter, r *http.Request) {
+	requestedTopics := make(map[string]bool)
+	for _, rawTopic := range r.URL.Query()["topics"] {
+		for _, topic := range strings.Split(rawTopic, ",") {
+			if !topicsHandled[topic] {
+				errJson := &network.DefaultErrorJson{
+					Message: fmt.Sprintf("Topic %s not allowed for slashing subscriptions", topic),
+					Code:    http.StatusBadRequest,
+				}
+				network.WriteError(w, errJson)
+				return
+			}
+			requestedTopics[topic] = true
+		}
+	}
+	if len(requestedTopics) == 0 {
+		requestedTopics = topicsHandled
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		errJson := &network.DefaultErrorJson{
+			Message: fmt.Sprintf("Streaming not supported by %T", w),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	eventsChan := make(chan *feed.Event, 1)
+	sub := s.SlashingNotifier.SlashingFeed().Subscribe(eventsChan)
+	defer sub.Unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case event := <-eventsChan:
+			topic, data := slashingEventData(event)
+			if !requestedTopics[topic] {
+				continue
+			}
+			if err := writeEvent(w, topic, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-sub.Err():
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func slashingEventData(event *feed.Event) (string, interface{}) {
+	switch event.Type {
+	case slasherservice.AttesterSlashingDetected:
+		data, ok := event.Data.(*slasherservice.AttesterSlashingDetectedData)
+		if !ok {
+			return "", nil
+		}
+		return AttesterSlashingTopic, attesterSlashingFromConsensus(data.Slashing)
+	case slasherservice.ProposerSlashingDetected:
+		data, ok := event.Data.(*slasherservice.ProposerSlashingDetectedData)
+		if !ok {
+			return "", nil
+		}
+		return ProposerSlashingTopic, proposerSlashingFromConsensus(data.Slashing)
+	default:
+		return "", nil
+	}
+}
+
+func writeEvent(w http.ResponseWriter, topic string, data interface{}) error {
+	dataJson, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal event data")
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", topic, dataJson)
+	return err
+}
@@ -0,0 +1,221 @@
+package slasher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v4/async/event"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	slasherservice "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/mock"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+type mockSlashingNotifier struct {
+	feed event.Feed
+}
+
+func (n *mockSlashingNotifier) SlashingFeed() *event.Feed {
+	return &n.feed
+}
+
+func TestSlashableAttestation(t *testing.T) {
+	att := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1, 2}})
+	body, err := json.Marshal(indexedAttestationFromConsensus(att))
+	require.NoError(t, err)
+
+	t.Run("slashing found", func(t *testing.T) {
+		s := &Server{SlashingChecker: &mock.MockSlashingChecker{AttesterSlashingFound: true}}
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/attestations/slashable", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SlashableAttestation(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &SlashableAttestationResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		slashing, err := resp.Data[0].Attestation1.ToConsensus()
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(slashing.AttestingIndices))
+	})
+	t.Run("no slashing", func(t *testing.T) {
+		s := &Server{SlashingChecker: &mock.MockSlashingChecker{}}
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/attestations/slashable", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SlashableAttestation(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &SlashableAttestationResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.NotNil(t, resp.Data)
+		assert.Equal(t, 0, len(resp.Data))
+	})
+	t.Run("invalid root", func(t *testing.T) {
+		invalidAtt := indexedAttestationFromConsensus(att)
+		invalidAtt.Data.Target.Root = "0x1234"
+		invalidBody, err := json.Marshal(invalidAtt)
+		require.NoError(t, err)
+		s := &Server{SlashingChecker: &mock.MockSlashingChecker{}}
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/attestations/slashable", bytes.NewReader(invalidBody))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SlashableAttestation(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusBadRequest, e.Code)
+		assert.StringContains(t, "data.target.root has length 2, expected 32", e.Message)
+	})
+	t.Run("missing data", func(t *testing.T) {
+		s := &Server{SlashingChecker: &mock.MockSlashingChecker{}}
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/attestations/slashable", strings.NewReader(`{"attesting_indices":["1"]}`))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SlashableAttestation(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "missing attestation data", e.Message)
+	})
+}
+
+func TestSlashableBlock(t *testing.T) {
+	header := util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{})
+	body, err := json.Marshal(signedHeaderFromConsensus(header))
+	require.NoError(t, err)
+
+	t.Run("slashing found", func(t *testing.T) {
+		s := &Server{SlashingChecker: &mock.MockSlashingChecker{ProposerSlashingFound: true}}
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/blocks/slashable", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SlashableBlock(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &SlashableBlockResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		_, err := resp.Data[0].SignedHeader2.ToConsensus()
+		require.NoError(t, err)
+	})
+	t.Run("no slashing", func(t *testing.T) {
+		s := &Server{SlashingChecker: &mock.MockSlashingChecker{}}
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/blocks/slashable", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SlashableBlock(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &SlashableBlockResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.NotNil(t, resp.Data)
+		assert.Equal(t, 0, len(resp.Data))
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		invalidHeader := signedHeaderFromConsensus(header)
+		invalidHeader.Signature = "foo"
+		invalidBody, err := json.Marshal(invalidHeader)
+		require.NoError(t, err)
+		s := &Server{SlashingChecker: &mock.MockSlashingChecker{}}
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/blocks/slashable", bytes.NewReader(invalidBody))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SlashableBlock(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "could not decode signature", e.Message)
+	})
+}
+
+func TestStreamSlashings(t *testing.T) {
+	attesterSlashing := &ethpb.AttesterSlashing{
+		Attestation_1: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1}}),
+		Attestation_2: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1}}),
+	}
+	proposerSlashing := &ethpb.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+		Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+	}
+	events := []*feed.Event{
+		{
+			Type: slasherservice.AttesterSlashingDetected,
+			Data: &slasherservice.AttesterSlashingDetectedData{Slashing: attesterSlashing},
+		},
+		{
+			Type: slasherservice.ProposerSlashingDetected,
+			Data: &slasherservice.ProposerSlashingDetectedData{Slashing: proposerSlashing},
+		},
+	}
+
+	// stream runs the handler until every event has been received by it, and returns the response.
+	stream := func(t *testing.T, url string) *httptest.ResponseRecorder {
+		notifier := &mockSlashingNotifier{}
+		s := &Server{SlashingNotifier: notifier}
+		ctx, cancel := context.WithCancel(context.Background())
+		request := httptest.NewRequest(http.MethodGet, url, nil).WithContext(ctx)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		done := make(chan struct{})
+		go func() {
+			s.StreamSlashings(writer, request)
+			close(done)
+		}()
+		// Wait for the handler to subscribe before sending the events.
+		for notifier.feed.Send(events[0]) == 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+		notifier.feed.Send(events[1])
+		// The last event is only received once the previous one has been written.
+		notifier.feed.Send(events[0])
+		cancel()
+		<-done
+		return writer
+	}
+
+	t.Run("all topics", func(t *testing.T) {
+		writer := stream(t, "http://example.com/prysm/v1/slasher/events")
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "text/event-stream", writer.Header().Get("Content-Type"))
+		body := writer.Body.String()
+		assert.StringContains(t, "event: attester_slashing\ndata: ", body)
+		assert.StringContains(t, "event: proposer_slashing\ndata: ", body)
+		assert.StringContains(t, hexutil.Encode(proposerSlashing.Header_1.Signature), body)
+	})
+	t.Run("filtered topics", func(t *testing.T) {
+		writer := stream(t, "http://example.com/prysm/v1/slasher/events?topics=proposer_slashing")
+		require.Equal(t, http.StatusOK, writer.Code)
+		body := writer.Body.String()
+		assert.Equal(t, false, strings.Contains(body, "event: attester_slashing"))
+		assert.StringContains(t, "event: proposer_slashing\ndata: ", body)
+	})
+	t.Run("invalid topic", func(t *testing.T) {
+		s := &Server{SlashingNotifier: &mockSlashingNotifier{}}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/events?topics=proposer_slashing,head", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.StreamSlashings(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Topic head not allowed", e.Message)
+	})
+}
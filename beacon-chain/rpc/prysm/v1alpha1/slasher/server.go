@@ -1,4 +1,4 @@
-// Package slasher defines a gRPC server implementation of a slasher service
+// Package slasher defines gRPC and HTTP server implementations of a slasher service
 // which allows for checking if attestations or blocks are slashable.
 package slasher
 
@@ -6,7 +6,9 @@ import (
 	slasherservice "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher"
 )
 
-// Server defines a server implementation of the gRPC slasher service.
+// Server defines a server implementation of the gRPC slasher service
+// and of its HTTP equivalent.
 type Server struct {
-	SlashingChecker slasherservice.SlashingChecker
+	SlashingChecker  slasherservice.SlashingChecker
+	SlashingNotifier slasherservice.SlashingNotifier
 }
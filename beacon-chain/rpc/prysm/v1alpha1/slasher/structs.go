@@ -0,0 +1,231 @@
+package slasher
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+type SlashableAttestationResponse struct {
+	Data []*AttesterSlashing `json:"data"`
+}
+
+type SlashableBlockResponse struct {
+	Data []*ProposerSlashing `json:"data"`
+}
+
+type AttesterSlashing struct {
+	Attestation1 *IndexedAttestation `json:"attestation_1"`
+	Attestation2 *IndexedAttestation `json:"attestation_2"`
+}
+
+type ProposerSlashing struct {
+	SignedHeader1 *SignedBeaconBlockHeader `json:"signed_header_1"`
+	SignedHeader2 *SignedBeaconBlockHeader `json:"signed_header_2"`
+}
+
+type IndexedAttestation struct {
+	AttestingIndices []string         `json:"attesting_indices"`
+	Data             *AttestationData `json:"data"`
+	Signature        string           `json:"signature"`
+}
+
+type AttestationData struct {
+	Slot            string      `json:"slot"`
+	Index           string      `json:"index"`
+	BeaconBlockRoot string      `json:"beacon_block_root"`
+	Source          *Checkpoint `json:"source"`
+	Target          *Checkpoint `json:"target"`
+}
+
+type Checkpoint struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+type SignedBeaconBlockHeader struct {
+	Message   *BeaconBlockHeader `json:"message"`
+	Signature string             `json:"signature"`
+}
+
+type BeaconBlockHeader struct {
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
+}
+
+// ToConsensus converts the indexed attestation to its consensus representation.
+func (a *IndexedAttestation) ToConsensus() (*ethpb.IndexedAttestation, error) {
+	if a.Data == nil || a.Data.Source == nil || a.Data.Target == nil {
+		return nil, errors.New("missing attestation data")
+	}
+	attestingIndices := make([]uint64, len(a.AttestingIndices))
+	for i, ix := range a.AttestingIndices {
+		index, err := strconv.ParseUint(ix, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode attesting_indices[%d]", i)
+		}
+		attestingIndices[i] = index
+	}
+	slot, err := strconv.ParseUint(a.Data.Slot, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode data.slot")
+	}
+	committeeIndex, err := strconv.ParseUint(a.Data.Index, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode data.index")
+	}
+	beaconBlockRoot, err := decodeHex(a.Data.BeaconBlockRoot, fieldparams.RootLength, "data.beacon_block_root")
+	if err != nil {
+		return nil, err
+	}
+	source, err := a.Data.Source.toConsensus("data.source")
+	if err != nil {
+		return nil, err
+	}
+	target, err := a.Data.Target.toConsensus("data.target")
+	if err != nil {
+		return nil, err
+	}
+	sig, err := decodeHex(a.Signature, fieldparams.BLSSignatureLength, "signature")
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.IndexedAttestation{
+		AttestingIndices: attestingIndices,
+		Data: &ethpb.AttestationData{
+			Slot:            primitives.Slot(slot),
+			CommitteeIndex:  primitives.CommitteeIndex(committeeIndex),
+			BeaconBlockRoot: beaconBlockRoot,
+			Source:          source,
+			Target:          target,
+		},
+		Signature: sig,
+	}, nil
+}
+
+func (c *Checkpoint) toConsensus(field string) (*ethpb.Checkpoint, error) {
+	epoch, err := strconv.ParseUint(c.Epoch, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode %s.epoch", field)
+	}
+	root, err := decodeHex(c.Root, fieldparams.RootLength, field+".root")
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.Checkpoint{
+		Epoch: primitives.Epoch(epoch),
+		Root:  root,
+	}, nil
+}
+
+// ToConsensus converts the signed block header to its consensus representation.
+func (h *SignedBeaconBlockHeader) ToConsensus() (*ethpb.SignedBeaconBlockHeader, error) {
+	if h.Message == nil {
+		return nil, errors.New("missing message")
+	}
+	slot, err := strconv.ParseUint(h.Message.Slot, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode message.slot")
+	}
+	proposerIndex, err := strconv.ParseUint(h.Message.ProposerIndex, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode message.proposer_index")
+	}
+	parentRoot, err := decodeHex(h.Message.ParentRoot, fieldparams.RootLength, "message.parent_root")
+	if err != nil {
+		return nil, err
+	}
+	stateRoot, err := decodeHex(h.Message.StateRoot, fieldparams.RootLength, "message.state_root")
+	if err != nil {
+		return nil, err
+	}
+	bodyRoot, err := decodeHex(h.Message.BodyRoot, fieldparams.RootLength, "message.body_root")
+	if err != nil {
+		return nil, err
+	}
+	sig, err := decodeHex(h.Signature, fieldparams.BLSSignatureLength, "signature")
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{
+			Slot:          primitives.Slot(slot),
+			ProposerIndex: primitives.ValidatorIndex(proposerIndex),
+			ParentRoot:    parentRoot,
+			StateRoot:     stateRoot,
+			BodyRoot:      bodyRoot,
+		},
+		Signature: sig,
+	}, nil
+}
+
+func decodeHex(s string, length int, field string) ([]byte, error) {
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode %s", field)
+	}
+	if len(b) != length {
+		return nil, fmt.Errorf("%s has length %d, expected %d", field, len(b), length)
+	}
+	return b, nil
+}
+
+func attesterSlashingFromConsensus(s *ethpb.AttesterSlashing) *AttesterSlashing {
+	return &AttesterSlashing{
+		Attestation1: indexedAttestationFromConsensus(s.Attestation_1),
+		Attestation2: indexedAttestationFromConsensus(s.Attestation_2),
+	}
+}
+
+func indexedAttestationFromConsensus(a *ethpb.IndexedAttestation) *IndexedAttestation {
+	attestingIndices := make([]string, len(a.AttestingIndices))
+	for i, ix := range a.AttestingIndices {
+		attestingIndices[i] = strconv.FormatUint(ix, 10)
+	}
+	return &IndexedAttestation{
+		AttestingIndices: attestingIndices,
+		Data: &AttestationData{
+			Slot:            strconv.FormatUint(uint64(a.Data.Slot), 10),
+			Index:           strconv.FormatUint(uint64(a.Data.CommitteeIndex), 10),
+			BeaconBlockRoot: hexutil.Encode(a.Data.BeaconBlockRoot),
+			Source:          checkpointFromConsensus(a.Data.Source),
+			Target:          checkpointFromConsensus(a.Data.Target),
+		},
+		Signature: hexutil.Encode(a.Signature),
+	}
+}
+
+func checkpointFromConsensus(c *ethpb.Checkpoint) *Checkpoint {
+	return &Checkpoint{
+		Epoch: strconv.FormatUint(uint64(c.Epoch), 10),
+		Root:  hexutil.Encode(c.Root),
+	}
+}
+
+func proposerSlashingFromConsensus(s *ethpb.ProposerSlashing) *ProposerSlashing {
+	return &ProposerSlashing{
+		SignedHeader1: signedHeaderFromConsensus(s.Header_1),
+		SignedHeader2: signedHeaderFromConsensus(s.Header_2),
+	}
+}
+
+func signedHeaderFromConsensus(h *ethpb.SignedBeaconBlockHeader) *SignedBeaconBlockHeader {
+	return &SignedBeaconBlockHeader{
+		Message: &BeaconBlockHeader{
+			Slot:          strconv.FormatUint(uint64(h.Header.Slot), 10),
+			ProposerIndex: strconv.FormatUint(uint64(h.Header.ProposerIndex), 10),
+			ParentRoot:    hexutil.Encode(h.Header.ParentRoot),
+			StateRoot:     hexutil.Encode(h.Header.StateRoot),
+			BodyRoot:      hexutil.Encode(h.Header.BodyRoot),
+		},
+		Signature: hexutil.Encode(h.Signature),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strings"
 	"sync"
 	"time"
@@ -26,6 +27,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	"github.com/sirupsen/logrus"
@@ -43,12 +45,41 @@ const (
 	eth1dataTimeout     = 2 * time.Second
 )
 
+// ProducedBlock is a block built for a proposer together with the values of its content.
+type ProducedBlock struct {
+	Block *ethpb.GenericBeaconBlock
+	// ExecutionPayloadValue is the value in Wei of the execution payload set in the block.
+	ExecutionPayloadValue *big.Int
+	// ConsensusBlockValue is the reward in Gwei the proposer receives for the consensus content of the block.
+	// It is only computed when requested, and is 0 otherwise.
+	ConsensusBlockValue uint64
+}
+
 // GetBeaconBlock is called by a proposer during its assigned slot to request a block to sign
 // by passing in the slot and the signed randao reveal of the slot.
 func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (*ethpb.GenericBeaconBlock, error) {
-	ctx, span := trace.StartSpan(ctx, "ProposerServer.GetBeaconBlock")
+	builderBoostFactor := params.BeaconConfig().BuilderBoostFactor
+	if req.SkipMevBoost {
+		builderBoostFactor = 0
+	}
+	blk, err := vs.ProduceBeaconBlock(ctx, req, builderBoostFactor, false)
+	if err != nil {
+		return nil, err
+	}
+	return blk.Block, nil
+}
+
+// ProduceBeaconBlock builds a block for the requested slot. The builder payload is used instead of the local one
+// when its value, multiplied by the builder boost factor percentage, is higher than the boosted local payload value.
+// A builder boost factor of 0 never requests a payload from the builder. Computing the consensus value of the
+// block re-processes its operations on a copy of the state, so it is only done when withConsensusValue is set.
+func (vs *Server) ProduceBeaconBlock(ctx context.Context, req *ethpb.BlockRequest, builderBoostFactor uint64, withConsensusValue bool) (*ProducedBlock, error) {
+	ctx, span := trace.StartSpan(ctx, "ProposerServer.ProduceBeaconBlock")
 	defer span.End()
-	span.AddAttributes(trace.Int64Attribute("slot", int64(req.Slot)))
+	span.AddAttributes(
+		trace.Int64Attribute("slot", int64(req.Slot)),
+		trace.Int64Attribute("builderBoostFactor", int64(builderBoostFactor)), // lint:ignore uintcast -- This is OK for tracing.
+	)
 
 	t, err := slots.ToTime(uint64(vs.TimeFetcher.GenesisTime().Unix()), req.Slot)
 	if err != nil {
@@ -105,8 +136,11 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 	}
 	sBlk.SetProposerIndex(idx)
 
+	var blobsBundle *enginev1.BlobsBundle
+	payloadValue := big.NewInt(0)
 	if features.Get().BuildBlockParallel {
-		if err := vs.BuildBlockParallel(ctx, sBlk, head); err != nil {
+		blobsBundle, payloadValue, err = vs.BuildBlockParallel(ctx, sBlk, head, builderBoostFactor)
+		if err != nil {
 			return nil, errors.Wrap(err, "could not build block in parallel")
 		}
 	} else {
@@ -140,24 +174,25 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 		// Set sync aggregate. New in Altair.
 		vs.setSyncAggregate(ctx, sBlk)
 
-		// Get local and builder (if enabled) payloads. Set execution data. New in Bellatrix.
-		localPayload, err := vs.getLocalPayload(ctx, sBlk.Block(), head)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not get local payload: %v", err)
-		}
-		builderPayload, err := vs.getBuilderPayload(ctx, sBlk.Block().Slot(), sBlk.Block().ProposerIndex())
+		// Get local and builder (if enabled) payloads. Set execution data and kzg commitments. New in Bellatrix and Deneb.
+		blobsBundle, payloadValue, err = vs.setExecutionPayload(ctx, sBlk, head, builderBoostFactor)
 		if err != nil {
-			builderGetPayloadMissCount.Inc()
-			log.WithError(err).Error("Could not get builder payload")
-		}
-		if err := setExecutionData(ctx, sBlk, localPayload, builderPayload); err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not set execution data: %v", err)
+			return nil, err
 		}
 
 		// Set bls to execution change. New in Capella.
 		vs.setBlsToExecData(sBlk, head)
 	}
 
+	var consensusValue uint64
+	if withConsensusValue {
+		consensusValue, err = consensusBlockValue(ctx, head, sBlk)
+		if err != nil {
+			// The value is informative, failing to compute it must not prevent the proposal.
+			log.WithError(err).Warn("Could not compute consensus block value")
+		}
+	}
+
 	sr, err := vs.computeStateRoot(ctx, sBlk)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not compute state root: %v", err)
@@ -170,29 +205,54 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 		"validator":          sBlk.Block().ProposerIndex(),
 	}).Info("Finished building block")
 
+	genericBlock, err := genericBeaconBlock(sBlk, blobsBundle)
+	if err != nil {
+		return nil, err
+	}
+	return &ProducedBlock{
+		Block:                 genericBlock,
+		ExecutionPayloadValue: payloadValue,
+		ConsensusBlockValue:   consensusValue,
+	}, nil
+}
+
+// genericBeaconBlock wraps the built block in the generic block container of its fork.
+func genericBeaconBlock(sBlk interfaces.SignedBeaconBlock, blobsBundle *enginev1.BlobsBundle) (*ethpb.GenericBeaconBlock, error) {
 	pb, err := sBlk.Block().Proto()
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not convert block to proto: %v", err)
 	}
-	if slots.ToEpoch(req.Slot) >= params.BeaconConfig().CapellaForkEpoch {
+	slot := sBlk.Block().Slot()
+	if slots.ToEpoch(slot) >= params.BeaconConfig().DenebForkEpoch {
+		if sBlk.IsBlinded() {
+			return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_BlindedDeneb{BlindedDeneb: pb.(*ethpb.BlindedBeaconBlockDeneb)}}, nil
+		}
+		contents := &ethpb.BeaconBlockContentsDeneb{Block: pb.(*ethpb.BeaconBlockDeneb)}
+		if blobsBundle != nil {
+			contents.KzgProofs = blobsBundle.Proofs
+			contents.Blobs = blobsBundle.Blobs
+		}
+		return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Deneb{Deneb: contents}}, nil
+	}
+	if slots.ToEpoch(slot) >= params.BeaconConfig().CapellaForkEpoch {
 		if sBlk.IsBlinded() {
 			return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_BlindedCapella{BlindedCapella: pb.(*ethpb.BlindedBeaconBlockCapella)}}, nil
 		}
 		return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Capella{Capella: pb.(*ethpb.BeaconBlockCapella)}}, nil
 	}
-	if slots.ToEpoch(req.Slot) >= params.BeaconConfig().BellatrixForkEpoch {
+	if slots.ToEpoch(slot) >= params.BeaconConfig().BellatrixForkEpoch {
 		if sBlk.IsBlinded() {
 			return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_BlindedBellatrix{BlindedBellatrix: pb.(*ethpb.BlindedBeaconBlockBellatrix)}}, nil
 		}
 		return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Bellatrix{Bellatrix: pb.(*ethpb.BeaconBlockBellatrix)}}, nil
 	}
-	if slots.ToEpoch(req.Slot) >= params.BeaconConfig().AltairForkEpoch {
+	if slots.ToEpoch(slot) >= params.BeaconConfig().AltairForkEpoch {
 		return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Altair{Altair: pb.(*ethpb.BeaconBlockAltair)}}, nil
 	}
 	return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Phase0{Phase0: pb.(*ethpb.BeaconBlock)}}, nil
 }
 
-func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.SignedBeaconBlock, head state.BeaconState) error {
+func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.SignedBeaconBlock, head state.BeaconState, builderBoostFactor uint64) (*enginev1.BlobsBundle, *big.Int, error) {
 	// Build consensus fields in background
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -233,24 +293,60 @@ func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.Signed
 		vs.setBlsToExecData(sBlk, head)
 	}()
 
-	localPayload, err := vs.getLocalPayload(ctx, sBlk.Block(), head)
+	blobsBundle, payloadValue, err := vs.setExecutionPayload(ctx, sBlk, head, builderBoostFactor)
 	if err != nil {
-		return status.Errorf(codes.Internal, "Could not get local payload: %v", err)
+		return nil, nil, err
 	}
 
-	builderPayload, err := vs.getBuilderPayload(ctx, sBlk.Block().Slot(), sBlk.Block().ProposerIndex())
+	wg.Wait() // Wait until block is built via consensus and execution fields.
+
+	return blobsBundle, payloadValue, nil
+}
+
+// setExecutionPayload gets the local and, if enabled, the builder payloads and sets the execution data and
+// kzg commitments of the block. It returns the blobs bundle of the local payload and the value in Wei of
+// the payload set in the block.
+func (vs *Server) setExecutionPayload(
+	ctx context.Context,
+	sBlk interfaces.SignedBeaconBlock,
+	head state.BeaconState,
+	builderBoostFactor uint64,
+) (*enginev1.BlobsBundle, *big.Int, error) {
+	localPayload, blobsBundle, err := vs.getLocalPayload(ctx, sBlk.Block(), head)
 	if err != nil {
-		builderGetPayloadMissCount.Inc()
-		log.WithError(err).Error("Could not get builder payload")
+		return nil, nil, status.Errorf(codes.Internal, "Could not get local payload: %v", err)
 	}
 
-	if err := setExecutionData(ctx, sBlk, localPayload, builderPayload); err != nil {
-		return status.Errorf(codes.Internal, "Could not set execution data: %v", err)
+	var builderPayload interfaces.ExecutionData
+	if builderBoostFactor > 0 {
+		builderPayload, err = vs.getBuilderPayload(ctx, sBlk.Block().Slot(), sBlk.Block().ProposerIndex())
+		if err != nil {
+			builderGetPayloadMissCount.Inc()
+			log.WithError(err).Error("Could not get builder payload")
+		}
 	}
 
-	wg.Wait() // Wait until block is built via consensus and execution fields.
+	if err := setExecutionData(ctx, sBlk, localPayload, builderPayload, builderBoostFactor); err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Could not set execution data: %v", err)
+	}
+
+	// Set kzg commitments. New in Deneb.
+	if err := setKzgCommitments(sBlk, blobsBundle); err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Could not set kzg commitments: %v", err)
+	}
 
-	return nil
+	chosenPayload := localPayload
+	if sBlk.IsBlinded() {
+		chosenPayload = builderPayload
+	}
+	payloadValue := big.NewInt(0)
+	if chosenPayload != nil {
+		// Payloads before Capella do not carry a value.
+		if v, err := chosenPayload.ValueInWei(); err == nil {
+			payloadValue = v
+		}
+	}
+	return blobsBundle, payloadValue, nil
 }
 
 // ProposeBeaconBlock is called by a proposer during its assigned slot to create a block in an attempt
@@ -262,6 +358,11 @@ func (vs *Server) ProposeBeaconBlock(ctx context.Context, req *ethpb.GenericSign
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "%s: %v", CouldNotDecodeBlock, err)
 	}
+	if contents, ok := req.Block.(*ethpb.GenericSignedBeaconBlock_Deneb); ok {
+		if err := vs.broadcastAndSaveBlobSidecars(ctx, blk, contents.Deneb); err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not broadcast blob sidecars: %v", err)
+		}
+	}
 	return vs.proposeGenericBeaconBlock(ctx, blk)
 }
 
@@ -274,6 +375,16 @@ func (vs *Server) PrepareBeaconProposer(
 	var feeRecipients []common.Address
 	var validatorIndices []primitives.ValidatorIndex
 
+	if vs.ValidatorMonitor != nil {
+		preparedIndices := make([]primitives.ValidatorIndex, len(request.Recipients))
+		for i, r := range request.Recipients {
+			preparedIndices[i] = r.ValidatorIndex
+		}
+		if err := vs.ValidatorMonitor.TrackProposerPreparations(ctx, preparedIndices); err != nil {
+			log.WithError(err).Warn("Could not track prepared proposers in the validator monitor")
+		}
+	}
+
 	newRecipients := make([]*ethpb.PrepareBeaconProposerRequest_FeeRecipientContainer, 0, len(request.Recipients))
 	for _, r := range request.Recipients {
 		f, err := vs.BeaconDB.FeeRecipientByValidatorID(ctx, r.ValidatorIndex)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"math/big"
 	"strings"
 	"time"
 
@@ -41,7 +42,9 @@ var emptyTransactionsRoot = [32]byte{127, 254, 36, 30, 166, 1, 135, 253, 176, 24
 const blockBuilderTimeout = 1 * time.Second
 
 // Sets the execution data for the block. Execution data can come from local EL client or remote builder depends on validator registration and circuit breaker conditions.
-func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, localPayload, builderPayload interfaces.ExecutionData) error {
+// The builder boost factor is a percentage applied to the builder payload value before it is compared with the local payload value,
+// a factor of 0 always uses the local payload.
+func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, localPayload, builderPayload interfaces.ExecutionData, builderBoostFactor uint64) error {
 	_, span := trace.StartSpan(ctx, "ProposerServer.setExecutionData")
 	defer span.End()
 
@@ -54,8 +57,8 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 		return errors.New("local payload is nil")
 	}
 
-	// Use local payload if builder payload is nil.
-	if builderPayload == nil {
+	// Use local payload if builder payload is nil or the builder is not wanted.
+	if builderPayload == nil || builderBoostFactor == 0 {
 		return blk.SetExecution(localPayload)
 	}
 
@@ -80,9 +83,9 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 		}
 
 		// Use builder payload if the following in true:
-		// builder_bid_value * 100 > local_block_value * (local-block-value-boost + 100)
+		// builder_bid_value * builder_boost_factor > local_block_value * (local-block-value-boost + 100)
 		boost := params.BeaconConfig().LocalBlockValueBoost
-		higherValueBuilder := builderValueGwei*100 > localValueGwei*(100+boost)
+		higherValueBuilder := isHigherValueBuilder(builderValueGwei, localValueGwei, builderBoostFactor, boost)
 
 		// If we can't get the builder value, just use local block.
 		if higherValueBuilder && withdrawalsMatched { // Builder value is higher and withdrawals match.
@@ -99,6 +102,7 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 			log.WithFields(logrus.Fields{
 				"localGweiValue":       localValueGwei,
 				"localBoostPercentage": boost,
+				"builderBoostFactor":   builderBoostFactor,
 				"builderGweiValue":     builderValueGwei,
 			}).Warn("Proposer: using local execution payload because higher value")
 		}
@@ -121,6 +125,14 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 	}
 }
 
+// isHigherValueBuilder returns true if builderValue * builderBoostFactor > localValue * (localBoost + 100).
+// The products are computed without overflow so that the maximum boost factor always prefers the builder.
+func isHigherValueBuilder(builderValue, localValue, builderBoostFactor, localBoost uint64) bool {
+	boostedBuilderValue := new(big.Int).Mul(new(big.Int).SetUint64(builderValue), new(big.Int).SetUint64(builderBoostFactor))
+	boostedLocalValue := new(big.Int).Mul(new(big.Int).SetUint64(localValue), new(big.Int).Add(new(big.Int).SetUint64(localBoost), big.NewInt(100)))
+	return boostedBuilderValue.Cmp(boostedLocalValue) > 0
+}
+
 // This function retrieves the payload header given the slot number and the validator index.
 // It's a no-op if the latest head block is not versioned bellatrix.
 func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot primitives.Slot, idx primitives.ValidatorIndex) (interfaces.ExecutionData, error) {
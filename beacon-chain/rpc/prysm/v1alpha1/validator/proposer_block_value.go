@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	coreblocks "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/validators"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"go.opencensus.io/trace"
+)
+
+// consensusBlockValue returns the reward in Gwei the proposer of the block receives for including its
+// attestations, slashings and sync aggregate. The given state is the pre-state of the block, advanced to
+// the block's slot, and is not modified. Phase 0 proposers are rewarded at epoch processing, so the value
+// of a Phase 0 block is always 0.
+func consensusBlockValue(ctx context.Context, preState state.BeaconState, signed interfaces.ReadOnlySignedBeaconBlock) (uint64, error) {
+	ctx, span := trace.StartSpan(ctx, "ProposerServer.consensusBlockValue")
+	defer span.End()
+
+	blk := signed.Block()
+	if blk.Version() == version.Phase0 {
+		return 0, nil
+	}
+
+	st := preState.Copy()
+	proposerIndex := blk.ProposerIndex()
+	initBalance, err := st.BalanceAtIndex(proposerIndex)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get proposer's balance")
+	}
+	st, err = altair.ProcessAttestationsNoVerifySignature(ctx, st, signed)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not process attestations")
+	}
+	st, err = coreblocks.ProcessAttesterSlashings(ctx, st, blk.Body().AttesterSlashings(), validators.SlashValidator)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not process attester slashings")
+	}
+	st, err = coreblocks.ProcessProposerSlashings(ctx, st, blk.Body().ProposerSlashings(), validators.SlashValidator)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not process proposer slashings")
+	}
+	balance, err := st.BalanceAtIndex(proposerIndex)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get proposer's balance")
+	}
+	sa, err := blk.Body().SyncAggregate()
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get sync aggregate")
+	}
+	_, syncAggregateReward, err := altair.ProcessSyncAggregate(ctx, st, sa)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not process sync aggregate")
+	}
+	// The proposer's balance decreases when the block slashes it, in which case the block has no value.
+	gain := balance + syncAggregateReward
+	if gain < initBalance {
+		return 0, nil
+	}
+	return gain - initBalance, nil
+}
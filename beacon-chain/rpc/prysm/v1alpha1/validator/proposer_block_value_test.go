@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestConsensusBlockValue_ProposerSlashing(t *testing.T) {
+	ctx := context.Background()
+	st, keys := util.DeterministicGenesisStateAltair(t, 64)
+	require.NoError(t, st.SetSlot(1))
+	pubKeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubKeys {
+		pubKeys[i] = keys[i%len(keys)].PublicKey().Marshal()
+	}
+	require.NoError(t, st.SetCurrentSyncCommittee(&ethpb.SyncCommittee{Pubkeys: pubKeys, AggregatePubkey: make([]byte, 48)}))
+	proposerIndex, err := helpers.BeaconProposerIndex(ctx, st)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		slashed primitives.ValidatorIndex
+		want    uint64
+	}{
+		{
+			name:    "other validator slashed",
+			slashed: (proposerIndex + 1) % 64,
+			want:    params.BeaconConfig().MaxEffectiveBalance / params.BeaconConfig().WhistleBlowerRewardQuotient,
+		},
+		{
+			// The slashing penalty exceeds the whistleblower reward, which must not underflow the value.
+			name:    "proposer slashed",
+			slashed: proposerIndex,
+			want:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slashing, err := util.GenerateProposerSlashingForValidator(st, keys[tt.slashed], tt.slashed)
+			require.NoError(t, err)
+			b := util.NewBeaconBlockAltair()
+			b.Block.Slot = 1
+			b.Block.ProposerIndex = proposerIndex
+			b.Block.Body.ProposerSlashings = []*ethpb.ProposerSlashing{slashing}
+			b.Block.Body.SyncAggregate.SyncCommitteeSignature = append([]byte{0xC0}, make([]byte, 95)...)
+			wsb, err := blocks.NewSignedBeaconBlock(b)
+			require.NoError(t, err)
+
+			v, err := consensusBlockValue(ctx, st, wsb)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, v)
+		})
+	}
+}
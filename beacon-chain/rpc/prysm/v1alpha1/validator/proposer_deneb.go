@@ -0,0 +1,46 @@
+package validator
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+)
+
+// Sets the kzg commitments of the blobs bundle returned by the execution client in the block.
+func setKzgCommitments(blk interfaces.SignedBeaconBlock, bundle *enginev1.BlobsBundle) error {
+	if blk.Version() < version.Deneb {
+		return nil
+	}
+	if bundle == nil {
+		return blk.SetBlobKzgCommitments([][]byte{})
+	}
+	return blk.SetBlobKzgCommitments(bundle.KzgCommitments)
+}
+
+// Builds the blob sidecars of a signed block contents, saves them in the database and broadcasts
+// them over their respective gossip subnets.
+func (vs *Server) broadcastAndSaveBlobSidecars(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock, contents *ethpb.SignedBeaconBlockContentsDeneb) error {
+	sidecars, err := blocks.BuildBlobSidecars(blk, contents.Blobs, contents.KzgProofs)
+	if err != nil {
+		return errors.Wrap(err, "could not build blob sidecars")
+	}
+	if len(sidecars) == 0 {
+		return nil
+	}
+	if err := vs.BeaconDB.SaveBlobSidecars(ctx, sidecars); err != nil {
+		return errors.Wrap(err, "could not save blob sidecars")
+	}
+	for _, sc := range sidecars {
+		subnet := sc.Index % params.BeaconNetworkConfig().BlobsidecarSubnetCount
+		if err := vs.P2P.BroadcastBlob(ctx, subnet, sc); err != nil {
+			return errors.Wrapf(err, "could not broadcast blob sidecar %d", sc.Index)
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"sync"
 
 	"github.com/gorilla/mux"
@@ -23,6 +24,7 @@ import (
 	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
+	monitorservice "github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/blstoexec"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/slashings"
@@ -30,15 +32,19 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/beacon"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/blob"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/debug"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/events"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/light-client"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/node"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/rewards"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/validator"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/monitor"
 	beaconv1alpha1 "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/beacon"
 	debugv1alpha1 "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/debug"
 	nodev1alpha1 "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/node"
+	slasherv1alpha1 "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/slasher"
 	validatorv1alpha1 "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/validator"
 	slasherservice "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
@@ -102,12 +108,17 @@ type Config struct {
 	ExitPool                      voluntaryexits.PoolManager
 	SlashingsPool                 slashings.PoolManager
 	SlashingChecker               slasherservice.SlashingChecker
+	SlashingNotifier              slasherservice.SlashingNotifier
+	ValidatorMonitor              monitorservice.TrackedValidatorsManager
 	SyncCommitteeObjectPool       synccommittee.Pool
 	BLSChangesPool                blstoexec.PoolManager
 	SyncService                   chainSync.Checker
+	RateLimitManager              chainSync.RateLimitManager
+	GossipReplayer                chainSync.GossipReplayer
 	Broadcaster                   p2p.Broadcaster
 	PeersFetcher                  p2p.PeersProvider
 	PeerManager                   p2p.PeerManager
+	PeerGroupManager              p2p.PeerGroupManager
 	MetadataProvider              p2p.MetadataProvider
 	DepositFetcher                depositcache.DepositFetcher
 	PendingDepositFetcher         depositcache.PendingDepositsFetcher
@@ -119,6 +130,7 @@ type Config struct {
 	ExecutionEngineCaller         execution.EngineCaller
 	ProposerIdsCache              *cache.ProposerPayloadIDsCache
 	OptimisticModeFetcher         blockchain.OptimisticModeFetcher
+	LightClientFetcher            blockchain.LightClientFetcher
 	BlockBuilder                  builder.BlockBuilder
 	Router                        *mux.Router
 	ClockWaiter                   startup.ClockWaiter
@@ -193,7 +205,7 @@ func (s *Service) Start() {
 		stateCache = s.cfg.StateGen.CombinedCache()
 	}
 	withCache := stategen.WithCache(stateCache)
-	ch := stategen.NewCanonicalHistory(s.cfg.BeaconDB, s.cfg.ChainInfoFetcher, s.cfg.ChainInfoFetcher, withCache)
+	ch := stategen.NewCanonicalHistory(s.cfg.BeaconDB, s.cfg.ChainInfoFetcher, s.cfg.ChainInfoFetcher, withCache, stategen.WithRetainedHistory(s.cfg.BeaconDB))
 	stater := &lookup.BeaconDbStater{
 		BeaconDB:           s.cfg.BeaconDB,
 		ChainInfoFetcher:   s.cfg.ChainInfoFetcher,
@@ -211,8 +223,48 @@ func (s *Service) Start() {
 		OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
 		FinalizationFetcher:   s.cfg.FinalizationFetcher,
 		ReplayerBuilder:       ch,
+		TimeFetcher:           s.cfg.GenesisTimeFetcher,
 	}
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/rewards/blocks/{block_id}", rewardsServer.BlockRewards)
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/rewards/attestations/{epoch}", rewardsServer.AttestationRewards)
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/rewards/sync_committee/{block_id}", rewardsServer.SyncCommitteeRewards)
+
+	blobServer := &blob.Server{
+		Blocker:  blocker,
+		BeaconDB: s.cfg.BeaconDB,
+	}
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/blob_sidecars/{block_id}", blobServer.Blobs)
+
+	if features.Get().EnableLightClient {
+		lightClientServer := &lightclient.Server{
+			LightClientFetcher: s.cfg.LightClientFetcher,
+		}
+		s.cfg.Router.HandleFunc("/eth/v1/beacon/light_client/bootstrap/{block_root}", lightClientServer.GetLightClientBootstrap)
+		s.cfg.Router.HandleFunc("/eth/v1/beacon/light_client/updates", lightClientServer.GetLightClientUpdatesByRange)
+		s.cfg.Router.HandleFunc("/eth/v1/beacon/light_client/finality_update", lightClientServer.GetLightClientFinalityUpdate)
+		s.cfg.Router.HandleFunc("/eth/v1/beacon/light_client/optimistic_update", lightClientServer.GetLightClientOptimisticUpdate)
+	}
+
+	if features.Get().EnableSlasher {
+		slasherServer := &slasherv1alpha1.Server{
+			SlashingChecker:  s.cfg.SlashingChecker,
+			SlashingNotifier: s.cfg.SlashingNotifier,
+		}
+		s.cfg.Router.HandleFunc("/prysm/v1/slasher/attestations/slashable", slasherServer.SlashableAttestation)
+		s.cfg.Router.HandleFunc("/prysm/v1/slasher/blocks/slashable", slasherServer.SlashableBlock)
+		s.cfg.Router.HandleFunc("/prysm/v1/slasher/events", slasherServer.StreamSlashings)
+	}
+
+	if s.cfg.ValidatorMonitor != nil {
+		monitorServer := &monitor.Server{
+			ValidatorMonitor: s.cfg.ValidatorMonitor,
+			HeadFetcher:      s.cfg.HeadFetcher,
+		}
+		s.cfg.Router.HandleFunc("/prysm/v1/validator_monitor/validators", monitorServer.TrackedValidators)
+		s.cfg.Router.HandleFunc("/prysm/v1/validator_monitor/validators/track", monitorServer.TrackValidators).Methods(http.MethodPost)
+		s.cfg.Router.HandleFunc("/prysm/v1/validator_monitor/validators/untrack", monitorServer.UntrackValidators).Methods(http.MethodPost)
+		s.cfg.Router.HandleFunc("/prysm/v1/validator_monitor/performance", monitorServer.Performance)
+	}
 
 	validatorServer := &validatorv1alpha1.Server{
 		Ctx:                    s.ctx,
@@ -249,6 +301,7 @@ func (s *Service) Start() {
 		BlockBuilder:           s.cfg.BlockBuilder,
 		BLSChangesPool:         s.cfg.BLSChangesPool,
 		ClockWaiter:            s.cfg.ClockWaiter,
+		ValidatorMonitor:       s.cfg.ValidatorMonitor,
 	}
 	validatorServerV1 := &validator.Server{
 		HeadFetcher:            s.cfg.HeadFetcher,
@@ -259,13 +312,17 @@ func (s *Service) Start() {
 		PeerManager:            s.cfg.PeerManager,
 		Broadcaster:            s.cfg.Broadcaster,
 		V1Alpha1Server:         validatorServer,
+		BlockProducer:          validatorServer,
 		Stater:                 stater,
 		SyncCommitteePool:      s.cfg.SyncCommitteeObjectPool,
 		ProposerSlotIndexCache: s.cfg.ProposerIdsCache,
 		ChainInfoFetcher:       s.cfg.ChainInfoFetcher,
 		BeaconDB:               s.cfg.BeaconDB,
 		BlockBuilder:           s.cfg.BlockBuilder,
+		ValidatorMonitor:       s.cfg.ValidatorMonitor,
 	}
+	s.cfg.Router.HandleFunc("/eth/v1/validator/liveness/{epoch}", validatorServerV1.Liveness)
+	s.cfg.Router.HandleFunc("/eth/v3/validator/blocks/{slot}", validatorServerV1.ProduceBlockV3)
 
 	nodeServer := &nodev1alpha1.Server{
 		LogsStreamer:         logs.NewStreamServer(),
@@ -289,10 +346,14 @@ func (s *Service) Start() {
 		GenesisTimeFetcher:        s.cfg.GenesisTimeFetcher,
 		PeersFetcher:              s.cfg.PeersFetcher,
 		PeerManager:               s.cfg.PeerManager,
+		PeerGroupManager:          s.cfg.PeerGroupManager,
 		MetadataProvider:          s.cfg.MetadataProvider,
 		HeadFetcher:               s.cfg.HeadFetcher,
 		ExecutionChainInfoFetcher: s.cfg.ExecutionChainInfoFetcher,
+		ExecutionEngineCaller:     s.cfg.ExecutionEngineCaller,
 	}
+	s.cfg.Router.HandleFunc("/prysm/v1/node/execution_client_version", nodeServerV1.GetExecutionClientVersion)
+	s.cfg.Router.HandleFunc("/prysm/v1/node/peer_groups", nodeServerV1.GetPeerGroups)
 
 	beaconChainServer := &beaconv1alpha1.Server{
 		Ctx:                         s.ctx,
@@ -372,14 +433,26 @@ func (s *Service) Start() {
 			BeaconDB:              s.cfg.BeaconDB,
 			HeadFetcher:           s.cfg.HeadFetcher,
 			Stater:                stater,
+			Blocker:               blocker,
 			OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
 			ForkFetcher:           s.cfg.ForkFetcher,
 			ForkchoiceFetcher:     s.cfg.ForkchoiceFetcher,
 			FinalizationFetcher:   s.cfg.FinalizationFetcher,
 			ChainInfoFetcher:      s.cfg.ChainInfoFetcher,
+			RateLimitManager:      s.cfg.RateLimitManager,
+			GossipReplayer:        s.cfg.GossipReplayer,
 		}
 		ethpbv1alpha1.RegisterDebugServer(s.grpcServer, debugServer)
 		ethpbservice.RegisterBeaconDebugServer(s.grpcServer, debugServerV1)
+		s.cfg.Router.HandleFunc("/prysm/v1/debug/fork_choice", debugServerV1.GetForkChoiceDiagnostics)
+		s.cfg.Router.HandleFunc("/prysm/v1/debug/beacon/states/{state_id}/proof", debugServerV1.GetStateFieldProof)
+		s.cfg.Router.HandleFunc("/prysm/v1/debug/beacon/blocks/{block_id}/proof", debugServerV1.GetBlockFieldProof)
+		s.cfg.Router.HandleFunc("/prysm/v1/debug/rate_limits", debugServerV1.GetRateLimits)
+		s.cfg.Router.HandleFunc("/prysm/v1/debug/rate_limits/quotas", debugServerV1.SetRateLimitQuota).Methods(http.MethodPost)
+		s.cfg.Router.HandleFunc("/prysm/v1/debug/gossip/replay", debugServerV1.ReplayGossipMessage).Methods(http.MethodPost)
+		// Changing peer groups alters the connections of the node, so it is restricted like other debug endpoints.
+		s.cfg.Router.HandleFunc("/prysm/v1/node/peer_groups/add", nodeServerV1.AddPeerGroupMembers).Methods(http.MethodPost)
+		s.cfg.Router.HandleFunc("/prysm/v1/node/peer_groups/remove", nodeServerV1.RemovePeerGroupMembers).Methods(http.MethodPost)
 	}
 	ethpbv1alpha1.RegisterBeaconNodeValidatorServer(s.grpcServer, validatorServer)
 	ethpbservice.RegisterBeaconValidatorServer(s.grpcServer, validatorServerV1)
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -86,3 +88,41 @@ func TestRPC_InsecureEndpoint(t *testing.T) {
 	require.LogsContain(t, hook, "You are using an insecure gRPC server")
 	assert.NoError(t, rpcService.Stop())
 }
+
+func TestPeerGroupMembershipRoutes(t *testing.T) {
+	matches := func(router *mux.Router, method, path string) bool {
+		return router.Match(httptest.NewRequest(method, path, nil), &mux.RouteMatch{})
+	}
+	chainService := &mock.ChainService{Genesis: time.Now()}
+	newService := func(port string, enableDebug bool) *mux.Router {
+		router := mux.NewRouter()
+		rpcService := NewService(context.Background(), &Config{
+			Port:                    port,
+			SyncService:             &mockSync.Sync{IsSyncing: false},
+			BlockReceiver:           chainService,
+			AttestationReceiver:     chainService,
+			HeadFetcher:             chainService,
+			GenesisTimeFetcher:      chainService,
+			ExecutionChainService:   &mockExecution.Chain{},
+			StateNotifier:           chainService.StateNotifier(),
+			Router:                  router,
+			EnableDebugRPCEndpoints: enableDebug,
+		})
+		rpcService.Start()
+		t.Cleanup(func() {
+			assert.NoError(t, rpcService.Stop())
+		})
+		return router
+	}
+
+	router := newService("7349", false)
+	assert.Equal(t, true, matches(router, http.MethodGet, "/prysm/v1/node/peer_groups"))
+	assert.Equal(t, false, matches(router, http.MethodPost, "/prysm/v1/node/peer_groups/add"))
+	assert.Equal(t, false, matches(router, http.MethodPost, "/prysm/v1/node/peer_groups/remove"))
+
+	router = newService("7350", true)
+	assert.Equal(t, true, matches(router, http.MethodPost, "/prysm/v1/node/peer_groups/add"))
+	assert.Equal(t, true, matches(router, http.MethodPost, "/prysm/v1/node/peer_groups/remove"))
+	assert.Equal(t, false, matches(router, http.MethodGet, "/prysm/v1/node/peer_groups/add"))
+	assert.Equal(t, false, matches(router, http.MethodGet, "/prysm/v1/node/peer_groups/remove"))
+}
@@ -0,0 +1,38 @@
+package slasher
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/async/event"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+const (
+	// AttesterSlashingDetected is sent after the slasher has detected an attester slashing
+	// and verified the signatures of both attestations.
+	AttesterSlashingDetected = iota + 1
+
+	// ProposerSlashingDetected is sent after the slasher has detected a proposer slashing
+	// and verified the signatures of both block headers.
+	ProposerSlashingDetected
+)
+
+// AttesterSlashingDetectedData is the data sent with AttesterSlashingDetected events.
+type AttesterSlashingDetectedData struct {
+	// Slashing is the detected attester slashing.
+	Slashing *ethpb.AttesterSlashing
+}
+
+// ProposerSlashingDetectedData is the data sent with ProposerSlashingDetected events.
+type ProposerSlashingDetectedData struct {
+	// Slashing is the detected proposer slashing.
+	Slashing *ethpb.ProposerSlashing
+}
+
+// SlashingNotifier interface defines the methods of the service that provides detected slashings to consumers.
+type SlashingNotifier interface {
+	SlashingFeed() *event.Feed
+}
+
+// SlashingFeed returns the feed on which detected slashings are sent.
+func (s *Service) SlashingFeed() *event.Feed {
+	return &s.slashingFeed
+}
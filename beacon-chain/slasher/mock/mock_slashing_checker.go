@@ -61,10 +61,28 @@ func (s *MockSlashingChecker) IsSlashableAttestation(_ context.Context, _ *ethpb
 		return []*ethpb.AttesterSlashing{
 			{
 				Attestation_1: &ethpb.IndexedAttestation{
-					Data: &ethpb.AttestationData{},
+					Data: &ethpb.AttestationData{
+						BeaconBlockRoot: params.BeaconConfig().ZeroHash[:],
+						Source: &ethpb.Checkpoint{
+							Root: params.BeaconConfig().ZeroHash[:],
+						},
+						Target: &ethpb.Checkpoint{
+							Root: params.BeaconConfig().ZeroHash[:],
+						},
+					},
+					Signature: params.BeaconConfig().EmptySignature[:],
 				},
 				Attestation_2: &ethpb.IndexedAttestation{
-					Data: &ethpb.AttestationData{},
+					Data: &ethpb.AttestationData{
+						BeaconBlockRoot: params.BeaconConfig().ZeroHash[:],
+						Source: &ethpb.Checkpoint{
+							Root: params.BeaconConfig().ZeroHash[:],
+						},
+						Target: &ethpb.Checkpoint{
+							Root: params.BeaconConfig().ZeroHash[:],
+						},
+					},
+					Signature: params.BeaconConfig().EmptySignature[:],
 				},
 			},
 		}, nil
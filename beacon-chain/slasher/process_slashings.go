@@ -4,13 +4,14 @@ import (
 	"context"
 
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 )
 
-// Verifies attester slashings, logs them, and submits them to the slashing operations pool
-// in the beacon node if they pass validation.
+// Verifies attester slashings, logs them, sends them to the slashing feed, and submits them
+// to the slashing operations pool in the beacon node if they pass validation.
 func (s *Service) processAttesterSlashings(ctx context.Context, slashings []*ethpb.AttesterSlashing) error {
 	var beaconState state.BeaconState
 	var err error
@@ -36,6 +37,10 @@ func (s *Service) processAttesterSlashings(ctx context.Context, slashings []*eth
 
 		// Log the slashing event and insert into the beacon node's operations pool.
 		logAttesterSlashing(sl)
+		s.slashingFeed.Send(&feed.Event{
+			Type: AttesterSlashingDetected,
+			Data: &AttesterSlashingDetectedData{Slashing: sl},
+		})
 		if err := s.serviceCfg.SlashingPoolInserter.InsertAttesterSlashing(
 			ctx, beaconState, sl,
 		); err != nil {
@@ -45,8 +50,8 @@ func (s *Service) processAttesterSlashings(ctx context.Context, slashings []*eth
 	return nil
 }
 
-// Verifies proposer slashings, logs them, and submits them to the slashing operations pool
-// in the beacon node if they pass validation.
+// Verifies proposer slashings, logs them, sends them to the slashing feed, and submits them
+// to the slashing operations pool in the beacon node if they pass validation.
 func (s *Service) processProposerSlashings(ctx context.Context, slashings []*ethpb.ProposerSlashing) error {
 	var beaconState state.BeaconState
 	var err error
@@ -71,6 +76,10 @@ func (s *Service) processProposerSlashings(ctx context.Context, slashings []*eth
 		}
 		// Log the slashing event and insert into the beacon node's operations pool.
 		logProposerSlashing(sl)
+		s.slashingFeed.Send(&feed.Event{
+			Type: ProposerSlashingDetected,
+			Data: &ProposerSlashingDetectedData{Slashing: sl},
+		})
 		if err := s.serviceCfg.SlashingPoolInserter.InsertProposerSlashing(ctx, beaconState, sl); err != nil {
 			log.WithError(err).Error("Could not insert proposer slashing into operations pool")
 		}
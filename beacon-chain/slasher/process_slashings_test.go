@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/doubly-linked-tree"
@@ -120,9 +121,19 @@ func TestService_processAttesterSlashings(t *testing.T) {
 			},
 		}
 
+		eventsChan := make(chan *feed.Event, 1)
+		sub := s.SlashingFeed().Subscribe(eventsChan)
+		defer sub.Unsubscribe()
+
 		err = s.processAttesterSlashings(ctx, slashings)
 		require.NoError(tt, err)
 		require.LogsDoNotContain(tt, hook, "Invalid signature")
+
+		e := <-eventsChan
+		require.Equal(tt, feed.EventType(AttesterSlashingDetected), e.Type)
+		data, ok := e.Data.(*AttesterSlashingDetectedData)
+		require.Equal(tt, true, ok)
+		require.DeepEqual(tt, slashings[0], data.Slashing)
 	})
 }
 
@@ -246,8 +257,18 @@ func TestService_processProposerSlashings(t *testing.T) {
 			},
 		}
 
+		eventsChan := make(chan *feed.Event, 1)
+		sub := s.SlashingFeed().Subscribe(eventsChan)
+		defer sub.Unsubscribe()
+
 		err = s.processProposerSlashings(ctx, slashings)
 		require.NoError(tt, err)
 		require.LogsDoNotContain(tt, hook, "Invalid signature")
+
+		e := <-eventsChan
+		require.Equal(tt, feed.EventType(ProposerSlashingDetected), e.Type)
+		data, ok := e.Data.(*ProposerSlashingDetectedData)
+		require.Equal(tt, true, ok)
+		require.DeepEqual(tt, slashings[0], data.Slashing)
 	})
 }
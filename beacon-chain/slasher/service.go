@@ -67,6 +67,7 @@ type Service struct {
 	blocksSlotTicker               *slots.SlotTicker
 	pruningSlotTicker              *slots.SlotTicker
 	latestEpochWrittenForValidator map[primitives.ValidatorIndex]primitives.Epoch
+	slashingFeed                   event.Feed
 }
 
 // New instantiates a new slasher from configuration values.
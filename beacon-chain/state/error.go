@@ -6,4 +6,6 @@ var (
 	// ErrNilValidatorsInState returns when accessing validators in the state while the state has a
 	// nil slice for the validators field.
 	ErrNilValidatorsInState = errors.New("state has nil validator slice")
+	// ErrInvalidProofPath returns when the path of a beacon state field to prove does not exist in the state.
+	ErrInvalidProofPath = errors.New("invalid proof path")
 )
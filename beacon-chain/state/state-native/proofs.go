@@ -3,10 +3,14 @@ package state_native
 import (
 	"context"
 	"encoding/binary"
+	"strings"
 
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/fieldtrie"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native/types"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 )
 
@@ -106,3 +110,37 @@ func (b *BeaconState) FinalizedRootProof(ctx context.Context) ([][]byte, error)
 	proof = append(proof, branch...)
 	return proof, nil
 }
+
+// FieldProof crafts a Merkle proof of the chunk at the given path in the beacon state, against the
+// hash tree root of the state. The path starts with the name of a field in the consensus specs,
+// optionally followed by list indices and container field names, separated by dots, such as
+// "validators.5.effective_balance" or "latest_execution_payload_header.block_hash". The chunk of a
+// list of packed uint64, such as "balances.5", contains 4 values and the value at index i is found
+// at the byte offset 8*(i%4).
+func (b *BeaconState) FieldProof(ctx context.Context, path string) (*ssz.Proof, error) {
+	elements := strings.Split(path, ProofPathSeparator)
+	field, ok := b.fieldByName(elements[0])
+	if !ok {
+		return nil, errors.Wrapf(state.ErrInvalidProofPath, "unknown field %s for %s state", elements[0], version.String(b.version))
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if err := b.initializeMerkleLayers(ctx); err != nil {
+		return nil, err
+	}
+	if err := b.recomputeDirtyFields(ctx); err != nil {
+		return nil, err
+	}
+	proof, err := b.fieldProof(field, elements[1:])
+	if err != nil {
+		return nil, err
+	}
+	fieldBranch := fieldtrie.ProofFromMerkleLayers(b.merkleLayers, field.RealPosition())
+	branch := make([][32]byte, len(fieldBranch))
+	for i, node := range fieldBranch {
+		branch[i] = bytesutil.ToBytes32(node)
+	}
+	return proof.Nest(uint64(1)<<len(branch)+uint64(field.RealPosition()), branch)
+}
@@ -0,0 +1,414 @@
+package state_native
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	fastssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native/types"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stateutil"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+)
+
+// ProofPathSeparator separates the elements of the path of a proven beacon state field.
+const ProofPathSeparator = "."
+
+var (
+	validatorFieldNames         = []string{"pubkey", "withdrawal_credentials", "effective_balance", "slashed", "activation_eligibility_epoch", "activation_epoch", "exit_epoch", "withdrawable_epoch"}
+	historicalSummaryFieldNames = []string{"block_summary_root", "state_summary_root"}
+	checkpointFieldNames        = []string{"epoch", "root"}
+	forkFieldNames              = []string{"previous_version", "current_version", "epoch"}
+	blockHeaderFieldNames       = []string{"slot", "proposer_index", "parent_root", "state_root", "body_root"}
+	eth1DataFieldNames          = []string{"deposit_root", "deposit_count", "block_hash"}
+	payloadHeaderFieldNames     = []string{"parent_hash", "fee_recipient", "state_root", "receipts_root", "logs_bloom", "prev_randao", "block_number", "gas_limit", "gas_used", "timestamp", "extra_data", "base_fee_per_gas", "block_hash", "transactions_root", "withdrawals_root", "blob_gas_used", "excess_blob_gas"}
+)
+
+// specFieldName returns the name of the beacon state field in the consensus specs.
+func specFieldName(field types.FieldIndex) string {
+	switch field {
+	case types.GenesisTime:
+		return "genesis_time"
+	case types.GenesisValidatorsRoot:
+		return "genesis_validators_root"
+	case types.Slot:
+		return "slot"
+	case types.Fork:
+		return "fork"
+	case types.LatestBlockHeader:
+		return "latest_block_header"
+	case types.BlockRoots:
+		return "block_roots"
+	case types.StateRoots:
+		return "state_roots"
+	case types.HistoricalRoots:
+		return "historical_roots"
+	case types.Eth1Data:
+		return "eth1_data"
+	case types.Eth1DataVotes:
+		return "eth1_data_votes"
+	case types.Eth1DepositIndex:
+		return "eth1_deposit_index"
+	case types.Validators:
+		return "validators"
+	case types.Balances:
+		return "balances"
+	case types.RandaoMixes:
+		return "randao_mixes"
+	case types.Slashings:
+		return "slashings"
+	case types.PreviousEpochAttestations:
+		return "previous_epoch_attestations"
+	case types.CurrentEpochAttestations:
+		return "current_epoch_attestations"
+	case types.PreviousEpochParticipationBits:
+		return "previous_epoch_participation"
+	case types.CurrentEpochParticipationBits:
+		return "current_epoch_participation"
+	case types.JustificationBits:
+		return "justification_bits"
+	case types.PreviousJustifiedCheckpoint:
+		return "previous_justified_checkpoint"
+	case types.CurrentJustifiedCheckpoint:
+		return "current_justified_checkpoint"
+	case types.FinalizedCheckpoint:
+		return "finalized_checkpoint"
+	case types.InactivityScores:
+		return "inactivity_scores"
+	case types.CurrentSyncCommittee:
+		return "current_sync_committee"
+	case types.NextSyncCommittee:
+		return "next_sync_committee"
+	case types.LatestExecutionPayloadHeader, types.LatestExecutionPayloadHeaderCapella, types.LatestExecutionPayloadHeaderDeneb:
+		return "latest_execution_payload_header"
+	case types.NextWithdrawalIndex:
+		return "next_withdrawal_index"
+	case types.NextWithdrawalValidatorIndex:
+		return "next_withdrawal_validator_index"
+	case types.HistoricalSummaries:
+		return "historical_summaries"
+	default:
+		return ""
+	}
+}
+
+// fieldByName returns the field of the state with the given name in the consensus specs.
+func (b *BeaconState) fieldByName(name string) (types.FieldIndex, bool) {
+	var fields []types.FieldIndex
+	switch b.version {
+	case version.Phase0:
+		fields = phase0Fields
+	case version.Altair:
+		fields = altairFields
+	case version.Bellatrix:
+		fields = bellatrixFields
+	case version.Capella:
+		fields = capellaFields
+	case version.Deneb:
+		fields = denebFields
+	}
+	for _, f := range fields {
+		if specFieldName(f) == name {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// fieldProof proves the element at the path within the given field, relative to the root of the field.
+// An empty path proves the field root itself.
+//
+// WARNING: Caller must acquire the mutex before using.
+func (b *BeaconState) fieldProof(field types.FieldIndex, path []string) (*ssz.Proof, error) {
+	if len(path) == 0 {
+		return &ssz.Proof{GeneralizedIndex: 1, Leaf: bytesutil.ToBytes32(b.merkleLayers[0][field.RealPosition()])}, nil
+	}
+	switch field {
+	case types.Validators:
+		return validatorsProof(b.validators, path)
+	case types.Balances:
+		return packedUint64ListProof(b.balances, path)
+	case types.InactivityScores:
+		return packedUint64ListProof(b.inactivityScores, path)
+	case types.BlockRoots:
+		return rootsVectorProof(b.blockRoots[:], path)
+	case types.StateRoots:
+		return rootsVectorProof(b.stateRoots[:], path)
+	case types.RandaoMixes:
+		return rootsVectorProof(b.randaoMixes[:], path)
+	case types.HistoricalRoots:
+		index, err := elementIndex(path, len(b.historicalRoots))
+		if err != nil {
+			return nil, err
+		}
+		if len(path) > 1 {
+			return nil, errNotContainer(path)
+		}
+		return ssz.ListProof(b.historicalRoots, fieldparams.HistoricalRootsLength, uint64(len(b.historicalRoots)), index)
+	case types.HistoricalSummaries:
+		return historicalSummariesProof(b.historicalSummaries, path)
+	case types.Fork:
+		return containerProof(forkFieldNames, forkFieldRoots(b.fork), path)
+	case types.LatestBlockHeader:
+		return containerProof(blockHeaderFieldNames, stateutil.BlockHeaderFieldRoots(b.latestBlockHeader), path)
+	case types.Eth1Data:
+		return containerProof(eth1DataFieldNames, eth1DataFieldRoots(b.eth1Data), path)
+	case types.PreviousJustifiedCheckpoint:
+		return containerProof(checkpointFieldNames, checkpointFieldRoots(b.previousJustifiedCheckpoint), path)
+	case types.CurrentJustifiedCheckpoint:
+		return containerProof(checkpointFieldNames, checkpointFieldRoots(b.currentJustifiedCheckpoint), path)
+	case types.FinalizedCheckpoint:
+		return containerProof(checkpointFieldNames, checkpointFieldRoots(b.finalizedCheckpoint), path)
+	case types.LatestExecutionPayloadHeader, types.LatestExecutionPayloadHeaderCapella, types.LatestExecutionPayloadHeaderDeneb:
+		roots, err := b.payloadHeaderFieldRoots()
+		if err != nil {
+			return nil, err
+		}
+		return containerProof(payloadHeaderFieldNames[:len(roots)], roots, path)
+	default:
+		return nil, errors.Wrapf(state.ErrInvalidProofPath, "proofs within field %s are not supported", specFieldName(field))
+	}
+}
+
+func validatorsProof(validators []*ethpb.Validator, path []string) (*ssz.Proof, error) {
+	index, err := elementIndex(path, len(validators))
+	if err != nil {
+		return nil, err
+	}
+	roots, err := stateutil.OptimizedValidatorRoots(validators)
+	if err != nil {
+		return nil, err
+	}
+	p, err := ssz.ListProof(roots, fieldparams.ValidatorRegistryLimit, uint64(len(validators)), index)
+	if err != nil {
+		return nil, err
+	}
+	if len(path) == 1 {
+		return p, nil
+	}
+	fieldRoots, err := stateutil.ValidatorFieldRoots(validators[index])
+	if err != nil {
+		return nil, err
+	}
+	return nestedContainerProof(validatorFieldNames, fieldRoots, path[1:], p)
+}
+
+// packedUint64ListProof proves the chunk containing the uint64 at the given index of a list of
+// uint64 limited to the validator registry limit. As 4 values are packed in a chunk, the value is
+// found at the byte offset 8*(index%4) of the proven chunk.
+func packedUint64ListProof(values []uint64, path []string) (*ssz.Proof, error) {
+	index, err := elementIndex(path, len(values))
+	if err != nil {
+		return nil, err
+	}
+	if len(path) > 1 {
+		return nil, errNotContainer(path)
+	}
+	chunks, err := stateutil.PackUint64IntoChunks(values)
+	if err != nil {
+		return nil, err
+	}
+	return ssz.ListProof(chunks, stateutil.ValidatorLimitForBalancesChunks(), uint64(len(values)), index/4)
+}
+
+func rootsVectorProof(roots [][32]byte, path []string) (*ssz.Proof, error) {
+	index, err := elementIndex(path, len(roots))
+	if err != nil {
+		return nil, err
+	}
+	if len(path) > 1 {
+		return nil, errNotContainer(path)
+	}
+	return ssz.VectorProof(roots, uint64(len(roots)), index)
+}
+
+func historicalSummariesProof(summaries []*ethpb.HistoricalSummary, path []string) (*ssz.Proof, error) {
+	index, err := elementIndex(path, len(summaries))
+	if err != nil {
+		return nil, err
+	}
+	roots := make([][32]byte, len(summaries))
+	for i, s := range summaries {
+		roots[i], err = s.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not merkleize historical summary")
+		}
+	}
+	p, err := ssz.ListProof(roots, fieldparams.HistoricalRootsLength, uint64(len(summaries)), index)
+	if err != nil {
+		return nil, err
+	}
+	if len(path) == 1 {
+		return p, nil
+	}
+	summary := summaries[index]
+	fieldRoots := [][32]byte{bytesutil.ToBytes32(summary.BlockSummaryRoot), bytesutil.ToBytes32(summary.StateSummaryRoot)}
+	return nestedContainerProof(historicalSummaryFieldNames, fieldRoots, path[1:], p)
+}
+
+// containerProof proves the field of a container named by the path, given the roots of all the
+// fields of the container.
+func containerProof(names []string, fieldRoots [][32]byte, path []string) (*ssz.Proof, error) {
+	if len(path) > 1 {
+		return nil, errNotContainer(path[1:])
+	}
+	for i, name := range names {
+		if name == path[0] {
+			return ssz.VectorProof(fieldRoots, uint64(len(fieldRoots)), uint64(i))
+		}
+	}
+	return nil, errors.Wrapf(state.ErrInvalidProofPath, "unknown field %s", path[0])
+}
+
+// nestedContainerProof proves the field of a container named by the path, where the container is
+// the leaf of the given proof.
+func nestedContainerProof(names []string, fieldRoots [][32]byte, path []string, container *ssz.Proof) (*ssz.Proof, error) {
+	p, err := containerProof(names, fieldRoots, path)
+	if err != nil {
+		return nil, err
+	}
+	return p.Nest(container.GeneralizedIndex, container.Branch)
+}
+
+// elementIndex parses the first element of the path as an index into a list or vector of the given length.
+func elementIndex(path []string, length int) (uint64, error) {
+	index, err := strconv.ParseUint(path[0], 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(state.ErrInvalidProofPath, "invalid index %s", path[0])
+	}
+	if index >= uint64(length) {
+		return 0, errors.Wrapf(state.ErrInvalidProofPath, "index %d out of range for length %d", index, length)
+	}
+	return index, nil
+}
+
+func errNotContainer(path []string) error {
+	return errors.Wrapf(state.ErrInvalidProofPath, "cannot prove %s within a basic type", strings.Join(path, ProofPathSeparator))
+}
+
+func forkFieldRoots(fork *ethpb.Fork) [][32]byte {
+	if fork == nil {
+		fork = &ethpb.Fork{}
+	}
+	return [][32]byte{
+		bytesutil.ToBytes32(fork.PreviousVersion),
+		bytesutil.ToBytes32(fork.CurrentVersion),
+		ssz.Uint64Root(uint64(fork.Epoch)),
+	}
+}
+
+func eth1DataFieldRoots(data *ethpb.Eth1Data) [][32]byte {
+	if data == nil {
+		data = &ethpb.Eth1Data{}
+	}
+	return [][32]byte{
+		bytesutil.ToBytes32(data.DepositRoot),
+		ssz.Uint64Root(data.DepositCount),
+		bytesutil.ToBytes32(data.BlockHash),
+	}
+}
+
+func checkpointFieldRoots(cp *ethpb.Checkpoint) [][32]byte {
+	if cp == nil {
+		cp = &ethpb.Checkpoint{}
+	}
+	return [][32]byte{ssz.Uint64Root(uint64(cp.Epoch)), bytesutil.ToBytes32(cp.Root)}
+}
+
+// payloadHeaderFieldRoots returns the roots of the fields of the latest execution payload header,
+// which has more fields at every fork.
+//
+// WARNING: Caller must acquire the mutex before using.
+func (b *BeaconState) payloadHeaderFieldRoots() ([][32]byte, error) {
+	var roots [][32]byte
+	var err error
+	var expected [32]byte
+	switch b.version {
+	case version.Bellatrix:
+		h := b.latestExecutionPayloadHeader
+		roots, err = payloadHeaderCommonFieldRoots(h.ParentHash, h.FeeRecipient, h.StateRoot, h.ReceiptsRoot, h.LogsBloom, h.PrevRandao,
+			h.BlockNumber, h.GasLimit, h.GasUsed, h.Timestamp, h.ExtraData, h.BaseFeePerGas, h.BlockHash, h.TransactionsRoot)
+		if err != nil {
+			return nil, err
+		}
+		expected, err = h.HashTreeRoot()
+	case version.Capella:
+		h := b.latestExecutionPayloadHeaderCapella
+		roots, err = payloadHeaderCommonFieldRoots(h.ParentHash, h.FeeRecipient, h.StateRoot, h.ReceiptsRoot, h.LogsBloom, h.PrevRandao,
+			h.BlockNumber, h.GasLimit, h.GasUsed, h.Timestamp, h.ExtraData, h.BaseFeePerGas, h.BlockHash, h.TransactionsRoot)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, bytesutil.ToBytes32(h.WithdrawalsRoot))
+		expected, err = h.HashTreeRoot()
+	case version.Deneb:
+		h := b.latestExecutionPayloadHeaderDeneb
+		roots, err = payloadHeaderCommonFieldRoots(h.ParentHash, h.FeeRecipient, h.StateRoot, h.ReceiptsRoot, h.LogsBloom, h.PrevRandao,
+			h.BlockNumber, h.GasLimit, h.GasUsed, h.Timestamp, h.ExtraData, h.BaseFeePerGas, h.BlockHash, h.TransactionsRoot)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, bytesutil.ToBytes32(h.WithdrawalsRoot), ssz.Uint64Root(h.BlobGasUsed), ssz.Uint64Root(h.ExcessBlobGas))
+		expected, err = h.HashTreeRoot()
+	default:
+		return nil, errNotSupported("latest_execution_payload_header", b.version)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The field roots are computed separately from the generated SSZ code, make sure they agree.
+	// Merkleization hashes the chunks in place, so it is performed on a copy of the roots.
+	chunks := make([][32]byte, len(roots))
+	copy(chunks, roots)
+	root, err := ssz.BitwiseMerkleize(chunks, uint64(len(chunks)), uint64(len(chunks)))
+	if err != nil {
+		return nil, err
+	}
+	if root != expected {
+		return nil, errors.New("execution payload header field roots do not match its hash tree root")
+	}
+	return roots, nil
+}
+
+func payloadHeaderCommonFieldRoots(parentHash, feeRecipient, stateRoot, receiptsRoot, logsBloom, prevRandao []byte,
+	blockNumber, gasLimit, gasUsed, timestamp uint64, extraData, baseFeePerGas, blockHash, transactionsRoot []byte) ([][32]byte, error) {
+	logsBloomRoot, err := bytesRoot(logsBloom)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not merkleize logs bloom")
+	}
+	hh := fastssz.NewHasher()
+	indx := hh.Index()
+	hh.PutBytes(extraData)
+	hh.MerkleizeWithMixin(indx, uint64(len(extraData)), (fieldparams.RootLength+31)/32)
+	extraDataRoot, err := hh.HashRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not merkleize extra data")
+	}
+	return [][32]byte{
+		bytesutil.ToBytes32(parentHash),
+		bytesutil.ToBytes32(feeRecipient),
+		bytesutil.ToBytes32(stateRoot),
+		bytesutil.ToBytes32(receiptsRoot),
+		logsBloomRoot,
+		bytesutil.ToBytes32(prevRandao),
+		ssz.Uint64Root(blockNumber),
+		ssz.Uint64Root(gasLimit),
+		ssz.Uint64Root(gasUsed),
+		ssz.Uint64Root(timestamp),
+		extraDataRoot,
+		bytesutil.ToBytes32(baseFeePerGas),
+		bytesutil.ToBytes32(blockHash),
+		bytesutil.ToBytes32(transactionsRoot),
+	}, nil
+}
+
+func bytesRoot(b []byte) ([32]byte, error) {
+	hh := fastssz.NewHasher()
+	hh.PutBytes(b)
+	return hh.HashRoot()
+}
@@ -2,11 +2,19 @@ package state_native_test
 
 import (
 	"context"
+	"encoding/binary"
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	statenative "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/container/trie"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
 )
@@ -164,3 +172,81 @@ func TestBeaconStateMerkleProofs_bellatrix(t *testing.T) {
 		require.Equal(t, true, valid)
 	})
 }
+
+func TestBeaconStateFieldProof(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateDeneb(t, 64)
+	require.NoError(t, st.UpdateBalancesAtIndex(5, 31_000_000_000))
+	require.NoError(t, st.AppendHistoricalSummaries(&ethpb.HistoricalSummary{
+		BlockSummaryRoot: bytesutil.PadTo([]byte("block"), 32),
+		StateSummaryRoot: bytesutil.PadTo([]byte("state"), 32),
+	}))
+	header, err := blocks.WrappedExecutionPayloadHeaderDeneb(&enginev1.ExecutionPayloadHeaderDeneb{
+		ParentHash:       bytesutil.PadTo([]byte("parent"), 32),
+		FeeRecipient:     bytesutil.PadTo([]byte("fee"), 20),
+		StateRoot:        bytesutil.PadTo([]byte("state"), 32),
+		ReceiptsRoot:     bytesutil.PadTo([]byte("receipts"), 32),
+		LogsBloom:        bytesutil.PadTo([]byte("bloom"), 256),
+		PrevRandao:       bytesutil.PadTo([]byte("randao"), 32),
+		BlockNumber:      10,
+		GasLimit:         30_000_000,
+		ExtraData:        []byte("extra"),
+		BaseFeePerGas:    bytesutil.PadTo([]byte{7}, 32),
+		BlockHash:        bytesutil.PadTo([]byte("hash"), 32),
+		TransactionsRoot: bytesutil.PadTo([]byte("txs"), 32),
+		WithdrawalsRoot:  bytesutil.PadTo([]byte("withdrawals"), 32),
+		BlobGasUsed:      131072,
+	}, big.NewInt(0))
+	require.NoError(t, err)
+	require.NoError(t, st.SetLatestExecutionPayloadHeader(header))
+	root, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+
+	validator, err := st.ValidatorAtIndex(3)
+	require.NoError(t, err)
+	tests := []struct {
+		path   string
+		gindex uint64
+		leaf   [32]byte
+	}{
+		{path: "slot", gindex: 34, leaf: ssz.Uint64Root(0)},
+		{path: "validators.3.effective_balance", gindex: 756463999909914},
+		{path: "validators.3.pubkey"},
+		{path: "validators.63"},
+		{path: "balances.5"},
+		{path: "inactivity_scores.2"},
+		{path: "block_roots.7"},
+		{path: "historical_summaries.0"},
+		{path: "historical_summaries.0.state_summary_root", leaf: bytesutil.ToBytes32(bytesutil.PadTo([]byte("state"), 32))},
+		{path: "latest_execution_payload_header.block_hash", leaf: bytesutil.ToBytes32(bytesutil.PadTo([]byte("hash"), 32))},
+		{path: "latest_execution_payload_header.extra_data"},
+		{path: "latest_execution_payload_header.blob_gas_used", leaf: ssz.Uint64Root(131072)},
+		{path: "finalized_checkpoint.root"},
+		{path: "latest_block_header.body_root"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			proof, err := st.FieldProof(ctx, tt.path)
+			require.NoError(t, err)
+			require.Equal(t, true, proof.Verify(root))
+			if tt.gindex != 0 {
+				require.Equal(t, tt.gindex, proof.GeneralizedIndex)
+			}
+			if tt.leaf != [32]byte{} {
+				require.Equal(t, tt.leaf, proof.Leaf)
+			}
+		})
+	}
+
+	proof, err := st.FieldProof(ctx, "validators.3.effective_balance")
+	require.NoError(t, err)
+	require.Equal(t, ssz.Uint64Root(validator.EffectiveBalance), proof.Leaf)
+	proof, err = st.FieldProof(ctx, "balances.5")
+	require.NoError(t, err)
+	require.Equal(t, uint64(31_000_000_000), binary.LittleEndian.Uint64(proof.Leaf[8:16]))
+
+	for _, path := range []string{"unknown", "validators.64", "validators.x", "validators.1.unknown", "slot.1", "balances.1.2", "current_epoch_attestations"} {
+		_, err := st.FieldProof(ctx, path)
+		require.ErrorIs(t, err, state.ErrInvalidProofPath)
+	}
+}
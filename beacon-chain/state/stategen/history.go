@@ -20,6 +20,14 @@ func WithCache(c CachedGetter) CanonicalHistoryOption {
 	}
 }
 
+// WithRetainedHistory makes CanonicalHistory refuse slots older than the block history retained in the database.
+// Without it, states in a pruned range would be regenerated by replaying slots without their blocks.
+func WithRetainedHistory(r RetainedHistory) CanonicalHistoryOption {
+	return func(h *CanonicalHistory) {
+		h.retained = r
+	}
+}
+
 type CanonicalHistoryOption func(*CanonicalHistory)
 
 func NewCanonicalHistory(h HistoryAccessor, cc CanonicalChecker, cs CurrentSlotter, opts ...CanonicalHistoryOption) *CanonicalHistory {
@@ -35,10 +43,11 @@ func NewCanonicalHistory(h HistoryAccessor, cc CanonicalChecker, cs CurrentSlott
 }
 
 type CanonicalHistory struct {
-	h     HistoryAccessor
-	cc    CanonicalChecker
-	cs    CurrentSlotter
-	cache CachedGetter
+	h        HistoryAccessor
+	cc       CanonicalChecker
+	cs       CurrentSlotter
+	cache    CachedGetter
+	retained RetainedHistory
 }
 
 func (c *CanonicalHistory) ReplayerForSlot(target primitives.Slot) Replayer {
@@ -49,6 +58,15 @@ func (c *CanonicalHistory) BlockRootForSlot(ctx context.Context, target primitiv
 	if currentSlot := c.cs.CurrentSlot(); target > currentSlot {
 		return [32]byte{}, errors.Wrap(ErrFutureSlotRequested, fmt.Sprintf("requested=%d, current=%d", target, currentSlot))
 	}
+	if c.retained != nil {
+		lowest, err := c.retained.LowestRetainedSlot(ctx)
+		if err != nil {
+			return [32]byte{}, errors.Wrap(err, "could not get lowest retained slot")
+		}
+		if target < lowest {
+			return [32]byte{}, errors.Wrapf(ErrNoDataForSlot, "slot %d was pruned, history starts at slot %d", target, lowest)
+		}
+	}
 
 	slotAbove := target + 1
 	// don't bother searching for candidate roots when we know the target slot is genesis
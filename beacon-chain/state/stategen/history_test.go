@@ -23,6 +23,31 @@ func TestBlockForSlotFuture(t *testing.T) {
 	require.ErrorIs(t, err, ErrFutureSlotRequested)
 }
 
+type mockRetainedHistory struct {
+	lowest primitives.Slot
+}
+
+func (m *mockRetainedHistory) LowestRetainedSlot(_ context.Context) (primitives.Slot, error) {
+	return m.lowest, nil
+}
+
+func TestBlockForSlotPruned(t *testing.T) {
+	ctx := context.Background()
+	var begin, end primitives.Slot = 100, 150
+	specs := []mockHistorySpec{
+		{slot: begin, canonicalBlock: true},
+		{slot: end, canonicalBlock: true},
+	}
+	hist := newMockHistory(t, specs, end+1)
+	ch := NewCanonicalHistory(hist, hist, hist, WithRetainedHistory(&mockRetainedHistory{lowest: begin}))
+
+	r, err := ch.BlockRootForSlot(ctx, begin)
+	require.NoError(t, err)
+	require.Equal(t, hist.slotMap[begin], r)
+	_, err = ch.BlockRootForSlot(ctx, begin-1)
+	require.ErrorIs(t, err, ErrNoDataForSlot)
+}
+
 func TestChainForSlotFuture(t *testing.T) {
 	ch := &CanonicalHistory{
 		cs: &mockCurrentSlotter{Slot: 0},
@@ -32,6 +32,11 @@ type HistoryAccessor interface {
 	StateOrError(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
 }
 
+// RetainedHistory describes the database method reporting the lowest slot of the block history that was not pruned.
+type RetainedHistory interface {
+	LowestRetainedSlot(ctx context.Context) (primitives.Slot, error)
+}
+
 // CanonicalChecker determines whether the given block root is canonical.
 // In practice this should be satisfied by a type that uses the fork choice store.
 type CanonicalChecker interface {
@@ -12,6 +12,12 @@ import (
 // a BeaconBlockHeader struct according to the Ethereum
 // Simple Serialize specification.
 func BlockHeaderRoot(header *ethpb.BeaconBlockHeader) ([32]byte, error) {
+	fieldRoots := BlockHeaderFieldRoots(header)
+	return ssz.BitwiseMerkleize(fieldRoots, uint64(len(fieldRoots)), uint64(len(fieldRoots)))
+}
+
+// BlockHeaderFieldRoots returns the roots of the fields of a BeaconBlockHeader struct, in order.
+func BlockHeaderFieldRoots(header *ethpb.BeaconBlockHeader) [][32]byte {
 	fieldRoots := make([][32]byte, 5)
 	if header != nil {
 		headerSlotBuf := make([]byte, 8)
@@ -29,5 +35,5 @@ func BlockHeaderRoot(header *ethpb.BeaconBlockHeader) ([32]byte, error) {
 		bodyRoot := bytesutil.ToBytes32(header.BodyRoot)
 		fieldRoots[4] = bodyRoot
 	}
-	return ssz.BitwiseMerkleize(fieldRoots, uint64(len(fieldRoots)), uint64(len(fieldRoots)))
+	return fieldRoots
 }
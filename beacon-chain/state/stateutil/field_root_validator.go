@@ -29,6 +29,12 @@ func ValidatorRegistryRoot(vals []*ethpb.Validator) ([32]byte, error) {
 	return validatorRegistryRoot(vals)
 }
 
+// OptimizedValidatorRoots computes the HashTreeRoot Merkleization of
+// each validator of a list of validator structs.
+func OptimizedValidatorRoots(vals []*ethpb.Validator) ([][32]byte, error) {
+	return optimizedValidatorRoots(vals)
+}
+
 func validatorRegistryRoot(validators []*ethpb.Validator) ([32]byte, error) {
 	roots, err := optimizedValidatorRoots(validators)
 	if err != nil {
@@ -0,0 +1,34 @@
+package backfill
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	backfillRemainingSlots = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "backfill_remaining_slots",
+			Help: "Number of slots between genesis and the lowest backfilled block that remain to be backfilled.",
+		},
+	)
+	backfillBlocksCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "backfill_blocks_total",
+			Help: "Number of blocks downloaded and saved by backfill.",
+		},
+	)
+	backfillBatchFailures = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "backfill_batch_failures_total",
+			Help: "Number of backfill batches that failed to be downloaded, verified or saved.",
+		},
+	)
+	backfillBatchRequestLatency = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "backfill_batch_request_latency_milliseconds",
+			Help:    "Time taken by peers to respond to a backfill BeaconBlocksByRange request.",
+			Buckets: []float64{100, 250, 500, 1000, 2000, 4000, 8000},
+		},
+	)
+)
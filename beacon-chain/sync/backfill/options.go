@@ -0,0 +1,59 @@
+package backfill
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+)
+
+// ServiceOption represents a functional option for the backfill service constructor.
+type ServiceOption func(*Service) error
+
+// WithEnableBackfill toggles whether the backfill service downloads the blocks missing before the origin checkpoint.
+func WithEnableBackfill(enabled bool) ServiceOption {
+	return func(s *Service) error {
+		s.enabled = enabled
+		return nil
+	}
+}
+
+// WithBatchSize sets the number of slots requested from a peer in each backfill batch.
+func WithBatchSize(n uint64) ServiceOption {
+	return func(s *Service) error {
+		if n > 0 {
+			s.batchSize = n
+		}
+		return nil
+	}
+}
+
+// WithStore sets the database used to save backfilled blocks.
+func WithStore(store Store) ServiceOption {
+	return func(s *Service) error {
+		s.store = store
+		return nil
+	}
+}
+
+// WithP2P sets the p2p service used to select peers to backfill from.
+func WithP2P(p p2p.P2P) ServiceOption {
+	return func(s *Service) error {
+		s.p2p = p
+		return nil
+	}
+}
+
+// WithClockWaiter sets the startup.ClockWaiter, used to wait until the genesis data is known.
+func WithClockWaiter(cw startup.ClockWaiter) ServiceOption {
+	return func(s *Service) error {
+		s.clockWaiter = cw
+		return nil
+	}
+}
+
+// WithBlockRequester sets the function used to request block ranges from peers.
+func WithBlockRequester(r BlockRequester) ServiceOption {
+	return func(s *Service) error {
+		s.requester = r
+		return nil
+	}
+}
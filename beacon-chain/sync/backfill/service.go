@@ -0,0 +1,249 @@
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/rand"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var _ runtime.Service = (*Service)(nil)
+
+const (
+	defaultBatchSize = 64
+	// retryDelay is how long the service waits before retrying after a failed batch, or when no peers are available.
+	retryDelay = 5 * time.Second
+)
+
+var (
+	errNoPeers          = errors.New("no suitable peers available for backfill")
+	errUnlinkedBlock    = errors.New("block does not link to the parent root of the lowest backfilled block")
+	errUnexpectedSlot   = errors.New("block slot is outside of the requested range")
+	errMissingGenesis   = errors.New("backfill reached genesis slot without linking to the genesis block root")
+	errNilBlockRequest  = errors.New("backfill service requires a BlockRequester")
+	errNilBackfillStore = errors.New("backfill service requires a Store")
+)
+
+// BlockRequester requests a range of blocks from the given peer. This is typically satisfied by a closure over
+// sync.SendBeaconBlocksByRangeRequest, which cannot be imported here directly without creating an import cycle.
+type BlockRequester func(ctx context.Context, pid peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]interfaces.ReadOnlySignedBeaconBlock, error)
+
+// Store describes the set of DB methods that the backfill Service needs to function.
+type Store interface {
+	BackfillDB
+	SaveBackfillBlocks(ctx context.Context, blks []interfaces.ReadOnlySignedBeaconBlock, childRoot [32]byte) error
+}
+
+// Service downloads the blocks missing between genesis and the checkpoint sync origin block. Blocks are requested
+// backwards from the origin in batches via BeaconBlocksByRange, each block is checked against the parent root of
+// the block above it, and batches are written to the database, indexed as finalized, before the backfill Status
+// is advanced.
+type Service struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	enabled     bool
+	batchSize   uint64
+	store       Store
+	status      *Status
+	p2p         p2p.P2P
+	clockWaiter startup.ClockWaiter
+	requester   BlockRequester
+	rand        *rand.Rand
+	// cursor is the exclusive upper bound of the next requested range, and expected is the root that the highest
+	// block of that range must have. These may run ahead of the Status when a range contains only skipped slots.
+	cursor   primitives.Slot
+	expected [32]byte
+}
+
+// NewService initializes a backfill Service with the given options.
+func NewService(ctx context.Context, status *Status, opts ...ServiceOption) (*Service, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Service{
+		ctx:       ctx,
+		cancel:    cancel,
+		status:    status,
+		batchSize: defaultBatchSize,
+		rand:      rand.NewGenerator(),
+	}
+	for _, o := range opts {
+		if err := o(s); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	if !s.enabled {
+		return s, nil
+	}
+	if s.store == nil {
+		cancel()
+		return nil, errNilBackfillStore
+	}
+	if s.requester == nil {
+		cancel()
+		return nil, errNilBlockRequest
+	}
+	return s, nil
+}
+
+// Start runs the backfill loop until the gap between genesis and the origin checkpoint has been filled.
+func (s *Service) Start() {
+	if !s.enabled {
+		log.Debug("Backfill service not enabled")
+		return
+	}
+	if s.status.Complete() {
+		log.Info("Backfill not required, node history is complete")
+		return
+	}
+	go s.run()
+}
+
+// Stop the backfill service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the backfill service.
+func (s *Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	if _, err := s.clockWaiter.WaitForClock(s.ctx); err != nil {
+		log.WithError(err).Error("Backfill service failed to receive startup event")
+		return
+	}
+	s.resetCursor()
+	log.WithFields(logrus.Fields{
+		"startSlot": s.status.StartGap(),
+		"endSlot":   s.status.EndGap(),
+	}).Info("Starting backfill of blocks before the checkpoint origin")
+	for !s.status.Complete() {
+		if s.ctx.Err() != nil {
+			return
+		}
+		if err := s.fillBatch(s.ctx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			backfillBatchFailures.Inc()
+			log.WithError(err).Debug("Failed to backfill batch, retrying")
+			s.resetCursor()
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(retryDelay):
+			}
+		}
+	}
+	log.WithField("genesisRoot", fmt.Sprintf("%#x", s.status.GenesisRoot())).Info("Backfill complete")
+}
+
+// resetCursor discards any progress across empty ranges that has not been confirmed by a linked block,
+// and resumes from the current Status.
+func (s *Service) resetCursor() {
+	s.cursor = s.status.EndGap()
+	s.expected = s.status.EndParentRoot()
+}
+
+// fillBatch requests the next range of blocks below the cursor, verifies that they link to the expected
+// parent root, saves them as finalized blocks and advances the backfill Status.
+func (s *Service) fillBatch(ctx context.Context) error {
+	req := s.nextRequest()
+	pid, err := s.selectPeer()
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	blks, err := s.requester(ctx, pid, req)
+	if err != nil {
+		return errors.Wrapf(err, "could not request blocks from peer %s", pid)
+	}
+	backfillBatchRequestLatency.Observe(float64(time.Since(start).Milliseconds()))
+	expected, err := verifyBatch(req, blks, s.expected)
+	if err != nil {
+		s.p2p.Peers().Scorers().BadResponsesScorer().Increment(pid)
+		return errors.Wrapf(err, "invalid batch from peer %s", pid)
+	}
+	s.cursor = req.StartSlot
+	s.expected = expected
+	if len(blks) > 0 {
+		if err := s.store.SaveBackfillBlocks(ctx, blks, s.status.EndRoot()); err != nil {
+			return errors.Wrap(err, "could not save backfilled blocks")
+		}
+		if err := s.status.Advance(ctx, blks[0]); err != nil {
+			return err
+		}
+		backfillBlocksCount.Add(float64(len(blks)))
+	}
+	backfillRemainingSlots.Set(float64(s.cursor - s.status.StartGap()))
+	log.WithFields(logrus.Fields{
+		"peer":      pid,
+		"startSlot": req.StartSlot,
+		"count":     req.Count,
+		"blocks":    len(blks),
+	}).Debug("Backfilled batch")
+	if s.cursor <= s.status.StartGap()+1 && !s.status.Complete() {
+		return errMissingGenesis
+	}
+	return nil
+}
+
+// nextRequest builds the BeaconBlocksByRange request for the batch immediately below the cursor.
+func (s *Service) nextRequest() *ethpb.BeaconBlocksByRangeRequest {
+	// The genesis block is always present, so the lowest slot to request is the one above it.
+	lowest := s.status.StartGap() + 1
+	start := lowest
+	if s.cursor > lowest+primitives.Slot(s.batchSize) {
+		start = s.cursor - primitives.Slot(s.batchSize)
+	}
+	return &ethpb.BeaconBlocksByRangeRequest{
+		StartSlot: start,
+		Count:     uint64(s.cursor - start),
+		Step:      1,
+	}
+}
+
+// selectPeer picks a random peer whose finalized checkpoint is at or beyond the backfill range.
+func (s *Service) selectPeer() (peer.ID, error) {
+	_, pids := s.p2p.Peers().BestFinalized(params.BeaconConfig().MaxPeersToSync, slots.ToEpoch(s.cursor))
+	if len(pids) == 0 {
+		return "", errNoPeers
+	}
+	return pids[s.rand.Intn(len(pids))], nil
+}
+
+// verifyBatch checks that every block in the batch is within the requested range and that, walking downwards from
+// the highest block, each block root matches the parent root of the block above it. It returns the parent root
+// that the next lower batch must link to.
+func verifyBatch(req *ethpb.BeaconBlocksByRangeRequest, blks []interfaces.ReadOnlySignedBeaconBlock, expected [32]byte) ([32]byte, error) {
+	end := req.StartSlot.Add(req.Count)
+	for i := len(blks) - 1; i >= 0; i-- {
+		b := blks[i].Block()
+		if b.Slot() < req.StartSlot || b.Slot() >= end {
+			return [32]byte{}, errors.Wrapf(errUnexpectedSlot, "slot=%d", b.Slot())
+		}
+		root, err := b.HashTreeRoot()
+		if err != nil {
+			return [32]byte{}, err
+		}
+		if root != expected {
+			return [32]byte{}, errors.Wrapf(errUnlinkedBlock, "slot=%d, root=%#x, expected=%#x", b.Slot(), root, expected)
+		}
+		expected = b.ParentRoot()
+	}
+	return expected, nil
+}
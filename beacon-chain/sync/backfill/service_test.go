@@ -0,0 +1,191 @@
+package backfill
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
+	p2ptest "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	blocktest "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks/testing"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+type mockStore struct {
+	*mockBackfillDB
+	blocks map[[32]byte]interfaces.ReadOnlySignedBeaconBlock
+}
+
+func (s *mockStore) SaveBackfillBlocks(_ context.Context, blks []interfaces.ReadOnlySignedBeaconBlock, _ [32]byte) error {
+	for _, b := range blks {
+		r, err := b.Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		s.blocks[r] = b
+	}
+	return nil
+}
+
+// testChain builds a chain of blocks from genesis up to the given slot, skipping the given slots.
+func testChain(t *testing.T, upTo primitives.Slot, skipped map[primitives.Slot]bool) []interfaces.ReadOnlySignedBeaconBlock {
+	genesis, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	chain := []interfaces.ReadOnlySignedBeaconBlock{genesis}
+	parent, err := genesis.Block().HashTreeRoot()
+	require.NoError(t, err)
+	for slot := primitives.Slot(1); slot <= upTo; slot++ {
+		if skipped[slot] {
+			continue
+		}
+		b, err := setupTestBlock(slot)
+		require.NoError(t, err)
+		b, err = blocktest.SetBlockParentRoot(b, parent)
+		require.NoError(t, err)
+		parent, err = b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		chain = append(chain, b)
+	}
+	return chain
+}
+
+func rangeRequester(chain []interfaces.ReadOnlySignedBeaconBlock) BlockRequester {
+	return func(_ context.Context, _ peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+		res := make([]interfaces.ReadOnlySignedBeaconBlock, 0)
+		for _, b := range chain {
+			if b.Block().Slot() >= req.StartSlot && b.Block().Slot() < req.StartSlot.Add(req.Count) {
+				res = append(res, b)
+			}
+		}
+		return res, nil
+	}
+}
+
+func setupService(t *testing.T, chain []interfaces.ReadOnlySignedBeaconBlock, requester BlockRequester) (*Service, *mockStore, peer.ID) {
+	ctx := context.Background()
+	genesisRoot, err := chain[0].Block().HashTreeRoot()
+	require.NoError(t, err)
+	origin := chain[len(chain)-1]
+	originRoot, err := origin.Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	store := &mockStore{blocks: map[[32]byte]interfaces.ReadOnlySignedBeaconBlock{
+		genesisRoot: chain[0],
+		originRoot:  origin,
+	}}
+	var bfRoot [32]byte
+	store.mockBackfillDB = &mockBackfillDB{
+		genesisBlockRoot:          goodBlockRoot(genesisRoot),
+		originCheckpointBlockRoot: goodBlockRoot(originRoot),
+		backfillBlockRoot: func(ctx context.Context) ([32]byte, error) {
+			return bfRoot, nil
+		},
+		saveBackfillBlockRoot: func(ctx context.Context, root [32]byte) error {
+			bfRoot = root
+			return nil
+		},
+		block: func(ctx context.Context, root [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error) {
+			return store.blocks[root], nil
+		},
+	}
+	bfRoot = genesisRoot
+	status := NewStatus(store)
+	require.NoError(t, status.Reload(ctx))
+
+	p := p2ptest.NewTestP2P(t)
+	pid := peer.ID("peer")
+	p.Peers().Add(new(enr.Record), pid, nil, network.DirOutbound)
+	p.Peers().SetConnectionState(pid, peers.PeerConnected)
+	p.Peers().SetChainState(pid, &ethpb.Status{FinalizedEpoch: 10})
+
+	s, err := NewService(ctx, status,
+		WithEnableBackfill(true),
+		WithBatchSize(8),
+		WithStore(store),
+		WithP2P(p),
+		WithBlockRequester(requester),
+	)
+	require.NoError(t, err)
+	s.resetCursor()
+	return s, store, pid
+}
+
+func TestService_FillBatch(t *testing.T) {
+	ctx := context.Background()
+	// skip an entire batch worth of slots to make sure empty ranges are crossed.
+	skipped := map[primitives.Slot]bool{3: true, 17: true}
+	for i := primitives.Slot(20); i < 30; i++ {
+		skipped[i] = true
+	}
+	chain := testChain(t, 40, skipped)
+	s, store, _ := setupService(t, chain, rangeRequester(chain))
+	require.Equal(t, primitives.Slot(40), s.status.EndGap())
+	require.Equal(t, false, s.status.SlotCovered(10))
+
+	for i := 0; i < 10 && !s.status.Complete(); i++ {
+		require.NoError(t, s.fillBatch(ctx))
+	}
+	require.Equal(t, true, s.status.Complete())
+	require.Equal(t, primitives.Slot(1), s.status.EndGap())
+	require.Equal(t, true, s.status.SlotCovered(10))
+	for _, b := range chain {
+		r, err := b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		_, ok := store.blocks[r]
+		require.Equal(t, true, ok)
+	}
+
+	// Reloading from the database should pick up the completed backfill.
+	status := NewStatus(store)
+	require.NoError(t, status.Reload(ctx))
+	require.Equal(t, true, status.Complete())
+}
+
+func TestService_FillBatch_UnlinkedBlock(t *testing.T) {
+	ctx := context.Background()
+	chain := testChain(t, 40, nil)
+	// A peer serving blocks from a different chain must not be able to advance the backfill.
+	forked := testChain(t, 40, map[primitives.Slot]bool{5: true})
+	s, store, pid := setupService(t, chain, rangeRequester(forked))
+
+	err := s.fillBatch(ctx)
+	require.ErrorIs(t, err, errUnlinkedBlock)
+	require.Equal(t, primitives.Slot(40), s.status.EndGap())
+	require.Equal(t, 2, len(store.blocks))
+	badResponses, err := s.p2p.Peers().Scorers().BadResponsesScorer().Count(pid)
+	require.NoError(t, err)
+	require.Equal(t, 1, badResponses)
+}
+
+func TestService_FillBatch_MissingGenesisLink(t *testing.T) {
+	ctx := context.Background()
+	chain := testChain(t, 20, nil)
+	// Only serve the upper part of the chain, so that the range down to genesis appears empty.
+	s, _, _ := setupService(t, chain, rangeRequester(chain[10:]))
+
+	var err error
+	for i := 0; i < 10 && err == nil; i++ {
+		err = s.fillBatch(ctx)
+	}
+	require.ErrorIs(t, err, errMissingGenesis)
+	require.Equal(t, false, s.status.Complete())
+	// The unconfirmed progress across the empty range is discarded.
+	s.resetCursor()
+	require.Equal(t, s.status.EndGap(), s.cursor)
+}
+
+func TestVerifyBatch_OutOfRange(t *testing.T) {
+	chain := testChain(t, 20, nil)
+	root, err := chain[20].Block().HashTreeRoot()
+	require.NoError(t, err)
+	req := &ethpb.BeaconBlocksByRangeRequest{StartSlot: 10, Count: 5, Step: 1}
+	_, err = verifyBatch(req, chain[18:21], root)
+	require.ErrorIs(t, err, errUnexpectedSlot)
+}
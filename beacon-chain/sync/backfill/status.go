@@ -2,6 +2,7 @@ package backfill
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
@@ -19,25 +20,34 @@ func NewStatus(store BackfillDB) *Status {
 
 // Status provides a way to update and query the status of a backfill process that may be necessary to track when
 // a node was initialized via checkpoint sync. With checkpoint sync, there will be a gap in node history from genesis
-// until the checkpoint sync origin block. Status provides the means to update the value keeping track of the lower
-// end of the missing block range via the Advance() method, to check whether a Slot is missing from the database
-// via the SlotCovered() method, and to see the current StartGap() and EndGap().
+// until the checkpoint sync origin block. Backfill fills this gap backwards, starting from the origin block and
+// following parent roots down to genesis. Status provides the means to update the value keeping track of the upper
+// end of the missing block range (the lowest block that has been backfilled so far) via the Advance() method, to check
+// whether a Slot is missing from the database via the SlotCovered() method, and to see the current StartGap()
+// and EndGap().
 type Status struct {
+	mu          sync.RWMutex
 	start       primitives.Slot
 	end         primitives.Slot
+	endRoot     [32]byte
+	endParent   [32]byte
+	genesisRoot [32]byte
 	store       BackfillDB
 	genesisSync bool
+	complete    bool
 }
 
 // SlotCovered uses StartGap() and EndGap() to determine if the given slot is covered by the current chain history.
 // If the slot is <= StartGap(), or >= EndGap(), the result is true.
 // If the slot is between StartGap() and EndGap(), the result is false.
 func (s *Status) SlotCovered(sl primitives.Slot) bool {
-	// short circuit if the node was synced from genesis
-	if s.genesisSync {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// short circuit if the node was synced from genesis, or backfill has reached genesis
+	if s.genesisSync || s.complete {
 		return true
 	}
-	if s.StartGap() < sl && sl < s.EndGap() {
+	if s.start < sl && sl < s.end {
 		return false
 	}
 	return true
@@ -45,29 +55,82 @@ func (s *Status) SlotCovered(sl primitives.Slot) bool {
 
 // StartGap returns the slot at the beginning of the range that needs to be backfilled.
 func (s *Status) StartGap() primitives.Slot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.start
 }
 
 // EndGap returns the slot at the end of the range that needs to be backfilled.
+// This is the slot of the lowest block that is known to link back to the origin checkpoint block.
 func (s *Status) EndGap() primitives.Slot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.end
 }
 
+// EndRoot returns the root of the block at EndGap(), which is the child of the next block to be backfilled.
+func (s *Status) EndRoot() [32]byte {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.endRoot
+}
+
+// EndParentRoot returns the parent root of the block at EndGap(). The next block to be backfilled must
+// have this root.
+func (s *Status) EndParentRoot() [32]byte {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.endParent
+}
+
+// GenesisRoot returns the genesis block root, which is the final parent root that backfill must link to.
+func (s *Status) GenesisRoot() [32]byte {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.genesisRoot
+}
+
+// Complete returns true when there is no gap to fill, either because the node was synced from genesis,
+// or because backfill has reached the genesis block.
+func (s *Status) Complete() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.genesisSync || s.complete
+}
+
 var ErrAdvancePastOrigin = errors.New("cannot advance backfill Status beyond the origin checkpoint slot")
 
-// Advance advances the backfill position to the given slot & root.
-// It updates the backfill block root entry in the database,
-// and also updates the Status value's copy of the backfill position slot.
-func (s *Status) Advance(ctx context.Context, upTo primitives.Slot, root [32]byte) error {
-	if upTo > s.end {
-		return errors.Wrapf(ErrAdvancePastOrigin, "advance slot=%d, origin slot=%d", upTo, s.end)
+// Advance moves the upper end of the gap down to the given block, which must be the parent of the block at EndGap().
+// It updates the backfill block root entry in the database, and also updates the Status value's copy of
+// the backfill position. Once the parent of the given block is the genesis block, the backfill is complete.
+func (s *Status) Advance(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock) error {
+	if err := blocks.BeaconBlockIsNil(blk); err != nil {
+		return err
+	}
+	upTo := blk.Block().Slot()
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute backfill block root")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if upTo >= s.end {
+		return errors.Wrapf(ErrAdvancePastOrigin, "advance slot=%d, current backfill slot=%d", upTo, s.end)
 	}
-	s.start = upTo
-	return s.store.SaveBackfillBlockRoot(ctx, root)
+	if err := s.store.SaveBackfillBlockRoot(ctx, root); err != nil {
+		return err
+	}
+	s.end = upTo
+	s.endRoot = root
+	s.endParent = blk.Block().ParentRoot()
+	s.complete = s.endParent == s.genesisRoot
+	return nil
 }
 
 // Reload queries the database for backfill status, initializing the internal data and validating the database state.
 func (s *Status) Reload(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	cpRoot, err := s.store.OriginCheckpointBlockRoot(ctx)
 	if err != nil {
 		// mark genesis sync and short circuit further lookups
@@ -85,14 +148,17 @@ func (s *Status) Reload(ctx context.Context) error {
 		return err
 	}
 	s.end = cpBlock.Block().Slot()
+	s.endRoot = cpRoot
+	s.endParent = cpBlock.Block().ParentRoot()
 
-	_, err = s.store.GenesisBlockRoot(ctx)
+	genesisRoot, err := s.store.GenesisBlockRoot(ctx)
 	if err != nil {
 		if errors.Is(err, db.ErrNotFoundGenesisBlockRoot) {
 			return errors.Wrap(err, "genesis block root required for checkpoint sync")
 		}
 		return err
 	}
+	s.genesisRoot = genesisRoot
 
 	bfRoot, err := s.store.BackfillBlockRoot(ctx)
 	if err != nil {
@@ -101,14 +167,21 @@ func (s *Status) Reload(ctx context.Context) error {
 		}
 		return err
 	}
-	bfBlock, err := s.store.Block(ctx, bfRoot)
-	if err != nil {
-		return errors.Wrapf(err, "error retrieving block for backfill root=%#x", bfRoot)
-	}
-	if err := blocks.BeaconBlockIsNil(bfBlock); err != nil {
-		return err
+	// Checkpoint sync initializes the backfill block root to the genesis root, meaning that nothing has been
+	// backfilled yet and the gap extends all the way up to the origin block.
+	if bfRoot != genesisRoot {
+		bfBlock, err := s.store.Block(ctx, bfRoot)
+		if err != nil {
+			return errors.Wrapf(err, "error retrieving block for backfill root=%#x", bfRoot)
+		}
+		if err := blocks.BeaconBlockIsNil(bfBlock); err != nil {
+			return err
+		}
+		s.end = bfBlock.Block().Slot()
+		s.endRoot = bfRoot
+		s.endParent = bfBlock.Block().ParentRoot()
 	}
-	s.start = bfBlock.Block().Slot()
+	s.complete = s.endParent == s.genesisRoot
 	return nil
 }
 
@@ -121,19 +121,34 @@ func TestAdvance(t *testing.T) {
 			return nil
 		},
 	}
-	s := &Status{end: 100, store: mdb}
-	var root [32]byte
-	copy(root[:], []byte{0x23, 0x23})
-	require.NoError(t, s.Advance(ctx, 90, root))
+	var genesisRoot [32]byte
+	copy(genesisRoot[:], []byte{0x01})
+	s := &Status{end: 100, genesisRoot: genesisRoot, store: mdb}
+	blk, err := setupTestBlock(90)
+	require.NoError(t, err)
+	root, err := blk.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, s.Advance(ctx, blk))
 	require.Equal(t, root, saveBackfillBuf[0])
-	not := s.SlotCovered(95)
-	require.Equal(t, false, not)
+	require.Equal(t, primitives.Slot(90), s.EndGap())
+	require.Equal(t, false, s.Complete())
+	require.Equal(t, false, s.SlotCovered(85))
+	require.Equal(t, true, s.SlotCovered(95))
 
 	// this should still be len 1 after failing to advance
 	require.Equal(t, 1, len(saveBackfillBuf))
-	require.ErrorIs(t, s.Advance(ctx, s.end+1, root), ErrAdvancePastOrigin)
+	require.ErrorIs(t, s.Advance(ctx, blk), ErrAdvancePastOrigin)
 	// this has an element in it from the previous test, there shouldn't be an additional one
 	require.Equal(t, 1, len(saveBackfillBuf))
+
+	// advancing to a block whose parent is the genesis block completes the backfill
+	blk, err = setupTestBlock(1)
+	require.NoError(t, err)
+	blk, err = blocktest.SetBlockParentRoot(blk, genesisRoot)
+	require.NoError(t, err)
+	require.NoError(t, s.Advance(ctx, blk))
+	require.Equal(t, true, s.Complete())
+	require.Equal(t, true, s.SlotCovered(85))
 }
 
 func goodBlockRoot(root [32]byte) func(ctx context.Context) ([32]byte, error) {
@@ -142,7 +157,7 @@ func goodBlockRoot(root [32]byte) func(ctx context.Context) ([32]byte, error) {
 	}
 }
 
-func setupTestBlock(slot primitives.Slot) (interfaces.ReadOnlySignedBeaconBlock, error) {
+func setupTestBlock(slot primitives.Slot) (interfaces.SignedBeaconBlock, error) {
 	bRaw := util.NewBeaconBlock()
 	b, err := blocks.NewSignedBeaconBlock(bRaw)
 	if err != nil {
@@ -163,7 +178,7 @@ func TestReload(t *testing.T) {
 
 	backfillSlot := primitives.Slot(50)
 	var backfillRoot [32]byte
-	copy(originRoot[:], []byte{0x02})
+	copy(backfillRoot[:], []byte{0x02})
 	backfillBlock, err := setupTestBlock(backfillSlot)
 	require.NoError(t, err)
 
@@ -334,7 +349,23 @@ func TestReload(t *testing.T) {
 				backfillBlockRoot: goodBlockRoot(backfillRoot),
 			},
 			err:      derp,
-			expected: &Status{genesisSync: false, start: backfillSlot, end: originSlot},
+			expected: &Status{genesisSync: false, start: 0, end: backfillSlot},
+		},
+		{
+			name: "backfill root is genesis root, nothing backfilled yet",
+			db: &mockBackfillDB{
+				genesisBlockRoot:          goodBlockRoot(params.BeaconConfig().ZeroHash),
+				originCheckpointBlockRoot: goodBlockRoot(originRoot),
+				block: func(ctx context.Context, root [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error) {
+					switch root {
+					case originRoot:
+						return originBlock, nil
+					}
+					return nil, errors.New("not derp")
+				},
+				backfillBlockRoot: goodBlockRoot(params.BeaconConfig().ZeroHash),
+			},
+			expected: &Status{genesisSync: false, start: 0, end: originSlot},
 		},
 	}
 
@@ -0,0 +1,112 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+)
+
+// ErrInvalidBlobSidecar is returned when a blob sidecar received over req/resp does not match
+// the block it is supposed to belong to.
+var ErrInvalidBlobSidecar = errors.New("invalid blob sidecar")
+
+// VerifyBlobSidecars checks that the given blob sidecars, fetched over req/resp, belong to the
+// given block: the sidecar header must match the block root, the commitment must be included in
+// the block body and the KZG proof must be valid. The block itself is verified when imported.
+func VerifyBlobSidecars(blk interfaces.ReadOnlySignedBeaconBlock, root [32]byte, sidecars []*ethpb.BlobSidecar) error {
+	if len(sidecars) == 0 {
+		return nil
+	}
+	if blk.Version() < version.Deneb {
+		return errors.Wrap(ErrInvalidBlobSidecar, "blob sidecars for a pre-deneb block")
+	}
+	commitments, err := blk.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return err
+	}
+	for _, sc := range sidecars {
+		if sc == nil || sc.SignedBlockHeader == nil || sc.SignedBlockHeader.Header == nil {
+			return errors.Wrap(ErrInvalidBlobSidecar, "nil blob sidecar")
+		}
+		r, err := sc.SignedBlockHeader.Header.HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		if r != root {
+			return errors.Wrapf(ErrInvalidBlobSidecar, "sidecar block root %#x does not match block root %#x", r, root)
+		}
+		if sc.Index >= uint64(len(commitments)) || !bytes.Equal(sc.KzgCommitment, commitments[sc.Index]) {
+			return errors.Wrapf(ErrInvalidBlobSidecar, "sidecar %d does not match a block kzg commitment", sc.Index)
+		}
+		if err := blocks.VerifyKZGInclusionProof(sc); err != nil {
+			return errors.Wrap(ErrInvalidBlobSidecar, err.Error())
+		}
+	}
+	if err := kzg.Verify(sidecars...); err != nil {
+		return errors.Wrap(ErrInvalidBlobSidecar, err.Error())
+	}
+	return nil
+}
+
+// requestPendingBlobs fetches from the given peer the blob sidecars of the block that are not stored
+// in the database yet, verifies them and hands them over to the chain service.
+func (s *Service) requestPendingBlobs(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock, root [32]byte, pid peer.ID) error {
+	if blk.Version() < version.Deneb {
+		return nil
+	}
+	minStart, err := BlobRPCMinValidSlot(s.cfg.clock.CurrentSlot())
+	if err != nil {
+		return err
+	}
+	if blk.Block().Slot() < minStart {
+		return nil
+	}
+	commitments, err := blk.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return err
+	}
+	if len(commitments) == 0 {
+		return nil
+	}
+	stored := make(map[uint64]bool)
+	sidecars, err := s.cfg.beaconDB.BlobSidecarsByRoot(ctx, root)
+	if err != nil && !errors.Is(err, kv.ErrNotFoundBlobSidecars) {
+		return err
+	}
+	for _, sc := range sidecars {
+		stored[sc.Index] = true
+	}
+	req := make(types.BlobSidecarsByRootReq, 0, len(commitments))
+	for i := range commitments {
+		if !stored[uint64(i)] {
+			req = append(req, &ethpb.BlobIdentifier{BlockRoot: bytesutil.SafeCopyBytes(root[:]), Index: uint64(i)})
+		}
+	}
+	if len(req) == 0 {
+		return nil
+	}
+	fetched, err := SendBlobSidecarByRoot(ctx, s.cfg.clock, s.cfg.p2p, pid, &req)
+	if err != nil {
+		return err
+	}
+	if err := VerifyBlobSidecars(blk, root, fetched); err != nil {
+		s.cfg.p2p.Peers().Scorers().BadResponsesScorer().Increment(pid)
+		return err
+	}
+	for _, sc := range fetched {
+		if err := s.cfg.chain.ReceiveBlob(ctx, sc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
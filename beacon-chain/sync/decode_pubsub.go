@@ -7,7 +7,9 @@ import (
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/pkg/errors"
 	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"google.golang.org/protobuf/proto"
 )
@@ -37,6 +39,8 @@ func (s *Service) decodePubsubMessage(msg *pubsub.Message) (ssz.Unmarshaler, err
 		// differentiate them below.
 	case strings.Contains(topic, p2p.GossipSyncCommitteeMessage) && !strings.Contains(topic, p2p.SyncContributionAndProofSubnetTopicFormat):
 		topic = p2p.GossipTypeMapping[reflect.TypeOf(&ethpb.SyncCommitteeMessage{})]
+	case strings.Contains(topic, p2p.GossipBlobSidecarMessage):
+		topic = p2p.GossipTypeMapping[reflect.TypeOf(&ethpb.BlobSidecar{})]
 	}
 
 	base := p2p.GossipTopicMappings(topic, 0)
@@ -48,11 +52,17 @@ func (s *Service) decodePubsubMessage(msg *pubsub.Message) (ssz.Unmarshaler, err
 		return nil, errors.Errorf("message of %T does not support marshaller interface", base)
 	}
 	// Handle different message types across forks.
-	if topic == p2p.BlockSubnetTopicFormat {
+	switch topic {
+	case p2p.BlockSubnetTopicFormat:
 		m, err = extractBlockDataType(fDigest[:], s.cfg.clock)
 		if err != nil {
 			return nil, err
 		}
+	case p2p.LightClientFinalityUpdateTopicFormat, p2p.LightClientOptimisticUpdateTopicFormat:
+		m, err = extractLightClientDataType(topic, fDigest, s.cfg.clock)
+		if err != nil {
+			return nil, err
+		}
 	}
 	if err := s.cfg.p2p.Encoding().DecodeGossip(msg.Data, m); err != nil {
 		return nil, err
@@ -60,6 +70,21 @@ func (s *Service) decodePubsubMessage(msg *pubsub.Message) (ssz.Unmarshaler, err
 	return m, nil
 }
 
+// extractLightClientDataType returns the light client update type of the fork of the given digest, as light
+// client updates change with the Capella and Deneb forks.
+func extractLightClientDataType(topic string, digest [4]byte, tor blockchain.TemporalOracle) (ssz.Unmarshaler, error) {
+	vRoot := tor.GenesisValidatorsRoot()
+	_, epoch, err := forks.RetrieveForkDataFromDigest(digest, vRoot[:])
+	if err != nil {
+		return nil, errors.Wrapf(ErrNoValidDigest, "could not extract light client data type, saw digest=%#x", digest)
+	}
+	m, ok := p2p.GossipTopicMappings(topic, epoch).(ssz.Unmarshaler)
+	if !ok {
+		return nil, errors.Errorf("no light client data type for topic %s", topic)
+	}
+	return m, nil
+}
+
 // Replaces our fork digest with the formatter.
 func (_ *Service) replaceForkDigest(topic string) (string, error) {
 	subStrings := strings.Split(topic, "/")
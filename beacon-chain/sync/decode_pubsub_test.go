@@ -11,15 +11,19 @@ import (
 	"github.com/d4l3k/messagediff"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/prysmaticlabs/go-bitfield"
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
 	p2ptesting "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	lightclient "github.com/prysmaticlabs/prysm/v4/consensus-types/light-client"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
 )
@@ -109,3 +113,32 @@ func TestService_decodePubsubMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestService_decodePubsubMessage_LightClientForkTypes(t *testing.T) {
+	digest, err := signing.ComputeForkDigest(params.BeaconConfig().CapellaForkVersion, make([]byte, 32))
+	require.NoError(t, err)
+	header, err := lightclient.EmptyHeader(version.Capella)
+	require.NoError(t, err)
+	update := &ethpb.LightClientOptimisticUpdateCapella{
+		AttestedHeader: header.Proto().(*ethpb.LightClientHeaderCapella),
+		SyncAggregate: &ethpb.SyncAggregate{
+			SyncCommitteeBits:      bitfield.NewBitvector512(),
+			SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
+		},
+		SignatureSlot: 1,
+	}
+	buf := new(bytes.Buffer)
+	_, err = p2ptesting.NewTestP2P(t).Encoding().EncodeGossip(buf, update)
+	require.NoError(t, err)
+	topic := fmt.Sprintf(p2p.LightClientOptimisticUpdateTopicFormat, digest) + p2ptesting.NewTestP2P(t).Encoding().ProtocolSuffix()
+
+	chain := &mock.ChainService{ValidatorsRoot: [32]byte{}, Genesis: time.Now()}
+	s := &Service{
+		cfg: &config{p2p: p2ptesting.NewTestP2P(t), chain: chain, clock: startup.NewClock(chain.Genesis, chain.ValidatorsRoot)},
+	}
+	got, err := s.decodePubsubMessage(&pubsub.Message{Message: &pb.Message{Topic: &topic, Data: buf.Bytes()}})
+	require.NoError(t, err)
+	decoded, ok := got.(*ethpb.LightClientOptimisticUpdateCapella)
+	require.Equal(t, true, ok)
+	require.DeepEqual(t, update.AttestedHeader.Execution, decoded.AttestedHeader.Execution)
+}
@@ -0,0 +1,99 @@
+package sync
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+)
+
+// gossipReplayPeerID is the peer from which replayed messages are received when the captured peer is
+// unknown or is the node itself, as validators accept the messages of the node without validation.
+const gossipReplayPeerID = peer.ID("gossip-replay")
+
+var (
+	// ErrUnknownGossipTopic is returned when no validator is registered for the topic of a replayed message.
+	ErrUnknownGossipTopic = errors.New("no validator registered for gossip topic")
+	// ErrChainNotStarted is returned when a message is replayed before chain start.
+	ErrChainNotStarted = errors.New("chain has not started")
+	// ErrGossipReplayDisabled is returned when a message is replayed by a node not running with --enable-gossip-replay.
+	ErrGossipReplayDisabled = errors.New("gossip replay is disabled")
+)
+
+// GossipReplayer runs gossip messages, such as the messages of a gossip capture, through the
+// validation pipeline of the node.
+type GossipReplayer interface {
+	ReplayGossipMessage(ctx context.Context, topic string, data []byte, pid peer.ID) (pubsub.ValidationResult, error)
+}
+
+// ReplayGossipMessage validates the snappy compressed gossip message as if it was received from the given
+// peer on the topic, and returns the validation result along with the error explaining it, if any. The
+// message is not processed further, nor forwarded to peers. Messages of any subnet of a topic can be
+// replayed, provided the node subscribed to one of its subnets.
+//
+// The validators run exactly as for gossip, with the same side effects: valid messages are marked as seen,
+// so that the same message received from peers is ignored, blocks and attestations with unknown parents
+// are queued as pending and trigger requests to peers, and invalid blocks are marked as bad. Replay is
+// therefore refused unless the service was created with WithGossipReplay, which must only be used on
+// throwaway nodes.
+func (s *Service) ReplayGossipMessage(ctx context.Context, topic string, data []byte, pid peer.ID) (pubsub.ValidationResult, error) {
+	if !s.cfg.gossipReplay {
+		return pubsub.ValidationIgnore, ErrGossipReplayDisabled
+	}
+	if s.chainStarted.IsNotSet() {
+		return pubsub.ValidationIgnore, ErrChainNotStarted
+	}
+	v, ok := s.gossipValidator(topic)
+	if !ok {
+		return pubsub.ValidationIgnore, errors.Wrapf(ErrUnknownGossipTopic, "topic %s", topic)
+	}
+	if pid == "" || pid == s.cfg.p2p.PeerID() {
+		pid = gossipReplayPeerID
+	}
+	msg := &pubsub.Message{
+		Message:      &pubsubpb.Message{Data: data, Topic: &topic},
+		ReceivedFrom: pid,
+	}
+	genRoot := s.cfg.clock.GenesisValidatorsRoot()
+	msg.ID = p2p.MsgID(genRoot[:], msg.Message)
+
+	ctx, cancel := context.WithTimeout(ctx, pubsubMessageTimeout)
+	defer cancel()
+	return v(ctx, pid, msg)
+}
+
+func (s *Service) addGossipValidator(topic string, v wrappedVal) {
+	s.gossipValidatorsLock.Lock()
+	defer s.gossipValidatorsLock.Unlock()
+	if s.gossipValidators == nil {
+		s.gossipValidators = make(map[string]wrappedVal)
+	}
+	s.gossipValidators[gossipValidatorKey(topic)] = v
+}
+
+func (s *Service) gossipValidator(topic string) (wrappedVal, bool) {
+	s.gossipValidatorsLock.RLock()
+	defer s.gossipValidatorsLock.RUnlock()
+	v, ok := s.gossipValidators[gossipValidatorKey(topic)]
+	return v, ok
+}
+
+// Returns the topic without its subnet index, as all the subnets of a topic share the same validator.
+func gossipValidatorKey(topic string) string {
+	parts := strings.Split(topic, "/")
+	for i, part := range parts {
+		idx := strings.LastIndex(part, "_")
+		if idx < 0 {
+			continue
+		}
+		if _, err := strconv.ParseUint(part[idx+1:], 10, 64); err == nil {
+			parts[i] = part[:idx]
+		}
+	}
+	return strings.Join(parts, "/")
+}
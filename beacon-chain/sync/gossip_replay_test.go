@@ -0,0 +1,69 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/async/abool"
+	p2ptest "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestGossipValidatorKey(t *testing.T) {
+	assert.Equal(t, "/eth2/01020304/beacon_attestation/ssz_snappy", gossipValidatorKey("/eth2/01020304/beacon_attestation_12/ssz_snappy"))
+	assert.Equal(t, "/eth2/01020304/sync_committee/ssz_snappy", gossipValidatorKey("/eth2/01020304/sync_committee_3/ssz_snappy"))
+	assert.Equal(t, "/eth2/01020304/beacon_block/ssz_snappy", gossipValidatorKey("/eth2/01020304/beacon_block/ssz_snappy"))
+}
+
+func TestService_ReplayGossipMessage(t *testing.T) {
+	p2pService := p2ptest.NewTestP2P(t)
+	r := &Service{
+		ctx:          context.Background(),
+		cfg:          &config{p2p: p2pService, clock: startup.NewClock(time.Now(), [32]byte{'A'}), gossipReplay: true},
+		chainStarted: abool.New(),
+	}
+	var validated *pubsub.Message
+	var validatedFrom peer.ID
+	r.addGossipValidator("/eth2/01020304/beacon_attestation_1/ssz_snappy", func(_ context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+		validated, validatedFrom = msg, pid
+		return pubsub.ValidationReject, errors.New("invalid signature")
+	})
+
+	topic := "/eth2/01020304/beacon_attestation_7/ssz_snappy"
+	_, err := r.ReplayGossipMessage(context.Background(), topic, []byte{1}, "")
+	require.ErrorIs(t, err, ErrChainNotStarted)
+	r.chainStarted.Set()
+
+	res, err := r.ReplayGossipMessage(context.Background(), topic, []byte{1}, "")
+	assert.ErrorContains(t, "invalid signature", err)
+	assert.Equal(t, pubsub.ValidationReject, res)
+	require.NotNil(t, validated)
+	assert.Equal(t, topic, *validated.Topic)
+	assert.DeepEqual(t, []byte{1}, validated.Data)
+	assert.NotEqual(t, "", validated.ID)
+	assert.Equal(t, gossipReplayPeerID, validatedFrom)
+
+	// Messages published by the node are replayed as received from another peer.
+	_, err = r.ReplayGossipMessage(context.Background(), topic, []byte{1}, p2pService.PeerID())
+	assert.ErrorContains(t, "invalid signature", err)
+	assert.Equal(t, gossipReplayPeerID, validatedFrom)
+	_, err = r.ReplayGossipMessage(context.Background(), topic, []byte{1}, "remote")
+	assert.ErrorContains(t, "invalid signature", err)
+	assert.Equal(t, peer.ID("remote"), validatedFrom)
+
+	_, err = r.ReplayGossipMessage(context.Background(), "/eth2/01020304/beacon_block/ssz_snappy", []byte{1}, "")
+	require.ErrorIs(t, err, ErrUnknownGossipTopic)
+
+	// Messages are not replayed unless gossip replay is enabled.
+	validated = nil
+	r.cfg.gossipReplay = false
+	_, err = r.ReplayGossipMessage(context.Background(), topic, []byte{1}, "")
+	require.ErrorIs(t, err, ErrGossipReplayDisabled)
+	assert.Equal(t, (*pubsub.Message)(nil), validated)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/crypto/rand"
 	"github.com/prysmaticlabs/prysm/v4/math"
 	p2ppb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
@@ -294,16 +295,96 @@ func (f *blocksFetcher) fetchBlocksFromPeer(
 	}
 	for i := 0; i < len(peers); i++ {
 		blocks, err := f.requestBlocks(ctx, req, peers[i])
-		if err == nil {
-			f.p2p.Peers().Scorers().BlockProviderScorer().Touch(peers[i])
-			return blocks, peers[i], nil
-		} else {
+		if err != nil {
 			log.WithError(err).Debug("Could not request blocks by range")
+			continue
 		}
+		if err := f.fetchBlobsFromPeer(ctx, blocks, peers[i]); err != nil {
+			log.WithError(err).Debug("Could not request blob sidecars by range")
+			continue
+		}
+		f.p2p.Peers().Scorers().BlockProviderScorer().Touch(peers[i])
+		return blocks, peers[i], nil
 	}
 	return nil, "", errNoPeersAvailable
 }
 
+// fetchBlobsFromPeer fetches the blob sidecars of the given blocks which are within the blob
+// retention window from the same peer, and hands them over to the chain service once verified.
+func (f *blocksFetcher) fetchBlobsFromPeer(ctx context.Context, blks []interfaces.ReadOnlySignedBeaconBlock, pid peer.ID) error {
+	ctx, span := trace.StartSpan(ctx, "initialsync.fetchBlobsFromPeer")
+	defer span.End()
+
+	minSlot, err := prysmsync.BlobRPCMinValidSlot(f.chain.CurrentSlot())
+	if err != nil {
+		return err
+	}
+	byRoot := make(map[[32]byte]interfaces.ReadOnlySignedBeaconBlock)
+	var start, end primitives.Slot
+	for _, b := range blks {
+		if b.Version() < version.Deneb || b.Block().Slot() < minSlot {
+			continue
+		}
+		commitments, err := b.Block().Body().BlobKzgCommitments()
+		if err != nil {
+			return err
+		}
+		if len(commitments) == 0 {
+			continue
+		}
+		root, err := b.Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		if len(byRoot) == 0 {
+			start = b.Block().Slot()
+		}
+		byRoot[root] = b
+		end = b.Block().Slot()
+	}
+	if len(byRoot) == 0 {
+		return nil
+	}
+	req := &p2ppb.BlobSidecarsByRangeRequest{
+		StartSlot: start,
+		Count:     uint64(end-start) + 1,
+	}
+	sidecars, err := prysmsync.SendBlobsByRangeRequest(ctx, f.chain, f.p2p, pid, req)
+	if err != nil {
+		return err
+	}
+	grouped := make(map[[32]byte][]*p2ppb.BlobSidecar)
+	for _, sc := range sidecars {
+		root, err := sc.SignedBlockHeader.Header.HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		if _, ok := byRoot[root]; !ok {
+			return errors.Wrapf(prysmsync.ErrInvalidBlobSidecar, "unexpected blob sidecar for block %#x", root)
+		}
+		grouped[root] = append(grouped[root], sc)
+	}
+	for root, b := range byRoot {
+		commitments, err := b.Block().Body().BlobKzgCommitments()
+		if err != nil {
+			return err
+		}
+		if len(grouped[root]) != len(commitments) {
+			return errors.Wrapf(prysmsync.ErrInvalidBlobSidecar, "received %d blob sidecars for block %#x with %d commitments", len(grouped[root]), root, len(commitments))
+		}
+		if err := prysmsync.VerifyBlobSidecars(b, root, grouped[root]); err != nil {
+			f.p2p.Peers().Scorers().BadResponsesScorer().Increment(pid)
+			return err
+		}
+	}
+	for _, sc := range sidecars {
+		if err := f.chain.ReceiveBlob(ctx, sc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // requestBlocks is a wrapper for handling BeaconBlocksByRangeRequest requests/streams.
 func (f *blocksFetcher) requestBlocks(
 	ctx context.Context,
@@ -56,6 +56,13 @@ var (
 		},
 		[]string{"topic"},
 	)
+	rpcRateLimitedRequestsCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rpc_rate_limited_requests_total",
+			Help: "Count of req/resp requests rejected by the rate limiter.",
+		},
+		[]string{"topic"},
+	)
 	numberOfTimesResyncedCounter = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Name: "number_of_times_resynced",
@@ -1,6 +1,7 @@
 package sync
 
 import (
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/prysmaticlabs/prysm/v4/async/event"
 	blockfeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/block"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
@@ -143,3 +144,20 @@ func WithInitialSyncComplete(c chan struct{}) Option {
 		return nil
 	}
 }
+
+// WithGossipReplay allows gossip messages to be replayed through the validation pipeline of the node.
+// As replayed messages have the side effects of gossip, it must only be used on throwaway nodes.
+func WithGossipReplay() Option {
+	return func(s *Service) error {
+		s.cfg.gossipReplay = true
+		return nil
+	}
+}
+
+// WithTrustedPeers grants the req/resp rate limiting quotas of trusted peers to the provided peers.
+func WithTrustedPeers(pids []peer.ID) Option {
+	return func(s *Service) error {
+		s.cfg.trustedPeers = pids
+		return nil
+	}
+}
@@ -0,0 +1,209 @@
+package sync
+
+import (
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	leakybucket "github.com/prysmaticlabs/prysm/v4/container/leaky-bucket"
+	"github.com/sirupsen/logrus"
+)
+
+// RateLimitTier is a tier of peers sharing the same req/resp rate limiting quotas.
+type RateLimitTier string
+
+const (
+	// RateLimitTierDefault is the tier of all the peers which are not trusted.
+	RateLimitTierDefault RateLimitTier = "default"
+	// RateLimitTierTrusted is the tier of the members of peer groups, such as the static peers, and of the allow-listed peers.
+	RateLimitTierTrusted RateLimitTier = "trusted"
+)
+
+var (
+	// ErrUnknownRateLimitTier is returned when the rate limit tier is not known.
+	ErrUnknownRateLimitTier = errors.New("unknown rate limit tier")
+	// ErrUnknownRateLimitProtocol is returned when the protocol is not rate limited.
+	ErrUnknownRateLimitProtocol = errors.New("unknown rate limit protocol")
+	// ErrInvalidRateLimitQuota is returned when the quota would not allow any request.
+	ErrInvalidRateLimitQuota = errors.New("rate and burst of a rate limit quota must be positive")
+)
+
+// RateLimitManager allows to inspect and change the rate limits applied to the req/resp requests of peers.
+type RateLimitManager interface {
+	RateLimitPolicies() []*RateLimitPolicy
+	SetRateLimitQuota(protocol string, tier RateLimitTier, rate float64, burst int64) error
+	PeerRateLimits() []*PeerRateLimits
+}
+
+// RateLimitQuota is the quota of requests of a peer for a protocol. The bucket of each peer holds up to
+// Burst requests, and leaks Rate requests every Period.
+type RateLimitQuota struct {
+	Rate   float64
+	Burst  int64
+	Period time.Duration
+}
+
+// RateLimitPolicy lists the quotas of a protocol for each tier. Protocols sharing a bucket, such as
+// the protocols of blocks requests of the same version, are listed in SharedWith.
+type RateLimitPolicy struct {
+	Protocol   string
+	SharedWith []string
+	Quotas     map[RateLimitTier]RateLimitQuota
+}
+
+// PeerRateLimits is the state of the rate limits of a peer.
+type PeerRateLimits struct {
+	PeerID    peer.ID
+	Tier      RateLimitTier
+	Protocols []*ProtocolRateLimit
+}
+
+// ProtocolRateLimit is the fill level of the bucket of a peer for a protocol, and the number of its
+// requests which were rejected.
+type ProtocolRateLimit struct {
+	Protocol   string
+	Count      int64
+	Capacity   int64
+	Rejections uint64
+}
+
+// RateLimitPolicies returns the quotas of all the rate limited req/resp protocols.
+func (s *Service) RateLimitPolicies() []*RateLimitPolicy {
+	return s.rateLimiter.policies()
+}
+
+// SetRateLimitQuota changes the quota of a protocol for a tier of peers. The change also applies to the
+// protocols sharing a bucket with it, and keeps the current fill levels of the buckets.
+func (s *Service) SetRateLimitQuota(protocol string, tier RateLimitTier, rate float64, burst int64) error {
+	return s.rateLimiter.setQuota(protocol, tier, rate, burst)
+}
+
+// PeerRateLimits returns the fill levels of the buckets of the peers, and their rejected requests.
+func (s *Service) PeerRateLimits() []*PeerRateLimits {
+	return s.rateLimiter.peerLimits()
+}
+
+func (l *limiter) policies() []*RateLimitPolicy {
+	l.RLock()
+	defer l.RUnlock()
+
+	policies := make([]*RateLimitPolicy, 0, len(l.limiterMap))
+	for topic, collector := range l.limiterMap {
+		p := &RateLimitPolicy{
+			Protocol:   topic,
+			SharedWith: sharedTopics(l.limiterMap, topic, collector),
+			Quotas:     map[RateLimitTier]RateLimitQuota{RateLimitTierDefault: collectorQuota(collector)},
+		}
+		if trusted, ok := l.trustedLimiterMap[topic]; ok {
+			p.Quotas[RateLimitTierTrusted] = collectorQuota(trusted)
+		}
+		policies = append(policies, p)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Protocol < policies[j].Protocol
+	})
+	return policies
+}
+
+func (l *limiter) setQuota(protocol string, tier RateLimitTier, rate float64, burst int64) error {
+	if tier != RateLimitTierDefault && tier != RateLimitTierTrusted {
+		return errors.Wrapf(ErrUnknownRateLimitTier, "tier %s", tier)
+	}
+	if rate <= 0 || burst <= 0 {
+		return ErrInvalidRateLimitQuota
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	collector, err := l.retrieveTierCollector(protocol, tier)
+	if err != nil {
+		// Allow the protocol to be provided without its encoding suffix.
+		collector, err = l.retrieveTierCollector(protocol+l.p2p.Encoding().ProtocolSuffix(), tier)
+		if err != nil {
+			return errors.Wrapf(ErrUnknownRateLimitProtocol, "protocol %s", protocol)
+		}
+	}
+	collector.SetLimits(rate, burst)
+	log.WithFields(logrus.Fields{
+		"protocol": protocol,
+		"tier":     tier,
+		"rate":     rate,
+		"burst":    burst,
+	}).Info("Changed rate limit quota")
+	return nil
+}
+
+func (l *limiter) peerLimits() []*PeerRateLimits {
+	l.RLock()
+	defer l.RUnlock()
+
+	limits := make(map[peer.ID]*PeerRateLimits)
+	protocolLimit := func(pid peer.ID, topic string) *ProtocolRateLimit {
+		pl, ok := limits[pid]
+		if !ok {
+			pl = &PeerRateLimits{PeerID: pid, Tier: l.peerTier(pid)}
+			limits[pid] = pl
+		}
+		for _, p := range pl.Protocols {
+			if p.Protocol == topic {
+				return p
+			}
+		}
+		p := &ProtocolRateLimit{Protocol: topic}
+		if collector, err := l.retrievePeerCollector(topic, pid); err == nil {
+			p.Capacity = collector.Capacity()
+		}
+		pl.Protocols = append(pl.Protocols, p)
+		return p
+	}
+
+	for _, limiterMap := range []map[string]*leakybucket.Collector{l.limiterMap, l.trustedLimiterMap} {
+		for topic, collector := range limiterMap {
+			for key, count := range collector.Counts() {
+				pid, err := peer.Decode(key)
+				if err != nil {
+					continue
+				}
+				protocolLimit(pid, topic).Count = count
+			}
+		}
+	}
+
+	l.rejectionsLock.Lock()
+	for pid, topics := range l.rejections {
+		for topic, rejections := range topics {
+			protocolLimit(pid, topic).Rejections = rejections
+		}
+	}
+	l.rejectionsLock.Unlock()
+
+	result := make([]*PeerRateLimits, 0, len(limits))
+	for _, pl := range limits {
+		sort.Slice(pl.Protocols, func(i, j int) bool {
+			return pl.Protocols[i].Protocol < pl.Protocols[j].Protocol
+		})
+		result = append(result, pl)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PeerID < result[j].PeerID
+	})
+	return result
+}
+
+func collectorQuota(c *leakybucket.Collector) RateLimitQuota {
+	return RateLimitQuota{Rate: c.Rate(), Burst: c.Capacity(), Period: c.Period()}
+}
+
+// Returns the other topics using the same collector as the provided topic.
+func sharedTopics(limiterMap map[string]*leakybucket.Collector, topic string, collector *leakybucket.Collector) []string {
+	var shared []string
+	for t, c := range limiterMap {
+		if t != topic && c == collector {
+			shared = append(shared, t)
+		}
+	}
+	sort.Strings(shared)
+	return shared
+}
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
 	p2ptypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
@@ -26,38 +28,63 @@ const blockBucketPeriod = 30 * time.Second
 const rpcLimiterTopic = "rpc-limiter-topic"
 
 type limiter struct {
-	limiterMap map[string]*leakybucket.Collector
-	p2p        p2p.P2P
+	limiterMap        map[string]*leakybucket.Collector
+	trustedLimiterMap map[string]*leakybucket.Collector
+	trustedPeers      map[peer.ID]bool
+	p2p               p2p.P2P
 	sync.RWMutex
+	rejections     map[peer.ID]map[string]uint64
+	rejectionsLock sync.Mutex
 }
 
 // Instantiates a multi-rpc protocol rate limiter, providing
-// separate collectors for each topic.
+// separate collectors for each topic, and for each tier of peers.
 func newRateLimiter(p2pProvider p2p.P2P) *limiter {
+	trustedFactor := int64(flags.Get().RateLimitTrustedFactor)
+	if trustedFactor < 1 {
+		trustedFactor = 1
+	}
+	return &limiter{
+		limiterMap:        newTopicCollectors(p2pProvider, 1),
+		trustedLimiterMap: newTopicCollectors(p2pProvider, trustedFactor),
+		trustedPeers:      make(map[peer.ID]bool),
+		p2p:               p2pProvider,
+		rejections:        make(map[peer.ID]map[string]uint64),
+	}
+}
+
+// Builds the collectors of all rpc topics, with their default quotas multiplied by the provided factor.
+func newTopicCollectors(p2pProvider p2p.P2P, factor int64) map[string]*leakybucket.Collector {
 	// add encoding suffix
 	addEncoding := func(topic string) string {
 		return topic + p2pProvider.Encoding().ProtocolSuffix()
 	}
+	newCollector := func(rate float64, capacity int64, period time.Duration) *leakybucket.Collector {
+		return leakybucket.NewCollector(rate*float64(factor), capacity*factor, period, false /* deleteEmptyBuckets */)
+	}
 	// Initialize block limits.
 	allowedBlocksPerSecond := float64(flags.Get().BlockBatchLimit)
 	allowedBlocksBurst := int64(flags.Get().BlockBatchLimitBurstFactor * flags.Get().BlockBatchLimit)
+	// Initialize blob limits.
+	allowedBlobsPerSecond := float64(flags.Get().BlobBatchLimit)
+	allowedBlobsBurst := int64(flags.Get().BlobBatchLimitBurstFactor * flags.Get().BlobBatchLimit)
 
 	// Set topic map for all rpc topics.
 	topicMap := make(map[string]*leakybucket.Collector, len(p2p.RPCTopicMappings))
 	// Goodbye Message
-	topicMap[addEncoding(p2p.RPCGoodByeTopicV1)] = leakybucket.NewCollector(1, 1, leakyBucketPeriod, false /* deleteEmptyBuckets */)
+	topicMap[addEncoding(p2p.RPCGoodByeTopicV1)] = newCollector(1, 1, leakyBucketPeriod)
 	// MetadataV0 Message
-	topicMap[addEncoding(p2p.RPCMetaDataTopicV1)] = leakybucket.NewCollector(1, defaultBurstLimit, leakyBucketPeriod, false /* deleteEmptyBuckets */)
-	topicMap[addEncoding(p2p.RPCMetaDataTopicV2)] = leakybucket.NewCollector(1, defaultBurstLimit, leakyBucketPeriod, false /* deleteEmptyBuckets */)
+	topicMap[addEncoding(p2p.RPCMetaDataTopicV1)] = newCollector(1, defaultBurstLimit, leakyBucketPeriod)
+	topicMap[addEncoding(p2p.RPCMetaDataTopicV2)] = newCollector(1, defaultBurstLimit, leakyBucketPeriod)
 	// Ping Message
-	topicMap[addEncoding(p2p.RPCPingTopicV1)] = leakybucket.NewCollector(1, defaultBurstLimit, leakyBucketPeriod, false /* deleteEmptyBuckets */)
+	topicMap[addEncoding(p2p.RPCPingTopicV1)] = newCollector(1, defaultBurstLimit, leakyBucketPeriod)
 	// Status Message
-	topicMap[addEncoding(p2p.RPCStatusTopicV1)] = leakybucket.NewCollector(1, defaultBurstLimit, leakyBucketPeriod, false /* deleteEmptyBuckets */)
+	topicMap[addEncoding(p2p.RPCStatusTopicV1)] = newCollector(1, defaultBurstLimit, leakyBucketPeriod)
 
 	// Use a single collector for block requests
-	blockCollector := leakybucket.NewCollector(allowedBlocksPerSecond, allowedBlocksBurst, blockBucketPeriod, false /* deleteEmptyBuckets */)
+	blockCollector := newCollector(allowedBlocksPerSecond, allowedBlocksBurst, blockBucketPeriod)
 	// Collector for V2
-	blockCollectorV2 := leakybucket.NewCollector(allowedBlocksPerSecond, allowedBlocksBurst, blockBucketPeriod, false /* deleteEmptyBuckets */)
+	blockCollectorV2 := newCollector(allowedBlocksPerSecond, allowedBlocksBurst, blockBucketPeriod)
 
 	// BlocksByRoots requests
 	topicMap[addEncoding(p2p.RPCBlocksByRootTopicV1)] = blockCollector
@@ -67,17 +94,42 @@ func newRateLimiter(p2pProvider p2p.P2P) *limiter {
 	topicMap[addEncoding(p2p.RPCBlocksByRangeTopicV1)] = blockCollector
 	topicMap[addEncoding(p2p.RPCBlocksByRangeTopicV2)] = blockCollectorV2
 
+	// Use a single collector for blob sidecar requests, BlobSidecarsByRange requests are
+	// charged one unit per returned sidecar.
+	blobCollector := newCollector(allowedBlobsPerSecond, allowedBlobsBurst, blockBucketPeriod)
+
+	// BlobSidecarsByRoot requests
+	topicMap[addEncoding(p2p.RPCBlobSidecarsByRootTopicV1)] = blobCollector
+	// BlobSidecarsByRange requests
+	topicMap[addEncoding(p2p.RPCBlobSidecarsByRangeTopicV1)] = blobCollector
+
+	// Light client requests, LightClientUpdatesByRange requests are charged one unit per returned update.
+	topicMap[addEncoding(p2p.RPCLightClientBootstrapTopicV1)] = newCollector(1, defaultBurstLimit, leakyBucketPeriod)
+	topicMap[addEncoding(p2p.RPCLightClientUpdatesByRangeTopicV1)] = newCollector(1, blockchain.MaxRequestLightClientUpdates, blockBucketPeriod)
+	topicMap[addEncoding(p2p.RPCLightClientFinalityUpdateTopicV1)] = newCollector(1, defaultBurstLimit, leakyBucketPeriod)
+	topicMap[addEncoding(p2p.RPCLightClientOptimisticUpdateTopicV1)] = newCollector(1, defaultBurstLimit, leakyBucketPeriod)
+
 	// General topic for all rpc requests.
-	topicMap[rpcLimiterTopic] = leakybucket.NewCollector(5, defaultBurstLimit*2, leakyBucketPeriod, false /* deleteEmptyBuckets */)
+	topicMap[rpcLimiterTopic] = newCollector(5, defaultBurstLimit*2, leakyBucketPeriod)
 
-	return &limiter{limiterMap: topicMap, p2p: p2pProvider}
+	return topicMap
 }
 
-// Returns the current topic collector for the provided topic.
-func (l *limiter) topicCollector(topic string) (*leakybucket.Collector, error) {
+// trustPeers grants the quotas of trusted peers to the provided peers.
+func (l *limiter) trustPeers(pids ...peer.ID) {
+	l.Lock()
+	defer l.Unlock()
+
+	for _, pid := range pids {
+		l.trustedPeers[pid] = true
+	}
+}
+
+// Returns the current topic collector for the provided topic and peer.
+func (l *limiter) topicCollector(topic string, pid peer.ID) (*leakybucket.Collector, error) {
 	l.RLock()
 	defer l.RUnlock()
-	return l.retrieveCollector(topic)
+	return l.retrievePeerCollector(topic, pid)
 }
 
 // validates a request with the accompanying cost.
@@ -86,19 +138,19 @@ func (l *limiter) validateRequest(stream network.Stream, amt uint64) error {
 	defer l.RUnlock()
 
 	topic := string(stream.Protocol())
+	pid := stream.Conn().RemotePeer()
 
-	collector, err := l.retrieveCollector(topic)
+	collector, err := l.retrievePeerCollector(topic, pid)
 	if err != nil {
 		return err
 	}
-	key := stream.Conn().RemotePeer().String()
-	remaining := collector.Remaining(key)
+	remaining := collector.Remaining(pid.String())
 	// Treat each request as a minimum of 1.
 	if amt == 0 {
 		amt = 1
 	}
 	if amt > uint64(remaining) {
-		l.p2p.Peers().Scorers().BadResponsesScorer().Increment(stream.Conn().RemotePeer())
+		l.recordRejection(pid, topic)
 		writeErrorResponseToStream(responseCodeInvalidRequest, p2ptypes.ErrRateLimited.Error(), stream, l.p2p)
 		return p2ptypes.ErrRateLimited
 	}
@@ -111,17 +163,17 @@ func (l *limiter) validateRawRpcRequest(stream network.Stream) error {
 	defer l.RUnlock()
 
 	topic := rpcLimiterTopic
+	pid := stream.Conn().RemotePeer()
 
-	collector, err := l.retrieveCollector(topic)
+	collector, err := l.retrievePeerCollector(topic, pid)
 	if err != nil {
 		return err
 	}
-	key := stream.Conn().RemotePeer().String()
-	remaining := collector.Remaining(key)
+	remaining := collector.Remaining(pid.String())
 	// Treat each request as a minimum of 1.
 	amt := int64(1)
 	if amt > remaining {
-		l.p2p.Peers().Scorers().BadResponsesScorer().Increment(stream.Conn().RemotePeer())
+		l.recordRejection(pid, topic)
 		writeErrorResponseToStream(responseCodeInvalidRequest, p2ptypes.ErrRateLimited.Error(), stream, l.p2p)
 		return p2ptypes.ErrRateLimited
 	}
@@ -136,13 +188,13 @@ func (l *limiter) add(stream network.Stream, amt int64) {
 	topic := string(stream.Protocol())
 	log := l.topicLogger(topic)
 
-	collector, err := l.retrieveCollector(topic)
+	pid := stream.Conn().RemotePeer()
+	collector, err := l.retrievePeerCollector(topic, pid)
 	if err != nil {
 		log.Errorf("collector with topic '%s' does not exist", topic)
 		return
 	}
-	key := stream.Conn().RemotePeer().String()
-	collector.Add(key, amt)
+	collector.Add(pid.String(), amt)
 }
 
 // adds the cost to our leaky bucket for the peer.
@@ -153,13 +205,13 @@ func (l *limiter) addRawStream(stream network.Stream) {
 	topic := rpcLimiterTopic
 	log := l.topicLogger(topic)
 
-	collector, err := l.retrieveCollector(topic)
+	pid := stream.Conn().RemotePeer()
+	collector, err := l.retrievePeerCollector(topic, pid)
 	if err != nil {
 		log.Errorf("collector with topic '%s' does not exist", topic)
 		return
 	}
-	key := stream.Conn().RemotePeer().String()
-	collector.Add(key, 1)
+	collector.Add(pid.String(), 1)
 }
 
 // frees all the collectors and removes them.
@@ -167,19 +219,24 @@ func (l *limiter) free() {
 	l.Lock()
 	defer l.Unlock()
 
+	freeCollectors(l.limiterMap)
+	freeCollectors(l.trustedLimiterMap)
+}
+
+func freeCollectors(limiterMap map[string]*leakybucket.Collector) {
 	tempMap := map[uintptr]bool{}
-	for t, collector := range l.limiterMap {
+	for t, collector := range limiterMap {
 		// Check if collector has already been cleared off
 		// as all collectors are not distinct from each other.
 		ptr := reflect.ValueOf(collector).Pointer()
 		if tempMap[ptr] {
 			// Remove from map
-			delete(l.limiterMap, t)
+			delete(limiterMap, t)
 			continue
 		}
 		collector.Free()
 		// Remove from map
-		delete(l.limiterMap, t)
+		delete(limiterMap, t)
 		tempMap[ptr] = true
 	}
 }
@@ -187,16 +244,62 @@ func (l *limiter) free() {
 // not to be used outside the rate limiter file as it is unsafe for concurrent usage
 // and is protected by a lock on all of its usages here.
 func (l *limiter) retrieveCollector(topic string) (*leakybucket.Collector, error) {
+	return l.retrieveTierCollector(topic, RateLimitTierDefault)
+}
+
+// retrieves the collector of the topic for the tier of the peer, with the same
+// locking requirements as retrieveCollector.
+func (l *limiter) retrievePeerCollector(topic string, pid peer.ID) (*leakybucket.Collector, error) {
+	return l.retrieveTierCollector(topic, l.peerTier(pid))
+}
+
+func (l *limiter) retrieveTierCollector(topic string, tier RateLimitTier) (*leakybucket.Collector, error) {
 	if !mutexasserts.RWMutexLocked(&l.RWMutex) && !mutexasserts.RWMutexRLocked(&l.RWMutex) {
 		return nil, errors.New("limiter.retrieveCollector: caller must hold read/write lock")
 	}
-	collector, ok := l.limiterMap[topic]
+	limiterMap := l.limiterMap
+	if tier == RateLimitTierTrusted {
+		limiterMap = l.trustedLimiterMap
+	}
+	collector, ok := limiterMap[topic]
 	if !ok {
 		return nil, errors.Errorf("collector does not exist for topic %s", topic)
 	}
 	return collector, nil
 }
 
+func (l *limiter) peerTier(pid peer.ID) RateLimitTier {
+	if l.trustedPeers[pid] || l.p2p.Peers().IsProtected(pid) {
+		return RateLimitTierTrusted
+	}
+	return RateLimitTierDefault
+}
+
+// counts a request of the peer which was rejected by the rate limiter, and penalizes the peer.
+// Peers of the trusted tier are expected to send heavy requests, so they are not penalized.
+func (l *limiter) recordRejection(pid peer.ID, topic string) {
+	if l.peerTier(pid) != RateLimitTierTrusted {
+		l.p2p.Peers().Scorers().BadResponsesScorer().Increment(pid)
+	}
+
+	l.rejectionsLock.Lock()
+	defer l.rejectionsLock.Unlock()
+
+	if l.rejections[pid] == nil {
+		l.rejections[pid] = make(map[string]uint64)
+	}
+	l.rejections[pid][topic]++
+	rpcRateLimitedRequestsCounter.WithLabelValues(topic).Inc()
+}
+
+// forgets the rejected requests of a peer.
+func (l *limiter) removeRejections(pid peer.ID) {
+	l.rejectionsLock.Lock()
+	defer l.rejectionsLock.Unlock()
+
+	delete(l.rejections, pid)
+}
+
 func (_ *limiter) topicLogger(topic string) *logrus.Entry {
 	return log.WithField("rate limiter", topic)
 }
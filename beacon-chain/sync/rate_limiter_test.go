@@ -6,11 +6,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
 	mockp2p "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
 	p2ptypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
@@ -18,7 +20,7 @@ import (
 
 func TestNewRateLimiter(t *testing.T) {
 	rlimiter := newRateLimiter(mockp2p.NewTestP2P(t))
-	assert.Equal(t, len(rlimiter.limiterMap), 10, "correct number of topics not registered")
+	assert.Equal(t, len(rlimiter.limiterMap), 16, "correct number of topics not registered")
 }
 
 func TestNewRateLimiter_FreeCorrectly(t *testing.T) {
@@ -55,6 +57,9 @@ func TestRateLimiter_ExceedCapacity(t *testing.T) {
 	// Attempt to create an error, rate limit and lead to disconnect
 	err = rlimiter.validateRequest(stream, 1000)
 	require.NotNil(t, err, "could not get error from leaky bucket")
+	badResponses, err := p1.Peers().Scorers().BadResponsesScorer().Count(p2.PeerID())
+	require.NoError(t, err)
+	assert.Equal(t, 1, badResponses)
 
 	require.NoError(t, stream.Close(), "could not close stream")
 
@@ -111,3 +116,118 @@ func Test_limiter_retrieveCollector_requiresLock(t *testing.T) {
 	_, err := l.retrieveCollector("")
 	require.ErrorContains(t, "caller must hold read/write lock", err)
 }
+
+func TestRateLimiter_TrustedPeers(t *testing.T) {
+	resetCfg := flags.Get()
+	flags.Init(&flags.GlobalFlags{
+		BlockBatchLimit:            64,
+		BlockBatchLimitBurstFactor: 2,
+		RateLimitTrustedFactor:     4,
+	})
+	defer flags.Init(resetCfg)
+
+	p1 := mockp2p.NewTestP2P(t)
+	p2 := mockp2p.NewTestP2P(t)
+	p1.Connect(p2)
+	p1.Peers().Add(new(enr.Record), p2.PeerID(), nil, network.DirOutbound)
+	rlimiter := newRateLimiter(p1)
+	rlimiter.trustPeers(p2.PeerID())
+
+	topic := p2p.RPCBlocksByRangeTopicV1 + p1.Encoding().ProtocolSuffix()
+	p2.BHost.SetStreamHandler(protocol.ID(topic), func(stream network.Stream) {})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.PeerID(), protocol.ID(topic))
+	require.NoError(t, err, "could not create stream")
+
+	// Requests above the default burst are allowed for trusted peers.
+	require.NoError(t, rlimiter.validateRequest(stream, 500))
+	rlimiter.add(stream, 500)
+
+	collector, err := rlimiter.topicCollector(topic, p2.PeerID())
+	require.NoError(t, err)
+	assert.Equal(t, int64(512), collector.Capacity())
+	assert.Equal(t, int64(12), collector.Remaining(p2.PeerID().String()))
+	defaultCollector, err := rlimiter.topicCollector(topic, p1.PeerID())
+	require.NoError(t, err)
+	assert.Equal(t, int64(128), defaultCollector.Remaining(p2.PeerID().String()))
+
+	// Trusted peers exceeding their quota are rate limited, but not penalized.
+	require.ErrorIs(t, rlimiter.validateRequest(stream, 500), p2ptypes.ErrRateLimited)
+	badResponses, err := p1.Peers().Scorers().BadResponsesScorer().Count(p2.PeerID())
+	require.NoError(t, err)
+	assert.Equal(t, 0, badResponses)
+	require.NoError(t, stream.Close())
+}
+
+func TestRateLimiter_SetQuota(t *testing.T) {
+	p1 := mockp2p.NewTestP2P(t)
+	rlimiter := newRateLimiter(p1)
+
+	suffix := p1.Encoding().ProtocolSuffix()
+	require.NoError(t, rlimiter.setQuota(p2p.RPCBlocksByRootTopicV1, RateLimitTierTrusted, 100, 300))
+
+	policies := rlimiter.policies()
+	require.Equal(t, len(rlimiter.limiterMap), len(policies))
+	for _, p := range policies {
+		switch p.Protocol {
+		case p2p.RPCBlocksByRootTopicV1 + suffix, p2p.RPCBlocksByRangeTopicV1 + suffix:
+			// Both topics share the same collector.
+			assert.Equal(t, 1, len(p.SharedWith))
+			assert.Equal(t, float64(100), p.Quotas[RateLimitTierTrusted].Rate)
+			assert.Equal(t, int64(300), p.Quotas[RateLimitTierTrusted].Burst)
+			assert.Equal(t, blockBucketPeriod, p.Quotas[RateLimitTierTrusted].Period)
+		case p2p.RPCPingTopicV1 + suffix:
+			assert.Equal(t, 0, len(p.SharedWith))
+			assert.Equal(t, int64(defaultBurstLimit), p.Quotas[RateLimitTierDefault].Burst)
+		}
+	}
+
+	require.ErrorIs(t, rlimiter.setQuota("/unknown", RateLimitTierDefault, 1, 1), ErrUnknownRateLimitProtocol)
+	require.ErrorIs(t, rlimiter.setQuota(p2p.RPCPingTopicV1, "unknown", 1, 1), ErrUnknownRateLimitTier)
+	require.ErrorIs(t, rlimiter.setQuota(p2p.RPCPingTopicV1, RateLimitTierDefault, 0, 1), ErrInvalidRateLimitQuota)
+}
+
+func TestRateLimiter_PeerLimits(t *testing.T) {
+	p1 := mockp2p.NewTestP2P(t)
+	p2 := mockp2p.NewTestP2P(t)
+	p1.Connect(p2)
+	p1.Peers().Add(nil, p2.PeerID(), p2.BHost.Addrs()[0], network.DirOutbound)
+	rlimiter := newRateLimiter(p1)
+
+	topic := p2p.RPCPingTopicV1 + p1.Encoding().ProtocolSuffix()
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(protocol.ID(topic), func(stream network.Stream) {
+		defer wg.Done()
+		_, _, err := readStatusCodeNoDeadline(stream, p2.Encoding())
+		require.NoError(t, err, "could not read incoming stream")
+	})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.PeerID(), protocol.ID(topic))
+	require.NoError(t, err, "could not create stream")
+
+	for i := 0; i < defaultBurstLimit; i++ {
+		require.NoError(t, rlimiter.validateRequest(stream, 1))
+		rlimiter.add(stream, 1)
+	}
+	assert.ErrorContains(t, p2ptypes.ErrRateLimited.Error(), rlimiter.validateRequest(stream, 1))
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+
+	limits := rlimiter.peerLimits()
+	require.Equal(t, 1, len(limits))
+	assert.Equal(t, p2.PeerID(), limits[0].PeerID)
+	assert.Equal(t, RateLimitTierDefault, limits[0].Tier)
+	require.Equal(t, 1, len(limits[0].Protocols))
+	assert.DeepEqual(t, &ProtocolRateLimit{
+		Protocol:   topic,
+		Count:      defaultBurstLimit,
+		Capacity:   defaultBurstLimit,
+		Rejections: 1,
+	}, limits[0].Protocols[0])
+
+	rlimiter.removeRejections(p2.PeerID())
+	limits = rlimiter.peerLimits()
+	require.Equal(t, 1, len(limits))
+	assert.Equal(t, uint64(0), limits[0].Protocols[0].Rejections)
+	require.NoError(t, stream.Close())
+}
@@ -11,6 +11,7 @@ import (
 	ssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
 	p2ptypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
 	"github.com/prysmaticlabs/prysm/v4/time"
@@ -49,6 +50,9 @@ func (s *Service) registerRPCHandlers() {
 			s.pingHandler,
 		)
 		s.registerRPCHandlersAltair()
+		if currEpoch >= params.BeaconConfig().DenebForkEpoch {
+			s.registerRPCHandlersDeneb()
+		}
 		return
 	}
 	s.registerRPC(
@@ -91,6 +95,41 @@ func (s *Service) registerRPCHandlersAltair() {
 		p2p.RPCMetaDataTopicV2,
 		s.metaDataHandler,
 	)
+	if features.Get().EnableLightClient {
+		s.registerRPCHandlersLightClient()
+	}
+}
+
+// registerRPCHandlersDeneb registers the blob sidecar req/resp handlers.
+func (s *Service) registerRPCHandlersDeneb() {
+	s.registerRPC(
+		p2p.RPCBlobSidecarsByRangeTopicV1,
+		s.blobSidecarsByRangeRPCHandler,
+	)
+	s.registerRPC(
+		p2p.RPCBlobSidecarsByRootTopicV1,
+		s.blobSidecarByRootRPCHandler,
+	)
+}
+
+// registerRPCHandlersLightClient registers the light client req/resp handlers.
+func (s *Service) registerRPCHandlersLightClient() {
+	s.registerRPC(
+		p2p.RPCLightClientBootstrapTopicV1,
+		s.lightClientBootstrapRPCHandler,
+	)
+	s.registerRPC(
+		p2p.RPCLightClientUpdatesByRangeTopicV1,
+		s.lightClientUpdatesByRangeRPCHandler,
+	)
+	s.registerRPC(
+		p2p.RPCLightClientFinalityUpdateTopicV1,
+		s.lightClientFinalityUpdateRPCHandler,
+	)
+	s.registerRPC(
+		p2p.RPCLightClientOptimisticUpdateTopicV1,
+		s.lightClientOptimisticUpdateRPCHandler,
+	)
 }
 
 // Remove all v1 Stream handlers that are no longer supported
@@ -166,9 +205,10 @@ func (s *Service) registerRPC(baseTopic string, handle rpcHandler) {
 		// Increment message received counter.
 		messageReceivedCounter.WithLabelValues(topic).Inc()
 
-		// since metadata requests do not have any data in the payload, we
+		// since metadata and light client update requests do not have any data in the payload, we
 		// do not decode anything.
-		if baseTopic == p2p.RPCMetaDataTopicV1 || baseTopic == p2p.RPCMetaDataTopicV2 {
+		if baseTopic == p2p.RPCMetaDataTopicV1 || baseTopic == p2p.RPCMetaDataTopicV2 ||
+			baseTopic == p2p.RPCLightClientFinalityUpdateTopicV1 || baseTopic == p2p.RPCLightClientOptimisticUpdateTopicV1 {
 			if err := handle(ctx, base, stream); err != nil {
 				messageFailedProcessingCounter.WithLabelValues(topic).Inc()
 				if err != p2ptypes.ErrWrongForkDigestVersion {
@@ -37,8 +37,21 @@ func (s *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 		tracing.AnnotateError(span, err)
 		return err
 	}
+	// Blocks below the lowest retained slot were pruned from the database, so the range cannot be served.
+	lowest, err := s.cfg.beaconDB.LowestRetainedSlot(ctx)
+	if err != nil {
+		s.writeErrorResponseToStream(responseCodeServerError, p2ptypes.ErrGeneric.Error(), stream)
+		tracing.AnnotateError(span, err)
+		return err
+	}
+	if rp.start < lowest {
+		s.writeErrorResponseToStream(responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+		err := errors.Wrapf(p2ptypes.ErrResourceUnavailable, "start slot %d is lower than the lowest retained slot %d", rp.start, lowest)
+		tracing.AnnotateError(span, err)
+		return err
+	}
 
-	blockLimiter, err := s.rateLimiter.topicCollector(string(stream.Protocol()))
+	blockLimiter, err := s.rateLimiter.topicCollector(string(stream.Protocol()), stream.Conn().RemotePeer())
 	if err != nil {
 		return err
 	}
@@ -98,6 +98,70 @@ func TestRPCBeaconBlocksByRange_RPCHandlerReturnsBlocks(t *testing.T) {
 	}
 }
 
+// savePrunedChain saves a chain of blocks up to slot 127 and prunes the history below slot 64.
+func savePrunedChain(t *testing.T, d db2.Database) [][32]byte {
+	ctx := context.Background()
+	roots := make([][32]byte, 0, 128)
+	var parentRoot [32]byte
+	for slot := primitives.Slot(0); slot < 128; slot++ {
+		blk := util.NewBeaconBlock()
+		blk.Block.Slot = slot
+		blk.Block.ParentRoot = parentRoot[:]
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, d, blk)
+		require.NoError(t, d.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: root[:]}))
+		if slot%64 == 0 {
+			st, err := util.NewBeaconState()
+			require.NoError(t, err)
+			require.NoError(t, st.SetSlot(slot))
+			require.NoError(t, d.SaveState(ctx, st, root))
+		}
+		roots = append(roots, root)
+		parentRoot = root
+	}
+	require.NoError(t, d.SaveGenesisBlockRoot(ctx, roots[0]))
+	require.NoError(t, d.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: roots[96][:]}))
+	_, err := d.PruneHistory(ctx, 80)
+	require.NoError(t, err)
+	lowest, err := d.LowestRetainedSlot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(64), lowest)
+	return roots
+}
+
+func TestRPCBeaconBlocksByRange_PrunedHistory(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	assert.Equal(t, 1, len(p1.BHost.Network().Peers()), "Expected peers to be connected")
+	d := db.SetupDB(t)
+	savePrunedChain(t, d)
+
+	clock := startup.NewClock(time.Unix(0, 0), [32]byte{})
+	r := &Service{cfg: &config{p2p: p1, beaconDB: d, clock: clock, chain: &chainMock.ChainService{}}, rateLimiter: newRateLimiter(p1)}
+	pcl := protocol.ID(p2p.RPCBlocksByRangeTopicV1)
+	topic := string(pcl)
+	r.rateLimiter.limiterMap[topic] = leakybucket.NewCollector(0.000001, 640, time.Second, false)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+	})
+
+	stream1, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := &ethpb.BeaconBlocksByRangeRequest{StartSlot: 32, Step: 1, Count: 64}
+	err = r.beaconBlocksByRangeRPCHandler(context.Background(), req, stream1)
+	assert.ErrorContains(t, "lowest retained slot 64", err)
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+	assert.Equal(t, 0, len(p1.Peers().Scorers().BadResponsesScorer().BadPeers()), "Peer was penalized")
+}
+
 func TestRPCBeaconBlocksByRange_ReturnCorrectNumberBack(t *testing.T) {
 	p1 := p2ptest.NewTestP2P(t)
 	p2 := p2ptest.NewTestP2P(t)
@@ -24,6 +24,9 @@ func (s *Service) sendRecentBeaconBlocksRequest(ctx context.Context, blockRoots
 		if err != nil {
 			return err
 		}
+		if err := s.requestPendingBlobs(ctx, blk, blkRoot, id); err != nil {
+			return err
+		}
 		s.pendingQueueLock.Lock()
 		defer s.pendingQueueLock.Unlock()
 		if err := s.insertBlockToPendingQueue(blk.Block().Slot(), blk, blkRoot); err != nil {
@@ -64,6 +67,7 @@ func (s *Service) beaconBlocksRootRPCHandler(ctx context.Context, msg interface{
 	}
 	s.rateLimiter.add(stream, int64(len(blockRoots)))
 
+	var missing bool
 	for _, root := range blockRoots {
 		blk, err := s.cfg.beaconDB.Block(ctx, root)
 		if err != nil {
@@ -72,6 +76,7 @@ func (s *Service) beaconBlocksRootRPCHandler(ctx context.Context, msg interface{
 			return err
 		}
 		if err := blocks.BeaconBlockIsNil(blk); err != nil {
+			missing = true
 			continue
 		}
 
@@ -93,6 +98,21 @@ func (s *Service) beaconBlocksRootRPCHandler(ctx context.Context, msg interface{
 		}
 	}
 
+	// A missing block may have been pruned from the database, in which case the peer is told the
+	// block is not available rather than getting a silently shortened response.
+	if missing {
+		lowest, err := s.cfg.beaconDB.LowestRetainedSlot(ctx)
+		if err != nil {
+			log.WithError(err).Debug("Could not fetch lowest retained slot")
+			s.writeErrorResponseToStream(responseCodeServerError, types.ErrGeneric.Error(), stream)
+			return err
+		}
+		if lowest > 0 {
+			s.writeErrorResponseToStream(responseCodeResourceUnavailable, types.ErrResourceUnavailable.Error(), stream)
+			return nil
+		}
+	}
+
 	closeStream(stream, log)
 	return nil
 }
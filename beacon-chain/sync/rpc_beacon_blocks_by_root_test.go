@@ -255,6 +255,40 @@ func TestRecentBeaconBlocks_RPCRequestSent(t *testing.T) {
 	}
 }
 
+func TestRecentBeaconBlocksRPCHandler_PrunedHistory(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	assert.Equal(t, 1, len(p1.BHost.Network().Peers()), "Expected peers to be connected")
+	d := db.SetupDB(t)
+	roots := savePrunedChain(t, d)
+
+	r := &Service{cfg: &config{p2p: p1, beaconDB: d, clock: startup.NewClock(time.Unix(0, 0), [32]byte{})}, rateLimiter: newRateLimiter(p1)}
+	pcl := protocol.ID(p2p.RPCBlocksByRootTopicV1)
+	topic := string(pcl)
+	r.rateLimiter.limiterMap[topic] = leakybucket.NewCollector(10000, 10000, time.Second, false)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		// The retained block is sent before the peer is told the pruned one is not available.
+		expectSuccess(t, stream)
+		res := util.NewBeaconBlock()
+		assert.NoError(t, r.cfg.p2p.Encoding().DecodeWithMaxLength(stream, res))
+		assert.Equal(t, primitives.Slot(100), res.Block.Slot)
+		expectFailure(t, responseCodeResourceUnavailable, p2pTypes.ErrResourceUnavailable.Error(), stream)
+	})
+
+	stream1, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := p2pTypes.BeaconBlockByRootsReq{roots[10], roots[100]}
+	require.NoError(t, r.beaconBlocksRootRPCHandler(context.Background(), &req, stream1))
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
 func TestRecentBeaconBlocksRPCHandler_HandleZeroBlocks(t *testing.T) {
 	p1 := p2ptest.NewTestP2P(t)
 	p2 := p2ptest.NewTestP2P(t)
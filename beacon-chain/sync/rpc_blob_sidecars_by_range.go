@@ -0,0 +1,143 @@
+package sync
+
+import (
+	"context"
+
+	libp2pcore "github.com/libp2p/go-libp2p/core"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	p2ptypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
+	pb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"go.opencensus.io/trace"
+)
+
+// blobSidecarsByRangeRPCHandler looks up the canonical blob sidecars in the database for the
+// requested slot range, bounded by the blob sidecar retention window.
+func (s *Service) blobSidecarsByRangeRPCHandler(ctx context.Context, msg interface{}, stream libp2pcore.Stream) error {
+	ctx, span := trace.StartSpan(ctx, "sync.BlobSidecarsByRangeHandler")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, respTimeout)
+	defer cancel()
+	SetRPCStreamDeadlines(stream)
+	log := log.WithField("handler", "blob_sidecars_by_range")
+
+	r, ok := msg.(*pb.BlobSidecarsByRangeRequest)
+	if !ok {
+		return errors.New("message is not type *pb.BlobSidecarsByRangeRequest")
+	}
+	if err := s.rateLimiter.validateRequest(stream, 1); err != nil {
+		return err
+	}
+	rp, err := validateBlobsByRange(r, s.cfg.clock.CurrentSlot())
+	if err != nil {
+		s.writeErrorResponseToStream(responseCodeInvalidRequest, err.Error(), stream)
+		s.cfg.p2p.Peers().Scorers().BadResponsesScorer().Increment(stream.Conn().RemotePeer())
+		tracing.AnnotateError(span, err)
+		return err
+	}
+	// The requested range is entirely out of the retention window, there is nothing to serve.
+	if rp.size == 0 {
+		s.rateLimiter.add(stream, 1)
+		closeStream(stream, log)
+		return nil
+	}
+
+	var sent uint64
+	maxSidecars := params.BeaconNetworkConfig().MaxRequestBlobSidecars
+	for slot := rp.start; slot <= rp.end; slot++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		_, roots, err := s.cfg.beaconDB.BlockRootsBySlot(ctx, slot)
+		if err != nil {
+			s.writeErrorResponseToStream(responseCodeServerError, p2ptypes.ErrGeneric.Error(), stream)
+			return errors.Wrapf(err, "could not retrieve block roots for slot %d", slot)
+		}
+		for _, root := range roots {
+			canonical, err := s.cfg.chain.IsCanonical(ctx, root)
+			if err != nil {
+				s.writeErrorResponseToStream(responseCodeServerError, p2ptypes.ErrGeneric.Error(), stream)
+				return errors.Wrapf(err, "could not determine if block %#x is canonical", root)
+			}
+			if !canonical {
+				continue
+			}
+			sidecars, err := s.cfg.beaconDB.BlobSidecarsByRoot(ctx, root)
+			if errors.Is(err, kv.ErrNotFoundBlobSidecars) {
+				continue
+			}
+			if err != nil {
+				s.writeErrorResponseToStream(responseCodeServerError, p2ptypes.ErrGeneric.Error(), stream)
+				return errors.Wrapf(err, "could not retrieve blob sidecars for block %#x", root)
+			}
+			for _, sc := range sidecars {
+				if sent >= maxSidecars {
+					closeStream(stream, log)
+					return nil
+				}
+				SetStreamWriteDeadline(stream, defaultWriteDuration)
+				if err := WriteBlobSidecarChunk(stream, s.cfg.clock, s.cfg.p2p.Encoding(), sc); err != nil {
+					log.WithError(err).Debug("Could not send a chunked response")
+					s.writeErrorResponseToStream(responseCodeServerError, p2ptypes.ErrGeneric.Error(), stream)
+					tracing.AnnotateError(span, err)
+					return err
+				}
+				sent++
+			}
+		}
+	}
+	s.rateLimiter.add(stream, int64(sent))
+	closeStream(stream, log)
+	return nil
+}
+
+// BlobRPCMinValidSlot returns the lowest slot for which blob sidecars are expected to be served over
+// req/resp, which is the start of the retention window or the Deneb fork, whichever is later.
+func BlobRPCMinValidSlot(current primitives.Slot) (primitives.Slot, error) {
+	minReqEpochs := params.BeaconNetworkConfig().MinEpochsForBlobsSidecarsRequest
+	currEpoch := slots.ToEpoch(current)
+	minStart := params.BeaconConfig().DenebForkEpoch
+	if currEpoch > minReqEpochs && currEpoch-minReqEpochs > minStart {
+		minStart = currEpoch - minReqEpochs
+	}
+	if minStart >= params.BeaconConfig().FarFutureEpoch {
+		return params.BeaconConfig().FarFutureSlot, nil
+	}
+	return slots.EpochStart(minStart)
+}
+
+// validateBlobsByRange checks the bounds of a blob sidecars by range request and clamps its
+// start to the retention window. A returned size of zero means there is nothing to serve.
+func validateBlobsByRange(r *pb.BlobSidecarsByRangeRequest, current primitives.Slot) (rangeParams, error) {
+	if r.Count == 0 {
+		return rangeParams{}, errors.Wrap(p2ptypes.ErrInvalidRequest, "invalid request count parameter")
+	}
+	maxBlocks := params.BeaconNetworkConfig().MaxRequestBlocksDeneb
+	if r.Count > maxBlocks || r.Count*fieldparams.MaxBlobsPerBlock > params.BeaconNetworkConfig().MaxRequestBlobSidecars {
+		return rangeParams{}, errors.Wrap(p2ptypes.ErrInvalidRequest, "requested more than the max blob sidecars limit")
+	}
+	end, err := r.StartSlot.SafeAdd(r.Count - 1)
+	if err != nil {
+		return rangeParams{}, errors.Wrap(p2ptypes.ErrInvalidRequest, "overflow start + count -1")
+	}
+	if end > current {
+		end = current
+	}
+	minStart, err := BlobRPCMinValidSlot(current)
+	if err != nil {
+		return rangeParams{}, errors.Wrap(p2ptypes.ErrInvalidRequest, "blobs by range request overflows")
+	}
+	start := r.StartSlot
+	if start < minStart {
+		start = minStart
+	}
+	if start > end {
+		return rangeParams{start: start, end: start}, nil
+	}
+	return rangeParams{start: start, end: end, size: uint64(end - start + 1)}, nil
+}
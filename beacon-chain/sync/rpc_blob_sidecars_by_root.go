@@ -0,0 +1,80 @@
+package sync
+
+import (
+	"context"
+
+	libp2pcore "github.com/libp2p/go-libp2p/core"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"go.opencensus.io/trace"
+)
+
+// blobSidecarByRootRPCHandler handles the /eth2/beacon_chain/req/blob_sidecars_by_root/1/ RPC request.
+// Sidecars that are not found or that are out of the retention window are skipped.
+func (s *Service) blobSidecarByRootRPCHandler(ctx context.Context, msg interface{}, stream libp2pcore.Stream) error {
+	ctx, span := trace.StartSpan(ctx, "sync.blobSidecarByRootRPCHandler")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, ttfbTimeout)
+	defer cancel()
+	SetRPCStreamDeadlines(stream)
+	log := log.WithField("handler", "blob_sidecars_by_root")
+
+	ref, ok := msg.(*types.BlobSidecarsByRootReq)
+	if !ok {
+		return errors.New("message is not type BlobSidecarsByRootReq")
+	}
+	blobIdents := *ref
+	if err := s.rateLimiter.validateRequest(stream, uint64(len(blobIdents))); err != nil {
+		return err
+	}
+	if uint64(len(blobIdents)) > params.BeaconNetworkConfig().MaxRequestBlobSidecars {
+		s.cfg.p2p.Peers().Scorers().BadResponsesScorer().Increment(stream.Conn().RemotePeer())
+		s.writeErrorResponseToStream(responseCodeInvalidRequest, types.ErrMaxBlobReqExceeded.Error(), stream)
+		return types.ErrMaxBlobReqExceeded
+	}
+	s.rateLimiter.add(stream, int64(len(blobIdents)))
+
+	minStart, err := BlobRPCMinValidSlot(s.cfg.clock.CurrentSlot())
+	if err != nil {
+		s.writeErrorResponseToStream(responseCodeServerError, types.ErrGeneric.Error(), stream)
+		return err
+	}
+	minEpoch := slots.ToEpoch(minStart)
+	for _, id := range blobIdents {
+		if err := ctx.Err(); err != nil {
+			closeStream(stream, log)
+			return err
+		}
+		root := bytesutil.ToBytes32(id.BlockRoot)
+		sidecars, err := s.cfg.beaconDB.BlobSidecarsByRoot(ctx, root, id.Index)
+		if errors.Is(err, kv.ErrNotFoundBlobSidecars) {
+			log.WithField("root", root).WithField("index", id.Index).Debug("Peer requested blob sidecar that was not found")
+			continue
+		}
+		if err != nil {
+			log.WithError(err).Debug("Could not fetch blob sidecar")
+			s.writeErrorResponseToStream(responseCodeServerError, types.ErrGeneric.Error(), stream)
+			tracing.AnnotateError(span, err)
+			return err
+		}
+		sc := sidecars[0]
+		// Sidecars older than the retention window are not served, even if they were not pruned yet.
+		if slots.ToEpoch(sc.SignedBlockHeader.Header.Slot) < minEpoch {
+			continue
+		}
+		SetStreamWriteDeadline(stream, defaultWriteDuration)
+		if err := WriteBlobSidecarChunk(stream, s.cfg.clock, s.cfg.p2p.Encoding(), sc); err != nil {
+			log.WithError(err).Debug("Could not send a chunked response")
+			s.writeErrorResponseToStream(responseCodeServerError, types.ErrGeneric.Error(), stream)
+			tracing.AnnotateError(span, err)
+			return err
+		}
+	}
+	closeStream(stream, log)
+	return nil
+}
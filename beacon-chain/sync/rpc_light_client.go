@@ -0,0 +1,161 @@
+package sync
+
+import (
+	"context"
+
+	libp2pcore "github.com/libp2p/go-libp2p/core"
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	pb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// lightClientBootstrapRPCHandler serves the light client bootstrap for the requested block root.
+func (s *Service) lightClientBootstrapRPCHandler(ctx context.Context, msg interface{}, stream libp2pcore.Stream) error {
+	ctx, cancel := context.WithTimeout(ctx, respTimeout)
+	defer cancel()
+	SetRPCStreamDeadlines(stream)
+	log := log.WithField("handler", "light_client_bootstrap")
+
+	req, ok := msg.(*pb.LightClientBootstrapRequest)
+	if !ok {
+		return errors.New("message is not type LightClientBootstrapRequest")
+	}
+	if err := s.rateLimiter.validateRequest(stream, 1); err != nil {
+		return err
+	}
+	s.rateLimiter.add(stream, 1)
+
+	bootstrap, err := s.cfg.chain.LightClientBootstrap(ctx, bytesutil.ToBytes32(req.BlockRoot))
+	if err != nil && !errors.Is(err, blockchain.ErrLightClientBootstrapUnavailable) {
+		log.WithError(err).Debug("Could not get light client bootstrap")
+		s.writeErrorResponseToStream(responseCodeServerError, types.ErrGeneric.Error(), stream)
+		return err
+	}
+	if bootstrap == nil {
+		s.writeErrorResponseToStream(responseCodeResourceUnavailable, types.ErrResourceUnavailable.Error(), stream)
+		return nil
+	}
+	if err := s.writeLightClientChunk(stream, bootstrap.Header().Beacon().Slot, bootstrap); err != nil {
+		return err
+	}
+	closeStream(stream, log)
+	return nil
+}
+
+// lightClientUpdatesByRangeRPCHandler serves the best light client update of each requested sync committee period.
+// The request is charged one unit per returned update.
+func (s *Service) lightClientUpdatesByRangeRPCHandler(ctx context.Context, msg interface{}, stream libp2pcore.Stream) error {
+	ctx, cancel := context.WithTimeout(ctx, respTimeout)
+	defer cancel()
+	SetRPCStreamDeadlines(stream)
+	log := log.WithField("handler", "light_client_updates_by_range")
+
+	req, ok := msg.(*pb.LightClientUpdatesByRangeRequest)
+	if !ok {
+		return errors.New("message is not type LightClientUpdatesByRangeRequest")
+	}
+	if err := s.rateLimiter.validateRequest(stream, 1); err != nil {
+		return err
+	}
+
+	if req.Count == 0 {
+		s.rateLimiter.add(stream, 1)
+		s.cfg.p2p.Peers().Scorers().BadResponsesScorer().Increment(stream.Conn().RemotePeer())
+		s.writeErrorResponseToStream(responseCodeInvalidRequest, types.ErrInvalidRequest.Error(), stream)
+		return types.ErrInvalidRequest
+	}
+	// Requests for more than the maximum number of updates are served up to the maximum.
+	updates, err := s.cfg.chain.LightClientUpdatesByRange(ctx, req.StartPeriod, req.Count)
+	if err != nil {
+		s.rateLimiter.add(stream, 1)
+		log.WithError(err).Debug("Could not get light client updates")
+		s.writeErrorResponseToStream(responseCodeServerError, types.ErrGeneric.Error(), stream)
+		return err
+	}
+	if len(updates) == 0 {
+		s.rateLimiter.add(stream, 1)
+		closeStream(stream, log)
+		return nil
+	}
+	if err := s.rateLimiter.validateRequest(stream, uint64(len(updates))); err != nil {
+		return err
+	}
+	s.rateLimiter.add(stream, int64(len(updates)))
+	for _, u := range updates {
+		if err := s.writeLightClientChunk(stream, u.AttestedHeader().Beacon().Slot, u); err != nil {
+			return err
+		}
+	}
+	closeStream(stream, log)
+	return nil
+}
+
+// lightClientFinalityUpdateRPCHandler serves the latest light client finality update.
+func (s *Service) lightClientFinalityUpdateRPCHandler(_ context.Context, _ interface{}, stream libp2pcore.Stream) error {
+	SetRPCStreamDeadlines(stream)
+	log := log.WithField("handler", "light_client_finality_update")
+
+	if err := s.rateLimiter.validateRequest(stream, 1); err != nil {
+		return err
+	}
+	s.rateLimiter.add(stream, 1)
+
+	update := s.cfg.chain.LightClientFinalityUpdate()
+	if update == nil {
+		s.writeErrorResponseToStream(responseCodeResourceUnavailable, types.ErrResourceUnavailable.Error(), stream)
+		return nil
+	}
+	if err := s.writeLightClientChunk(stream, update.AttestedHeader().Beacon().Slot, update); err != nil {
+		return err
+	}
+	closeStream(stream, log)
+	return nil
+}
+
+// lightClientOptimisticUpdateRPCHandler serves the latest light client optimistic update.
+func (s *Service) lightClientOptimisticUpdateRPCHandler(_ context.Context, _ interface{}, stream libp2pcore.Stream) error {
+	SetRPCStreamDeadlines(stream)
+	log := log.WithField("handler", "light_client_optimistic_update")
+
+	if err := s.rateLimiter.validateRequest(stream, 1); err != nil {
+		return err
+	}
+	s.rateLimiter.add(stream, 1)
+
+	update := s.cfg.chain.LightClientOptimisticUpdate()
+	if update == nil {
+		s.writeErrorResponseToStream(responseCodeResourceUnavailable, types.ErrResourceUnavailable.Error(), stream)
+		return nil
+	}
+	if err := s.writeLightClientChunk(stream, update.AttestedHeader().Beacon().Slot, update); err != nil {
+		return err
+	}
+	closeStream(stream, log)
+	return nil
+}
+
+// writeLightClientChunk writes a light client object as a chunked response, using the fork digest of the
+// epoch of the given slot as the context bytes.
+// response_chunk  ::= <result> | <context-bytes> | <encoding-dependent-header> | <encoded-payload>
+func (s *Service) writeLightClientChunk(stream libp2pcore.Stream, slot primitives.Slot, msg ssz.Marshaler) error {
+	SetStreamWriteDeadline(stream, defaultWriteDuration)
+	valRoot := s.cfg.clock.GenesisValidatorsRoot()
+	digest, err := forks.ForkDigestFromEpoch(slots.ToEpoch(slot), valRoot[:])
+	if err != nil {
+		return err
+	}
+	if _, err := stream.Write([]byte{responseCodeSuccess}); err != nil {
+		return err
+	}
+	if err := writeContextToStream(digest[:], stream); err != nil {
+		return err
+	}
+	_, err = s.cfg.p2p.Encoding().EncodeWithMaxLength(stream, msg)
+	return err
+}
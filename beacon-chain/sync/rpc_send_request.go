@@ -4,21 +4,33 @@ import (
 	"context"
 	"io"
 
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
 	p2ptypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	pb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
 )
 
 // ErrInvalidFetchedData is thrown if stream fails to provide requested blocks.
 var ErrInvalidFetchedData = errors.New("invalid data returned from peer")
 
+var (
+	errBlobChunkedReadFailure         = errors.New("failed to read stream of chunk-encoded blobs")
+	errChunkResponseContextMismatch   = errors.New("unexpected context bytes for chunked response")
+	errBlobResponseOutOfBounds        = errors.Wrap(ErrInvalidFetchedData, "blob sidecar slot out of requested range")
+	errBlobResponseUnrequested        = errors.Wrap(ErrInvalidFetchedData, "blob sidecar was not requested")
+	errMaxRequestBlobSidecarsExceeded = errors.Wrap(ErrInvalidFetchedData, "peer sent more than the max blob sidecars per request")
+)
+
 // BeaconBlockProcessor defines a block processing function, which allows to start utilizing
 // blocks even before all blocks are ready.
 type BeaconBlockProcessor func(block interfaces.ReadOnlySignedBeaconBlock) error
@@ -129,3 +141,113 @@ func SendBeaconBlocksByRootRequest(
 	}
 	return blocks, nil
 }
+
+// SendBlobsByRangeRequest sends BlobSidecarsByRange and returns the fetched blob sidecars, if any.
+func SendBlobsByRangeRequest(
+	ctx context.Context, tor blockchain.TemporalOracle, p2pProvider p2p.SenderEncoder, pid peer.ID,
+	req *pb.BlobSidecarsByRangeRequest,
+) ([]*pb.BlobSidecar, error) {
+	topic, err := p2p.TopicFromMessage(p2p.BlobSidecarsByRangeName, slots.ToEpoch(tor.CurrentSlot()))
+	if err != nil {
+		return nil, err
+	}
+	log.WithFields(logrus.Fields{
+		"topic":     topic,
+		"startSlot": req.StartSlot,
+		"count":     req.Count,
+	}).Debug("Sending blob sidecars by range request")
+	stream, err := p2pProvider.Send(ctx, req, topic, pid)
+	if err != nil {
+		return nil, err
+	}
+	defer closeStream(stream, log)
+
+	max := req.Count * fieldparams.MaxBlobsPerBlock
+	if max > params.BeaconNetworkConfig().MaxRequestBlobSidecars {
+		max = params.BeaconNetworkConfig().MaxRequestBlobSidecars
+	}
+	end := req.StartSlot.Add(req.Count)
+	var prevSlot primitives.Slot
+	return readBlobSidecars(stream, tor, p2pProvider, max, func(sc *pb.BlobSidecar, isFirst bool) error {
+		slot := sc.SignedBlockHeader.Header.Slot
+		// Returned sidecars MUST be in the slot range [start_slot, start_slot + count).
+		if slot < req.StartSlot || slot >= end {
+			return errBlobResponseOutOfBounds
+		}
+		// Returned sidecars MUST be sent in a consecutive order.
+		if !isFirst && slot < prevSlot {
+			return errors.Wrap(ErrInvalidFetchedData, "blob sidecars out of order")
+		}
+		prevSlot = slot
+		return nil
+	})
+}
+
+// SendBlobSidecarByRoot sends BlobSidecarsByRoot and returns the fetched blob sidecars, if any.
+func SendBlobSidecarByRoot(
+	ctx context.Context, tor blockchain.TemporalOracle, p2pProvider p2p.P2P, pid peer.ID,
+	req *p2ptypes.BlobSidecarsByRootReq,
+) ([]*pb.BlobSidecar, error) {
+	if uint64(len(*req)) > params.BeaconNetworkConfig().MaxRequestBlobSidecars {
+		return nil, errors.Wrapf(p2ptypes.ErrMaxBlobReqExceeded, "length=%d", len(*req))
+	}
+	topic, err := p2p.TopicFromMessage(p2p.BlobSidecarsByRootName, slots.ToEpoch(tor.CurrentSlot()))
+	if err != nil {
+		return nil, err
+	}
+	log.WithField("topic", topic).Debug("Sending blob sidecars by root request")
+	stream, err := p2pProvider.Send(ctx, req, topic, pid)
+	if err != nil {
+		return nil, err
+	}
+	defer closeStream(stream, log)
+
+	requested := make(map[[32]byte]map[uint64]bool, len(*req))
+	for _, id := range *req {
+		root := bytesutil.ToBytes32(id.BlockRoot)
+		if requested[root] == nil {
+			requested[root] = make(map[uint64]bool)
+		}
+		requested[root][id.Index] = true
+	}
+	return readBlobSidecars(stream, tor, p2pProvider, uint64(len(*req)), func(sc *pb.BlobSidecar, _ bool) error {
+		root, err := sc.SignedBlockHeader.Header.HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		if !requested[root][sc.Index] {
+			return errBlobResponseUnrequested
+		}
+		return nil
+	})
+}
+
+// readBlobSidecars reads up to max blob sidecar chunks from the stream, checking each of them
+// with the provided validation function before accepting it.
+func readBlobSidecars(
+	stream network.Stream, tor blockchain.TemporalOracle, p2pProvider p2p.EncodingProvider, max uint64,
+	validate func(sc *pb.BlobSidecar, isFirst bool) error,
+) ([]*pb.BlobSidecar, error) {
+	sidecars := make([]*pb.BlobSidecar, 0)
+	for i := uint64(0); ; i++ {
+		isFirstChunk := i == 0
+		sc, err := ReadChunkedBlobSidecar(stream, tor, p2pProvider, isFirstChunk)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if i >= max {
+			return nil, errMaxRequestBlobSidecarsExceeded
+		}
+		if sc.SignedBlockHeader == nil || sc.SignedBlockHeader.Header == nil {
+			return nil, errors.Wrap(ErrInvalidFetchedData, "blob sidecar without block header")
+		}
+		if err := validate(sc, isFirstChunk); err != nil {
+			return nil, err
+		}
+		sidecars = append(sidecars, sc)
+	}
+	return sidecars, nil
+}
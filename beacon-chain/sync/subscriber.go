@@ -119,6 +119,20 @@ func (s *Service) registerSubscribers(epoch primitives.Epoch, digest [4]byte) {
 				digest,
 			)
 		}
+		if features.Get().EnableLightClient {
+			s.subscribe(
+				p2p.LightClientFinalityUpdateTopicFormat,
+				s.validateLightClientFinalityUpdate,
+				s.lightClientUpdateSubscriber,
+				digest,
+			)
+			s.subscribe(
+				p2p.LightClientOptimisticUpdateTopicFormat,
+				s.validateLightClientOptimisticUpdate,
+				s.lightClientUpdateSubscriber,
+				digest,
+			)
+		}
 	}
 
 	// New Gossip Topic in Capella
@@ -130,6 +144,35 @@ func (s *Service) registerSubscribers(epoch primitives.Epoch, digest [4]byte) {
 			digest,
 		)
 	}
+
+	// New Gossip Topic in Deneb
+	if epoch >= params.BeaconConfig().DenebForkEpoch {
+		s.subscribeStaticWithBlobSubnets(
+			p2p.BlobSubnetTopicFormat,
+			s.validateBlob,   /* validator */
+			s.blobSubscriber, /* message handler */
+			digest,
+		)
+	}
+}
+
+// subscribe to all the blob sidecar subnets. Unlike attestation and sync committee subnets, blob
+// sidecar subnets are not advertised in the node record so there is no subnet peer search.
+func (s *Service) subscribeStaticWithBlobSubnets(topic string, validator wrappedVal, handle subHandler, digest [4]byte) {
+	genRoot := s.cfg.clock.GenesisValidatorsRoot()
+	_, e, err := forks.RetrieveForkDataFromDigest(digest, genRoot[:])
+	if err != nil {
+		// Impossible condition as it would mean digest does not exist.
+		panic(err)
+	}
+	base := p2p.GossipTopicMappings(topic, e)
+	if base == nil {
+		// Impossible condition as it would mean topic does not exist.
+		panic(fmt.Sprintf("%s is not mapped to any message in GossipTopicMappings", topic))
+	}
+	for i := uint64(0); i < params.BeaconNetworkConfig().BlobsidecarSubnetCount; i++ {
+		s.subscribeWithBase(s.addDigestAndIndexToTopic(topic, digest, i), validator, handle)
+	}
 }
 
 // subscribe to a given topic with a given validator and subscription handler.
@@ -164,6 +207,7 @@ func (s *Service) subscribeWithBase(topic string, validator wrappedVal, handle s
 		log.WithError(err).Error("Could not register validator for topic")
 		return nil
 	}
+	s.addGossipValidator(topic, validator)
 
 	sub, err := s.cfg.p2p.SubscribeToTopic(topic)
 	if err != nil {
@@ -0,0 +1,19 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"google.golang.org/protobuf/proto"
+)
+
+func (s *Service) blobSubscriber(ctx context.Context, msg proto.Message) error {
+	b, ok := msg.(*eth.BlobSidecar)
+	if !ok {
+		return fmt.Errorf("message was not type blob sidecar, type=%T", msg)
+	}
+	header := b.SignedBlockHeader.Header
+	s.setSeenBlobIndex(header.Slot, header.ProposerIndex, b.Index)
+	return s.cfg.chain.ReceiveBlob(ctx, b)
+}
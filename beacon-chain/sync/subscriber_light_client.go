@@ -0,0 +1,12 @@
+package sync
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// lightClientUpdateSubscriber is a no-op, light client updates are only validated and forwarded to peers.
+func (s *Service) lightClientUpdateSubscriber(_ context.Context, _ proto.Message) error {
+	return nil
+}
@@ -0,0 +1,192 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
+	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// validateBlob checks the incoming blob sidecar against the gossip conditions of the
+// blob_sidecar_{subnet_id} topics, as defined in the Deneb p2p specification.
+func (s *Service) validateBlob(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+	ctx, span := trace.StartSpan(ctx, "sync.validateBlob")
+	defer span.End()
+
+	if msg.Topic == nil {
+		return pubsub.ValidationReject, errInvalidTopic
+	}
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, errors.Wrap(err, "could not decode message")
+	}
+	blob, ok := m.(*eth.BlobSidecar)
+	if !ok {
+		log.WithField("message", m).Error("Message is not of type *eth.BlobSidecar")
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	if blob.SignedBlockHeader == nil || blob.SignedBlockHeader.Header == nil {
+		return pubsub.ValidationReject, errNilMessage
+	}
+	header := blob.SignedBlockHeader.Header
+
+	// [REJECT] The sidecar's index is consistent with MAX_BLOBS_PER_BLOCK -- i.e. blob_sidecar.index < MAX_BLOBS_PER_BLOCK.
+	if blob.Index >= fieldparams.MaxBlobsPerBlock {
+		log.WithFields(blobFields(blob)).Debug("Sidecar index >= MAX_BLOBS_PER_BLOCK")
+		return pubsub.ValidationReject, errors.New("incorrect blob sidecar index")
+	}
+
+	// [REJECT] The sidecar is for the correct subnet -- i.e. compute_subnet_for_blob_sidecar(blob_sidecar.index) == subnet_id.
+	want := fmt.Sprintf("blob_sidecar_%d", computeSubnetForBlobSidecar(blob.Index))
+	if !strings.Contains(*msg.Topic, want) {
+		log.WithFields(blobFields(blob)).Debug("Sidecar index does not match topic")
+		return pubsub.ValidationReject, fmt.Errorf("wrong topic name: %s", *msg.Topic)
+	}
+
+	// [IGNORE] The sidecar is not from a future slot (with a MAXIMUM_GOSSIP_CLOCK_DISPARITY allowance).
+	genesisTime := uint64(s.cfg.clock.GenesisTime().Unix())
+	if err := slots.VerifyTime(genesisTime, header.Slot, params.BeaconNetworkConfig().MaximumGossipClockDisparity); err != nil {
+		log.WithError(err).WithFields(blobFields(blob)).Debug("Ignored blob: too far into future")
+		return pubsub.ValidationIgnore, errors.Wrap(err, "blob too far into future")
+	}
+
+	// [IGNORE] The sidecar is from a slot greater than the latest finalized slot.
+	startSlot, err := slots.EpochStart(s.cfg.chain.FinalizedCheckpt().Epoch)
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	if startSlot >= header.Slot {
+		err := fmt.Errorf("finalized slot %d greater or equal to blob slot %d", startSlot, header.Slot)
+		log.WithFields(blobFields(blob)).Debug(err)
+		return pubsub.ValidationIgnore, err
+	}
+
+	// [IGNORE] The sidecar is the first sidecar for the tuple (block_header.slot, block_header.proposer_index, blob_sidecar.index).
+	if s.hasSeenBlobIndex(header.Slot, header.ProposerIndex, blob.Index) {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	// [IGNORE] The sidecar's block's parent (defined by block_header.parent_root) has been seen.
+	parentRoot := bytesutil.ToBytes32(header.ParentRoot)
+	if !s.cfg.chain.HasBlock(ctx, parentRoot) {
+		log.WithFields(blobFields(blob)).Debug("Ignored blob: parent block not found")
+		return pubsub.ValidationIgnore, nil
+	}
+
+	// [REJECT] The sidecar's block's parent (defined by block_header.parent_root) passes validation.
+	if s.hasBadBlock(parentRoot) {
+		log.WithFields(blobFields(blob)).Debug("Received blob with invalid parent")
+		return pubsub.ValidationReject, errors.New("invalid parent")
+	}
+
+	// [REJECT] The current finalized_checkpoint is an ancestor of the sidecar's block.
+	if !s.cfg.chain.InForkchoice(parentRoot) {
+		log.WithFields(blobFields(blob)).Debug("Received blob not descending from the finalized checkpoint")
+		return pubsub.ValidationReject, blockchain.ErrNotDescendantOfFinalized
+	}
+
+	// [REJECT] The sidecar's inclusion proof is valid as verified by verify_blob_sidecar_inclusion_proof(blob_sidecar).
+	if err := consensusblocks.VerifyKZGInclusionProof(blob); err != nil {
+		log.WithError(err).WithFields(blobFields(blob)).Debug("Failed to verify inclusion proof")
+		return pubsub.ValidationReject, err
+	}
+
+	// [REJECT] The sidecar's blob is valid as verified by verify_blob_kzg_proof(blob_sidecar.blob, blob_sidecar.kzg_commitment, blob_sidecar.kzg_proof).
+	if err := kzg.Verify(blob); err != nil {
+		log.WithError(err).WithFields(blobFields(blob)).Debug("Failed to verify blob kzg proof")
+		return pubsub.ValidationReject, err
+	}
+
+	parentState, err := s.cfg.stateGen.StateByRoot(ctx, parentRoot)
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	// [REJECT] The sidecar is from a higher slot than the sidecar's block's parent (defined by block_header.parent_root).
+	if header.Slot <= parentState.Slot() {
+		log.WithFields(blobFields(blob)).Debug("Received blob with lower slot than its parent")
+		return pubsub.ValidationReject, errors.New("blob slot lower than parent slot")
+	}
+	parentState, err = transition.ProcessSlotsUsingNextSlotCache(ctx, parentState, parentRoot[:], header.Slot)
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+
+	// [REJECT] The proposer signature of blob_sidecar.signed_block_header, is valid with respect to the block_header.proposer_index pubkey.
+	if err := blocks.VerifyBlockHeaderSignature(parentState, blob.SignedBlockHeader); err != nil {
+		log.WithError(err).WithFields(blobFields(blob)).Debug("Failed to verify proposer signature")
+		return pubsub.ValidationReject, err
+	}
+
+	// [REJECT] The sidecar is proposed by the expected proposer_index for the block's slot in the context of the current shuffling.
+	idx, err := helpers.BeaconProposerIndex(ctx, parentState)
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	if header.ProposerIndex != idx {
+		log.WithFields(blobFields(blob)).WithField("expectedProposerIndex", idx).Debug("Received blob from an unexpected proposer")
+		return pubsub.ValidationReject, errors.New("incorrect proposer index")
+	}
+
+	msg.ValidatorData = blob
+	return pubsub.ValidationAccept, nil
+}
+
+// computeSubnetForBlobSidecar returns the subnet a blob sidecar of the given index is gossiped on.
+func computeSubnetForBlobSidecar(index uint64) uint64 {
+	return index % params.BeaconNetworkConfig().BlobsidecarSubnetCount
+}
+
+// Returns true if the blob with the same slot, proposer index and blob index has been seen before.
+func (s *Service) hasSeenBlobIndex(slot primitives.Slot, proposerIndex primitives.ValidatorIndex, index uint64) bool {
+	s.seenBlobLock.RLock()
+	defer s.seenBlobLock.RUnlock()
+	b := append(bytesutil.Bytes32(uint64(slot)), bytesutil.Bytes32(uint64(proposerIndex))...)
+	b = append(b, bytesutil.Bytes32(index)...)
+	_, seen := s.seenBlobCache.Get(string(b))
+	return seen
+}
+
+// Sets the blob with the same slot, proposer index and blob index as seen.
+func (s *Service) setSeenBlobIndex(slot primitives.Slot, proposerIndex primitives.ValidatorIndex, index uint64) {
+	s.seenBlobLock.Lock()
+	defer s.seenBlobLock.Unlock()
+	b := append(bytesutil.Bytes32(uint64(slot)), bytesutil.Bytes32(uint64(proposerIndex))...)
+	b = append(b, bytesutil.Bytes32(index)...)
+	s.seenBlobCache.Add(string(b), true)
+}
+
+func blobFields(b *eth.BlobSidecar) logrus.Fields {
+	return logrus.Fields{
+		"slot":          b.SignedBlockHeader.Header.Slot,
+		"proposerIndex": b.SignedBlockHeader.Header.ProposerIndex,
+		"bodyRoot":      fmt.Sprintf("%#x", bytesutil.Trunc(b.SignedBlockHeader.Header.BodyRoot)),
+		"index":         b.Index,
+	}
+}
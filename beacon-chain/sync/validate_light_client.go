@@ -0,0 +1,103 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	lightclient "github.com/prysmaticlabs/prysm/v4/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// validateLightClientFinalityUpdate forwards a finality update only if it is the update this node would
+// produce itself, and only once a third of the signature slot has passed.
+func (s *Service) validateLightClientFinalityUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	_, span := trace.StartSpan(ctx, "sync.validateLightClientFinalityUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	pb, ok := m.(proto.Message)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	update, err := lightclient.NewWrappedFinalityUpdate(pb)
+	if err != nil {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	if !s.lightClientUpdateIsTimely(update.SignatureSlot()) {
+		return pubsub.ValidationIgnore, nil
+	}
+	local := s.cfg.chain.LightClientFinalityUpdate()
+	if local == nil || !proto.Equal(local.Proto(), pb) {
+		return pubsub.ValidationIgnore, nil
+	}
+	msg.ValidatorData = pb
+	return pubsub.ValidationAccept, nil
+}
+
+// validateLightClientOptimisticUpdate forwards an optimistic update only if it is the update this node would
+// produce itself, and only once a third of the signature slot has passed.
+func (s *Service) validateLightClientOptimisticUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	_, span := trace.StartSpan(ctx, "sync.validateLightClientOptimisticUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	pb, ok := m.(proto.Message)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	update, err := lightclient.NewWrappedOptimisticUpdate(pb)
+	if err != nil {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	if !s.lightClientUpdateIsTimely(update.SignatureSlot()) {
+		return pubsub.ValidationIgnore, nil
+	}
+	local := s.cfg.chain.LightClientOptimisticUpdate()
+	if local == nil || !proto.Equal(local.Proto(), pb) {
+		return pubsub.ValidationIgnore, nil
+	}
+	msg.ValidatorData = pb
+	return pubsub.ValidationAccept, nil
+}
+
+// lightClientUpdateIsTimely checks that at least a third of the signature slot has passed, allowing for
+// the maximum gossip clock disparity.
+func (s *Service) lightClientUpdateIsTimely(signatureSlot primitives.Slot) bool {
+	earliest := slots.StartTime(uint64(s.cfg.clock.GenesisTime().Unix()), signatureSlot).
+		Add(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second / 3).
+		Add(-params.BeaconNetworkConfig().MaximumGossipClockDisparity)
+	return !s.cfg.clock.Now().Before(earliest)
+}
@@ -0,0 +1,58 @@
+package pruner
+
+import (
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/pruner"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/node"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/sync/backfill"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// MinimalHistory enables the pruning of the finalized block and state history older than the retention period.
+	MinimalHistory = &cli.BoolFlag{
+		Name: "minimal-history",
+		Usage: "Only keep the blocks and states of the last --history-retention-epochs epochs, along with the " +
+			"genesis and weak subjectivity checkpoint ones, and delete older history in the background. " +
+			"Not compatible with --enable-experimental-backfill.",
+	}
+	// HistoryRetentionEpochs sets the number of epochs of history kept when running with --minimal-history.
+	HistoryRetentionEpochs = &cli.Uint64Flag{
+		Name:  "history-retention-epochs",
+		Usage: "Number of epochs of blocks and states to keep when running with --minimal-history. Must be at least " +
+			"MIN_EPOCHS_FOR_BLOCK_REQUESTS, the number of epochs of blocks a node must be able to serve to its peers.",
+		Value: uint64(params.BeaconNetworkConfig().MinEpochsForBlockRequests),
+	}
+	// BlobRetentionEpochs sets the number of epochs of blob sidecars kept by the node.
+	BlobRetentionEpochs = &cli.Uint64Flag{
+		Name: "blob-retention-epochs",
+		Usage: "Number of epochs of blob sidecars to keep before deleting them. Must be at least " +
+			"MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS, the number of epochs of blobs a node must be able to serve to its peers.",
+		Value: uint64(params.BeaconNetworkConfig().MinEpochsForBlobsSidecarsRequest),
+	}
+)
+
+// BeaconNodeOptions sets the appropriate functional opts on the *node.BeaconNode value, to decouple options
+// from flag parsing.
+func BeaconNodeOptions(c *cli.Context) (node.Option, error) {
+	enabled := c.Bool(MinimalHistory.Name)
+	if enabled && c.Bool(backfill.EnableExperimentalBackfill.Name) {
+		return nil, fmt.Errorf("--%s cannot be used with --%s", MinimalHistory.Name, backfill.EnableExperimentalBackfill.Name)
+	}
+	wsCheckpoint, err := helpers.ParseWeakSubjectivityInputString(c.String(flags.WeakSubjectivityCheckpoint.Name))
+	if err != nil {
+		return nil, err
+	}
+	opts := []pruner.ServiceOption{
+		pruner.WithMinimalHistory(enabled),
+		pruner.WithRetentionEpochs(primitives.Epoch(c.Uint64(HistoryRetentionEpochs.Name))),
+		pruner.WithWeakSubjectivityCheckpoint(wsCheckpoint),
+		pruner.WithBlobRetentionEpochs(primitives.Epoch(c.Uint64(BlobRetentionEpochs.Name))),
+	}
+	return node.WithPrunerOptions(opts), nil
+}
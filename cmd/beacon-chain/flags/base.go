@@ -3,18 +3,27 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/prysmaticlabs/prysm/v4/cmd"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/urfave/cli/v2"
 )
 
 var (
-	// MevRelayEndpoint provides an HTTP access endpoint to a MEV builder network.
+	// MevRelayEndpoint provides HTTP access endpoints to a MEV builder network.
 	MevRelayEndpoint = &cli.StringFlag{
-		Name:  "http-mev-relay",
-		Usage: "A MEV builder relay string http endpoint, this wil be used to interact MEV builder network using API defined in: https://ethereum.github.io/builder-specs/#/Builder",
+		Name: "http-mev-relay",
+		Usage: "A MEV builder relay string http endpoint, this wil be used to interact MEV builder network using API defined in: https://ethereum.github.io/builder-specs/#/Builder. " +
+			"Multiple relays can be given as a comma separated list, in which case the highest valid bid among all relays is used.",
 		Value: "",
 	}
+	// MevRelayGetHeaderTimeout sets the time each relay is given to respond with a bid.
+	MevRelayGetHeaderTimeout = &cli.DurationFlag{
+		Name:  "http-mev-relay-get-header-timeout",
+		Usage: "Time each relay configured with --http-mev-relay is given to respond with a bid",
+		Value: time.Second,
+	}
 	MaxBuilderConsecutiveMissedSlots = &cli.IntFlag{
 		Name:  "max-builder-consecutive-missed-slots",
 		Usage: "Number of consecutive skip slot to fallback from using relay/builder to local execution engine for block construction",
@@ -30,6 +39,12 @@ var (
 		Usage: "A percentage boost for local block construction. This is used to prioritize local block construction over relay/builder block construction" +
 			"Boost is an additional percentage to multiple local block value. Use builder block if: builder_bid_value * 100 > local_block_value * (local-block-value-boost + 100)",
 	}
+	BuilderBoostFactor = &cli.Uint64Flag{
+		Name: "builder-boost-factor",
+		Usage: "A percentage multiplier applied to the builder payload value when choosing between local and builder block construction. " +
+			"0 always builds locally, 18446744073709551615 always prefers the builder. Can be overridden per request with the builder_boost_factor query parameter of the block production endpoint",
+		Value: 100,
+	}
 	// ExecutionEngineEndpoint provides an HTTP access endpoint to connect to an execution client on the execution layer
 	ExecutionEngineEndpoint = &cli.StringFlag{
 		Name:  "execution-endpoint",
@@ -164,6 +179,53 @@ var (
 		Usage: "The factor by which block batch limit may increase on burst.",
 		Value: 2,
 	}
+	// BlobBatchLimit specifies the requested blob batch size.
+	BlobBatchLimit = &cli.IntFlag{
+		Name:  "blob-batch-limit",
+		Usage: "The amount of blobs the local peer is bounded to request and respond to in a batch.",
+		Value: 64,
+	}
+	// BlobBatchLimitBurstFactor specifies the factor by which blob batch size may increase.
+	BlobBatchLimitBurstFactor = &cli.IntFlag{
+		Name:  "blob-batch-limit-burst-factor",
+		Usage: "The factor by which blob batch limit may increase on burst.",
+		Value: 2,
+	}
+	// RateLimitTrustedFactor specifies the factor by which the rate limiting quotas of trusted peers are increased.
+	RateLimitTrustedFactor = &cli.IntFlag{
+		Name:  "rate-limit-trusted-factor",
+		Usage: "The factor by which the req/resp rate limiting quotas of trusted peers are multiplied. Static peers, members of peer groups and the peers of --rate-limit-allowlist are trusted.",
+		Value: 4,
+	}
+	// RateLimitAllowlist specifies the peer IDs which are granted the rate limiting quotas of trusted peers.
+	RateLimitAllowlist = &cli.StringSliceFlag{
+		Name:  "rate-limit-allowlist",
+		Usage: "Comma-separated list of peer IDs which are granted the req/resp rate limiting quotas of trusted peers.",
+	}
+	// GossipCaptureDir specifies the directory of the gossip capture, which is disabled when unset.
+	GossipCaptureDir = &cli.StringFlag{
+		Name:  "gossip-capture-dir",
+		Usage: "Directory to which every gossip message received or published by the node is written, along with its topic, peer, arrival time and validation result. The capture can be replayed with prysmctl. Disabled when unset.",
+	}
+	// GossipCaptureMaxFileSize specifies the size of the gossip capture files, in megabytes.
+	GossipCaptureMaxFileSize = &cli.Uint64Flag{
+		Name:  "gossip-capture-max-file-size",
+		Usage: "The size in megabytes beyond which a new gossip capture file is started.",
+		Value: 100,
+	}
+	// GossipCaptureMaxFiles specifies the number of gossip capture files which are kept.
+	GossipCaptureMaxFiles = &cli.IntFlag{
+		Name:  "gossip-capture-max-files",
+		Usage: "The number of gossip capture files to keep. The oldest files are deleted.",
+		Value: 10,
+	}
+	// EnableGossipReplay enables the replay of gossip messages through the debug endpoints.
+	EnableGossipReplay = &cli.BoolFlag{
+		Name: "enable-gossip-replay",
+		Usage: "Enables the replay of gossip captures through the debug endpoints, which also requires --enable-debug-rpc-endpoints. " +
+			"Replayed messages update the seen caches, pending queues and bad blocks of the node like gossip does, so this " +
+			"must only be enabled on a throwaway node which neither validates nor serves other nodes.",
+	}
 	// EnableDebugRPCEndpoints as /v1/beacon/state.
 	EnableDebugRPCEndpoints = &cli.BoolFlag{
 		Name:  "enable-debug-rpc-endpoints",
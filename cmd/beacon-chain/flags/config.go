@@ -13,6 +13,9 @@ type GlobalFlags struct {
 	MinimumPeersPerSubnet      int
 	BlockBatchLimit            int
 	BlockBatchLimitBurstFactor int
+	BlobBatchLimit             int
+	BlobBatchLimitBurstFactor  int
+	RateLimitTrustedFactor     int
 }
 
 var globalConfig *GlobalFlags
@@ -40,6 +43,9 @@ func ConfigureGlobalFlags(ctx *cli.Context) {
 	}
 	cfg.BlockBatchLimit = ctx.Int(BlockBatchLimit.Name)
 	cfg.BlockBatchLimitBurstFactor = ctx.Int(BlockBatchLimitBurstFactor.Name)
+	cfg.BlobBatchLimit = ctx.Int(BlobBatchLimit.Name)
+	cfg.BlobBatchLimitBurstFactor = ctx.Int(BlobBatchLimitBurstFactor.Name)
+	cfg.RateLimitTrustedFactor = ctx.Int(RateLimitTrustedFactor.Name)
 	cfg.MinimumPeersPerSubnet = ctx.Int(MinPeersPerSubnet.Name)
 	configureMinimumPeers(ctx, cfg)
 
@@ -15,9 +15,11 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/cmd"
 	blockchaincmd "github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/blockchain"
 	dbcommands "github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/db/pruner"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
 	jwtcommands "github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/jwt"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/sync/backfill"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/sync/checkpoint"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/sync/genesis"
 	"github.com/prysmaticlabs/prysm/v4/config/features"
@@ -53,6 +55,14 @@ var appFlags = []cli.Flag{
 	flags.SetGCPercent,
 	flags.BlockBatchLimit,
 	flags.BlockBatchLimitBurstFactor,
+	flags.BlobBatchLimit,
+	flags.BlobBatchLimitBurstFactor,
+	flags.RateLimitTrustedFactor,
+	flags.RateLimitAllowlist,
+	flags.GossipCaptureDir,
+	flags.GossipCaptureMaxFileSize,
+	flags.GossipCaptureMaxFiles,
+	flags.EnableGossipReplay,
 	flags.InteropMockEth1DataVotesFlag,
 	flags.InteropNumValidatorsFlag,
 	flags.InteropGenesisTimeFlag,
@@ -71,8 +81,10 @@ var appFlags = []cli.Flag{
 	flags.TerminalBlockHashOverride,
 	flags.TerminalBlockHashActivationEpochOverride,
 	flags.MevRelayEndpoint,
+	flags.MevRelayGetHeaderTimeout,
 	flags.MaxBuilderEpochMissedSlots,
 	flags.MaxBuilderConsecutiveMissedSlots,
+	flags.BuilderBoostFactor,
 	flags.EngineEndpointTimeoutSeconds,
 	cmd.BackupWebhookOutputDir,
 	cmd.MinimalConfigFlag,
@@ -81,9 +93,11 @@ var appFlags = []cli.Flag{
 	cmd.BootstrapNode,
 	cmd.NoDiscovery,
 	cmd.StaticPeers,
+	cmd.PeerGroups,
 	cmd.RelayNode,
 	cmd.P2PUDPPort,
 	cmd.P2PTCPPort,
+	cmd.P2PQUICPort,
 	cmd.P2PIP,
 	cmd.P2PHost,
 	cmd.P2PHostDNS,
@@ -97,6 +111,7 @@ var appFlags = []cli.Flag{
 	cmd.VerbosityFlag,
 	cmd.EnableTracingFlag,
 	cmd.TracingProcessNameFlag,
+	cmd.TracingExporterFlag,
 	cmd.TracingEndpointFlag,
 	cmd.TraceSampleFractionFlag,
 	cmd.MonitoringHostFlag,
@@ -123,12 +138,19 @@ var appFlags = []cli.Flag{
 	cmd.RestoreSourceFileFlag,
 	cmd.RestoreTargetDirFlag,
 	cmd.ValidatorMonitorIndicesFlag,
+	cmd.EnableValidatorMonitorFlag,
+	cmd.ValidatorMonitorProposerPreparationsFlag,
 	cmd.ApiTimeoutFlag,
 	checkpoint.BlockPath,
 	checkpoint.StatePath,
 	checkpoint.RemoteURL,
 	genesis.StatePath,
 	genesis.BeaconAPIURL,
+	backfill.EnableExperimentalBackfill,
+	backfill.BackfillBatchSize,
+	pruner.MinimalHistory,
+	pruner.HistoryRetentionEpochs,
+	pruner.BlobRetentionEpochs,
 	flags.SlasherDirFlag,
 }
 
@@ -275,6 +297,8 @@ func startNode(ctx *cli.Context) error {
 	optFuncs := []func(*cli.Context) (node.Option, error){
 		genesis.BeaconNodeOptions,
 		checkpoint.BeaconNodeOptions,
+		backfill.BeaconNodeOptions,
+		pruner.BeaconNodeOptions,
 	}
 	for _, of := range optFuncs {
 		ofo, err := of(ctx)
@@ -0,0 +1,33 @@
+package backfill
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/node"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/backfill"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// EnableExperimentalBackfill enables backfilling of the block history between genesis and the checkpoint
+	// sync origin.
+	EnableExperimentalBackfill = &cli.BoolFlag{
+		Name: "enable-experimental-backfill",
+		Usage: "Backfill the block history from the checkpoint sync origin down to genesis, so that the node can " +
+			"serve historical blocks. Has no effect on nodes synced from genesis.",
+	}
+	// BackfillBatchSize sets the number of slots requested from a peer in a single backfill request.
+	BackfillBatchSize = &cli.Uint64Flag{
+		Name:  "backfill-batch-size",
+		Usage: "Number of slots to request from a peer in each backfill BeaconBlocksByRange request.",
+		Value: 64,
+	}
+)
+
+// BeaconNodeOptions sets the appropriate functional opts on the *node.BeaconNode value, to decouple options
+// from flag parsing.
+func BeaconNodeOptions(c *cli.Context) (node.Option, error) {
+	opts := []backfill.ServiceOption{
+		backfill.WithEnableBackfill(c.Bool(EnableExperimentalBackfill.Name)),
+		backfill.WithBatchSize(c.Uint64(BackfillBatchSize.Name)),
+	}
+	return node.WithBackfillOptions(opts), nil
+}
@@ -6,7 +6,9 @@ import (
 	"sort"
 
 	"github.com/prysmaticlabs/prysm/v4/cmd"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/db/pruner"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/sync/backfill"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/sync/checkpoint"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/sync/genesis"
 	"github.com/prysmaticlabs/prysm/v4/config/features"
@@ -54,10 +56,12 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.RelayNode,
 			cmd.P2PUDPPort,
 			cmd.P2PTCPPort,
+			cmd.P2PQUICPort,
 			cmd.DataDirFlag,
 			cmd.VerbosityFlag,
 			cmd.EnableTracingFlag,
 			cmd.TracingProcessNameFlag,
+			cmd.TracingExporterFlag,
 			cmd.TracingEndpointFlag,
 			cmd.TraceSampleFractionFlag,
 			cmd.MonitoringHostFlag,
@@ -74,6 +78,8 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.RestoreSourceFileFlag,
 			cmd.RestoreTargetDirFlag,
 			cmd.ValidatorMonitorIndicesFlag,
+			cmd.EnableValidatorMonitorFlag,
+			cmd.ValidatorMonitorProposerPreparationsFlag,
 			cmd.ApiTimeoutFlag,
 		},
 	},
@@ -112,6 +118,14 @@ var appHelpFlagGroups = []flagGroup{
 			flags.SlotsPerArchivedPoint,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
+			flags.BlobBatchLimit,
+			flags.BlobBatchLimitBurstFactor,
+			flags.RateLimitTrustedFactor,
+			flags.RateLimitAllowlist,
+			flags.GossipCaptureDir,
+			flags.GossipCaptureMaxFileSize,
+			flags.GossipCaptureMaxFiles,
+			flags.EnableGossipReplay,
 			flags.EnableDebugRPCEndpoints,
 			flags.EnableRegistrationCache,
 			flags.SubscribeToAllSubnets,
@@ -122,8 +136,10 @@ var appHelpFlagGroups = []flagGroup{
 			flags.Eth1HeaderReqLimit,
 			flags.MinPeersPerSubnet,
 			flags.MevRelayEndpoint,
+			flags.MevRelayGetHeaderTimeout,
 			flags.MaxBuilderEpochMissedSlots,
 			flags.MaxBuilderConsecutiveMissedSlots,
+			flags.BuilderBoostFactor,
 			flags.EngineEndpointTimeoutSeconds,
 			flags.SlasherDirFlag,
 			checkpoint.BlockPath,
@@ -131,6 +147,11 @@ var appHelpFlagGroups = []flagGroup{
 			checkpoint.RemoteURL,
 			genesis.StatePath,
 			genesis.BeaconAPIURL,
+			backfill.EnableExperimentalBackfill,
+			backfill.BackfillBatchSize,
+			pruner.MinimalHistory,
+			pruner.HistoryRetentionEpochs,
+			pruner.BlobRetentionEpochs,
 		},
 	},
 	{
@@ -155,6 +176,7 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.P2PAllowList,
 			cmd.P2PDenyList,
 			cmd.StaticPeers,
+			cmd.PeerGroups,
 			cmd.EnableUPnPFlag,
 			flags.MinSyncPeers,
 		},
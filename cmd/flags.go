@@ -60,11 +60,17 @@ var (
 		Name:  "tracing-process-name",
 		Usage: "The name to apply to tracing tag \"process_name\"",
 	}
-	// TracingEndpointFlag flag defines the http endpoint for serving traces to Jaeger.
+	// TracingExporterFlag defines the exporter used to send traces.
+	TracingExporterFlag = &cli.StringFlag{
+		Name:  "tracing-exporter",
+		Usage: "Exporter used to send traces, one of jaeger, otlp-grpc or otlp-http.",
+		Value: "jaeger",
+	}
+	// TracingEndpointFlag flag defines the endpoint of the collector traces are sent to.
 	TracingEndpointFlag = &cli.StringFlag{
-		Name:  "tracing-endpoint",
-		Usage: "Tracing endpoint defines where beacon chain traces are exposed to Jaeger.",
-		Value: "http://127.0.0.1:14268/api/traces",
+		Name: "tracing-endpoint",
+		Usage: "Tracing endpoint defines where traces are sent to by the tracing exporter. Defaults to " +
+			"http://127.0.0.1:14268/api/traces for jaeger, http://127.0.0.1:4317 for otlp-grpc and http://127.0.0.1:4318 for otlp-http.",
 	}
 	// TraceSampleFractionFlag defines a flag to indicate what fraction of p2p
 	// messages are sampled for tracing.
@@ -95,6 +101,11 @@ var (
 		Name:  "peer",
 		Usage: "Connect with this peer. This flag may be used multiple times.",
 	}
+	// PeerGroups specifies named groups of peers to stay connected to.
+	PeerGroups = &cli.StringSliceFlag{
+		Name:  "peer-group",
+		Usage: "Add a peer to a named peer group, in the form name=address. Members of peer groups have reserved connection slots, are never pruned and are reconnected when disconnected. This flag may be used multiple times.",
+	}
 	// BootstrapNode tells the beacon node which bootstrap node to connect to
 	BootstrapNode = &cli.StringSliceFlag{
 		Name:  "bootstrap-node",
@@ -120,6 +131,12 @@ var (
 		Usage: "The port used by libp2p.",
 		Value: 13000,
 	}
+	// P2PQUICPort defines the port to be used by the QUIC transport of libp2p.
+	P2PQUICPort = &cli.IntFlag{
+		Name:  "p2p-quic-port",
+		Usage: "The UDP port used by the QUIC transport of libp2p, when enabled with --enable-quic.",
+		Value: 13000,
+	}
 	// P2PIP defines the local IP to be used by libp2p.
 	P2PIP = &cli.StringFlag{
 		Name:  "p2p-local-ip",
@@ -240,6 +257,18 @@ var (
 		Name:  "monitor-indices",
 		Usage: "List of validator indices to track performance",
 	}
+	// EnableValidatorMonitorFlag starts the validator monitor without tracked validators,
+	// so that they can be added at runtime through the API.
+	EnableValidatorMonitorFlag = &cli.BoolFlag{
+		Name:  "enable-validator-monitor",
+		Usage: "Starts the validator monitor even if no --monitor-indices are given, so that validators can be tracked at runtime through the API",
+	}
+	// ValidatorMonitorProposerPreparationsFlag automatically tracks every validator
+	// submitting a proposer preparation.
+	ValidatorMonitorProposerPreparationsFlag = &cli.BoolFlag{
+		Name:  "monitor-proposer-preparations",
+		Usage: "Tracks the performance of every validator that submits a proposer preparation to this beacon node",
+	}
 
 	// RestoreSourceFileFlag specifies the filepath to the backed-up database file
 	// which will be used to restore the database.
@@ -0,0 +1,201 @@
+package db
+
+import (
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/era"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/genesis"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var eraFlags = struct {
+	Path            string
+	EraDir          string
+	From            uint64
+	To              uint64
+	ChainConfigFile string
+	Checkpoint      string
+	ValidatorsRoot  string
+}{}
+
+var eraPathFlag = &cli.StringFlag{
+	Name:        "path",
+	Usage:       "path to directory containing beaconchain.db",
+	Destination: &eraFlags.Path,
+	Required:    true,
+}
+
+var eraDirFlag = &cli.StringFlag{
+	Name:        "era-dir",
+	Usage:       "directory containing the .era files",
+	Destination: &eraFlags.EraDir,
+	Required:    true,
+}
+
+var eraChainConfigFlag = &cli.StringFlag{
+	Name:        "chain-config-file",
+	Usage:       "path to a chain config yaml file, for networks other than mainnet",
+	Destination: &eraFlags.ChainConfigFile,
+}
+
+var eraCheckpointFlag = &cli.StringFlag{
+	Name: "weak-subjectivity-checkpoint",
+	Usage: "trusted checkpoint, in block_root:epoch_number format, which the last imported era state must match. " +
+		"It is the checkpoint at the start of the epoch of the last era state",
+	Destination: &eraFlags.Checkpoint,
+	Required:    true,
+}
+
+var eraValidatorsRootFlag = &cli.StringFlag{
+	Name: "genesis-validators-root",
+	Usage: "hex encoded genesis validators root of the network, required for networks whose genesis state " +
+		"is not embedded in the binary",
+	Destination: &eraFlags.ValidatorsRoot,
+}
+
+var eraCmd = &cli.Command{
+	Name:  "era",
+	Usage: "export and import finalized history as .era files",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "export",
+			Usage: "write the finalized blocks and states of a stopped beacon node to .era files",
+			Action: func(cliCtx *cli.Context) error {
+				if err := eraExportAction(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not export era files")
+				}
+				return nil
+			},
+			Flags: []cli.Flag{
+				eraPathFlag,
+				eraDirFlag,
+				eraChainConfigFlag,
+				&cli.Uint64Flag{
+					Name:        "from",
+					Usage:       "first era to export",
+					Destination: &eraFlags.From,
+				},
+				&cli.Uint64Flag{
+					Name:        "to",
+					Usage:       "last era to export, defaults to the last finalized era",
+					Destination: &eraFlags.To,
+					Value:       ^uint64(0),
+				},
+			},
+		},
+		{
+			Name:  "import",
+			Usage: "seed a new beacon node database from .era files, starting from era 0",
+			Action: func(cliCtx *cli.Context) error {
+				if err := eraImportAction(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not import era files")
+				}
+				return nil
+			},
+			Flags: []cli.Flag{
+				eraPathFlag,
+				eraDirFlag,
+				eraChainConfigFlag,
+				eraCheckpointFlag,
+				eraValidatorsRootFlag,
+			},
+		},
+	},
+}
+
+func eraExportAction(cliCtx *cli.Context) error {
+	if err := loadEraChainConfig(); err != nil {
+		return err
+	}
+	d, err := kv.NewKVStore(cliCtx.Context, eraFlags.Path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	paths, err := era.Export(cliCtx.Context, d, eraFlags.EraDir, eraFlags.From, eraFlags.To)
+	if err != nil {
+		return err
+	}
+	log.Infof("Exported %d era files to %s", len(paths), eraFlags.EraDir)
+	return nil
+}
+
+func eraImportAction(cliCtx *cli.Context) error {
+	if err := loadEraChainConfig(); err != nil {
+		return err
+	}
+	paths, err := filepath.Glob(filepath.Join(eraFlags.EraDir, "*.era"))
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return errors.Errorf("no .era files found in %s", eraFlags.EraDir)
+	}
+	trust, err := eraTrust()
+	if err != nil {
+		return err
+	}
+	d, err := kv.NewKVStore(cliCtx.Context, eraFlags.Path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	cp, err := era.Import(cliCtx.Context, d, paths, trust)
+	if err != nil {
+		return err
+	}
+	log.Infof("Imported %d era files, finalized checkpoint is epoch %d root %#x", len(paths), cp.Epoch, cp.Root)
+	return nil
+}
+
+func loadEraChainConfig() error {
+	if eraFlags.ChainConfigFile == "" {
+		return nil
+	}
+	return params.LoadChainConfigFile(eraFlags.ChainConfigFile, nil)
+}
+
+// eraTrust returns the trusted checkpoint and genesis validators root which imported era files are verified
+// against. The genesis validators root defaults to the one of the genesis state embedded for the network.
+func eraTrust() (era.Trust, error) {
+	cp, err := helpers.ParseWeakSubjectivityInputString(eraFlags.Checkpoint)
+	if err != nil {
+		return era.Trust{}, errors.Wrap(err, "could not parse weak subjectivity checkpoint")
+	}
+	trust := era.Trust{Checkpoint: cp}
+	if eraFlags.ValidatorsRoot != "" {
+		root, err := hexutil.Decode(eraFlags.ValidatorsRoot)
+		if err != nil {
+			return era.Trust{}, errors.Wrap(err, "could not decode genesis validators root")
+		}
+		if len(root) != 32 {
+			return era.Trust{}, errors.Errorf("genesis validators root has %d bytes, expected 32", len(root))
+		}
+		trust.GenesisValidatorsRoot = bytesutil.ToBytes32(root)
+		return trust, nil
+	}
+	st, err := genesis.State(params.BeaconConfig().ConfigName)
+	if err != nil {
+		return era.Trust{}, errors.Wrap(err, "could not load embedded genesis state")
+	}
+	if st == nil {
+		return era.Trust{}, errors.Errorf("no genesis state is embedded for network %s, the genesis validators root must be provided", params.BeaconConfig().ConfigName)
+	}
+	trust.GenesisValidatorsRoot = bytesutil.ToBytes32(st.GenesisValidatorsRoot())
+	return trust, nil
+}
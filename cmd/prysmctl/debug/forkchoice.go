@@ -0,0 +1,91 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var forkChoiceFlags = struct {
+	BeaconNodeHost string
+	Timeout        time.Duration
+	Format         string
+	Output         string
+}{}
+
+var forkChoiceCmd = &cli.Command{
+	Name:    "fork-choice",
+	Aliases: []string{"forkchoice", "fc"},
+	Usage:   "Export the live fork choice store of a beacon node as a Graphviz DOT document or as a JSON dump. The beacon node must run with --enable-debug-rpc-endpoints.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := cliActionForkChoice(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not export fork choice")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port for beacon node to query",
+			Destination: &forkChoiceFlags.BeaconNodeHost,
+			Value:       "http://localhost:3500",
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-url (uses duration format, ex: 2m31s). default: 2m",
+			Destination: &forkChoiceFlags.Timeout,
+			Value:       time.Minute * 2,
+		},
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "output format, one of: dot, json",
+			Destination: &forkChoiceFlags.Format,
+			Value:       "dot",
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Usage:       "file to write the export to. The export is printed to stdout when unset",
+			Destination: &forkChoiceFlags.Output,
+		},
+	},
+}
+
+func cliActionForkChoice(_ *cli.Context) error {
+	ctx := context.Background()
+	f := forkChoiceFlags
+
+	var dot bool
+	switch f.Format {
+	case "dot":
+		dot = true
+	case "json":
+	default:
+		return fmt.Errorf("unsupported format %q, expected one of: dot, json", f.Format)
+	}
+
+	client, err := beacon.NewClient(f.BeaconNodeHost, beacon.WithTimeout(f.Timeout))
+	if err != nil {
+		return err
+	}
+	b, err := client.GetForkChoiceDiagnostics(ctx, dot)
+	if err != nil {
+		return err
+	}
+
+	if f.Output == "" {
+		_, err = os.Stdout.Write(b)
+		return err
+	}
+	if err := file.WriteFile(f.Output, b); err != nil {
+		return errors.Wrapf(err, "could not write fork choice export to %s", f.Output)
+	}
+	log.Infof("Fork choice export written to %s", f.Output)
+	return nil
+}
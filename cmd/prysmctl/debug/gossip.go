@@ -0,0 +1,188 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var replayGossipFlags = struct {
+	BeaconNodeHost string
+	Timeout        time.Duration
+	Capture        string
+	Topic          string
+	From           string
+	To             string
+}{}
+
+var replayGossipCmd = &cli.Command{
+	Name:  "replay-gossip",
+	Usage: "Replay a gossip capture, written by a beacon node running with --gossip-capture-dir, into the gossip validation pipeline of a beacon node, and compare the validation results. The beacon node must run with --enable-debug-rpc-endpoints and --enable-gossip-replay: replayed messages alter its state like gossip does, so only replay into a throwaway node.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := cliActionReplayGossip(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not replay gossip capture")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port for beacon node to query",
+			Destination: &replayGossipFlags.BeaconNodeHost,
+			Value:       "http://localhost:3500",
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-url (uses duration format, ex: 2m31s). default: 2m",
+			Destination: &replayGossipFlags.Timeout,
+			Value:       time.Minute * 2,
+		},
+		&cli.StringFlag{
+			Name:        "capture",
+			Usage:       "gossip capture file, or directory of gossip capture files to replay from the oldest to the newest",
+			Destination: &replayGossipFlags.Capture,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "topic",
+			Usage:       "only replay the messages whose topic contains this string, ex: beacon_block",
+			Destination: &replayGossipFlags.Topic,
+		},
+		&cli.StringFlag{
+			Name:        "from",
+			Usage:       "only replay the messages which arrived at or after this RFC 3339 time",
+			Destination: &replayGossipFlags.From,
+		},
+		&cli.StringFlag{
+			Name:        "to",
+			Usage:       "only replay the messages which arrived before this RFC 3339 time",
+			Destination: &replayGossipFlags.To,
+		},
+	},
+}
+
+// replayTopicSummary counts the replayed messages of a topic whose validation result matches the captured result.
+type replayTopicSummary struct {
+	replayed   int
+	matching   int
+	mismatched int
+}
+
+func cliActionReplayGossip(_ *cli.Context) error {
+	ctx := context.Background()
+	f := replayGossipFlags
+
+	var from, to time.Time
+	var err error
+	if f.From != "" {
+		if from, err = time.Parse(time.RFC3339, f.From); err != nil {
+			return errors.Wrap(err, "invalid --from time")
+		}
+	}
+	if f.To != "" {
+		if to, err = time.Parse(time.RFC3339, f.To); err != nil {
+			return errors.Wrap(err, "invalid --to time")
+		}
+	}
+	files, err := captureFiles(f.Capture)
+	if err != nil {
+		return err
+	}
+	client, err := beacon.NewClient(f.BeaconNodeHost, beacon.WithTimeout(f.Timeout))
+	if err != nil {
+		return err
+	}
+
+	summaries := make(map[string]*replayTopicSummary)
+	replay := func(r *p2p.GossipCaptureRecord) error {
+		if len(r.Data) == 0 || (f.Topic != "" && !strings.Contains(r.Topic, f.Topic)) {
+			return nil
+		}
+		if (!from.IsZero() && r.Arrival.Before(from)) || (!to.IsZero() && !r.Arrival.Before(to)) {
+			return nil
+		}
+		res, err := client.ReplayGossipMessage(ctx, r.Topic, r.Peer, r.Data)
+		if err != nil {
+			return errors.Wrapf(err, "could not replay message %s", r.MessageID)
+		}
+		summary, ok := summaries[r.Topic]
+		if !ok {
+			summary = &replayTopicSummary{}
+			summaries[r.Topic] = summary
+		}
+		summary.replayed++
+		if res.Result == r.Result {
+			summary.matching++
+			return nil
+		}
+		summary.mismatched++
+		log.WithFields(log.Fields{
+			"topic":          r.Topic,
+			"messageID":      r.MessageID,
+			"peer":           r.Peer,
+			"arrival":        r.Arrival,
+			"capturedResult": r.Result,
+			"replayedResult": res.Result,
+			"error":          res.Error,
+		}).Warn("Validation result of replayed message differs from the capture")
+		return nil
+	}
+	for _, name := range files {
+		if err := replayCaptureFile(name, replay); err != nil {
+			return err
+		}
+	}
+
+	topics := make([]string, 0, len(summaries))
+	for topic := range summaries {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	for _, topic := range topics {
+		s := summaries[topic]
+		fmt.Printf("%s: replayed=%d matching=%d mismatched=%d\n", topic, s.replayed, s.matching, s.mismatched)
+	}
+	return nil
+}
+
+// Returns the capture file, or the capture files of the directory from the oldest to the newest.
+func captureFiles(capture string) ([]string, error) {
+	info, err := os.Stat(capture)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not open gossip capture %s", capture)
+	}
+	if !info.IsDir() {
+		return []string{capture}, nil
+	}
+	files, err := p2p.GossipCaptureFiles(capture)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no gossip capture files in %s", capture)
+	}
+	return files, nil
+}
+
+func replayCaptureFile(name string, fn func(*p2p.GossipCaptureRecord) error) (err error) {
+	file, err := os.Open(name) // #nosec G304
+	if err != nil {
+		return errors.Wrapf(err, "could not open gossip capture file %s", name)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	log.WithField("file", name).Info("Replaying gossip capture file")
+	return p2p.ReadGossipCapture(file, fn)
+}
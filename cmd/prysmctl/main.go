@@ -5,6 +5,7 @@ import (
 
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/checkpointsync"
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/db"
+	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/debug"
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/deprecated"
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/p2p"
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/testnet"
@@ -33,6 +34,7 @@ func init() {
 
 	prysmctlCommands = append(prysmctlCommands, checkpointsync.Commands...)
 	prysmctlCommands = append(prysmctlCommands, db.Commands...)
+	prysmctlCommands = append(prysmctlCommands, debug.Commands...)
 	prysmctlCommands = append(prysmctlCommands, p2p.Commands...)
 	prysmctlCommands = append(prysmctlCommands, testnet.Commands...)
 	prysmctlCommands = append(prysmctlCommands, weaksubjectivity.Commands...)
@@ -132,6 +132,7 @@ var Commands = []*cli.Command{
 					return nil
 				},
 			},
+			slashingProtectionCmd,
 		},
 	},
 }
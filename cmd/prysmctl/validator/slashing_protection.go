@@ -0,0 +1,95 @@
+package validator
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	slashingprotection "github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-history"
+	"github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-history/format"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var mergeSlashingProtectionFlags = struct {
+	Inputs *cli.StringSlice
+	Output string
+}{
+	Inputs: cli.NewStringSlice(),
+}
+
+var slashingProtectionCmd = &cli.Command{
+	Name:  "slashing-protection",
+	Usage: "commands to manipulate EIP-3076 slashing protection interchange files",
+	Subcommands: []*cli.Command{
+		{
+			Name: "merge",
+			Usage: "Merges several EIP-3076 slashing protection files into a single one. Keys with a slashable " +
+				"history across the files are reported and only their highest signed slot and epochs are kept.",
+			Flags: []cli.Flag{
+				&cli.StringSliceFlag{
+					Name:        "input",
+					Aliases:     []string{"i"},
+					Usage:       "path to a slashing protection JSON file to merge, can be repeated",
+					Destination: mergeSlashingProtectionFlags.Inputs,
+					Required:    true,
+				},
+				&cli.StringFlag{
+					Name:        "output",
+					Aliases:     []string{"o"},
+					Usage:       "path to write the merged slashing protection JSON file to",
+					Destination: &mergeSlashingProtectionFlags.Output,
+					Required:    true,
+				},
+			},
+			Action: func(cliCtx *cli.Context) error {
+				if err := mergeSlashingProtection(
+					mergeSlashingProtectionFlags.Inputs.Value(),
+					mergeSlashingProtectionFlags.Output,
+				); err != nil {
+					log.WithError(err).Fatal("Could not merge slashing protection files")
+				}
+				return nil
+			},
+		},
+	},
+}
+
+func mergeSlashingProtection(inputs []string, output string) error {
+	if len(inputs) < 2 {
+		return errors.New("at least two slashing protection files are needed to merge")
+	}
+	files := make([]*format.EIPSlashingProtectionFormat, len(inputs))
+	for i, input := range inputs {
+		enc, err := file.ReadFileAsBytes(input)
+		if err != nil {
+			return errors.Wrapf(err, "could not read slashing protection file %s", input)
+		}
+		files[i] = &format.EIPSlashingProtectionFormat{}
+		if err := json.Unmarshal(enc, files[i]); err != nil {
+			return errors.Wrapf(err, "could not unmarshal slashing protection file %s", input)
+		}
+	}
+	merged, conflicts, err := slashingprotection.MergeStandardProtectionJSON(files...)
+	if err != nil {
+		return err
+	}
+	for _, conflict := range conflicts {
+		log.WithField("pubkey", conflict.Pubkey).Warnf(
+			"Slashable history found while merging, only keeping the highest signed slot and epochs: %s",
+			conflict.Reason,
+		)
+	}
+	encoded, err := json.MarshalIndent(merged, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "could not JSON marshal merged slashing protection history")
+	}
+	if err := file.WriteFile(output, encoded); err != nil {
+		return errors.Wrapf(err, "could not write file to path %s", output)
+	}
+	log.WithFields(log.Fields{
+		"keys":      len(merged.Data),
+		"conflicts": len(conflicts),
+	}).Infof("Wrote merged slashing protection file to %s", output)
+	return nil
+}
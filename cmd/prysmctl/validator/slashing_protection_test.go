@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-history/format"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func writeInterchangeFile(t *testing.T, path string, data ...*format.ProtectionData) {
+	f := &format.EIPSlashingProtectionFormat{Data: data}
+	f.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	f.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", [32]byte{1})
+	enc, err := json.Marshal(f)
+	require.NoError(t, err)
+	require.NoError(t, file.WriteFile(path, enc))
+}
+
+func TestMergeSlashingProtection(t *testing.T) {
+	hook := logtest.NewGlobal()
+	dir := t.TempDir()
+	pubKey1 := fmt.Sprintf("%#x", [fieldparams.BLSPubkeyLength]byte{1})
+	pubKey2 := fmt.Sprintf("%#x", [fieldparams.BLSPubkeyLength]byte{2})
+	first := filepath.Join(dir, "first.json")
+	second := filepath.Join(dir, "second.json")
+	output := filepath.Join(dir, "merged.json")
+	writeInterchangeFile(t, first, &format.ProtectionData{
+		Pubkey:             pubKey1,
+		SignedBlocks:       []*format.SignedBlock{{Slot: "1", SigningRoot: fmt.Sprintf("%#x", [32]byte{1})}},
+		SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "1", TargetEpoch: "2"}},
+	})
+	writeInterchangeFile(t, second, &format.ProtectionData{
+		Pubkey:             pubKey1,
+		SignedBlocks:       []*format.SignedBlock{{Slot: "1", SigningRoot: fmt.Sprintf("%#x", [32]byte{2})}},
+		SignedAttestations: []*format.SignedAttestation{},
+	}, &format.ProtectionData{
+		Pubkey:             pubKey2,
+		SignedBlocks:       []*format.SignedBlock{{Slot: "3"}},
+		SignedAttestations: []*format.SignedAttestation{},
+	})
+
+	require.ErrorContains(t, "at least two slashing protection files", mergeSlashingProtection([]string{first}, output))
+	require.NoError(t, mergeSlashingProtection([]string{first, second}, output))
+	assert.LogsContain(t, hook, "different blocks signed at slot 1")
+
+	enc, err := file.ReadFileAsBytes(output)
+	require.NoError(t, err)
+	merged := &format.EIPSlashingProtectionFormat{}
+	require.NoError(t, json.Unmarshal(enc, merged))
+	require.Equal(t, 2, len(merged.Data))
+	assert.Equal(t, pubKey1, merged.Data[0].Pubkey)
+	assert.DeepEqual(t, []*format.SignedBlock{{Slot: "1"}}, merged.Data[0].SignedBlocks)
+	assert.DeepEqual(t, []*format.SignedAttestation{{SourceEpoch: "1", TargetEpoch: "2"}}, merged.Data[0].SignedAttestations)
+	assert.Equal(t, pubKey2, merged.Data[1].Pubkey)
+}
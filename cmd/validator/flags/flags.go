@@ -30,8 +30,9 @@ var (
 	}
 	// BeaconRPCProviderFlag defines a beacon node RPC endpoint.
 	BeaconRPCProviderFlag = &cli.StringFlag{
-		Name:  "beacon-rpc-provider",
-		Usage: "Beacon node RPC provider endpoint",
+		Name: "beacon-rpc-provider",
+		Usage: "Beacon node RPC provider endpoint. Multiple comma-separated endpoints can be given, in which case " +
+			"requests go to the healthiest beacon node and signed messages are broadcast to every beacon node",
 		Value: "127.0.0.1:4000",
 	}
 	// BeaconRPCGatewayProviderFlag defines a beacon node JSON-RPC endpoint.
@@ -42,8 +43,9 @@ var (
 	}
 	// BeaconRESTApiProviderFlag defines a beacon node REST API endpoint.
 	BeaconRESTApiProviderFlag = &cli.StringFlag{
-		Name:  "beacon-rest-api-provider",
-		Usage: "Beacon node REST API provider endpoint",
+		Name: "beacon-rest-api-provider",
+		Usage: "Beacon node REST API provider endpoint. Multiple comma-separated endpoints can be given, in which case " +
+			"requests go to the healthiest beacon node and signed messages are broadcast to every beacon node",
 		Value: "http://127.0.0.1:3500",
 	}
 	// CertFlag defines a flag for the node's TLS certificate.
@@ -88,7 +90,7 @@ var (
 	// GraffitiFlag defines the graffiti value included in proposed blocks
 	GraffitiFlag = &cli.StringFlag{
 		Name:  "graffiti",
-		Usage: "String to include in proposed blocks",
+		Usage: "String to include in proposed blocks. May be a template with fields filled in at proposal time (i.e. --graffiti=\"{{cl_code}}{{cl_commit}}{{el_code}}{{el_commit}} #{{index}}\")",
 	}
 	// GrpcRetriesFlag defines the number of times to retry a failed gRPC request.
 	GrpcRetriesFlag = &cli.UintFlag{
@@ -323,15 +325,41 @@ var (
 		Usage: "Allows users to specify the output directory to export their slashing protection EIP-3076 standard JSON File",
 		Value: "",
 	}
+	// SlashingProtectionExportPublicKeysFlag limits the slashing protection history export
+	// to a comma-separated list of validator public keys.
+	SlashingProtectionExportPublicKeysFlag = &cli.StringFlag{
+		Name:  "slashing-protection-export-public-keys",
+		Usage: "Comma-separated list of public key hex strings to only export the slashing protection history of these validators",
+		Value: "",
+	}
+	// SlashingProtectionExportFromEpochFlag limits the slashing protection history export
+	// to the data signed from the given epoch.
+	SlashingProtectionExportFromEpochFlag = &cli.Uint64Flag{
+		Name:  "slashing-protection-export-from-epoch",
+		Usage: "Only exports the blocks and attestations of the slashing protection history signed from this epoch",
+	}
+	// SlashingProtectionExportToEpochFlag limits the slashing protection history export
+	// to the data signed up to the given epoch.
+	SlashingProtectionExportToEpochFlag = &cli.Uint64Flag{
+		Name: "slashing-protection-export-to-epoch",
+		Usage: "Only exports the blocks and attestations of the slashing protection history signed up to this epoch. " +
+			"WARNING: the exported file does not protect against signing again anything signed after this epoch",
+	}
+	// SlashingProtectionExportMinimalFlag exports the slashing protection history in the minimal format.
+	SlashingProtectionExportMinimalFlag = &cli.BoolFlag{
+		Name: "slashing-protection-export-minimal",
+		Usage: "Only exports the highest signed slot and the highest signed source and target epochs of every validator, " +
+			"as described by the minimal format of EIP-3076",
+	}
 	// GraffitiFileFlag specifies the file path to load graffiti values.
 	GraffitiFileFlag = &cli.StringFlag{
 		Name:  "graffiti-file",
-		Usage: "The path to a YAML file with graffiti values",
+		Usage: "The path to a YAML file with graffiti values, which may be templates. The file is reloaded when it changes",
 	}
 	// ProposerSettingsFlag defines the path or URL to a file with proposer config.
 	ProposerSettingsFlag = &cli.StringFlag{
 		Name:  "proposer-settings-file",
-		Usage: "Set path to a YAML or JSON file containing validator settings used when proposing blocks such as (fee recipient and gas limit) (i.e. --proposer-settings-file=/path/to/proposer.json). File format found in docs",
+		Usage: "Set path to a YAML or JSON file containing validator settings used when proposing blocks such as (fee recipient and gas limit) (i.e. --proposer-settings-file=/path/to/proposer.json). File format found in docs. Changes to the file are applied at the next epoch",
 		Value: "",
 	}
 	// ProposerSettingsURLFlag defines the path or URL to a file with proposer config.
@@ -340,6 +368,12 @@ var (
 		Usage: "Set URL to a REST endpoint containing validator settings used when proposing blocks such as (fee recipient) (i.e. --proposer-settings-url=https://example.com/api/getConfig). File format found in docs",
 		Value: "",
 	}
+	// ProposerSettingsURLRefreshIntervalFlag defines how often the proposer settings URL is polled for changes.
+	ProposerSettingsURLRefreshIntervalFlag = &cli.DurationFlag{
+		Name:  "proposer-settings-url-refresh-interval",
+		Usage: "Interval at which the --" + ProposerSettingsURLFlag.Name + " endpoint is polled for changes to the proposer settings, which are applied at the next epoch. Set to 0 to disable",
+		Value: 5 * time.Minute,
+	}
 
 	// SuggestedFeeRecipientFlag defines the address of the fee recipient.
 	SuggestedFeeRecipientFlag = &cli.StringFlag{
@@ -364,6 +398,26 @@ var (
 		Usage: "Sets gas limit for the builder to use for constructing a payload for all the validators",
 		Value: fmt.Sprint(params.BeaconConfig().DefaultBuilderGasLimit),
 	}
+
+	// DoppelGangerEpochsFlag defines the number of epochs a newly loaded key must be seen offline before it performs duties.
+	DoppelGangerEpochsFlag = &cli.Uint64Flag{
+		Name: "doppelganger-detection-epochs",
+		Usage: "The number of epochs during which a newly loaded validator key must not be observed on chain " +
+			"before it starts performing duties. Only used with --enable-doppelganger",
+		Value: 2,
+	}
+	// EnablePerformanceHistoryFlag enables saving the per-epoch performance of the validator keys to the database.
+	EnablePerformanceHistoryFlag = &cli.BoolFlag{
+		Name: "enable-performance-history",
+		Usage: "Saves the outcome of the duties of each validator key at every epoch (attestation correctness, " +
+			"block proposals, sync committee messages and balance changes) to the database, to be queried through the validator API",
+	}
+	// PerformanceHistoryEpochsFlag defines how many epochs of performance history are kept in the database.
+	PerformanceHistoryEpochsFlag = &cli.Uint64Flag{
+		Name:  "performance-history-epochs",
+		Usage: "The number of epochs of performance history kept in the database. Only used with --enable-performance-history",
+		Value: 1575, // One week.
+	}
 )
 
 // DefaultValidatorDir returns OS-specific default validator directory.
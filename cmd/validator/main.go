@@ -76,9 +76,13 @@ var appFlags = []cli.Flag{
 	flags.Web3SignerPublicValidatorKeysFlag,
 	flags.SuggestedFeeRecipientFlag,
 	flags.ProposerSettingsURLFlag,
+	flags.ProposerSettingsURLRefreshIntervalFlag,
 	flags.ProposerSettingsFlag,
 	flags.EnableBuilderFlag,
 	flags.BuilderGasLimitFlag,
+	flags.DoppelGangerEpochsFlag,
+	flags.EnablePerformanceHistoryFlag,
+	flags.PerformanceHistoryEpochsFlag,
 	////////////////////
 	cmd.DisableMonitoringFlag,
 	cmd.MonitoringHostFlag,
@@ -92,6 +96,7 @@ var appFlags = []cli.Flag{
 	cmd.ForceClearDB,
 	cmd.EnableTracingFlag,
 	cmd.TracingProcessNameFlag,
+	cmd.TracingExporterFlag,
 	cmd.TracingEndpointFlag,
 	cmd.TraceSampleFractionFlag,
 	cmd.LogFormat,
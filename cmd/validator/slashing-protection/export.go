@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/cmd"
 	"github.com/prysmaticlabs/prysm/v4/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/io/file"
 	"github.com/prysmaticlabs/prysm/v4/validator/accounts/userprompt"
 	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
@@ -63,11 +65,19 @@ func exportSlashingProtectionJSON(cliCtx *cli.Context) error {
 			log.WithError(err).Errorf("Could not close validator DB")
 		}
 	}()
-	eipJSON, err := slashingprotection.ExportStandardProtectionJSON(cliCtx.Context, validatorDB)
+	filter, err := exportFilterFromCli(cliCtx)
+	if err != nil {
+		return err
+	}
+	eipJSON, err := slashingprotection.ExportFilteredStandardProtectionJSON(cliCtx.Context, validatorDB, filter)
 	if err != nil {
 		return errors.Wrap(err, "could not export slashing protection history")
 	}
 
+	isFiltered := len(filter.PublicKeys) > 0 || filter.FromEpoch > 0 || filter.ToEpoch > 0
+	if isFiltered && (eipJSON == nil || len(eipJSON.Data) == 0) {
+		return errors.New("no slashing protection data matches the requested public keys and epoch range")
+	}
 	// Check if JSON data is empty and issue a warning about common problems to the user.
 	if eipJSON == nil || len(eipJSON.Data) == 0 {
 		log.Fatal(
@@ -115,3 +125,31 @@ func exportSlashingProtectionJSON(cliCtx *cli.Context) error {
 	)
 	return nil
 }
+
+// Builds the export filter from the public keys, epoch range and minimal format flags.
+func exportFilterFromCli(cliCtx *cli.Context) (*slashingprotection.ExportFilter, error) {
+	filter := &slashingprotection.ExportFilter{
+		FromEpoch: primitives.Epoch(cliCtx.Uint64(flags.SlashingProtectionExportFromEpochFlag.Name)),
+		ToEpoch:   primitives.Epoch(cliCtx.Uint64(flags.SlashingProtectionExportToEpochFlag.Name)),
+		Minimal:   cliCtx.Bool(flags.SlashingProtectionExportMinimalFlag.Name),
+	}
+	if filter.ToEpoch > 0 {
+		log.Warnf(
+			"Exporting the slashing protection history up to epoch %d only. Do not use this file to migrate "+
+				"keys, as it does not protect against signing again anything signed after that epoch",
+			filter.ToEpoch,
+		)
+	}
+	pubKeysStr := cliCtx.String(flags.SlashingProtectionExportPublicKeysFlag.Name)
+	if pubKeysStr == "" {
+		return filter, nil
+	}
+	for _, str := range strings.Split(pubKeysStr, ",") {
+		pubKey, err := slashingprotection.PubKeyFromHex(strings.TrimSpace(str))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse public key %s", str)
+		}
+		filter.PublicKeys = append(filter.PublicKeys, pubKey[:])
+	}
+	return filter, nil
+}
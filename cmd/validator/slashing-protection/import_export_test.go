@@ -3,6 +3,7 @@ package historycmd
 import (
 	"encoding/json"
 	"flag"
+	"fmt"
 	"path/filepath"
 	"testing"
 
@@ -176,3 +177,49 @@ func TestImportExportSlashingProtectionCli_EmptyData(t *testing.T) {
 		require.DeepEqual(t, make([]*format.SignedAttestation, 0), item.SignedAttestations)
 	}
 }
+
+func TestImportExportSlashingProtectionCli_Filtered(t *testing.T) {
+	numValidators := 4
+	outputPath := filepath.Join(t.TempDir(), "slashing-exports")
+	require.NoError(t, file.MkdirAll(outputPath))
+
+	pubKeys, err := mocks.CreateRandomPubKeys(numValidators)
+	require.NoError(t, err)
+	attestingHistory, proposalHistory := mocks.MockAttestingAndProposalHistories(pubKeys)
+	mockJSON, err := mocks.MockSlashingProtectionJSON(pubKeys, attestingHistory, proposalHistory)
+	require.NoError(t, err)
+	encoded, err := json.Marshal(mockJSON)
+	require.NoError(t, err)
+	protectionFilePath := filepath.Join(outputPath, "slashing_history_import.json")
+	require.NoError(t, file.WriteFile(protectionFilePath, encoded))
+
+	validatorDB := dbTest.SetupDB(t, pubKeys)
+	dbPath := validatorDB.DatabasePath()
+	require.NoError(t, validatorDB.Close())
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, dbPath, "")
+	set.String(flags.SlashingProtectionJSONFileFlag.Name, protectionFilePath, "")
+	set.String(flags.SlashingProtectionExportDirFlag.Name, outputPath, "")
+	set.String(flags.SlashingProtectionExportPublicKeysFlag.Name, "", "")
+	set.Bool(flags.SlashingProtectionExportMinimalFlag.Name, false, "")
+	require.NoError(t, set.Set(cmd.DataDirFlag.Name, dbPath))
+	require.NoError(t, set.Set(flags.SlashingProtectionJSONFileFlag.Name, protectionFilePath))
+	require.NoError(t, set.Set(flags.SlashingProtectionExportDirFlag.Name, outputPath))
+	require.NoError(t, set.Set(flags.SlashingProtectionExportPublicKeysFlag.Name, fmt.Sprintf("%#x", pubKeys[1])))
+	require.NoError(t, set.Set(flags.SlashingProtectionExportMinimalFlag.Name, "true"))
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	require.NoError(t, importSlashingProtectionJSON(cliCtx))
+	require.NoError(t, exportSlashingProtectionJSON(cliCtx))
+
+	enc, err := file.ReadFileAsBytes(filepath.Join(outputPath, jsonExportFileName))
+	require.NoError(t, err)
+	receivedJSON := &format.EIPSlashingProtectionFormat{}
+	require.NoError(t, json.Unmarshal(enc, receivedJSON))
+	require.Equal(t, 1, len(receivedJSON.Data))
+	assert.Equal(t, fmt.Sprintf("%#x", pubKeys[1]), receivedJSON.Data[0].Pubkey)
+	assert.Equal(t, true, len(receivedJSON.Data[0].SignedBlocks) <= 1)
+	assert.Equal(t, true, len(receivedJSON.Data[0].SignedAttestations) <= 1)
+}
@@ -21,6 +21,10 @@ var Commands = &cli.Command{
 			Flags: cmd.WrapFlags([]cli.Flag{
 				cmd.DataDirFlag,
 				flags.SlashingProtectionExportDirFlag,
+				flags.SlashingProtectionExportPublicKeysFlag,
+				flags.SlashingProtectionExportFromEpochFlag,
+				flags.SlashingProtectionExportToEpochFlag,
+				flags.SlashingProtectionExportMinimalFlag,
 				features.Mainnet,
 				features.PraterTestnet,
 				features.SepoliaTestnet,
@@ -54,6 +54,7 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.BackupWebhookOutputDir,
 			cmd.EnableTracingFlag,
 			cmd.TracingProcessNameFlag,
+			cmd.TracingExporterFlag,
 			cmd.TracingEndpointFlag,
 			cmd.TraceSampleFractionFlag,
 			cmd.MonitoringHostFlag,
@@ -110,9 +111,13 @@ var appHelpFlagGroups = []flagGroup{
 			flags.Web3SignerPublicValidatorKeysFlag,
 			flags.ProposerSettingsFlag,
 			flags.ProposerSettingsURLFlag,
+			flags.ProposerSettingsURLRefreshIntervalFlag,
 			flags.SuggestedFeeRecipientFlag,
 			flags.EnableBuilderFlag,
 			flags.BuilderGasLimitFlag,
+			flags.DoppelGangerEpochsFlag,
+			flags.EnablePerformanceHistoryFlag,
+			flags.PerformanceHistoryEpochsFlag,
 		},
 	},
 	{
@@ -42,7 +42,7 @@ type Flags struct {
 	EnablePeerScorer                    bool // EnablePeerScorer enables experimental peer scoring in p2p.
 	DisableReorgLateBlocks              bool // DisableReorgLateBlocks disables reorgs of late blocks.
 	WriteWalletPasswordOnWebOnboarding  bool // WriteWalletPasswordOnWebOnboarding writes the password to disk after Prysm web signup.
-	EnableDoppelGanger                  bool // EnableDoppelGanger enables doppelganger protection on startup for the validator.
+	EnableDoppelGanger                  bool // EnableDoppelGanger enables doppelganger protection for the validator keys loaded on startup and at runtime.
 	EnableHistoricalSpaceRepresentation bool // EnableHistoricalSpaceRepresentation enables the saving of registry validators in separate buckets to save space
 	EnableBeaconRESTApi                 bool // EnableBeaconRESTApi enables experimental usage of the beacon REST API by the validator when querying a beacon node
 	// Logging related toggles.
@@ -71,6 +71,12 @@ type Flags struct {
 
 	BuildBlockParallel bool // BuildBlockParallel builds beacon block for proposer in parallel.
 
+	EnableLightClient bool // EnableLightClient enables serving light client data over the REST API, req/resp and gossip.
+
+	EnableForkChoicePersistence bool // EnableForkChoicePersistence saves fork choice to the database and restores it on startup.
+
+	EnableQUIC bool // EnableQUIC enables the QUIC transport of libp2p alongside TCP.
+
 	// KeystoreImportDebounceInterval specifies the time duration the validator waits to reload new keys if they have
 	// changed on disk. This feature is for advanced use cases only.
 	KeystoreImportDebounceInterval time.Duration
@@ -233,6 +239,18 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		logEnabled(disableResourceManager)
 		cfg.DisableResourceManager = true
 	}
+	if ctx.IsSet(enableLightClient.Name) {
+		logEnabled(enableLightClient)
+		cfg.EnableLightClient = true
+	}
+	if ctx.IsSet(enableForkChoicePersistence.Name) {
+		logEnabled(enableForkChoicePersistence)
+		cfg.EnableForkChoicePersistence = true
+	}
+	if ctx.IsSet(enableQUIC.Name) {
+		logEnabled(enableQUIC)
+		cfg.EnableQUIC = true
+	}
 	cfg.AggregateIntervals = [3]time.Duration{aggregateFirstInterval.Value, aggregateSecondInterval.Value, aggregateThirdInterval.Value}
 	Init(cfg)
 	return nil
@@ -96,7 +96,7 @@ var (
 	}
 	enableDoppelGangerProtection = &cli.BoolFlag{
 		Name: "enable-doppelganger",
-		Usage: "Enables the validator to perform a doppelganger check. (Warning): This is not " +
+		Usage: "Enables the validator to perform a doppelganger check on startup and whenever new keys are loaded. (Warning): This is not " +
 			"a foolproof method to find duplicate instances in the network. Your validator will still be" +
 			" vulnerable if it is being run in unsafe configurations.",
 	}
@@ -148,6 +148,20 @@ var (
 		Name:  "disable-resource-manager",
 		Usage: "Disables running the libp2p resource manager",
 	}
+	enableLightClient = &cli.BoolFlag{
+		Name:  "enable-lightclient",
+		Usage: "Enables building and serving light client bootstraps and updates over the REST API, req/resp and gossip",
+	}
+	enableForkChoicePersistence = &cli.BoolFlag{
+		Name: "enable-forkchoice-persistence",
+		Usage: "Periodically saves the fork choice store, including the votes of non-finalized blocks, to the database " +
+			"and restores it on startup so that the node does not need to relearn its head from gossip",
+	}
+	enableQUIC = &cli.BoolFlag{
+		Name: "enable-quic",
+		Usage: "Enables listening for libp2p connections over QUIC alongside TCP, advertises the QUIC port in the ENR " +
+			"and dials peers over QUIC when they advertise it",
+	}
 )
 
 // devModeFlags holds list of flags that are set when development mode is on.
@@ -200,6 +214,9 @@ var BeaconChainFlags = append(deprecatedBeaconFlags, append(deprecatedFlags, []c
 	aggregateSecondInterval,
 	aggregateThirdInterval,
 	disableResourceManager,
+	enableLightClient,
+	enableForkChoicePersistence,
+	enableQUIC,
 }...)...)
 
 // E2EBeaconChainFlags contains a list of the beacon chain feature flags to be tested in E2E.
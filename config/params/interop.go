@@ -10,6 +10,7 @@ func InteropConfig() *BeaconChainConfig {
 	c.AltairForkVersion = []byte{1, 0, 0, 235}
 	c.BellatrixForkVersion = []byte{2, 0, 0, 235}
 	c.CapellaForkVersion = []byte{3, 0, 0, 235}
+	c.DenebForkVersion = []byte{4, 0, 0, 235}
 
 	c.InitializeForkSchedule()
 	return c
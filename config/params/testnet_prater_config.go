@@ -40,6 +40,8 @@ func PraterConfig() *BeaconChainConfig {
 	cfg.BellatrixForkVersion = []byte{0x2, 0x0, 0x10, 0x20}
 	cfg.CapellaForkEpoch = 162304
 	cfg.CapellaForkVersion = []byte{0x3, 0x0, 0x10, 0x20}
+	cfg.DenebForkEpoch = 231680
+	cfg.DenebForkVersion = []byte{0x4, 0x0, 0x10, 0x20}
 	cfg.TerminalTotalDifficulty = "10790000"
 	cfg.DepositContractAddress = "0xff50ed3d0ec03aC01D4C79aAd74928BFF48a7b2b"
 	cfg.InitializeForkSchedule()
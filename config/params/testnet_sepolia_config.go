@@ -35,6 +35,8 @@ func SepoliaConfig() *BeaconChainConfig {
 	cfg.BellatrixForkVersion = []byte{0x90, 0x00, 0x00, 0x71}
 	cfg.CapellaForkEpoch = 56832
 	cfg.CapellaForkVersion = []byte{0x90, 0x00, 0x00, 0x72}
+	cfg.DenebForkEpoch = 132608
+	cfg.DenebForkVersion = []byte{0x90, 0x00, 0x00, 0x73}
 	cfg.TerminalTotalDifficulty = "17000000000000000"
 	cfg.DepositContractAddress = "0x7f02C3E3c98b133055B8B348B2Ac625669Ed295D"
 	cfg.InitializeForkSchedule()
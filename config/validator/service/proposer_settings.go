@@ -204,3 +204,33 @@ func (po *ProposerOption) Clone() *ProposerOption {
 	}
 	return p
 }
+
+// Equal reports whether the proposer options set the same fee recipient and builder settings.
+func (po *ProposerOption) Equal(other *ProposerOption) bool {
+	if po == nil || other == nil {
+		return po == other
+	}
+	if (po.FeeRecipientConfig == nil) != (other.FeeRecipientConfig == nil) {
+		return false
+	}
+	if po.FeeRecipientConfig != nil && po.FeeRecipientConfig.FeeRecipient != other.FeeRecipientConfig.FeeRecipient {
+		return false
+	}
+	return po.BuilderConfig.Equal(other.BuilderConfig)
+}
+
+// Equal reports whether the builder configs have the same settings.
+func (bc *BuilderConfig) Equal(other *BuilderConfig) bool {
+	if bc == nil || other == nil {
+		return bc == other
+	}
+	if bc.Enabled != other.Enabled || bc.GasLimit != other.GasLimit || len(bc.Relays) != len(other.Relays) {
+		return false
+	}
+	for i := range bc.Relays {
+		if bc.Relays[i] != other.Relays[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -98,3 +98,35 @@ func Test_Proposer_Setting_Cloning(t *testing.T) {
 
 	})
 }
+
+func Test_Proposer_Option_Equal(t *testing.T) {
+	option := &ProposerOption{
+		FeeRecipientConfig: &FeeRecipientConfig{
+			FeeRecipient: common.HexToAddress("0x50155530FCE8a85ec7055A5F8b2bE214B3DaeFd3"),
+		},
+		BuilderConfig: &BuilderConfig{
+			Enabled:  true,
+			GasLimit: validator.Uint64(40000000),
+			Relays:   []string{"https://example-relay.com"},
+		},
+	}
+	require.Equal(t, true, option.Equal(option.Clone()))
+	require.Equal(t, false, option.Equal(nil))
+	require.Equal(t, true, (*ProposerOption)(nil).Equal(nil))
+
+	other := option.Clone()
+	other.FeeRecipientConfig.FeeRecipient = common.HexToAddress("0x6e35733c5af9B61374A128e6F85f553aF09ff89A")
+	require.Equal(t, false, option.Equal(other))
+
+	other = option.Clone()
+	other.BuilderConfig.GasLimit = 30000000
+	require.Equal(t, false, option.Equal(other))
+
+	other = option.Clone()
+	other.BuilderConfig.Relays = nil
+	require.Equal(t, false, option.Equal(other))
+
+	other = option.Clone()
+	other.BuilderConfig = nil
+	require.Equal(t, false, option.Equal(other))
+}
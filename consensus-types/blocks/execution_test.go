@@ -1,6 +1,7 @@
 package blocks_test
 
 import (
+	"math/big"
 	"testing"
 
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
@@ -105,11 +106,14 @@ func TestWrapExecutionPayloadCapella(t *testing.T) {
 			Amount:         77,
 		}},
 	}
-	payload, err := blocks.WrappedExecutionPayloadCapella(data, 10)
+	payload, err := blocks.WrappedExecutionPayloadCapella(data, big.NewInt(10*1e9))
 	require.NoError(t, err)
 	v, err := payload.ValueInGwei()
 	require.NoError(t, err)
 	assert.Equal(t, uint64(10), v)
+	wei, err := payload.ValueInWei()
+	require.NoError(t, err)
+	assert.Equal(t, "10000000000", wei.String())
 
 	assert.DeepEqual(t, data, payload.Proto())
 }
@@ -132,7 +136,7 @@ func TestWrapExecutionPayloadHeaderCapella(t *testing.T) {
 		TransactionsRoot: []byte("transactionsroot"),
 		WithdrawalsRoot:  []byte("withdrawalsroot"),
 	}
-	payload, err := blocks.WrappedExecutionPayloadHeaderCapella(data, 10)
+	payload, err := blocks.WrappedExecutionPayloadHeaderCapella(data, big.NewInt(10*1e9))
 	require.NoError(t, err)
 
 	v, err := payload.ValueInGwei()
@@ -151,22 +155,22 @@ func TestWrapExecutionPayloadHeaderCapella(t *testing.T) {
 }
 
 func TestWrapExecutionPayloadCapella_IsNil(t *testing.T) {
-	_, err := blocks.WrappedExecutionPayloadCapella(nil, 0)
+	_, err := blocks.WrappedExecutionPayloadCapella(nil, big.NewInt(0))
 	require.Equal(t, consensus_types.ErrNilObjectWrapped, err)
 
 	data := &enginev1.ExecutionPayloadCapella{GasUsed: 54}
-	payload, err := blocks.WrappedExecutionPayloadCapella(data, 0)
+	payload, err := blocks.WrappedExecutionPayloadCapella(data, big.NewInt(0))
 	require.NoError(t, err)
 
 	assert.Equal(t, false, payload.IsNil())
 }
 
 func TestWrapExecutionPayloadHeaderCapella_IsNil(t *testing.T) {
-	_, err := blocks.WrappedExecutionPayloadHeaderCapella(nil, 0)
+	_, err := blocks.WrappedExecutionPayloadHeaderCapella(nil, big.NewInt(0))
 	require.Equal(t, consensus_types.ErrNilObjectWrapped, err)
 
 	data := &enginev1.ExecutionPayloadHeaderCapella{GasUsed: 54}
-	payload, err := blocks.WrappedExecutionPayloadHeaderCapella(data, 0)
+	payload, err := blocks.WrappedExecutionPayloadHeaderCapella(data, big.NewInt(0))
 	require.NoError(t, err)
 
 	assert.Equal(t, false, payload.IsNil())
@@ -301,7 +305,7 @@ func createWrappedPayloadCapella(t testing.TB) interfaces.ExecutionData {
 		BlockHash:     make([]byte, fieldparams.RootLength),
 		Transactions:  make([][]byte, 0),
 		Withdrawals:   make([]*enginev1.Withdrawal, 0),
-	}, 0)
+	}, big.NewInt(0))
 	require.NoError(t, err)
 	return payload
 }
@@ -323,7 +327,7 @@ func createWrappedPayloadHeaderCapella(t testing.TB) interfaces.ExecutionData {
 		BlockHash:        make([]byte, fieldparams.RootLength),
 		TransactionsRoot: make([]byte, fieldparams.RootLength),
 		WithdrawalsRoot:  make([]byte, fieldparams.RootLength),
-	}, 0)
+	}, big.NewInt(0))
 	require.NoError(t, err)
 	return payload
 }
@@ -0,0 +1,309 @@
+package blocks
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+	field_params "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/container/trie"
+	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	prysmssz "github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+)
+
+const (
+	bodyLength        = 12 // The number of fields in the BeaconBlockBody container
+	logBodyLength     = 4  // The log 2 of bodyLength
+	kzgPosition       = 11 // The index of the KZG commitment list in the Body
+	executionPosition = 9  // The index of the execution payload in the Body
+)
+
+var (
+	errInvalidIndex          = errors.New("index out of bounds")
+	errInvalidInclusionProof = errors.New("invalid KZG commitment inclusion proof")
+	errNilSidecar            = errors.New("nil blob sidecar")
+)
+
+// MerkleProofKZGCommitment constructs a Merkle proof of inclusion of the KZG
+// commitment of index `index` into the Beacon Block with the given `body`
+func MerkleProofKZGCommitment(body interfaces.ReadOnlyBeaconBlockBody, index int) ([][]byte, error) {
+	commitments, err := body.BlobKzgCommitments()
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(commitments) {
+		return nil, errInvalidIndex
+	}
+	proof, err := bodyProof(commitments, index)
+	if err != nil {
+		return nil, err
+	}
+	membersRoots, err := topLevelRoots(body)
+	if err != nil {
+		return nil, err
+	}
+	hasher := prysmssz.NewHasherFunc(hash.CustomSHA256Hasher())
+	topProof := prysmssz.ConstructProof(hasher, bodyLength, 1<<logBodyLength, func(i uint64) []byte {
+		return membersRoots[i][:]
+	}, kzgPosition)
+	for _, p := range topProof {
+		proof = append(proof, bytesutil.SafeCopyBytes(p[:]))
+	}
+	return proof, nil
+}
+
+// MerkleProofExecutionPayload constructs a Merkle proof of inclusion of the
+// execution payload into the Capella or later Beacon Block with the given `body`.
+func MerkleProofExecutionPayload(body interfaces.ReadOnlyBeaconBlockBody) ([][]byte, error) {
+	membersRoots, err := topLevelRoots(body)
+	if err != nil {
+		return nil, err
+	}
+	// The KZG commitments are a sibling subtree of the execution payload, so
+	// their root is needed for Deneb bodies.
+	commitments, err := body.BlobKzgCommitments()
+	if err == nil {
+		root, err := listRoot(len(commitments), field_params.MaxBlobCommitmentsPerBlock, func(hh *ssz.Hasher, i int) error {
+			hh.PutBytes(commitments[i])
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		membersRoots[kzgPosition] = root
+	}
+	hasher := prysmssz.NewHasherFunc(hash.CustomSHA256Hasher())
+	topProof := prysmssz.ConstructProof(hasher, bodyLength, 1<<logBodyLength, func(i uint64) []byte {
+		return membersRoots[i][:]
+	}, executionPosition)
+	proof := make([][]byte, 0, len(topProof))
+	for _, p := range topProof {
+		proof = append(proof, bytesutil.SafeCopyBytes(p[:]))
+	}
+	return proof, nil
+}
+
+// VerifyKZGInclusionProof verifies the Merkle proof in a Blob sidecar against
+// the beacon block body root.
+func VerifyKZGInclusionProof(sc *eth.BlobSidecar) error {
+	if sc == nil || sc.SignedBlockHeader == nil || sc.SignedBlockHeader.Header == nil {
+		return errNilSidecar
+	}
+	if len(sc.CommitmentInclusionProof) != field_params.KzgCommitmentInclusionProofDepth {
+		return errInvalidInclusionProof
+	}
+	root, err := kzgCommitmentRoot(sc.KzgCommitment)
+	if err != nil {
+		return err
+	}
+	depth := uint64(field_params.KzgCommitmentInclusionProofDepth)
+	subtreeIndex := uint64(kzgPosition)<<(depth-logBodyLength) + sc.Index
+	if !trie.VerifyMerkleProofWithDepth(sc.SignedBlockHeader.Header.BodyRoot, root[:], subtreeIndex, sc.CommitmentInclusionProof, depth-1) {
+		return errInvalidInclusionProof
+	}
+	return nil
+}
+
+// BuildBlobSidecars assembles the blob sidecars of a signed Deneb block out of the blobs
+// and KZG proofs returned by the execution client alongside the block's payload.
+func BuildBlobSidecars(blk interfaces.ReadOnlySignedBeaconBlock, blobs [][]byte, kzgProofs [][]byte) ([]*eth.BlobSidecar, error) {
+	if blk.Version() < version.Deneb {
+		return nil, nil
+	}
+	body := blk.Block().Body()
+	commitments, err := body.BlobKzgCommitments()
+	if err != nil {
+		return nil, err
+	}
+	if len(blobs) != len(commitments) || len(kzgProofs) != len(commitments) {
+		return nil, fmt.Errorf("mismatched number of blobs (%d), kzg proofs (%d) and commitments (%d)", len(blobs), len(kzgProofs), len(commitments))
+	}
+	header, err := blk.Header()
+	if err != nil {
+		return nil, err
+	}
+	sidecars := make([]*eth.BlobSidecar, len(blobs))
+	for i := range blobs {
+		proof, err := MerkleProofKZGCommitment(body, i)
+		if err != nil {
+			return nil, err
+		}
+		sidecars[i] = &eth.BlobSidecar{
+			Index:                    uint64(i),
+			Blob:                     blobs[i],
+			KzgCommitment:            commitments[i],
+			KzgProof:                 kzgProofs[i],
+			SignedBlockHeader:        header,
+			CommitmentInclusionProof: proof,
+		}
+	}
+	return sidecars, nil
+}
+
+// kzgCommitmentRoot returns the hash tree root of a single KZG commitment.
+func kzgCommitmentRoot(commitment []byte) ([32]byte, error) {
+	if len(commitment) != 48 {
+		return [32]byte{}, fmt.Errorf("invalid kzg commitment length %d", len(commitment))
+	}
+	hh := ssz.NewHasher()
+	hh.PutBytes(commitment)
+	return hh.HashRoot()
+}
+
+// bodyProof returns the Merkle proof of the subtree up to the root of the KZG
+// commitment list.
+func bodyProof(commitments [][]byte, index int) ([][]byte, error) {
+	leaves := make([][32]byte, len(commitments))
+	for i, c := range commitments {
+		root, err := kzgCommitmentRoot(c)
+		if err != nil {
+			return nil, err
+		}
+		leaves[i] = root
+	}
+	hasher := prysmssz.NewHasherFunc(hash.CustomSHA256Hasher())
+	branch := prysmssz.ConstructProof(hasher, uint64(len(leaves)), field_params.MaxBlobCommitmentsPerBlock, func(i uint64) []byte {
+		return leaves[i][:]
+	}, uint64(index))
+	proof := make([][]byte, 0, len(branch)+1)
+	for _, p := range branch {
+		proof = append(proof, bytesutil.SafeCopyBytes(p[:]))
+	}
+	length := prysmssz.Uint64Root(uint64(len(commitments)))
+	proof = append(proof, length[:])
+	return proof, nil
+}
+
+// topLevelRoots computes the slice with the roots of each element in the
+// BeaconBlockBody. Notice that the KZG commitments root is not needed for the
+// proof computation thus it's omitted.
+func topLevelRoots(body interfaces.ReadOnlyBeaconBlockBody) ([][32]byte, error) {
+	cfg := params.BeaconConfig()
+	layer := make([][32]byte, bodyLength)
+
+	// Randao Reveal
+	randao := body.RandaoReveal()
+	hh := ssz.NewHasher()
+	hh.PutBytes(randao[:])
+	root, err := hh.HashRoot()
+	if err != nil {
+		return nil, err
+	}
+	layer[0] = root
+
+	// eth1_data
+	root, err = body.Eth1Data().HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	layer[1] = root
+
+	// graffiti
+	layer[2] = body.Graffiti()
+
+	// Proposer slashings
+	ps := body.ProposerSlashings()
+	root, err = listRoot(len(ps), cfg.MaxProposerSlashings, func(hh *ssz.Hasher, i int) error {
+		return ps[i].HashTreeRootWith(hh)
+	})
+	if err != nil {
+		return nil, err
+	}
+	layer[3] = root
+
+	// Attester slashings
+	as := body.AttesterSlashings()
+	root, err = listRoot(len(as), cfg.MaxAttesterSlashings, func(hh *ssz.Hasher, i int) error {
+		return as[i].HashTreeRootWith(hh)
+	})
+	if err != nil {
+		return nil, err
+	}
+	layer[4] = root
+
+	// Attestations
+	att := body.Attestations()
+	root, err = listRoot(len(att), cfg.MaxAttestations, func(hh *ssz.Hasher, i int) error {
+		return att[i].HashTreeRootWith(hh)
+	})
+	if err != nil {
+		return nil, err
+	}
+	layer[5] = root
+
+	// Deposits
+	dep := body.Deposits()
+	root, err = listRoot(len(dep), cfg.MaxDeposits, func(hh *ssz.Hasher, i int) error {
+		return dep[i].HashTreeRootWith(hh)
+	})
+	if err != nil {
+		return nil, err
+	}
+	layer[6] = root
+
+	// Voluntary Exits
+	ve := body.VoluntaryExits()
+	root, err = listRoot(len(ve), cfg.MaxVoluntaryExits, func(hh *ssz.Hasher, i int) error {
+		return ve[i].HashTreeRootWith(hh)
+	})
+	if err != nil {
+		return nil, err
+	}
+	layer[7] = root
+
+	// Sync Aggregate
+	sa, err := body.SyncAggregate()
+	if err != nil {
+		return nil, err
+	}
+	root, err = sa.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	layer[8] = root
+
+	// Execution Payload
+	ep, err := body.Execution()
+	if err != nil {
+		return nil, err
+	}
+	root, err = ep.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	layer[9] = root
+
+	// BLS Changes
+	bls, err := body.BLSToExecutionChanges()
+	if err != nil {
+		return nil, err
+	}
+	root, err = listRoot(len(bls), cfg.MaxBlsToExecutionChanges, func(hh *ssz.Hasher, i int) error {
+		return bls[i].HashTreeRootWith(hh)
+	})
+	if err != nil {
+		return nil, err
+	}
+	layer[10] = root
+
+	// KZG commitments is not needed
+	return layer, nil
+}
+
+// listRoot computes the hash tree root of an SSZ list of containers with the given limit.
+func listRoot(length int, limit uint64, appendElem func(hh *ssz.Hasher, i int) error) ([32]byte, error) {
+	hh := ssz.NewHasher()
+	indx := hh.Index()
+	for i := 0; i < length; i++ {
+		if err := appendElem(hh, i); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	hh.MerkleizeWithMixin(indx, uint64(length), limit)
+	return hh.HashRoot()
+}
@@ -0,0 +1,94 @@
+package blocks_test
+
+import (
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/container/trie"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func denebBlockWithCommitments(t *testing.T, n int) *ethpb.SignedBeaconBlockDeneb {
+	b := util.NewBeaconBlockDeneb()
+	b.Block.Body.Graffiti = bytesutil.PadTo([]byte("graffiti"), fieldparams.RootLength)
+	b.Block.Body.VoluntaryExits = []*ethpb.SignedVoluntaryExit{{
+		Exit:      &ethpb.VoluntaryExit{Epoch: 1, ValidatorIndex: 2},
+		Signature: make([]byte, fieldparams.BLSSignatureLength),
+	}}
+	commitments := make([][]byte, n)
+	for i := range commitments {
+		commitments[i] = bytesutil.PadTo([]byte{byte(i + 1)}, 48)
+	}
+	b.Block.Body.BlobKzgCommitments = commitments
+	return b
+}
+
+func TestMerkleProofKZGCommitment(t *testing.T) {
+	pb := denebBlockWithCommitments(t, 3)
+	blk, err := blocks.NewSignedBeaconBlock(pb)
+	require.NoError(t, err)
+
+	blobs := make([][]byte, 3)
+	proofs := make([][]byte, 3)
+	for i := range blobs {
+		blobs[i] = make([]byte, fieldparams.BlobLength)
+		proofs[i] = make([]byte, 48)
+	}
+	sidecars, err := blocks.BuildBlobSidecars(blk, blobs, proofs)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(sidecars))
+
+	bodyRoot, err := pb.Block.Body.HashTreeRoot()
+	require.NoError(t, err)
+	for i, sc := range sidecars {
+		require.Equal(t, uint64(i), sc.Index)
+		require.Equal(t, fieldparams.KzgCommitmentInclusionProofDepth, len(sc.CommitmentInclusionProof))
+		require.DeepEqual(t, bodyRoot[:], sc.SignedBlockHeader.Header.BodyRoot)
+		require.NoError(t, blocks.VerifyKZGInclusionProof(sc))
+	}
+
+	sidecars[1].KzgCommitment = sidecars[0].KzgCommitment
+	require.ErrorContains(t, "invalid KZG commitment inclusion proof", blocks.VerifyKZGInclusionProof(sidecars[1]))
+}
+
+func TestMerkleProofKZGCommitment_OutOfBounds(t *testing.T) {
+	blk, err := blocks.NewSignedBeaconBlock(denebBlockWithCommitments(t, 1))
+	require.NoError(t, err)
+	_, err = blocks.MerkleProofKZGCommitment(blk.Block().Body(), 1)
+	require.ErrorContains(t, "index out of bounds", err)
+}
+
+func TestMerkleProofExecutionPayload(t *testing.T) {
+	capella := util.NewBeaconBlockCapella()
+	capella.Block.Body.Graffiti = bytesutil.PadTo([]byte("graffiti"), fieldparams.RootLength)
+	capella.Block.Body.ExecutionPayload.BlockNumber = 10
+	deneb := denebBlockWithCommitments(t, 2)
+	deneb.Block.Body.ExecutionPayload.BlockNumber = 10
+
+	for _, pb := range []interface{}{capella, deneb} {
+		blk, err := blocks.NewSignedBeaconBlock(pb)
+		require.NoError(t, err)
+		proof, err := blocks.MerkleProofExecutionPayload(blk.Block().Body())
+		require.NoError(t, err)
+		require.Equal(t, 4, len(proof))
+
+		bodyRoot, err := blk.Block().Body().HashTreeRoot()
+		require.NoError(t, err)
+		payload, err := blk.Block().Body().Execution()
+		require.NoError(t, err)
+		payloadRoot, err := payload.HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, true, trie.VerifyMerkleProofWithDepth(bodyRoot[:], payloadRoot[:], 9, proof, 3))
+	}
+}
+
+func TestBuildBlobSidecars_MismatchedLengths(t *testing.T) {
+	blk, err := blocks.NewSignedBeaconBlock(denebBlockWithCommitments(t, 2))
+	require.NoError(t, err)
+	_, err = blocks.BuildBlobSidecars(blk, [][]byte{{}}, [][]byte{{}, {}})
+	require.ErrorContains(t, "mismatched number of blobs", err)
+}
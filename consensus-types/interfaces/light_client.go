@@ -0,0 +1,65 @@
+package interfaces
+
+import (
+	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"google.golang.org/protobuf/proto"
+)
+
+// LightClientHeader describes the header of a light client object. Its version is the version of the
+// light client object containing it, which may be newer than the version of the block it was built from.
+type LightClientHeader interface {
+	ssz.Marshaler
+	Version() int
+	Proto() proto.Message
+	Beacon() *ethpb.BeaconBlockHeader
+	Execution() (ExecutionData, error)
+	ExecutionBranch() ([][]byte, error)
+}
+
+// LightClientBootstrap describes the light client bootstrap served for a trusted block root.
+type LightClientBootstrap interface {
+	ssz.Marshaler
+	Version() int
+	Proto() proto.Message
+	Header() LightClientHeader
+	CurrentSyncCommittee() *ethpb.SyncCommittee
+	CurrentSyncCommitteeBranch() [][]byte
+}
+
+// LightClientUpdate describes the light client update of a sync committee period.
+type LightClientUpdate interface {
+	ssz.Marshaler
+	Version() int
+	Proto() proto.Message
+	AttestedHeader() LightClientHeader
+	NextSyncCommittee() *ethpb.SyncCommittee
+	NextSyncCommitteeBranch() [][]byte
+	FinalizedHeader() LightClientHeader
+	FinalityBranch() [][]byte
+	SyncAggregate() *ethpb.SyncAggregate
+	SignatureSlot() primitives.Slot
+}
+
+// LightClientFinalityUpdate describes the latest light client finality update.
+type LightClientFinalityUpdate interface {
+	ssz.Marshaler
+	Version() int
+	Proto() proto.Message
+	AttestedHeader() LightClientHeader
+	FinalizedHeader() LightClientHeader
+	FinalityBranch() [][]byte
+	SyncAggregate() *ethpb.SyncAggregate
+	SignatureSlot() primitives.Slot
+}
+
+// LightClientOptimisticUpdate describes the latest light client optimistic update.
+type LightClientOptimisticUpdate interface {
+	ssz.Marshaler
+	Version() int
+	Proto() proto.Message
+	AttestedHeader() LightClientHeader
+	SyncAggregate() *ethpb.SyncAggregate
+	SignatureSlot() primitives.Slot
+}
@@ -0,0 +1,289 @@
+package lightclient
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	consensus_types "github.com/prysmaticlabs/prysm/v4/consensus-types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"google.golang.org/protobuf/proto"
+)
+
+// ExecutionBranchDepth is the depth of the Merkle branch of the execution payload within the block body.
+const ExecutionBranchDepth = 4
+
+type header struct {
+	ssz.Marshaler
+	p               proto.Message
+	version         int
+	beacon          *ethpb.BeaconBlockHeader
+	execution       interfaces.ExecutionData
+	executionBranch [][]byte
+}
+
+// NewWrappedHeader wraps an Altair, Capella or Deneb light client header protobuf object.
+func NewWrappedHeader(m proto.Message) (interfaces.LightClientHeader, error) {
+	switch h := m.(type) {
+	case *ethpb.LightClientHeader:
+		if h == nil || h.Beacon == nil {
+			return nil, consensus_types.ErrNilObjectWrapped
+		}
+		return &header{Marshaler: h, p: h, version: version.Altair, beacon: h.Beacon}, nil
+	case *ethpb.LightClientHeaderCapella:
+		if h == nil || h.Beacon == nil {
+			return nil, consensus_types.ErrNilObjectWrapped
+		}
+		execution, err := blocks.WrappedExecutionPayloadHeaderCapella(h.Execution, big.NewInt(0))
+		if err != nil {
+			return nil, err
+		}
+		return &header{
+			Marshaler:       h,
+			p:               h,
+			version:         version.Capella,
+			beacon:          h.Beacon,
+			execution:       execution,
+			executionBranch: h.ExecutionBranch,
+		}, nil
+	case *ethpb.LightClientHeaderDeneb:
+		if h == nil || h.Beacon == nil {
+			return nil, consensus_types.ErrNilObjectWrapped
+		}
+		execution, err := blocks.WrappedExecutionPayloadHeaderDeneb(h.Execution, big.NewInt(0))
+		if err != nil {
+			return nil, err
+		}
+		return &header{
+			Marshaler:       h,
+			p:               h,
+			version:         version.Deneb,
+			beacon:          h.Beacon,
+			execution:       execution,
+			executionBranch: h.ExecutionBranch,
+		}, nil
+	default:
+		return nil, errors.Errorf("unsupported light client header type %T", m)
+	}
+}
+
+// HeaderVersion returns the version of the light client objects built for a block of the given version.
+// Light client objects only change with the Capella and Deneb forks.
+func HeaderVersion(blockVersion int) int {
+	switch {
+	case blockVersion >= version.Deneb:
+		return version.Deneb
+	case blockVersion >= version.Capella:
+		return version.Capella
+	default:
+		return version.Altair
+	}
+}
+
+// HeaderFromBlock builds the light client header of the given version for the block, following
+// block_to_light_client_header and the header upgrade functions of the light client specs. Blocks
+// older than Capella get an empty execution header and branch in Capella or later headers.
+func HeaderFromBlock(blk interfaces.ReadOnlySignedBeaconBlock, v int) (interfaces.LightClientHeader, error) {
+	h, err := blk.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get block header")
+	}
+	if v < version.Capella {
+		return NewWrappedHeader(&ethpb.LightClientHeader{Beacon: h.Header})
+	}
+
+	capellaHeader := emptyExecutionHeaderCapella()
+	branch := emptyBranch(ExecutionBranchDepth)
+	var blobGasUsed, excessBlobGas uint64
+	if blk.Version() >= version.Capella {
+		body := blk.Block().Body()
+		payload, err := body.Execution()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get execution payload")
+		}
+		capellaHeader, err = executionHeaderCapella(payload)
+		if err != nil {
+			return nil, err
+		}
+		branch, err = blocks.MerkleProofExecutionPayload(body)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get execution payload proof")
+		}
+		if blk.Version() >= version.Deneb {
+			if blobGasUsed, err = payload.BlobGasUsed(); err != nil {
+				return nil, err
+			}
+			if excessBlobGas, err = payload.ExcessBlobGas(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if v == version.Capella {
+		return NewWrappedHeader(&ethpb.LightClientHeaderCapella{
+			Beacon:          h.Header,
+			Execution:       capellaHeader,
+			ExecutionBranch: branch,
+		})
+	}
+	return NewWrappedHeader(&ethpb.LightClientHeaderDeneb{
+		Beacon: h.Header,
+		Execution: &enginev1.ExecutionPayloadHeaderDeneb{
+			ParentHash:       capellaHeader.ParentHash,
+			FeeRecipient:     capellaHeader.FeeRecipient,
+			StateRoot:        capellaHeader.StateRoot,
+			ReceiptsRoot:     capellaHeader.ReceiptsRoot,
+			LogsBloom:        capellaHeader.LogsBloom,
+			PrevRandao:       capellaHeader.PrevRandao,
+			BlockNumber:      capellaHeader.BlockNumber,
+			GasLimit:         capellaHeader.GasLimit,
+			GasUsed:          capellaHeader.GasUsed,
+			Timestamp:        capellaHeader.Timestamp,
+			ExtraData:        capellaHeader.ExtraData,
+			BaseFeePerGas:    capellaHeader.BaseFeePerGas,
+			BlockHash:        capellaHeader.BlockHash,
+			TransactionsRoot: capellaHeader.TransactionsRoot,
+			WithdrawalsRoot:  capellaHeader.WithdrawalsRoot,
+			BlobGasUsed:      blobGasUsed,
+			ExcessBlobGas:    excessBlobGas,
+		},
+		ExecutionBranch: branch,
+	})
+}
+
+// EmptyHeader returns the light client header of the given version used when no block is known,
+// for instance as the finalized header of an update before the first finalized checkpoint.
+func EmptyHeader(v int) (interfaces.LightClientHeader, error) {
+	beacon := &ethpb.BeaconBlockHeader{
+		ParentRoot: make([]byte, fieldparams.RootLength),
+		StateRoot:  make([]byte, fieldparams.RootLength),
+		BodyRoot:   make([]byte, fieldparams.RootLength),
+	}
+	switch {
+	case v >= version.Deneb:
+		return NewWrappedHeader(&ethpb.LightClientHeaderDeneb{
+			Beacon:          beacon,
+			Execution:       emptyExecutionHeaderDeneb(),
+			ExecutionBranch: emptyBranch(ExecutionBranchDepth),
+		})
+	case v >= version.Capella:
+		return NewWrappedHeader(&ethpb.LightClientHeaderCapella{
+			Beacon:          beacon,
+			Execution:       emptyExecutionHeaderCapella(),
+			ExecutionBranch: emptyBranch(ExecutionBranchDepth),
+		})
+	default:
+		return NewWrappedHeader(&ethpb.LightClientHeader{Beacon: beacon})
+	}
+}
+
+// Version returns the version of the light client header.
+func (h *header) Version() int {
+	return h.version
+}
+
+// Proto returns the underlying protobuf object.
+func (h *header) Proto() proto.Message {
+	return h.p
+}
+
+// Beacon returns the beacon block header.
+func (h *header) Beacon() *ethpb.BeaconBlockHeader {
+	return h.beacon
+}
+
+// Execution returns the execution payload header. It is only supported for Capella and later headers.
+func (h *header) Execution() (interfaces.ExecutionData, error) {
+	if h.version < version.Capella {
+		return nil, consensus_types.ErrNotSupported("Execution", h.version)
+	}
+	return h.execution, nil
+}
+
+// ExecutionBranch returns the Merkle branch of the execution payload within the block body. It is only
+// supported for Capella and later headers.
+func (h *header) ExecutionBranch() ([][]byte, error) {
+	if h.version < version.Capella {
+		return nil, consensus_types.ErrNotSupported("ExecutionBranch", h.version)
+	}
+	return h.executionBranch, nil
+}
+
+// executionHeaderCapella converts a full or blinded execution payload into a Capella execution payload header.
+func executionHeaderCapella(payload interfaces.ExecutionData) (*enginev1.ExecutionPayloadHeaderCapella, error) {
+	if !payload.IsBlinded() {
+		return blocks.PayloadToHeaderCapella(payload)
+	}
+	txRoot, err := payload.TransactionsRoot()
+	if err != nil {
+		return nil, err
+	}
+	withdrawalsRoot, err := payload.WithdrawalsRoot()
+	if err != nil {
+		return nil, err
+	}
+	return &enginev1.ExecutionPayloadHeaderCapella{
+		ParentHash:       bytesutil.SafeCopyBytes(payload.ParentHash()),
+		FeeRecipient:     bytesutil.SafeCopyBytes(payload.FeeRecipient()),
+		StateRoot:        bytesutil.SafeCopyBytes(payload.StateRoot()),
+		ReceiptsRoot:     bytesutil.SafeCopyBytes(payload.ReceiptsRoot()),
+		LogsBloom:        bytesutil.SafeCopyBytes(payload.LogsBloom()),
+		PrevRandao:       bytesutil.SafeCopyBytes(payload.PrevRandao()),
+		BlockNumber:      payload.BlockNumber(),
+		GasLimit:         payload.GasLimit(),
+		GasUsed:          payload.GasUsed(),
+		Timestamp:        payload.Timestamp(),
+		ExtraData:        bytesutil.SafeCopyBytes(payload.ExtraData()),
+		BaseFeePerGas:    bytesutil.SafeCopyBytes(payload.BaseFeePerGas()),
+		BlockHash:        bytesutil.SafeCopyBytes(payload.BlockHash()),
+		TransactionsRoot: bytesutil.SafeCopyBytes(txRoot),
+		WithdrawalsRoot:  bytesutil.SafeCopyBytes(withdrawalsRoot),
+	}, nil
+}
+
+func emptyExecutionHeaderCapella() *enginev1.ExecutionPayloadHeaderCapella {
+	return &enginev1.ExecutionPayloadHeaderCapella{
+		ParentHash:       make([]byte, fieldparams.RootLength),
+		FeeRecipient:     make([]byte, fieldparams.FeeRecipientLength),
+		StateRoot:        make([]byte, fieldparams.RootLength),
+		ReceiptsRoot:     make([]byte, fieldparams.RootLength),
+		LogsBloom:        make([]byte, fieldparams.LogsBloomLength),
+		PrevRandao:       make([]byte, fieldparams.RootLength),
+		ExtraData:        make([]byte, 0),
+		BaseFeePerGas:    make([]byte, fieldparams.RootLength),
+		BlockHash:        make([]byte, fieldparams.RootLength),
+		TransactionsRoot: make([]byte, fieldparams.RootLength),
+		WithdrawalsRoot:  make([]byte, fieldparams.RootLength),
+	}
+}
+
+func emptyExecutionHeaderDeneb() *enginev1.ExecutionPayloadHeaderDeneb {
+	h := emptyExecutionHeaderCapella()
+	return &enginev1.ExecutionPayloadHeaderDeneb{
+		ParentHash:       h.ParentHash,
+		FeeRecipient:     h.FeeRecipient,
+		StateRoot:        h.StateRoot,
+		ReceiptsRoot:     h.ReceiptsRoot,
+		LogsBloom:        h.LogsBloom,
+		PrevRandao:       h.PrevRandao,
+		ExtraData:        h.ExtraData,
+		BaseFeePerGas:    h.BaseFeePerGas,
+		BlockHash:        h.BlockHash,
+		TransactionsRoot: h.TransactionsRoot,
+		WithdrawalsRoot:  h.WithdrawalsRoot,
+	}
+}
+
+func emptyBranch(depth int) [][]byte {
+	branch := make([][]byte, depth)
+	for i := range branch {
+		branch[i] = make([]byte, fieldparams.RootLength)
+	}
+	return branch
+}
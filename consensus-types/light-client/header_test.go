@@ -0,0 +1,117 @@
+package lightclient_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	lightclient "github.com/prysmaticlabs/prysm/v4/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v4/container/trie"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestHeaderFromBlock_Altair(t *testing.T) {
+	blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockBellatrix())
+	require.NoError(t, err)
+	h, err := lightclient.HeaderFromBlock(blk, lightclient.HeaderVersion(blk.Version()))
+	require.NoError(t, err)
+	require.Equal(t, version.Altair, h.Version())
+	_, ok := h.Proto().(*ethpb.LightClientHeader)
+	require.Equal(t, true, ok)
+	_, err = h.Execution()
+	require.ErrorContains(t, "Execution is not supported", err)
+}
+
+func TestHeaderFromBlock_Capella(t *testing.T) {
+	pb := util.NewBeaconBlockCapella()
+	pb.Block.Body.ExecutionPayload.BlockNumber = 10
+	blk, err := blocks.NewSignedBeaconBlock(pb)
+	require.NoError(t, err)
+	h, err := lightclient.HeaderFromBlock(blk, lightclient.HeaderVersion(blk.Version()))
+	require.NoError(t, err)
+	require.Equal(t, version.Capella, h.Version())
+	_, ok := h.Proto().(*ethpb.LightClientHeaderCapella)
+	require.Equal(t, true, ok)
+
+	execution, err := h.Execution()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), execution.BlockNumber())
+	executionRoot, err := execution.HashTreeRoot()
+	require.NoError(t, err)
+	payloadRoot, err := pb.Block.Body.ExecutionPayload.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, payloadRoot, executionRoot)
+
+	branch, err := h.ExecutionBranch()
+	require.NoError(t, err)
+	require.Equal(t, true, trie.VerifyMerkleProofWithDepth(h.Beacon().BodyRoot, executionRoot[:], 9, branch, lightclient.ExecutionBranchDepth-1))
+}
+
+func TestHeaderFromBlock_BlindedCapella(t *testing.T) {
+	pb := util.NewBlindedBeaconBlockCapella()
+	pb.Block.Body.ExecutionPayloadHeader.BlockNumber = 10
+	blk, err := blocks.NewSignedBeaconBlock(pb)
+	require.NoError(t, err)
+	h, err := lightclient.HeaderFromBlock(blk, version.Capella)
+	require.NoError(t, err)
+	execution, err := h.Execution()
+	require.NoError(t, err)
+	executionRoot, err := execution.HashTreeRoot()
+	require.NoError(t, err)
+	headerRoot, err := pb.Block.Body.ExecutionPayloadHeader.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, headerRoot, executionRoot)
+}
+
+func TestHeaderFromBlock_UpgradesOlderBlocks(t *testing.T) {
+	blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockBellatrix())
+	require.NoError(t, err)
+	h, err := lightclient.HeaderFromBlock(blk, version.Deneb)
+	require.NoError(t, err)
+	require.Equal(t, version.Deneb, h.Version())
+	empty, err := lightclient.EmptyHeader(version.Deneb)
+	require.NoError(t, err)
+	execution, err := h.Execution()
+	require.NoError(t, err)
+	emptyExecution, err := empty.Execution()
+	require.NoError(t, err)
+	require.DeepEqual(t, emptyExecution.Proto(), execution.Proto())
+
+	capella, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockCapella())
+	require.NoError(t, err)
+	h, err = lightclient.HeaderFromBlock(capella, version.Deneb)
+	require.NoError(t, err)
+	d, ok := h.Proto().(*ethpb.LightClientHeaderDeneb)
+	require.Equal(t, true, ok)
+	require.Equal(t, uint64(0), d.Execution.BlobGasUsed)
+}
+
+func TestNewUpdate_Versions(t *testing.T) {
+	for _, v := range []int{version.Altair, version.Capella, version.Deneb} {
+		h, err := lightclient.EmptyHeader(v)
+		require.NoError(t, err)
+		u, err := lightclient.NewUpdate(h, &ethpb.SyncCommittee{}, nil, h, nil, &ethpb.SyncAggregate{}, 5)
+		require.NoError(t, err)
+		require.Equal(t, v, u.Version())
+
+		wrapped, err := lightclient.NewWrappedUpdate(u.Proto())
+		require.NoError(t, err)
+		require.Equal(t, v, wrapped.Version())
+
+		finality, err := lightclient.NewFinalityUpdate(u)
+		require.NoError(t, err)
+		require.Equal(t, v, finality.Version())
+		optimistic, err := lightclient.NewOptimisticUpdate(u)
+		require.NoError(t, err)
+		require.Equal(t, v, optimistic.Version())
+	}
+
+	altair, err := lightclient.EmptyHeader(version.Altair)
+	require.NoError(t, err)
+	capella, err := lightclient.EmptyHeader(version.Capella)
+	require.NoError(t, err)
+	_, err = lightclient.NewUpdate(capella, nil, nil, altair, nil, nil, 0)
+	require.ErrorContains(t, "different versions", err)
+}
@@ -0,0 +1,450 @@
+package lightclient
+
+import (
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+	consensus_types "github.com/prysmaticlabs/prysm/v4/consensus-types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"google.golang.org/protobuf/proto"
+)
+
+var errMismatchedHeaderVersions = errors.New("light client headers have different versions")
+
+type bootstrap struct {
+	ssz.Marshaler
+	p                          proto.Message
+	header                     interfaces.LightClientHeader
+	currentSyncCommittee       *ethpb.SyncCommittee
+	currentSyncCommitteeBranch [][]byte
+}
+
+// NewWrappedBootstrap wraps an Altair, Capella or Deneb light client bootstrap protobuf object.
+func NewWrappedBootstrap(m proto.Message) (interfaces.LightClientBootstrap, error) {
+	var (
+		h         proto.Message
+		committee *ethpb.SyncCommittee
+		branch    [][]byte
+	)
+	switch b := m.(type) {
+	case *ethpb.LightClientBootstrap:
+		if b == nil {
+			return nil, consensus_types.ErrNilObjectWrapped
+		}
+		h, committee, branch = b.Header, b.CurrentSyncCommittee, b.CurrentSyncCommitteeBranch
+	case *ethpb.LightClientBootstrapCapella:
+		if b == nil {
+			return nil, consensus_types.ErrNilObjectWrapped
+		}
+		h, committee, branch = b.Header, b.CurrentSyncCommittee, b.CurrentSyncCommitteeBranch
+	case *ethpb.LightClientBootstrapDeneb:
+		if b == nil {
+			return nil, consensus_types.ErrNilObjectWrapped
+		}
+		h, committee, branch = b.Header, b.CurrentSyncCommittee, b.CurrentSyncCommitteeBranch
+	default:
+		return nil, errors.Errorf("unsupported light client bootstrap type %T", m)
+	}
+	header, err := NewWrappedHeader(h)
+	if err != nil {
+		return nil, err
+	}
+	return &bootstrap{
+		Marshaler:                  m.(ssz.Marshaler),
+		p:                          m,
+		header:                     header,
+		currentSyncCommittee:       committee,
+		currentSyncCommitteeBranch: branch,
+	}, nil
+}
+
+// NewBootstrap builds the light client bootstrap of the version of the given header.
+func NewBootstrap(header interfaces.LightClientHeader, committee *ethpb.SyncCommittee, branch [][]byte) (interfaces.LightClientBootstrap, error) {
+	switch h := header.Proto().(type) {
+	case *ethpb.LightClientHeader:
+		return NewWrappedBootstrap(&ethpb.LightClientBootstrap{Header: h, CurrentSyncCommittee: committee, CurrentSyncCommitteeBranch: branch})
+	case *ethpb.LightClientHeaderCapella:
+		return NewWrappedBootstrap(&ethpb.LightClientBootstrapCapella{Header: h, CurrentSyncCommittee: committee, CurrentSyncCommitteeBranch: branch})
+	case *ethpb.LightClientHeaderDeneb:
+		return NewWrappedBootstrap(&ethpb.LightClientBootstrapDeneb{Header: h, CurrentSyncCommittee: committee, CurrentSyncCommitteeBranch: branch})
+	default:
+		return nil, errors.Errorf("unsupported light client header type %T", h)
+	}
+}
+
+// Version returns the version of the light client bootstrap.
+func (b *bootstrap) Version() int {
+	return b.header.Version()
+}
+
+// Proto returns the underlying protobuf object.
+func (b *bootstrap) Proto() proto.Message {
+	return b.p
+}
+
+// Header returns the header of the trusted block.
+func (b *bootstrap) Header() interfaces.LightClientHeader {
+	return b.header
+}
+
+// CurrentSyncCommittee returns the sync committee of the period of the trusted block.
+func (b *bootstrap) CurrentSyncCommittee() *ethpb.SyncCommittee {
+	return b.currentSyncCommittee
+}
+
+// CurrentSyncCommitteeBranch returns the Merkle branch of the current sync committee within the state.
+func (b *bootstrap) CurrentSyncCommitteeBranch() [][]byte {
+	return b.currentSyncCommitteeBranch
+}
+
+type update struct {
+	ssz.Marshaler
+	p                       proto.Message
+	attestedHeader          interfaces.LightClientHeader
+	nextSyncCommittee       *ethpb.SyncCommittee
+	nextSyncCommitteeBranch [][]byte
+	finalizedHeader         interfaces.LightClientHeader
+	finalityBranch          [][]byte
+	syncAggregate           *ethpb.SyncAggregate
+	signatureSlot           primitives.Slot
+}
+
+// NewWrappedUpdate wraps an Altair, Capella or Deneb light client update protobuf object.
+func NewWrappedUpdate(m proto.Message) (interfaces.LightClientUpdate, error) {
+	u := &update{p: m}
+	var attested, finalized proto.Message
+	switch p := m.(type) {
+	case *ethpb.LightClientUpdate:
+		if p == nil {
+			return nil, consensus_types.ErrNilObjectWrapped
+		}
+		attested, finalized = p.AttestedHeader, p.FinalizedHeader
+		u.nextSyncCommittee, u.nextSyncCommitteeBranch = p.NextSyncCommittee, p.NextSyncCommitteeBranch
+		u.finalityBranch, u.syncAggregate, u.signatureSlot = p.FinalityBranch, p.SyncAggregate, p.SignatureSlot
+	case *ethpb.LightClientUpdateCapella:
+		if p == nil {
+			return nil, consensus_types.ErrNilObjectWrapped
+		}
+		attested, finalized = p.AttestedHeader, p.FinalizedHeader
+		u.nextSyncCommittee, u.nextSyncCommitteeBranch = p.NextSyncCommittee, p.NextSyncCommitteeBranch
+		u.finalityBranch, u.syncAggregate, u.signatureSlot = p.FinalityBranch, p.SyncAggregate, p.SignatureSlot
+	case *ethpb.LightClientUpdateDeneb:
+		if p == nil {
+			return nil, consensus_types.ErrNilObjectWrapped
+		}
+		attested, finalized = p.AttestedHeader, p.FinalizedHeader
+		u.nextSyncCommittee, u.nextSyncCommitteeBranch = p.NextSyncCommittee, p.NextSyncCommitteeBranch
+		u.finalityBranch, u.syncAggregate, u.signatureSlot = p.FinalityBranch, p.SyncAggregate, p.SignatureSlot
+	default:
+		return nil, errors.Errorf("unsupported light client update type %T", m)
+	}
+	var err error
+	if u.attestedHeader, err = NewWrappedHeader(attested); err != nil {
+		return nil, err
+	}
+	if u.finalizedHeader, err = NewWrappedHeader(finalized); err != nil {
+		return nil, err
+	}
+	u.Marshaler = m.(ssz.Marshaler)
+	return u, nil
+}
+
+// NewUpdate builds the light client update of the version of the given headers, which must match.
+func NewUpdate(
+	attestedHeader interfaces.LightClientHeader,
+	nextSyncCommittee *ethpb.SyncCommittee,
+	nextSyncCommitteeBranch [][]byte,
+	finalizedHeader interfaces.LightClientHeader,
+	finalityBranch [][]byte,
+	syncAggregate *ethpb.SyncAggregate,
+	signatureSlot primitives.Slot,
+) (interfaces.LightClientUpdate, error) {
+	if attestedHeader.Version() != finalizedHeader.Version() {
+		return nil, errMismatchedHeaderVersions
+	}
+	switch attestedHeader.Version() {
+	case version.Altair:
+		return NewWrappedUpdate(&ethpb.LightClientUpdate{
+			AttestedHeader:          attestedHeader.Proto().(*ethpb.LightClientHeader),
+			NextSyncCommittee:       nextSyncCommittee,
+			NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+			FinalizedHeader:         finalizedHeader.Proto().(*ethpb.LightClientHeader),
+			FinalityBranch:          finalityBranch,
+			SyncAggregate:           syncAggregate,
+			SignatureSlot:           signatureSlot,
+		})
+	case version.Capella:
+		return NewWrappedUpdate(&ethpb.LightClientUpdateCapella{
+			AttestedHeader:          attestedHeader.Proto().(*ethpb.LightClientHeaderCapella),
+			NextSyncCommittee:       nextSyncCommittee,
+			NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+			FinalizedHeader:         finalizedHeader.Proto().(*ethpb.LightClientHeaderCapella),
+			FinalityBranch:          finalityBranch,
+			SyncAggregate:           syncAggregate,
+			SignatureSlot:           signatureSlot,
+		})
+	case version.Deneb:
+		return NewWrappedUpdate(&ethpb.LightClientUpdateDeneb{
+			AttestedHeader:          attestedHeader.Proto().(*ethpb.LightClientHeaderDeneb),
+			NextSyncCommittee:       nextSyncCommittee,
+			NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+			FinalizedHeader:         finalizedHeader.Proto().(*ethpb.LightClientHeaderDeneb),
+			FinalityBranch:          finalityBranch,
+			SyncAggregate:           syncAggregate,
+			SignatureSlot:           signatureSlot,
+		})
+	default:
+		return nil, errors.Errorf("unsupported light client version %s", version.String(attestedHeader.Version()))
+	}
+}
+
+// Version returns the version of the light client update.
+func (u *update) Version() int {
+	return u.attestedHeader.Version()
+}
+
+// Proto returns the underlying protobuf object.
+func (u *update) Proto() proto.Message {
+	return u.p
+}
+
+// AttestedHeader returns the header attested to by the sync committee.
+func (u *update) AttestedHeader() interfaces.LightClientHeader {
+	return u.attestedHeader
+}
+
+// NextSyncCommittee returns the next sync committee of the attested state.
+func (u *update) NextSyncCommittee() *ethpb.SyncCommittee {
+	return u.nextSyncCommittee
+}
+
+// NextSyncCommitteeBranch returns the Merkle branch of the next sync committee within the attested state.
+func (u *update) NextSyncCommitteeBranch() [][]byte {
+	return u.nextSyncCommitteeBranch
+}
+
+// FinalizedHeader returns the header of the block finalized by the attested state.
+func (u *update) FinalizedHeader() interfaces.LightClientHeader {
+	return u.finalizedHeader
+}
+
+// FinalityBranch returns the Merkle branch of the finalized root within the attested state.
+func (u *update) FinalityBranch() [][]byte {
+	return u.finalityBranch
+}
+
+// SyncAggregate returns the sync committee aggregate signature.
+func (u *update) SyncAggregate() *ethpb.SyncAggregate {
+	return u.syncAggregate
+}
+
+// SignatureSlot returns the slot at which the sync aggregate was signed.
+func (u *update) SignatureSlot() primitives.Slot {
+	return u.signatureSlot
+}
+
+type finalityUpdate struct {
+	ssz.Marshaler
+	p               proto.Message
+	attestedHeader  interfaces.LightClientHeader
+	finalizedHeader interfaces.LightClientHeader
+	finalityBranch  [][]byte
+	syncAggregate   *ethpb.SyncAggregate
+	signatureSlot   primitives.Slot
+}
+
+// NewWrappedFinalityUpdate wraps an Altair, Capella or Deneb light client finality update protobuf object.
+func NewWrappedFinalityUpdate(m proto.Message) (interfaces.LightClientFinalityUpdate, error) {
+	u := &finalityUpdate{p: m}
+	var attested, finalized proto.Message
+	switch p := m.(type) {
+	case *ethpb.LightClientFinalityUpdate:
+		if p == nil {
+			return nil, consensus_types.ErrNilObjectWrapped
+		}
+		attested, finalized = p.AttestedHeader, p.FinalizedHeader
+		u.finalityBranch, u.syncAggregate, u.signatureSlot = p.FinalityBranch, p.SyncAggregate, p.SignatureSlot
+	case *ethpb.LightClientFinalityUpdateCapella:
+		if p == nil {
+			return nil, consensus_types.ErrNilObjectWrapped
+		}
+		attested, finalized = p.AttestedHeader, p.FinalizedHeader
+		u.finalityBranch, u.syncAggregate, u.signatureSlot = p.FinalityBranch, p.SyncAggregate, p.SignatureSlot
+	case *ethpb.LightClientFinalityUpdateDeneb:
+		if p == nil {
+			return nil, consensus_types.ErrNilObjectWrapped
+		}
+		attested, finalized = p.AttestedHeader, p.FinalizedHeader
+		u.finalityBranch, u.syncAggregate, u.signatureSlot = p.FinalityBranch, p.SyncAggregate, p.SignatureSlot
+	default:
+		return nil, errors.Errorf("unsupported light client finality update type %T", m)
+	}
+	var err error
+	if u.attestedHeader, err = NewWrappedHeader(attested); err != nil {
+		return nil, err
+	}
+	if u.finalizedHeader, err = NewWrappedHeader(finalized); err != nil {
+		return nil, err
+	}
+	u.Marshaler = m.(ssz.Marshaler)
+	return u, nil
+}
+
+// NewFinalityUpdate builds the finality update of the same version from a light client update.
+func NewFinalityUpdate(u interfaces.LightClientUpdate) (interfaces.LightClientFinalityUpdate, error) {
+	switch u.Version() {
+	case version.Altair:
+		return NewWrappedFinalityUpdate(&ethpb.LightClientFinalityUpdate{
+			AttestedHeader:  u.AttestedHeader().Proto().(*ethpb.LightClientHeader),
+			FinalizedHeader: u.FinalizedHeader().Proto().(*ethpb.LightClientHeader),
+			FinalityBranch:  u.FinalityBranch(),
+			SyncAggregate:   u.SyncAggregate(),
+			SignatureSlot:   u.SignatureSlot(),
+		})
+	case version.Capella:
+		return NewWrappedFinalityUpdate(&ethpb.LightClientFinalityUpdateCapella{
+			AttestedHeader:  u.AttestedHeader().Proto().(*ethpb.LightClientHeaderCapella),
+			FinalizedHeader: u.FinalizedHeader().Proto().(*ethpb.LightClientHeaderCapella),
+			FinalityBranch:  u.FinalityBranch(),
+			SyncAggregate:   u.SyncAggregate(),
+			SignatureSlot:   u.SignatureSlot(),
+		})
+	case version.Deneb:
+		return NewWrappedFinalityUpdate(&ethpb.LightClientFinalityUpdateDeneb{
+			AttestedHeader:  u.AttestedHeader().Proto().(*ethpb.LightClientHeaderDeneb),
+			FinalizedHeader: u.FinalizedHeader().Proto().(*ethpb.LightClientHeaderDeneb),
+			FinalityBranch:  u.FinalityBranch(),
+			SyncAggregate:   u.SyncAggregate(),
+			SignatureSlot:   u.SignatureSlot(),
+		})
+	default:
+		return nil, errors.Errorf("unsupported light client version %s", version.String(u.Version()))
+	}
+}
+
+// Version returns the version of the light client finality update.
+func (u *finalityUpdate) Version() int {
+	return u.attestedHeader.Version()
+}
+
+// Proto returns the underlying protobuf object.
+func (u *finalityUpdate) Proto() proto.Message {
+	return u.p
+}
+
+// AttestedHeader returns the header attested to by the sync committee.
+func (u *finalityUpdate) AttestedHeader() interfaces.LightClientHeader {
+	return u.attestedHeader
+}
+
+// FinalizedHeader returns the header of the block finalized by the attested state.
+func (u *finalityUpdate) FinalizedHeader() interfaces.LightClientHeader {
+	return u.finalizedHeader
+}
+
+// FinalityBranch returns the Merkle branch of the finalized root within the attested state.
+func (u *finalityUpdate) FinalityBranch() [][]byte {
+	return u.finalityBranch
+}
+
+// SyncAggregate returns the sync committee aggregate signature.
+func (u *finalityUpdate) SyncAggregate() *ethpb.SyncAggregate {
+	return u.syncAggregate
+}
+
+// SignatureSlot returns the slot at which the sync aggregate was signed.
+func (u *finalityUpdate) SignatureSlot() primitives.Slot {
+	return u.signatureSlot
+}
+
+type optimisticUpdate struct {
+	ssz.Marshaler
+	p              proto.Message
+	attestedHeader interfaces.LightClientHeader
+	syncAggregate  *ethpb.SyncAggregate
+	signatureSlot  primitives.Slot
+}
+
+// NewWrappedOptimisticUpdate wraps an Altair, Capella or Deneb light client optimistic update protobuf object.
+func NewWrappedOptimisticUpdate(m proto.Message) (interfaces.LightClientOptimisticUpdate, error) {
+	u := &optimisticUpdate{p: m}
+	var attested proto.Message
+	switch p := m.(type) {
+	case *ethpb.LightClientOptimisticUpdate:
+		if p == nil {
+			return nil, consensus_types.ErrNilObjectWrapped
+		}
+		attested, u.syncAggregate, u.signatureSlot = p.AttestedHeader, p.SyncAggregate, p.SignatureSlot
+	case *ethpb.LightClientOptimisticUpdateCapella:
+		if p == nil {
+			return nil, consensus_types.ErrNilObjectWrapped
+		}
+		attested, u.syncAggregate, u.signatureSlot = p.AttestedHeader, p.SyncAggregate, p.SignatureSlot
+	case *ethpb.LightClientOptimisticUpdateDeneb:
+		if p == nil {
+			return nil, consensus_types.ErrNilObjectWrapped
+		}
+		attested, u.syncAggregate, u.signatureSlot = p.AttestedHeader, p.SyncAggregate, p.SignatureSlot
+	default:
+		return nil, errors.Errorf("unsupported light client optimistic update type %T", m)
+	}
+	var err error
+	if u.attestedHeader, err = NewWrappedHeader(attested); err != nil {
+		return nil, err
+	}
+	u.Marshaler = m.(ssz.Marshaler)
+	return u, nil
+}
+
+// NewOptimisticUpdate builds the optimistic update of the same version from a light client update.
+func NewOptimisticUpdate(u interfaces.LightClientUpdate) (interfaces.LightClientOptimisticUpdate, error) {
+	switch u.Version() {
+	case version.Altair:
+		return NewWrappedOptimisticUpdate(&ethpb.LightClientOptimisticUpdate{
+			AttestedHeader: u.AttestedHeader().Proto().(*ethpb.LightClientHeader),
+			SyncAggregate:  u.SyncAggregate(),
+			SignatureSlot:  u.SignatureSlot(),
+		})
+	case version.Capella:
+		return NewWrappedOptimisticUpdate(&ethpb.LightClientOptimisticUpdateCapella{
+			AttestedHeader: u.AttestedHeader().Proto().(*ethpb.LightClientHeaderCapella),
+			SyncAggregate:  u.SyncAggregate(),
+			SignatureSlot:  u.SignatureSlot(),
+		})
+	case version.Deneb:
+		return NewWrappedOptimisticUpdate(&ethpb.LightClientOptimisticUpdateDeneb{
+			AttestedHeader: u.AttestedHeader().Proto().(*ethpb.LightClientHeaderDeneb),
+			SyncAggregate:  u.SyncAggregate(),
+			SignatureSlot:  u.SignatureSlot(),
+		})
+	default:
+		return nil, errors.Errorf("unsupported light client version %s", version.String(u.Version()))
+	}
+}
+
+// Version returns the version of the light client optimistic update.
+func (u *optimisticUpdate) Version() int {
+	return u.attestedHeader.Version()
+}
+
+// Proto returns the underlying protobuf object.
+func (u *optimisticUpdate) Proto() proto.Message {
+	return u.p
+}
+
+// AttestedHeader returns the header attested to by the sync committee.
+func (u *optimisticUpdate) AttestedHeader() interfaces.LightClientHeader {
+	return u.attestedHeader
+}
+
+// SyncAggregate returns the sync committee aggregate signature.
+func (u *optimisticUpdate) SyncAggregate() *ethpb.SyncAggregate {
+	return u.syncAggregate
+}
+
+// SignatureSlot returns the slot at which the sync aggregate was signed.
+func (u *optimisticUpdate) SignatureSlot() primitives.Slot {
+	return u.signatureSlot
+}
@@ -135,6 +135,26 @@ func TestPayloadAttributeGetters(t *testing.T) {
 				require.DeepEqual(t, p, got)
 			},
 		},
+		{
+			name: "Get PbDeneb",
+			tc: func(t *testing.T) {
+				p := &enginev1.PayloadAttributesV3{
+					Timestamp:             1,
+					PrevRandao:            []byte{1, 2, 3},
+					SuggestedFeeRecipient: []byte{4, 5, 6},
+					Withdrawals:           []*enginev1.Withdrawal{{Index: 1}, {Index: 2}, {Index: 3}},
+					ParentBeaconBlockRoot: []byte{7, 8, 9},
+				}
+				a, err := New(p)
+				require.NoError(t, err)
+				got, err := a.PbV3()
+				require.NoError(t, err)
+				require.DeepEqual(t, p, got)
+				r, err := a.ParentBeaconBlockRoot()
+				require.NoError(t, err)
+				require.DeepEqual(t, []byte{7, 8, 9}, r)
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -28,9 +28,9 @@ type Collector struct {
 
 // NewCollector creates a new Collector. When new buckets are created within
 // the Collector, they will be assigned the capacity and rate of the Collector.
-// A Collector does not provide a way to change the rate or capacity of
-// bucket's within it. If different rates or capacities are required, either
-// use multiple Collector's or manage your own LeakyBucket's.
+// The rate and capacity of all the bucket's within it can be changed with
+// SetLimits. If different rates or capacities are required, either use
+// multiple Collector's or manage your own LeakyBucket's.
 //
 // If deleteEmptyBuckets is true, a concurrent goroutine will be run that
 // watches for bucket's that become empty and automatically removes them,
@@ -73,19 +73,46 @@ func (c *Collector) Reset() {
 
 // Capacity returns the collector's capacity.
 func (c *Collector) Capacity() int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	return c.capacity
 }
 
 // Rate returns the collector's rate.
 func (c *Collector) Rate() float64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	return c.rate
 }
 
+// Period returns the collector's period.
+func (c *Collector) Period() time.Duration {
+	return c.period
+}
+
+// SetLimits changes the rate and the capacity of the collector, and of all
+// the internal buckets. The count of each bucket is preserved, up to the new
+// capacity.
+func (c *Collector) SetLimits(rate float64, capacity int64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.rate = rate
+	c.capacity = capacity
+	for _, b := range c.buckets {
+		b.ChangeRate(rate)
+		b.ChangeCapacity(capacity)
+		heap.Fix(&c.heap, b.index)
+	}
+}
+
 // Remaining returns the remaining capacity of the internal bucket associated
 // with key.  If key is not associated with a bucket internally, it is treated
 // as being empty.
 func (c *Collector) Remaining(key string) int64 {
-	return c.capacity - c.Count(key)
+	return c.Capacity() - c.Count(key)
 }
 
 // Count returns the count of the internal bucket associated with key. If key
@@ -102,6 +129,21 @@ func (c *Collector) Count(key string) int64 {
 	return b.Count()
 }
 
+// Counts returns the count of all the internal buckets which are not empty,
+// by key.
+func (c *Collector) Counts() map[string]int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	counts := make(map[string]int64, len(c.buckets))
+	for key, b := range c.buckets {
+		if count := b.Count(); count > 0 {
+			counts[key] = count
+		}
+	}
+	return counts
+}
+
 // TillEmpty returns how much time must pass until the internal bucket
 // associated with key is empty. If key is not associated with a bucket
 // internally, it is treated as being empty.
@@ -208,3 +208,48 @@ func TestPeriodicPrune(t *testing.T) {
 
 	c.Free()
 }
+
+func TestCollector_SetLimits(t *testing.T) {
+	setElapsed(0)
+	key := "127.0.0.1"
+	c := NewCollector(1, 10, time.Second, false)
+	c.Add(key, 6)
+
+	c.SetLimits(2, 4)
+	if c.Rate() != 2 || c.Capacity() != 4 {
+		t.Fatal("Limits not changed?!")
+	}
+	if c.Count(key) != 4 {
+		t.Fatalf("Count not capped to the new capacity: %d", c.Count(key))
+	}
+	setElapsed(time.Second)
+	if c.Count(key) != 2 {
+		t.Fatalf("Count not leaking at the new rate: %d", c.Count(key))
+	}
+
+	c.SetLimits(1, 4)
+	setElapsed(2 * time.Second)
+	if c.Count(key) != 1 {
+		t.Fatalf("Count not preserved across a rate change: %d", c.Count(key))
+	}
+	if n := c.Add("other", 10); n != 4 {
+		t.Fatalf("New bucket not created with the new capacity: %d", n)
+	}
+}
+
+func TestCollector_Counts(t *testing.T) {
+	setElapsed(0)
+	c := NewCollector(1, 10, time.Second, false)
+	c.Add("a", 3)
+	c.Add("b", 1)
+
+	counts := c.Counts()
+	if len(counts) != 2 || counts["a"] != 3 || counts["b"] != 1 {
+		t.Fatalf("Wrong counts: %v", counts)
+	}
+	setElapsed(2 * time.Second)
+	counts = c.Counts()
+	if len(counts) != 1 || counts["a"] != 1 {
+		t.Fatalf("Empty buckets not omitted: %v", counts)
+	}
+}
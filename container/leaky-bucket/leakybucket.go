@@ -125,6 +125,17 @@ func (b *LeakyBucket) ChangeCapacity(capacity int64) {
 	b.capacity = capacity
 }
 
+// ChangeRate changes the bucket's rate.
+//
+// The current count of the bucket is preserved, and leaks at the new rate.
+func (b *LeakyBucket) ChangeRate(rate float64) {
+	if now().Before(b.p) {
+		remaining := float64(b.p.Sub(now()))
+		b.p = now().Add(time.Duration(remaining * b.rate / rate))
+	}
+	b.rate = rate
+}
+
 // TillEmpty returns how much time must pass until the bucket is empty.
 func (b *LeakyBucket) TillEmpty() time.Duration {
 	return b.p.Sub(now())
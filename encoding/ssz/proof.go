@@ -0,0 +1,134 @@
+package ssz
+
+import (
+	"math/bits"
+
+	"github.com/minio/sha256-simd"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/container/trie"
+	"github.com/prysmaticlabs/prysm/v4/crypto/hash/htr"
+)
+
+// Proof is a Merkle proof of a 32 byte chunk of an SSZ object. The generalized index identifies
+// the chunk in the Merkle tree of the object, and the branch lists the sibling nodes on the path
+// from the chunk to the root, starting from the bottom of the tree.
+type Proof struct {
+	GeneralizedIndex uint64
+	Leaf             [32]byte
+	Branch           [][32]byte
+}
+
+// Verify checks that the proof is valid for an object with the given hash tree root.
+func (p *Proof) Verify(root [32]byte) bool {
+	if p == nil {
+		return false
+	}
+	return VerifyMerkleBranch(root, p.Leaf, p.GeneralizedIndex, p.Branch)
+}
+
+// Nest turns a proof of a chunk of an object into a proof of the same chunk in a parent object,
+// given the generalized index and the branch of the object's root in the Merkle tree of the parent.
+func (p *Proof) Nest(gindex uint64, branch [][32]byte) (*Proof, error) {
+	if gindex == 0 || uint64(len(branch)) != GeneralizedIndexDepth(gindex) {
+		return nil, errors.Errorf("branch of length %d does not match generalized index %d", len(branch), gindex)
+	}
+	nested, err := ConcatGeneralizedIndices(gindex, p.GeneralizedIndex)
+	if err != nil {
+		return nil, err
+	}
+	fullBranch := make([][32]byte, 0, len(p.Branch)+len(branch))
+	fullBranch = append(fullBranch, p.Branch...)
+	fullBranch = append(fullBranch, branch...)
+	return &Proof{GeneralizedIndex: nested, Leaf: p.Leaf, Branch: fullBranch}, nil
+}
+
+// VectorProof builds the proof of the chunk at the given index of a vector of chunks, which is
+// padded with zero chunks up to the limit when merkleized. A container is proven as the vector of
+// the roots of its fields.
+func VectorProof(chunks [][32]byte, limit, index uint64) (*Proof, error) {
+	if uint64(len(chunks)) > limit {
+		return nil, errors.Errorf("%d chunks exceed the limit of %d", len(chunks), limit)
+	}
+	if index >= uint64(len(chunks)) {
+		return nil, errors.Errorf("index %d out of range for %d chunks", index, len(chunks))
+	}
+	depth := Depth(limit)
+	branch := make([][32]byte, depth)
+	layer := make([][32]byte, len(chunks), len(chunks)+1)
+	copy(layer, chunks)
+	for i, idx := uint8(0), index; i < depth; i, idx = i+1, idx/2 {
+		if sibling := idx ^ 1; sibling < uint64(len(layer)) {
+			branch[i] = layer[sibling]
+		} else {
+			branch[i] = trie.ZeroHashes[i]
+		}
+		if len(layer)%2 == 1 {
+			layer = append(layer, trie.ZeroHashes[i])
+		}
+		htr.VectorizedSha256(layer, layer)
+		layer = layer[:len(layer)/2]
+	}
+	return &Proof{
+		GeneralizedIndex: uint64(1)<<depth + index,
+		Leaf:             chunks[index],
+		Branch:           branch,
+	}, nil
+}
+
+// ListProof builds the proof of the chunk at the given index of a list, for which the length is
+// mixed in the root. The length is the number of elements of the list, which differs from the
+// number of chunks for lists of packed basic types.
+func ListProof(chunks [][32]byte, limit, length, index uint64) (*Proof, error) {
+	p, err := VectorProof(chunks, limit, index)
+	if err != nil {
+		return nil, err
+	}
+	return p.Nest(2, [][32]byte{Uint64Root(length)})
+}
+
+// GeneralizedIndexDepth returns the depth of the node with the given generalized index, which is
+// also the length of its Merkle branch. The root has generalized index 1 and depth 0.
+func GeneralizedIndexDepth(gindex uint64) uint64 {
+	if gindex == 0 {
+		return 0
+	}
+	return uint64(bits.Len64(gindex) - 1)
+}
+
+// ConcatGeneralizedIndices returns the generalized index of a node given the generalized indices
+// along its path, each of them relative to the subtree rooted at the node of the previous one.
+func ConcatGeneralizedIndices(indices ...uint64) (uint64, error) {
+	result := uint64(1)
+	for _, index := range indices {
+		if index == 0 {
+			return 0, errors.New("generalized index cannot be 0")
+		}
+		depth := GeneralizedIndexDepth(index)
+		if GeneralizedIndexDepth(result)+depth >= 64 {
+			return 0, errors.New("generalized index overflows uint64")
+		}
+		result = result<<depth | (index ^ (1 << depth))
+	}
+	return result, nil
+}
+
+// VerifyMerkleBranch checks that the branch proves the leaf at the given generalized index in a
+// Merkle tree with the given root, as defined by is_valid_merkle_branch in the consensus specs.
+func VerifyMerkleBranch(root, leaf [32]byte, gindex uint64, branch [][32]byte) bool {
+	if gindex == 0 || uint64(len(branch)) != GeneralizedIndexDepth(gindex) {
+		return false
+	}
+	node := leaf
+	buf := make([]byte, 64)
+	for i, sibling := range branch {
+		if (gindex>>uint(i))&1 == 1 {
+			copy(buf[:32], sibling[:])
+			copy(buf[32:], node[:])
+		} else {
+			copy(buf[:32], node[:])
+			copy(buf[32:], sibling[:])
+		}
+		node = sha256.Sum256(buf)
+	}
+	return node == root
+}
@@ -0,0 +1,112 @@
+package ssz_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func testChunks(n int) [][32]byte {
+	chunks := make([][32]byte, n)
+	for i := range chunks {
+		chunks[i][0] = byte(i + 1)
+	}
+	return chunks
+}
+
+func TestVectorProof(t *testing.T) {
+	for _, count := range []int{1, 2, 5, 8, 17} {
+		chunks := testChunks(count)
+		limit := uint64(32)
+		root, err := ssz.BitwiseMerkleize(testChunks(count), uint64(count), limit)
+		require.NoError(t, err)
+		for i := 0; i < count; i++ {
+			p, err := ssz.VectorProof(chunks, limit, uint64(i))
+			require.NoError(t, err)
+			assert.Equal(t, uint64(32+i), p.GeneralizedIndex)
+			assert.Equal(t, chunks[i], p.Leaf)
+			assert.Equal(t, true, p.Verify(root), "invalid proof of chunk %d of %d", i, count)
+		}
+	}
+
+	_, err := ssz.VectorProof(testChunks(3), 2, 0)
+	require.ErrorContains(t, "exceed the limit", err)
+	_, err = ssz.VectorProof(testChunks(3), 4, 3)
+	require.ErrorContains(t, "out of range", err)
+}
+
+func TestListProof(t *testing.T) {
+	chunks := testChunks(5)
+	limit := uint64(1024)
+	root, err := ssz.BitwiseMerkleize(testChunks(5), 5, limit)
+	require.NoError(t, err)
+	length := ssz.Uint64Root(5)
+	root = ssz.MixInLength(root, length[:])
+
+	p, err := ssz.ListProof(chunks, limit, 5, 4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2*1024+4), p.GeneralizedIndex)
+	assert.Equal(t, true, p.Verify(root))
+
+	p.Leaf[0]++
+	assert.Equal(t, false, p.Verify(root))
+}
+
+func TestProof_Nest(t *testing.T) {
+	inner := testChunks(4)
+	innerRoot, err := ssz.BitwiseMerkleize(testChunks(4), 4, 4)
+	require.NoError(t, err)
+	outer := testChunks(3)
+	outer[2] = innerRoot
+	outerRoot, err := ssz.BitwiseMerkleize(append(testChunks(2), innerRoot), 3, 4)
+	require.NoError(t, err)
+
+	innerProof, err := ssz.VectorProof(inner, 4, 1)
+	require.NoError(t, err)
+	outerProof, err := ssz.VectorProof(outer, 4, 2)
+	require.NoError(t, err)
+	p, err := innerProof.Nest(outerProof.GeneralizedIndex, outerProof.Branch)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0b11001), p.GeneralizedIndex)
+	assert.Equal(t, inner[1], p.Leaf)
+	assert.Equal(t, true, p.Verify(outerRoot))
+
+	_, err = innerProof.Nest(outerProof.GeneralizedIndex, outerProof.Branch[1:])
+	require.ErrorContains(t, "does not match generalized index", err)
+}
+
+func TestConcatGeneralizedIndices(t *testing.T) {
+	gindex, err := ssz.ConcatGeneralizedIndices(6, 5, 2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0b110010), gindex)
+
+	gindex, err = ssz.ConcatGeneralizedIndices()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), gindex)
+
+	_, err = ssz.ConcatGeneralizedIndices(3, 0)
+	require.ErrorContains(t, "cannot be 0", err)
+	_, err = ssz.ConcatGeneralizedIndices(1<<40, 1<<30)
+	require.ErrorContains(t, "overflows", err)
+}
+
+func TestGeneralizedIndexDepth(t *testing.T) {
+	assert.Equal(t, uint64(0), ssz.GeneralizedIndexDepth(1))
+	assert.Equal(t, uint64(1), ssz.GeneralizedIndexDepth(3))
+	assert.Equal(t, uint64(5), ssz.GeneralizedIndexDepth(34))
+}
+
+func TestVerifyMerkleBranch(t *testing.T) {
+	chunks := testChunks(4)
+	root, err := ssz.BitwiseMerkleize(testChunks(4), 4, 4)
+	require.NoError(t, err)
+	p, err := ssz.VectorProof(chunks, 4, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, true, ssz.VerifyMerkleBranch(root, chunks[2], 6, p.Branch))
+	assert.Equal(t, false, ssz.VerifyMerkleBranch(root, chunks[2], 7, p.Branch))
+	assert.Equal(t, false, ssz.VerifyMerkleBranch(root, chunks[2], 14, p.Branch))
+	assert.Equal(t, false, ssz.VerifyMerkleBranch(root, chunks[2], 0, nil))
+}
@@ -0,0 +1,26 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opencensus.io/plugin/ochttp"
+	"go.opencensus.io/plugin/ochttp/propagation/tracecontext"
+)
+
+// NewHandler wraps an HTTP handler so that every request is served in a span, which continues
+// the trace of the caller when the request carries a W3C trace context.
+func NewHandler(handler http.Handler) http.Handler {
+	return &ochttp.Handler{
+		Handler:     handler,
+		Propagation: &tracecontext.HTTPFormat{},
+	}
+}
+
+// NewTransport wraps an HTTP round tripper so that every request is sent in a span, whose W3C trace
+// context is propagated to the server. The default transport is used when base is nil.
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	return &ochttp.Transport{
+		Base:        base,
+		Propagation: &tracecontext.HTTPFormat{},
+	}
+}
@@ -0,0 +1,320 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	otlpExportTimeout = 10 * time.Second
+	// otlpHTTPTracesPath is the default path of the OTLP over HTTP traces endpoint of a collector.
+	otlpHTTPTracesPath = "/v1/traces"
+	// otlpGRPCExportMethod is the export method of the OTLP trace collector gRPC service.
+	otlpGRPCExportMethod = "/opentelemetry.proto.collector.trace.v1.TraceService/Export"
+)
+
+// otlpClient uploads spans to an OpenTelemetry collector.
+//
+// TODO: Replace this client with the otlptracegrpc and otlptracehttp exporters. They require
+// go.opentelemetry.io/proto/otlp v0.19.0 or later, which uses grpc-gateway v2 APIs missing from
+// the github.com/prysmaticlabs/grpc-gateway/v2 fork this module is pinned to, see
+// https://github.com/prysmaticlabs/grpc-gateway/issues/2. For the same reason the collector
+// package of go.opentelemetry.io/proto/otlp is not imported, and the spans are sent as TracesData,
+// which is encoded the same way as the ExportTraceServiceRequest of the collector.
+type otlpClient interface {
+	upload(ctx context.Context, req *tracepb.TracesData) error
+	stop() error
+}
+
+// otlpExporter is a span exporter sending the spans of the tracer provider to an OpenTelemetry
+// collector with the OTLP protocol, either over gRPC or over HTTP.
+type otlpExporter struct {
+	client otlpClient
+}
+
+var _ sdktrace.SpanExporter = (*otlpExporter)(nil)
+
+func newOTLPExporter(exporter string, u *url.URL) (*otlpExporter, error) {
+	switch exporter {
+	case OTLPGRPCExporter:
+		creds := insecure.NewCredentials()
+		if u.Scheme == "https" {
+			creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+		}
+		conn, err := grpc.Dial(u.Host, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not dial OTLP endpoint %s", u.Host)
+		}
+		return &otlpExporter{client: &otlpGRPCClient{conn: conn}}, nil
+	case OTLPHTTPExporter:
+		endpoint := *u
+		if endpoint.Path == "" || endpoint.Path == "/" {
+			endpoint.Path = otlpHTTPTracesPath
+		}
+		return &otlpExporter{client: &otlpHTTPClient{url: endpoint.String(), client: &http.Client{Timeout: otlpExportTimeout}}}, nil
+	default:
+		return nil, fmt.Errorf("unknown OTLP exporter %q", exporter)
+	}
+}
+
+// ExportSpans converts the spans to their OTLP representation and uploads them to the collector.
+func (e *otlpExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, otlpExportTimeout)
+	defer cancel()
+	return e.client.upload(ctx, &tracepb.TracesData{ResourceSpans: otlpResourceSpans(spans)})
+}
+
+// Shutdown closes the connection to the collector.
+func (e *otlpExporter) Shutdown(_ context.Context) error {
+	return e.client.stop()
+}
+
+type otlpGRPCClient struct {
+	conn *grpc.ClientConn
+}
+
+// upload calls the export method of the collector. The response is decoded into an empty message,
+// as its fields are not used.
+func (c *otlpGRPCClient) upload(ctx context.Context, req *tracepb.TracesData) error {
+	return c.conn.Invoke(ctx, otlpGRPCExportMethod, req, &emptypb.Empty{})
+}
+
+func (c *otlpGRPCClient) stop() error {
+	return c.conn.Close()
+}
+
+type otlpHTTPClient struct {
+	url    string
+	client *http.Client
+}
+
+func (c *otlpHTTPClient) upload(ctx context.Context, req *tracepb.TracesData) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close OTLP response body")
+		}
+	}()
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("OTLP endpoint %s returned status %s", c.url, resp.Status)
+	}
+	return nil
+}
+
+func (c *otlpHTTPClient) stop() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+// otlpResourceSpans groups the spans by resource and instrumentation scope.
+func otlpResourceSpans(spans []sdktrace.ReadOnlySpan) []*tracepb.ResourceSpans {
+	type scopeKey struct {
+		res     attribute.Distinct
+		name    string
+		version string
+	}
+	resources := make(map[attribute.Distinct]*tracepb.ResourceSpans)
+	scopes := make(map[scopeKey]*tracepb.ScopeSpans)
+	var result []*tracepb.ResourceSpans
+	for _, sd := range spans {
+		if sd == nil {
+			continue
+		}
+		res := sd.Resource()
+		resKey := res.Equivalent()
+		rs, ok := resources[resKey]
+		if !ok {
+			rs = &tracepb.ResourceSpans{Resource: otlpResource(res)}
+			resources[resKey] = rs
+			result = append(result, rs)
+		}
+		scope := sd.InstrumentationScope()
+		key := scopeKey{res: resKey, name: scope.Name, version: scope.Version}
+		ss, ok := scopes[key]
+		if !ok {
+			ss = &tracepb.ScopeSpans{
+				Scope: &commonpb.InstrumentationScope{Name: scope.Name, Version: scope.Version},
+			}
+			scopes[key] = ss
+			rs.ScopeSpans = append(rs.ScopeSpans, ss)
+		}
+		ss.Spans = append(ss.Spans, otlpSpan(sd))
+	}
+	return result
+}
+
+func otlpResource(res *resource.Resource) *resourcepb.Resource {
+	if res == nil {
+		return &resourcepb.Resource{}
+	}
+	return &resourcepb.Resource{Attributes: otlpAttributes(res.Attributes())}
+}
+
+func otlpSpan(sd sdktrace.ReadOnlySpan) *tracepb.Span {
+	sc := sd.SpanContext()
+	traceID := sc.TraceID()
+	spanID := sc.SpanID()
+	s := &tracepb.Span{
+		TraceId:                traceID[:],
+		SpanId:                 spanID[:],
+		TraceState:             sc.TraceState().String(),
+		Name:                   sd.Name(),
+		Kind:                   otlpSpanKind(sd.SpanKind()),
+		StartTimeUnixNano:      uint64(sd.StartTime().UnixNano()),
+		EndTimeUnixNano:        uint64(sd.EndTime().UnixNano()),
+		Attributes:             otlpAttributes(sd.Attributes()),
+		DroppedAttributesCount: uint32(sd.DroppedAttributes()),
+		DroppedEventsCount:     uint32(sd.DroppedEvents()),
+		DroppedLinksCount:      uint32(sd.DroppedLinks()),
+		Status:                 otlpStatus(sd.Status()),
+	}
+	if parent := sd.Parent(); parent.SpanID().IsValid() {
+		parentID := parent.SpanID()
+		s.ParentSpanId = parentID[:]
+	}
+	for _, e := range sd.Events() {
+		s.Events = append(s.Events, &tracepb.Span_Event{
+			TimeUnixNano:           uint64(e.Time.UnixNano()),
+			Name:                   e.Name,
+			Attributes:             otlpAttributes(e.Attributes),
+			DroppedAttributesCount: uint32(e.DroppedAttributeCount),
+		})
+	}
+	for _, l := range sd.Links() {
+		linkTraceID := l.SpanContext.TraceID()
+		linkSpanID := l.SpanContext.SpanID()
+		s.Links = append(s.Links, &tracepb.Span_Link{
+			TraceId:                linkTraceID[:],
+			SpanId:                 linkSpanID[:],
+			TraceState:             l.SpanContext.TraceState().String(),
+			Attributes:             otlpAttributes(l.Attributes),
+			DroppedAttributesCount: uint32(l.DroppedAttributeCount),
+		})
+	}
+	return s
+}
+
+func otlpSpanKind(kind trace.SpanKind) tracepb.Span_SpanKind {
+	switch kind {
+	case trace.SpanKindInternal:
+		return tracepb.Span_SPAN_KIND_INTERNAL
+	case trace.SpanKindServer:
+		return tracepb.Span_SPAN_KIND_SERVER
+	case trace.SpanKindClient:
+		return tracepb.Span_SPAN_KIND_CLIENT
+	case trace.SpanKindProducer:
+		return tracepb.Span_SPAN_KIND_PRODUCER
+	case trace.SpanKindConsumer:
+		return tracepb.Span_SPAN_KIND_CONSUMER
+	default:
+		return tracepb.Span_SPAN_KIND_UNSPECIFIED
+	}
+}
+
+func otlpStatus(status sdktrace.Status) *tracepb.Status {
+	s := &tracepb.Status{Message: status.Description}
+	switch status.Code {
+	case codes.Ok:
+		s.Code = tracepb.Status_STATUS_CODE_OK
+	case codes.Error:
+		s.Code = tracepb.Status_STATUS_CODE_ERROR
+	default:
+		s.Code = tracepb.Status_STATUS_CODE_UNSET
+	}
+	return s
+}
+
+func otlpAttributes(attrs []attribute.KeyValue) []*commonpb.KeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	kvs := make([]*commonpb.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		kvs = append(kvs, &commonpb.KeyValue{Key: string(a.Key), Value: otlpValue(a.Value)})
+	}
+	return kvs
+}
+
+func otlpValue(v attribute.Value) *commonpb.AnyValue {
+	switch v.Type() {
+	case attribute.BOOL:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v.AsBool()}}
+	case attribute.INT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v.AsInt64()}}
+	case attribute.FLOAT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v.AsFloat64()}}
+	case attribute.BOOLSLICE:
+		values := v.AsBoolSlice()
+		array := make([]*commonpb.AnyValue, len(values))
+		for i, b := range values {
+			array[i] = &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: b}}
+		}
+		return otlpArray(array)
+	case attribute.INT64SLICE:
+		values := v.AsInt64Slice()
+		array := make([]*commonpb.AnyValue, len(values))
+		for i, n := range values {
+			array[i] = &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: n}}
+		}
+		return otlpArray(array)
+	case attribute.FLOAT64SLICE:
+		values := v.AsFloat64Slice()
+		array := make([]*commonpb.AnyValue, len(values))
+		for i, f := range values {
+			array[i] = &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: f}}
+		}
+		return otlpArray(array)
+	case attribute.STRINGSLICE:
+		values := v.AsStringSlice()
+		array := make([]*commonpb.AnyValue, len(values))
+		for i, str := range values {
+			array[i] = &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: str}}
+		}
+		return otlpArray(array)
+	default:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.Emit()}}
+	}
+}
+
+func otlpArray(values []*commonpb.AnyValue) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: values}}}
+}
@@ -0,0 +1,89 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	u, err := parseEndpoint("https://collector:4317")
+	require.NoError(t, err)
+	assert.Equal(t, "https", u.Scheme)
+	assert.Equal(t, "collector:4317", u.Host)
+
+	u, err = parseEndpoint("127.0.0.1:4318")
+	require.NoError(t, err)
+	assert.Equal(t, "http", u.Scheme)
+	assert.Equal(t, "127.0.0.1:4318", u.Host)
+
+	_, err = parseEndpoint("local host:4317")
+	require.ErrorContains(t, "invalid tracing endpoint", err)
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	err := Setup("beacon-chain", "process", "zipkin", "", 1, true)
+	require.ErrorContains(t, "unknown tracing exporter", err)
+}
+
+func TestOTLPExporter_HTTP(t *testing.T) {
+	received := make(chan *tracepb.TracesData, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, otlpHTTPTracesPath, r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := &tracepb.TracesData{}
+		require.NoError(t, proto.Unmarshal(body, req))
+		received <- req
+	}))
+	defer srv.Close()
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	exporter, err := newOTLPExporter(OTLPHTTPExporter, u)
+	require.NoError(t, err)
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	_, child := tp.Tracer("test").Start(ctx, "child")
+	child.SetAttributes(attribute.Int64("slot", 32), attribute.StringSlice("roots", []string{"a", "b"}))
+	child.End()
+	parent.End()
+
+	require.NoError(t, exporter.ExportSpans(context.Background(), recorder.Ended()))
+	require.NoError(t, exporter.Shutdown(context.Background()))
+
+	req := <-received
+	require.Equal(t, 1, len(req.ResourceSpans))
+	require.Equal(t, 1, len(req.ResourceSpans[0].ScopeSpans))
+	scope := req.ResourceSpans[0].ScopeSpans[0]
+	assert.Equal(t, "test", scope.Scope.Name)
+	require.Equal(t, 2, len(scope.Spans))
+	exportedChild, exportedParent := scope.Spans[0], scope.Spans[1]
+	assert.Equal(t, "child", exportedChild.Name)
+	assert.Equal(t, "parent", exportedParent.Name)
+	assert.DeepEqual(t, exportedParent.TraceId, exportedChild.TraceId)
+	assert.DeepEqual(t, exportedParent.SpanId, exportedChild.ParentSpanId)
+	assert.Equal(t, 0, len(exportedParent.ParentSpanId))
+	assert.Equal(t, tracepb.Span_SPAN_KIND_INTERNAL, exportedChild.Kind)
+	require.Equal(t, 2, len(exportedChild.Attributes))
+	assert.Equal(t, "slot", exportedChild.Attributes[0].Key)
+	assert.Equal(t, int64(32), exportedChild.Attributes[0].Value.GetIntValue())
+	assert.DeepEqual(t, []*commonpb.AnyValue{
+		{Value: &commonpb.AnyValue_StringValue{StringValue: "a"}},
+		{Value: &commonpb.AnyValue_StringValue{StringValue: "b"}},
+	}, exportedChild.Attributes[1].Value.GetArrayValue().Values)
+}
@@ -1,20 +1,50 @@
-// Package tracing sets up jaeger as an opentracing tool
-// for services in Prysm.
+// Package tracing sets up the export of traces to Jaeger or to an OpenTelemetry
+// collector for services in Prysm.
 package tracing
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/url"
 
 	"contrib.go.opencensus.io/exporter/jaeger"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/bridge/opencensus"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 )
 
 var log = logrus.WithField("prefix", "tracing")
 
-// Setup creates and initializes a new tracing configuration..
-func Setup(serviceName, processName, endpoint string, sampleFraction float64, enable bool) error {
+// Supported trace exporters.
+const (
+	// JaegerExporter exports traces to a Jaeger collector through the OpenCensus Jaeger exporter.
+	JaegerExporter = "jaeger"
+	// OTLPGRPCExporter exports traces to an OpenTelemetry collector with OTLP over gRPC.
+	OTLPGRPCExporter = "otlp-grpc"
+	// OTLPHTTPExporter exports traces to an OpenTelemetry collector with OTLP over HTTP.
+	OTLPHTTPExporter = "otlp-http"
+)
+
+// Default endpoints of the trace exporters, used when no endpoint is given.
+const (
+	defaultJaegerEndpoint   = "http://127.0.0.1:14268/api/traces"
+	defaultOTLPGRPCEndpoint = "http://127.0.0.1:4317"
+	defaultOTLPHTTPEndpoint = "http://127.0.0.1:4318"
+)
+
+var tracerProvider *sdktrace.TracerProvider
+
+// Setup creates and initializes a new tracing configuration. With an OTLP exporter, the spans
+// created with OpenCensus throughout Prysm are bridged to an OpenTelemetry tracer provider.
+func Setup(serviceName, processName, exporter, endpoint string, sampleFraction float64, enable bool) error {
 	if !enable {
 		trace.ApplyConfig(trace.Config{DefaultSampler: trace.NeverSample()})
 		return nil
@@ -24,6 +54,28 @@ func Setup(serviceName, processName, endpoint string, sampleFraction float64, en
 		return errors.New("tracing service name cannot be empty")
 	}
 
+	switch exporter {
+	case JaegerExporter, "":
+		return setupJaeger(serviceName, processName, endpoint, sampleFraction)
+	case OTLPGRPCExporter, OTLPHTTPExporter:
+		return setupOTLP(serviceName, processName, exporter, endpoint, sampleFraction)
+	default:
+		return fmt.Errorf("unknown tracing exporter %q, expected one of %s, %s or %s", exporter, JaegerExporter, OTLPGRPCExporter, OTLPHTTPExporter)
+	}
+}
+
+// Shutdown flushes the spans which were not exported yet and stops the OpenTelemetry tracer provider, if any.
+func Shutdown(ctx context.Context) error {
+	if tracerProvider == nil {
+		return nil
+	}
+	return tracerProvider.Shutdown(ctx)
+}
+
+func setupJaeger(serviceName, processName, endpoint string, sampleFraction float64) error {
+	if endpoint == "" {
+		endpoint = defaultJaegerEndpoint
+	}
 	trace.ApplyConfig(trace.Config{
 		DefaultSampler:          trace.ProbabilitySampler(sampleFraction),
 		MaxMessageEventsPerSpan: 500,
@@ -51,3 +103,63 @@ func Setup(serviceName, processName, endpoint string, sampleFraction float64, en
 
 	return nil
 }
+
+func setupOTLP(serviceName, processName, exporterName, endpoint string, sampleFraction float64) error {
+	if endpoint == "" {
+		endpoint = defaultOTLPGRPCEndpoint
+		if exporterName == OTLPHTTPExporter {
+			endpoint = defaultOTLPHTTPEndpoint
+		}
+	}
+	u, err := parseEndpoint(endpoint)
+	if err != nil {
+		return err
+	}
+
+	exporter, err := newOTLPExporter(exporterName, u)
+	if err != nil {
+		return err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceNameKey.String(serviceName),
+		semconv.ServiceVersionKey.String(version.Version()),
+		attribute.String("process_name", processName),
+	))
+	if err != nil {
+		return err
+	}
+
+	limits := sdktrace.NewSpanLimits()
+	limits.EventCountLimit = 500
+	log.Infof("Starting OpenTelemetry %s exporter endpoint at address = %s", exporterName, endpoint)
+	tracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter, sdktrace.WithMaxQueueSize(10000)),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleFraction))),
+		sdktrace.WithSpanLimits(limits),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.WithError(err).Error("Could not process span")
+	}))
+	// Spans are sampled by the tracer provider, the OpenCensus sampler is not used by the bridge.
+	trace.DefaultTracer = opencensus.NewTracer(tracerProvider.Tracer("github.com/prysmaticlabs/prysm"))
+	return nil
+}
+
+// parseEndpoint parses an exporter endpoint given either as a URL or as host:port, in which case
+// a plain text connection is assumed.
+func parseEndpoint(endpoint string) (*url.URL, error) {
+	u, err := url.Parse(endpoint)
+	if err == nil && u.Host != "" {
+		return u, nil
+	}
+	u, err = url.Parse("http://" + endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid tracing endpoint %q", endpoint)
+	}
+	return u, nil
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
 	"github.com/prysmaticlabs/prysm/v4/network/authorization"
 	log "github.com/sirupsen/logrus"
 )
@@ -132,7 +133,10 @@ func NewExecutionRPCClient(ctx context.Context, endpoint Endpoint) (*gethRPC.Cli
 	}
 	switch u.Scheme {
 	case "http", "https":
-		client, err = gethRPC.DialOptions(ctx, endpoint.Url, gethRPC.WithHTTPClient(endpoint.HttpClient()))
+		// Copy the client so that the trace context is propagated without altering the shared default client.
+		httpClient := *endpoint.HttpClient()
+		httpClient.Transport = tracing.NewTransport(httpClient.Transport)
+		client, err = gethRPC.DialOptions(ctx, endpoint.Url, gethRPC.WithHTTPClient(&httpClient))
 		if err != nil {
 			return nil, err
 		}
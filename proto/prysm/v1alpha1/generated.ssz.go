@@ -1,5 +1,5 @@
 // Code generated by fastssz. DO NOT EDIT.
-// Hash: da030c0ebc8b54fe7980b6bbe6d6795c74bab4f92ca977b2bc1c6d4fcf4ed25f
+// Hash: be5500a15d5584b4a726500a9e29f3417b5cb7b14fcaf2c98a33c886433b54be
 package eth
 
 import (
@@ -6291,188 +6291,218 @@ func (b *BlindedBeaconBlockBodyCapella) HashTreeRootWith(hh *ssz.Hasher) (err er
 	return
 }
 
-// MarshalSSZ ssz marshals the ValidatorRegistrationV1 object
-func (v *ValidatorRegistrationV1) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(v)
+// MarshalSSZ ssz marshals the SignedBeaconBlockContentsDeneb object
+func (s *SignedBeaconBlockContentsDeneb) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(s)
 }
 
-// MarshalSSZTo ssz marshals the ValidatorRegistrationV1 object to a target array
-func (v *ValidatorRegistrationV1) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+// MarshalSSZTo ssz marshals the SignedBeaconBlockContentsDeneb object to a target array
+func (s *SignedBeaconBlockContentsDeneb) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
+	offset := int(12)
 
-	// Field (0) 'FeeRecipient'
-	if size := len(v.FeeRecipient); size != 20 {
-		err = ssz.ErrBytesLengthFn("--.FeeRecipient", size, 20)
-		return
+	// Offset (0) 'Block'
+	dst = ssz.WriteOffset(dst, offset)
+	if s.Block == nil {
+		s.Block = new(SignedBeaconBlockDeneb)
 	}
-	dst = append(dst, v.FeeRecipient...)
+	offset += s.Block.SizeSSZ()
 
-	// Field (1) 'GasLimit'
-	dst = ssz.MarshalUint64(dst, v.GasLimit)
+	// Offset (1) 'KzgProofs'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(s.KzgProofs) * 48
 
-	// Field (2) 'Timestamp'
-	dst = ssz.MarshalUint64(dst, v.Timestamp)
+	// Offset (2) 'Blobs'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(s.Blobs) * 131072
 
-	// Field (3) 'Pubkey'
-	if size := len(v.Pubkey); size != 48 {
-		err = ssz.ErrBytesLengthFn("--.Pubkey", size, 48)
+	// Field (0) 'Block'
+	if dst, err = s.Block.MarshalSSZTo(dst); err != nil {
 		return
 	}
-	dst = append(dst, v.Pubkey...)
-
-	return
-}
 
-// UnmarshalSSZ ssz unmarshals the ValidatorRegistrationV1 object
-func (v *ValidatorRegistrationV1) UnmarshalSSZ(buf []byte) error {
-	var err error
-	size := uint64(len(buf))
-	if size != 84 {
-		return ssz.ErrSize
+	// Field (1) 'KzgProofs'
+	if size := len(s.KzgProofs); size > 4096 {
+		err = ssz.ErrListTooBigFn("--.KzgProofs", size, 4096)
+		return
 	}
-
-	// Field (0) 'FeeRecipient'
-	if cap(v.FeeRecipient) == 0 {
-		v.FeeRecipient = make([]byte, 0, len(buf[0:20]))
+	for ii := 0; ii < len(s.KzgProofs); ii++ {
+		if size := len(s.KzgProofs[ii]); size != 48 {
+			err = ssz.ErrBytesLengthFn("--.KzgProofs[ii]", size, 48)
+			return
+		}
+		dst = append(dst, s.KzgProofs[ii]...)
 	}
-	v.FeeRecipient = append(v.FeeRecipient, buf[0:20]...)
-
-	// Field (1) 'GasLimit'
-	v.GasLimit = ssz.UnmarshallUint64(buf[20:28])
-
-	// Field (2) 'Timestamp'
-	v.Timestamp = ssz.UnmarshallUint64(buf[28:36])
 
-	// Field (3) 'Pubkey'
-	if cap(v.Pubkey) == 0 {
-		v.Pubkey = make([]byte, 0, len(buf[36:84]))
+	// Field (2) 'Blobs'
+	if size := len(s.Blobs); size > 4096 {
+		err = ssz.ErrListTooBigFn("--.Blobs", size, 4096)
+		return
+	}
+	for ii := 0; ii < len(s.Blobs); ii++ {
+		if size := len(s.Blobs[ii]); size != 131072 {
+			err = ssz.ErrBytesLengthFn("--.Blobs[ii]", size, 131072)
+			return
+		}
+		dst = append(dst, s.Blobs[ii]...)
 	}
-	v.Pubkey = append(v.Pubkey, buf[36:84]...)
-
-	return err
-}
 
-// SizeSSZ returns the ssz encoded size in bytes for the ValidatorRegistrationV1 object
-func (v *ValidatorRegistrationV1) SizeSSZ() (size int) {
-	size = 84
 	return
 }
 
-// HashTreeRoot ssz hashes the ValidatorRegistrationV1 object
-func (v *ValidatorRegistrationV1) HashTreeRoot() ([32]byte, error) {
-	return ssz.HashWithDefaultHasher(v)
-}
-
-// HashTreeRootWith ssz hashes the ValidatorRegistrationV1 object with a hasher
-func (v *ValidatorRegistrationV1) HashTreeRootWith(hh *ssz.Hasher) (err error) {
-	indx := hh.Index()
-
-	// Field (0) 'FeeRecipient'
-	if size := len(v.FeeRecipient); size != 20 {
-		err = ssz.ErrBytesLengthFn("--.FeeRecipient", size, 20)
-		return
+// UnmarshalSSZ ssz unmarshals the SignedBeaconBlockContentsDeneb object
+func (s *SignedBeaconBlockContentsDeneb) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 12 {
+		return ssz.ErrSize
 	}
-	hh.PutBytes(v.FeeRecipient)
-
-	// Field (1) 'GasLimit'
-	hh.PutUint64(v.GasLimit)
 
-	// Field (2) 'Timestamp'
-	hh.PutUint64(v.Timestamp)
+	tail := buf
+	var o0, o1, o2 uint64
 
-	// Field (3) 'Pubkey'
-	if size := len(v.Pubkey); size != 48 {
-		err = ssz.ErrBytesLengthFn("--.Pubkey", size, 48)
-		return
+	// Offset (0) 'Block'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
 	}
-	hh.PutBytes(v.Pubkey)
 
-	if ssz.EnableVectorizedHTR {
-		hh.MerkleizeVectorizedHTR(indx)
-	} else {
-		hh.Merkleize(indx)
+	if o0 < 12 {
+		return ssz.ErrInvalidVariableOffset
 	}
-	return
-}
-
-// MarshalSSZ ssz marshals the SignedValidatorRegistrationV1 object
-func (s *SignedValidatorRegistrationV1) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(s)
-}
 
-// MarshalSSZTo ssz marshals the SignedValidatorRegistrationV1 object to a target array
-func (s *SignedValidatorRegistrationV1) MarshalSSZTo(buf []byte) (dst []byte, err error) {
-	dst = buf
+	// Offset (1) 'KzgProofs'
+	if o1 = ssz.ReadOffset(buf[4:8]); o1 > size || o0 > o1 {
+		return ssz.ErrOffset
+	}
 
-	// Field (0) 'Message'
-	if s.Message == nil {
-		s.Message = new(ValidatorRegistrationV1)
+	// Offset (2) 'Blobs'
+	if o2 = ssz.ReadOffset(buf[8:12]); o2 > size || o1 > o2 {
+		return ssz.ErrOffset
 	}
-	if dst, err = s.Message.MarshalSSZTo(dst); err != nil {
-		return
+
+	// Field (0) 'Block'
+	{
+		buf = tail[o0:o1]
+		if s.Block == nil {
+			s.Block = new(SignedBeaconBlockDeneb)
+		}
+		if err = s.Block.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
 	}
 
-	// Field (1) 'Signature'
-	if size := len(s.Signature); size != 96 {
-		err = ssz.ErrBytesLengthFn("--.Signature", size, 96)
-		return
+	// Field (1) 'KzgProofs'
+	{
+		buf = tail[o1:o2]
+		num, err := ssz.DivideInt2(len(buf), 48, 4096)
+		if err != nil {
+			return err
+		}
+		s.KzgProofs = make([][]byte, num)
+		for ii := 0; ii < num; ii++ {
+			if cap(s.KzgProofs[ii]) == 0 {
+				s.KzgProofs[ii] = make([]byte, 0, len(buf[ii*48:(ii+1)*48]))
+			}
+			s.KzgProofs[ii] = append(s.KzgProofs[ii], buf[ii*48:(ii+1)*48]...)
+		}
 	}
-	dst = append(dst, s.Signature...)
 
-	return
+	// Field (2) 'Blobs'
+	{
+		buf = tail[o2:]
+		num, err := ssz.DivideInt2(len(buf), 131072, 4096)
+		if err != nil {
+			return err
+		}
+		s.Blobs = make([][]byte, num)
+		for ii := 0; ii < num; ii++ {
+			if cap(s.Blobs[ii]) == 0 {
+				s.Blobs[ii] = make([]byte, 0, len(buf[ii*131072:(ii+1)*131072]))
+			}
+			s.Blobs[ii] = append(s.Blobs[ii], buf[ii*131072:(ii+1)*131072]...)
+		}
+	}
+	return err
 }
 
-// UnmarshalSSZ ssz unmarshals the SignedValidatorRegistrationV1 object
-func (s *SignedValidatorRegistrationV1) UnmarshalSSZ(buf []byte) error {
-	var err error
-	size := uint64(len(buf))
-	if size != 180 {
-		return ssz.ErrSize
-	}
+// SizeSSZ returns the ssz encoded size in bytes for the SignedBeaconBlockContentsDeneb object
+func (s *SignedBeaconBlockContentsDeneb) SizeSSZ() (size int) {
+	size = 12
 
-	// Field (0) 'Message'
-	if s.Message == nil {
-		s.Message = new(ValidatorRegistrationV1)
-	}
-	if err = s.Message.UnmarshalSSZ(buf[0:84]); err != nil {
-		return err
+	// Field (0) 'Block'
+	if s.Block == nil {
+		s.Block = new(SignedBeaconBlockDeneb)
 	}
+	size += s.Block.SizeSSZ()
 
-	// Field (1) 'Signature'
-	if cap(s.Signature) == 0 {
-		s.Signature = make([]byte, 0, len(buf[84:180]))
-	}
-	s.Signature = append(s.Signature, buf[84:180]...)
+	// Field (1) 'KzgProofs'
+	size += len(s.KzgProofs) * 48
 
-	return err
-}
+	// Field (2) 'Blobs'
+	size += len(s.Blobs) * 131072
 
-// SizeSSZ returns the ssz encoded size in bytes for the SignedValidatorRegistrationV1 object
-func (s *SignedValidatorRegistrationV1) SizeSSZ() (size int) {
-	size = 180
 	return
 }
 
-// HashTreeRoot ssz hashes the SignedValidatorRegistrationV1 object
-func (s *SignedValidatorRegistrationV1) HashTreeRoot() ([32]byte, error) {
+// HashTreeRoot ssz hashes the SignedBeaconBlockContentsDeneb object
+func (s *SignedBeaconBlockContentsDeneb) HashTreeRoot() ([32]byte, error) {
 	return ssz.HashWithDefaultHasher(s)
 }
 
-// HashTreeRootWith ssz hashes the SignedValidatorRegistrationV1 object with a hasher
-func (s *SignedValidatorRegistrationV1) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+// HashTreeRootWith ssz hashes the SignedBeaconBlockContentsDeneb object with a hasher
+func (s *SignedBeaconBlockContentsDeneb) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	indx := hh.Index()
 
-	// Field (0) 'Message'
-	if err = s.Message.HashTreeRootWith(hh); err != nil {
+	// Field (0) 'Block'
+	if err = s.Block.HashTreeRootWith(hh); err != nil {
 		return
 	}
 
-	// Field (1) 'Signature'
-	if size := len(s.Signature); size != 96 {
-		err = ssz.ErrBytesLengthFn("--.Signature", size, 96)
-		return
+	// Field (1) 'KzgProofs'
+	{
+		if size := len(s.KzgProofs); size > 4096 {
+			err = ssz.ErrListTooBigFn("--.KzgProofs", size, 4096)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range s.KzgProofs {
+			if len(i) != 48 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.PutBytes(i)
+		}
+
+		numItems := uint64(len(s.KzgProofs))
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, 4096)
+		} else {
+			hh.MerkleizeWithMixin(subIndx, numItems, 4096)
+		}
+	}
+
+	// Field (2) 'Blobs'
+	{
+		if size := len(s.Blobs); size > 4096 {
+			err = ssz.ErrListTooBigFn("--.Blobs", size, 4096)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range s.Blobs {
+			if len(i) != 131072 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.PutBytes(i)
+		}
+
+		numItems := uint64(len(s.Blobs))
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, 4096)
+		} else {
+			hh.MerkleizeWithMixin(subIndx, numItems, 4096)
+		}
 	}
-	hh.PutBytes(s.Signature)
 
 	if ssz.EnableVectorizedHTR {
 		hh.MerkleizeVectorizedHTR(indx)
@@ -6482,130 +6512,218 @@ func (s *SignedValidatorRegistrationV1) HashTreeRootWith(hh *ssz.Hasher) (err er
 	return
 }
 
-// MarshalSSZ ssz marshals the BuilderBid object
-func (b *BuilderBid) MarshalSSZ() ([]byte, error) {
+// MarshalSSZ ssz marshals the BeaconBlockContentsDeneb object
+func (b *BeaconBlockContentsDeneb) MarshalSSZ() ([]byte, error) {
 	return ssz.MarshalSSZ(b)
 }
 
-// MarshalSSZTo ssz marshals the BuilderBid object to a target array
-func (b *BuilderBid) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+// MarshalSSZTo ssz marshals the BeaconBlockContentsDeneb object to a target array
+func (b *BeaconBlockContentsDeneb) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
-	offset := int(84)
+	offset := int(12)
 
-	// Offset (0) 'Header'
+	// Offset (0) 'Block'
 	dst = ssz.WriteOffset(dst, offset)
-	if b.Header == nil {
-		b.Header = new(v1.ExecutionPayloadHeader)
+	if b.Block == nil {
+		b.Block = new(BeaconBlockDeneb)
 	}
-	offset += b.Header.SizeSSZ()
+	offset += b.Block.SizeSSZ()
 
-	// Field (1) 'Value'
-	if size := len(b.Value); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.Value", size, 32)
+	// Offset (1) 'KzgProofs'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.KzgProofs) * 48
+
+	// Offset (2) 'Blobs'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Blobs) * 131072
+
+	// Field (0) 'Block'
+	if dst, err = b.Block.MarshalSSZTo(dst); err != nil {
 		return
 	}
-	dst = append(dst, b.Value...)
 
-	// Field (2) 'Pubkey'
-	if size := len(b.Pubkey); size != 48 {
-		err = ssz.ErrBytesLengthFn("--.Pubkey", size, 48)
+	// Field (1) 'KzgProofs'
+	if size := len(b.KzgProofs); size > 4096 {
+		err = ssz.ErrListTooBigFn("--.KzgProofs", size, 4096)
 		return
 	}
-	dst = append(dst, b.Pubkey...)
+	for ii := 0; ii < len(b.KzgProofs); ii++ {
+		if size := len(b.KzgProofs[ii]); size != 48 {
+			err = ssz.ErrBytesLengthFn("--.KzgProofs[ii]", size, 48)
+			return
+		}
+		dst = append(dst, b.KzgProofs[ii]...)
+	}
 
-	// Field (0) 'Header'
-	if dst, err = b.Header.MarshalSSZTo(dst); err != nil {
+	// Field (2) 'Blobs'
+	if size := len(b.Blobs); size > 4096 {
+		err = ssz.ErrListTooBigFn("--.Blobs", size, 4096)
 		return
 	}
+	for ii := 0; ii < len(b.Blobs); ii++ {
+		if size := len(b.Blobs[ii]); size != 131072 {
+			err = ssz.ErrBytesLengthFn("--.Blobs[ii]", size, 131072)
+			return
+		}
+		dst = append(dst, b.Blobs[ii]...)
+	}
 
 	return
 }
 
-// UnmarshalSSZ ssz unmarshals the BuilderBid object
-func (b *BuilderBid) UnmarshalSSZ(buf []byte) error {
+// UnmarshalSSZ ssz unmarshals the BeaconBlockContentsDeneb object
+func (b *BeaconBlockContentsDeneb) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size < 84 {
+	if size < 12 {
 		return ssz.ErrSize
 	}
 
 	tail := buf
-	var o0 uint64
+	var o0, o1, o2 uint64
 
-	// Offset (0) 'Header'
+	// Offset (0) 'Block'
 	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
 		return ssz.ErrOffset
 	}
 
-	if o0 < 84 {
+	if o0 < 12 {
 		return ssz.ErrInvalidVariableOffset
 	}
 
-	// Field (1) 'Value'
-	if cap(b.Value) == 0 {
-		b.Value = make([]byte, 0, len(buf[4:36]))
+	// Offset (1) 'KzgProofs'
+	if o1 = ssz.ReadOffset(buf[4:8]); o1 > size || o0 > o1 {
+		return ssz.ErrOffset
 	}
-	b.Value = append(b.Value, buf[4:36]...)
 
-	// Field (2) 'Pubkey'
-	if cap(b.Pubkey) == 0 {
-		b.Pubkey = make([]byte, 0, len(buf[36:84]))
+	// Offset (2) 'Blobs'
+	if o2 = ssz.ReadOffset(buf[8:12]); o2 > size || o1 > o2 {
+		return ssz.ErrOffset
 	}
-	b.Pubkey = append(b.Pubkey, buf[36:84]...)
 
-	// Field (0) 'Header'
+	// Field (0) 'Block'
 	{
-		buf = tail[o0:]
-		if b.Header == nil {
-			b.Header = new(v1.ExecutionPayloadHeader)
+		buf = tail[o0:o1]
+		if b.Block == nil {
+			b.Block = new(BeaconBlockDeneb)
 		}
-		if err = b.Header.UnmarshalSSZ(buf); err != nil {
+		if err = b.Block.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+
+	// Field (1) 'KzgProofs'
+	{
+		buf = tail[o1:o2]
+		num, err := ssz.DivideInt2(len(buf), 48, 4096)
+		if err != nil {
+			return err
+		}
+		b.KzgProofs = make([][]byte, num)
+		for ii := 0; ii < num; ii++ {
+			if cap(b.KzgProofs[ii]) == 0 {
+				b.KzgProofs[ii] = make([]byte, 0, len(buf[ii*48:(ii+1)*48]))
+			}
+			b.KzgProofs[ii] = append(b.KzgProofs[ii], buf[ii*48:(ii+1)*48]...)
+		}
+	}
+
+	// Field (2) 'Blobs'
+	{
+		buf = tail[o2:]
+		num, err := ssz.DivideInt2(len(buf), 131072, 4096)
+		if err != nil {
 			return err
 		}
+		b.Blobs = make([][]byte, num)
+		for ii := 0; ii < num; ii++ {
+			if cap(b.Blobs[ii]) == 0 {
+				b.Blobs[ii] = make([]byte, 0, len(buf[ii*131072:(ii+1)*131072]))
+			}
+			b.Blobs[ii] = append(b.Blobs[ii], buf[ii*131072:(ii+1)*131072]...)
+		}
 	}
 	return err
 }
 
-// SizeSSZ returns the ssz encoded size in bytes for the BuilderBid object
-func (b *BuilderBid) SizeSSZ() (size int) {
-	size = 84
+// SizeSSZ returns the ssz encoded size in bytes for the BeaconBlockContentsDeneb object
+func (b *BeaconBlockContentsDeneb) SizeSSZ() (size int) {
+	size = 12
 
-	// Field (0) 'Header'
-	if b.Header == nil {
-		b.Header = new(v1.ExecutionPayloadHeader)
+	// Field (0) 'Block'
+	if b.Block == nil {
+		b.Block = new(BeaconBlockDeneb)
 	}
-	size += b.Header.SizeSSZ()
+	size += b.Block.SizeSSZ()
+
+	// Field (1) 'KzgProofs'
+	size += len(b.KzgProofs) * 48
+
+	// Field (2) 'Blobs'
+	size += len(b.Blobs) * 131072
 
 	return
 }
 
-// HashTreeRoot ssz hashes the BuilderBid object
-func (b *BuilderBid) HashTreeRoot() ([32]byte, error) {
+// HashTreeRoot ssz hashes the BeaconBlockContentsDeneb object
+func (b *BeaconBlockContentsDeneb) HashTreeRoot() ([32]byte, error) {
 	return ssz.HashWithDefaultHasher(b)
 }
 
-// HashTreeRootWith ssz hashes the BuilderBid object with a hasher
-func (b *BuilderBid) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+// HashTreeRootWith ssz hashes the BeaconBlockContentsDeneb object with a hasher
+func (b *BeaconBlockContentsDeneb) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	indx := hh.Index()
 
-	// Field (0) 'Header'
-	if err = b.Header.HashTreeRootWith(hh); err != nil {
+	// Field (0) 'Block'
+	if err = b.Block.HashTreeRootWith(hh); err != nil {
 		return
 	}
 
-	// Field (1) 'Value'
-	if size := len(b.Value); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.Value", size, 32)
-		return
+	// Field (1) 'KzgProofs'
+	{
+		if size := len(b.KzgProofs); size > 4096 {
+			err = ssz.ErrListTooBigFn("--.KzgProofs", size, 4096)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.KzgProofs {
+			if len(i) != 48 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.PutBytes(i)
+		}
+
+		numItems := uint64(len(b.KzgProofs))
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, 4096)
+		} else {
+			hh.MerkleizeWithMixin(subIndx, numItems, 4096)
+		}
 	}
-	hh.PutBytes(b.Value)
 
-	// Field (2) 'Pubkey'
-	if size := len(b.Pubkey); size != 48 {
-		err = ssz.ErrBytesLengthFn("--.Pubkey", size, 48)
-		return
+	// Field (2) 'Blobs'
+	{
+		if size := len(b.Blobs); size > 4096 {
+			err = ssz.ErrListTooBigFn("--.Blobs", size, 4096)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.Blobs {
+			if len(i) != 131072 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.PutBytes(i)
+		}
+
+		numItems := uint64(len(b.Blobs))
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, 4096)
+		} else {
+			hh.MerkleizeWithMixin(subIndx, numItems, 4096)
+		}
 	}
-	hh.PutBytes(b.Pubkey)
 
 	if ssz.EnableVectorizedHTR {
 		hh.MerkleizeVectorizedHTR(indx)
@@ -6615,130 +6733,110 @@ func (b *BuilderBid) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	return
 }
 
-// MarshalSSZ ssz marshals the BuilderBidCapella object
-func (b *BuilderBidCapella) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(b)
+// MarshalSSZ ssz marshals the SignedBeaconBlockDeneb object
+func (s *SignedBeaconBlockDeneb) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(s)
 }
 
-// MarshalSSZTo ssz marshals the BuilderBidCapella object to a target array
-func (b *BuilderBidCapella) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+// MarshalSSZTo ssz marshals the SignedBeaconBlockDeneb object to a target array
+func (s *SignedBeaconBlockDeneb) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
-	offset := int(84)
+	offset := int(100)
 
-	// Offset (0) 'Header'
+	// Offset (0) 'Block'
 	dst = ssz.WriteOffset(dst, offset)
-	if b.Header == nil {
-		b.Header = new(v1.ExecutionPayloadHeaderCapella)
-	}
-	offset += b.Header.SizeSSZ()
-
-	// Field (1) 'Value'
-	if size := len(b.Value); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.Value", size, 32)
-		return
+	if s.Block == nil {
+		s.Block = new(BeaconBlockDeneb)
 	}
-	dst = append(dst, b.Value...)
+	offset += s.Block.SizeSSZ()
 
-	// Field (2) 'Pubkey'
-	if size := len(b.Pubkey); size != 48 {
-		err = ssz.ErrBytesLengthFn("--.Pubkey", size, 48)
+	// Field (1) 'Signature'
+	if size := len(s.Signature); size != 96 {
+		err = ssz.ErrBytesLengthFn("--.Signature", size, 96)
 		return
 	}
-	dst = append(dst, b.Pubkey...)
+	dst = append(dst, s.Signature...)
 
-	// Field (0) 'Header'
-	if dst, err = b.Header.MarshalSSZTo(dst); err != nil {
+	// Field (0) 'Block'
+	if dst, err = s.Block.MarshalSSZTo(dst); err != nil {
 		return
 	}
 
 	return
 }
 
-// UnmarshalSSZ ssz unmarshals the BuilderBidCapella object
-func (b *BuilderBidCapella) UnmarshalSSZ(buf []byte) error {
+// UnmarshalSSZ ssz unmarshals the SignedBeaconBlockDeneb object
+func (s *SignedBeaconBlockDeneb) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size < 84 {
+	if size < 100 {
 		return ssz.ErrSize
 	}
 
 	tail := buf
 	var o0 uint64
 
-	// Offset (0) 'Header'
+	// Offset (0) 'Block'
 	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
 		return ssz.ErrOffset
 	}
 
-	if o0 < 84 {
+	if o0 < 100 {
 		return ssz.ErrInvalidVariableOffset
 	}
 
-	// Field (1) 'Value'
-	if cap(b.Value) == 0 {
-		b.Value = make([]byte, 0, len(buf[4:36]))
-	}
-	b.Value = append(b.Value, buf[4:36]...)
-
-	// Field (2) 'Pubkey'
-	if cap(b.Pubkey) == 0 {
-		b.Pubkey = make([]byte, 0, len(buf[36:84]))
+	// Field (1) 'Signature'
+	if cap(s.Signature) == 0 {
+		s.Signature = make([]byte, 0, len(buf[4:100]))
 	}
-	b.Pubkey = append(b.Pubkey, buf[36:84]...)
+	s.Signature = append(s.Signature, buf[4:100]...)
 
-	// Field (0) 'Header'
+	// Field (0) 'Block'
 	{
 		buf = tail[o0:]
-		if b.Header == nil {
-			b.Header = new(v1.ExecutionPayloadHeaderCapella)
+		if s.Block == nil {
+			s.Block = new(BeaconBlockDeneb)
 		}
-		if err = b.Header.UnmarshalSSZ(buf); err != nil {
+		if err = s.Block.UnmarshalSSZ(buf); err != nil {
 			return err
 		}
 	}
 	return err
 }
 
-// SizeSSZ returns the ssz encoded size in bytes for the BuilderBidCapella object
-func (b *BuilderBidCapella) SizeSSZ() (size int) {
-	size = 84
+// SizeSSZ returns the ssz encoded size in bytes for the SignedBeaconBlockDeneb object
+func (s *SignedBeaconBlockDeneb) SizeSSZ() (size int) {
+	size = 100
 
-	// Field (0) 'Header'
-	if b.Header == nil {
-		b.Header = new(v1.ExecutionPayloadHeaderCapella)
+	// Field (0) 'Block'
+	if s.Block == nil {
+		s.Block = new(BeaconBlockDeneb)
 	}
-	size += b.Header.SizeSSZ()
+	size += s.Block.SizeSSZ()
 
 	return
 }
 
-// HashTreeRoot ssz hashes the BuilderBidCapella object
-func (b *BuilderBidCapella) HashTreeRoot() ([32]byte, error) {
-	return ssz.HashWithDefaultHasher(b)
+// HashTreeRoot ssz hashes the SignedBeaconBlockDeneb object
+func (s *SignedBeaconBlockDeneb) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(s)
 }
 
-// HashTreeRootWith ssz hashes the BuilderBidCapella object with a hasher
-func (b *BuilderBidCapella) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+// HashTreeRootWith ssz hashes the SignedBeaconBlockDeneb object with a hasher
+func (s *SignedBeaconBlockDeneb) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	indx := hh.Index()
 
-	// Field (0) 'Header'
-	if err = b.Header.HashTreeRootWith(hh); err != nil {
-		return
-	}
-
-	// Field (1) 'Value'
-	if size := len(b.Value); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.Value", size, 32)
+	// Field (0) 'Block'
+	if err = s.Block.HashTreeRootWith(hh); err != nil {
 		return
 	}
-	hh.PutBytes(b.Value)
 
-	// Field (2) 'Pubkey'
-	if size := len(b.Pubkey); size != 48 {
-		err = ssz.ErrBytesLengthFn("--.Pubkey", size, 48)
+	// Field (1) 'Signature'
+	if size := len(s.Signature); size != 96 {
+		err = ssz.ErrBytesLengthFn("--.Signature", size, 96)
 		return
 	}
-	hh.PutBytes(b.Pubkey)
+	hh.PutBytes(s.Signature)
 
 	if ssz.EnableVectorizedHTR {
 		hh.MerkleizeVectorizedHTR(indx)
@@ -6748,112 +6846,148 @@ func (b *BuilderBidCapella) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	return
 }
 
-// MarshalSSZ ssz marshals the Deposit_Data object
-func (d *Deposit_Data) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(d)
+// MarshalSSZ ssz marshals the BeaconBlockDeneb object
+func (b *BeaconBlockDeneb) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
 }
 
-// MarshalSSZTo ssz marshals the Deposit_Data object to a target array
-func (d *Deposit_Data) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+// MarshalSSZTo ssz marshals the BeaconBlockDeneb object to a target array
+func (b *BeaconBlockDeneb) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
+	offset := int(84)
 
-	// Field (0) 'PublicKey'
-	if size := len(d.PublicKey); size != 48 {
-		err = ssz.ErrBytesLengthFn("--.PublicKey", size, 48)
+	// Field (0) 'Slot'
+	dst = ssz.MarshalUint64(dst, uint64(b.Slot))
+
+	// Field (1) 'ProposerIndex'
+	dst = ssz.MarshalUint64(dst, uint64(b.ProposerIndex))
+
+	// Field (2) 'ParentRoot'
+	if size := len(b.ParentRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.ParentRoot", size, 32)
 		return
 	}
-	dst = append(dst, d.PublicKey...)
+	dst = append(dst, b.ParentRoot...)
 
-	// Field (1) 'WithdrawalCredentials'
-	if size := len(d.WithdrawalCredentials); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.WithdrawalCredentials", size, 32)
+	// Field (3) 'StateRoot'
+	if size := len(b.StateRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.StateRoot", size, 32)
 		return
 	}
-	dst = append(dst, d.WithdrawalCredentials...)
+	dst = append(dst, b.StateRoot...)
 
-	// Field (2) 'Amount'
-	dst = ssz.MarshalUint64(dst, d.Amount)
+	// Offset (4) 'Body'
+	dst = ssz.WriteOffset(dst, offset)
+	if b.Body == nil {
+		b.Body = new(BeaconBlockBodyDeneb)
+	}
+	offset += b.Body.SizeSSZ()
 
-	// Field (3) 'Signature'
-	if size := len(d.Signature); size != 96 {
-		err = ssz.ErrBytesLengthFn("--.Signature", size, 96)
+	// Field (4) 'Body'
+	if dst, err = b.Body.MarshalSSZTo(dst); err != nil {
 		return
 	}
-	dst = append(dst, d.Signature...)
 
 	return
 }
 
-// UnmarshalSSZ ssz unmarshals the Deposit_Data object
-func (d *Deposit_Data) UnmarshalSSZ(buf []byte) error {
+// UnmarshalSSZ ssz unmarshals the BeaconBlockDeneb object
+func (b *BeaconBlockDeneb) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size != 184 {
+	if size < 84 {
 		return ssz.ErrSize
 	}
 
-	// Field (0) 'PublicKey'
-	if cap(d.PublicKey) == 0 {
-		d.PublicKey = make([]byte, 0, len(buf[0:48]))
-	}
-	d.PublicKey = append(d.PublicKey, buf[0:48]...)
+	tail := buf
+	var o4 uint64
 
-	// Field (1) 'WithdrawalCredentials'
-	if cap(d.WithdrawalCredentials) == 0 {
-		d.WithdrawalCredentials = make([]byte, 0, len(buf[48:80]))
-	}
-	d.WithdrawalCredentials = append(d.WithdrawalCredentials, buf[48:80]...)
+	// Field (0) 'Slot'
+	b.Slot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[0:8]))
 
-	// Field (2) 'Amount'
-	d.Amount = ssz.UnmarshallUint64(buf[80:88])
+	// Field (1) 'ProposerIndex'
+	b.ProposerIndex = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.ValidatorIndex(ssz.UnmarshallUint64(buf[8:16]))
 
-	// Field (3) 'Signature'
-	if cap(d.Signature) == 0 {
-		d.Signature = make([]byte, 0, len(buf[88:184]))
+	// Field (2) 'ParentRoot'
+	if cap(b.ParentRoot) == 0 {
+		b.ParentRoot = make([]byte, 0, len(buf[16:48]))
+	}
+	b.ParentRoot = append(b.ParentRoot, buf[16:48]...)
+
+	// Field (3) 'StateRoot'
+	if cap(b.StateRoot) == 0 {
+		b.StateRoot = make([]byte, 0, len(buf[48:80]))
+	}
+	b.StateRoot = append(b.StateRoot, buf[48:80]...)
+
+	// Offset (4) 'Body'
+	if o4 = ssz.ReadOffset(buf[80:84]); o4 > size {
+		return ssz.ErrOffset
 	}
-	d.Signature = append(d.Signature, buf[88:184]...)
 
+	if o4 < 84 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (4) 'Body'
+	{
+		buf = tail[o4:]
+		if b.Body == nil {
+			b.Body = new(BeaconBlockBodyDeneb)
+		}
+		if err = b.Body.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
 	return err
 }
 
-// SizeSSZ returns the ssz encoded size in bytes for the Deposit_Data object
-func (d *Deposit_Data) SizeSSZ() (size int) {
-	size = 184
+// SizeSSZ returns the ssz encoded size in bytes for the BeaconBlockDeneb object
+func (b *BeaconBlockDeneb) SizeSSZ() (size int) {
+	size = 84
+
+	// Field (4) 'Body'
+	if b.Body == nil {
+		b.Body = new(BeaconBlockBodyDeneb)
+	}
+	size += b.Body.SizeSSZ()
+
 	return
 }
 
-// HashTreeRoot ssz hashes the Deposit_Data object
-func (d *Deposit_Data) HashTreeRoot() ([32]byte, error) {
-	return ssz.HashWithDefaultHasher(d)
+// HashTreeRoot ssz hashes the BeaconBlockDeneb object
+func (b *BeaconBlockDeneb) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
 }
 
-// HashTreeRootWith ssz hashes the Deposit_Data object with a hasher
-func (d *Deposit_Data) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+// HashTreeRootWith ssz hashes the BeaconBlockDeneb object with a hasher
+func (b *BeaconBlockDeneb) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	indx := hh.Index()
 
-	// Field (0) 'PublicKey'
-	if size := len(d.PublicKey); size != 48 {
-		err = ssz.ErrBytesLengthFn("--.PublicKey", size, 48)
+	// Field (0) 'Slot'
+	hh.PutUint64(uint64(b.Slot))
+
+	// Field (1) 'ProposerIndex'
+	hh.PutUint64(uint64(b.ProposerIndex))
+
+	// Field (2) 'ParentRoot'
+	if size := len(b.ParentRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.ParentRoot", size, 32)
 		return
 	}
-	hh.PutBytes(d.PublicKey)
+	hh.PutBytes(b.ParentRoot)
 
-	// Field (1) 'WithdrawalCredentials'
-	if size := len(d.WithdrawalCredentials); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.WithdrawalCredentials", size, 32)
+	// Field (3) 'StateRoot'
+	if size := len(b.StateRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.StateRoot", size, 32)
 		return
 	}
-	hh.PutBytes(d.WithdrawalCredentials)
-
-	// Field (2) 'Amount'
-	hh.PutUint64(d.Amount)
+	hh.PutBytes(b.StateRoot)
 
-	// Field (3) 'Signature'
-	if size := len(d.Signature); size != 96 {
-		err = ssz.ErrBytesLengthFn("--.Signature", size, 96)
+	// Field (4) 'Body'
+	if err = b.Body.HashTreeRootWith(hh); err != nil {
 		return
 	}
-	hh.PutBytes(d.Signature)
 
 	if ssz.EnableVectorizedHTR {
 		hh.MerkleizeVectorizedHTR(indx)
@@ -6863,805 +6997,749 @@ func (d *Deposit_Data) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	return
 }
 
-// MarshalSSZ ssz marshals the BeaconState object
-func (b *BeaconState) MarshalSSZ() ([]byte, error) {
+// MarshalSSZ ssz marshals the BeaconBlockBodyDeneb object
+func (b *BeaconBlockBodyDeneb) MarshalSSZ() ([]byte, error) {
 	return ssz.MarshalSSZ(b)
 }
 
-// MarshalSSZTo ssz marshals the BeaconState object to a target array
-func (b *BeaconState) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+// MarshalSSZTo ssz marshals the BeaconBlockBodyDeneb object to a target array
+func (b *BeaconBlockBodyDeneb) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
-	offset := int(2687377)
-
-	// Field (0) 'GenesisTime'
-	dst = ssz.MarshalUint64(dst, b.GenesisTime)
+	offset := int(392)
 
-	// Field (1) 'GenesisValidatorsRoot'
-	if size := len(b.GenesisValidatorsRoot); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
+	// Field (0) 'RandaoReveal'
+	if size := len(b.RandaoReveal); size != 96 {
+		err = ssz.ErrBytesLengthFn("--.RandaoReveal", size, 96)
 		return
 	}
-	dst = append(dst, b.GenesisValidatorsRoot...)
-
-	// Field (2) 'Slot'
-	dst = ssz.MarshalUint64(dst, uint64(b.Slot))
+	dst = append(dst, b.RandaoReveal...)
 
-	// Field (3) 'Fork'
-	if b.Fork == nil {
-		b.Fork = new(Fork)
+	// Field (1) 'Eth1Data'
+	if b.Eth1Data == nil {
+		b.Eth1Data = new(Eth1Data)
 	}
-	if dst, err = b.Fork.MarshalSSZTo(dst); err != nil {
+	if dst, err = b.Eth1Data.MarshalSSZTo(dst); err != nil {
 		return
 	}
 
-	// Field (4) 'LatestBlockHeader'
-	if b.LatestBlockHeader == nil {
-		b.LatestBlockHeader = new(BeaconBlockHeader)
-	}
-	if dst, err = b.LatestBlockHeader.MarshalSSZTo(dst); err != nil {
+	// Field (2) 'Graffiti'
+	if size := len(b.Graffiti); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.Graffiti", size, 32)
 		return
 	}
+	dst = append(dst, b.Graffiti...)
 
-	// Field (5) 'BlockRoots'
-	if size := len(b.BlockRoots); size != 8192 {
-		err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
-		return
-	}
-	for ii := 0; ii < 8192; ii++ {
-		if size := len(b.BlockRoots[ii]); size != 32 {
-			err = ssz.ErrBytesLengthFn("--.BlockRoots[ii]", size, 32)
-			return
-		}
-		dst = append(dst, b.BlockRoots[ii]...)
-	}
+	// Offset (3) 'ProposerSlashings'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.ProposerSlashings) * 416
 
-	// Field (6) 'StateRoots'
-	if size := len(b.StateRoots); size != 8192 {
-		err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
-		return
+	// Offset (4) 'AttesterSlashings'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(b.AttesterSlashings); ii++ {
+		offset += 4
+		offset += b.AttesterSlashings[ii].SizeSSZ()
 	}
-	for ii := 0; ii < 8192; ii++ {
-		if size := len(b.StateRoots[ii]); size != 32 {
-			err = ssz.ErrBytesLengthFn("--.StateRoots[ii]", size, 32)
-			return
-		}
-		dst = append(dst, b.StateRoots[ii]...)
+
+	// Offset (5) 'Attestations'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(b.Attestations); ii++ {
+		offset += 4
+		offset += b.Attestations[ii].SizeSSZ()
 	}
 
-	// Offset (7) 'HistoricalRoots'
+	// Offset (6) 'Deposits'
 	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.HistoricalRoots) * 32
+	offset += len(b.Deposits) * 1240
 
-	// Field (8) 'Eth1Data'
-	if b.Eth1Data == nil {
-		b.Eth1Data = new(Eth1Data)
+	// Offset (7) 'VoluntaryExits'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.VoluntaryExits) * 112
+
+	// Field (8) 'SyncAggregate'
+	if b.SyncAggregate == nil {
+		b.SyncAggregate = new(SyncAggregate)
 	}
-	if dst, err = b.Eth1Data.MarshalSSZTo(dst); err != nil {
+	if dst, err = b.SyncAggregate.MarshalSSZTo(dst); err != nil {
 		return
 	}
 
-	// Offset (9) 'Eth1DataVotes'
+	// Offset (9) 'ExecutionPayload'
 	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.Eth1DataVotes) * 72
-
-	// Field (10) 'Eth1DepositIndex'
-	dst = ssz.MarshalUint64(dst, b.Eth1DepositIndex)
+	if b.ExecutionPayload == nil {
+		b.ExecutionPayload = new(v1.ExecutionPayloadDeneb)
+	}
+	offset += b.ExecutionPayload.SizeSSZ()
 
-	// Offset (11) 'Validators'
+	// Offset (10) 'BlsToExecutionChanges'
 	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.Validators) * 121
+	offset += len(b.BlsToExecutionChanges) * 172
 
-	// Offset (12) 'Balances'
+	// Offset (11) 'BlobKzgCommitments'
 	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.Balances) * 8
+	offset += len(b.BlobKzgCommitments) * 48
 
-	// Field (13) 'RandaoMixes'
-	if size := len(b.RandaoMixes); size != 65536 {
-		err = ssz.ErrVectorLengthFn("--.RandaoMixes", size, 65536)
+	// Field (3) 'ProposerSlashings'
+	if size := len(b.ProposerSlashings); size > 16 {
+		err = ssz.ErrListTooBigFn("--.ProposerSlashings", size, 16)
 		return
 	}
-	for ii := 0; ii < 65536; ii++ {
-		if size := len(b.RandaoMixes[ii]); size != 32 {
-			err = ssz.ErrBytesLengthFn("--.RandaoMixes[ii]", size, 32)
+	for ii := 0; ii < len(b.ProposerSlashings); ii++ {
+		if dst, err = b.ProposerSlashings[ii].MarshalSSZTo(dst); err != nil {
 			return
 		}
-		dst = append(dst, b.RandaoMixes[ii]...)
 	}
 
-	// Field (14) 'Slashings'
-	if size := len(b.Slashings); size != 8192 {
-		err = ssz.ErrVectorLengthFn("--.Slashings", size, 8192)
+	// Field (4) 'AttesterSlashings'
+	if size := len(b.AttesterSlashings); size > 2 {
+		err = ssz.ErrListTooBigFn("--.AttesterSlashings", size, 2)
 		return
 	}
-	for ii := 0; ii < 8192; ii++ {
-		dst = ssz.MarshalUint64(dst, b.Slashings[ii])
-	}
-
-	// Offset (15) 'PreviousEpochAttestations'
-	dst = ssz.WriteOffset(dst, offset)
-	for ii := 0; ii < len(b.PreviousEpochAttestations); ii++ {
-		offset += 4
-		offset += b.PreviousEpochAttestations[ii].SizeSSZ()
+	{
+		offset = 4 * len(b.AttesterSlashings)
+		for ii := 0; ii < len(b.AttesterSlashings); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += b.AttesterSlashings[ii].SizeSSZ()
+		}
 	}
-
-	// Offset (16) 'CurrentEpochAttestations'
-	dst = ssz.WriteOffset(dst, offset)
-	for ii := 0; ii < len(b.CurrentEpochAttestations); ii++ {
-		offset += 4
-		offset += b.CurrentEpochAttestations[ii].SizeSSZ()
+	for ii := 0; ii < len(b.AttesterSlashings); ii++ {
+		if dst, err = b.AttesterSlashings[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
 	}
 
-	// Field (17) 'JustificationBits'
-	if size := len(b.JustificationBits); size != 1 {
-		err = ssz.ErrBytesLengthFn("--.JustificationBits", size, 1)
+	// Field (5) 'Attestations'
+	if size := len(b.Attestations); size > 128 {
+		err = ssz.ErrListTooBigFn("--.Attestations", size, 128)
 		return
 	}
-	dst = append(dst, b.JustificationBits...)
-
-	// Field (18) 'PreviousJustifiedCheckpoint'
-	if b.PreviousJustifiedCheckpoint == nil {
-		b.PreviousJustifiedCheckpoint = new(Checkpoint)
+	{
+		offset = 4 * len(b.Attestations)
+		for ii := 0; ii < len(b.Attestations); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += b.Attestations[ii].SizeSSZ()
+		}
 	}
-	if dst, err = b.PreviousJustifiedCheckpoint.MarshalSSZTo(dst); err != nil {
-		return
+	for ii := 0; ii < len(b.Attestations); ii++ {
+		if dst, err = b.Attestations[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
 	}
 
-	// Field (19) 'CurrentJustifiedCheckpoint'
-	if b.CurrentJustifiedCheckpoint == nil {
-		b.CurrentJustifiedCheckpoint = new(Checkpoint)
-	}
-	if dst, err = b.CurrentJustifiedCheckpoint.MarshalSSZTo(dst); err != nil {
+	// Field (6) 'Deposits'
+	if size := len(b.Deposits); size > 16 {
+		err = ssz.ErrListTooBigFn("--.Deposits", size, 16)
 		return
 	}
-
-	// Field (20) 'FinalizedCheckpoint'
-	if b.FinalizedCheckpoint == nil {
-		b.FinalizedCheckpoint = new(Checkpoint)
-	}
-	if dst, err = b.FinalizedCheckpoint.MarshalSSZTo(dst); err != nil {
-		return
-	}
-
-	// Field (7) 'HistoricalRoots'
-	if size := len(b.HistoricalRoots); size > 16777216 {
-		err = ssz.ErrListTooBigFn("--.HistoricalRoots", size, 16777216)
-		return
-	}
-	for ii := 0; ii < len(b.HistoricalRoots); ii++ {
-		if size := len(b.HistoricalRoots[ii]); size != 32 {
-			err = ssz.ErrBytesLengthFn("--.HistoricalRoots[ii]", size, 32)
-			return
-		}
-		dst = append(dst, b.HistoricalRoots[ii]...)
-	}
-
-	// Field (9) 'Eth1DataVotes'
-	if size := len(b.Eth1DataVotes); size > 2048 {
-		err = ssz.ErrListTooBigFn("--.Eth1DataVotes", size, 2048)
-		return
-	}
-	for ii := 0; ii < len(b.Eth1DataVotes); ii++ {
-		if dst, err = b.Eth1DataVotes[ii].MarshalSSZTo(dst); err != nil {
+	for ii := 0; ii < len(b.Deposits); ii++ {
+		if dst, err = b.Deposits[ii].MarshalSSZTo(dst); err != nil {
 			return
 		}
 	}
 
-	// Field (11) 'Validators'
-	if size := len(b.Validators); size > 1099511627776 {
-		err = ssz.ErrListTooBigFn("--.Validators", size, 1099511627776)
+	// Field (7) 'VoluntaryExits'
+	if size := len(b.VoluntaryExits); size > 16 {
+		err = ssz.ErrListTooBigFn("--.VoluntaryExits", size, 16)
 		return
 	}
-	for ii := 0; ii < len(b.Validators); ii++ {
-		if dst, err = b.Validators[ii].MarshalSSZTo(dst); err != nil {
+	for ii := 0; ii < len(b.VoluntaryExits); ii++ {
+		if dst, err = b.VoluntaryExits[ii].MarshalSSZTo(dst); err != nil {
 			return
 		}
 	}
 
-	// Field (12) 'Balances'
-	if size := len(b.Balances); size > 1099511627776 {
-		err = ssz.ErrListTooBigFn("--.Balances", size, 1099511627776)
+	// Field (9) 'ExecutionPayload'
+	if dst, err = b.ExecutionPayload.MarshalSSZTo(dst); err != nil {
 		return
 	}
-	for ii := 0; ii < len(b.Balances); ii++ {
-		dst = ssz.MarshalUint64(dst, b.Balances[ii])
-	}
 
-	// Field (15) 'PreviousEpochAttestations'
-	if size := len(b.PreviousEpochAttestations); size > 4096 {
-		err = ssz.ErrListTooBigFn("--.PreviousEpochAttestations", size, 4096)
+	// Field (10) 'BlsToExecutionChanges'
+	if size := len(b.BlsToExecutionChanges); size > 16 {
+		err = ssz.ErrListTooBigFn("--.BlsToExecutionChanges", size, 16)
 		return
 	}
-	{
-		offset = 4 * len(b.PreviousEpochAttestations)
-		for ii := 0; ii < len(b.PreviousEpochAttestations); ii++ {
-			dst = ssz.WriteOffset(dst, offset)
-			offset += b.PreviousEpochAttestations[ii].SizeSSZ()
-		}
-	}
-	for ii := 0; ii < len(b.PreviousEpochAttestations); ii++ {
-		if dst, err = b.PreviousEpochAttestations[ii].MarshalSSZTo(dst); err != nil {
+	for ii := 0; ii < len(b.BlsToExecutionChanges); ii++ {
+		if dst, err = b.BlsToExecutionChanges[ii].MarshalSSZTo(dst); err != nil {
 			return
 		}
 	}
 
-	// Field (16) 'CurrentEpochAttestations'
-	if size := len(b.CurrentEpochAttestations); size > 4096 {
-		err = ssz.ErrListTooBigFn("--.CurrentEpochAttestations", size, 4096)
+	// Field (11) 'BlobKzgCommitments'
+	if size := len(b.BlobKzgCommitments); size > 4096 {
+		err = ssz.ErrListTooBigFn("--.BlobKzgCommitments", size, 4096)
 		return
 	}
-	{
-		offset = 4 * len(b.CurrentEpochAttestations)
-		for ii := 0; ii < len(b.CurrentEpochAttestations); ii++ {
-			dst = ssz.WriteOffset(dst, offset)
-			offset += b.CurrentEpochAttestations[ii].SizeSSZ()
-		}
-	}
-	for ii := 0; ii < len(b.CurrentEpochAttestations); ii++ {
-		if dst, err = b.CurrentEpochAttestations[ii].MarshalSSZTo(dst); err != nil {
+	for ii := 0; ii < len(b.BlobKzgCommitments); ii++ {
+		if size := len(b.BlobKzgCommitments[ii]); size != 48 {
+			err = ssz.ErrBytesLengthFn("--.BlobKzgCommitments[ii]", size, 48)
 			return
 		}
+		dst = append(dst, b.BlobKzgCommitments[ii]...)
 	}
 
 	return
 }
 
-// UnmarshalSSZ ssz unmarshals the BeaconState object
-func (b *BeaconState) UnmarshalSSZ(buf []byte) error {
+// UnmarshalSSZ ssz unmarshals the BeaconBlockBodyDeneb object
+func (b *BeaconBlockBodyDeneb) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size < 2687377 {
+	if size < 392 {
 		return ssz.ErrSize
 	}
 
 	tail := buf
-	var o7, o9, o11, o12, o15, o16 uint64
-
-	// Field (0) 'GenesisTime'
-	b.GenesisTime = ssz.UnmarshallUint64(buf[0:8])
-
-	// Field (1) 'GenesisValidatorsRoot'
-	if cap(b.GenesisValidatorsRoot) == 0 {
-		b.GenesisValidatorsRoot = make([]byte, 0, len(buf[8:40]))
-	}
-	b.GenesisValidatorsRoot = append(b.GenesisValidatorsRoot, buf[8:40]...)
+	var o3, o4, o5, o6, o7, o9, o10, o11 uint64
 
-	// Field (2) 'Slot'
-	b.Slot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[40:48]))
-
-	// Field (3) 'Fork'
-	if b.Fork == nil {
-		b.Fork = new(Fork)
-	}
-	if err = b.Fork.UnmarshalSSZ(buf[48:64]); err != nil {
-		return err
+	// Field (0) 'RandaoReveal'
+	if cap(b.RandaoReveal) == 0 {
+		b.RandaoReveal = make([]byte, 0, len(buf[0:96]))
 	}
+	b.RandaoReveal = append(b.RandaoReveal, buf[0:96]...)
 
-	// Field (4) 'LatestBlockHeader'
-	if b.LatestBlockHeader == nil {
-		b.LatestBlockHeader = new(BeaconBlockHeader)
+	// Field (1) 'Eth1Data'
+	if b.Eth1Data == nil {
+		b.Eth1Data = new(Eth1Data)
 	}
-	if err = b.LatestBlockHeader.UnmarshalSSZ(buf[64:176]); err != nil {
+	if err = b.Eth1Data.UnmarshalSSZ(buf[96:168]); err != nil {
 		return err
 	}
 
-	// Field (5) 'BlockRoots'
-	b.BlockRoots = make([][]byte, 8192)
-	for ii := 0; ii < 8192; ii++ {
-		if cap(b.BlockRoots[ii]) == 0 {
-			b.BlockRoots[ii] = make([]byte, 0, len(buf[176:262320][ii*32:(ii+1)*32]))
-		}
-		b.BlockRoots[ii] = append(b.BlockRoots[ii], buf[176:262320][ii*32:(ii+1)*32]...)
-	}
-
-	// Field (6) 'StateRoots'
-	b.StateRoots = make([][]byte, 8192)
-	for ii := 0; ii < 8192; ii++ {
-		if cap(b.StateRoots[ii]) == 0 {
-			b.StateRoots[ii] = make([]byte, 0, len(buf[262320:524464][ii*32:(ii+1)*32]))
-		}
-		b.StateRoots[ii] = append(b.StateRoots[ii], buf[262320:524464][ii*32:(ii+1)*32]...)
+	// Field (2) 'Graffiti'
+	if cap(b.Graffiti) == 0 {
+		b.Graffiti = make([]byte, 0, len(buf[168:200]))
 	}
+	b.Graffiti = append(b.Graffiti, buf[168:200]...)
 
-	// Offset (7) 'HistoricalRoots'
-	if o7 = ssz.ReadOffset(buf[524464:524468]); o7 > size {
+	// Offset (3) 'ProposerSlashings'
+	if o3 = ssz.ReadOffset(buf[200:204]); o3 > size {
 		return ssz.ErrOffset
 	}
 
-	if o7 < 2687377 {
+	if o3 < 392 {
 		return ssz.ErrInvalidVariableOffset
 	}
 
-	// Field (8) 'Eth1Data'
-	if b.Eth1Data == nil {
-		b.Eth1Data = new(Eth1Data)
-	}
-	if err = b.Eth1Data.UnmarshalSSZ(buf[524468:524540]); err != nil {
-		return err
+	// Offset (4) 'AttesterSlashings'
+	if o4 = ssz.ReadOffset(buf[204:208]); o4 > size || o3 > o4 {
+		return ssz.ErrOffset
 	}
 
-	// Offset (9) 'Eth1DataVotes'
-	if o9 = ssz.ReadOffset(buf[524540:524544]); o9 > size || o7 > o9 {
+	// Offset (5) 'Attestations'
+	if o5 = ssz.ReadOffset(buf[208:212]); o5 > size || o4 > o5 {
 		return ssz.ErrOffset
 	}
 
-	// Field (10) 'Eth1DepositIndex'
-	b.Eth1DepositIndex = ssz.UnmarshallUint64(buf[524544:524552])
-
-	// Offset (11) 'Validators'
-	if o11 = ssz.ReadOffset(buf[524552:524556]); o11 > size || o9 > o11 {
+	// Offset (6) 'Deposits'
+	if o6 = ssz.ReadOffset(buf[212:216]); o6 > size || o5 > o6 {
 		return ssz.ErrOffset
 	}
 
-	// Offset (12) 'Balances'
-	if o12 = ssz.ReadOffset(buf[524556:524560]); o12 > size || o11 > o12 {
+	// Offset (7) 'VoluntaryExits'
+	if o7 = ssz.ReadOffset(buf[216:220]); o7 > size || o6 > o7 {
 		return ssz.ErrOffset
 	}
 
-	// Field (13) 'RandaoMixes'
-	b.RandaoMixes = make([][]byte, 65536)
-	for ii := 0; ii < 65536; ii++ {
-		if cap(b.RandaoMixes[ii]) == 0 {
-			b.RandaoMixes[ii] = make([]byte, 0, len(buf[524560:2621712][ii*32:(ii+1)*32]))
-		}
-		b.RandaoMixes[ii] = append(b.RandaoMixes[ii], buf[524560:2621712][ii*32:(ii+1)*32]...)
+	// Field (8) 'SyncAggregate'
+	if b.SyncAggregate == nil {
+		b.SyncAggregate = new(SyncAggregate)
 	}
-
-	// Field (14) 'Slashings'
-	b.Slashings = ssz.ExtendUint64(b.Slashings, 8192)
-	for ii := 0; ii < 8192; ii++ {
-		b.Slashings[ii] = ssz.UnmarshallUint64(buf[2621712:2687248][ii*8 : (ii+1)*8])
+	if err = b.SyncAggregate.UnmarshalSSZ(buf[220:380]); err != nil {
+		return err
 	}
 
-	// Offset (15) 'PreviousEpochAttestations'
-	if o15 = ssz.ReadOffset(buf[2687248:2687252]); o15 > size || o12 > o15 {
+	// Offset (9) 'ExecutionPayload'
+	if o9 = ssz.ReadOffset(buf[380:384]); o9 > size || o7 > o9 {
 		return ssz.ErrOffset
 	}
 
-	// Offset (16) 'CurrentEpochAttestations'
-	if o16 = ssz.ReadOffset(buf[2687252:2687256]); o16 > size || o15 > o16 {
+	// Offset (10) 'BlsToExecutionChanges'
+	if o10 = ssz.ReadOffset(buf[384:388]); o10 > size || o9 > o10 {
 		return ssz.ErrOffset
 	}
 
-	// Field (17) 'JustificationBits'
-	if cap(b.JustificationBits) == 0 {
-		b.JustificationBits = make([]byte, 0, len(buf[2687256:2687257]))
-	}
-	b.JustificationBits = append(b.JustificationBits, buf[2687256:2687257]...)
-
-	// Field (18) 'PreviousJustifiedCheckpoint'
-	if b.PreviousJustifiedCheckpoint == nil {
-		b.PreviousJustifiedCheckpoint = new(Checkpoint)
-	}
-	if err = b.PreviousJustifiedCheckpoint.UnmarshalSSZ(buf[2687257:2687297]); err != nil {
-		return err
-	}
-
-	// Field (19) 'CurrentJustifiedCheckpoint'
-	if b.CurrentJustifiedCheckpoint == nil {
-		b.CurrentJustifiedCheckpoint = new(Checkpoint)
-	}
-	if err = b.CurrentJustifiedCheckpoint.UnmarshalSSZ(buf[2687297:2687337]); err != nil {
-		return err
-	}
-
-	// Field (20) 'FinalizedCheckpoint'
-	if b.FinalizedCheckpoint == nil {
-		b.FinalizedCheckpoint = new(Checkpoint)
-	}
-	if err = b.FinalizedCheckpoint.UnmarshalSSZ(buf[2687337:2687377]); err != nil {
-		return err
+	// Offset (11) 'BlobKzgCommitments'
+	if o11 = ssz.ReadOffset(buf[388:392]); o11 > size || o10 > o11 {
+		return ssz.ErrOffset
 	}
 
-	// Field (7) 'HistoricalRoots'
+	// Field (3) 'ProposerSlashings'
 	{
-		buf = tail[o7:o9]
-		num, err := ssz.DivideInt2(len(buf), 32, 16777216)
+		buf = tail[o3:o4]
+		num, err := ssz.DivideInt2(len(buf), 416, 16)
 		if err != nil {
 			return err
 		}
-		b.HistoricalRoots = make([][]byte, num)
+		b.ProposerSlashings = make([]*ProposerSlashing, num)
 		for ii := 0; ii < num; ii++ {
-			if cap(b.HistoricalRoots[ii]) == 0 {
-				b.HistoricalRoots[ii] = make([]byte, 0, len(buf[ii*32:(ii+1)*32]))
+			if b.ProposerSlashings[ii] == nil {
+				b.ProposerSlashings[ii] = new(ProposerSlashing)
+			}
+			if err = b.ProposerSlashings[ii].UnmarshalSSZ(buf[ii*416 : (ii+1)*416]); err != nil {
+				return err
 			}
-			b.HistoricalRoots[ii] = append(b.HistoricalRoots[ii], buf[ii*32:(ii+1)*32]...)
 		}
 	}
 
-	// Field (9) 'Eth1DataVotes'
+	// Field (4) 'AttesterSlashings'
 	{
-		buf = tail[o9:o11]
-		num, err := ssz.DivideInt2(len(buf), 72, 2048)
+		buf = tail[o4:o5]
+		num, err := ssz.DecodeDynamicLength(buf, 2)
 		if err != nil {
 			return err
 		}
-		b.Eth1DataVotes = make([]*Eth1Data, num)
-		for ii := 0; ii < num; ii++ {
-			if b.Eth1DataVotes[ii] == nil {
-				b.Eth1DataVotes[ii] = new(Eth1Data)
+		b.AttesterSlashings = make([]*AttesterSlashing, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if b.AttesterSlashings[indx] == nil {
+				b.AttesterSlashings[indx] = new(AttesterSlashing)
 			}
-			if err = b.Eth1DataVotes[ii].UnmarshalSSZ(buf[ii*72 : (ii+1)*72]); err != nil {
+			if err = b.AttesterSlashings[indx].UnmarshalSSZ(buf); err != nil {
 				return err
 			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
 	}
 
-	// Field (11) 'Validators'
+	// Field (5) 'Attestations'
 	{
-		buf = tail[o11:o12]
-		num, err := ssz.DivideInt2(len(buf), 121, 1099511627776)
+		buf = tail[o5:o6]
+		num, err := ssz.DecodeDynamicLength(buf, 128)
 		if err != nil {
 			return err
 		}
-		b.Validators = make([]*Validator, num)
-		for ii := 0; ii < num; ii++ {
-			if b.Validators[ii] == nil {
-				b.Validators[ii] = new(Validator)
+		b.Attestations = make([]*Attestation, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if b.Attestations[indx] == nil {
+				b.Attestations[indx] = new(Attestation)
 			}
-			if err = b.Validators[ii].UnmarshalSSZ(buf[ii*121 : (ii+1)*121]); err != nil {
+			if err = b.Attestations[indx].UnmarshalSSZ(buf); err != nil {
 				return err
 			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
 	}
 
-	// Field (12) 'Balances'
+	// Field (6) 'Deposits'
 	{
-		buf = tail[o12:o15]
-		num, err := ssz.DivideInt2(len(buf), 8, 1099511627776)
+		buf = tail[o6:o7]
+		num, err := ssz.DivideInt2(len(buf), 1240, 16)
 		if err != nil {
 			return err
 		}
-		b.Balances = ssz.ExtendUint64(b.Balances, num)
+		b.Deposits = make([]*Deposit, num)
 		for ii := 0; ii < num; ii++ {
-			b.Balances[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+			if b.Deposits[ii] == nil {
+				b.Deposits[ii] = new(Deposit)
+			}
+			if err = b.Deposits[ii].UnmarshalSSZ(buf[ii*1240 : (ii+1)*1240]); err != nil {
+				return err
+			}
 		}
 	}
 
-	// Field (15) 'PreviousEpochAttestations'
+	// Field (7) 'VoluntaryExits'
 	{
-		buf = tail[o15:o16]
-		num, err := ssz.DecodeDynamicLength(buf, 4096)
+		buf = tail[o7:o9]
+		num, err := ssz.DivideInt2(len(buf), 112, 16)
 		if err != nil {
 			return err
 		}
-		b.PreviousEpochAttestations = make([]*PendingAttestation, num)
-		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
-			if b.PreviousEpochAttestations[indx] == nil {
-				b.PreviousEpochAttestations[indx] = new(PendingAttestation)
+		b.VoluntaryExits = make([]*SignedVoluntaryExit, num)
+		for ii := 0; ii < num; ii++ {
+			if b.VoluntaryExits[ii] == nil {
+				b.VoluntaryExits[ii] = new(SignedVoluntaryExit)
 			}
-			if err = b.PreviousEpochAttestations[indx].UnmarshalSSZ(buf); err != nil {
+			if err = b.VoluntaryExits[ii].UnmarshalSSZ(buf[ii*112 : (ii+1)*112]); err != nil {
 				return err
 			}
-			return nil
-		})
-		if err != nil {
+		}
+	}
+
+	// Field (9) 'ExecutionPayload'
+	{
+		buf = tail[o9:o10]
+		if b.ExecutionPayload == nil {
+			b.ExecutionPayload = new(v1.ExecutionPayloadDeneb)
+		}
+		if err = b.ExecutionPayload.UnmarshalSSZ(buf); err != nil {
 			return err
 		}
 	}
 
-	// Field (16) 'CurrentEpochAttestations'
+	// Field (10) 'BlsToExecutionChanges'
 	{
-		buf = tail[o16:]
-		num, err := ssz.DecodeDynamicLength(buf, 4096)
+		buf = tail[o10:o11]
+		num, err := ssz.DivideInt2(len(buf), 172, 16)
 		if err != nil {
 			return err
 		}
-		b.CurrentEpochAttestations = make([]*PendingAttestation, num)
-		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
-			if b.CurrentEpochAttestations[indx] == nil {
-				b.CurrentEpochAttestations[indx] = new(PendingAttestation)
+		b.BlsToExecutionChanges = make([]*SignedBLSToExecutionChange, num)
+		for ii := 0; ii < num; ii++ {
+			if b.BlsToExecutionChanges[ii] == nil {
+				b.BlsToExecutionChanges[ii] = new(SignedBLSToExecutionChange)
 			}
-			if err = b.CurrentEpochAttestations[indx].UnmarshalSSZ(buf); err != nil {
+			if err = b.BlsToExecutionChanges[ii].UnmarshalSSZ(buf[ii*172 : (ii+1)*172]); err != nil {
 				return err
 			}
-			return nil
-		})
+		}
+	}
+
+	// Field (11) 'BlobKzgCommitments'
+	{
+		buf = tail[o11:]
+		num, err := ssz.DivideInt2(len(buf), 48, 4096)
 		if err != nil {
 			return err
 		}
+		b.BlobKzgCommitments = make([][]byte, num)
+		for ii := 0; ii < num; ii++ {
+			if cap(b.BlobKzgCommitments[ii]) == 0 {
+				b.BlobKzgCommitments[ii] = make([]byte, 0, len(buf[ii*48:(ii+1)*48]))
+			}
+			b.BlobKzgCommitments[ii] = append(b.BlobKzgCommitments[ii], buf[ii*48:(ii+1)*48]...)
+		}
 	}
 	return err
 }
 
-// SizeSSZ returns the ssz encoded size in bytes for the BeaconState object
-func (b *BeaconState) SizeSSZ() (size int) {
-	size = 2687377
-
-	// Field (7) 'HistoricalRoots'
-	size += len(b.HistoricalRoots) * 32
-
-	// Field (9) 'Eth1DataVotes'
-	size += len(b.Eth1DataVotes) * 72
-
-	// Field (11) 'Validators'
-	size += len(b.Validators) * 121
+// SizeSSZ returns the ssz encoded size in bytes for the BeaconBlockBodyDeneb object
+func (b *BeaconBlockBodyDeneb) SizeSSZ() (size int) {
+	size = 392
 
-	// Field (12) 'Balances'
-	size += len(b.Balances) * 8
+	// Field (3) 'ProposerSlashings'
+	size += len(b.ProposerSlashings) * 416
 
-	// Field (15) 'PreviousEpochAttestations'
-	for ii := 0; ii < len(b.PreviousEpochAttestations); ii++ {
+	// Field (4) 'AttesterSlashings'
+	for ii := 0; ii < len(b.AttesterSlashings); ii++ {
 		size += 4
-		size += b.PreviousEpochAttestations[ii].SizeSSZ()
+		size += b.AttesterSlashings[ii].SizeSSZ()
 	}
 
-	// Field (16) 'CurrentEpochAttestations'
-	for ii := 0; ii < len(b.CurrentEpochAttestations); ii++ {
+	// Field (5) 'Attestations'
+	for ii := 0; ii < len(b.Attestations); ii++ {
 		size += 4
-		size += b.CurrentEpochAttestations[ii].SizeSSZ()
+		size += b.Attestations[ii].SizeSSZ()
+	}
+
+	// Field (6) 'Deposits'
+	size += len(b.Deposits) * 1240
+
+	// Field (7) 'VoluntaryExits'
+	size += len(b.VoluntaryExits) * 112
+
+	// Field (9) 'ExecutionPayload'
+	if b.ExecutionPayload == nil {
+		b.ExecutionPayload = new(v1.ExecutionPayloadDeneb)
 	}
+	size += b.ExecutionPayload.SizeSSZ()
+
+	// Field (10) 'BlsToExecutionChanges'
+	size += len(b.BlsToExecutionChanges) * 172
+
+	// Field (11) 'BlobKzgCommitments'
+	size += len(b.BlobKzgCommitments) * 48
 
 	return
 }
 
-// HashTreeRoot ssz hashes the BeaconState object
-func (b *BeaconState) HashTreeRoot() ([32]byte, error) {
+// HashTreeRoot ssz hashes the BeaconBlockBodyDeneb object
+func (b *BeaconBlockBodyDeneb) HashTreeRoot() ([32]byte, error) {
 	return ssz.HashWithDefaultHasher(b)
 }
 
-// HashTreeRootWith ssz hashes the BeaconState object with a hasher
-func (b *BeaconState) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+// HashTreeRootWith ssz hashes the BeaconBlockBodyDeneb object with a hasher
+func (b *BeaconBlockBodyDeneb) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	indx := hh.Index()
 
-	// Field (0) 'GenesisTime'
-	hh.PutUint64(b.GenesisTime)
-
-	// Field (1) 'GenesisValidatorsRoot'
-	if size := len(b.GenesisValidatorsRoot); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
+	// Field (0) 'RandaoReveal'
+	if size := len(b.RandaoReveal); size != 96 {
+		err = ssz.ErrBytesLengthFn("--.RandaoReveal", size, 96)
 		return
 	}
-	hh.PutBytes(b.GenesisValidatorsRoot)
-
-	// Field (2) 'Slot'
-	hh.PutUint64(uint64(b.Slot))
+	hh.PutBytes(b.RandaoReveal)
 
-	// Field (3) 'Fork'
-	if err = b.Fork.HashTreeRootWith(hh); err != nil {
+	// Field (1) 'Eth1Data'
+	if err = b.Eth1Data.HashTreeRootWith(hh); err != nil {
 		return
 	}
 
-	// Field (4) 'LatestBlockHeader'
-	if err = b.LatestBlockHeader.HashTreeRootWith(hh); err != nil {
+	// Field (2) 'Graffiti'
+	if size := len(b.Graffiti); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.Graffiti", size, 32)
 		return
 	}
+	hh.PutBytes(b.Graffiti)
 
-	// Field (5) 'BlockRoots'
+	// Field (3) 'ProposerSlashings'
 	{
-		if size := len(b.BlockRoots); size != 8192 {
-			err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
+		subIndx := hh.Index()
+		num := uint64(len(b.ProposerSlashings))
+		if num > 16 {
+			err = ssz.ErrIncorrectListSize
 			return
 		}
-		subIndx := hh.Index()
-		for _, i := range b.BlockRoots {
-			if len(i) != 32 {
-				err = ssz.ErrBytesLength
+		for _, elem := range b.ProposerSlashings {
+			if err = elem.HashTreeRootWith(hh); err != nil {
 				return
 			}
-			hh.Append(i)
 		}
-
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeVectorizedHTR(subIndx)
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 16)
 		} else {
-			hh.Merkleize(subIndx)
+			hh.MerkleizeWithMixin(subIndx, num, 16)
 		}
 	}
 
-	// Field (6) 'StateRoots'
+	// Field (4) 'AttesterSlashings'
 	{
-		if size := len(b.StateRoots); size != 8192 {
-			err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
+		subIndx := hh.Index()
+		num := uint64(len(b.AttesterSlashings))
+		if num > 2 {
+			err = ssz.ErrIncorrectListSize
 			return
 		}
-		subIndx := hh.Index()
-		for _, i := range b.StateRoots {
-			if len(i) != 32 {
-				err = ssz.ErrBytesLength
+		for _, elem := range b.AttesterSlashings {
+			if err = elem.HashTreeRootWith(hh); err != nil {
 				return
 			}
-			hh.Append(i)
 		}
-
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeVectorizedHTR(subIndx)
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 2)
 		} else {
-			hh.Merkleize(subIndx)
+			hh.MerkleizeWithMixin(subIndx, num, 2)
 		}
 	}
 
-	// Field (7) 'HistoricalRoots'
+	// Field (5) 'Attestations'
 	{
-		if size := len(b.HistoricalRoots); size > 16777216 {
-			err = ssz.ErrListTooBigFn("--.HistoricalRoots", size, 16777216)
+		subIndx := hh.Index()
+		num := uint64(len(b.Attestations))
+		if num > 128 {
+			err = ssz.ErrIncorrectListSize
 			return
 		}
-		subIndx := hh.Index()
-		for _, i := range b.HistoricalRoots {
-			if len(i) != 32 {
-				err = ssz.ErrBytesLength
+		for _, elem := range b.Attestations {
+			if err = elem.HashTreeRootWith(hh); err != nil {
 				return
 			}
-			hh.Append(i)
 		}
-
-		numItems := uint64(len(b.HistoricalRoots))
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(16777216, numItems, 32))
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 128)
 		} else {
-			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(16777216, numItems, 32))
+			hh.MerkleizeWithMixin(subIndx, num, 128)
 		}
 	}
 
-	// Field (8) 'Eth1Data'
-	if err = b.Eth1Data.HashTreeRootWith(hh); err != nil {
-		return
-	}
-
-	// Field (9) 'Eth1DataVotes'
+	// Field (6) 'Deposits'
 	{
 		subIndx := hh.Index()
-		num := uint64(len(b.Eth1DataVotes))
-		if num > 2048 {
+		num := uint64(len(b.Deposits))
+		if num > 16 {
 			err = ssz.ErrIncorrectListSize
 			return
 		}
-		for _, elem := range b.Eth1DataVotes {
+		for _, elem := range b.Deposits {
 			if err = elem.HashTreeRootWith(hh); err != nil {
 				return
 			}
 		}
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 2048)
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 16)
 		} else {
-			hh.MerkleizeWithMixin(subIndx, num, 2048)
+			hh.MerkleizeWithMixin(subIndx, num, 16)
 		}
 	}
 
-	// Field (10) 'Eth1DepositIndex'
-	hh.PutUint64(b.Eth1DepositIndex)
-
-	// Field (11) 'Validators'
+	// Field (7) 'VoluntaryExits'
 	{
 		subIndx := hh.Index()
-		num := uint64(len(b.Validators))
-		if num > 1099511627776 {
+		num := uint64(len(b.VoluntaryExits))
+		if num > 16 {
 			err = ssz.ErrIncorrectListSize
 			return
 		}
-		for _, elem := range b.Validators {
+		for _, elem := range b.VoluntaryExits {
 			if err = elem.HashTreeRootWith(hh); err != nil {
 				return
 			}
 		}
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 1099511627776)
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 16)
 		} else {
-			hh.MerkleizeWithMixin(subIndx, num, 1099511627776)
+			hh.MerkleizeWithMixin(subIndx, num, 16)
 		}
 	}
 
-	// Field (12) 'Balances'
+	// Field (8) 'SyncAggregate'
+	if err = b.SyncAggregate.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (9) 'ExecutionPayload'
+	if err = b.ExecutionPayload.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (10) 'BlsToExecutionChanges'
 	{
-		if size := len(b.Balances); size > 1099511627776 {
-			err = ssz.ErrListTooBigFn("--.Balances", size, 1099511627776)
+		subIndx := hh.Index()
+		num := uint64(len(b.BlsToExecutionChanges))
+		if num > 16 {
+			err = ssz.ErrIncorrectListSize
 			return
 		}
-		subIndx := hh.Index()
-		for _, i := range b.Balances {
-			hh.AppendUint64(i)
+		for _, elem := range b.BlsToExecutionChanges {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
 		}
-		hh.FillUpTo32()
-
-		numItems := uint64(len(b.Balances))
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 16)
 		} else {
-			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+			hh.MerkleizeWithMixin(subIndx, num, 16)
 		}
 	}
 
-	// Field (13) 'RandaoMixes'
+	// Field (11) 'BlobKzgCommitments'
 	{
-		if size := len(b.RandaoMixes); size != 65536 {
-			err = ssz.ErrVectorLengthFn("--.RandaoMixes", size, 65536)
+		if size := len(b.BlobKzgCommitments); size > 4096 {
+			err = ssz.ErrListTooBigFn("--.BlobKzgCommitments", size, 4096)
 			return
 		}
 		subIndx := hh.Index()
-		for _, i := range b.RandaoMixes {
-			if len(i) != 32 {
+		for _, i := range b.BlobKzgCommitments {
+			if len(i) != 48 {
 				err = ssz.ErrBytesLength
 				return
 			}
-			hh.Append(i)
+			hh.PutBytes(i)
 		}
 
+		numItems := uint64(len(b.BlobKzgCommitments))
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeVectorizedHTR(subIndx)
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, 4096)
 		} else {
-			hh.Merkleize(subIndx)
+			hh.MerkleizeWithMixin(subIndx, numItems, 4096)
 		}
 	}
 
-	// Field (14) 'Slashings'
-	{
-		if size := len(b.Slashings); size != 8192 {
-			err = ssz.ErrVectorLengthFn("--.Slashings", size, 8192)
-			return
-		}
-		subIndx := hh.Index()
-		for _, i := range b.Slashings {
-			hh.AppendUint64(i)
-		}
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
 
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeVectorizedHTR(subIndx)
-		} else {
-			hh.Merkleize(subIndx)
-		}
+// MarshalSSZ ssz marshals the SignedBlindedBeaconBlockDeneb object
+func (s *SignedBlindedBeaconBlockDeneb) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(s)
+}
+
+// MarshalSSZTo ssz marshals the SignedBlindedBeaconBlockDeneb object to a target array
+func (s *SignedBlindedBeaconBlockDeneb) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(100)
+
+	// Offset (0) 'Block'
+	dst = ssz.WriteOffset(dst, offset)
+	if s.Block == nil {
+		s.Block = new(BlindedBeaconBlockDeneb)
 	}
+	offset += s.Block.SizeSSZ()
 
-	// Field (15) 'PreviousEpochAttestations'
-	{
-		subIndx := hh.Index()
-		num := uint64(len(b.PreviousEpochAttestations))
-		if num > 4096 {
-			err = ssz.ErrIncorrectListSize
-			return
-		}
-		for _, elem := range b.PreviousEpochAttestations {
-			if err = elem.HashTreeRootWith(hh); err != nil {
-				return
-			}
-		}
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 4096)
-		} else {
-			hh.MerkleizeWithMixin(subIndx, num, 4096)
-		}
+	// Field (1) 'Signature'
+	if size := len(s.Signature); size != 96 {
+		err = ssz.ErrBytesLengthFn("--.Signature", size, 96)
+		return
 	}
+	dst = append(dst, s.Signature...)
 
-	// Field (16) 'CurrentEpochAttestations'
+	// Field (0) 'Block'
+	if dst, err = s.Block.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the SignedBlindedBeaconBlockDeneb object
+func (s *SignedBlindedBeaconBlockDeneb) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 100 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'Block'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 100 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (1) 'Signature'
+	if cap(s.Signature) == 0 {
+		s.Signature = make([]byte, 0, len(buf[4:100]))
+	}
+	s.Signature = append(s.Signature, buf[4:100]...)
+
+	// Field (0) 'Block'
 	{
-		subIndx := hh.Index()
-		num := uint64(len(b.CurrentEpochAttestations))
-		if num > 4096 {
-			err = ssz.ErrIncorrectListSize
-			return
-		}
-		for _, elem := range b.CurrentEpochAttestations {
-			if err = elem.HashTreeRootWith(hh); err != nil {
-				return
-			}
+		buf = tail[o0:]
+		if s.Block == nil {
+			s.Block = new(BlindedBeaconBlockDeneb)
 		}
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 4096)
-		} else {
-			hh.MerkleizeWithMixin(subIndx, num, 4096)
+		if err = s.Block.UnmarshalSSZ(buf); err != nil {
+			return err
 		}
 	}
+	return err
+}
 
-	// Field (17) 'JustificationBits'
-	if size := len(b.JustificationBits); size != 1 {
-		err = ssz.ErrBytesLengthFn("--.JustificationBits", size, 1)
-		return
-	}
-	hh.PutBytes(b.JustificationBits)
+// SizeSSZ returns the ssz encoded size in bytes for the SignedBlindedBeaconBlockDeneb object
+func (s *SignedBlindedBeaconBlockDeneb) SizeSSZ() (size int) {
+	size = 100
 
-	// Field (18) 'PreviousJustifiedCheckpoint'
-	if err = b.PreviousJustifiedCheckpoint.HashTreeRootWith(hh); err != nil {
-		return
+	// Field (0) 'Block'
+	if s.Block == nil {
+		s.Block = new(BlindedBeaconBlockDeneb)
 	}
+	size += s.Block.SizeSSZ()
 
-	// Field (19) 'CurrentJustifiedCheckpoint'
-	if err = b.CurrentJustifiedCheckpoint.HashTreeRootWith(hh); err != nil {
+	return
+}
+
+// HashTreeRoot ssz hashes the SignedBlindedBeaconBlockDeneb object
+func (s *SignedBlindedBeaconBlockDeneb) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(s)
+}
+
+// HashTreeRootWith ssz hashes the SignedBlindedBeaconBlockDeneb object with a hasher
+func (s *SignedBlindedBeaconBlockDeneb) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Block'
+	if err = s.Block.HashTreeRootWith(hh); err != nil {
 		return
 	}
 
-	// Field (20) 'FinalizedCheckpoint'
-	if err = b.FinalizedCheckpoint.HashTreeRootWith(hh); err != nil {
+	// Field (1) 'Signature'
+	if size := len(s.Signature); size != 96 {
+		err = ssz.ErrBytesLengthFn("--.Signature", size, 96)
 		return
 	}
+	hh.PutBytes(s.Signature)
 
 	if ssz.EnableVectorizedHTR {
 		hh.MerkleizeVectorizedHTR(indx)
@@ -7671,839 +7749,891 @@ func (b *BeaconState) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	return
 }
 
-// MarshalSSZ ssz marshals the BeaconStateAltair object
-func (b *BeaconStateAltair) MarshalSSZ() ([]byte, error) {
+// MarshalSSZ ssz marshals the BlindedBeaconBlockDeneb object
+func (b *BlindedBeaconBlockDeneb) MarshalSSZ() ([]byte, error) {
 	return ssz.MarshalSSZ(b)
 }
 
-// MarshalSSZTo ssz marshals the BeaconStateAltair object to a target array
-func (b *BeaconStateAltair) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+// MarshalSSZTo ssz marshals the BlindedBeaconBlockDeneb object to a target array
+func (b *BlindedBeaconBlockDeneb) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
-	offset := int(2736629)
-
-	// Field (0) 'GenesisTime'
-	dst = ssz.MarshalUint64(dst, b.GenesisTime)
-
-	// Field (1) 'GenesisValidatorsRoot'
-	if size := len(b.GenesisValidatorsRoot); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
-		return
-	}
-	dst = append(dst, b.GenesisValidatorsRoot...)
+	offset := int(84)
 
-	// Field (2) 'Slot'
+	// Field (0) 'Slot'
 	dst = ssz.MarshalUint64(dst, uint64(b.Slot))
 
-	// Field (3) 'Fork'
-	if b.Fork == nil {
-		b.Fork = new(Fork)
-	}
-	if dst, err = b.Fork.MarshalSSZTo(dst); err != nil {
-		return
-	}
+	// Field (1) 'ProposerIndex'
+	dst = ssz.MarshalUint64(dst, uint64(b.ProposerIndex))
 
-	// Field (4) 'LatestBlockHeader'
-	if b.LatestBlockHeader == nil {
-		b.LatestBlockHeader = new(BeaconBlockHeader)
-	}
-	if dst, err = b.LatestBlockHeader.MarshalSSZTo(dst); err != nil {
+	// Field (2) 'ParentRoot'
+	if size := len(b.ParentRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.ParentRoot", size, 32)
 		return
 	}
+	dst = append(dst, b.ParentRoot...)
 
-	// Field (5) 'BlockRoots'
-	if size := len(b.BlockRoots); size != 8192 {
-		err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
+	// Field (3) 'StateRoot'
+	if size := len(b.StateRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.StateRoot", size, 32)
 		return
 	}
-	for ii := 0; ii < 8192; ii++ {
-		if size := len(b.BlockRoots[ii]); size != 32 {
-			err = ssz.ErrBytesLengthFn("--.BlockRoots[ii]", size, 32)
-			return
-		}
-		dst = append(dst, b.BlockRoots[ii]...)
+	dst = append(dst, b.StateRoot...)
+
+	// Offset (4) 'Body'
+	dst = ssz.WriteOffset(dst, offset)
+	if b.Body == nil {
+		b.Body = new(BlindedBeaconBlockBodyDeneb)
 	}
+	offset += b.Body.SizeSSZ()
 
-	// Field (6) 'StateRoots'
-	if size := len(b.StateRoots); size != 8192 {
-		err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
+	// Field (4) 'Body'
+	if dst, err = b.Body.MarshalSSZTo(dst); err != nil {
 		return
 	}
-	for ii := 0; ii < 8192; ii++ {
-		if size := len(b.StateRoots[ii]); size != 32 {
-			err = ssz.ErrBytesLengthFn("--.StateRoots[ii]", size, 32)
-			return
-		}
-		dst = append(dst, b.StateRoots[ii]...)
-	}
 
-	// Offset (7) 'HistoricalRoots'
-	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.HistoricalRoots) * 32
+	return
+}
 
-	// Field (8) 'Eth1Data'
-	if b.Eth1Data == nil {
-		b.Eth1Data = new(Eth1Data)
-	}
-	if dst, err = b.Eth1Data.MarshalSSZTo(dst); err != nil {
-		return
+// UnmarshalSSZ ssz unmarshals the BlindedBeaconBlockDeneb object
+func (b *BlindedBeaconBlockDeneb) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 84 {
+		return ssz.ErrSize
 	}
 
-	// Offset (9) 'Eth1DataVotes'
-	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.Eth1DataVotes) * 72
+	tail := buf
+	var o4 uint64
 
-	// Field (10) 'Eth1DepositIndex'
-	dst = ssz.MarshalUint64(dst, b.Eth1DepositIndex)
+	// Field (0) 'Slot'
+	b.Slot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[0:8]))
 
-	// Offset (11) 'Validators'
-	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.Validators) * 121
+	// Field (1) 'ProposerIndex'
+	b.ProposerIndex = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.ValidatorIndex(ssz.UnmarshallUint64(buf[8:16]))
 
-	// Offset (12) 'Balances'
-	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.Balances) * 8
+	// Field (2) 'ParentRoot'
+	if cap(b.ParentRoot) == 0 {
+		b.ParentRoot = make([]byte, 0, len(buf[16:48]))
+	}
+	b.ParentRoot = append(b.ParentRoot, buf[16:48]...)
 
-	// Field (13) 'RandaoMixes'
-	if size := len(b.RandaoMixes); size != 65536 {
-		err = ssz.ErrVectorLengthFn("--.RandaoMixes", size, 65536)
-		return
+	// Field (3) 'StateRoot'
+	if cap(b.StateRoot) == 0 {
+		b.StateRoot = make([]byte, 0, len(buf[48:80]))
 	}
-	for ii := 0; ii < 65536; ii++ {
-		if size := len(b.RandaoMixes[ii]); size != 32 {
-			err = ssz.ErrBytesLengthFn("--.RandaoMixes[ii]", size, 32)
-			return
-		}
-		dst = append(dst, b.RandaoMixes[ii]...)
+	b.StateRoot = append(b.StateRoot, buf[48:80]...)
+
+	// Offset (4) 'Body'
+	if o4 = ssz.ReadOffset(buf[80:84]); o4 > size {
+		return ssz.ErrOffset
 	}
 
-	// Field (14) 'Slashings'
-	if size := len(b.Slashings); size != 8192 {
-		err = ssz.ErrVectorLengthFn("--.Slashings", size, 8192)
-		return
+	if o4 < 84 {
+		return ssz.ErrInvalidVariableOffset
 	}
-	for ii := 0; ii < 8192; ii++ {
-		dst = ssz.MarshalUint64(dst, b.Slashings[ii])
+
+	// Field (4) 'Body'
+	{
+		buf = tail[o4:]
+		if b.Body == nil {
+			b.Body = new(BlindedBeaconBlockBodyDeneb)
+		}
+		if err = b.Body.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
 	}
+	return err
+}
 
-	// Offset (15) 'PreviousEpochParticipation'
-	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.PreviousEpochParticipation)
+// SizeSSZ returns the ssz encoded size in bytes for the BlindedBeaconBlockDeneb object
+func (b *BlindedBeaconBlockDeneb) SizeSSZ() (size int) {
+	size = 84
 
-	// Offset (16) 'CurrentEpochParticipation'
-	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.CurrentEpochParticipation)
+	// Field (4) 'Body'
+	if b.Body == nil {
+		b.Body = new(BlindedBeaconBlockBodyDeneb)
+	}
+	size += b.Body.SizeSSZ()
 
-	// Field (17) 'JustificationBits'
-	if size := len(b.JustificationBits); size != 1 {
-		err = ssz.ErrBytesLengthFn("--.JustificationBits", size, 1)
+	return
+}
+
+// HashTreeRoot ssz hashes the BlindedBeaconBlockDeneb object
+func (b *BlindedBeaconBlockDeneb) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
+}
+
+// HashTreeRootWith ssz hashes the BlindedBeaconBlockDeneb object with a hasher
+func (b *BlindedBeaconBlockDeneb) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Slot'
+	hh.PutUint64(uint64(b.Slot))
+
+	// Field (1) 'ProposerIndex'
+	hh.PutUint64(uint64(b.ProposerIndex))
+
+	// Field (2) 'ParentRoot'
+	if size := len(b.ParentRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.ParentRoot", size, 32)
 		return
 	}
-	dst = append(dst, b.JustificationBits...)
+	hh.PutBytes(b.ParentRoot)
 
-	// Field (18) 'PreviousJustifiedCheckpoint'
-	if b.PreviousJustifiedCheckpoint == nil {
-		b.PreviousJustifiedCheckpoint = new(Checkpoint)
+	// Field (3) 'StateRoot'
+	if size := len(b.StateRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.StateRoot", size, 32)
+		return
 	}
-	if dst, err = b.PreviousJustifiedCheckpoint.MarshalSSZTo(dst); err != nil {
+	hh.PutBytes(b.StateRoot)
+
+	// Field (4) 'Body'
+	if err = b.Body.HashTreeRootWith(hh); err != nil {
 		return
 	}
 
-	// Field (19) 'CurrentJustifiedCheckpoint'
-	if b.CurrentJustifiedCheckpoint == nil {
-		b.CurrentJustifiedCheckpoint = new(Checkpoint)
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
 	}
-	if dst, err = b.CurrentJustifiedCheckpoint.MarshalSSZTo(dst); err != nil {
+	return
+}
+
+// MarshalSSZ ssz marshals the BlindedBeaconBlockBodyDeneb object
+func (b *BlindedBeaconBlockBodyDeneb) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
+}
+
+// MarshalSSZTo ssz marshals the BlindedBeaconBlockBodyDeneb object to a target array
+func (b *BlindedBeaconBlockBodyDeneb) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(392)
+
+	// Field (0) 'RandaoReveal'
+	if size := len(b.RandaoReveal); size != 96 {
+		err = ssz.ErrBytesLengthFn("--.RandaoReveal", size, 96)
 		return
 	}
+	dst = append(dst, b.RandaoReveal...)
 
-	// Field (20) 'FinalizedCheckpoint'
-	if b.FinalizedCheckpoint == nil {
-		b.FinalizedCheckpoint = new(Checkpoint)
+	// Field (1) 'Eth1Data'
+	if b.Eth1Data == nil {
+		b.Eth1Data = new(Eth1Data)
 	}
-	if dst, err = b.FinalizedCheckpoint.MarshalSSZTo(dst); err != nil {
+	if dst, err = b.Eth1Data.MarshalSSZTo(dst); err != nil {
 		return
 	}
 
-	// Offset (21) 'InactivityScores'
+	// Field (2) 'Graffiti'
+	if size := len(b.Graffiti); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.Graffiti", size, 32)
+		return
+	}
+	dst = append(dst, b.Graffiti...)
+
+	// Offset (3) 'ProposerSlashings'
 	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.InactivityScores) * 8
+	offset += len(b.ProposerSlashings) * 416
 
-	// Field (22) 'CurrentSyncCommittee'
-	if b.CurrentSyncCommittee == nil {
-		b.CurrentSyncCommittee = new(SyncCommittee)
+	// Offset (4) 'AttesterSlashings'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(b.AttesterSlashings); ii++ {
+		offset += 4
+		offset += b.AttesterSlashings[ii].SizeSSZ()
 	}
-	if dst, err = b.CurrentSyncCommittee.MarshalSSZTo(dst); err != nil {
-		return
+
+	// Offset (5) 'Attestations'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(b.Attestations); ii++ {
+		offset += 4
+		offset += b.Attestations[ii].SizeSSZ()
 	}
 
-	// Field (23) 'NextSyncCommittee'
-	if b.NextSyncCommittee == nil {
-		b.NextSyncCommittee = new(SyncCommittee)
+	// Offset (6) 'Deposits'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Deposits) * 1240
+
+	// Offset (7) 'VoluntaryExits'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.VoluntaryExits) * 112
+
+	// Field (8) 'SyncAggregate'
+	if b.SyncAggregate == nil {
+		b.SyncAggregate = new(SyncAggregate)
 	}
-	if dst, err = b.NextSyncCommittee.MarshalSSZTo(dst); err != nil {
+	if dst, err = b.SyncAggregate.MarshalSSZTo(dst); err != nil {
 		return
 	}
 
-	// Field (7) 'HistoricalRoots'
-	if size := len(b.HistoricalRoots); size > 16777216 {
-		err = ssz.ErrListTooBigFn("--.HistoricalRoots", size, 16777216)
+	// Offset (9) 'ExecutionPayloadHeader'
+	dst = ssz.WriteOffset(dst, offset)
+	if b.ExecutionPayloadHeader == nil {
+		b.ExecutionPayloadHeader = new(v1.ExecutionPayloadHeaderDeneb)
+	}
+	offset += b.ExecutionPayloadHeader.SizeSSZ()
+
+	// Offset (10) 'BlsToExecutionChanges'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.BlsToExecutionChanges) * 172
+
+	// Offset (11) 'BlobKzgCommitments'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.BlobKzgCommitments) * 48
+
+	// Field (3) 'ProposerSlashings'
+	if size := len(b.ProposerSlashings); size > 16 {
+		err = ssz.ErrListTooBigFn("--.ProposerSlashings", size, 16)
 		return
 	}
-	for ii := 0; ii < len(b.HistoricalRoots); ii++ {
-		if size := len(b.HistoricalRoots[ii]); size != 32 {
-			err = ssz.ErrBytesLengthFn("--.HistoricalRoots[ii]", size, 32)
+	for ii := 0; ii < len(b.ProposerSlashings); ii++ {
+		if dst, err = b.ProposerSlashings[ii].MarshalSSZTo(dst); err != nil {
 			return
 		}
-		dst = append(dst, b.HistoricalRoots[ii]...)
 	}
 
-	// Field (9) 'Eth1DataVotes'
-	if size := len(b.Eth1DataVotes); size > 2048 {
-		err = ssz.ErrListTooBigFn("--.Eth1DataVotes", size, 2048)
+	// Field (4) 'AttesterSlashings'
+	if size := len(b.AttesterSlashings); size > 2 {
+		err = ssz.ErrListTooBigFn("--.AttesterSlashings", size, 2)
 		return
 	}
-	for ii := 0; ii < len(b.Eth1DataVotes); ii++ {
-		if dst, err = b.Eth1DataVotes[ii].MarshalSSZTo(dst); err != nil {
+	{
+		offset = 4 * len(b.AttesterSlashings)
+		for ii := 0; ii < len(b.AttesterSlashings); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += b.AttesterSlashings[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(b.AttesterSlashings); ii++ {
+		if dst, err = b.AttesterSlashings[ii].MarshalSSZTo(dst); err != nil {
 			return
 		}
 	}
 
-	// Field (11) 'Validators'
-	if size := len(b.Validators); size > 1099511627776 {
-		err = ssz.ErrListTooBigFn("--.Validators", size, 1099511627776)
+	// Field (5) 'Attestations'
+	if size := len(b.Attestations); size > 128 {
+		err = ssz.ErrListTooBigFn("--.Attestations", size, 128)
 		return
 	}
-	for ii := 0; ii < len(b.Validators); ii++ {
-		if dst, err = b.Validators[ii].MarshalSSZTo(dst); err != nil {
+	{
+		offset = 4 * len(b.Attestations)
+		for ii := 0; ii < len(b.Attestations); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += b.Attestations[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(b.Attestations); ii++ {
+		if dst, err = b.Attestations[ii].MarshalSSZTo(dst); err != nil {
 			return
 		}
 	}
 
-	// Field (12) 'Balances'
-	if size := len(b.Balances); size > 1099511627776 {
-		err = ssz.ErrListTooBigFn("--.Balances", size, 1099511627776)
+	// Field (6) 'Deposits'
+	if size := len(b.Deposits); size > 16 {
+		err = ssz.ErrListTooBigFn("--.Deposits", size, 16)
 		return
 	}
-	for ii := 0; ii < len(b.Balances); ii++ {
-		dst = ssz.MarshalUint64(dst, b.Balances[ii])
+	for ii := 0; ii < len(b.Deposits); ii++ {
+		if dst, err = b.Deposits[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
 	}
 
-	// Field (15) 'PreviousEpochParticipation'
-	if size := len(b.PreviousEpochParticipation); size > 1099511627776 {
-		err = ssz.ErrBytesLengthFn("--.PreviousEpochParticipation", size, 1099511627776)
+	// Field (7) 'VoluntaryExits'
+	if size := len(b.VoluntaryExits); size > 16 {
+		err = ssz.ErrListTooBigFn("--.VoluntaryExits", size, 16)
 		return
 	}
-	dst = append(dst, b.PreviousEpochParticipation...)
+	for ii := 0; ii < len(b.VoluntaryExits); ii++ {
+		if dst, err = b.VoluntaryExits[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
 
-	// Field (16) 'CurrentEpochParticipation'
-	if size := len(b.CurrentEpochParticipation); size > 1099511627776 {
-		err = ssz.ErrBytesLengthFn("--.CurrentEpochParticipation", size, 1099511627776)
+	// Field (9) 'ExecutionPayloadHeader'
+	if dst, err = b.ExecutionPayloadHeader.MarshalSSZTo(dst); err != nil {
 		return
 	}
-	dst = append(dst, b.CurrentEpochParticipation...)
 
-	// Field (21) 'InactivityScores'
-	if size := len(b.InactivityScores); size > 1099511627776 {
-		err = ssz.ErrListTooBigFn("--.InactivityScores", size, 1099511627776)
+	// Field (10) 'BlsToExecutionChanges'
+	if size := len(b.BlsToExecutionChanges); size > 16 {
+		err = ssz.ErrListTooBigFn("--.BlsToExecutionChanges", size, 16)
 		return
 	}
-	for ii := 0; ii < len(b.InactivityScores); ii++ {
-		dst = ssz.MarshalUint64(dst, b.InactivityScores[ii])
+	for ii := 0; ii < len(b.BlsToExecutionChanges); ii++ {
+		if dst, err = b.BlsToExecutionChanges[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (11) 'BlobKzgCommitments'
+	if size := len(b.BlobKzgCommitments); size > 4096 {
+		err = ssz.ErrListTooBigFn("--.BlobKzgCommitments", size, 4096)
+		return
+	}
+	for ii := 0; ii < len(b.BlobKzgCommitments); ii++ {
+		if size := len(b.BlobKzgCommitments[ii]); size != 48 {
+			err = ssz.ErrBytesLengthFn("--.BlobKzgCommitments[ii]", size, 48)
+			return
+		}
+		dst = append(dst, b.BlobKzgCommitments[ii]...)
 	}
 
 	return
 }
 
-// UnmarshalSSZ ssz unmarshals the BeaconStateAltair object
-func (b *BeaconStateAltair) UnmarshalSSZ(buf []byte) error {
+// UnmarshalSSZ ssz unmarshals the BlindedBeaconBlockBodyDeneb object
+func (b *BlindedBeaconBlockBodyDeneb) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size < 2736629 {
+	if size < 392 {
 		return ssz.ErrSize
 	}
 
 	tail := buf
-	var o7, o9, o11, o12, o15, o16, o21 uint64
-
-	// Field (0) 'GenesisTime'
-	b.GenesisTime = ssz.UnmarshallUint64(buf[0:8])
-
-	// Field (1) 'GenesisValidatorsRoot'
-	if cap(b.GenesisValidatorsRoot) == 0 {
-		b.GenesisValidatorsRoot = make([]byte, 0, len(buf[8:40]))
-	}
-	b.GenesisValidatorsRoot = append(b.GenesisValidatorsRoot, buf[8:40]...)
+	var o3, o4, o5, o6, o7, o9, o10, o11 uint64
 
-	// Field (2) 'Slot'
-	b.Slot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[40:48]))
-
-	// Field (3) 'Fork'
-	if b.Fork == nil {
-		b.Fork = new(Fork)
-	}
-	if err = b.Fork.UnmarshalSSZ(buf[48:64]); err != nil {
-		return err
+	// Field (0) 'RandaoReveal'
+	if cap(b.RandaoReveal) == 0 {
+		b.RandaoReveal = make([]byte, 0, len(buf[0:96]))
 	}
+	b.RandaoReveal = append(b.RandaoReveal, buf[0:96]...)
 
-	// Field (4) 'LatestBlockHeader'
-	if b.LatestBlockHeader == nil {
-		b.LatestBlockHeader = new(BeaconBlockHeader)
+	// Field (1) 'Eth1Data'
+	if b.Eth1Data == nil {
+		b.Eth1Data = new(Eth1Data)
 	}
-	if err = b.LatestBlockHeader.UnmarshalSSZ(buf[64:176]); err != nil {
+	if err = b.Eth1Data.UnmarshalSSZ(buf[96:168]); err != nil {
 		return err
 	}
 
-	// Field (5) 'BlockRoots'
-	b.BlockRoots = make([][]byte, 8192)
-	for ii := 0; ii < 8192; ii++ {
-		if cap(b.BlockRoots[ii]) == 0 {
-			b.BlockRoots[ii] = make([]byte, 0, len(buf[176:262320][ii*32:(ii+1)*32]))
-		}
-		b.BlockRoots[ii] = append(b.BlockRoots[ii], buf[176:262320][ii*32:(ii+1)*32]...)
-	}
-
-	// Field (6) 'StateRoots'
-	b.StateRoots = make([][]byte, 8192)
-	for ii := 0; ii < 8192; ii++ {
-		if cap(b.StateRoots[ii]) == 0 {
-			b.StateRoots[ii] = make([]byte, 0, len(buf[262320:524464][ii*32:(ii+1)*32]))
-		}
-		b.StateRoots[ii] = append(b.StateRoots[ii], buf[262320:524464][ii*32:(ii+1)*32]...)
+	// Field (2) 'Graffiti'
+	if cap(b.Graffiti) == 0 {
+		b.Graffiti = make([]byte, 0, len(buf[168:200]))
 	}
+	b.Graffiti = append(b.Graffiti, buf[168:200]...)
 
-	// Offset (7) 'HistoricalRoots'
-	if o7 = ssz.ReadOffset(buf[524464:524468]); o7 > size {
+	// Offset (3) 'ProposerSlashings'
+	if o3 = ssz.ReadOffset(buf[200:204]); o3 > size {
 		return ssz.ErrOffset
 	}
 
-	if o7 < 2736629 {
+	if o3 < 392 {
 		return ssz.ErrInvalidVariableOffset
 	}
 
-	// Field (8) 'Eth1Data'
-	if b.Eth1Data == nil {
-		b.Eth1Data = new(Eth1Data)
-	}
-	if err = b.Eth1Data.UnmarshalSSZ(buf[524468:524540]); err != nil {
-		return err
-	}
-
-	// Offset (9) 'Eth1DataVotes'
-	if o9 = ssz.ReadOffset(buf[524540:524544]); o9 > size || o7 > o9 {
-		return ssz.ErrOffset
-	}
-
-	// Field (10) 'Eth1DepositIndex'
-	b.Eth1DepositIndex = ssz.UnmarshallUint64(buf[524544:524552])
-
-	// Offset (11) 'Validators'
-	if o11 = ssz.ReadOffset(buf[524552:524556]); o11 > size || o9 > o11 {
+	// Offset (4) 'AttesterSlashings'
+	if o4 = ssz.ReadOffset(buf[204:208]); o4 > size || o3 > o4 {
 		return ssz.ErrOffset
 	}
 
-	// Offset (12) 'Balances'
-	if o12 = ssz.ReadOffset(buf[524556:524560]); o12 > size || o11 > o12 {
+	// Offset (5) 'Attestations'
+	if o5 = ssz.ReadOffset(buf[208:212]); o5 > size || o4 > o5 {
 		return ssz.ErrOffset
 	}
 
-	// Field (13) 'RandaoMixes'
-	b.RandaoMixes = make([][]byte, 65536)
-	for ii := 0; ii < 65536; ii++ {
-		if cap(b.RandaoMixes[ii]) == 0 {
-			b.RandaoMixes[ii] = make([]byte, 0, len(buf[524560:2621712][ii*32:(ii+1)*32]))
-		}
-		b.RandaoMixes[ii] = append(b.RandaoMixes[ii], buf[524560:2621712][ii*32:(ii+1)*32]...)
-	}
-
-	// Field (14) 'Slashings'
-	b.Slashings = ssz.ExtendUint64(b.Slashings, 8192)
-	for ii := 0; ii < 8192; ii++ {
-		b.Slashings[ii] = ssz.UnmarshallUint64(buf[2621712:2687248][ii*8 : (ii+1)*8])
-	}
-
-	// Offset (15) 'PreviousEpochParticipation'
-	if o15 = ssz.ReadOffset(buf[2687248:2687252]); o15 > size || o12 > o15 {
+	// Offset (6) 'Deposits'
+	if o6 = ssz.ReadOffset(buf[212:216]); o6 > size || o5 > o6 {
 		return ssz.ErrOffset
 	}
 
-	// Offset (16) 'CurrentEpochParticipation'
-	if o16 = ssz.ReadOffset(buf[2687252:2687256]); o16 > size || o15 > o16 {
+	// Offset (7) 'VoluntaryExits'
+	if o7 = ssz.ReadOffset(buf[216:220]); o7 > size || o6 > o7 {
 		return ssz.ErrOffset
 	}
 
-	// Field (17) 'JustificationBits'
-	if cap(b.JustificationBits) == 0 {
-		b.JustificationBits = make([]byte, 0, len(buf[2687256:2687257]))
-	}
-	b.JustificationBits = append(b.JustificationBits, buf[2687256:2687257]...)
-
-	// Field (18) 'PreviousJustifiedCheckpoint'
-	if b.PreviousJustifiedCheckpoint == nil {
-		b.PreviousJustifiedCheckpoint = new(Checkpoint)
-	}
-	if err = b.PreviousJustifiedCheckpoint.UnmarshalSSZ(buf[2687257:2687297]); err != nil {
-		return err
-	}
-
-	// Field (19) 'CurrentJustifiedCheckpoint'
-	if b.CurrentJustifiedCheckpoint == nil {
-		b.CurrentJustifiedCheckpoint = new(Checkpoint)
-	}
-	if err = b.CurrentJustifiedCheckpoint.UnmarshalSSZ(buf[2687297:2687337]); err != nil {
-		return err
-	}
-
-	// Field (20) 'FinalizedCheckpoint'
-	if b.FinalizedCheckpoint == nil {
-		b.FinalizedCheckpoint = new(Checkpoint)
+	// Field (8) 'SyncAggregate'
+	if b.SyncAggregate == nil {
+		b.SyncAggregate = new(SyncAggregate)
 	}
-	if err = b.FinalizedCheckpoint.UnmarshalSSZ(buf[2687337:2687377]); err != nil {
+	if err = b.SyncAggregate.UnmarshalSSZ(buf[220:380]); err != nil {
 		return err
 	}
 
-	// Offset (21) 'InactivityScores'
-	if o21 = ssz.ReadOffset(buf[2687377:2687381]); o21 > size || o16 > o21 {
+	// Offset (9) 'ExecutionPayloadHeader'
+	if o9 = ssz.ReadOffset(buf[380:384]); o9 > size || o7 > o9 {
 		return ssz.ErrOffset
 	}
 
-	// Field (22) 'CurrentSyncCommittee'
-	if b.CurrentSyncCommittee == nil {
-		b.CurrentSyncCommittee = new(SyncCommittee)
-	}
-	if err = b.CurrentSyncCommittee.UnmarshalSSZ(buf[2687381:2712005]); err != nil {
-		return err
+	// Offset (10) 'BlsToExecutionChanges'
+	if o10 = ssz.ReadOffset(buf[384:388]); o10 > size || o9 > o10 {
+		return ssz.ErrOffset
 	}
 
-	// Field (23) 'NextSyncCommittee'
-	if b.NextSyncCommittee == nil {
-		b.NextSyncCommittee = new(SyncCommittee)
-	}
-	if err = b.NextSyncCommittee.UnmarshalSSZ(buf[2712005:2736629]); err != nil {
-		return err
+	// Offset (11) 'BlobKzgCommitments'
+	if o11 = ssz.ReadOffset(buf[388:392]); o11 > size || o10 > o11 {
+		return ssz.ErrOffset
 	}
 
-	// Field (7) 'HistoricalRoots'
+	// Field (3) 'ProposerSlashings'
 	{
-		buf = tail[o7:o9]
-		num, err := ssz.DivideInt2(len(buf), 32, 16777216)
+		buf = tail[o3:o4]
+		num, err := ssz.DivideInt2(len(buf), 416, 16)
 		if err != nil {
 			return err
 		}
-		b.HistoricalRoots = make([][]byte, num)
+		b.ProposerSlashings = make([]*ProposerSlashing, num)
 		for ii := 0; ii < num; ii++ {
-			if cap(b.HistoricalRoots[ii]) == 0 {
-				b.HistoricalRoots[ii] = make([]byte, 0, len(buf[ii*32:(ii+1)*32]))
+			if b.ProposerSlashings[ii] == nil {
+				b.ProposerSlashings[ii] = new(ProposerSlashing)
+			}
+			if err = b.ProposerSlashings[ii].UnmarshalSSZ(buf[ii*416 : (ii+1)*416]); err != nil {
+				return err
 			}
-			b.HistoricalRoots[ii] = append(b.HistoricalRoots[ii], buf[ii*32:(ii+1)*32]...)
 		}
 	}
 
-	// Field (9) 'Eth1DataVotes'
+	// Field (4) 'AttesterSlashings'
 	{
-		buf = tail[o9:o11]
-		num, err := ssz.DivideInt2(len(buf), 72, 2048)
+		buf = tail[o4:o5]
+		num, err := ssz.DecodeDynamicLength(buf, 2)
 		if err != nil {
 			return err
 		}
-		b.Eth1DataVotes = make([]*Eth1Data, num)
-		for ii := 0; ii < num; ii++ {
-			if b.Eth1DataVotes[ii] == nil {
-				b.Eth1DataVotes[ii] = new(Eth1Data)
+		b.AttesterSlashings = make([]*AttesterSlashing, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if b.AttesterSlashings[indx] == nil {
+				b.AttesterSlashings[indx] = new(AttesterSlashing)
 			}
-			if err = b.Eth1DataVotes[ii].UnmarshalSSZ(buf[ii*72 : (ii+1)*72]); err != nil {
+			if err = b.AttesterSlashings[indx].UnmarshalSSZ(buf); err != nil {
 				return err
 			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
 	}
 
-	// Field (11) 'Validators'
+	// Field (5) 'Attestations'
 	{
-		buf = tail[o11:o12]
-		num, err := ssz.DivideInt2(len(buf), 121, 1099511627776)
+		buf = tail[o5:o6]
+		num, err := ssz.DecodeDynamicLength(buf, 128)
 		if err != nil {
 			return err
 		}
-		b.Validators = make([]*Validator, num)
-		for ii := 0; ii < num; ii++ {
-			if b.Validators[ii] == nil {
-				b.Validators[ii] = new(Validator)
+		b.Attestations = make([]*Attestation, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if b.Attestations[indx] == nil {
+				b.Attestations[indx] = new(Attestation)
 			}
-			if err = b.Validators[ii].UnmarshalSSZ(buf[ii*121 : (ii+1)*121]); err != nil {
+			if err = b.Attestations[indx].UnmarshalSSZ(buf); err != nil {
 				return err
 			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
 	}
 
-	// Field (12) 'Balances'
+	// Field (6) 'Deposits'
 	{
-		buf = tail[o12:o15]
-		num, err := ssz.DivideInt2(len(buf), 8, 1099511627776)
+		buf = tail[o6:o7]
+		num, err := ssz.DivideInt2(len(buf), 1240, 16)
 		if err != nil {
 			return err
 		}
-		b.Balances = ssz.ExtendUint64(b.Balances, num)
+		b.Deposits = make([]*Deposit, num)
 		for ii := 0; ii < num; ii++ {
-			b.Balances[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+			if b.Deposits[ii] == nil {
+				b.Deposits[ii] = new(Deposit)
+			}
+			if err = b.Deposits[ii].UnmarshalSSZ(buf[ii*1240 : (ii+1)*1240]); err != nil {
+				return err
+			}
 		}
 	}
 
-	// Field (15) 'PreviousEpochParticipation'
+	// Field (7) 'VoluntaryExits'
 	{
-		buf = tail[o15:o16]
-		if len(buf) > 1099511627776 {
-			return ssz.ErrBytesLength
+		buf = tail[o7:o9]
+		num, err := ssz.DivideInt2(len(buf), 112, 16)
+		if err != nil {
+			return err
 		}
-		if cap(b.PreviousEpochParticipation) == 0 {
-			b.PreviousEpochParticipation = make([]byte, 0, len(buf))
+		b.VoluntaryExits = make([]*SignedVoluntaryExit, num)
+		for ii := 0; ii < num; ii++ {
+			if b.VoluntaryExits[ii] == nil {
+				b.VoluntaryExits[ii] = new(SignedVoluntaryExit)
+			}
+			if err = b.VoluntaryExits[ii].UnmarshalSSZ(buf[ii*112 : (ii+1)*112]); err != nil {
+				return err
+			}
 		}
-		b.PreviousEpochParticipation = append(b.PreviousEpochParticipation, buf...)
 	}
 
-	// Field (16) 'CurrentEpochParticipation'
+	// Field (9) 'ExecutionPayloadHeader'
 	{
-		buf = tail[o16:o21]
-		if len(buf) > 1099511627776 {
-			return ssz.ErrBytesLength
+		buf = tail[o9:o10]
+		if b.ExecutionPayloadHeader == nil {
+			b.ExecutionPayloadHeader = new(v1.ExecutionPayloadHeaderDeneb)
 		}
-		if cap(b.CurrentEpochParticipation) == 0 {
-			b.CurrentEpochParticipation = make([]byte, 0, len(buf))
+		if err = b.ExecutionPayloadHeader.UnmarshalSSZ(buf); err != nil {
+			return err
 		}
-		b.CurrentEpochParticipation = append(b.CurrentEpochParticipation, buf...)
 	}
 
-	// Field (21) 'InactivityScores'
+	// Field (10) 'BlsToExecutionChanges'
 	{
-		buf = tail[o21:]
-		num, err := ssz.DivideInt2(len(buf), 8, 1099511627776)
+		buf = tail[o10:o11]
+		num, err := ssz.DivideInt2(len(buf), 172, 16)
 		if err != nil {
 			return err
 		}
-		b.InactivityScores = ssz.ExtendUint64(b.InactivityScores, num)
+		b.BlsToExecutionChanges = make([]*SignedBLSToExecutionChange, num)
 		for ii := 0; ii < num; ii++ {
-			b.InactivityScores[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+			if b.BlsToExecutionChanges[ii] == nil {
+				b.BlsToExecutionChanges[ii] = new(SignedBLSToExecutionChange)
+			}
+			if err = b.BlsToExecutionChanges[ii].UnmarshalSSZ(buf[ii*172 : (ii+1)*172]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (11) 'BlobKzgCommitments'
+	{
+		buf = tail[o11:]
+		num, err := ssz.DivideInt2(len(buf), 48, 4096)
+		if err != nil {
+			return err
+		}
+		b.BlobKzgCommitments = make([][]byte, num)
+		for ii := 0; ii < num; ii++ {
+			if cap(b.BlobKzgCommitments[ii]) == 0 {
+				b.BlobKzgCommitments[ii] = make([]byte, 0, len(buf[ii*48:(ii+1)*48]))
+			}
+			b.BlobKzgCommitments[ii] = append(b.BlobKzgCommitments[ii], buf[ii*48:(ii+1)*48]...)
 		}
 	}
 	return err
 }
 
-// SizeSSZ returns the ssz encoded size in bytes for the BeaconStateAltair object
-func (b *BeaconStateAltair) SizeSSZ() (size int) {
-	size = 2736629
+// SizeSSZ returns the ssz encoded size in bytes for the BlindedBeaconBlockBodyDeneb object
+func (b *BlindedBeaconBlockBodyDeneb) SizeSSZ() (size int) {
+	size = 392
 
-	// Field (7) 'HistoricalRoots'
-	size += len(b.HistoricalRoots) * 32
+	// Field (3) 'ProposerSlashings'
+	size += len(b.ProposerSlashings) * 416
 
-	// Field (9) 'Eth1DataVotes'
-	size += len(b.Eth1DataVotes) * 72
+	// Field (4) 'AttesterSlashings'
+	for ii := 0; ii < len(b.AttesterSlashings); ii++ {
+		size += 4
+		size += b.AttesterSlashings[ii].SizeSSZ()
+	}
 
-	// Field (11) 'Validators'
-	size += len(b.Validators) * 121
+	// Field (5) 'Attestations'
+	for ii := 0; ii < len(b.Attestations); ii++ {
+		size += 4
+		size += b.Attestations[ii].SizeSSZ()
+	}
 
-	// Field (12) 'Balances'
-	size += len(b.Balances) * 8
+	// Field (6) 'Deposits'
+	size += len(b.Deposits) * 1240
 
-	// Field (15) 'PreviousEpochParticipation'
-	size += len(b.PreviousEpochParticipation)
+	// Field (7) 'VoluntaryExits'
+	size += len(b.VoluntaryExits) * 112
 
-	// Field (16) 'CurrentEpochParticipation'
-	size += len(b.CurrentEpochParticipation)
+	// Field (9) 'ExecutionPayloadHeader'
+	if b.ExecutionPayloadHeader == nil {
+		b.ExecutionPayloadHeader = new(v1.ExecutionPayloadHeaderDeneb)
+	}
+	size += b.ExecutionPayloadHeader.SizeSSZ()
 
-	// Field (21) 'InactivityScores'
-	size += len(b.InactivityScores) * 8
+	// Field (10) 'BlsToExecutionChanges'
+	size += len(b.BlsToExecutionChanges) * 172
+
+	// Field (11) 'BlobKzgCommitments'
+	size += len(b.BlobKzgCommitments) * 48
 
 	return
 }
 
-// HashTreeRoot ssz hashes the BeaconStateAltair object
-func (b *BeaconStateAltair) HashTreeRoot() ([32]byte, error) {
+// HashTreeRoot ssz hashes the BlindedBeaconBlockBodyDeneb object
+func (b *BlindedBeaconBlockBodyDeneb) HashTreeRoot() ([32]byte, error) {
 	return ssz.HashWithDefaultHasher(b)
 }
 
-// HashTreeRootWith ssz hashes the BeaconStateAltair object with a hasher
-func (b *BeaconStateAltair) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+// HashTreeRootWith ssz hashes the BlindedBeaconBlockBodyDeneb object with a hasher
+func (b *BlindedBeaconBlockBodyDeneb) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	indx := hh.Index()
 
-	// Field (0) 'GenesisTime'
-	hh.PutUint64(b.GenesisTime)
-
-	// Field (1) 'GenesisValidatorsRoot'
-	if size := len(b.GenesisValidatorsRoot); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
+	// Field (0) 'RandaoReveal'
+	if size := len(b.RandaoReveal); size != 96 {
+		err = ssz.ErrBytesLengthFn("--.RandaoReveal", size, 96)
 		return
 	}
-	hh.PutBytes(b.GenesisValidatorsRoot)
-
-	// Field (2) 'Slot'
-	hh.PutUint64(uint64(b.Slot))
+	hh.PutBytes(b.RandaoReveal)
 
-	// Field (3) 'Fork'
-	if err = b.Fork.HashTreeRootWith(hh); err != nil {
+	// Field (1) 'Eth1Data'
+	if err = b.Eth1Data.HashTreeRootWith(hh); err != nil {
 		return
 	}
 
-	// Field (4) 'LatestBlockHeader'
-	if err = b.LatestBlockHeader.HashTreeRootWith(hh); err != nil {
+	// Field (2) 'Graffiti'
+	if size := len(b.Graffiti); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.Graffiti", size, 32)
 		return
 	}
+	hh.PutBytes(b.Graffiti)
 
-	// Field (5) 'BlockRoots'
+	// Field (3) 'ProposerSlashings'
 	{
-		if size := len(b.BlockRoots); size != 8192 {
-			err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
+		subIndx := hh.Index()
+		num := uint64(len(b.ProposerSlashings))
+		if num > 16 {
+			err = ssz.ErrIncorrectListSize
 			return
 		}
-		subIndx := hh.Index()
-		for _, i := range b.BlockRoots {
-			if len(i) != 32 {
-				err = ssz.ErrBytesLength
+		for _, elem := range b.ProposerSlashings {
+			if err = elem.HashTreeRootWith(hh); err != nil {
 				return
 			}
-			hh.Append(i)
 		}
-
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeVectorizedHTR(subIndx)
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 16)
 		} else {
-			hh.Merkleize(subIndx)
+			hh.MerkleizeWithMixin(subIndx, num, 16)
 		}
 	}
 
-	// Field (6) 'StateRoots'
+	// Field (4) 'AttesterSlashings'
 	{
-		if size := len(b.StateRoots); size != 8192 {
-			err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
+		subIndx := hh.Index()
+		num := uint64(len(b.AttesterSlashings))
+		if num > 2 {
+			err = ssz.ErrIncorrectListSize
 			return
 		}
-		subIndx := hh.Index()
-		for _, i := range b.StateRoots {
-			if len(i) != 32 {
-				err = ssz.ErrBytesLength
+		for _, elem := range b.AttesterSlashings {
+			if err = elem.HashTreeRootWith(hh); err != nil {
 				return
 			}
-			hh.Append(i)
 		}
-
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeVectorizedHTR(subIndx)
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 2)
 		} else {
-			hh.Merkleize(subIndx)
+			hh.MerkleizeWithMixin(subIndx, num, 2)
 		}
 	}
 
-	// Field (7) 'HistoricalRoots'
+	// Field (5) 'Attestations'
 	{
-		if size := len(b.HistoricalRoots); size > 16777216 {
-			err = ssz.ErrListTooBigFn("--.HistoricalRoots", size, 16777216)
+		subIndx := hh.Index()
+		num := uint64(len(b.Attestations))
+		if num > 128 {
+			err = ssz.ErrIncorrectListSize
 			return
 		}
-		subIndx := hh.Index()
-		for _, i := range b.HistoricalRoots {
-			if len(i) != 32 {
-				err = ssz.ErrBytesLength
+		for _, elem := range b.Attestations {
+			if err = elem.HashTreeRootWith(hh); err != nil {
 				return
 			}
-			hh.Append(i)
 		}
-
-		numItems := uint64(len(b.HistoricalRoots))
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(16777216, numItems, 32))
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 128)
 		} else {
-			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(16777216, numItems, 32))
+			hh.MerkleizeWithMixin(subIndx, num, 128)
 		}
 	}
 
-	// Field (8) 'Eth1Data'
-	if err = b.Eth1Data.HashTreeRootWith(hh); err != nil {
-		return
-	}
-
-	// Field (9) 'Eth1DataVotes'
+	// Field (6) 'Deposits'
 	{
 		subIndx := hh.Index()
-		num := uint64(len(b.Eth1DataVotes))
-		if num > 2048 {
+		num := uint64(len(b.Deposits))
+		if num > 16 {
 			err = ssz.ErrIncorrectListSize
 			return
 		}
-		for _, elem := range b.Eth1DataVotes {
+		for _, elem := range b.Deposits {
 			if err = elem.HashTreeRootWith(hh); err != nil {
 				return
 			}
 		}
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 2048)
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 16)
 		} else {
-			hh.MerkleizeWithMixin(subIndx, num, 2048)
+			hh.MerkleizeWithMixin(subIndx, num, 16)
 		}
 	}
 
-	// Field (10) 'Eth1DepositIndex'
-	hh.PutUint64(b.Eth1DepositIndex)
-
-	// Field (11) 'Validators'
+	// Field (7) 'VoluntaryExits'
 	{
 		subIndx := hh.Index()
-		num := uint64(len(b.Validators))
-		if num > 1099511627776 {
+		num := uint64(len(b.VoluntaryExits))
+		if num > 16 {
 			err = ssz.ErrIncorrectListSize
 			return
 		}
-		for _, elem := range b.Validators {
+		for _, elem := range b.VoluntaryExits {
 			if err = elem.HashTreeRootWith(hh); err != nil {
 				return
 			}
 		}
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 1099511627776)
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 16)
 		} else {
-			hh.MerkleizeWithMixin(subIndx, num, 1099511627776)
+			hh.MerkleizeWithMixin(subIndx, num, 16)
 		}
 	}
 
-	// Field (12) 'Balances'
+	// Field (8) 'SyncAggregate'
+	if err = b.SyncAggregate.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (9) 'ExecutionPayloadHeader'
+	if err = b.ExecutionPayloadHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (10) 'BlsToExecutionChanges'
 	{
-		if size := len(b.Balances); size > 1099511627776 {
-			err = ssz.ErrListTooBigFn("--.Balances", size, 1099511627776)
+		subIndx := hh.Index()
+		num := uint64(len(b.BlsToExecutionChanges))
+		if num > 16 {
+			err = ssz.ErrIncorrectListSize
 			return
 		}
-		subIndx := hh.Index()
-		for _, i := range b.Balances {
-			hh.AppendUint64(i)
+		for _, elem := range b.BlsToExecutionChanges {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
 		}
-		hh.FillUpTo32()
-
-		numItems := uint64(len(b.Balances))
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 16)
 		} else {
-			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+			hh.MerkleizeWithMixin(subIndx, num, 16)
 		}
 	}
 
-	// Field (13) 'RandaoMixes'
+	// Field (11) 'BlobKzgCommitments'
 	{
-		if size := len(b.RandaoMixes); size != 65536 {
-			err = ssz.ErrVectorLengthFn("--.RandaoMixes", size, 65536)
+		if size := len(b.BlobKzgCommitments); size > 4096 {
+			err = ssz.ErrListTooBigFn("--.BlobKzgCommitments", size, 4096)
 			return
 		}
 		subIndx := hh.Index()
-		for _, i := range b.RandaoMixes {
-			if len(i) != 32 {
+		for _, i := range b.BlobKzgCommitments {
+			if len(i) != 48 {
 				err = ssz.ErrBytesLength
 				return
 			}
-			hh.Append(i)
+			hh.PutBytes(i)
 		}
 
+		numItems := uint64(len(b.BlobKzgCommitments))
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeVectorizedHTR(subIndx)
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, 4096)
 		} else {
-			hh.Merkleize(subIndx)
+			hh.MerkleizeWithMixin(subIndx, numItems, 4096)
 		}
 	}
 
-	// Field (14) 'Slashings'
-	{
-		if size := len(b.Slashings); size != 8192 {
-			err = ssz.ErrVectorLengthFn("--.Slashings", size, 8192)
-			return
-		}
-		subIndx := hh.Index()
-		for _, i := range b.Slashings {
-			hh.AppendUint64(i)
-		}
-
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeVectorizedHTR(subIndx)
-		} else {
-			hh.Merkleize(subIndx)
-		}
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
 	}
+	return
+}
 
-	// Field (15) 'PreviousEpochParticipation'
-	{
-		elemIndx := hh.Index()
-		byteLen := uint64(len(b.PreviousEpochParticipation))
-		if byteLen > 1099511627776 {
-			err = ssz.ErrIncorrectListSize
-			return
-		}
-		hh.PutBytes(b.PreviousEpochParticipation)
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(elemIndx, byteLen, (1099511627776+31)/32)
-		} else {
-			hh.MerkleizeWithMixin(elemIndx, byteLen, (1099511627776+31)/32)
-		}
-	}
+// MarshalSSZ ssz marshals the ValidatorRegistrationV1 object
+func (v *ValidatorRegistrationV1) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(v)
+}
 
-	// Field (16) 'CurrentEpochParticipation'
-	{
-		elemIndx := hh.Index()
-		byteLen := uint64(len(b.CurrentEpochParticipation))
-		if byteLen > 1099511627776 {
-			err = ssz.ErrIncorrectListSize
-			return
-		}
-		hh.PutBytes(b.CurrentEpochParticipation)
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(elemIndx, byteLen, (1099511627776+31)/32)
-		} else {
-			hh.MerkleizeWithMixin(elemIndx, byteLen, (1099511627776+31)/32)
-		}
-	}
+// MarshalSSZTo ssz marshals the ValidatorRegistrationV1 object to a target array
+func (v *ValidatorRegistrationV1) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
 
-	// Field (17) 'JustificationBits'
-	if size := len(b.JustificationBits); size != 1 {
-		err = ssz.ErrBytesLengthFn("--.JustificationBits", size, 1)
+	// Field (0) 'FeeRecipient'
+	if size := len(v.FeeRecipient); size != 20 {
+		err = ssz.ErrBytesLengthFn("--.FeeRecipient", size, 20)
 		return
 	}
-	hh.PutBytes(b.JustificationBits)
+	dst = append(dst, v.FeeRecipient...)
 
-	// Field (18) 'PreviousJustifiedCheckpoint'
-	if err = b.PreviousJustifiedCheckpoint.HashTreeRootWith(hh); err != nil {
+	// Field (1) 'GasLimit'
+	dst = ssz.MarshalUint64(dst, v.GasLimit)
+
+	// Field (2) 'Timestamp'
+	dst = ssz.MarshalUint64(dst, v.Timestamp)
+
+	// Field (3) 'Pubkey'
+	if size := len(v.Pubkey); size != 48 {
+		err = ssz.ErrBytesLengthFn("--.Pubkey", size, 48)
 		return
 	}
+	dst = append(dst, v.Pubkey...)
 
-	// Field (19) 'CurrentJustifiedCheckpoint'
-	if err = b.CurrentJustifiedCheckpoint.HashTreeRootWith(hh); err != nil {
-		return
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the ValidatorRegistrationV1 object
+func (v *ValidatorRegistrationV1) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 84 {
+		return ssz.ErrSize
 	}
 
-	// Field (20) 'FinalizedCheckpoint'
-	if err = b.FinalizedCheckpoint.HashTreeRootWith(hh); err != nil {
-		return
+	// Field (0) 'FeeRecipient'
+	if cap(v.FeeRecipient) == 0 {
+		v.FeeRecipient = make([]byte, 0, len(buf[0:20]))
 	}
+	v.FeeRecipient = append(v.FeeRecipient, buf[0:20]...)
 
-	// Field (21) 'InactivityScores'
-	{
-		if size := len(b.InactivityScores); size > 1099511627776 {
-			err = ssz.ErrListTooBigFn("--.InactivityScores", size, 1099511627776)
-			return
-		}
-		subIndx := hh.Index()
-		for _, i := range b.InactivityScores {
-			hh.AppendUint64(i)
-		}
-		hh.FillUpTo32()
+	// Field (1) 'GasLimit'
+	v.GasLimit = ssz.UnmarshallUint64(buf[20:28])
 
-		numItems := uint64(len(b.InactivityScores))
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
-		} else {
-			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
-		}
+	// Field (2) 'Timestamp'
+	v.Timestamp = ssz.UnmarshallUint64(buf[28:36])
+
+	// Field (3) 'Pubkey'
+	if cap(v.Pubkey) == 0 {
+		v.Pubkey = make([]byte, 0, len(buf[36:84]))
 	}
+	v.Pubkey = append(v.Pubkey, buf[36:84]...)
 
-	// Field (22) 'CurrentSyncCommittee'
-	if err = b.CurrentSyncCommittee.HashTreeRootWith(hh); err != nil {
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the ValidatorRegistrationV1 object
+func (v *ValidatorRegistrationV1) SizeSSZ() (size int) {
+	size = 84
+	return
+}
+
+// HashTreeRoot ssz hashes the ValidatorRegistrationV1 object
+func (v *ValidatorRegistrationV1) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(v)
+}
+
+// HashTreeRootWith ssz hashes the ValidatorRegistrationV1 object with a hasher
+func (v *ValidatorRegistrationV1) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'FeeRecipient'
+	if size := len(v.FeeRecipient); size != 20 {
+		err = ssz.ErrBytesLengthFn("--.FeeRecipient", size, 20)
 		return
 	}
+	hh.PutBytes(v.FeeRecipient)
 
-	// Field (23) 'NextSyncCommittee'
-	if err = b.NextSyncCommittee.HashTreeRootWith(hh); err != nil {
+	// Field (1) 'GasLimit'
+	hh.PutUint64(v.GasLimit)
+
+	// Field (2) 'Timestamp'
+	hh.PutUint64(v.Timestamp)
+
+	// Field (3) 'Pubkey'
+	if size := len(v.Pubkey); size != 48 {
+		err = ssz.ErrBytesLengthFn("--.Pubkey", size, 48)
 		return
 	}
+	hh.PutBytes(v.Pubkey)
 
 	if ssz.EnableVectorizedHTR {
 		hh.MerkleizeVectorizedHTR(indx)
@@ -8513,92 +8643,84 @@ func (b *BeaconStateAltair) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	return
 }
 
-// MarshalSSZ ssz marshals the Fork object
-func (f *Fork) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(f)
+// MarshalSSZ ssz marshals the SignedValidatorRegistrationV1 object
+func (s *SignedValidatorRegistrationV1) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(s)
 }
 
-// MarshalSSZTo ssz marshals the Fork object to a target array
-func (f *Fork) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+// MarshalSSZTo ssz marshals the SignedValidatorRegistrationV1 object to a target array
+func (s *SignedValidatorRegistrationV1) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
 
-	// Field (0) 'PreviousVersion'
-	if size := len(f.PreviousVersion); size != 4 {
-		err = ssz.ErrBytesLengthFn("--.PreviousVersion", size, 4)
+	// Field (0) 'Message'
+	if s.Message == nil {
+		s.Message = new(ValidatorRegistrationV1)
+	}
+	if dst, err = s.Message.MarshalSSZTo(dst); err != nil {
 		return
 	}
-	dst = append(dst, f.PreviousVersion...)
 
-	// Field (1) 'CurrentVersion'
-	if size := len(f.CurrentVersion); size != 4 {
-		err = ssz.ErrBytesLengthFn("--.CurrentVersion", size, 4)
+	// Field (1) 'Signature'
+	if size := len(s.Signature); size != 96 {
+		err = ssz.ErrBytesLengthFn("--.Signature", size, 96)
 		return
 	}
-	dst = append(dst, f.CurrentVersion...)
-
-	// Field (2) 'Epoch'
-	dst = ssz.MarshalUint64(dst, uint64(f.Epoch))
+	dst = append(dst, s.Signature...)
 
 	return
 }
 
-// UnmarshalSSZ ssz unmarshals the Fork object
-func (f *Fork) UnmarshalSSZ(buf []byte) error {
+// UnmarshalSSZ ssz unmarshals the SignedValidatorRegistrationV1 object
+func (s *SignedValidatorRegistrationV1) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size != 16 {
+	if size != 180 {
 		return ssz.ErrSize
 	}
 
-	// Field (0) 'PreviousVersion'
-	if cap(f.PreviousVersion) == 0 {
-		f.PreviousVersion = make([]byte, 0, len(buf[0:4]))
+	// Field (0) 'Message'
+	if s.Message == nil {
+		s.Message = new(ValidatorRegistrationV1)
 	}
-	f.PreviousVersion = append(f.PreviousVersion, buf[0:4]...)
-
-	// Field (1) 'CurrentVersion'
-	if cap(f.CurrentVersion) == 0 {
-		f.CurrentVersion = make([]byte, 0, len(buf[4:8]))
+	if err = s.Message.UnmarshalSSZ(buf[0:84]); err != nil {
+		return err
 	}
-	f.CurrentVersion = append(f.CurrentVersion, buf[4:8]...)
 
-	// Field (2) 'Epoch'
-	f.Epoch = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Epoch(ssz.UnmarshallUint64(buf[8:16]))
+	// Field (1) 'Signature'
+	if cap(s.Signature) == 0 {
+		s.Signature = make([]byte, 0, len(buf[84:180]))
+	}
+	s.Signature = append(s.Signature, buf[84:180]...)
 
 	return err
 }
 
-// SizeSSZ returns the ssz encoded size in bytes for the Fork object
-func (f *Fork) SizeSSZ() (size int) {
-	size = 16
+// SizeSSZ returns the ssz encoded size in bytes for the SignedValidatorRegistrationV1 object
+func (s *SignedValidatorRegistrationV1) SizeSSZ() (size int) {
+	size = 180
 	return
 }
 
-// HashTreeRoot ssz hashes the Fork object
-func (f *Fork) HashTreeRoot() ([32]byte, error) {
-	return ssz.HashWithDefaultHasher(f)
+// HashTreeRoot ssz hashes the SignedValidatorRegistrationV1 object
+func (s *SignedValidatorRegistrationV1) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(s)
 }
 
-// HashTreeRootWith ssz hashes the Fork object with a hasher
-func (f *Fork) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+// HashTreeRootWith ssz hashes the SignedValidatorRegistrationV1 object with a hasher
+func (s *SignedValidatorRegistrationV1) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	indx := hh.Index()
 
-	// Field (0) 'PreviousVersion'
-	if size := len(f.PreviousVersion); size != 4 {
-		err = ssz.ErrBytesLengthFn("--.PreviousVersion", size, 4)
+	// Field (0) 'Message'
+	if err = s.Message.HashTreeRootWith(hh); err != nil {
 		return
 	}
-	hh.PutBytes(f.PreviousVersion)
 
-	// Field (1) 'CurrentVersion'
-	if size := len(f.CurrentVersion); size != 4 {
-		err = ssz.ErrBytesLengthFn("--.CurrentVersion", size, 4)
+	// Field (1) 'Signature'
+	if size := len(s.Signature); size != 96 {
+		err = ssz.ErrBytesLengthFn("--.Signature", size, 96)
 		return
 	}
-	hh.PutBytes(f.CurrentVersion)
-
-	// Field (2) 'Epoch'
-	hh.PutUint64(uint64(f.Epoch))
+	hh.PutBytes(s.Signature)
 
 	if ssz.EnableVectorizedHTR {
 		hh.MerkleizeVectorizedHTR(indx)
@@ -8608,128 +8730,130 @@ func (f *Fork) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	return
 }
 
-// MarshalSSZ ssz marshals the PendingAttestation object
-func (p *PendingAttestation) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(p)
+// MarshalSSZ ssz marshals the BuilderBid object
+func (b *BuilderBid) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
 }
 
-// MarshalSSZTo ssz marshals the PendingAttestation object to a target array
-func (p *PendingAttestation) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+// MarshalSSZTo ssz marshals the BuilderBid object to a target array
+func (b *BuilderBid) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
-	offset := int(148)
+	offset := int(84)
 
-	// Offset (0) 'AggregationBits'
+	// Offset (0) 'Header'
 	dst = ssz.WriteOffset(dst, offset)
-	offset += len(p.AggregationBits)
-
-	// Field (1) 'Data'
-	if p.Data == nil {
-		p.Data = new(AttestationData)
+	if b.Header == nil {
+		b.Header = new(v1.ExecutionPayloadHeader)
 	}
-	if dst, err = p.Data.MarshalSSZTo(dst); err != nil {
+	offset += b.Header.SizeSSZ()
+
+	// Field (1) 'Value'
+	if size := len(b.Value); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.Value", size, 32)
 		return
 	}
+	dst = append(dst, b.Value...)
 
-	// Field (2) 'InclusionDelay'
-	dst = ssz.MarshalUint64(dst, uint64(p.InclusionDelay))
-
-	// Field (3) 'ProposerIndex'
-	dst = ssz.MarshalUint64(dst, uint64(p.ProposerIndex))
+	// Field (2) 'Pubkey'
+	if size := len(b.Pubkey); size != 48 {
+		err = ssz.ErrBytesLengthFn("--.Pubkey", size, 48)
+		return
+	}
+	dst = append(dst, b.Pubkey...)
 
-	// Field (0) 'AggregationBits'
-	if size := len(p.AggregationBits); size > 2048 {
-		err = ssz.ErrBytesLengthFn("--.AggregationBits", size, 2048)
+	// Field (0) 'Header'
+	if dst, err = b.Header.MarshalSSZTo(dst); err != nil {
 		return
 	}
-	dst = append(dst, p.AggregationBits...)
 
 	return
 }
 
-// UnmarshalSSZ ssz unmarshals the PendingAttestation object
-func (p *PendingAttestation) UnmarshalSSZ(buf []byte) error {
+// UnmarshalSSZ ssz unmarshals the BuilderBid object
+func (b *BuilderBid) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size < 148 {
+	if size < 84 {
 		return ssz.ErrSize
 	}
 
 	tail := buf
 	var o0 uint64
 
-	// Offset (0) 'AggregationBits'
+	// Offset (0) 'Header'
 	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
 		return ssz.ErrOffset
 	}
 
-	if o0 < 148 {
+	if o0 < 84 {
 		return ssz.ErrInvalidVariableOffset
 	}
 
-	// Field (1) 'Data'
-	if p.Data == nil {
-		p.Data = new(AttestationData)
-	}
-	if err = p.Data.UnmarshalSSZ(buf[4:132]); err != nil {
-		return err
+	// Field (1) 'Value'
+	if cap(b.Value) == 0 {
+		b.Value = make([]byte, 0, len(buf[4:36]))
 	}
+	b.Value = append(b.Value, buf[4:36]...)
 
-	// Field (2) 'InclusionDelay'
-	p.InclusionDelay = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[132:140]))
-
-	// Field (3) 'ProposerIndex'
-	p.ProposerIndex = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.ValidatorIndex(ssz.UnmarshallUint64(buf[140:148]))
+	// Field (2) 'Pubkey'
+	if cap(b.Pubkey) == 0 {
+		b.Pubkey = make([]byte, 0, len(buf[36:84]))
+	}
+	b.Pubkey = append(b.Pubkey, buf[36:84]...)
 
-	// Field (0) 'AggregationBits'
+	// Field (0) 'Header'
 	{
 		buf = tail[o0:]
-		if err = ssz.ValidateBitlist(buf, 2048); err != nil {
-			return err
+		if b.Header == nil {
+			b.Header = new(v1.ExecutionPayloadHeader)
 		}
-		if cap(p.AggregationBits) == 0 {
-			p.AggregationBits = make([]byte, 0, len(buf))
+		if err = b.Header.UnmarshalSSZ(buf); err != nil {
+			return err
 		}
-		p.AggregationBits = append(p.AggregationBits, buf...)
 	}
 	return err
 }
 
-// SizeSSZ returns the ssz encoded size in bytes for the PendingAttestation object
-func (p *PendingAttestation) SizeSSZ() (size int) {
-	size = 148
+// SizeSSZ returns the ssz encoded size in bytes for the BuilderBid object
+func (b *BuilderBid) SizeSSZ() (size int) {
+	size = 84
 
-	// Field (0) 'AggregationBits'
-	size += len(p.AggregationBits)
+	// Field (0) 'Header'
+	if b.Header == nil {
+		b.Header = new(v1.ExecutionPayloadHeader)
+	}
+	size += b.Header.SizeSSZ()
 
 	return
 }
 
-// HashTreeRoot ssz hashes the PendingAttestation object
-func (p *PendingAttestation) HashTreeRoot() ([32]byte, error) {
-	return ssz.HashWithDefaultHasher(p)
+// HashTreeRoot ssz hashes the BuilderBid object
+func (b *BuilderBid) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
 }
 
-// HashTreeRootWith ssz hashes the PendingAttestation object with a hasher
-func (p *PendingAttestation) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+// HashTreeRootWith ssz hashes the BuilderBid object with a hasher
+func (b *BuilderBid) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	indx := hh.Index()
 
-	// Field (0) 'AggregationBits'
-	if len(p.AggregationBits) == 0 {
-		err = ssz.ErrEmptyBitlist
+	// Field (0) 'Header'
+	if err = b.Header.HashTreeRootWith(hh); err != nil {
 		return
 	}
-	hh.PutBitlist(p.AggregationBits, 2048)
 
-	// Field (1) 'Data'
-	if err = p.Data.HashTreeRootWith(hh); err != nil {
+	// Field (1) 'Value'
+	if size := len(b.Value); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.Value", size, 32)
 		return
 	}
+	hh.PutBytes(b.Value)
 
-	// Field (2) 'InclusionDelay'
-	hh.PutUint64(uint64(p.InclusionDelay))
-
-	// Field (3) 'ProposerIndex'
-	hh.PutUint64(uint64(p.ProposerIndex))
+	// Field (2) 'Pubkey'
+	if size := len(b.Pubkey); size != 48 {
+		err = ssz.ErrBytesLengthFn("--.Pubkey", size, 48)
+		return
+	}
+	hh.PutBytes(b.Pubkey)
 
 	if ssz.EnableVectorizedHTR {
 		hh.MerkleizeVectorizedHTR(indx)
@@ -8739,303 +8863,130 @@ func (p *PendingAttestation) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	return
 }
 
-// MarshalSSZ ssz marshals the HistoricalBatch object
-func (h *HistoricalBatch) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(h)
+// MarshalSSZ ssz marshals the BuilderBidCapella object
+func (b *BuilderBidCapella) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
 }
 
-// MarshalSSZTo ssz marshals the HistoricalBatch object to a target array
-func (h *HistoricalBatch) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+// MarshalSSZTo ssz marshals the BuilderBidCapella object to a target array
+func (b *BuilderBidCapella) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
+	offset := int(84)
 
-	// Field (0) 'BlockRoots'
-	if size := len(h.BlockRoots); size != 8192 {
-		err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
-		return
+	// Offset (0) 'Header'
+	dst = ssz.WriteOffset(dst, offset)
+	if b.Header == nil {
+		b.Header = new(v1.ExecutionPayloadHeaderCapella)
 	}
-	for ii := 0; ii < 8192; ii++ {
-		if size := len(h.BlockRoots[ii]); size != 32 {
-			err = ssz.ErrBytesLengthFn("--.BlockRoots[ii]", size, 32)
-			return
-		}
-		dst = append(dst, h.BlockRoots[ii]...)
+	offset += b.Header.SizeSSZ()
+
+	// Field (1) 'Value'
+	if size := len(b.Value); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.Value", size, 32)
+		return
 	}
+	dst = append(dst, b.Value...)
 
-	// Field (1) 'StateRoots'
-	if size := len(h.StateRoots); size != 8192 {
-		err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
+	// Field (2) 'Pubkey'
+	if size := len(b.Pubkey); size != 48 {
+		err = ssz.ErrBytesLengthFn("--.Pubkey", size, 48)
 		return
 	}
-	for ii := 0; ii < 8192; ii++ {
-		if size := len(h.StateRoots[ii]); size != 32 {
-			err = ssz.ErrBytesLengthFn("--.StateRoots[ii]", size, 32)
-			return
-		}
-		dst = append(dst, h.StateRoots[ii]...)
+	dst = append(dst, b.Pubkey...)
+
+	// Field (0) 'Header'
+	if dst, err = b.Header.MarshalSSZTo(dst); err != nil {
+		return
 	}
 
 	return
 }
 
-// UnmarshalSSZ ssz unmarshals the HistoricalBatch object
-func (h *HistoricalBatch) UnmarshalSSZ(buf []byte) error {
+// UnmarshalSSZ ssz unmarshals the BuilderBidCapella object
+func (b *BuilderBidCapella) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size != 524288 {
+	if size < 84 {
 		return ssz.ErrSize
 	}
 
-	// Field (0) 'BlockRoots'
-	h.BlockRoots = make([][]byte, 8192)
-	for ii := 0; ii < 8192; ii++ {
-		if cap(h.BlockRoots[ii]) == 0 {
-			h.BlockRoots[ii] = make([]byte, 0, len(buf[0:262144][ii*32:(ii+1)*32]))
-		}
-		h.BlockRoots[ii] = append(h.BlockRoots[ii], buf[0:262144][ii*32:(ii+1)*32]...)
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'Header'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
 	}
 
-	// Field (1) 'StateRoots'
-	h.StateRoots = make([][]byte, 8192)
-	for ii := 0; ii < 8192; ii++ {
-		if cap(h.StateRoots[ii]) == 0 {
-			h.StateRoots[ii] = make([]byte, 0, len(buf[262144:524288][ii*32:(ii+1)*32]))
-		}
-		h.StateRoots[ii] = append(h.StateRoots[ii], buf[262144:524288][ii*32:(ii+1)*32]...)
+	if o0 < 84 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (1) 'Value'
+	if cap(b.Value) == 0 {
+		b.Value = make([]byte, 0, len(buf[4:36]))
 	}
+	b.Value = append(b.Value, buf[4:36]...)
+
+	// Field (2) 'Pubkey'
+	if cap(b.Pubkey) == 0 {
+		b.Pubkey = make([]byte, 0, len(buf[36:84]))
+	}
+	b.Pubkey = append(b.Pubkey, buf[36:84]...)
 
+	// Field (0) 'Header'
+	{
+		buf = tail[o0:]
+		if b.Header == nil {
+			b.Header = new(v1.ExecutionPayloadHeaderCapella)
+		}
+		if err = b.Header.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
 	return err
 }
 
-// SizeSSZ returns the ssz encoded size in bytes for the HistoricalBatch object
-func (h *HistoricalBatch) SizeSSZ() (size int) {
-	size = 524288
+// SizeSSZ returns the ssz encoded size in bytes for the BuilderBidCapella object
+func (b *BuilderBidCapella) SizeSSZ() (size int) {
+	size = 84
+
+	// Field (0) 'Header'
+	if b.Header == nil {
+		b.Header = new(v1.ExecutionPayloadHeaderCapella)
+	}
+	size += b.Header.SizeSSZ()
+
 	return
 }
 
-// HashTreeRoot ssz hashes the HistoricalBatch object
-func (h *HistoricalBatch) HashTreeRoot() ([32]byte, error) {
-	return ssz.HashWithDefaultHasher(h)
+// HashTreeRoot ssz hashes the BuilderBidCapella object
+func (b *BuilderBidCapella) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
 }
 
-// HashTreeRootWith ssz hashes the HistoricalBatch object with a hasher
-func (h *HistoricalBatch) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+// HashTreeRootWith ssz hashes the BuilderBidCapella object with a hasher
+func (b *BuilderBidCapella) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	indx := hh.Index()
 
-	// Field (0) 'BlockRoots'
-	{
-		if size := len(h.BlockRoots); size != 8192 {
-			err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
-			return
-		}
-		subIndx := hh.Index()
-		for _, i := range h.BlockRoots {
-			if len(i) != 32 {
-				err = ssz.ErrBytesLength
-				return
-			}
-			hh.Append(i)
-		}
-
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeVectorizedHTR(subIndx)
-		} else {
-			hh.Merkleize(subIndx)
-		}
-	}
-
-	// Field (1) 'StateRoots'
-	{
-		if size := len(h.StateRoots); size != 8192 {
-			err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
-			return
-		}
-		subIndx := hh.Index()
-		for _, i := range h.StateRoots {
-			if len(i) != 32 {
-				err = ssz.ErrBytesLength
-				return
-			}
-			hh.Append(i)
-		}
-
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeVectorizedHTR(subIndx)
-		} else {
-			hh.Merkleize(subIndx)
-		}
-	}
-
-	if ssz.EnableVectorizedHTR {
-		hh.MerkleizeVectorizedHTR(indx)
-	} else {
-		hh.Merkleize(indx)
-	}
-	return
-}
-
-// MarshalSSZ ssz marshals the SigningData object
-func (s *SigningData) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(s)
-}
-
-// MarshalSSZTo ssz marshals the SigningData object to a target array
-func (s *SigningData) MarshalSSZTo(buf []byte) (dst []byte, err error) {
-	dst = buf
-
-	// Field (0) 'ObjectRoot'
-	if size := len(s.ObjectRoot); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.ObjectRoot", size, 32)
-		return
-	}
-	dst = append(dst, s.ObjectRoot...)
-
-	// Field (1) 'Domain'
-	if size := len(s.Domain); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.Domain", size, 32)
-		return
-	}
-	dst = append(dst, s.Domain...)
-
-	return
-}
-
-// UnmarshalSSZ ssz unmarshals the SigningData object
-func (s *SigningData) UnmarshalSSZ(buf []byte) error {
-	var err error
-	size := uint64(len(buf))
-	if size != 64 {
-		return ssz.ErrSize
-	}
-
-	// Field (0) 'ObjectRoot'
-	if cap(s.ObjectRoot) == 0 {
-		s.ObjectRoot = make([]byte, 0, len(buf[0:32]))
-	}
-	s.ObjectRoot = append(s.ObjectRoot, buf[0:32]...)
-
-	// Field (1) 'Domain'
-	if cap(s.Domain) == 0 {
-		s.Domain = make([]byte, 0, len(buf[32:64]))
-	}
-	s.Domain = append(s.Domain, buf[32:64]...)
-
-	return err
-}
-
-// SizeSSZ returns the ssz encoded size in bytes for the SigningData object
-func (s *SigningData) SizeSSZ() (size int) {
-	size = 64
-	return
-}
-
-// HashTreeRoot ssz hashes the SigningData object
-func (s *SigningData) HashTreeRoot() ([32]byte, error) {
-	return ssz.HashWithDefaultHasher(s)
-}
-
-// HashTreeRootWith ssz hashes the SigningData object with a hasher
-func (s *SigningData) HashTreeRootWith(hh *ssz.Hasher) (err error) {
-	indx := hh.Index()
-
-	// Field (0) 'ObjectRoot'
-	if size := len(s.ObjectRoot); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.ObjectRoot", size, 32)
-		return
-	}
-	hh.PutBytes(s.ObjectRoot)
-
-	// Field (1) 'Domain'
-	if size := len(s.Domain); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.Domain", size, 32)
-		return
-	}
-	hh.PutBytes(s.Domain)
-
-	if ssz.EnableVectorizedHTR {
-		hh.MerkleizeVectorizedHTR(indx)
-	} else {
-		hh.Merkleize(indx)
-	}
-	return
-}
-
-// MarshalSSZ ssz marshals the ForkData object
-func (f *ForkData) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(f)
-}
-
-// MarshalSSZTo ssz marshals the ForkData object to a target array
-func (f *ForkData) MarshalSSZTo(buf []byte) (dst []byte, err error) {
-	dst = buf
-
-	// Field (0) 'CurrentVersion'
-	if size := len(f.CurrentVersion); size != 4 {
-		err = ssz.ErrBytesLengthFn("--.CurrentVersion", size, 4)
-		return
-	}
-	dst = append(dst, f.CurrentVersion...)
-
-	// Field (1) 'GenesisValidatorsRoot'
-	if size := len(f.GenesisValidatorsRoot); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
+	// Field (0) 'Header'
+	if err = b.Header.HashTreeRootWith(hh); err != nil {
 		return
 	}
-	dst = append(dst, f.GenesisValidatorsRoot...)
-
-	return
-}
-
-// UnmarshalSSZ ssz unmarshals the ForkData object
-func (f *ForkData) UnmarshalSSZ(buf []byte) error {
-	var err error
-	size := uint64(len(buf))
-	if size != 36 {
-		return ssz.ErrSize
-	}
-
-	// Field (0) 'CurrentVersion'
-	if cap(f.CurrentVersion) == 0 {
-		f.CurrentVersion = make([]byte, 0, len(buf[0:4]))
-	}
-	f.CurrentVersion = append(f.CurrentVersion, buf[0:4]...)
-
-	// Field (1) 'GenesisValidatorsRoot'
-	if cap(f.GenesisValidatorsRoot) == 0 {
-		f.GenesisValidatorsRoot = make([]byte, 0, len(buf[4:36]))
-	}
-	f.GenesisValidatorsRoot = append(f.GenesisValidatorsRoot, buf[4:36]...)
-
-	return err
-}
-
-// SizeSSZ returns the ssz encoded size in bytes for the ForkData object
-func (f *ForkData) SizeSSZ() (size int) {
-	size = 36
-	return
-}
 
-// HashTreeRoot ssz hashes the ForkData object
-func (f *ForkData) HashTreeRoot() ([32]byte, error) {
-	return ssz.HashWithDefaultHasher(f)
-}
-
-// HashTreeRootWith ssz hashes the ForkData object with a hasher
-func (f *ForkData) HashTreeRootWith(hh *ssz.Hasher) (err error) {
-	indx := hh.Index()
-
-	// Field (0) 'CurrentVersion'
-	if size := len(f.CurrentVersion); size != 4 {
-		err = ssz.ErrBytesLengthFn("--.CurrentVersion", size, 4)
+	// Field (1) 'Value'
+	if size := len(b.Value); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.Value", size, 32)
 		return
 	}
-	hh.PutBytes(f.CurrentVersion)
+	hh.PutBytes(b.Value)
 
-	// Field (1) 'GenesisValidatorsRoot'
-	if size := len(f.GenesisValidatorsRoot); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
+	// Field (2) 'Pubkey'
+	if size := len(b.Pubkey); size != 48 {
+		err = ssz.ErrBytesLengthFn("--.Pubkey", size, 48)
 		return
 	}
-	hh.PutBytes(f.GenesisValidatorsRoot)
+	hh.PutBytes(b.Pubkey)
 
 	if ssz.EnableVectorizedHTR {
 		hh.MerkleizeVectorizedHTR(indx)
@@ -9045,13 +8996,13 @@ func (f *ForkData) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	return
 }
 
-// MarshalSSZ ssz marshals the DepositMessage object
-func (d *DepositMessage) MarshalSSZ() ([]byte, error) {
+// MarshalSSZ ssz marshals the Deposit_Data object
+func (d *Deposit_Data) MarshalSSZ() ([]byte, error) {
 	return ssz.MarshalSSZ(d)
 }
 
-// MarshalSSZTo ssz marshals the DepositMessage object to a target array
-func (d *DepositMessage) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+// MarshalSSZTo ssz marshals the Deposit_Data object to a target array
+func (d *Deposit_Data) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
 
 	// Field (0) 'PublicKey'
@@ -9071,14 +9022,21 @@ func (d *DepositMessage) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	// Field (2) 'Amount'
 	dst = ssz.MarshalUint64(dst, d.Amount)
 
+	// Field (3) 'Signature'
+	if size := len(d.Signature); size != 96 {
+		err = ssz.ErrBytesLengthFn("--.Signature", size, 96)
+		return
+	}
+	dst = append(dst, d.Signature...)
+
 	return
 }
 
-// UnmarshalSSZ ssz unmarshals the DepositMessage object
-func (d *DepositMessage) UnmarshalSSZ(buf []byte) error {
+// UnmarshalSSZ ssz unmarshals the Deposit_Data object
+func (d *Deposit_Data) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size != 88 {
+	if size != 184 {
 		return ssz.ErrSize
 	}
 
@@ -9097,22 +9055,28 @@ func (d *DepositMessage) UnmarshalSSZ(buf []byte) error {
 	// Field (2) 'Amount'
 	d.Amount = ssz.UnmarshallUint64(buf[80:88])
 
+	// Field (3) 'Signature'
+	if cap(d.Signature) == 0 {
+		d.Signature = make([]byte, 0, len(buf[88:184]))
+	}
+	d.Signature = append(d.Signature, buf[88:184]...)
+
 	return err
 }
 
-// SizeSSZ returns the ssz encoded size in bytes for the DepositMessage object
-func (d *DepositMessage) SizeSSZ() (size int) {
-	size = 88
+// SizeSSZ returns the ssz encoded size in bytes for the Deposit_Data object
+func (d *Deposit_Data) SizeSSZ() (size int) {
+	size = 184
 	return
 }
 
-// HashTreeRoot ssz hashes the DepositMessage object
-func (d *DepositMessage) HashTreeRoot() ([32]byte, error) {
+// HashTreeRoot ssz hashes the Deposit_Data object
+func (d *Deposit_Data) HashTreeRoot() ([32]byte, error) {
 	return ssz.HashWithDefaultHasher(d)
 }
 
-// HashTreeRootWith ssz hashes the DepositMessage object with a hasher
-func (d *DepositMessage) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+// HashTreeRootWith ssz hashes the Deposit_Data object with a hasher
+func (d *Deposit_Data) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	indx := hh.Index()
 
 	// Field (0) 'PublicKey'
@@ -9132,6 +9096,13 @@ func (d *DepositMessage) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	// Field (2) 'Amount'
 	hh.PutUint64(d.Amount)
 
+	// Field (3) 'Signature'
+	if size := len(d.Signature); size != 96 {
+		err = ssz.ErrBytesLengthFn("--.Signature", size, 96)
+		return
+	}
+	hh.PutBytes(d.Signature)
+
 	if ssz.EnableVectorizedHTR {
 		hh.MerkleizeVectorizedHTR(indx)
 	} else {
@@ -9140,189 +9111,15 @@ func (d *DepositMessage) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	return
 }
 
-// MarshalSSZ ssz marshals the SyncCommittee object
-func (s *SyncCommittee) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(s)
+// MarshalSSZ ssz marshals the BeaconState object
+func (b *BeaconState) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
 }
 
-// MarshalSSZTo ssz marshals the SyncCommittee object to a target array
-func (s *SyncCommittee) MarshalSSZTo(buf []byte) (dst []byte, err error) {
-	dst = buf
-
-	// Field (0) 'Pubkeys'
-	if size := len(s.Pubkeys); size != 512 {
-		err = ssz.ErrVectorLengthFn("--.Pubkeys", size, 512)
-		return
-	}
-	for ii := 0; ii < 512; ii++ {
-		if size := len(s.Pubkeys[ii]); size != 48 {
-			err = ssz.ErrBytesLengthFn("--.Pubkeys[ii]", size, 48)
-			return
-		}
-		dst = append(dst, s.Pubkeys[ii]...)
-	}
-
-	// Field (1) 'AggregatePubkey'
-	if size := len(s.AggregatePubkey); size != 48 {
-		err = ssz.ErrBytesLengthFn("--.AggregatePubkey", size, 48)
-		return
-	}
-	dst = append(dst, s.AggregatePubkey...)
-
-	return
-}
-
-// UnmarshalSSZ ssz unmarshals the SyncCommittee object
-func (s *SyncCommittee) UnmarshalSSZ(buf []byte) error {
-	var err error
-	size := uint64(len(buf))
-	if size != 24624 {
-		return ssz.ErrSize
-	}
-
-	// Field (0) 'Pubkeys'
-	s.Pubkeys = make([][]byte, 512)
-	for ii := 0; ii < 512; ii++ {
-		if cap(s.Pubkeys[ii]) == 0 {
-			s.Pubkeys[ii] = make([]byte, 0, len(buf[0:24576][ii*48:(ii+1)*48]))
-		}
-		s.Pubkeys[ii] = append(s.Pubkeys[ii], buf[0:24576][ii*48:(ii+1)*48]...)
-	}
-
-	// Field (1) 'AggregatePubkey'
-	if cap(s.AggregatePubkey) == 0 {
-		s.AggregatePubkey = make([]byte, 0, len(buf[24576:24624]))
-	}
-	s.AggregatePubkey = append(s.AggregatePubkey, buf[24576:24624]...)
-
-	return err
-}
-
-// SizeSSZ returns the ssz encoded size in bytes for the SyncCommittee object
-func (s *SyncCommittee) SizeSSZ() (size int) {
-	size = 24624
-	return
-}
-
-// HashTreeRoot ssz hashes the SyncCommittee object
-func (s *SyncCommittee) HashTreeRoot() ([32]byte, error) {
-	return ssz.HashWithDefaultHasher(s)
-}
-
-// HashTreeRootWith ssz hashes the SyncCommittee object with a hasher
-func (s *SyncCommittee) HashTreeRootWith(hh *ssz.Hasher) (err error) {
-	indx := hh.Index()
-
-	// Field (0) 'Pubkeys'
-	{
-		if size := len(s.Pubkeys); size != 512 {
-			err = ssz.ErrVectorLengthFn("--.Pubkeys", size, 512)
-			return
-		}
-		subIndx := hh.Index()
-		for _, i := range s.Pubkeys {
-			if len(i) != 48 {
-				err = ssz.ErrBytesLength
-				return
-			}
-			hh.PutBytes(i)
-		}
-
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeVectorizedHTR(subIndx)
-		} else {
-			hh.Merkleize(subIndx)
-		}
-	}
-
-	// Field (1) 'AggregatePubkey'
-	if size := len(s.AggregatePubkey); size != 48 {
-		err = ssz.ErrBytesLengthFn("--.AggregatePubkey", size, 48)
-		return
-	}
-	hh.PutBytes(s.AggregatePubkey)
-
-	if ssz.EnableVectorizedHTR {
-		hh.MerkleizeVectorizedHTR(indx)
-	} else {
-		hh.Merkleize(indx)
-	}
-	return
-}
-
-// MarshalSSZ ssz marshals the SyncAggregatorSelectionData object
-func (s *SyncAggregatorSelectionData) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(s)
-}
-
-// MarshalSSZTo ssz marshals the SyncAggregatorSelectionData object to a target array
-func (s *SyncAggregatorSelectionData) MarshalSSZTo(buf []byte) (dst []byte, err error) {
-	dst = buf
-
-	// Field (0) 'Slot'
-	dst = ssz.MarshalUint64(dst, uint64(s.Slot))
-
-	// Field (1) 'SubcommitteeIndex'
-	dst = ssz.MarshalUint64(dst, s.SubcommitteeIndex)
-
-	return
-}
-
-// UnmarshalSSZ ssz unmarshals the SyncAggregatorSelectionData object
-func (s *SyncAggregatorSelectionData) UnmarshalSSZ(buf []byte) error {
-	var err error
-	size := uint64(len(buf))
-	if size != 16 {
-		return ssz.ErrSize
-	}
-
-	// Field (0) 'Slot'
-	s.Slot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[0:8]))
-
-	// Field (1) 'SubcommitteeIndex'
-	s.SubcommitteeIndex = ssz.UnmarshallUint64(buf[8:16])
-
-	return err
-}
-
-// SizeSSZ returns the ssz encoded size in bytes for the SyncAggregatorSelectionData object
-func (s *SyncAggregatorSelectionData) SizeSSZ() (size int) {
-	size = 16
-	return
-}
-
-// HashTreeRoot ssz hashes the SyncAggregatorSelectionData object
-func (s *SyncAggregatorSelectionData) HashTreeRoot() ([32]byte, error) {
-	return ssz.HashWithDefaultHasher(s)
-}
-
-// HashTreeRootWith ssz hashes the SyncAggregatorSelectionData object with a hasher
-func (s *SyncAggregatorSelectionData) HashTreeRootWith(hh *ssz.Hasher) (err error) {
-	indx := hh.Index()
-
-	// Field (0) 'Slot'
-	hh.PutUint64(uint64(s.Slot))
-
-	// Field (1) 'SubcommitteeIndex'
-	hh.PutUint64(s.SubcommitteeIndex)
-
-	if ssz.EnableVectorizedHTR {
-		hh.MerkleizeVectorizedHTR(indx)
-	} else {
-		hh.Merkleize(indx)
-	}
-	return
-}
-
-// MarshalSSZ ssz marshals the BeaconStateBellatrix object
-func (b *BeaconStateBellatrix) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(b)
-}
-
-// MarshalSSZTo ssz marshals the BeaconStateBellatrix object to a target array
-func (b *BeaconStateBellatrix) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+// MarshalSSZTo ssz marshals the BeaconState object to a target array
+func (b *BeaconState) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
-	offset := int(2736633)
+	offset := int(2687377)
 
 	// Field (0) 'GenesisTime'
 	dst = ssz.MarshalUint64(dst, b.GenesisTime)
@@ -9428,13 +9225,19 @@ func (b *BeaconStateBellatrix) MarshalSSZTo(buf []byte) (dst []byte, err error)
 		dst = ssz.MarshalUint64(dst, b.Slashings[ii])
 	}
 
-	// Offset (15) 'PreviousEpochParticipation'
+	// Offset (15) 'PreviousEpochAttestations'
 	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.PreviousEpochParticipation)
+	for ii := 0; ii < len(b.PreviousEpochAttestations); ii++ {
+		offset += 4
+		offset += b.PreviousEpochAttestations[ii].SizeSSZ()
+	}
 
-	// Offset (16) 'CurrentEpochParticipation'
+	// Offset (16) 'CurrentEpochAttestations'
 	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.CurrentEpochParticipation)
+	for ii := 0; ii < len(b.CurrentEpochAttestations); ii++ {
+		offset += 4
+		offset += b.CurrentEpochAttestations[ii].SizeSSZ()
+	}
 
 	// Field (17) 'JustificationBits'
 	if size := len(b.JustificationBits); size != 1 {
@@ -9467,33 +9270,6 @@ func (b *BeaconStateBellatrix) MarshalSSZTo(buf []byte) (dst []byte, err error)
 		return
 	}
 
-	// Offset (21) 'InactivityScores'
-	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.InactivityScores) * 8
-
-	// Field (22) 'CurrentSyncCommittee'
-	if b.CurrentSyncCommittee == nil {
-		b.CurrentSyncCommittee = new(SyncCommittee)
-	}
-	if dst, err = b.CurrentSyncCommittee.MarshalSSZTo(dst); err != nil {
-		return
-	}
-
-	// Field (23) 'NextSyncCommittee'
-	if b.NextSyncCommittee == nil {
-		b.NextSyncCommittee = new(SyncCommittee)
-	}
-	if dst, err = b.NextSyncCommittee.MarshalSSZTo(dst); err != nil {
-		return
-	}
-
-	// Offset (24) 'LatestExecutionPayloadHeader'
-	dst = ssz.WriteOffset(dst, offset)
-	if b.LatestExecutionPayloadHeader == nil {
-		b.LatestExecutionPayloadHeader = new(v1.ExecutionPayloadHeader)
-	}
-	offset += b.LatestExecutionPayloadHeader.SizeSSZ()
-
 	// Field (7) 'HistoricalRoots'
 	if size := len(b.HistoricalRoots); size > 16777216 {
 		err = ssz.ErrListTooBigFn("--.HistoricalRoots", size, 16777216)
@@ -9538,47 +9314,55 @@ func (b *BeaconStateBellatrix) MarshalSSZTo(buf []byte) (dst []byte, err error)
 		dst = ssz.MarshalUint64(dst, b.Balances[ii])
 	}
 
-	// Field (15) 'PreviousEpochParticipation'
-	if size := len(b.PreviousEpochParticipation); size > 1099511627776 {
-		err = ssz.ErrBytesLengthFn("--.PreviousEpochParticipation", size, 1099511627776)
-		return
-	}
-	dst = append(dst, b.PreviousEpochParticipation...)
-
-	// Field (16) 'CurrentEpochParticipation'
-	if size := len(b.CurrentEpochParticipation); size > 1099511627776 {
-		err = ssz.ErrBytesLengthFn("--.CurrentEpochParticipation", size, 1099511627776)
+	// Field (15) 'PreviousEpochAttestations'
+	if size := len(b.PreviousEpochAttestations); size > 4096 {
+		err = ssz.ErrListTooBigFn("--.PreviousEpochAttestations", size, 4096)
 		return
 	}
-	dst = append(dst, b.CurrentEpochParticipation...)
-
-	// Field (21) 'InactivityScores'
-	if size := len(b.InactivityScores); size > 1099511627776 {
-		err = ssz.ErrListTooBigFn("--.InactivityScores", size, 1099511627776)
-		return
+	{
+		offset = 4 * len(b.PreviousEpochAttestations)
+		for ii := 0; ii < len(b.PreviousEpochAttestations); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += b.PreviousEpochAttestations[ii].SizeSSZ()
+		}
 	}
-	for ii := 0; ii < len(b.InactivityScores); ii++ {
-		dst = ssz.MarshalUint64(dst, b.InactivityScores[ii])
+	for ii := 0; ii < len(b.PreviousEpochAttestations); ii++ {
+		if dst, err = b.PreviousEpochAttestations[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
 	}
 
-	// Field (24) 'LatestExecutionPayloadHeader'
-	if dst, err = b.LatestExecutionPayloadHeader.MarshalSSZTo(dst); err != nil {
+	// Field (16) 'CurrentEpochAttestations'
+	if size := len(b.CurrentEpochAttestations); size > 4096 {
+		err = ssz.ErrListTooBigFn("--.CurrentEpochAttestations", size, 4096)
 		return
 	}
+	{
+		offset = 4 * len(b.CurrentEpochAttestations)
+		for ii := 0; ii < len(b.CurrentEpochAttestations); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += b.CurrentEpochAttestations[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(b.CurrentEpochAttestations); ii++ {
+		if dst, err = b.CurrentEpochAttestations[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
 
 	return
 }
 
-// UnmarshalSSZ ssz unmarshals the BeaconStateBellatrix object
-func (b *BeaconStateBellatrix) UnmarshalSSZ(buf []byte) error {
+// UnmarshalSSZ ssz unmarshals the BeaconState object
+func (b *BeaconState) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size < 2736633 {
+	if size < 2687377 {
 		return ssz.ErrSize
 	}
 
 	tail := buf
-	var o7, o9, o11, o12, o15, o16, o21, o24 uint64
+	var o7, o9, o11, o12, o15, o16 uint64
 
 	// Field (0) 'GenesisTime'
 	b.GenesisTime = ssz.UnmarshallUint64(buf[0:8])
@@ -9631,7 +9415,7 @@ func (b *BeaconStateBellatrix) UnmarshalSSZ(buf []byte) error {
 		return ssz.ErrOffset
 	}
 
-	if o7 < 2736633 {
+	if o7 < 2687377 {
 		return ssz.ErrInvalidVariableOffset
 	}
 
@@ -9676,12 +9460,12 @@ func (b *BeaconStateBellatrix) UnmarshalSSZ(buf []byte) error {
 		b.Slashings[ii] = ssz.UnmarshallUint64(buf[2621712:2687248][ii*8 : (ii+1)*8])
 	}
 
-	// Offset (15) 'PreviousEpochParticipation'
+	// Offset (15) 'PreviousEpochAttestations'
 	if o15 = ssz.ReadOffset(buf[2687248:2687252]); o15 > size || o12 > o15 {
 		return ssz.ErrOffset
 	}
 
-	// Offset (16) 'CurrentEpochParticipation'
+	// Offset (16) 'CurrentEpochAttestations'
 	if o16 = ssz.ReadOffset(buf[2687252:2687256]); o16 > size || o15 > o16 {
 		return ssz.ErrOffset
 	}
@@ -9716,32 +9500,6 @@ func (b *BeaconStateBellatrix) UnmarshalSSZ(buf []byte) error {
 		return err
 	}
 
-	// Offset (21) 'InactivityScores'
-	if o21 = ssz.ReadOffset(buf[2687377:2687381]); o21 > size || o16 > o21 {
-		return ssz.ErrOffset
-	}
-
-	// Field (22) 'CurrentSyncCommittee'
-	if b.CurrentSyncCommittee == nil {
-		b.CurrentSyncCommittee = new(SyncCommittee)
-	}
-	if err = b.CurrentSyncCommittee.UnmarshalSSZ(buf[2687381:2712005]); err != nil {
-		return err
-	}
-
-	// Field (23) 'NextSyncCommittee'
-	if b.NextSyncCommittee == nil {
-		b.NextSyncCommittee = new(SyncCommittee)
-	}
-	if err = b.NextSyncCommittee.UnmarshalSSZ(buf[2712005:2736629]); err != nil {
-		return err
-	}
-
-	// Offset (24) 'LatestExecutionPayloadHeader'
-	if o24 = ssz.ReadOffset(buf[2736629:2736633]); o24 > size || o21 > o24 {
-		return ssz.ErrOffset
-	}
-
 	// Field (7) 'HistoricalRoots'
 	{
 		buf = tail[o7:o9]
@@ -9807,59 +9565,55 @@ func (b *BeaconStateBellatrix) UnmarshalSSZ(buf []byte) error {
 		}
 	}
 
-	// Field (15) 'PreviousEpochParticipation'
+	// Field (15) 'PreviousEpochAttestations'
 	{
 		buf = tail[o15:o16]
-		if len(buf) > 1099511627776 {
-			return ssz.ErrBytesLength
-		}
-		if cap(b.PreviousEpochParticipation) == 0 {
-			b.PreviousEpochParticipation = make([]byte, 0, len(buf))
-		}
-		b.PreviousEpochParticipation = append(b.PreviousEpochParticipation, buf...)
-	}
-
-	// Field (16) 'CurrentEpochParticipation'
-	{
-		buf = tail[o16:o21]
-		if len(buf) > 1099511627776 {
-			return ssz.ErrBytesLength
-		}
-		if cap(b.CurrentEpochParticipation) == 0 {
-			b.CurrentEpochParticipation = make([]byte, 0, len(buf))
-		}
-		b.CurrentEpochParticipation = append(b.CurrentEpochParticipation, buf...)
-	}
-
-	// Field (21) 'InactivityScores'
-	{
-		buf = tail[o21:o24]
-		num, err := ssz.DivideInt2(len(buf), 8, 1099511627776)
+		num, err := ssz.DecodeDynamicLength(buf, 4096)
 		if err != nil {
 			return err
 		}
-		b.InactivityScores = ssz.ExtendUint64(b.InactivityScores, num)
-		for ii := 0; ii < num; ii++ {
-			b.InactivityScores[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+		b.PreviousEpochAttestations = make([]*PendingAttestation, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if b.PreviousEpochAttestations[indx] == nil {
+				b.PreviousEpochAttestations[indx] = new(PendingAttestation)
+			}
+			if err = b.PreviousEpochAttestations[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
 	}
 
-	// Field (24) 'LatestExecutionPayloadHeader'
+	// Field (16) 'CurrentEpochAttestations'
 	{
-		buf = tail[o24:]
-		if b.LatestExecutionPayloadHeader == nil {
-			b.LatestExecutionPayloadHeader = new(v1.ExecutionPayloadHeader)
+		buf = tail[o16:]
+		num, err := ssz.DecodeDynamicLength(buf, 4096)
+		if err != nil {
+			return err
 		}
-		if err = b.LatestExecutionPayloadHeader.UnmarshalSSZ(buf); err != nil {
+		b.CurrentEpochAttestations = make([]*PendingAttestation, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if b.CurrentEpochAttestations[indx] == nil {
+				b.CurrentEpochAttestations[indx] = new(PendingAttestation)
+			}
+			if err = b.CurrentEpochAttestations[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
 			return err
 		}
 	}
 	return err
 }
 
-// SizeSSZ returns the ssz encoded size in bytes for the BeaconStateBellatrix object
-func (b *BeaconStateBellatrix) SizeSSZ() (size int) {
-	size = 2736633
+// SizeSSZ returns the ssz encoded size in bytes for the BeaconState object
+func (b *BeaconState) SizeSSZ() (size int) {
+	size = 2687377
 
 	// Field (7) 'HistoricalRoots'
 	size += len(b.HistoricalRoots) * 32
@@ -9873,31 +9627,28 @@ func (b *BeaconStateBellatrix) SizeSSZ() (size int) {
 	// Field (12) 'Balances'
 	size += len(b.Balances) * 8
 
-	// Field (15) 'PreviousEpochParticipation'
-	size += len(b.PreviousEpochParticipation)
-
-	// Field (16) 'CurrentEpochParticipation'
-	size += len(b.CurrentEpochParticipation)
-
-	// Field (21) 'InactivityScores'
-	size += len(b.InactivityScores) * 8
+	// Field (15) 'PreviousEpochAttestations'
+	for ii := 0; ii < len(b.PreviousEpochAttestations); ii++ {
+		size += 4
+		size += b.PreviousEpochAttestations[ii].SizeSSZ()
+	}
 
-	// Field (24) 'LatestExecutionPayloadHeader'
-	if b.LatestExecutionPayloadHeader == nil {
-		b.LatestExecutionPayloadHeader = new(v1.ExecutionPayloadHeader)
+	// Field (16) 'CurrentEpochAttestations'
+	for ii := 0; ii < len(b.CurrentEpochAttestations); ii++ {
+		size += 4
+		size += b.CurrentEpochAttestations[ii].SizeSSZ()
 	}
-	size += b.LatestExecutionPayloadHeader.SizeSSZ()
 
 	return
 }
 
-// HashTreeRoot ssz hashes the BeaconStateBellatrix object
-func (b *BeaconStateBellatrix) HashTreeRoot() ([32]byte, error) {
+// HashTreeRoot ssz hashes the BeaconState object
+func (b *BeaconState) HashTreeRoot() ([32]byte, error) {
 	return ssz.HashWithDefaultHasher(b)
 }
 
-// HashTreeRootWith ssz hashes the BeaconStateBellatrix object with a hasher
-func (b *BeaconStateBellatrix) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+// HashTreeRootWith ssz hashes the BeaconState object with a hasher
+func (b *BeaconState) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	indx := hh.Index()
 
 	// Field (0) 'GenesisTime'
@@ -10098,35 +9849,43 @@ func (b *BeaconStateBellatrix) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 		}
 	}
 
-	// Field (15) 'PreviousEpochParticipation'
+	// Field (15) 'PreviousEpochAttestations'
 	{
-		elemIndx := hh.Index()
-		byteLen := uint64(len(b.PreviousEpochParticipation))
-		if byteLen > 1099511627776 {
+		subIndx := hh.Index()
+		num := uint64(len(b.PreviousEpochAttestations))
+		if num > 4096 {
 			err = ssz.ErrIncorrectListSize
 			return
 		}
-		hh.PutBytes(b.PreviousEpochParticipation)
+		for _, elem := range b.PreviousEpochAttestations {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(elemIndx, byteLen, (1099511627776+31)/32)
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 4096)
 		} else {
-			hh.MerkleizeWithMixin(elemIndx, byteLen, (1099511627776+31)/32)
+			hh.MerkleizeWithMixin(subIndx, num, 4096)
 		}
 	}
 
-	// Field (16) 'CurrentEpochParticipation'
+	// Field (16) 'CurrentEpochAttestations'
 	{
-		elemIndx := hh.Index()
-		byteLen := uint64(len(b.CurrentEpochParticipation))
-		if byteLen > 1099511627776 {
+		subIndx := hh.Index()
+		num := uint64(len(b.CurrentEpochAttestations))
+		if num > 4096 {
 			err = ssz.ErrIncorrectListSize
 			return
 		}
-		hh.PutBytes(b.CurrentEpochParticipation)
+		for _, elem := range b.CurrentEpochAttestations {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(elemIndx, byteLen, (1099511627776+31)/32)
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 4096)
 		} else {
-			hh.MerkleizeWithMixin(elemIndx, byteLen, (1099511627776+31)/32)
+			hh.MerkleizeWithMixin(subIndx, num, 4096)
 		}
 	}
 
@@ -10152,40 +9911,6170 @@ func (b *BeaconStateBellatrix) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 		return
 	}
 
-	// Field (21) 'InactivityScores'
-	{
-		if size := len(b.InactivityScores); size > 1099511627776 {
-			err = ssz.ErrListTooBigFn("--.InactivityScores", size, 1099511627776)
-			return
-		}
-		subIndx := hh.Index()
-		for _, i := range b.InactivityScores {
-			hh.AppendUint64(i)
-		}
-		hh.FillUpTo32()
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
 
-		numItems := uint64(len(b.InactivityScores))
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
-		} else {
-			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
-		}
+// MarshalSSZ ssz marshals the BeaconStateAltair object
+func (b *BeaconStateAltair) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
+}
+
+// MarshalSSZTo ssz marshals the BeaconStateAltair object to a target array
+func (b *BeaconStateAltair) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(2736629)
+
+	// Field (0) 'GenesisTime'
+	dst = ssz.MarshalUint64(dst, b.GenesisTime)
+
+	// Field (1) 'GenesisValidatorsRoot'
+	if size := len(b.GenesisValidatorsRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
+		return
 	}
+	dst = append(dst, b.GenesisValidatorsRoot...)
 
-	// Field (22) 'CurrentSyncCommittee'
-	if err = b.CurrentSyncCommittee.HashTreeRootWith(hh); err != nil {
+	// Field (2) 'Slot'
+	dst = ssz.MarshalUint64(dst, uint64(b.Slot))
+
+	// Field (3) 'Fork'
+	if b.Fork == nil {
+		b.Fork = new(Fork)
+	}
+	if dst, err = b.Fork.MarshalSSZTo(dst); err != nil {
 		return
 	}
 
-	// Field (23) 'NextSyncCommittee'
-	if err = b.NextSyncCommittee.HashTreeRootWith(hh); err != nil {
+	// Field (4) 'LatestBlockHeader'
+	if b.LatestBlockHeader == nil {
+		b.LatestBlockHeader = new(BeaconBlockHeader)
+	}
+	if dst, err = b.LatestBlockHeader.MarshalSSZTo(dst); err != nil {
 		return
 	}
 
-	// Field (24) 'LatestExecutionPayloadHeader'
-	if err = b.LatestExecutionPayloadHeader.HashTreeRootWith(hh); err != nil {
+	// Field (5) 'BlockRoots'
+	if size := len(b.BlockRoots); size != 8192 {
+		err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
 		return
 	}
+	for ii := 0; ii < 8192; ii++ {
+		if size := len(b.BlockRoots[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.BlockRoots[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.BlockRoots[ii]...)
+	}
+
+	// Field (6) 'StateRoots'
+	if size := len(b.StateRoots); size != 8192 {
+		err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
+		return
+	}
+	for ii := 0; ii < 8192; ii++ {
+		if size := len(b.StateRoots[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.StateRoots[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.StateRoots[ii]...)
+	}
+
+	// Offset (7) 'HistoricalRoots'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.HistoricalRoots) * 32
+
+	// Field (8) 'Eth1Data'
+	if b.Eth1Data == nil {
+		b.Eth1Data = new(Eth1Data)
+	}
+	if dst, err = b.Eth1Data.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Offset (9) 'Eth1DataVotes'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Eth1DataVotes) * 72
+
+	// Field (10) 'Eth1DepositIndex'
+	dst = ssz.MarshalUint64(dst, b.Eth1DepositIndex)
+
+	// Offset (11) 'Validators'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Validators) * 121
+
+	// Offset (12) 'Balances'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Balances) * 8
+
+	// Field (13) 'RandaoMixes'
+	if size := len(b.RandaoMixes); size != 65536 {
+		err = ssz.ErrVectorLengthFn("--.RandaoMixes", size, 65536)
+		return
+	}
+	for ii := 0; ii < 65536; ii++ {
+		if size := len(b.RandaoMixes[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.RandaoMixes[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.RandaoMixes[ii]...)
+	}
+
+	// Field (14) 'Slashings'
+	if size := len(b.Slashings); size != 8192 {
+		err = ssz.ErrVectorLengthFn("--.Slashings", size, 8192)
+		return
+	}
+	for ii := 0; ii < 8192; ii++ {
+		dst = ssz.MarshalUint64(dst, b.Slashings[ii])
+	}
+
+	// Offset (15) 'PreviousEpochParticipation'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.PreviousEpochParticipation)
+
+	// Offset (16) 'CurrentEpochParticipation'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.CurrentEpochParticipation)
+
+	// Field (17) 'JustificationBits'
+	if size := len(b.JustificationBits); size != 1 {
+		err = ssz.ErrBytesLengthFn("--.JustificationBits", size, 1)
+		return
+	}
+	dst = append(dst, b.JustificationBits...)
+
+	// Field (18) 'PreviousJustifiedCheckpoint'
+	if b.PreviousJustifiedCheckpoint == nil {
+		b.PreviousJustifiedCheckpoint = new(Checkpoint)
+	}
+	if dst, err = b.PreviousJustifiedCheckpoint.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (19) 'CurrentJustifiedCheckpoint'
+	if b.CurrentJustifiedCheckpoint == nil {
+		b.CurrentJustifiedCheckpoint = new(Checkpoint)
+	}
+	if dst, err = b.CurrentJustifiedCheckpoint.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (20) 'FinalizedCheckpoint'
+	if b.FinalizedCheckpoint == nil {
+		b.FinalizedCheckpoint = new(Checkpoint)
+	}
+	if dst, err = b.FinalizedCheckpoint.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Offset (21) 'InactivityScores'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.InactivityScores) * 8
+
+	// Field (22) 'CurrentSyncCommittee'
+	if b.CurrentSyncCommittee == nil {
+		b.CurrentSyncCommittee = new(SyncCommittee)
+	}
+	if dst, err = b.CurrentSyncCommittee.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (23) 'NextSyncCommittee'
+	if b.NextSyncCommittee == nil {
+		b.NextSyncCommittee = new(SyncCommittee)
+	}
+	if dst, err = b.NextSyncCommittee.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (7) 'HistoricalRoots'
+	if size := len(b.HistoricalRoots); size > 16777216 {
+		err = ssz.ErrListTooBigFn("--.HistoricalRoots", size, 16777216)
+		return
+	}
+	for ii := 0; ii < len(b.HistoricalRoots); ii++ {
+		if size := len(b.HistoricalRoots[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.HistoricalRoots[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.HistoricalRoots[ii]...)
+	}
+
+	// Field (9) 'Eth1DataVotes'
+	if size := len(b.Eth1DataVotes); size > 2048 {
+		err = ssz.ErrListTooBigFn("--.Eth1DataVotes", size, 2048)
+		return
+	}
+	for ii := 0; ii < len(b.Eth1DataVotes); ii++ {
+		if dst, err = b.Eth1DataVotes[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (11) 'Validators'
+	if size := len(b.Validators); size > 1099511627776 {
+		err = ssz.ErrListTooBigFn("--.Validators", size, 1099511627776)
+		return
+	}
+	for ii := 0; ii < len(b.Validators); ii++ {
+		if dst, err = b.Validators[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (12) 'Balances'
+	if size := len(b.Balances); size > 1099511627776 {
+		err = ssz.ErrListTooBigFn("--.Balances", size, 1099511627776)
+		return
+	}
+	for ii := 0; ii < len(b.Balances); ii++ {
+		dst = ssz.MarshalUint64(dst, b.Balances[ii])
+	}
+
+	// Field (15) 'PreviousEpochParticipation'
+	if size := len(b.PreviousEpochParticipation); size > 1099511627776 {
+		err = ssz.ErrBytesLengthFn("--.PreviousEpochParticipation", size, 1099511627776)
+		return
+	}
+	dst = append(dst, b.PreviousEpochParticipation...)
+
+	// Field (16) 'CurrentEpochParticipation'
+	if size := len(b.CurrentEpochParticipation); size > 1099511627776 {
+		err = ssz.ErrBytesLengthFn("--.CurrentEpochParticipation", size, 1099511627776)
+		return
+	}
+	dst = append(dst, b.CurrentEpochParticipation...)
+
+	// Field (21) 'InactivityScores'
+	if size := len(b.InactivityScores); size > 1099511627776 {
+		err = ssz.ErrListTooBigFn("--.InactivityScores", size, 1099511627776)
+		return
+	}
+	for ii := 0; ii < len(b.InactivityScores); ii++ {
+		dst = ssz.MarshalUint64(dst, b.InactivityScores[ii])
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the BeaconStateAltair object
+func (b *BeaconStateAltair) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 2736629 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o7, o9, o11, o12, o15, o16, o21 uint64
+
+	// Field (0) 'GenesisTime'
+	b.GenesisTime = ssz.UnmarshallUint64(buf[0:8])
+
+	// Field (1) 'GenesisValidatorsRoot'
+	if cap(b.GenesisValidatorsRoot) == 0 {
+		b.GenesisValidatorsRoot = make([]byte, 0, len(buf[8:40]))
+	}
+	b.GenesisValidatorsRoot = append(b.GenesisValidatorsRoot, buf[8:40]...)
+
+	// Field (2) 'Slot'
+	b.Slot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[40:48]))
+
+	// Field (3) 'Fork'
+	if b.Fork == nil {
+		b.Fork = new(Fork)
+	}
+	if err = b.Fork.UnmarshalSSZ(buf[48:64]); err != nil {
+		return err
+	}
+
+	// Field (4) 'LatestBlockHeader'
+	if b.LatestBlockHeader == nil {
+		b.LatestBlockHeader = new(BeaconBlockHeader)
+	}
+	if err = b.LatestBlockHeader.UnmarshalSSZ(buf[64:176]); err != nil {
+		return err
+	}
+
+	// Field (5) 'BlockRoots'
+	b.BlockRoots = make([][]byte, 8192)
+	for ii := 0; ii < 8192; ii++ {
+		if cap(b.BlockRoots[ii]) == 0 {
+			b.BlockRoots[ii] = make([]byte, 0, len(buf[176:262320][ii*32:(ii+1)*32]))
+		}
+		b.BlockRoots[ii] = append(b.BlockRoots[ii], buf[176:262320][ii*32:(ii+1)*32]...)
+	}
+
+	// Field (6) 'StateRoots'
+	b.StateRoots = make([][]byte, 8192)
+	for ii := 0; ii < 8192; ii++ {
+		if cap(b.StateRoots[ii]) == 0 {
+			b.StateRoots[ii] = make([]byte, 0, len(buf[262320:524464][ii*32:(ii+1)*32]))
+		}
+		b.StateRoots[ii] = append(b.StateRoots[ii], buf[262320:524464][ii*32:(ii+1)*32]...)
+	}
+
+	// Offset (7) 'HistoricalRoots'
+	if o7 = ssz.ReadOffset(buf[524464:524468]); o7 > size {
+		return ssz.ErrOffset
+	}
+
+	if o7 < 2736629 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (8) 'Eth1Data'
+	if b.Eth1Data == nil {
+		b.Eth1Data = new(Eth1Data)
+	}
+	if err = b.Eth1Data.UnmarshalSSZ(buf[524468:524540]); err != nil {
+		return err
+	}
+
+	// Offset (9) 'Eth1DataVotes'
+	if o9 = ssz.ReadOffset(buf[524540:524544]); o9 > size || o7 > o9 {
+		return ssz.ErrOffset
+	}
+
+	// Field (10) 'Eth1DepositIndex'
+	b.Eth1DepositIndex = ssz.UnmarshallUint64(buf[524544:524552])
+
+	// Offset (11) 'Validators'
+	if o11 = ssz.ReadOffset(buf[524552:524556]); o11 > size || o9 > o11 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (12) 'Balances'
+	if o12 = ssz.ReadOffset(buf[524556:524560]); o12 > size || o11 > o12 {
+		return ssz.ErrOffset
+	}
+
+	// Field (13) 'RandaoMixes'
+	b.RandaoMixes = make([][]byte, 65536)
+	for ii := 0; ii < 65536; ii++ {
+		if cap(b.RandaoMixes[ii]) == 0 {
+			b.RandaoMixes[ii] = make([]byte, 0, len(buf[524560:2621712][ii*32:(ii+1)*32]))
+		}
+		b.RandaoMixes[ii] = append(b.RandaoMixes[ii], buf[524560:2621712][ii*32:(ii+1)*32]...)
+	}
+
+	// Field (14) 'Slashings'
+	b.Slashings = ssz.ExtendUint64(b.Slashings, 8192)
+	for ii := 0; ii < 8192; ii++ {
+		b.Slashings[ii] = ssz.UnmarshallUint64(buf[2621712:2687248][ii*8 : (ii+1)*8])
+	}
+
+	// Offset (15) 'PreviousEpochParticipation'
+	if o15 = ssz.ReadOffset(buf[2687248:2687252]); o15 > size || o12 > o15 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (16) 'CurrentEpochParticipation'
+	if o16 = ssz.ReadOffset(buf[2687252:2687256]); o16 > size || o15 > o16 {
+		return ssz.ErrOffset
+	}
+
+	// Field (17) 'JustificationBits'
+	if cap(b.JustificationBits) == 0 {
+		b.JustificationBits = make([]byte, 0, len(buf[2687256:2687257]))
+	}
+	b.JustificationBits = append(b.JustificationBits, buf[2687256:2687257]...)
+
+	// Field (18) 'PreviousJustifiedCheckpoint'
+	if b.PreviousJustifiedCheckpoint == nil {
+		b.PreviousJustifiedCheckpoint = new(Checkpoint)
+	}
+	if err = b.PreviousJustifiedCheckpoint.UnmarshalSSZ(buf[2687257:2687297]); err != nil {
+		return err
+	}
+
+	// Field (19) 'CurrentJustifiedCheckpoint'
+	if b.CurrentJustifiedCheckpoint == nil {
+		b.CurrentJustifiedCheckpoint = new(Checkpoint)
+	}
+	if err = b.CurrentJustifiedCheckpoint.UnmarshalSSZ(buf[2687297:2687337]); err != nil {
+		return err
+	}
+
+	// Field (20) 'FinalizedCheckpoint'
+	if b.FinalizedCheckpoint == nil {
+		b.FinalizedCheckpoint = new(Checkpoint)
+	}
+	if err = b.FinalizedCheckpoint.UnmarshalSSZ(buf[2687337:2687377]); err != nil {
+		return err
+	}
+
+	// Offset (21) 'InactivityScores'
+	if o21 = ssz.ReadOffset(buf[2687377:2687381]); o21 > size || o16 > o21 {
+		return ssz.ErrOffset
+	}
+
+	// Field (22) 'CurrentSyncCommittee'
+	if b.CurrentSyncCommittee == nil {
+		b.CurrentSyncCommittee = new(SyncCommittee)
+	}
+	if err = b.CurrentSyncCommittee.UnmarshalSSZ(buf[2687381:2712005]); err != nil {
+		return err
+	}
+
+	// Field (23) 'NextSyncCommittee'
+	if b.NextSyncCommittee == nil {
+		b.NextSyncCommittee = new(SyncCommittee)
+	}
+	if err = b.NextSyncCommittee.UnmarshalSSZ(buf[2712005:2736629]); err != nil {
+		return err
+	}
+
+	// Field (7) 'HistoricalRoots'
+	{
+		buf = tail[o7:o9]
+		num, err := ssz.DivideInt2(len(buf), 32, 16777216)
+		if err != nil {
+			return err
+		}
+		b.HistoricalRoots = make([][]byte, num)
+		for ii := 0; ii < num; ii++ {
+			if cap(b.HistoricalRoots[ii]) == 0 {
+				b.HistoricalRoots[ii] = make([]byte, 0, len(buf[ii*32:(ii+1)*32]))
+			}
+			b.HistoricalRoots[ii] = append(b.HistoricalRoots[ii], buf[ii*32:(ii+1)*32]...)
+		}
+	}
+
+	// Field (9) 'Eth1DataVotes'
+	{
+		buf = tail[o9:o11]
+		num, err := ssz.DivideInt2(len(buf), 72, 2048)
+		if err != nil {
+			return err
+		}
+		b.Eth1DataVotes = make([]*Eth1Data, num)
+		for ii := 0; ii < num; ii++ {
+			if b.Eth1DataVotes[ii] == nil {
+				b.Eth1DataVotes[ii] = new(Eth1Data)
+			}
+			if err = b.Eth1DataVotes[ii].UnmarshalSSZ(buf[ii*72 : (ii+1)*72]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (11) 'Validators'
+	{
+		buf = tail[o11:o12]
+		num, err := ssz.DivideInt2(len(buf), 121, 1099511627776)
+		if err != nil {
+			return err
+		}
+		b.Validators = make([]*Validator, num)
+		for ii := 0; ii < num; ii++ {
+			if b.Validators[ii] == nil {
+				b.Validators[ii] = new(Validator)
+			}
+			if err = b.Validators[ii].UnmarshalSSZ(buf[ii*121 : (ii+1)*121]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (12) 'Balances'
+	{
+		buf = tail[o12:o15]
+		num, err := ssz.DivideInt2(len(buf), 8, 1099511627776)
+		if err != nil {
+			return err
+		}
+		b.Balances = ssz.ExtendUint64(b.Balances, num)
+		for ii := 0; ii < num; ii++ {
+			b.Balances[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+		}
+	}
+
+	// Field (15) 'PreviousEpochParticipation'
+	{
+		buf = tail[o15:o16]
+		if len(buf) > 1099511627776 {
+			return ssz.ErrBytesLength
+		}
+		if cap(b.PreviousEpochParticipation) == 0 {
+			b.PreviousEpochParticipation = make([]byte, 0, len(buf))
+		}
+		b.PreviousEpochParticipation = append(b.PreviousEpochParticipation, buf...)
+	}
+
+	// Field (16) 'CurrentEpochParticipation'
+	{
+		buf = tail[o16:o21]
+		if len(buf) > 1099511627776 {
+			return ssz.ErrBytesLength
+		}
+		if cap(b.CurrentEpochParticipation) == 0 {
+			b.CurrentEpochParticipation = make([]byte, 0, len(buf))
+		}
+		b.CurrentEpochParticipation = append(b.CurrentEpochParticipation, buf...)
+	}
+
+	// Field (21) 'InactivityScores'
+	{
+		buf = tail[o21:]
+		num, err := ssz.DivideInt2(len(buf), 8, 1099511627776)
+		if err != nil {
+			return err
+		}
+		b.InactivityScores = ssz.ExtendUint64(b.InactivityScores, num)
+		for ii := 0; ii < num; ii++ {
+			b.InactivityScores[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the BeaconStateAltair object
+func (b *BeaconStateAltair) SizeSSZ() (size int) {
+	size = 2736629
+
+	// Field (7) 'HistoricalRoots'
+	size += len(b.HistoricalRoots) * 32
+
+	// Field (9) 'Eth1DataVotes'
+	size += len(b.Eth1DataVotes) * 72
+
+	// Field (11) 'Validators'
+	size += len(b.Validators) * 121
+
+	// Field (12) 'Balances'
+	size += len(b.Balances) * 8
+
+	// Field (15) 'PreviousEpochParticipation'
+	size += len(b.PreviousEpochParticipation)
+
+	// Field (16) 'CurrentEpochParticipation'
+	size += len(b.CurrentEpochParticipation)
+
+	// Field (21) 'InactivityScores'
+	size += len(b.InactivityScores) * 8
+
+	return
+}
+
+// HashTreeRoot ssz hashes the BeaconStateAltair object
+func (b *BeaconStateAltair) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
+}
+
+// HashTreeRootWith ssz hashes the BeaconStateAltair object with a hasher
+func (b *BeaconStateAltair) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'GenesisTime'
+	hh.PutUint64(b.GenesisTime)
+
+	// Field (1) 'GenesisValidatorsRoot'
+	if size := len(b.GenesisValidatorsRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
+		return
+	}
+	hh.PutBytes(b.GenesisValidatorsRoot)
+
+	// Field (2) 'Slot'
+	hh.PutUint64(uint64(b.Slot))
+
+	// Field (3) 'Fork'
+	if err = b.Fork.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (4) 'LatestBlockHeader'
+	if err = b.LatestBlockHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (5) 'BlockRoots'
+	{
+		if size := len(b.BlockRoots); size != 8192 {
+			err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.BlockRoots {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (6) 'StateRoots'
+	{
+		if size := len(b.StateRoots); size != 8192 {
+			err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.StateRoots {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (7) 'HistoricalRoots'
+	{
+		if size := len(b.HistoricalRoots); size > 16777216 {
+			err = ssz.ErrListTooBigFn("--.HistoricalRoots", size, 16777216)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.HistoricalRoots {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		numItems := uint64(len(b.HistoricalRoots))
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(16777216, numItems, 32))
+		} else {
+			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(16777216, numItems, 32))
+		}
+	}
+
+	// Field (8) 'Eth1Data'
+	if err = b.Eth1Data.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (9) 'Eth1DataVotes'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(b.Eth1DataVotes))
+		if num > 2048 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range b.Eth1DataVotes {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 2048)
+		} else {
+			hh.MerkleizeWithMixin(subIndx, num, 2048)
+		}
+	}
+
+	// Field (10) 'Eth1DepositIndex'
+	hh.PutUint64(b.Eth1DepositIndex)
+
+	// Field (11) 'Validators'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(b.Validators))
+		if num > 1099511627776 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range b.Validators {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 1099511627776)
+		} else {
+			hh.MerkleizeWithMixin(subIndx, num, 1099511627776)
+		}
+	}
+
+	// Field (12) 'Balances'
+	{
+		if size := len(b.Balances); size > 1099511627776 {
+			err = ssz.ErrListTooBigFn("--.Balances", size, 1099511627776)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.Balances {
+			hh.AppendUint64(i)
+		}
+		hh.FillUpTo32()
+
+		numItems := uint64(len(b.Balances))
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		} else {
+			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		}
+	}
+
+	// Field (13) 'RandaoMixes'
+	{
+		if size := len(b.RandaoMixes); size != 65536 {
+			err = ssz.ErrVectorLengthFn("--.RandaoMixes", size, 65536)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.RandaoMixes {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (14) 'Slashings'
+	{
+		if size := len(b.Slashings); size != 8192 {
+			err = ssz.ErrVectorLengthFn("--.Slashings", size, 8192)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.Slashings {
+			hh.AppendUint64(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (15) 'PreviousEpochParticipation'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(b.PreviousEpochParticipation))
+		if byteLen > 1099511627776 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(b.PreviousEpochParticipation)
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(elemIndx, byteLen, (1099511627776+31)/32)
+		} else {
+			hh.MerkleizeWithMixin(elemIndx, byteLen, (1099511627776+31)/32)
+		}
+	}
+
+	// Field (16) 'CurrentEpochParticipation'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(b.CurrentEpochParticipation))
+		if byteLen > 1099511627776 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(b.CurrentEpochParticipation)
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(elemIndx, byteLen, (1099511627776+31)/32)
+		} else {
+			hh.MerkleizeWithMixin(elemIndx, byteLen, (1099511627776+31)/32)
+		}
+	}
+
+	// Field (17) 'JustificationBits'
+	if size := len(b.JustificationBits); size != 1 {
+		err = ssz.ErrBytesLengthFn("--.JustificationBits", size, 1)
+		return
+	}
+	hh.PutBytes(b.JustificationBits)
+
+	// Field (18) 'PreviousJustifiedCheckpoint'
+	if err = b.PreviousJustifiedCheckpoint.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (19) 'CurrentJustifiedCheckpoint'
+	if err = b.CurrentJustifiedCheckpoint.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (20) 'FinalizedCheckpoint'
+	if err = b.FinalizedCheckpoint.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (21) 'InactivityScores'
+	{
+		if size := len(b.InactivityScores); size > 1099511627776 {
+			err = ssz.ErrListTooBigFn("--.InactivityScores", size, 1099511627776)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.InactivityScores {
+			hh.AppendUint64(i)
+		}
+		hh.FillUpTo32()
+
+		numItems := uint64(len(b.InactivityScores))
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		} else {
+			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		}
+	}
+
+	// Field (22) 'CurrentSyncCommittee'
+	if err = b.CurrentSyncCommittee.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (23) 'NextSyncCommittee'
+	if err = b.NextSyncCommittee.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the Fork object
+func (f *Fork) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(f)
+}
+
+// MarshalSSZTo ssz marshals the Fork object to a target array
+func (f *Fork) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'PreviousVersion'
+	if size := len(f.PreviousVersion); size != 4 {
+		err = ssz.ErrBytesLengthFn("--.PreviousVersion", size, 4)
+		return
+	}
+	dst = append(dst, f.PreviousVersion...)
+
+	// Field (1) 'CurrentVersion'
+	if size := len(f.CurrentVersion); size != 4 {
+		err = ssz.ErrBytesLengthFn("--.CurrentVersion", size, 4)
+		return
+	}
+	dst = append(dst, f.CurrentVersion...)
+
+	// Field (2) 'Epoch'
+	dst = ssz.MarshalUint64(dst, uint64(f.Epoch))
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the Fork object
+func (f *Fork) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 16 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'PreviousVersion'
+	if cap(f.PreviousVersion) == 0 {
+		f.PreviousVersion = make([]byte, 0, len(buf[0:4]))
+	}
+	f.PreviousVersion = append(f.PreviousVersion, buf[0:4]...)
+
+	// Field (1) 'CurrentVersion'
+	if cap(f.CurrentVersion) == 0 {
+		f.CurrentVersion = make([]byte, 0, len(buf[4:8]))
+	}
+	f.CurrentVersion = append(f.CurrentVersion, buf[4:8]...)
+
+	// Field (2) 'Epoch'
+	f.Epoch = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Epoch(ssz.UnmarshallUint64(buf[8:16]))
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the Fork object
+func (f *Fork) SizeSSZ() (size int) {
+	size = 16
+	return
+}
+
+// HashTreeRoot ssz hashes the Fork object
+func (f *Fork) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(f)
+}
+
+// HashTreeRootWith ssz hashes the Fork object with a hasher
+func (f *Fork) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'PreviousVersion'
+	if size := len(f.PreviousVersion); size != 4 {
+		err = ssz.ErrBytesLengthFn("--.PreviousVersion", size, 4)
+		return
+	}
+	hh.PutBytes(f.PreviousVersion)
+
+	// Field (1) 'CurrentVersion'
+	if size := len(f.CurrentVersion); size != 4 {
+		err = ssz.ErrBytesLengthFn("--.CurrentVersion", size, 4)
+		return
+	}
+	hh.PutBytes(f.CurrentVersion)
+
+	// Field (2) 'Epoch'
+	hh.PutUint64(uint64(f.Epoch))
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the PendingAttestation object
+func (p *PendingAttestation) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(p)
+}
+
+// MarshalSSZTo ssz marshals the PendingAttestation object to a target array
+func (p *PendingAttestation) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(148)
+
+	// Offset (0) 'AggregationBits'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(p.AggregationBits)
+
+	// Field (1) 'Data'
+	if p.Data == nil {
+		p.Data = new(AttestationData)
+	}
+	if dst, err = p.Data.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (2) 'InclusionDelay'
+	dst = ssz.MarshalUint64(dst, uint64(p.InclusionDelay))
+
+	// Field (3) 'ProposerIndex'
+	dst = ssz.MarshalUint64(dst, uint64(p.ProposerIndex))
+
+	// Field (0) 'AggregationBits'
+	if size := len(p.AggregationBits); size > 2048 {
+		err = ssz.ErrBytesLengthFn("--.AggregationBits", size, 2048)
+		return
+	}
+	dst = append(dst, p.AggregationBits...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the PendingAttestation object
+func (p *PendingAttestation) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 148 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'AggregationBits'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 148 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (1) 'Data'
+	if p.Data == nil {
+		p.Data = new(AttestationData)
+	}
+	if err = p.Data.UnmarshalSSZ(buf[4:132]); err != nil {
+		return err
+	}
+
+	// Field (2) 'InclusionDelay'
+	p.InclusionDelay = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[132:140]))
+
+	// Field (3) 'ProposerIndex'
+	p.ProposerIndex = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.ValidatorIndex(ssz.UnmarshallUint64(buf[140:148]))
+
+	// Field (0) 'AggregationBits'
+	{
+		buf = tail[o0:]
+		if err = ssz.ValidateBitlist(buf, 2048); err != nil {
+			return err
+		}
+		if cap(p.AggregationBits) == 0 {
+			p.AggregationBits = make([]byte, 0, len(buf))
+		}
+		p.AggregationBits = append(p.AggregationBits, buf...)
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the PendingAttestation object
+func (p *PendingAttestation) SizeSSZ() (size int) {
+	size = 148
+
+	// Field (0) 'AggregationBits'
+	size += len(p.AggregationBits)
+
+	return
+}
+
+// HashTreeRoot ssz hashes the PendingAttestation object
+func (p *PendingAttestation) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(p)
+}
+
+// HashTreeRootWith ssz hashes the PendingAttestation object with a hasher
+func (p *PendingAttestation) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'AggregationBits'
+	if len(p.AggregationBits) == 0 {
+		err = ssz.ErrEmptyBitlist
+		return
+	}
+	hh.PutBitlist(p.AggregationBits, 2048)
+
+	// Field (1) 'Data'
+	if err = p.Data.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (2) 'InclusionDelay'
+	hh.PutUint64(uint64(p.InclusionDelay))
+
+	// Field (3) 'ProposerIndex'
+	hh.PutUint64(uint64(p.ProposerIndex))
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the HistoricalBatch object
+func (h *HistoricalBatch) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(h)
+}
+
+// MarshalSSZTo ssz marshals the HistoricalBatch object to a target array
+func (h *HistoricalBatch) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'BlockRoots'
+	if size := len(h.BlockRoots); size != 8192 {
+		err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
+		return
+	}
+	for ii := 0; ii < 8192; ii++ {
+		if size := len(h.BlockRoots[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.BlockRoots[ii]", size, 32)
+			return
+		}
+		dst = append(dst, h.BlockRoots[ii]...)
+	}
+
+	// Field (1) 'StateRoots'
+	if size := len(h.StateRoots); size != 8192 {
+		err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
+		return
+	}
+	for ii := 0; ii < 8192; ii++ {
+		if size := len(h.StateRoots[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.StateRoots[ii]", size, 32)
+			return
+		}
+		dst = append(dst, h.StateRoots[ii]...)
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the HistoricalBatch object
+func (h *HistoricalBatch) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 524288 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'BlockRoots'
+	h.BlockRoots = make([][]byte, 8192)
+	for ii := 0; ii < 8192; ii++ {
+		if cap(h.BlockRoots[ii]) == 0 {
+			h.BlockRoots[ii] = make([]byte, 0, len(buf[0:262144][ii*32:(ii+1)*32]))
+		}
+		h.BlockRoots[ii] = append(h.BlockRoots[ii], buf[0:262144][ii*32:(ii+1)*32]...)
+	}
+
+	// Field (1) 'StateRoots'
+	h.StateRoots = make([][]byte, 8192)
+	for ii := 0; ii < 8192; ii++ {
+		if cap(h.StateRoots[ii]) == 0 {
+			h.StateRoots[ii] = make([]byte, 0, len(buf[262144:524288][ii*32:(ii+1)*32]))
+		}
+		h.StateRoots[ii] = append(h.StateRoots[ii], buf[262144:524288][ii*32:(ii+1)*32]...)
+	}
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the HistoricalBatch object
+func (h *HistoricalBatch) SizeSSZ() (size int) {
+	size = 524288
+	return
+}
+
+// HashTreeRoot ssz hashes the HistoricalBatch object
+func (h *HistoricalBatch) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(h)
+}
+
+// HashTreeRootWith ssz hashes the HistoricalBatch object with a hasher
+func (h *HistoricalBatch) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'BlockRoots'
+	{
+		if size := len(h.BlockRoots); size != 8192 {
+			err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range h.BlockRoots {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (1) 'StateRoots'
+	{
+		if size := len(h.StateRoots); size != 8192 {
+			err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range h.StateRoots {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the SigningData object
+func (s *SigningData) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(s)
+}
+
+// MarshalSSZTo ssz marshals the SigningData object to a target array
+func (s *SigningData) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'ObjectRoot'
+	if size := len(s.ObjectRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.ObjectRoot", size, 32)
+		return
+	}
+	dst = append(dst, s.ObjectRoot...)
+
+	// Field (1) 'Domain'
+	if size := len(s.Domain); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.Domain", size, 32)
+		return
+	}
+	dst = append(dst, s.Domain...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the SigningData object
+func (s *SigningData) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 64 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'ObjectRoot'
+	if cap(s.ObjectRoot) == 0 {
+		s.ObjectRoot = make([]byte, 0, len(buf[0:32]))
+	}
+	s.ObjectRoot = append(s.ObjectRoot, buf[0:32]...)
+
+	// Field (1) 'Domain'
+	if cap(s.Domain) == 0 {
+		s.Domain = make([]byte, 0, len(buf[32:64]))
+	}
+	s.Domain = append(s.Domain, buf[32:64]...)
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the SigningData object
+func (s *SigningData) SizeSSZ() (size int) {
+	size = 64
+	return
+}
+
+// HashTreeRoot ssz hashes the SigningData object
+func (s *SigningData) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(s)
+}
+
+// HashTreeRootWith ssz hashes the SigningData object with a hasher
+func (s *SigningData) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'ObjectRoot'
+	if size := len(s.ObjectRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.ObjectRoot", size, 32)
+		return
+	}
+	hh.PutBytes(s.ObjectRoot)
+
+	// Field (1) 'Domain'
+	if size := len(s.Domain); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.Domain", size, 32)
+		return
+	}
+	hh.PutBytes(s.Domain)
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the ForkData object
+func (f *ForkData) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(f)
+}
+
+// MarshalSSZTo ssz marshals the ForkData object to a target array
+func (f *ForkData) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'CurrentVersion'
+	if size := len(f.CurrentVersion); size != 4 {
+		err = ssz.ErrBytesLengthFn("--.CurrentVersion", size, 4)
+		return
+	}
+	dst = append(dst, f.CurrentVersion...)
+
+	// Field (1) 'GenesisValidatorsRoot'
+	if size := len(f.GenesisValidatorsRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
+		return
+	}
+	dst = append(dst, f.GenesisValidatorsRoot...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the ForkData object
+func (f *ForkData) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 36 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'CurrentVersion'
+	if cap(f.CurrentVersion) == 0 {
+		f.CurrentVersion = make([]byte, 0, len(buf[0:4]))
+	}
+	f.CurrentVersion = append(f.CurrentVersion, buf[0:4]...)
+
+	// Field (1) 'GenesisValidatorsRoot'
+	if cap(f.GenesisValidatorsRoot) == 0 {
+		f.GenesisValidatorsRoot = make([]byte, 0, len(buf[4:36]))
+	}
+	f.GenesisValidatorsRoot = append(f.GenesisValidatorsRoot, buf[4:36]...)
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the ForkData object
+func (f *ForkData) SizeSSZ() (size int) {
+	size = 36
+	return
+}
+
+// HashTreeRoot ssz hashes the ForkData object
+func (f *ForkData) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(f)
+}
+
+// HashTreeRootWith ssz hashes the ForkData object with a hasher
+func (f *ForkData) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'CurrentVersion'
+	if size := len(f.CurrentVersion); size != 4 {
+		err = ssz.ErrBytesLengthFn("--.CurrentVersion", size, 4)
+		return
+	}
+	hh.PutBytes(f.CurrentVersion)
+
+	// Field (1) 'GenesisValidatorsRoot'
+	if size := len(f.GenesisValidatorsRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
+		return
+	}
+	hh.PutBytes(f.GenesisValidatorsRoot)
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the DepositMessage object
+func (d *DepositMessage) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(d)
+}
+
+// MarshalSSZTo ssz marshals the DepositMessage object to a target array
+func (d *DepositMessage) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'PublicKey'
+	if size := len(d.PublicKey); size != 48 {
+		err = ssz.ErrBytesLengthFn("--.PublicKey", size, 48)
+		return
+	}
+	dst = append(dst, d.PublicKey...)
+
+	// Field (1) 'WithdrawalCredentials'
+	if size := len(d.WithdrawalCredentials); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.WithdrawalCredentials", size, 32)
+		return
+	}
+	dst = append(dst, d.WithdrawalCredentials...)
+
+	// Field (2) 'Amount'
+	dst = ssz.MarshalUint64(dst, d.Amount)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the DepositMessage object
+func (d *DepositMessage) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 88 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'PublicKey'
+	if cap(d.PublicKey) == 0 {
+		d.PublicKey = make([]byte, 0, len(buf[0:48]))
+	}
+	d.PublicKey = append(d.PublicKey, buf[0:48]...)
+
+	// Field (1) 'WithdrawalCredentials'
+	if cap(d.WithdrawalCredentials) == 0 {
+		d.WithdrawalCredentials = make([]byte, 0, len(buf[48:80]))
+	}
+	d.WithdrawalCredentials = append(d.WithdrawalCredentials, buf[48:80]...)
+
+	// Field (2) 'Amount'
+	d.Amount = ssz.UnmarshallUint64(buf[80:88])
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the DepositMessage object
+func (d *DepositMessage) SizeSSZ() (size int) {
+	size = 88
+	return
+}
+
+// HashTreeRoot ssz hashes the DepositMessage object
+func (d *DepositMessage) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(d)
+}
+
+// HashTreeRootWith ssz hashes the DepositMessage object with a hasher
+func (d *DepositMessage) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'PublicKey'
+	if size := len(d.PublicKey); size != 48 {
+		err = ssz.ErrBytesLengthFn("--.PublicKey", size, 48)
+		return
+	}
+	hh.PutBytes(d.PublicKey)
+
+	// Field (1) 'WithdrawalCredentials'
+	if size := len(d.WithdrawalCredentials); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.WithdrawalCredentials", size, 32)
+		return
+	}
+	hh.PutBytes(d.WithdrawalCredentials)
+
+	// Field (2) 'Amount'
+	hh.PutUint64(d.Amount)
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the SyncCommittee object
+func (s *SyncCommittee) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(s)
+}
+
+// MarshalSSZTo ssz marshals the SyncCommittee object to a target array
+func (s *SyncCommittee) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'Pubkeys'
+	if size := len(s.Pubkeys); size != 512 {
+		err = ssz.ErrVectorLengthFn("--.Pubkeys", size, 512)
+		return
+	}
+	for ii := 0; ii < 512; ii++ {
+		if size := len(s.Pubkeys[ii]); size != 48 {
+			err = ssz.ErrBytesLengthFn("--.Pubkeys[ii]", size, 48)
+			return
+		}
+		dst = append(dst, s.Pubkeys[ii]...)
+	}
+
+	// Field (1) 'AggregatePubkey'
+	if size := len(s.AggregatePubkey); size != 48 {
+		err = ssz.ErrBytesLengthFn("--.AggregatePubkey", size, 48)
+		return
+	}
+	dst = append(dst, s.AggregatePubkey...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the SyncCommittee object
+func (s *SyncCommittee) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 24624 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'Pubkeys'
+	s.Pubkeys = make([][]byte, 512)
+	for ii := 0; ii < 512; ii++ {
+		if cap(s.Pubkeys[ii]) == 0 {
+			s.Pubkeys[ii] = make([]byte, 0, len(buf[0:24576][ii*48:(ii+1)*48]))
+		}
+		s.Pubkeys[ii] = append(s.Pubkeys[ii], buf[0:24576][ii*48:(ii+1)*48]...)
+	}
+
+	// Field (1) 'AggregatePubkey'
+	if cap(s.AggregatePubkey) == 0 {
+		s.AggregatePubkey = make([]byte, 0, len(buf[24576:24624]))
+	}
+	s.AggregatePubkey = append(s.AggregatePubkey, buf[24576:24624]...)
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the SyncCommittee object
+func (s *SyncCommittee) SizeSSZ() (size int) {
+	size = 24624
+	return
+}
+
+// HashTreeRoot ssz hashes the SyncCommittee object
+func (s *SyncCommittee) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(s)
+}
+
+// HashTreeRootWith ssz hashes the SyncCommittee object with a hasher
+func (s *SyncCommittee) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Pubkeys'
+	{
+		if size := len(s.Pubkeys); size != 512 {
+			err = ssz.ErrVectorLengthFn("--.Pubkeys", size, 512)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range s.Pubkeys {
+			if len(i) != 48 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.PutBytes(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (1) 'AggregatePubkey'
+	if size := len(s.AggregatePubkey); size != 48 {
+		err = ssz.ErrBytesLengthFn("--.AggregatePubkey", size, 48)
+		return
+	}
+	hh.PutBytes(s.AggregatePubkey)
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the SyncAggregatorSelectionData object
+func (s *SyncAggregatorSelectionData) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(s)
+}
+
+// MarshalSSZTo ssz marshals the SyncAggregatorSelectionData object to a target array
+func (s *SyncAggregatorSelectionData) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'Slot'
+	dst = ssz.MarshalUint64(dst, uint64(s.Slot))
+
+	// Field (1) 'SubcommitteeIndex'
+	dst = ssz.MarshalUint64(dst, s.SubcommitteeIndex)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the SyncAggregatorSelectionData object
+func (s *SyncAggregatorSelectionData) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 16 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'Slot'
+	s.Slot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[0:8]))
+
+	// Field (1) 'SubcommitteeIndex'
+	s.SubcommitteeIndex = ssz.UnmarshallUint64(buf[8:16])
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the SyncAggregatorSelectionData object
+func (s *SyncAggregatorSelectionData) SizeSSZ() (size int) {
+	size = 16
+	return
+}
+
+// HashTreeRoot ssz hashes the SyncAggregatorSelectionData object
+func (s *SyncAggregatorSelectionData) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(s)
+}
+
+// HashTreeRootWith ssz hashes the SyncAggregatorSelectionData object with a hasher
+func (s *SyncAggregatorSelectionData) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Slot'
+	hh.PutUint64(uint64(s.Slot))
+
+	// Field (1) 'SubcommitteeIndex'
+	hh.PutUint64(s.SubcommitteeIndex)
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the BeaconStateBellatrix object
+func (b *BeaconStateBellatrix) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
+}
+
+// MarshalSSZTo ssz marshals the BeaconStateBellatrix object to a target array
+func (b *BeaconStateBellatrix) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(2736633)
+
+	// Field (0) 'GenesisTime'
+	dst = ssz.MarshalUint64(dst, b.GenesisTime)
+
+	// Field (1) 'GenesisValidatorsRoot'
+	if size := len(b.GenesisValidatorsRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
+		return
+	}
+	dst = append(dst, b.GenesisValidatorsRoot...)
+
+	// Field (2) 'Slot'
+	dst = ssz.MarshalUint64(dst, uint64(b.Slot))
+
+	// Field (3) 'Fork'
+	if b.Fork == nil {
+		b.Fork = new(Fork)
+	}
+	if dst, err = b.Fork.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (4) 'LatestBlockHeader'
+	if b.LatestBlockHeader == nil {
+		b.LatestBlockHeader = new(BeaconBlockHeader)
+	}
+	if dst, err = b.LatestBlockHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (5) 'BlockRoots'
+	if size := len(b.BlockRoots); size != 8192 {
+		err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
+		return
+	}
+	for ii := 0; ii < 8192; ii++ {
+		if size := len(b.BlockRoots[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.BlockRoots[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.BlockRoots[ii]...)
+	}
+
+	// Field (6) 'StateRoots'
+	if size := len(b.StateRoots); size != 8192 {
+		err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
+		return
+	}
+	for ii := 0; ii < 8192; ii++ {
+		if size := len(b.StateRoots[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.StateRoots[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.StateRoots[ii]...)
+	}
+
+	// Offset (7) 'HistoricalRoots'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.HistoricalRoots) * 32
+
+	// Field (8) 'Eth1Data'
+	if b.Eth1Data == nil {
+		b.Eth1Data = new(Eth1Data)
+	}
+	if dst, err = b.Eth1Data.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Offset (9) 'Eth1DataVotes'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Eth1DataVotes) * 72
+
+	// Field (10) 'Eth1DepositIndex'
+	dst = ssz.MarshalUint64(dst, b.Eth1DepositIndex)
+
+	// Offset (11) 'Validators'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Validators) * 121
+
+	// Offset (12) 'Balances'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Balances) * 8
+
+	// Field (13) 'RandaoMixes'
+	if size := len(b.RandaoMixes); size != 65536 {
+		err = ssz.ErrVectorLengthFn("--.RandaoMixes", size, 65536)
+		return
+	}
+	for ii := 0; ii < 65536; ii++ {
+		if size := len(b.RandaoMixes[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.RandaoMixes[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.RandaoMixes[ii]...)
+	}
+
+	// Field (14) 'Slashings'
+	if size := len(b.Slashings); size != 8192 {
+		err = ssz.ErrVectorLengthFn("--.Slashings", size, 8192)
+		return
+	}
+	for ii := 0; ii < 8192; ii++ {
+		dst = ssz.MarshalUint64(dst, b.Slashings[ii])
+	}
+
+	// Offset (15) 'PreviousEpochParticipation'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.PreviousEpochParticipation)
+
+	// Offset (16) 'CurrentEpochParticipation'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.CurrentEpochParticipation)
+
+	// Field (17) 'JustificationBits'
+	if size := len(b.JustificationBits); size != 1 {
+		err = ssz.ErrBytesLengthFn("--.JustificationBits", size, 1)
+		return
+	}
+	dst = append(dst, b.JustificationBits...)
+
+	// Field (18) 'PreviousJustifiedCheckpoint'
+	if b.PreviousJustifiedCheckpoint == nil {
+		b.PreviousJustifiedCheckpoint = new(Checkpoint)
+	}
+	if dst, err = b.PreviousJustifiedCheckpoint.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (19) 'CurrentJustifiedCheckpoint'
+	if b.CurrentJustifiedCheckpoint == nil {
+		b.CurrentJustifiedCheckpoint = new(Checkpoint)
+	}
+	if dst, err = b.CurrentJustifiedCheckpoint.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (20) 'FinalizedCheckpoint'
+	if b.FinalizedCheckpoint == nil {
+		b.FinalizedCheckpoint = new(Checkpoint)
+	}
+	if dst, err = b.FinalizedCheckpoint.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Offset (21) 'InactivityScores'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.InactivityScores) * 8
+
+	// Field (22) 'CurrentSyncCommittee'
+	if b.CurrentSyncCommittee == nil {
+		b.CurrentSyncCommittee = new(SyncCommittee)
+	}
+	if dst, err = b.CurrentSyncCommittee.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (23) 'NextSyncCommittee'
+	if b.NextSyncCommittee == nil {
+		b.NextSyncCommittee = new(SyncCommittee)
+	}
+	if dst, err = b.NextSyncCommittee.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Offset (24) 'LatestExecutionPayloadHeader'
+	dst = ssz.WriteOffset(dst, offset)
+	if b.LatestExecutionPayloadHeader == nil {
+		b.LatestExecutionPayloadHeader = new(v1.ExecutionPayloadHeader)
+	}
+	offset += b.LatestExecutionPayloadHeader.SizeSSZ()
+
+	// Field (7) 'HistoricalRoots'
+	if size := len(b.HistoricalRoots); size > 16777216 {
+		err = ssz.ErrListTooBigFn("--.HistoricalRoots", size, 16777216)
+		return
+	}
+	for ii := 0; ii < len(b.HistoricalRoots); ii++ {
+		if size := len(b.HistoricalRoots[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.HistoricalRoots[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.HistoricalRoots[ii]...)
+	}
+
+	// Field (9) 'Eth1DataVotes'
+	if size := len(b.Eth1DataVotes); size > 2048 {
+		err = ssz.ErrListTooBigFn("--.Eth1DataVotes", size, 2048)
+		return
+	}
+	for ii := 0; ii < len(b.Eth1DataVotes); ii++ {
+		if dst, err = b.Eth1DataVotes[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (11) 'Validators'
+	if size := len(b.Validators); size > 1099511627776 {
+		err = ssz.ErrListTooBigFn("--.Validators", size, 1099511627776)
+		return
+	}
+	for ii := 0; ii < len(b.Validators); ii++ {
+		if dst, err = b.Validators[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (12) 'Balances'
+	if size := len(b.Balances); size > 1099511627776 {
+		err = ssz.ErrListTooBigFn("--.Balances", size, 1099511627776)
+		return
+	}
+	for ii := 0; ii < len(b.Balances); ii++ {
+		dst = ssz.MarshalUint64(dst, b.Balances[ii])
+	}
+
+	// Field (15) 'PreviousEpochParticipation'
+	if size := len(b.PreviousEpochParticipation); size > 1099511627776 {
+		err = ssz.ErrBytesLengthFn("--.PreviousEpochParticipation", size, 1099511627776)
+		return
+	}
+	dst = append(dst, b.PreviousEpochParticipation...)
+
+	// Field (16) 'CurrentEpochParticipation'
+	if size := len(b.CurrentEpochParticipation); size > 1099511627776 {
+		err = ssz.ErrBytesLengthFn("--.CurrentEpochParticipation", size, 1099511627776)
+		return
+	}
+	dst = append(dst, b.CurrentEpochParticipation...)
+
+	// Field (21) 'InactivityScores'
+	if size := len(b.InactivityScores); size > 1099511627776 {
+		err = ssz.ErrListTooBigFn("--.InactivityScores", size, 1099511627776)
+		return
+	}
+	for ii := 0; ii < len(b.InactivityScores); ii++ {
+		dst = ssz.MarshalUint64(dst, b.InactivityScores[ii])
+	}
+
+	// Field (24) 'LatestExecutionPayloadHeader'
+	if dst, err = b.LatestExecutionPayloadHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the BeaconStateBellatrix object
+func (b *BeaconStateBellatrix) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 2736633 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o7, o9, o11, o12, o15, o16, o21, o24 uint64
+
+	// Field (0) 'GenesisTime'
+	b.GenesisTime = ssz.UnmarshallUint64(buf[0:8])
+
+	// Field (1) 'GenesisValidatorsRoot'
+	if cap(b.GenesisValidatorsRoot) == 0 {
+		b.GenesisValidatorsRoot = make([]byte, 0, len(buf[8:40]))
+	}
+	b.GenesisValidatorsRoot = append(b.GenesisValidatorsRoot, buf[8:40]...)
+
+	// Field (2) 'Slot'
+	b.Slot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[40:48]))
+
+	// Field (3) 'Fork'
+	if b.Fork == nil {
+		b.Fork = new(Fork)
+	}
+	if err = b.Fork.UnmarshalSSZ(buf[48:64]); err != nil {
+		return err
+	}
+
+	// Field (4) 'LatestBlockHeader'
+	if b.LatestBlockHeader == nil {
+		b.LatestBlockHeader = new(BeaconBlockHeader)
+	}
+	if err = b.LatestBlockHeader.UnmarshalSSZ(buf[64:176]); err != nil {
+		return err
+	}
+
+	// Field (5) 'BlockRoots'
+	b.BlockRoots = make([][]byte, 8192)
+	for ii := 0; ii < 8192; ii++ {
+		if cap(b.BlockRoots[ii]) == 0 {
+			b.BlockRoots[ii] = make([]byte, 0, len(buf[176:262320][ii*32:(ii+1)*32]))
+		}
+		b.BlockRoots[ii] = append(b.BlockRoots[ii], buf[176:262320][ii*32:(ii+1)*32]...)
+	}
+
+	// Field (6) 'StateRoots'
+	b.StateRoots = make([][]byte, 8192)
+	for ii := 0; ii < 8192; ii++ {
+		if cap(b.StateRoots[ii]) == 0 {
+			b.StateRoots[ii] = make([]byte, 0, len(buf[262320:524464][ii*32:(ii+1)*32]))
+		}
+		b.StateRoots[ii] = append(b.StateRoots[ii], buf[262320:524464][ii*32:(ii+1)*32]...)
+	}
+
+	// Offset (7) 'HistoricalRoots'
+	if o7 = ssz.ReadOffset(buf[524464:524468]); o7 > size {
+		return ssz.ErrOffset
+	}
+
+	if o7 < 2736633 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (8) 'Eth1Data'
+	if b.Eth1Data == nil {
+		b.Eth1Data = new(Eth1Data)
+	}
+	if err = b.Eth1Data.UnmarshalSSZ(buf[524468:524540]); err != nil {
+		return err
+	}
+
+	// Offset (9) 'Eth1DataVotes'
+	if o9 = ssz.ReadOffset(buf[524540:524544]); o9 > size || o7 > o9 {
+		return ssz.ErrOffset
+	}
+
+	// Field (10) 'Eth1DepositIndex'
+	b.Eth1DepositIndex = ssz.UnmarshallUint64(buf[524544:524552])
+
+	// Offset (11) 'Validators'
+	if o11 = ssz.ReadOffset(buf[524552:524556]); o11 > size || o9 > o11 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (12) 'Balances'
+	if o12 = ssz.ReadOffset(buf[524556:524560]); o12 > size || o11 > o12 {
+		return ssz.ErrOffset
+	}
+
+	// Field (13) 'RandaoMixes'
+	b.RandaoMixes = make([][]byte, 65536)
+	for ii := 0; ii < 65536; ii++ {
+		if cap(b.RandaoMixes[ii]) == 0 {
+			b.RandaoMixes[ii] = make([]byte, 0, len(buf[524560:2621712][ii*32:(ii+1)*32]))
+		}
+		b.RandaoMixes[ii] = append(b.RandaoMixes[ii], buf[524560:2621712][ii*32:(ii+1)*32]...)
+	}
+
+	// Field (14) 'Slashings'
+	b.Slashings = ssz.ExtendUint64(b.Slashings, 8192)
+	for ii := 0; ii < 8192; ii++ {
+		b.Slashings[ii] = ssz.UnmarshallUint64(buf[2621712:2687248][ii*8 : (ii+1)*8])
+	}
+
+	// Offset (15) 'PreviousEpochParticipation'
+	if o15 = ssz.ReadOffset(buf[2687248:2687252]); o15 > size || o12 > o15 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (16) 'CurrentEpochParticipation'
+	if o16 = ssz.ReadOffset(buf[2687252:2687256]); o16 > size || o15 > o16 {
+		return ssz.ErrOffset
+	}
+
+	// Field (17) 'JustificationBits'
+	if cap(b.JustificationBits) == 0 {
+		b.JustificationBits = make([]byte, 0, len(buf[2687256:2687257]))
+	}
+	b.JustificationBits = append(b.JustificationBits, buf[2687256:2687257]...)
+
+	// Field (18) 'PreviousJustifiedCheckpoint'
+	if b.PreviousJustifiedCheckpoint == nil {
+		b.PreviousJustifiedCheckpoint = new(Checkpoint)
+	}
+	if err = b.PreviousJustifiedCheckpoint.UnmarshalSSZ(buf[2687257:2687297]); err != nil {
+		return err
+	}
+
+	// Field (19) 'CurrentJustifiedCheckpoint'
+	if b.CurrentJustifiedCheckpoint == nil {
+		b.CurrentJustifiedCheckpoint = new(Checkpoint)
+	}
+	if err = b.CurrentJustifiedCheckpoint.UnmarshalSSZ(buf[2687297:2687337]); err != nil {
+		return err
+	}
+
+	// Field (20) 'FinalizedCheckpoint'
+	if b.FinalizedCheckpoint == nil {
+		b.FinalizedCheckpoint = new(Checkpoint)
+	}
+	if err = b.FinalizedCheckpoint.UnmarshalSSZ(buf[2687337:2687377]); err != nil {
+		return err
+	}
+
+	// Offset (21) 'InactivityScores'
+	if o21 = ssz.ReadOffset(buf[2687377:2687381]); o21 > size || o16 > o21 {
+		return ssz.ErrOffset
+	}
+
+	// Field (22) 'CurrentSyncCommittee'
+	if b.CurrentSyncCommittee == nil {
+		b.CurrentSyncCommittee = new(SyncCommittee)
+	}
+	if err = b.CurrentSyncCommittee.UnmarshalSSZ(buf[2687381:2712005]); err != nil {
+		return err
+	}
+
+	// Field (23) 'NextSyncCommittee'
+	if b.NextSyncCommittee == nil {
+		b.NextSyncCommittee = new(SyncCommittee)
+	}
+	if err = b.NextSyncCommittee.UnmarshalSSZ(buf[2712005:2736629]); err != nil {
+		return err
+	}
+
+	// Offset (24) 'LatestExecutionPayloadHeader'
+	if o24 = ssz.ReadOffset(buf[2736629:2736633]); o24 > size || o21 > o24 {
+		return ssz.ErrOffset
+	}
+
+	// Field (7) 'HistoricalRoots'
+	{
+		buf = tail[o7:o9]
+		num, err := ssz.DivideInt2(len(buf), 32, 16777216)
+		if err != nil {
+			return err
+		}
+		b.HistoricalRoots = make([][]byte, num)
+		for ii := 0; ii < num; ii++ {
+			if cap(b.HistoricalRoots[ii]) == 0 {
+				b.HistoricalRoots[ii] = make([]byte, 0, len(buf[ii*32:(ii+1)*32]))
+			}
+			b.HistoricalRoots[ii] = append(b.HistoricalRoots[ii], buf[ii*32:(ii+1)*32]...)
+		}
+	}
+
+	// Field (9) 'Eth1DataVotes'
+	{
+		buf = tail[o9:o11]
+		num, err := ssz.DivideInt2(len(buf), 72, 2048)
+		if err != nil {
+			return err
+		}
+		b.Eth1DataVotes = make([]*Eth1Data, num)
+		for ii := 0; ii < num; ii++ {
+			if b.Eth1DataVotes[ii] == nil {
+				b.Eth1DataVotes[ii] = new(Eth1Data)
+			}
+			if err = b.Eth1DataVotes[ii].UnmarshalSSZ(buf[ii*72 : (ii+1)*72]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (11) 'Validators'
+	{
+		buf = tail[o11:o12]
+		num, err := ssz.DivideInt2(len(buf), 121, 1099511627776)
+		if err != nil {
+			return err
+		}
+		b.Validators = make([]*Validator, num)
+		for ii := 0; ii < num; ii++ {
+			if b.Validators[ii] == nil {
+				b.Validators[ii] = new(Validator)
+			}
+			if err = b.Validators[ii].UnmarshalSSZ(buf[ii*121 : (ii+1)*121]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (12) 'Balances'
+	{
+		buf = tail[o12:o15]
+		num, err := ssz.DivideInt2(len(buf), 8, 1099511627776)
+		if err != nil {
+			return err
+		}
+		b.Balances = ssz.ExtendUint64(b.Balances, num)
+		for ii := 0; ii < num; ii++ {
+			b.Balances[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+		}
+	}
+
+	// Field (15) 'PreviousEpochParticipation'
+	{
+		buf = tail[o15:o16]
+		if len(buf) > 1099511627776 {
+			return ssz.ErrBytesLength
+		}
+		if cap(b.PreviousEpochParticipation) == 0 {
+			b.PreviousEpochParticipation = make([]byte, 0, len(buf))
+		}
+		b.PreviousEpochParticipation = append(b.PreviousEpochParticipation, buf...)
+	}
+
+	// Field (16) 'CurrentEpochParticipation'
+	{
+		buf = tail[o16:o21]
+		if len(buf) > 1099511627776 {
+			return ssz.ErrBytesLength
+		}
+		if cap(b.CurrentEpochParticipation) == 0 {
+			b.CurrentEpochParticipation = make([]byte, 0, len(buf))
+		}
+		b.CurrentEpochParticipation = append(b.CurrentEpochParticipation, buf...)
+	}
+
+	// Field (21) 'InactivityScores'
+	{
+		buf = tail[o21:o24]
+		num, err := ssz.DivideInt2(len(buf), 8, 1099511627776)
+		if err != nil {
+			return err
+		}
+		b.InactivityScores = ssz.ExtendUint64(b.InactivityScores, num)
+		for ii := 0; ii < num; ii++ {
+			b.InactivityScores[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+		}
+	}
+
+	// Field (24) 'LatestExecutionPayloadHeader'
+	{
+		buf = tail[o24:]
+		if b.LatestExecutionPayloadHeader == nil {
+			b.LatestExecutionPayloadHeader = new(v1.ExecutionPayloadHeader)
+		}
+		if err = b.LatestExecutionPayloadHeader.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the BeaconStateBellatrix object
+func (b *BeaconStateBellatrix) SizeSSZ() (size int) {
+	size = 2736633
+
+	// Field (7) 'HistoricalRoots'
+	size += len(b.HistoricalRoots) * 32
+
+	// Field (9) 'Eth1DataVotes'
+	size += len(b.Eth1DataVotes) * 72
+
+	// Field (11) 'Validators'
+	size += len(b.Validators) * 121
+
+	// Field (12) 'Balances'
+	size += len(b.Balances) * 8
+
+	// Field (15) 'PreviousEpochParticipation'
+	size += len(b.PreviousEpochParticipation)
+
+	// Field (16) 'CurrentEpochParticipation'
+	size += len(b.CurrentEpochParticipation)
+
+	// Field (21) 'InactivityScores'
+	size += len(b.InactivityScores) * 8
+
+	// Field (24) 'LatestExecutionPayloadHeader'
+	if b.LatestExecutionPayloadHeader == nil {
+		b.LatestExecutionPayloadHeader = new(v1.ExecutionPayloadHeader)
+	}
+	size += b.LatestExecutionPayloadHeader.SizeSSZ()
+
+	return
+}
+
+// HashTreeRoot ssz hashes the BeaconStateBellatrix object
+func (b *BeaconStateBellatrix) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
+}
+
+// HashTreeRootWith ssz hashes the BeaconStateBellatrix object with a hasher
+func (b *BeaconStateBellatrix) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'GenesisTime'
+	hh.PutUint64(b.GenesisTime)
+
+	// Field (1) 'GenesisValidatorsRoot'
+	if size := len(b.GenesisValidatorsRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
+		return
+	}
+	hh.PutBytes(b.GenesisValidatorsRoot)
+
+	// Field (2) 'Slot'
+	hh.PutUint64(uint64(b.Slot))
+
+	// Field (3) 'Fork'
+	if err = b.Fork.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (4) 'LatestBlockHeader'
+	if err = b.LatestBlockHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (5) 'BlockRoots'
+	{
+		if size := len(b.BlockRoots); size != 8192 {
+			err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.BlockRoots {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (6) 'StateRoots'
+	{
+		if size := len(b.StateRoots); size != 8192 {
+			err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.StateRoots {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (7) 'HistoricalRoots'
+	{
+		if size := len(b.HistoricalRoots); size > 16777216 {
+			err = ssz.ErrListTooBigFn("--.HistoricalRoots", size, 16777216)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.HistoricalRoots {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		numItems := uint64(len(b.HistoricalRoots))
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(16777216, numItems, 32))
+		} else {
+			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(16777216, numItems, 32))
+		}
+	}
+
+	// Field (8) 'Eth1Data'
+	if err = b.Eth1Data.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (9) 'Eth1DataVotes'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(b.Eth1DataVotes))
+		if num > 2048 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range b.Eth1DataVotes {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 2048)
+		} else {
+			hh.MerkleizeWithMixin(subIndx, num, 2048)
+		}
+	}
+
+	// Field (10) 'Eth1DepositIndex'
+	hh.PutUint64(b.Eth1DepositIndex)
+
+	// Field (11) 'Validators'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(b.Validators))
+		if num > 1099511627776 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range b.Validators {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 1099511627776)
+		} else {
+			hh.MerkleizeWithMixin(subIndx, num, 1099511627776)
+		}
+	}
+
+	// Field (12) 'Balances'
+	{
+		if size := len(b.Balances); size > 1099511627776 {
+			err = ssz.ErrListTooBigFn("--.Balances", size, 1099511627776)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.Balances {
+			hh.AppendUint64(i)
+		}
+		hh.FillUpTo32()
+
+		numItems := uint64(len(b.Balances))
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		} else {
+			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		}
+	}
+
+	// Field (13) 'RandaoMixes'
+	{
+		if size := len(b.RandaoMixes); size != 65536 {
+			err = ssz.ErrVectorLengthFn("--.RandaoMixes", size, 65536)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.RandaoMixes {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (14) 'Slashings'
+	{
+		if size := len(b.Slashings); size != 8192 {
+			err = ssz.ErrVectorLengthFn("--.Slashings", size, 8192)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.Slashings {
+			hh.AppendUint64(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (15) 'PreviousEpochParticipation'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(b.PreviousEpochParticipation))
+		if byteLen > 1099511627776 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(b.PreviousEpochParticipation)
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(elemIndx, byteLen, (1099511627776+31)/32)
+		} else {
+			hh.MerkleizeWithMixin(elemIndx, byteLen, (1099511627776+31)/32)
+		}
+	}
+
+	// Field (16) 'CurrentEpochParticipation'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(b.CurrentEpochParticipation))
+		if byteLen > 1099511627776 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(b.CurrentEpochParticipation)
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(elemIndx, byteLen, (1099511627776+31)/32)
+		} else {
+			hh.MerkleizeWithMixin(elemIndx, byteLen, (1099511627776+31)/32)
+		}
+	}
+
+	// Field (17) 'JustificationBits'
+	if size := len(b.JustificationBits); size != 1 {
+		err = ssz.ErrBytesLengthFn("--.JustificationBits", size, 1)
+		return
+	}
+	hh.PutBytes(b.JustificationBits)
+
+	// Field (18) 'PreviousJustifiedCheckpoint'
+	if err = b.PreviousJustifiedCheckpoint.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (19) 'CurrentJustifiedCheckpoint'
+	if err = b.CurrentJustifiedCheckpoint.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (20) 'FinalizedCheckpoint'
+	if err = b.FinalizedCheckpoint.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (21) 'InactivityScores'
+	{
+		if size := len(b.InactivityScores); size > 1099511627776 {
+			err = ssz.ErrListTooBigFn("--.InactivityScores", size, 1099511627776)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.InactivityScores {
+			hh.AppendUint64(i)
+		}
+		hh.FillUpTo32()
+
+		numItems := uint64(len(b.InactivityScores))
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		} else {
+			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		}
+	}
+
+	// Field (22) 'CurrentSyncCommittee'
+	if err = b.CurrentSyncCommittee.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (23) 'NextSyncCommittee'
+	if err = b.NextSyncCommittee.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (24) 'LatestExecutionPayloadHeader'
+	if err = b.LatestExecutionPayloadHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the BeaconStateCapella object
+func (b *BeaconStateCapella) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
+}
+
+// MarshalSSZTo ssz marshals the BeaconStateCapella object to a target array
+func (b *BeaconStateCapella) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(2736653)
+
+	// Field (0) 'GenesisTime'
+	dst = ssz.MarshalUint64(dst, b.GenesisTime)
+
+	// Field (1) 'GenesisValidatorsRoot'
+	if size := len(b.GenesisValidatorsRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
+		return
+	}
+	dst = append(dst, b.GenesisValidatorsRoot...)
+
+	// Field (2) 'Slot'
+	dst = ssz.MarshalUint64(dst, uint64(b.Slot))
+
+	// Field (3) 'Fork'
+	if b.Fork == nil {
+		b.Fork = new(Fork)
+	}
+	if dst, err = b.Fork.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (4) 'LatestBlockHeader'
+	if b.LatestBlockHeader == nil {
+		b.LatestBlockHeader = new(BeaconBlockHeader)
+	}
+	if dst, err = b.LatestBlockHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (5) 'BlockRoots'
+	if size := len(b.BlockRoots); size != 8192 {
+		err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
+		return
+	}
+	for ii := 0; ii < 8192; ii++ {
+		if size := len(b.BlockRoots[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.BlockRoots[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.BlockRoots[ii]...)
+	}
+
+	// Field (6) 'StateRoots'
+	if size := len(b.StateRoots); size != 8192 {
+		err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
+		return
+	}
+	for ii := 0; ii < 8192; ii++ {
+		if size := len(b.StateRoots[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.StateRoots[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.StateRoots[ii]...)
+	}
+
+	// Offset (7) 'HistoricalRoots'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.HistoricalRoots) * 32
+
+	// Field (8) 'Eth1Data'
+	if b.Eth1Data == nil {
+		b.Eth1Data = new(Eth1Data)
+	}
+	if dst, err = b.Eth1Data.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Offset (9) 'Eth1DataVotes'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Eth1DataVotes) * 72
+
+	// Field (10) 'Eth1DepositIndex'
+	dst = ssz.MarshalUint64(dst, b.Eth1DepositIndex)
+
+	// Offset (11) 'Validators'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Validators) * 121
+
+	// Offset (12) 'Balances'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Balances) * 8
+
+	// Field (13) 'RandaoMixes'
+	if size := len(b.RandaoMixes); size != 65536 {
+		err = ssz.ErrVectorLengthFn("--.RandaoMixes", size, 65536)
+		return
+	}
+	for ii := 0; ii < 65536; ii++ {
+		if size := len(b.RandaoMixes[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.RandaoMixes[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.RandaoMixes[ii]...)
+	}
+
+	// Field (14) 'Slashings'
+	if size := len(b.Slashings); size != 8192 {
+		err = ssz.ErrVectorLengthFn("--.Slashings", size, 8192)
+		return
+	}
+	for ii := 0; ii < 8192; ii++ {
+		dst = ssz.MarshalUint64(dst, b.Slashings[ii])
+	}
+
+	// Offset (15) 'PreviousEpochParticipation'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.PreviousEpochParticipation)
+
+	// Offset (16) 'CurrentEpochParticipation'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.CurrentEpochParticipation)
+
+	// Field (17) 'JustificationBits'
+	if size := len(b.JustificationBits); size != 1 {
+		err = ssz.ErrBytesLengthFn("--.JustificationBits", size, 1)
+		return
+	}
+	dst = append(dst, b.JustificationBits...)
+
+	// Field (18) 'PreviousJustifiedCheckpoint'
+	if b.PreviousJustifiedCheckpoint == nil {
+		b.PreviousJustifiedCheckpoint = new(Checkpoint)
+	}
+	if dst, err = b.PreviousJustifiedCheckpoint.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (19) 'CurrentJustifiedCheckpoint'
+	if b.CurrentJustifiedCheckpoint == nil {
+		b.CurrentJustifiedCheckpoint = new(Checkpoint)
+	}
+	if dst, err = b.CurrentJustifiedCheckpoint.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (20) 'FinalizedCheckpoint'
+	if b.FinalizedCheckpoint == nil {
+		b.FinalizedCheckpoint = new(Checkpoint)
+	}
+	if dst, err = b.FinalizedCheckpoint.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Offset (21) 'InactivityScores'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.InactivityScores) * 8
+
+	// Field (22) 'CurrentSyncCommittee'
+	if b.CurrentSyncCommittee == nil {
+		b.CurrentSyncCommittee = new(SyncCommittee)
+	}
+	if dst, err = b.CurrentSyncCommittee.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (23) 'NextSyncCommittee'
+	if b.NextSyncCommittee == nil {
+		b.NextSyncCommittee = new(SyncCommittee)
+	}
+	if dst, err = b.NextSyncCommittee.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Offset (24) 'LatestExecutionPayloadHeader'
+	dst = ssz.WriteOffset(dst, offset)
+	if b.LatestExecutionPayloadHeader == nil {
+		b.LatestExecutionPayloadHeader = new(v1.ExecutionPayloadHeaderCapella)
+	}
+	offset += b.LatestExecutionPayloadHeader.SizeSSZ()
+
+	// Field (25) 'NextWithdrawalIndex'
+	dst = ssz.MarshalUint64(dst, b.NextWithdrawalIndex)
+
+	// Field (26) 'NextWithdrawalValidatorIndex'
+	dst = ssz.MarshalUint64(dst, uint64(b.NextWithdrawalValidatorIndex))
+
+	// Offset (27) 'HistoricalSummaries'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.HistoricalSummaries) * 64
+
+	// Field (7) 'HistoricalRoots'
+	if size := len(b.HistoricalRoots); size > 16777216 {
+		err = ssz.ErrListTooBigFn("--.HistoricalRoots", size, 16777216)
+		return
+	}
+	for ii := 0; ii < len(b.HistoricalRoots); ii++ {
+		if size := len(b.HistoricalRoots[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.HistoricalRoots[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.HistoricalRoots[ii]...)
+	}
+
+	// Field (9) 'Eth1DataVotes'
+	if size := len(b.Eth1DataVotes); size > 2048 {
+		err = ssz.ErrListTooBigFn("--.Eth1DataVotes", size, 2048)
+		return
+	}
+	for ii := 0; ii < len(b.Eth1DataVotes); ii++ {
+		if dst, err = b.Eth1DataVotes[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (11) 'Validators'
+	if size := len(b.Validators); size > 1099511627776 {
+		err = ssz.ErrListTooBigFn("--.Validators", size, 1099511627776)
+		return
+	}
+	for ii := 0; ii < len(b.Validators); ii++ {
+		if dst, err = b.Validators[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (12) 'Balances'
+	if size := len(b.Balances); size > 1099511627776 {
+		err = ssz.ErrListTooBigFn("--.Balances", size, 1099511627776)
+		return
+	}
+	for ii := 0; ii < len(b.Balances); ii++ {
+		dst = ssz.MarshalUint64(dst, b.Balances[ii])
+	}
+
+	// Field (15) 'PreviousEpochParticipation'
+	if size := len(b.PreviousEpochParticipation); size > 1099511627776 {
+		err = ssz.ErrBytesLengthFn("--.PreviousEpochParticipation", size, 1099511627776)
+		return
+	}
+	dst = append(dst, b.PreviousEpochParticipation...)
+
+	// Field (16) 'CurrentEpochParticipation'
+	if size := len(b.CurrentEpochParticipation); size > 1099511627776 {
+		err = ssz.ErrBytesLengthFn("--.CurrentEpochParticipation", size, 1099511627776)
+		return
+	}
+	dst = append(dst, b.CurrentEpochParticipation...)
+
+	// Field (21) 'InactivityScores'
+	if size := len(b.InactivityScores); size > 1099511627776 {
+		err = ssz.ErrListTooBigFn("--.InactivityScores", size, 1099511627776)
+		return
+	}
+	for ii := 0; ii < len(b.InactivityScores); ii++ {
+		dst = ssz.MarshalUint64(dst, b.InactivityScores[ii])
+	}
+
+	// Field (24) 'LatestExecutionPayloadHeader'
+	if dst, err = b.LatestExecutionPayloadHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (27) 'HistoricalSummaries'
+	if size := len(b.HistoricalSummaries); size > 16777216 {
+		err = ssz.ErrListTooBigFn("--.HistoricalSummaries", size, 16777216)
+		return
+	}
+	for ii := 0; ii < len(b.HistoricalSummaries); ii++ {
+		if dst, err = b.HistoricalSummaries[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the BeaconStateCapella object
+func (b *BeaconStateCapella) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 2736653 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o7, o9, o11, o12, o15, o16, o21, o24, o27 uint64
+
+	// Field (0) 'GenesisTime'
+	b.GenesisTime = ssz.UnmarshallUint64(buf[0:8])
+
+	// Field (1) 'GenesisValidatorsRoot'
+	if cap(b.GenesisValidatorsRoot) == 0 {
+		b.GenesisValidatorsRoot = make([]byte, 0, len(buf[8:40]))
+	}
+	b.GenesisValidatorsRoot = append(b.GenesisValidatorsRoot, buf[8:40]...)
+
+	// Field (2) 'Slot'
+	b.Slot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[40:48]))
+
+	// Field (3) 'Fork'
+	if b.Fork == nil {
+		b.Fork = new(Fork)
+	}
+	if err = b.Fork.UnmarshalSSZ(buf[48:64]); err != nil {
+		return err
+	}
+
+	// Field (4) 'LatestBlockHeader'
+	if b.LatestBlockHeader == nil {
+		b.LatestBlockHeader = new(BeaconBlockHeader)
+	}
+	if err = b.LatestBlockHeader.UnmarshalSSZ(buf[64:176]); err != nil {
+		return err
+	}
+
+	// Field (5) 'BlockRoots'
+	b.BlockRoots = make([][]byte, 8192)
+	for ii := 0; ii < 8192; ii++ {
+		if cap(b.BlockRoots[ii]) == 0 {
+			b.BlockRoots[ii] = make([]byte, 0, len(buf[176:262320][ii*32:(ii+1)*32]))
+		}
+		b.BlockRoots[ii] = append(b.BlockRoots[ii], buf[176:262320][ii*32:(ii+1)*32]...)
+	}
+
+	// Field (6) 'StateRoots'
+	b.StateRoots = make([][]byte, 8192)
+	for ii := 0; ii < 8192; ii++ {
+		if cap(b.StateRoots[ii]) == 0 {
+			b.StateRoots[ii] = make([]byte, 0, len(buf[262320:524464][ii*32:(ii+1)*32]))
+		}
+		b.StateRoots[ii] = append(b.StateRoots[ii], buf[262320:524464][ii*32:(ii+1)*32]...)
+	}
+
+	// Offset (7) 'HistoricalRoots'
+	if o7 = ssz.ReadOffset(buf[524464:524468]); o7 > size {
+		return ssz.ErrOffset
+	}
+
+	if o7 < 2736653 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (8) 'Eth1Data'
+	if b.Eth1Data == nil {
+		b.Eth1Data = new(Eth1Data)
+	}
+	if err = b.Eth1Data.UnmarshalSSZ(buf[524468:524540]); err != nil {
+		return err
+	}
+
+	// Offset (9) 'Eth1DataVotes'
+	if o9 = ssz.ReadOffset(buf[524540:524544]); o9 > size || o7 > o9 {
+		return ssz.ErrOffset
+	}
+
+	// Field (10) 'Eth1DepositIndex'
+	b.Eth1DepositIndex = ssz.UnmarshallUint64(buf[524544:524552])
+
+	// Offset (11) 'Validators'
+	if o11 = ssz.ReadOffset(buf[524552:524556]); o11 > size || o9 > o11 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (12) 'Balances'
+	if o12 = ssz.ReadOffset(buf[524556:524560]); o12 > size || o11 > o12 {
+		return ssz.ErrOffset
+	}
+
+	// Field (13) 'RandaoMixes'
+	b.RandaoMixes = make([][]byte, 65536)
+	for ii := 0; ii < 65536; ii++ {
+		if cap(b.RandaoMixes[ii]) == 0 {
+			b.RandaoMixes[ii] = make([]byte, 0, len(buf[524560:2621712][ii*32:(ii+1)*32]))
+		}
+		b.RandaoMixes[ii] = append(b.RandaoMixes[ii], buf[524560:2621712][ii*32:(ii+1)*32]...)
+	}
+
+	// Field (14) 'Slashings'
+	b.Slashings = ssz.ExtendUint64(b.Slashings, 8192)
+	for ii := 0; ii < 8192; ii++ {
+		b.Slashings[ii] = ssz.UnmarshallUint64(buf[2621712:2687248][ii*8 : (ii+1)*8])
+	}
+
+	// Offset (15) 'PreviousEpochParticipation'
+	if o15 = ssz.ReadOffset(buf[2687248:2687252]); o15 > size || o12 > o15 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (16) 'CurrentEpochParticipation'
+	if o16 = ssz.ReadOffset(buf[2687252:2687256]); o16 > size || o15 > o16 {
+		return ssz.ErrOffset
+	}
+
+	// Field (17) 'JustificationBits'
+	if cap(b.JustificationBits) == 0 {
+		b.JustificationBits = make([]byte, 0, len(buf[2687256:2687257]))
+	}
+	b.JustificationBits = append(b.JustificationBits, buf[2687256:2687257]...)
+
+	// Field (18) 'PreviousJustifiedCheckpoint'
+	if b.PreviousJustifiedCheckpoint == nil {
+		b.PreviousJustifiedCheckpoint = new(Checkpoint)
+	}
+	if err = b.PreviousJustifiedCheckpoint.UnmarshalSSZ(buf[2687257:2687297]); err != nil {
+		return err
+	}
+
+	// Field (19) 'CurrentJustifiedCheckpoint'
+	if b.CurrentJustifiedCheckpoint == nil {
+		b.CurrentJustifiedCheckpoint = new(Checkpoint)
+	}
+	if err = b.CurrentJustifiedCheckpoint.UnmarshalSSZ(buf[2687297:2687337]); err != nil {
+		return err
+	}
+
+	// Field (20) 'FinalizedCheckpoint'
+	if b.FinalizedCheckpoint == nil {
+		b.FinalizedCheckpoint = new(Checkpoint)
+	}
+	if err = b.FinalizedCheckpoint.UnmarshalSSZ(buf[2687337:2687377]); err != nil {
+		return err
+	}
+
+	// Offset (21) 'InactivityScores'
+	if o21 = ssz.ReadOffset(buf[2687377:2687381]); o21 > size || o16 > o21 {
+		return ssz.ErrOffset
+	}
+
+	// Field (22) 'CurrentSyncCommittee'
+	if b.CurrentSyncCommittee == nil {
+		b.CurrentSyncCommittee = new(SyncCommittee)
+	}
+	if err = b.CurrentSyncCommittee.UnmarshalSSZ(buf[2687381:2712005]); err != nil {
+		return err
+	}
+
+	// Field (23) 'NextSyncCommittee'
+	if b.NextSyncCommittee == nil {
+		b.NextSyncCommittee = new(SyncCommittee)
+	}
+	if err = b.NextSyncCommittee.UnmarshalSSZ(buf[2712005:2736629]); err != nil {
+		return err
+	}
+
+	// Offset (24) 'LatestExecutionPayloadHeader'
+	if o24 = ssz.ReadOffset(buf[2736629:2736633]); o24 > size || o21 > o24 {
+		return ssz.ErrOffset
+	}
+
+	// Field (25) 'NextWithdrawalIndex'
+	b.NextWithdrawalIndex = ssz.UnmarshallUint64(buf[2736633:2736641])
+
+	// Field (26) 'NextWithdrawalValidatorIndex'
+	b.NextWithdrawalValidatorIndex = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.ValidatorIndex(ssz.UnmarshallUint64(buf[2736641:2736649]))
+
+	// Offset (27) 'HistoricalSummaries'
+	if o27 = ssz.ReadOffset(buf[2736649:2736653]); o27 > size || o24 > o27 {
+		return ssz.ErrOffset
+	}
+
+	// Field (7) 'HistoricalRoots'
+	{
+		buf = tail[o7:o9]
+		num, err := ssz.DivideInt2(len(buf), 32, 16777216)
+		if err != nil {
+			return err
+		}
+		b.HistoricalRoots = make([][]byte, num)
+		for ii := 0; ii < num; ii++ {
+			if cap(b.HistoricalRoots[ii]) == 0 {
+				b.HistoricalRoots[ii] = make([]byte, 0, len(buf[ii*32:(ii+1)*32]))
+			}
+			b.HistoricalRoots[ii] = append(b.HistoricalRoots[ii], buf[ii*32:(ii+1)*32]...)
+		}
+	}
+
+	// Field (9) 'Eth1DataVotes'
+	{
+		buf = tail[o9:o11]
+		num, err := ssz.DivideInt2(len(buf), 72, 2048)
+		if err != nil {
+			return err
+		}
+		b.Eth1DataVotes = make([]*Eth1Data, num)
+		for ii := 0; ii < num; ii++ {
+			if b.Eth1DataVotes[ii] == nil {
+				b.Eth1DataVotes[ii] = new(Eth1Data)
+			}
+			if err = b.Eth1DataVotes[ii].UnmarshalSSZ(buf[ii*72 : (ii+1)*72]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (11) 'Validators'
+	{
+		buf = tail[o11:o12]
+		num, err := ssz.DivideInt2(len(buf), 121, 1099511627776)
+		if err != nil {
+			return err
+		}
+		b.Validators = make([]*Validator, num)
+		for ii := 0; ii < num; ii++ {
+			if b.Validators[ii] == nil {
+				b.Validators[ii] = new(Validator)
+			}
+			if err = b.Validators[ii].UnmarshalSSZ(buf[ii*121 : (ii+1)*121]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (12) 'Balances'
+	{
+		buf = tail[o12:o15]
+		num, err := ssz.DivideInt2(len(buf), 8, 1099511627776)
+		if err != nil {
+			return err
+		}
+		b.Balances = ssz.ExtendUint64(b.Balances, num)
+		for ii := 0; ii < num; ii++ {
+			b.Balances[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+		}
+	}
+
+	// Field (15) 'PreviousEpochParticipation'
+	{
+		buf = tail[o15:o16]
+		if len(buf) > 1099511627776 {
+			return ssz.ErrBytesLength
+		}
+		if cap(b.PreviousEpochParticipation) == 0 {
+			b.PreviousEpochParticipation = make([]byte, 0, len(buf))
+		}
+		b.PreviousEpochParticipation = append(b.PreviousEpochParticipation, buf...)
+	}
+
+	// Field (16) 'CurrentEpochParticipation'
+	{
+		buf = tail[o16:o21]
+		if len(buf) > 1099511627776 {
+			return ssz.ErrBytesLength
+		}
+		if cap(b.CurrentEpochParticipation) == 0 {
+			b.CurrentEpochParticipation = make([]byte, 0, len(buf))
+		}
+		b.CurrentEpochParticipation = append(b.CurrentEpochParticipation, buf...)
+	}
+
+	// Field (21) 'InactivityScores'
+	{
+		buf = tail[o21:o24]
+		num, err := ssz.DivideInt2(len(buf), 8, 1099511627776)
+		if err != nil {
+			return err
+		}
+		b.InactivityScores = ssz.ExtendUint64(b.InactivityScores, num)
+		for ii := 0; ii < num; ii++ {
+			b.InactivityScores[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+		}
+	}
+
+	// Field (24) 'LatestExecutionPayloadHeader'
+	{
+		buf = tail[o24:o27]
+		if b.LatestExecutionPayloadHeader == nil {
+			b.LatestExecutionPayloadHeader = new(v1.ExecutionPayloadHeaderCapella)
+		}
+		if err = b.LatestExecutionPayloadHeader.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+
+	// Field (27) 'HistoricalSummaries'
+	{
+		buf = tail[o27:]
+		num, err := ssz.DivideInt2(len(buf), 64, 16777216)
+		if err != nil {
+			return err
+		}
+		b.HistoricalSummaries = make([]*HistoricalSummary, num)
+		for ii := 0; ii < num; ii++ {
+			if b.HistoricalSummaries[ii] == nil {
+				b.HistoricalSummaries[ii] = new(HistoricalSummary)
+			}
+			if err = b.HistoricalSummaries[ii].UnmarshalSSZ(buf[ii*64 : (ii+1)*64]); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the BeaconStateCapella object
+func (b *BeaconStateCapella) SizeSSZ() (size int) {
+	size = 2736653
+
+	// Field (7) 'HistoricalRoots'
+	size += len(b.HistoricalRoots) * 32
+
+	// Field (9) 'Eth1DataVotes'
+	size += len(b.Eth1DataVotes) * 72
+
+	// Field (11) 'Validators'
+	size += len(b.Validators) * 121
+
+	// Field (12) 'Balances'
+	size += len(b.Balances) * 8
+
+	// Field (15) 'PreviousEpochParticipation'
+	size += len(b.PreviousEpochParticipation)
+
+	// Field (16) 'CurrentEpochParticipation'
+	size += len(b.CurrentEpochParticipation)
+
+	// Field (21) 'InactivityScores'
+	size += len(b.InactivityScores) * 8
+
+	// Field (24) 'LatestExecutionPayloadHeader'
+	if b.LatestExecutionPayloadHeader == nil {
+		b.LatestExecutionPayloadHeader = new(v1.ExecutionPayloadHeaderCapella)
+	}
+	size += b.LatestExecutionPayloadHeader.SizeSSZ()
+
+	// Field (27) 'HistoricalSummaries'
+	size += len(b.HistoricalSummaries) * 64
+
+	return
+}
+
+// HashTreeRoot ssz hashes the BeaconStateCapella object
+func (b *BeaconStateCapella) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
+}
+
+// HashTreeRootWith ssz hashes the BeaconStateCapella object with a hasher
+func (b *BeaconStateCapella) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'GenesisTime'
+	hh.PutUint64(b.GenesisTime)
+
+	// Field (1) 'GenesisValidatorsRoot'
+	if size := len(b.GenesisValidatorsRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
+		return
+	}
+	hh.PutBytes(b.GenesisValidatorsRoot)
+
+	// Field (2) 'Slot'
+	hh.PutUint64(uint64(b.Slot))
+
+	// Field (3) 'Fork'
+	if err = b.Fork.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (4) 'LatestBlockHeader'
+	if err = b.LatestBlockHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (5) 'BlockRoots'
+	{
+		if size := len(b.BlockRoots); size != 8192 {
+			err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.BlockRoots {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (6) 'StateRoots'
+	{
+		if size := len(b.StateRoots); size != 8192 {
+			err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.StateRoots {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (7) 'HistoricalRoots'
+	{
+		if size := len(b.HistoricalRoots); size > 16777216 {
+			err = ssz.ErrListTooBigFn("--.HistoricalRoots", size, 16777216)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.HistoricalRoots {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		numItems := uint64(len(b.HistoricalRoots))
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(16777216, numItems, 32))
+		} else {
+			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(16777216, numItems, 32))
+		}
+	}
+
+	// Field (8) 'Eth1Data'
+	if err = b.Eth1Data.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (9) 'Eth1DataVotes'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(b.Eth1DataVotes))
+		if num > 2048 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range b.Eth1DataVotes {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 2048)
+		} else {
+			hh.MerkleizeWithMixin(subIndx, num, 2048)
+		}
+	}
+
+	// Field (10) 'Eth1DepositIndex'
+	hh.PutUint64(b.Eth1DepositIndex)
+
+	// Field (11) 'Validators'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(b.Validators))
+		if num > 1099511627776 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range b.Validators {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 1099511627776)
+		} else {
+			hh.MerkleizeWithMixin(subIndx, num, 1099511627776)
+		}
+	}
+
+	// Field (12) 'Balances'
+	{
+		if size := len(b.Balances); size > 1099511627776 {
+			err = ssz.ErrListTooBigFn("--.Balances", size, 1099511627776)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.Balances {
+			hh.AppendUint64(i)
+		}
+		hh.FillUpTo32()
+
+		numItems := uint64(len(b.Balances))
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		} else {
+			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		}
+	}
+
+	// Field (13) 'RandaoMixes'
+	{
+		if size := len(b.RandaoMixes); size != 65536 {
+			err = ssz.ErrVectorLengthFn("--.RandaoMixes", size, 65536)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.RandaoMixes {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (14) 'Slashings'
+	{
+		if size := len(b.Slashings); size != 8192 {
+			err = ssz.ErrVectorLengthFn("--.Slashings", size, 8192)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.Slashings {
+			hh.AppendUint64(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (15) 'PreviousEpochParticipation'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(b.PreviousEpochParticipation))
+		if byteLen > 1099511627776 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(b.PreviousEpochParticipation)
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(elemIndx, byteLen, (1099511627776+31)/32)
+		} else {
+			hh.MerkleizeWithMixin(elemIndx, byteLen, (1099511627776+31)/32)
+		}
+	}
+
+	// Field (16) 'CurrentEpochParticipation'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(b.CurrentEpochParticipation))
+		if byteLen > 1099511627776 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(b.CurrentEpochParticipation)
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(elemIndx, byteLen, (1099511627776+31)/32)
+		} else {
+			hh.MerkleizeWithMixin(elemIndx, byteLen, (1099511627776+31)/32)
+		}
+	}
+
+	// Field (17) 'JustificationBits'
+	if size := len(b.JustificationBits); size != 1 {
+		err = ssz.ErrBytesLengthFn("--.JustificationBits", size, 1)
+		return
+	}
+	hh.PutBytes(b.JustificationBits)
+
+	// Field (18) 'PreviousJustifiedCheckpoint'
+	if err = b.PreviousJustifiedCheckpoint.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (19) 'CurrentJustifiedCheckpoint'
+	if err = b.CurrentJustifiedCheckpoint.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (20) 'FinalizedCheckpoint'
+	if err = b.FinalizedCheckpoint.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (21) 'InactivityScores'
+	{
+		if size := len(b.InactivityScores); size > 1099511627776 {
+			err = ssz.ErrListTooBigFn("--.InactivityScores", size, 1099511627776)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.InactivityScores {
+			hh.AppendUint64(i)
+		}
+		hh.FillUpTo32()
+
+		numItems := uint64(len(b.InactivityScores))
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		} else {
+			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		}
+	}
+
+	// Field (22) 'CurrentSyncCommittee'
+	if err = b.CurrentSyncCommittee.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (23) 'NextSyncCommittee'
+	if err = b.NextSyncCommittee.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (24) 'LatestExecutionPayloadHeader'
+	if err = b.LatestExecutionPayloadHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (25) 'NextWithdrawalIndex'
+	hh.PutUint64(b.NextWithdrawalIndex)
+
+	// Field (26) 'NextWithdrawalValidatorIndex'
+	hh.PutUint64(uint64(b.NextWithdrawalValidatorIndex))
+
+	// Field (27) 'HistoricalSummaries'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(b.HistoricalSummaries))
+		if num > 16777216 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range b.HistoricalSummaries {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 16777216)
+		} else {
+			hh.MerkleizeWithMixin(subIndx, num, 16777216)
+		}
+	}
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the BeaconStateDeneb object
+func (b *BeaconStateDeneb) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
+}
+
+// MarshalSSZTo ssz marshals the BeaconStateDeneb object to a target array
+func (b *BeaconStateDeneb) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(2736653)
+
+	// Field (0) 'GenesisTime'
+	dst = ssz.MarshalUint64(dst, b.GenesisTime)
+
+	// Field (1) 'GenesisValidatorsRoot'
+	if size := len(b.GenesisValidatorsRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
+		return
+	}
+	dst = append(dst, b.GenesisValidatorsRoot...)
+
+	// Field (2) 'Slot'
+	dst = ssz.MarshalUint64(dst, uint64(b.Slot))
+
+	// Field (3) 'Fork'
+	if b.Fork == nil {
+		b.Fork = new(Fork)
+	}
+	if dst, err = b.Fork.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (4) 'LatestBlockHeader'
+	if b.LatestBlockHeader == nil {
+		b.LatestBlockHeader = new(BeaconBlockHeader)
+	}
+	if dst, err = b.LatestBlockHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (5) 'BlockRoots'
+	if size := len(b.BlockRoots); size != 8192 {
+		err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
+		return
+	}
+	for ii := 0; ii < 8192; ii++ {
+		if size := len(b.BlockRoots[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.BlockRoots[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.BlockRoots[ii]...)
+	}
+
+	// Field (6) 'StateRoots'
+	if size := len(b.StateRoots); size != 8192 {
+		err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
+		return
+	}
+	for ii := 0; ii < 8192; ii++ {
+		if size := len(b.StateRoots[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.StateRoots[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.StateRoots[ii]...)
+	}
+
+	// Offset (7) 'HistoricalRoots'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.HistoricalRoots) * 32
+
+	// Field (8) 'Eth1Data'
+	if b.Eth1Data == nil {
+		b.Eth1Data = new(Eth1Data)
+	}
+	if dst, err = b.Eth1Data.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Offset (9) 'Eth1DataVotes'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Eth1DataVotes) * 72
+
+	// Field (10) 'Eth1DepositIndex'
+	dst = ssz.MarshalUint64(dst, b.Eth1DepositIndex)
+
+	// Offset (11) 'Validators'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Validators) * 121
+
+	// Offset (12) 'Balances'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Balances) * 8
+
+	// Field (13) 'RandaoMixes'
+	if size := len(b.RandaoMixes); size != 65536 {
+		err = ssz.ErrVectorLengthFn("--.RandaoMixes", size, 65536)
+		return
+	}
+	for ii := 0; ii < 65536; ii++ {
+		if size := len(b.RandaoMixes[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.RandaoMixes[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.RandaoMixes[ii]...)
+	}
+
+	// Field (14) 'Slashings'
+	if size := len(b.Slashings); size != 8192 {
+		err = ssz.ErrVectorLengthFn("--.Slashings", size, 8192)
+		return
+	}
+	for ii := 0; ii < 8192; ii++ {
+		dst = ssz.MarshalUint64(dst, b.Slashings[ii])
+	}
+
+	// Offset (15) 'PreviousEpochParticipation'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.PreviousEpochParticipation)
+
+	// Offset (16) 'CurrentEpochParticipation'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.CurrentEpochParticipation)
+
+	// Field (17) 'JustificationBits'
+	if size := len(b.JustificationBits); size != 1 {
+		err = ssz.ErrBytesLengthFn("--.JustificationBits", size, 1)
+		return
+	}
+	dst = append(dst, b.JustificationBits...)
+
+	// Field (18) 'PreviousJustifiedCheckpoint'
+	if b.PreviousJustifiedCheckpoint == nil {
+		b.PreviousJustifiedCheckpoint = new(Checkpoint)
+	}
+	if dst, err = b.PreviousJustifiedCheckpoint.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (19) 'CurrentJustifiedCheckpoint'
+	if b.CurrentJustifiedCheckpoint == nil {
+		b.CurrentJustifiedCheckpoint = new(Checkpoint)
+	}
+	if dst, err = b.CurrentJustifiedCheckpoint.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (20) 'FinalizedCheckpoint'
+	if b.FinalizedCheckpoint == nil {
+		b.FinalizedCheckpoint = new(Checkpoint)
+	}
+	if dst, err = b.FinalizedCheckpoint.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Offset (21) 'InactivityScores'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.InactivityScores) * 8
+
+	// Field (22) 'CurrentSyncCommittee'
+	if b.CurrentSyncCommittee == nil {
+		b.CurrentSyncCommittee = new(SyncCommittee)
+	}
+	if dst, err = b.CurrentSyncCommittee.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (23) 'NextSyncCommittee'
+	if b.NextSyncCommittee == nil {
+		b.NextSyncCommittee = new(SyncCommittee)
+	}
+	if dst, err = b.NextSyncCommittee.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Offset (24) 'LatestExecutionPayloadHeader'
+	dst = ssz.WriteOffset(dst, offset)
+	if b.LatestExecutionPayloadHeader == nil {
+		b.LatestExecutionPayloadHeader = new(v1.ExecutionPayloadHeaderDeneb)
+	}
+	offset += b.LatestExecutionPayloadHeader.SizeSSZ()
+
+	// Field (25) 'NextWithdrawalIndex'
+	dst = ssz.MarshalUint64(dst, b.NextWithdrawalIndex)
+
+	// Field (26) 'NextWithdrawalValidatorIndex'
+	dst = ssz.MarshalUint64(dst, uint64(b.NextWithdrawalValidatorIndex))
+
+	// Offset (27) 'HistoricalSummaries'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.HistoricalSummaries) * 64
+
+	// Field (7) 'HistoricalRoots'
+	if size := len(b.HistoricalRoots); size > 16777216 {
+		err = ssz.ErrListTooBigFn("--.HistoricalRoots", size, 16777216)
+		return
+	}
+	for ii := 0; ii < len(b.HistoricalRoots); ii++ {
+		if size := len(b.HistoricalRoots[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.HistoricalRoots[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.HistoricalRoots[ii]...)
+	}
+
+	// Field (9) 'Eth1DataVotes'
+	if size := len(b.Eth1DataVotes); size > 2048 {
+		err = ssz.ErrListTooBigFn("--.Eth1DataVotes", size, 2048)
+		return
+	}
+	for ii := 0; ii < len(b.Eth1DataVotes); ii++ {
+		if dst, err = b.Eth1DataVotes[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (11) 'Validators'
+	if size := len(b.Validators); size > 1099511627776 {
+		err = ssz.ErrListTooBigFn("--.Validators", size, 1099511627776)
+		return
+	}
+	for ii := 0; ii < len(b.Validators); ii++ {
+		if dst, err = b.Validators[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (12) 'Balances'
+	if size := len(b.Balances); size > 1099511627776 {
+		err = ssz.ErrListTooBigFn("--.Balances", size, 1099511627776)
+		return
+	}
+	for ii := 0; ii < len(b.Balances); ii++ {
+		dst = ssz.MarshalUint64(dst, b.Balances[ii])
+	}
+
+	// Field (15) 'PreviousEpochParticipation'
+	if size := len(b.PreviousEpochParticipation); size > 1099511627776 {
+		err = ssz.ErrBytesLengthFn("--.PreviousEpochParticipation", size, 1099511627776)
+		return
+	}
+	dst = append(dst, b.PreviousEpochParticipation...)
+
+	// Field (16) 'CurrentEpochParticipation'
+	if size := len(b.CurrentEpochParticipation); size > 1099511627776 {
+		err = ssz.ErrBytesLengthFn("--.CurrentEpochParticipation", size, 1099511627776)
+		return
+	}
+	dst = append(dst, b.CurrentEpochParticipation...)
+
+	// Field (21) 'InactivityScores'
+	if size := len(b.InactivityScores); size > 1099511627776 {
+		err = ssz.ErrListTooBigFn("--.InactivityScores", size, 1099511627776)
+		return
+	}
+	for ii := 0; ii < len(b.InactivityScores); ii++ {
+		dst = ssz.MarshalUint64(dst, b.InactivityScores[ii])
+	}
+
+	// Field (24) 'LatestExecutionPayloadHeader'
+	if dst, err = b.LatestExecutionPayloadHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (27) 'HistoricalSummaries'
+	if size := len(b.HistoricalSummaries); size > 16777216 {
+		err = ssz.ErrListTooBigFn("--.HistoricalSummaries", size, 16777216)
+		return
+	}
+	for ii := 0; ii < len(b.HistoricalSummaries); ii++ {
+		if dst, err = b.HistoricalSummaries[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the BeaconStateDeneb object
+func (b *BeaconStateDeneb) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 2736653 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o7, o9, o11, o12, o15, o16, o21, o24, o27 uint64
+
+	// Field (0) 'GenesisTime'
+	b.GenesisTime = ssz.UnmarshallUint64(buf[0:8])
+
+	// Field (1) 'GenesisValidatorsRoot'
+	if cap(b.GenesisValidatorsRoot) == 0 {
+		b.GenesisValidatorsRoot = make([]byte, 0, len(buf[8:40]))
+	}
+	b.GenesisValidatorsRoot = append(b.GenesisValidatorsRoot, buf[8:40]...)
+
+	// Field (2) 'Slot'
+	b.Slot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[40:48]))
+
+	// Field (3) 'Fork'
+	if b.Fork == nil {
+		b.Fork = new(Fork)
+	}
+	if err = b.Fork.UnmarshalSSZ(buf[48:64]); err != nil {
+		return err
+	}
+
+	// Field (4) 'LatestBlockHeader'
+	if b.LatestBlockHeader == nil {
+		b.LatestBlockHeader = new(BeaconBlockHeader)
+	}
+	if err = b.LatestBlockHeader.UnmarshalSSZ(buf[64:176]); err != nil {
+		return err
+	}
+
+	// Field (5) 'BlockRoots'
+	b.BlockRoots = make([][]byte, 8192)
+	for ii := 0; ii < 8192; ii++ {
+		if cap(b.BlockRoots[ii]) == 0 {
+			b.BlockRoots[ii] = make([]byte, 0, len(buf[176:262320][ii*32:(ii+1)*32]))
+		}
+		b.BlockRoots[ii] = append(b.BlockRoots[ii], buf[176:262320][ii*32:(ii+1)*32]...)
+	}
+
+	// Field (6) 'StateRoots'
+	b.StateRoots = make([][]byte, 8192)
+	for ii := 0; ii < 8192; ii++ {
+		if cap(b.StateRoots[ii]) == 0 {
+			b.StateRoots[ii] = make([]byte, 0, len(buf[262320:524464][ii*32:(ii+1)*32]))
+		}
+		b.StateRoots[ii] = append(b.StateRoots[ii], buf[262320:524464][ii*32:(ii+1)*32]...)
+	}
+
+	// Offset (7) 'HistoricalRoots'
+	if o7 = ssz.ReadOffset(buf[524464:524468]); o7 > size {
+		return ssz.ErrOffset
+	}
+
+	if o7 < 2736653 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (8) 'Eth1Data'
+	if b.Eth1Data == nil {
+		b.Eth1Data = new(Eth1Data)
+	}
+	if err = b.Eth1Data.UnmarshalSSZ(buf[524468:524540]); err != nil {
+		return err
+	}
+
+	// Offset (9) 'Eth1DataVotes'
+	if o9 = ssz.ReadOffset(buf[524540:524544]); o9 > size || o7 > o9 {
+		return ssz.ErrOffset
+	}
+
+	// Field (10) 'Eth1DepositIndex'
+	b.Eth1DepositIndex = ssz.UnmarshallUint64(buf[524544:524552])
+
+	// Offset (11) 'Validators'
+	if o11 = ssz.ReadOffset(buf[524552:524556]); o11 > size || o9 > o11 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (12) 'Balances'
+	if o12 = ssz.ReadOffset(buf[524556:524560]); o12 > size || o11 > o12 {
+		return ssz.ErrOffset
+	}
+
+	// Field (13) 'RandaoMixes'
+	b.RandaoMixes = make([][]byte, 65536)
+	for ii := 0; ii < 65536; ii++ {
+		if cap(b.RandaoMixes[ii]) == 0 {
+			b.RandaoMixes[ii] = make([]byte, 0, len(buf[524560:2621712][ii*32:(ii+1)*32]))
+		}
+		b.RandaoMixes[ii] = append(b.RandaoMixes[ii], buf[524560:2621712][ii*32:(ii+1)*32]...)
+	}
+
+	// Field (14) 'Slashings'
+	b.Slashings = ssz.ExtendUint64(b.Slashings, 8192)
+	for ii := 0; ii < 8192; ii++ {
+		b.Slashings[ii] = ssz.UnmarshallUint64(buf[2621712:2687248][ii*8 : (ii+1)*8])
+	}
+
+	// Offset (15) 'PreviousEpochParticipation'
+	if o15 = ssz.ReadOffset(buf[2687248:2687252]); o15 > size || o12 > o15 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (16) 'CurrentEpochParticipation'
+	if o16 = ssz.ReadOffset(buf[2687252:2687256]); o16 > size || o15 > o16 {
+		return ssz.ErrOffset
+	}
+
+	// Field (17) 'JustificationBits'
+	if cap(b.JustificationBits) == 0 {
+		b.JustificationBits = make([]byte, 0, len(buf[2687256:2687257]))
+	}
+	b.JustificationBits = append(b.JustificationBits, buf[2687256:2687257]...)
+
+	// Field (18) 'PreviousJustifiedCheckpoint'
+	if b.PreviousJustifiedCheckpoint == nil {
+		b.PreviousJustifiedCheckpoint = new(Checkpoint)
+	}
+	if err = b.PreviousJustifiedCheckpoint.UnmarshalSSZ(buf[2687257:2687297]); err != nil {
+		return err
+	}
+
+	// Field (19) 'CurrentJustifiedCheckpoint'
+	if b.CurrentJustifiedCheckpoint == nil {
+		b.CurrentJustifiedCheckpoint = new(Checkpoint)
+	}
+	if err = b.CurrentJustifiedCheckpoint.UnmarshalSSZ(buf[2687297:2687337]); err != nil {
+		return err
+	}
+
+	// Field (20) 'FinalizedCheckpoint'
+	if b.FinalizedCheckpoint == nil {
+		b.FinalizedCheckpoint = new(Checkpoint)
+	}
+	if err = b.FinalizedCheckpoint.UnmarshalSSZ(buf[2687337:2687377]); err != nil {
+		return err
+	}
+
+	// Offset (21) 'InactivityScores'
+	if o21 = ssz.ReadOffset(buf[2687377:2687381]); o21 > size || o16 > o21 {
+		return ssz.ErrOffset
+	}
+
+	// Field (22) 'CurrentSyncCommittee'
+	if b.CurrentSyncCommittee == nil {
+		b.CurrentSyncCommittee = new(SyncCommittee)
+	}
+	if err = b.CurrentSyncCommittee.UnmarshalSSZ(buf[2687381:2712005]); err != nil {
+		return err
+	}
+
+	// Field (23) 'NextSyncCommittee'
+	if b.NextSyncCommittee == nil {
+		b.NextSyncCommittee = new(SyncCommittee)
+	}
+	if err = b.NextSyncCommittee.UnmarshalSSZ(buf[2712005:2736629]); err != nil {
+		return err
+	}
+
+	// Offset (24) 'LatestExecutionPayloadHeader'
+	if o24 = ssz.ReadOffset(buf[2736629:2736633]); o24 > size || o21 > o24 {
+		return ssz.ErrOffset
+	}
+
+	// Field (25) 'NextWithdrawalIndex'
+	b.NextWithdrawalIndex = ssz.UnmarshallUint64(buf[2736633:2736641])
+
+	// Field (26) 'NextWithdrawalValidatorIndex'
+	b.NextWithdrawalValidatorIndex = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.ValidatorIndex(ssz.UnmarshallUint64(buf[2736641:2736649]))
+
+	// Offset (27) 'HistoricalSummaries'
+	if o27 = ssz.ReadOffset(buf[2736649:2736653]); o27 > size || o24 > o27 {
+		return ssz.ErrOffset
+	}
+
+	// Field (7) 'HistoricalRoots'
+	{
+		buf = tail[o7:o9]
+		num, err := ssz.DivideInt2(len(buf), 32, 16777216)
+		if err != nil {
+			return err
+		}
+		b.HistoricalRoots = make([][]byte, num)
+		for ii := 0; ii < num; ii++ {
+			if cap(b.HistoricalRoots[ii]) == 0 {
+				b.HistoricalRoots[ii] = make([]byte, 0, len(buf[ii*32:(ii+1)*32]))
+			}
+			b.HistoricalRoots[ii] = append(b.HistoricalRoots[ii], buf[ii*32:(ii+1)*32]...)
+		}
+	}
+
+	// Field (9) 'Eth1DataVotes'
+	{
+		buf = tail[o9:o11]
+		num, err := ssz.DivideInt2(len(buf), 72, 2048)
+		if err != nil {
+			return err
+		}
+		b.Eth1DataVotes = make([]*Eth1Data, num)
+		for ii := 0; ii < num; ii++ {
+			if b.Eth1DataVotes[ii] == nil {
+				b.Eth1DataVotes[ii] = new(Eth1Data)
+			}
+			if err = b.Eth1DataVotes[ii].UnmarshalSSZ(buf[ii*72 : (ii+1)*72]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (11) 'Validators'
+	{
+		buf = tail[o11:o12]
+		num, err := ssz.DivideInt2(len(buf), 121, 1099511627776)
+		if err != nil {
+			return err
+		}
+		b.Validators = make([]*Validator, num)
+		for ii := 0; ii < num; ii++ {
+			if b.Validators[ii] == nil {
+				b.Validators[ii] = new(Validator)
+			}
+			if err = b.Validators[ii].UnmarshalSSZ(buf[ii*121 : (ii+1)*121]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (12) 'Balances'
+	{
+		buf = tail[o12:o15]
+		num, err := ssz.DivideInt2(len(buf), 8, 1099511627776)
+		if err != nil {
+			return err
+		}
+		b.Balances = ssz.ExtendUint64(b.Balances, num)
+		for ii := 0; ii < num; ii++ {
+			b.Balances[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+		}
+	}
+
+	// Field (15) 'PreviousEpochParticipation'
+	{
+		buf = tail[o15:o16]
+		if len(buf) > 1099511627776 {
+			return ssz.ErrBytesLength
+		}
+		if cap(b.PreviousEpochParticipation) == 0 {
+			b.PreviousEpochParticipation = make([]byte, 0, len(buf))
+		}
+		b.PreviousEpochParticipation = append(b.PreviousEpochParticipation, buf...)
+	}
+
+	// Field (16) 'CurrentEpochParticipation'
+	{
+		buf = tail[o16:o21]
+		if len(buf) > 1099511627776 {
+			return ssz.ErrBytesLength
+		}
+		if cap(b.CurrentEpochParticipation) == 0 {
+			b.CurrentEpochParticipation = make([]byte, 0, len(buf))
+		}
+		b.CurrentEpochParticipation = append(b.CurrentEpochParticipation, buf...)
+	}
+
+	// Field (21) 'InactivityScores'
+	{
+		buf = tail[o21:o24]
+		num, err := ssz.DivideInt2(len(buf), 8, 1099511627776)
+		if err != nil {
+			return err
+		}
+		b.InactivityScores = ssz.ExtendUint64(b.InactivityScores, num)
+		for ii := 0; ii < num; ii++ {
+			b.InactivityScores[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+		}
+	}
+
+	// Field (24) 'LatestExecutionPayloadHeader'
+	{
+		buf = tail[o24:o27]
+		if b.LatestExecutionPayloadHeader == nil {
+			b.LatestExecutionPayloadHeader = new(v1.ExecutionPayloadHeaderDeneb)
+		}
+		if err = b.LatestExecutionPayloadHeader.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+
+	// Field (27) 'HistoricalSummaries'
+	{
+		buf = tail[o27:]
+		num, err := ssz.DivideInt2(len(buf), 64, 16777216)
+		if err != nil {
+			return err
+		}
+		b.HistoricalSummaries = make([]*HistoricalSummary, num)
+		for ii := 0; ii < num; ii++ {
+			if b.HistoricalSummaries[ii] == nil {
+				b.HistoricalSummaries[ii] = new(HistoricalSummary)
+			}
+			if err = b.HistoricalSummaries[ii].UnmarshalSSZ(buf[ii*64 : (ii+1)*64]); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the BeaconStateDeneb object
+func (b *BeaconStateDeneb) SizeSSZ() (size int) {
+	size = 2736653
+
+	// Field (7) 'HistoricalRoots'
+	size += len(b.HistoricalRoots) * 32
+
+	// Field (9) 'Eth1DataVotes'
+	size += len(b.Eth1DataVotes) * 72
+
+	// Field (11) 'Validators'
+	size += len(b.Validators) * 121
+
+	// Field (12) 'Balances'
+	size += len(b.Balances) * 8
+
+	// Field (15) 'PreviousEpochParticipation'
+	size += len(b.PreviousEpochParticipation)
+
+	// Field (16) 'CurrentEpochParticipation'
+	size += len(b.CurrentEpochParticipation)
+
+	// Field (21) 'InactivityScores'
+	size += len(b.InactivityScores) * 8
+
+	// Field (24) 'LatestExecutionPayloadHeader'
+	if b.LatestExecutionPayloadHeader == nil {
+		b.LatestExecutionPayloadHeader = new(v1.ExecutionPayloadHeaderDeneb)
+	}
+	size += b.LatestExecutionPayloadHeader.SizeSSZ()
+
+	// Field (27) 'HistoricalSummaries'
+	size += len(b.HistoricalSummaries) * 64
+
+	return
+}
+
+// HashTreeRoot ssz hashes the BeaconStateDeneb object
+func (b *BeaconStateDeneb) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
+}
+
+// HashTreeRootWith ssz hashes the BeaconStateDeneb object with a hasher
+func (b *BeaconStateDeneb) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'GenesisTime'
+	hh.PutUint64(b.GenesisTime)
+
+	// Field (1) 'GenesisValidatorsRoot'
+	if size := len(b.GenesisValidatorsRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
+		return
+	}
+	hh.PutBytes(b.GenesisValidatorsRoot)
+
+	// Field (2) 'Slot'
+	hh.PutUint64(uint64(b.Slot))
+
+	// Field (3) 'Fork'
+	if err = b.Fork.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (4) 'LatestBlockHeader'
+	if err = b.LatestBlockHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (5) 'BlockRoots'
+	{
+		if size := len(b.BlockRoots); size != 8192 {
+			err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.BlockRoots {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (6) 'StateRoots'
+	{
+		if size := len(b.StateRoots); size != 8192 {
+			err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.StateRoots {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (7) 'HistoricalRoots'
+	{
+		if size := len(b.HistoricalRoots); size > 16777216 {
+			err = ssz.ErrListTooBigFn("--.HistoricalRoots", size, 16777216)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.HistoricalRoots {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		numItems := uint64(len(b.HistoricalRoots))
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(16777216, numItems, 32))
+		} else {
+			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(16777216, numItems, 32))
+		}
+	}
+
+	// Field (8) 'Eth1Data'
+	if err = b.Eth1Data.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (9) 'Eth1DataVotes'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(b.Eth1DataVotes))
+		if num > 2048 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range b.Eth1DataVotes {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 2048)
+		} else {
+			hh.MerkleizeWithMixin(subIndx, num, 2048)
+		}
+	}
+
+	// Field (10) 'Eth1DepositIndex'
+	hh.PutUint64(b.Eth1DepositIndex)
+
+	// Field (11) 'Validators'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(b.Validators))
+		if num > 1099511627776 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range b.Validators {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 1099511627776)
+		} else {
+			hh.MerkleizeWithMixin(subIndx, num, 1099511627776)
+		}
+	}
+
+	// Field (12) 'Balances'
+	{
+		if size := len(b.Balances); size > 1099511627776 {
+			err = ssz.ErrListTooBigFn("--.Balances", size, 1099511627776)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.Balances {
+			hh.AppendUint64(i)
+		}
+		hh.FillUpTo32()
+
+		numItems := uint64(len(b.Balances))
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		} else {
+			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		}
+	}
+
+	// Field (13) 'RandaoMixes'
+	{
+		if size := len(b.RandaoMixes); size != 65536 {
+			err = ssz.ErrVectorLengthFn("--.RandaoMixes", size, 65536)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.RandaoMixes {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (14) 'Slashings'
+	{
+		if size := len(b.Slashings); size != 8192 {
+			err = ssz.ErrVectorLengthFn("--.Slashings", size, 8192)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.Slashings {
+			hh.AppendUint64(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (15) 'PreviousEpochParticipation'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(b.PreviousEpochParticipation))
+		if byteLen > 1099511627776 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(b.PreviousEpochParticipation)
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(elemIndx, byteLen, (1099511627776+31)/32)
+		} else {
+			hh.MerkleizeWithMixin(elemIndx, byteLen, (1099511627776+31)/32)
+		}
+	}
+
+	// Field (16) 'CurrentEpochParticipation'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(b.CurrentEpochParticipation))
+		if byteLen > 1099511627776 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(b.CurrentEpochParticipation)
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(elemIndx, byteLen, (1099511627776+31)/32)
+		} else {
+			hh.MerkleizeWithMixin(elemIndx, byteLen, (1099511627776+31)/32)
+		}
+	}
+
+	// Field (17) 'JustificationBits'
+	if size := len(b.JustificationBits); size != 1 {
+		err = ssz.ErrBytesLengthFn("--.JustificationBits", size, 1)
+		return
+	}
+	hh.PutBytes(b.JustificationBits)
+
+	// Field (18) 'PreviousJustifiedCheckpoint'
+	if err = b.PreviousJustifiedCheckpoint.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (19) 'CurrentJustifiedCheckpoint'
+	if err = b.CurrentJustifiedCheckpoint.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (20) 'FinalizedCheckpoint'
+	if err = b.FinalizedCheckpoint.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (21) 'InactivityScores'
+	{
+		if size := len(b.InactivityScores); size > 1099511627776 {
+			err = ssz.ErrListTooBigFn("--.InactivityScores", size, 1099511627776)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.InactivityScores {
+			hh.AppendUint64(i)
+		}
+		hh.FillUpTo32()
+
+		numItems := uint64(len(b.InactivityScores))
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		} else {
+			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		}
+	}
+
+	// Field (22) 'CurrentSyncCommittee'
+	if err = b.CurrentSyncCommittee.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (23) 'NextSyncCommittee'
+	if err = b.NextSyncCommittee.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (24) 'LatestExecutionPayloadHeader'
+	if err = b.LatestExecutionPayloadHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (25) 'NextWithdrawalIndex'
+	hh.PutUint64(b.NextWithdrawalIndex)
+
+	// Field (26) 'NextWithdrawalValidatorIndex'
+	hh.PutUint64(uint64(b.NextWithdrawalValidatorIndex))
+
+	// Field (27) 'HistoricalSummaries'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(b.HistoricalSummaries))
+		if num > 16777216 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range b.HistoricalSummaries {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 16777216)
+		} else {
+			hh.MerkleizeWithMixin(subIndx, num, 16777216)
+		}
+	}
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the PowBlock object
+func (p *PowBlock) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(p)
+}
+
+// MarshalSSZTo ssz marshals the PowBlock object to a target array
+func (p *PowBlock) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'BlockHash'
+	if size := len(p.BlockHash); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.BlockHash", size, 32)
+		return
+	}
+	dst = append(dst, p.BlockHash...)
+
+	// Field (1) 'ParentHash'
+	if size := len(p.ParentHash); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.ParentHash", size, 32)
+		return
+	}
+	dst = append(dst, p.ParentHash...)
+
+	// Field (2) 'TotalDifficulty'
+	if size := len(p.TotalDifficulty); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.TotalDifficulty", size, 32)
+		return
+	}
+	dst = append(dst, p.TotalDifficulty...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the PowBlock object
+func (p *PowBlock) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 96 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'BlockHash'
+	if cap(p.BlockHash) == 0 {
+		p.BlockHash = make([]byte, 0, len(buf[0:32]))
+	}
+	p.BlockHash = append(p.BlockHash, buf[0:32]...)
+
+	// Field (1) 'ParentHash'
+	if cap(p.ParentHash) == 0 {
+		p.ParentHash = make([]byte, 0, len(buf[32:64]))
+	}
+	p.ParentHash = append(p.ParentHash, buf[32:64]...)
+
+	// Field (2) 'TotalDifficulty'
+	if cap(p.TotalDifficulty) == 0 {
+		p.TotalDifficulty = make([]byte, 0, len(buf[64:96]))
+	}
+	p.TotalDifficulty = append(p.TotalDifficulty, buf[64:96]...)
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the PowBlock object
+func (p *PowBlock) SizeSSZ() (size int) {
+	size = 96
+	return
+}
+
+// HashTreeRoot ssz hashes the PowBlock object
+func (p *PowBlock) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(p)
+}
+
+// HashTreeRootWith ssz hashes the PowBlock object with a hasher
+func (p *PowBlock) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'BlockHash'
+	if size := len(p.BlockHash); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.BlockHash", size, 32)
+		return
+	}
+	hh.PutBytes(p.BlockHash)
+
+	// Field (1) 'ParentHash'
+	if size := len(p.ParentHash); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.ParentHash", size, 32)
+		return
+	}
+	hh.PutBytes(p.ParentHash)
+
+	// Field (2) 'TotalDifficulty'
+	if size := len(p.TotalDifficulty); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.TotalDifficulty", size, 32)
+		return
+	}
+	hh.PutBytes(p.TotalDifficulty)
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the HistoricalSummary object
+func (h *HistoricalSummary) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(h)
+}
+
+// MarshalSSZTo ssz marshals the HistoricalSummary object to a target array
+func (h *HistoricalSummary) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'BlockSummaryRoot'
+	if size := len(h.BlockSummaryRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.BlockSummaryRoot", size, 32)
+		return
+	}
+	dst = append(dst, h.BlockSummaryRoot...)
+
+	// Field (1) 'StateSummaryRoot'
+	if size := len(h.StateSummaryRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.StateSummaryRoot", size, 32)
+		return
+	}
+	dst = append(dst, h.StateSummaryRoot...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the HistoricalSummary object
+func (h *HistoricalSummary) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 64 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'BlockSummaryRoot'
+	if cap(h.BlockSummaryRoot) == 0 {
+		h.BlockSummaryRoot = make([]byte, 0, len(buf[0:32]))
+	}
+	h.BlockSummaryRoot = append(h.BlockSummaryRoot, buf[0:32]...)
+
+	// Field (1) 'StateSummaryRoot'
+	if cap(h.StateSummaryRoot) == 0 {
+		h.StateSummaryRoot = make([]byte, 0, len(buf[32:64]))
+	}
+	h.StateSummaryRoot = append(h.StateSummaryRoot, buf[32:64]...)
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the HistoricalSummary object
+func (h *HistoricalSummary) SizeSSZ() (size int) {
+	size = 64
+	return
+}
+
+// HashTreeRoot ssz hashes the HistoricalSummary object
+func (h *HistoricalSummary) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(h)
+}
+
+// HashTreeRootWith ssz hashes the HistoricalSummary object with a hasher
+func (h *HistoricalSummary) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'BlockSummaryRoot'
+	if size := len(h.BlockSummaryRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.BlockSummaryRoot", size, 32)
+		return
+	}
+	hh.PutBytes(h.BlockSummaryRoot)
+
+	// Field (1) 'StateSummaryRoot'
+	if size := len(h.StateSummaryRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.StateSummaryRoot", size, 32)
+		return
+	}
+	hh.PutBytes(h.StateSummaryRoot)
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the BlobSidecars object
+func (b *BlobSidecars) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
+}
+
+// MarshalSSZTo ssz marshals the BlobSidecars object to a target array
+func (b *BlobSidecars) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(4)
+
+	// Offset (0) 'Sidecars'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Sidecars) * 131928
+
+	// Field (0) 'Sidecars'
+	if size := len(b.Sidecars); size > 6 {
+		err = ssz.ErrListTooBigFn("--.Sidecars", size, 6)
+		return
+	}
+	for ii := 0; ii < len(b.Sidecars); ii++ {
+		if dst, err = b.Sidecars[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the BlobSidecars object
+func (b *BlobSidecars) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 4 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'Sidecars'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 4 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (0) 'Sidecars'
+	{
+		buf = tail[o0:]
+		num, err := ssz.DivideInt2(len(buf), 131928, 6)
+		if err != nil {
+			return err
+		}
+		b.Sidecars = make([]*BlobSidecar, num)
+		for ii := 0; ii < num; ii++ {
+			if b.Sidecars[ii] == nil {
+				b.Sidecars[ii] = new(BlobSidecar)
+			}
+			if err = b.Sidecars[ii].UnmarshalSSZ(buf[ii*131928 : (ii+1)*131928]); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the BlobSidecars object
+func (b *BlobSidecars) SizeSSZ() (size int) {
+	size = 4
+
+	// Field (0) 'Sidecars'
+	size += len(b.Sidecars) * 131928
+
+	return
+}
+
+// HashTreeRoot ssz hashes the BlobSidecars object
+func (b *BlobSidecars) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
+}
+
+// HashTreeRootWith ssz hashes the BlobSidecars object with a hasher
+func (b *BlobSidecars) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Sidecars'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(b.Sidecars))
+		if num > 6 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range b.Sidecars {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 6)
+		} else {
+			hh.MerkleizeWithMixin(subIndx, num, 6)
+		}
+	}
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the BlobSidecar object
+func (b *BlobSidecar) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
+}
+
+// MarshalSSZTo ssz marshals the BlobSidecar object to a target array
+func (b *BlobSidecar) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'Index'
+	dst = ssz.MarshalUint64(dst, b.Index)
+
+	// Field (1) 'Blob'
+	if size := len(b.Blob); size != 131072 {
+		err = ssz.ErrBytesLengthFn("--.Blob", size, 131072)
+		return
+	}
+	dst = append(dst, b.Blob...)
+
+	// Field (2) 'KzgCommitment'
+	if size := len(b.KzgCommitment); size != 48 {
+		err = ssz.ErrBytesLengthFn("--.KzgCommitment", size, 48)
+		return
+	}
+	dst = append(dst, b.KzgCommitment...)
+
+	// Field (3) 'KzgProof'
+	if size := len(b.KzgProof); size != 48 {
+		err = ssz.ErrBytesLengthFn("--.KzgProof", size, 48)
+		return
+	}
+	dst = append(dst, b.KzgProof...)
+
+	// Field (4) 'SignedBlockHeader'
+	if b.SignedBlockHeader == nil {
+		b.SignedBlockHeader = new(SignedBeaconBlockHeader)
+	}
+	if dst, err = b.SignedBlockHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (5) 'CommitmentInclusionProof'
+	if size := len(b.CommitmentInclusionProof); size != 17 {
+		err = ssz.ErrVectorLengthFn("--.CommitmentInclusionProof", size, 17)
+		return
+	}
+	for ii := 0; ii < 17; ii++ {
+		if size := len(b.CommitmentInclusionProof[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.CommitmentInclusionProof[ii]", size, 32)
+			return
+		}
+		dst = append(dst, b.CommitmentInclusionProof[ii]...)
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the BlobSidecar object
+func (b *BlobSidecar) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 131928 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'Index'
+	b.Index = ssz.UnmarshallUint64(buf[0:8])
+
+	// Field (1) 'Blob'
+	if cap(b.Blob) == 0 {
+		b.Blob = make([]byte, 0, len(buf[8:131080]))
+	}
+	b.Blob = append(b.Blob, buf[8:131080]...)
+
+	// Field (2) 'KzgCommitment'
+	if cap(b.KzgCommitment) == 0 {
+		b.KzgCommitment = make([]byte, 0, len(buf[131080:131128]))
+	}
+	b.KzgCommitment = append(b.KzgCommitment, buf[131080:131128]...)
+
+	// Field (3) 'KzgProof'
+	if cap(b.KzgProof) == 0 {
+		b.KzgProof = make([]byte, 0, len(buf[131128:131176]))
+	}
+	b.KzgProof = append(b.KzgProof, buf[131128:131176]...)
+
+	// Field (4) 'SignedBlockHeader'
+	if b.SignedBlockHeader == nil {
+		b.SignedBlockHeader = new(SignedBeaconBlockHeader)
+	}
+	if err = b.SignedBlockHeader.UnmarshalSSZ(buf[131176:131384]); err != nil {
+		return err
+	}
+
+	// Field (5) 'CommitmentInclusionProof'
+	b.CommitmentInclusionProof = make([][]byte, 17)
+	for ii := 0; ii < 17; ii++ {
+		if cap(b.CommitmentInclusionProof[ii]) == 0 {
+			b.CommitmentInclusionProof[ii] = make([]byte, 0, len(buf[131384:131928][ii*32:(ii+1)*32]))
+		}
+		b.CommitmentInclusionProof[ii] = append(b.CommitmentInclusionProof[ii], buf[131384:131928][ii*32:(ii+1)*32]...)
+	}
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the BlobSidecar object
+func (b *BlobSidecar) SizeSSZ() (size int) {
+	size = 131928
+	return
+}
+
+// HashTreeRoot ssz hashes the BlobSidecar object
+func (b *BlobSidecar) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
+}
+
+// HashTreeRootWith ssz hashes the BlobSidecar object with a hasher
+func (b *BlobSidecar) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Index'
+	hh.PutUint64(b.Index)
+
+	// Field (1) 'Blob'
+	if size := len(b.Blob); size != 131072 {
+		err = ssz.ErrBytesLengthFn("--.Blob", size, 131072)
+		return
+	}
+	hh.PutBytes(b.Blob)
+
+	// Field (2) 'KzgCommitment'
+	if size := len(b.KzgCommitment); size != 48 {
+		err = ssz.ErrBytesLengthFn("--.KzgCommitment", size, 48)
+		return
+	}
+	hh.PutBytes(b.KzgCommitment)
+
+	// Field (3) 'KzgProof'
+	if size := len(b.KzgProof); size != 48 {
+		err = ssz.ErrBytesLengthFn("--.KzgProof", size, 48)
+		return
+	}
+	hh.PutBytes(b.KzgProof)
+
+	// Field (4) 'SignedBlockHeader'
+	if err = b.SignedBlockHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (5) 'CommitmentInclusionProof'
+	{
+		if size := len(b.CommitmentInclusionProof); size != 17 {
+			err = ssz.ErrVectorLengthFn("--.CommitmentInclusionProof", size, 17)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range b.CommitmentInclusionProof {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the BlobIdentifier object
+func (b *BlobIdentifier) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
+}
+
+// MarshalSSZTo ssz marshals the BlobIdentifier object to a target array
+func (b *BlobIdentifier) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'BlockRoot'
+	if size := len(b.BlockRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.BlockRoot", size, 32)
+		return
+	}
+	dst = append(dst, b.BlockRoot...)
+
+	// Field (1) 'Index'
+	dst = ssz.MarshalUint64(dst, b.Index)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the BlobIdentifier object
+func (b *BlobIdentifier) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 40 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'BlockRoot'
+	if cap(b.BlockRoot) == 0 {
+		b.BlockRoot = make([]byte, 0, len(buf[0:32]))
+	}
+	b.BlockRoot = append(b.BlockRoot, buf[0:32]...)
+
+	// Field (1) 'Index'
+	b.Index = ssz.UnmarshallUint64(buf[32:40])
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the BlobIdentifier object
+func (b *BlobIdentifier) SizeSSZ() (size int) {
+	size = 40
+	return
+}
+
+// HashTreeRoot ssz hashes the BlobIdentifier object
+func (b *BlobIdentifier) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
+}
+
+// HashTreeRootWith ssz hashes the BlobIdentifier object with a hasher
+func (b *BlobIdentifier) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'BlockRoot'
+	if size := len(b.BlockRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.BlockRoot", size, 32)
+		return
+	}
+	hh.PutBytes(b.BlockRoot)
+
+	// Field (1) 'Index'
+	hh.PutUint64(b.Index)
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the LightClientHeader object
+func (l *LightClientHeader) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
+}
+
+// MarshalSSZTo ssz marshals the LightClientHeader object to a target array
+func (l *LightClientHeader) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'Beacon'
+	if l.Beacon == nil {
+		l.Beacon = new(BeaconBlockHeader)
+	}
+	if dst, err = l.Beacon.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientHeader object
+func (l *LightClientHeader) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 112 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'Beacon'
+	if l.Beacon == nil {
+		l.Beacon = new(BeaconBlockHeader)
+	}
+	if err = l.Beacon.UnmarshalSSZ(buf[0:112]); err != nil {
+		return err
+	}
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientHeader object
+func (l *LightClientHeader) SizeSSZ() (size int) {
+	size = 112
+	return
+}
+
+// HashTreeRoot ssz hashes the LightClientHeader object
+func (l *LightClientHeader) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
+}
+
+// HashTreeRootWith ssz hashes the LightClientHeader object with a hasher
+func (l *LightClientHeader) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Beacon'
+	if err = l.Beacon.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the LightClientHeaderCapella object
+func (l *LightClientHeaderCapella) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
+}
+
+// MarshalSSZTo ssz marshals the LightClientHeaderCapella object to a target array
+func (l *LightClientHeaderCapella) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(244)
+
+	// Field (0) 'Beacon'
+	if l.Beacon == nil {
+		l.Beacon = new(BeaconBlockHeader)
+	}
+	if dst, err = l.Beacon.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Offset (1) 'Execution'
+	dst = ssz.WriteOffset(dst, offset)
+	if l.Execution == nil {
+		l.Execution = new(v1.ExecutionPayloadHeaderCapella)
+	}
+	offset += l.Execution.SizeSSZ()
+
+	// Field (2) 'ExecutionBranch'
+	if size := len(l.ExecutionBranch); size != 4 {
+		err = ssz.ErrVectorLengthFn("--.ExecutionBranch", size, 4)
+		return
+	}
+	for ii := 0; ii < 4; ii++ {
+		if size := len(l.ExecutionBranch[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.ExecutionBranch[ii]", size, 32)
+			return
+		}
+		dst = append(dst, l.ExecutionBranch[ii]...)
+	}
+
+	// Field (1) 'Execution'
+	if dst, err = l.Execution.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientHeaderCapella object
+func (l *LightClientHeaderCapella) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 244 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o1 uint64
+
+	// Field (0) 'Beacon'
+	if l.Beacon == nil {
+		l.Beacon = new(BeaconBlockHeader)
+	}
+	if err = l.Beacon.UnmarshalSSZ(buf[0:112]); err != nil {
+		return err
+	}
+
+	// Offset (1) 'Execution'
+	if o1 = ssz.ReadOffset(buf[112:116]); o1 > size {
+		return ssz.ErrOffset
+	}
+
+	if o1 < 244 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (2) 'ExecutionBranch'
+	l.ExecutionBranch = make([][]byte, 4)
+	for ii := 0; ii < 4; ii++ {
+		if cap(l.ExecutionBranch[ii]) == 0 {
+			l.ExecutionBranch[ii] = make([]byte, 0, len(buf[116:244][ii*32:(ii+1)*32]))
+		}
+		l.ExecutionBranch[ii] = append(l.ExecutionBranch[ii], buf[116:244][ii*32:(ii+1)*32]...)
+	}
+
+	// Field (1) 'Execution'
+	{
+		buf = tail[o1:]
+		if l.Execution == nil {
+			l.Execution = new(v1.ExecutionPayloadHeaderCapella)
+		}
+		if err = l.Execution.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientHeaderCapella object
+func (l *LightClientHeaderCapella) SizeSSZ() (size int) {
+	size = 244
+
+	// Field (1) 'Execution'
+	if l.Execution == nil {
+		l.Execution = new(v1.ExecutionPayloadHeaderCapella)
+	}
+	size += l.Execution.SizeSSZ()
+
+	return
+}
+
+// HashTreeRoot ssz hashes the LightClientHeaderCapella object
+func (l *LightClientHeaderCapella) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
+}
+
+// HashTreeRootWith ssz hashes the LightClientHeaderCapella object with a hasher
+func (l *LightClientHeaderCapella) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Beacon'
+	if err = l.Beacon.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (1) 'Execution'
+	if err = l.Execution.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (2) 'ExecutionBranch'
+	{
+		if size := len(l.ExecutionBranch); size != 4 {
+			err = ssz.ErrVectorLengthFn("--.ExecutionBranch", size, 4)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range l.ExecutionBranch {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the LightClientHeaderDeneb object
+func (l *LightClientHeaderDeneb) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
+}
+
+// MarshalSSZTo ssz marshals the LightClientHeaderDeneb object to a target array
+func (l *LightClientHeaderDeneb) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(244)
+
+	// Field (0) 'Beacon'
+	if l.Beacon == nil {
+		l.Beacon = new(BeaconBlockHeader)
+	}
+	if dst, err = l.Beacon.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Offset (1) 'Execution'
+	dst = ssz.WriteOffset(dst, offset)
+	if l.Execution == nil {
+		l.Execution = new(v1.ExecutionPayloadHeaderDeneb)
+	}
+	offset += l.Execution.SizeSSZ()
+
+	// Field (2) 'ExecutionBranch'
+	if size := len(l.ExecutionBranch); size != 4 {
+		err = ssz.ErrVectorLengthFn("--.ExecutionBranch", size, 4)
+		return
+	}
+	for ii := 0; ii < 4; ii++ {
+		if size := len(l.ExecutionBranch[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.ExecutionBranch[ii]", size, 32)
+			return
+		}
+		dst = append(dst, l.ExecutionBranch[ii]...)
+	}
+
+	// Field (1) 'Execution'
+	if dst, err = l.Execution.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientHeaderDeneb object
+func (l *LightClientHeaderDeneb) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 244 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o1 uint64
+
+	// Field (0) 'Beacon'
+	if l.Beacon == nil {
+		l.Beacon = new(BeaconBlockHeader)
+	}
+	if err = l.Beacon.UnmarshalSSZ(buf[0:112]); err != nil {
+		return err
+	}
+
+	// Offset (1) 'Execution'
+	if o1 = ssz.ReadOffset(buf[112:116]); o1 > size {
+		return ssz.ErrOffset
+	}
+
+	if o1 < 244 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (2) 'ExecutionBranch'
+	l.ExecutionBranch = make([][]byte, 4)
+	for ii := 0; ii < 4; ii++ {
+		if cap(l.ExecutionBranch[ii]) == 0 {
+			l.ExecutionBranch[ii] = make([]byte, 0, len(buf[116:244][ii*32:(ii+1)*32]))
+		}
+		l.ExecutionBranch[ii] = append(l.ExecutionBranch[ii], buf[116:244][ii*32:(ii+1)*32]...)
+	}
+
+	// Field (1) 'Execution'
+	{
+		buf = tail[o1:]
+		if l.Execution == nil {
+			l.Execution = new(v1.ExecutionPayloadHeaderDeneb)
+		}
+		if err = l.Execution.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientHeaderDeneb object
+func (l *LightClientHeaderDeneb) SizeSSZ() (size int) {
+	size = 244
+
+	// Field (1) 'Execution'
+	if l.Execution == nil {
+		l.Execution = new(v1.ExecutionPayloadHeaderDeneb)
+	}
+	size += l.Execution.SizeSSZ()
+
+	return
+}
+
+// HashTreeRoot ssz hashes the LightClientHeaderDeneb object
+func (l *LightClientHeaderDeneb) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
+}
+
+// HashTreeRootWith ssz hashes the LightClientHeaderDeneb object with a hasher
+func (l *LightClientHeaderDeneb) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Beacon'
+	if err = l.Beacon.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (1) 'Execution'
+	if err = l.Execution.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (2) 'ExecutionBranch'
+	{
+		if size := len(l.ExecutionBranch); size != 4 {
+			err = ssz.ErrVectorLengthFn("--.ExecutionBranch", size, 4)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range l.ExecutionBranch {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the LightClientBootstrap object
+func (l *LightClientBootstrap) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
+}
+
+// MarshalSSZTo ssz marshals the LightClientBootstrap object to a target array
+func (l *LightClientBootstrap) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'Header'
+	if l.Header == nil {
+		l.Header = new(LightClientHeader)
+	}
+	if dst, err = l.Header.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (1) 'CurrentSyncCommittee'
+	if l.CurrentSyncCommittee == nil {
+		l.CurrentSyncCommittee = new(SyncCommittee)
+	}
+	if dst, err = l.CurrentSyncCommittee.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (2) 'CurrentSyncCommitteeBranch'
+	if size := len(l.CurrentSyncCommitteeBranch); size != 5 {
+		err = ssz.ErrVectorLengthFn("--.CurrentSyncCommitteeBranch", size, 5)
+		return
+	}
+	for ii := 0; ii < 5; ii++ {
+		if size := len(l.CurrentSyncCommitteeBranch[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.CurrentSyncCommitteeBranch[ii]", size, 32)
+			return
+		}
+		dst = append(dst, l.CurrentSyncCommitteeBranch[ii]...)
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientBootstrap object
+func (l *LightClientBootstrap) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 24896 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'Header'
+	if l.Header == nil {
+		l.Header = new(LightClientHeader)
+	}
+	if err = l.Header.UnmarshalSSZ(buf[0:112]); err != nil {
+		return err
+	}
+
+	// Field (1) 'CurrentSyncCommittee'
+	if l.CurrentSyncCommittee == nil {
+		l.CurrentSyncCommittee = new(SyncCommittee)
+	}
+	if err = l.CurrentSyncCommittee.UnmarshalSSZ(buf[112:24736]); err != nil {
+		return err
+	}
+
+	// Field (2) 'CurrentSyncCommitteeBranch'
+	l.CurrentSyncCommitteeBranch = make([][]byte, 5)
+	for ii := 0; ii < 5; ii++ {
+		if cap(l.CurrentSyncCommitteeBranch[ii]) == 0 {
+			l.CurrentSyncCommitteeBranch[ii] = make([]byte, 0, len(buf[24736:24896][ii*32:(ii+1)*32]))
+		}
+		l.CurrentSyncCommitteeBranch[ii] = append(l.CurrentSyncCommitteeBranch[ii], buf[24736:24896][ii*32:(ii+1)*32]...)
+	}
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientBootstrap object
+func (l *LightClientBootstrap) SizeSSZ() (size int) {
+	size = 24896
+	return
+}
+
+// HashTreeRoot ssz hashes the LightClientBootstrap object
+func (l *LightClientBootstrap) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
+}
+
+// HashTreeRootWith ssz hashes the LightClientBootstrap object with a hasher
+func (l *LightClientBootstrap) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Header'
+	if err = l.Header.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (1) 'CurrentSyncCommittee'
+	if err = l.CurrentSyncCommittee.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (2) 'CurrentSyncCommitteeBranch'
+	{
+		if size := len(l.CurrentSyncCommitteeBranch); size != 5 {
+			err = ssz.ErrVectorLengthFn("--.CurrentSyncCommitteeBranch", size, 5)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range l.CurrentSyncCommitteeBranch {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the LightClientUpdate object
+func (l *LightClientUpdate) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
+}
+
+// MarshalSSZTo ssz marshals the LightClientUpdate object to a target array
+func (l *LightClientUpdate) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'AttestedHeader'
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeader)
+	}
+	if dst, err = l.AttestedHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (1) 'NextSyncCommittee'
+	if l.NextSyncCommittee == nil {
+		l.NextSyncCommittee = new(SyncCommittee)
+	}
+	if dst, err = l.NextSyncCommittee.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (2) 'NextSyncCommitteeBranch'
+	if size := len(l.NextSyncCommitteeBranch); size != 5 {
+		err = ssz.ErrVectorLengthFn("--.NextSyncCommitteeBranch", size, 5)
+		return
+	}
+	for ii := 0; ii < 5; ii++ {
+		if size := len(l.NextSyncCommitteeBranch[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.NextSyncCommitteeBranch[ii]", size, 32)
+			return
+		}
+		dst = append(dst, l.NextSyncCommitteeBranch[ii]...)
+	}
+
+	// Field (3) 'FinalizedHeader'
+	if l.FinalizedHeader == nil {
+		l.FinalizedHeader = new(LightClientHeader)
+	}
+	if dst, err = l.FinalizedHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (4) 'FinalityBranch'
+	if size := len(l.FinalityBranch); size != 6 {
+		err = ssz.ErrVectorLengthFn("--.FinalityBranch", size, 6)
+		return
+	}
+	for ii := 0; ii < 6; ii++ {
+		if size := len(l.FinalityBranch[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.FinalityBranch[ii]", size, 32)
+			return
+		}
+		dst = append(dst, l.FinalityBranch[ii]...)
+	}
+
+	// Field (5) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if dst, err = l.SyncAggregate.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (6) 'SignatureSlot'
+	dst = ssz.MarshalUint64(dst, uint64(l.SignatureSlot))
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientUpdate object
+func (l *LightClientUpdate) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 25368 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'AttestedHeader'
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeader)
+	}
+	if err = l.AttestedHeader.UnmarshalSSZ(buf[0:112]); err != nil {
+		return err
+	}
+
+	// Field (1) 'NextSyncCommittee'
+	if l.NextSyncCommittee == nil {
+		l.NextSyncCommittee = new(SyncCommittee)
+	}
+	if err = l.NextSyncCommittee.UnmarshalSSZ(buf[112:24736]); err != nil {
+		return err
+	}
+
+	// Field (2) 'NextSyncCommitteeBranch'
+	l.NextSyncCommitteeBranch = make([][]byte, 5)
+	for ii := 0; ii < 5; ii++ {
+		if cap(l.NextSyncCommitteeBranch[ii]) == 0 {
+			l.NextSyncCommitteeBranch[ii] = make([]byte, 0, len(buf[24736:24896][ii*32:(ii+1)*32]))
+		}
+		l.NextSyncCommitteeBranch[ii] = append(l.NextSyncCommitteeBranch[ii], buf[24736:24896][ii*32:(ii+1)*32]...)
+	}
+
+	// Field (3) 'FinalizedHeader'
+	if l.FinalizedHeader == nil {
+		l.FinalizedHeader = new(LightClientHeader)
+	}
+	if err = l.FinalizedHeader.UnmarshalSSZ(buf[24896:25008]); err != nil {
+		return err
+	}
+
+	// Field (4) 'FinalityBranch'
+	l.FinalityBranch = make([][]byte, 6)
+	for ii := 0; ii < 6; ii++ {
+		if cap(l.FinalityBranch[ii]) == 0 {
+			l.FinalityBranch[ii] = make([]byte, 0, len(buf[25008:25200][ii*32:(ii+1)*32]))
+		}
+		l.FinalityBranch[ii] = append(l.FinalityBranch[ii], buf[25008:25200][ii*32:(ii+1)*32]...)
+	}
+
+	// Field (5) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if err = l.SyncAggregate.UnmarshalSSZ(buf[25200:25360]); err != nil {
+		return err
+	}
+
+	// Field (6) 'SignatureSlot'
+	l.SignatureSlot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[25360:25368]))
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientUpdate object
+func (l *LightClientUpdate) SizeSSZ() (size int) {
+	size = 25368
+	return
+}
+
+// HashTreeRoot ssz hashes the LightClientUpdate object
+func (l *LightClientUpdate) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
+}
+
+// HashTreeRootWith ssz hashes the LightClientUpdate object with a hasher
+func (l *LightClientUpdate) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'AttestedHeader'
+	if err = l.AttestedHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (1) 'NextSyncCommittee'
+	if err = l.NextSyncCommittee.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (2) 'NextSyncCommitteeBranch'
+	{
+		if size := len(l.NextSyncCommitteeBranch); size != 5 {
+			err = ssz.ErrVectorLengthFn("--.NextSyncCommitteeBranch", size, 5)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range l.NextSyncCommitteeBranch {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (3) 'FinalizedHeader'
+	if err = l.FinalizedHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (4) 'FinalityBranch'
+	{
+		if size := len(l.FinalityBranch); size != 6 {
+			err = ssz.ErrVectorLengthFn("--.FinalityBranch", size, 6)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range l.FinalityBranch {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (5) 'SyncAggregate'
+	if err = l.SyncAggregate.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (6) 'SignatureSlot'
+	hh.PutUint64(uint64(l.SignatureSlot))
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the LightClientFinalityUpdate object
+func (l *LightClientFinalityUpdate) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
+}
+
+// MarshalSSZTo ssz marshals the LightClientFinalityUpdate object to a target array
+func (l *LightClientFinalityUpdate) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'AttestedHeader'
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeader)
+	}
+	if dst, err = l.AttestedHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (1) 'FinalizedHeader'
+	if l.FinalizedHeader == nil {
+		l.FinalizedHeader = new(LightClientHeader)
+	}
+	if dst, err = l.FinalizedHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (2) 'FinalityBranch'
+	if size := len(l.FinalityBranch); size != 6 {
+		err = ssz.ErrVectorLengthFn("--.FinalityBranch", size, 6)
+		return
+	}
+	for ii := 0; ii < 6; ii++ {
+		if size := len(l.FinalityBranch[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.FinalityBranch[ii]", size, 32)
+			return
+		}
+		dst = append(dst, l.FinalityBranch[ii]...)
+	}
+
+	// Field (3) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if dst, err = l.SyncAggregate.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (4) 'SignatureSlot'
+	dst = ssz.MarshalUint64(dst, uint64(l.SignatureSlot))
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientFinalityUpdate object
+func (l *LightClientFinalityUpdate) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 584 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'AttestedHeader'
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeader)
+	}
+	if err = l.AttestedHeader.UnmarshalSSZ(buf[0:112]); err != nil {
+		return err
+	}
+
+	// Field (1) 'FinalizedHeader'
+	if l.FinalizedHeader == nil {
+		l.FinalizedHeader = new(LightClientHeader)
+	}
+	if err = l.FinalizedHeader.UnmarshalSSZ(buf[112:224]); err != nil {
+		return err
+	}
+
+	// Field (2) 'FinalityBranch'
+	l.FinalityBranch = make([][]byte, 6)
+	for ii := 0; ii < 6; ii++ {
+		if cap(l.FinalityBranch[ii]) == 0 {
+			l.FinalityBranch[ii] = make([]byte, 0, len(buf[224:416][ii*32:(ii+1)*32]))
+		}
+		l.FinalityBranch[ii] = append(l.FinalityBranch[ii], buf[224:416][ii*32:(ii+1)*32]...)
+	}
+
+	// Field (3) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if err = l.SyncAggregate.UnmarshalSSZ(buf[416:576]); err != nil {
+		return err
+	}
+
+	// Field (4) 'SignatureSlot'
+	l.SignatureSlot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[576:584]))
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientFinalityUpdate object
+func (l *LightClientFinalityUpdate) SizeSSZ() (size int) {
+	size = 584
+	return
+}
+
+// HashTreeRoot ssz hashes the LightClientFinalityUpdate object
+func (l *LightClientFinalityUpdate) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
+}
+
+// HashTreeRootWith ssz hashes the LightClientFinalityUpdate object with a hasher
+func (l *LightClientFinalityUpdate) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'AttestedHeader'
+	if err = l.AttestedHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (1) 'FinalizedHeader'
+	if err = l.FinalizedHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (2) 'FinalityBranch'
+	{
+		if size := len(l.FinalityBranch); size != 6 {
+			err = ssz.ErrVectorLengthFn("--.FinalityBranch", size, 6)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range l.FinalityBranch {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
+
+	// Field (3) 'SyncAggregate'
+	if err = l.SyncAggregate.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (4) 'SignatureSlot'
+	hh.PutUint64(uint64(l.SignatureSlot))
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the LightClientOptimisticUpdate object
+func (l *LightClientOptimisticUpdate) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
+}
+
+// MarshalSSZTo ssz marshals the LightClientOptimisticUpdate object to a target array
+func (l *LightClientOptimisticUpdate) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'AttestedHeader'
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeader)
+	}
+	if dst, err = l.AttestedHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (1) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if dst, err = l.SyncAggregate.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (2) 'SignatureSlot'
+	dst = ssz.MarshalUint64(dst, uint64(l.SignatureSlot))
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientOptimisticUpdate object
+func (l *LightClientOptimisticUpdate) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 280 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'AttestedHeader'
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeader)
+	}
+	if err = l.AttestedHeader.UnmarshalSSZ(buf[0:112]); err != nil {
+		return err
+	}
+
+	// Field (1) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if err = l.SyncAggregate.UnmarshalSSZ(buf[112:272]); err != nil {
+		return err
+	}
+
+	// Field (2) 'SignatureSlot'
+	l.SignatureSlot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[272:280]))
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientOptimisticUpdate object
+func (l *LightClientOptimisticUpdate) SizeSSZ() (size int) {
+	size = 280
+	return
+}
+
+// HashTreeRoot ssz hashes the LightClientOptimisticUpdate object
+func (l *LightClientOptimisticUpdate) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
+}
+
+// HashTreeRootWith ssz hashes the LightClientOptimisticUpdate object with a hasher
+func (l *LightClientOptimisticUpdate) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'AttestedHeader'
+	if err = l.AttestedHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (1) 'SyncAggregate'
+	if err = l.SyncAggregate.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (2) 'SignatureSlot'
+	hh.PutUint64(uint64(l.SignatureSlot))
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the LightClientBootstrapCapella object
+func (l *LightClientBootstrapCapella) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
+}
+
+// MarshalSSZTo ssz marshals the LightClientBootstrapCapella object to a target array
+func (l *LightClientBootstrapCapella) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(24788)
+
+	// Offset (0) 'Header'
+	dst = ssz.WriteOffset(dst, offset)
+	if l.Header == nil {
+		l.Header = new(LightClientHeaderCapella)
+	}
+	offset += l.Header.SizeSSZ()
+
+	// Field (1) 'CurrentSyncCommittee'
+	if l.CurrentSyncCommittee == nil {
+		l.CurrentSyncCommittee = new(SyncCommittee)
+	}
+	if dst, err = l.CurrentSyncCommittee.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (2) 'CurrentSyncCommitteeBranch'
+	if size := len(l.CurrentSyncCommitteeBranch); size != 5 {
+		err = ssz.ErrVectorLengthFn("--.CurrentSyncCommitteeBranch", size, 5)
+		return
+	}
+	for ii := 0; ii < 5; ii++ {
+		if size := len(l.CurrentSyncCommitteeBranch[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.CurrentSyncCommitteeBranch[ii]", size, 32)
+			return
+		}
+		dst = append(dst, l.CurrentSyncCommitteeBranch[ii]...)
+	}
+
+	// Field (0) 'Header'
+	if dst, err = l.Header.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientBootstrapCapella object
+func (l *LightClientBootstrapCapella) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 24788 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'Header'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 24788 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (1) 'CurrentSyncCommittee'
+	if l.CurrentSyncCommittee == nil {
+		l.CurrentSyncCommittee = new(SyncCommittee)
+	}
+	if err = l.CurrentSyncCommittee.UnmarshalSSZ(buf[4:24628]); err != nil {
+		return err
+	}
+
+	// Field (2) 'CurrentSyncCommitteeBranch'
+	l.CurrentSyncCommitteeBranch = make([][]byte, 5)
+	for ii := 0; ii < 5; ii++ {
+		if cap(l.CurrentSyncCommitteeBranch[ii]) == 0 {
+			l.CurrentSyncCommitteeBranch[ii] = make([]byte, 0, len(buf[24628:24788][ii*32:(ii+1)*32]))
+		}
+		l.CurrentSyncCommitteeBranch[ii] = append(l.CurrentSyncCommitteeBranch[ii], buf[24628:24788][ii*32:(ii+1)*32]...)
+	}
+
+	// Field (0) 'Header'
+	{
+		buf = tail[o0:]
+		if l.Header == nil {
+			l.Header = new(LightClientHeaderCapella)
+		}
+		if err = l.Header.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientBootstrapCapella object
+func (l *LightClientBootstrapCapella) SizeSSZ() (size int) {
+	size = 24788
+
+	// Field (0) 'Header'
+	if l.Header == nil {
+		l.Header = new(LightClientHeaderCapella)
+	}
+	size += l.Header.SizeSSZ()
+
+	return
+}
+
+// HashTreeRoot ssz hashes the LightClientBootstrapCapella object
+func (l *LightClientBootstrapCapella) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
+}
+
+// HashTreeRootWith ssz hashes the LightClientBootstrapCapella object with a hasher
+func (l *LightClientBootstrapCapella) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Header'
+	if err = l.Header.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (1) 'CurrentSyncCommittee'
+	if err = l.CurrentSyncCommittee.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (2) 'CurrentSyncCommitteeBranch'
+	{
+		if size := len(l.CurrentSyncCommitteeBranch); size != 5 {
+			err = ssz.ErrVectorLengthFn("--.CurrentSyncCommitteeBranch", size, 5)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range l.CurrentSyncCommitteeBranch {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
+	}
 
 	if ssz.EnableVectorizedHTR {
 		hh.MerkleizeVectorizedHTR(indx)
@@ -10195,676 +16084,649 @@ func (b *BeaconStateBellatrix) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	return
 }
 
-// MarshalSSZ ssz marshals the BeaconStateCapella object
-func (b *BeaconStateCapella) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(b)
+// MarshalSSZ ssz marshals the LightClientBootstrapDeneb object
+func (l *LightClientBootstrapDeneb) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
 }
 
-// MarshalSSZTo ssz marshals the BeaconStateCapella object to a target array
-func (b *BeaconStateCapella) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+// MarshalSSZTo ssz marshals the LightClientBootstrapDeneb object to a target array
+func (l *LightClientBootstrapDeneb) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
-	offset := int(2736653)
-
-	// Field (0) 'GenesisTime'
-	dst = ssz.MarshalUint64(dst, b.GenesisTime)
+	offset := int(24788)
 
-	// Field (1) 'GenesisValidatorsRoot'
-	if size := len(b.GenesisValidatorsRoot); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
-		return
-	}
-	dst = append(dst, b.GenesisValidatorsRoot...)
-
-	// Field (2) 'Slot'
-	dst = ssz.MarshalUint64(dst, uint64(b.Slot))
-
-	// Field (3) 'Fork'
-	if b.Fork == nil {
-		b.Fork = new(Fork)
-	}
-	if dst, err = b.Fork.MarshalSSZTo(dst); err != nil {
-		return
+	// Offset (0) 'Header'
+	dst = ssz.WriteOffset(dst, offset)
+	if l.Header == nil {
+		l.Header = new(LightClientHeaderDeneb)
 	}
+	offset += l.Header.SizeSSZ()
 
-	// Field (4) 'LatestBlockHeader'
-	if b.LatestBlockHeader == nil {
-		b.LatestBlockHeader = new(BeaconBlockHeader)
+	// Field (1) 'CurrentSyncCommittee'
+	if l.CurrentSyncCommittee == nil {
+		l.CurrentSyncCommittee = new(SyncCommittee)
 	}
-	if dst, err = b.LatestBlockHeader.MarshalSSZTo(dst); err != nil {
+	if dst, err = l.CurrentSyncCommittee.MarshalSSZTo(dst); err != nil {
 		return
 	}
 
-	// Field (5) 'BlockRoots'
-	if size := len(b.BlockRoots); size != 8192 {
-		err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
+	// Field (2) 'CurrentSyncCommitteeBranch'
+	if size := len(l.CurrentSyncCommitteeBranch); size != 5 {
+		err = ssz.ErrVectorLengthFn("--.CurrentSyncCommitteeBranch", size, 5)
 		return
 	}
-	for ii := 0; ii < 8192; ii++ {
-		if size := len(b.BlockRoots[ii]); size != 32 {
-			err = ssz.ErrBytesLengthFn("--.BlockRoots[ii]", size, 32)
+	for ii := 0; ii < 5; ii++ {
+		if size := len(l.CurrentSyncCommitteeBranch[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.CurrentSyncCommitteeBranch[ii]", size, 32)
 			return
 		}
-		dst = append(dst, b.BlockRoots[ii]...)
+		dst = append(dst, l.CurrentSyncCommitteeBranch[ii]...)
 	}
 
-	// Field (6) 'StateRoots'
-	if size := len(b.StateRoots); size != 8192 {
-		err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
+	// Field (0) 'Header'
+	if dst, err = l.Header.MarshalSSZTo(dst); err != nil {
 		return
 	}
-	for ii := 0; ii < 8192; ii++ {
-		if size := len(b.StateRoots[ii]); size != 32 {
-			err = ssz.ErrBytesLengthFn("--.StateRoots[ii]", size, 32)
-			return
-		}
-		dst = append(dst, b.StateRoots[ii]...)
-	}
 
-	// Offset (7) 'HistoricalRoots'
-	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.HistoricalRoots) * 32
+	return
+}
 
-	// Field (8) 'Eth1Data'
-	if b.Eth1Data == nil {
-		b.Eth1Data = new(Eth1Data)
-	}
-	if dst, err = b.Eth1Data.MarshalSSZTo(dst); err != nil {
-		return
+// UnmarshalSSZ ssz unmarshals the LightClientBootstrapDeneb object
+func (l *LightClientBootstrapDeneb) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 24788 {
+		return ssz.ErrSize
 	}
 
-	// Offset (9) 'Eth1DataVotes'
-	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.Eth1DataVotes) * 72
-
-	// Field (10) 'Eth1DepositIndex'
-	dst = ssz.MarshalUint64(dst, b.Eth1DepositIndex)
+	tail := buf
+	var o0 uint64
 
-	// Offset (11) 'Validators'
-	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.Validators) * 121
+	// Offset (0) 'Header'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
 
-	// Offset (12) 'Balances'
-	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.Balances) * 8
+	if o0 < 24788 {
+		return ssz.ErrInvalidVariableOffset
+	}
 
-	// Field (13) 'RandaoMixes'
-	if size := len(b.RandaoMixes); size != 65536 {
-		err = ssz.ErrVectorLengthFn("--.RandaoMixes", size, 65536)
-		return
+	// Field (1) 'CurrentSyncCommittee'
+	if l.CurrentSyncCommittee == nil {
+		l.CurrentSyncCommittee = new(SyncCommittee)
 	}
-	for ii := 0; ii < 65536; ii++ {
-		if size := len(b.RandaoMixes[ii]); size != 32 {
-			err = ssz.ErrBytesLengthFn("--.RandaoMixes[ii]", size, 32)
-			return
-		}
-		dst = append(dst, b.RandaoMixes[ii]...)
+	if err = l.CurrentSyncCommittee.UnmarshalSSZ(buf[4:24628]); err != nil {
+		return err
 	}
 
-	// Field (14) 'Slashings'
-	if size := len(b.Slashings); size != 8192 {
-		err = ssz.ErrVectorLengthFn("--.Slashings", size, 8192)
-		return
-	}
-	for ii := 0; ii < 8192; ii++ {
-		dst = ssz.MarshalUint64(dst, b.Slashings[ii])
+	// Field (2) 'CurrentSyncCommitteeBranch'
+	l.CurrentSyncCommitteeBranch = make([][]byte, 5)
+	for ii := 0; ii < 5; ii++ {
+		if cap(l.CurrentSyncCommitteeBranch[ii]) == 0 {
+			l.CurrentSyncCommitteeBranch[ii] = make([]byte, 0, len(buf[24628:24788][ii*32:(ii+1)*32]))
+		}
+		l.CurrentSyncCommitteeBranch[ii] = append(l.CurrentSyncCommitteeBranch[ii], buf[24628:24788][ii*32:(ii+1)*32]...)
 	}
 
-	// Offset (15) 'PreviousEpochParticipation'
-	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.PreviousEpochParticipation)
+	// Field (0) 'Header'
+	{
+		buf = tail[o0:]
+		if l.Header == nil {
+			l.Header = new(LightClientHeaderDeneb)
+		}
+		if err = l.Header.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+	return err
+}
 
-	// Offset (16) 'CurrentEpochParticipation'
-	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.CurrentEpochParticipation)
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientBootstrapDeneb object
+func (l *LightClientBootstrapDeneb) SizeSSZ() (size int) {
+	size = 24788
 
-	// Field (17) 'JustificationBits'
-	if size := len(b.JustificationBits); size != 1 {
-		err = ssz.ErrBytesLengthFn("--.JustificationBits", size, 1)
-		return
+	// Field (0) 'Header'
+	if l.Header == nil {
+		l.Header = new(LightClientHeaderDeneb)
 	}
-	dst = append(dst, b.JustificationBits...)
+	size += l.Header.SizeSSZ()
 
-	// Field (18) 'PreviousJustifiedCheckpoint'
-	if b.PreviousJustifiedCheckpoint == nil {
-		b.PreviousJustifiedCheckpoint = new(Checkpoint)
-	}
-	if dst, err = b.PreviousJustifiedCheckpoint.MarshalSSZTo(dst); err != nil {
-		return
-	}
+	return
+}
 
-	// Field (19) 'CurrentJustifiedCheckpoint'
-	if b.CurrentJustifiedCheckpoint == nil {
-		b.CurrentJustifiedCheckpoint = new(Checkpoint)
-	}
-	if dst, err = b.CurrentJustifiedCheckpoint.MarshalSSZTo(dst); err != nil {
-		return
-	}
+// HashTreeRoot ssz hashes the LightClientBootstrapDeneb object
+func (l *LightClientBootstrapDeneb) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
+}
 
-	// Field (20) 'FinalizedCheckpoint'
-	if b.FinalizedCheckpoint == nil {
-		b.FinalizedCheckpoint = new(Checkpoint)
-	}
-	if dst, err = b.FinalizedCheckpoint.MarshalSSZTo(dst); err != nil {
+// HashTreeRootWith ssz hashes the LightClientBootstrapDeneb object with a hasher
+func (l *LightClientBootstrapDeneb) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Header'
+	if err = l.Header.HashTreeRootWith(hh); err != nil {
 		return
 	}
 
-	// Offset (21) 'InactivityScores'
-	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.InactivityScores) * 8
-
-	// Field (22) 'CurrentSyncCommittee'
-	if b.CurrentSyncCommittee == nil {
-		b.CurrentSyncCommittee = new(SyncCommittee)
-	}
-	if dst, err = b.CurrentSyncCommittee.MarshalSSZTo(dst); err != nil {
+	// Field (1) 'CurrentSyncCommittee'
+	if err = l.CurrentSyncCommittee.HashTreeRootWith(hh); err != nil {
 		return
 	}
 
-	// Field (23) 'NextSyncCommittee'
-	if b.NextSyncCommittee == nil {
-		b.NextSyncCommittee = new(SyncCommittee)
-	}
-	if dst, err = b.NextSyncCommittee.MarshalSSZTo(dst); err != nil {
-		return
+	// Field (2) 'CurrentSyncCommitteeBranch'
+	{
+		if size := len(l.CurrentSyncCommitteeBranch); size != 5 {
+			err = ssz.ErrVectorLengthFn("--.CurrentSyncCommitteeBranch", size, 5)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range l.CurrentSyncCommitteeBranch {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
 	}
 
-	// Offset (24) 'LatestExecutionPayloadHeader'
-	dst = ssz.WriteOffset(dst, offset)
-	if b.LatestExecutionPayloadHeader == nil {
-		b.LatestExecutionPayloadHeader = new(v1.ExecutionPayloadHeaderCapella)
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
 	}
-	offset += b.LatestExecutionPayloadHeader.SizeSSZ()
+	return
+}
 
-	// Field (25) 'NextWithdrawalIndex'
-	dst = ssz.MarshalUint64(dst, b.NextWithdrawalIndex)
+// MarshalSSZ ssz marshals the LightClientUpdateCapella object
+func (l *LightClientUpdateCapella) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
+}
 
-	// Field (26) 'NextWithdrawalValidatorIndex'
-	dst = ssz.MarshalUint64(dst, uint64(b.NextWithdrawalValidatorIndex))
+// MarshalSSZTo ssz marshals the LightClientUpdateCapella object to a target array
+func (l *LightClientUpdateCapella) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(25152)
 
-	// Offset (27) 'HistoricalSummaries'
+	// Offset (0) 'AttestedHeader'
 	dst = ssz.WriteOffset(dst, offset)
-	offset += len(b.HistoricalSummaries) * 64
-
-	// Field (7) 'HistoricalRoots'
-	if size := len(b.HistoricalRoots); size > 16777216 {
-		err = ssz.ErrListTooBigFn("--.HistoricalRoots", size, 16777216)
-		return
-	}
-	for ii := 0; ii < len(b.HistoricalRoots); ii++ {
-		if size := len(b.HistoricalRoots[ii]); size != 32 {
-			err = ssz.ErrBytesLengthFn("--.HistoricalRoots[ii]", size, 32)
-			return
-		}
-		dst = append(dst, b.HistoricalRoots[ii]...)
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeaderCapella)
 	}
+	offset += l.AttestedHeader.SizeSSZ()
 
-	// Field (9) 'Eth1DataVotes'
-	if size := len(b.Eth1DataVotes); size > 2048 {
-		err = ssz.ErrListTooBigFn("--.Eth1DataVotes", size, 2048)
-		return
+	// Field (1) 'NextSyncCommittee'
+	if l.NextSyncCommittee == nil {
+		l.NextSyncCommittee = new(SyncCommittee)
 	}
-	for ii := 0; ii < len(b.Eth1DataVotes); ii++ {
-		if dst, err = b.Eth1DataVotes[ii].MarshalSSZTo(dst); err != nil {
-			return
-		}
+	if dst, err = l.NextSyncCommittee.MarshalSSZTo(dst); err != nil {
+		return
 	}
 
-	// Field (11) 'Validators'
-	if size := len(b.Validators); size > 1099511627776 {
-		err = ssz.ErrListTooBigFn("--.Validators", size, 1099511627776)
+	// Field (2) 'NextSyncCommitteeBranch'
+	if size := len(l.NextSyncCommitteeBranch); size != 5 {
+		err = ssz.ErrVectorLengthFn("--.NextSyncCommitteeBranch", size, 5)
 		return
 	}
-	for ii := 0; ii < len(b.Validators); ii++ {
-		if dst, err = b.Validators[ii].MarshalSSZTo(dst); err != nil {
+	for ii := 0; ii < 5; ii++ {
+		if size := len(l.NextSyncCommitteeBranch[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.NextSyncCommitteeBranch[ii]", size, 32)
 			return
 		}
+		dst = append(dst, l.NextSyncCommitteeBranch[ii]...)
 	}
 
-	// Field (12) 'Balances'
-	if size := len(b.Balances); size > 1099511627776 {
-		err = ssz.ErrListTooBigFn("--.Balances", size, 1099511627776)
-		return
-	}
-	for ii := 0; ii < len(b.Balances); ii++ {
-		dst = ssz.MarshalUint64(dst, b.Balances[ii])
-	}
-
-	// Field (15) 'PreviousEpochParticipation'
-	if size := len(b.PreviousEpochParticipation); size > 1099511627776 {
-		err = ssz.ErrBytesLengthFn("--.PreviousEpochParticipation", size, 1099511627776)
-		return
-	}
-	dst = append(dst, b.PreviousEpochParticipation...)
-
-	// Field (16) 'CurrentEpochParticipation'
-	if size := len(b.CurrentEpochParticipation); size > 1099511627776 {
-		err = ssz.ErrBytesLengthFn("--.CurrentEpochParticipation", size, 1099511627776)
-		return
+	// Offset (3) 'FinalizedHeader'
+	dst = ssz.WriteOffset(dst, offset)
+	if l.FinalizedHeader == nil {
+		l.FinalizedHeader = new(LightClientHeaderCapella)
 	}
-	dst = append(dst, b.CurrentEpochParticipation...)
+	offset += l.FinalizedHeader.SizeSSZ()
 
-	// Field (21) 'InactivityScores'
-	if size := len(b.InactivityScores); size > 1099511627776 {
-		err = ssz.ErrListTooBigFn("--.InactivityScores", size, 1099511627776)
+	// Field (4) 'FinalityBranch'
+	if size := len(l.FinalityBranch); size != 6 {
+		err = ssz.ErrVectorLengthFn("--.FinalityBranch", size, 6)
 		return
 	}
-	for ii := 0; ii < len(b.InactivityScores); ii++ {
-		dst = ssz.MarshalUint64(dst, b.InactivityScores[ii])
+	for ii := 0; ii < 6; ii++ {
+		if size := len(l.FinalityBranch[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.FinalityBranch[ii]", size, 32)
+			return
+		}
+		dst = append(dst, l.FinalityBranch[ii]...)
 	}
 
-	// Field (24) 'LatestExecutionPayloadHeader'
-	if dst, err = b.LatestExecutionPayloadHeader.MarshalSSZTo(dst); err != nil {
+	// Field (5) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if dst, err = l.SyncAggregate.MarshalSSZTo(dst); err != nil {
 		return
 	}
 
-	// Field (27) 'HistoricalSummaries'
-	if size := len(b.HistoricalSummaries); size > 16777216 {
-		err = ssz.ErrListTooBigFn("--.HistoricalSummaries", size, 16777216)
+	// Field (6) 'SignatureSlot'
+	dst = ssz.MarshalUint64(dst, uint64(l.SignatureSlot))
+
+	// Field (0) 'AttestedHeader'
+	if dst, err = l.AttestedHeader.MarshalSSZTo(dst); err != nil {
 		return
 	}
-	for ii := 0; ii < len(b.HistoricalSummaries); ii++ {
-		if dst, err = b.HistoricalSummaries[ii].MarshalSSZTo(dst); err != nil {
-			return
-		}
+
+	// Field (3) 'FinalizedHeader'
+	if dst, err = l.FinalizedHeader.MarshalSSZTo(dst); err != nil {
+		return
 	}
 
 	return
 }
 
-// UnmarshalSSZ ssz unmarshals the BeaconStateCapella object
-func (b *BeaconStateCapella) UnmarshalSSZ(buf []byte) error {
+// UnmarshalSSZ ssz unmarshals the LightClientUpdateCapella object
+func (l *LightClientUpdateCapella) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size < 2736653 {
+	if size < 25152 {
 		return ssz.ErrSize
 	}
 
 	tail := buf
-	var o7, o9, o11, o12, o15, o16, o21, o24, o27 uint64
-
-	// Field (0) 'GenesisTime'
-	b.GenesisTime = ssz.UnmarshallUint64(buf[0:8])
+	var o0, o3 uint64
 
-	// Field (1) 'GenesisValidatorsRoot'
-	if cap(b.GenesisValidatorsRoot) == 0 {
-		b.GenesisValidatorsRoot = make([]byte, 0, len(buf[8:40]))
+	// Offset (0) 'AttestedHeader'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
 	}
-	b.GenesisValidatorsRoot = append(b.GenesisValidatorsRoot, buf[8:40]...)
-
-	// Field (2) 'Slot'
-	b.Slot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[40:48]))
 
-	// Field (3) 'Fork'
-	if b.Fork == nil {
-		b.Fork = new(Fork)
-	}
-	if err = b.Fork.UnmarshalSSZ(buf[48:64]); err != nil {
-		return err
+	if o0 < 25152 {
+		return ssz.ErrInvalidVariableOffset
 	}
 
-	// Field (4) 'LatestBlockHeader'
-	if b.LatestBlockHeader == nil {
-		b.LatestBlockHeader = new(BeaconBlockHeader)
+	// Field (1) 'NextSyncCommittee'
+	if l.NextSyncCommittee == nil {
+		l.NextSyncCommittee = new(SyncCommittee)
 	}
-	if err = b.LatestBlockHeader.UnmarshalSSZ(buf[64:176]); err != nil {
+	if err = l.NextSyncCommittee.UnmarshalSSZ(buf[4:24628]); err != nil {
 		return err
 	}
 
-	// Field (5) 'BlockRoots'
-	b.BlockRoots = make([][]byte, 8192)
-	for ii := 0; ii < 8192; ii++ {
-		if cap(b.BlockRoots[ii]) == 0 {
-			b.BlockRoots[ii] = make([]byte, 0, len(buf[176:262320][ii*32:(ii+1)*32]))
+	// Field (2) 'NextSyncCommitteeBranch'
+	l.NextSyncCommitteeBranch = make([][]byte, 5)
+	for ii := 0; ii < 5; ii++ {
+		if cap(l.NextSyncCommitteeBranch[ii]) == 0 {
+			l.NextSyncCommitteeBranch[ii] = make([]byte, 0, len(buf[24628:24788][ii*32:(ii+1)*32]))
 		}
-		b.BlockRoots[ii] = append(b.BlockRoots[ii], buf[176:262320][ii*32:(ii+1)*32]...)
+		l.NextSyncCommitteeBranch[ii] = append(l.NextSyncCommitteeBranch[ii], buf[24628:24788][ii*32:(ii+1)*32]...)
 	}
 
-	// Field (6) 'StateRoots'
-	b.StateRoots = make([][]byte, 8192)
-	for ii := 0; ii < 8192; ii++ {
-		if cap(b.StateRoots[ii]) == 0 {
-			b.StateRoots[ii] = make([]byte, 0, len(buf[262320:524464][ii*32:(ii+1)*32]))
+	// Offset (3) 'FinalizedHeader'
+	if o3 = ssz.ReadOffset(buf[24788:24792]); o3 > size || o0 > o3 {
+		return ssz.ErrOffset
+	}
+
+	// Field (4) 'FinalityBranch'
+	l.FinalityBranch = make([][]byte, 6)
+	for ii := 0; ii < 6; ii++ {
+		if cap(l.FinalityBranch[ii]) == 0 {
+			l.FinalityBranch[ii] = make([]byte, 0, len(buf[24792:24984][ii*32:(ii+1)*32]))
 		}
-		b.StateRoots[ii] = append(b.StateRoots[ii], buf[262320:524464][ii*32:(ii+1)*32]...)
+		l.FinalityBranch[ii] = append(l.FinalityBranch[ii], buf[24792:24984][ii*32:(ii+1)*32]...)
 	}
 
-	// Offset (7) 'HistoricalRoots'
-	if o7 = ssz.ReadOffset(buf[524464:524468]); o7 > size {
-		return ssz.ErrOffset
+	// Field (5) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if err = l.SyncAggregate.UnmarshalSSZ(buf[24984:25144]); err != nil {
+		return err
 	}
 
-	if o7 < 2736653 {
-		return ssz.ErrInvalidVariableOffset
+	// Field (6) 'SignatureSlot'
+	l.SignatureSlot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[25144:25152]))
+
+	// Field (0) 'AttestedHeader'
+	{
+		buf = tail[o0:o3]
+		if l.AttestedHeader == nil {
+			l.AttestedHeader = new(LightClientHeaderCapella)
+		}
+		if err = l.AttestedHeader.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
 	}
 
-	// Field (8) 'Eth1Data'
-	if b.Eth1Data == nil {
-		b.Eth1Data = new(Eth1Data)
+	// Field (3) 'FinalizedHeader'
+	{
+		buf = tail[o3:]
+		if l.FinalizedHeader == nil {
+			l.FinalizedHeader = new(LightClientHeaderCapella)
+		}
+		if err = l.FinalizedHeader.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
 	}
-	if err = b.Eth1Data.UnmarshalSSZ(buf[524468:524540]); err != nil {
-		return err
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientUpdateCapella object
+func (l *LightClientUpdateCapella) SizeSSZ() (size int) {
+	size = 25152
+
+	// Field (0) 'AttestedHeader'
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeaderCapella)
 	}
+	size += l.AttestedHeader.SizeSSZ()
 
-	// Offset (9) 'Eth1DataVotes'
-	if o9 = ssz.ReadOffset(buf[524540:524544]); o9 > size || o7 > o9 {
-		return ssz.ErrOffset
+	// Field (3) 'FinalizedHeader'
+	if l.FinalizedHeader == nil {
+		l.FinalizedHeader = new(LightClientHeaderCapella)
 	}
+	size += l.FinalizedHeader.SizeSSZ()
 
-	// Field (10) 'Eth1DepositIndex'
-	b.Eth1DepositIndex = ssz.UnmarshallUint64(buf[524544:524552])
+	return
+}
 
-	// Offset (11) 'Validators'
-	if o11 = ssz.ReadOffset(buf[524552:524556]); o11 > size || o9 > o11 {
-		return ssz.ErrOffset
+// HashTreeRoot ssz hashes the LightClientUpdateCapella object
+func (l *LightClientUpdateCapella) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
+}
+
+// HashTreeRootWith ssz hashes the LightClientUpdateCapella object with a hasher
+func (l *LightClientUpdateCapella) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'AttestedHeader'
+	if err = l.AttestedHeader.HashTreeRootWith(hh); err != nil {
+		return
 	}
 
-	// Offset (12) 'Balances'
-	if o12 = ssz.ReadOffset(buf[524556:524560]); o12 > size || o11 > o12 {
-		return ssz.ErrOffset
+	// Field (1) 'NextSyncCommittee'
+	if err = l.NextSyncCommittee.HashTreeRootWith(hh); err != nil {
+		return
 	}
 
-	// Field (13) 'RandaoMixes'
-	b.RandaoMixes = make([][]byte, 65536)
-	for ii := 0; ii < 65536; ii++ {
-		if cap(b.RandaoMixes[ii]) == 0 {
-			b.RandaoMixes[ii] = make([]byte, 0, len(buf[524560:2621712][ii*32:(ii+1)*32]))
+	// Field (2) 'NextSyncCommitteeBranch'
+	{
+		if size := len(l.NextSyncCommitteeBranch); size != 5 {
+			err = ssz.ErrVectorLengthFn("--.NextSyncCommitteeBranch", size, 5)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range l.NextSyncCommitteeBranch {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
 		}
-		b.RandaoMixes[ii] = append(b.RandaoMixes[ii], buf[524560:2621712][ii*32:(ii+1)*32]...)
-	}
 
-	// Field (14) 'Slashings'
-	b.Slashings = ssz.ExtendUint64(b.Slashings, 8192)
-	for ii := 0; ii < 8192; ii++ {
-		b.Slashings[ii] = ssz.UnmarshallUint64(buf[2621712:2687248][ii*8 : (ii+1)*8])
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
 	}
 
-	// Offset (15) 'PreviousEpochParticipation'
-	if o15 = ssz.ReadOffset(buf[2687248:2687252]); o15 > size || o12 > o15 {
-		return ssz.ErrOffset
+	// Field (3) 'FinalizedHeader'
+	if err = l.FinalizedHeader.HashTreeRootWith(hh); err != nil {
+		return
 	}
 
-	// Offset (16) 'CurrentEpochParticipation'
-	if o16 = ssz.ReadOffset(buf[2687252:2687256]); o16 > size || o15 > o16 {
-		return ssz.ErrOffset
+	// Field (4) 'FinalityBranch'
+	{
+		if size := len(l.FinalityBranch); size != 6 {
+			err = ssz.ErrVectorLengthFn("--.FinalityBranch", size, 6)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range l.FinalityBranch {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+
+		if ssz.EnableVectorizedHTR {
+			hh.MerkleizeVectorizedHTR(subIndx)
+		} else {
+			hh.Merkleize(subIndx)
+		}
 	}
 
-	// Field (17) 'JustificationBits'
-	if cap(b.JustificationBits) == 0 {
-		b.JustificationBits = make([]byte, 0, len(buf[2687256:2687257]))
+	// Field (5) 'SyncAggregate'
+	if err = l.SyncAggregate.HashTreeRootWith(hh); err != nil {
+		return
 	}
-	b.JustificationBits = append(b.JustificationBits, buf[2687256:2687257]...)
 
-	// Field (18) 'PreviousJustifiedCheckpoint'
-	if b.PreviousJustifiedCheckpoint == nil {
-		b.PreviousJustifiedCheckpoint = new(Checkpoint)
+	// Field (6) 'SignatureSlot'
+	hh.PutUint64(uint64(l.SignatureSlot))
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
 	}
-	if err = b.PreviousJustifiedCheckpoint.UnmarshalSSZ(buf[2687257:2687297]); err != nil {
-		return err
+	return
+}
+
+// MarshalSSZ ssz marshals the LightClientUpdateDeneb object
+func (l *LightClientUpdateDeneb) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
+}
+
+// MarshalSSZTo ssz marshals the LightClientUpdateDeneb object to a target array
+func (l *LightClientUpdateDeneb) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(25152)
+
+	// Offset (0) 'AttestedHeader'
+	dst = ssz.WriteOffset(dst, offset)
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeaderDeneb)
 	}
+	offset += l.AttestedHeader.SizeSSZ()
 
-	// Field (19) 'CurrentJustifiedCheckpoint'
-	if b.CurrentJustifiedCheckpoint == nil {
-		b.CurrentJustifiedCheckpoint = new(Checkpoint)
+	// Field (1) 'NextSyncCommittee'
+	if l.NextSyncCommittee == nil {
+		l.NextSyncCommittee = new(SyncCommittee)
 	}
-	if err = b.CurrentJustifiedCheckpoint.UnmarshalSSZ(buf[2687297:2687337]); err != nil {
-		return err
+	if dst, err = l.NextSyncCommittee.MarshalSSZTo(dst); err != nil {
+		return
 	}
 
-	// Field (20) 'FinalizedCheckpoint'
-	if b.FinalizedCheckpoint == nil {
-		b.FinalizedCheckpoint = new(Checkpoint)
+	// Field (2) 'NextSyncCommitteeBranch'
+	if size := len(l.NextSyncCommitteeBranch); size != 5 {
+		err = ssz.ErrVectorLengthFn("--.NextSyncCommitteeBranch", size, 5)
+		return
 	}
-	if err = b.FinalizedCheckpoint.UnmarshalSSZ(buf[2687337:2687377]); err != nil {
-		return err
+	for ii := 0; ii < 5; ii++ {
+		if size := len(l.NextSyncCommitteeBranch[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.NextSyncCommitteeBranch[ii]", size, 32)
+			return
+		}
+		dst = append(dst, l.NextSyncCommitteeBranch[ii]...)
 	}
 
-	// Offset (21) 'InactivityScores'
-	if o21 = ssz.ReadOffset(buf[2687377:2687381]); o21 > size || o16 > o21 {
-		return ssz.ErrOffset
+	// Offset (3) 'FinalizedHeader'
+	dst = ssz.WriteOffset(dst, offset)
+	if l.FinalizedHeader == nil {
+		l.FinalizedHeader = new(LightClientHeaderDeneb)
 	}
+	offset += l.FinalizedHeader.SizeSSZ()
 
-	// Field (22) 'CurrentSyncCommittee'
-	if b.CurrentSyncCommittee == nil {
-		b.CurrentSyncCommittee = new(SyncCommittee)
+	// Field (4) 'FinalityBranch'
+	if size := len(l.FinalityBranch); size != 6 {
+		err = ssz.ErrVectorLengthFn("--.FinalityBranch", size, 6)
+		return
 	}
-	if err = b.CurrentSyncCommittee.UnmarshalSSZ(buf[2687381:2712005]); err != nil {
-		return err
+	for ii := 0; ii < 6; ii++ {
+		if size := len(l.FinalityBranch[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.FinalityBranch[ii]", size, 32)
+			return
+		}
+		dst = append(dst, l.FinalityBranch[ii]...)
 	}
 
-	// Field (23) 'NextSyncCommittee'
-	if b.NextSyncCommittee == nil {
-		b.NextSyncCommittee = new(SyncCommittee)
-	}
-	if err = b.NextSyncCommittee.UnmarshalSSZ(buf[2712005:2736629]); err != nil {
-		return err
+	// Field (5) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
 	}
-
-	// Offset (24) 'LatestExecutionPayloadHeader'
-	if o24 = ssz.ReadOffset(buf[2736629:2736633]); o24 > size || o21 > o24 {
-		return ssz.ErrOffset
+	if dst, err = l.SyncAggregate.MarshalSSZTo(dst); err != nil {
+		return
 	}
 
-	// Field (25) 'NextWithdrawalIndex'
-	b.NextWithdrawalIndex = ssz.UnmarshallUint64(buf[2736633:2736641])
-
-	// Field (26) 'NextWithdrawalValidatorIndex'
-	b.NextWithdrawalValidatorIndex = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.ValidatorIndex(ssz.UnmarshallUint64(buf[2736641:2736649]))
+	// Field (6) 'SignatureSlot'
+	dst = ssz.MarshalUint64(dst, uint64(l.SignatureSlot))
 
-	// Offset (27) 'HistoricalSummaries'
-	if o27 = ssz.ReadOffset(buf[2736649:2736653]); o27 > size || o24 > o27 {
-		return ssz.ErrOffset
+	// Field (0) 'AttestedHeader'
+	if dst, err = l.AttestedHeader.MarshalSSZTo(dst); err != nil {
+		return
 	}
 
-	// Field (7) 'HistoricalRoots'
-	{
-		buf = tail[o7:o9]
-		num, err := ssz.DivideInt2(len(buf), 32, 16777216)
-		if err != nil {
-			return err
-		}
-		b.HistoricalRoots = make([][]byte, num)
-		for ii := 0; ii < num; ii++ {
-			if cap(b.HistoricalRoots[ii]) == 0 {
-				b.HistoricalRoots[ii] = make([]byte, 0, len(buf[ii*32:(ii+1)*32]))
-			}
-			b.HistoricalRoots[ii] = append(b.HistoricalRoots[ii], buf[ii*32:(ii+1)*32]...)
-		}
+	// Field (3) 'FinalizedHeader'
+	if dst, err = l.FinalizedHeader.MarshalSSZTo(dst); err != nil {
+		return
 	}
 
-	// Field (9) 'Eth1DataVotes'
-	{
-		buf = tail[o9:o11]
-		num, err := ssz.DivideInt2(len(buf), 72, 2048)
-		if err != nil {
-			return err
-		}
-		b.Eth1DataVotes = make([]*Eth1Data, num)
-		for ii := 0; ii < num; ii++ {
-			if b.Eth1DataVotes[ii] == nil {
-				b.Eth1DataVotes[ii] = new(Eth1Data)
-			}
-			if err = b.Eth1DataVotes[ii].UnmarshalSSZ(buf[ii*72 : (ii+1)*72]); err != nil {
-				return err
-			}
-		}
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientUpdateDeneb object
+func (l *LightClientUpdateDeneb) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 25152 {
+		return ssz.ErrSize
 	}
 
-	// Field (11) 'Validators'
-	{
-		buf = tail[o11:o12]
-		num, err := ssz.DivideInt2(len(buf), 121, 1099511627776)
-		if err != nil {
-			return err
-		}
-		b.Validators = make([]*Validator, num)
-		for ii := 0; ii < num; ii++ {
-			if b.Validators[ii] == nil {
-				b.Validators[ii] = new(Validator)
-			}
-			if err = b.Validators[ii].UnmarshalSSZ(buf[ii*121 : (ii+1)*121]); err != nil {
-				return err
-			}
-		}
+	tail := buf
+	var o0, o3 uint64
+
+	// Offset (0) 'AttestedHeader'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
 	}
 
-	// Field (12) 'Balances'
-	{
-		buf = tail[o12:o15]
-		num, err := ssz.DivideInt2(len(buf), 8, 1099511627776)
-		if err != nil {
-			return err
-		}
-		b.Balances = ssz.ExtendUint64(b.Balances, num)
-		for ii := 0; ii < num; ii++ {
-			b.Balances[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
-		}
+	if o0 < 25152 {
+		return ssz.ErrInvalidVariableOffset
 	}
 
-	// Field (15) 'PreviousEpochParticipation'
-	{
-		buf = tail[o15:o16]
-		if len(buf) > 1099511627776 {
-			return ssz.ErrBytesLength
-		}
-		if cap(b.PreviousEpochParticipation) == 0 {
-			b.PreviousEpochParticipation = make([]byte, 0, len(buf))
-		}
-		b.PreviousEpochParticipation = append(b.PreviousEpochParticipation, buf...)
+	// Field (1) 'NextSyncCommittee'
+	if l.NextSyncCommittee == nil {
+		l.NextSyncCommittee = new(SyncCommittee)
+	}
+	if err = l.NextSyncCommittee.UnmarshalSSZ(buf[4:24628]); err != nil {
+		return err
 	}
 
-	// Field (16) 'CurrentEpochParticipation'
-	{
-		buf = tail[o16:o21]
-		if len(buf) > 1099511627776 {
-			return ssz.ErrBytesLength
-		}
-		if cap(b.CurrentEpochParticipation) == 0 {
-			b.CurrentEpochParticipation = make([]byte, 0, len(buf))
+	// Field (2) 'NextSyncCommitteeBranch'
+	l.NextSyncCommitteeBranch = make([][]byte, 5)
+	for ii := 0; ii < 5; ii++ {
+		if cap(l.NextSyncCommitteeBranch[ii]) == 0 {
+			l.NextSyncCommitteeBranch[ii] = make([]byte, 0, len(buf[24628:24788][ii*32:(ii+1)*32]))
 		}
-		b.CurrentEpochParticipation = append(b.CurrentEpochParticipation, buf...)
+		l.NextSyncCommitteeBranch[ii] = append(l.NextSyncCommitteeBranch[ii], buf[24628:24788][ii*32:(ii+1)*32]...)
 	}
 
-	// Field (21) 'InactivityScores'
-	{
-		buf = tail[o21:o24]
-		num, err := ssz.DivideInt2(len(buf), 8, 1099511627776)
-		if err != nil {
-			return err
-		}
-		b.InactivityScores = ssz.ExtendUint64(b.InactivityScores, num)
-		for ii := 0; ii < num; ii++ {
-			b.InactivityScores[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+	// Offset (3) 'FinalizedHeader'
+	if o3 = ssz.ReadOffset(buf[24788:24792]); o3 > size || o0 > o3 {
+		return ssz.ErrOffset
+	}
+
+	// Field (4) 'FinalityBranch'
+	l.FinalityBranch = make([][]byte, 6)
+	for ii := 0; ii < 6; ii++ {
+		if cap(l.FinalityBranch[ii]) == 0 {
+			l.FinalityBranch[ii] = make([]byte, 0, len(buf[24792:24984][ii*32:(ii+1)*32]))
 		}
+		l.FinalityBranch[ii] = append(l.FinalityBranch[ii], buf[24792:24984][ii*32:(ii+1)*32]...)
 	}
 
-	// Field (24) 'LatestExecutionPayloadHeader'
+	// Field (5) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if err = l.SyncAggregate.UnmarshalSSZ(buf[24984:25144]); err != nil {
+		return err
+	}
+
+	// Field (6) 'SignatureSlot'
+	l.SignatureSlot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[25144:25152]))
+
+	// Field (0) 'AttestedHeader'
 	{
-		buf = tail[o24:o27]
-		if b.LatestExecutionPayloadHeader == nil {
-			b.LatestExecutionPayloadHeader = new(v1.ExecutionPayloadHeaderCapella)
+		buf = tail[o0:o3]
+		if l.AttestedHeader == nil {
+			l.AttestedHeader = new(LightClientHeaderDeneb)
 		}
-		if err = b.LatestExecutionPayloadHeader.UnmarshalSSZ(buf); err != nil {
+		if err = l.AttestedHeader.UnmarshalSSZ(buf); err != nil {
 			return err
 		}
 	}
 
-	// Field (27) 'HistoricalSummaries'
+	// Field (3) 'FinalizedHeader'
 	{
-		buf = tail[o27:]
-		num, err := ssz.DivideInt2(len(buf), 64, 16777216)
-		if err != nil {
-			return err
+		buf = tail[o3:]
+		if l.FinalizedHeader == nil {
+			l.FinalizedHeader = new(LightClientHeaderDeneb)
 		}
-		b.HistoricalSummaries = make([]*HistoricalSummary, num)
-		for ii := 0; ii < num; ii++ {
-			if b.HistoricalSummaries[ii] == nil {
-				b.HistoricalSummaries[ii] = new(HistoricalSummary)
-			}
-			if err = b.HistoricalSummaries[ii].UnmarshalSSZ(buf[ii*64 : (ii+1)*64]); err != nil {
-				return err
-			}
+		if err = l.FinalizedHeader.UnmarshalSSZ(buf); err != nil {
+			return err
 		}
 	}
 	return err
 }
 
-// SizeSSZ returns the ssz encoded size in bytes for the BeaconStateCapella object
-func (b *BeaconStateCapella) SizeSSZ() (size int) {
-	size = 2736653
-
-	// Field (7) 'HistoricalRoots'
-	size += len(b.HistoricalRoots) * 32
-
-	// Field (9) 'Eth1DataVotes'
-	size += len(b.Eth1DataVotes) * 72
-
-	// Field (11) 'Validators'
-	size += len(b.Validators) * 121
-
-	// Field (12) 'Balances'
-	size += len(b.Balances) * 8
-
-	// Field (15) 'PreviousEpochParticipation'
-	size += len(b.PreviousEpochParticipation)
-
-	// Field (16) 'CurrentEpochParticipation'
-	size += len(b.CurrentEpochParticipation)
-
-	// Field (21) 'InactivityScores'
-	size += len(b.InactivityScores) * 8
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientUpdateDeneb object
+func (l *LightClientUpdateDeneb) SizeSSZ() (size int) {
+	size = 25152
 
-	// Field (24) 'LatestExecutionPayloadHeader'
-	if b.LatestExecutionPayloadHeader == nil {
-		b.LatestExecutionPayloadHeader = new(v1.ExecutionPayloadHeaderCapella)
+	// Field (0) 'AttestedHeader'
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeaderDeneb)
 	}
-	size += b.LatestExecutionPayloadHeader.SizeSSZ()
+	size += l.AttestedHeader.SizeSSZ()
 
-	// Field (27) 'HistoricalSummaries'
-	size += len(b.HistoricalSummaries) * 64
+	// Field (3) 'FinalizedHeader'
+	if l.FinalizedHeader == nil {
+		l.FinalizedHeader = new(LightClientHeaderDeneb)
+	}
+	size += l.FinalizedHeader.SizeSSZ()
 
 	return
 }
 
-// HashTreeRoot ssz hashes the BeaconStateCapella object
-func (b *BeaconStateCapella) HashTreeRoot() ([32]byte, error) {
-	return ssz.HashWithDefaultHasher(b)
+// HashTreeRoot ssz hashes the LightClientUpdateDeneb object
+func (l *LightClientUpdateDeneb) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
 }
 
-// HashTreeRootWith ssz hashes the BeaconStateCapella object with a hasher
-func (b *BeaconStateCapella) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+// HashTreeRootWith ssz hashes the LightClientUpdateDeneb object with a hasher
+func (l *LightClientUpdateDeneb) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	indx := hh.Index()
 
-	// Field (0) 'GenesisTime'
-	hh.PutUint64(b.GenesisTime)
-
-	// Field (1) 'GenesisValidatorsRoot'
-	if size := len(b.GenesisValidatorsRoot); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.GenesisValidatorsRoot", size, 32)
-		return
-	}
-	hh.PutBytes(b.GenesisValidatorsRoot)
-
-	// Field (2) 'Slot'
-	hh.PutUint64(uint64(b.Slot))
-
-	// Field (3) 'Fork'
-	if err = b.Fork.HashTreeRootWith(hh); err != nil {
+	// Field (0) 'AttestedHeader'
+	if err = l.AttestedHeader.HashTreeRootWith(hh); err != nil {
 		return
 	}
 
-	// Field (4) 'LatestBlockHeader'
-	if err = b.LatestBlockHeader.HashTreeRootWith(hh); err != nil {
+	// Field (1) 'NextSyncCommittee'
+	if err = l.NextSyncCommittee.HashTreeRootWith(hh); err != nil {
 		return
 	}
 
-	// Field (5) 'BlockRoots'
+	// Field (2) 'NextSyncCommitteeBranch'
 	{
-		if size := len(b.BlockRoots); size != 8192 {
-			err = ssz.ErrVectorLengthFn("--.BlockRoots", size, 8192)
+		if size := len(l.NextSyncCommitteeBranch); size != 5 {
+			err = ssz.ErrVectorLengthFn("--.NextSyncCommitteeBranch", size, 5)
 			return
 		}
 		subIndx := hh.Index()
-		for _, i := range b.BlockRoots {
+		for _, i := range l.NextSyncCommitteeBranch {
 			if len(i) != 32 {
 				err = ssz.ErrBytesLength
 				return
@@ -10879,14 +16741,19 @@ func (b *BeaconStateCapella) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 		}
 	}
 
-	// Field (6) 'StateRoots'
+	// Field (3) 'FinalizedHeader'
+	if err = l.FinalizedHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (4) 'FinalityBranch'
 	{
-		if size := len(b.StateRoots); size != 8192 {
-			err = ssz.ErrVectorLengthFn("--.StateRoots", size, 8192)
+		if size := len(l.FinalityBranch); size != 6 {
+			err = ssz.ErrVectorLengthFn("--.FinalityBranch", size, 6)
 			return
 		}
 		subIndx := hh.Index()
-		for _, i := range b.StateRoots {
+		for _, i := range l.FinalityBranch {
 			if len(i) != 32 {
 				err = ssz.ErrBytesLength
 				return
@@ -10901,14 +16768,198 @@ func (b *BeaconStateCapella) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 		}
 	}
 
-	// Field (7) 'HistoricalRoots'
+	// Field (5) 'SyncAggregate'
+	if err = l.SyncAggregate.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (6) 'SignatureSlot'
+	hh.PutUint64(uint64(l.SignatureSlot))
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the LightClientFinalityUpdateCapella object
+func (l *LightClientFinalityUpdateCapella) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
+}
+
+// MarshalSSZTo ssz marshals the LightClientFinalityUpdateCapella object to a target array
+func (l *LightClientFinalityUpdateCapella) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(368)
+
+	// Offset (0) 'AttestedHeader'
+	dst = ssz.WriteOffset(dst, offset)
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeaderCapella)
+	}
+	offset += l.AttestedHeader.SizeSSZ()
+
+	// Offset (1) 'FinalizedHeader'
+	dst = ssz.WriteOffset(dst, offset)
+	if l.FinalizedHeader == nil {
+		l.FinalizedHeader = new(LightClientHeaderCapella)
+	}
+	offset += l.FinalizedHeader.SizeSSZ()
+
+	// Field (2) 'FinalityBranch'
+	if size := len(l.FinalityBranch); size != 6 {
+		err = ssz.ErrVectorLengthFn("--.FinalityBranch", size, 6)
+		return
+	}
+	for ii := 0; ii < 6; ii++ {
+		if size := len(l.FinalityBranch[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.FinalityBranch[ii]", size, 32)
+			return
+		}
+		dst = append(dst, l.FinalityBranch[ii]...)
+	}
+
+	// Field (3) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if dst, err = l.SyncAggregate.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (4) 'SignatureSlot'
+	dst = ssz.MarshalUint64(dst, uint64(l.SignatureSlot))
+
+	// Field (0) 'AttestedHeader'
+	if dst, err = l.AttestedHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (1) 'FinalizedHeader'
+	if dst, err = l.FinalizedHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientFinalityUpdateCapella object
+func (l *LightClientFinalityUpdateCapella) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 368 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0, o1 uint64
+
+	// Offset (0) 'AttestedHeader'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 368 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (1) 'FinalizedHeader'
+	if o1 = ssz.ReadOffset(buf[4:8]); o1 > size || o0 > o1 {
+		return ssz.ErrOffset
+	}
+
+	// Field (2) 'FinalityBranch'
+	l.FinalityBranch = make([][]byte, 6)
+	for ii := 0; ii < 6; ii++ {
+		if cap(l.FinalityBranch[ii]) == 0 {
+			l.FinalityBranch[ii] = make([]byte, 0, len(buf[8:200][ii*32:(ii+1)*32]))
+		}
+		l.FinalityBranch[ii] = append(l.FinalityBranch[ii], buf[8:200][ii*32:(ii+1)*32]...)
+	}
+
+	// Field (3) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if err = l.SyncAggregate.UnmarshalSSZ(buf[200:360]); err != nil {
+		return err
+	}
+
+	// Field (4) 'SignatureSlot'
+	l.SignatureSlot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[360:368]))
+
+	// Field (0) 'AttestedHeader'
+	{
+		buf = tail[o0:o1]
+		if l.AttestedHeader == nil {
+			l.AttestedHeader = new(LightClientHeaderCapella)
+		}
+		if err = l.AttestedHeader.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+
+	// Field (1) 'FinalizedHeader'
+	{
+		buf = tail[o1:]
+		if l.FinalizedHeader == nil {
+			l.FinalizedHeader = new(LightClientHeaderCapella)
+		}
+		if err = l.FinalizedHeader.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientFinalityUpdateCapella object
+func (l *LightClientFinalityUpdateCapella) SizeSSZ() (size int) {
+	size = 368
+
+	// Field (0) 'AttestedHeader'
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeaderCapella)
+	}
+	size += l.AttestedHeader.SizeSSZ()
+
+	// Field (1) 'FinalizedHeader'
+	if l.FinalizedHeader == nil {
+		l.FinalizedHeader = new(LightClientHeaderCapella)
+	}
+	size += l.FinalizedHeader.SizeSSZ()
+
+	return
+}
+
+// HashTreeRoot ssz hashes the LightClientFinalityUpdateCapella object
+func (l *LightClientFinalityUpdateCapella) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
+}
+
+// HashTreeRootWith ssz hashes the LightClientFinalityUpdateCapella object with a hasher
+func (l *LightClientFinalityUpdateCapella) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'AttestedHeader'
+	if err = l.AttestedHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (1) 'FinalizedHeader'
+	if err = l.FinalizedHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (2) 'FinalityBranch'
 	{
-		if size := len(b.HistoricalRoots); size > 16777216 {
-			err = ssz.ErrListTooBigFn("--.HistoricalRoots", size, 16777216)
+		if size := len(l.FinalityBranch); size != 6 {
+			err = ssz.ErrVectorLengthFn("--.FinalityBranch", size, 6)
 			return
 		}
 		subIndx := hh.Index()
-		for _, i := range b.HistoricalRoots {
+		for _, i := range l.FinalityBranch {
 			if len(i) != 32 {
 				err = ssz.ErrBytesLength
 				return
@@ -10916,90 +16967,205 @@ func (b *BeaconStateCapella) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 			hh.Append(i)
 		}
 
-		numItems := uint64(len(b.HistoricalRoots))
 		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(16777216, numItems, 32))
+			hh.MerkleizeVectorizedHTR(subIndx)
 		} else {
-			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(16777216, numItems, 32))
+			hh.Merkleize(subIndx)
 		}
 	}
 
-	// Field (8) 'Eth1Data'
-	if err = b.Eth1Data.HashTreeRootWith(hh); err != nil {
+	// Field (3) 'SyncAggregate'
+	if err = l.SyncAggregate.HashTreeRootWith(hh); err != nil {
 		return
 	}
 
-	// Field (9) 'Eth1DataVotes'
-	{
-		subIndx := hh.Index()
-		num := uint64(len(b.Eth1DataVotes))
-		if num > 2048 {
-			err = ssz.ErrIncorrectListSize
+	// Field (4) 'SignatureSlot'
+	hh.PutUint64(uint64(l.SignatureSlot))
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
+// MarshalSSZ ssz marshals the LightClientFinalityUpdateDeneb object
+func (l *LightClientFinalityUpdateDeneb) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
+}
+
+// MarshalSSZTo ssz marshals the LightClientFinalityUpdateDeneb object to a target array
+func (l *LightClientFinalityUpdateDeneb) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(368)
+
+	// Offset (0) 'AttestedHeader'
+	dst = ssz.WriteOffset(dst, offset)
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeaderDeneb)
+	}
+	offset += l.AttestedHeader.SizeSSZ()
+
+	// Offset (1) 'FinalizedHeader'
+	dst = ssz.WriteOffset(dst, offset)
+	if l.FinalizedHeader == nil {
+		l.FinalizedHeader = new(LightClientHeaderDeneb)
+	}
+	offset += l.FinalizedHeader.SizeSSZ()
+
+	// Field (2) 'FinalityBranch'
+	if size := len(l.FinalityBranch); size != 6 {
+		err = ssz.ErrVectorLengthFn("--.FinalityBranch", size, 6)
+		return
+	}
+	for ii := 0; ii < 6; ii++ {
+		if size := len(l.FinalityBranch[ii]); size != 32 {
+			err = ssz.ErrBytesLengthFn("--.FinalityBranch[ii]", size, 32)
 			return
 		}
-		for _, elem := range b.Eth1DataVotes {
-			if err = elem.HashTreeRootWith(hh); err != nil {
-				return
-			}
-		}
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 2048)
-		} else {
-			hh.MerkleizeWithMixin(subIndx, num, 2048)
+		dst = append(dst, l.FinalityBranch[ii]...)
+	}
+
+	// Field (3) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if dst, err = l.SyncAggregate.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (4) 'SignatureSlot'
+	dst = ssz.MarshalUint64(dst, uint64(l.SignatureSlot))
+
+	// Field (0) 'AttestedHeader'
+	if dst, err = l.AttestedHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (1) 'FinalizedHeader'
+	if dst, err = l.FinalizedHeader.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientFinalityUpdateDeneb object
+func (l *LightClientFinalityUpdateDeneb) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 368 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0, o1 uint64
+
+	// Offset (0) 'AttestedHeader'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 368 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (1) 'FinalizedHeader'
+	if o1 = ssz.ReadOffset(buf[4:8]); o1 > size || o0 > o1 {
+		return ssz.ErrOffset
+	}
+
+	// Field (2) 'FinalityBranch'
+	l.FinalityBranch = make([][]byte, 6)
+	for ii := 0; ii < 6; ii++ {
+		if cap(l.FinalityBranch[ii]) == 0 {
+			l.FinalityBranch[ii] = make([]byte, 0, len(buf[8:200][ii*32:(ii+1)*32]))
 		}
+		l.FinalityBranch[ii] = append(l.FinalityBranch[ii], buf[8:200][ii*32:(ii+1)*32]...)
 	}
 
-	// Field (10) 'Eth1DepositIndex'
-	hh.PutUint64(b.Eth1DepositIndex)
+	// Field (3) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if err = l.SyncAggregate.UnmarshalSSZ(buf[200:360]); err != nil {
+		return err
+	}
 
-	// Field (11) 'Validators'
+	// Field (4) 'SignatureSlot'
+	l.SignatureSlot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[360:368]))
+
+	// Field (0) 'AttestedHeader'
 	{
-		subIndx := hh.Index()
-		num := uint64(len(b.Validators))
-		if num > 1099511627776 {
-			err = ssz.ErrIncorrectListSize
-			return
-		}
-		for _, elem := range b.Validators {
-			if err = elem.HashTreeRootWith(hh); err != nil {
-				return
-			}
+		buf = tail[o0:o1]
+		if l.AttestedHeader == nil {
+			l.AttestedHeader = new(LightClientHeaderDeneb)
 		}
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 1099511627776)
-		} else {
-			hh.MerkleizeWithMixin(subIndx, num, 1099511627776)
+		if err = l.AttestedHeader.UnmarshalSSZ(buf); err != nil {
+			return err
 		}
 	}
 
-	// Field (12) 'Balances'
+	// Field (1) 'FinalizedHeader'
 	{
-		if size := len(b.Balances); size > 1099511627776 {
-			err = ssz.ErrListTooBigFn("--.Balances", size, 1099511627776)
-			return
+		buf = tail[o1:]
+		if l.FinalizedHeader == nil {
+			l.FinalizedHeader = new(LightClientHeaderDeneb)
 		}
-		subIndx := hh.Index()
-		for _, i := range b.Balances {
-			hh.AppendUint64(i)
+		if err = l.FinalizedHeader.UnmarshalSSZ(buf); err != nil {
+			return err
 		}
-		hh.FillUpTo32()
+	}
+	return err
+}
 
-		numItems := uint64(len(b.Balances))
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
-		} else {
-			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
-		}
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientFinalityUpdateDeneb object
+func (l *LightClientFinalityUpdateDeneb) SizeSSZ() (size int) {
+	size = 368
+
+	// Field (0) 'AttestedHeader'
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeaderDeneb)
 	}
+	size += l.AttestedHeader.SizeSSZ()
 
-	// Field (13) 'RandaoMixes'
+	// Field (1) 'FinalizedHeader'
+	if l.FinalizedHeader == nil {
+		l.FinalizedHeader = new(LightClientHeaderDeneb)
+	}
+	size += l.FinalizedHeader.SizeSSZ()
+
+	return
+}
+
+// HashTreeRoot ssz hashes the LightClientFinalityUpdateDeneb object
+func (l *LightClientFinalityUpdateDeneb) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
+}
+
+// HashTreeRootWith ssz hashes the LightClientFinalityUpdateDeneb object with a hasher
+func (l *LightClientFinalityUpdateDeneb) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'AttestedHeader'
+	if err = l.AttestedHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (1) 'FinalizedHeader'
+	if err = l.FinalizedHeader.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (2) 'FinalityBranch'
 	{
-		if size := len(b.RandaoMixes); size != 65536 {
-			err = ssz.ErrVectorLengthFn("--.RandaoMixes", size, 65536)
+		if size := len(l.FinalityBranch); size != 6 {
+			err = ssz.ErrVectorLengthFn("--.FinalityBranch", size, 6)
 			return
 		}
 		subIndx := hh.Index()
-		for _, i := range b.RandaoMixes {
+		for _, i := range l.FinalityBranch {
 			if len(i) != 32 {
 				err = ssz.ErrBytesLength
 				return
@@ -11014,138 +17180,136 @@ func (b *BeaconStateCapella) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 		}
 	}
 
-	// Field (14) 'Slashings'
-	{
-		if size := len(b.Slashings); size != 8192 {
-			err = ssz.ErrVectorLengthFn("--.Slashings", size, 8192)
-			return
-		}
-		subIndx := hh.Index()
-		for _, i := range b.Slashings {
-			hh.AppendUint64(i)
-		}
-
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeVectorizedHTR(subIndx)
-		} else {
-			hh.Merkleize(subIndx)
-		}
+	// Field (3) 'SyncAggregate'
+	if err = l.SyncAggregate.HashTreeRootWith(hh); err != nil {
+		return
 	}
 
-	// Field (15) 'PreviousEpochParticipation'
-	{
-		elemIndx := hh.Index()
-		byteLen := uint64(len(b.PreviousEpochParticipation))
-		if byteLen > 1099511627776 {
-			err = ssz.ErrIncorrectListSize
-			return
-		}
-		hh.PutBytes(b.PreviousEpochParticipation)
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(elemIndx, byteLen, (1099511627776+31)/32)
-		} else {
-			hh.MerkleizeWithMixin(elemIndx, byteLen, (1099511627776+31)/32)
-		}
+	// Field (4) 'SignatureSlot'
+	hh.PutUint64(uint64(l.SignatureSlot))
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
 	}
+	return
+}
 
-	// Field (16) 'CurrentEpochParticipation'
-	{
-		elemIndx := hh.Index()
-		byteLen := uint64(len(b.CurrentEpochParticipation))
-		if byteLen > 1099511627776 {
-			err = ssz.ErrIncorrectListSize
-			return
-		}
-		hh.PutBytes(b.CurrentEpochParticipation)
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(elemIndx, byteLen, (1099511627776+31)/32)
-		} else {
-			hh.MerkleizeWithMixin(elemIndx, byteLen, (1099511627776+31)/32)
-		}
+// MarshalSSZ ssz marshals the LightClientOptimisticUpdateCapella object
+func (l *LightClientOptimisticUpdateCapella) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
+}
+
+// MarshalSSZTo ssz marshals the LightClientOptimisticUpdateCapella object to a target array
+func (l *LightClientOptimisticUpdateCapella) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(172)
+
+	// Offset (0) 'AttestedHeader'
+	dst = ssz.WriteOffset(dst, offset)
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeaderCapella)
 	}
+	offset += l.AttestedHeader.SizeSSZ()
 
-	// Field (17) 'JustificationBits'
-	if size := len(b.JustificationBits); size != 1 {
-		err = ssz.ErrBytesLengthFn("--.JustificationBits", size, 1)
+	// Field (1) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if dst, err = l.SyncAggregate.MarshalSSZTo(dst); err != nil {
 		return
 	}
-	hh.PutBytes(b.JustificationBits)
 
-	// Field (18) 'PreviousJustifiedCheckpoint'
-	if err = b.PreviousJustifiedCheckpoint.HashTreeRootWith(hh); err != nil {
+	// Field (2) 'SignatureSlot'
+	dst = ssz.MarshalUint64(dst, uint64(l.SignatureSlot))
+
+	// Field (0) 'AttestedHeader'
+	if dst, err = l.AttestedHeader.MarshalSSZTo(dst); err != nil {
 		return
 	}
 
-	// Field (19) 'CurrentJustifiedCheckpoint'
-	if err = b.CurrentJustifiedCheckpoint.HashTreeRootWith(hh); err != nil {
-		return
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientOptimisticUpdateCapella object
+func (l *LightClientOptimisticUpdateCapella) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 172 {
+		return ssz.ErrSize
 	}
 
-	// Field (20) 'FinalizedCheckpoint'
-	if err = b.FinalizedCheckpoint.HashTreeRootWith(hh); err != nil {
-		return
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'AttestedHeader'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
 	}
 
-	// Field (21) 'InactivityScores'
+	if o0 < 172 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (1) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if err = l.SyncAggregate.UnmarshalSSZ(buf[4:164]); err != nil {
+		return err
+	}
+
+	// Field (2) 'SignatureSlot'
+	l.SignatureSlot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[164:172]))
+
+	// Field (0) 'AttestedHeader'
 	{
-		if size := len(b.InactivityScores); size > 1099511627776 {
-			err = ssz.ErrListTooBigFn("--.InactivityScores", size, 1099511627776)
-			return
-		}
-		subIndx := hh.Index()
-		for _, i := range b.InactivityScores {
-			hh.AppendUint64(i)
+		buf = tail[o0:]
+		if l.AttestedHeader == nil {
+			l.AttestedHeader = new(LightClientHeaderCapella)
 		}
-		hh.FillUpTo32()
-
-		numItems := uint64(len(b.InactivityScores))
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
-		} else {
-			hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(1099511627776, numItems, 8))
+		if err = l.AttestedHeader.UnmarshalSSZ(buf); err != nil {
+			return err
 		}
 	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientOptimisticUpdateCapella object
+func (l *LightClientOptimisticUpdateCapella) SizeSSZ() (size int) {
+	size = 172
 
-	// Field (22) 'CurrentSyncCommittee'
-	if err = b.CurrentSyncCommittee.HashTreeRootWith(hh); err != nil {
-		return
+	// Field (0) 'AttestedHeader'
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeaderCapella)
 	}
+	size += l.AttestedHeader.SizeSSZ()
 
-	// Field (23) 'NextSyncCommittee'
-	if err = b.NextSyncCommittee.HashTreeRootWith(hh); err != nil {
+	return
+}
+
+// HashTreeRoot ssz hashes the LightClientOptimisticUpdateCapella object
+func (l *LightClientOptimisticUpdateCapella) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
+}
+
+// HashTreeRootWith ssz hashes the LightClientOptimisticUpdateCapella object with a hasher
+func (l *LightClientOptimisticUpdateCapella) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'AttestedHeader'
+	if err = l.AttestedHeader.HashTreeRootWith(hh); err != nil {
 		return
 	}
 
-	// Field (24) 'LatestExecutionPayloadHeader'
-	if err = b.LatestExecutionPayloadHeader.HashTreeRootWith(hh); err != nil {
+	// Field (1) 'SyncAggregate'
+	if err = l.SyncAggregate.HashTreeRootWith(hh); err != nil {
 		return
 	}
 
-	// Field (25) 'NextWithdrawalIndex'
-	hh.PutUint64(b.NextWithdrawalIndex)
-
-	// Field (26) 'NextWithdrawalValidatorIndex'
-	hh.PutUint64(uint64(b.NextWithdrawalValidatorIndex))
-
-	// Field (27) 'HistoricalSummaries'
-	{
-		subIndx := hh.Index()
-		num := uint64(len(b.HistoricalSummaries))
-		if num > 16777216 {
-			err = ssz.ErrIncorrectListSize
-			return
-		}
-		for _, elem := range b.HistoricalSummaries {
-			if err = elem.HashTreeRootWith(hh); err != nil {
-				return
-			}
-		}
-		if ssz.EnableVectorizedHTR {
-			hh.MerkleizeWithMixinVectorizedHTR(subIndx, num, 16777216)
-		} else {
-			hh.MerkleizeWithMixin(subIndx, num, 16777216)
-		}
-	}
+	// Field (2) 'SignatureSlot'
+	hh.PutUint64(uint64(l.SignatureSlot))
 
 	if ssz.EnableVectorizedHTR {
 		hh.MerkleizeVectorizedHTR(indx)
@@ -11155,103 +17319,120 @@ func (b *BeaconStateCapella) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	return
 }
 
-// MarshalSSZ ssz marshals the PowBlock object
-func (p *PowBlock) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(p)
+// MarshalSSZ ssz marshals the LightClientOptimisticUpdateDeneb object
+func (l *LightClientOptimisticUpdateDeneb) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
 }
 
-// MarshalSSZTo ssz marshals the PowBlock object to a target array
-func (p *PowBlock) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+// MarshalSSZTo ssz marshals the LightClientOptimisticUpdateDeneb object to a target array
+func (l *LightClientOptimisticUpdateDeneb) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
+	offset := int(172)
 
-	// Field (0) 'BlockHash'
-	if size := len(p.BlockHash); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.BlockHash", size, 32)
-		return
+	// Offset (0) 'AttestedHeader'
+	dst = ssz.WriteOffset(dst, offset)
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeaderDeneb)
 	}
-	dst = append(dst, p.BlockHash...)
+	offset += l.AttestedHeader.SizeSSZ()
 
-	// Field (1) 'ParentHash'
-	if size := len(p.ParentHash); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.ParentHash", size, 32)
+	// Field (1) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if dst, err = l.SyncAggregate.MarshalSSZTo(dst); err != nil {
 		return
 	}
-	dst = append(dst, p.ParentHash...)
 
-	// Field (2) 'TotalDifficulty'
-	if size := len(p.TotalDifficulty); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.TotalDifficulty", size, 32)
+	// Field (2) 'SignatureSlot'
+	dst = ssz.MarshalUint64(dst, uint64(l.SignatureSlot))
+
+	// Field (0) 'AttestedHeader'
+	if dst, err = l.AttestedHeader.MarshalSSZTo(dst); err != nil {
 		return
 	}
-	dst = append(dst, p.TotalDifficulty...)
 
 	return
 }
 
-// UnmarshalSSZ ssz unmarshals the PowBlock object
-func (p *PowBlock) UnmarshalSSZ(buf []byte) error {
+// UnmarshalSSZ ssz unmarshals the LightClientOptimisticUpdateDeneb object
+func (l *LightClientOptimisticUpdateDeneb) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size != 96 {
+	if size < 172 {
 		return ssz.ErrSize
 	}
 
-	// Field (0) 'BlockHash'
-	if cap(p.BlockHash) == 0 {
-		p.BlockHash = make([]byte, 0, len(buf[0:32]))
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'AttestedHeader'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
 	}
-	p.BlockHash = append(p.BlockHash, buf[0:32]...)
 
-	// Field (1) 'ParentHash'
-	if cap(p.ParentHash) == 0 {
-		p.ParentHash = make([]byte, 0, len(buf[32:64]))
+	if o0 < 172 {
+		return ssz.ErrInvalidVariableOffset
 	}
-	p.ParentHash = append(p.ParentHash, buf[32:64]...)
 
-	// Field (2) 'TotalDifficulty'
-	if cap(p.TotalDifficulty) == 0 {
-		p.TotalDifficulty = make([]byte, 0, len(buf[64:96]))
+	// Field (1) 'SyncAggregate'
+	if l.SyncAggregate == nil {
+		l.SyncAggregate = new(SyncAggregate)
+	}
+	if err = l.SyncAggregate.UnmarshalSSZ(buf[4:164]); err != nil {
+		return err
 	}
-	p.TotalDifficulty = append(p.TotalDifficulty, buf[64:96]...)
 
+	// Field (2) 'SignatureSlot'
+	l.SignatureSlot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[164:172]))
+
+	// Field (0) 'AttestedHeader'
+	{
+		buf = tail[o0:]
+		if l.AttestedHeader == nil {
+			l.AttestedHeader = new(LightClientHeaderDeneb)
+		}
+		if err = l.AttestedHeader.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
 	return err
 }
 
-// SizeSSZ returns the ssz encoded size in bytes for the PowBlock object
-func (p *PowBlock) SizeSSZ() (size int) {
-	size = 96
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientOptimisticUpdateDeneb object
+func (l *LightClientOptimisticUpdateDeneb) SizeSSZ() (size int) {
+	size = 172
+
+	// Field (0) 'AttestedHeader'
+	if l.AttestedHeader == nil {
+		l.AttestedHeader = new(LightClientHeaderDeneb)
+	}
+	size += l.AttestedHeader.SizeSSZ()
+
 	return
 }
 
-// HashTreeRoot ssz hashes the PowBlock object
-func (p *PowBlock) HashTreeRoot() ([32]byte, error) {
-	return ssz.HashWithDefaultHasher(p)
+// HashTreeRoot ssz hashes the LightClientOptimisticUpdateDeneb object
+func (l *LightClientOptimisticUpdateDeneb) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
 }
 
-// HashTreeRootWith ssz hashes the PowBlock object with a hasher
-func (p *PowBlock) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+// HashTreeRootWith ssz hashes the LightClientOptimisticUpdateDeneb object with a hasher
+func (l *LightClientOptimisticUpdateDeneb) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	indx := hh.Index()
 
-	// Field (0) 'BlockHash'
-	if size := len(p.BlockHash); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.BlockHash", size, 32)
+	// Field (0) 'AttestedHeader'
+	if err = l.AttestedHeader.HashTreeRootWith(hh); err != nil {
 		return
 	}
-	hh.PutBytes(p.BlockHash)
 
-	// Field (1) 'ParentHash'
-	if size := len(p.ParentHash); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.ParentHash", size, 32)
+	// Field (1) 'SyncAggregate'
+	if err = l.SyncAggregate.HashTreeRootWith(hh); err != nil {
 		return
 	}
-	hh.PutBytes(p.ParentHash)
 
-	// Field (2) 'TotalDifficulty'
-	if size := len(p.TotalDifficulty); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.TotalDifficulty", size, 32)
-		return
-	}
-	hh.PutBytes(p.TotalDifficulty)
+	// Field (2) 'SignatureSlot'
+	hh.PutUint64(uint64(l.SignatureSlot))
 
 	if ssz.EnableVectorizedHTR {
 		hh.MerkleizeVectorizedHTR(indx)
@@ -11261,83 +17442,127 @@ func (p *PowBlock) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	return
 }
 
-// MarshalSSZ ssz marshals the HistoricalSummary object
-func (h *HistoricalSummary) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(h)
+// MarshalSSZ ssz marshals the LightClientBootstrapRequest object
+func (l *LightClientBootstrapRequest) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
 }
 
-// MarshalSSZTo ssz marshals the HistoricalSummary object to a target array
-func (h *HistoricalSummary) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+// MarshalSSZTo ssz marshals the LightClientBootstrapRequest object to a target array
+func (l *LightClientBootstrapRequest) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
 
-	// Field (0) 'BlockSummaryRoot'
-	if size := len(h.BlockSummaryRoot); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.BlockSummaryRoot", size, 32)
-		return
-	}
-	dst = append(dst, h.BlockSummaryRoot...)
-
-	// Field (1) 'StateSummaryRoot'
-	if size := len(h.StateSummaryRoot); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.StateSummaryRoot", size, 32)
+	// Field (0) 'BlockRoot'
+	if size := len(l.BlockRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.BlockRoot", size, 32)
 		return
 	}
-	dst = append(dst, h.StateSummaryRoot...)
+	dst = append(dst, l.BlockRoot...)
 
 	return
 }
 
-// UnmarshalSSZ ssz unmarshals the HistoricalSummary object
-func (h *HistoricalSummary) UnmarshalSSZ(buf []byte) error {
+// UnmarshalSSZ ssz unmarshals the LightClientBootstrapRequest object
+func (l *LightClientBootstrapRequest) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size != 64 {
+	if size != 32 {
 		return ssz.ErrSize
 	}
 
-	// Field (0) 'BlockSummaryRoot'
-	if cap(h.BlockSummaryRoot) == 0 {
-		h.BlockSummaryRoot = make([]byte, 0, len(buf[0:32]))
-	}
-	h.BlockSummaryRoot = append(h.BlockSummaryRoot, buf[0:32]...)
-
-	// Field (1) 'StateSummaryRoot'
-	if cap(h.StateSummaryRoot) == 0 {
-		h.StateSummaryRoot = make([]byte, 0, len(buf[32:64]))
+	// Field (0) 'BlockRoot'
+	if cap(l.BlockRoot) == 0 {
+		l.BlockRoot = make([]byte, 0, len(buf[0:32]))
 	}
-	h.StateSummaryRoot = append(h.StateSummaryRoot, buf[32:64]...)
+	l.BlockRoot = append(l.BlockRoot, buf[0:32]...)
 
 	return err
 }
 
-// SizeSSZ returns the ssz encoded size in bytes for the HistoricalSummary object
-func (h *HistoricalSummary) SizeSSZ() (size int) {
-	size = 64
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientBootstrapRequest object
+func (l *LightClientBootstrapRequest) SizeSSZ() (size int) {
+	size = 32
 	return
 }
 
-// HashTreeRoot ssz hashes the HistoricalSummary object
-func (h *HistoricalSummary) HashTreeRoot() ([32]byte, error) {
-	return ssz.HashWithDefaultHasher(h)
+// HashTreeRoot ssz hashes the LightClientBootstrapRequest object
+func (l *LightClientBootstrapRequest) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
 }
 
-// HashTreeRootWith ssz hashes the HistoricalSummary object with a hasher
-func (h *HistoricalSummary) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+// HashTreeRootWith ssz hashes the LightClientBootstrapRequest object with a hasher
+func (l *LightClientBootstrapRequest) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	indx := hh.Index()
 
-	// Field (0) 'BlockSummaryRoot'
-	if size := len(h.BlockSummaryRoot); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.BlockSummaryRoot", size, 32)
+	// Field (0) 'BlockRoot'
+	if size := len(l.BlockRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("--.BlockRoot", size, 32)
 		return
 	}
-	hh.PutBytes(h.BlockSummaryRoot)
+	hh.PutBytes(l.BlockRoot)
 
-	// Field (1) 'StateSummaryRoot'
-	if size := len(h.StateSummaryRoot); size != 32 {
-		err = ssz.ErrBytesLengthFn("--.StateSummaryRoot", size, 32)
-		return
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
 	}
-	hh.PutBytes(h.StateSummaryRoot)
+	return
+}
+
+// MarshalSSZ ssz marshals the LightClientUpdatesByRangeRequest object
+func (l *LightClientUpdatesByRangeRequest) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(l)
+}
+
+// MarshalSSZTo ssz marshals the LightClientUpdatesByRangeRequest object to a target array
+func (l *LightClientUpdatesByRangeRequest) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'StartPeriod'
+	dst = ssz.MarshalUint64(dst, l.StartPeriod)
+
+	// Field (1) 'Count'
+	dst = ssz.MarshalUint64(dst, l.Count)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientUpdatesByRangeRequest object
+func (l *LightClientUpdatesByRangeRequest) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 16 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'StartPeriod'
+	l.StartPeriod = ssz.UnmarshallUint64(buf[0:8])
+
+	// Field (1) 'Count'
+	l.Count = ssz.UnmarshallUint64(buf[8:16])
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientUpdatesByRangeRequest object
+func (l *LightClientUpdatesByRangeRequest) SizeSSZ() (size int) {
+	size = 16
+	return
+}
+
+// HashTreeRoot ssz hashes the LightClientUpdatesByRangeRequest object
+func (l *LightClientUpdatesByRangeRequest) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(l)
+}
+
+// HashTreeRootWith ssz hashes the LightClientUpdatesByRangeRequest object with a hasher
+func (l *LightClientUpdatesByRangeRequest) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'StartPeriod'
+	hh.PutUint64(l.StartPeriod)
+
+	// Field (1) 'Count'
+	hh.PutUint64(l.Count)
 
 	if ssz.EnableVectorizedHTR {
 		hh.MerkleizeVectorizedHTR(indx)
@@ -11544,6 +17769,70 @@ func (b *BeaconBlocksByRangeRequest) HashTreeRootWith(hh *ssz.Hasher) (err error
 	return
 }
 
+// MarshalSSZ ssz marshals the BlobSidecarsByRangeRequest object
+func (b *BlobSidecarsByRangeRequest) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
+}
+
+// MarshalSSZTo ssz marshals the BlobSidecarsByRangeRequest object to a target array
+func (b *BlobSidecarsByRangeRequest) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'StartSlot'
+	dst = ssz.MarshalUint64(dst, uint64(b.StartSlot))
+
+	// Field (1) 'Count'
+	dst = ssz.MarshalUint64(dst, b.Count)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the BlobSidecarsByRangeRequest object
+func (b *BlobSidecarsByRangeRequest) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 16 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'StartSlot'
+	b.StartSlot = github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(ssz.UnmarshallUint64(buf[0:8]))
+
+	// Field (1) 'Count'
+	b.Count = ssz.UnmarshallUint64(buf[8:16])
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the BlobSidecarsByRangeRequest object
+func (b *BlobSidecarsByRangeRequest) SizeSSZ() (size int) {
+	size = 16
+	return
+}
+
+// HashTreeRoot ssz hashes the BlobSidecarsByRangeRequest object
+func (b *BlobSidecarsByRangeRequest) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
+}
+
+// HashTreeRootWith ssz hashes the BlobSidecarsByRangeRequest object with a hasher
+func (b *BlobSidecarsByRangeRequest) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'StartSlot'
+	hh.PutUint64(uint64(b.StartSlot))
+
+	// Field (1) 'Count'
+	hh.PutUint64(b.Count)
+
+	if ssz.EnableVectorizedHTR {
+		hh.MerkleizeVectorizedHTR(indx)
+	} else {
+		hh.Merkleize(indx)
+	}
+	return
+}
+
 // MarshalSSZ ssz marshals the ENRForkID object
 func (e *ENRForkID) MarshalSSZ() ([]byte, error) {
 	return ssz.MarshalSSZ(e)
@@ -21,6 +21,10 @@ mainnet = {
     "sync_committee_aggregate_bytes.size": "16",
     "sync_committee_aggregate_bits.type": "github.com/prysmaticlabs/go-bitfield.Bitvector128",
     "withdrawal.size": "16",
+    "blob.size": "131072", # BYTES_PER_FIELD_ELEMENT * FIELD_ELEMENTS_PER_BLOB
+    "max_blobs_per_block.size": "6", # MAX_BLOBS_PER_BLOCK
+    "max_blob_commitments.size": "4096", # MAX_BLOB_COMMITMENTS_PER_BLOCK
+    "kzg_commitment_inclusion_proof_depth.size": "17", # KZG_COMMITMENT_INCLUSION_PROOF_DEPTH
 }
 
 minimal = {
@@ -37,6 +41,10 @@ minimal = {
     "sync_committee_aggregate_bytes.size": "1",
     "sync_committee_aggregate_bits.type": "github.com/prysmaticlabs/go-bitfield.Bitvector8",
     "withdrawal.size": "4",
+    "blob.size": "131072",
+    "max_blobs_per_block.size": "6",
+    "max_blob_commitments.size": "16",
+    "kzg_commitment_inclusion_proof_depth.size": "9",
 }
 
 ###### Rules definitions #######
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/params"
 	clparams "github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 )
 
@@ -80,16 +81,26 @@ const DefaultCliqueSigner = "0x0000000000000000000000000000000000000000000000000
 // GethShanghaiTime calculates the absolute time of the shanghai (aka capella) fork block
 // by adding the relative time of the capella the fork epoch to the given genesis timestamp.
 func GethShanghaiTime(genesisTime uint64, cfg *clparams.BeaconChainConfig) *uint64 {
-	var shanghaiTime *uint64
-	if cfg.CapellaForkEpoch != math.MaxUint64 {
-		startSlot, err := slots.EpochStart(cfg.CapellaForkEpoch)
+	return gethForkTime(genesisTime, cfg.CapellaForkEpoch)
+}
+
+// GethCancunTime calculates the absolute time of the cancun (aka deneb) fork block
+// by adding the relative time of the deneb fork epoch to the given genesis timestamp.
+func GethCancunTime(genesisTime uint64, cfg *clparams.BeaconChainConfig) *uint64 {
+	return gethForkTime(genesisTime, cfg.DenebForkEpoch)
+}
+
+func gethForkTime(genesisTime uint64, forkEpoch primitives.Epoch) *uint64 {
+	var forkTime *uint64
+	if forkEpoch != math.MaxUint64 {
+		startSlot, err := slots.EpochStart(forkEpoch)
 		if err == nil {
 			startTime := slots.StartTime(genesisTime, startSlot)
 			newTime := uint64(startTime.Unix())
-			shanghaiTime = &newTime
+			forkTime = &newTime
 		}
 	}
-	return shanghaiTime
+	return forkTime
 }
 
 // GethTestnetGenesis creates a genesis.json for eth1 clients with a set of defaults suitable for ephemeral testnets,
@@ -102,6 +113,7 @@ func GethTestnetGenesis(genesisTime uint64, cfg *clparams.BeaconChainConfig) *co
 	}
 
 	shanghaiTime := GethShanghaiTime(genesisTime, cfg)
+	cancunTime := GethCancunTime(genesisTime, cfg)
 	cc := &params.ChainConfig{
 		ChainID:                       big.NewInt(defaultTestChainId),
 		HomesteadBlock:                bigz,
@@ -126,6 +138,7 @@ func GethTestnetGenesis(genesisTime uint64, cfg *clparams.BeaconChainConfig) *co
 			Epoch:  20000,
 		},
 		ShanghaiTime: shanghaiTime,
+		CancunTime:   cancunTime,
 	}
 	da := defaultDepositContractAllocation(cfg.DepositContractAddress)
 	ma := minerAllocation()
@@ -2,6 +2,7 @@ package operations
 
 import (
 	"context"
+	"math/big"
 	"path"
 	"testing"
 
@@ -43,7 +44,7 @@ func RunWithdrawalsTest(t *testing.T, config string) {
 				if err != nil {
 					return nil, err
 				}
-				p, err := consensusblocks.WrappedExecutionPayloadCapella(&enginev1.ExecutionPayloadCapella{Withdrawals: withdrawals}, 0)
+				p, err := consensusblocks.WrappedExecutionPayloadCapella(&enginev1.ExecutionPayloadCapella{Withdrawals: withdrawals}, big.NewInt(0))
 				require.NoError(t, err)
 				return blocks.ProcessWithdrawals(s, p)
 			})
@@ -108,6 +108,16 @@ func GenerateAttestations(
 				return nil, err
 			}
 			headState = genState
+		case version.Deneb:
+			pbState, err := state_native.ProtobufBeaconStateDeneb(bState.ToProto())
+			if err != nil {
+				return nil, err
+			}
+			genState, err := state_native.InitializeFromProtoUnsafeDeneb(pbState)
+			if err != nil {
+				return nil, err
+			}
+			headState = genState
 		default:
 			return nil, errors.New("state type isn't supported")
 		}
@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	primitives "github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	v2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
 	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
@@ -127,6 +128,21 @@ func (mr *MockValidatorClientMockRecorder) GetFeeRecipientByPubKey(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeRecipientByPubKey", reflect.TypeOf((*MockValidatorClient)(nil).GetFeeRecipientByPubKey), arg0, arg1)
 }
 
+// GetLiveness mocks base method.
+func (m *MockValidatorClient) GetLiveness(arg0 context.Context, arg1 *v2.GetLivenessRequest) (*v2.GetLivenessResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLiveness", arg0, arg1)
+	ret0, _ := ret[0].(*v2.GetLivenessResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLiveness indicates an expected call of GetLiveness.
+func (mr *MockValidatorClientMockRecorder) GetLiveness(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLiveness", reflect.TypeOf((*MockValidatorClient)(nil).GetLiveness), arg0, arg1)
+}
+
 // GetSyncCommitteeContribution mocks base method.
 func (m *MockValidatorClient) GetSyncCommitteeContribution(arg0 context.Context, arg1 *eth.SyncCommitteeContributionRequest) (*eth.SyncCommitteeContribution, error) {
 	m.ctrl.T.Helper()
@@ -162,6 +162,10 @@ func (_ MockValidator) UpdateDomainDataCaches(_ context.Context, _ primitives.Sl
 	panic("implement me")
 }
 
+func (_ MockValidator) UpdateExecutionClientVersionCache(_ context.Context) {
+	panic("implement me")
+}
+
 func (_ MockValidator) WaitForKeymanagerInitialization(_ context.Context) error {
 	panic("implement me")
 }
@@ -186,6 +190,10 @@ func (_ MockValidator) CheckDoppelGanger(_ context.Context) error {
 	panic("implement me")
 }
 
+func (_ MockValidator) CheckDoppelGangerLiveness(_ context.Context, _ primitives.Slot) error {
+	panic("implement me")
+}
+
 // HasProposerSettings for mocking
 func (MockValidator) HasProposerSettings() bool {
 	panic("implement me")
@@ -4,21 +4,24 @@ import (
 	"context"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 )
 
 type beaconApiBeaconChainClient struct {
-	fallbackClient          iface.BeaconChainClient
 	jsonRestHandler         jsonRestHandler
 	stateValidatorsProvider stateValidatorsProvider
 }
@@ -144,12 +147,133 @@ func (c beaconApiBeaconChainClient) GetChainHead(ctx context.Context, _ *empty.E
 }
 
 func (c beaconApiBeaconChainClient) ListValidatorBalances(ctx context.Context, in *ethpb.ListValidatorBalancesRequest) (*ethpb.ValidatorBalances, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.ListValidatorBalances(ctx, in)
+	pageSize := in.PageSize
+
+	// We follow the gRPC behavior here, which returns a maximum of 250 results when pageSize == 0
+	if pageSize == 0 {
+		pageSize = 250
+	}
+
+	var pageToken uint64
+	var err error
+
+	if in.PageToken != "" {
+		if pageToken, err = strconv.ParseUint(in.PageToken, 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse page token `%s`", in.PageToken)
+		}
+	}
+
+	pubkeys := make([]string, 0, len(in.PublicKeys))
+	for _, pubkey := range in.PublicKeys {
+		// Skip empty public keys, like the gRPC API does.
+		if len(pubkey) == 0 {
+			continue
+		}
+		pubkeys = append(pubkeys, hexutil.Encode(pubkey))
+	}
+
+	var stateValidators *apimiddleware.StateValidatorsResponseJson
+	var epoch primitives.Epoch
+
+	switch queryFilter := in.QueryFilter.(type) {
+	case *ethpb.ListValidatorBalancesRequest_Epoch:
+		slot, err := slots.EpochStart(queryFilter.Epoch)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get first slot for epoch `%d`", queryFilter.Epoch)
+		}
+		if stateValidators, err = c.stateValidatorsProvider.GetStateValidatorsForSlot(ctx, slot, pubkeys, in.Indices, nil); err != nil {
+			return nil, errors.Wrapf(err, "failed to get state validators for slot `%d`", slot)
+		}
+		epoch = queryFilter.Epoch
+	case *ethpb.ListValidatorBalancesRequest_Genesis:
+		if stateValidators, err = c.stateValidatorsProvider.GetStateValidatorsForSlot(ctx, 0, pubkeys, in.Indices, nil); err != nil {
+			return nil, errors.Wrapf(err, "failed to get genesis state validators")
+		}
+		epoch = 0
+	case nil:
+		if stateValidators, err = c.stateValidatorsProvider.GetStateValidatorsForHead(ctx, pubkeys, in.Indices, nil); err != nil {
+			return nil, errors.Wrap(err, "failed to get head state validators")
+		}
+
+		blockHeader, err := c.getHeadBlockHeaders(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get head block headers")
+		}
+
+		slot, err := strconv.ParseUint(blockHeader.Data.Header.Message.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse header slot `%s`", blockHeader.Data.Header.Message.Slot)
+		}
+
+		epoch = slots.ToEpoch(primitives.Slot(slot))
+	default:
+		return nil, errors.Errorf("unsupported query filter type `%v`", reflect.TypeOf(queryFilter))
+	}
+
+	if stateValidators.Data == nil {
+		return nil, errors.New("state validators data is nil")
+	}
+
+	balances := make([]*ethpb.ValidatorBalances_Balance, len(stateValidators.Data))
+	for idx, stateValidator := range stateValidators.Data {
+		if stateValidator == nil || stateValidator.Validator == nil {
+			return nil, errors.Errorf("state validator at index `%d` is nil", idx)
+		}
+
+		validatorIndex, err := strconv.ParseUint(stateValidator.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse validator index `%s`", stateValidator.Index)
+		}
+
+		pubkey, err := hexutil.Decode(stateValidator.Validator.PublicKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode validator pubkey `%s`", stateValidator.Validator.PublicKey)
+		}
+
+		balance, err := strconv.ParseUint(stateValidator.Balance, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse validator balance `%s`", stateValidator.Balance)
+		}
+
+		status, ok := beaconAPITogRPCValidatorStatus[stateValidator.Status]
+		if !ok {
+			return nil, errors.Errorf("invalid validator status `%s`", stateValidator.Status)
+		}
+
+		balances[idx] = &ethpb.ValidatorBalances_Balance{
+			PublicKey: pubkey,
+			Index:     primitives.ValidatorIndex(validatorIndex),
+			Balance:   balance,
+			Status:    status.String(),
+		}
+	}
+
+	// Depending on the indices and public keys given, results might not be sorted.
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].Index < balances[j].Index
+	})
+
+	start := pageToken * uint64(pageSize)
+	if start > uint64(len(balances)) {
+		start = uint64(len(balances))
+	}
+
+	end := start + uint64(pageSize)
+	if end > uint64(len(balances)) {
+		end = uint64(len(balances))
+	}
+
+	var nextPageToken string
+	if end < uint64(len(balances)) {
+		nextPageToken = strconv.FormatUint(pageToken+1, 10)
 	}
 
-	// TODO: Implement me
-	panic("beaconApiBeaconChainClient.ListValidatorBalances is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiBeaconChainClientWithFallback.")
+	return &ethpb.ValidatorBalances{
+		Epoch:         epoch,
+		Balances:      balances[start:end],
+		TotalSize:     int32(len(balances)),
+		NextPageToken: nextPageToken,
+	}, nil
 }
 
 func (c beaconApiBeaconChainClient) ListValidators(ctx context.Context, in *ethpb.ListValidatorsRequest) (*ethpb.Validators, error) {
@@ -239,58 +363,14 @@ func (c beaconApiBeaconChainClient) ListValidators(ctx context.Context, in *ethp
 			return nil, errors.Errorf("state validator at index `%d` is nil", idx)
 		}
 
-		pubkey, err := hexutil.Decode(stateValidator.Validator.PublicKey)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to decode validator pubkey `%s`", stateValidator.Validator.PublicKey)
-		}
-
-		withdrawalCredentials, err := hexutil.Decode(stateValidator.Validator.WithdrawalCredentials)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to decode validator withdrawal credentials `%s`", stateValidator.Validator.WithdrawalCredentials)
-		}
-
-		effectiveBalance, err := strconv.ParseUint(stateValidator.Validator.EffectiveBalance, 10, 64)
+		validatorIndex, validator, err := convertValidatorJsonToProto(stateValidator)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to parse validator effective balance `%s`", stateValidator.Validator.EffectiveBalance)
-		}
-
-		validatorIndex, err := strconv.ParseUint(stateValidator.Index, 10, 64)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to parse validator index `%s`", stateValidator.Index)
-		}
-
-		activationEligibilityEpoch, err := strconv.ParseUint(stateValidator.Validator.ActivationEligibilityEpoch, 10, 64)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to parse validator activation eligibility epoch `%s`", stateValidator.Validator.ActivationEligibilityEpoch)
-		}
-
-		activationEpoch, err := strconv.ParseUint(stateValidator.Validator.ActivationEpoch, 10, 64)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to parse validator activation epoch `%s`", stateValidator.Validator.ActivationEpoch)
-		}
-
-		exitEpoch, err := strconv.ParseUint(stateValidator.Validator.ExitEpoch, 10, 64)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to parse validator exit epoch `%s`", stateValidator.Validator.ExitEpoch)
-		}
-
-		withdrawableEpoch, err := strconv.ParseUint(stateValidator.Validator.WithdrawableEpoch, 10, 64)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to parse validator withdrawable epoch `%s`", stateValidator.Validator.WithdrawableEpoch)
+			return nil, err
 		}
 
 		validators[idx-start] = &ethpb.Validators_ValidatorContainer{
-			Index: primitives.ValidatorIndex(validatorIndex),
-			Validator: &ethpb.Validator{
-				PublicKey:                  pubkey,
-				WithdrawalCredentials:      withdrawalCredentials,
-				EffectiveBalance:           effectiveBalance,
-				Slashed:                    stateValidator.Validator.Slashed,
-				ActivationEligibilityEpoch: primitives.Epoch(activationEligibilityEpoch),
-				ActivationEpoch:            primitives.Epoch(activationEpoch),
-				ExitEpoch:                  primitives.Epoch(exitEpoch),
-				WithdrawableEpoch:          primitives.Epoch(withdrawableEpoch),
-			},
+			Index:     validatorIndex,
+			Validator: validator,
 		}
 	}
 
@@ -307,42 +387,205 @@ func (c beaconApiBeaconChainClient) ListValidators(ctx context.Context, in *ethp
 	}, nil
 }
 
-func (c beaconApiBeaconChainClient) GetValidatorQueue(ctx context.Context, in *empty.Empty) (*ethpb.ValidatorQueue, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.GetValidatorQueue(ctx, in)
+func (c beaconApiBeaconChainClient) GetValidatorQueue(ctx context.Context, _ *empty.Empty) (*ethpb.ValidatorQueue, error) {
+	const finalityCheckpointsEndpoint = "/eth/v1/beacon/states/head/finality_checkpoints"
+	const committeesEndpoint = "/eth/v1/beacon/states/head/committees"
+
+	finalityCheckpoints := apimiddleware.StateFinalityCheckpointResponseJson{}
+	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, finalityCheckpointsEndpoint, &finalityCheckpoints); err != nil {
+		return nil, errors.Wrapf(err, "failed to query %s", finalityCheckpointsEndpoint)
 	}
 
-	// TODO: Implement me
-	panic("beaconApiBeaconChainClient.GetValidatorQueue is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiBeaconChainClientWithFallback.")
-}
+	if finalityCheckpoints.Data == nil || finalityCheckpoints.Data.Finalized == nil {
+		return nil, errors.New("finalized checkpoint is nil")
+	}
+
+	finalizedEpoch, err := strconv.ParseUint(finalityCheckpoints.Data.Finalized.Epoch, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse finalized epoch `%s`", finalityCheckpoints.Data.Finalized.Epoch)
+	}
+
+	blockHeader, err := c.getHeadBlockHeaders(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get head block headers")
+	}
 
-func (c beaconApiBeaconChainClient) GetValidatorPerformance(ctx context.Context, in *ethpb.ValidatorPerformanceRequest) (*ethpb.ValidatorPerformanceResponse, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.GetValidatorPerformance(ctx, in)
+	headSlot, err := strconv.ParseUint(blockHeader.Data.Header.Message.Slot, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse head block slot `%s`", blockHeader.Data.Header.Message.Slot)
 	}
 
-	// TODO: Implement me
-	panic("beaconApiBeaconChainClient.GetValidatorPerformance is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiBeaconChainClientWithFallback.")
-}
+	currentEpoch := slots.ToEpoch(primitives.Slot(headSlot))
+
+	// Only validators waiting for activation or waiting to exit can be part of a queue, so we don't need to
+	// retrieve the whole validator registry.
+	stateValidators, err := c.stateValidatorsProvider.GetStateValidatorsForHead(ctx, nil, nil, []string{"pending_queued", "active_exiting", "active_slashed"})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get head state validators")
+	}
+
+	// The committees of the current epoch contain every active validator exactly once, which is much cheaper to
+	// retrieve than the active validators themselves.
+	committees := apimiddleware.StateCommitteesResponseJson{}
+	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, committeesEndpoint, &committees); err != nil {
+		return nil, errors.Wrapf(err, "failed to query %s", committeesEndpoint)
+	}
+
+	if committees.Data == nil {
+		return nil, errors.New("state committees data is nil")
+	}
+
+	var activeValidatorCount uint64
+	for _, committee := range committees.Data {
+		if committee == nil {
+			return nil, errors.New("state committee is nil")
+		}
+		activeValidatorCount += uint64(len(committee.Validators))
+	}
+
+	churnLimit, err := helpers.ValidatorChurnLimit(activeValidatorCount)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute churn limit")
+	}
+
+	farFutureEpoch := params.BeaconConfig().FarFutureEpoch
+	activationExitEpoch := helpers.ActivationExitEpoch(primitives.Epoch(finalizedEpoch))
+
+	vals := make(map[primitives.ValidatorIndex]*ethpb.Validator, len(stateValidators.Data))
+	activationQueue := make([]primitives.ValidatorIndex, 0)
+	awaitingExit := make([]primitives.ValidatorIndex, 0)
+	exitQueueEpoch := primitives.Epoch(0)
+	for idx, stateValidator := range stateValidators.Data {
+		if stateValidator == nil || stateValidator.Validator == nil {
+			return nil, errors.Errorf("state validator at index `%d` is nil", idx)
+		}
+
+		validatorIndex, validator, err := convertValidatorJsonToProto(stateValidator)
+		if err != nil {
+			return nil, err
+		}
+		vals[validatorIndex] = validator
+
+		if validator.ActivationEligibilityEpoch != farFutureEpoch && validator.ActivationEpoch >= activationExitEpoch {
+			activationQueue = append(activationQueue, validatorIndex)
+		}
+		if validator.ExitEpoch != farFutureEpoch {
+			awaitingExit = append(awaitingExit, validatorIndex)
+			if exitQueueEpoch < validator.ExitEpoch {
+				exitQueueEpoch = validator.ExitEpoch
+			}
+		}
+	}
 
-func (c beaconApiBeaconChainClient) GetValidatorParticipation(ctx context.Context, in *ethpb.GetValidatorParticipationRequest) (*ethpb.ValidatorParticipationResponse, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.GetValidatorParticipation(ctx, in)
+	sort.SliceStable(activationQueue, func(i, j int) bool {
+		return vals[activationQueue[i]].ActivationEligibilityEpoch < vals[activationQueue[j]].ActivationEligibilityEpoch
+	})
+	sort.SliceStable(awaitingExit, func(i, j int) bool {
+		return vals[awaitingExit[i]].WithdrawableEpoch < vals[awaitingExit[j]].WithdrawableEpoch
+	})
+
+	exitQueueChurn := uint64(0)
+	for _, validatorIndex := range awaitingExit {
+		if vals[validatorIndex].ExitEpoch == exitQueueEpoch {
+			exitQueueChurn++
+		}
+	}
+	// If we are above the churn limit, we simply increase the churn by one.
+	if churnLimit < exitQueueChurn {
+		exitQueueEpoch++
+	}
+
+	// We use the exit queue churn to determine if we have passed a churn limit.
+	minEpoch := exitQueueEpoch + params.BeaconConfig().MinValidatorWithdrawabilityDelay
+	exitQueue := make([]primitives.ValidatorIndex, 0, len(awaitingExit))
+	for _, validatorIndex := range awaitingExit {
+		validator := vals[validatorIndex]
+		// Ensure the validator has not yet exited before adding its index to the exit queue.
+		if validator.WithdrawableEpoch < minEpoch && currentEpoch < validator.ExitEpoch {
+			exitQueue = append(exitQueue, validatorIndex)
+		}
 	}
 
-	// TODO: Implement me
-	panic("beaconApiBeaconChainClient.GetValidatorParticipation is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiBeaconChainClientWithFallback.")
+	activationPublicKeys := make([][]byte, len(activationQueue))
+	for i, validatorIndex := range activationQueue {
+		activationPublicKeys[i] = vals[validatorIndex].PublicKey
+	}
+
+	exitPublicKeys := make([][]byte, len(exitQueue))
+	for i, validatorIndex := range exitQueue {
+		exitPublicKeys[i] = vals[validatorIndex].PublicKey
+	}
+
+	return &ethpb.ValidatorQueue{
+		ChurnLimit:                 churnLimit,
+		ActivationPublicKeys:       activationPublicKeys,
+		ExitPublicKeys:             exitPublicKeys,
+		ActivationValidatorIndices: activationQueue,
+		ExitValidatorIndices:       exitQueue,
+	}, nil
 }
 
-func NewBeaconApiBeaconChainClientWithFallback(host string, timeout time.Duration, fallbackClient iface.BeaconChainClient) iface.BeaconChainClient {
+func NewBeaconApiBeaconChainClient(host string, timeout time.Duration) iface.BeaconChainClient {
 	jsonRestHandler := beaconApiJsonRestHandler{
-		httpClient: http.Client{Timeout: timeout},
+		httpClient: http.Client{Timeout: timeout, Transport: tracing.NewTransport(nil)},
 		host:       host,
 	}
 
 	return &beaconApiBeaconChainClient{
 		jsonRestHandler:         jsonRestHandler,
-		fallbackClient:          fallbackClient,
 		stateValidatorsProvider: beaconApiStateValidatorsProvider{jsonRestHandler: jsonRestHandler},
 	}
 }
+
+func convertValidatorJsonToProto(stateValidator *apimiddleware.ValidatorContainerJson) (primitives.ValidatorIndex, *ethpb.Validator, error) {
+	pubkey, err := hexutil.Decode(stateValidator.Validator.PublicKey)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to decode validator pubkey `%s`", stateValidator.Validator.PublicKey)
+	}
+
+	withdrawalCredentials, err := hexutil.Decode(stateValidator.Validator.WithdrawalCredentials)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to decode validator withdrawal credentials `%s`", stateValidator.Validator.WithdrawalCredentials)
+	}
+
+	effectiveBalance, err := strconv.ParseUint(stateValidator.Validator.EffectiveBalance, 10, 64)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to parse validator effective balance `%s`", stateValidator.Validator.EffectiveBalance)
+	}
+
+	validatorIndex, err := strconv.ParseUint(stateValidator.Index, 10, 64)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to parse validator index `%s`", stateValidator.Index)
+	}
+
+	activationEligibilityEpoch, err := strconv.ParseUint(stateValidator.Validator.ActivationEligibilityEpoch, 10, 64)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to parse validator activation eligibility epoch `%s`", stateValidator.Validator.ActivationEligibilityEpoch)
+	}
+
+	activationEpoch, err := strconv.ParseUint(stateValidator.Validator.ActivationEpoch, 10, 64)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to parse validator activation epoch `%s`", stateValidator.Validator.ActivationEpoch)
+	}
+
+	exitEpoch, err := strconv.ParseUint(stateValidator.Validator.ExitEpoch, 10, 64)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to parse validator exit epoch `%s`", stateValidator.Validator.ExitEpoch)
+	}
+
+	withdrawableEpoch, err := strconv.ParseUint(stateValidator.Validator.WithdrawableEpoch, 10, 64)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to parse validator withdrawable epoch `%s`", stateValidator.Validator.WithdrawableEpoch)
+	}
+
+	return primitives.ValidatorIndex(validatorIndex), &ethpb.Validator{
+		PublicKey:                  pubkey,
+		WithdrawalCredentials:      withdrawalCredentials,
+		EffectiveBalance:           effectiveBalance,
+		Slashed:                    stateValidator.Validator.Slashed,
+		ActivationEligibilityEpoch: primitives.Epoch(activationEligibilityEpoch),
+		ActivationEpoch:            primitives.Epoch(activationEpoch),
+		ExitEpoch:                  primitives.Epoch(exitEpoch),
+		WithdrawableEpoch:          primitives.Epoch(withdrawableEpoch),
+	}, nil
+}
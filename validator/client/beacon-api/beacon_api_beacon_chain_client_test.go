@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/mock/gomock"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
@@ -925,3 +926,370 @@ func TestGetChainHead(t *testing.T) {
 		assert.DeepEqual(t, expectedChainHead, chainHead)
 	})
 }
+
+func TestListValidatorBalances(t *testing.T) {
+	const blockHeaderEndpoint = "/eth/v1/beacon/headers/head"
+
+	stateValidators := &apimiddleware.StateValidatorsResponseJson{
+		Data: []*apimiddleware.ValidatorContainerJson{
+			testValidatorContainer(9, 33000000000, "active_ongoing", &ethpb.Validator{PublicKey: []byte{9}}),
+			testValidatorContainer(3, 31000000000, "active_exiting", &ethpb.Validator{PublicKey: []byte{3}}),
+			testValidatorContainer(5, 32000000000, "pending_queued", &ethpb.Validator{PublicKey: []byte{5}}),
+		},
+	}
+
+	t.Run("invalid page token", func(t *testing.T) {
+		beaconChainClient := beaconApiBeaconChainClient{}
+		_, err := beaconChainClient.ListValidatorBalances(context.Background(), &ethpb.ListValidatorBalancesRequest{PageToken: "foo"})
+		assert.ErrorContains(t, "failed to parse page token `foo`", err)
+	})
+
+	t.Run("fails to get validators for epoch filter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+		stateValidatorsProvider.EXPECT().GetStateValidatorsForSlot(ctx, primitives.Slot(64), []string{}, []primitives.ValidatorIndex(nil), []string(nil)).Return(
+			nil,
+			errors.New("foo error"),
+		)
+
+		beaconChainClient := beaconApiBeaconChainClient{stateValidatorsProvider: stateValidatorsProvider}
+		_, err := beaconChainClient.ListValidatorBalances(ctx, &ethpb.ListValidatorBalancesRequest{
+			QueryFilter: &ethpb.ListValidatorBalancesRequest_Epoch{Epoch: 2},
+		})
+		assert.ErrorContains(t, "failed to get state validators for slot `64`: foo error", err)
+	})
+
+	t.Run("fails to get latest block header for nil filter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+		stateValidatorsProvider.EXPECT().GetStateValidatorsForHead(ctx, []string{}, []primitives.ValidatorIndex(nil), []string(nil)).Return(
+			stateValidators,
+			nil,
+		)
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		jsonRestHandler.EXPECT().GetRestJsonResponse(ctx, blockHeaderEndpoint, gomock.Any()).Return(
+			nil,
+			errors.New("bar error"),
+		)
+
+		beaconChainClient := beaconApiBeaconChainClient{
+			stateValidatorsProvider: stateValidatorsProvider,
+			jsonRestHandler:         jsonRestHandler,
+		}
+		_, err := beaconChainClient.ListValidatorBalances(ctx, &ethpb.ListValidatorBalancesRequest{})
+		assert.ErrorContains(t, "bar error", err)
+	})
+
+	t.Run("invalid validator status", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+		stateValidatorsProvider.EXPECT().GetStateValidatorsForSlot(ctx, primitives.Slot(0), []string{}, []primitives.ValidatorIndex(nil), []string(nil)).Return(
+			&apimiddleware.StateValidatorsResponseJson{
+				Data: []*apimiddleware.ValidatorContainerJson{
+					testValidatorContainer(1, 32000000000, "foo", &ethpb.Validator{PublicKey: []byte{1}}),
+				},
+			},
+			nil,
+		)
+
+		beaconChainClient := beaconApiBeaconChainClient{stateValidatorsProvider: stateValidatorsProvider}
+		_, err := beaconChainClient.ListValidatorBalances(ctx, &ethpb.ListValidatorBalancesRequest{
+			QueryFilter: &ethpb.ListValidatorBalancesRequest_Genesis{Genesis: true},
+		})
+		assert.ErrorContains(t, "invalid validator status `foo`", err)
+	})
+
+	testCases := []struct {
+		name             string
+		pubkeys          [][]byte
+		pubkeyStrings    []string
+		pageSize         int32
+		pageToken        string
+		expectedResponse *ethpb.ValidatorBalances
+	}{
+		{
+			name:          "page size 0",
+			pubkeys:       [][]byte{{}, {9}},
+			pubkeyStrings: []string{hexutil.Encode([]byte{9})},
+			expectedResponse: &ethpb.ValidatorBalances{
+				Epoch: 2,
+				Balances: []*ethpb.ValidatorBalances_Balance{
+					{PublicKey: []byte{3}, Index: 3, Balance: 31000000000, Status: "EXITING"},
+					{PublicKey: []byte{5}, Index: 5, Balance: 32000000000, Status: "PENDING"},
+					{PublicKey: []byte{9}, Index: 9, Balance: 33000000000, Status: "ACTIVE"},
+				},
+				TotalSize: 3,
+			},
+		},
+		{
+			name:          "first page",
+			pubkeyStrings: []string{},
+			pageSize:      2,
+			expectedResponse: &ethpb.ValidatorBalances{
+				Epoch: 2,
+				Balances: []*ethpb.ValidatorBalances_Balance{
+					{PublicKey: []byte{3}, Index: 3, Balance: 31000000000, Status: "EXITING"},
+					{PublicKey: []byte{5}, Index: 5, Balance: 32000000000, Status: "PENDING"},
+				},
+				TotalSize:     3,
+				NextPageToken: "1",
+			},
+		},
+		{
+			name:          "last page",
+			pubkeyStrings: []string{},
+			pageSize:      2,
+			pageToken:     "1",
+			expectedResponse: &ethpb.ValidatorBalances{
+				Epoch: 2,
+				Balances: []*ethpb.ValidatorBalances_Balance{
+					{PublicKey: []byte{9}, Index: 9, Balance: 33000000000, Status: "ACTIVE"},
+				},
+				TotalSize: 3,
+			},
+		},
+		{
+			name:          "page out of range",
+			pubkeyStrings: []string{},
+			pageSize:      2,
+			pageToken:     "5",
+			expectedResponse: &ethpb.ValidatorBalances{
+				Epoch:     2,
+				Balances:  []*ethpb.ValidatorBalances_Balance{},
+				TotalSize: 3,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+
+			stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+			stateValidatorsProvider.EXPECT().GetStateValidatorsForSlot(ctx, primitives.Slot(64), testCase.pubkeyStrings, []primitives.ValidatorIndex{3}, []string(nil)).Return(
+				stateValidators,
+				nil,
+			)
+
+			beaconChainClient := beaconApiBeaconChainClient{stateValidatorsProvider: stateValidatorsProvider}
+			balances, err := beaconChainClient.ListValidatorBalances(ctx, &ethpb.ListValidatorBalancesRequest{
+				QueryFilter: &ethpb.ListValidatorBalancesRequest_Epoch{Epoch: 2},
+				PublicKeys:  testCase.pubkeys,
+				Indices:     []primitives.ValidatorIndex{3},
+				PageSize:    testCase.pageSize,
+				PageToken:   testCase.pageToken,
+			})
+			require.NoError(t, err)
+			assert.DeepEqual(t, testCase.expectedResponse, balances)
+		})
+	}
+}
+
+func TestGetValidatorQueue(t *testing.T) {
+	const finalityCheckpointsEndpoint = "/eth/v1/beacon/states/head/finality_checkpoints"
+	const blockHeaderEndpoint = "/eth/v1/beacon/headers/head"
+	const committeesEndpoint = "/eth/v1/beacon/states/head/committees"
+
+	farFutureEpoch := params.BeaconConfig().FarFutureEpoch
+	minWithdrawabilityDelay := params.BeaconConfig().MinValidatorWithdrawabilityDelay
+
+	t.Run("fails to get finality checkpoints", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		jsonRestHandler.EXPECT().GetRestJsonResponse(ctx, finalityCheckpointsEndpoint, gomock.Any()).Return(
+			nil,
+			errors.New("foo error"),
+		)
+
+		beaconChainClient := beaconApiBeaconChainClient{jsonRestHandler: jsonRestHandler}
+		_, err := beaconChainClient.GetValidatorQueue(ctx, &emptypb.Empty{})
+		assert.ErrorContains(t, "failed to query /eth/v1/beacon/states/head/finality_checkpoints: foo error", err)
+	})
+
+	t.Run("fails to get committees", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		jsonRestHandler.EXPECT().GetRestJsonResponse(ctx, finalityCheckpointsEndpoint, gomock.Any()).Return(
+			nil,
+			nil,
+		).SetArg(
+			2,
+			apimiddleware.StateFinalityCheckpointResponseJson{
+				Data: &apimiddleware.StateFinalityCheckpointResponse_StateFinalityCheckpointJson{
+					Finalized: &apimiddleware.CheckpointJson{Epoch: "1"},
+				},
+			},
+		)
+		jsonRestHandler.EXPECT().GetRestJsonResponse(ctx, blockHeaderEndpoint, gomock.Any()).Return(
+			nil,
+			nil,
+		).SetArg(
+			2,
+			apimiddleware.BlockHeaderResponseJson{
+				Data: &apimiddleware.BlockHeaderContainerJson{
+					Header: &apimiddleware.BeaconBlockHeaderContainerJson{
+						Message: &apimiddleware.BeaconBlockHeaderJson{Slot: "64"},
+					},
+				},
+			},
+		)
+		jsonRestHandler.EXPECT().GetRestJsonResponse(ctx, committeesEndpoint, gomock.Any()).Return(
+			nil,
+			errors.New("bar error"),
+		)
+
+		stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+		stateValidatorsProvider.EXPECT().GetStateValidatorsForHead(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(
+			&apimiddleware.StateValidatorsResponseJson{},
+			nil,
+		)
+
+		beaconChainClient := beaconApiBeaconChainClient{
+			jsonRestHandler:         jsonRestHandler,
+			stateValidatorsProvider: stateValidatorsProvider,
+		}
+		_, err := beaconChainClient.GetValidatorQueue(ctx, &emptypb.Empty{})
+		assert.ErrorContains(t, "failed to query /eth/v1/beacon/states/head/committees: bar error", err)
+	})
+
+	t.Run("returns the activation and exit queues", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		jsonRestHandler.EXPECT().GetRestJsonResponse(ctx, finalityCheckpointsEndpoint, gomock.Any()).Return(
+			nil,
+			nil,
+		).SetArg(
+			2,
+			apimiddleware.StateFinalityCheckpointResponseJson{
+				Data: &apimiddleware.StateFinalityCheckpointResponse_StateFinalityCheckpointJson{
+					Finalized: &apimiddleware.CheckpointJson{Epoch: "1"},
+				},
+			},
+		)
+		jsonRestHandler.EXPECT().GetRestJsonResponse(ctx, blockHeaderEndpoint, gomock.Any()).Return(
+			nil,
+			nil,
+		).SetArg(
+			2,
+			apimiddleware.BlockHeaderResponseJson{
+				Data: &apimiddleware.BlockHeaderContainerJson{
+					Header: &apimiddleware.BeaconBlockHeaderContainerJson{
+						Message: &apimiddleware.BeaconBlockHeaderJson{Slot: "64"},
+					},
+				},
+			},
+		)
+		jsonRestHandler.EXPECT().GetRestJsonResponse(ctx, committeesEndpoint, gomock.Any()).Return(
+			nil,
+			nil,
+		).SetArg(
+			2,
+			apimiddleware.StateCommitteesResponseJson{
+				Data: []*apimiddleware.CommitteeJson{
+					{Index: "0", Slot: "64", Validators: []string{"1", "3"}},
+					{Index: "0", Slot: "65", Validators: []string{"4", "5"}},
+				},
+			},
+		)
+
+		stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+		stateValidatorsProvider.EXPECT().GetStateValidatorsForHead(
+			ctx,
+			[]string(nil),
+			[]primitives.ValidatorIndex(nil),
+			[]string{"pending_queued", "active_exiting", "active_slashed"},
+		).Return(
+			&apimiddleware.StateValidatorsResponseJson{
+				Data: []*apimiddleware.ValidatorContainerJson{
+					testValidatorContainer(1, 32000000000, "active_exiting", &ethpb.Validator{
+						PublicKey:         []byte{1},
+						ExitEpoch:         5,
+						WithdrawableEpoch: 5 + minWithdrawabilityDelay,
+					}),
+					testValidatorContainer(2, 32000000000, "pending_queued", &ethpb.Validator{
+						PublicKey:                  []byte{2},
+						ActivationEligibilityEpoch: 1,
+						ActivationEpoch:            farFutureEpoch,
+						ExitEpoch:                  farFutureEpoch,
+						WithdrawableEpoch:          farFutureEpoch,
+					}),
+					testValidatorContainer(3, 32000000000, "active_exiting", &ethpb.Validator{
+						PublicKey:         []byte{3},
+						ExitEpoch:         4,
+						WithdrawableEpoch: 4 + minWithdrawabilityDelay,
+					}),
+					testValidatorContainer(6, 32000000000, "pending_queued", &ethpb.Validator{
+						PublicKey:                  []byte{6},
+						ActivationEligibilityEpoch: 0,
+						ActivationEpoch:            farFutureEpoch,
+						ExitEpoch:                  farFutureEpoch,
+						WithdrawableEpoch:          farFutureEpoch,
+					}),
+					testValidatorContainer(7, 32000000000, "pending_queued", &ethpb.Validator{
+						PublicKey:                  []byte{7},
+						ActivationEligibilityEpoch: farFutureEpoch,
+						ActivationEpoch:            farFutureEpoch,
+						ExitEpoch:                  farFutureEpoch,
+						WithdrawableEpoch:          farFutureEpoch,
+					}),
+				},
+			},
+			nil,
+		)
+
+		beaconChainClient := beaconApiBeaconChainClient{
+			jsonRestHandler:         jsonRestHandler,
+			stateValidatorsProvider: stateValidatorsProvider,
+		}
+		queue, err := beaconChainClient.GetValidatorQueue(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+
+		expectedQueue := &ethpb.ValidatorQueue{
+			ChurnLimit:                 params.BeaconConfig().MinPerEpochChurnLimit,
+			ActivationPublicKeys:       [][]byte{{6}, {2}},
+			ExitPublicKeys:             [][]byte{{3}},
+			ActivationValidatorIndices: []primitives.ValidatorIndex{6, 2},
+			ExitValidatorIndices:       []primitives.ValidatorIndex{3},
+		}
+		assert.DeepEqual(t, expectedQueue, queue)
+	})
+}
+
+func testValidatorContainer(index primitives.ValidatorIndex, balance uint64, status string, validator *ethpb.Validator) *apimiddleware.ValidatorContainerJson {
+	return &apimiddleware.ValidatorContainerJson{
+		Index:   strconv.FormatUint(uint64(index), 10),
+		Balance: strconv.FormatUint(balance, 10),
+		Status:  status,
+		Validator: &apimiddleware.ValidatorJson{
+			PublicKey:                  hexutil.Encode(validator.PublicKey),
+			WithdrawalCredentials:      hexutil.Encode(validator.WithdrawalCredentials),
+			EffectiveBalance:           strconv.FormatUint(validator.EffectiveBalance, 10),
+			Slashed:                    validator.Slashed,
+			ActivationEligibilityEpoch: strconv.FormatUint(uint64(validator.ActivationEligibilityEpoch), 10),
+			ActivationEpoch:            strconv.FormatUint(uint64(validator.ActivationEpoch), 10),
+			ExitEpoch:                  strconv.FormatUint(uint64(validator.ExitEpoch), 10),
+			WithdrawableEpoch:          strconv.FormatUint(uint64(validator.WithdrawableEpoch), 10),
+		},
+	}
+}
@@ -3,20 +3,22 @@ package beacon_api
 import (
 	"context"
 	"net/http"
+	neturl "net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type beaconApiNodeClient struct {
-	fallbackClient  iface.NodeClient
 	jsonRestHandler jsonRestHandler
 	genesisProvider genesisProvider
 }
@@ -75,33 +77,72 @@ func (c *beaconApiNodeClient) GetGenesis(ctx context.Context, _ *empty.Empty) (*
 	}, nil
 }
 
-func (c *beaconApiNodeClient) GetVersion(ctx context.Context, in *empty.Empty) (*ethpb.Version, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.GetVersion(ctx, in)
+func (c *beaconApiNodeClient) GetVersion(ctx context.Context, _ *empty.Empty) (*ethpb.Version, error) {
+	const endpoint = "/eth/v1/node/version"
+
+	versionResponse := apimiddleware.VersionResponseJson{}
+	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, endpoint, &versionResponse); err != nil {
+		return nil, errors.Wrapf(err, "failed to query %s", endpoint)
+	}
+
+	if versionResponse.Data == nil {
+		return nil, errors.New("version data is nil")
 	}
 
-	// TODO: Implement me
-	panic("beaconApiNodeClient.GetVersion is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiNodeClientWithFallback.")
+	return &ethpb.Version{
+		Version: versionResponse.Data.Version,
+	}, nil
 }
 
-func (c *beaconApiNodeClient) ListPeers(ctx context.Context, in *empty.Empty) (*ethpb.Peers, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.ListPeers(ctx, in)
+// ListPeers returns the peers the beacon node is connected to, which matches the behavior of the gRPC API.
+func (c *beaconApiNodeClient) ListPeers(ctx context.Context, _ *empty.Empty) (*ethpb.Peers, error) {
+	endpoint := buildURL("/eth/v1/node/peers", neturl.Values{"state": []string{"connected"}})
+
+	peersResponse := apimiddleware.PeersResponseJson{}
+	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, endpoint, &peersResponse); err != nil {
+		return nil, errors.Wrapf(err, "failed to query %s", endpoint)
+	}
+
+	if peersResponse.Data == nil {
+		return nil, errors.New("peers data is nil")
+	}
+
+	peers := make([]*ethpb.Peer, len(peersResponse.Data))
+	for idx, peer := range peersResponse.Data {
+		if peer == nil {
+			return nil, errors.Errorf("peer at index `%d` is nil", idx)
+		}
+
+		direction, ok := ethpb.PeerDirection_value[strings.ToUpper(peer.Direction)]
+		if !ok {
+			return nil, errors.Errorf("invalid peer direction `%s`", peer.Direction)
+		}
+
+		state, ok := ethpb.ConnectionState_value[strings.ToUpper(peer.State)]
+		if !ok {
+			return nil, errors.Errorf("invalid peer connection state `%s`", peer.State)
+		}
+
+		peers[idx] = &ethpb.Peer{
+			Address:         peer.Address,
+			Direction:       ethpb.PeerDirection(direction),
+			ConnectionState: ethpb.ConnectionState(state),
+			PeerId:          peer.PeerId,
+			Enr:             peer.Enr,
+		}
 	}
 
-	// TODO: Implement me
-	panic("beaconApiNodeClient.ListPeers is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiNodeClientWithFallback.")
+	return &ethpb.Peers{Peers: peers}, nil
 }
 
-func NewNodeClientWithFallback(host string, timeout time.Duration, fallbackClient iface.NodeClient) iface.NodeClient {
+func NewBeaconApiNodeClient(host string, timeout time.Duration) iface.NodeClient {
 	jsonRestHandler := beaconApiJsonRestHandler{
-		httpClient: http.Client{Timeout: timeout},
+		httpClient: http.Client{Timeout: timeout, Transport: tracing.NewTransport(nil)},
 		host:       host,
 	}
 
 	return &beaconApiNodeClient{
 		jsonRestHandler: jsonRestHandler,
-		fallbackClient:  fallbackClient,
 		genesisProvider: beaconApiGenesisProvider{jsonRestHandler: jsonRestHandler},
 	}
 }
@@ -1,45 +1,80 @@
 package beacon_api
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 )
 
 type beaconApiSlasherClient struct {
-	fallbackClient  iface.SlasherClient
 	jsonRestHandler jsonRestHandler
 }
 
 func (c beaconApiSlasherClient) IsSlashableAttestation(ctx context.Context, in *ethpb.IndexedAttestation) (*ethpb.AttesterSlashingResponse, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.IsSlashableAttestation(ctx, in)
+	const endpoint = "/prysm/v1/slasher/attestations/slashable"
+
+	if in == nil {
+		return nil, errors.New("indexed attestation is nil")
+	}
+
+	marshalledAttestation, err := json.Marshal(jsonifyIndexedAttestation(in))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal indexed attestation")
+	}
+
+	slashableAttestationJson := apimiddleware.SlashableAttestationResponseJson{}
+	if _, err := c.jsonRestHandler.PostRestJson(ctx, endpoint, nil, bytes.NewBuffer(marshalledAttestation), &slashableAttestationJson); err != nil {
+		return nil, errors.Wrapf(err, "failed to send POST data to `%s` REST URL", endpoint)
+	}
+
+	attesterSlashings, err := convertAttesterSlashingsToProto(slashableAttestationJson.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert attester slashings")
 	}
 
-	// TODO: Implement me
-	panic("beaconApiSlasherClient.IsSlashableAttestation is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiSlasherClientWithFallback.")
+	return &ethpb.AttesterSlashingResponse{AttesterSlashings: attesterSlashings}, nil
 }
 
 func (c beaconApiSlasherClient) IsSlashableBlock(ctx context.Context, in *ethpb.SignedBeaconBlockHeader) (*ethpb.ProposerSlashingResponse, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.IsSlashableBlock(ctx, in)
+	const endpoint = "/prysm/v1/slasher/blocks/slashable"
+
+	if in == nil {
+		return nil, errors.New("signed block header is nil")
+	}
+
+	marshalledHeader, err := json.Marshal(jsonifySignedBeaconBlockHeader(in))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal signed block header")
+	}
+
+	slashableBlockJson := apimiddleware.SlashableBlockResponseJson{}
+	if _, err := c.jsonRestHandler.PostRestJson(ctx, endpoint, nil, bytes.NewBuffer(marshalledHeader), &slashableBlockJson); err != nil {
+		return nil, errors.Wrapf(err, "failed to send POST data to `%s` REST URL", endpoint)
+	}
+
+	proposerSlashings, err := convertProposerSlashingsToProto(slashableBlockJson.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert proposer slashings")
 	}
 
-	// TODO: Implement me
-	panic("beaconApiSlasherClient.IsSlashableBlock is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiSlasherClientWithFallback.")
+	return &ethpb.ProposerSlashingResponse{ProposerSlashings: proposerSlashings}, nil
 }
 
-func NewSlasherClientWithFallback(host string, timeout time.Duration, fallbackClient iface.SlasherClient) iface.SlasherClient {
+func NewBeaconApiSlasherClient(host string, timeout time.Duration) iface.SlasherClient {
 	jsonRestHandler := beaconApiJsonRestHandler{
-		httpClient: http.Client{Timeout: timeout},
+		httpClient: http.Client{Timeout: timeout, Transport: tracing.NewTransport(nil)},
 		host:       host,
 	}
 
 	return &beaconApiSlasherClient{
 		jsonRestHandler: jsonRestHandler,
-		fallbackClient:  fallbackClient,
 	}
 }
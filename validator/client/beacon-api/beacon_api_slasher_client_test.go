@@ -0,0 +1,199 @@
+package beacon_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/beacon-api/mock"
+)
+
+func TestIsSlashableAttestation(t *testing.T) {
+	const endpoint = "/prysm/v1/slasher/attestations/slashable"
+
+	indexedAttestation := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{1, 2},
+		Data: &ethpb.AttestationData{
+			Slot:            3,
+			CommitteeIndex:  4,
+			BeaconBlockRoot: []byte{5},
+			Source:          &ethpb.Checkpoint{Epoch: 6, Root: []byte{7}},
+			Target:          &ethpb.Checkpoint{Epoch: 8, Root: []byte{9}},
+		},
+		Signature: []byte{10},
+	}
+
+	marshalledAttestation, err := json.Marshal(jsonifyIndexedAttestation(indexedAttestation))
+	require.NoError(t, err)
+
+	expectedSlashings, err := convertAttesterSlashingsToProto(generateAttesterSlashings())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name                 string
+		restEndpointResponse apimiddleware.SlashableAttestationResponseJson
+		restEndpointError    error
+		expectedResponse     *ethpb.AttesterSlashingResponse
+		expectedError        string
+	}{
+		{
+			name:              "fails to query REST endpoint",
+			restEndpointError: errors.New("foo error"),
+			expectedError:     "failed to send POST data to `/prysm/v1/slasher/attestations/slashable` REST URL: foo error",
+		},
+		{
+			name: "fails to convert attester slashings",
+			restEndpointResponse: apimiddleware.SlashableAttestationResponseJson{
+				Data: []*apimiddleware.AttesterSlashingJson{nil},
+			},
+			expectedError: "failed to convert attester slashings",
+		},
+		{
+			name:                 "returns no slashing",
+			restEndpointResponse: apimiddleware.SlashableAttestationResponseJson{Data: []*apimiddleware.AttesterSlashingJson{}},
+			expectedResponse:     &ethpb.AttesterSlashingResponse{AttesterSlashings: []*ethpb.AttesterSlashing{}},
+		},
+		{
+			name:                 "returns slashings",
+			restEndpointResponse: apimiddleware.SlashableAttestationResponseJson{Data: generateAttesterSlashings()},
+			expectedResponse:     &ethpb.AttesterSlashingResponse{AttesterSlashings: expectedSlashings},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+
+			jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+			jsonRestHandler.EXPECT().PostRestJson(
+				ctx,
+				endpoint,
+				nil,
+				bytes.NewBuffer(marshalledAttestation),
+				&apimiddleware.SlashableAttestationResponseJson{},
+			).Return(
+				nil,
+				testCase.restEndpointError,
+			).SetArg(
+				4,
+				testCase.restEndpointResponse,
+			)
+
+			slasherClient := beaconApiSlasherClient{jsonRestHandler: jsonRestHandler}
+			response, err := slasherClient.IsSlashableAttestation(ctx, indexedAttestation)
+
+			if testCase.expectedResponse == nil {
+				assert.ErrorContains(t, testCase.expectedError, err)
+			} else {
+				require.NoError(t, err)
+				assert.DeepEqual(t, testCase.expectedResponse, response)
+			}
+		})
+	}
+
+	t.Run("nil attestation", func(t *testing.T) {
+		slasherClient := beaconApiSlasherClient{}
+		_, err := slasherClient.IsSlashableAttestation(context.Background(), nil)
+		assert.ErrorContains(t, "indexed attestation is nil", err)
+	})
+}
+
+func TestIsSlashableBlock(t *testing.T) {
+	const endpoint = "/prysm/v1/slasher/blocks/slashable"
+
+	signedBlockHeader := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{
+			Slot:          1,
+			ProposerIndex: 2,
+			ParentRoot:    []byte{3},
+			StateRoot:     []byte{4},
+			BodyRoot:      []byte{5},
+		},
+		Signature: []byte{6},
+	}
+
+	marshalledHeader, err := json.Marshal(jsonifySignedBeaconBlockHeader(signedBlockHeader))
+	require.NoError(t, err)
+
+	expectedSlashings, err := convertProposerSlashingsToProto(generateProposerSlashings())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name                 string
+		restEndpointResponse apimiddleware.SlashableBlockResponseJson
+		restEndpointError    error
+		expectedResponse     *ethpb.ProposerSlashingResponse
+		expectedError        string
+	}{
+		{
+			name:              "fails to query REST endpoint",
+			restEndpointError: errors.New("foo error"),
+			expectedError:     "failed to send POST data to `/prysm/v1/slasher/blocks/slashable` REST URL: foo error",
+		},
+		{
+			name: "fails to convert proposer slashings",
+			restEndpointResponse: apimiddleware.SlashableBlockResponseJson{
+				Data: []*apimiddleware.ProposerSlashingJson{nil},
+			},
+			expectedError: "failed to convert proposer slashings",
+		},
+		{
+			name:                 "returns no slashing",
+			restEndpointResponse: apimiddleware.SlashableBlockResponseJson{Data: []*apimiddleware.ProposerSlashingJson{}},
+			expectedResponse:     &ethpb.ProposerSlashingResponse{ProposerSlashings: []*ethpb.ProposerSlashing{}},
+		},
+		{
+			name:                 "returns slashings",
+			restEndpointResponse: apimiddleware.SlashableBlockResponseJson{Data: generateProposerSlashings()},
+			expectedResponse:     &ethpb.ProposerSlashingResponse{ProposerSlashings: expectedSlashings},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+
+			jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+			jsonRestHandler.EXPECT().PostRestJson(
+				ctx,
+				endpoint,
+				nil,
+				bytes.NewBuffer(marshalledHeader),
+				&apimiddleware.SlashableBlockResponseJson{},
+			).Return(
+				nil,
+				testCase.restEndpointError,
+			).SetArg(
+				4,
+				testCase.restEndpointResponse,
+			)
+
+			slasherClient := beaconApiSlasherClient{jsonRestHandler: jsonRestHandler}
+			response, err := slasherClient.IsSlashableBlock(ctx, signedBlockHeader)
+
+			if testCase.expectedResponse == nil {
+				assert.ErrorContains(t, testCase.expectedError, err)
+			} else {
+				require.NoError(t, err)
+				assert.DeepEqual(t, testCase.expectedResponse, response)
+			}
+		})
+	}
+
+	t.Run("nil block header", func(t *testing.T) {
+		slasherClient := beaconApiSlasherClient{}
+		_, err := slasherClient.IsSlashableBlock(context.Background(), nil)
+		assert.ErrorContains(t, "signed block header is nil", err)
+	})
+}
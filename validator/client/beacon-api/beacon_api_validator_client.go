@@ -10,6 +10,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 )
@@ -24,7 +26,7 @@ type beaconApiValidatorClient struct {
 
 func NewBeaconApiValidatorClient(host string, timeout time.Duration) iface.ValidatorClient {
 	jsonRestHandler := beaconApiJsonRestHandler{
-		httpClient: http.Client{Timeout: timeout},
+		httpClient: http.Client{Timeout: timeout, Transport: tracing.NewTransport(nil)},
 		host:       host,
 	}
 
@@ -45,6 +47,14 @@ func (c *beaconApiValidatorClient) CheckDoppelGanger(ctx context.Context, in *et
 	return c.checkDoppelGanger(ctx, in)
 }
 
+func (c *beaconApiValidatorClient) GetLiveness(ctx context.Context, in *ethpbv2.GetLivenessRequest) (*ethpbv2.GetLivenessResponse, error) {
+	if in == nil {
+		return nil, errors.New("GetLiveness received nil argument `in`")
+	}
+
+	return c.getValidatorsLiveness(ctx, in.Epoch, in.Index)
+}
+
 func (c *beaconApiValidatorClient) DomainData(ctx context.Context, in *ethpb.DomainRequest) (*ethpb.DomainResponse, error) {
 	if len(in.Domain) != 4 {
 		return nil, errors.Errorf("invalid domain type: %s", hexutil.Encode(in.Domain))
@@ -18,6 +18,10 @@ type beaconBlockConverter interface {
 	ConvertRESTAltairBlockToProto(block *apimiddleware.BeaconBlockAltairJson) (*ethpb.BeaconBlockAltair, error)
 	ConvertRESTBellatrixBlockToProto(block *apimiddleware.BeaconBlockBellatrixJson) (*ethpb.BeaconBlockBellatrix, error)
 	ConvertRESTCapellaBlockToProto(block *apimiddleware.BeaconBlockCapellaJson) (*ethpb.BeaconBlockCapella, error)
+	ConvertRESTBlindedBellatrixBlockToProto(block *apimiddleware.BlindedBeaconBlockBellatrixJson) (*ethpb.BlindedBeaconBlockBellatrix, error)
+	ConvertRESTBlindedCapellaBlockToProto(block *apimiddleware.BlindedBeaconBlockCapellaJson) (*ethpb.BlindedBeaconBlockCapella, error)
+	ConvertRESTDenebBlockToProto(block *apimiddleware.BeaconBlockDenebJson) (*ethpb.BeaconBlockDeneb, error)
+	ConvertRESTBlindedDenebBlockToProto(block *apimiddleware.BlindedBeaconBlockDenebJson) (*ethpb.BlindedBeaconBlockDeneb, error)
 }
 
 type beaconApiBeaconBlockConverter struct{}
@@ -421,3 +425,407 @@ func (c beaconApiBeaconBlockConverter) ConvertRESTCapellaBlockToProto(block *api
 		},
 	}, nil
 }
+
+// ConvertRESTBlindedBellatrixBlockToProto converts a blinded Bellatrix JSON beacon block to its protobuf equivalent
+func (c beaconApiBeaconBlockConverter) ConvertRESTBlindedBellatrixBlockToProto(block *apimiddleware.BlindedBeaconBlockBellatrixJson) (*ethpb.BlindedBeaconBlockBellatrix, error) {
+	if block.Body == nil {
+		return nil, errors.New("block body is nil")
+	}
+
+	if block.Body.ExecutionPayloadHeader == nil {
+		return nil, errors.New("execution payload header is nil")
+	}
+
+	// Call convertRESTBellatrixBlockToProto to set the fields shared with the full bellatrix block because all the
+	// error handling and the heavy lifting has already been done
+	header := block.Body.ExecutionPayloadHeader
+	bellatrixBlock, err := c.ConvertRESTBellatrixBlockToProto(&apimiddleware.BeaconBlockBellatrixJson{
+		Slot:          block.Slot,
+		ProposerIndex: block.ProposerIndex,
+		ParentRoot:    block.ParentRoot,
+		StateRoot:     block.StateRoot,
+		Body: &apimiddleware.BeaconBlockBodyBellatrixJson{
+			RandaoReveal:      block.Body.RandaoReveal,
+			Eth1Data:          block.Body.Eth1Data,
+			Graffiti:          block.Body.Graffiti,
+			ProposerSlashings: block.Body.ProposerSlashings,
+			AttesterSlashings: block.Body.AttesterSlashings,
+			Attestations:      block.Body.Attestations,
+			Deposits:          block.Body.Deposits,
+			VoluntaryExits:    block.Body.VoluntaryExits,
+			SyncAggregate:     block.Body.SyncAggregate,
+			ExecutionPayload: &apimiddleware.ExecutionPayloadJson{
+				ParentHash:    header.ParentHash,
+				FeeRecipient:  header.FeeRecipient,
+				StateRoot:     header.StateRoot,
+				ReceiptsRoot:  header.ReceiptsRoot,
+				LogsBloom:     header.LogsBloom,
+				PrevRandao:    header.PrevRandao,
+				BlockNumber:   header.BlockNumber,
+				GasLimit:      header.GasLimit,
+				GasUsed:       header.GasUsed,
+				TimeStamp:     header.TimeStamp,
+				ExtraData:     header.ExtraData,
+				BaseFeePerGas: header.BaseFeePerGas,
+				BlockHash:     header.BlockHash,
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the bellatrix fields of the blinded bellatrix block")
+	}
+
+	transactionsRoot, err := hexutil.Decode(header.TransactionsRoot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode execution payload header transactions root `%s`", header.TransactionsRoot)
+	}
+
+	payload := bellatrixBlock.Body.ExecutionPayload
+	return &ethpb.BlindedBeaconBlockBellatrix{
+		Slot:          bellatrixBlock.Slot,
+		ProposerIndex: bellatrixBlock.ProposerIndex,
+		ParentRoot:    bellatrixBlock.ParentRoot,
+		StateRoot:     bellatrixBlock.StateRoot,
+		Body: &ethpb.BlindedBeaconBlockBodyBellatrix{
+			RandaoReveal:      bellatrixBlock.Body.RandaoReveal,
+			Eth1Data:          bellatrixBlock.Body.Eth1Data,
+			Graffiti:          bellatrixBlock.Body.Graffiti,
+			ProposerSlashings: bellatrixBlock.Body.ProposerSlashings,
+			AttesterSlashings: bellatrixBlock.Body.AttesterSlashings,
+			Attestations:      bellatrixBlock.Body.Attestations,
+			Deposits:          bellatrixBlock.Body.Deposits,
+			VoluntaryExits:    bellatrixBlock.Body.VoluntaryExits,
+			SyncAggregate:     bellatrixBlock.Body.SyncAggregate,
+			ExecutionPayloadHeader: &enginev1.ExecutionPayloadHeader{
+				ParentHash:       payload.ParentHash,
+				FeeRecipient:     payload.FeeRecipient,
+				StateRoot:        payload.StateRoot,
+				ReceiptsRoot:     payload.ReceiptsRoot,
+				LogsBloom:        payload.LogsBloom,
+				PrevRandao:       payload.PrevRandao,
+				BlockNumber:      payload.BlockNumber,
+				GasLimit:         payload.GasLimit,
+				GasUsed:          payload.GasUsed,
+				Timestamp:        payload.Timestamp,
+				ExtraData:        payload.ExtraData,
+				BaseFeePerGas:    payload.BaseFeePerGas,
+				BlockHash:        payload.BlockHash,
+				TransactionsRoot: transactionsRoot,
+			},
+		},
+	}, nil
+}
+
+// ConvertRESTBlindedCapellaBlockToProto converts a blinded Capella JSON beacon block to its protobuf equivalent
+func (c beaconApiBeaconBlockConverter) ConvertRESTBlindedCapellaBlockToProto(block *apimiddleware.BlindedBeaconBlockCapellaJson) (*ethpb.BlindedBeaconBlockCapella, error) {
+	if block.Body == nil {
+		return nil, errors.New("block body is nil")
+	}
+
+	if block.Body.ExecutionPayloadHeader == nil {
+		return nil, errors.New("execution payload header is nil")
+	}
+
+	// Call convertRESTBlindedBellatrixBlockToProto to set the blinded bellatrix fields because all the error handling
+	// and the heavy lifting has already been done
+	header := block.Body.ExecutionPayloadHeader
+	bellatrixBlock, err := c.ConvertRESTBlindedBellatrixBlockToProto(&apimiddleware.BlindedBeaconBlockBellatrixJson{
+		Slot:          block.Slot,
+		ProposerIndex: block.ProposerIndex,
+		ParentRoot:    block.ParentRoot,
+		StateRoot:     block.StateRoot,
+		Body: &apimiddleware.BlindedBeaconBlockBodyBellatrixJson{
+			RandaoReveal:      block.Body.RandaoReveal,
+			Eth1Data:          block.Body.Eth1Data,
+			Graffiti:          block.Body.Graffiti,
+			ProposerSlashings: block.Body.ProposerSlashings,
+			AttesterSlashings: block.Body.AttesterSlashings,
+			Attestations:      block.Body.Attestations,
+			Deposits:          block.Body.Deposits,
+			VoluntaryExits:    block.Body.VoluntaryExits,
+			SyncAggregate:     block.Body.SyncAggregate,
+			ExecutionPayloadHeader: &apimiddleware.ExecutionPayloadHeaderJson{
+				ParentHash:       header.ParentHash,
+				FeeRecipient:     header.FeeRecipient,
+				StateRoot:        header.StateRoot,
+				ReceiptsRoot:     header.ReceiptsRoot,
+				LogsBloom:        header.LogsBloom,
+				PrevRandao:       header.PrevRandao,
+				BlockNumber:      header.BlockNumber,
+				GasLimit:         header.GasLimit,
+				GasUsed:          header.GasUsed,
+				TimeStamp:        header.TimeStamp,
+				ExtraData:        header.ExtraData,
+				BaseFeePerGas:    header.BaseFeePerGas,
+				BlockHash:        header.BlockHash,
+				TransactionsRoot: header.TransactionsRoot,
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the bellatrix fields of the blinded capella block")
+	}
+
+	withdrawalsRoot, err := hexutil.Decode(header.WithdrawalsRoot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode execution payload header withdrawals root `%s`", header.WithdrawalsRoot)
+	}
+
+	blsToExecutionChanges, err := convertBlsToExecutionChangesToProto(block.Body.BLSToExecutionChanges)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get bls to execution changes")
+	}
+
+	bellatrixHeader := bellatrixBlock.Body.ExecutionPayloadHeader
+	return &ethpb.BlindedBeaconBlockCapella{
+		Slot:          bellatrixBlock.Slot,
+		ProposerIndex: bellatrixBlock.ProposerIndex,
+		ParentRoot:    bellatrixBlock.ParentRoot,
+		StateRoot:     bellatrixBlock.StateRoot,
+		Body: &ethpb.BlindedBeaconBlockBodyCapella{
+			RandaoReveal:      bellatrixBlock.Body.RandaoReveal,
+			Eth1Data:          bellatrixBlock.Body.Eth1Data,
+			Graffiti:          bellatrixBlock.Body.Graffiti,
+			ProposerSlashings: bellatrixBlock.Body.ProposerSlashings,
+			AttesterSlashings: bellatrixBlock.Body.AttesterSlashings,
+			Attestations:      bellatrixBlock.Body.Attestations,
+			Deposits:          bellatrixBlock.Body.Deposits,
+			VoluntaryExits:    bellatrixBlock.Body.VoluntaryExits,
+			SyncAggregate:     bellatrixBlock.Body.SyncAggregate,
+			ExecutionPayloadHeader: &enginev1.ExecutionPayloadHeaderCapella{
+				ParentHash:       bellatrixHeader.ParentHash,
+				FeeRecipient:     bellatrixHeader.FeeRecipient,
+				StateRoot:        bellatrixHeader.StateRoot,
+				ReceiptsRoot:     bellatrixHeader.ReceiptsRoot,
+				LogsBloom:        bellatrixHeader.LogsBloom,
+				PrevRandao:       bellatrixHeader.PrevRandao,
+				BlockNumber:      bellatrixHeader.BlockNumber,
+				GasLimit:         bellatrixHeader.GasLimit,
+				GasUsed:          bellatrixHeader.GasUsed,
+				Timestamp:        bellatrixHeader.Timestamp,
+				ExtraData:        bellatrixHeader.ExtraData,
+				BaseFeePerGas:    bellatrixHeader.BaseFeePerGas,
+				BlockHash:        bellatrixHeader.BlockHash,
+				TransactionsRoot: bellatrixHeader.TransactionsRoot,
+				WithdrawalsRoot:  withdrawalsRoot,
+			},
+			BlsToExecutionChanges: blsToExecutionChanges,
+		},
+	}, nil
+}
+
+// ConvertRESTDenebBlockToProto converts a Deneb JSON beacon block to its protobuf equivalent
+func (c beaconApiBeaconBlockConverter) ConvertRESTDenebBlockToProto(block *apimiddleware.BeaconBlockDenebJson) (*ethpb.BeaconBlockDeneb, error) {
+	if block.Body == nil {
+		return nil, errors.New("block body is nil")
+	}
+
+	if block.Body.ExecutionPayload == nil {
+		return nil, errors.New("execution payload is nil")
+	}
+
+	// Call convertRESTCapellaBlockToProto to set the capella fields because all the error handling and the heavy
+	// lifting has already been done
+	payload := block.Body.ExecutionPayload
+	capellaBlock, err := c.ConvertRESTCapellaBlockToProto(&apimiddleware.BeaconBlockCapellaJson{
+		Slot:          block.Slot,
+		ProposerIndex: block.ProposerIndex,
+		ParentRoot:    block.ParentRoot,
+		StateRoot:     block.StateRoot,
+		Body: &apimiddleware.BeaconBlockBodyCapellaJson{
+			RandaoReveal:      block.Body.RandaoReveal,
+			Eth1Data:          block.Body.Eth1Data,
+			Graffiti:          block.Body.Graffiti,
+			ProposerSlashings: block.Body.ProposerSlashings,
+			AttesterSlashings: block.Body.AttesterSlashings,
+			Attestations:      block.Body.Attestations,
+			Deposits:          block.Body.Deposits,
+			VoluntaryExits:    block.Body.VoluntaryExits,
+			SyncAggregate:     block.Body.SyncAggregate,
+			ExecutionPayload: &apimiddleware.ExecutionPayloadCapellaJson{
+				ParentHash:    payload.ParentHash,
+				FeeRecipient:  payload.FeeRecipient,
+				StateRoot:     payload.StateRoot,
+				ReceiptsRoot:  payload.ReceiptsRoot,
+				LogsBloom:     payload.LogsBloom,
+				PrevRandao:    payload.PrevRandao,
+				BlockNumber:   payload.BlockNumber,
+				GasLimit:      payload.GasLimit,
+				GasUsed:       payload.GasUsed,
+				TimeStamp:     payload.TimeStamp,
+				ExtraData:     payload.ExtraData,
+				BaseFeePerGas: payload.BaseFeePerGas,
+				BlockHash:     payload.BlockHash,
+				Transactions:  payload.Transactions,
+				Withdrawals:   payload.Withdrawals,
+			},
+			BLSToExecutionChanges: block.Body.BLSToExecutionChanges,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the capella fields of the deneb block")
+	}
+
+	blobGasUsed, err := strconv.ParseUint(payload.BlobGasUsed, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse execution payload blob gas used `%s`", payload.BlobGasUsed)
+	}
+
+	excessBlobGas, err := strconv.ParseUint(payload.ExcessBlobGas, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse execution payload excess blob gas `%s`", payload.ExcessBlobGas)
+	}
+
+	blobKzgCommitments, err := convertHexListToProto(block.Body.BlobKzgCommitments, "blob kzg commitment")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get blob kzg commitments")
+	}
+
+	capellaPayload := capellaBlock.Body.ExecutionPayload
+	return &ethpb.BeaconBlockDeneb{
+		Slot:          capellaBlock.Slot,
+		ProposerIndex: capellaBlock.ProposerIndex,
+		ParentRoot:    capellaBlock.ParentRoot,
+		StateRoot:     capellaBlock.StateRoot,
+		Body: &ethpb.BeaconBlockBodyDeneb{
+			RandaoReveal:      capellaBlock.Body.RandaoReveal,
+			Eth1Data:          capellaBlock.Body.Eth1Data,
+			Graffiti:          capellaBlock.Body.Graffiti,
+			ProposerSlashings: capellaBlock.Body.ProposerSlashings,
+			AttesterSlashings: capellaBlock.Body.AttesterSlashings,
+			Attestations:      capellaBlock.Body.Attestations,
+			Deposits:          capellaBlock.Body.Deposits,
+			VoluntaryExits:    capellaBlock.Body.VoluntaryExits,
+			SyncAggregate:     capellaBlock.Body.SyncAggregate,
+			ExecutionPayload: &enginev1.ExecutionPayloadDeneb{
+				ParentHash:    capellaPayload.ParentHash,
+				FeeRecipient:  capellaPayload.FeeRecipient,
+				StateRoot:     capellaPayload.StateRoot,
+				ReceiptsRoot:  capellaPayload.ReceiptsRoot,
+				LogsBloom:     capellaPayload.LogsBloom,
+				PrevRandao:    capellaPayload.PrevRandao,
+				BlockNumber:   capellaPayload.BlockNumber,
+				GasLimit:      capellaPayload.GasLimit,
+				GasUsed:       capellaPayload.GasUsed,
+				Timestamp:     capellaPayload.Timestamp,
+				ExtraData:     capellaPayload.ExtraData,
+				BaseFeePerGas: capellaPayload.BaseFeePerGas,
+				BlockHash:     capellaPayload.BlockHash,
+				Transactions:  capellaPayload.Transactions,
+				Withdrawals:   capellaPayload.Withdrawals,
+				BlobGasUsed:   blobGasUsed,
+				ExcessBlobGas: excessBlobGas,
+			},
+			BlsToExecutionChanges: capellaBlock.Body.BlsToExecutionChanges,
+			BlobKzgCommitments:    blobKzgCommitments,
+		},
+	}, nil
+}
+
+// ConvertRESTBlindedDenebBlockToProto converts a blinded Deneb JSON beacon block to its protobuf equivalent
+func (c beaconApiBeaconBlockConverter) ConvertRESTBlindedDenebBlockToProto(block *apimiddleware.BlindedBeaconBlockDenebJson) (*ethpb.BlindedBeaconBlockDeneb, error) {
+	if block.Body == nil {
+		return nil, errors.New("block body is nil")
+	}
+
+	if block.Body.ExecutionPayloadHeader == nil {
+		return nil, errors.New("execution payload header is nil")
+	}
+
+	// Call convertRESTBlindedCapellaBlockToProto to set the blinded capella fields because all the error handling
+	// and the heavy lifting has already been done
+	header := block.Body.ExecutionPayloadHeader
+	capellaBlock, err := c.ConvertRESTBlindedCapellaBlockToProto(&apimiddleware.BlindedBeaconBlockCapellaJson{
+		Slot:          block.Slot,
+		ProposerIndex: block.ProposerIndex,
+		ParentRoot:    block.ParentRoot,
+		StateRoot:     block.StateRoot,
+		Body: &apimiddleware.BlindedBeaconBlockBodyCapellaJson{
+			RandaoReveal:      block.Body.RandaoReveal,
+			Eth1Data:          block.Body.Eth1Data,
+			Graffiti:          block.Body.Graffiti,
+			ProposerSlashings: block.Body.ProposerSlashings,
+			AttesterSlashings: block.Body.AttesterSlashings,
+			Attestations:      block.Body.Attestations,
+			Deposits:          block.Body.Deposits,
+			VoluntaryExits:    block.Body.VoluntaryExits,
+			SyncAggregate:     block.Body.SyncAggregate,
+			ExecutionPayloadHeader: &apimiddleware.ExecutionPayloadHeaderCapellaJson{
+				ParentHash:       header.ParentHash,
+				FeeRecipient:     header.FeeRecipient,
+				StateRoot:        header.StateRoot,
+				ReceiptsRoot:     header.ReceiptsRoot,
+				LogsBloom:        header.LogsBloom,
+				PrevRandao:       header.PrevRandao,
+				BlockNumber:      header.BlockNumber,
+				GasLimit:         header.GasLimit,
+				GasUsed:          header.GasUsed,
+				TimeStamp:        header.TimeStamp,
+				ExtraData:        header.ExtraData,
+				BaseFeePerGas:    header.BaseFeePerGas,
+				BlockHash:        header.BlockHash,
+				TransactionsRoot: header.TransactionsRoot,
+				WithdrawalsRoot:  header.WithdrawalsRoot,
+			},
+			BLSToExecutionChanges: block.Body.BLSToExecutionChanges,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the capella fields of the blinded deneb block")
+	}
+
+	blobGasUsed, err := strconv.ParseUint(header.BlobGasUsed, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse execution payload header blob gas used `%s`", header.BlobGasUsed)
+	}
+
+	excessBlobGas, err := strconv.ParseUint(header.ExcessBlobGas, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse execution payload header excess blob gas `%s`", header.ExcessBlobGas)
+	}
+
+	blobKzgCommitments, err := convertHexListToProto(block.Body.BlobKzgCommitments, "blob kzg commitment")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get blob kzg commitments")
+	}
+
+	capellaHeader := capellaBlock.Body.ExecutionPayloadHeader
+	return &ethpb.BlindedBeaconBlockDeneb{
+		Slot:          capellaBlock.Slot,
+		ProposerIndex: capellaBlock.ProposerIndex,
+		ParentRoot:    capellaBlock.ParentRoot,
+		StateRoot:     capellaBlock.StateRoot,
+		Body: &ethpb.BlindedBeaconBlockBodyDeneb{
+			RandaoReveal:      capellaBlock.Body.RandaoReveal,
+			Eth1Data:          capellaBlock.Body.Eth1Data,
+			Graffiti:          capellaBlock.Body.Graffiti,
+			ProposerSlashings: capellaBlock.Body.ProposerSlashings,
+			AttesterSlashings: capellaBlock.Body.AttesterSlashings,
+			Attestations:      capellaBlock.Body.Attestations,
+			Deposits:          capellaBlock.Body.Deposits,
+			VoluntaryExits:    capellaBlock.Body.VoluntaryExits,
+			SyncAggregate:     capellaBlock.Body.SyncAggregate,
+			ExecutionPayloadHeader: &enginev1.ExecutionPayloadHeaderDeneb{
+				ParentHash:       capellaHeader.ParentHash,
+				FeeRecipient:     capellaHeader.FeeRecipient,
+				StateRoot:        capellaHeader.StateRoot,
+				ReceiptsRoot:     capellaHeader.ReceiptsRoot,
+				LogsBloom:        capellaHeader.LogsBloom,
+				PrevRandao:       capellaHeader.PrevRandao,
+				BlockNumber:      capellaHeader.BlockNumber,
+				GasLimit:         capellaHeader.GasLimit,
+				GasUsed:          capellaHeader.GasUsed,
+				Timestamp:        capellaHeader.Timestamp,
+				ExtraData:        capellaHeader.ExtraData,
+				BaseFeePerGas:    capellaHeader.BaseFeePerGas,
+				BlockHash:        capellaHeader.BlockHash,
+				TransactionsRoot: capellaHeader.TransactionsRoot,
+				WithdrawalsRoot:  capellaHeader.WithdrawalsRoot,
+				BlobGasUsed:      blobGasUsed,
+				ExcessBlobGas:    excessBlobGas,
+			},
+			BlsToExecutionChanges: capellaBlock.Body.BlsToExecutionChanges,
+			BlobKzgCommitments:    blobKzgCommitments,
+		},
+	}, nil
+}
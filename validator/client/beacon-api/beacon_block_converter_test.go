@@ -503,3 +503,84 @@ func TestGetBeaconBlockConverter_CapellaError(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBeaconBlockConverter_DenebValid(t *testing.T) {
+	expectedBeaconBlock := test_helpers.GenerateProtoDenebBeaconBlock()
+	beaconBlockConverter := &beaconApiBeaconBlockConverter{}
+	beaconBlock, err := beaconBlockConverter.ConvertRESTDenebBlockToProto(test_helpers.GenerateJsonDenebBeaconBlock())
+	require.NoError(t, err)
+	assert.DeepEqual(t, expectedBeaconBlock, beaconBlock)
+}
+
+func TestGetBeaconBlockConverter_DenebError(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		expectedErrorMessage string
+		generateData         func() *apimiddleware.BeaconBlockDenebJson
+	}{
+		{
+			name:                 "nil body",
+			expectedErrorMessage: "block body is nil",
+			generateData: func() *apimiddleware.BeaconBlockDenebJson {
+				beaconBlock := test_helpers.GenerateJsonDenebBeaconBlock()
+				beaconBlock.Body = nil
+				return beaconBlock
+			},
+		},
+		{
+			name:                 "nil execution payload",
+			expectedErrorMessage: "execution payload is nil",
+			generateData: func() *apimiddleware.BeaconBlockDenebJson {
+				beaconBlock := test_helpers.GenerateJsonDenebBeaconBlock()
+				beaconBlock.Body.ExecutionPayload = nil
+				return beaconBlock
+			},
+		},
+		{
+			name:                 "bad capella fields",
+			expectedErrorMessage: "failed to get the capella fields of the deneb block",
+			generateData: func() *apimiddleware.BeaconBlockDenebJson {
+				beaconBlock := test_helpers.GenerateJsonDenebBeaconBlock()
+				beaconBlock.Body.Eth1Data = nil
+				return beaconBlock
+			},
+		},
+		{
+			name:                 "bad blob gas used",
+			expectedErrorMessage: "failed to parse execution payload blob gas used `foo`",
+			generateData: func() *apimiddleware.BeaconBlockDenebJson {
+				beaconBlock := test_helpers.GenerateJsonDenebBeaconBlock()
+				beaconBlock.Body.ExecutionPayload.BlobGasUsed = "foo"
+				return beaconBlock
+			},
+		},
+		{
+			name:                 "bad excess blob gas",
+			expectedErrorMessage: "failed to parse execution payload excess blob gas `bar`",
+			generateData: func() *apimiddleware.BeaconBlockDenebJson {
+				beaconBlock := test_helpers.GenerateJsonDenebBeaconBlock()
+				beaconBlock.Body.ExecutionPayload.ExcessBlobGas = "bar"
+				return beaconBlock
+			},
+		},
+		{
+			name:                 "bad blob kzg commitments",
+			expectedErrorMessage: "failed to get blob kzg commitments",
+			generateData: func() *apimiddleware.BeaconBlockDenebJson {
+				beaconBlock := test_helpers.GenerateJsonDenebBeaconBlock()
+				beaconBlock.Body.BlobKzgCommitments[0] = "foo"
+				return beaconBlock
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			beaconBlockJson := testCase.generateData()
+
+			beaconBlockConverter := &beaconApiBeaconBlockConverter{}
+			_, err := beaconBlockConverter.ConvertRESTDenebBlockToProto(beaconBlockJson)
+			assert.ErrorContains(t, testCase.expectedErrorMessage, err)
+		})
+	}
+}
@@ -364,6 +364,21 @@ func convertTransactionsToProto(jsonTransactions []string) ([][]byte, error) {
 	return transactions, nil
 }
 
+func convertHexListToProto(jsonItems []string, name string) ([][]byte, error) {
+	items := make([][]byte, len(jsonItems))
+
+	for index, jsonItem := range jsonItems {
+		item, err := hexutil.Decode(jsonItem)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode %s `%s`", name, jsonItem)
+		}
+
+		items[index] = item
+	}
+
+	return items, nil
+}
+
 func convertWithdrawalsToProto(jsonWithdrawals []*apimiddleware.WithdrawalJson) ([]*enginev1.Withdrawal, error) {
 	withdrawals := make([]*enginev1.Withdrawal, len(jsonWithdrawals))
 
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
@@ -236,3 +237,39 @@ func (c *beaconApiValidatorClient) getIndexToLiveness(ctx context.Context, epoch
 
 	return indexToLiveness, nil
 }
+
+func (c *beaconApiValidatorClient) getValidatorsLiveness(ctx context.Context, epoch primitives.Epoch, indices []primitives.ValidatorIndex) (*ethpbv2.GetLivenessResponse, error) {
+	stringIndices := make([]string, len(indices))
+	for i, index := range indices {
+		stringIndices[i] = strconv.FormatUint(uint64(index), 10)
+	}
+
+	livenessResponse, err := c.getLiveness(ctx, epoch, stringIndices)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get liveness for epoch %d", epoch)
+	}
+	if livenessResponse.Data == nil {
+		return nil, errors.New("liveness data is nil")
+	}
+
+	response := &ethpbv2.GetLivenessResponse{
+		Data: make([]*ethpbv2.GetLivenessResponse_Liveness, len(livenessResponse.Data)),
+	}
+	for i, liveness := range livenessResponse.Data {
+		if liveness == nil {
+			return nil, errors.New("liveness is nil")
+		}
+
+		index, err := strconv.ParseUint(liveness.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse validator index %s", liveness.Index)
+		}
+
+		response.Data[i] = &ethpbv2.GetLivenessResponse_Liveness{
+			Index:  primitives.ValidatorIndex(index),
+			IsLive: liveness.IsLive,
+		}
+	}
+
+	return response, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/validator"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
@@ -57,7 +58,7 @@ func TestCheckDoppelGanger_Nominal(t *testing.T) {
 		getLivelinessInterfaces []struct {
 			inputUrl           string
 			inputStringIndexes []string
-			output             *apimiddleware.LivenessResponseJson
+			output             *validator.GetLivenessResponse
 		}
 	}{
 		{
@@ -237,7 +238,7 @@ func TestCheckDoppelGanger_Nominal(t *testing.T) {
 			getLivelinessInterfaces: []struct {
 				inputUrl           string
 				inputStringIndexes []string
-				output             *apimiddleware.LivenessResponseJson
+				output             *validator.GetLivenessResponse
 			}{
 				{
 					inputUrl: "/eth/v1/validator/liveness/99", // previous epoch
@@ -249,11 +250,8 @@ func TestCheckDoppelGanger_Nominal(t *testing.T) {
 						// No "55555" since corresponding validator it does not exist
 						"66666", // not recent - not duplicate
 					},
-					output: &apimiddleware.LivenessResponseJson{
-						Data: []*struct {
-							Index  string `json:"index"`
-							IsLive bool   `json:"is_live"`
-						}{
+					output: &validator.GetLivenessResponse{
+						Data: []*validator.Liveness{
 							// No "11111" since corresponding validator is recent
 							{Index: "22222", IsLive: true},  // not recent - duplicate on previous epoch
 							{Index: "33333", IsLive: false}, // not recent - duplicate on current epoch
@@ -273,11 +271,8 @@ func TestCheckDoppelGanger_Nominal(t *testing.T) {
 						// No "55555" since corresponding validator it does not exist
 						"66666", // not recent - not duplicate
 					},
-					output: &apimiddleware.LivenessResponseJson{
-						Data: []*struct {
-							Index  string `json:"index"`
-							IsLive bool   `json:"is_live"`
-						}{
+					output: &validator.GetLivenessResponse{
+						Data: []*validator.Liveness{
 							// No "11111" since corresponding validator is recent
 							{Index: "22222", IsLive: false}, // not recent - duplicate on previous epoch
 							{Index: "33333", IsLive: true},  // not recent - duplicate on current epoch
@@ -350,7 +345,7 @@ func TestCheckDoppelGanger_Nominal(t *testing.T) {
 
 			if testCase.getLivelinessInterfaces != nil {
 				for _, iface := range testCase.getLivelinessInterfaces {
-					livenessResponseJson := apimiddleware.LivenessResponseJson{}
+					livenessResponseJson := validator.GetLivenessResponse{}
 
 					marshalledIndexes, err := json.Marshal(iface.inputStringIndexes)
 					require.NoError(t, err)
@@ -473,7 +468,7 @@ func TestCheckDoppelGanger_Errors(t *testing.T) {
 		getLivenessInterfaces []struct {
 			inputUrl           string
 			inputStringIndexes []string
-			output             *apimiddleware.LivenessResponseJson
+			output             *validator.GetLivenessResponse
 			err                error
 		}
 	}{
@@ -600,13 +595,13 @@ func TestCheckDoppelGanger_Errors(t *testing.T) {
 			getLivenessInterfaces: []struct {
 				inputUrl           string
 				inputStringIndexes []string
-				output             *apimiddleware.LivenessResponseJson
+				output             *validator.GetLivenessResponse
 				err                error
 			}{
 				{
 					inputUrl:           "/eth/v1/validator/liveness/30",
 					inputStringIndexes: []string{"42"},
-					output:             &apimiddleware.LivenessResponseJson{},
+					output:             &validator.GetLivenessResponse{},
 					err:                errors.New("custom error"),
 				},
 			},
@@ -622,17 +617,14 @@ func TestCheckDoppelGanger_Errors(t *testing.T) {
 			getLivenessInterfaces: []struct {
 				inputUrl           string
 				inputStringIndexes []string
-				output             *apimiddleware.LivenessResponseJson
+				output             *validator.GetLivenessResponse
 				err                error
 			}{
 				{
 					inputUrl:           "/eth/v1/validator/liveness/30",
 					inputStringIndexes: []string{"42"},
-					output: &apimiddleware.LivenessResponseJson{
-						Data: []*struct {
-							Index  string `json:"index"`
-							IsLive bool   `json:"is_live"`
-						}{nil},
+					output: &validator.GetLivenessResponse{
+						Data: []*validator.Liveness{nil},
 					},
 				},
 			},
@@ -648,23 +640,20 @@ func TestCheckDoppelGanger_Errors(t *testing.T) {
 			getLivenessInterfaces: []struct {
 				inputUrl           string
 				inputStringIndexes []string
-				output             *apimiddleware.LivenessResponseJson
+				output             *validator.GetLivenessResponse
 				err                error
 			}{
 				{
 					inputUrl:           "/eth/v1/validator/liveness/30",
 					inputStringIndexes: []string{"42"},
-					output: &apimiddleware.LivenessResponseJson{
-						Data: []*struct {
-							Index  string `json:"index"`
-							IsLive bool   `json:"is_live"`
-						}{},
+					output: &validator.GetLivenessResponse{
+						Data: []*validator.Liveness{},
 					},
 				},
 				{
 					inputUrl:           "/eth/v1/validator/liveness/31",
 					inputStringIndexes: []string{"42"},
-					output:             &apimiddleware.LivenessResponseJson{},
+					output:             &validator.GetLivenessResponse{},
 					err:                errors.New("custom error"),
 				},
 			},
@@ -680,27 +669,21 @@ func TestCheckDoppelGanger_Errors(t *testing.T) {
 			getLivenessInterfaces: []struct {
 				inputUrl           string
 				inputStringIndexes []string
-				output             *apimiddleware.LivenessResponseJson
+				output             *validator.GetLivenessResponse
 				err                error
 			}{
 				{
 					inputUrl:           "/eth/v1/validator/liveness/30",
 					inputStringIndexes: []string{"42"},
-					output: &apimiddleware.LivenessResponseJson{
-						Data: []*struct {
-							Index  string `json:"index"`
-							IsLive bool   `json:"is_live"`
-						}{},
+					output: &validator.GetLivenessResponse{
+						Data: []*validator.Liveness{},
 					},
 				},
 				{
 					inputUrl:           "/eth/v1/validator/liveness/31",
 					inputStringIndexes: []string{"42"},
-					output: &apimiddleware.LivenessResponseJson{
-						Data: []*struct {
-							Index  string `json:"index"`
-							IsLive bool   `json:"is_live"`
-						}{},
+					output: &validator.GetLivenessResponse{
+						Data: []*validator.Liveness{},
 					},
 				},
 			},
@@ -716,17 +699,14 @@ func TestCheckDoppelGanger_Errors(t *testing.T) {
 			getLivenessInterfaces: []struct {
 				inputUrl           string
 				inputStringIndexes []string
-				output             *apimiddleware.LivenessResponseJson
+				output             *validator.GetLivenessResponse
 				err                error
 			}{
 				{
 					inputUrl:           "/eth/v1/validator/liveness/30",
 					inputStringIndexes: []string{"42"},
-					output: &apimiddleware.LivenessResponseJson{
-						Data: []*struct {
-							Index  string `json:"index"`
-							IsLive bool   `json:"is_live"`
-						}{
+					output: &validator.GetLivenessResponse{
+						Data: []*validator.Liveness{
 							{
 								Index: "42",
 							},
@@ -736,11 +716,8 @@ func TestCheckDoppelGanger_Errors(t *testing.T) {
 				{
 					inputUrl:           "/eth/v1/validator/liveness/31",
 					inputStringIndexes: []string{"42"},
-					output: &apimiddleware.LivenessResponseJson{
-						Data: []*struct {
-							Index  string `json:"index"`
-							IsLive bool   `json:"is_live"`
-						}{},
+					output: &validator.GetLivenessResponse{
+						Data: []*validator.Liveness{},
 					},
 				},
 			},
@@ -820,7 +797,7 @@ func TestCheckDoppelGanger_Errors(t *testing.T) {
 
 			if testCase.getLivenessInterfaces != nil {
 				for _, iface := range testCase.getLivenessInterfaces {
-					livenessResponseJson := apimiddleware.LivenessResponseJson{}
+					livenessResponseJson := validator.GetLivenessResponse{}
 
 					marshalledIndexes, err := json.Marshal(iface.inputStringIndexes)
 					require.NoError(t, err)
@@ -15,8 +15,9 @@ import (
 )
 
 type abstractProduceBlockResponseJson struct {
-	Version string          `json:"version" enum:"true"`
-	Data    json.RawMessage `json:"data"`
+	Version                 string          `json:"version" enum:"true"`
+	ExecutionPayloadBlinded bool            `json:"execution_payload_blinded"`
+	Data                    json.RawMessage `json:"data"`
 }
 
 func (c beaconApiValidatorClient) getBeaconBlock(ctx context.Context, slot primitives.Slot, randaoReveal []byte, graffiti []byte) (*ethpb.GenericBeaconBlock, error) {
@@ -27,10 +28,12 @@ func (c beaconApiValidatorClient) getBeaconBlock(ctx context.Context, slot primi
 		queryParams.Add("graffiti", hexutil.Encode(graffiti))
 	}
 
-	queryUrl := buildURL(fmt.Sprintf("/eth/v2/validator/blocks/%d", slot), queryParams)
+	// The beacon node decides whether the block is built with a local or a builder payload, so the block is either full
+	// or blinded
+	queryUrl := buildURL(fmt.Sprintf("/eth/v3/validator/blocks/%d", slot), queryParams)
 
-	// Since we don't know yet what the json looks like, we unmarshal into an abstract structure that has only a version
-	// and a blob of data
+	// Since we don't know yet what the json looks like, we unmarshal into an abstract structure that has only a version,
+	// whether the block is blinded and a blob of data
 	produceBlockResponseJson := abstractProduceBlockResponseJson{}
 	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, queryUrl, &produceBlockResponseJson); err != nil {
 		return nil, errors.Wrap(err, "failed to query GET REST endpoint")
@@ -72,6 +75,22 @@ func (c beaconApiValidatorClient) getBeaconBlock(ctx context.Context, slot primi
 		}
 
 	case "bellatrix":
+		if produceBlockResponseJson.ExecutionPayloadBlinded {
+			jsonBellatrixBlock := apimiddleware.BlindedBeaconBlockBellatrixJson{}
+			if err := decoder.Decode(&jsonBellatrixBlock); err != nil {
+				return nil, errors.Wrap(err, "failed to decode blinded bellatrix block response json")
+			}
+
+			bellatrixBlock, err := c.beaconBlockConverter.ConvertRESTBlindedBellatrixBlockToProto(&jsonBellatrixBlock)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to get blinded bellatrix block")
+			}
+			response.Block = &ethpb.GenericBeaconBlock_BlindedBellatrix{
+				BlindedBellatrix: bellatrixBlock,
+			}
+			break
+		}
+
 		jsonBellatrixBlock := apimiddleware.BeaconBlockBellatrixJson{}
 		if err := decoder.Decode(&jsonBellatrixBlock); err != nil {
 			return nil, errors.Wrap(err, "failed to decode bellatrix block response json")
@@ -86,6 +105,22 @@ func (c beaconApiValidatorClient) getBeaconBlock(ctx context.Context, slot primi
 		}
 
 	case "capella":
+		if produceBlockResponseJson.ExecutionPayloadBlinded {
+			jsonCapellaBlock := apimiddleware.BlindedBeaconBlockCapellaJson{}
+			if err := decoder.Decode(&jsonCapellaBlock); err != nil {
+				return nil, errors.Wrap(err, "failed to decode blinded capella block response json")
+			}
+
+			capellaBlock, err := c.beaconBlockConverter.ConvertRESTBlindedCapellaBlockToProto(&jsonCapellaBlock)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to get blinded capella block")
+			}
+			response.Block = &ethpb.GenericBeaconBlock_BlindedCapella{
+				BlindedCapella: capellaBlock,
+			}
+			break
+		}
+
 		jsonCapellaBlock := apimiddleware.BeaconBlockCapellaJson{}
 		if err := decoder.Decode(&jsonCapellaBlock); err != nil {
 			return nil, errors.Wrap(err, "failed to decode capella block response json")
@@ -99,6 +134,52 @@ func (c beaconApiValidatorClient) getBeaconBlock(ctx context.Context, slot primi
 			Capella: capellaBlock,
 		}
 
+	case "deneb":
+		if produceBlockResponseJson.ExecutionPayloadBlinded {
+			jsonDenebBlock := apimiddleware.BlindedBeaconBlockDenebJson{}
+			if err := decoder.Decode(&jsonDenebBlock); err != nil {
+				return nil, errors.Wrap(err, "failed to decode blinded deneb block response json")
+			}
+
+			denebBlock, err := c.beaconBlockConverter.ConvertRESTBlindedDenebBlockToProto(&jsonDenebBlock)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to get blinded deneb block")
+			}
+			response.Block = &ethpb.GenericBeaconBlock_BlindedDeneb{
+				BlindedDeneb: denebBlock,
+			}
+			break
+		}
+
+		// Full deneb blocks are served along with the blobs and kzg proofs of the block
+		jsonDenebBlockContents := apimiddleware.BeaconBlockContentsDenebJson{}
+		if err := decoder.Decode(&jsonDenebBlockContents); err != nil {
+			return nil, errors.Wrap(err, "failed to decode deneb block response json")
+		}
+		if jsonDenebBlockContents.Block == nil {
+			return nil, errors.New("deneb block is nil")
+		}
+
+		denebBlock, err := c.beaconBlockConverter.ConvertRESTDenebBlockToProto(jsonDenebBlockContents.Block)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get deneb block")
+		}
+		kzgProofs, err := convertHexListToProto(jsonDenebBlockContents.KzgProofs, "kzg proof")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get deneb kzg proofs")
+		}
+		blobs, err := convertHexListToProto(jsonDenebBlockContents.Blobs, "blob")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get deneb blobs")
+		}
+		response.Block = &ethpb.GenericBeaconBlock_Deneb{
+			Deneb: &ethpb.BeaconBlockContentsDeneb{
+				Block:     denebBlock,
+				KzgProofs: kzgProofs,
+				Blobs:     blobs,
+			},
+		}
+
 	default:
 		return nil, errors.Errorf("unsupported consensus version `%s`", produceBlockResponseJson.Version)
 	}
@@ -49,6 +49,8 @@ func TestGetBeaconBlock_Error(t *testing.T) {
 	require.NoError(t, err)
 	capellaBeaconBlockBytes, err := json.Marshal(apimiddleware.BeaconBlockCapellaJson{})
 	require.NoError(t, err)
+	denebBeaconBlockBytes, err := json.Marshal(apimiddleware.BeaconBlockContentsDenebJson{Block: &apimiddleware.BeaconBlockDenebJson{}})
+	require.NoError(t, err)
 
 	testCases := []struct {
 		name                 string
@@ -107,6 +109,18 @@ func TestGetBeaconBlock_Error(t *testing.T) {
 			consensusVersion:     "capella",
 			data:                 capellaBeaconBlockBytes,
 		},
+		{
+			name:                 "deneb block decoding failed",
+			expectedErrorMessage: "failed to decode deneb block response json",
+			consensusVersion:     "deneb",
+			data:                 []byte{},
+		},
+		{
+			name:                 "deneb block conversion failed",
+			expectedErrorMessage: "failed to get deneb block",
+			consensusVersion:     "deneb",
+			data:                 denebBeaconBlockBytes,
+		},
 		{
 			name:                 "unsupported consensus version",
 			expectedErrorMessage: "unsupported consensus version `foo`",
@@ -166,6 +180,13 @@ func TestGetBeaconBlock_Error(t *testing.T) {
 				errors.New(testCase.expectedErrorMessage),
 			).AnyTimes()
 
+			beaconBlockConverter.EXPECT().ConvertRESTDenebBlockToProto(
+				gomock.Any(),
+			).Return(
+				nil,
+				errors.New(testCase.expectedErrorMessage),
+			).AnyTimes()
+
 			validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler, beaconBlockConverter: beaconBlockConverter}
 			_, err := validatorClient.getBeaconBlock(ctx, 1, []byte{1}, []byte{2})
 			assert.ErrorContains(t, testCase.expectedErrorMessage, err)
@@ -190,7 +211,7 @@ func TestGetBeaconBlock_Phase0Valid(t *testing.T) {
 	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
 	jsonRestHandler.EXPECT().GetRestJsonResponse(
 		ctx,
-		fmt.Sprintf("/eth/v2/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
+		fmt.Sprintf("/eth/v3/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
 		&abstractProduceBlockResponseJson{},
 	).SetArg(
 		2,
@@ -242,7 +263,7 @@ func TestGetBeaconBlock_AltairValid(t *testing.T) {
 	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
 	jsonRestHandler.EXPECT().GetRestJsonResponse(
 		ctx,
-		fmt.Sprintf("/eth/v2/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
+		fmt.Sprintf("/eth/v3/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
 		&abstractProduceBlockResponseJson{},
 	).SetArg(
 		2,
@@ -294,7 +315,7 @@ func TestGetBeaconBlock_BellatrixValid(t *testing.T) {
 	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
 	jsonRestHandler.EXPECT().GetRestJsonResponse(
 		ctx,
-		fmt.Sprintf("/eth/v2/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
+		fmt.Sprintf("/eth/v3/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
 		&abstractProduceBlockResponseJson{},
 	).SetArg(
 		2,
@@ -346,7 +367,7 @@ func TestGetBeaconBlock_CapellaValid(t *testing.T) {
 	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
 	jsonRestHandler.EXPECT().GetRestJsonResponse(
 		ctx,
-		fmt.Sprintf("/eth/v2/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
+		fmt.Sprintf("/eth/v3/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
 		&abstractProduceBlockResponseJson{},
 	).SetArg(
 		2,
@@ -379,3 +400,191 @@ func TestGetBeaconBlock_CapellaValid(t *testing.T) {
 
 	assert.DeepEqual(t, expectedBeaconBlock, beaconBlock)
 }
+
+func TestGetBeaconBlock_BlindedCapellaValid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blindedCapellaProtoBeaconBlock := &ethpb.BlindedBeaconBlockCapella{
+		Slot:          1,
+		ProposerIndex: 2,
+		ParentRoot:    []byte{3},
+		StateRoot:     []byte{4},
+	}
+	blindedCapellaBeaconBlock := &apimiddleware.BlindedBeaconBlockCapellaJson{
+		Slot:          "1",
+		ProposerIndex: "2",
+		ParentRoot:    hexutil.Encode([]byte{3}),
+		StateRoot:     hexutil.Encode([]byte{4}),
+		Body:          &apimiddleware.BlindedBeaconBlockBodyCapellaJson{},
+	}
+	blindedCapellaBeaconBlockBytes, err := json.Marshal(blindedCapellaBeaconBlock)
+	require.NoError(t, err)
+
+	const slot = primitives.Slot(1)
+	randaoReveal := []byte{2}
+	graffiti := []byte{3}
+
+	ctx := context.Background()
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().GetRestJsonResponse(
+		ctx,
+		fmt.Sprintf("/eth/v3/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
+		&abstractProduceBlockResponseJson{},
+	).SetArg(
+		2,
+		abstractProduceBlockResponseJson{
+			Version:                 "capella",
+			ExecutionPayloadBlinded: true,
+			Data:                    blindedCapellaBeaconBlockBytes,
+		},
+	).Return(
+		nil,
+		nil,
+	).Times(1)
+
+	beaconBlockConverter := mock.NewMockbeaconBlockConverter(ctrl)
+	beaconBlockConverter.EXPECT().ConvertRESTBlindedCapellaBlockToProto(
+		blindedCapellaBeaconBlock,
+	).Return(
+		blindedCapellaProtoBeaconBlock,
+		nil,
+	).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler, beaconBlockConverter: beaconBlockConverter}
+	beaconBlock, err := validatorClient.getBeaconBlock(ctx, slot, randaoReveal, graffiti)
+	require.NoError(t, err)
+
+	expectedBeaconBlock := &ethpb.GenericBeaconBlock{
+		Block: &ethpb.GenericBeaconBlock_BlindedCapella{
+			BlindedCapella: blindedCapellaProtoBeaconBlock,
+		},
+	}
+
+	assert.DeepEqual(t, expectedBeaconBlock, beaconBlock)
+}
+
+func TestGetBeaconBlock_DenebValid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	denebProtoBeaconBlock := test_helpers.GenerateProtoDenebBeaconBlock()
+	denebBeaconBlock := test_helpers.GenerateJsonDenebBeaconBlock()
+	denebBeaconBlockContentsBytes, err := json.Marshal(apimiddleware.BeaconBlockContentsDenebJson{
+		Block:     denebBeaconBlock,
+		KzgProofs: []string{hexutil.Encode([]byte{5})},
+		Blobs:     []string{hexutil.Encode([]byte{6})},
+	})
+	require.NoError(t, err)
+
+	const slot = primitives.Slot(1)
+	randaoReveal := []byte{2}
+	graffiti := []byte{3}
+
+	ctx := context.Background()
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().GetRestJsonResponse(
+		ctx,
+		fmt.Sprintf("/eth/v3/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
+		&abstractProduceBlockResponseJson{},
+	).SetArg(
+		2,
+		abstractProduceBlockResponseJson{
+			Version: "deneb",
+			Data:    denebBeaconBlockContentsBytes,
+		},
+	).Return(
+		nil,
+		nil,
+	).Times(1)
+
+	beaconBlockConverter := mock.NewMockbeaconBlockConverter(ctrl)
+	beaconBlockConverter.EXPECT().ConvertRESTDenebBlockToProto(
+		denebBeaconBlock,
+	).Return(
+		denebProtoBeaconBlock,
+		nil,
+	).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler, beaconBlockConverter: beaconBlockConverter}
+	beaconBlock, err := validatorClient.getBeaconBlock(ctx, slot, randaoReveal, graffiti)
+	require.NoError(t, err)
+
+	expectedBeaconBlock := &ethpb.GenericBeaconBlock{
+		Block: &ethpb.GenericBeaconBlock_Deneb{
+			Deneb: &ethpb.BeaconBlockContentsDeneb{
+				Block:     denebProtoBeaconBlock,
+				KzgProofs: [][]byte{{5}},
+				Blobs:     [][]byte{{6}},
+			},
+		},
+	}
+
+	assert.DeepEqual(t, expectedBeaconBlock, beaconBlock)
+}
+
+func TestGetBeaconBlock_BlindedDenebValid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blindedDenebProtoBeaconBlock := &ethpb.BlindedBeaconBlockDeneb{
+		Slot:          1,
+		ProposerIndex: 2,
+		ParentRoot:    []byte{3},
+		StateRoot:     []byte{4},
+	}
+	blindedDenebBeaconBlock := &apimiddleware.BlindedBeaconBlockDenebJson{
+		Slot:          "1",
+		ProposerIndex: "2",
+		ParentRoot:    hexutil.Encode([]byte{3}),
+		StateRoot:     hexutil.Encode([]byte{4}),
+		Body:          &apimiddleware.BlindedBeaconBlockBodyDenebJson{},
+	}
+	blindedDenebBeaconBlockBytes, err := json.Marshal(blindedDenebBeaconBlock)
+	require.NoError(t, err)
+
+	const slot = primitives.Slot(1)
+	randaoReveal := []byte{2}
+	graffiti := []byte{3}
+
+	ctx := context.Background()
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().GetRestJsonResponse(
+		ctx,
+		fmt.Sprintf("/eth/v3/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
+		&abstractProduceBlockResponseJson{},
+	).SetArg(
+		2,
+		abstractProduceBlockResponseJson{
+			Version:                 "deneb",
+			ExecutionPayloadBlinded: true,
+			Data:                    blindedDenebBeaconBlockBytes,
+		},
+	).Return(
+		nil,
+		nil,
+	).Times(1)
+
+	beaconBlockConverter := mock.NewMockbeaconBlockConverter(ctrl)
+	beaconBlockConverter.EXPECT().ConvertRESTBlindedDenebBlockToProto(
+		blindedDenebBeaconBlock,
+	).Return(
+		blindedDenebProtoBeaconBlock,
+		nil,
+	).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler, beaconBlockConverter: beaconBlockConverter}
+	beaconBlock, err := validatorClient.getBeaconBlock(ctx, slot, randaoReveal, graffiti)
+	require.NoError(t, err)
+
+	expectedBeaconBlock := &ethpb.GenericBeaconBlock{
+		Block: &ethpb.GenericBeaconBlock_BlindedDeneb{
+			BlindedDeneb: blindedDenebProtoBeaconBlock,
+		},
+	}
+
+	assert.DeepEqual(t, expectedBeaconBlock, beaconBlock)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/pkg/errors"
@@ -15,6 +16,34 @@ type jsonRestHandler interface {
 	PostRestJson(ctx context.Context, apiEndpoint string, headers map[string]string, data *bytes.Buffer, responseJson interface{}) (*apimiddleware.DefaultErrorJson, error)
 }
 
+// HttpError is returned when the beacon node answers a request with an HTTP error status code.
+type HttpError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *HttpError) Error() string {
+	return fmt.Sprintf("error %d: %s", e.StatusCode, e.Message)
+}
+
+// ErrNotSupported is returned when the beacon node does not serve an endpoint needed to answer a request.
+var ErrNotSupported = errors.New("endpoint is not supported by the beacon node")
+
+// wrapNotSupported wraps the error returned by a request to apiEndpoint with ErrNotSupported when the beacon node
+// answered that it does not serve the endpoint.
+func wrapNotSupported(err error, apiEndpoint string) error {
+	httpErr := &HttpError{}
+	if !errors.As(err, &httpErr) {
+		return err
+	}
+	switch httpErr.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return errors.Wrapf(ErrNotSupported, "%s: %s", apiEndpoint, httpErr.Message)
+	default:
+		return err
+	}
+}
+
 type beaconApiJsonRestHandler struct {
 	httpClient http.Client
 	host       string
@@ -87,10 +116,13 @@ func decodeJsonResp(resp *http.Response, responseJson interface{}) (*apimiddlewa
 	if resp.StatusCode != http.StatusOK {
 		errorJson := &apimiddleware.DefaultErrorJson{}
 		if err := decoder.Decode(errorJson); err != nil {
-			return nil, errors.Wrapf(err, "failed to decode error json for %s", resp.Request.URL)
+			return nil, &HttpError{
+				StatusCode: resp.StatusCode,
+				Message:    errors.Wrapf(err, "failed to decode error json for %s", resp.Request.URL).Error(),
+			}
 		}
 
-		return errorJson, errors.Errorf("error %d: %s", errorJson.Code, errorJson.Message)
+		return errorJson, &HttpError{StatusCode: resp.StatusCode, Message: errorJson.Message}
 	}
 
 	if responseJson != nil {
@@ -0,0 +1,95 @@
+package test_helpers
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+// GenerateProtoDenebBeaconBlock extends the capella test block with the fields added in Deneb.
+func GenerateProtoDenebBeaconBlock() *ethpb.BeaconBlockDeneb {
+	capellaBlock := GenerateProtoCapellaBeaconBlock()
+	payload := capellaBlock.Body.ExecutionPayload
+	return &ethpb.BeaconBlockDeneb{
+		Slot:          capellaBlock.Slot,
+		ProposerIndex: capellaBlock.ProposerIndex,
+		ParentRoot:    capellaBlock.ParentRoot,
+		StateRoot:     capellaBlock.StateRoot,
+		Body: &ethpb.BeaconBlockBodyDeneb{
+			RandaoReveal:      capellaBlock.Body.RandaoReveal,
+			Eth1Data:          capellaBlock.Body.Eth1Data,
+			Graffiti:          capellaBlock.Body.Graffiti,
+			ProposerSlashings: capellaBlock.Body.ProposerSlashings,
+			AttesterSlashings: capellaBlock.Body.AttesterSlashings,
+			Attestations:      capellaBlock.Body.Attestations,
+			Deposits:          capellaBlock.Body.Deposits,
+			VoluntaryExits:    capellaBlock.Body.VoluntaryExits,
+			SyncAggregate:     capellaBlock.Body.SyncAggregate,
+			ExecutionPayload: &enginev1.ExecutionPayloadDeneb{
+				ParentHash:    payload.ParentHash,
+				FeeRecipient:  payload.FeeRecipient,
+				StateRoot:     payload.StateRoot,
+				ReceiptsRoot:  payload.ReceiptsRoot,
+				LogsBloom:     payload.LogsBloom,
+				PrevRandao:    payload.PrevRandao,
+				BlockNumber:   payload.BlockNumber,
+				GasLimit:      payload.GasLimit,
+				GasUsed:       payload.GasUsed,
+				Timestamp:     payload.Timestamp,
+				ExtraData:     payload.ExtraData,
+				BaseFeePerGas: payload.BaseFeePerGas,
+				BlockHash:     payload.BlockHash,
+				Transactions:  payload.Transactions,
+				Withdrawals:   payload.Withdrawals,
+				BlobGasUsed:   200,
+				ExcessBlobGas: 201,
+			},
+			BlsToExecutionChanges: capellaBlock.Body.BlsToExecutionChanges,
+			BlobKzgCommitments:    [][]byte{FillByteSlice(48, 202), FillByteSlice(48, 203)},
+		},
+	}
+}
+
+// GenerateJsonDenebBeaconBlock extends the capella test block with the fields added in Deneb.
+func GenerateJsonDenebBeaconBlock() *apimiddleware.BeaconBlockDenebJson {
+	capellaBlock := GenerateJsonCapellaBeaconBlock()
+	payload := capellaBlock.Body.ExecutionPayload
+	return &apimiddleware.BeaconBlockDenebJson{
+		Slot:          capellaBlock.Slot,
+		ProposerIndex: capellaBlock.ProposerIndex,
+		ParentRoot:    capellaBlock.ParentRoot,
+		StateRoot:     capellaBlock.StateRoot,
+		Body: &apimiddleware.BeaconBlockBodyDenebJson{
+			RandaoReveal:      capellaBlock.Body.RandaoReveal,
+			Eth1Data:          capellaBlock.Body.Eth1Data,
+			Graffiti:          capellaBlock.Body.Graffiti,
+			ProposerSlashings: capellaBlock.Body.ProposerSlashings,
+			AttesterSlashings: capellaBlock.Body.AttesterSlashings,
+			Attestations:      capellaBlock.Body.Attestations,
+			Deposits:          capellaBlock.Body.Deposits,
+			VoluntaryExits:    capellaBlock.Body.VoluntaryExits,
+			SyncAggregate:     capellaBlock.Body.SyncAggregate,
+			ExecutionPayload: &apimiddleware.ExecutionPayloadDenebJson{
+				ParentHash:    payload.ParentHash,
+				FeeRecipient:  payload.FeeRecipient,
+				StateRoot:     payload.StateRoot,
+				ReceiptsRoot:  payload.ReceiptsRoot,
+				LogsBloom:     payload.LogsBloom,
+				PrevRandao:    payload.PrevRandao,
+				BlockNumber:   payload.BlockNumber,
+				GasLimit:      payload.GasLimit,
+				GasUsed:       payload.GasUsed,
+				TimeStamp:     payload.TimeStamp,
+				ExtraData:     payload.ExtraData,
+				BaseFeePerGas: payload.BaseFeePerGas,
+				BlockHash:     payload.BlockHash,
+				Transactions:  payload.Transactions,
+				Withdrawals:   payload.Withdrawals,
+				BlobGasUsed:   "200",
+				ExcessBlobGas: "201",
+			},
+			BLSToExecutionChanges: capellaBlock.Body.BLSToExecutionChanges,
+			BlobKzgCommitments:    []string{FillEncodedByteSlice(48, 202), FillEncodedByteSlice(48, 203)},
+		},
+	}
+}
@@ -0,0 +1,434 @@
+package beacon_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/rewards"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// GetValidatorPerformance reports the performance of the requested validators during the most recent epoch whose
+// attestation rewards have been fully applied. Beacon nodes can only compute the attestation rewards of an epoch
+// once the following epoch is over, so this is the epoch before the previous one. The balances before and after
+// the epoch transition are the balances at the last slot of the previous epoch and at the first slot of the current epoch.
+// ErrNotSupported is returned when the beacon node does not serve the attestation rewards.
+func (c beaconApiBeaconChainClient) GetValidatorPerformance(ctx context.Context, in *ethpb.ValidatorPerformanceRequest) (*ethpb.ValidatorPerformanceResponse, error) {
+	blockHeader, err := c.getHeadBlockHeaders(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get head block headers")
+	}
+
+	headSlot, err := strconv.ParseUint(blockHeader.Data.Header.Message.Slot, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse head block slot `%s`", blockHeader.Data.Header.Message.Slot)
+	}
+
+	currentEpoch := slots.ToEpoch(primitives.Slot(headSlot))
+	currentEpochStart, err := slots.EpochStart(currentEpoch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get first slot for epoch `%d`", currentEpoch)
+	}
+
+	pubkeys := make([]string, 0, len(in.PublicKeys))
+	for _, pubkey := range in.PublicKeys {
+		// Skip empty public keys, like the gRPC API does.
+		if len(pubkey) == 0 {
+			continue
+		}
+		pubkeys = append(pubkeys, hexutil.Encode(pubkey))
+	}
+
+	afterValidators, err := c.stateValidatorsProvider.GetStateValidatorsForSlot(ctx, currentEpochStart, pubkeys, in.Indices, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get state validators for slot `%d`", currentEpochStart)
+	}
+
+	found := make(map[string]bool, len(afterValidators.Data))
+	activeIndices := make([]primitives.ValidatorIndex, 0, len(afterValidators.Data))
+	validatorPubkeys := make(map[primitives.ValidatorIndex][]byte, len(afterValidators.Data))
+	afterBalances := make(map[primitives.ValidatorIndex]uint64, len(afterValidators.Data))
+	effectiveBalances := make(map[primitives.ValidatorIndex]uint64, len(afterValidators.Data))
+	missingValidators := make([][]byte, 0)
+	for idx, stateValidator := range afterValidators.Data {
+		if stateValidator == nil || stateValidator.Validator == nil {
+			return nil, errors.Errorf("state validator at index `%d` is nil", idx)
+		}
+
+		validatorIndex, validator, err := convertValidatorJsonToProto(stateValidator)
+		if err != nil {
+			return nil, err
+		}
+		found[stateValidator.Validator.PublicKey] = true
+
+		status, ok := beaconAPITogRPCValidatorStatus[stateValidator.Status]
+		if !ok {
+			return nil, errors.Errorf("invalid validator status `%s`", stateValidator.Status)
+		}
+		if status != ethpb.ValidatorStatus_ACTIVE && status != ethpb.ValidatorStatus_EXITING && status != ethpb.ValidatorStatus_SLASHING {
+			// Inactive validator; treat it as missing.
+			missingValidators = append(missingValidators, validator.PublicKey)
+			continue
+		}
+
+		balance, err := strconv.ParseUint(stateValidator.Balance, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse validator balance `%s`", stateValidator.Balance)
+		}
+
+		activeIndices = append(activeIndices, validatorIndex)
+		validatorPubkeys[validatorIndex] = validator.PublicKey
+		afterBalances[validatorIndex] = balance
+		effectiveBalances[validatorIndex] = validator.EffectiveBalance
+	}
+
+	for _, pubkey := range pubkeys {
+		if !found[pubkey] {
+			decodedPubkey, err := hexutil.Decode(pubkey)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decode pubkey `%s`", pubkey)
+			}
+			missingValidators = append(missingValidators, decodedPubkey)
+		}
+	}
+
+	sort.Slice(activeIndices, func(i, j int) bool {
+		return activeIndices[i] < activeIndices[j]
+	})
+
+	response := &ethpb.ValidatorPerformanceResponse{
+		PublicKeys:                    make([][]byte, 0, len(activeIndices)),
+		CorrectlyVotedSource:          make([]bool, 0, len(activeIndices)),
+		CorrectlyVotedTarget:          make([]bool, 0, len(activeIndices)),
+		CorrectlyVotedHead:            make([]bool, 0, len(activeIndices)),
+		CurrentEffectiveBalances:      make([]uint64, 0, len(activeIndices)),
+		BalancesBeforeEpochTransition: make([]uint64, 0, len(activeIndices)),
+		BalancesAfterEpochTransition:  make([]uint64, 0, len(activeIndices)),
+		MissingValidators:             missingValidators,
+	}
+
+	if len(activeIndices) == 0 {
+		return response, nil
+	}
+
+	beforeBalances := afterBalances
+	totalRewards := make(map[primitives.ValidatorIndex]*rewards.TotalAttestationReward)
+	// There is no epoch transition to report on before the third epoch of the chain.
+	if currentEpoch >= 2 {
+		beforeValidators, err := c.stateValidatorsProvider.GetStateValidatorsForSlot(ctx, currentEpochStart-1, nil, activeIndices, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get state validators for slot `%d`", currentEpochStart-1)
+		}
+
+		beforeBalances = make(map[primitives.ValidatorIndex]uint64, len(beforeValidators.Data))
+		for idx, stateValidator := range beforeValidators.Data {
+			if stateValidator == nil {
+				return nil, errors.Errorf("state validator at index `%d` is nil", idx)
+			}
+
+			validatorIndex, err := strconv.ParseUint(stateValidator.Index, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse validator index `%s`", stateValidator.Index)
+			}
+
+			balance, err := strconv.ParseUint(stateValidator.Balance, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse validator balance `%s`", stateValidator.Balance)
+			}
+
+			beforeBalances[primitives.ValidatorIndex(validatorIndex)] = balance
+		}
+
+		attestationRewards, err := c.getAttestationRewards(ctx, currentEpoch-2, activeIndices)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, reward := range attestationRewards.Data.TotalRewards {
+			validatorIndex, err := strconv.ParseUint(reward.ValidatorIndex, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse validator index `%s`", reward.ValidatorIndex)
+			}
+			reward := reward
+			totalRewards[primitives.ValidatorIndex(validatorIndex)] = &reward
+		}
+
+		if currentEpoch-2 >= params.BeaconConfig().AltairForkEpoch {
+			response.InactivityScores = make([]uint64, 0, len(activeIndices))
+		}
+	}
+
+	for _, validatorIndex := range activeIndices {
+		beforeBalance, ok := beforeBalances[validatorIndex]
+		if !ok {
+			// The validator did not exist at the end of the previous epoch; treat it as missing.
+			response.MissingValidators = append(response.MissingValidators, validatorPubkeys[validatorIndex])
+			continue
+		}
+
+		var correctlyVotedSource, correctlyVotedTarget, correctlyVotedHead bool
+		var inactivityScore uint64
+		if reward, ok := totalRewards[validatorIndex]; ok {
+			if correctlyVotedSource, err = isCorrectVote(reward.Source, true); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse source reward `%s`", reward.Source)
+			}
+			if correctlyVotedTarget, err = isCorrectVote(reward.Target, true); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse target reward `%s`", reward.Target)
+			}
+			if correctlyVotedHead, err = isCorrectVote(reward.Head, false); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse head reward `%s`", reward.Head)
+			}
+			if inactivityScore, err = inactivityScoreFromPenalty(reward.Inactivity, effectiveBalances[validatorIndex], currentEpoch-2); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse inactivity penalty `%s`", reward.Inactivity)
+			}
+		}
+
+		response.PublicKeys = append(response.PublicKeys, validatorPubkeys[validatorIndex])
+		response.CorrectlyVotedSource = append(response.CorrectlyVotedSource, correctlyVotedSource)
+		response.CorrectlyVotedTarget = append(response.CorrectlyVotedTarget, correctlyVotedTarget)
+		response.CorrectlyVotedHead = append(response.CorrectlyVotedHead, correctlyVotedHead)
+		response.CurrentEffectiveBalances = append(response.CurrentEffectiveBalances, effectiveBalances[validatorIndex])
+		response.BalancesBeforeEpochTransition = append(response.BalancesBeforeEpochTransition, beforeBalance)
+		response.BalancesAfterEpochTransition = append(response.BalancesAfterEpochTransition, afterBalances[validatorIndex])
+		if response.InactivityScores != nil {
+			response.InactivityScores = append(response.InactivityScores, inactivityScore)
+		}
+	}
+
+	return response, nil
+}
+
+// GetValidatorParticipation computes the participation of the previous epoch of the requested epoch from the
+// attestation rewards of that epoch. The attesting balance of the requested epoch itself comes from the liveness
+// of its active validators, which beacon nodes only report for their current and previous epochs; the target and
+// head attesting balances of the requested epoch cannot be derived from the standard API and are left empty. If no
+// epoch is requested, the most recent epoch for which beacon nodes can compute the attestation rewards of the
+// previous epoch is used. ErrNotSupported is returned when the beacon node does not serve these endpoints.
+func (c beaconApiBeaconChainClient) GetValidatorParticipation(ctx context.Context, in *ethpb.GetValidatorParticipationRequest) (*ethpb.ValidatorParticipationResponse, error) {
+	const finalityCheckpointsEndpoint = "/eth/v1/beacon/states/head/finality_checkpoints"
+
+	blockHeader, err := c.getHeadBlockHeaders(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get head block headers")
+	}
+
+	headSlot, err := strconv.ParseUint(blockHeader.Data.Header.Message.Slot, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse head block slot `%s`", blockHeader.Data.Header.Message.Slot)
+	}
+
+	currentEpoch := slots.ToEpoch(primitives.Slot(headSlot))
+
+	var requestedEpoch primitives.Epoch
+	switch queryFilter := in.QueryFilter.(type) {
+	case *ethpb.GetValidatorParticipationRequest_Genesis:
+		requestedEpoch = 0
+	case *ethpb.GetValidatorParticipationRequest_Epoch:
+		requestedEpoch = queryFilter.Epoch
+	default:
+		if currentEpoch > 0 {
+			requestedEpoch = currentEpoch - 1
+		}
+	}
+
+	if requestedEpoch > currentEpoch {
+		return nil, errors.Errorf("cannot retrieve information about an epoch greater than current epoch, current epoch %d, requesting %d", currentEpoch, requestedEpoch)
+	}
+
+	requestedEpochStart, err := slots.EpochStart(requestedEpoch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get first slot for epoch `%d`", requestedEpoch)
+	}
+
+	stateValidators, err := c.stateValidatorsProvider.GetStateValidatorsForSlot(ctx, requestedEpochStart, nil, nil, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get state validators for slot `%d`", requestedEpochStart)
+	}
+
+	type activeValidator struct {
+		effectiveBalance uint64
+		slashed          bool
+	}
+	previousEpochValidators := make(map[primitives.ValidatorIndex]activeValidator)
+	currentEpochBalances := make(map[string]uint64)
+	participation := &ethpb.ValidatorParticipation{}
+	for idx, stateValidator := range stateValidators.Data {
+		if stateValidator == nil || stateValidator.Validator == nil {
+			return nil, errors.Errorf("state validator at index `%d` is nil", idx)
+		}
+
+		validatorIndex, validator, err := convertValidatorJsonToProto(stateValidator)
+		if err != nil {
+			return nil, err
+		}
+
+		if validator.ActivationEpoch <= requestedEpoch && requestedEpoch < validator.ExitEpoch {
+			participation.CurrentEpochActiveGwei += validator.EffectiveBalance
+			currentEpochBalances[stateValidator.Index] = validator.EffectiveBalance
+		}
+		if requestedEpoch > 0 && validator.ActivationEpoch <= requestedEpoch-1 && requestedEpoch-1 < validator.ExitEpoch {
+			participation.PreviousEpochActiveGwei += validator.EffectiveBalance
+			previousEpochValidators[validatorIndex] = activeValidator{effectiveBalance: validator.EffectiveBalance, slashed: validator.Slashed}
+		}
+	}
+
+	// Beacon nodes are only required to know the liveness of validators during the current and previous epochs.
+	if requestedEpoch+1 >= currentEpoch && len(currentEpochBalances) > 0 {
+		indices := make([]string, 0, len(currentEpochBalances))
+		for index := range currentEpochBalances {
+			indices = append(indices, index)
+		}
+		sort.Strings(indices)
+
+		liveness, err := getLiveness(ctx, c.jsonRestHandler, requestedEpoch, indices)
+		if err != nil {
+			return nil, wrapNotSupported(err, "/eth/v1/validator/liveness/"+strconv.FormatUint(uint64(requestedEpoch), 10))
+		}
+
+		for _, validatorLiveness := range liveness.Data {
+			if validatorLiveness == nil {
+				return nil, errors.New("validator liveness is nil")
+			}
+			if validatorLiveness.IsLive {
+				participation.CurrentEpochAttestingGwei += currentEpochBalances[validatorLiveness.Index]
+			}
+		}
+	}
+
+	if requestedEpoch > 0 {
+		attestationRewards, err := c.getAttestationRewards(ctx, requestedEpoch-1, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, reward := range attestationRewards.Data.TotalRewards {
+			validatorIndex, err := strconv.ParseUint(reward.ValidatorIndex, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse validator index `%s`", reward.ValidatorIndex)
+			}
+
+			validator, ok := previousEpochValidators[primitives.ValidatorIndex(validatorIndex)]
+			if !ok || validator.slashed {
+				continue
+			}
+
+			correctlyVotedSource, err := isCorrectVote(reward.Source, true)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse source reward `%s`", reward.Source)
+			}
+			correctlyVotedTarget, err := isCorrectVote(reward.Target, true)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse target reward `%s`", reward.Target)
+			}
+			correctlyVotedHead, err := isCorrectVote(reward.Head, false)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse head reward `%s`", reward.Head)
+			}
+
+			if correctlyVotedSource || correctlyVotedTarget {
+				participation.PreviousEpochAttestingGwei += validator.effectiveBalance
+			}
+			if correctlyVotedTarget {
+				participation.PreviousEpochTargetAttestingGwei += validator.effectiveBalance
+			}
+			if correctlyVotedHead {
+				participation.PreviousEpochHeadAttestingGwei += validator.effectiveBalance
+			}
+		}
+	}
+
+	// TODO(7130): Remove these three deprecated fields.
+	if participation.PreviousEpochActiveGwei > 0 {
+		participation.GlobalParticipationRate = float32(participation.PreviousEpochTargetAttestingGwei) / float32(participation.PreviousEpochActiveGwei)
+	}
+	participation.VotedEther = participation.PreviousEpochTargetAttestingGwei
+	participation.EligibleEther = participation.PreviousEpochActiveGwei
+
+	finalityCheckpoints := apimiddleware.StateFinalityCheckpointResponseJson{}
+	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, finalityCheckpointsEndpoint, &finalityCheckpoints); err != nil {
+		return nil, errors.Wrapf(err, "failed to query %s", finalityCheckpointsEndpoint)
+	}
+
+	if finalityCheckpoints.Data == nil || finalityCheckpoints.Data.Finalized == nil {
+		return nil, errors.New("finalized checkpoint is nil")
+	}
+
+	finalizedEpoch, err := strconv.ParseUint(finalityCheckpoints.Data.Finalized.Epoch, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse finalized epoch `%s`", finalityCheckpoints.Data.Finalized.Epoch)
+	}
+
+	return &ethpb.ValidatorParticipationResponse{
+		Epoch:         requestedEpoch,
+		Finalized:     requestedEpoch <= primitives.Epoch(finalizedEpoch),
+		Participation: participation,
+	}, nil
+}
+
+// getAttestationRewards returns the attestation rewards of the given validators for the given epoch. If no validator
+// is given, the rewards of all validators are returned.
+func (c beaconApiBeaconChainClient) getAttestationRewards(ctx context.Context, epoch primitives.Epoch, validatorIndices []primitives.ValidatorIndex) (*rewards.AttestationRewardsResponse, error) {
+	url := "/eth/v1/beacon/rewards/attestations/" + strconv.FormatUint(uint64(epoch), 10)
+
+	indices := make([]string, len(validatorIndices))
+	for i, validatorIndex := range validatorIndices {
+		indices[i] = strconv.FormatUint(uint64(validatorIndex), 10)
+	}
+
+	marshalledIndices, err := json.Marshal(indices)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal validator indices")
+	}
+
+	attestationRewards := &rewards.AttestationRewardsResponse{}
+	if _, err := c.jsonRestHandler.PostRestJson(ctx, url, nil, bytes.NewBuffer(marshalledIndices), attestationRewards); err != nil {
+		return nil, errors.Wrapf(wrapNotSupported(err, url), "failed to send POST data to `%s` REST URL", url)
+	}
+
+	return attestationRewards, nil
+}
+
+// isCorrectVote tells whether an attestation reward corresponds to a correct vote. Correct votes are not rewarded
+// during an inactivity leak, while missed source and target votes are always penalized. Missed head votes are
+// never penalized, so a head vote is only known to be correct when it was rewarded.
+func isCorrectVote(reward string, penalizedWhenMissed bool) (bool, error) {
+	value, err := strconv.ParseInt(reward, 10, 64)
+	if err != nil {
+		return false, err
+	}
+	if penalizedWhenMissed {
+		return value >= 0, nil
+	}
+	return value > 0, nil
+}
+
+// inactivityScoreFromPenalty recovers the inactivity score of a validator from its inactivity penalty, which is
+// effective_balance * inactivity_score / (INACTIVITY_SCORE_BIAS * INACTIVITY_PENALTY_QUOTIENT). The inactivity
+// score itself is not exposed by the standard API. Validators that voted for the correct target are not penalized,
+// so their score is reported as 0.
+func inactivityScoreFromPenalty(penalty string, effectiveBalance uint64, epoch primitives.Epoch) (uint64, error) {
+	value, err := strconv.ParseInt(penalty, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if value >= 0 || effectiveBalance == 0 {
+		return 0, nil
+	}
+
+	cfg := params.BeaconConfig()
+	quotient := cfg.InactivityPenaltyQuotientAltair
+	if epoch >= cfg.BellatrixForkEpoch {
+		quotient = cfg.InactivityPenaltyQuotientBellatrix
+	}
+	denominator := cfg.InactivityScoreBias * quotient
+	return (uint64(-value)*denominator + effectiveBalance - 1) / effectiveBalance, nil
+}
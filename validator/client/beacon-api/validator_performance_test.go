@@ -0,0 +1,430 @@
+package beacon_api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/rewards"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/validator"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/beacon-api/mock"
+)
+
+const headBlockHeaderEndpoint = "/eth/v1/beacon/headers/head"
+
+func expectHeadBlockHeader(ctx context.Context, jsonRestHandler *mock.MockjsonRestHandler, slot string) {
+	jsonRestHandler.EXPECT().GetRestJsonResponse(
+		ctx,
+		headBlockHeaderEndpoint,
+		&apimiddleware.BlockHeaderResponseJson{},
+	).Return(
+		nil,
+		nil,
+	).SetArg(
+		2,
+		apimiddleware.BlockHeaderResponseJson{
+			Data: &apimiddleware.BlockHeaderContainerJson{
+				Header: &apimiddleware.BeaconBlockHeaderContainerJson{
+					Message: &apimiddleware.BeaconBlockHeaderJson{Slot: slot},
+				},
+			},
+		},
+	)
+}
+
+func TestGetValidatorPerformance(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	t.Run("fails to get head block header", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		jsonRestHandler.EXPECT().GetRestJsonResponse(ctx, headBlockHeaderEndpoint, gomock.Any()).Return(
+			nil,
+			errors.New("foo error"),
+		)
+
+		beaconChainClient := beaconApiBeaconChainClient{jsonRestHandler: jsonRestHandler}
+		_, err := beaconChainClient.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{})
+		assert.ErrorContains(t, "failed to get head block headers: failed to get head block header: foo error", err)
+	})
+
+	t.Run("fails to get attestation rewards", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		expectHeadBlockHeader(ctx, jsonRestHandler, "96")
+		jsonRestHandler.EXPECT().PostRestJson(
+			ctx,
+			"/eth/v1/beacon/rewards/attestations/1",
+			nil,
+			bytes.NewBuffer([]byte(`["1"]`)),
+			&rewards.AttestationRewardsResponse{},
+		).Return(
+			nil,
+			errors.New("bar error"),
+		)
+
+		stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+		stateValidatorsProvider.EXPECT().GetStateValidatorsForSlot(ctx, primitives.Slot(96), []string{}, []primitives.ValidatorIndex{1}, []string(nil)).Return(
+			&apimiddleware.StateValidatorsResponseJson{
+				Data: []*apimiddleware.ValidatorContainerJson{
+					testValidatorContainer(1, 32000000000, "active_ongoing", &ethpb.Validator{PublicKey: []byte{1}, EffectiveBalance: 32000000000}),
+				},
+			},
+			nil,
+		)
+		stateValidatorsProvider.EXPECT().GetStateValidatorsForSlot(ctx, primitives.Slot(95), []string(nil), []primitives.ValidatorIndex{1}, []string(nil)).Return(
+			&apimiddleware.StateValidatorsResponseJson{},
+			nil,
+		)
+
+		beaconChainClient := beaconApiBeaconChainClient{
+			jsonRestHandler:         jsonRestHandler,
+			stateValidatorsProvider: stateValidatorsProvider,
+		}
+		_, err := beaconChainClient.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{Indices: []primitives.ValidatorIndex{1}})
+		assert.ErrorContains(t, "failed to send POST data to `/eth/v1/beacon/rewards/attestations/1` REST URL: bar error", err)
+	})
+
+	t.Run("attestation rewards not supported", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		expectHeadBlockHeader(ctx, jsonRestHandler, "96")
+		jsonRestHandler.EXPECT().PostRestJson(
+			ctx,
+			"/eth/v1/beacon/rewards/attestations/1",
+			nil,
+			gomock.Any(),
+			&rewards.AttestationRewardsResponse{},
+		).Return(
+			nil,
+			&HttpError{StatusCode: http.StatusNotFound, Message: "Not Found"},
+		)
+
+		stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+		stateValidatorsProvider.EXPECT().GetStateValidatorsForSlot(ctx, primitives.Slot(96), []string{}, []primitives.ValidatorIndex{1}, []string(nil)).Return(
+			&apimiddleware.StateValidatorsResponseJson{
+				Data: []*apimiddleware.ValidatorContainerJson{
+					testValidatorContainer(1, 32000000000, "active_ongoing", &ethpb.Validator{PublicKey: []byte{1}, EffectiveBalance: 32000000000}),
+				},
+			},
+			nil,
+		)
+		stateValidatorsProvider.EXPECT().GetStateValidatorsForSlot(ctx, primitives.Slot(95), []string(nil), []primitives.ValidatorIndex{1}, []string(nil)).Return(
+			&apimiddleware.StateValidatorsResponseJson{},
+			nil,
+		)
+
+		beaconChainClient := beaconApiBeaconChainClient{
+			jsonRestHandler:         jsonRestHandler,
+			stateValidatorsProvider: stateValidatorsProvider,
+		}
+		_, err := beaconChainClient.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{Indices: []primitives.ValidatorIndex{1}})
+		assert.Equal(t, true, errors.Is(err, ErrNotSupported))
+		assert.ErrorContains(t, "/eth/v1/beacon/rewards/attestations/1: Not Found", err)
+	})
+
+	t.Run("returns the performance of active validators", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		pubkeys := [][]byte{{1}, {2}, {3}, {4}, {5}}
+		pubkeyStrings := make([]string, len(pubkeys))
+		for i, pubkey := range pubkeys {
+			pubkeyStrings[i] = hexutil.Encode(pubkey)
+		}
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		expectHeadBlockHeader(ctx, jsonRestHandler, "96")
+		jsonRestHandler.EXPECT().PostRestJson(
+			ctx,
+			"/eth/v1/beacon/rewards/attestations/1",
+			nil,
+			bytes.NewBuffer([]byte(`["1","2","5"]`)),
+			&rewards.AttestationRewardsResponse{},
+		).Return(
+			nil,
+			nil,
+		).SetArg(
+			4,
+			rewards.AttestationRewardsResponse{
+				Data: rewards.AttestationRewards{
+					TotalRewards: []rewards.TotalAttestationReward{
+						{ValidatorIndex: "1", Head: "10", Target: "20", Source: "15", Inactivity: "0"},
+						{ValidatorIndex: "2", Head: "0", Target: "-20", Source: "-15", Inactivity: "-1907"},
+					},
+				},
+			},
+		)
+
+		stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+		stateValidatorsProvider.EXPECT().GetStateValidatorsForSlot(ctx, primitives.Slot(96), pubkeyStrings, []primitives.ValidatorIndex(nil), []string(nil)).Return(
+			&apimiddleware.StateValidatorsResponseJson{
+				Data: []*apimiddleware.ValidatorContainerJson{
+					testValidatorContainer(2, 31000000000, "active_ongoing", &ethpb.Validator{PublicKey: []byte{2}, EffectiveBalance: 32000000000}),
+					testValidatorContainer(1, 32000000010, "active_exiting", &ethpb.Validator{PublicKey: []byte{1}, EffectiveBalance: 32000000000}),
+					testValidatorContainer(3, 32000000000, "pending_queued", &ethpb.Validator{PublicKey: []byte{3}}),
+					testValidatorContainer(5, 32000000000, "active_ongoing", &ethpb.Validator{PublicKey: []byte{5}, EffectiveBalance: 32000000000}),
+				},
+			},
+			nil,
+		)
+		stateValidatorsProvider.EXPECT().GetStateValidatorsForSlot(ctx, primitives.Slot(95), []string(nil), []primitives.ValidatorIndex{1, 2, 5}, []string(nil)).Return(
+			&apimiddleware.StateValidatorsResponseJson{
+				Data: []*apimiddleware.ValidatorContainerJson{
+					testValidatorContainer(1, 32000000000, "active_ongoing", &ethpb.Validator{PublicKey: []byte{1}, EffectiveBalance: 32000000000}),
+					testValidatorContainer(2, 31000001000, "active_ongoing", &ethpb.Validator{PublicKey: []byte{2}, EffectiveBalance: 32000000000}),
+				},
+			},
+			nil,
+		)
+
+		beaconChainClient := beaconApiBeaconChainClient{
+			jsonRestHandler:         jsonRestHandler,
+			stateValidatorsProvider: stateValidatorsProvider,
+		}
+		performance, err := beaconChainClient.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{PublicKeys: pubkeys})
+		require.NoError(t, err)
+
+		expectedPerformance := &ethpb.ValidatorPerformanceResponse{
+			PublicKeys:                    [][]byte{{1}, {2}},
+			CorrectlyVotedSource:          []bool{true, false},
+			CorrectlyVotedTarget:          []bool{true, false},
+			CorrectlyVotedHead:            []bool{true, false},
+			CurrentEffectiveBalances:      []uint64{32000000000, 32000000000},
+			BalancesBeforeEpochTransition: []uint64{32000000000, 31000001000},
+			BalancesAfterEpochTransition:  []uint64{32000000010, 31000000000},
+			MissingValidators:             [][]byte{{3}, {4}, {5}},
+			InactivityScores:              []uint64{0, 4},
+		}
+		assert.DeepEqual(t, expectedPerformance, performance)
+	})
+}
+
+func TestGetValidatorParticipation(t *testing.T) {
+	const finalityCheckpointsEndpoint = "/eth/v1/beacon/states/head/finality_checkpoints"
+
+	farFutureEpoch := params.BeaconConfig().FarFutureEpoch
+
+	t.Run("epoch greater than current epoch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		expectHeadBlockHeader(ctx, jsonRestHandler, "96")
+
+		beaconChainClient := beaconApiBeaconChainClient{jsonRestHandler: jsonRestHandler}
+		_, err := beaconChainClient.GetValidatorParticipation(ctx, &ethpb.GetValidatorParticipationRequest{
+			QueryFilter: &ethpb.GetValidatorParticipationRequest_Epoch{Epoch: 4},
+		})
+		assert.ErrorContains(t, "cannot retrieve information about an epoch greater than current epoch, current epoch 3, requesting 4", err)
+	})
+
+	t.Run("returns the participation of the previous epoch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		expectHeadBlockHeader(ctx, jsonRestHandler, "96")
+		jsonRestHandler.EXPECT().PostRestJson(
+			ctx,
+			"/eth/v1/beacon/rewards/attestations/1",
+			nil,
+			bytes.NewBuffer([]byte(`[]`)),
+			&rewards.AttestationRewardsResponse{},
+		).Return(
+			nil,
+			nil,
+		).SetArg(
+			4,
+			rewards.AttestationRewardsResponse{
+				Data: rewards.AttestationRewards{
+					TotalRewards: []rewards.TotalAttestationReward{
+						{ValidatorIndex: "0", Head: "10", Target: "20", Source: "15", Inactivity: "0"},
+						{ValidatorIndex: "1", Head: "0", Target: "-20", Source: "15", Inactivity: "0"},
+						{ValidatorIndex: "3", Head: "10", Target: "20", Source: "15", Inactivity: "0"},
+					},
+				},
+			},
+		)
+		jsonRestHandler.EXPECT().PostRestJson(
+			ctx,
+			"/eth/v1/validator/liveness/2",
+			nil,
+			bytes.NewBuffer([]byte(`["0","1","2","3"]`)),
+			&validator.GetLivenessResponse{},
+		).Return(
+			nil,
+			nil,
+		).SetArg(
+			4,
+			validator.GetLivenessResponse{
+				Data: []*validator.Liveness{
+					{Index: "0", IsLive: true},
+					{Index: "1", IsLive: false},
+					{Index: "2", IsLive: true},
+					{Index: "3", IsLive: false},
+				},
+			},
+		)
+		jsonRestHandler.EXPECT().GetRestJsonResponse(
+			ctx,
+			finalityCheckpointsEndpoint,
+			&apimiddleware.StateFinalityCheckpointResponseJson{},
+		).Return(
+			nil,
+			nil,
+		).SetArg(
+			2,
+			apimiddleware.StateFinalityCheckpointResponseJson{
+				Data: &apimiddleware.StateFinalityCheckpointResponse_StateFinalityCheckpointJson{
+					Finalized: &apimiddleware.CheckpointJson{Epoch: "2"},
+				},
+			},
+		)
+
+		stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+		stateValidatorsProvider.EXPECT().GetStateValidatorsForSlot(ctx, primitives.Slot(64), []string(nil), []primitives.ValidatorIndex(nil), []string(nil)).Return(
+			&apimiddleware.StateValidatorsResponseJson{
+				Data: []*apimiddleware.ValidatorContainerJson{
+					testValidatorContainer(0, 32000000000, "active_ongoing", &ethpb.Validator{
+						PublicKey:         []byte{0},
+						EffectiveBalance:  32000000000,
+						ExitEpoch:         farFutureEpoch,
+						WithdrawableEpoch: farFutureEpoch,
+					}),
+					testValidatorContainer(1, 31000000000, "active_ongoing", &ethpb.Validator{
+						PublicKey:         []byte{1},
+						EffectiveBalance:  31000000000,
+						ExitEpoch:         farFutureEpoch,
+						WithdrawableEpoch: farFutureEpoch,
+					}),
+					testValidatorContainer(2, 32000000000, "active_ongoing", &ethpb.Validator{
+						PublicKey:         []byte{2},
+						EffectiveBalance:  32000000000,
+						ActivationEpoch:   2,
+						ExitEpoch:         farFutureEpoch,
+						WithdrawableEpoch: farFutureEpoch,
+					}),
+					testValidatorContainer(3, 32000000000, "active_slashed", &ethpb.Validator{
+						PublicKey:         []byte{3},
+						EffectiveBalance:  32000000000,
+						Slashed:           true,
+						ExitEpoch:         10,
+						WithdrawableEpoch: 8202,
+					}),
+				},
+			},
+			nil,
+		)
+
+		beaconChainClient := beaconApiBeaconChainClient{
+			jsonRestHandler:         jsonRestHandler,
+			stateValidatorsProvider: stateValidatorsProvider,
+		}
+		participation, err := beaconChainClient.GetValidatorParticipation(ctx, &ethpb.GetValidatorParticipationRequest{})
+		require.NoError(t, err)
+
+		expectedParticipation := &ethpb.ValidatorParticipationResponse{
+			Epoch:     2,
+			Finalized: true,
+			Participation: &ethpb.ValidatorParticipation{
+				GlobalParticipationRate:          float32(32000000000) / float32(95000000000),
+				VotedEther:                       32000000000,
+				EligibleEther:                    95000000000,
+				CurrentEpochActiveGwei:           127000000000,
+				CurrentEpochAttestingGwei:        64000000000,
+				PreviousEpochActiveGwei:          95000000000,
+				PreviousEpochAttestingGwei:       63000000000,
+				PreviousEpochTargetAttestingGwei: 32000000000,
+				PreviousEpochHeadAttestingGwei:   32000000000,
+			},
+		}
+		assert.DeepEqual(t, expectedParticipation, participation)
+	})
+}
+
+func TestIsCorrectVote(t *testing.T) {
+	testCases := []struct {
+		name                string
+		reward              string
+		penalizedWhenMissed bool
+		expected            bool
+	}{
+		{name: "rewarded source vote", reward: "10", penalizedWhenMissed: true, expected: true},
+		{name: "unrewarded source vote during inactivity leak", reward: "0", penalizedWhenMissed: true, expected: true},
+		{name: "penalized source vote", reward: "-10", penalizedWhenMissed: true, expected: false},
+		{name: "rewarded head vote", reward: "10", penalizedWhenMissed: false, expected: true},
+		{name: "unrewarded head vote", reward: "0", penalizedWhenMissed: false, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			correct, err := isCorrectVote(testCase.reward, testCase.penalizedWhenMissed)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, correct)
+		})
+	}
+
+	t.Run("invalid reward", func(t *testing.T) {
+		_, err := isCorrectVote("foo", true)
+		assert.ErrorContains(t, "invalid syntax", err)
+	})
+}
+
+func TestInactivityScoreFromPenalty(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.BellatrixForkEpoch = 10
+	params.OverrideBeaconConfig(cfg)
+
+	const effectiveBalance = uint64(32000000000)
+
+	for _, score := range []uint64{0, 1, 4, 37, 1000} {
+		for _, epoch := range []primitives.Epoch{5, 10} {
+			quotient := cfg.InactivityPenaltyQuotientAltair
+			if epoch >= cfg.BellatrixForkEpoch {
+				quotient = cfg.InactivityPenaltyQuotientBellatrix
+			}
+			// This mirrors the penalty computation of the Altair epoch processing.
+			penalty := effectiveBalance * score / (cfg.InactivityScoreBias * quotient)
+
+			recoveredScore, err := inactivityScoreFromPenalty("-"+strconv.FormatUint(penalty, 10), effectiveBalance, epoch)
+			require.NoError(t, err)
+			assert.Equal(t, score, recoveredScore)
+		}
+	}
+
+	recoveredScore, err := inactivityScoreFromPenalty("0", 0, 5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), recoveredScore)
+
+	_, err = inactivityScoreFromPenalty("foo", effectiveBalance, 5)
+	assert.ErrorContains(t, "invalid syntax", err)
+}
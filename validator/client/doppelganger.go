@@ -0,0 +1,280 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// DefaultDoppelGangerEpochs is the default number of epochs during which a newly imported key
+// must not be observed on chain before it starts performing its duties.
+const DefaultDoppelGangerEpochs = 2
+
+var errDoppelGangerFound = errors.New("duplicate instances exist in the network")
+
+// doppelGangerKey is the protection status of a single key.
+type doppelGangerKey struct {
+	// startEpoch is the first epoch for which the liveness of the key is checked.
+	startEpoch primitives.Epoch
+	// nextEpoch is the next epoch for which the liveness of the key must be checked.
+	nextEpoch primitives.Epoch
+	// remainingEpochs is the number of consecutive epochs which still have to be checked before the key is released.
+	remainingEpochs primitives.Epoch
+}
+
+// doppelGangerTracker keeps track of the validating keys under doppelganger protection.
+// A protected key does not perform any duty until the beacon node has reported it as not live
+// for the configured number of epochs.
+type doppelGangerTracker struct {
+	sync.RWMutex
+	epochs    primitives.Epoch
+	known     map[[fieldparams.BLSPubkeyLength]byte]bool
+	protected map[[fieldparams.BLSPubkeyLength]byte]*doppelGangerKey
+}
+
+func newDoppelGangerTracker(epochs primitives.Epoch) *doppelGangerTracker {
+	if epochs == 0 {
+		epochs = DefaultDoppelGangerEpochs
+	}
+	return &doppelGangerTracker{
+		epochs:    epochs,
+		known:     make(map[[fieldparams.BLSPubkeyLength]byte]bool),
+		protected: make(map[[fieldparams.BLSPubkeyLength]byte]*doppelGangerKey),
+	}
+}
+
+// track starts protecting the keys which have not been seen before, and forgets the keys which are no longer used.
+// Checks start at the epoch following the current one, as the key may have legitimately signed messages
+// in the current epoch before it was (re)loaded. It returns the keys that were added.
+func (d *doppelGangerTracker) track(keys [][fieldparams.BLSPubkeyLength]byte, currentEpoch primitives.Epoch) [][fieldparams.BLSPubkeyLength]byte {
+	d.Lock()
+	defer d.Unlock()
+
+	current := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(keys))
+	added := make([][fieldparams.BLSPubkeyLength]byte, 0)
+	for _, k := range keys {
+		current[k] = true
+		if d.known[k] {
+			continue
+		}
+		d.known[k] = true
+		d.protected[k] = &doppelGangerKey{
+			startEpoch:      currentEpoch + 1,
+			nextEpoch:       currentEpoch + 1,
+			remainingEpochs: d.epochs,
+		}
+		added = append(added, k)
+	}
+	for k := range d.known {
+		if !current[k] {
+			delete(d.known, k)
+			delete(d.protected, k)
+		}
+	}
+	return added
+}
+
+// isProtected returns true if the key must not perform any duty.
+func (d *doppelGangerTracker) isProtected(key [fieldparams.BLSPubkeyLength]byte) bool {
+	if d == nil {
+		return false
+	}
+	d.RLock()
+	defer d.RUnlock()
+	_, ok := d.protected[key]
+	return ok
+}
+
+// protectedKeys returns a copy of the protection status of every protected key.
+func (d *doppelGangerTracker) protectedKeys() map[[fieldparams.BLSPubkeyLength]byte]doppelGangerKey {
+	d.RLock()
+	defer d.RUnlock()
+	keys := make(map[[fieldparams.BLSPubkeyLength]byte]doppelGangerKey, len(d.protected))
+	for k, s := range d.protected {
+		keys[k] = *s
+	}
+	return keys
+}
+
+// epochChecked records that the liveness of the given keys has been queried for the given epoch.
+// It returns the keys for which protection is complete. The liveness of skipped epochs can no longer
+// be queried, so the protection of a key starts over when epochs were skipped since its last check.
+func (d *doppelGangerTracker) epochChecked(keys [][fieldparams.BLSPubkeyLength]byte, epoch primitives.Epoch) [][fieldparams.BLSPubkeyLength]byte {
+	d.Lock()
+	defer d.Unlock()
+	released := make([][fieldparams.BLSPubkeyLength]byte, 0)
+	for _, k := range keys {
+		s, ok := d.protected[k]
+		if !ok || epoch < s.nextEpoch {
+			continue
+		}
+		if epoch > s.nextEpoch {
+			s.remainingEpochs = d.epochs
+		}
+		s.nextEpoch = epoch + 1
+		s.remainingEpochs--
+		if s.remainingEpochs == 0 {
+			delete(d.protected, k)
+			released = append(released, k)
+		}
+	}
+	return released
+}
+
+// CheckDoppelGanger puts all the validating keys under doppelganger protection.
+// The keys do not perform any duty until CheckDoppelGangerLiveness has not observed them
+// on chain for the configured number of epochs.
+func (v *validator) CheckDoppelGanger(ctx context.Context) error {
+	if v.doppelGanger == nil {
+		return nil
+	}
+	pubkeys, err := v.keyManager.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return err
+	}
+	v.trackDoppelGangerKeys(pubkeys)
+	return nil
+}
+
+// trackDoppelGangerKeys puts the keys which were not previously known under doppelganger protection.
+func (v *validator) trackDoppelGangerKeys(pubkeys [][fieldparams.BLSPubkeyLength]byte) {
+	if v.doppelGanger == nil {
+		return
+	}
+	currentEpoch := slots.ToEpoch(slots.CurrentSlot(v.genesisTime))
+	added := v.doppelGanger.track(pubkeys, currentEpoch)
+	if len(added) == 0 {
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"keys":       len(added),
+		"startEpoch": currentEpoch + 1,
+		"epochs":     v.doppelGanger.epochs,
+	}).Info("Running doppelganger check, duties of new keys are postponed")
+}
+
+// CheckDoppelGangerLiveness queries the liveness of the keys under doppelganger protection for the previous
+// and current epoch. It returns an error if any of these keys was live in an epoch in which it could not
+// have been used by this validator client. Keys are released once enough epochs have been checked.
+// It is meant to be called during the last slot of an epoch, so that the current epoch participation is as
+// complete as possible.
+func (v *validator) CheckDoppelGangerLiveness(ctx context.Context, slot primitives.Slot) error {
+	ctx, span := trace.StartSpan(ctx, "validator.CheckDoppelGangerLiveness")
+	defer span.End()
+
+	if v.doppelGanger == nil {
+		return nil
+	}
+	keys := v.doppelGanger.protectedKeys()
+	if len(keys) == 0 {
+		return nil
+	}
+
+	currentEpoch := slots.ToEpoch(slot)
+	altairEpoch := params.BeaconConfig().AltairForkEpoch
+	if currentEpoch < altairEpoch {
+		// Liveness is derived from epoch participation, which does not exist before Altair.
+		log.WithField("altairEpoch", altairEpoch).Info("Doppelganger check cannot run before Altair, duties of new keys are postponed")
+		return nil
+	}
+	if v.duties == nil {
+		return errors.New("no duties to retrieve the validator indices from")
+	}
+
+	// Only validators which are part of the active set are able to produce messages.
+	indices := make(map[[fieldparams.BLSPubkeyLength]byte]primitives.ValidatorIndex)
+	for _, duty := range v.duties.CurrentEpochDuties {
+		if duty == nil {
+			continue
+		}
+		key := bytesutil.ToBytes48(duty.PublicKey)
+		if _, ok := keys[key]; !ok {
+			continue
+		}
+		if duty.Status == ethpb.ValidatorStatus_ACTIVE || duty.Status == ethpb.ValidatorStatus_EXITING {
+			indices[key] = duty.ValidatorIndex
+		}
+	}
+
+	var previousLiveness map[primitives.ValidatorIndex]bool
+	if currentEpoch > altairEpoch {
+		var err error
+		previousLiveness, err = v.liveness(ctx, currentEpoch-1, indices)
+		if err != nil {
+			return err
+		}
+	}
+	currentLiveness, err := v.liveness(ctx, currentEpoch, indices)
+	if err != nil {
+		return err
+	}
+
+	duplicates := make([][]byte, 0)
+	for key, index := range indices {
+		s := keys[key]
+		if currentEpoch > altairEpoch && currentEpoch-1 >= s.startEpoch && previousLiveness[index] {
+			duplicates = append(duplicates, bytesutil.SafeCopyBytes(key[:]))
+			continue
+		}
+		if currentEpoch >= s.startEpoch && currentLiveness[index] {
+			duplicates = append(duplicates, bytesutil.SafeCopyBytes(key[:]))
+		}
+	}
+	if len(duplicates) > 0 {
+		return errors.Wrapf(errDoppelGangerFound, "validator keys %#x", duplicates)
+	}
+
+	if currentEpoch <= altairEpoch {
+		return nil
+	}
+	// The previous epoch can no longer be attested to, so its check is final. Only the keys whose
+	// liveness the beacon node reported for it are checked: keys without duties stay protected.
+	checked := make([][fieldparams.BLSPubkeyLength]byte, 0, len(indices))
+	for key, index := range indices {
+		if _, ok := previousLiveness[index]; ok {
+			checked = append(checked, key)
+		}
+	}
+	released := v.doppelGanger.epochChecked(checked, currentEpoch-1)
+	for _, k := range released {
+		log.WithField("pubkey", fmt.Sprintf("%#x", bytesutil.Trunc(k[:]))).Info("Doppelganger check complete, starting duties")
+	}
+	return nil
+}
+
+func (v *validator) liveness(
+	ctx context.Context,
+	epoch primitives.Epoch,
+	indices map[[fieldparams.BLSPubkeyLength]byte]primitives.ValidatorIndex,
+) (map[primitives.ValidatorIndex]bool, error) {
+	liveness := make(map[primitives.ValidatorIndex]bool, len(indices))
+	if len(indices) == 0 {
+		return liveness, nil
+	}
+	req := &ethpbv2.GetLivenessRequest{
+		Epoch: epoch,
+		Index: make([]primitives.ValidatorIndex, 0, len(indices)),
+	}
+	for _, index := range indices {
+		req.Index = append(req.Index, index)
+	}
+	resp, err := v.validatorClient.GetLiveness(ctx, req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get liveness for epoch %d", epoch)
+	}
+	for _, l := range resp.Data {
+		liveness[l.Index] = l.IsLive
+	}
+	return liveness, nil
+}
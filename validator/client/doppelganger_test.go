@@ -0,0 +1,223 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/v4/testing/validator-mock"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// livenessResponder answers liveness requests with the given live indices per epoch.
+func livenessResponder(live map[primitives.Epoch]map[primitives.ValidatorIndex]bool) func(context.Context, *ethpbv2.GetLivenessRequest) (*ethpbv2.GetLivenessResponse, error) {
+	return func(_ context.Context, req *ethpbv2.GetLivenessRequest) (*ethpbv2.GetLivenessResponse, error) {
+		resp := &ethpbv2.GetLivenessResponse{}
+		for _, index := range req.Index {
+			resp.Data = append(resp.Data, &ethpbv2.GetLivenessResponse_Liveness{
+				Index:  index,
+				IsLive: live[req.Epoch][index],
+			})
+		}
+		return resp, nil
+	}
+}
+
+func lastSlotOf(t *testing.T, epoch primitives.Epoch) primitives.Slot {
+	slot, err := slots.EpochEnd(epoch)
+	require.NoError(t, err)
+	return slot
+}
+
+func TestValidator_CheckDoppelGanger(t *testing.T) {
+	km := genMockKeymanager(t, 3)
+	keys, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+
+	t.Run("disabled", func(t *testing.T) {
+		v := &validator{keyManager: km}
+		require.NoError(t, v.CheckDoppelGanger(context.Background()))
+		assert.Equal(t, false, v.doppelGanger.isProtected(keys[0]))
+	})
+	t.Run("protects every key", func(t *testing.T) {
+		v := &validator{
+			keyManager:   km,
+			genesisTime:  uint64(time.Now().Unix()),
+			doppelGanger: newDoppelGangerTracker(2),
+		}
+		require.NoError(t, v.CheckDoppelGanger(context.Background()))
+		for _, k := range keys {
+			assert.Equal(t, true, v.doppelGanger.isProtected(k))
+		}
+		// Keys are only tracked once.
+		require.NoError(t, v.CheckDoppelGanger(context.Background()))
+		assert.Equal(t, 3, len(v.doppelGanger.protectedKeys()))
+	})
+}
+
+func TestValidator_TrackDoppelGangerKeys(t *testing.T) {
+	km := genMockKeymanager(t, 3)
+	keys, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	v := &validator{
+		genesisTime:  uint64(time.Now().Unix()),
+		doppelGanger: newDoppelGangerTracker(2),
+	}
+
+	v.trackDoppelGangerKeys(keys[:2])
+	v.doppelGanger.epochChecked(keys[:2], 1)
+	v.doppelGanger.epochChecked(keys[:2], 2)
+	assert.Equal(t, false, v.doppelGanger.isProtected(keys[0]))
+
+	// A key added at runtime is protected while the known keys keep performing their duties.
+	v.trackDoppelGangerKeys(keys)
+	assert.Equal(t, false, v.doppelGanger.isProtected(keys[0]))
+	assert.Equal(t, false, v.doppelGanger.isProtected(keys[1]))
+	assert.Equal(t, true, v.doppelGanger.isProtected(keys[2]))
+
+	// A key which is removed and imported again is protected again.
+	v.trackDoppelGangerKeys(keys[1:])
+	v.trackDoppelGangerKeys(keys)
+	assert.Equal(t, true, v.doppelGanger.isProtected(keys[0]))
+	assert.Equal(t, false, v.doppelGanger.isProtected(keys[1]))
+}
+
+func TestValidator_CheckDoppelGangerLiveness(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	km := genMockKeymanager(t, 3)
+	keys, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	duties := &ethpb.DutiesResponse{
+		CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{PublicKey: keys[0][:], ValidatorIndex: 0, Status: ethpb.ValidatorStatus_ACTIVE},
+			{PublicKey: keys[1][:], ValidatorIndex: 1, Status: ethpb.ValidatorStatus_ACTIVE},
+			// Pending validators cannot be live, so their liveness is not requested.
+			{PublicKey: keys[2][:], ValidatorIndex: 2, Status: ethpb.ValidatorStatus_PENDING},
+		},
+	}
+	newValidator := func(t *testing.T) (*validator, *validatormock.MockValidatorClient) {
+		ctrl := gomock.NewController(t)
+		client := validatormock.NewMockValidatorClient(ctrl)
+		v := &validator{
+			validatorClient: client,
+			duties:          duties,
+			doppelGanger:    newDoppelGangerTracker(2),
+		}
+		v.doppelGanger.track(keys, 10)
+		return v, client
+	}
+
+	t.Run("no doppelganger", func(t *testing.T) {
+		v, client := newValidator(t)
+		live := map[primitives.Epoch]map[primitives.ValidatorIndex]bool{
+			// Liveness before the start epoch is caused by this validator client.
+			9:  {0: true, 1: true},
+			10: {0: true, 1: true},
+		}
+		client.EXPECT().GetLiveness(gomock.Any(), gomock.Any()).DoAndReturn(livenessResponder(live)).Times(8)
+
+		require.NoError(t, v.CheckDoppelGangerLiveness(context.Background(), lastSlotOf(t, 10)))
+		require.NoError(t, v.CheckDoppelGangerLiveness(context.Background(), lastSlotOf(t, 11)))
+		require.NoError(t, v.CheckDoppelGangerLiveness(context.Background(), lastSlotOf(t, 12)))
+		for _, k := range keys {
+			assert.Equal(t, true, v.doppelGanger.isProtected(k))
+		}
+		require.NoError(t, v.CheckDoppelGangerLiveness(context.Background(), lastSlotOf(t, 13)))
+		assert.Equal(t, false, v.doppelGanger.isProtected(keys[0]))
+		assert.Equal(t, false, v.doppelGanger.isProtected(keys[1]))
+		// The liveness of the pending key was never checked, so it stays protected.
+		assert.Equal(t, true, v.doppelGanger.isProtected(keys[2]))
+		// Nothing is requested once every active key is released.
+		require.NoError(t, v.CheckDoppelGangerLiveness(context.Background(), lastSlotOf(t, 14)))
+	})
+	t.Run("skipped epoch restarts the protection", func(t *testing.T) {
+		v, client := newValidator(t)
+		client.EXPECT().GetLiveness(gomock.Any(), gomock.Any()).DoAndReturn(livenessResponder(nil)).Times(6)
+
+		require.NoError(t, v.CheckDoppelGangerLiveness(context.Background(), lastSlotOf(t, 12)))
+		// The check of epoch 12 is missed.
+		require.NoError(t, v.CheckDoppelGangerLiveness(context.Background(), lastSlotOf(t, 14)))
+		assert.Equal(t, true, v.doppelGanger.isProtected(keys[0]))
+		require.NoError(t, v.CheckDoppelGangerLiveness(context.Background(), lastSlotOf(t, 15)))
+		assert.Equal(t, false, v.doppelGanger.isProtected(keys[0]))
+	})
+	t.Run("live in current epoch", func(t *testing.T) {
+		v, client := newValidator(t)
+		live := map[primitives.Epoch]map[primitives.ValidatorIndex]bool{
+			11: {1: true},
+		}
+		client.EXPECT().GetLiveness(gomock.Any(), gomock.Any()).DoAndReturn(livenessResponder(live)).Times(2)
+		err := v.CheckDoppelGangerLiveness(context.Background(), lastSlotOf(t, 11))
+		require.ErrorIs(t, err, errDoppelGangerFound)
+		assert.ErrorContains(t, "validator keys", err)
+	})
+	t.Run("live in previous epoch", func(t *testing.T) {
+		v, client := newValidator(t)
+		live := map[primitives.Epoch]map[primitives.ValidatorIndex]bool{
+			11: {0: true},
+		}
+		client.EXPECT().GetLiveness(gomock.Any(), gomock.Any()).DoAndReturn(livenessResponder(live)).Times(2)
+		err := v.CheckDoppelGangerLiveness(context.Background(), lastSlotOf(t, 12))
+		require.ErrorIs(t, err, errDoppelGangerFound)
+	})
+	t.Run("liveness request fails", func(t *testing.T) {
+		v, client := newValidator(t)
+		client.EXPECT().GetLiveness(gomock.Any(), gomock.Any()).Return(nil, errors.New("bad request")).Times(1)
+		err := v.CheckDoppelGangerLiveness(context.Background(), lastSlotOf(t, 12))
+		require.ErrorContains(t, "could not get liveness for epoch 11", err)
+		assert.Equal(t, false, errors.Is(err, errDoppelGangerFound))
+		// The failed epoch is not counted.
+		assert.Equal(t, primitives.Epoch(2), v.doppelGanger.protectedKeys()[keys[0]].remainingEpochs)
+	})
+	t.Run("phase 0", func(t *testing.T) {
+		cfg := params.BeaconConfig().Copy()
+		cfg.AltairForkEpoch = 100
+		params.OverrideBeaconConfig(cfg)
+		defer func() {
+			cfg := params.BeaconConfig().Copy()
+			cfg.AltairForkEpoch = 0
+			params.OverrideBeaconConfig(cfg)
+		}()
+		v, _ := newValidator(t)
+		require.NoError(t, v.CheckDoppelGangerLiveness(context.Background(), lastSlotOf(t, 11)))
+		// Liveness cannot be checked before Altair, so the keys stay protected.
+		assert.Equal(t, 3, len(v.doppelGanger.protectedKeys()))
+	})
+}
+
+func TestValidator_RolesAt_SkipsDoppelGangerProtectedKeys(t *testing.T) {
+	km := genMockKeymanager(t, 2)
+	keys, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	v := &validator{
+		duties: &ethpb.DutiesResponse{
+			Duties: []*ethpb.DutiesResponse_Duty{
+				{PublicKey: keys[0][:], AttesterSlot: 10},
+				{PublicKey: keys[1][:], AttesterSlot: 11},
+			},
+		},
+		doppelGanger: newDoppelGangerTracker(2),
+	}
+	v.doppelGanger.track([][fieldparams.BLSPubkeyLength]byte{keys[1]}, 0)
+
+	roles, err := v.RolesAt(context.Background(), 12)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(roles))
+	_, ok := roles[keys[0]]
+	assert.Equal(t, true, ok)
+	_, ok = roles[keys[1]]
+	assert.Equal(t, false, ok)
+}
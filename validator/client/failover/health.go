@@ -0,0 +1,95 @@
+package failover
+
+import (
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+)
+
+const (
+	// errorRateWeight is the weight given to the outcome of the latest request when updating the error rate of a
+	// beacon node. The error rate is an exponential moving average, so older requests matter less and less.
+	errorRateWeight = 0.2
+	// maxHeadSlotLag is the number of slots a beacon node can lag behind the most advanced beacon node before
+	// being considered behind.
+	maxHeadSlotLag = 2
+	// syncingPenalty is the factor applied to the score of a syncing beacon node.
+	syncingPenalty = 0.25
+	// laggingPenalty is the factor applied to the score of a beacon node whose head is behind.
+	laggingPenalty = 0.5
+)
+
+// health tracks how well a beacon node is doing, both from periodic health checks and from the
+// outcome of the requests sent to it.
+type health struct {
+	lock      sync.RWMutex
+	reachable bool
+	syncing   bool
+	headSlot  primitives.Slot
+	errorRate float64
+}
+
+// newHealth assumes a beacon node is reachable until proven otherwise, so that every beacon node
+// is usable before the first health check.
+func newHealth() *health {
+	return &health{reachable: true}
+}
+
+// recordRequest updates the error rate with the outcome of a request. Any answer from the beacon node
+// proves that it is reachable.
+func (h *health) recordRequest(err error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.updateErrorRate(err)
+	if err == nil {
+		h.reachable = true
+	}
+}
+
+// recordStatus updates the sync status and the head slot of the beacon node with the result of a health check.
+func (h *health) recordStatus(syncing bool, headSlot primitives.Slot, err error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.updateErrorRate(err)
+	h.reachable = err == nil
+	if err != nil {
+		return
+	}
+	h.syncing = syncing
+	h.headSlot = headSlot
+}
+
+func (h *health) updateErrorRate(err error) {
+	outcome := 0.0
+	if err != nil {
+		outcome = 1.0
+	}
+	h.errorRate = (1-errorRateWeight)*h.errorRate + errorRateWeight*outcome
+}
+
+func (h *health) head() primitives.Slot {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	return h.headSlot
+}
+
+// score ranks the beacon node between 0 and 1, 1 being perfectly healthy. An unreachable beacon node
+// scores 0, and the score of a reachable one is lowered by its error rate, by syncing and by having a
+// head more than maxHeadSlotLag slots behind the best known head.
+func (h *health) score(bestHeadSlot primitives.Slot) float64 {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	if !h.reachable {
+		return 0
+	}
+
+	score := 1 - h.errorRate
+	if h.syncing {
+		score *= syncingPenalty
+	}
+	if h.headSlot+maxHeadSlotLag < bestHeadSlot {
+		score *= laggingPenalty
+	}
+	return score
+}
@@ -0,0 +1,24 @@
+package failover
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"google.golang.org/grpc"
+)
+
+type healthClient struct {
+	pool *Pool
+}
+
+// NewHealthClient returns a health client streaming the logs of the healthiest beacon node of the pool.
+func NewHealthClient(pool *Pool) ethpb.HealthClient {
+	return &healthClient{pool: pool}
+}
+
+func (c *healthClient) StreamBeaconLogs(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (ethpb.Health_StreamBeaconLogsClient, error) {
+	return callStream(ctx, c.pool, "StreamBeaconLogs", func(ctx context.Context, n *Node) (ethpb.Health_StreamBeaconLogsClient, error) {
+		return n.healthClient.StreamBeaconLogs(ctx, in, opts...)
+	})
+}
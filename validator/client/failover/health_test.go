@@ -0,0 +1,53 @@
+package failover
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+)
+
+func TestHealth_Score(t *testing.T) {
+	t.Run("healthy", func(t *testing.T) {
+		h := newHealth()
+		h.recordStatus(false, 100, nil)
+		assert.Equal(t, 1.0, h.score(100))
+		assert.Equal(t, 1.0, h.score(100+maxHeadSlotLag))
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		h := newHealth()
+		h.recordStatus(false, 0, errors.New("connection refused"))
+		assert.Equal(t, 0.0, h.score(100))
+
+		// Answering a request proves that the beacon node is reachable again.
+		h.recordRequest(nil)
+		assert.Equal(t, true, h.score(0) > 0)
+	})
+
+	t.Run("syncing", func(t *testing.T) {
+		h := newHealth()
+		h.recordStatus(true, 100, nil)
+		assert.Equal(t, syncingPenalty, h.score(100))
+	})
+
+	t.Run("behind", func(t *testing.T) {
+		h := newHealth()
+		h.recordStatus(false, 100, nil)
+		assert.Equal(t, laggingPenalty, h.score(100+maxHeadSlotLag+1))
+	})
+
+	t.Run("failing requests", func(t *testing.T) {
+		h := newHealth()
+		h.recordRequest(errors.New("timeout"))
+		assert.Equal(t, 1-errorRateWeight, h.score(0))
+
+		h.recordRequest(errors.New("timeout"))
+		failingScore := h.score(0)
+		assert.Equal(t, true, failingScore < 1-errorRateWeight)
+
+		// The error rate recovers as requests succeed again.
+		h.recordRequest(nil)
+		assert.Equal(t, true, h.score(0) > failingScore)
+	})
+}
@@ -0,0 +1,25 @@
+package failover
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	beaconNodeHealthScore = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_health_score",
+			Help:      "The health score of a beacon node, between 0 (unhealthy) and 1 (healthy).",
+		},
+		[]string{"endpoint"},
+	)
+	beaconNodeFailoverCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_failovers_total",
+			Help:      "The number of requests retried on another beacon node after a beacon node failed to answer.",
+		},
+		[]string{"method"},
+	)
+)
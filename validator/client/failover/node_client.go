@@ -0,0 +1,42 @@
+package failover
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+)
+
+type nodeClient struct {
+	pool *Pool
+}
+
+// NewNodeClient returns a node client querying the healthiest beacon node of the pool.
+func NewNodeClient(pool *Pool) iface.NodeClient {
+	return &nodeClient{pool: pool}
+}
+
+func (c *nodeClient) GetSyncStatus(ctx context.Context, in *empty.Empty) (*ethpb.SyncStatus, error) {
+	return call(ctx, c.pool, "GetSyncStatus", quickRequest, func(ctx context.Context, n *Node) (*ethpb.SyncStatus, error) {
+		return n.nodeClient.GetSyncStatus(ctx, in)
+	})
+}
+
+func (c *nodeClient) GetGenesis(ctx context.Context, in *empty.Empty) (*ethpb.Genesis, error) {
+	return call(ctx, c.pool, "GetGenesis", quickRequest, func(ctx context.Context, n *Node) (*ethpb.Genesis, error) {
+		return n.nodeClient.GetGenesis(ctx, in)
+	})
+}
+
+func (c *nodeClient) GetVersion(ctx context.Context, in *empty.Empty) (*ethpb.Version, error) {
+	return call(ctx, c.pool, "GetVersion", quickRequest, func(ctx context.Context, n *Node) (*ethpb.Version, error) {
+		return n.nodeClient.GetVersion(ctx, in)
+	})
+}
+
+func (c *nodeClient) ListPeers(ctx context.Context, in *empty.Empty) (*ethpb.Peers, error) {
+	return call(ctx, c.pool, "ListPeers", quickRequest, func(ctx context.Context, n *Node) (*ethpb.Peers, error) {
+		return n.nodeClient.ListPeers(ctx, in)
+	})
+}
@@ -0,0 +1,324 @@
+package failover
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	beaconApi "github.com/prysmaticlabs/prysm/v4/validator/client/beacon-api"
+	beaconChainClientFactory "github.com/prysmaticlabs/prysm/v4/validator/client/beacon-chain-client-factory"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	nodeClientFactory "github.com/prysmaticlabs/prysm/v4/validator/client/node-client-factory"
+	slasherClientFactory "github.com/prysmaticlabs/prysm/v4/validator/client/slasher-client-factory"
+	validatorClientFactory "github.com/prysmaticlabs/prysm/v4/validator/client/validator-client-factory"
+	validatorHelpers "github.com/prysmaticlabs/prysm/v4/validator/helpers"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Node is a beacon node of the pool, along with the clients used to talk to it.
+type Node struct {
+	endpoint          string
+	conn              validatorHelpers.NodeConnection
+	validatorClient   iface.ValidatorClient
+	beaconChainClient iface.BeaconChainClient
+	nodeClient        iface.NodeClient
+	slasherClient     iface.SlasherClient
+	healthClient      ethpb.HealthClient
+	health            *health
+}
+
+// requestKind tells how long a beacon node may take to answer a request before it is retried on the next one.
+type requestKind int
+
+const (
+	// quickRequest is a lookup or a request on the critical path of a duty, like getting attestation data.
+	quickRequest requestKind = iota
+	// blockRequest builds or imports a block, which may wait for the execution client or the builder.
+	blockRequest
+	// bulkRequest covers many validators at once, so its cost grows with the number of validators.
+	bulkRequest
+)
+
+// Pool is a set of beacon nodes used by a single validator client. Requests are sent to the
+// healthiest beacon node and retried on the next healthiest one when it fails, while messages
+// meant to be gossiped, like attestations and blocks, are broadcast to every beacon node.
+type Pool struct {
+	ctx                 context.Context
+	nodes               []*Node
+	requestTimeouts     map[requestKind]time.Duration
+	healthCheckInterval time.Duration
+}
+
+// NewPool creates a pool with one beacon node per connection. The gRPC or REST clients of each
+// beacon node are created by the usual client factories, so the pool honors the same flags as a
+// validator client using a single beacon node.
+func NewPool(ctx context.Context, conns []validatorHelpers.NodeConnection) *Pool {
+	nodes := make([]*Node, len(conns))
+	for i, conn := range conns {
+		endpoint := conn.GetGrpcClientConn().Target()
+		if features.Get().EnableBeaconRESTApi {
+			endpoint = conn.GetBeaconApiUrl()
+		}
+		nodes[i] = &Node{
+			endpoint:          endpoint,
+			conn:              conn,
+			validatorClient:   validatorClientFactory.NewValidatorClient(conn),
+			beaconChainClient: beaconChainClientFactory.NewBeaconChainClient(conn),
+			nodeClient:        nodeClientFactory.NewNodeClient(conn),
+			slasherClient:     slasherClientFactory.NewSlasherClient(conn),
+			healthClient:      ethpb.NewHealthClient(conn.GetGrpcClientConn()),
+			health:            newHealth(),
+		}
+	}
+	return newPool(ctx, nodes)
+}
+
+func newPool(ctx context.Context, nodes []*Node) *Pool {
+	secondsPerSlot := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	return &Pool{
+		ctx:   ctx,
+		nodes: nodes,
+		requestTimeouts: map[requestKind]time.Duration{
+			// A beacon node that does not answer within a sixth of a slot leaves enough time to retry
+			// the request on another beacon node before the duty is due.
+			quickRequest: secondsPerSlot / 6,
+			blockRequest: secondsPerSlot / 2,
+			bulkRequest:  secondsPerSlot,
+		},
+		healthCheckInterval: secondsPerSlot / 2,
+	}
+}
+
+// Start checks the health of every beacon node of the pool periodically, until the context of the pool is done.
+func (p *Pool) Start() {
+	go func() {
+		p.checkHealth()
+		ticker := time.NewTicker(p.healthCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.checkHealth()
+			case <-p.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// checkHealth queries the sync status and the head slot of every beacon node.
+func (p *Pool) checkHealth() {
+	var wg sync.WaitGroup
+	for _, n := range p.nodes {
+		wg.Add(1)
+		go func(n *Node) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(p.ctx, p.requestTimeouts[quickRequest])
+			defer cancel()
+
+			syncStatus, err := n.nodeClient.GetSyncStatus(ctx, &empty.Empty{})
+			if err != nil {
+				log.WithError(err).WithField("endpoint", n.endpoint).Debug("Could not get beacon node sync status")
+				n.health.recordStatus(false, 0, err)
+				return
+			}
+			chainHead, err := n.beaconChainClient.GetChainHead(ctx, &empty.Empty{})
+			if err != nil {
+				log.WithError(err).WithField("endpoint", n.endpoint).Debug("Could not get beacon node chain head")
+				n.health.recordStatus(false, 0, err)
+				return
+			}
+			n.health.recordStatus(syncStatus.Syncing, chainHead.HeadSlot, nil)
+		}(n)
+	}
+	wg.Wait()
+}
+
+// ordered returns the beacon nodes from the healthiest to the least healthy one. Equally healthy
+// beacon nodes keep the order in which they were configured.
+func (p *Pool) ordered() []*Node {
+	var bestHeadSlot primitives.Slot
+	for _, n := range p.nodes {
+		if headSlot := n.health.head(); headSlot > bestHeadSlot {
+			bestHeadSlot = headSlot
+		}
+	}
+
+	nodes := make([]*Node, len(p.nodes))
+	scores := make(map[*Node]float64, len(p.nodes))
+	for i, n := range p.nodes {
+		nodes[i] = n
+		scores[n] = n.health.score(bestHeadSlot)
+		beaconNodeHealthScore.WithLabelValues(n.endpoint).Set(scores[n])
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return scores[nodes[i]] > scores[nodes[j]]
+	})
+	return nodes
+}
+
+// ActiveConnection returns the connection to the healthiest beacon node, for features which talk
+// to a beacon node directly rather than through the clients of the pool.
+func (p *Pool) ActiveConnection() validatorHelpers.NodeConnection {
+	return p.ordered()[0].conn
+}
+
+// call sends a request to the healthiest beacon node. If the beacon node fails to answer within
+// the timeout of the request kind, the request is retried on the next healthiest beacon node, and so on.
+func call[T any](ctx context.Context, p *Pool, method string, kind requestKind, f func(context.Context, *Node) (T, error)) (T, error) {
+	return failover(ctx, p, method, p.requestTimeouts[kind], f)
+}
+
+// callStream is like call, for requests which open a stream or wait for an event and therefore
+// cannot be bounded by a request timeout.
+func callStream[T any](ctx context.Context, p *Pool, method string, f func(context.Context, *Node) (T, error)) (T, error) {
+	return failover(ctx, p, method, 0, f)
+}
+
+func failover[T any](ctx context.Context, p *Pool, method string, timeout time.Duration, f func(context.Context, *Node) (T, error)) (T, error) {
+	var zero T
+	var firstErr error
+	nodes := p.ordered()
+	for i, n := range nodes {
+		// The last beacon node is given as much time as the caller allows, as there is no other one to try.
+		attemptTimeout := timeout
+		if i == len(nodes)-1 {
+			attemptTimeout = 0
+		}
+		resp, err := attempt(ctx, n, attemptTimeout, f)
+		if err == nil || !isNodeFailure(err) {
+			n.health.recordRequest(nil)
+			return resp, err
+		}
+		if ctx.Err() != nil {
+			// The caller gave up, there is no point in trying another beacon node.
+			return zero, err
+		}
+
+		n.health.recordRequest(err)
+		if firstErr == nil {
+			firstErr = err
+		}
+		if i < len(nodes)-1 {
+			beaconNodeFailoverCount.WithLabelValues(method).Inc()
+			log.WithError(err).WithFields(logrus.Fields{
+				"method":   method,
+				"endpoint": n.endpoint,
+				"next":     nodes[i+1].endpoint,
+			}).Warn("Beacon node request failed, trying the next beacon node")
+		}
+	}
+	return zero, firstErr
+}
+
+func attempt[T any](ctx context.Context, n *Node, timeout time.Duration, f func(context.Context, *Node) (T, error)) (T, error) {
+	if timeout == 0 {
+		return f(ctx, n)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return f(ctx, n)
+}
+
+// send sends a request to the given beacon node only, for requests which no other beacon node can serve.
+func send[T any](ctx context.Context, n *Node, method string, f func(context.Context, *Node) (T, error)) (T, error) {
+	resp, err := f(ctx, n)
+	if err != nil && isNodeFailure(err) {
+		n.health.recordRequest(err)
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":   method,
+			"endpoint": n.endpoint,
+		}).Debug("Beacon node request failed")
+	} else {
+		n.health.recordRequest(nil)
+	}
+	return resp, err
+}
+
+// broadcast sends a request to every beacon node at once and returns as soon as one of them succeeds.
+// The other requests keep going in the background, so that every beacon node gets the message even if
+// the caller moves on. If every beacon node fails, the error of the healthiest one is returned.
+func broadcast[T any](ctx context.Context, p *Pool, method string, kind requestKind, f func(context.Context, *Node) (T, error)) (T, error) {
+	type result struct {
+		index int
+		resp  T
+		err   error
+	}
+
+	nodes := p.ordered()
+	results := make(chan result, len(nodes))
+	for i, n := range nodes {
+		go func(i int, n *Node) {
+			requestCtx, cancel := detach(ctx, p, kind)
+			defer cancel()
+
+			resp, err := f(requestCtx, n)
+			if err != nil && isNodeFailure(err) {
+				n.health.recordRequest(err)
+				log.WithError(err).WithFields(logrus.Fields{
+					"method":   method,
+					"endpoint": n.endpoint,
+				}).Debug("Could not broadcast to beacon node")
+			} else {
+				n.health.recordRequest(nil)
+			}
+			results <- result{index: i, resp: resp, err: err}
+		}(i, n)
+	}
+
+	var zero T
+	errs := make([]error, len(nodes))
+	for range nodes {
+		r := <-results
+		if r.err == nil {
+			return r.resp, nil
+		}
+		errs[r.index] = r.err
+	}
+	return zero, errs[0]
+}
+
+// detach returns a context which outlives the given one, but carries its gRPC metadata and is
+// bounded by its deadline, or by the timeout of the request kind if it has none.
+func detach(ctx context.Context, p *Pool, kind requestKind) (context.Context, context.CancelFunc) {
+	detached := p.ctx
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		detached = metadata.NewOutgoingContext(detached, md)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(detached, deadline)
+	}
+	return context.WithTimeout(detached, p.requestTimeouts[kind])
+}
+
+// isNodeFailure tells whether an error means that the beacon node could not serve the request, because
+// it could not be reached, did not answer in time or failed internally. Any other error is a valid answer
+// rejecting the request, which any other beacon node would reject too.
+func isNodeFailure(err error) bool {
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown, codes.Unimplemented, codes.DataLoss:
+			return true
+		default:
+			return false
+		}
+	}
+	var httpErr *beaconApi.HttpError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) || errors.Is(err, context.DeadlineExceeded)
+}
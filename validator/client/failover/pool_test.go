@@ -0,0 +1,353 @@
+package failover
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/v4/testing/validator-mock"
+	beaconApi "github.com/prysmaticlabs/prysm/v4/validator/client/beacon-api"
+	validatorHelpers "github.com/prysmaticlabs/prysm/v4/validator/helpers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockNode struct {
+	*Node
+	validatorClient   *validatormock.MockValidatorClient
+	beaconChainClient *validatormock.MockBeaconChainClient
+	nodeClient        *validatormock.MockNodeClient
+}
+
+func newMockNode(ctrl *gomock.Controller, endpoint string) *mockNode {
+	m := &mockNode{
+		validatorClient:   validatormock.NewMockValidatorClient(ctrl),
+		beaconChainClient: validatormock.NewMockBeaconChainClient(ctrl),
+		nodeClient:        validatormock.NewMockNodeClient(ctrl),
+	}
+	m.Node = &Node{
+		endpoint:          endpoint,
+		conn:              validatorHelpers.NewNodeConnection(nil, endpoint, time.Second),
+		validatorClient:   m.validatorClient,
+		beaconChainClient: m.beaconChainClient,
+		nodeClient:        m.nodeClient,
+		slasherClient:     validatormock.NewMockSlasherClient(ctrl),
+		health:            newHealth(),
+	}
+	return m
+}
+
+func newTestPool(ctx context.Context, nodes ...*mockNode) *Pool {
+	poolNodes := make([]*Node, len(nodes))
+	for i, n := range nodes {
+		poolNodes[i] = n.Node
+	}
+	return newPool(ctx, poolNodes)
+}
+
+func TestPool_Ordered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first := newMockNode(ctrl, "first")
+	second := newMockNode(ctrl, "second")
+	third := newMockNode(ctrl, "third")
+	pool := newTestPool(context.Background(), first, second, third)
+
+	// Equally healthy beacon nodes keep their configured order.
+	nodes := pool.ordered()
+	assert.Equal(t, first.Node, nodes[0])
+	assert.Equal(t, second.Node, nodes[1])
+	assert.Equal(t, third.Node, nodes[2])
+
+	first.health.recordStatus(true, 100, nil)
+	second.health.recordStatus(false, 0, errors.New("connection refused"))
+	third.health.recordStatus(false, 100, nil)
+
+	nodes = pool.ordered()
+	assert.Equal(t, third.Node, nodes[0])
+	assert.Equal(t, first.Node, nodes[1])
+	assert.Equal(t, second.Node, nodes[2])
+}
+
+func TestPool_ActiveConnection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first := newMockNode(ctrl, "first")
+	second := newMockNode(ctrl, "second")
+	pool := newTestPool(context.Background(), first, second)
+	assert.Equal(t, "first", pool.ActiveConnection().GetBeaconApiUrl())
+
+	first.health.recordStatus(false, 0, errors.New("connection refused"))
+	second.health.recordStatus(false, 100, nil)
+	assert.Equal(t, "second", pool.ActiveConnection().GetBeaconApiUrl())
+}
+
+func TestPool_CheckHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	syncing := newMockNode(ctrl, "syncing")
+	syncing.nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(&ethpb.SyncStatus{Syncing: true}, nil)
+	syncing.beaconChainClient.EXPECT().GetChainHead(gomock.Any(), gomock.Any()).Return(&ethpb.ChainHead{HeadSlot: 10}, nil)
+
+	behind := newMockNode(ctrl, "behind")
+	behind.nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(&ethpb.SyncStatus{}, nil)
+	behind.beaconChainClient.EXPECT().GetChainHead(gomock.Any(), gomock.Any()).Return(&ethpb.ChainHead{HeadSlot: 90}, nil)
+
+	down := newMockNode(ctrl, "down")
+	down.nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+
+	healthy := newMockNode(ctrl, "healthy")
+	healthy.nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(&ethpb.SyncStatus{}, nil)
+	healthy.beaconChainClient.EXPECT().GetChainHead(gomock.Any(), gomock.Any()).Return(&ethpb.ChainHead{HeadSlot: 100}, nil)
+
+	pool := newTestPool(context.Background(), syncing, behind, down, healthy)
+	pool.checkHealth()
+
+	nodes := pool.ordered()
+	assert.Equal(t, healthy.Node, nodes[0])
+	assert.Equal(t, behind.Node, nodes[1])
+	assert.Equal(t, syncing.Node, nodes[2])
+	assert.Equal(t, down.Node, nodes[3])
+}
+
+func TestCall(t *testing.T) {
+	dutiesRequest := &ethpb.DutiesRequest{Epoch: 1}
+	dutiesResponse := &ethpb.DutiesResponse{CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{{ValidatorIndex: 1}}}
+
+	t.Run("uses the healthiest beacon node", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		first := newMockNode(ctrl, "first")
+		first.health.recordStatus(true, 100, nil)
+		second := newMockNode(ctrl, "second")
+		second.validatorClient.EXPECT().GetDuties(gomock.Any(), dutiesRequest).Return(dutiesResponse, nil)
+
+		client := NewValidatorClient(newTestPool(context.Background(), first, second))
+		resp, err := client.GetDuties(context.Background(), dutiesRequest)
+		require.NoError(t, err)
+		assert.DeepEqual(t, dutiesResponse, resp)
+	})
+
+	t.Run("fails over when a beacon node fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		first := newMockNode(ctrl, "first")
+		first.validatorClient.EXPECT().GetDuties(gomock.Any(), dutiesRequest).Return(nil, status.Error(codes.Unavailable, "unavailable"))
+		second := newMockNode(ctrl, "second")
+		second.validatorClient.EXPECT().GetDuties(gomock.Any(), dutiesRequest).Return(dutiesResponse, nil)
+
+		pool := newTestPool(context.Background(), first, second)
+		client := NewValidatorClient(pool)
+		resp, err := client.GetDuties(context.Background(), dutiesRequest)
+		require.NoError(t, err)
+		assert.DeepEqual(t, dutiesResponse, resp)
+
+		// The failing beacon node is not the preferred one anymore.
+		assert.Equal(t, second.Node, pool.ordered()[0])
+	})
+
+	t.Run("fails over when a beacon node does not answer in time", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		first := newMockNode(ctrl, "first")
+		first.validatorClient.EXPECT().GetDuties(gomock.Any(), dutiesRequest).DoAndReturn(
+			func(ctx context.Context, _ *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		)
+		second := newMockNode(ctrl, "second")
+		second.validatorClient.EXPECT().GetDuties(gomock.Any(), dutiesRequest).Return(dutiesResponse, nil)
+
+		pool := newTestPool(context.Background(), first, second)
+		pool.requestTimeouts[bulkRequest] = 10 * time.Millisecond
+		client := NewValidatorClient(pool)
+		resp, err := client.GetDuties(context.Background(), dutiesRequest)
+		require.NoError(t, err)
+		assert.DeepEqual(t, dutiesResponse, resp)
+	})
+
+	t.Run("does not fail over when the request is rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		first := newMockNode(ctrl, "first")
+		first.validatorClient.EXPECT().ValidatorIndex(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "unknown validator"))
+		second := newMockNode(ctrl, "second")
+
+		client := NewValidatorClient(newTestPool(context.Background(), first, second))
+		_, err := client.ValidatorIndex(context.Background(), &ethpb.ValidatorIndexRequest{})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("does not fail over when the REST request is rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		first := newMockNode(ctrl, "first")
+		first.validatorClient.EXPECT().GetDuties(gomock.Any(), dutiesRequest).Return(nil, errors.Wrap(&beaconApi.HttpError{StatusCode: http.StatusBadRequest, Message: "invalid epoch"}, "failed to get duties"))
+		second := newMockNode(ctrl, "second")
+
+		client := NewValidatorClient(newTestPool(context.Background(), first, second))
+		_, err := client.GetDuties(context.Background(), dutiesRequest)
+		assert.ErrorContains(t, "error 400: invalid epoch", err)
+	})
+
+	t.Run("fails over when the REST request fails on the beacon node", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		first := newMockNode(ctrl, "first")
+		first.validatorClient.EXPECT().GetDuties(gomock.Any(), dutiesRequest).Return(nil, errors.Wrap(&beaconApi.HttpError{StatusCode: http.StatusServiceUnavailable, Message: "syncing"}, "failed to get duties"))
+		second := newMockNode(ctrl, "second")
+		second.validatorClient.EXPECT().GetDuties(gomock.Any(), dutiesRequest).Return(dutiesResponse, nil)
+
+		client := NewValidatorClient(newTestPool(context.Background(), first, second))
+		resp, err := client.GetDuties(context.Background(), dutiesRequest)
+		require.NoError(t, err)
+		assert.DeepEqual(t, dutiesResponse, resp)
+	})
+
+	t.Run("returns the error of the healthiest beacon node when all fail", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		first := newMockNode(ctrl, "first")
+		first.validatorClient.EXPECT().GetDuties(gomock.Any(), dutiesRequest).Return(nil, status.Error(codes.Unavailable, "first error"))
+		second := newMockNode(ctrl, "second")
+		second.validatorClient.EXPECT().GetDuties(gomock.Any(), dutiesRequest).Return(nil, status.Error(codes.Unavailable, "second error"))
+
+		client := NewValidatorClient(newTestPool(context.Background(), first, second))
+		_, err := client.GetDuties(context.Background(), dutiesRequest)
+		assert.ErrorContains(t, "first error", err)
+	})
+}
+
+func TestBroadcast(t *testing.T) {
+	attestation := &ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}}
+	attestResponse := &ethpb.AttestResponse{AttestationDataRoot: []byte{1}}
+
+	t.Run("sends to every beacon node", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		received := make(chan string, 3)
+		first := newMockNode(ctrl, "first")
+		first.validatorClient.EXPECT().ProposeAttestation(gomock.Any(), attestation).DoAndReturn(
+			func(context.Context, *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+				received <- "first"
+				return nil, status.Error(codes.Unavailable, "unavailable")
+			},
+		)
+		second := newMockNode(ctrl, "second")
+		second.validatorClient.EXPECT().ProposeAttestation(gomock.Any(), attestation).DoAndReturn(
+			func(context.Context, *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+				received <- "second"
+				return attestResponse, nil
+			},
+		)
+		third := newMockNode(ctrl, "third")
+		third.validatorClient.EXPECT().ProposeAttestation(gomock.Any(), attestation).DoAndReturn(
+			func(context.Context, *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+				received <- "third"
+				return attestResponse, nil
+			},
+		)
+
+		client := NewValidatorClient(newTestPool(context.Background(), first, second, third))
+		ctx, cancel := context.WithCancel(context.Background())
+		resp, err := client.ProposeAttestation(ctx, attestation)
+		// Cancelling the context of the caller does not stop the broadcast.
+		cancel()
+		require.NoError(t, err)
+		assert.DeepEqual(t, attestResponse, resp)
+
+		receivers := make(map[string]bool)
+		for i := 0; i < 3; i++ {
+			receivers[<-received] = true
+		}
+		assert.Equal(t, 3, len(receivers))
+	})
+
+	t.Run("returns the error of the healthiest beacon node when all fail", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		first := newMockNode(ctrl, "first")
+		first.validatorClient.EXPECT().ProposeAttestation(gomock.Any(), attestation).Return(nil, status.Error(codes.Unavailable, "first error"))
+		second := newMockNode(ctrl, "second")
+		second.validatorClient.EXPECT().ProposeAttestation(gomock.Any(), attestation).Return(nil, status.Error(codes.Unavailable, "second error"))
+
+		client := NewValidatorClient(newTestPool(context.Background(), first, second))
+		_, err := client.ProposeAttestation(context.Background(), attestation)
+		assert.ErrorContains(t, "first error", err)
+	})
+}
+
+func TestProposeBlindedBlock(t *testing.T) {
+	blindedBlock := &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_BlindedCapella{BlindedCapella: &ethpb.BlindedBeaconBlockCapella{Slot: 5}}}
+	signedBlindedBlock := &ethpb.GenericSignedBeaconBlock{Block: &ethpb.GenericSignedBeaconBlock_BlindedCapella{
+		BlindedCapella: &ethpb.SignedBlindedBeaconBlockCapella{Block: &ethpb.BlindedBeaconBlockCapella{Slot: 5}},
+	}}
+	proposeResponse := &ethpb.ProposeResponse{BlockRoot: []byte{1}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first := newMockNode(ctrl, "first")
+	first.validatorClient.EXPECT().GetBeaconBlock(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "unavailable"))
+	// The producer of the blinded block is the only beacon node receiving it, even though it is not the healthiest one anymore.
+	second := newMockNode(ctrl, "second")
+	second.validatorClient.EXPECT().GetBeaconBlock(gomock.Any(), gomock.Any()).Return(blindedBlock, nil)
+	second.validatorClient.EXPECT().ProposeBeaconBlock(gomock.Any(), signedBlindedBlock).Return(proposeResponse, nil)
+	third := newMockNode(ctrl, "third")
+
+	client := NewValidatorClient(newTestPool(context.Background(), first, second, third))
+	blk, err := client.GetBeaconBlock(context.Background(), &ethpb.BlockRequest{Slot: 5})
+	require.NoError(t, err)
+	assert.DeepEqual(t, blindedBlock, blk)
+
+	second.health.recordStatus(true, 0, nil)
+	resp, err := client.ProposeBeaconBlock(context.Background(), signedBlindedBlock)
+	require.NoError(t, err)
+	assert.DeepEqual(t, proposeResponse, resp)
+}
+
+func TestIsNodeFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		failure bool
+	}{
+		{name: "gRPC unavailable", err: status.Error(codes.Unavailable, "unavailable"), failure: true},
+		{name: "gRPC deadline exceeded", err: status.Error(codes.DeadlineExceeded, "deadline exceeded"), failure: true},
+		{name: "gRPC internal", err: status.Error(codes.Internal, "internal"), failure: true},
+		{name: "gRPC not found", err: status.Error(codes.NotFound, "not found"), failure: false},
+		{name: "gRPC invalid argument", err: status.Error(codes.InvalidArgument, "invalid argument"), failure: false},
+		{name: "HTTP 500", err: errors.Wrap(&beaconApi.HttpError{StatusCode: http.StatusInternalServerError}, "wrapped"), failure: true},
+		{name: "HTTP 503", err: errors.Wrap(&beaconApi.HttpError{StatusCode: http.StatusServiceUnavailable}, "wrapped"), failure: true},
+		{name: "HTTP 400", err: errors.Wrap(&beaconApi.HttpError{StatusCode: http.StatusBadRequest}, "wrapped"), failure: false},
+		{name: "HTTP 404", err: errors.Wrap(&beaconApi.HttpError{StatusCode: http.StatusNotFound}, "wrapped"), failure: false},
+		{name: "HTTP transport", err: errors.Wrap(&url.Error{Op: "Get", URL: "http://localhost:3500", Err: errors.New("connection refused")}, "wrapped"), failure: true},
+		{name: "timeout", err: errors.Wrap(context.DeadlineExceeded, "wrapped"), failure: true},
+		{name: "invalid response", err: errors.New("failed to parse slot"), failure: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.failure, isNodeFailure(tt.err))
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package failover
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+)
+
+type slasherClient struct {
+	pool *Pool
+}
+
+// NewSlasherClient returns a slasher client querying the healthiest beacon node of the pool.
+func NewSlasherClient(pool *Pool) iface.SlasherClient {
+	return &slasherClient{pool: pool}
+}
+
+func (c *slasherClient) IsSlashableAttestation(ctx context.Context, in *ethpb.IndexedAttestation) (*ethpb.AttesterSlashingResponse, error) {
+	return call(ctx, c.pool, "IsSlashableAttestation", quickRequest, func(ctx context.Context, n *Node) (*ethpb.AttesterSlashingResponse, error) {
+		return n.slasherClient.IsSlashableAttestation(ctx, in)
+	})
+}
+
+func (c *slasherClient) IsSlashableBlock(ctx context.Context, in *ethpb.SignedBeaconBlockHeader) (*ethpb.ProposerSlashingResponse, error) {
+	return call(ctx, c.pool, "IsSlashableBlock", quickRequest, func(ctx context.Context, n *Node) (*ethpb.ProposerSlashingResponse, error) {
+		return n.slasherClient.IsSlashableBlock(ctx, in)
+	})
+}
@@ -0,0 +1,235 @@
+package failover
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+)
+
+type validatorClient struct {
+	pool *Pool
+	// blindedBlockNodes tracks which beacon node produced the blinded block of a slot. Only this beacon
+	// node knows the builder bid of the block, so it is the only one able to get its payload revealed.
+	blindedBlockNodes     map[primitives.Slot]*Node
+	blindedBlockNodesLock sync.Mutex
+}
+
+// NewValidatorClient returns a validator client backed by every beacon node of the pool. Duties and
+// other data are requested from the healthiest beacon node, while signed messages are broadcast to
+// every beacon node. Signed blinded blocks are only sent to the beacon node which produced them.
+func NewValidatorClient(pool *Pool) iface.ValidatorClient {
+	return &validatorClient{pool: pool, blindedBlockNodes: make(map[primitives.Slot]*Node)}
+}
+
+func (c *validatorClient) GetDuties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+	return call(ctx, c.pool, "GetDuties", bulkRequest, func(ctx context.Context, n *Node) (*ethpb.DutiesResponse, error) {
+		return n.validatorClient.GetDuties(ctx, in)
+	})
+}
+
+func (c *validatorClient) DomainData(ctx context.Context, in *ethpb.DomainRequest) (*ethpb.DomainResponse, error) {
+	return call(ctx, c.pool, "DomainData", quickRequest, func(ctx context.Context, n *Node) (*ethpb.DomainResponse, error) {
+		return n.validatorClient.DomainData(ctx, in)
+	})
+}
+
+func (c *validatorClient) WaitForChainStart(ctx context.Context, in *empty.Empty) (*ethpb.ChainStartResponse, error) {
+	return callStream(ctx, c.pool, "WaitForChainStart", func(ctx context.Context, n *Node) (*ethpb.ChainStartResponse, error) {
+		return n.validatorClient.WaitForChainStart(ctx, in)
+	})
+}
+
+func (c *validatorClient) WaitForActivation(ctx context.Context, in *ethpb.ValidatorActivationRequest) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+	return callStream(ctx, c.pool, "WaitForActivation", func(ctx context.Context, n *Node) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+		return n.validatorClient.WaitForActivation(ctx, in)
+	})
+}
+
+func (c *validatorClient) ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest) (*ethpb.ValidatorIndexResponse, error) {
+	return call(ctx, c.pool, "ValidatorIndex", quickRequest, func(ctx context.Context, n *Node) (*ethpb.ValidatorIndexResponse, error) {
+		return n.validatorClient.ValidatorIndex(ctx, in)
+	})
+}
+
+func (c *validatorClient) ValidatorStatus(ctx context.Context, in *ethpb.ValidatorStatusRequest) (*ethpb.ValidatorStatusResponse, error) {
+	return call(ctx, c.pool, "ValidatorStatus", quickRequest, func(ctx context.Context, n *Node) (*ethpb.ValidatorStatusResponse, error) {
+		return n.validatorClient.ValidatorStatus(ctx, in)
+	})
+}
+
+func (c *validatorClient) MultipleValidatorStatus(ctx context.Context, in *ethpb.MultipleValidatorStatusRequest) (*ethpb.MultipleValidatorStatusResponse, error) {
+	return call(ctx, c.pool, "MultipleValidatorStatus", bulkRequest, func(ctx context.Context, n *Node) (*ethpb.MultipleValidatorStatusResponse, error) {
+		return n.validatorClient.MultipleValidatorStatus(ctx, in)
+	})
+}
+
+func (c *validatorClient) GetBeaconBlock(ctx context.Context, in *ethpb.BlockRequest) (*ethpb.GenericBeaconBlock, error) {
+	var producer *Node
+	blk, err := call(ctx, c.pool, "GetBeaconBlock", blockRequest, func(ctx context.Context, n *Node) (*ethpb.GenericBeaconBlock, error) {
+		blk, err := n.validatorClient.GetBeaconBlock(ctx, in)
+		if err == nil {
+			producer = n
+		}
+		return blk, err
+	})
+	if err != nil || !isBlinded(blk) {
+		return blk, err
+	}
+
+	c.blindedBlockNodesLock.Lock()
+	defer c.blindedBlockNodesLock.Unlock()
+	for slot := range c.blindedBlockNodes {
+		if slot < in.Slot {
+			delete(c.blindedBlockNodes, slot)
+		}
+	}
+	c.blindedBlockNodes[in.Slot] = producer
+	return blk, nil
+}
+
+func (c *validatorClient) ProposeBeaconBlock(ctx context.Context, in *ethpb.GenericSignedBeaconBlock) (*ethpb.ProposeResponse, error) {
+	if slot, ok := blindedBlockSlot(in); ok {
+		c.blindedBlockNodesLock.Lock()
+		producer, ok := c.blindedBlockNodes[slot]
+		delete(c.blindedBlockNodes, slot)
+		c.blindedBlockNodesLock.Unlock()
+		if ok {
+			return send(ctx, producer, "ProposeBeaconBlock", func(ctx context.Context, n *Node) (*ethpb.ProposeResponse, error) {
+				return n.validatorClient.ProposeBeaconBlock(ctx, in)
+			})
+		}
+	}
+	return broadcast(ctx, c.pool, "ProposeBeaconBlock", blockRequest, func(ctx context.Context, n *Node) (*ethpb.ProposeResponse, error) {
+		return n.validatorClient.ProposeBeaconBlock(ctx, in)
+	})
+}
+
+func (c *validatorClient) PrepareBeaconProposer(ctx context.Context, in *ethpb.PrepareBeaconProposerRequest) (*empty.Empty, error) {
+	return broadcast(ctx, c.pool, "PrepareBeaconProposer", bulkRequest, func(ctx context.Context, n *Node) (*empty.Empty, error) {
+		return n.validatorClient.PrepareBeaconProposer(ctx, in)
+	})
+}
+
+func (c *validatorClient) GetFeeRecipientByPubKey(ctx context.Context, in *ethpb.FeeRecipientByPubKeyRequest) (*ethpb.FeeRecipientByPubKeyResponse, error) {
+	return call(ctx, c.pool, "GetFeeRecipientByPubKey", quickRequest, func(ctx context.Context, n *Node) (*ethpb.FeeRecipientByPubKeyResponse, error) {
+		return n.validatorClient.GetFeeRecipientByPubKey(ctx, in)
+	})
+}
+
+func (c *validatorClient) GetAttestationData(ctx context.Context, in *ethpb.AttestationDataRequest) (*ethpb.AttestationData, error) {
+	return call(ctx, c.pool, "GetAttestationData", quickRequest, func(ctx context.Context, n *Node) (*ethpb.AttestationData, error) {
+		return n.validatorClient.GetAttestationData(ctx, in)
+	})
+}
+
+func (c *validatorClient) ProposeAttestation(ctx context.Context, in *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+	return broadcast(ctx, c.pool, "ProposeAttestation", quickRequest, func(ctx context.Context, n *Node) (*ethpb.AttestResponse, error) {
+		return n.validatorClient.ProposeAttestation(ctx, in)
+	})
+}
+
+func (c *validatorClient) SubmitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest) (*ethpb.AggregateSelectionResponse, error) {
+	return call(ctx, c.pool, "SubmitAggregateSelectionProof", quickRequest, func(ctx context.Context, n *Node) (*ethpb.AggregateSelectionResponse, error) {
+		return n.validatorClient.SubmitAggregateSelectionProof(ctx, in)
+	})
+}
+
+func (c *validatorClient) SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest) (*ethpb.SignedAggregateSubmitResponse, error) {
+	return broadcast(ctx, c.pool, "SubmitSignedAggregateSelectionProof", quickRequest, func(ctx context.Context, n *Node) (*ethpb.SignedAggregateSubmitResponse, error) {
+		return n.validatorClient.SubmitSignedAggregateSelectionProof(ctx, in)
+	})
+}
+
+func (c *validatorClient) ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit) (*ethpb.ProposeExitResponse, error) {
+	return broadcast(ctx, c.pool, "ProposeExit", quickRequest, func(ctx context.Context, n *Node) (*ethpb.ProposeExitResponse, error) {
+		return n.validatorClient.ProposeExit(ctx, in)
+	})
+}
+
+func (c *validatorClient) SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, validatorIndices []primitives.ValidatorIndex) (*empty.Empty, error) {
+	return broadcast(ctx, c.pool, "SubscribeCommitteeSubnets", bulkRequest, func(ctx context.Context, n *Node) (*empty.Empty, error) {
+		return n.validatorClient.SubscribeCommitteeSubnets(ctx, in, validatorIndices)
+	})
+}
+
+func (c *validatorClient) CheckDoppelGanger(ctx context.Context, in *ethpb.DoppelGangerRequest) (*ethpb.DoppelGangerResponse, error) {
+	return call(ctx, c.pool, "CheckDoppelGanger", bulkRequest, func(ctx context.Context, n *Node) (*ethpb.DoppelGangerResponse, error) {
+		return n.validatorClient.CheckDoppelGanger(ctx, in)
+	})
+}
+
+func (c *validatorClient) GetLiveness(ctx context.Context, in *ethpbv2.GetLivenessRequest) (*ethpbv2.GetLivenessResponse, error) {
+	return call(ctx, c.pool, "GetLiveness", bulkRequest, func(ctx context.Context, n *Node) (*ethpbv2.GetLivenessResponse, error) {
+		return n.validatorClient.GetLiveness(ctx, in)
+	})
+}
+
+func (c *validatorClient) GetSyncMessageBlockRoot(ctx context.Context, in *empty.Empty) (*ethpb.SyncMessageBlockRootResponse, error) {
+	return call(ctx, c.pool, "GetSyncMessageBlockRoot", quickRequest, func(ctx context.Context, n *Node) (*ethpb.SyncMessageBlockRootResponse, error) {
+		return n.validatorClient.GetSyncMessageBlockRoot(ctx, in)
+	})
+}
+
+func (c *validatorClient) SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage) (*empty.Empty, error) {
+	return broadcast(ctx, c.pool, "SubmitSyncMessage", quickRequest, func(ctx context.Context, n *Node) (*empty.Empty, error) {
+		return n.validatorClient.SubmitSyncMessage(ctx, in)
+	})
+}
+
+func (c *validatorClient) GetSyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+	return call(ctx, c.pool, "GetSyncSubcommitteeIndex", quickRequest, func(ctx context.Context, n *Node) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+		return n.validatorClient.GetSyncSubcommitteeIndex(ctx, in)
+	})
+}
+
+func (c *validatorClient) GetSyncCommitteeContribution(ctx context.Context, in *ethpb.SyncCommitteeContributionRequest) (*ethpb.SyncCommitteeContribution, error) {
+	return call(ctx, c.pool, "GetSyncCommitteeContribution", quickRequest, func(ctx context.Context, n *Node) (*ethpb.SyncCommitteeContribution, error) {
+		return n.validatorClient.GetSyncCommitteeContribution(ctx, in)
+	})
+}
+
+func (c *validatorClient) SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof) (*empty.Empty, error) {
+	return broadcast(ctx, c.pool, "SubmitSignedContributionAndProof", quickRequest, func(ctx context.Context, n *Node) (*empty.Empty, error) {
+		return n.validatorClient.SubmitSignedContributionAndProof(ctx, in)
+	})
+}
+
+func (c *validatorClient) StreamBlocksAltair(ctx context.Context, in *ethpb.StreamBlocksRequest) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+	return callStream(ctx, c.pool, "StreamBlocksAltair", func(ctx context.Context, n *Node) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+		return n.validatorClient.StreamBlocksAltair(ctx, in)
+	})
+}
+
+func (c *validatorClient) SubmitValidatorRegistrations(ctx context.Context, in *ethpb.SignedValidatorRegistrationsV1) (*empty.Empty, error) {
+	return broadcast(ctx, c.pool, "SubmitValidatorRegistrations", bulkRequest, func(ctx context.Context, n *Node) (*empty.Empty, error) {
+		return n.validatorClient.SubmitValidatorRegistrations(ctx, in)
+	})
+}
+
+// blindedBlockSlot returns the slot of a signed blinded block, and false if the block is not blinded.
+func blindedBlockSlot(blk *ethpb.GenericSignedBeaconBlock) (primitives.Slot, bool) {
+	switch b := blk.Block.(type) {
+	case *ethpb.GenericSignedBeaconBlock_BlindedBellatrix:
+		return b.BlindedBellatrix.GetBlock().GetSlot(), true
+	case *ethpb.GenericSignedBeaconBlock_BlindedCapella:
+		return b.BlindedCapella.GetBlock().GetSlot(), true
+	case *ethpb.GenericSignedBeaconBlock_BlindedDeneb:
+		return b.BlindedDeneb.GetBlock().GetSlot(), true
+	default:
+		return 0, false
+	}
+}
+
+func isBlinded(blk *ethpb.GenericBeaconBlock) bool {
+	switch blk.Block.(type) {
+	case *ethpb.GenericBeaconBlock_BlindedBellatrix, *ethpb.GenericBeaconBlock_BlindedCapella, *ethpb.GenericBeaconBlock_BlindedDeneb:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/failover"
+	"github.com/prysmaticlabs/prysm/v4/validator/graffiti"
+)
+
+// executionClientVersionFetcher fetches the versions of the client implementations
+// run by the execution node connected to the beacon node.
+type executionClientVersionFetcher interface {
+	GetExecutionClientVersion(ctx context.Context) ([]*beacon.ClientVersion, error)
+}
+
+// activeBeaconNodeVersionFetcher asks the healthiest beacon node of the pool for the version of its
+// execution client, so that the graffiti follows the beacon node the validator client fails over to.
+type activeBeaconNodeVersionFetcher struct {
+	pool *failover.Pool
+}
+
+func (f *activeBeaconNodeVersionFetcher) GetExecutionClientVersion(ctx context.Context) ([]*beacon.ClientVersion, error) {
+	conn := f.pool.ActiveConnection()
+	c, err := beacon.NewClient(conn.GetBeaconApiUrl(), beacon.WithTimeout(conn.GetBeaconApiTimeout()))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create beacon API client")
+	}
+	return c.GetExecutionClientVersion(ctx)
+}
+
+// executionClientVersionCache keeps the version of the execution client, so that proposals
+// never wait for the beacon node to report it.
+type executionClientVersionCache struct {
+	fetcher executionClientVersionFetcher
+	lock    sync.RWMutex
+	version *graffiti.ExecutionClientVersion
+}
+
+// UpdateExecutionClientVersionCache requests the version of the execution client connected to the
+// beacon node, when the graffiti needs it. It is called once per epoch, in the background, so that
+// the execution client can be upgraded or replaced without restarting the validator client.
+func (v *validator) UpdateExecutionClientVersionCache(ctx context.Context) {
+	c := v.executionClientVersionCache
+	if c == nil || c.fetcher == nil || !v.graffitiUsesExecutionClient() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second)
+	defer cancel()
+	versions, err := c.fetcher.GetExecutionClientVersion(ctx)
+	if err != nil {
+		log.WithError(err).Warn("Could not get execution client version for graffiti")
+		return
+	}
+	var version *graffiti.ExecutionClientVersion
+	if len(versions) > 0 {
+		version = &graffiti.ExecutionClientVersion{
+			Code:    versions[0].Code,
+			Name:    versions[0].Name,
+			Version: versions[0].Version,
+			Commit:  versions[0].Commit,
+		}
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.version = version
+}
+
+// executionClientVersion returns the last known version of the execution client connected to
+// the beacon node, or nil when it is not known.
+func (v *validator) executionClientVersion() *graffiti.ExecutionClientVersion {
+	c := v.executionClientVersionCache
+	if c == nil {
+		return nil
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.version
+}
+
+// graffitiUsesExecutionClient tells whether any of the configured graffiti refers to the execution client.
+func (v *validator) graffitiUsesExecutionClient() bool {
+	v.graffitiLock.Lock()
+	defer v.graffitiLock.Unlock()
+	if len(v.graffiti) != 0 {
+		return graffiti.UsesExecutionClient(string(v.graffiti))
+	}
+	g := v.graffitiStruct
+	if g == nil {
+		return false
+	}
+	candidates := append([]string{g.Default}, g.Ordered...)
+	candidates = append(candidates, g.Random...)
+	for _, specific := range g.Specific {
+		candidates = append(candidates, specific)
+	}
+	for _, candidate := range candidates {
+		if graffiti.UsesExecutionClient(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// reloadGraffitiStruct replaces the graffiti read from the graffiti file. The ordered
+// graffiti start over from the beginning when the content of the file changed.
+func (v *validator) reloadGraffitiStruct(ctx context.Context, g *graffiti.Graffiti) {
+	v.graffitiLock.Lock()
+	defer v.graffitiLock.Unlock()
+	orderedIndex, err := v.db.GraffitiOrderedIndex(ctx, g.Hash)
+	if err != nil {
+		log.WithError(err).Error("Could not read graffiti ordered index from disk")
+		return
+	}
+	v.graffitiStruct = g
+	v.graffitiOrderedIndex = orderedIndex
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpbservice "github.com/prysmaticlabs/prysm/v4/proto/eth/service"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 	"google.golang.org/grpc"
@@ -13,6 +15,7 @@ import (
 
 type grpcValidatorClient struct {
 	beaconNodeValidatorClient ethpb.BeaconNodeValidatorClient
+	beaconValidatorClient     ethpbservice.BeaconValidatorClient
 }
 
 func (c *grpcValidatorClient) GetDuties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
@@ -23,6 +26,10 @@ func (c *grpcValidatorClient) CheckDoppelGanger(ctx context.Context, in *ethpb.D
 	return c.beaconNodeValidatorClient.CheckDoppelGanger(ctx, in)
 }
 
+func (c *grpcValidatorClient) GetLiveness(ctx context.Context, in *ethpbv2.GetLivenessRequest) (*ethpbv2.GetLivenessResponse, error) {
+	return c.beaconValidatorClient.GetLiveness(ctx, in)
+}
+
 func (c *grpcValidatorClient) DomainData(ctx context.Context, in *ethpb.DomainRequest) (*ethpb.DomainResponse, error) {
 	return c.beaconNodeValidatorClient.DomainData(ctx, in)
 }
@@ -135,5 +142,8 @@ func (c *grpcValidatorClient) AggregatedSigAndAggregationBits(
 }
 
 func NewGrpcValidatorClient(cc grpc.ClientConnInterface) iface.ValidatorClient {
-	return &grpcValidatorClient{ethpb.NewBeaconNodeValidatorClient(cc)}
+	return &grpcValidatorClient{
+		beaconNodeValidatorClient: ethpb.NewBeaconNodeValidatorClient(cc),
+		beaconValidatorClient:     ethpbservice.NewBeaconValidatorClient(cc),
+	}
 }
@@ -15,6 +15,8 @@ func (v *validator) HandleKeyReload(ctx context.Context, currentKeys [][fieldpar
 	ctx, span := trace.StartSpan(ctx, "validator.HandleKeyReload")
 	defer span.End()
 
+	v.trackDoppelGangerKeys(currentKeys)
+
 	statusRequestKeys := make([][]byte, len(currentKeys))
 	for i := range currentKeys {
 		statusRequestKeys[i] = currentKeys[i][:]
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+)
+
+// performanceHistory saves the performance of every validator key in each epoch to the database.
+// The beacon node reports the outcome of every duty from the canonical chain, so nothing is kept
+// in memory and the history survives restarts of the validator client.
+type performanceHistory struct {
+	retention primitives.Epoch
+}
+
+func newPerformanceHistory(retention primitives.Epoch) *performanceHistory {
+	return &performanceHistory{retention: retention}
+}
+
+// savePerformanceHistory saves the outcome of the duties of every validator key in the given
+// epoch to the database, and prunes the records which fell out of the retention window.
+func (v *validator) savePerformanceHistory(ctx context.Context, resp *ethpb.ValidatorPerformanceResponse, epoch primitives.Epoch) error {
+	records := make([]*kv.PerformanceRecord, len(resp.PublicKeys))
+	for i, pubKey := range resp.PublicKeys {
+		pubKeyBytes := bytesutil.ToBytes48(pubKey)
+		record := &kv.PerformanceRecord{
+			PubKey: pubKeyBytes,
+			Epoch:  epoch,
+		}
+		// Beacon nodes which do not look up the canonical blocks of the epoch, like the ones reached
+		// through the standard REST API, report no proposals and no sync committee messages.
+		if i < len(resp.ProposedBlocks) {
+			record.ProposedBlocks = resp.ProposedBlocks[i]
+		}
+		if i < len(resp.IncludedSyncCommitteeMessages) {
+			record.SyncCommitteeMessages = resp.IncludedSyncCommitteeMessages[i]
+		}
+		if i < len(resp.CorrectlyVotedSource) {
+			record.CorrectlyVotedSource = resp.CorrectlyVotedSource[i]
+		}
+		if i < len(resp.CorrectlyVotedTarget) {
+			record.CorrectlyVotedTarget = resp.CorrectlyVotedTarget[i]
+		}
+		if i < len(resp.CorrectlyVotedHead) {
+			record.CorrectlyVotedHead = resp.CorrectlyVotedHead[i]
+		}
+		// The beacon node reports the far future slot for attestations which were not included.
+		if i < len(resp.InclusionSlots) && resp.InclusionSlots[i] != params.BeaconConfig().FarFutureSlot {
+			record.AttestationIncluded = true
+			record.InclusionSlot = resp.InclusionSlots[i]
+			if i < len(resp.InclusionDistances) {
+				record.InclusionDistance = resp.InclusionDistances[i]
+			}
+		}
+		if i < len(resp.BalancesBeforeEpochTransition) {
+			record.BalanceBefore = resp.BalancesBeforeEpochTransition[i]
+		}
+		if i < len(resp.BalancesAfterEpochTransition) {
+			record.BalanceAfter = resp.BalancesAfterEpochTransition[i]
+		}
+		if i < len(resp.InactivityScores) {
+			record.InactivityScore = resp.InactivityScores[i]
+		}
+		records[i] = record
+	}
+	if err := v.db.SavePerformanceRecords(ctx, records); err != nil {
+		return errors.Wrap(err, "could not save performance records")
+	}
+	if epoch+1 > v.performanceHistory.retention {
+		if err := v.db.PrunePerformanceHistory(ctx, epoch+1-v.performanceHistory.retention); err != nil {
+			return errors.Wrap(err, "could not prune performance history")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/v4/testing/validator-mock"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+	dbTest "github.com/prysmaticlabs/prysm/v4/validator/db/testing"
+)
+
+func TestLogValidatorGainsAndLosses_SavesPerformanceHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	km := genMockKeymanager(t, 2)
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	client := validatormock.NewMockBeaconChainClient(ctrl)
+	db := dbTest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{})
+	v := &validator{
+		keyManager:         km,
+		beaconClient:       client,
+		db:                 db,
+		voteStats:          voteStats{startEpoch: primitives.Epoch(^uint64(0))},
+		performanceHistory: newPerformanceHistory(2),
+	}
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	require.NoError(t, db.SavePerformanceRecords(ctx, []*kv.PerformanceRecord{{PubKey: keys[0], Epoch: 7}}))
+
+	client.EXPECT().GetValidatorPerformance(gomock.Any(), gomock.Any()).Return(&ethpb.ValidatorPerformanceResponse{
+		PublicKeys:                    [][]byte{keys[0][:], keys[1][:]},
+		CorrectlyVotedSource:          []bool{true, false},
+		CorrectlyVotedTarget:          []bool{true, false},
+		CorrectlyVotedHead:            []bool{false, false},
+		BalancesBeforeEpochTransition: []uint64{32000000000, 32000000000},
+		BalancesAfterEpochTransition:  []uint64{32000010000, 31999990000},
+		InactivityScores:              []uint64{0, 4},
+		InclusionSlots:                []primitives.Slot{9*slotsPerEpoch + 2, params.BeaconConfig().FarFutureSlot},
+		InclusionDistances:            []primitives.Slot{1, params.BeaconConfig().FarFutureSlot},
+		ProposedBlocks:                []uint64{1, 0},
+		IncludedSyncCommitteeMessages: []uint64{0, 1},
+	}, nil)
+
+	// The performance of epoch 9 is reported at the end of epoch 10.
+	require.NoError(t, v.LogValidatorGainsAndLosses(ctx, 11*slotsPerEpoch-1))
+
+	records, err := db.PerformanceHistoryForPubKey(ctx, keys[0], 0, 20)
+	require.NoError(t, err)
+	// The record of epoch 7 fell out of the retention window of 2 epochs.
+	require.DeepEqual(t, []*kv.PerformanceRecord{{
+		PubKey:               keys[0],
+		Epoch:                9,
+		AttestationIncluded:  true,
+		InclusionSlot:        9*slotsPerEpoch + 2,
+		InclusionDistance:    1,
+		CorrectlyVotedSource: true,
+		CorrectlyVotedTarget: true,
+		ProposedBlocks:       1,
+		BalanceBefore:        32000000000,
+		BalanceAfter:         32000010000,
+	}}, records)
+
+	records, err = db.PerformanceHistoryForPubKey(ctx, keys[1], 0, 20)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*kv.PerformanceRecord{{
+		PubKey:                keys[1],
+		Epoch:                 9,
+		SyncCommitteeMessages: 1,
+		BalanceBefore:         32000000000,
+		BalanceAfter:          31999990000,
+		InactivityScore:       4,
+	}}, records)
+}
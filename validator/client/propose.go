@@ -23,6 +23,7 @@ import (
 	prysmTime "github.com/prysmaticlabs/prysm/v4/time"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/v4/validator/graffiti"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -64,7 +65,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 		return
 	}
 
-	g, err := v.getGraffiti(ctx, pubKey)
+	g, err := v.getGraffiti(ctx, pubKey, slot)
 	if err != nil {
 		// Graffiti is not a critical enough to fail block production and cause
 		// validator to miss block reward. When failed, validator should continue
@@ -130,6 +131,19 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 		}
 		return
 	}
+	// The blobs and their proofs travel next to the block and are not covered by its signature.
+	if contents, ok := b.Block.(*ethpb.GenericBeaconBlock_Deneb); ok {
+		signed, ok := proposal.Block.(*ethpb.GenericSignedBeaconBlock_Deneb)
+		if !ok {
+			log.Errorf("Unexpected proposal type %T for Deneb block contents", proposal.Block)
+			if v.emitAccountMetrics {
+				ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
+			}
+			return
+		}
+		signed.Deneb.KzgProofs = contents.Deneb.KzgProofs
+		signed.Deneb.Blobs = contents.Deneb.Blobs
+	}
 	blkResp, err := v.validatorClient.ProposeBeaconBlock(ctx, proposal)
 	if err != nil {
 		log.WithField("blockSlot", slot).WithError(err).Error("Failed to propose block")
@@ -155,6 +169,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 			"payloadHash": fmt.Sprintf("%#x", bytesutil.Trunc(p.BlockHash())),
 			"parentHash":  fmt.Sprintf("%#x", bytesutil.Trunc(p.ParentHash())),
 			"blockNumber": p.BlockNumber,
+			"blinded":     blk.IsBlinded(),
 		})
 		if !blk.IsBlinded() {
 			txs, err := p.Transactions()
@@ -352,25 +367,56 @@ func signVoluntaryExit(
 	return sig.Marshal(), nil
 }
 
-// Gets the graffiti from cli or file for the validator public key.
-func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, error) {
+// Gets the graffiti from cli or file for the validator public key. Graffiti templates
+// are filled in with the values of the proposal at the given slot.
+func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot) ([]byte, error) {
+	v.graffitiLock.Lock()
+	defer v.graffitiLock.Unlock()
+
+	g, idx, err := v.graffitiForKey(ctx, pubKey)
+	if err != nil {
+		return g, err
+	}
+	if !graffiti.IsTemplate(string(g)) {
+		return g, nil
+	}
+	if idx == nil {
+		resp, err := v.validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]})
+		if err != nil {
+			return []byte{}, err
+		}
+		idx = &resp.Index
+	}
+	data := &graffiti.TemplateData{
+		ValidatorIndex: *idx,
+		Slot:           slot,
+	}
+	if graffiti.UsesExecutionClient(string(g)) {
+		data.ExecutionClient = v.executionClientVersion()
+	}
+	return graffiti.RenderTemplate(string(g), data), nil
+}
+
+// Gets the raw graffiti from cli or file for the validator public key, along with
+// the validator index when it had to be looked up. The caller must hold the graffiti lock.
+func (v *validator) graffitiForKey(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, *primitives.ValidatorIndex, error) {
 	// When specified, default graffiti from the command line takes the first priority.
 	if len(v.graffiti) != 0 {
-		return v.graffiti, nil
+		return v.graffiti, nil, nil
 	}
 
 	if v.graffitiStruct == nil {
-		return nil, errors.New("graffitiStruct can't be nil")
+		return nil, nil, errors.New("graffitiStruct can't be nil")
 	}
 
 	// When specified, individual validator specified graffiti takes the second priority.
 	idx, err := v.validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]})
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, nil, err
 	}
 	g, ok := v.graffitiStruct.Specific[idx.Index]
 	if ok {
-		return []byte(g), nil
+		return []byte(g), &idx.Index, nil
 	}
 
 	// When specified, a graffiti from the ordered list in the file take third priority.
@@ -379,9 +425,9 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubk
 		v.graffitiOrderedIndex = v.graffitiOrderedIndex + 1
 		err := v.db.SaveGraffitiOrderedIndex(ctx, v.graffitiOrderedIndex)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to update graffiti ordered index")
+			return nil, nil, errors.Wrap(err, "failed to update graffiti ordered index")
 		}
-		return []byte(graffiti), nil
+		return []byte(graffiti), &idx.Index, nil
 	}
 
 	// When specified, a graffiti from the random list in the file take fourth priority.
@@ -389,13 +435,13 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubk
 		r := rand.NewGenerator()
 		r.Seed(time.Now().Unix())
 		i := r.Uint64() % uint64(len(v.graffitiStruct.Random))
-		return []byte(v.graffitiStruct.Random[i]), nil
+		return []byte(v.graffitiStruct.Random[i]), &idx.Index, nil
 	}
 
 	// Finally, default graffiti if specified in the file will be used.
 	if v.graffitiStruct.Default != "" {
-		return []byte(v.graffitiStruct.Default), nil
+		return []byte(v.graffitiStruct.Default), &idx.Index, nil
 	}
 
-	return []byte{}, nil
+	return []byte{}, &idx.Index, nil
 }
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"errors"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	lruwrpr "github.com/prysmaticlabs/prysm/v4/cache/lru"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
@@ -636,6 +638,47 @@ func testProposeBlock(t *testing.T, graffiti []byte) {
 	}
 }
 
+func TestProposeBlock_DenebIncludesBlobs(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	var pubKey [fieldparams.BLSPubkeyLength]byte
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+
+	blk := util.NewBeaconBlockDeneb()
+	blk.Block.Body.BlobKzgCommitments = [][]byte{bytes.Repeat([]byte{1}, 48)}
+	blobs := [][]byte{make([]byte, fieldparams.BlobLength)}
+	proofs := [][]byte{bytes.Repeat([]byte{2}, 48)}
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Times(2).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+	m.validatorClient.EXPECT().GetBeaconBlock(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.BlockRequest{}),
+	).Return(&ethpb.GenericBeaconBlock{
+		Block: &ethpb.GenericBeaconBlock_Deneb{
+			Deneb: &ethpb.BeaconBlockContentsDeneb{Block: blk.Block, KzgProofs: proofs, Blobs: blobs},
+		},
+	}, nil /*err*/)
+
+	var sent *ethpb.SignedBeaconBlockContentsDeneb
+	m.validatorClient.EXPECT().ProposeBeaconBlock(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.GenericSignedBeaconBlock{}),
+	).DoAndReturn(func(ctx context.Context, block *ethpb.GenericSignedBeaconBlock) (*ethpb.ProposeResponse, error) {
+		sent = block.GetDeneb()
+		return &ethpb.ProposeResponse{BlockRoot: make([]byte, 32)}, nil
+	})
+
+	validator.ProposeBlock(context.Background(), 1, pubKey)
+	require.NotNil(t, sent)
+	assert.DeepEqual(t, blobs, sent.Blobs)
+	assert.DeepEqual(t, proofs, sent.KzgProofs)
+	require.LogsContain(t, hook, "Submitted new block")
+}
+
 func TestProposeExit_ValidatorIndexFailed(t *testing.T) {
 	_, m, validatorKey, finish := setup(t)
 	defer finish()
@@ -983,7 +1026,7 @@ func TestGetGraffiti_Ok(t *testing.T) {
 					ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]}).
 					Return(&ethpb.ValidatorIndexResponse{Index: 2}, nil)
 			}
-			got, err := tt.v.getGraffiti(context.Background(), pubKey)
+			got, err := tt.v.getGraffiti(context.Background(), pubKey, 0)
 			require.NoError(t, err)
 			require.DeepEqual(t, tt.want, got)
 		})
@@ -1011,8 +1054,75 @@ func TestGetGraffitiOrdered_Ok(t *testing.T) {
 		},
 	}
 	for _, want := range [][]byte{{'a'}, {'b'}, {'c'}, {'d'}, {'d'}} {
-		got, err := v.getGraffiti(context.Background(), pubKey)
+		got, err := v.getGraffiti(context.Background(), pubKey, 0)
 		require.NoError(t, err)
 		require.DeepEqual(t, want, got)
 	}
 }
+
+type mockExecutionClientVersionFetcher struct {
+	versions []*beacon.ClientVersion
+	err      error
+	calls    int
+}
+
+func (m *mockExecutionClientVersionFetcher) GetExecutionClientVersion(_ context.Context) ([]*beacon.ClientVersion, error) {
+	m.calls++
+	return m.versions, m.err
+}
+
+func TestGetGraffiti_Template(t *testing.T) {
+	pubKey := [fieldparams.BLSPubkeyLength]byte{'a'}
+	ctrl := gomock.NewController(t)
+	m := &mocks{
+		validatorClient: validatormock.NewMockValidatorClient(ctrl),
+	}
+	m.validatorClient.EXPECT().
+		ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]}).
+		Times(2).
+		Return(&ethpb.ValidatorIndexResponse{Index: 42}, nil)
+	fetcher := &mockExecutionClientVersionFetcher{
+		versions: []*beacon.ClientVersion{{Code: "GE", Name: "Geth", Version: "1.13.5", Commit: "0xfa8a5e14"}},
+	}
+	v := &validator{
+		graffiti:                    []byte("{{el_code}}{{el_commit}} #{{index}} e{{epoch}}"),
+		validatorClient:             m.validatorClient,
+		executionClientVersionCache: &executionClientVersionCache{fetcher: fetcher},
+	}
+
+	// The execution client version is unknown until it is fetched in the background.
+	got, err := v.getGraffiti(context.Background(), pubKey, params.BeaconConfig().SlotsPerEpoch)
+	require.NoError(t, err)
+	require.Equal(t, "00000000 #42 e1", string(got))
+
+	v.UpdateExecutionClientVersionCache(context.Background())
+	got, err = v.getGraffiti(context.Background(), pubKey, params.BeaconConfig().SlotsPerEpoch+1)
+	require.NoError(t, err)
+	require.Equal(t, "GEfa8a5e14 #42 e1", string(got))
+	require.Equal(t, 1, fetcher.calls)
+}
+
+func TestGetGraffiti_TemplateExecutionClientUnavailable(t *testing.T) {
+	pubKey := [fieldparams.BLSPubkeyLength]byte{'a'}
+	valDB := testing2.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+	ctrl := gomock.NewController(t)
+	m := &mocks{
+		validatorClient: validatormock.NewMockValidatorClient(ctrl),
+	}
+	m.validatorClient.EXPECT().
+		ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]}).
+		Return(&ethpb.ValidatorIndexResponse{Index: 2}, nil)
+	v := &validator{
+		db:              valDB,
+		validatorClient: m.validatorClient,
+		graffitiStruct:  &graffiti.Graffiti{Default: "{{el_name}}slot {{slot}}"},
+		executionClientVersionCache: &executionClientVersionCache{
+			fetcher: &mockExecutionClientVersionFetcher{err: errors.New("not found")},
+		},
+	}
+
+	v.UpdateExecutionClientVersionCache(context.Background())
+	got, err := v.getGraffiti(context.Background(), pubKey, 7)
+	require.NoError(t, err)
+	require.Equal(t, "slot 7", string(got))
+}
@@ -0,0 +1,246 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/fsnotify/fsnotify"
+	"github.com/prysmaticlabs/prysm/v4/async"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+	"github.com/sirupsen/logrus"
+)
+
+// proposerSettingsDebounceInterval debounces the file system events fired when the proposer
+// settings file is written, as editors usually write a file in several steps.
+const proposerSettingsDebounceInterval = time.Second
+
+// ProposerSettingsLoader reads the proposer settings from the file or URL they were loaded from at startup.
+type ProposerSettingsLoader func(ctx context.Context) (*validatorserviceconfig.ProposerSettings, error)
+
+// proposerSettingsSource is the file or URL the proposer settings are reloaded from. The URL is
+// polled at the refresh interval.
+type proposerSettingsSource struct {
+	load    ProposerSettingsLoader
+	file    string
+	url     string
+	refresh time.Duration
+}
+
+// pendingProposerSettings are reloaded proposer settings waiting to be applied at the next epoch. They are
+// kept as loaded, and merged with the settings in use when applied.
+type pendingProposerSettings struct {
+	settings *validatorserviceconfig.ProposerSettings
+	source   string
+}
+
+// watchProposerSettingsFile reloads the proposer settings every time the proposer settings file
+// changes. The directory of the file is watched rather than the file itself, so that files
+// replaced on save by editors are followed. It returns once the context is canceled.
+func (v *validator) watchProposerSettingsFile(ctx context.Context, path string, load ProposerSettingsLoader) {
+	path = filepath.Clean(path)
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.WithError(err).Error("Could not initialize proposer settings file watcher")
+		return
+	}
+	defer func() {
+		if err := watcher.Close(); err != nil {
+			log.WithError(err).Error("Could not close proposer settings file watcher")
+		}
+	}()
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		log.WithError(err).Errorf("Could not watch proposer settings file %s", path)
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// The channel is not closed, as the debouncer could read from it before seeing the canceled
+	// context. The debouncer returns on cancellation, and the channel is then garbage collected.
+	fileChangesChan := make(chan interface{}, 100)
+
+	go async.Debounce(ctx, proposerSettingsDebounceInterval, fileChangesChan, func(_ interface{}) {
+		v.reloadProposerSettings(ctx, load, path)
+	})
+	for {
+		select {
+		case ev := <-watcher.Events:
+			if filepath.Clean(ev.Name) != path || !(ev.Has(fsnotify.Write) || ev.Has(fsnotify.Create)) {
+				continue
+			}
+			fileChangesChan <- ev
+		case err := <-watcher.Errors:
+			log.WithError(err).Error("Could not watch proposer settings file")
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// pollProposerSettingsURL reloads the proposer settings from the proposer settings URL at the given
+// interval. It returns once the context is canceled.
+func (v *validator) pollProposerSettingsURL(ctx context.Context, url string, interval time.Duration, load ProposerSettingsLoader) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			v.reloadProposerSettings(ctx, load, url)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reloadProposerSettings loads the proposer settings from their source and, when they differ from the
+// settings in use, stages them to be applied at the start of the next epoch. Settings which cannot be
+// loaded are logged and ignored, so the settings in use remain unchanged.
+func (v *validator) reloadProposerSettings(ctx context.Context, load ProposerSettingsLoader, source string) {
+	settings, err := load(ctx)
+	if err != nil {
+		log.WithError(err).Errorf("Could not reload proposer settings from %s, keeping the previous settings", source)
+		return
+	}
+	v.pendingProposerSettingsLock.Lock()
+	defer v.pendingProposerSettingsLock.Unlock()
+	latest := v.ProposerSettings()
+	if v.pendingProposerSettings != nil {
+		latest = mergeProposerSettings(latest, v.pendingProposerSettings.settings)
+	}
+	if len(proposerSettingsChanges(latest, mergeProposerSettings(latest, settings))) == 0 {
+		return
+	}
+	v.pendingProposerSettings = &pendingProposerSettings{settings: settings, source: source}
+	log.WithField("source", source).Info("Reloaded proposer settings, they will be applied at the start of the next epoch")
+}
+
+// applyPendingProposerSettings merges the reloaded proposer settings, if any, into the proposer settings in
+// use and saves them to the database. Every changed key is recorded in the proposer settings audit log.
+func (v *validator) applyPendingProposerSettings(ctx context.Context, epoch primitives.Epoch) error {
+	v.pendingProposerSettingsLock.Lock()
+	pending := v.pendingProposerSettings
+	v.pendingProposerSettings = nil
+	v.pendingProposerSettingsLock.Unlock()
+	if pending == nil {
+		return nil
+	}
+
+	settings := mergeProposerSettings(v.ProposerSettings(), pending.settings)
+	diffs := proposerSettingsChanges(v.ProposerSettings(), settings)
+	if len(diffs) == 0 {
+		return nil
+	}
+	if err := v.db.SaveProposerSettings(ctx, settings); err != nil {
+		return err
+	}
+	v.SetProposerSettings(settings)
+
+	now := uint64(time.Now().Unix())
+	changes := make([]*kv.ProposerSettingsChange, len(diffs))
+	for i, d := range diffs {
+		changes[i] = &kv.ProposerSettingsChange{
+			Timestamp: now,
+			Epoch:     epoch,
+			Source:    pending.source,
+			PubKey:    d.pubKey,
+			Previous:  d.previous,
+			Current:   d.current,
+		}
+		log.WithFields(proposerSettingsChangeFields(changes[i])).Info("Applied proposer settings change")
+	}
+	return v.db.SaveProposerSettingsChanges(ctx, changes)
+}
+
+// mergeProposerSettings merges reloaded proposer settings into the settings in use with the rules applied
+// to the settings saved in the database at startup: the default option is always updated, while the options
+// of the keys, which may have been set through the keymanager API, are only replaced when the reloaded
+// settings define options for keys.
+func mergeProposerSettings(current, reloaded *validatorserviceconfig.ProposerSettings) *validatorserviceconfig.ProposerSettings {
+	if current == nil || reloaded == nil || reloaded.ProposeConfig != nil {
+		return reloaded
+	}
+	merged := current.Clone()
+	merged.DefaultConfig = reloaded.DefaultConfig.Clone()
+	return merged
+}
+
+// proposerSettingsDiff is a change of the proposer option of a public key, or of the default proposer
+// option when pubKey is nil.
+type proposerSettingsDiff struct {
+	pubKey   []byte
+	previous *validatorserviceconfig.ProposerOption
+	current  *validatorserviceconfig.ProposerOption
+}
+
+// proposerSettingsChanges lists the proposer options which differ between the previous and the current
+// settings, with the default option first.
+func proposerSettingsChanges(previous, current *validatorserviceconfig.ProposerSettings) []*proposerSettingsDiff {
+	if previous == nil {
+		previous = &validatorserviceconfig.ProposerSettings{}
+	}
+	if current == nil {
+		current = &validatorserviceconfig.ProposerSettings{}
+	}
+	var diffs []*proposerSettingsDiff
+	if !previous.DefaultConfig.Equal(current.DefaultConfig) {
+		diffs = append(diffs, &proposerSettingsDiff{
+			previous: previous.DefaultConfig.Clone(),
+			current:  current.DefaultConfig.Clone(),
+		})
+	}
+	for k, option := range current.ProposeConfig {
+		previousOption := previous.ProposeConfig[k]
+		if previousOption.Equal(option) {
+			continue
+		}
+		diffs = append(diffs, newProposerSettingsDiff(k, previousOption, option))
+	}
+	for k, option := range previous.ProposeConfig {
+		if _, ok := current.ProposeConfig[k]; ok {
+			continue
+		}
+		diffs = append(diffs, newProposerSettingsDiff(k, option, nil))
+	}
+	return diffs
+}
+
+func newProposerSettingsDiff(pubKey [fieldparams.BLSPubkeyLength]byte, previous, current *validatorserviceconfig.ProposerOption) *proposerSettingsDiff {
+	return &proposerSettingsDiff{
+		pubKey:   bytesutil.SafeCopyBytes(pubKey[:]),
+		previous: previous.Clone(),
+		current:  current.Clone(),
+	}
+}
+
+func proposerSettingsChangeFields(change *kv.ProposerSettingsChange) logrus.Fields {
+	fields := logrus.Fields{
+		"epoch":  change.Epoch,
+		"source": change.Source,
+	}
+	if len(change.PubKey) == 0 {
+		fields["pubkey"] = "default"
+	} else {
+		fields["pubkey"] = hexutil.Encode(change.PubKey)
+	}
+	addOption := func(prefix string, option *validatorserviceconfig.ProposerOption) {
+		if option == nil {
+			fields[prefix] = "none"
+			return
+		}
+		if option.FeeRecipientConfig != nil {
+			fields[prefix+"FeeRecipient"] = option.FeeRecipientConfig.FeeRecipient.Hex()
+		}
+		if option.BuilderConfig != nil {
+			fields[prefix+"BuilderEnabled"] = option.BuilderConfig.Enabled
+			fields[prefix+"GasLimit"] = option.BuilderConfig.GasLimit
+		}
+	}
+	addOption("previous", change.Previous)
+	addOption("current", change.Current)
+	return fields
+}
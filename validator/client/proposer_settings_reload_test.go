@@ -0,0 +1,224 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
+	validatortypes "github.com/prysmaticlabs/prysm/v4/consensus-types/validator"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	testing2 "github.com/prysmaticlabs/prysm/v4/validator/db/testing"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func testProposerOption(feeRecipient string, gasLimit uint64) *validatorserviceconfig.ProposerOption {
+	return &validatorserviceconfig.ProposerOption{
+		FeeRecipientConfig: &validatorserviceconfig.FeeRecipientConfig{
+			FeeRecipient: common.HexToAddress(feeRecipient),
+		},
+		BuilderConfig: &validatorserviceconfig.BuilderConfig{
+			Enabled:  true,
+			GasLimit: validatortypes.Uint64(gasLimit),
+		},
+	}
+}
+
+func TestProposerSettingsChanges(t *testing.T) {
+	pubKey1 := [fieldparams.BLSPubkeyLength]byte{1}
+	pubKey2 := [fieldparams.BLSPubkeyLength]byte{2}
+	pubKey3 := [fieldparams.BLSPubkeyLength]byte{3}
+	previous := &validatorserviceconfig.ProposerSettings{
+		DefaultConfig: testProposerOption("0x01", 30000000),
+		ProposeConfig: map[[fieldparams.BLSPubkeyLength]byte]*validatorserviceconfig.ProposerOption{
+			pubKey1: testProposerOption("0x11", 30000000),
+			pubKey2: testProposerOption("0x12", 30000000),
+		},
+	}
+
+	require.Equal(t, 0, len(proposerSettingsChanges(previous, previous.Clone())))
+
+	current := previous.Clone()
+	current.DefaultConfig = testProposerOption("0x01", 35000000)
+	current.ProposeConfig[pubKey1] = testProposerOption("0x21", 30000000)
+	delete(current.ProposeConfig, pubKey2)
+	current.ProposeConfig[pubKey3] = testProposerOption("0x13", 30000000)
+
+	changes := proposerSettingsChanges(previous, current)
+	require.Equal(t, 4, len(changes))
+	require.Equal(t, 0, len(changes[0].pubKey))
+	require.DeepEqual(t, previous.DefaultConfig, changes[0].previous)
+	require.DeepEqual(t, current.DefaultConfig, changes[0].current)
+	byKey := make(map[[fieldparams.BLSPubkeyLength]byte]*proposerSettingsDiff)
+	for _, c := range changes[1:] {
+		byKey[bytesutil.ToBytes48(c.pubKey)] = c
+	}
+	require.DeepEqual(t, previous.ProposeConfig[pubKey1], byKey[pubKey1].previous)
+	require.DeepEqual(t, current.ProposeConfig[pubKey1], byKey[pubKey1].current)
+	require.DeepEqual(t, previous.ProposeConfig[pubKey2], byKey[pubKey2].previous)
+	require.Equal(t, (*validatorserviceconfig.ProposerOption)(nil), byKey[pubKey2].current)
+	require.Equal(t, (*validatorserviceconfig.ProposerOption)(nil), byKey[pubKey3].previous)
+	require.DeepEqual(t, current.ProposeConfig[pubKey3], byKey[pubKey3].current)
+}
+
+func TestReloadProposerSettings_AppliedAtNextEpoch(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	initial := &validatorserviceconfig.ProposerSettings{
+		DefaultConfig: testProposerOption("0x01", 30000000),
+		ProposeConfig: map[[fieldparams.BLSPubkeyLength]byte]*validatorserviceconfig.ProposerOption{
+			pubKey: testProposerOption("0x11", 30000000),
+		},
+	}
+	v := &validator{
+		db:               testing2.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey}),
+		proposerSettings: initial,
+	}
+
+	// Unchanged settings are not staged.
+	v.reloadProposerSettings(ctx, func(context.Context) (*validatorserviceconfig.ProposerSettings, error) {
+		return initial.Clone(), nil
+	}, "proposer.yaml")
+	require.Equal(t, (*pendingProposerSettings)(nil), v.pendingProposerSettings)
+
+	// Settings which cannot be loaded are ignored.
+	hook := logTest.NewGlobal()
+	v.reloadProposerSettings(ctx, func(context.Context) (*validatorserviceconfig.ProposerSettings, error) {
+		return nil, errors.New("bad file")
+	}, "proposer.yaml")
+	require.LogsContain(t, hook, "Could not reload proposer settings")
+	require.Equal(t, (*pendingProposerSettings)(nil), v.pendingProposerSettings)
+
+	updated := initial.Clone()
+	updated.ProposeConfig[pubKey] = testProposerOption("0x21", 30000000)
+	v.reloadProposerSettings(ctx, func(context.Context) (*validatorserviceconfig.ProposerSettings, error) {
+		return updated, nil
+	}, "proposer.yaml")
+	require.NotNil(t, v.pendingProposerSettings)
+	// The settings in use only change at the next epoch.
+	require.DeepEqual(t, initial, v.ProposerSettings())
+
+	require.NoError(t, v.applyPendingProposerSettings(ctx, 7))
+	require.DeepEqual(t, updated, v.ProposerSettings())
+	require.Equal(t, (*pendingProposerSettings)(nil), v.pendingProposerSettings)
+	require.LogsContain(t, hook, "Applied proposer settings change")
+
+	saved, err := v.db.ProposerSettings(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, updated, saved)
+	changes, err := v.db.ProposerSettingsChanges(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(changes))
+	require.DeepEqual(t, pubKey[:], changes[0].PubKey)
+	require.Equal(t, "proposer.yaml", changes[0].Source)
+	require.Equal(t, uint64(7), uint64(changes[0].Epoch))
+	require.DeepEqual(t, initial.ProposeConfig[pubKey], changes[0].Previous)
+	require.DeepEqual(t, updated.ProposeConfig[pubKey], changes[0].Current)
+
+	// Nothing is applied when no settings were reloaded.
+	require.NoError(t, v.applyPendingProposerSettings(ctx, 8))
+	changes, err = v.db.ProposerSettingsChanges(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(changes))
+}
+
+func TestReloadProposerSettings_MergedLikeAtStartup(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	initial := &validatorserviceconfig.ProposerSettings{
+		DefaultConfig: testProposerOption("0x01", 30000000),
+		ProposeConfig: map[[fieldparams.BLSPubkeyLength]byte]*validatorserviceconfig.ProposerOption{
+			pubKey: testProposerOption("0x11", 30000000),
+		},
+	}
+	v := &validator{
+		db:               testing2.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey}),
+		proposerSettings: initial,
+	}
+	load := func(settings *validatorserviceconfig.ProposerSettings) ProposerSettingsLoader {
+		return func(context.Context) (*validatorserviceconfig.ProposerSettings, error) {
+			return settings, nil
+		}
+	}
+
+	// Settings without options for keys only update the default option, the options of the keys are kept.
+	v.reloadProposerSettings(ctx, load(&validatorserviceconfig.ProposerSettings{DefaultConfig: initial.DefaultConfig.Clone()}), "proposer.yaml")
+	require.Equal(t, (*pendingProposerSettings)(nil), v.pendingProposerSettings)
+	v.reloadProposerSettings(ctx, load(&validatorserviceconfig.ProposerSettings{DefaultConfig: testProposerOption("0x02", 30000000)}), "proposer.yaml")
+	require.NoError(t, v.applyPendingProposerSettings(ctx, 7))
+	want := initial.Clone()
+	want.DefaultConfig = testProposerOption("0x02", 30000000)
+	require.DeepEqual(t, want, v.ProposerSettings())
+	saved, err := v.db.ProposerSettings(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, want, saved)
+	changes, err := v.db.ProposerSettingsChanges(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(changes))
+	require.Equal(t, 0, len(changes[0].PubKey))
+
+	// Settings with options for keys replace the options of the keys.
+	replaced := &validatorserviceconfig.ProposerSettings{
+		DefaultConfig: testProposerOption("0x02", 30000000),
+		ProposeConfig: map[[fieldparams.BLSPubkeyLength]byte]*validatorserviceconfig.ProposerOption{
+			{2}: testProposerOption("0x12", 30000000),
+		},
+	}
+	v.reloadProposerSettings(ctx, load(replaced), "proposer.yaml")
+	require.NoError(t, v.applyPendingProposerSettings(ctx, 8))
+	require.DeepEqual(t, replaced, v.ProposerSettings())
+}
+
+func TestWatchProposerSettingsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "proposer.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("first"), os.ModePerm))
+	v := &validator{
+		proposerSettings: &validatorserviceconfig.ProposerSettings{DefaultConfig: testProposerOption("0x01", 30000000)},
+	}
+	load := func(context.Context) (*validatorserviceconfig.ProposerSettings, error) {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if string(b) != "second" {
+			return v.ProposerSettings(), nil
+		}
+		return &validatorserviceconfig.ProposerSettings{DefaultConfig: testProposerOption("0x02", 30000000)}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		v.watchProposerSettingsFile(ctx, path, load)
+		close(done)
+	}()
+	// Give the watcher time to start.
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, os.WriteFile(path, []byte("second"), os.ModePerm))
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		v.pendingProposerSettingsLock.Lock()
+		pending := v.pendingProposerSettings
+		v.pendingProposerSettingsLock.Unlock()
+		if pending != nil {
+			require.Equal(t, path, pending.source)
+			require.Equal(t, common.HexToAddress("0x02"), pending.settings.DefaultConfig.FeeRecipientConfig.FeeRecipient)
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("proposer settings file was not reloaded")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	cancel()
+	<-done
+}
@@ -43,6 +43,7 @@ func run(ctx context.Context, v iface.Validator) {
 
 	connectionErrorChannel := make(chan error, 1)
 	go v.ReceiveBlocks(ctx, connectionErrorChannel)
+	go v.UpdateExecutionClientVersionCache(ctx)
 	if err := v.UpdateDuties(ctx, headSlot); err != nil {
 		handleAssignmentError(err, headSlot)
 	}
@@ -126,9 +127,11 @@ func run(ctx context.Context, v iface.Validator) {
 				}()
 			}
 
-			// Start fetching domain data for the next epoch.
+			// Start fetching domain data and the execution client version for the next epoch.
 			if slots.IsEpochEnd(slot) {
 				go v.UpdateDomainDataCaches(ctx, slot+1)
+				go v.UpdateExecutionClientVersionCache(ctx)
+				go checkDoppelGangerLiveness(ctx, v, slot)
 			}
 
 			var wg sync.WaitGroup
@@ -145,6 +148,20 @@ func run(ctx context.Context, v iface.Validator) {
 	}
 }
 
+// checkDoppelGangerLiveness runs the doppelganger check at the end of an epoch. The check is not bound
+// to the deadline of the slot duties, which would often cut it short, but it is given one slot to complete.
+func checkDoppelGangerLiveness(ctx context.Context, v iface.Validator, slot primitives.Slot) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second)
+	defer cancel()
+	err := v.CheckDoppelGangerLiveness(ctx, slot)
+	if errors.Is(err, errDoppelGangerFound) {
+		log.WithError(err).Fatal("Doppelganger found, stopping validator")
+	}
+	if err != nil {
+		log.WithError(err).Warn("Could not check doppelganger liveness")
+	}
+}
+
 func onAccountsChanged(ctx context.Context, v iface.Validator, current [][48]byte, ac chan [][fieldparams.BLSPubkeyLength]byte) {
 	anyActive, err := v.HandleKeyReload(ctx, current)
 	if err != nil {
@@ -11,9 +11,11 @@ import (
 	grpcopentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	grpcprometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
 	grpcutil "github.com/prysmaticlabs/prysm/v4/api/grpc"
 	"github.com/prysmaticlabs/prysm/v4/async/event"
 	lruwrpr "github.com/prysmaticlabs/prysm/v4/cache/lru"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	validatorserviceconfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
@@ -22,6 +24,7 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/validator/accounts/wallet"
 	beaconChainClientFactory "github.com/prysmaticlabs/prysm/v4/validator/client/beacon-chain-client-factory"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/failover"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 	nodeClientFactory "github.com/prysmaticlabs/prysm/v4/validator/client/node-client-factory"
 	slasherClientFactory "github.com/prysmaticlabs/prysm/v4/validator/client/slasher-client-factory"
@@ -58,6 +61,8 @@ type ValidatorService struct {
 	logValidatorBalances  bool
 	interopKeysConfig     *local.InteropKeymanagerConfig
 	conn                  validatorHelpers.NodeConnection
+	conns                 []validatorHelpers.NodeConnection
+	pool                  *failover.Pool
 	grpcRetryDelay        time.Duration
 	grpcRetries           uint
 	maxCallRecvMsgSize    int
@@ -65,6 +70,7 @@ type ValidatorService struct {
 	walletInitializedFeed *event.Feed
 	wallet                *wallet.Wallet
 	graffitiStruct        *graffiti.Graffiti
+	graffitiFile          string
 	dataDir               string
 	withCert              string
 	endpoint              string
@@ -75,6 +81,9 @@ type ValidatorService struct {
 	graffiti              []byte
 	Web3SignerConfig      *remoteweb3signer.SetupConfig
 	proposerSettings      *validatorserviceconfig.ProposerSettings
+	proposerSettingsSrc   proposerSettingsSource
+	doppelGangerEpochs    primitives.Epoch
+	performanceEpochs     primitives.Epoch
 }
 
 // Config for the validator service.
@@ -89,6 +98,7 @@ type Config struct {
 	GrpcMaxCallRecvMsgSizeFlag int
 	GrpcRetryDelay             time.Duration
 	GraffitiStruct             *graffiti.Graffiti
+	GraffitiFile               string
 	Validator                  iface.Validator
 	ValDB                      db.Database
 	CertFlag                   string
@@ -98,8 +108,14 @@ type Config struct {
 	Endpoint                   string
 	Web3SignerConfig           *remoteweb3signer.SetupConfig
 	ProposerSettings           *validatorserviceconfig.ProposerSettings
+	ProposerSettingsLoader     ProposerSettingsLoader
+	ProposerSettingsFile       string
+	ProposerSettingsURL        string
+	ProposerSettingsRefresh    time.Duration
 	BeaconApiEndpoint          string
 	BeaconApiTimeout           time.Duration
+	DoppelGangerEpochs         primitives.Epoch
+	PerformanceHistoryEpochs   primitives.Epoch
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -126,8 +142,17 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		useWeb:                cfg.UseWeb,
 		interopKeysConfig:     cfg.InteropKeysConfig,
 		graffitiStruct:        cfg.GraffitiStruct,
+		graffitiFile:          cfg.GraffitiFile,
 		Web3SignerConfig:      cfg.Web3SignerConfig,
 		proposerSettings:      cfg.ProposerSettings,
+		doppelGangerEpochs:    cfg.DoppelGangerEpochs,
+		performanceEpochs:     cfg.PerformanceHistoryEpochs,
+		proposerSettingsSrc: proposerSettingsSource{
+			load:    cfg.ProposerSettingsLoader,
+			file:    cfg.ProposerSettingsFile,
+			url:     cfg.ProposerSettingsURL,
+			refresh: cfg.ProposerSettingsRefresh,
+		},
 	}
 
 	dialOpts := ConstructDialOptions(
@@ -142,22 +167,62 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 
 	s.ctx = grpcutil.AppendHeaders(ctx, s.grpcHeaders)
 
-	grpcConn, err := grpc.DialContext(ctx, s.endpoint, dialOpts...)
-	if err != nil {
-		return s, err
+	// Each beacon node gets its own connection, so that the validator client can tell which beacon node
+	// is healthy and fail over to another one when it is not.
+	beaconApiEndpoints := splitEndpoints(cfg.BeaconApiEndpoint)
+	if features.Get().EnableBeaconRESTApi {
+		grpcConn, err := grpc.DialContext(ctx, s.endpoint, dialOpts...)
+		if err != nil {
+			return s, err
+		}
+		for _, beaconApiEndpoint := range beaconApiEndpoints {
+			s.conns = append(s.conns, validatorHelpers.NewNodeConnection(
+				grpcConn,
+				beaconApiEndpoint,
+				cfg.BeaconApiTimeout,
+			))
+		}
+	} else {
+		for _, endpoint := range splitEndpoints(s.endpoint) {
+			grpcConn, err := grpc.DialContext(ctx, endpoint, dialOpts...)
+			if err != nil {
+				return s, err
+			}
+			s.conns = append(s.conns, validatorHelpers.NewNodeConnection(
+				grpcConn,
+				beaconApiEndpoints[0],
+				cfg.BeaconApiTimeout,
+			))
+		}
 	}
 	if s.withCert != "" {
 		log.Info("Established secure gRPC connection")
 	}
-	s.conn = validatorHelpers.NewNodeConnection(
-		grpcConn,
-		cfg.BeaconApiEndpoint,
-		cfg.BeaconApiTimeout,
-	)
+	s.conn = s.conns[0]
+	if len(s.conns) > 1 {
+		s.pool = failover.NewPool(s.ctx, s.conns)
+		log.WithField("beaconNodes", len(s.conns)).Info("Using multiple beacon nodes with failover")
+	}
 
 	return s, nil
 }
 
+// splitEndpoints splits a comma-separated list of beacon node endpoints, ignoring the spaces around
+// each endpoint and the empty entries left by stray commas. An empty list yields a single empty
+// endpoint, which fails when used like any other invalid endpoint.
+func splitEndpoints(endpoints string) []string {
+	var split []string
+	for _, endpoint := range strings.Split(endpoints, ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			split = append(split, endpoint)
+		}
+	}
+	if len(split) == 0 {
+		return []string{""}
+	}
+	return split
+}
+
 // Start the validator service. Launches the main go routine for the validator
 // client.
 func (v *ValidatorService) Start() {
@@ -198,13 +263,22 @@ func (v *ValidatorService) Start() {
 
 	validatorClient := validatorClientFactory.NewValidatorClient(v.conn)
 	beaconClient := beaconChainClientFactory.NewBeaconChainClient(v.conn)
+	slasherClient := slasherClientFactory.NewSlasherClient(v.conn)
+	nodeClient := nodeClientFactory.NewNodeClient(v.conn)
+	if v.pool != nil {
+		v.pool.Start()
+		validatorClient = failover.NewValidatorClient(v.pool)
+		beaconClient = failover.NewBeaconChainClient(v.pool)
+		slasherClient = failover.NewSlasherClient(v.pool)
+		nodeClient = failover.NewNodeClient(v.pool)
+	}
 
 	valStruct := &validator{
 		db:                             v.db,
 		validatorClient:                validatorClient,
 		beaconClient:                   beaconClient,
-		slashingProtectionClient:       slasherClientFactory.NewSlasherClient(v.conn),
-		node:                           nodeClientFactory.NewNodeClient(v.conn),
+		slashingProtectionClient:       slasherClient,
+		node:                           nodeClient,
 		graffiti:                       v.graffiti,
 		logValidatorBalances:           v.logValidatorBalances,
 		emitAccountMetrics:             v.emitAccountMetrics,
@@ -229,6 +303,32 @@ func (v *ValidatorService) Start() {
 		proposerSettings:               v.proposerSettings,
 		walletInitializedChannel:       make(chan *wallet.Wallet, 1),
 	}
+	if features.Get().EnableDoppelGanger {
+		valStruct.doppelGanger = newDoppelGangerTracker(v.doppelGangerEpochs)
+	}
+	if v.performanceEpochs > 0 {
+		valStruct.performanceHistory = newPerformanceHistory(v.performanceEpochs)
+	}
+	if v.pool != nil {
+		valStruct.executionClientVersionCache = &executionClientVersionCache{fetcher: &activeBeaconNodeVersionFetcher{pool: v.pool}}
+	} else if elClient, err := beacon.NewClient(v.conn.GetBeaconApiUrl(), beacon.WithTimeout(v.conn.GetBeaconApiTimeout())); err != nil {
+		log.WithError(err).Warn("Could not create beacon API client, execution client fields of graffiti templates will be empty")
+	} else {
+		valStruct.executionClientVersionCache = &executionClientVersionCache{fetcher: elClient}
+	}
+	if v.proposerSettingsSrc.load != nil {
+		switch {
+		case v.proposerSettingsSrc.file != "":
+			go valStruct.watchProposerSettingsFile(v.ctx, v.proposerSettingsSrc.file, v.proposerSettingsSrc.load)
+		case v.proposerSettingsSrc.url != "" && v.proposerSettingsSrc.refresh > 0:
+			go valStruct.pollProposerSettingsURL(v.ctx, v.proposerSettingsSrc.url, v.proposerSettingsSrc.refresh, v.proposerSettingsSrc.load)
+		}
+	}
+	if v.graffitiFile != "" {
+		go graffiti.WatchGraffitiFile(v.ctx, v.graffitiFile, func(g *graffiti.Graffiti) {
+			valStruct.reloadGraffitiStruct(v.ctx, g)
+		})
+	}
 
 	// To resolve a race condition at startup due to the interface
 	// nature of the abstracted block type. We initialize
@@ -248,12 +348,27 @@ func (v *ValidatorService) Start() {
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
-	if v.conn != nil {
-		return v.conn.GetGrpcClientConn().Close()
+	// Beacon nodes share the same gRPC connection when using the REST API.
+	closed := make(map[*grpc.ClientConn]bool)
+	for _, conn := range v.conns {
+		grpcConn := conn.GetGrpcClientConn()
+		if closed[grpcConn] {
+			continue
+		}
+		closed[grpcConn] = true
+		if err := grpcConn.Close(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// BeaconNodePool returns the pool of beacon nodes the validator client fails over between, or nil
+// when a single beacon node is configured.
+func (v *ValidatorService) BeaconNodePool() *failover.Pool {
+	return v.pool
+}
+
 // Status of the validator service.
 func (v *ValidatorService) Status() error {
 	if v.conn == nil {
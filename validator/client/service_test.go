@@ -39,6 +39,18 @@ func TestNew_Insecure(t *testing.T) {
 	require.LogsContain(t, hook, "You are using an insecure gRPC connection")
 }
 
+func TestNew_MultipleBeaconNodes(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validatorService, err := NewValidatorService(context.Background(), &Config{Endpoint: " 127.0.0.1:4000, ,127.0.0.1:4001,"})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(validatorService.conns))
+	assert.NotNil(t, validatorService.BeaconNodePool())
+	assert.Equal(t, "127.0.0.1:4000", validatorService.conn.GetGrpcClientConn().Target())
+	assert.Equal(t, "127.0.0.1:4001", validatorService.conns[1].GetGrpcClientConn().Target())
+	require.LogsContain(t, hook, "Using multiple beacon nodes with failover")
+	assert.NoError(t, validatorService.Stop())
+}
+
 func TestStatus_NoConnectionError(t *testing.T) {
 	validatorService := &ValidatorService{}
 	assert.ErrorContains(t, "no connection", validatorService.Status())
@@ -9,12 +9,11 @@ import (
 )
 
 func NewSlasherClient(validatorConn validatorHelpers.NodeConnection) iface.SlasherClient {
-	grpcClient := grpcApi.NewSlasherClient(validatorConn.GetGrpcClientConn())
 	featureFlags := features.Get()
 
 	if featureFlags.EnableBeaconRESTApi {
-		return beaconApi.NewSlasherClientWithFallback(validatorConn.GetBeaconApiUrl(), validatorConn.GetBeaconApiTimeout(), grpcClient)
+		return beaconApi.NewBeaconApiSlasherClient(validatorConn.GetBeaconApiUrl(), validatorConn.GetBeaconApiTimeout())
 	} else {
-		return grpcClient
+		return grpcApi.NewSlasherClient(validatorConn.GetGrpcClientConn())
 	}
 }
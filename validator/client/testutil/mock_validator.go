@@ -192,6 +192,9 @@ func (_ *FakeValidator) LogAttestationsSubmitted() {}
 // UpdateDomainDataCaches for mocking.
 func (_ *FakeValidator) UpdateDomainDataCaches(context.Context, primitives.Slot) {}
 
+// UpdateExecutionClientVersionCache for mocking.
+func (_ *FakeValidator) UpdateExecutionClientVersionCache(context.Context) {}
+
 // BalancesByPubkeys for mocking.
 func (fv *FakeValidator) BalancesByPubkeys(_ context.Context) map[[fieldparams.BLSPubkeyLength]byte]uint64 {
 	return fv.Balances
@@ -230,6 +233,11 @@ func (_ *FakeValidator) CheckDoppelGanger(_ context.Context) error {
 	return nil
 }
 
+// CheckDoppelGangerLiveness for mocking
+func (_ *FakeValidator) CheckDoppelGangerLiveness(_ context.Context, _ primitives.Slot) error {
+	return nil
+}
+
 // ReceiveBlocks for mocking
 func (fv *FakeValidator) ReceiveBlocks(_ context.Context, connectionErrorChannel chan<- error) {
 	fv.ReceiveBlocksCalled++
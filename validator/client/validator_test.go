@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"errors"
-	"fmt"
 	"io"
 	"math"
 	"strings"
@@ -17,7 +16,6 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/prysmaticlabs/prysm/v4/async/event"
-	"github.com/prysmaticlabs/prysm/v4/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	validatorserviceconfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
@@ -1015,264 +1013,6 @@ func TestService_ReceiveBlocks_SetHighest(t *testing.T) {
 	require.Equal(t, slot, v.highestValidSlot)
 }
 
-type doppelGangerRequestMatcher struct {
-	req *ethpb.DoppelGangerRequest
-}
-
-var _ gomock.Matcher = (*doppelGangerRequestMatcher)(nil)
-
-func (m *doppelGangerRequestMatcher) Matches(x interface{}) bool {
-	r, ok := x.(*ethpb.DoppelGangerRequest)
-	if !ok {
-		panic("Invalid match type")
-	}
-	return gomock.InAnyOrder(m.req.ValidatorRequests).Matches(r.ValidatorRequests)
-}
-
-func (m *doppelGangerRequestMatcher) String() string {
-	return fmt.Sprintf("%#v", m.req.ValidatorRequests)
-}
-
-func TestValidator_CheckDoppelGanger(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-	flgs := features.Get()
-	flgs.EnableDoppelGanger = true
-	reset := features.InitWithReset(flgs)
-	defer reset()
-	tests := []struct {
-		name            string
-		validatorSetter func(t *testing.T) *validator
-		err             string
-	}{
-		{
-			name: "no doppelganger",
-			validatorSetter: func(t *testing.T) *validator {
-				client := validatormock.NewMockValidatorClient(ctrl)
-				km := genMockKeymanager(t, 10)
-				keys, err := km.FetchValidatingPublicKeys(context.Background())
-				assert.NoError(t, err)
-				db := dbTest.SetupDB(t, keys)
-				req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				resp := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				for _, k := range keys {
-					pkey := k
-					att := createAttestation(10, 12)
-					rt, err := att.Data.HashTreeRoot()
-					assert.NoError(t, err)
-					assert.NoError(t, db.SaveAttestationForPubKey(context.Background(), pkey, rt, att))
-					resp.ValidatorRequests = append(resp.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: pkey[:], Epoch: att.Data.Target.Epoch, SignedRoot: rt[:]})
-					req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: pkey[:], Epoch: att.Data.Target.Epoch, SignedRoot: rt[:]})
-				}
-				v := &validator{
-					validatorClient: client,
-					keyManager:      km,
-					db:              db,
-				}
-				client.EXPECT().CheckDoppelGanger(
-					gomock.Any(),                     // ctx
-					&doppelGangerRequestMatcher{req}, // request
-				).Return(nil, nil /*err*/)
-
-				return v
-			},
-		},
-		{
-			name: "multiple doppelganger exists",
-			validatorSetter: func(t *testing.T) *validator {
-				client := validatormock.NewMockValidatorClient(ctrl)
-				km := genMockKeymanager(t, 10)
-				keys, err := km.FetchValidatingPublicKeys(context.Background())
-				assert.NoError(t, err)
-				db := dbTest.SetupDB(t, keys)
-				req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				resp := &ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{}}
-				for i, k := range keys {
-					pkey := k
-					att := createAttestation(10, 12)
-					rt, err := att.Data.HashTreeRoot()
-					assert.NoError(t, err)
-					assert.NoError(t, db.SaveAttestationForPubKey(context.Background(), pkey, rt, att))
-					if i%3 == 0 {
-						resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{PublicKey: pkey[:], DuplicateExists: true})
-					}
-					req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: pkey[:], Epoch: att.Data.Target.Epoch, SignedRoot: rt[:]})
-				}
-				v := &validator{
-					validatorClient: client,
-					keyManager:      km,
-					db:              db,
-				}
-				client.EXPECT().CheckDoppelGanger(
-					gomock.Any(),                     // ctx
-					&doppelGangerRequestMatcher{req}, // request
-				).Return(resp, nil /*err*/)
-				return v
-			},
-			err: "Duplicate instances exists in the network for validator keys",
-		},
-		{
-			name: "single doppelganger exists",
-			validatorSetter: func(t *testing.T) *validator {
-				client := validatormock.NewMockValidatorClient(ctrl)
-				km := genMockKeymanager(t, 10)
-				keys, err := km.FetchValidatingPublicKeys(context.Background())
-				assert.NoError(t, err)
-				db := dbTest.SetupDB(t, keys)
-				req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				resp := &ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{}}
-				for i, k := range keys {
-					pkey := k
-					att := createAttestation(10, 12)
-					rt, err := att.Data.HashTreeRoot()
-					assert.NoError(t, err)
-					assert.NoError(t, db.SaveAttestationForPubKey(context.Background(), pkey, rt, att))
-					if i%9 == 0 {
-						resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{PublicKey: pkey[:], DuplicateExists: true})
-					}
-					req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: pkey[:], Epoch: att.Data.Target.Epoch, SignedRoot: rt[:]})
-				}
-				v := &validator{
-					validatorClient: client,
-					keyManager:      km,
-					db:              db,
-				}
-				client.EXPECT().CheckDoppelGanger(
-					gomock.Any(),                     // ctx
-					&doppelGangerRequestMatcher{req}, // request
-				).Return(resp, nil /*err*/)
-				return v
-			},
-			err: "Duplicate instances exists in the network for validator keys",
-		},
-		{
-			name: "multiple attestations saved",
-			validatorSetter: func(t *testing.T) *validator {
-				client := validatormock.NewMockValidatorClient(ctrl)
-				km := genMockKeymanager(t, 10)
-				keys, err := km.FetchValidatingPublicKeys(context.Background())
-				assert.NoError(t, err)
-				db := dbTest.SetupDB(t, keys)
-				req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				resp := &ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{}}
-				attLimit := 5
-				for i, k := range keys {
-					pkey := k
-					for j := 0; j < attLimit; j++ {
-						att := createAttestation(10+primitives.Epoch(j), 12+primitives.Epoch(j))
-						rt, err := att.Data.HashTreeRoot()
-						assert.NoError(t, err)
-						assert.NoError(t, db.SaveAttestationForPubKey(context.Background(), pkey, rt, att))
-						if j == attLimit-1 {
-							req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: pkey[:], Epoch: att.Data.Target.Epoch, SignedRoot: rt[:]})
-						}
-					}
-					if i%3 == 0 {
-						resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{PublicKey: pkey[:], DuplicateExists: true})
-					}
-				}
-				v := &validator{
-					validatorClient: client,
-					keyManager:      km,
-					db:              db,
-				}
-				client.EXPECT().CheckDoppelGanger(
-					gomock.Any(),                     // ctx
-					&doppelGangerRequestMatcher{req}, // request
-				).Return(resp, nil /*err*/)
-				return v
-			},
-			err: "Duplicate instances exists in the network for validator keys",
-		},
-		{
-			name: "no history exists",
-			validatorSetter: func(t *testing.T) *validator {
-				client := validatormock.NewMockValidatorClient(ctrl)
-				// Use only 1 key for deterministic order.
-				km := genMockKeymanager(t, 1)
-				keys, err := km.FetchValidatingPublicKeys(context.Background())
-				assert.NoError(t, err)
-				db := dbTest.SetupDB(t, keys)
-				resp := &ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{}}
-				req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				for _, k := range keys {
-					resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{PublicKey: k[:], DuplicateExists: false})
-					req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: k[:], SignedRoot: make([]byte, 32), Epoch: 0})
-				}
-				v := &validator{
-					validatorClient: client,
-					keyManager:      km,
-					db:              db,
-				}
-				client.EXPECT().CheckDoppelGanger(
-					gomock.Any(), // ctx
-					req,          // request
-				).Return(resp, nil /*err*/)
-				return v
-			},
-			err: "",
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			v := tt.validatorSetter(t)
-			if err := v.CheckDoppelGanger(context.Background()); tt.err != "" {
-				assert.ErrorContains(t, tt.err, err)
-			}
-		})
-	}
-}
-
-func TestValidatorAttestationsAreOrdered(t *testing.T) {
-	km := genMockKeymanager(t, 10)
-	keys, err := km.FetchValidatingPublicKeys(context.Background())
-	assert.NoError(t, err)
-	db := dbTest.SetupDB(t, keys)
-
-	k := keys[0]
-	att := createAttestation(10, 14)
-	rt, err := att.Data.HashTreeRoot()
-	assert.NoError(t, err)
-	assert.NoError(t, db.SaveAttestationForPubKey(context.Background(), k, rt, att))
-
-	att = createAttestation(6, 8)
-	rt, err = att.Data.HashTreeRoot()
-	assert.NoError(t, err)
-	assert.NoError(t, db.SaveAttestationForPubKey(context.Background(), k, rt, att))
-
-	att = createAttestation(10, 12)
-	rt, err = att.Data.HashTreeRoot()
-	assert.NoError(t, err)
-	assert.NoError(t, db.SaveAttestationForPubKey(context.Background(), k, rt, att))
-
-	att = createAttestation(2, 3)
-	rt, err = att.Data.HashTreeRoot()
-	assert.NoError(t, err)
-	assert.NoError(t, db.SaveAttestationForPubKey(context.Background(), k, rt, att))
-
-	histories, err := db.AttestationHistoryForPubKey(context.Background(), k)
-	assert.NoError(t, err)
-	r := retrieveLatestRecord(histories)
-	assert.Equal(t, r.Target, primitives.Epoch(14))
-}
-
-func createAttestation(source, target primitives.Epoch) *ethpb.IndexedAttestation {
-	return &ethpb.IndexedAttestation{
-		Data: &ethpb.AttestationData{
-			Source: &ethpb.Checkpoint{
-				Epoch: source,
-				Root:  make([]byte, 32),
-			},
-			Target: &ethpb.Checkpoint{
-				Epoch: target,
-				Root:  make([]byte, 32),
-			},
-			BeaconBlockRoot: make([]byte, 32),
-		},
-		Signature: make([]byte, fieldparams.BLSSignatureLength),
-	}
-}
-
 func TestIsSyncCommitteeAggregator_OK(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
 	v, m, validatorKey, finish := setup(t)
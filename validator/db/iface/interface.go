@@ -70,4 +70,12 @@ type ValidatorDB interface {
 	UpdateProposerSettingsDefault(context.Context, *validatorServiceConfig.ProposerOption) error
 	UpdateProposerSettingsForPubkey(context.Context, [fieldparams.BLSPubkeyLength]byte, *validatorServiceConfig.ProposerOption) error
 	SaveProposerSettings(ctx context.Context, settings *validatorServiceConfig.ProposerSettings) error
+	SaveProposerSettingsChanges(ctx context.Context, changes []*kv.ProposerSettingsChange) error
+	ProposerSettingsChanges(ctx context.Context) ([]*kv.ProposerSettingsChange, error)
+
+	// Performance history related methods
+	SavePerformanceRecords(ctx context.Context, records []*kv.PerformanceRecord) error
+	PerformanceHistoryForPubKey(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, startEpoch, endEpoch primitives.Epoch) ([]*kv.PerformanceRecord, error)
+	PerformanceHistoryPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error)
+	PrunePerformanceHistory(ctx context.Context, oldestEpoch primitives.Epoch) error
 }
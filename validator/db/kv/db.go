@@ -153,6 +153,8 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 			migrationsBucket,
 			graffitiBucket,
 			proposerSettingsBucket,
+			performanceHistoryBucket,
+			proposerSettingsAuditBucket,
 		)
 	}); err != nil {
 		return nil, err
@@ -0,0 +1,184 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+const (
+	attestationIncludedFlag = 1 << iota
+	correctlyVotedSourceFlag
+	correctlyVotedTargetFlag
+	correctlyVotedHeadFlag
+)
+
+// performanceRecordLength is the size of an encoded performance record:
+// one byte of flags followed by seven big endian uint64 values.
+const performanceRecordLength = 1 + 7*8
+
+// PerformanceRecord is the outcome of the duties of a validator during an epoch. The inclusion slot
+// and distance of the attestation of the validator are only set when the attestation was included.
+// The proposed blocks and sync committee messages only count the ones included in the canonical chain.
+type PerformanceRecord struct {
+	PubKey                [fieldparams.BLSPubkeyLength]byte
+	Epoch                 primitives.Epoch
+	AttestationIncluded   bool
+	InclusionSlot         primitives.Slot
+	InclusionDistance     primitives.Slot
+	CorrectlyVotedSource  bool
+	CorrectlyVotedTarget  bool
+	CorrectlyVotedHead    bool
+	ProposedBlocks        uint64
+	SyncCommitteeMessages uint64
+	BalanceBefore         uint64
+	BalanceAfter          uint64
+	InactivityScore       uint64
+}
+
+// SavePerformanceRecords persists the given per-epoch performance records,
+// overwriting any record already saved for the same public key and epoch.
+func (s *Store) SavePerformanceRecords(ctx context.Context, records []*PerformanceRecord) error {
+	_, span := trace.StartSpan(ctx, "Validator.SavePerformanceRecords")
+	defer span.End()
+	return s.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(performanceHistoryBucket)
+		for _, record := range records {
+			pkBucket, err := bucket.CreateBucketIfNotExists(record.PubKey[:])
+			if err != nil {
+				return errors.Wrap(err, "could not create performance history bucket")
+			}
+			if err := pkBucket.Put(bytesutil.EpochToBytesBigEndian(record.Epoch), encodePerformanceRecord(record)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PerformanceHistoryForPubKey retrieves the performance records of a public key
+// with an epoch between startEpoch and endEpoch inclusive, in ascending epoch order.
+func (s *Store) PerformanceHistoryForPubKey(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, startEpoch, endEpoch primitives.Epoch,
+) ([]*PerformanceRecord, error) {
+	_, span := trace.StartSpan(ctx, "Validator.PerformanceHistoryForPubKey")
+	defer span.End()
+	records := make([]*PerformanceRecord, 0)
+	err := s.view(func(tx *bolt.Tx) error {
+		pkBucket := tx.Bucket(performanceHistoryBucket).Bucket(pubKey[:])
+		if pkBucket == nil {
+			return nil
+		}
+		c := pkBucket.Cursor()
+		for k, v := c.Seek(bytesutil.EpochToBytesBigEndian(startEpoch)); k != nil; k, v = c.Next() {
+			epoch := bytesutil.BytesToEpochBigEndian(k)
+			if epoch > endEpoch {
+				break
+			}
+			record, err := decodePerformanceRecord(v)
+			if err != nil {
+				return errors.Wrapf(err, "could not decode performance record of epoch %d", epoch)
+			}
+			record.PubKey = pubKey
+			record.Epoch = epoch
+			records = append(records, record)
+		}
+		return nil
+	})
+	return records, err
+}
+
+// PerformanceHistoryPublicKeys retrieves the public keys which have a recorded performance history.
+func (s *Store) PerformanceHistoryPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	_, span := trace.StartSpan(ctx, "Validator.PerformanceHistoryPublicKeys")
+	defer span.End()
+	var pubKeys [][fieldparams.BLSPubkeyLength]byte
+	err := s.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(performanceHistoryBucket).ForEach(func(pubKey []byte, _ []byte) error {
+			pubKeys = append(pubKeys, bytesutil.ToBytes48(pubKey))
+			return nil
+		})
+	})
+	return pubKeys, err
+}
+
+// PrunePerformanceHistory deletes every performance record older than the given epoch.
+func (s *Store) PrunePerformanceHistory(ctx context.Context, oldestEpoch primitives.Epoch) error {
+	_, span := trace.StartSpan(ctx, "Validator.PrunePerformanceHistory")
+	defer span.End()
+	return s.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(performanceHistoryBucket)
+		return bucket.ForEach(func(pubKey []byte, _ []byte) error {
+			pkBucket := bucket.Bucket(pubKey)
+			if pkBucket == nil {
+				return nil
+			}
+			c := pkBucket.Cursor()
+			for k, _ := c.First(); k != nil && bytesutil.BytesToEpochBigEndian(k) < oldestEpoch; k, _ = c.First() {
+				if err := pkBucket.Delete(k); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+func encodePerformanceRecord(record *PerformanceRecord) []byte {
+	enc := make([]byte, performanceRecordLength)
+	var flags byte
+	if record.AttestationIncluded {
+		flags |= attestationIncludedFlag
+	}
+	if record.CorrectlyVotedSource {
+		flags |= correctlyVotedSourceFlag
+	}
+	if record.CorrectlyVotedTarget {
+		flags |= correctlyVotedTargetFlag
+	}
+	if record.CorrectlyVotedHead {
+		flags |= correctlyVotedHeadFlag
+	}
+	enc[0] = flags
+	for i, v := range []uint64{
+		record.ProposedBlocks,
+		record.SyncCommitteeMessages,
+		record.BalanceBefore,
+		record.BalanceAfter,
+		record.InactivityScore,
+		uint64(record.InclusionSlot),
+		uint64(record.InclusionDistance),
+	} {
+		binary.BigEndian.PutUint64(enc[1+i*8:], v)
+	}
+	return enc
+}
+
+func decodePerformanceRecord(enc []byte) (*PerformanceRecord, error) {
+	if len(enc) != performanceRecordLength {
+		return nil, errors.Errorf("wrong performance record length, expected %d, got %d", performanceRecordLength, len(enc))
+	}
+	flags := enc[0]
+	value := func(i int) uint64 {
+		return binary.BigEndian.Uint64(enc[1+i*8:])
+	}
+	return &PerformanceRecord{
+		AttestationIncluded:   flags&attestationIncludedFlag != 0,
+		CorrectlyVotedSource:  flags&correctlyVotedSourceFlag != 0,
+		CorrectlyVotedTarget:  flags&correctlyVotedTargetFlag != 0,
+		CorrectlyVotedHead:    flags&correctlyVotedHeadFlag != 0,
+		ProposedBlocks:        value(0),
+		SyncCommitteeMessages: value(1),
+		BalanceBefore:         value(2),
+		BalanceAfter:          value(3),
+		InactivityScore:       value(4),
+		InclusionSlot:         primitives.Slot(value(5)),
+		InclusionDistance:     primitives.Slot(value(6)),
+	}, nil
+}
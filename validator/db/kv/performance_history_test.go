@@ -0,0 +1,86 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_PerformanceHistory_SaveAndRead(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, [][fieldparams.BLSPubkeyLength]byte{})
+	pubKey1 := [fieldparams.BLSPubkeyLength]byte{1}
+	pubKey2 := [fieldparams.BLSPubkeyLength]byte{2}
+
+	records := []*PerformanceRecord{
+		{
+			PubKey:                pubKey1,
+			Epoch:                 3,
+			AttestationIncluded:   true,
+			InclusionSlot:         97,
+			InclusionDistance:     3,
+			CorrectlyVotedSource:  true,
+			CorrectlyVotedTarget:  true,
+			ProposedBlocks:        1,
+			SyncCommitteeMessages: 32,
+			BalanceBefore:         32000000000,
+			BalanceAfter:          32000010000,
+		},
+		{PubKey: pubKey1, Epoch: 4, CorrectlyVotedHead: true, InactivityScore: 4},
+		{PubKey: pubKey1, Epoch: 5},
+		{PubKey: pubKey2, Epoch: 4, BalanceBefore: 31000000000},
+	}
+	require.NoError(t, db.SavePerformanceRecords(ctx, records))
+
+	got, err := db.PerformanceHistoryForPubKey(ctx, pubKey1, 0, 10)
+	require.NoError(t, err)
+	require.DeepEqual(t, records[:3], got)
+
+	got, err = db.PerformanceHistoryForPubKey(ctx, pubKey1, 4, 4)
+	require.NoError(t, err)
+	require.DeepEqual(t, records[1:2], got)
+
+	got, err = db.PerformanceHistoryForPubKey(ctx, [fieldparams.BLSPubkeyLength]byte{3}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(got))
+
+	pubKeys, err := db.PerformanceHistoryPublicKeys(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{pubKey1, pubKey2}, pubKeys)
+
+	// Saving a record for an existing epoch overwrites it.
+	updated := &PerformanceRecord{PubKey: pubKey1, Epoch: 5, ProposedBlocks: 2}
+	require.NoError(t, db.SavePerformanceRecords(ctx, []*PerformanceRecord{updated}))
+	got, err = db.PerformanceHistoryForPubKey(ctx, pubKey1, 5, 5)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*PerformanceRecord{updated}, got)
+}
+
+func TestStore_PrunePerformanceHistory(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, [][fieldparams.BLSPubkeyLength]byte{})
+	pubKey1 := [fieldparams.BLSPubkeyLength]byte{1}
+	pubKey2 := [fieldparams.BLSPubkeyLength]byte{2}
+
+	var records []*PerformanceRecord
+	for epoch := primitives.Epoch(0); epoch < 10; epoch++ {
+		records = append(records, &PerformanceRecord{PubKey: pubKey1, Epoch: epoch})
+		records = append(records, &PerformanceRecord{PubKey: pubKey2, Epoch: epoch + 5})
+	}
+	require.NoError(t, db.SavePerformanceRecords(ctx, records))
+	require.NoError(t, db.PrunePerformanceHistory(ctx, 7))
+
+	got, err := db.PerformanceHistoryForPubKey(ctx, pubKey1, 0, 20)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(got))
+	require.Equal(t, primitives.Epoch(7), got[0].Epoch)
+
+	got, err = db.PerformanceHistoryForPubKey(ctx, pubKey2, 0, 20)
+	require.NoError(t, err)
+	require.Equal(t, 8, len(got))
+	require.Equal(t, primitives.Epoch(7), got[0].Epoch)
+	require.Equal(t, primitives.Epoch(14), got[7].Epoch)
+}
@@ -0,0 +1,68 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	validatorServiceConfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// ProposerSettingsChange records a change of the proposer settings of a public key, or of the
+// default proposer settings when PubKey is empty. Previous is nil for a key that was added and
+// Current is nil for a key that was removed.
+type ProposerSettingsChange struct {
+	Timestamp uint64                                 `json:"timestamp"`
+	Epoch     primitives.Epoch                       `json:"epoch"`
+	Source    string                                 `json:"source"`
+	PubKey    []byte                                 `json:"pubkey,omitempty"`
+	Previous  *validatorServiceConfig.ProposerOption `json:"previous,omitempty"`
+	Current   *validatorServiceConfig.ProposerOption `json:"current,omitempty"`
+}
+
+// SaveProposerSettingsChanges appends the given changes to the proposer settings audit log.
+func (s *Store) SaveProposerSettingsChanges(ctx context.Context, changes []*ProposerSettingsChange) error {
+	_, span := trace.StartSpan(ctx, "Validator.SaveProposerSettingsChanges")
+	defer span.End()
+	return s.update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(proposerSettingsAuditBucket)
+		for _, change := range changes {
+			enc, err := json.Marshal(change)
+			if err != nil {
+				return errors.Wrap(err, "could not encode proposer settings change")
+			}
+			seq, err := bkt.NextSequence()
+			if err != nil {
+				return err
+			}
+			key := make([]byte, 8)
+			binary.BigEndian.PutUint64(key, seq)
+			if err := bkt.Put(key, enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ProposerSettingsChanges retrieves the proposer settings audit log, in the order the changes were saved.
+func (s *Store) ProposerSettingsChanges(ctx context.Context) ([]*ProposerSettingsChange, error) {
+	_, span := trace.StartSpan(ctx, "Validator.ProposerSettingsChanges")
+	defer span.End()
+	changes := make([]*ProposerSettingsChange, 0)
+	err := s.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(proposerSettingsAuditBucket).ForEach(func(_, v []byte) error {
+			change := &ProposerSettingsChange{}
+			if err := json.Unmarshal(v, change); err != nil {
+				return errors.Wrap(err, "could not decode proposer settings change")
+			}
+			changes = append(changes, change)
+			return nil
+		})
+	})
+	return changes, err
+}
@@ -0,0 +1,54 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	validatorServiceConfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_ProposerSettingsChanges(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, [][fieldparams.BLSPubkeyLength]byte{})
+
+	got, err := db.ProposerSettingsChanges(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(got))
+
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	first := []*ProposerSettingsChange{
+		{
+			Timestamp: 100,
+			Epoch:     2,
+			Source:    "proposer.yaml",
+			Previous: &validatorServiceConfig.ProposerOption{
+				FeeRecipientConfig: &validatorServiceConfig.FeeRecipientConfig{FeeRecipient: common.HexToAddress("0x01")},
+			},
+			Current: &validatorServiceConfig.ProposerOption{
+				FeeRecipientConfig: &validatorServiceConfig.FeeRecipientConfig{FeeRecipient: common.HexToAddress("0x02")},
+				BuilderConfig:      &validatorServiceConfig.BuilderConfig{Enabled: true, GasLimit: 30000000},
+			},
+		},
+		{
+			Timestamp: 100,
+			Epoch:     2,
+			Source:    "proposer.yaml",
+			PubKey:    pubKey[:],
+			Current: &validatorServiceConfig.ProposerOption{
+				FeeRecipientConfig: &validatorServiceConfig.FeeRecipientConfig{FeeRecipient: common.HexToAddress("0x03")},
+			},
+		},
+	}
+	second := []*ProposerSettingsChange{
+		{Timestamp: 200, Epoch: 5, Source: "proposer.yaml", PubKey: pubKey[:], Previous: first[1].Current},
+	}
+	require.NoError(t, db.SaveProposerSettingsChanges(ctx, first))
+	require.NoError(t, db.SaveProposerSettingsChanges(ctx, second))
+
+	got, err = db.ProposerSettingsChanges(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, append(first, second...), got)
+}
@@ -41,4 +41,10 @@ var (
 	// ProposerSettings stores the encoded proposer settings file
 	proposerSettingsBucket = []byte("proposer-settings-bucket")
 	proposerSettingsKey    = []byte("proposer-settings")
+
+	// Audit log of the changes applied to the proposer settings, keyed by sequence number.
+	proposerSettingsAuditBucket = []byte("proposer-settings-audit-bucket")
+
+	// Per-epoch performance history of the validator keys, nested by public key.
+	performanceHistoryBucket = []byte("performance-history-bucket")
 )
@@ -54,6 +54,10 @@ func ParseGraffitiFile(f string) (*Graffiti, error) {
 	g.Default = ParseHexGraffiti(g.Default)
 	g.Hash = hash.Hash(yamlFile)
 
+	if err := g.validateTemplates(); err != nil {
+		return nil, err
+	}
+
 	return g, nil
 }
 
@@ -82,3 +86,23 @@ func ParseHexGraffiti(rawGraffiti string) string {
 	}
 	return rawGraffiti
 }
+
+// validateTemplates checks every graffiti of the file which is a template.
+func (g *Graffiti) validateTemplates() error {
+	all := make([]string, 0, 1+len(g.Ordered)+len(g.Random)+len(g.Specific))
+	all = append(all, g.Default)
+	all = append(all, g.Ordered...)
+	all = append(all, g.Random...)
+	for _, s := range g.Specific {
+		all = append(all, s)
+	}
+	for _, s := range all {
+		if !IsTemplate(s) {
+			continue
+		}
+		if err := ValidateTemplate(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package graffiti
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// Fields which can be used in a graffiti template, written between double braces,
+// such as "{{el_code}}{{el_commit}}{{cl_code}} #{{index}}". They are filled in at proposal time.
+const (
+	// FieldClientCode is the two letter code of Prysm, as defined by the engine API.
+	FieldClientCode = "cl_code"
+	// FieldClientVersion is the version of the Prysm validator client.
+	FieldClientVersion = "cl_version"
+	// FieldClientCommit is the first four bytes, in hex, of the git commit of the Prysm validator client.
+	FieldClientCommit = "cl_commit"
+	// FieldExecutionCode is the two letter code of the execution client connected to the beacon node.
+	FieldExecutionCode = "el_code"
+	// FieldExecutionName is the name of the execution client connected to the beacon node.
+	FieldExecutionName = "el_name"
+	// FieldExecutionVersion is the version of the execution client connected to the beacon node.
+	FieldExecutionVersion = "el_version"
+	// FieldExecutionCommit is the first four bytes, in hex, of the git commit of the execution client.
+	FieldExecutionCommit = "el_commit"
+	// FieldValidatorIndex is the index of the proposing validator.
+	FieldValidatorIndex = "index"
+	// FieldSlot is the slot of the proposed block.
+	FieldSlot = "slot"
+	// FieldEpoch is the epoch of the proposed block.
+	FieldEpoch = "epoch"
+)
+
+const (
+	graffitiLength = 32
+	prysmCode      = "PM"
+	commitLength   = 8
+)
+
+var templateFieldRegex = regexp.MustCompile(`{{\s*([a-z_]+)\s*}}`)
+
+// minFieldLengths holds the length of the fields whose value has a known minimum length,
+// used to reject templates which cannot fit in a graffiti.
+var minFieldLengths = map[string]int{
+	FieldClientCode:       len(prysmCode),
+	FieldClientVersion:    0,
+	FieldClientCommit:     commitLength,
+	FieldExecutionCode:    0,
+	FieldExecutionName:    0,
+	FieldExecutionVersion: 0,
+	FieldExecutionCommit:  commitLength,
+	FieldValidatorIndex:   1,
+	FieldSlot:             1,
+	FieldEpoch:            1,
+}
+
+// ExecutionClientVersion identifies the execution client connected to the beacon node.
+type ExecutionClientVersion struct {
+	Code    string
+	Name    string
+	Version string
+	Commit  string
+}
+
+// TemplateData holds the values filled in a graffiti template at proposal time.
+type TemplateData struct {
+	ValidatorIndex primitives.ValidatorIndex
+	Slot           primitives.Slot
+	// ExecutionClient is nil when the execution client version is unknown,
+	// in which case the execution client fields are left empty.
+	ExecutionClient *ExecutionClientVersion
+}
+
+// IsTemplate returns true if the graffiti contains template fields.
+func IsTemplate(graffiti string) bool {
+	return templateFieldRegex.MatchString(graffiti)
+}
+
+// UsesExecutionClient returns true if the graffiti contains fields describing the execution client.
+func UsesExecutionClient(graffiti string) bool {
+	for _, m := range templateFieldRegex.FindAllStringSubmatch(graffiti, -1) {
+		if strings.HasPrefix(m[1], "el_") {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTemplate checks that all the fields of the graffiti template are known and
+// that the template, once filled in, can fit in the 32 bytes of a graffiti.
+func ValidateTemplate(graffiti string) error {
+	minLength := len(templateFieldRegex.ReplaceAllString(graffiti, ""))
+	for _, m := range templateFieldRegex.FindAllStringSubmatch(graffiti, -1) {
+		l, ok := minFieldLengths[m[1]]
+		if !ok {
+			return fmt.Errorf("unknown field %q in graffiti template %q", m[1], graffiti)
+		}
+		minLength += l
+	}
+	if minLength > graffitiLength {
+		return fmt.Errorf(
+			"graffiti template %q is at least %d bytes long once filled in, more than the %d bytes of a graffiti",
+			graffiti,
+			minLength,
+			graffitiLength,
+		)
+	}
+	return nil
+}
+
+// RenderTemplate fills in the fields of the graffiti template with the given data.
+// A graffiti longer than 32 bytes once filled in is truncated.
+func RenderTemplate(graffiti string, data *TemplateData) []byte {
+	rendered := templateFieldRegex.ReplaceAllStringFunc(graffiti, func(field string) string {
+		return fieldValue(templateFieldRegex.FindStringSubmatch(field)[1], data)
+	})
+	if len(rendered) > graffitiLength {
+		log.WithField("graffiti", rendered).Warnf("Graffiti is longer than %d bytes, truncating it", graffitiLength)
+		rendered = rendered[:graffitiLength]
+	}
+	return []byte(rendered)
+}
+
+func fieldValue(field string, data *TemplateData) string {
+	el := data.ExecutionClient
+	if el == nil {
+		el = &ExecutionClientVersion{}
+	}
+	switch field {
+	case FieldClientCode:
+		return prysmCode
+	case FieldClientVersion:
+		return version.SemanticVersion()
+	case FieldClientCommit:
+		return shortCommit(version.GitCommit())
+	case FieldExecutionCode:
+		return el.Code
+	case FieldExecutionName:
+		return el.Name
+	case FieldExecutionVersion:
+		return el.Version
+	case FieldExecutionCommit:
+		return shortCommit(el.Commit)
+	case FieldValidatorIndex:
+		return strconv.FormatUint(uint64(data.ValidatorIndex), 10)
+	case FieldSlot:
+		return strconv.FormatUint(uint64(data.Slot), 10)
+	case FieldEpoch:
+		return strconv.FormatUint(uint64(slots.ToEpoch(data.Slot)), 10)
+	default:
+		return ""
+	}
+}
+
+// shortCommit returns the first four bytes of a hex encoded commit hash,
+// or zeros when the commit is not known.
+func shortCommit(commit string) string {
+	commit = strings.TrimPrefix(commit, hex0xPrefix)
+	if len(commit) < commitLength {
+		return strings.Repeat("0", commitLength)
+	}
+	if _, err := hex.DecodeString(commit[:commitLength]); err != nil {
+		return strings.Repeat("0", commitLength)
+	}
+	return strings.ToLower(commit[:commitLength])
+}
@@ -0,0 +1,79 @@
+package graffiti
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestIsTemplate(t *testing.T) {
+	assert.Equal(t, false, IsTemplate("Mr T was here"))
+	assert.Equal(t, false, IsTemplate("{not a template}"))
+	assert.Equal(t, true, IsTemplate("Validator {{index}}"))
+	assert.Equal(t, true, IsTemplate("Validator {{ index }}"))
+}
+
+func TestUsesExecutionClient(t *testing.T) {
+	assert.Equal(t, false, UsesExecutionClient("{{cl_code}} {{index}}"))
+	assert.Equal(t, true, UsesExecutionClient("{{el_code}}{{cl_code}}"))
+}
+
+func TestValidateTemplate(t *testing.T) {
+	require.NoError(t, ValidateTemplate("{{el_code}}{{el_commit}}{{cl_code}}{{cl_commit}} #{{index}}"))
+	require.ErrorContains(t, `unknown field "foo"`, ValidateTemplate("{{foo}}"))
+	require.ErrorContains(t, "more than the 32 bytes of a graffiti", ValidateTemplate("This graffiti template is too long {{index}}"))
+	require.ErrorContains(t, "at least 34 bytes long", ValidateTemplate("{{cl_commit}}{{el_commit}}{{cl_commit}}{{el_commit}}{{cl_code}}"))
+}
+
+func TestRenderTemplate(t *testing.T) {
+	data := &TemplateData{
+		ValidatorIndex: 42,
+		Slot:           65,
+		ExecutionClient: &ExecutionClientVersion{
+			Code:    "GE",
+			Name:    "Geth",
+			Version: "1.13.0",
+			Commit:  "0xFA4C7B5B",
+		},
+	}
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{template: "Mr T was here", want: "Mr T was here"},
+		{template: "{{el_code}}fa4c{{cl_code}} #{{index}}", want: "GEfa4cPM #42"},
+		{template: "{{el_name}}/{{el_version}}/{{el_commit}}", want: "Geth/1.13.0/fa4c7b5b"},
+		{template: "slot {{slot}} epoch {{ epoch }}", want: "slot 65 epoch 2"},
+		{template: "{{el_name}}{{el_name}}{{el_name}}{{el_name}}{{el_name}}{{el_name}}{{el_name}}{{el_name}}{{el_name}}", want: "GethGethGethGethGethGethGethGeth"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			assert.Equal(t, tt.want, string(RenderTemplate(tt.template, data)))
+		})
+	}
+
+	// Execution client fields are left empty when the execution client is unknown.
+	assert.Equal(t, "PM  00000000", string(RenderTemplate("{{cl_code}} {{el_code}} {{el_commit}}", &TemplateData{})))
+}
+
+func TestParseGraffitiFile_Templates(t *testing.T) {
+	dirName := t.TempDir()
+	someFileName := filepath.Join(dirName, "somefile.txt")
+
+	input := []byte(`default: "{{el_code}}{{cl_code}} #{{index}}"
+ordered:
+  - "epoch {{epoch}}"`)
+	require.NoError(t, os.WriteFile(someFileName, input, os.ModePerm))
+	got, err := ParseGraffitiFile(someFileName)
+	require.NoError(t, err)
+	assert.Equal(t, "{{el_code}}{{cl_code}} #{{index}}", got.Default)
+
+	input = []byte(`random:
+  - "{{unknown}}"`)
+	require.NoError(t, os.WriteFile(someFileName, input, os.ModePerm))
+	_, err = ParseGraffitiFile(someFileName)
+	require.ErrorContains(t, `unknown field "unknown"`, err)
+}
@@ -0,0 +1,73 @@
+package graffiti
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/prysmaticlabs/prysm/v4/async"
+)
+
+// reloadDebounceInterval debounces the file system events fired when the graffiti file
+// is written, as editors usually write a file in several steps.
+const reloadDebounceInterval = time.Second
+
+// WatchGraffitiFile listens for changes to the graffiti file and hands the newly parsed graffiti
+// to onReload every time the file content changes. An invalid file is logged and ignored, so the
+// previously loaded graffiti remains in use. The directory of the file is watched rather than the
+// file itself, so that files replaced on save by editors are followed. It returns once the
+// context is canceled.
+func WatchGraffitiFile(ctx context.Context, path string, onReload func(*Graffiti)) {
+	path = filepath.Clean(path)
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.WithError(err).Error("Could not initialize graffiti file watcher")
+		return
+	}
+	defer func() {
+		if err := watcher.Close(); err != nil {
+			log.WithError(err).Error("Could not close graffiti file watcher")
+		}
+	}()
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		log.WithError(err).Errorf("Could not watch graffiti file %s", path)
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// The channel is not closed, as the debouncer could read from it before seeing the canceled
+	// context. The debouncer returns on cancellation, and the channel is then garbage collected.
+	fileChangesChan := make(chan interface{}, 100)
+
+	var lastHash [32]byte
+	if g, err := ParseGraffitiFile(path); err == nil {
+		lastHash = g.Hash
+	}
+	go async.Debounce(ctx, reloadDebounceInterval, fileChangesChan, func(_ interface{}) {
+		g, err := ParseGraffitiFile(path)
+		if err != nil {
+			log.WithError(err).Errorf("Could not reload graffiti file %s, keeping the previous graffiti", path)
+			return
+		}
+		if g.Hash == lastHash {
+			return
+		}
+		lastHash = g.Hash
+		log.WithField("path", path).Info("Reloaded graffiti file")
+		onReload(g)
+	})
+	for {
+		select {
+		case ev := <-watcher.Events:
+			if filepath.Clean(ev.Name) != path || !(ev.Has(fsnotify.Write) || ev.Has(fsnotify.Create)) {
+				continue
+			}
+			fileChangesChan <- ev
+		case err := <-watcher.Errors:
+			log.WithError(err).Error("Could not watch graffiti file")
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package graffiti
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestWatchGraffitiFile(t *testing.T) {
+	hook := logTest.NewGlobal()
+	path := filepath.Join(t.TempDir(), "graffiti.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`default: "first"`), os.ModePerm))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloaded := make(chan *Graffiti, 1)
+	done := make(chan struct{})
+	go func() {
+		WatchGraffitiFile(ctx, path, func(g *Graffiti) {
+			reloaded <- g
+		})
+		close(done)
+	}()
+	// Give the watcher time to start.
+	time.Sleep(100 * time.Millisecond)
+
+	// Invalid files are ignored.
+	require.NoError(t, os.WriteFile(path, []byte(`default: "{{unknown}}"`), os.ModePerm))
+	time.Sleep(2 * reloadDebounceInterval)
+	require.LogsContain(t, hook, "Could not reload graffiti file")
+
+	require.NoError(t, os.WriteFile(path, []byte(`default: "second {{index}}"`), os.ModePerm))
+	select {
+	case g := <-reloaded:
+		require.Equal(t, "second {{index}}", g.Default)
+	case <-time.After(5 * time.Second):
+		t.Fatal("graffiti file was not reloaded")
+	}
+
+	cancel()
+	<-done
+}
@@ -35,6 +35,7 @@ import (
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	validatorServiceConfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/validator"
 	"github.com/prysmaticlabs/prysm/v4/container/slice"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
@@ -85,6 +86,7 @@ func NewValidatorClient(cliCtx *cli.Context) (*ValidatorClient, error) {
 	if err := tracing2.Setup(
 		"validator", // service name
 		cliCtx.String(cmd.TracingProcessNameFlag.Name),
+		cliCtx.String(cmd.TracingExporterFlag.Name),
 		cliCtx.String(cmd.TracingEndpointFlag.Name),
 		cliCtx.Float64(cmd.TraceSampleFractionFlag.Name),
 		cliCtx.Bool(cmd.EnableTracingFlag.Name),
@@ -191,6 +193,9 @@ func (c *ValidatorClient) Close() {
 
 	c.services.StopAll()
 	log.Info("Stopping Prysm validator")
+	if err := tracing2.Shutdown(c.cliCtx.Context); err != nil {
+		log.WithError(err).Error("Failed to flush traces")
+	}
 	c.cancel()
 	close(c.stop)
 }
@@ -393,13 +398,22 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		}
 	}
 
+	graffiti = g.ParseHexGraffiti(graffiti)
+	if g.IsTemplate(graffiti) {
+		if err := g.ValidateTemplate(graffiti); err != nil {
+			return errors.Wrapf(err, "invalid graffiti template %s", graffiti)
+		}
+	}
+
 	gStruct := &g.Graffiti{}
-	var err error
+	var graffitiFile string
 	if c.cliCtx.IsSet(flags.GraffitiFileFlag.Name) {
-		n := c.cliCtx.String(flags.GraffitiFileFlag.Name)
-		gStruct, err = g.ParseGraffitiFile(n)
+		graffitiFile = c.cliCtx.String(flags.GraffitiFileFlag.Name)
+		parsed, err := g.ParseGraffitiFile(graffitiFile)
 		if err != nil {
 			log.WithError(err).Warn("Could not parse graffiti file")
+		} else {
+			gStruct = parsed
 		}
 	}
 
@@ -413,13 +427,18 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		return err
 	}
 
+	var performanceHistoryEpochs primitives.Epoch
+	if c.cliCtx.Bool(flags.EnablePerformanceHistoryFlag.Name) {
+		performanceHistoryEpochs = primitives.Epoch(c.cliCtx.Uint64(flags.PerformanceHistoryEpochsFlag.Name))
+	}
+
 	v, err := client.NewValidatorService(c.cliCtx.Context, &client.Config{
 		Endpoint:                   endpoint,
 		DataDir:                    dataDir,
 		LogValidatorBalances:       logValidatorBalances,
 		EmitAccountMetrics:         emitAccountMetrics,
 		CertFlag:                   cert,
-		GraffitiFlag:               g.ParseHexGraffiti(graffiti),
+		GraffitiFlag:               graffiti,
 		GrpcMaxCallRecvMsgSizeFlag: maxCallRecvMsgSize,
 		GrpcRetriesFlag:            grpcRetries,
 		GrpcRetryDelay:             grpcRetryDelay,
@@ -430,10 +449,17 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		Wallet:                     c.wallet,
 		WalletInitializedFeed:      c.walletInitialized,
 		GraffitiStruct:             gStruct,
+		GraffitiFile:               graffitiFile,
 		Web3SignerConfig:           wsc,
 		ProposerSettings:           bpc,
+		ProposerSettingsLoader:     proposerSettingsLoader(c.cliCtx),
+		ProposerSettingsFile:       c.cliCtx.String(flags.ProposerSettingsFlag.Name),
+		ProposerSettingsURL:        c.cliCtx.String(flags.ProposerSettingsURLFlag.Name),
+		ProposerSettingsRefresh:    c.cliCtx.Duration(flags.ProposerSettingsURLRefreshIntervalFlag.Name),
 		BeaconApiTimeout:           time.Second * 30,
 		BeaconApiEndpoint:          c.cliCtx.String(flags.BeaconRESTApiProviderFlag.Name),
+		DoppelGangerEpochs:         primitives.Epoch(c.cliCtx.Uint64(flags.DoppelGangerEpochsFlag.Name)),
+		PerformanceHistoryEpochs:   performanceHistoryEpochs,
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize validator service")
@@ -515,13 +541,10 @@ func proposerSettings(cliCtx *cli.Context, db iface.ValidatorDB) (*validatorServ
 		}
 	}
 
-	if cliCtx.IsSet(flags.ProposerSettingsFlag.Name) {
-		if err := unmarshalFromFile(cliCtx.Context, cliCtx.String(flags.ProposerSettingsFlag.Name), &fileConfig); err != nil {
-			return nil, err
-		}
-	}
-	if cliCtx.IsSet(flags.ProposerSettingsURLFlag.Name) {
-		if err := unmarshalFromURL(cliCtx.Context, cliCtx.String(flags.ProposerSettingsURLFlag.Name), &fileConfig); err != nil {
+	if cliCtx.IsSet(flags.ProposerSettingsFlag.Name) || cliCtx.IsSet(flags.ProposerSettingsURLFlag.Name) {
+		var err error
+		fileConfig, err = proposerSettingsPayload(cliCtx.Context, cliCtx)
+		if err != nil {
 			return nil, err
 		}
 	}
@@ -533,20 +556,64 @@ func proposerSettings(cliCtx *cli.Context, db iface.ValidatorDB) (*validatorServ
 		}
 		return nil, nil
 	}
+	vpSettings, err := proposerSettingsFromPayload(cliCtx, fileConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	psExists, err := db.ProposerSettingsExists(cliCtx.Context)
+	if err != nil {
+		return nil, err
+	}
+	if psExists {
+		// if settings exist update the default
+		if err := db.UpdateProposerSettingsDefault(cliCtx.Context, vpSettings.DefaultConfig); err != nil {
+			return nil, err
+		}
+		if vpSettings.ProposeConfig != nil {
+			// override the existing saved settings if providing values via fileConfig.ProposerConfig
+			if err := db.SaveProposerSettings(cliCtx.Context, vpSettings); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		// if no proposer settings ever existed in the db just save the settings
+		if err := db.SaveProposerSettings(cliCtx.Context, vpSettings); err != nil {
+			return nil, err
+		}
+	}
+	return vpSettings, nil
+}
+
+// proposerSettingsPayload reads the proposer settings from the file or URL set on the command line.
+func proposerSettingsPayload(ctx context.Context, cliCtx *cli.Context) (*validatorpb.ProposerSettingsPayload, error) {
+	var fileConfig *validatorpb.ProposerSettingsPayload
+	if cliCtx.IsSet(flags.ProposerSettingsFlag.Name) {
+		if err := unmarshalFromFile(ctx, cliCtx.String(flags.ProposerSettingsFlag.Name), &fileConfig); err != nil {
+			return nil, err
+		}
+	}
+	if cliCtx.IsSet(flags.ProposerSettingsURLFlag.Name) {
+		if err := unmarshalFromURL(ctx, cliCtx.String(flags.ProposerSettingsURLFlag.Name), &fileConfig); err != nil {
+			return nil, err
+		}
+	}
+	return fileConfig, nil
+}
+
+// proposerSettingsFromPayload validates the proposer settings read from the command line, file or URL
+// and converts them for internal use, filling in the builder settings from the command line if needed.
+func proposerSettingsFromPayload(cliCtx *cli.Context, fileConfig *validatorpb.ProposerSettingsPayload) (*validatorServiceConfig.ProposerSettings, error) {
 	// convert file config to proposer config for internal use
 	vpSettings := &validatorServiceConfig.ProposerSettings{}
 
 	// default fileConfig is mandatory
-	if fileConfig.DefaultConfig == nil {
+	if fileConfig == nil || fileConfig.DefaultConfig == nil {
 		return nil, errors.New("default fileConfig is required, proposer settings file is either empty or an incorrect format")
 	}
 	if !common.IsHexAddress(fileConfig.DefaultConfig.FeeRecipient) {
 		return nil, errors.New("default fileConfig fee recipient is not a valid eth1 address")
 	}
-	psExists, err := db.ProposerSettingsExists(cliCtx.Context)
-	if err != nil {
-		return nil, err
-	}
 	if err := warnNonChecksummedAddress(fileConfig.DefaultConfig.FeeRecipient); err != nil {
 		return nil, err
 	}
@@ -568,13 +635,6 @@ func proposerSettings(cliCtx *cli.Context, db iface.ValidatorDB) (*validatorServ
 		vpSettings.DefaultConfig.BuilderConfig.GasLimit = reviewGasLimit(vpSettings.DefaultConfig.BuilderConfig.GasLimit)
 	}
 
-	if psExists {
-		// if settings exist update the default
-		if err := db.UpdateProposerSettingsDefault(cliCtx.Context, vpSettings.DefaultConfig); err != nil {
-			return nil, err
-		}
-	}
-
 	if fileConfig.ProposerConfig != nil {
 		vpSettings.ProposeConfig = make(map[[fieldparams.BLSPubkeyLength]byte]*validatorServiceConfig.ProposerOption)
 		for key, option := range fileConfig.ProposerConfig {
@@ -612,20 +672,23 @@ func proposerSettings(cliCtx *cli.Context, db iface.ValidatorDB) (*validatorServ
 			pubkeyB := bytesutil.ToBytes48(decodedKey)
 			vpSettings.ProposeConfig[pubkeyB] = o
 		}
-		if psExists {
-			// override the existing saved settings if providing values via fileConfig.ProposerConfig
-			if err := db.SaveProposerSettings(cliCtx.Context, vpSettings); err != nil {
-				return nil, err
-			}
-		}
 	}
-	if !psExists {
-		// if no proposer settings ever existed in the db just save the settings
-		if err := db.SaveProposerSettings(cliCtx.Context, vpSettings); err != nil {
+	return vpSettings, nil
+}
+
+// proposerSettingsLoader returns a loader reading the proposer settings again from the file or URL
+// set on the command line, or nil when the proposer settings are not read from a file or URL.
+func proposerSettingsLoader(cliCtx *cli.Context) client.ProposerSettingsLoader {
+	if !cliCtx.IsSet(flags.ProposerSettingsFlag.Name) && !cliCtx.IsSet(flags.ProposerSettingsURLFlag.Name) {
+		return nil
+	}
+	return func(ctx context.Context) (*validatorServiceConfig.ProposerSettings, error) {
+		fileConfig, err := proposerSettingsPayload(ctx, cliCtx)
+		if err != nil {
 			return nil, err
 		}
+		return proposerSettingsFromPayload(cliCtx, fileConfig)
 	}
-	return vpSettings, nil
 }
 
 func BuilderSettingsFromFlags(cliCtx *cli.Context) (*validatorServiceConfig.BuilderConfig, error) {
@@ -783,6 +846,15 @@ func (c *ValidatorClient) registerRPCGatewayService(cliCtx *cli.Context) error {
 		}
 	}
 
+	var rpcServer *rpc.Server
+	if err := c.services.FetchService(&rpcServer); err != nil {
+		return err
+	}
+	router := mux.NewRouter()
+	// The performance history is served over plain HTTP rather than through the gRPC gateway.
+	// It is registered before the protobuf handlers so that it takes precedence over the "/v2/" prefix.
+	router.HandleFunc("/v2/validator/performance-history", rpcServer.PerformanceHistory)
+
 	// remove "/accounts/", "/v2/" after WebUI DEPRECATED
 	pbHandler := &gateway.PbMux{
 		Registrations: registrations,
@@ -790,7 +862,7 @@ func (c *ValidatorClient) registerRPCGatewayService(cliCtx *cli.Context) error {
 		Mux:           gwmux,
 	}
 	opts := []gateway.Option{
-		gateway.WithRouter(mux.NewRouter()),
+		gateway.WithRouter(router),
 		gateway.WithRemoteAddr(rpcAddr),
 		gateway.WithGatewayAddr(gatewayAddress),
 		gateway.WithMaxCallRecvMsgSize(maxCallSize),
@@ -15,6 +15,7 @@ import (
 	validatorpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/v4/validator/client"
 	beaconChainClientFactory "github.com/prysmaticlabs/prysm/v4/validator/client/beacon-chain-client-factory"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/failover"
 	nodeClientFactory "github.com/prysmaticlabs/prysm/v4/validator/client/node-client-factory"
 	validatorClientFactory "github.com/prysmaticlabs/prysm/v4/validator/client/validator-client-factory"
 	validatorHelpers "github.com/prysmaticlabs/prysm/v4/validator/helpers"
@@ -22,8 +23,19 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-// Initialize a client connect to a beacon node gRPC endpoint.
+// Initialize a client connect to a beacon node gRPC endpoint. When the validator client fails over
+// between several beacon nodes, the web UI uses the same beacon nodes, through the same failover.
 func (s *Server) registerBeaconClient() error {
+	if s.validatorService != nil {
+		if pool := s.validatorService.BeaconNodePool(); pool != nil {
+			s.beaconNodeHealthClient = failover.NewHealthClient(pool)
+			s.beaconChainClient = failover.NewBeaconChainClient(pool)
+			s.beaconNodeClient = failover.NewNodeClient(pool)
+			s.beaconNodeValidatorClient = failover.NewValidatorClient(pool)
+			return nil
+		}
+	}
+
 	streamInterceptor := grpc.WithStreamInterceptor(middleware.ChainStreamClient(
 		grpcopentracing.StreamClientInterceptor(),
 		grpcprometheus.StreamClientInterceptor,
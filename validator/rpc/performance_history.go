@@ -0,0 +1,156 @@
+package rpc
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+)
+
+// PerformanceHistory is an HTTP handler returning the per-epoch performance history saved by
+// the validator client. The optional, repeatable "pubkey" query parameter restricts the response
+// to the given public keys, and the optional "start_epoch" and "end_epoch" query parameters
+// restrict it to an inclusive range of epochs.
+func (s *Server) PerformanceHistory(w http.ResponseWriter, r *http.Request) {
+	if err := s.authorizeHTTP(r); err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: err.Error(),
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+	if s.valDB == nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: "Validator database not found",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+
+	query := r.URL.Query()
+	startEpoch, ok := epochFromQuery(w, query.Get("start_epoch"), "start_epoch", 0)
+	if !ok {
+		return
+	}
+	endEpoch, ok := epochFromQuery(w, query.Get("end_epoch"), "end_epoch", math.MaxUint64)
+	if !ok {
+		return
+	}
+	if startEpoch > endEpoch {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("Start epoch %d is after end epoch %d", startEpoch, endEpoch),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	var pubKeys [][fieldparams.BLSPubkeyLength]byte
+	for _, rawPubKeys := range query["pubkey"] {
+		for _, rawPubKey := range strings.Split(rawPubKeys, ",") {
+			if rawPubKey == "" {
+				continue
+			}
+			pubKey, err := hexutil.Decode(rawPubKey)
+			if err != nil || len(pubKey) != fieldparams.BLSPubkeyLength {
+				network.WriteError(w, &network.DefaultErrorJson{
+					Message: fmt.Sprintf("%s is not a validator public key", rawPubKey),
+					Code:    http.StatusBadRequest,
+				})
+				return
+			}
+			pubKeys = append(pubKeys, bytesutil.ToBytes48(pubKey))
+		}
+	}
+	if len(pubKeys) == 0 {
+		var err error
+		pubKeys, err = s.valDB.PerformanceHistoryPublicKeys(r.Context())
+		if err != nil {
+			network.WriteError(w, &network.DefaultErrorJson{
+				Message: errors.Wrap(err, "could not get public keys with a performance history").Error(),
+				Code:    http.StatusInternalServerError,
+			})
+			return
+		}
+	}
+
+	resp := &PerformanceHistoryResponse{Data: make([]*ValidatorPerformanceHistory, len(pubKeys))}
+	for i, pubKey := range pubKeys {
+		records, err := s.valDB.PerformanceHistoryForPubKey(r.Context(), pubKey, startEpoch, endEpoch)
+		if err != nil {
+			network.WriteError(w, &network.DefaultErrorJson{
+				Message: errors.Wrapf(err, "could not get performance history of %#x", pubKey).Error(),
+				Code:    http.StatusInternalServerError,
+			})
+			return
+		}
+		history := &ValidatorPerformanceHistory{
+			Pubkey: hexutil.Encode(pubKey[:]),
+			Epochs: make([]*EpochPerformance, len(records)),
+		}
+		for j, record := range records {
+			history.Epochs[j] = epochPerformanceFromRecord(record)
+		}
+		resp.Data[i] = history
+	}
+	network.WriteJson(w, resp)
+}
+
+// authorizeHTTP checks the bearer token of an HTTP request in the same way
+// the JWT interceptor does for gRPC requests.
+func (s *Server) authorizeHTTP(r *http.Request) error {
+	authHeader := r.Header.Get("Authorization")
+	if !strings.Contains(authHeader, "Bearer ") {
+		return errors.New("Invalid auth header, needs Bearer {token}")
+	}
+	token := strings.Split(authHeader, "Bearer ")[1]
+	if _, err := jwt.Parse(token, s.validateJWT); err != nil {
+		return errors.Wrap(err, "could not parse JWT token")
+	}
+	return nil
+}
+
+func epochFromQuery(w http.ResponseWriter, raw, name string, defaultEpoch primitives.Epoch) (primitives.Epoch, bool) {
+	if raw == "" {
+		return defaultEpoch, true
+	}
+	epoch, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("Invalid %s: %s", name, raw),
+			Code:    http.StatusBadRequest,
+		})
+		return 0, false
+	}
+	return primitives.Epoch(epoch), true
+}
+
+func epochPerformanceFromRecord(record *kv.PerformanceRecord) *EpochPerformance {
+	performance := &EpochPerformance{
+		Epoch:                 strconv.FormatUint(uint64(record.Epoch), 10),
+		AttestationIncluded:   record.AttestationIncluded,
+		CorrectlyVotedSource:  record.CorrectlyVotedSource,
+		CorrectlyVotedTarget:  record.CorrectlyVotedTarget,
+		CorrectlyVotedHead:    record.CorrectlyVotedHead,
+		ProposedBlocks:        strconv.FormatUint(record.ProposedBlocks, 10),
+		SyncCommitteeMessages: strconv.FormatUint(record.SyncCommitteeMessages, 10),
+		BalanceBefore:         strconv.FormatUint(record.BalanceBefore, 10),
+		BalanceAfter:          strconv.FormatUint(record.BalanceAfter, 10),
+		BalanceChange:         strconv.FormatInt(int64(record.BalanceAfter)-int64(record.BalanceBefore), 10),
+		InactivityScore:       strconv.FormatUint(record.InactivityScore, 10),
+	}
+	if record.AttestationIncluded {
+		performance.InclusionSlot = strconv.FormatUint(uint64(record.InclusionSlot), 10)
+		performance.InclusionDistance = strconv.FormatUint(uint64(record.InclusionDistance), 10)
+	}
+	return performance
+}
@@ -0,0 +1,121 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+	dbtest "github.com/prysmaticlabs/prysm/v4/validator/db/testing"
+)
+
+func TestServer_PerformanceHistory(t *testing.T) {
+	ctx := context.Background()
+	validatorDB := dbtest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{})
+	pubKey1 := [fieldparams.BLSPubkeyLength]byte{1}
+	pubKey2 := [fieldparams.BLSPubkeyLength]byte{2}
+	require.NoError(t, validatorDB.SavePerformanceRecords(ctx, []*kv.PerformanceRecord{
+		{
+			PubKey:               pubKey1,
+			Epoch:                10,
+			AttestationIncluded:  true,
+			InclusionSlot:        322,
+			InclusionDistance:    2,
+			CorrectlyVotedSource: true,
+			ProposedBlocks:       1,
+			BalanceBefore:        32000000000,
+			BalanceAfter:         31999990000,
+		},
+		{PubKey: pubKey1, Epoch: 11},
+		{PubKey: pubKey2, Epoch: 11, SyncCommitteeMessages: 32},
+	}))
+	s := &Server{valDB: validatorDB, jwtSecret: []byte("testKey")}
+	token, err := createTokenString(s.jwtSecret)
+	require.NoError(t, err)
+
+	t.Run("all keys", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/v2/validator/performance-history", nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.PerformanceHistory(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &PerformanceHistoryResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, hexutil.Encode(pubKey1[:]), resp.Data[0].Pubkey)
+		require.Equal(t, 2, len(resp.Data[0].Epochs))
+		epoch := resp.Data[0].Epochs[0]
+		assert.Equal(t, "10", epoch.Epoch)
+		assert.Equal(t, true, epoch.AttestationIncluded)
+		assert.Equal(t, "322", epoch.InclusionSlot)
+		assert.Equal(t, "2", epoch.InclusionDistance)
+		assert.Equal(t, "", resp.Data[0].Epochs[1].InclusionDistance)
+		assert.Equal(t, true, epoch.CorrectlyVotedSource)
+		assert.Equal(t, false, epoch.CorrectlyVotedTarget)
+		assert.Equal(t, "1", epoch.ProposedBlocks)
+		assert.Equal(t, "-10000", epoch.BalanceChange)
+		assert.Equal(t, hexutil.Encode(pubKey2[:]), resp.Data[1].Pubkey)
+		require.Equal(t, 1, len(resp.Data[1].Epochs))
+		assert.Equal(t, "32", resp.Data[1].Epochs[0].SyncCommitteeMessages)
+	})
+	t.Run("filtered by key and epoch", func(t *testing.T) {
+		request := httptest.NewRequest(
+			http.MethodGet,
+			"http://example.com/v2/validator/performance-history?pubkey="+hexutil.Encode(pubKey1[:])+"&start_epoch=11",
+			nil,
+		)
+		request.Header.Set("Authorization", "Bearer "+token)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.PerformanceHistory(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &PerformanceHistoryResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		require.Equal(t, 1, len(resp.Data[0].Epochs))
+		assert.Equal(t, "11", resp.Data[0].Epochs[0].Epoch)
+	})
+	t.Run("invalid epoch range", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/v2/validator/performance-history?start_epoch=5&end_epoch=4", nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.PerformanceHistory(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Start epoch 5 is after end epoch 4", e.Message)
+	})
+	t.Run("invalid pubkey", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/v2/validator/performance-history?pubkey=0x01", nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.PerformanceHistory(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		badToken, err := createTokenString([]byte("badTestKey"))
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/v2/validator/performance-history", nil)
+		request.Header.Set("Authorization", "Bearer "+badToken)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.PerformanceHistory(writer, request)
+		require.Equal(t, http.StatusUnauthorized, writer.Code)
+	})
+}
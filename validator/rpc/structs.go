@@ -0,0 +1,26 @@
+package rpc
+
+type PerformanceHistoryResponse struct {
+	Data []*ValidatorPerformanceHistory `json:"data"`
+}
+
+type ValidatorPerformanceHistory struct {
+	Pubkey string              `json:"pubkey"`
+	Epochs []*EpochPerformance `json:"epochs"`
+}
+
+type EpochPerformance struct {
+	Epoch                 string `json:"epoch"`
+	AttestationIncluded   bool   `json:"attestation_included"`
+	InclusionSlot         string `json:"inclusion_slot,omitempty"`
+	InclusionDistance     string `json:"inclusion_distance,omitempty"`
+	CorrectlyVotedSource  bool   `json:"correctly_voted_source"`
+	CorrectlyVotedTarget  bool   `json:"correctly_voted_target"`
+	CorrectlyVotedHead    bool   `json:"correctly_voted_head"`
+	ProposedBlocks        string `json:"proposed_blocks"`
+	SyncCommitteeMessages string `json:"sync_committee_messages"`
+	BalanceBefore         string `json:"balance_before_epoch_transition"`
+	BalanceAfter          string `json:"balance_after_epoch_transition"`
+	BalanceChange         string `json:"balance_change"`
+	InactivityScore       string `json:"inactivity_score"`
+}
@@ -10,12 +10,42 @@ import (
 	"github.com/pkg/errors"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/monitoring/progress"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	"github.com/prysmaticlabs/prysm/v4/validator/db"
 	"github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-history/format"
 )
 
+// ExportFilter restricts the slashing protection data extracted by ExportFilteredStandardProtectionJSON.
+type ExportFilter struct {
+	// PublicKeys limits the export to the given validator public keys.
+	// All the public keys in the database are exported when empty.
+	PublicKeys [][]byte
+	// FromEpoch and ToEpoch limit the export to the blocks proposed and the attestations
+	// targeting an epoch within [FromEpoch, ToEpoch]. A zero ToEpoch means no upper bound.
+	// Dropping the most recent history is not safe when migrating keys, as the importing
+	// validator would not be protected against signing again what was filtered out.
+	FromEpoch primitives.Epoch
+	ToEpoch   primitives.Epoch
+	// Minimal only keeps, for every public key, the highest signed slot and a single attestation
+	// made of the highest signed source and target epochs, as described in the minimal
+	// interchange format of EIP-3076.
+	Minimal bool
+}
+
+func (f *ExportFilter) hasEpochRange() bool {
+	return f != nil && (f.FromEpoch > 0 || f.ToEpoch > 0)
+}
+
+func (f *ExportFilter) includesEpoch(epoch primitives.Epoch) bool {
+	if f == nil {
+		return true
+	}
+	return epoch >= f.FromEpoch && (f.ToEpoch == 0 || epoch <= f.ToEpoch)
+}
+
 // ExportStandardProtectionJSON extracts all slashing protection data from a validator database
 // and packages it into an EIP-3076 compliant, standard
 func ExportStandardProtectionJSON(
@@ -23,6 +53,23 @@ func ExportStandardProtectionJSON(
 	validatorDB db.Database,
 	filteredKeys ...[]byte,
 ) (*format.EIPSlashingProtectionFormat, error) {
+	return ExportFilteredStandardProtectionJSON(ctx, validatorDB, &ExportFilter{PublicKeys: filteredKeys})
+}
+
+// ExportFilteredStandardProtectionJSON extracts the slashing protection data matching the given filter
+// from a validator database and packages it into an EIP-3076 compliant, standard JSON.
+func ExportFilteredStandardProtectionJSON(
+	ctx context.Context,
+	validatorDB db.Database,
+	filter *ExportFilter,
+) (*format.EIPSlashingProtectionFormat, error) {
+	if filter == nil {
+		filter = &ExportFilter{}
+	}
+	if filter.ToEpoch > 0 && filter.ToEpoch < filter.FromEpoch {
+		return nil, fmt.Errorf("epoch range upper bound %d is lower than lower bound %d", filter.ToEpoch, filter.FromEpoch)
+	}
+	filteredKeys := filter.PublicKeys
 	interchangeJSON := &format.EIPSlashingProtectionFormat{}
 	genesisValidatorsRoot, err := validatorDB.GenesisValidatorsRoot(ctx)
 	if err != nil {
@@ -69,7 +116,7 @@ func ExportStandardProtectionJSON(
 		if err != nil {
 			return nil, errors.Wrap(err, "could not convert public key to hex string")
 		}
-		signedBlocks, err := signedBlocksByPubKey(ctx, validatorDB, pubKey)
+		signedBlocks, err := signedBlocksByPubKey(ctx, validatorDB, pubKey, filter)
 		if err != nil {
 			return nil, errors.Wrapf(err, "could not retrieve signed blocks for public key %s", pubKeyHex)
 		}
@@ -95,7 +142,7 @@ func ExportStandardProtectionJSON(
 		if err != nil {
 			return nil, errors.Wrap(err, "could not convert public key to hex string")
 		}
-		signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKey)
+		signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKey, filter)
 		if err != nil {
 			return nil, errors.Wrapf(err, "could not retrieve signed attestations for public key %s", pubKeyHex)
 		}
@@ -122,6 +169,21 @@ func ExportStandardProtectionJSON(
 		if item.SignedBlocks == nil {
 			item.SignedBlocks = make([]*format.SignedBlock, 0)
 		}
+		// Keys without any history within the requested epoch range are left out.
+		if filter.hasEpochRange() && len(item.SignedAttestations) == 0 && len(item.SignedBlocks) == 0 {
+			continue
+		}
+		if filter.Minimal {
+			var err error
+			item.SignedBlocks, err = minimalSignedBlocks(item.SignedBlocks)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not compute minimal signed blocks for public key %s", item.Pubkey)
+			}
+			item.SignedAttestations, err = minimalSignedAttestations(item.SignedAttestations)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not compute minimal signed attestations for public key %s", item.Pubkey)
+			}
+		}
 		dataList = append(dataList, item)
 	}
 	sort.Slice(dataList, func(i, j int) bool {
@@ -131,7 +193,7 @@ func ExportStandardProtectionJSON(
 	return interchangeJSON, nil
 }
 
-func signedAttestationsByPubKey(ctx context.Context, validatorDB db.Database, pubKey [fieldparams.BLSPubkeyLength]byte) ([]*format.SignedAttestation, error) {
+func signedAttestationsByPubKey(ctx context.Context, validatorDB db.Database, pubKey [fieldparams.BLSPubkeyLength]byte, filter *ExportFilter) ([]*format.SignedAttestation, error) {
 	// If a key does not have an attestation history in our database, we return nil.
 	// This way, a user will be able to export their slashing protection history
 	// even if one of their keys does not have a history of signed attestations.
@@ -157,6 +219,9 @@ func signedAttestationsByPubKey(ctx context.Context, validatorDB db.Database, pu
 				continue
 			}
 		}
+		if !filter.includesEpoch(att.Target) {
+			continue
+		}
 		var root string
 		if !bytes.Equal(att.SigningRoot[:], params.BeaconConfig().ZeroHash[:]) {
 			root, err = rootToHexString(att.SigningRoot[:])
@@ -173,7 +238,7 @@ func signedAttestationsByPubKey(ctx context.Context, validatorDB db.Database, pu
 	return signedAttestations, nil
 }
 
-func signedBlocksByPubKey(ctx context.Context, validatorDB db.Database, pubKey [fieldparams.BLSPubkeyLength]byte) ([]*format.SignedBlock, error) {
+func signedBlocksByPubKey(ctx context.Context, validatorDB db.Database, pubKey [fieldparams.BLSPubkeyLength]byte, filter *ExportFilter) ([]*format.SignedBlock, error) {
 	// If a key does not have a lowest or highest signed proposal history
 	// in our database, we return nil. This way, a user will be able to export their
 	// slashing protection history even if one of their keys does not have a history
@@ -187,6 +252,9 @@ func signedBlocksByPubKey(ctx context.Context, validatorDB db.Database, pubKey [
 		if ctx.Err() != nil {
 			return nil, errors.Wrap(err, "context canceled")
 		}
+		if !filter.includesEpoch(slots.ToEpoch(proposal.Slot)) {
+			continue
+		}
 		signingRootHex, err := rootToHexString(proposal.SigningRoot)
 		if err != nil {
 			return nil, errors.Wrap(err, "could not convert signing root to hex string")
@@ -37,7 +37,7 @@ func Test_getSignedAttestationsByPubKey(t *testing.T) {
 		validatorDB := dbtest.SetupDB(t, pubKeys)
 
 		// No attestation history stored should return empty.
-		signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0])
+		signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0], nil)
 		require.NoError(t, err)
 		assert.Equal(t, 0, len(signedAttestations))
 
@@ -55,7 +55,7 @@ func Test_getSignedAttestationsByPubKey(t *testing.T) {
 		)))
 
 		// We then retrieve the signed attestations and expect a correct result.
-		signedAttestations, err = signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0])
+		signedAttestations, err = signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0], nil)
 		require.NoError(t, err)
 
 		wanted := []*format.SignedAttestation{
@@ -80,7 +80,7 @@ func Test_getSignedAttestationsByPubKey(t *testing.T) {
 		validatorDB := dbtest.SetupDB(t, pubKeys)
 
 		// No attestation history stored should return empty.
-		signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0])
+		signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0], nil)
 		require.NoError(t, err)
 		assert.Equal(t, 0, len(signedAttestations))
 
@@ -102,7 +102,7 @@ func Test_getSignedAttestationsByPubKey(t *testing.T) {
 
 		// We then retrieve the signed attestations and expect to have
 		// skipped the 0th, corrupted entry.
-		signedAttestations, err = signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0])
+		signedAttestations, err = signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0], nil)
 		require.NoError(t, err)
 
 		wanted := []*format.SignedAttestation{
@@ -122,7 +122,7 @@ func Test_getSignedAttestationsByPubKey(t *testing.T) {
 		validatorDB := dbtest.SetupDB(t, pubKeys)
 
 		// No attestation history stored should return empty.
-		signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0])
+		signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0], nil)
 		require.NoError(t, err)
 		assert.Equal(t, 0, len(signedAttestations))
 
@@ -144,7 +144,7 @@ func Test_getSignedAttestationsByPubKey(t *testing.T) {
 
 		// We then retrieve the signed attestations and do not expect changes
 		// as the bug only manifests in the genesis epoch.
-		signedAttestations, err = signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0])
+		signedAttestations, err = signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0], nil)
 		require.NoError(t, err)
 
 		wanted := []*format.SignedAttestation{
@@ -171,7 +171,7 @@ func Test_getSignedBlocksByPubKey(t *testing.T) {
 	validatorDB := dbtest.SetupDB(t, pubKeys)
 
 	// No highest and/or lowest signed blocks will return empty.
-	signedBlocks, err := signedBlocksByPubKey(ctx, validatorDB, pubKeys[0])
+	signedBlocks, err := signedBlocksByPubKey(ctx, validatorDB, pubKeys[0], nil)
 	require.NoError(t, err)
 	assert.Equal(t, 0, len(signedBlocks))
 
@@ -191,7 +191,7 @@ func Test_getSignedBlocksByPubKey(t *testing.T) {
 
 	// We expect a valid proposal history containing slot 1 and slot 5 only
 	// when we attempt to retrieve it from disk.
-	signedBlocks, err = signedBlocksByPubKey(ctx, validatorDB, pubKeys[0])
+	signedBlocks, err = signedBlocksByPubKey(ctx, validatorDB, pubKeys[0], nil)
 	require.NoError(t, err)
 	wanted := []*format.SignedBlock{
 		{
@@ -211,3 +211,54 @@ func Test_getSignedBlocksByPubKey(t *testing.T) {
 		assert.DeepEqual(t, blk, signedBlocks[i])
 	}
 }
+
+func TestExportFilteredStandardProtectionJSON(t *testing.T) {
+	ctx := context.Background()
+	pubKeys := [][fieldparams.BLSPubkeyLength]byte{
+		{1},
+		{2},
+	}
+	validatorDB := dbtest.SetupDB(t, pubKeys)
+	genesisValidatorsRoot := [32]byte{1}
+	require.NoError(t, validatorDB.SaveGenesisValidatorsRoot(ctx, genesisValidatorsRoot[:]))
+	for _, pubKey := range pubKeys {
+		for _, slot := range []primitives.Slot{1, 64, 128} {
+			require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, pubKey, slot, []byte{byte(slot)}))
+		}
+		for target := primitives.Epoch(1); target <= 5; target++ {
+			require.NoError(t, validatorDB.SaveAttestationForPubKey(ctx, pubKey, [32]byte{byte(target)}, createAttestation(
+				target-1,
+				target,
+			)))
+		}
+	}
+
+	t.Run("public keys and epoch range", func(t *testing.T) {
+		exported, err := ExportFilteredStandardProtectionJSON(ctx, validatorDB, &ExportFilter{
+			PublicKeys: [][]byte{pubKeys[1][:]},
+			FromEpoch:  2,
+			ToEpoch:    3,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(exported.Data))
+		assert.Equal(t, fmt.Sprintf("%#x", pubKeys[1]), exported.Data[0].Pubkey)
+		require.Equal(t, 1, len(exported.Data[0].SignedBlocks))
+		assert.Equal(t, "64", exported.Data[0].SignedBlocks[0].Slot)
+		require.Equal(t, 2, len(exported.Data[0].SignedAttestations))
+		assert.Equal(t, "2", exported.Data[0].SignedAttestations[0].TargetEpoch)
+		assert.Equal(t, "3", exported.Data[0].SignedAttestations[1].TargetEpoch)
+	})
+	t.Run("minimal", func(t *testing.T) {
+		exported, err := ExportFilteredStandardProtectionJSON(ctx, validatorDB, &ExportFilter{Minimal: true})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(exported.Data))
+		for _, d := range exported.Data {
+			assert.DeepEqual(t, []*format.SignedBlock{{Slot: "128"}}, d.SignedBlocks)
+			assert.DeepEqual(t, []*format.SignedAttestation{{SourceEpoch: "4", TargetEpoch: "5"}}, d.SignedAttestations)
+		}
+	})
+	t.Run("invalid epoch range", func(t *testing.T) {
+		_, err := ExportFilteredStandardProtectionJSON(ctx, validatorDB, &ExportFilter{FromEpoch: 4, ToEpoch: 2})
+		require.ErrorContains(t, "epoch range upper bound 2 is lower than lower bound 4", err)
+	})
+}
@@ -0,0 +1,293 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-history/format"
+)
+
+// MergeConflict describes slashable data found in the merged history of a public key.
+type MergeConflict struct {
+	Pubkey string
+	Reason string
+}
+
+// MergeStandardProtectionJSON merges several EIP-3076 slashing protection files into a single one.
+// All the files must use the supported interchange format version and the same genesis validators root.
+// Duplicate entries are removed. When the merged history of a public key is slashable, for instance
+// because two files hold different signing roots for the same slot or surrounding attestations, the
+// conflict is reported and the history of that key is reduced to its minimal form: the highest signed
+// slot and the highest signed source and target epochs. The merged file is therefore free of slashable
+// data while still protecting every key against anything it signed in any of the files.
+func MergeStandardProtectionJSON(
+	files ...*format.EIPSlashingProtectionFormat,
+) (*format.EIPSlashingProtectionFormat, []*MergeConflict, error) {
+	if len(files) == 0 {
+		return nil, nil, errors.New("no slashing protection files to merge")
+	}
+	var genesisValidatorsRoot [32]byte
+	data := make([]*format.ProtectionData, 0)
+	for i, f := range files {
+		if f == nil {
+			return nil, nil, fmt.Errorf("slashing protection file %d is empty", i)
+		}
+		if f.Metadata.InterchangeFormatVersion != format.InterchangeFormatVersion {
+			return nil, nil, fmt.Errorf(
+				"slashing protection file %d has version '%s', wanted '%s'",
+				i,
+				f.Metadata.InterchangeFormatVersion,
+				format.InterchangeFormatVersion,
+			)
+		}
+		gvr, err := RootFromHex(f.Metadata.GenesisValidatorsRoot)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s is not a valid root: %w", f.Metadata.GenesisValidatorsRoot, err)
+		}
+		if i == 0 {
+			genesisValidatorsRoot = gvr
+		} else if gvr != genesisValidatorsRoot {
+			return nil, nil, fmt.Errorf(
+				"slashing protection file %d has genesis validators root %#x, wanted %#x",
+				i,
+				gvr,
+				genesisValidatorsRoot,
+			)
+		}
+		data = append(data, f.Data...)
+	}
+
+	signedBlocksByPubKey, err := parseBlocksForUniquePublicKeys(data)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not parse unique entries for blocks by public key")
+	}
+	signedAttsByPubKey, err := parseAttestationsForUniquePublicKeys(data)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not parse unique entries for attestations by public key")
+	}
+	pubKeys := make(map[[fieldparams.BLSPubkeyLength]byte]bool)
+	for _, d := range data {
+		pubKey, err := PubKeyFromHex(d.Pubkey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s is not a valid public key: %w", d.Pubkey, err)
+		}
+		pubKeys[pubKey] = true
+	}
+
+	merged := &format.EIPSlashingProtectionFormat{}
+	merged.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	merged.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", genesisValidatorsRoot)
+	merged.Data = make([]*format.ProtectionData, 0, len(pubKeys))
+	conflicts := make([]*MergeConflict, 0)
+	for pubKey := range pubKeys {
+		pubKeyHex := fmt.Sprintf("%#x", pubKey)
+		signedBlocks, blockConflict, err := mergeSignedBlocks(signedBlocksByPubKey[pubKey])
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not merge signed blocks for public key %s", pubKeyHex)
+		}
+		signedAtts, attConflict, err := mergeSignedAttestations(pubKey, signedAttsByPubKey[pubKey])
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not merge signed attestations for public key %s", pubKeyHex)
+		}
+		for _, reason := range []string{blockConflict, attConflict} {
+			if reason != "" {
+				conflicts = append(conflicts, &MergeConflict{Pubkey: pubKeyHex, Reason: reason})
+			}
+		}
+		if blockConflict != "" || attConflict != "" {
+			signedBlocks, err = minimalSignedBlocks(signedBlocks)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "could not compute minimal signed blocks for public key %s", pubKeyHex)
+			}
+			signedAtts, err = minimalSignedAttestations(signedAtts)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "could not compute minimal signed attestations for public key %s", pubKeyHex)
+			}
+		}
+		merged.Data = append(merged.Data, &format.ProtectionData{
+			Pubkey:             pubKeyHex,
+			SignedBlocks:       signedBlocks,
+			SignedAttestations: signedAtts,
+		})
+	}
+	sort.Slice(merged.Data, func(i, j int) bool {
+		return strings.Compare(merged.Data[i].Pubkey, merged.Data[j].Pubkey) < 0
+	})
+	sort.Slice(conflicts, func(i, j int) bool {
+		return strings.Compare(conflicts[i].Pubkey, conflicts[j].Pubkey) < 0
+	})
+	return merged, conflicts, nil
+}
+
+// mergeSignedBlocks removes duplicate blocks and sorts them by slot. It returns
+// a non-empty reason when two different blocks were signed for the same slot.
+func mergeSignedBlocks(signedBlocks []*format.SignedBlock) ([]*format.SignedBlock, string, error) {
+	history, err := transformSignedBlocks(context.Background(), signedBlocks)
+	if err != nil {
+		return nil, "", err
+	}
+	var conflict string
+	signingRootsBySlot := make(map[primitives.Slot][32]byte)
+	for _, proposal := range history.Proposals {
+		signingRoot := bytesToRoot(proposal.SigningRoot)
+		if existing, ok := signingRootsBySlot[proposal.Slot]; ok {
+			if existing != signingRoot && conflict == "" {
+				conflict = fmt.Sprintf("different blocks signed at slot %d", proposal.Slot)
+			}
+			continue
+		}
+		signingRootsBySlot[proposal.Slot] = signingRoot
+	}
+	proposalSlots := make([]primitives.Slot, 0, len(signingRootsBySlot))
+	for slot := range signingRootsBySlot {
+		proposalSlots = append(proposalSlots, slot)
+	}
+	sort.Slice(proposalSlots, func(i, j int) bool {
+		return proposalSlots[i] < proposalSlots[j]
+	})
+	merged := make([]*format.SignedBlock, len(proposalSlots))
+	for i, slot := range proposalSlots {
+		signingRoot := signingRootsBySlot[slot]
+		merged[i] = &format.SignedBlock{
+			Slot:        fmt.Sprintf("%d", slot),
+			SigningRoot: optionalRootToHexString(signingRoot),
+		}
+	}
+	return merged, conflict, nil
+}
+
+// mergeSignedAttestations removes duplicate attestations and sorts them by target and source epochs.
+// It returns a non-empty reason when the attestations contain a double vote or a surround vote.
+func mergeSignedAttestations(
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	signedAtts []*format.SignedAttestation,
+) ([]*format.SignedAttestation, string, error) {
+	history, err := transformSignedAttestations(pubKey, signedAtts)
+	if err != nil {
+		return nil, "", err
+	}
+	var conflict string
+	unique := make([]*kv.AttestationRecord, 0, len(history))
+	signingRootsByTarget := make(map[primitives.Epoch]*kv.AttestationRecord)
+	for _, att := range history {
+		if existing, ok := signingRootsByTarget[att.Target]; ok {
+			if (existing.Source != att.Source || existing.SigningRoot != att.SigningRoot) && conflict == "" {
+				conflict = fmt.Sprintf("different attestations signed for target epoch %d", att.Target)
+			}
+			continue
+		}
+		signingRootsByTarget[att.Target] = att
+		unique = append(unique, att)
+	}
+	sort.Slice(unique, func(i, j int) bool {
+		if unique[i].Source != unique[j].Source {
+			return unique[i].Source < unique[j].Source
+		}
+		return unique[i].Target < unique[j].Target
+	})
+	// An attestation is surrounded by another one with a strictly lower source and a strictly
+	// higher target. Walking the attestations by increasing source, it is enough to compare every
+	// target with the highest target of the attestations seen with a lower source.
+	var highestTarget primitives.Epoch
+	for i := 0; i < len(unique) && conflict == ""; {
+		j := i
+		for ; j < len(unique) && unique[j].Source == unique[i].Source; j++ {
+			if i > 0 && unique[j].Target < highestTarget {
+				conflict = fmt.Sprintf(
+					"attestation with source %d and target %d is surrounded by another one",
+					unique[j].Source,
+					unique[j].Target,
+				)
+				break
+			}
+		}
+		for ; i < j; i++ {
+			if unique[i].Target > highestTarget {
+				highestTarget = unique[i].Target
+			}
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool {
+		if unique[i].Target != unique[j].Target {
+			return unique[i].Target < unique[j].Target
+		}
+		return unique[i].Source < unique[j].Source
+	})
+	merged := make([]*format.SignedAttestation, len(unique))
+	for i, att := range unique {
+		merged[i] = &format.SignedAttestation{
+			SourceEpoch: fmt.Sprintf("%d", att.Source),
+			TargetEpoch: fmt.Sprintf("%d", att.Target),
+			SigningRoot: optionalRootToHexString(att.SigningRoot),
+		}
+	}
+	return merged, conflict, nil
+}
+
+// minimalSignedBlocks reduces signed blocks to a single block at the highest signed slot.
+func minimalSignedBlocks(signedBlocks []*format.SignedBlock) ([]*format.SignedBlock, error) {
+	if len(signedBlocks) == 0 {
+		return make([]*format.SignedBlock, 0), nil
+	}
+	var highestSlot primitives.Slot
+	for _, blk := range signedBlocks {
+		slot, err := SlotFromString(blk.Slot)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid slot: %w", blk.Slot, err)
+		}
+		if slot > highestSlot {
+			highestSlot = slot
+		}
+	}
+	return []*format.SignedBlock{{Slot: fmt.Sprintf("%d", highestSlot)}}, nil
+}
+
+// minimalSignedAttestations reduces signed attestations to a single attestation
+// made of the highest signed source epoch and the highest signed target epoch.
+func minimalSignedAttestations(signedAtts []*format.SignedAttestation) ([]*format.SignedAttestation, error) {
+	if len(signedAtts) == 0 {
+		return make([]*format.SignedAttestation, 0), nil
+	}
+	var highestSource, highestTarget primitives.Epoch
+	for _, att := range signedAtts {
+		source, err := EpochFromString(att.SourceEpoch)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid epoch: %w", att.SourceEpoch, err)
+		}
+		target, err := EpochFromString(att.TargetEpoch)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid epoch: %w", att.TargetEpoch, err)
+		}
+		if source > highestSource {
+			highestSource = source
+		}
+		if target > highestTarget {
+			highestTarget = target
+		}
+	}
+	return []*format.SignedAttestation{{
+		SourceEpoch: fmt.Sprintf("%d", highestSource),
+		TargetEpoch: fmt.Sprintf("%d", highestTarget),
+	}}, nil
+}
+
+func bytesToRoot(b []byte) [32]byte {
+	var root [32]byte
+	copy(root[:], b)
+	return root
+}
+
+// optionalRootToHexString leaves out zero signing roots, which stand for
+// the signing roots missing from the original files.
+func optionalRootToHexString(root [32]byte) string {
+	if root == [32]byte{} {
+		return ""
+	}
+	return fmt.Sprintf("%#x", root)
+}
@@ -0,0 +1,143 @@
+package history
+
+import (
+	"fmt"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-history/format"
+)
+
+func interchangeFile(gvr [32]byte, data ...*format.ProtectionData) *format.EIPSlashingProtectionFormat {
+	f := &format.EIPSlashingProtectionFormat{Data: data}
+	f.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	f.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", gvr)
+	return f
+}
+
+func TestMergeStandardProtectionJSON(t *testing.T) {
+	gvr := [32]byte{1}
+	pubKey1 := fmt.Sprintf("%#x", [fieldparams.BLSPubkeyLength]byte{1})
+	pubKey2 := fmt.Sprintf("%#x", [fieldparams.BLSPubkeyLength]byte{2})
+	root1 := fmt.Sprintf("%#x", [32]byte{1})
+	root2 := fmt.Sprintf("%#x", [32]byte{2})
+
+	first := interchangeFile(gvr, &format.ProtectionData{
+		Pubkey:       pubKey1,
+		SignedBlocks: []*format.SignedBlock{{Slot: "2", SigningRoot: root1}, {Slot: "1", SigningRoot: root2}},
+		SignedAttestations: []*format.SignedAttestation{
+			{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: root1},
+		},
+	})
+	second := interchangeFile(gvr, &format.ProtectionData{
+		Pubkey:       pubKey1,
+		SignedBlocks: []*format.SignedBlock{{Slot: "2", SigningRoot: root1}},
+		SignedAttestations: []*format.SignedAttestation{
+			{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: root1},
+			{SourceEpoch: "2", TargetEpoch: "3", SigningRoot: root2},
+		},
+	}, &format.ProtectionData{
+		Pubkey:             pubKey2,
+		SignedBlocks:       []*format.SignedBlock{{Slot: "5"}},
+		SignedAttestations: []*format.SignedAttestation{},
+	})
+
+	merged, conflicts, err := MergeStandardProtectionJSON(first, second)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(conflicts))
+	assert.Equal(t, fmt.Sprintf("%#x", gvr), merged.Metadata.GenesisValidatorsRoot)
+	want := []*format.ProtectionData{
+		{
+			Pubkey:       pubKey1,
+			SignedBlocks: []*format.SignedBlock{{Slot: "1", SigningRoot: root2}, {Slot: "2", SigningRoot: root1}},
+			SignedAttestations: []*format.SignedAttestation{
+				{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: root1},
+				{SourceEpoch: "2", TargetEpoch: "3", SigningRoot: root2},
+			},
+		},
+		{
+			Pubkey:             pubKey2,
+			SignedBlocks:       []*format.SignedBlock{{Slot: "5"}},
+			SignedAttestations: []*format.SignedAttestation{},
+		},
+	}
+	assert.DeepEqual(t, want, merged.Data)
+}
+
+func TestMergeStandardProtectionJSON_Conflicts(t *testing.T) {
+	gvr := [32]byte{1}
+	pubKey1 := fmt.Sprintf("%#x", [fieldparams.BLSPubkeyLength]byte{1})
+	pubKey2 := fmt.Sprintf("%#x", [fieldparams.BLSPubkeyLength]byte{2})
+	pubKey3 := fmt.Sprintf("%#x", [fieldparams.BLSPubkeyLength]byte{3})
+	root1 := fmt.Sprintf("%#x", [32]byte{1})
+	root2 := fmt.Sprintf("%#x", [32]byte{2})
+
+	first := interchangeFile(gvr,
+		&format.ProtectionData{
+			Pubkey:       pubKey1,
+			SignedBlocks: []*format.SignedBlock{{Slot: "10", SigningRoot: root1}, {Slot: "3", SigningRoot: root1}},
+		},
+		&format.ProtectionData{
+			Pubkey:             pubKey2,
+			SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "1", TargetEpoch: "5", SigningRoot: root1}},
+		},
+		&format.ProtectionData{
+			Pubkey:             pubKey3,
+			SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "1", TargetEpoch: "5", SigningRoot: root1}},
+		},
+	)
+	second := interchangeFile(gvr,
+		// Double proposal.
+		&format.ProtectionData{
+			Pubkey:       pubKey1,
+			SignedBlocks: []*format.SignedBlock{{Slot: "10", SigningRoot: root2}},
+		},
+		// Double vote.
+		&format.ProtectionData{
+			Pubkey:             pubKey2,
+			SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "1", TargetEpoch: "5", SigningRoot: root2}},
+		},
+		// Surround vote.
+		&format.ProtectionData{
+			Pubkey: pubKey3,
+			SignedAttestations: []*format.SignedAttestation{
+				{SourceEpoch: "2", TargetEpoch: "3", SigningRoot: root2},
+				{SourceEpoch: "3", TargetEpoch: "6", SigningRoot: root2},
+			},
+		},
+	)
+
+	merged, conflicts, err := MergeStandardProtectionJSON(first, second)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(conflicts))
+	assert.Equal(t, pubKey1, conflicts[0].Pubkey)
+	assert.Equal(t, "different blocks signed at slot 10", conflicts[0].Reason)
+	assert.Equal(t, pubKey2, conflicts[1].Pubkey)
+	assert.Equal(t, "different attestations signed for target epoch 5", conflicts[1].Reason)
+	assert.Equal(t, pubKey3, conflicts[2].Pubkey)
+	assert.Equal(t, "attestation with source 2 and target 3 is surrounded by another one", conflicts[2].Reason)
+
+	// Conflicting histories are reduced to their minimal form.
+	require.Equal(t, 3, len(merged.Data))
+	assert.DeepEqual(t, []*format.SignedBlock{{Slot: "10"}}, merged.Data[0].SignedBlocks)
+	assert.DeepEqual(t, []*format.SignedAttestation{}, merged.Data[0].SignedAttestations)
+	assert.DeepEqual(t, []*format.SignedAttestation{{SourceEpoch: "1", TargetEpoch: "5"}}, merged.Data[1].SignedAttestations)
+	assert.DeepEqual(t, []*format.SignedAttestation{{SourceEpoch: "3", TargetEpoch: "6"}}, merged.Data[2].SignedAttestations)
+}
+
+func TestMergeStandardProtectionJSON_BadMetadata(t *testing.T) {
+	_, _, err := MergeStandardProtectionJSON()
+	require.ErrorContains(t, "no slashing protection files to merge", err)
+
+	first := interchangeFile([32]byte{1})
+	second := interchangeFile([32]byte{2})
+	_, _, err = MergeStandardProtectionJSON(first, second)
+	require.ErrorContains(t, "slashing protection file 1 has genesis validators root", err)
+
+	second = interchangeFile([32]byte{1})
+	second.Metadata.InterchangeFormatVersion = "4"
+	_, _, err = MergeStandardProtectionJSON(first, second)
+	require.ErrorContains(t, "slashing protection file 1 has version '4', wanted '5'", err)
+}